@@ -26,15 +26,21 @@ type Applier interface {
 	Apply(ctx context.Context, authInfo authorization.Info, spaceGUID string, appInfo payloads.ManifestApplication, appState manifest.AppState) error
 }
 
+//counterfeiter:generate -o fake -fake-name Differ . Differ
+type Differ interface {
+	Diff(appInfo payloads.ManifestApplication, appState manifest.AppState) []manifest.Diff
+}
+
 type Manifest struct {
 	domainRepo        shared.CFDomainRepository
 	defaultDomainName string
 	stateCollector    StateCollector
 	normalizer        Normalizer
 	applier           Applier
+	differ            Differ
 }
 
-func NewManifest(domainRepo shared.CFDomainRepository, defaultDomainName string, stateCollector StateCollector, normalizer Normalizer, applier Applier,
+func NewManifest(domainRepo shared.CFDomainRepository, defaultDomainName string, stateCollector StateCollector, normalizer Normalizer, applier Applier, differ Differ,
 ) *Manifest {
 	return &Manifest{
 		domainRepo:        domainRepo,
@@ -42,6 +48,7 @@ func NewManifest(domainRepo shared.CFDomainRepository, defaultDomainName string,
 		stateCollector:    stateCollector,
 		normalizer:        normalizer,
 		applier:           applier,
+		differ:            differ,
 	}
 }
 
@@ -66,6 +73,24 @@ func (a *Manifest) Apply(ctx context.Context, authInfo authorization.Info, space
 	return nil
 }
 
+func (a *Manifest) Diff(ctx context.Context, authInfo authorization.Info, spaceGUID string, manifesto payloads.Manifest) ([]manifest.Diff, error) {
+	diffs := []manifest.Diff{}
+	for i, appInfo := range manifesto.Applications {
+		appState, err := a.stateCollector.CollectState(ctx, authInfo, appInfo.Name, spaceGUID)
+		if err != nil {
+			return nil, err
+		}
+		appInfo = a.normalizer.Normalize(appInfo, appState)
+
+		for _, diff := range a.differ.Diff(appInfo, appState) {
+			diff.Path = fmt.Sprintf("/applications/%d%s", i, diff.Path)
+			diffs = append(diffs, diff)
+		}
+	}
+
+	return diffs, nil
+}
+
 func (a *Manifest) ensureDefaultDomainConfigured(ctx context.Context, authInfo authorization.Info) error {
 	_, err := a.domainRepo.GetDomainByName(ctx, authInfo, a.defaultDomainName)
 	if err != nil {
@@ -17,6 +17,7 @@ type Applier struct {
 	domainRepo  shared.CFDomainRepository
 	processRepo shared.CFProcessRepository
 	routeRepo   shared.CFRouteRepository
+	sidecarRepo shared.CFSidecarRepository
 }
 
 func NewApplier(
@@ -24,12 +25,14 @@ func NewApplier(
 	domainRepo shared.CFDomainRepository,
 	processRepo shared.CFProcessRepository,
 	routeRepo shared.CFRouteRepository,
+	sidecarRepo shared.CFSidecarRepository,
 ) *Applier {
 	return &Applier{
 		appRepo:     appRepo,
 		domainRepo:  domainRepo,
 		processRepo: processRepo,
 		routeRepo:   routeRepo,
+		sidecarRepo: sidecarRepo,
 	}
 }
 
@@ -43,6 +46,10 @@ func (a *Applier) Apply(ctx context.Context, authInfo authorization.Info, spaceG
 		return err
 	}
 
+	if err := a.applySidecars(ctx, authInfo, appInfo, appState); err != nil {
+		return err
+	}
+
 	return a.applyRoutes(ctx, authInfo, appInfo, appState)
 }
 
@@ -85,6 +92,28 @@ func (a *Applier) applyProcesses(
 	return nil
 }
 
+func (a *Applier) applySidecars(
+	ctx context.Context,
+	authInfo authorization.Info,
+	appInfo payloads.ManifestApplication,
+	appState AppState,
+) error {
+	for _, sidecarInfo := range appInfo.Sidecars {
+		if sidecar, ok := appState.Sidecars[sidecarInfo.Name]; ok {
+			if _, err := a.sidecarRepo.PatchSidecar(ctx, authInfo, sidecarInfo.ToSidecarPatchMessage(sidecar.GUID, appState.App.SpaceGUID)); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if _, err := a.sidecarRepo.CreateSidecar(ctx, authInfo, sidecarInfo.ToSidecarCreateMessage(appState.App.GUID, appState.App.SpaceGUID)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (a *Applier) applyRoutes(ctx context.Context, authInfo authorization.Info, appInfo payloads.ManifestApplication, appState AppState) error {
 	if appInfo.NoRoute {
 		return a.deleteAppDestinations(ctx, authInfo, appState.App.GUID, appState.Routes)
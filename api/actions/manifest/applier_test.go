@@ -22,6 +22,7 @@ var _ = Describe("Applier", func() {
 		domainRepo  *fake.CFDomainRepository
 		processRepo *fake.CFProcessRepository
 		routeRepo   *fake.CFRouteRepository
+		sidecarRepo *fake.CFSidecarRepository
 		applier     *manifest.Applier
 		applierErr  error
 		ctx         context.Context
@@ -35,7 +36,8 @@ var _ = Describe("Applier", func() {
 		domainRepo = new(fake.CFDomainRepository)
 		processRepo = new(fake.CFProcessRepository)
 		routeRepo = new(fake.CFRouteRepository)
-		applier = manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo)
+		sidecarRepo = new(fake.CFSidecarRepository)
+		applier = manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, sidecarRepo)
 		ctx = context.Background()
 		authInfo = authorization.Info{Token: "a-token"}
 		appInfo = payloads.ManifestApplication{
@@ -62,6 +64,7 @@ var _ = Describe("Applier", func() {
 			App:       repositories.AppRecord{},
 			Processes: map[string]repositories.ProcessRecord{},
 			Routes:    map[string]repositories.RouteRecord{},
+			Sidecars:  map[string]repositories.SidecarRecord{},
 		}
 	})
 
@@ -258,6 +261,93 @@ var _ = Describe("Applier", func() {
 		})
 	})
 
+	Describe("applying sidecars", func() {
+		BeforeEach(func() {
+			appState.App.GUID = "app-guid"
+			appState.App.SpaceGUID = "space-guid"
+			appInfo.Sidecars = []payloads.ManifestApplicationSidecar{
+				{
+					Name:         "first-sidecar",
+					Command:      "bin/first",
+					ProcessTypes: []string{"web"},
+					Memory:       tools.PtrTo("64M"),
+				},
+				{
+					Name:         "second-sidecar",
+					Command:      "bin/second",
+					ProcessTypes: []string{"web", "worker"},
+				},
+			}
+		})
+
+		It("creates each sidecar", func() {
+			Expect(applierErr).NotTo(HaveOccurred())
+			Expect(sidecarRepo.PatchSidecarCallCount()).To(Equal(0))
+			Expect(sidecarRepo.CreateSidecarCallCount()).To(Equal(2))
+
+			_, _, createMsg := sidecarRepo.CreateSidecarArgsForCall(0)
+			Expect(createMsg).To(Equal(repositories.CreateSidecarMessage{
+				AppGUID:      "app-guid",
+				SpaceGUID:    "space-guid",
+				Name:         "first-sidecar",
+				Command:      "bin/first",
+				ProcessTypes: []string{"web"},
+				MemoryMB:     tools.PtrTo(int64(64)),
+			}))
+
+			_, _, createMsg = sidecarRepo.CreateSidecarArgsForCall(1)
+			Expect(createMsg).To(Equal(repositories.CreateSidecarMessage{
+				AppGUID:      "app-guid",
+				SpaceGUID:    "space-guid",
+				Name:         "second-sidecar",
+				Command:      "bin/second",
+				ProcessTypes: []string{"web", "worker"},
+			}))
+		})
+
+		When("creating a sidecar fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.CreateSidecarReturns(repositories.SidecarRecord{}, errors.New("create-sidecar-failed"))
+			})
+
+			It("returns the error", func() {
+				Expect(applierErr).To(MatchError("create-sidecar-failed"))
+			})
+		})
+
+		When("a sidecar with the same name exists", func() {
+			BeforeEach(func() {
+				appState.Sidecars = map[string]repositories.SidecarRecord{
+					"second-sidecar": {GUID: "sidecar-guid"},
+				}
+			})
+
+			It("patches that sidecar", func() {
+				Expect(applierErr).NotTo(HaveOccurred())
+				Expect(sidecarRepo.CreateSidecarCallCount()).To(Equal(1))
+				Expect(sidecarRepo.PatchSidecarCallCount()).To(Equal(1))
+
+				_, _, patchMsg := sidecarRepo.PatchSidecarArgsForCall(0)
+				Expect(patchMsg).To(Equal(repositories.PatchSidecarMessage{
+					GUID:         "sidecar-guid",
+					SpaceGUID:    "space-guid",
+					Command:      tools.PtrTo("bin/second"),
+					ProcessTypes: []string{"web", "worker"},
+				}))
+			})
+
+			When("patching the sidecar fails", func() {
+				BeforeEach(func() {
+					sidecarRepo.PatchSidecarReturns(repositories.SidecarRecord{}, errors.New("sidecar-patch-error"))
+				})
+
+				It("returns the error", func() {
+					Expect(applierErr).To(MatchError("sidecar-patch-error"))
+				})
+			})
+		})
+	})
+
 	Describe("applying routes", func() {
 		BeforeEach(func() {
 			appState.App.GUID = "app-guid"
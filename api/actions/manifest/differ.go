@@ -0,0 +1,290 @@
+package manifest
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"code.cloudfoundry.org/bytefmt"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	DiffOpAdd     = "add"
+	DiffOpReplace = "replace"
+	DiffOpRemove  = "remove"
+)
+
+// Diff is a JSON patch style operation, describing how applying a manifest
+// application would change the current state of the app. Paths are relative
+// to the manifest application.
+type Diff struct {
+	Op    string
+	Path  string
+	Was   any
+	Value any
+}
+
+type Differ struct{}
+
+func NewDiffer() Differ {
+	return Differ{}
+}
+
+// Diff expects the application to be normalized, as the applier does
+func (d Differ) Diff(appInfo payloads.ManifestApplication, appState AppState) []Diff {
+	diffs := []Diff{}
+	diffs = append(diffs, diffEnv(appInfo.Env, appState.EnvVars)...)
+	diffs = append(diffs, diffBuildpacks(appInfo.Buildpacks, appState.App.Lifecycle.Data.Buildpacks)...)
+	diffs = append(diffs, diffProcesses(appInfo.Processes, appState.Processes)...)
+	diffs = append(diffs, diffRoutes(appInfo, appState.Routes)...)
+	diffs = append(diffs, diffSidecars(appInfo.Sidecars, appState.Sidecars)...)
+	diffs = append(diffs, diffMetadata("/metadata/labels", appInfo.Metadata.Labels, appState.App.Labels)...)
+	diffs = append(diffs, diffMetadata("/metadata/annotations", appInfo.Metadata.Annotations, appState.App.Annotations)...)
+
+	return diffs
+}
+
+func diffEnv(desired, current map[string]string) []Diff {
+	diffs := []Diff{}
+	for _, key := range sortedKeys(desired) {
+		path := "/env/" + escapePathSegment(key)
+		currentValue, ok := current[key]
+		if !ok {
+			diffs = append(diffs, Diff{Op: DiffOpAdd, Path: path, Value: desired[key]})
+			continue
+		}
+		if currentValue != desired[key] {
+			diffs = append(diffs, Diff{Op: DiffOpReplace, Path: path, Was: currentValue, Value: desired[key]})
+		}
+	}
+
+	return diffs
+}
+
+func diffBuildpacks(desired, current []string) []Diff {
+	switch {
+	case len(desired) == 0 && len(current) == 0:
+		return nil
+	case len(current) == 0:
+		return []Diff{{Op: DiffOpAdd, Path: "/buildpacks", Value: desired}}
+	case len(desired) == 0:
+		return []Diff{{Op: DiffOpRemove, Path: "/buildpacks", Was: current}}
+	case !reflect.DeepEqual(desired, current):
+		return []Diff{{Op: DiffOpReplace, Path: "/buildpacks", Was: current, Value: desired}}
+	default:
+		return nil
+	}
+}
+
+func diffProcesses(desired []payloads.ManifestApplicationProcess, current map[string]repositories.ProcessRecord) []Diff {
+	diffs := []Diff{}
+	for i, process := range desired {
+		path := fmt.Sprintf("/processes/%d", i)
+
+		record, ok := current[process.Type]
+		if !ok {
+			diffs = append(diffs, Diff{Op: DiffOpAdd, Path: path, Value: processValue(process)})
+			continue
+		}
+
+		diffs = append(diffs, diffProcess(path, process, record)...)
+	}
+
+	return diffs
+}
+
+func diffProcess(path string, desired payloads.ManifestApplicationProcess, current repositories.ProcessRecord) []Diff {
+	diffs := []Diff{}
+
+	if desired.Instances != nil && *desired.Instances != current.DesiredInstances {
+		diffs = append(diffs, Diff{Op: DiffOpReplace, Path: path + "/instances", Was: current.DesiredInstances, Value: *desired.Instances})
+	}
+	if desired.Memory != nil && toMegabytes(*desired.Memory) != current.MemoryMB {
+		diffs = append(diffs, Diff{Op: DiffOpReplace, Path: path + "/memory", Was: fmt.Sprintf("%dM", current.MemoryMB), Value: *desired.Memory})
+	}
+	if desired.DiskQuota != nil && toMegabytes(*desired.DiskQuota) != current.DiskQuotaMB {
+		diffs = append(diffs, Diff{Op: DiffOpReplace, Path: path + "/disk_quota", Was: fmt.Sprintf("%dM", current.DiskQuotaMB), Value: *desired.DiskQuota})
+	}
+	if desired.HealthCheckType != nil && healthCheckType(*desired.HealthCheckType) != current.HealthCheck.Type {
+		diffs = append(diffs, Diff{Op: DiffOpReplace, Path: path + "/health-check-type", Was: current.HealthCheck.Type, Value: *desired.HealthCheckType})
+	}
+	if desired.Command != nil {
+		diffs = append(diffs, diffOptionalValue(path+"/command", current.Command, *desired.Command)...)
+	}
+	if desired.HealthCheckHTTPEndpoint != nil {
+		diffs = append(diffs, diffOptionalValue(path+"/health-check-http-endpoint", current.HealthCheck.Data.HTTPEndpoint, *desired.HealthCheckHTTPEndpoint)...)
+	}
+	if desired.HealthCheckInvocationTimeout != nil {
+		diffs = append(diffs, diffOptionalValue(path+"/health-check-invocation-timeout", current.HealthCheck.Data.InvocationTimeoutSeconds, *desired.HealthCheckInvocationTimeout)...)
+	}
+	if desired.Timeout != nil {
+		diffs = append(diffs, diffOptionalValue(path+"/timeout", current.HealthCheck.Data.TimeoutSeconds, *desired.Timeout)...)
+	}
+
+	return diffs
+}
+
+// diffOptionalValue treats a zero current value as unset
+func diffOptionalValue[T comparable](path string, current, desired T) []Diff {
+	var zero T
+	if current == desired {
+		return nil
+	}
+	if current == zero {
+		return []Diff{{Op: DiffOpAdd, Path: path, Value: desired}}
+	}
+
+	return []Diff{{Op: DiffOpReplace, Path: path, Was: current, Value: desired}}
+}
+
+func processValue(process payloads.ManifestApplicationProcess) map[string]any {
+	value := map[string]any{"type": process.Type}
+	if process.Instances != nil {
+		value["instances"] = *process.Instances
+	}
+	if process.Memory != nil {
+		value["memory"] = *process.Memory
+	}
+	if process.DiskQuota != nil {
+		value["disk_quota"] = *process.DiskQuota
+	}
+	if process.Command != nil {
+		value["command"] = *process.Command
+	}
+	if process.HealthCheckType != nil {
+		value["health-check-type"] = *process.HealthCheckType
+	}
+	if process.HealthCheckHTTPEndpoint != nil {
+		value["health-check-http-endpoint"] = *process.HealthCheckHTTPEndpoint
+	}
+	if process.HealthCheckInvocationTimeout != nil {
+		value["health-check-invocation-timeout"] = *process.HealthCheckInvocationTimeout
+	}
+	if process.Timeout != nil {
+		value["timeout"] = *process.Timeout
+	}
+
+	return value
+}
+
+func diffRoutes(appInfo payloads.ManifestApplication, current map[string]repositories.RouteRecord) []Diff {
+	diffs := []Diff{}
+
+	if appInfo.NoRoute {
+		for i, route := range sortedKeys(current) {
+			diffs = append(diffs, Diff{Op: DiffOpRemove, Path: fmt.Sprintf("/routes/%d", i), Was: map[string]any{"route": route}})
+		}
+		return diffs
+	}
+
+	for i, route := range appInfo.Routes {
+		if route.Route == nil {
+			continue
+		}
+		if _, ok := current[*route.Route]; ok {
+			continue
+		}
+		diffs = append(diffs, Diff{Op: DiffOpAdd, Path: fmt.Sprintf("/routes/%d", i), Value: map[string]any{"route": *route.Route}})
+	}
+
+	return diffs
+}
+
+// diffSidecars matches sidecars by name, as the applier does. Sidecars that
+// are not in the manifest are kept.
+func diffSidecars(desired []payloads.ManifestApplicationSidecar, current map[string]repositories.SidecarRecord) []Diff {
+	diffs := []Diff{}
+	for i, sidecar := range desired {
+		path := fmt.Sprintf("/sidecars/%d", i)
+
+		record, ok := current[sidecar.Name]
+		if !ok {
+			diffs = append(diffs, Diff{Op: DiffOpAdd, Path: path, Value: sidecarValue(sidecar)})
+			continue
+		}
+
+		if sidecar.Command != record.Command {
+			diffs = append(diffs, Diff{Op: DiffOpReplace, Path: path + "/command", Was: record.Command, Value: sidecar.Command})
+		}
+		if !reflect.DeepEqual(sidecar.ProcessTypes, record.ProcessTypes) {
+			diffs = append(diffs, Diff{Op: DiffOpReplace, Path: path + "/process_types", Was: record.ProcessTypes, Value: sidecar.ProcessTypes})
+		}
+		if sidecar.Memory != nil {
+			switch {
+			case record.MemoryMB == nil:
+				diffs = append(diffs, Diff{Op: DiffOpAdd, Path: path + "/memory", Value: *sidecar.Memory})
+			case toMegabytes(*sidecar.Memory) != *record.MemoryMB:
+				diffs = append(diffs, Diff{Op: DiffOpReplace, Path: path + "/memory", Was: fmt.Sprintf("%dM", *record.MemoryMB), Value: *sidecar.Memory})
+			}
+		}
+	}
+
+	return diffs
+}
+
+func sidecarValue(sidecar payloads.ManifestApplicationSidecar) map[string]any {
+	value := map[string]any{
+		"name":          sidecar.Name,
+		"command":       sidecar.Command,
+		"process_types": sidecar.ProcessTypes,
+	}
+	if sidecar.Memory != nil {
+		value["memory"] = *sidecar.Memory
+	}
+
+	return value
+}
+
+// diffMetadata follows the metadata patch semantics, where a nil value
+// deletes the key
+func diffMetadata(pathPrefix string, desired map[string]*string, current map[string]string) []Diff {
+	diffs := []Diff{}
+	for _, key := range sortedKeys(desired) {
+		path := pathPrefix + "/" + escapePathSegment(key)
+		currentValue, ok := current[key]
+
+		switch {
+		case desired[key] == nil:
+			if ok {
+				diffs = append(diffs, Diff{Op: DiffOpRemove, Path: path, Was: currentValue})
+			}
+		case !ok:
+			diffs = append(diffs, Diff{Op: DiffOpAdd, Path: path, Value: *desired[key]})
+		case currentValue != *desired[key]:
+			diffs = append(diffs, Diff{Op: DiffOpReplace, Path: path, Was: currentValue, Value: *desired[key]})
+		}
+	}
+
+	return diffs
+}
+
+func healthCheckType(manifestType string) string {
+	if manifestType == "none" {
+		return "process"
+	}
+	return manifestType
+}
+
+func toMegabytes(quantity string) int64 {
+	// error ignored intentionally, since the manifest yaml is validated in handlers
+	megabytes, _ := bytefmt.ToMegabytes(quantity)
+	return int64(megabytes)
+}
+
+// escapePathSegment escapes a map key as a JSON pointer segment (RFC 6901)
+func escapePathSegment(segment string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(segment)
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,245 @@
+package manifest_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"code.cloudfoundry.org/korifi/api/actions/manifest"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+)
+
+var _ = Describe("Differ", func() {
+	var (
+		differ   manifest.Differ
+		appInfo  payloads.ManifestApplication
+		appState manifest.AppState
+		diffs    []manifest.Diff
+	)
+
+	BeforeEach(func() {
+		differ = manifest.NewDiffer()
+		appInfo = payloads.ManifestApplication{Name: "my-app"}
+		appState = manifest.AppState{
+			App: repositories.AppRecord{
+				GUID: "app-guid",
+				Name: "my-app",
+			},
+			EnvVars:   map[string]string{},
+			Processes: map[string]repositories.ProcessRecord{},
+			Routes:    map[string]repositories.RouteRecord{},
+		}
+	})
+
+	JustBeforeEach(func() {
+		diffs = differ.Diff(appInfo, appState)
+	})
+
+	It("returns no diffs when nothing changes", func() {
+		Expect(diffs).To(BeEmpty())
+	})
+
+	Describe("env", func() {
+		BeforeEach(func() {
+			appState.EnvVars = map[string]string{"SAME": "value", "CHANGED": "old", "KEPT": "kept"}
+			appInfo.Env = map[string]string{"SAME": "value", "CHANGED": "new", "NEW/VAR": "added"}
+		})
+
+		It("adds new and replaces changed env vars", func() {
+			Expect(diffs).To(Equal([]manifest.Diff{
+				{Op: manifest.DiffOpReplace, Path: "/env/CHANGED", Was: "old", Value: "new"},
+				{Op: manifest.DiffOpAdd, Path: "/env/NEW~1VAR", Value: "added"},
+			}))
+		})
+	})
+
+	Describe("buildpacks", func() {
+		BeforeEach(func() {
+			appInfo.Buildpacks = []string{"java", "go"}
+		})
+
+		It("adds the buildpacks", func() {
+			Expect(diffs).To(Equal([]manifest.Diff{
+				{Op: manifest.DiffOpAdd, Path: "/buildpacks", Value: []string{"java", "go"}},
+			}))
+		})
+
+		When("the app has different buildpacks", func() {
+			BeforeEach(func() {
+				appState.App.Lifecycle.Data.Buildpacks = []string{"java"}
+			})
+
+			It("replaces the buildpacks", func() {
+				Expect(diffs).To(Equal([]manifest.Diff{
+					{Op: manifest.DiffOpReplace, Path: "/buildpacks", Was: []string{"java"}, Value: []string{"java", "go"}},
+				}))
+			})
+		})
+
+		When("the manifest has no buildpacks", func() {
+			BeforeEach(func() {
+				appInfo.Buildpacks = nil
+				appState.App.Lifecycle.Data.Buildpacks = []string{"java"}
+			})
+
+			It("removes the buildpacks", func() {
+				Expect(diffs).To(Equal([]manifest.Diff{
+					{Op: manifest.DiffOpRemove, Path: "/buildpacks", Was: []string{"java"}},
+				}))
+			})
+		})
+	})
+
+	Describe("processes", func() {
+		BeforeEach(func() {
+			appState.Processes["web"] = repositories.ProcessRecord{
+				Type:             "web",
+				DesiredInstances: 1,
+				MemoryMB:         256,
+				DiskQuotaMB:      1024,
+				HealthCheck: repositories.HealthCheck{
+					Type: "process",
+					Data: repositories.HealthCheckData{TimeoutSeconds: 60},
+				},
+			}
+			appInfo.Processes = []payloads.ManifestApplicationProcess{{
+				Type:                         "web",
+				Instances:                    tools.PtrTo(2),
+				Memory:                       tools.PtrTo("1G"),
+				DiskQuota:                    tools.PtrTo("1G"),
+				HealthCheckType:              tools.PtrTo("http"),
+				HealthCheckHTTPEndpoint:      tools.PtrTo("/healthz"),
+				HealthCheckInvocationTimeout: tools.PtrTo(int64(5)),
+				Timeout:                      tools.PtrTo(int64(30)),
+				Command:                      tools.PtrTo("start"),
+			}, {
+				Type:      "worker",
+				Instances: tools.PtrTo(3),
+				Command:   tools.PtrTo("work"),
+			}}
+		})
+
+		It("diffs existing processes field by field and adds new ones", func() {
+			Expect(diffs).To(Equal([]manifest.Diff{
+				{Op: manifest.DiffOpReplace, Path: "/processes/0/instances", Was: 1, Value: 2},
+				{Op: manifest.DiffOpReplace, Path: "/processes/0/memory", Was: "256M", Value: "1G"},
+				{Op: manifest.DiffOpReplace, Path: "/processes/0/health-check-type", Was: "process", Value: "http"},
+				{Op: manifest.DiffOpAdd, Path: "/processes/0/command", Value: "start"},
+				{Op: manifest.DiffOpAdd, Path: "/processes/0/health-check-http-endpoint", Value: "/healthz"},
+				{Op: manifest.DiffOpAdd, Path: "/processes/0/health-check-invocation-timeout", Value: int64(5)},
+				{Op: manifest.DiffOpReplace, Path: "/processes/0/timeout", Was: int64(60), Value: int64(30)},
+				{Op: manifest.DiffOpAdd, Path: "/processes/1", Value: map[string]any{
+					"type":      "worker",
+					"instances": 3,
+					"command":   "work",
+				}},
+			}))
+		})
+
+		When("the health check type is none", func() {
+			BeforeEach(func() {
+				appInfo.Processes = []payloads.ManifestApplicationProcess{{
+					Type:            "web",
+					HealthCheckType: tools.PtrTo("none"),
+				}}
+			})
+
+			It("treats it as a process health check", func() {
+				Expect(diffs).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("routes", func() {
+		BeforeEach(func() {
+			appState.Routes = map[string]repositories.RouteRecord{
+				"existing.my.domain": {},
+				"other.my.domain":    {},
+			}
+			appInfo.Routes = []payloads.ManifestRoute{
+				{Route: tools.PtrTo("existing.my.domain")},
+				{Route: tools.PtrTo("new.my.domain/path")},
+			}
+		})
+
+		It("adds the new routes", func() {
+			Expect(diffs).To(Equal([]manifest.Diff{
+				{Op: manifest.DiffOpAdd, Path: "/routes/1", Value: map[string]any{"route": "new.my.domain/path"}},
+			}))
+		})
+
+		When("no-route is set", func() {
+			BeforeEach(func() {
+				appInfo.NoRoute = true
+				appInfo.Routes = nil
+			})
+
+			It("removes the existing routes", func() {
+				Expect(diffs).To(Equal([]manifest.Diff{
+					{Op: manifest.DiffOpRemove, Path: "/routes/0", Was: map[string]any{"route": "existing.my.domain"}},
+					{Op: manifest.DiffOpRemove, Path: "/routes/1", Was: map[string]any{"route": "other.my.domain"}},
+				}))
+			})
+		})
+	})
+
+	Describe("sidecars", func() {
+		BeforeEach(func() {
+			appState.Sidecars = map[string]repositories.SidecarRecord{
+				"unchanged": {Name: "unchanged", Command: "./unchanged", ProcessTypes: []string{"web"}, MemoryMB: tools.PtrTo[int64](64)},
+				"changed":   {Name: "changed", Command: "./old", ProcessTypes: []string{"web"}, MemoryMB: tools.PtrTo[int64](64)},
+				"no-memory": {Name: "no-memory", Command: "./no-memory", ProcessTypes: []string{"web"}},
+				"kept":      {Name: "kept", Command: "./kept", ProcessTypes: []string{"web"}},
+			}
+			appInfo.Sidecars = []payloads.ManifestApplicationSidecar{
+				{Name: "unchanged", Command: "./unchanged", ProcessTypes: []string{"web"}, Memory: tools.PtrTo("64M")},
+				{Name: "changed", Command: "./new", ProcessTypes: []string{"web", "worker"}, Memory: tools.PtrTo("128M")},
+				{Name: "no-memory", Command: "./no-memory", ProcessTypes: []string{"web"}, Memory: tools.PtrTo("32M")},
+				{Name: "new", Command: "./new", ProcessTypes: []string{"worker"}},
+			}
+		})
+
+		It("diffs existing sidecars field by field and adds new ones", func() {
+			Expect(diffs).To(Equal([]manifest.Diff{
+				{Op: manifest.DiffOpReplace, Path: "/sidecars/1/command", Was: "./old", Value: "./new"},
+				{Op: manifest.DiffOpReplace, Path: "/sidecars/1/process_types", Was: []string{"web"}, Value: []string{"web", "worker"}},
+				{Op: manifest.DiffOpReplace, Path: "/sidecars/1/memory", Was: "64M", Value: "128M"},
+				{Op: manifest.DiffOpAdd, Path: "/sidecars/2/memory", Value: "32M"},
+				{Op: manifest.DiffOpAdd, Path: "/sidecars/3", Value: map[string]any{
+					"name":          "new",
+					"command":       "./new",
+					"process_types": []string{"worker"},
+				}},
+			}))
+		})
+	})
+
+	Describe("metadata", func() {
+		BeforeEach(func() {
+			appState.App.Labels = map[string]string{"same": "value", "changed": "old", "deleted": "gone"}
+			appState.App.Annotations = map[string]string{"example.org/note": "old"}
+			appInfo.Metadata = payloads.MetadataPatch{
+				Labels: map[string]*string{
+					"same":    tools.PtrTo("value"),
+					"changed": tools.PtrTo("new"),
+					"deleted": nil,
+					"missing": nil,
+					"added":   tools.PtrTo("new"),
+				},
+				Annotations: map[string]*string{
+					"example.org/note": tools.PtrTo("new"),
+				},
+			}
+		})
+
+		It("follows the metadata patch semantics", func() {
+			Expect(diffs).To(Equal([]manifest.Diff{
+				{Op: manifest.DiffOpAdd, Path: "/metadata/labels/added", Value: "new"},
+				{Op: manifest.DiffOpReplace, Path: "/metadata/labels/changed", Was: "old", Value: "new"},
+				{Op: manifest.DiffOpRemove, Path: "/metadata/labels/deleted", Was: "gone"},
+				{Op: manifest.DiffOpReplace, Path: "/metadata/annotations/example.org~1note", Was: "old", Value: "new"},
+			}))
+		})
+	})
+})
@@ -46,6 +46,9 @@ var _ = Describe("Normalizer", func() {
 				Labels:      map[string]*string{"foo": tools.PtrTo("FOO")},
 				Annotations: map[string]*string{"bar": tools.PtrTo("BAR")},
 			},
+			Sidecars: []payloads.ManifestApplicationSidecar{
+				{Name: "my-sidecar", Command: "./sidecar", ProcessTypes: []string{"web"}},
+			},
 		}
 		appState = manifest.AppState{
 			App:       repositories.AppRecord{},
@@ -66,6 +69,7 @@ var _ = Describe("Normalizer", func() {
 			Expect(normalizedAppInfo.Env).To(Equal(appInfo.Env))
 			Expect(normalizedAppInfo.Buildpacks).To(Equal(appInfo.Buildpacks))
 			Expect(normalizedAppInfo.Metadata).To(Equal(appInfo.Metadata))
+			Expect(normalizedAppInfo.Sidecars).To(Equal(appInfo.Sidecars))
 		})
 
 		When("no-route is set", func() {
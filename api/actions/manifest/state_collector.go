@@ -17,12 +17,15 @@ type StateCollector struct {
 	domainRepo  shared.CFDomainRepository
 	processRepo shared.CFProcessRepository
 	routeRepo   shared.CFRouteRepository
+	sidecarRepo shared.CFSidecarRepository
 }
 
 type AppState struct {
 	App       repositories.AppRecord
+	EnvVars   map[string]string
 	Processes map[string]repositories.ProcessRecord
 	Routes    map[string]repositories.RouteRecord
+	Sidecars  map[string]repositories.SidecarRecord
 }
 
 func NewStateCollector(
@@ -30,12 +33,14 @@ func NewStateCollector(
 	domainRepo shared.CFDomainRepository,
 	processRepo shared.CFProcessRepository,
 	routeRepo shared.CFRouteRepository,
+	sidecarRepo shared.CFSidecarRepository,
 ) StateCollector {
 	return StateCollector{
 		appRepo:     appRepo,
 		domainRepo:  domainRepo,
 		processRepo: processRepo,
 		routeRepo:   routeRepo,
+		sidecarRepo: sidecarRepo,
 	}
 }
 
@@ -45,9 +50,17 @@ func (s StateCollector) CollectState(ctx context.Context, authInfo authorization
 		return AppState{}, apierrors.ForbiddenAsNotFound(err)
 	}
 
+	existingEnvVars := map[string]string{}
 	existingProcesses := map[string]repositories.ProcessRecord{}
 	existingAppRoutes := map[string]repositories.RouteRecord{}
+	existingSidecars := map[string]repositories.SidecarRecord{}
 	if appRecord.GUID != "" {
+		appEnv, err := s.appRepo.GetAppEnv(ctx, authInfo, appRecord.GUID)
+		if err != nil {
+			return AppState{}, err
+		}
+		existingEnvVars = appEnv.EnvironmentVariables
+
 		procs, err := s.processRepo.ListProcesses(ctx, authInfo, repositories.ListProcessesMessage{
 			AppGUIDs:  []string{appRecord.GUID},
 			SpaceGUID: spaceGUID,
@@ -67,12 +80,25 @@ func (s StateCollector) CollectState(ctx context.Context, authInfo authorization
 		for _, r := range routes {
 			existingAppRoutes[unsplitRoute(r)] = r
 		}
+
+		sidecars, err := s.sidecarRepo.ListSidecars(ctx, authInfo, repositories.ListSidecarsMessage{
+			AppGUID:   appRecord.GUID,
+			SpaceGUID: spaceGUID,
+		})
+		if err != nil {
+			return AppState{}, err
+		}
+		for _, sc := range sidecars {
+			existingSidecars[sc.Name] = sc
+		}
 	}
 
 	return AppState{
 		App:       appRecord,
+		EnvVars:   existingEnvVars,
 		Processes: existingProcesses,
 		Routes:    existingAppRoutes,
+		Sidecars:  existingSidecars,
 	}, nil
 }
 
@@ -20,6 +20,7 @@ var _ = Describe("StateCollector", func() {
 		domainRepo      *fake.CFDomainRepository
 		processRepo     *fake.CFProcessRepository
 		routeRepo       *fake.CFRouteRepository
+		sidecarRepo     *fake.CFSidecarRepository
 		stateCollector  manifest.StateCollector
 		appState        manifest.AppState
 		collectStateErr error
@@ -30,11 +31,13 @@ var _ = Describe("StateCollector", func() {
 		domainRepo = new(fake.CFDomainRepository)
 		processRepo = new(fake.CFProcessRepository)
 		routeRepo = new(fake.CFRouteRepository)
+		sidecarRepo = new(fake.CFSidecarRepository)
 		stateCollector = manifest.NewStateCollector(
 			appRepo,
 			domainRepo,
 			processRepo,
 			routeRepo,
+			sidecarRepo,
 		)
 	})
 
@@ -46,6 +49,7 @@ var _ = Describe("StateCollector", func() {
 		It("returns an empty app", func() {
 			Expect(collectStateErr).NotTo(HaveOccurred())
 			Expect(appState.App).To(Equal(repositories.AppRecord{}))
+			Expect(appState.EnvVars).To(BeEmpty())
 			Expect(appState.Processes).To(BeEmpty())
 			Expect(appState.Routes).To(BeEmpty())
 		})
@@ -90,6 +94,36 @@ var _ = Describe("StateCollector", func() {
 		})
 	})
 
+	Describe("env vars", func() {
+		BeforeEach(func() {
+			appRepo.GetAppByNameAndSpaceReturns(repositories.AppRecord{GUID: "app-guid"}, nil)
+			appRepo.GetAppEnvReturns(repositories.AppEnvRecord{
+				EnvironmentVariables: map[string]string{"FOO": "bar"},
+			}, nil)
+		})
+
+		It("gets the app env", func() {
+			Expect(appRepo.GetAppEnvCallCount()).To(Equal(1))
+			_, _, appGUID := appRepo.GetAppEnvArgsForCall(0)
+			Expect(appGUID).To(Equal("app-guid"))
+		})
+
+		It("sets the env vars in the state", func() {
+			Expect(collectStateErr).NotTo(HaveOccurred())
+			Expect(appState.EnvVars).To(Equal(map[string]string{"FOO": "bar"}))
+		})
+
+		When("getting the app env fails", func() {
+			BeforeEach(func() {
+				appRepo.GetAppEnvReturns(repositories.AppEnvRecord{}, errors.New("get-app-env-err"))
+			})
+
+			It("returns the error", func() {
+				Expect(collectStateErr).To(MatchError("get-app-env-err"))
+			})
+		})
+	})
+
 	Describe("processes", func() {
 		BeforeEach(func() {
 			appRepo.GetAppByNameAndSpaceReturns(repositories.AppRecord{GUID: "app-guid"}, nil)
@@ -183,4 +217,39 @@ var _ = Describe("StateCollector", func() {
 			}))
 		})
 	})
+
+	Describe("sidecars", func() {
+		BeforeEach(func() {
+			appRepo.GetAppByNameAndSpaceReturns(repositories.AppRecord{GUID: "app-guid"}, nil)
+			sidecarRepo.ListSidecarsReturns([]repositories.SidecarRecord{
+				{GUID: "sidecar-guid", Name: "my-sidecar"},
+			}, nil)
+		})
+
+		It("lists the app sidecars", func() {
+			Expect(sidecarRepo.ListSidecarsCallCount()).To(Equal(1))
+			_, _, listMsg := sidecarRepo.ListSidecarsArgsForCall(0)
+			Expect(listMsg).To(Equal(repositories.ListSidecarsMessage{
+				AppGUID:   "app-guid",
+				SpaceGUID: "space-guid",
+			}))
+		})
+
+		It("constructs the sidecar map using the sidecar name", func() {
+			Expect(collectStateErr).NotTo(HaveOccurred())
+			Expect(appState.Sidecars).To(Equal(map[string]repositories.SidecarRecord{
+				"my-sidecar": {GUID: "sidecar-guid", Name: "my-sidecar"},
+			}))
+		})
+
+		When("listing the sidecars fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.ListSidecarsReturns(nil, errors.New("list-sidecars-error"))
+			})
+
+			It("returns the error", func() {
+				Expect(collectStateErr).To(MatchError("list-sidecars-error"))
+			})
+		})
+	})
 })
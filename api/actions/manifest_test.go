@@ -25,6 +25,7 @@ var _ = Describe("ApplyManifest", func() {
 		stateCollector   *fake.StateCollector
 		normalizer       *fake.Normalizer
 		applier          *fake.Applier
+		differ           *fake.Differ
 
 		appManifest payloads.Manifest
 	)
@@ -34,6 +35,7 @@ var _ = Describe("ApplyManifest", func() {
 		stateCollector = new(fake.StateCollector)
 		normalizer = new(fake.Normalizer)
 		applier = new(fake.Applier)
+		differ = new(fake.Differ)
 
 		stateCollector.CollectStateReturnsOnCall(0, manifest.AppState{
 			App: repositories.AppRecord{
@@ -63,7 +65,7 @@ var _ = Describe("ApplyManifest", func() {
 			}},
 		}
 
-		manifestAction = actions.NewManifest(domainRepository, "my.domain", stateCollector, normalizer, applier)
+		manifestAction = actions.NewManifest(domainRepository, "my.domain", stateCollector, normalizer, applier, differ)
 	})
 
 	JustBeforeEach(func() {
@@ -144,3 +146,88 @@ var _ = Describe("ApplyManifest", func() {
 		})
 	})
 })
+
+var _ = Describe("DiffManifest", func() {
+	var (
+		manifestAction *actions.Manifest
+		diffs          []manifest.Diff
+		diffErr        error
+
+		stateCollector *fake.StateCollector
+		normalizer     *fake.Normalizer
+		differ         *fake.Differ
+
+		appManifest payloads.Manifest
+	)
+
+	BeforeEach(func() {
+		stateCollector = new(fake.StateCollector)
+		normalizer = new(fake.Normalizer)
+		differ = new(fake.Differ)
+
+		stateCollector.CollectStateReturnsOnCall(0, manifest.AppState{
+			App: repositories.AppRecord{GUID: "app1-guid"},
+		}, nil)
+		stateCollector.CollectStateReturnsOnCall(1, manifest.AppState{
+			App: repositories.AppRecord{GUID: "app2-guid"},
+		}, nil)
+
+		normalizer.NormalizeReturnsOnCall(0, payloads.ManifestApplication{Name: "normalized-app1"})
+		normalizer.NormalizeReturnsOnCall(1, payloads.ManifestApplication{Name: "normalized-app2"})
+
+		differ.DiffReturnsOnCall(0, []manifest.Diff{
+			{Op: manifest.DiffOpAdd, Path: "/env/FOO", Value: "bar"},
+		})
+		differ.DiffReturnsOnCall(1, []manifest.Diff{
+			{Op: manifest.DiffOpReplace, Path: "/processes/0/instances", Was: 1, Value: 2},
+		})
+
+		appManifest = payloads.Manifest{
+			Applications: []payloads.ManifestApplication{{
+				Name: "app1",
+			}, {
+				Name: "app2",
+			}},
+		}
+
+		manifestAction = actions.NewManifest(new(reposfake.CFDomainRepository), "my.domain", stateCollector, normalizer, new(fake.Applier), differ)
+	})
+
+	JustBeforeEach(func() {
+		diffs, diffErr = manifestAction.Diff(context.Background(), authorization.Info{}, "space-guid", appManifest)
+	})
+
+	It("diffs the normalized applications against their state", func() {
+		Expect(diffErr).NotTo(HaveOccurred())
+
+		Expect(stateCollector.CollectStateCallCount()).To(Equal(2))
+		_, _, actualAppName, actualSpaceGUID := stateCollector.CollectStateArgsForCall(1)
+		Expect(actualAppName).To(Equal("app2"))
+		Expect(actualSpaceGUID).To(Equal("space-guid"))
+
+		Expect(differ.DiffCallCount()).To(Equal(2))
+		actualAppInManifest, actualState := differ.DiffArgsForCall(0)
+		Expect(actualAppInManifest.Name).To(Equal("normalized-app1"))
+		Expect(actualState.App.GUID).To(Equal("app1-guid"))
+		actualAppInManifest, actualState = differ.DiffArgsForCall(1)
+		Expect(actualAppInManifest.Name).To(Equal("normalized-app2"))
+		Expect(actualState.App.GUID).To(Equal("app2-guid"))
+	})
+
+	It("prefixes the paths with the application index", func() {
+		Expect(diffs).To(Equal([]manifest.Diff{
+			{Op: manifest.DiffOpAdd, Path: "/applications/0/env/FOO", Value: "bar"},
+			{Op: manifest.DiffOpReplace, Path: "/applications/1/processes/0/instances", Was: 1, Value: 2},
+		}))
+	})
+
+	When("collecting the app state fails", func() {
+		BeforeEach(func() {
+			stateCollector.CollectStateReturnsOnCall(1, manifest.AppState{}, errors.New("collect-state-err"))
+		})
+
+		It("returns the error", func() {
+			Expect(diffErr).To(MatchError("collect-state-err"))
+		})
+	})
+})
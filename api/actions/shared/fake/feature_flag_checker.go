@@ -0,0 +1,117 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/actions/shared"
+	"code.cloudfoundry.org/korifi/api/authorization"
+)
+
+type FeatureFlagChecker struct {
+	CheckFeatureFlagStub        func(context.Context, authorization.Info, string) error
+	checkFeatureFlagMutex       sync.RWMutex
+	checkFeatureFlagArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	checkFeatureFlagReturns struct {
+		result1 error
+	}
+	checkFeatureFlagReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FeatureFlagChecker) CheckFeatureFlag(arg1 context.Context, arg2 authorization.Info, arg3 string) error {
+	fake.checkFeatureFlagMutex.Lock()
+	ret, specificReturn := fake.checkFeatureFlagReturnsOnCall[len(fake.checkFeatureFlagArgsForCall)]
+	fake.checkFeatureFlagArgsForCall = append(fake.checkFeatureFlagArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.CheckFeatureFlagStub
+	fakeReturns := fake.checkFeatureFlagReturns
+	fake.recordInvocation("CheckFeatureFlag", []interface{}{arg1, arg2, arg3})
+	fake.checkFeatureFlagMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *FeatureFlagChecker) CheckFeatureFlagCallCount() int {
+	fake.checkFeatureFlagMutex.RLock()
+	defer fake.checkFeatureFlagMutex.RUnlock()
+	return len(fake.checkFeatureFlagArgsForCall)
+}
+
+func (fake *FeatureFlagChecker) CheckFeatureFlagCalls(stub func(context.Context, authorization.Info, string) error) {
+	fake.checkFeatureFlagMutex.Lock()
+	defer fake.checkFeatureFlagMutex.Unlock()
+	fake.CheckFeatureFlagStub = stub
+}
+
+func (fake *FeatureFlagChecker) CheckFeatureFlagArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.checkFeatureFlagMutex.RLock()
+	defer fake.checkFeatureFlagMutex.RUnlock()
+	argsForCall := fake.checkFeatureFlagArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FeatureFlagChecker) CheckFeatureFlagReturns(result1 error) {
+	fake.checkFeatureFlagMutex.Lock()
+	defer fake.checkFeatureFlagMutex.Unlock()
+	fake.CheckFeatureFlagStub = nil
+	fake.checkFeatureFlagReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FeatureFlagChecker) CheckFeatureFlagReturnsOnCall(i int, result1 error) {
+	fake.checkFeatureFlagMutex.Lock()
+	defer fake.checkFeatureFlagMutex.Unlock()
+	fake.CheckFeatureFlagStub = nil
+	if fake.checkFeatureFlagReturnsOnCall == nil {
+		fake.checkFeatureFlagReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.checkFeatureFlagReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FeatureFlagChecker) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.checkFeatureFlagMutex.RLock()
+	defer fake.checkFeatureFlagMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FeatureFlagChecker) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ shared.FeatureFlagChecker = new(FeatureFlagChecker)
@@ -9,6 +9,8 @@ import (
 	"code.cloudfoundry.org/korifi/api/authorization"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"github.com/go-logr/logr"
+	v1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 type PodRepository struct {
@@ -28,6 +30,22 @@ type PodRepository struct {
 		result1 []repositories.LogRecord
 		result2 error
 	}
+	ListPodsStub        func(context.Context, authorization.Info, string, client.MatchingLabels) ([]v1.Pod, error)
+	listPodsMutex       sync.RWMutex
+	listPodsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 client.MatchingLabels
+	}
+	listPodsReturns struct {
+		result1 []v1.Pod
+		result2 error
+	}
+	listPodsReturnsOnCall map[int]struct {
+		result1 []v1.Pod
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -99,11 +117,80 @@ func (fake *PodRepository) GetRuntimeLogsForAppReturnsOnCall(i int, result1 []re
 	}{result1, result2}
 }
 
+func (fake *PodRepository) ListPods(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 client.MatchingLabels) ([]v1.Pod, error) {
+	fake.listPodsMutex.Lock()
+	ret, specificReturn := fake.listPodsReturnsOnCall[len(fake.listPodsArgsForCall)]
+	fake.listPodsArgsForCall = append(fake.listPodsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 client.MatchingLabels
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ListPodsStub
+	fakeReturns := fake.listPodsReturns
+	fake.recordInvocation("ListPods", []interface{}{arg1, arg2, arg3, arg4})
+	fake.listPodsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PodRepository) ListPodsCallCount() int {
+	fake.listPodsMutex.RLock()
+	defer fake.listPodsMutex.RUnlock()
+	return len(fake.listPodsArgsForCall)
+}
+
+func (fake *PodRepository) ListPodsCalls(stub func(context.Context, authorization.Info, string, client.MatchingLabels) ([]v1.Pod, error)) {
+	fake.listPodsMutex.Lock()
+	defer fake.listPodsMutex.Unlock()
+	fake.ListPodsStub = stub
+}
+
+func (fake *PodRepository) ListPodsArgsForCall(i int) (context.Context, authorization.Info, string, client.MatchingLabels) {
+	fake.listPodsMutex.RLock()
+	defer fake.listPodsMutex.RUnlock()
+	argsForCall := fake.listPodsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *PodRepository) ListPodsReturns(result1 []v1.Pod, result2 error) {
+	fake.listPodsMutex.Lock()
+	defer fake.listPodsMutex.Unlock()
+	fake.ListPodsStub = nil
+	fake.listPodsReturns = struct {
+		result1 []v1.Pod
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PodRepository) ListPodsReturnsOnCall(i int, result1 []v1.Pod, result2 error) {
+	fake.listPodsMutex.Lock()
+	defer fake.listPodsMutex.Unlock()
+	fake.ListPodsStub = nil
+	if fake.listPodsReturnsOnCall == nil {
+		fake.listPodsReturnsOnCall = make(map[int]struct {
+			result1 []v1.Pod
+			result2 error
+		})
+	}
+	fake.listPodsReturnsOnCall[i] = struct {
+		result1 []v1.Pod
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *PodRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.getRuntimeLogsForAppMutex.RLock()
 	defer fake.getRuntimeLogsForAppMutex.RUnlock()
+	fake.listPodsMutex.RLock()
+	defer fake.listPodsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
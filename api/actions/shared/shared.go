@@ -6,6 +6,8 @@ import (
 	"code.cloudfoundry.org/korifi/api/authorization"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 //counterfeiter:generate -o fake -fake-name CFProcessRepository . CFProcessRepository
@@ -23,11 +25,24 @@ type CFProcessRepository interface {
 type CFAppRepository interface {
 	GetApp(context.Context, authorization.Info, string) (repositories.AppRecord, error)
 	GetAppByNameAndSpace(context.Context, authorization.Info, string, string) (repositories.AppRecord, error)
+	GetAppEnv(context.Context, authorization.Info, string) (repositories.AppEnvRecord, error)
 	CreateOrPatchAppEnvVars(context.Context, authorization.Info, repositories.CreateOrPatchAppEnvVarsMessage) (repositories.AppEnvVarsRecord, error)
 	CreateApp(context.Context, authorization.Info, repositories.CreateAppMessage) (repositories.AppRecord, error)
 	PatchApp(context.Context, authorization.Info, repositories.PatchAppMessage) (repositories.AppRecord, error)
 }
 
+//counterfeiter:generate -o fake -fake-name CFSpaceRepository . CFSpaceRepository
+
+type CFSpaceRepository interface {
+	GetSpace(context.Context, authorization.Info, string) (repositories.SpaceRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name FeatureFlagChecker . FeatureFlagChecker
+
+type FeatureFlagChecker interface {
+	CheckFeatureFlag(context.Context, authorization.Info, string) error
+}
+
 //counterfeiter:generate -o fake -fake-name CFBuildRepository . CFBuildRepository
 
 type CFBuildRepository interface {
@@ -39,6 +54,7 @@ type CFBuildRepository interface {
 
 type PodRepository interface {
 	GetRuntimeLogsForApp(context.Context, logr.Logger, authorization.Info, repositories.RuntimeLogsMessage) ([]repositories.LogRecord, error)
+	ListPods(context.Context, authorization.Info, string, client.MatchingLabels) ([]corev1.Pod, error)
 }
 
 //counterfeiter:generate -o fake -fake-name CFDomainRepository . CFDomainRepository
@@ -55,3 +71,11 @@ type CFRouteRepository interface {
 	AddDestinationsToRoute(ctx context.Context, c authorization.Info, message repositories.AddDestinationsToRouteMessage) (repositories.RouteRecord, error)
 	RemoveDestinationFromRoute(ctx context.Context, authInfo authorization.Info, message repositories.RemoveDestinationFromRouteMessage) (repositories.RouteRecord, error)
 }
+
+//counterfeiter:generate -o fake -fake-name CFSidecarRepository . CFSidecarRepository
+
+type CFSidecarRepository interface {
+	ListSidecars(context.Context, authorization.Info, repositories.ListSidecarsMessage) ([]repositories.SidecarRecord, error)
+	CreateSidecar(context.Context, authorization.Info, repositories.CreateSidecarMessage) (repositories.SidecarRecord, error)
+	PatchSidecar(context.Context, authorization.Info, repositories.PatchSidecarMessage) (repositories.SidecarRecord, error)
+}
@@ -0,0 +1,130 @@
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"code.cloudfoundry.org/korifi/api/actions/shared"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type (
+	SSHEnabledRecord struct {
+		Enabled bool
+		Reason  string
+	}
+
+	// SSHTarget is the container that an SSH session to a process instance
+	// is bridged to
+	SSHTarget struct {
+		Namespace string
+		PodName   string
+		Container string
+	}
+
+	SSHAccess struct {
+		processRepo        shared.CFProcessRepository
+		appRepo            shared.CFAppRepository
+		spaceRepo          shared.CFSpaceRepository
+		podRepo            shared.PodRepository
+		featureFlagChecker shared.FeatureFlagChecker
+	}
+)
+
+func NewSSHAccess(
+	processRepo shared.CFProcessRepository,
+	appRepo shared.CFAppRepository,
+	spaceRepo shared.CFSpaceRepository,
+	podRepo shared.PodRepository,
+	featureFlagChecker shared.FeatureFlagChecker,
+) *SSHAccess {
+	return &SSHAccess{
+		processRepo:        processRepo,
+		appRepo:            appRepo,
+		spaceRepo:          spaceRepo,
+		podRepo:            podRepo,
+		featureFlagChecker: featureFlagChecker,
+	}
+}
+
+// SSHEnabled reports whether SSH is enabled for the app, which requires SSH
+// to be enabled globally, for the space of the app and for the app itself
+func (a *SSHAccess) SSHEnabled(ctx context.Context, authInfo authorization.Info, app repositories.AppRecord) (SSHEnabledRecord, error) {
+	err := a.featureFlagChecker.CheckFeatureFlag(ctx, authInfo, repositories.FeatureFlagAppSSHAccess)
+	if errors.As(err, new(apierrors.FeatureDisabledError)) {
+		return SSHEnabledRecord{Enabled: false, Reason: "Disabled globally"}, nil
+	}
+	if err != nil {
+		return SSHEnabledRecord{}, err
+	}
+
+	space, err := a.spaceRepo.GetSpace(ctx, authInfo, app.SpaceGUID)
+	if err != nil {
+		return SSHEnabledRecord{}, err
+	}
+	if !space.SSHEnabled {
+		return SSHEnabledRecord{Enabled: false, Reason: fmt.Sprintf("Disabled for space %s", space.Name)}, nil
+	}
+
+	if !app.SSHEnabled {
+		return SSHEnabledRecord{Enabled: false, Reason: "Disabled for app"}, nil
+	}
+
+	return SSHEnabledRecord{Enabled: true, Reason: ""}, nil
+}
+
+// Target finds the container of the given process instance, provided that
+// SSH is enabled for its app
+func (a *SSHAccess) Target(ctx context.Context, authInfo authorization.Info, processGUID string, index int) (SSHTarget, error) {
+	process, err := a.processRepo.GetProcess(ctx, authInfo, processGUID)
+	if err != nil {
+		return SSHTarget{}, err
+	}
+
+	app, err := a.appRepo.GetApp(ctx, authInfo, process.AppGUID)
+	if err != nil {
+		return SSHTarget{}, err
+	}
+
+	sshEnabled, err := a.SSHEnabled(ctx, authInfo, app)
+	if err != nil {
+		return SSHTarget{}, err
+	}
+	if !sshEnabled.Enabled {
+		return SSHTarget{}, apierrors.NewForbiddenError(fmt.Errorf("ssh is not enabled: %s", sshEnabled.Reason), repositories.AppResourceType)
+	}
+
+	pods, err := a.podRepo.ListPods(ctx, authInfo, app.SpaceGUID, client.MatchingLabels{
+		korifiv1alpha1.CFAppGUIDLabelKey: app.GUID,
+		LabelVersion:                     app.Revision,
+		LabelGUID:                        processGUID,
+	})
+	if err != nil {
+		return SSHTarget{}, err
+	}
+
+	for _, pod := range pods {
+		if pod.Status.Phase != corev1.PodRunning {
+			continue
+		}
+
+		podIndex, err := extractIndex(pod)
+		if err != nil || podIndex != index {
+			continue
+		}
+
+		return SSHTarget{
+			Namespace: pod.Namespace,
+			PodName:   pod.Name,
+			Container: ApplicationContainerName,
+		}, nil
+	}
+
+	return SSHTarget{}, apierrors.NewNotFoundError(fmt.Errorf("instance %d of process %q is not running", index, processGUID), repositories.PodResourceType)
+}
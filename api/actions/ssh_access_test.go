@@ -0,0 +1,249 @@
+package actions_test
+
+import (
+	"context"
+	"errors"
+
+	. "code.cloudfoundry.org/korifi/api/actions"
+	sfake "code.cloudfoundry.org/korifi/api/actions/shared/fake"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("SSHAccess", func() {
+	var (
+		processRepo        *sfake.CFProcessRepository
+		appRepo            *sfake.CFAppRepository
+		spaceRepo          *sfake.CFSpaceRepository
+		podRepo            *sfake.PodRepository
+		featureFlagChecker *sfake.FeatureFlagChecker
+		authInfo           authorization.Info
+		app                repositories.AppRecord
+
+		sshAccess *SSHAccess
+	)
+
+	BeforeEach(func() {
+		processRepo = new(sfake.CFProcessRepository)
+		appRepo = new(sfake.CFAppRepository)
+		spaceRepo = new(sfake.CFSpaceRepository)
+		podRepo = new(sfake.PodRepository)
+		featureFlagChecker = new(sfake.FeatureFlagChecker)
+		authInfo = authorization.Info{Token: "a-token"}
+
+		app = repositories.AppRecord{
+			GUID:       "the-app-guid",
+			SpaceGUID:  "the-space-guid",
+			Revision:   "1",
+			SSHEnabled: true,
+		}
+		appRepo.GetAppReturns(app, nil)
+		spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+			GUID:       "the-space-guid",
+			Name:       "the-space",
+			SSHEnabled: true,
+		}, nil)
+
+		sshAccess = NewSSHAccess(processRepo, appRepo, spaceRepo, podRepo, featureFlagChecker)
+	})
+
+	Describe("SSHEnabled", func() {
+		var (
+			record repositories.AppRecord
+			result SSHEnabledRecord
+			err    error
+		)
+
+		BeforeEach(func() {
+			record = app
+		})
+
+		JustBeforeEach(func() {
+			result, err = sshAccess.SSHEnabled(context.Background(), authInfo, record)
+		})
+
+		It("is enabled", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(SSHEnabledRecord{Enabled: true}))
+
+			Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualFlag := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualFlag).To(Equal(repositories.FeatureFlagAppSSHAccess))
+
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, _, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(actualSpaceGUID).To(Equal("the-space-guid"))
+		})
+
+		When("ssh is disabled globally", func() {
+			BeforeEach(func() {
+				featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError(nil, repositories.FeatureFlagAppSSHAccess, ""))
+			})
+
+			It("is disabled globally", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(SSHEnabledRecord{Enabled: false, Reason: "Disabled globally"}))
+				Expect(spaceRepo.GetSpaceCallCount()).To(Equal(0))
+			})
+		})
+
+		When("checking the feature flag fails", func() {
+			BeforeEach(func() {
+				featureFlagChecker.CheckFeatureFlagReturns(errors.New("check-flag"))
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(MatchError("check-flag"))
+			})
+		})
+
+		When("ssh is disabled for the space", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{Name: "the-space", SSHEnabled: false}, nil)
+			})
+
+			It("is disabled for the space", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(SSHEnabledRecord{Enabled: false, Reason: "Disabled for space the-space"}))
+			})
+		})
+
+		When("getting the space fails", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, errors.New("get-space"))
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(MatchError("get-space"))
+			})
+		})
+
+		When("ssh is disabled for the app", func() {
+			BeforeEach(func() {
+				record.SSHEnabled = false
+			})
+
+			It("is disabled for the app", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(SSHEnabledRecord{Enabled: false, Reason: "Disabled for app"}))
+			})
+		})
+	})
+
+	Describe("Target", func() {
+		var (
+			target SSHTarget
+			err    error
+		)
+
+		BeforeEach(func() {
+			processRepo.GetProcessReturns(repositories.ProcessRecord{
+				GUID:    "the-process-guid",
+				AppGUID: "the-app-guid",
+			}, nil)
+
+			pod0 := createPod("0", "1")
+			pod0.Name = "pod-0"
+			pod0.Namespace = "the-space-guid"
+			pod1 := createPod("1", "1")
+			pod1.Name = "pod-1"
+			pod1.Namespace = "the-space-guid"
+			podRepo.ListPodsReturns([]corev1.Pod{pod0, pod1}, nil)
+		})
+
+		JustBeforeEach(func() {
+			target, err = sshAccess.Target(context.Background(), authInfo, "the-process-guid", 1)
+		})
+
+		It("returns the application container of the instance", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(target).To(Equal(SSHTarget{
+				Namespace: "the-space-guid",
+				PodName:   "pod-1",
+				Container: "application",
+			}))
+
+			Expect(processRepo.GetProcessCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualProcessGUID := processRepo.GetProcessArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualProcessGUID).To(Equal("the-process-guid"))
+
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal("the-app-guid"))
+
+			Expect(podRepo.ListPodsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualNamespace, actualSelector := podRepo.ListPodsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualNamespace).To(Equal("the-space-guid"))
+			Expect(actualSelector).To(Equal(client.MatchingLabels{
+				korifiv1alpha1.CFAppGUIDLabelKey: "the-app-guid",
+				LabelVersionKey:                  "1",
+				cfProcessGuidKey:                 "the-process-guid",
+			}))
+		})
+
+		When("getting the process fails", func() {
+			BeforeEach(func() {
+				processRepo.GetProcessReturns(repositories.ProcessRecord{}, apierrors.NewNotFoundError(nil, repositories.ProcessResourceType))
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+
+		When("getting the app fails", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, errors.New("get-app"))
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(MatchError("get-app"))
+			})
+		})
+
+		When("ssh is not enabled", func() {
+			BeforeEach(func() {
+				featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError(nil, repositories.FeatureFlagAppSSHAccess, ""))
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+				Expect(podRepo.ListPodsCallCount()).To(Equal(0))
+			})
+		})
+
+		When("listing the pods fails", func() {
+			BeforeEach(func() {
+				podRepo.ListPodsReturns(nil, errors.New("list-pods"))
+			})
+
+			It("returns the error", func() {
+				Expect(err).To(MatchError("list-pods"))
+			})
+		})
+
+		When("the instance is not running", func() {
+			BeforeEach(func() {
+				pod0 := createPod("0", "1")
+				pod1 := createPod("1", "1")
+				pod1.Status.Phase = corev1.PodPending
+				podRepo.ListPodsReturns([]corev1.Pod{pod0, pod1}, nil)
+			})
+
+			It("returns a not found error", func() {
+				Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+})
@@ -38,7 +38,9 @@ func NewUnprivilegedClientFactory(config *rest.Config, mapper meta.RESTMapper, b
 	}
 }
 
-func (f UnprivilegedClientFactory) BuildClient(authInfo Info) (client.WithWatch, error) {
+// BuildConfig returns a rest config that authenticates with the token or
+// client certificate of the user
+func (f UnprivilegedClientFactory) BuildConfig(authInfo Info) (*rest.Config, error) {
 	config := rest.CopyConfig(f.config)
 
 	switch strings.ToLower(authInfo.Scheme()) {
@@ -63,6 +65,15 @@ func (f UnprivilegedClientFactory) BuildClient(authInfo Info) (client.WithWatch,
 		return nil, apierrors.NewNotAuthenticatedError(errors.New("unsupported Authorization header scheme"))
 	}
 
+	return config, nil
+}
+
+func (f UnprivilegedClientFactory) BuildClient(authInfo Info) (client.WithWatch, error) {
+	config, err := f.BuildConfig(authInfo)
+	if err != nil {
+		return nil, err
+	}
+
 	userClient, err := client.NewWithWatch(config, client.Options{
 		Scheme: scheme.Scheme,
 		Mapper: f.mapper,
@@ -91,28 +102,9 @@ func isForbidden(err error) bool {
 }
 
 func (f UnprivilegedClientFactory) BuildK8sClient(authInfo Info) (k8sclient.Interface, error) {
-	config := rest.CopyConfig(f.config)
-
-	switch strings.ToLower(authInfo.Scheme()) {
-	case BearerScheme:
-		config.BearerToken = authInfo.Token
-
-	case CertScheme:
-		certBlock, rst := pem.Decode(authInfo.CertData)
-		if certBlock == nil {
-			return nil, fmt.Errorf("failed to decode cert PEM")
-		}
-
-		keyBlock, _ := pem.Decode(rst)
-		if keyBlock == nil {
-			return nil, fmt.Errorf("failed to decode key PEM")
-		}
-
-		config.CertData = pem.EncodeToMemory(certBlock)
-		config.KeyData = pem.EncodeToMemory(keyBlock)
-
-	default:
-		return nil, apierrors.NewNotAuthenticatedError(errors.New("unsupported Authorization header scheme"))
+	config, err := f.BuildConfig(authInfo)
+	if err != nil {
+		return nil, err
 	}
 
 	userK8sClient, err := k8sclient.NewForConfig(config)
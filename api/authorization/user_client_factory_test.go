@@ -202,6 +202,50 @@ var _ = Describe("Unprivileged User Client Factory", func() {
 		})
 	})
 
+	Describe("BuildConfig", func() {
+		var (
+			config   *rest.Config
+			buildErr error
+		)
+
+		JustBeforeEach(func() {
+			config, buildErr = clientFactory.BuildConfig(authInfo)
+		})
+
+		When("the auth info has a token", func() {
+			BeforeEach(func() {
+				authInfo.Token = "the-token"
+			})
+
+			It("authenticates with the token only", func() {
+				Expect(buildErr).NotTo(HaveOccurred())
+				Expect(config.BearerToken).To(Equal("the-token"))
+				Expect(config.CertData).To(BeEmpty())
+				Expect(config.Host).To(Equal(k8sConfig.Host))
+			})
+		})
+
+		When("the auth info has a certificate", func() {
+			BeforeEach(func() {
+				cert, key := testhelpers.ObtainClientCert(testEnv, userName)
+				authInfo.CertData = testhelpers.JoinCertAndKey(cert, key)
+			})
+
+			It("authenticates with the certificate only", func() {
+				Expect(buildErr).NotTo(HaveOccurred())
+				Expect(config.BearerToken).To(BeEmpty())
+				Expect(config.CertData).NotTo(BeEmpty())
+				Expect(config.KeyData).NotTo(BeEmpty())
+			})
+		})
+
+		When("auth info is empty", func() {
+			It("fails", func() {
+				Expect(buildErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotAuthenticatedError{}))
+			})
+		})
+	})
+
 	Context("bad auth info content", func() {
 		When("auth info is empty", func() {
 			BeforeEach(func() {
@@ -12,9 +12,10 @@ import (
 )
 
 const (
-	defaultExternalProtocol           = "https"
-	OrgRole                 RoleLevel = "org"
-	SpaceRole               RoleLevel = "space"
+	defaultExternalProtocol              = "https"
+	defaultSSHProxyOAuthClient           = "ssh-proxy"
+	OrgRole                    RoleLevel = "org"
+	SpaceRole                  RoleLevel = "space"
 )
 
 type (
@@ -45,6 +46,8 @@ type (
 		AuthProxyHost   string        `yaml:"authProxyHost"`
 		AuthProxyCACert string        `yaml:"authProxyCACert"`
 		LogLevel        zapcore.Level `yaml:"logLevel"`
+
+		SSHProxy SSHProxyConfig `yaml:"sshProxy"`
 	}
 
 	RoleLevel string
@@ -61,6 +64,14 @@ type (
 		Stack           string `yaml:"stack"`
 		StagingMemoryMB int    `yaml:"stagingMemoryMB"`
 	}
+
+	// SSHProxyConfig describes the SSH proxy to the CLI. SSH is not
+	// supported when the endpoint is empty.
+	SSHProxyConfig struct {
+		Endpoint           string `yaml:"endpoint"`
+		HostKeyFingerprint string `yaml:"hostKeyFingerprint"`
+		OAuthClient        string `yaml:"oauthClient"`
+	}
 )
 
 func LoadFromPath(path string) (*APIConfig, error) {
@@ -75,6 +86,10 @@ func LoadFromPath(path string) (*APIConfig, error) {
 		return nil, err
 	}
 
+	if config.SSHProxy.OAuthClient == "" {
+		config.SSHProxy.OAuthClient = defaultSSHProxyOAuthClient
+	}
+
 	config.ServerURL, err = config.composeServerURL()
 	if err != nil {
 		return nil, err
@@ -115,6 +130,10 @@ func (c *APIConfig) validate() error {
 		return errors.New("BuilderName must have a value")
 	}
 
+	if c.SSHProxy.Endpoint != "" && c.SSHProxy.HostKeyFingerprint == "" {
+		return errors.New("SSHProxy.Endpoint requires a value for SSHProxy.HostKeyFingerprint")
+	}
+
 	return nil
 }
 
@@ -195,4 +195,34 @@ var _ = Describe("Config", func() {
 			Expect(cfg.ServerURL).To(Equal("https://api.foo:1234"))
 		})
 	})
+
+	When("the ssh proxy is configured", func() {
+		BeforeEach(func() {
+			configMap["sshProxy"] = map[string]string{
+				"endpoint":           "ssh.foo:2222",
+				"hostKeyFingerprint": "SHA256:abc",
+			}
+		})
+
+		It("defaults the oauth client", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(cfg.SSHProxy).To(Equal(config.SSHProxyConfig{
+				Endpoint:           "ssh.foo:2222",
+				HostKeyFingerprint: "SHA256:abc",
+				OAuthClient:        "ssh-proxy",
+			}))
+		})
+
+		When("the host key fingerprint is not set", func() {
+			BeforeEach(func() {
+				configMap["sshProxy"] = map[string]string{
+					"endpoint": "ssh.foo:2222",
+				}
+			})
+
+			It("returns an error", func() {
+				Expect(loadErr).To(MatchError("SSHProxy.Endpoint requires a value for SSHProxy.HostKeyFingerprint"))
+			})
+		})
+	})
 })
@@ -350,6 +350,46 @@ func NewRollingDeployNotSupportedError(runnerName string) RollingDeployNotSuppor
 	}
 }
 
+type ServiceInstanceOperationInProgressError struct {
+	apiError
+}
+
+func NewServiceInstanceOperationInProgressError(cause error, serviceInstanceName string) ServiceInstanceOperationInProgressError {
+	return ServiceInstanceOperationInProgressError{
+		apiError: apiError{
+			cause:      cause,
+			title:      "CF-AsyncServiceInstanceOperationInProgress",
+			detail:     fmt.Sprintf("An operation for service instance %s is in progress.", serviceInstanceName),
+			code:       60016,
+			httpStatus: http.StatusConflict,
+		},
+	}
+}
+
+type FeatureDisabledError struct {
+	apiError
+}
+
+// NewFeatureDisabledError reports a request rejected because of a disabled
+// feature flag. The custom error message of the flag, if any, replaces the
+// name of the flag in the detail
+func NewFeatureDisabledError(cause error, flagName string, customErrorMessage string) FeatureDisabledError {
+	message := flagName
+	if customErrorMessage != "" {
+		message = customErrorMessage
+	}
+
+	return FeatureDisabledError{
+		apiError: apiError{
+			cause:      cause,
+			title:      "CF-FeatureDisabled",
+			detail:     fmt.Sprintf("Feature Disabled: %s", message),
+			code:       330002,
+			httpStatus: http.StatusForbidden,
+		},
+	}
+}
+
 func FromK8sError(err error, resourceType string) error {
 	if webhookValidationError, ok := webhooks.WebhookErrorToValidationError(err); ok {
 		return NewUnprocessableEntityError(err, webhookValidationError.GetMessage())
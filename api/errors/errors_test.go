@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "github.com/onsi/ginkgo/v2"
@@ -318,6 +319,21 @@ var _ = Describe("invalid lists", func() {
 	})
 })
 
+var _ = Describe("FeatureDisabledError", func() {
+	It("names the disabled feature flag", func() {
+		err := apierrors.NewFeatureDisabledError(nil, "task_creation", "")
+		Expect(err.Title()).To(Equal("CF-FeatureDisabled"))
+		Expect(err.Code()).To(Equal(330002))
+		Expect(err.HttpStatus()).To(Equal(http.StatusForbidden))
+		Expect(err.Detail()).To(Equal("Feature Disabled: task_creation"))
+	})
+
+	It("prefers the custom error message of the flag", func() {
+		err := apierrors.NewFeatureDisabledError(nil, "task_creation", "no tasks today")
+		Expect(err.Detail()).To(Equal("Feature Disabled: no tasks today"))
+	})
+})
+
 type testApiError struct {
 	apierrors.ApiError
 }
@@ -35,7 +35,6 @@ const (
 	AppEnvVarsPath                    = "/v3/apps/{guid}/environment_variables"
 	AppEnvPath                        = "/v3/apps/{guid}/env"
 	AppPackagesPath                   = "/v3/apps/{guid}/packages"
-	AppSSHEnabledPath                 = "/v3/apps/{guid}/ssh_enabled"
 	invalidDropletMsg                 = "Unable to assign current droplet. Ensure the droplet exists and belongs to this app."
 
 	AppStartedState = "STARTED"
@@ -54,18 +53,23 @@ type CFAppRepository interface {
 	DeleteApp(context.Context, authorization.Info, repositories.DeleteAppMessage) error
 	GetAppEnv(context.Context, authorization.Info, string) (repositories.AppEnvRecord, error)
 	PatchApp(context.Context, authorization.Info, repositories.PatchAppMessage) (repositories.AppRecord, error)
+	PatchAppFeatures(context.Context, authorization.Info, repositories.PatchAppFeaturesMessage) (repositories.AppRecord, error)
 }
 
 type App struct {
-	serverURL        url.URL
-	appRepo          CFAppRepository
-	dropletRepo      CFDropletRepository
-	processRepo      CFProcessRepository
-	routeRepo        CFRouteRepository
-	domainRepo       CFDomainRepository
-	spaceRepo        CFSpaceRepository
-	packageRepo      CFPackageRepository
-	requestValidator RequestValidator
+	serverURL          url.URL
+	appRepo            CFAppRepository
+	dropletRepo        CFDropletRepository
+	processRepo        CFProcessRepository
+	routeRepo          CFRouteRepository
+	domainRepo         CFDomainRepository
+	spaceRepo          CFSpaceRepository
+	packageRepo        CFPackageRepository
+	envVarGroupRepo    CFEnvVarGroupRepository
+	stackChecker       StackChecker
+	featureFlagChecker FeatureFlagChecker
+	auditRecorder      AuditEventRecorder
+	requestValidator   RequestValidator
 }
 
 func NewApp(
@@ -77,18 +81,26 @@ func NewApp(
 	domainRepo CFDomainRepository,
 	spaceRepo CFSpaceRepository,
 	packageRepo CFPackageRepository,
+	envVarGroupRepo CFEnvVarGroupRepository,
+	stackChecker StackChecker,
+	featureFlagChecker FeatureFlagChecker,
+	auditRecorder AuditEventRecorder,
 	requestValidator RequestValidator,
 ) *App {
 	return &App{
-		serverURL:        serverURL,
-		appRepo:          appRepo,
-		dropletRepo:      dropletRepo,
-		processRepo:      processRepo,
-		routeRepo:        routeRepo,
-		domainRepo:       domainRepo,
-		spaceRepo:        spaceRepo,
-		packageRepo:      packageRepo,
-		requestValidator: requestValidator,
+		serverURL:          serverURL,
+		appRepo:            appRepo,
+		dropletRepo:        dropletRepo,
+		processRepo:        processRepo,
+		routeRepo:          routeRepo,
+		domainRepo:         domainRepo,
+		spaceRepo:          spaceRepo,
+		packageRepo:        packageRepo,
+		envVarGroupRepo:    envVarGroupRepo,
+		stackChecker:       stackChecker,
+		featureFlagChecker: featureFlagChecker,
+		auditRecorder:      auditRecorder,
+		requestValidator:   requestValidator,
 	}
 }
 
@@ -123,6 +135,16 @@ func (h *App) create(r *http.Request) (*routing.Response, error) {
 		)
 	}
 
+	if payload.Lifecycle != nil && payload.Lifecycle.Type == string(korifiv1alpha1.DockerLifecycle) {
+		if err = h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, repositories.FeatureFlagDiegoDocker); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "docker apps are disabled")
+		}
+	} else if payload.Lifecycle != nil {
+		if err = h.stackChecker.CheckStack(r.Context(), authInfo, payload.Lifecycle.Data.Stack); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "invalid stack", "stack", payload.Lifecycle.Data.Stack)
+		}
+	}
+
 	appRecord, err := h.appRepo.CreateApp(r.Context(), authInfo, payload.ToAppCreateMessage())
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to create app", "App Name", payload.Name)
@@ -137,6 +159,14 @@ func (h *App) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to create web process", "App Name", payload.Name)
 	}
 
+	recordAuditEvent(r.Context(), logger, h.auditRecorder, authInfo, repositories.RecordAuditEventMessage{
+		Type:       korifiv1alpha1.AuditEventTypeAppCreate,
+		TargetGUID: appRecord.GUID,
+		TargetType: korifiv1alpha1.AuditEventTargetTypeApp,
+		TargetName: appRecord.Name,
+		SpaceGUID:  appRecord.SpaceGUID,
+	})
+
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForApp(appRecord, h.serverURL)), nil
 }
 
@@ -277,6 +307,14 @@ func (h *App) start(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to start app", "AppGUID", appGUID)
 	}
 
+	recordAuditEvent(r.Context(), logger, h.auditRecorder, authInfo, repositories.RecordAuditEventMessage{
+		Type:       korifiv1alpha1.AuditEventTypeAppStart,
+		TargetGUID: app.GUID,
+		TargetType: korifiv1alpha1.AuditEventTargetTypeApp,
+		TargetName: app.Name,
+		SpaceGUID:  app.SpaceGUID,
+	})
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForApp(app, h.serverURL)), nil
 }
 
@@ -382,6 +420,10 @@ func (h *App) scaleProcess(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode json payload")
 	}
 
+	if err := h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, repositories.FeatureFlagAppScaling); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "app scaling is disabled")
+	}
+
 	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "falied to get app")
@@ -526,12 +568,30 @@ func (h *App) getEnvironment(r *http.Request) (*routing.Response, error) {
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app.get-environment")
 	appGUID := routing.URLParam(r, "guid")
 
+	if err := h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, repositories.FeatureFlagEnvVarVisibility); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "env var visibility is disabled")
+	}
+
+	if err := h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, repositories.FeatureFlagSpaceDeveloperEnvVarVisibility); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "space developer env var visibility is disabled")
+	}
+
 	appEnvRecord, err := h.appRepo.GetAppEnv(r.Context(), authInfo, appGUID)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch app environment variables", "AppGUID", appGUID)
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAppEnv(appEnvRecord)), nil
+	runningEnvVarGroup, err := h.envVarGroupRepo.GetEnvVarGroup(r.Context(), authInfo, korifiv1alpha1.RunningEnvVarGroupName)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch running environment variable group")
+	}
+
+	stagingEnvVarGroup, err := h.envVarGroupRepo.GetEnvVarGroup(r.Context(), authInfo, korifiv1alpha1.StagingEnvVarGroupName)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to fetch staging environment variable group")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAppEnv(appEnvRecord, runningEnvVarGroup, stagingEnvVarGroup)), nil
 }
 
 func (h *App) getProcess(r *http.Request) (*routing.Response, error) {
@@ -592,6 +652,12 @@ func (h *App) update(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
 	}
 
+	if payload.Lifecycle != nil && payload.Lifecycle.Data != nil && payload.Lifecycle.Data.Stack != "" {
+		if err = h.stackChecker.CheckStack(r.Context(), authInfo, payload.Lifecycle.Data.Stack); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "invalid stack", "stack", payload.Lifecycle.Data.Stack)
+		}
+	}
+
 	app, err = h.appRepo.PatchApp(r.Context(), authInfo, payload.ToMessage(appGUID, app.SpaceGUID))
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to patch app", "AppGUID", appGUID)
@@ -599,13 +665,6 @@ func (h *App) update(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForApp(app, h.serverURL)), nil
 }
 
-func (h *App) getSSHEnabled(r *http.Request) (*routing.Response, error) {
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.AppSSHEnabled{
-		Enabled: false,
-		Reason:  "Disabled globally",
-	}), nil
-}
-
 func (h *App) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -629,6 +688,5 @@ func (h *App) AuthenticatedRoutes() []routing.Route {
 		{Method: "GET", Pattern: AppEnvPath, Handler: h.getEnvironment},
 		{Method: "GET", Pattern: AppPackagesPath, Handler: h.getPackages},
 		{Method: "PATCH", Pattern: AppPath, Handler: h.update},
-		{Method: "GET", Pattern: AppSSHEnabledPath, Handler: h.getSSHEnabled},
 	}
 }
@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/actions"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	AppFeaturesPath   = "/v3/apps/{guid}/features"
+	AppFeaturePath    = "/v3/apps/{guid}/features/{name}"
+	AppSSHEnabledPath = "/v3/apps/{guid}/ssh_enabled"
+)
+
+//counterfeiter:generate -o fake -fake-name SSHAccess . SSHAccess
+
+type SSHAccess interface {
+	SSHEnabled(context.Context, authorization.Info, repositories.AppRecord) (actions.SSHEnabledRecord, error)
+}
+
+type AppFeature struct {
+	serverURL        url.URL
+	appRepo          CFAppRepository
+	sshAccess        SSHAccess
+	requestValidator RequestValidator
+}
+
+func NewAppFeature(
+	serverURL url.URL,
+	appRepo CFAppRepository,
+	sshAccess SSHAccess,
+	requestValidator RequestValidator,
+) *AppFeature {
+	return &AppFeature{
+		serverURL:        serverURL,
+		appRepo:          appRepo,
+		sshAccess:        sshAccess,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *AppFeature) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app-feature.list")
+
+	appGUID := routing.URLParam(r, "guid")
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app", "guid", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAppFeatures(app, h.serverURL, *r.URL)), nil
+}
+
+func (h *AppFeature) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app-feature.get")
+
+	appGUID := routing.URLParam(r, "guid")
+	featureName := routing.URLParam(r, "name")
+
+	if err := validateAppFeatureName(featureName); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "unknown app feature", "name", featureName)
+	}
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app", "guid", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAppFeature(app, featureName)), nil
+}
+
+func (h *AppFeature) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app-feature.update")
+
+	appGUID := routing.URLParam(r, "guid")
+	featureName := routing.URLParam(r, "name")
+
+	if err := validateAppFeatureName(featureName); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "unknown app feature", "name", featureName)
+	}
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app", "guid", appGUID)
+	}
+
+	var payload payloads.AppFeatureUpdate
+	if err = h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	app, err = h.appRepo.PatchAppFeatures(r.Context(), authInfo, payload.ToMessage(app.GUID, app.SpaceGUID, featureName))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to update app feature", "guid", appGUID, "name", featureName)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAppFeature(app, featureName)), nil
+}
+
+func (h *AppFeature) getSSHEnabled(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.app-feature.get-ssh-enabled")
+
+	appGUID := routing.URLParam(r, "guid")
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app", "guid", appGUID)
+	}
+
+	sshEnabled, err := h.sshAccess.SSHEnabled(r.Context(), authInfo, app)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to check whether ssh is enabled", "guid", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAppSSHEnabled(sshEnabled)), nil
+}
+
+func validateAppFeatureName(name string) error {
+	if name != repositories.AppFeatureSSH && name != repositories.AppFeatureRevisions {
+		return apierrors.NewNotFoundError(fmt.Errorf("unknown app feature %q", name), repositories.FeatureResourceType)
+	}
+
+	return nil
+}
+
+func (h *AppFeature) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *AppFeature) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: AppFeaturesPath, Handler: h.list},
+		{Method: "GET", Pattern: AppFeaturePath, Handler: h.get},
+		{Method: "PATCH", Pattern: AppFeaturePath, Handler: h.update},
+		{Method: "GET", Pattern: AppSSHEnabledPath, Handler: h.getSSHEnabled},
+	}
+}
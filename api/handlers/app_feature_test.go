@@ -0,0 +1,258 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/actions"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AppFeature", func() {
+	var (
+		apiHandler       *handlers.AppFeature
+		appRepo          *fake.CFAppRepository
+		sshAccess        *fake.SSHAccess
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		appRepo = new(fake.CFAppRepository)
+		appRepo.GetAppReturns(repositories.AppRecord{
+			GUID:             "app-guid",
+			SpaceGUID:        spaceGUID,
+			SSHEnabled:       true,
+			RevisionsEnabled: false,
+		}, nil)
+		sshAccess = new(fake.SSHAccess)
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler = handlers.NewAppFeature(
+			*serverURL,
+			appRepo,
+			sshAccess,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/apps/{guid}/features", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/apps/app-guid/features", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the app features", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualAppGUID).To(Equal("app-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].name", "ssh"),
+				MatchJSONPath("$.resources[0].enabled", BeTrue()),
+				MatchJSONPath("$.resources[1].name", "revisions"),
+				MatchJSONPath("$.resources[1].enabled", BeFalse()),
+			)))
+		})
+
+		When("getting the app fails", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/apps/{guid}/features/{name}", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/apps/app-guid/features/ssh", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the app feature", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "ssh"),
+				MatchJSONPath("$.description", "Enable SSHing into the app."),
+				MatchJSONPath("$.enabled", BeTrue()),
+			)))
+		})
+
+		When("the feature is unknown", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequestWithContext(ctx, "GET", "/v3/apps/app-guid/features/fly", nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns a not found error", func() {
+				Expect(appRepo.GetAppCallCount()).To(Equal(0))
+				expectNotFoundError(repositories.FeatureResourceType)
+			})
+		})
+
+		When("getting the app fails", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, errors.New("get-app"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("PATCH /v3/apps/{guid}/features/{name}", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.AppFeatureUpdate{
+				Enabled: tools.PtrTo(true),
+			})
+
+			appRepo.PatchAppFeaturesReturns(repositories.AppRecord{
+				GUID:             "app-guid",
+				RevisionsEnabled: true,
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/apps/app-guid/features/revisions", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates the app feature", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(appRepo.PatchAppFeaturesCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := appRepo.PatchAppFeaturesArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.PatchAppFeaturesMessage{
+				AppGUID:          "app-guid",
+				SpaceGUID:        spaceGUID,
+				RevisionsEnabled: tools.PtrTo(true),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "revisions"),
+				MatchJSONPath("$.enabled", BeTrue()),
+			)))
+		})
+
+		When("the feature is unknown", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/apps/app-guid/features/fly", strings.NewReader("the-json-body"))
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns a not found error", func() {
+				Expect(appRepo.PatchAppFeaturesCallCount()).To(Equal(0))
+				expectNotFoundError(repositories.FeatureResourceType)
+			})
+		})
+
+		When("the app cannot be found", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				Expect(appRepo.PatchAppFeaturesCallCount()).To(Equal(0))
+				expectNotFoundError(repositories.AppResourceType)
+			})
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				Expect(appRepo.PatchAppFeaturesCallCount()).To(Equal(0))
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("patching the app fails", func() {
+			BeforeEach(func() {
+				appRepo.PatchAppFeaturesReturns(repositories.AppRecord{}, errors.New("patch-app"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/apps/{guid}/ssh_enabled", func() {
+		BeforeEach(func() {
+			sshAccess.SSHEnabledReturns(actions.SSHEnabledRecord{Enabled: false, Reason: "Disabled for space my-space"}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/apps/app-guid/ssh_enabled", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns whether ssh is enabled for the app", func() {
+			Expect(sshAccess.SSHEnabledCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualApp := sshAccess.SSHEnabledArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualApp.GUID).To(Equal("app-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.enabled", BeFalse()),
+				MatchJSONPath("$.reason", "Disabled for space my-space"),
+			)))
+		})
+
+		When("checking whether ssh is enabled fails", func() {
+			BeforeEach(func() {
+				sshAccess.SSHEnabledReturns(actions.SSHEnabledRecord{}, errors.New("ssh-enabled"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("the app cannot be found", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				Expect(sshAccess.SSHEnabledCallCount()).To(Equal(0))
+				expectNotFoundError(repositories.AppResourceType)
+			})
+		})
+	})
+})
@@ -30,15 +30,19 @@ const (
 
 var _ = Describe("App", func() {
 	var (
-		appRepo          *fake.CFAppRepository
-		dropletRepo      *fake.CFDropletRepository
-		processRepo      *fake.CFProcessRepository
-		routeRepo        *fake.CFRouteRepository
-		domainRepo       *fake.CFDomainRepository
-		spaceRepo        *fake.CFSpaceRepository
-		packageRepo      *fake.CFPackageRepository
-		requestValidator *fake.RequestValidator
-		req              *http.Request
+		appRepo            *fake.CFAppRepository
+		dropletRepo        *fake.CFDropletRepository
+		processRepo        *fake.CFProcessRepository
+		routeRepo          *fake.CFRouteRepository
+		domainRepo         *fake.CFDomainRepository
+		spaceRepo          *fake.CFSpaceRepository
+		packageRepo        *fake.CFPackageRepository
+		envVarGroupRepo    *fake.CFEnvVarGroupRepository
+		stackChecker       *fake.StackChecker
+		featureFlagChecker *fake.FeatureFlagChecker
+		auditRecorder      *fake.AuditEventRecorder
+		requestValidator   *fake.RequestValidator
+		req                *http.Request
 
 		appRecord repositories.AppRecord
 	)
@@ -51,6 +55,10 @@ var _ = Describe("App", func() {
 		domainRepo = new(fake.CFDomainRepository)
 		spaceRepo = new(fake.CFSpaceRepository)
 		packageRepo = new(fake.CFPackageRepository)
+		envVarGroupRepo = new(fake.CFEnvVarGroupRepository)
+		stackChecker = new(fake.StackChecker)
+		featureFlagChecker = new(fake.FeatureFlagChecker)
+		auditRecorder = new(fake.AuditEventRecorder)
 		requestValidator = new(fake.RequestValidator)
 
 		apiHandler := NewApp(
@@ -62,6 +70,10 @@ var _ = Describe("App", func() {
 			domainRepo,
 			spaceRepo,
 			packageRepo,
+			envVarGroupRepo,
+			stackChecker,
+			featureFlagChecker,
+			auditRecorder,
 			requestValidator,
 		)
 
@@ -185,6 +197,90 @@ var _ = Describe("App", func() {
 			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
 		})
 
+		It("does not check the default stack", func() {
+			Expect(stackChecker.CheckStackCallCount()).To(BeZero())
+		})
+
+		It("records an app create audit event", func() {
+			Expect(auditRecorder.RecordAuditEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMsg := auditRecorder.RecordAuditEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMsg).To(Equal(repositories.RecordAuditEventMessage{
+				Type:       "audit.app.create",
+				TargetGUID: appGUID,
+				TargetType: "app",
+				TargetName: "test-app",
+				SpaceGUID:  spaceGUID,
+			}))
+		})
+
+		When("recording the audit event fails", func() {
+			BeforeEach(func() {
+				auditRecorder.RecordAuditEventReturns(repositories.AuditEventRecord{}, errors.New("record-err"))
+			})
+
+			It("still returns the App", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			})
+		})
+
+		When("the app has a lifecycle", func() {
+			BeforeEach(func() {
+				payload.Lifecycle = &payloads.Lifecycle{
+					Type: "buildpack",
+					Data: &payloads.LifecycleData{Stack: "io.buildpacks.stacks.jammy"},
+				}
+			})
+
+			It("checks the stack", func() {
+				Expect(stackChecker.CheckStackCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualStack := stackChecker.CheckStackArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualStack).To(Equal("io.buildpacks.stacks.jammy"))
+			})
+
+			When("the stack does not exist", func() {
+				BeforeEach(func() {
+					stackChecker.CheckStackReturns(apierrors.NewUnprocessableEntityError(nil, "Stack must be an existing stack"))
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("Stack must be an existing stack")
+					Expect(appRepo.CreateAppCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		When("the app uses the docker lifecycle", func() {
+			BeforeEach(func() {
+				payload.Lifecycle = &payloads.Lifecycle{Type: "docker"}
+			})
+
+			It("checks the diego_docker feature flag instead of the stack", func() {
+				Expect(stackChecker.CheckStackCallCount()).To(BeZero())
+
+				Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualFlag := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualFlag).To(Equal(repositories.FeatureFlagDiegoDocker))
+
+				Expect(appRepo.CreateAppCallCount()).To(Equal(1))
+				_, _, actualMsg := appRepo.CreateAppArgsForCall(0)
+				Expect(actualMsg.Lifecycle).To(Equal(repositories.Lifecycle{Type: "docker"}))
+			})
+
+			When("the diego_docker feature flag is disabled", func() {
+				BeforeEach(func() {
+					featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError(nil, repositories.FeatureFlagDiegoDocker, ""))
+				})
+
+				It("returns a feature disabled error", func() {
+					expectErrorResponse(http.StatusForbidden, "CF-FeatureDisabled", "Feature Disabled: diego_docker", 330002)
+					Expect(appRepo.CreateAppCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		When("creating the process fails", func() {
 			BeforeEach(func() {
 				processRepo.CreateProcessReturns(errors.New("create-process-err"))
@@ -463,6 +559,34 @@ var _ = Describe("App", func() {
 			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
 		})
 
+		It("checks the stack", func() {
+			Expect(stackChecker.CheckStackCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualStack := stackChecker.CheckStackArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualStack).To(Equal("cflinuxfs3"))
+		})
+
+		When("the stack does not exist", func() {
+			BeforeEach(func() {
+				stackChecker.CheckStackReturns(apierrors.NewUnprocessableEntityError(nil, "Stack must be an existing stack"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Stack must be an existing stack")
+				Expect(appRepo.PatchAppCallCount()).To(BeZero())
+			})
+		})
+
+		When("the payload does not change the stack", func() {
+			BeforeEach(func() {
+				payload.Lifecycle.Data.Stack = ""
+			})
+
+			It("does not check the stack", func() {
+				Expect(stackChecker.CheckStackCallCount()).To(BeZero())
+			})
+		})
+
 		It("returns the App in the response", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
@@ -711,6 +835,19 @@ var _ = Describe("App", func() {
 			)))
 		})
 
+		It("records an app start audit event", func() {
+			Expect(auditRecorder.RecordAuditEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMsg := auditRecorder.RecordAuditEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMsg).To(Equal(repositories.RecordAuditEventMessage{
+				Type:       "audit.app.start",
+				TargetGUID: appGUID,
+				TargetType: "app",
+				TargetName: "test-app",
+				SpaceGUID:  spaceGUID,
+			}))
+		})
+
 		When("getting the app is forbidden", func() {
 			BeforeEach(func() {
 				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
@@ -1006,6 +1143,23 @@ var _ = Describe("App", func() {
 			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
 			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
 		})
+
+		When("the app_scaling feature flag is disabled", func() {
+			BeforeEach(func() {
+				featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError(nil, repositories.FeatureFlagAppScaling, ""))
+			})
+
+			It("returns a feature disabled error", func() {
+				Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualFlag := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualFlag).To(Equal(repositories.FeatureFlagAppScaling))
+
+				expectErrorResponse(http.StatusForbidden, "CF-FeatureDisabled", "Feature Disabled: app_scaling", 330002)
+				Expect(processRepo.ScaleProcessCallCount()).To(BeZero())
+			})
+		})
+
 		When("the request body is invalid", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(errors.New("validation-err"), "validation error"))
@@ -1393,6 +1547,14 @@ var _ = Describe("App", func() {
 			appRepo.GetAppEnvReturns(repositories.AppEnvRecord{
 				EnvironmentVariables: map[string]string{"VAR": "VAL"},
 			}, nil)
+			envVarGroupRepo.GetEnvVarGroupReturnsOnCall(0, repositories.EnvVarGroupRecord{
+				Name:                 "running",
+				EnvironmentVariables: map[string]string{"GROUP": "running"},
+			}, nil)
+			envVarGroupRepo.GetEnvVarGroupReturnsOnCall(1, repositories.EnvVarGroupRecord{
+				Name:                 "staging",
+				EnvironmentVariables: map[string]string{"GROUP": "staging"},
+			}, nil)
 
 			req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/env", nil)
 		})
@@ -1407,6 +1569,52 @@ var _ = Describe("App", func() {
 			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.environment_variables.VAR", "VAL")))
 		})
 
+		It("returns the environment variable groups", func() {
+			Expect(envVarGroupRepo.GetEnvVarGroupCallCount()).To(Equal(2))
+			_, actualAuthInfo, actualName := envVarGroupRepo.GetEnvVarGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualName).To(Equal("running"))
+			_, _, actualName = envVarGroupRepo.GetEnvVarGroupArgsForCall(1)
+			Expect(actualName).To(Equal("staging"))
+
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.running_env_json.GROUP", "running"),
+				MatchJSONPath("$.staging_env_json.GROUP", "staging"),
+			)))
+		})
+
+		It("checks the env var visibility feature flags", func() {
+			Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(2))
+			_, actualAuthInfo, actualFlag := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualFlag).To(Equal(repositories.FeatureFlagEnvVarVisibility))
+			_, actualAuthInfo, actualFlag = featureFlagChecker.CheckFeatureFlagArgsForCall(1)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualFlag).To(Equal(repositories.FeatureFlagSpaceDeveloperEnvVarVisibility))
+		})
+
+		When("the env_var_visibility feature flag is disabled", func() {
+			BeforeEach(func() {
+				featureFlagChecker.CheckFeatureFlagReturnsOnCall(0, apierrors.NewFeatureDisabledError(nil, repositories.FeatureFlagEnvVarVisibility, ""))
+			})
+
+			It("returns a feature disabled error", func() {
+				expectErrorResponse(http.StatusForbidden, "CF-FeatureDisabled", "Feature Disabled: env_var_visibility", 330002)
+				Expect(appRepo.GetAppEnvCallCount()).To(BeZero())
+			})
+		})
+
+		When("the space_developer_env_var_visibility feature flag is disabled", func() {
+			BeforeEach(func() {
+				featureFlagChecker.CheckFeatureFlagReturnsOnCall(1, apierrors.NewFeatureDisabledError(nil, repositories.FeatureFlagSpaceDeveloperEnvVarVisibility, ""))
+			})
+
+			It("returns a feature disabled error", func() {
+				expectErrorResponse(http.StatusForbidden, "CF-FeatureDisabled", "Feature Disabled: space_developer_env_var_visibility", 330002)
+				Expect(appRepo.GetAppEnvCallCount()).To(BeZero())
+			})
+		})
+
 		When("there is an error fetching the app env", func() {
 			BeforeEach(func() {
 				appRepo.GetAppEnvReturns(repositories.AppEnvRecord{}, errors.New("unknown!"))
@@ -1416,6 +1624,16 @@ var _ = Describe("App", func() {
 				expectUnknownError()
 			})
 		})
+
+		When("there is an error fetching the environment variable groups", func() {
+			BeforeEach(func() {
+				envVarGroupRepo.GetEnvVarGroupReturnsOnCall(0, repositories.EnvVarGroupRecord{}, errors.New("unknown!"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
 	})
 
 	Describe("PATCH /v3/apps/:guid/environment_variables", func() {
@@ -1580,21 +1798,6 @@ var _ = Describe("App", func() {
 			})
 		})
 	})
-
-	Describe("GET /v3/apps/GUID/ssh_enabled", func() {
-		BeforeEach(func() {
-			req = createHttpRequest("GET", "/v3/apps/"+appGUID+"/ssh_enabled", nil)
-		})
-
-		It("returns false", func() {
-			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
-			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
-			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.enabled", BeFalse()),
-				MatchJSONPath("$.reason", Equal("Disabled globally")),
-			)))
-		})
-	})
 })
 
 func createHttpRequest(method string, url string, body io.Reader) *http.Request {
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"context"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+)
+
+const (
+	AppUsageEventsPath               = "/v3/app_usage_events"
+	AppUsageEventPath                = "/v3/app_usage_events/{guid}"
+	AppUsageEventsPurgeAndReseedPath = "/v3/app_usage_events/actions/destructively_purge_all_and_reseed"
+)
+
+//counterfeiter:generate -o fake -fake-name CFAppUsageEventRepository . CFAppUsageEventRepository
+type CFAppUsageEventRepository interface {
+	ListAppUsageEvents(context.Context, authorization.Info, repositories.ListAppUsageEventsMessage) ([]repositories.AppUsageEventRecord, error)
+	GetAppUsageEvent(context.Context, authorization.Info, string) (repositories.AppUsageEventRecord, error)
+	PurgeAndReseedAppUsageEvents(context.Context, authorization.Info) error
+}
+
+type AppUsageEvent struct {
+	usageEvents[repositories.ListAppUsageEventsMessage, repositories.AppUsageEventRecord, presenter.AppUsageEventResponse]
+}
+
+func NewAppUsageEvent(
+	serverURL url.URL,
+	appUsageEventRepo CFAppUsageEventRepository,
+	requestValidator RequestValidator,
+) *AppUsageEvent {
+	return &AppUsageEvent{
+		usageEvents: usageEvents[repositories.ListAppUsageEventsMessage, repositories.AppUsageEventRecord, presenter.AppUsageEventResponse]{
+			serverURL:        serverURL,
+			requestValidator: requestValidator,
+			loggerName:       "handlers.app-usage-event",
+			eventsName:       "app usage events",
+			newListFilter: func() usageEventListFilter[repositories.ListAppUsageEventsMessage] {
+				return new(payloads.AppUsageEventList)
+			},
+			listEvents:     appUsageEventRepo.ListAppUsageEvents,
+			getEvent:       appUsageEventRepo.GetAppUsageEvent,
+			purgeAndReseed: appUsageEventRepo.PurgeAndReseedAppUsageEvents,
+			present:        presenter.ForAppUsageEvent,
+		},
+	}
+}
+
+func (h *AppUsageEvent) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *AppUsageEvent) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: AppUsageEventsPath, Handler: h.list},
+		{Method: "GET", Pattern: AppUsageEventPath, Handler: h.get},
+		{Method: "POST", Pattern: AppUsageEventsPurgeAndReseedPath, Handler: h.purgeAndReseedEvents},
+	}
+}
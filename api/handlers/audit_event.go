@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	AuditEventsPath = "/v3/audit_events"
+	AuditEventPath  = "/v3/audit_events/{guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFAuditEventRepository . CFAuditEventRepository
+type CFAuditEventRepository interface {
+	ListAuditEvents(context.Context, authorization.Info, repositories.ListAuditEventsMessage) ([]repositories.AuditEventRecord, error)
+	GetAuditEvent(context.Context, authorization.Info, string) (repositories.AuditEventRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name AuditEventRecorder . AuditEventRecorder
+type AuditEventRecorder interface {
+	RecordAuditEvent(context.Context, authorization.Info, repositories.RecordAuditEventMessage) (repositories.AuditEventRecord, error)
+}
+
+// recordAuditEvent records the event of a request that has already been
+// carried out, therefore failures are logged rather than returned
+func recordAuditEvent(ctx context.Context, logger logr.Logger, recorder AuditEventRecorder, authInfo authorization.Info, message repositories.RecordAuditEventMessage) {
+	if _, err := recorder.RecordAuditEvent(ctx, authInfo, message); err != nil {
+		logger.Info("failed to record audit event", "type", message.Type, "targetGUID", message.TargetGUID, "reason", err)
+	}
+}
+
+type AuditEvent struct {
+	serverURL        url.URL
+	auditEventRepo   CFAuditEventRepository
+	requestValidator RequestValidator
+}
+
+func NewAuditEvent(
+	serverURL url.URL,
+	auditEventRepo CFAuditEventRepository,
+	requestValidator RequestValidator,
+) *AuditEvent {
+	return &AuditEvent{
+		serverURL:        serverURL,
+		auditEventRepo:   auditEventRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *AuditEvent) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.audit-event.list")
+
+	listFilter := new(payloads.AuditEventList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, listFilter); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	auditEvents, err := h.auditEventRepo.ListAuditEvents(r.Context(), authInfo, listFilter.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list audit events")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForAuditEvent, auditEvents, h.serverURL, *r.URL)), nil
+}
+
+func (h *AuditEvent) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.audit-event.get")
+
+	auditEventGUID := routing.URLParam(r, "guid")
+
+	auditEvent, err := h.auditEventRepo.GetAuditEvent(r.Context(), authInfo, auditEventGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get audit event", "guid", auditEventGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForAuditEvent(auditEvent, h.serverURL)), nil
+}
+
+func (h *AuditEvent) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *AuditEvent) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: AuditEventsPath, Handler: h.list},
+		{Method: "GET", Pattern: AuditEventPath, Handler: h.get},
+	}
+}
@@ -0,0 +1,146 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditEvent", func() {
+	var (
+		apiHandler       *handlers.AuditEvent
+		auditEventRepo   *fake.CFAuditEventRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+		createdAt        time.Time
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		auditEventRepo = new(fake.CFAuditEventRepository)
+		createdAt = time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+
+		apiHandler = handlers.NewAuditEvent(
+			*serverURL,
+			auditEventRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/audit_events", func() {
+		BeforeEach(func() {
+			auditEventRepo.ListAuditEventsReturns([]repositories.AuditEventRecord{
+				{GUID: "event-1", Type: "audit.app.create", CreatedAt: createdAt},
+				{GUID: "event-2", Type: "audit.app.start", CreatedAt: createdAt},
+			}, nil)
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AuditEventList{
+				TargetGUIDs: "app-1,app-2",
+				Types:       "audit.app.create",
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/audit_events?target_guids=app-1,app-2&types=audit.app.create", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the audit events", func() {
+			Expect(auditEventRepo.ListAuditEventsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := auditEventRepo.ListAuditEventsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.TargetGUIDs).To(ConsistOf("app-1", "app-2"))
+			Expect(message.Types).To(ConsistOf("audit.app.create"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "event-1"),
+				MatchJSONPath("$.resources[0].type", "audit.app.create"),
+				MatchJSONPath("$.resources[1].guid", "event-2"),
+			)))
+		})
+
+		When("the query is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("listing the audit events fails", func() {
+			BeforeEach(func() {
+				auditEventRepo.ListAuditEventsReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/audit_events/:guid", func() {
+		BeforeEach(func() {
+			auditEventRepo.GetAuditEventReturns(repositories.AuditEventRecord{
+				GUID:      "event-guid",
+				Type:      "audit.app.create",
+				CreatedAt: createdAt,
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/audit_events/event-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the audit event", func() {
+			Expect(auditEventRepo.GetAuditEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := auditEventRepo.GetAuditEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("event-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "event-guid"),
+				MatchJSONPath("$.type", "audit.app.create"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/audit_events/event-guid"),
+			)))
+		})
+
+		When("the user is not authorized to get the audit event", func() {
+			BeforeEach(func() {
+				auditEventRepo.GetAuditEventReturns(repositories.AuditEventRecord{}, apierrors.NewForbiddenError(nil, repositories.AuditEventResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AuditEventResourceType)
+			})
+		})
+
+		When("getting the audit event fails", func() {
+			BeforeEach(func() {
+				auditEventRepo.GetAuditEventReturns(repositories.AuditEventRecord{}, errors.New("get-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
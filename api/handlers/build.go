@@ -32,6 +32,7 @@ type Build struct {
 	buildRepo        CFBuildRepository
 	packageRepo      CFPackageRepository
 	appRepo          CFAppRepository
+	stackChecker     StackChecker
 	requestValidator RequestValidator
 }
 
@@ -40,6 +41,7 @@ func NewBuild(
 	buildRepo CFBuildRepository,
 	packageRepo CFPackageRepository,
 	appRepo CFAppRepository,
+	stackChecker StackChecker,
 	requestValidator RequestValidator,
 ) *Build {
 	return &Build{
@@ -47,6 +49,7 @@ func NewBuild(
 		buildRepo:        buildRepo,
 		packageRepo:      packageRepo,
 		appRepo:          appRepo,
+		stackChecker:     stackChecker,
 		requestValidator: requestValidator,
 	}
 }
@@ -73,6 +76,12 @@ func (h *Build) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
 	}
 
+	if payload.Lifecycle != nil && payload.Lifecycle.Data != nil {
+		if err := h.stackChecker.CheckStack(r.Context(), authInfo, payload.Lifecycle.Data.Stack); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "invalid stack", "stack", payload.Lifecycle.Data.Stack)
+		}
+	}
+
 	packageRecord, err := h.packageRepo.GetPackage(r.Context(), authInfo, payload.Package.GUID)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(
@@ -30,6 +30,7 @@ var _ = Describe("Build", func() {
 		appRepo          *fake.CFAppRepository
 		buildRepo        *fake.CFBuildRepository
 		packageRepo      *fake.CFPackageRepository
+		stackChecker     *fake.StackChecker
 	)
 
 	BeforeEach(func() {
@@ -37,12 +38,14 @@ var _ = Describe("Build", func() {
 		appRepo = new(fake.CFAppRepository)
 		buildRepo = new(fake.CFBuildRepository)
 		packageRepo = new(fake.CFPackageRepository)
+		stackChecker = new(fake.StackChecker)
 
 		apiHandler = handlers.NewBuild(
 			*serverURL,
 			buildRepo,
 			packageRepo,
 			appRepo,
+			stackChecker,
 			requestValidator,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
@@ -279,6 +282,41 @@ var _ = Describe("Build", func() {
 			})
 		})
 
+		When("the build requests a stack", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.BuildCreate{
+					Package: &payloads.RelationshipData{
+						GUID: packageGUID,
+					},
+					Lifecycle: &payloads.Lifecycle{
+						Type: "buildpack",
+						Data: &payloads.LifecycleData{Stack: "io.buildpacks.stacks.jammy"},
+					},
+				})
+			})
+
+			It("builds the app on the stack", func() {
+				Expect(stackChecker.CheckStackCallCount()).To(Equal(1))
+				_, _, actualStack := stackChecker.CheckStackArgsForCall(0)
+				Expect(actualStack).To(Equal("io.buildpacks.stacks.jammy"))
+
+				Expect(buildRepo.CreateBuildCallCount()).To(Equal(1))
+				_, _, actualCreate := buildRepo.CreateBuildArgsForCall(0)
+				Expect(actualCreate.Lifecycle.Data.Stack).To(Equal("io.buildpacks.stacks.jammy"))
+			})
+
+			When("the stack does not exist", func() {
+				BeforeEach(func() {
+					stackChecker.CheckStackReturns(apierrors.NewUnprocessableEntityError(nil, "Stack must be an existing stack"))
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("Stack must be an existing stack")
+					Expect(buildRepo.CreateBuildCallCount()).To(Equal(0))
+				})
+			})
+		})
+
 		When("creating the build in the repo errors", func() {
 			BeforeEach(func() {
 				buildRepo.CreateBuildReturns(repositories.BuildRecord{}, errors.New("boom"))
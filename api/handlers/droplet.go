@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"compress/gzip"
 	"context"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 
@@ -14,10 +16,14 @@ import (
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 )
 
 const (
-	DropletPath = "/v3/droplets/{guid}"
+	DropletPath         = "/v3/droplets/{guid}"
+	DropletsPath        = "/v3/droplets"
+	DropletUploadPath   = "/v3/droplets/{guid}/upload"
+	DropletDownloadPath = "/v3/droplets/{guid}/download"
 )
 
 //counterfeiter:generate -o fake -fake-name CFDropletRepository . CFDropletRepository
@@ -25,23 +31,36 @@ type CFDropletRepository interface {
 	GetDroplet(context.Context, authorization.Info, string) (repositories.DropletRecord, error)
 	ListDroplets(context.Context, authorization.Info, repositories.ListDropletsMessage) ([]repositories.DropletRecord, error)
 	UpdateDroplet(context.Context, authorization.Info, repositories.UpdateDropletMessage) (repositories.DropletRecord, error)
+	CreateDroplet(context.Context, authorization.Info, repositories.CreateDropletMessage) (repositories.DropletRecord, error)
+	CopyDroplet(context.Context, authorization.Info, repositories.CopyDropletMessage) (repositories.DropletRecord, error)
+	UpdateDropletSource(context.Context, authorization.Info, repositories.UpdateDropletSourceMessage) (repositories.DropletRecord, error)
+	DeleteDroplet(context.Context, authorization.Info, repositories.DeleteDropletMessage) error
 }
 
 type Droplet struct {
-	serverURL        url.URL
-	dropletRepo      CFDropletRepository
-	requestValidator RequestValidator
+	serverURL           url.URL
+	dropletRepo         CFDropletRepository
+	appRepo             CFAppRepository
+	imageRepo           ImageRepository
+	requestValidator    RequestValidator
+	registrySecretNames []string
 }
 
 func NewDroplet(
 	serverURL url.URL,
 	dropletRepo CFDropletRepository,
+	appRepo CFAppRepository,
+	imageRepo ImageRepository,
 	requestValidator RequestValidator,
+	registrySecretNames []string,
 ) *Droplet {
 	return &Droplet{
-		serverURL:        serverURL,
-		dropletRepo:      dropletRepo,
-		requestValidator: requestValidator,
+		serverURL:           serverURL,
+		dropletRepo:         dropletRepo,
+		appRepo:             appRepo,
+		imageRepo:           imageRepo,
+		requestValidator:    requestValidator,
+		registrySecretNames: registrySecretNames,
 	}
 }
 
@@ -93,6 +112,236 @@ func (h *Droplet) update(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForDroplet(droplet, h.serverURL)), nil
 }
 
+func (h *Droplet) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.droplet.list")
+
+	dropletList := new(payloads.DropletList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, dropletList); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	droplets, err := h.dropletRepo.ListDroplets(r.Context(), authInfo, dropletList.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error fetching droplet list with repository")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForDroplet, droplets, h.serverURL, *r.URL)), nil
+}
+
+func (h *Droplet) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.droplet.create")
+
+	dropletCopy := new(payloads.DropletCopy)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, dropletCopy); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	var payload payloads.DropletCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	appRecord, err := h.appRepo.GetApp(r.Context(), authInfo, payload.Relationships.App.Data.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(
+				err,
+				"App is invalid. Ensure it exists and you have access to it.",
+				apierrors.NotFoundError{},
+				apierrors.ForbiddenError{},
+			),
+			"Error finding App",
+			"App GUID", payload.Relationships.App.Data.GUID,
+		)
+	}
+
+	if dropletCopy.SourceGUID != "" {
+		return h.copy(r.Context(), logger, authInfo, dropletCopy.SourceGUID, appRecord)
+	}
+
+	if appRecord.Lifecycle.Type == string(korifiv1alpha1.DockerLifecycle) {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Droplet creation is not supported for apps with 'docker' lifecycle."),
+			"cannot create droplet for docker app",
+			"App GUID", appRecord.GUID,
+		)
+	}
+
+	droplet, err := h.dropletRepo.CreateDroplet(r.Context(), authInfo, payload.ToMessage(appRecord))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error creating droplet with repository")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForDroplet(droplet, h.serverURL)), nil
+}
+
+// copy copies a staged droplet, including its image, to another app
+func (h *Droplet) copy(ctx context.Context, logger logr.Logger, authInfo authorization.Info, sourceGUID string, appRecord repositories.AppRecord) (*routing.Response, error) {
+	source, err := h.dropletRepo.GetDroplet(ctx, authInfo, sourceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(
+				err,
+				"Source droplet is invalid. Ensure it exists and you have access to it.",
+				apierrors.NotFoundError{},
+				apierrors.ForbiddenError{},
+			),
+			"Error finding source droplet",
+			"guid", sourceGUID,
+		)
+	}
+
+	if source.State != repositories.DropletStateStaged {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(nil, "Source droplet is not staged."), "cannot copy droplet", "guid", sourceGUID)
+	}
+
+	if source.Lifecycle.Type == string(korifiv1alpha1.DockerLifecycle) {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(nil, "Cannot copy droplets with 'docker' lifecycle."), "cannot copy droplet", "guid", sourceGUID)
+	}
+
+	droplet, err := h.dropletRepo.CopyDroplet(ctx, authInfo, repositories.CopyDropletMessage{
+		SourceGUID: sourceGUID,
+		AppGUID:    appRecord.GUID,
+		SpaceGUID:  appRecord.SpaceGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error copying droplet with repository")
+	}
+
+	copiedImageRef, err := h.imageRepo.CopyDropletImage(ctx, authInfo, source.Image, droplet.ImageRef, droplet.SpaceGUID, droplet.GUID)
+	if err != nil {
+		h.deleteDropletCopy(ctx, logger, authInfo, droplet)
+		return nil, apierrors.LogAndReturn(logger, err, "Error copying droplet image")
+	}
+
+	updatedDroplet, err := h.dropletRepo.UpdateDropletSource(ctx, authInfo, repositories.UpdateDropletSourceMessage{
+		GUID:                droplet.GUID,
+		SpaceGUID:           droplet.SpaceGUID,
+		ImageRef:            copiedImageRef,
+		RegistrySecretNames: h.registrySecretNames,
+	})
+	if err != nil {
+		h.deleteDropletCopy(ctx, logger, authInfo, droplet)
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling UpdateDropletSource")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForDroplet(updatedDroplet, h.serverURL)), nil
+}
+
+// deleteDropletCopy deletes a droplet copy that never got its image, as it
+// would otherwise stay around waiting for an upload
+func (h *Droplet) deleteDropletCopy(ctx context.Context, logger logr.Logger, authInfo authorization.Info, droplet repositories.DropletRecord) {
+	err := h.dropletRepo.DeleteDroplet(ctx, authInfo, repositories.DeleteDropletMessage{
+		GUID:      droplet.GUID,
+		SpaceGUID: droplet.SpaceGUID,
+	})
+	if err != nil {
+		logger.Info("failed to delete droplet copy", "guid", droplet.GUID, "reason", err)
+	}
+}
+
+func (h *Droplet) upload(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.droplet.upload")
+
+	dropletGUID := routing.URLParam(r, "guid")
+	err := r.ParseForm()
+	if err != nil { // untested - couldn't find a way to trigger this branch
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewInvalidRequestError(err, "Unable to parse body as multipart form"), "Error parsing multipart form")
+	}
+
+	bitsFile, _, err := r.FormFile("bits")
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(err, "Upload must include bits"), "Error reading form file \"bits\"")
+	}
+	defer bitsFile.Close()
+
+	droplet, err := h.dropletRepo.GetDroplet(r.Context(), authInfo, dropletGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error fetching droplet with repository")
+	}
+
+	if droplet.State != repositories.DropletStateAwaitingUpload {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "Droplet bits have already been uploaded."),
+			"Error, cannot call droplet upload state was not AWAITING_UPLOAD",
+			"dropletGUID", dropletGUID,
+		)
+	}
+
+	uploadedImageRef, err := h.imageRepo.UploadDropletImage(r.Context(), authInfo, droplet.ImageRef, bitsFile, droplet.SpaceGUID, dropletGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling UploadDropletImage")
+	}
+
+	droplet, err = h.dropletRepo.UpdateDropletSource(r.Context(), authInfo, repositories.UpdateDropletSourceMessage{
+		GUID:                dropletGUID,
+		SpaceGUID:           droplet.SpaceGUID,
+		ImageRef:            uploadedImageRef,
+		RegistrySecretNames: h.registrySecretNames,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error calling UpdateDropletSource")
+	}
+
+	return routing.NewResponse(http.StatusAccepted).
+		WithHeader("Location", presenter.JobURLForRedirects(dropletGUID, presenter.DropletUploadOperation, h.serverURL)).
+		WithBody(presenter.ForDroplet(droplet, h.serverURL)), nil
+}
+
+func (h *Droplet) download(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.droplet.download")
+
+	dropletGUID := routing.URLParam(r, "guid")
+
+	droplet, err := h.dropletRepo.GetDroplet(r.Context(), authInfo, dropletGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error fetching droplet with repository")
+	}
+
+	if droplet.Lifecycle.Type == string(korifiv1alpha1.DockerLifecycle) {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(nil, "Cannot download droplets with 'docker' lifecycle."), "cannot download droplet", "guid", dropletGUID)
+	}
+
+	if droplet.State != repositories.DropletStateStaged {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(nil, "Only staged droplets can be downloaded."), "cannot download droplet", "guid", dropletGUID)
+	}
+
+	imageReader, err := h.imageRepo.DownloadImage(r.Context(), droplet.Image)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error downloading droplet image")
+	}
+
+	return routing.NewResponse(http.StatusOK).
+		WithHeader("Content-Type", "application/gzip").
+		WithStream(gzipStream(imageReader)), nil
+}
+
+// gzipStream compresses the stream while it is being read
+func gzipStream(reader io.ReadCloser) io.ReadCloser {
+	pipeReader, pipeWriter := io.Pipe()
+
+	go func() {
+		defer reader.Close()
+
+		gzipWriter := gzip.NewWriter(pipeWriter)
+		_, err := io.Copy(gzipWriter, reader)
+		if closeErr := gzipWriter.Close(); err == nil {
+			err = closeErr
+		}
+		pipeWriter.CloseWithError(err)
+	}()
+
+	return pipeReader
+}
+
 func (h *Droplet) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -101,5 +350,9 @@ func (h *Droplet) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "GET", Pattern: DropletPath, Handler: h.get},
 		{Method: "PATCH", Pattern: DropletPath, Handler: h.update},
+		{Method: "GET", Pattern: DropletsPath, Handler: h.list},
+		{Method: "POST", Pattern: DropletsPath, Handler: h.create},
+		{Method: "POST", Pattern: DropletUploadPath, Handler: h.upload},
+		{Method: "GET", Pattern: DropletDownloadPath, Handler: h.download},
 	}
 }
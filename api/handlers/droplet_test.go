@@ -1,7 +1,11 @@
 package handlers_test
 
 import (
+	"bytes"
+	"compress/gzip"
 	"errors"
+	"io"
+	"mime/multipart"
 	"net/http"
 	"strings"
 	"time"
@@ -31,11 +35,15 @@ var _ = Describe("Droplet", func() {
 
 		requestValidator *fake.RequestValidator
 		dropletRepo      *fake.CFDropletRepository
+		appRepo          *fake.CFAppRepository
+		imageRepo        *fake.ImageRepository
 		req              *http.Request
 	)
 
 	BeforeEach(func() {
 		dropletRepo = new(fake.CFDropletRepository)
+		appRepo = new(fake.CFAppRepository)
+		imageRepo = new(fake.ImageRepository)
 		var err error
 		req, err = http.NewRequestWithContext(ctx, "GET", "/v3/droplets/"+dropletGUID, nil)
 		Expect(err).NotTo(HaveOccurred())
@@ -45,7 +53,10 @@ var _ = Describe("Droplet", func() {
 		apiHandler := NewDroplet(
 			*serverURL,
 			dropletRepo,
+			appRepo,
+			imageRepo,
 			requestValidator,
+			[]string{"registry-secret"},
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -240,4 +251,481 @@ var _ = Describe("Droplet", func() {
 			})
 		})
 	})
+
+	Describe("the GET /v3/droplets endpoint", func() {
+		BeforeEach(func() {
+			dropletRepo.ListDropletsReturns([]repositories.DropletRecord{
+				{GUID: "droplet-1", State: repositories.DropletStateStaged, AppGUID: appGUID},
+				{GUID: "droplet-2", State: repositories.DropletStateAwaitingUpload, AppGUID: appGUID},
+			}, nil)
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.DropletList{
+				AppGUIDs: appGUID,
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/droplets?app_guids="+appGUID, nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the droplets", func() {
+			Expect(dropletRepo.ListDropletsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := dropletRepo.ListDropletsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage.AppGUIDs).To(ConsistOf(appGUID))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "droplet-1"),
+				MatchJSONPath("$.resources[1].state", "AWAITING_UPLOAD"),
+			)))
+		})
+
+		When("the query is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "invalid query"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("invalid query")
+			})
+		})
+
+		When("listing the droplets fails", func() {
+			BeforeEach(func() {
+				dropletRepo.ListDropletsReturns(nil, errors.New("list-droplets"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the POST /v3/droplets endpoint", func() {
+		BeforeEach(func() {
+			appRepo.GetAppReturns(repositories.AppRecord{
+				GUID:      appGUID,
+				SpaceGUID: spaceGUID,
+				Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+			}, nil)
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.DropletCopy{})
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.DropletCreate{
+				Relationships: &payloads.DropletRelationships{
+					App: &payloads.Relationship{Data: &payloads.RelationshipData{GUID: appGUID}},
+				},
+				ProcessTypes: map[string]string{"web": "bundle exec rackup"},
+			})
+			dropletRepo.CreateDropletReturns(repositories.DropletRecord{
+				GUID:    dropletGUID,
+				State:   repositories.DropletStateAwaitingUpload,
+				AppGUID: appGUID,
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/droplets", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates a droplet awaiting upload", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal(appGUID))
+
+			Expect(dropletRepo.CreateDropletCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := dropletRepo.CreateDropletArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage.AppGUID).To(Equal(appGUID))
+			Expect(actualMessage.SpaceGUID).To(Equal(spaceGUID))
+			Expect(actualMessage.ProcessTypes).To(Equal(map[string]string{"web": "bundle exec rackup"}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", dropletGUID),
+				MatchJSONPath("$.state", "AWAITING_UPLOAD"),
+			)))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				Expect(dropletRepo.CreateDropletCallCount()).To(Equal(0))
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("the app cannot be found", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("App is invalid. Ensure it exists and you have access to it.")
+			})
+		})
+
+		When("the app uses the docker lifecycle", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{GUID: appGUID, Lifecycle: repositories.Lifecycle{Type: "docker"}}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(dropletRepo.CreateDropletCallCount()).To(Equal(0))
+				expectUnprocessableEntityError("Droplet creation is not supported for apps with 'docker' lifecycle.")
+			})
+		})
+
+		When("creating the droplet fails", func() {
+			BeforeEach(func() {
+				dropletRepo.CreateDropletReturns(repositories.DropletRecord{}, errors.New("create-droplet"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("a source droplet is given", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.DropletCopy{
+					SourceGUID: "source-droplet-guid",
+				})
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{
+					GUID:      "source-droplet-guid",
+					State:     repositories.DropletStateStaged,
+					Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+					Image:     "source-image",
+				}, nil)
+				dropletRepo.CopyDropletReturns(repositories.DropletRecord{
+					GUID:      dropletGUID,
+					SpaceGUID: spaceGUID,
+					State:     repositories.DropletStateAwaitingUpload,
+					ImageRef:  "droplets-repo",
+				}, nil)
+				imageRepo.CopyDropletImageReturns("droplets-repo@sha256:123", nil)
+				dropletRepo.UpdateDropletSourceReturns(repositories.DropletRecord{
+					GUID:  dropletGUID,
+					State: repositories.DropletStateProcessingUpload,
+				}, nil)
+			})
+
+			It("copies the droplet", func() {
+				Expect(dropletRepo.GetDropletCallCount()).To(Equal(1))
+				_, _, actualSourceGUID := dropletRepo.GetDropletArgsForCall(0)
+				Expect(actualSourceGUID).To(Equal("source-droplet-guid"))
+
+				Expect(dropletRepo.CopyDropletCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualMessage := dropletRepo.CopyDropletArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualMessage).To(Equal(repositories.CopyDropletMessage{
+					SourceGUID: "source-droplet-guid",
+					AppGUID:    appGUID,
+					SpaceGUID:  spaceGUID,
+				}))
+
+				Expect(imageRepo.CopyDropletImageCallCount()).To(Equal(1))
+				_, _, actualSrcRef, actualRef, actualSpaceGUID, actualTags := imageRepo.CopyDropletImageArgsForCall(0)
+				Expect(actualSrcRef).To(Equal("source-image"))
+				Expect(actualRef).To(Equal("droplets-repo"))
+				Expect(actualSpaceGUID).To(Equal(spaceGUID))
+				Expect(actualTags).To(ConsistOf(dropletGUID))
+
+				Expect(dropletRepo.UpdateDropletSourceCallCount()).To(Equal(1))
+				_, _, actualUpdate := dropletRepo.UpdateDropletSourceArgsForCall(0)
+				Expect(actualUpdate).To(Equal(repositories.UpdateDropletSourceMessage{
+					GUID:                dropletGUID,
+					SpaceGUID:           spaceGUID,
+					ImageRef:            "droplets-repo@sha256:123",
+					RegistrySecretNames: []string{"registry-secret"},
+				}))
+
+				Expect(dropletRepo.CreateDropletCallCount()).To(Equal(0))
+				Expect(dropletRepo.DeleteDropletCallCount()).To(Equal(0))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.guid", dropletGUID),
+					MatchJSONPath("$.state", "PROCESSING_UPLOAD"),
+				)))
+			})
+
+			When("the source droplet cannot be found", func() {
+				BeforeEach(func() {
+					dropletRepo.GetDropletReturns(repositories.DropletRecord{}, apierrors.NewForbiddenError(nil, repositories.DropletResourceType))
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Source droplet is invalid. Ensure it exists and you have access to it.")
+				})
+			})
+
+			When("the source droplet is not staged", func() {
+				BeforeEach(func() {
+					dropletRepo.GetDropletReturns(repositories.DropletRecord{State: repositories.DropletStateAwaitingUpload}, nil)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(dropletRepo.CopyDropletCallCount()).To(Equal(0))
+					expectUnprocessableEntityError("Source droplet is not staged.")
+				})
+			})
+
+			When("the source droplet uses the docker lifecycle", func() {
+				BeforeEach(func() {
+					dropletRepo.GetDropletReturns(repositories.DropletRecord{
+						State:     repositories.DropletStateStaged,
+						Lifecycle: repositories.Lifecycle{Type: "docker"},
+					}, nil)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(dropletRepo.CopyDropletCallCount()).To(Equal(0))
+					expectUnprocessableEntityError("Cannot copy droplets with 'docker' lifecycle.")
+				})
+			})
+
+			When("copying the image fails", func() {
+				BeforeEach(func() {
+					imageRepo.CopyDropletImageReturns("", apierrors.NewBlobstoreUnavailableError(errors.New("copy-failed")))
+				})
+
+				It("returns an error", func() {
+					Expect(dropletRepo.UpdateDropletSourceCallCount()).To(Equal(0))
+					expectBlobstoreUnavailableError()
+				})
+
+				It("deletes the droplet copy", func() {
+					Expect(dropletRepo.DeleteDropletCallCount()).To(Equal(1))
+					_, actualAuthInfo, actualMessage := dropletRepo.DeleteDropletArgsForCall(0)
+					Expect(actualAuthInfo).To(Equal(authInfo))
+					Expect(actualMessage).To(Equal(repositories.DeleteDropletMessage{
+						GUID:      dropletGUID,
+						SpaceGUID: spaceGUID,
+					}))
+				})
+
+				When("deleting the droplet copy fails", func() {
+					BeforeEach(func() {
+						dropletRepo.DeleteDropletReturns(errors.New("delete-failed"))
+					})
+
+					It("returns the copy error", func() {
+						expectBlobstoreUnavailableError()
+					})
+				})
+			})
+
+			When("updating the droplet source fails", func() {
+				BeforeEach(func() {
+					dropletRepo.UpdateDropletSourceReturns(repositories.DropletRecord{}, errors.New("update-source"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+
+				It("deletes the droplet copy", func() {
+					Expect(dropletRepo.DeleteDropletCallCount()).To(Equal(1))
+					_, _, actualMessage := dropletRepo.DeleteDropletArgsForCall(0)
+					Expect(actualMessage).To(Equal(repositories.DeleteDropletMessage{
+						GUID:      dropletGUID,
+						SpaceGUID: spaceGUID,
+					}))
+				})
+			})
+		})
+	})
+
+	Describe("the POST /v3/droplets/:guid/upload endpoint", func() {
+		newUploadRequest := func(writeForm func(*multipart.Writer)) *http.Request {
+			var body bytes.Buffer
+			writer := multipart.NewWriter(&body)
+			writeForm(writer)
+			Expect(writer.Close()).To(Succeed())
+
+			uploadReq, err := http.NewRequestWithContext(ctx, "POST", "/v3/droplets/"+dropletGUID+"/upload", &body)
+			Expect(err).NotTo(HaveOccurred())
+			uploadReq.Header.Add("Content-Type", writer.FormDataContentType())
+
+			return uploadReq
+		}
+
+		BeforeEach(func() {
+			dropletRepo.GetDropletReturns(repositories.DropletRecord{
+				GUID:      dropletGUID,
+				SpaceGUID: spaceGUID,
+				State:     repositories.DropletStateAwaitingUpload,
+				ImageRef:  "droplets-repo",
+			}, nil)
+			imageRepo.UploadDropletImageReturns("droplets-repo@sha256:123", nil)
+			dropletRepo.UpdateDropletSourceReturns(repositories.DropletRecord{
+				GUID:  dropletGUID,
+				State: repositories.DropletStateProcessingUpload,
+			}, nil)
+
+			req = newUploadRequest(func(writer *multipart.Writer) {
+				part, err := writer.CreateFormFile("bits", "droplet.tgz")
+				Expect(err).NotTo(HaveOccurred())
+				_, err = io.Copy(part, strings.NewReader("the-droplet-contents"))
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		It("uploads the droplet", func() {
+			Expect(imageRepo.UploadDropletImageCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualRef, srcFile, actualSpaceGUID, actualTags := imageRepo.UploadDropletImageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualRef).To(Equal("droplets-repo"))
+			Expect(io.ReadAll(srcFile)).To(Equal([]byte("the-droplet-contents")))
+			Expect(actualSpaceGUID).To(Equal(spaceGUID))
+			Expect(actualTags).To(ConsistOf(dropletGUID))
+
+			Expect(dropletRepo.UpdateDropletSourceCallCount()).To(Equal(1))
+			_, _, actualUpdate := dropletRepo.UpdateDropletSourceArgsForCall(0)
+			Expect(actualUpdate).To(Equal(repositories.UpdateDropletSourceMessage{
+				GUID:                dropletGUID,
+				SpaceGUID:           spaceGUID,
+				ImageRef:            "droplets-repo@sha256:123",
+				RegistrySecretNames: []string{"registry-secret"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/droplet.upload~"+dropletGUID))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.state", "PROCESSING_UPLOAD")))
+		})
+
+		When("no bits file is given", func() {
+			BeforeEach(func() {
+				req = newUploadRequest(func(*multipart.Writer) {})
+			})
+
+			It("returns an error", func() {
+				Expect(imageRepo.UploadDropletImageCallCount()).To(Equal(0))
+				expectUnprocessableEntityError("Upload must include bits")
+			})
+		})
+
+		When("the droplet cannot be found", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{}, apierrors.NewForbiddenError(nil, repositories.DropletResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.DropletResourceType)
+			})
+		})
+
+		When("the droplet has already been uploaded", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{State: repositories.DropletStateStaged}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(imageRepo.UploadDropletImageCallCount()).To(Equal(0))
+				expectUnprocessableEntityError("Droplet bits have already been uploaded.")
+			})
+		})
+
+		When("uploading the image fails", func() {
+			BeforeEach(func() {
+				imageRepo.UploadDropletImageReturns("", apierrors.NewBlobstoreUnavailableError(errors.New("push-failed")))
+			})
+
+			It("returns an error", func() {
+				Expect(dropletRepo.UpdateDropletSourceCallCount()).To(Equal(0))
+				expectBlobstoreUnavailableError()
+			})
+		})
+
+		When("updating the droplet source fails", func() {
+			BeforeEach(func() {
+				dropletRepo.UpdateDropletSourceReturns(repositories.DropletRecord{}, errors.New("update-source"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("the GET /v3/droplets/:guid/download endpoint", func() {
+		BeforeEach(func() {
+			dropletRepo.GetDropletReturns(repositories.DropletRecord{
+				GUID:      dropletGUID,
+				State:     repositories.DropletStateStaged,
+				Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+				Image:     "droplet-image",
+			}, nil)
+			imageRepo.DownloadImageReturns(io.NopCloser(strings.NewReader("the-droplet-contents")), nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/droplets/"+dropletGUID+"/download", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("streams the gzipped droplet", func() {
+			Expect(imageRepo.DownloadImageCallCount()).To(Equal(1))
+			_, actualRef := imageRepo.DownloadImageArgsForCall(0)
+			Expect(actualRef).To(Equal("droplet-image"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/gzip"))
+
+			gzipReader, err := gzip.NewReader(rr.Body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(io.ReadAll(gzipReader)).To(Equal([]byte("the-droplet-contents")))
+		})
+
+		When("the droplet cannot be found", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{}, apierrors.NewForbiddenError(nil, repositories.DropletResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.DropletResourceType)
+			})
+		})
+
+		When("the droplet uses the docker lifecycle", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{
+					State:     repositories.DropletStateStaged,
+					Lifecycle: repositories.Lifecycle{Type: "docker"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(imageRepo.DownloadImageCallCount()).To(Equal(0))
+				expectUnprocessableEntityError("Cannot download droplets with 'docker' lifecycle.")
+			})
+		})
+
+		When("the droplet is not staged", func() {
+			BeforeEach(func() {
+				dropletRepo.GetDropletReturns(repositories.DropletRecord{
+					State:     repositories.DropletStateAwaitingUpload,
+					Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(imageRepo.DownloadImageCallCount()).To(Equal(0))
+				expectUnprocessableEntityError("Only staged droplets can be downloaded.")
+			})
+		})
+
+		When("downloading the image fails", func() {
+			BeforeEach(func() {
+				imageRepo.DownloadImageReturns(nil, apierrors.NewBlobstoreUnavailableError(errors.New("download-failed")))
+			})
+
+			It("returns an error", func() {
+				expectBlobstoreUnavailableError()
+			})
+		})
+	})
 })
@@ -0,0 +1,149 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("EnvVarGroup", func() {
+	var (
+		apiHandler       *handlers.EnvVarGroup
+		envVarGroupRepo  *fake.CFEnvVarGroupRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		envVarGroupRepo = new(fake.CFEnvVarGroupRepository)
+
+		apiHandler = handlers.NewEnvVarGroup(
+			*serverURL,
+			envVarGroupRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/environment_variable_groups/:name", func() {
+		BeforeEach(func() {
+			envVarGroupRepo.GetEnvVarGroupReturns(repositories.EnvVarGroupRecord{
+				Name:                 "running",
+				EnvironmentVariables: map[string]string{"foo": "bar"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/environment_variable_groups/running", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the environment variable group", func() {
+			Expect(envVarGroupRepo.GetEnvVarGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualName := envVarGroupRepo.GetEnvVarGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualName).To(Equal("running"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "running"),
+				MatchJSONPath("$.var.foo", "bar"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/environment_variable_groups/running"),
+			)))
+		})
+
+		When("the environment variable group does not exist", func() {
+			BeforeEach(func() {
+				envVarGroupRepo.GetEnvVarGroupReturns(repositories.EnvVarGroupRecord{}, apierrors.NewNotFoundError(nil, repositories.EnvVarGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.EnvVarGroupResourceType)
+			})
+		})
+
+		When("getting the environment variable group fails", func() {
+			BeforeEach(func() {
+				envVarGroupRepo.GetEnvVarGroupReturns(repositories.EnvVarGroupRecord{}, errors.New("get-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("PATCH /v3/environment_variable_groups/:name", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.EnvVarGroupUpdate{
+				Var: map[string]interface{}{
+					"foo": "bar",
+					"baz": nil,
+				},
+			})
+
+			envVarGroupRepo.PatchEnvVarGroupReturns(repositories.EnvVarGroupRecord{
+				Name:                 "staging",
+				EnvironmentVariables: map[string]string{"foo": "bar"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/environment_variable_groups/staging", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates the environment variable group", func() {
+			Expect(envVarGroupRepo.PatchEnvVarGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := envVarGroupRepo.PatchEnvVarGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.PatchEnvVarGroupMessage{
+				Name: "staging",
+				EnvironmentVariables: map[string]*string{
+					"foo": tools.PtrTo("bar"),
+					"baz": nil,
+				},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "staging"),
+				MatchJSONPath("$.var.foo", "bar"),
+			)))
+		})
+
+		When("decoding the payload fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("the user is not allowed to update environment variable groups", func() {
+			BeforeEach(func() {
+				envVarGroupRepo.PatchEnvVarGroupReturns(repositories.EnvVarGroupRecord{}, apierrors.NewForbiddenError(nil, repositories.EnvVarGroupResourceType))
+			})
+
+			It("returns a forbidden error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+	})
+})
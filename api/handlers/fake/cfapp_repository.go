@@ -114,6 +114,21 @@ type CFAppRepository struct {
 		result1 repositories.AppEnvVarsRecord
 		result2 error
 	}
+	PatchAppFeaturesStub        func(context.Context, authorization.Info, repositories.PatchAppFeaturesMessage) (repositories.AppRecord, error)
+	patchAppFeaturesMutex       sync.RWMutex
+	patchAppFeaturesArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.PatchAppFeaturesMessage
+	}
+	patchAppFeaturesReturns struct {
+		result1 repositories.AppRecord
+		result2 error
+	}
+	patchAppFeaturesReturnsOnCall map[int]struct {
+		result1 repositories.AppRecord
+		result2 error
+	}
 	SetAppDesiredStateStub        func(context.Context, authorization.Info, repositories.SetAppDesiredStateMessage) (repositories.AppRecord, error)
 	setAppDesiredStateMutex       sync.RWMutex
 	setAppDesiredStateArgsForCall []struct {
@@ -607,6 +622,72 @@ func (fake *CFAppRepository) PatchAppEnvVarsReturnsOnCall(i int, result1 reposit
 	}{result1, result2}
 }
 
+func (fake *CFAppRepository) PatchAppFeatures(arg1 context.Context, arg2 authorization.Info, arg3 repositories.PatchAppFeaturesMessage) (repositories.AppRecord, error) {
+	fake.patchAppFeaturesMutex.Lock()
+	ret, specificReturn := fake.patchAppFeaturesReturnsOnCall[len(fake.patchAppFeaturesArgsForCall)]
+	fake.patchAppFeaturesArgsForCall = append(fake.patchAppFeaturesArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.PatchAppFeaturesMessage
+	}{arg1, arg2, arg3})
+	stub := fake.PatchAppFeaturesStub
+	fakeReturns := fake.patchAppFeaturesReturns
+	fake.recordInvocation("PatchAppFeatures", []interface{}{arg1, arg2, arg3})
+	fake.patchAppFeaturesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFAppRepository) PatchAppFeaturesCallCount() int {
+	fake.patchAppFeaturesMutex.RLock()
+	defer fake.patchAppFeaturesMutex.RUnlock()
+	return len(fake.patchAppFeaturesArgsForCall)
+}
+
+func (fake *CFAppRepository) PatchAppFeaturesCalls(stub func(context.Context, authorization.Info, repositories.PatchAppFeaturesMessage) (repositories.AppRecord, error)) {
+	fake.patchAppFeaturesMutex.Lock()
+	defer fake.patchAppFeaturesMutex.Unlock()
+	fake.PatchAppFeaturesStub = stub
+}
+
+func (fake *CFAppRepository) PatchAppFeaturesArgsForCall(i int) (context.Context, authorization.Info, repositories.PatchAppFeaturesMessage) {
+	fake.patchAppFeaturesMutex.RLock()
+	defer fake.patchAppFeaturesMutex.RUnlock()
+	argsForCall := fake.patchAppFeaturesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFAppRepository) PatchAppFeaturesReturns(result1 repositories.AppRecord, result2 error) {
+	fake.patchAppFeaturesMutex.Lock()
+	defer fake.patchAppFeaturesMutex.Unlock()
+	fake.PatchAppFeaturesStub = nil
+	fake.patchAppFeaturesReturns = struct {
+		result1 repositories.AppRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFAppRepository) PatchAppFeaturesReturnsOnCall(i int, result1 repositories.AppRecord, result2 error) {
+	fake.patchAppFeaturesMutex.Lock()
+	defer fake.patchAppFeaturesMutex.Unlock()
+	fake.PatchAppFeaturesStub = nil
+	if fake.patchAppFeaturesReturnsOnCall == nil {
+		fake.patchAppFeaturesReturnsOnCall = make(map[int]struct {
+			result1 repositories.AppRecord
+			result2 error
+		})
+	}
+	fake.patchAppFeaturesReturnsOnCall[i] = struct {
+		result1 repositories.AppRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFAppRepository) SetAppDesiredState(arg1 context.Context, arg2 authorization.Info, arg3 repositories.SetAppDesiredStateMessage) (repositories.AppRecord, error) {
 	fake.setAppDesiredStateMutex.Lock()
 	ret, specificReturn := fake.setAppDesiredStateReturnsOnCall[len(fake.setAppDesiredStateArgsForCall)]
@@ -756,6 +837,8 @@ func (fake *CFAppRepository) Invocations() map[string][][]interface{} {
 	defer fake.patchAppMutex.RUnlock()
 	fake.patchAppEnvVarsMutex.RLock()
 	defer fake.patchAppEnvVarsMutex.RUnlock()
+	fake.patchAppFeaturesMutex.RLock()
+	defer fake.patchAppFeaturesMutex.RUnlock()
 	fake.setAppDesiredStateMutex.RLock()
 	defer fake.setAppDesiredStateMutex.RUnlock()
 	fake.setCurrentDropletMutex.RLock()
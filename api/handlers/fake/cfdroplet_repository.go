@@ -11,6 +11,49 @@ import (
 )
 
 type CFDropletRepository struct {
+	CopyDropletStub        func(context.Context, authorization.Info, repositories.CopyDropletMessage) (repositories.DropletRecord, error)
+	copyDropletMutex       sync.RWMutex
+	copyDropletArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CopyDropletMessage
+	}
+	copyDropletReturns struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}
+	copyDropletReturnsOnCall map[int]struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}
+	CreateDropletStub        func(context.Context, authorization.Info, repositories.CreateDropletMessage) (repositories.DropletRecord, error)
+	createDropletMutex       sync.RWMutex
+	createDropletArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateDropletMessage
+	}
+	createDropletReturns struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}
+	createDropletReturnsOnCall map[int]struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}
+	DeleteDropletStub        func(context.Context, authorization.Info, repositories.DeleteDropletMessage) error
+	deleteDropletMutex       sync.RWMutex
+	deleteDropletArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.DeleteDropletMessage
+	}
+	deleteDropletReturns struct {
+		result1 error
+	}
+	deleteDropletReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetDropletStub        func(context.Context, authorization.Info, string) (repositories.DropletRecord, error)
 	getDropletMutex       sync.RWMutex
 	getDropletArgsForCall []struct {
@@ -56,10 +99,220 @@ type CFDropletRepository struct {
 		result1 repositories.DropletRecord
 		result2 error
 	}
+	UpdateDropletSourceStub        func(context.Context, authorization.Info, repositories.UpdateDropletSourceMessage) (repositories.DropletRecord, error)
+	updateDropletSourceMutex       sync.RWMutex
+	updateDropletSourceArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateDropletSourceMessage
+	}
+	updateDropletSourceReturns struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}
+	updateDropletSourceReturnsOnCall map[int]struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *CFDropletRepository) CopyDroplet(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CopyDropletMessage) (repositories.DropletRecord, error) {
+	fake.copyDropletMutex.Lock()
+	ret, specificReturn := fake.copyDropletReturnsOnCall[len(fake.copyDropletArgsForCall)]
+	fake.copyDropletArgsForCall = append(fake.copyDropletArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CopyDropletMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CopyDropletStub
+	fakeReturns := fake.copyDropletReturns
+	fake.recordInvocation("CopyDroplet", []interface{}{arg1, arg2, arg3})
+	fake.copyDropletMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFDropletRepository) CopyDropletCallCount() int {
+	fake.copyDropletMutex.RLock()
+	defer fake.copyDropletMutex.RUnlock()
+	return len(fake.copyDropletArgsForCall)
+}
+
+func (fake *CFDropletRepository) CopyDropletCalls(stub func(context.Context, authorization.Info, repositories.CopyDropletMessage) (repositories.DropletRecord, error)) {
+	fake.copyDropletMutex.Lock()
+	defer fake.copyDropletMutex.Unlock()
+	fake.CopyDropletStub = stub
+}
+
+func (fake *CFDropletRepository) CopyDropletArgsForCall(i int) (context.Context, authorization.Info, repositories.CopyDropletMessage) {
+	fake.copyDropletMutex.RLock()
+	defer fake.copyDropletMutex.RUnlock()
+	argsForCall := fake.copyDropletArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFDropletRepository) CopyDropletReturns(result1 repositories.DropletRecord, result2 error) {
+	fake.copyDropletMutex.Lock()
+	defer fake.copyDropletMutex.Unlock()
+	fake.CopyDropletStub = nil
+	fake.copyDropletReturns = struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFDropletRepository) CopyDropletReturnsOnCall(i int, result1 repositories.DropletRecord, result2 error) {
+	fake.copyDropletMutex.Lock()
+	defer fake.copyDropletMutex.Unlock()
+	fake.CopyDropletStub = nil
+	if fake.copyDropletReturnsOnCall == nil {
+		fake.copyDropletReturnsOnCall = make(map[int]struct {
+			result1 repositories.DropletRecord
+			result2 error
+		})
+	}
+	fake.copyDropletReturnsOnCall[i] = struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFDropletRepository) CreateDroplet(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateDropletMessage) (repositories.DropletRecord, error) {
+	fake.createDropletMutex.Lock()
+	ret, specificReturn := fake.createDropletReturnsOnCall[len(fake.createDropletArgsForCall)]
+	fake.createDropletArgsForCall = append(fake.createDropletArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateDropletMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CreateDropletStub
+	fakeReturns := fake.createDropletReturns
+	fake.recordInvocation("CreateDroplet", []interface{}{arg1, arg2, arg3})
+	fake.createDropletMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFDropletRepository) CreateDropletCallCount() int {
+	fake.createDropletMutex.RLock()
+	defer fake.createDropletMutex.RUnlock()
+	return len(fake.createDropletArgsForCall)
+}
+
+func (fake *CFDropletRepository) CreateDropletCalls(stub func(context.Context, authorization.Info, repositories.CreateDropletMessage) (repositories.DropletRecord, error)) {
+	fake.createDropletMutex.Lock()
+	defer fake.createDropletMutex.Unlock()
+	fake.CreateDropletStub = stub
+}
+
+func (fake *CFDropletRepository) CreateDropletArgsForCall(i int) (context.Context, authorization.Info, repositories.CreateDropletMessage) {
+	fake.createDropletMutex.RLock()
+	defer fake.createDropletMutex.RUnlock()
+	argsForCall := fake.createDropletArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFDropletRepository) CreateDropletReturns(result1 repositories.DropletRecord, result2 error) {
+	fake.createDropletMutex.Lock()
+	defer fake.createDropletMutex.Unlock()
+	fake.CreateDropletStub = nil
+	fake.createDropletReturns = struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFDropletRepository) CreateDropletReturnsOnCall(i int, result1 repositories.DropletRecord, result2 error) {
+	fake.createDropletMutex.Lock()
+	defer fake.createDropletMutex.Unlock()
+	fake.CreateDropletStub = nil
+	if fake.createDropletReturnsOnCall == nil {
+		fake.createDropletReturnsOnCall = make(map[int]struct {
+			result1 repositories.DropletRecord
+			result2 error
+		})
+	}
+	fake.createDropletReturnsOnCall[i] = struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFDropletRepository) DeleteDroplet(arg1 context.Context, arg2 authorization.Info, arg3 repositories.DeleteDropletMessage) error {
+	fake.deleteDropletMutex.Lock()
+	ret, specificReturn := fake.deleteDropletReturnsOnCall[len(fake.deleteDropletArgsForCall)]
+	fake.deleteDropletArgsForCall = append(fake.deleteDropletArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.DeleteDropletMessage
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteDropletStub
+	fakeReturns := fake.deleteDropletReturns
+	fake.recordInvocation("DeleteDroplet", []interface{}{arg1, arg2, arg3})
+	fake.deleteDropletMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFDropletRepository) DeleteDropletCallCount() int {
+	fake.deleteDropletMutex.RLock()
+	defer fake.deleteDropletMutex.RUnlock()
+	return len(fake.deleteDropletArgsForCall)
+}
+
+func (fake *CFDropletRepository) DeleteDropletCalls(stub func(context.Context, authorization.Info, repositories.DeleteDropletMessage) error) {
+	fake.deleteDropletMutex.Lock()
+	defer fake.deleteDropletMutex.Unlock()
+	fake.DeleteDropletStub = stub
+}
+
+func (fake *CFDropletRepository) DeleteDropletArgsForCall(i int) (context.Context, authorization.Info, repositories.DeleteDropletMessage) {
+	fake.deleteDropletMutex.RLock()
+	defer fake.deleteDropletMutex.RUnlock()
+	argsForCall := fake.deleteDropletArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFDropletRepository) DeleteDropletReturns(result1 error) {
+	fake.deleteDropletMutex.Lock()
+	defer fake.deleteDropletMutex.Unlock()
+	fake.DeleteDropletStub = nil
+	fake.deleteDropletReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFDropletRepository) DeleteDropletReturnsOnCall(i int, result1 error) {
+	fake.deleteDropletMutex.Lock()
+	defer fake.deleteDropletMutex.Unlock()
+	fake.DeleteDropletStub = nil
+	if fake.deleteDropletReturnsOnCall == nil {
+		fake.deleteDropletReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteDropletReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *CFDropletRepository) GetDroplet(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.DropletRecord, error) {
 	fake.getDropletMutex.Lock()
 	ret, specificReturn := fake.getDropletReturnsOnCall[len(fake.getDropletArgsForCall)]
@@ -258,15 +511,89 @@ func (fake *CFDropletRepository) UpdateDropletReturnsOnCall(i int, result1 repos
 	}{result1, result2}
 }
 
+func (fake *CFDropletRepository) UpdateDropletSource(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UpdateDropletSourceMessage) (repositories.DropletRecord, error) {
+	fake.updateDropletSourceMutex.Lock()
+	ret, specificReturn := fake.updateDropletSourceReturnsOnCall[len(fake.updateDropletSourceArgsForCall)]
+	fake.updateDropletSourceArgsForCall = append(fake.updateDropletSourceArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateDropletSourceMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateDropletSourceStub
+	fakeReturns := fake.updateDropletSourceReturns
+	fake.recordInvocation("UpdateDropletSource", []interface{}{arg1, arg2, arg3})
+	fake.updateDropletSourceMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFDropletRepository) UpdateDropletSourceCallCount() int {
+	fake.updateDropletSourceMutex.RLock()
+	defer fake.updateDropletSourceMutex.RUnlock()
+	return len(fake.updateDropletSourceArgsForCall)
+}
+
+func (fake *CFDropletRepository) UpdateDropletSourceCalls(stub func(context.Context, authorization.Info, repositories.UpdateDropletSourceMessage) (repositories.DropletRecord, error)) {
+	fake.updateDropletSourceMutex.Lock()
+	defer fake.updateDropletSourceMutex.Unlock()
+	fake.UpdateDropletSourceStub = stub
+}
+
+func (fake *CFDropletRepository) UpdateDropletSourceArgsForCall(i int) (context.Context, authorization.Info, repositories.UpdateDropletSourceMessage) {
+	fake.updateDropletSourceMutex.RLock()
+	defer fake.updateDropletSourceMutex.RUnlock()
+	argsForCall := fake.updateDropletSourceArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFDropletRepository) UpdateDropletSourceReturns(result1 repositories.DropletRecord, result2 error) {
+	fake.updateDropletSourceMutex.Lock()
+	defer fake.updateDropletSourceMutex.Unlock()
+	fake.UpdateDropletSourceStub = nil
+	fake.updateDropletSourceReturns = struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFDropletRepository) UpdateDropletSourceReturnsOnCall(i int, result1 repositories.DropletRecord, result2 error) {
+	fake.updateDropletSourceMutex.Lock()
+	defer fake.updateDropletSourceMutex.Unlock()
+	fake.UpdateDropletSourceStub = nil
+	if fake.updateDropletSourceReturnsOnCall == nil {
+		fake.updateDropletSourceReturnsOnCall = make(map[int]struct {
+			result1 repositories.DropletRecord
+			result2 error
+		})
+	}
+	fake.updateDropletSourceReturnsOnCall[i] = struct {
+		result1 repositories.DropletRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFDropletRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.copyDropletMutex.RLock()
+	defer fake.copyDropletMutex.RUnlock()
+	fake.createDropletMutex.RLock()
+	defer fake.createDropletMutex.RUnlock()
+	fake.deleteDropletMutex.RLock()
+	defer fake.deleteDropletMutex.RUnlock()
 	fake.getDropletMutex.RLock()
 	defer fake.getDropletMutex.RUnlock()
 	fake.listDropletsMutex.RLock()
 	defer fake.listDropletsMutex.RUnlock()
 	fake.updateDropletMutex.RLock()
 	defer fake.updateDropletMutex.RUnlock()
+	fake.updateDropletSourceMutex.RLock()
+	defer fake.updateDropletSourceMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,206 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type CFEnvVarGroupRepository struct {
+	GetEnvVarGroupStub        func(context.Context, authorization.Info, string) (repositories.EnvVarGroupRecord, error)
+	getEnvVarGroupMutex       sync.RWMutex
+	getEnvVarGroupArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getEnvVarGroupReturns struct {
+		result1 repositories.EnvVarGroupRecord
+		result2 error
+	}
+	getEnvVarGroupReturnsOnCall map[int]struct {
+		result1 repositories.EnvVarGroupRecord
+		result2 error
+	}
+	PatchEnvVarGroupStub        func(context.Context, authorization.Info, repositories.PatchEnvVarGroupMessage) (repositories.EnvVarGroupRecord, error)
+	patchEnvVarGroupMutex       sync.RWMutex
+	patchEnvVarGroupArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.PatchEnvVarGroupMessage
+	}
+	patchEnvVarGroupReturns struct {
+		result1 repositories.EnvVarGroupRecord
+		result2 error
+	}
+	patchEnvVarGroupReturnsOnCall map[int]struct {
+		result1 repositories.EnvVarGroupRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CFEnvVarGroupRepository) GetEnvVarGroup(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.EnvVarGroupRecord, error) {
+	fake.getEnvVarGroupMutex.Lock()
+	ret, specificReturn := fake.getEnvVarGroupReturnsOnCall[len(fake.getEnvVarGroupArgsForCall)]
+	fake.getEnvVarGroupArgsForCall = append(fake.getEnvVarGroupArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetEnvVarGroupStub
+	fakeReturns := fake.getEnvVarGroupReturns
+	fake.recordInvocation("GetEnvVarGroup", []interface{}{arg1, arg2, arg3})
+	fake.getEnvVarGroupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFEnvVarGroupRepository) GetEnvVarGroupCallCount() int {
+	fake.getEnvVarGroupMutex.RLock()
+	defer fake.getEnvVarGroupMutex.RUnlock()
+	return len(fake.getEnvVarGroupArgsForCall)
+}
+
+func (fake *CFEnvVarGroupRepository) GetEnvVarGroupCalls(stub func(context.Context, authorization.Info, string) (repositories.EnvVarGroupRecord, error)) {
+	fake.getEnvVarGroupMutex.Lock()
+	defer fake.getEnvVarGroupMutex.Unlock()
+	fake.GetEnvVarGroupStub = stub
+}
+
+func (fake *CFEnvVarGroupRepository) GetEnvVarGroupArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getEnvVarGroupMutex.RLock()
+	defer fake.getEnvVarGroupMutex.RUnlock()
+	argsForCall := fake.getEnvVarGroupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFEnvVarGroupRepository) GetEnvVarGroupReturns(result1 repositories.EnvVarGroupRecord, result2 error) {
+	fake.getEnvVarGroupMutex.Lock()
+	defer fake.getEnvVarGroupMutex.Unlock()
+	fake.GetEnvVarGroupStub = nil
+	fake.getEnvVarGroupReturns = struct {
+		result1 repositories.EnvVarGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFEnvVarGroupRepository) GetEnvVarGroupReturnsOnCall(i int, result1 repositories.EnvVarGroupRecord, result2 error) {
+	fake.getEnvVarGroupMutex.Lock()
+	defer fake.getEnvVarGroupMutex.Unlock()
+	fake.GetEnvVarGroupStub = nil
+	if fake.getEnvVarGroupReturnsOnCall == nil {
+		fake.getEnvVarGroupReturnsOnCall = make(map[int]struct {
+			result1 repositories.EnvVarGroupRecord
+			result2 error
+		})
+	}
+	fake.getEnvVarGroupReturnsOnCall[i] = struct {
+		result1 repositories.EnvVarGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFEnvVarGroupRepository) PatchEnvVarGroup(arg1 context.Context, arg2 authorization.Info, arg3 repositories.PatchEnvVarGroupMessage) (repositories.EnvVarGroupRecord, error) {
+	fake.patchEnvVarGroupMutex.Lock()
+	ret, specificReturn := fake.patchEnvVarGroupReturnsOnCall[len(fake.patchEnvVarGroupArgsForCall)]
+	fake.patchEnvVarGroupArgsForCall = append(fake.patchEnvVarGroupArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.PatchEnvVarGroupMessage
+	}{arg1, arg2, arg3})
+	stub := fake.PatchEnvVarGroupStub
+	fakeReturns := fake.patchEnvVarGroupReturns
+	fake.recordInvocation("PatchEnvVarGroup", []interface{}{arg1, arg2, arg3})
+	fake.patchEnvVarGroupMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFEnvVarGroupRepository) PatchEnvVarGroupCallCount() int {
+	fake.patchEnvVarGroupMutex.RLock()
+	defer fake.patchEnvVarGroupMutex.RUnlock()
+	return len(fake.patchEnvVarGroupArgsForCall)
+}
+
+func (fake *CFEnvVarGroupRepository) PatchEnvVarGroupCalls(stub func(context.Context, authorization.Info, repositories.PatchEnvVarGroupMessage) (repositories.EnvVarGroupRecord, error)) {
+	fake.patchEnvVarGroupMutex.Lock()
+	defer fake.patchEnvVarGroupMutex.Unlock()
+	fake.PatchEnvVarGroupStub = stub
+}
+
+func (fake *CFEnvVarGroupRepository) PatchEnvVarGroupArgsForCall(i int) (context.Context, authorization.Info, repositories.PatchEnvVarGroupMessage) {
+	fake.patchEnvVarGroupMutex.RLock()
+	defer fake.patchEnvVarGroupMutex.RUnlock()
+	argsForCall := fake.patchEnvVarGroupArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFEnvVarGroupRepository) PatchEnvVarGroupReturns(result1 repositories.EnvVarGroupRecord, result2 error) {
+	fake.patchEnvVarGroupMutex.Lock()
+	defer fake.patchEnvVarGroupMutex.Unlock()
+	fake.PatchEnvVarGroupStub = nil
+	fake.patchEnvVarGroupReturns = struct {
+		result1 repositories.EnvVarGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFEnvVarGroupRepository) PatchEnvVarGroupReturnsOnCall(i int, result1 repositories.EnvVarGroupRecord, result2 error) {
+	fake.patchEnvVarGroupMutex.Lock()
+	defer fake.patchEnvVarGroupMutex.Unlock()
+	fake.PatchEnvVarGroupStub = nil
+	if fake.patchEnvVarGroupReturnsOnCall == nil {
+		fake.patchEnvVarGroupReturnsOnCall = make(map[int]struct {
+			result1 repositories.EnvVarGroupRecord
+			result2 error
+		})
+	}
+	fake.patchEnvVarGroupReturnsOnCall[i] = struct {
+		result1 repositories.EnvVarGroupRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFEnvVarGroupRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getEnvVarGroupMutex.RLock()
+	defer fake.getEnvVarGroupMutex.RUnlock()
+	fake.patchEnvVarGroupMutex.RLock()
+	defer fake.patchEnvVarGroupMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CFEnvVarGroupRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.CFEnvVarGroupRepository = new(CFEnvVarGroupRepository)
@@ -0,0 +1,938 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type CFIsolationSegmentRepository struct {
+	AssignSpaceIsolationSegmentStub        func(context.Context, authorization.Info, repositories.AssignSpaceIsolationSegmentMessage) error
+	assignSpaceIsolationSegmentMutex       sync.RWMutex
+	assignSpaceIsolationSegmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.AssignSpaceIsolationSegmentMessage
+	}
+	assignSpaceIsolationSegmentReturns struct {
+		result1 error
+	}
+	assignSpaceIsolationSegmentReturnsOnCall map[int]struct {
+		result1 error
+	}
+	CreateIsolationSegmentStub        func(context.Context, authorization.Info, repositories.CreateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)
+	createIsolationSegmentMutex       sync.RWMutex
+	createIsolationSegmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateIsolationSegmentMessage
+	}
+	createIsolationSegmentReturns struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	createIsolationSegmentReturnsOnCall map[int]struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	DeleteIsolationSegmentStub        func(context.Context, authorization.Info, string) error
+	deleteIsolationSegmentMutex       sync.RWMutex
+	deleteIsolationSegmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	deleteIsolationSegmentReturns struct {
+		result1 error
+	}
+	deleteIsolationSegmentReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EntitleIsolationSegmentStub        func(context.Context, authorization.Info, repositories.EntitleIsolationSegmentMessage) ([]string, error)
+	entitleIsolationSegmentMutex       sync.RWMutex
+	entitleIsolationSegmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.EntitleIsolationSegmentMessage
+	}
+	entitleIsolationSegmentReturns struct {
+		result1 []string
+		result2 error
+	}
+	entitleIsolationSegmentReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	GetIsolationSegmentStub        func(context.Context, authorization.Info, string) (repositories.IsolationSegmentRecord, error)
+	getIsolationSegmentMutex       sync.RWMutex
+	getIsolationSegmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getIsolationSegmentReturns struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	getIsolationSegmentReturnsOnCall map[int]struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	GetSpaceIsolationSegmentStub        func(context.Context, authorization.Info, string) (string, error)
+	getSpaceIsolationSegmentMutex       sync.RWMutex
+	getSpaceIsolationSegmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getSpaceIsolationSegmentReturns struct {
+		result1 string
+		result2 error
+	}
+	getSpaceIsolationSegmentReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	ListIsolationSegmentOrgsStub        func(context.Context, authorization.Info, string) ([]string, error)
+	listIsolationSegmentOrgsMutex       sync.RWMutex
+	listIsolationSegmentOrgsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	listIsolationSegmentOrgsReturns struct {
+		result1 []string
+		result2 error
+	}
+	listIsolationSegmentOrgsReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	ListIsolationSegmentSpacesStub        func(context.Context, authorization.Info, string) ([]string, error)
+	listIsolationSegmentSpacesMutex       sync.RWMutex
+	listIsolationSegmentSpacesArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	listIsolationSegmentSpacesReturns struct {
+		result1 []string
+		result2 error
+	}
+	listIsolationSegmentSpacesReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
+	ListIsolationSegmentsStub        func(context.Context, authorization.Info, repositories.ListIsolationSegmentsMessage) ([]repositories.IsolationSegmentRecord, error)
+	listIsolationSegmentsMutex       sync.RWMutex
+	listIsolationSegmentsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListIsolationSegmentsMessage
+	}
+	listIsolationSegmentsReturns struct {
+		result1 []repositories.IsolationSegmentRecord
+		result2 error
+	}
+	listIsolationSegmentsReturnsOnCall map[int]struct {
+		result1 []repositories.IsolationSegmentRecord
+		result2 error
+	}
+	RevokeIsolationSegmentStub        func(context.Context, authorization.Info, repositories.RevokeIsolationSegmentMessage) error
+	revokeIsolationSegmentMutex       sync.RWMutex
+	revokeIsolationSegmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.RevokeIsolationSegmentMessage
+	}
+	revokeIsolationSegmentReturns struct {
+		result1 error
+	}
+	revokeIsolationSegmentReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateIsolationSegmentStub        func(context.Context, authorization.Info, repositories.UpdateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)
+	updateIsolationSegmentMutex       sync.RWMutex
+	updateIsolationSegmentArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateIsolationSegmentMessage
+	}
+	updateIsolationSegmentReturns struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	updateIsolationSegmentReturnsOnCall map[int]struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CFIsolationSegmentRepository) AssignSpaceIsolationSegment(arg1 context.Context, arg2 authorization.Info, arg3 repositories.AssignSpaceIsolationSegmentMessage) error {
+	fake.assignSpaceIsolationSegmentMutex.Lock()
+	ret, specificReturn := fake.assignSpaceIsolationSegmentReturnsOnCall[len(fake.assignSpaceIsolationSegmentArgsForCall)]
+	fake.assignSpaceIsolationSegmentArgsForCall = append(fake.assignSpaceIsolationSegmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.AssignSpaceIsolationSegmentMessage
+	}{arg1, arg2, arg3})
+	stub := fake.AssignSpaceIsolationSegmentStub
+	fakeReturns := fake.assignSpaceIsolationSegmentReturns
+	fake.recordInvocation("AssignSpaceIsolationSegment", []interface{}{arg1, arg2, arg3})
+	fake.assignSpaceIsolationSegmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFIsolationSegmentRepository) AssignSpaceIsolationSegmentCallCount() int {
+	fake.assignSpaceIsolationSegmentMutex.RLock()
+	defer fake.assignSpaceIsolationSegmentMutex.RUnlock()
+	return len(fake.assignSpaceIsolationSegmentArgsForCall)
+}
+
+func (fake *CFIsolationSegmentRepository) AssignSpaceIsolationSegmentCalls(stub func(context.Context, authorization.Info, repositories.AssignSpaceIsolationSegmentMessage) error) {
+	fake.assignSpaceIsolationSegmentMutex.Lock()
+	defer fake.assignSpaceIsolationSegmentMutex.Unlock()
+	fake.AssignSpaceIsolationSegmentStub = stub
+}
+
+func (fake *CFIsolationSegmentRepository) AssignSpaceIsolationSegmentArgsForCall(i int) (context.Context, authorization.Info, repositories.AssignSpaceIsolationSegmentMessage) {
+	fake.assignSpaceIsolationSegmentMutex.RLock()
+	defer fake.assignSpaceIsolationSegmentMutex.RUnlock()
+	argsForCall := fake.assignSpaceIsolationSegmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFIsolationSegmentRepository) AssignSpaceIsolationSegmentReturns(result1 error) {
+	fake.assignSpaceIsolationSegmentMutex.Lock()
+	defer fake.assignSpaceIsolationSegmentMutex.Unlock()
+	fake.AssignSpaceIsolationSegmentStub = nil
+	fake.assignSpaceIsolationSegmentReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFIsolationSegmentRepository) AssignSpaceIsolationSegmentReturnsOnCall(i int, result1 error) {
+	fake.assignSpaceIsolationSegmentMutex.Lock()
+	defer fake.assignSpaceIsolationSegmentMutex.Unlock()
+	fake.AssignSpaceIsolationSegmentStub = nil
+	if fake.assignSpaceIsolationSegmentReturnsOnCall == nil {
+		fake.assignSpaceIsolationSegmentReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.assignSpaceIsolationSegmentReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFIsolationSegmentRepository) CreateIsolationSegment(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error) {
+	fake.createIsolationSegmentMutex.Lock()
+	ret, specificReturn := fake.createIsolationSegmentReturnsOnCall[len(fake.createIsolationSegmentArgsForCall)]
+	fake.createIsolationSegmentArgsForCall = append(fake.createIsolationSegmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateIsolationSegmentMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CreateIsolationSegmentStub
+	fakeReturns := fake.createIsolationSegmentReturns
+	fake.recordInvocation("CreateIsolationSegment", []interface{}{arg1, arg2, arg3})
+	fake.createIsolationSegmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFIsolationSegmentRepository) CreateIsolationSegmentCallCount() int {
+	fake.createIsolationSegmentMutex.RLock()
+	defer fake.createIsolationSegmentMutex.RUnlock()
+	return len(fake.createIsolationSegmentArgsForCall)
+}
+
+func (fake *CFIsolationSegmentRepository) CreateIsolationSegmentCalls(stub func(context.Context, authorization.Info, repositories.CreateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)) {
+	fake.createIsolationSegmentMutex.Lock()
+	defer fake.createIsolationSegmentMutex.Unlock()
+	fake.CreateIsolationSegmentStub = stub
+}
+
+func (fake *CFIsolationSegmentRepository) CreateIsolationSegmentArgsForCall(i int) (context.Context, authorization.Info, repositories.CreateIsolationSegmentMessage) {
+	fake.createIsolationSegmentMutex.RLock()
+	defer fake.createIsolationSegmentMutex.RUnlock()
+	argsForCall := fake.createIsolationSegmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFIsolationSegmentRepository) CreateIsolationSegmentReturns(result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.createIsolationSegmentMutex.Lock()
+	defer fake.createIsolationSegmentMutex.Unlock()
+	fake.CreateIsolationSegmentStub = nil
+	fake.createIsolationSegmentReturns = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) CreateIsolationSegmentReturnsOnCall(i int, result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.createIsolationSegmentMutex.Lock()
+	defer fake.createIsolationSegmentMutex.Unlock()
+	fake.CreateIsolationSegmentStub = nil
+	if fake.createIsolationSegmentReturnsOnCall == nil {
+		fake.createIsolationSegmentReturnsOnCall = make(map[int]struct {
+			result1 repositories.IsolationSegmentRecord
+			result2 error
+		})
+	}
+	fake.createIsolationSegmentReturnsOnCall[i] = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) DeleteIsolationSegment(arg1 context.Context, arg2 authorization.Info, arg3 string) error {
+	fake.deleteIsolationSegmentMutex.Lock()
+	ret, specificReturn := fake.deleteIsolationSegmentReturnsOnCall[len(fake.deleteIsolationSegmentArgsForCall)]
+	fake.deleteIsolationSegmentArgsForCall = append(fake.deleteIsolationSegmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteIsolationSegmentStub
+	fakeReturns := fake.deleteIsolationSegmentReturns
+	fake.recordInvocation("DeleteIsolationSegment", []interface{}{arg1, arg2, arg3})
+	fake.deleteIsolationSegmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFIsolationSegmentRepository) DeleteIsolationSegmentCallCount() int {
+	fake.deleteIsolationSegmentMutex.RLock()
+	defer fake.deleteIsolationSegmentMutex.RUnlock()
+	return len(fake.deleteIsolationSegmentArgsForCall)
+}
+
+func (fake *CFIsolationSegmentRepository) DeleteIsolationSegmentCalls(stub func(context.Context, authorization.Info, string) error) {
+	fake.deleteIsolationSegmentMutex.Lock()
+	defer fake.deleteIsolationSegmentMutex.Unlock()
+	fake.DeleteIsolationSegmentStub = stub
+}
+
+func (fake *CFIsolationSegmentRepository) DeleteIsolationSegmentArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.deleteIsolationSegmentMutex.RLock()
+	defer fake.deleteIsolationSegmentMutex.RUnlock()
+	argsForCall := fake.deleteIsolationSegmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFIsolationSegmentRepository) DeleteIsolationSegmentReturns(result1 error) {
+	fake.deleteIsolationSegmentMutex.Lock()
+	defer fake.deleteIsolationSegmentMutex.Unlock()
+	fake.DeleteIsolationSegmentStub = nil
+	fake.deleteIsolationSegmentReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFIsolationSegmentRepository) DeleteIsolationSegmentReturnsOnCall(i int, result1 error) {
+	fake.deleteIsolationSegmentMutex.Lock()
+	defer fake.deleteIsolationSegmentMutex.Unlock()
+	fake.DeleteIsolationSegmentStub = nil
+	if fake.deleteIsolationSegmentReturnsOnCall == nil {
+		fake.deleteIsolationSegmentReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteIsolationSegmentReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFIsolationSegmentRepository) EntitleIsolationSegment(arg1 context.Context, arg2 authorization.Info, arg3 repositories.EntitleIsolationSegmentMessage) ([]string, error) {
+	fake.entitleIsolationSegmentMutex.Lock()
+	ret, specificReturn := fake.entitleIsolationSegmentReturnsOnCall[len(fake.entitleIsolationSegmentArgsForCall)]
+	fake.entitleIsolationSegmentArgsForCall = append(fake.entitleIsolationSegmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.EntitleIsolationSegmentMessage
+	}{arg1, arg2, arg3})
+	stub := fake.EntitleIsolationSegmentStub
+	fakeReturns := fake.entitleIsolationSegmentReturns
+	fake.recordInvocation("EntitleIsolationSegment", []interface{}{arg1, arg2, arg3})
+	fake.entitleIsolationSegmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFIsolationSegmentRepository) EntitleIsolationSegmentCallCount() int {
+	fake.entitleIsolationSegmentMutex.RLock()
+	defer fake.entitleIsolationSegmentMutex.RUnlock()
+	return len(fake.entitleIsolationSegmentArgsForCall)
+}
+
+func (fake *CFIsolationSegmentRepository) EntitleIsolationSegmentCalls(stub func(context.Context, authorization.Info, repositories.EntitleIsolationSegmentMessage) ([]string, error)) {
+	fake.entitleIsolationSegmentMutex.Lock()
+	defer fake.entitleIsolationSegmentMutex.Unlock()
+	fake.EntitleIsolationSegmentStub = stub
+}
+
+func (fake *CFIsolationSegmentRepository) EntitleIsolationSegmentArgsForCall(i int) (context.Context, authorization.Info, repositories.EntitleIsolationSegmentMessage) {
+	fake.entitleIsolationSegmentMutex.RLock()
+	defer fake.entitleIsolationSegmentMutex.RUnlock()
+	argsForCall := fake.entitleIsolationSegmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFIsolationSegmentRepository) EntitleIsolationSegmentReturns(result1 []string, result2 error) {
+	fake.entitleIsolationSegmentMutex.Lock()
+	defer fake.entitleIsolationSegmentMutex.Unlock()
+	fake.EntitleIsolationSegmentStub = nil
+	fake.entitleIsolationSegmentReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) EntitleIsolationSegmentReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.entitleIsolationSegmentMutex.Lock()
+	defer fake.entitleIsolationSegmentMutex.Unlock()
+	fake.EntitleIsolationSegmentStub = nil
+	if fake.entitleIsolationSegmentReturnsOnCall == nil {
+		fake.entitleIsolationSegmentReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.entitleIsolationSegmentReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) GetIsolationSegment(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.IsolationSegmentRecord, error) {
+	fake.getIsolationSegmentMutex.Lock()
+	ret, specificReturn := fake.getIsolationSegmentReturnsOnCall[len(fake.getIsolationSegmentArgsForCall)]
+	fake.getIsolationSegmentArgsForCall = append(fake.getIsolationSegmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetIsolationSegmentStub
+	fakeReturns := fake.getIsolationSegmentReturns
+	fake.recordInvocation("GetIsolationSegment", []interface{}{arg1, arg2, arg3})
+	fake.getIsolationSegmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFIsolationSegmentRepository) GetIsolationSegmentCallCount() int {
+	fake.getIsolationSegmentMutex.RLock()
+	defer fake.getIsolationSegmentMutex.RUnlock()
+	return len(fake.getIsolationSegmentArgsForCall)
+}
+
+func (fake *CFIsolationSegmentRepository) GetIsolationSegmentCalls(stub func(context.Context, authorization.Info, string) (repositories.IsolationSegmentRecord, error)) {
+	fake.getIsolationSegmentMutex.Lock()
+	defer fake.getIsolationSegmentMutex.Unlock()
+	fake.GetIsolationSegmentStub = stub
+}
+
+func (fake *CFIsolationSegmentRepository) GetIsolationSegmentArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getIsolationSegmentMutex.RLock()
+	defer fake.getIsolationSegmentMutex.RUnlock()
+	argsForCall := fake.getIsolationSegmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFIsolationSegmentRepository) GetIsolationSegmentReturns(result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.getIsolationSegmentMutex.Lock()
+	defer fake.getIsolationSegmentMutex.Unlock()
+	fake.GetIsolationSegmentStub = nil
+	fake.getIsolationSegmentReturns = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) GetIsolationSegmentReturnsOnCall(i int, result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.getIsolationSegmentMutex.Lock()
+	defer fake.getIsolationSegmentMutex.Unlock()
+	fake.GetIsolationSegmentStub = nil
+	if fake.getIsolationSegmentReturnsOnCall == nil {
+		fake.getIsolationSegmentReturnsOnCall = make(map[int]struct {
+			result1 repositories.IsolationSegmentRecord
+			result2 error
+		})
+	}
+	fake.getIsolationSegmentReturnsOnCall[i] = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) GetSpaceIsolationSegment(arg1 context.Context, arg2 authorization.Info, arg3 string) (string, error) {
+	fake.getSpaceIsolationSegmentMutex.Lock()
+	ret, specificReturn := fake.getSpaceIsolationSegmentReturnsOnCall[len(fake.getSpaceIsolationSegmentArgsForCall)]
+	fake.getSpaceIsolationSegmentArgsForCall = append(fake.getSpaceIsolationSegmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetSpaceIsolationSegmentStub
+	fakeReturns := fake.getSpaceIsolationSegmentReturns
+	fake.recordInvocation("GetSpaceIsolationSegment", []interface{}{arg1, arg2, arg3})
+	fake.getSpaceIsolationSegmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFIsolationSegmentRepository) GetSpaceIsolationSegmentCallCount() int {
+	fake.getSpaceIsolationSegmentMutex.RLock()
+	defer fake.getSpaceIsolationSegmentMutex.RUnlock()
+	return len(fake.getSpaceIsolationSegmentArgsForCall)
+}
+
+func (fake *CFIsolationSegmentRepository) GetSpaceIsolationSegmentCalls(stub func(context.Context, authorization.Info, string) (string, error)) {
+	fake.getSpaceIsolationSegmentMutex.Lock()
+	defer fake.getSpaceIsolationSegmentMutex.Unlock()
+	fake.GetSpaceIsolationSegmentStub = stub
+}
+
+func (fake *CFIsolationSegmentRepository) GetSpaceIsolationSegmentArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getSpaceIsolationSegmentMutex.RLock()
+	defer fake.getSpaceIsolationSegmentMutex.RUnlock()
+	argsForCall := fake.getSpaceIsolationSegmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFIsolationSegmentRepository) GetSpaceIsolationSegmentReturns(result1 string, result2 error) {
+	fake.getSpaceIsolationSegmentMutex.Lock()
+	defer fake.getSpaceIsolationSegmentMutex.Unlock()
+	fake.GetSpaceIsolationSegmentStub = nil
+	fake.getSpaceIsolationSegmentReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) GetSpaceIsolationSegmentReturnsOnCall(i int, result1 string, result2 error) {
+	fake.getSpaceIsolationSegmentMutex.Lock()
+	defer fake.getSpaceIsolationSegmentMutex.Unlock()
+	fake.GetSpaceIsolationSegmentStub = nil
+	if fake.getSpaceIsolationSegmentReturnsOnCall == nil {
+		fake.getSpaceIsolationSegmentReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.getSpaceIsolationSegmentReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentOrgs(arg1 context.Context, arg2 authorization.Info, arg3 string) ([]string, error) {
+	fake.listIsolationSegmentOrgsMutex.Lock()
+	ret, specificReturn := fake.listIsolationSegmentOrgsReturnsOnCall[len(fake.listIsolationSegmentOrgsArgsForCall)]
+	fake.listIsolationSegmentOrgsArgsForCall = append(fake.listIsolationSegmentOrgsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ListIsolationSegmentOrgsStub
+	fakeReturns := fake.listIsolationSegmentOrgsReturns
+	fake.recordInvocation("ListIsolationSegmentOrgs", []interface{}{arg1, arg2, arg3})
+	fake.listIsolationSegmentOrgsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentOrgsCallCount() int {
+	fake.listIsolationSegmentOrgsMutex.RLock()
+	defer fake.listIsolationSegmentOrgsMutex.RUnlock()
+	return len(fake.listIsolationSegmentOrgsArgsForCall)
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentOrgsCalls(stub func(context.Context, authorization.Info, string) ([]string, error)) {
+	fake.listIsolationSegmentOrgsMutex.Lock()
+	defer fake.listIsolationSegmentOrgsMutex.Unlock()
+	fake.ListIsolationSegmentOrgsStub = stub
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentOrgsArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.listIsolationSegmentOrgsMutex.RLock()
+	defer fake.listIsolationSegmentOrgsMutex.RUnlock()
+	argsForCall := fake.listIsolationSegmentOrgsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentOrgsReturns(result1 []string, result2 error) {
+	fake.listIsolationSegmentOrgsMutex.Lock()
+	defer fake.listIsolationSegmentOrgsMutex.Unlock()
+	fake.ListIsolationSegmentOrgsStub = nil
+	fake.listIsolationSegmentOrgsReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentOrgsReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.listIsolationSegmentOrgsMutex.Lock()
+	defer fake.listIsolationSegmentOrgsMutex.Unlock()
+	fake.ListIsolationSegmentOrgsStub = nil
+	if fake.listIsolationSegmentOrgsReturnsOnCall == nil {
+		fake.listIsolationSegmentOrgsReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.listIsolationSegmentOrgsReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentSpaces(arg1 context.Context, arg2 authorization.Info, arg3 string) ([]string, error) {
+	fake.listIsolationSegmentSpacesMutex.Lock()
+	ret, specificReturn := fake.listIsolationSegmentSpacesReturnsOnCall[len(fake.listIsolationSegmentSpacesArgsForCall)]
+	fake.listIsolationSegmentSpacesArgsForCall = append(fake.listIsolationSegmentSpacesArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.ListIsolationSegmentSpacesStub
+	fakeReturns := fake.listIsolationSegmentSpacesReturns
+	fake.recordInvocation("ListIsolationSegmentSpaces", []interface{}{arg1, arg2, arg3})
+	fake.listIsolationSegmentSpacesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentSpacesCallCount() int {
+	fake.listIsolationSegmentSpacesMutex.RLock()
+	defer fake.listIsolationSegmentSpacesMutex.RUnlock()
+	return len(fake.listIsolationSegmentSpacesArgsForCall)
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentSpacesCalls(stub func(context.Context, authorization.Info, string) ([]string, error)) {
+	fake.listIsolationSegmentSpacesMutex.Lock()
+	defer fake.listIsolationSegmentSpacesMutex.Unlock()
+	fake.ListIsolationSegmentSpacesStub = stub
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentSpacesArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.listIsolationSegmentSpacesMutex.RLock()
+	defer fake.listIsolationSegmentSpacesMutex.RUnlock()
+	argsForCall := fake.listIsolationSegmentSpacesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentSpacesReturns(result1 []string, result2 error) {
+	fake.listIsolationSegmentSpacesMutex.Lock()
+	defer fake.listIsolationSegmentSpacesMutex.Unlock()
+	fake.ListIsolationSegmentSpacesStub = nil
+	fake.listIsolationSegmentSpacesReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentSpacesReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.listIsolationSegmentSpacesMutex.Lock()
+	defer fake.listIsolationSegmentSpacesMutex.Unlock()
+	fake.ListIsolationSegmentSpacesStub = nil
+	if fake.listIsolationSegmentSpacesReturnsOnCall == nil {
+		fake.listIsolationSegmentSpacesReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.listIsolationSegmentSpacesReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegments(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListIsolationSegmentsMessage) ([]repositories.IsolationSegmentRecord, error) {
+	fake.listIsolationSegmentsMutex.Lock()
+	ret, specificReturn := fake.listIsolationSegmentsReturnsOnCall[len(fake.listIsolationSegmentsArgsForCall)]
+	fake.listIsolationSegmentsArgsForCall = append(fake.listIsolationSegmentsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListIsolationSegmentsMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListIsolationSegmentsStub
+	fakeReturns := fake.listIsolationSegmentsReturns
+	fake.recordInvocation("ListIsolationSegments", []interface{}{arg1, arg2, arg3})
+	fake.listIsolationSegmentsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentsCallCount() int {
+	fake.listIsolationSegmentsMutex.RLock()
+	defer fake.listIsolationSegmentsMutex.RUnlock()
+	return len(fake.listIsolationSegmentsArgsForCall)
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentsCalls(stub func(context.Context, authorization.Info, repositories.ListIsolationSegmentsMessage) ([]repositories.IsolationSegmentRecord, error)) {
+	fake.listIsolationSegmentsMutex.Lock()
+	defer fake.listIsolationSegmentsMutex.Unlock()
+	fake.ListIsolationSegmentsStub = stub
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentsArgsForCall(i int) (context.Context, authorization.Info, repositories.ListIsolationSegmentsMessage) {
+	fake.listIsolationSegmentsMutex.RLock()
+	defer fake.listIsolationSegmentsMutex.RUnlock()
+	argsForCall := fake.listIsolationSegmentsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentsReturns(result1 []repositories.IsolationSegmentRecord, result2 error) {
+	fake.listIsolationSegmentsMutex.Lock()
+	defer fake.listIsolationSegmentsMutex.Unlock()
+	fake.ListIsolationSegmentsStub = nil
+	fake.listIsolationSegmentsReturns = struct {
+		result1 []repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) ListIsolationSegmentsReturnsOnCall(i int, result1 []repositories.IsolationSegmentRecord, result2 error) {
+	fake.listIsolationSegmentsMutex.Lock()
+	defer fake.listIsolationSegmentsMutex.Unlock()
+	fake.ListIsolationSegmentsStub = nil
+	if fake.listIsolationSegmentsReturnsOnCall == nil {
+		fake.listIsolationSegmentsReturnsOnCall = make(map[int]struct {
+			result1 []repositories.IsolationSegmentRecord
+			result2 error
+		})
+	}
+	fake.listIsolationSegmentsReturnsOnCall[i] = struct {
+		result1 []repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) RevokeIsolationSegment(arg1 context.Context, arg2 authorization.Info, arg3 repositories.RevokeIsolationSegmentMessage) error {
+	fake.revokeIsolationSegmentMutex.Lock()
+	ret, specificReturn := fake.revokeIsolationSegmentReturnsOnCall[len(fake.revokeIsolationSegmentArgsForCall)]
+	fake.revokeIsolationSegmentArgsForCall = append(fake.revokeIsolationSegmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.RevokeIsolationSegmentMessage
+	}{arg1, arg2, arg3})
+	stub := fake.RevokeIsolationSegmentStub
+	fakeReturns := fake.revokeIsolationSegmentReturns
+	fake.recordInvocation("RevokeIsolationSegment", []interface{}{arg1, arg2, arg3})
+	fake.revokeIsolationSegmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFIsolationSegmentRepository) RevokeIsolationSegmentCallCount() int {
+	fake.revokeIsolationSegmentMutex.RLock()
+	defer fake.revokeIsolationSegmentMutex.RUnlock()
+	return len(fake.revokeIsolationSegmentArgsForCall)
+}
+
+func (fake *CFIsolationSegmentRepository) RevokeIsolationSegmentCalls(stub func(context.Context, authorization.Info, repositories.RevokeIsolationSegmentMessage) error) {
+	fake.revokeIsolationSegmentMutex.Lock()
+	defer fake.revokeIsolationSegmentMutex.Unlock()
+	fake.RevokeIsolationSegmentStub = stub
+}
+
+func (fake *CFIsolationSegmentRepository) RevokeIsolationSegmentArgsForCall(i int) (context.Context, authorization.Info, repositories.RevokeIsolationSegmentMessage) {
+	fake.revokeIsolationSegmentMutex.RLock()
+	defer fake.revokeIsolationSegmentMutex.RUnlock()
+	argsForCall := fake.revokeIsolationSegmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFIsolationSegmentRepository) RevokeIsolationSegmentReturns(result1 error) {
+	fake.revokeIsolationSegmentMutex.Lock()
+	defer fake.revokeIsolationSegmentMutex.Unlock()
+	fake.RevokeIsolationSegmentStub = nil
+	fake.revokeIsolationSegmentReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFIsolationSegmentRepository) RevokeIsolationSegmentReturnsOnCall(i int, result1 error) {
+	fake.revokeIsolationSegmentMutex.Lock()
+	defer fake.revokeIsolationSegmentMutex.Unlock()
+	fake.RevokeIsolationSegmentStub = nil
+	if fake.revokeIsolationSegmentReturnsOnCall == nil {
+		fake.revokeIsolationSegmentReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.revokeIsolationSegmentReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFIsolationSegmentRepository) UpdateIsolationSegment(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UpdateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error) {
+	fake.updateIsolationSegmentMutex.Lock()
+	ret, specificReturn := fake.updateIsolationSegmentReturnsOnCall[len(fake.updateIsolationSegmentArgsForCall)]
+	fake.updateIsolationSegmentArgsForCall = append(fake.updateIsolationSegmentArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateIsolationSegmentMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateIsolationSegmentStub
+	fakeReturns := fake.updateIsolationSegmentReturns
+	fake.recordInvocation("UpdateIsolationSegment", []interface{}{arg1, arg2, arg3})
+	fake.updateIsolationSegmentMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFIsolationSegmentRepository) UpdateIsolationSegmentCallCount() int {
+	fake.updateIsolationSegmentMutex.RLock()
+	defer fake.updateIsolationSegmentMutex.RUnlock()
+	return len(fake.updateIsolationSegmentArgsForCall)
+}
+
+func (fake *CFIsolationSegmentRepository) UpdateIsolationSegmentCalls(stub func(context.Context, authorization.Info, repositories.UpdateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)) {
+	fake.updateIsolationSegmentMutex.Lock()
+	defer fake.updateIsolationSegmentMutex.Unlock()
+	fake.UpdateIsolationSegmentStub = stub
+}
+
+func (fake *CFIsolationSegmentRepository) UpdateIsolationSegmentArgsForCall(i int) (context.Context, authorization.Info, repositories.UpdateIsolationSegmentMessage) {
+	fake.updateIsolationSegmentMutex.RLock()
+	defer fake.updateIsolationSegmentMutex.RUnlock()
+	argsForCall := fake.updateIsolationSegmentArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFIsolationSegmentRepository) UpdateIsolationSegmentReturns(result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.updateIsolationSegmentMutex.Lock()
+	defer fake.updateIsolationSegmentMutex.Unlock()
+	fake.UpdateIsolationSegmentStub = nil
+	fake.updateIsolationSegmentReturns = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) UpdateIsolationSegmentReturnsOnCall(i int, result1 repositories.IsolationSegmentRecord, result2 error) {
+	fake.updateIsolationSegmentMutex.Lock()
+	defer fake.updateIsolationSegmentMutex.Unlock()
+	fake.UpdateIsolationSegmentStub = nil
+	if fake.updateIsolationSegmentReturnsOnCall == nil {
+		fake.updateIsolationSegmentReturnsOnCall = make(map[int]struct {
+			result1 repositories.IsolationSegmentRecord
+			result2 error
+		})
+	}
+	fake.updateIsolationSegmentReturnsOnCall[i] = struct {
+		result1 repositories.IsolationSegmentRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFIsolationSegmentRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.assignSpaceIsolationSegmentMutex.RLock()
+	defer fake.assignSpaceIsolationSegmentMutex.RUnlock()
+	fake.createIsolationSegmentMutex.RLock()
+	defer fake.createIsolationSegmentMutex.RUnlock()
+	fake.deleteIsolationSegmentMutex.RLock()
+	defer fake.deleteIsolationSegmentMutex.RUnlock()
+	fake.entitleIsolationSegmentMutex.RLock()
+	defer fake.entitleIsolationSegmentMutex.RUnlock()
+	fake.getIsolationSegmentMutex.RLock()
+	defer fake.getIsolationSegmentMutex.RUnlock()
+	fake.getSpaceIsolationSegmentMutex.RLock()
+	defer fake.getSpaceIsolationSegmentMutex.RUnlock()
+	fake.listIsolationSegmentOrgsMutex.RLock()
+	defer fake.listIsolationSegmentOrgsMutex.RUnlock()
+	fake.listIsolationSegmentSpacesMutex.RLock()
+	defer fake.listIsolationSegmentSpacesMutex.RUnlock()
+	fake.listIsolationSegmentsMutex.RLock()
+	defer fake.listIsolationSegmentsMutex.RUnlock()
+	fake.revokeIsolationSegmentMutex.RLock()
+	defer fake.revokeIsolationSegmentMutex.RUnlock()
+	fake.updateIsolationSegmentMutex.RLock()
+	defer fake.updateIsolationSegmentMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CFIsolationSegmentRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.CFIsolationSegmentRepository = new(CFIsolationSegmentRepository)
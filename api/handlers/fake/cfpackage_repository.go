@@ -11,6 +11,21 @@ import (
 )
 
 type CFPackageRepository struct {
+	CopyPackageStub        func(context.Context, authorization.Info, repositories.CopyPackageMessage) (repositories.PackageRecord, error)
+	copyPackageMutex       sync.RWMutex
+	copyPackageArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CopyPackageMessage
+	}
+	copyPackageReturns struct {
+		result1 repositories.PackageRecord
+		result2 error
+	}
+	copyPackageReturnsOnCall map[int]struct {
+		result1 repositories.PackageRecord
+		result2 error
+	}
 	CreatePackageStub        func(context.Context, authorization.Info, repositories.CreatePackageMessage) (repositories.PackageRecord, error)
 	createPackageMutex       sync.RWMutex
 	createPackageArgsForCall []struct {
@@ -26,6 +41,19 @@ type CFPackageRepository struct {
 		result1 repositories.PackageRecord
 		result2 error
 	}
+	DeletePackageStub        func(context.Context, authorization.Info, repositories.DeletePackageMessage) error
+	deletePackageMutex       sync.RWMutex
+	deletePackageArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.DeletePackageMessage
+	}
+	deletePackageReturns struct {
+		result1 error
+	}
+	deletePackageReturnsOnCall map[int]struct {
+		result1 error
+	}
 	GetPackageStub        func(context.Context, authorization.Info, string) (repositories.PackageRecord, error)
 	getPackageMutex       sync.RWMutex
 	getPackageArgsForCall []struct {
@@ -90,6 +118,72 @@ type CFPackageRepository struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *CFPackageRepository) CopyPackage(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CopyPackageMessage) (repositories.PackageRecord, error) {
+	fake.copyPackageMutex.Lock()
+	ret, specificReturn := fake.copyPackageReturnsOnCall[len(fake.copyPackageArgsForCall)]
+	fake.copyPackageArgsForCall = append(fake.copyPackageArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CopyPackageMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CopyPackageStub
+	fakeReturns := fake.copyPackageReturns
+	fake.recordInvocation("CopyPackage", []interface{}{arg1, arg2, arg3})
+	fake.copyPackageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFPackageRepository) CopyPackageCallCount() int {
+	fake.copyPackageMutex.RLock()
+	defer fake.copyPackageMutex.RUnlock()
+	return len(fake.copyPackageArgsForCall)
+}
+
+func (fake *CFPackageRepository) CopyPackageCalls(stub func(context.Context, authorization.Info, repositories.CopyPackageMessage) (repositories.PackageRecord, error)) {
+	fake.copyPackageMutex.Lock()
+	defer fake.copyPackageMutex.Unlock()
+	fake.CopyPackageStub = stub
+}
+
+func (fake *CFPackageRepository) CopyPackageArgsForCall(i int) (context.Context, authorization.Info, repositories.CopyPackageMessage) {
+	fake.copyPackageMutex.RLock()
+	defer fake.copyPackageMutex.RUnlock()
+	argsForCall := fake.copyPackageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFPackageRepository) CopyPackageReturns(result1 repositories.PackageRecord, result2 error) {
+	fake.copyPackageMutex.Lock()
+	defer fake.copyPackageMutex.Unlock()
+	fake.CopyPackageStub = nil
+	fake.copyPackageReturns = struct {
+		result1 repositories.PackageRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFPackageRepository) CopyPackageReturnsOnCall(i int, result1 repositories.PackageRecord, result2 error) {
+	fake.copyPackageMutex.Lock()
+	defer fake.copyPackageMutex.Unlock()
+	fake.CopyPackageStub = nil
+	if fake.copyPackageReturnsOnCall == nil {
+		fake.copyPackageReturnsOnCall = make(map[int]struct {
+			result1 repositories.PackageRecord
+			result2 error
+		})
+	}
+	fake.copyPackageReturnsOnCall[i] = struct {
+		result1 repositories.PackageRecord
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *CFPackageRepository) CreatePackage(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreatePackageMessage) (repositories.PackageRecord, error) {
 	fake.createPackageMutex.Lock()
 	ret, specificReturn := fake.createPackageReturnsOnCall[len(fake.createPackageArgsForCall)]
@@ -156,6 +250,69 @@ func (fake *CFPackageRepository) CreatePackageReturnsOnCall(i int, result1 repos
 	}{result1, result2}
 }
 
+func (fake *CFPackageRepository) DeletePackage(arg1 context.Context, arg2 authorization.Info, arg3 repositories.DeletePackageMessage) error {
+	fake.deletePackageMutex.Lock()
+	ret, specificReturn := fake.deletePackageReturnsOnCall[len(fake.deletePackageArgsForCall)]
+	fake.deletePackageArgsForCall = append(fake.deletePackageArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.DeletePackageMessage
+	}{arg1, arg2, arg3})
+	stub := fake.DeletePackageStub
+	fakeReturns := fake.deletePackageReturns
+	fake.recordInvocation("DeletePackage", []interface{}{arg1, arg2, arg3})
+	fake.deletePackageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFPackageRepository) DeletePackageCallCount() int {
+	fake.deletePackageMutex.RLock()
+	defer fake.deletePackageMutex.RUnlock()
+	return len(fake.deletePackageArgsForCall)
+}
+
+func (fake *CFPackageRepository) DeletePackageCalls(stub func(context.Context, authorization.Info, repositories.DeletePackageMessage) error) {
+	fake.deletePackageMutex.Lock()
+	defer fake.deletePackageMutex.Unlock()
+	fake.DeletePackageStub = stub
+}
+
+func (fake *CFPackageRepository) DeletePackageArgsForCall(i int) (context.Context, authorization.Info, repositories.DeletePackageMessage) {
+	fake.deletePackageMutex.RLock()
+	defer fake.deletePackageMutex.RUnlock()
+	argsForCall := fake.deletePackageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFPackageRepository) DeletePackageReturns(result1 error) {
+	fake.deletePackageMutex.Lock()
+	defer fake.deletePackageMutex.Unlock()
+	fake.DeletePackageStub = nil
+	fake.deletePackageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFPackageRepository) DeletePackageReturnsOnCall(i int, result1 error) {
+	fake.deletePackageMutex.Lock()
+	defer fake.deletePackageMutex.Unlock()
+	fake.DeletePackageStub = nil
+	if fake.deletePackageReturnsOnCall == nil {
+		fake.deletePackageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deletePackageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *CFPackageRepository) GetPackage(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.PackageRecord, error) {
 	fake.getPackageMutex.Lock()
 	ret, specificReturn := fake.getPackageReturnsOnCall[len(fake.getPackageArgsForCall)]
@@ -178,6 +335,8 @@ func (fake *CFPackageRepository) GetPackage(arg1 context.Context, arg2 authoriza
 }
 
 func (fake *CFPackageRepository) GetPackageCallCount() int {
+	fake.deletePackageMutex.RLock()
+	defer fake.deletePackageMutex.RUnlock()
 	fake.getPackageMutex.RLock()
 	defer fake.getPackageMutex.RUnlock()
 	return len(fake.getPackageArgsForCall)
@@ -423,6 +582,8 @@ func (fake *CFPackageRepository) UpdatePackageSourceReturnsOnCall(i int, result1
 func (fake *CFPackageRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.copyPackageMutex.RLock()
+	defer fake.copyPackageMutex.RUnlock()
 	fake.createPackageMutex.RLock()
 	defer fake.createPackageMutex.RUnlock()
 	fake.getPackageMutex.RLock()
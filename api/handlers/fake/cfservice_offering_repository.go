@@ -0,0 +1,206 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type CFServiceOfferingRepository struct {
+	GetServiceOfferingStub        func(context.Context, authorization.Info, string) (repositories.ServiceOfferingRecord, error)
+	getServiceOfferingMutex       sync.RWMutex
+	getServiceOfferingArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getServiceOfferingReturns struct {
+		result1 repositories.ServiceOfferingRecord
+		result2 error
+	}
+	getServiceOfferingReturnsOnCall map[int]struct {
+		result1 repositories.ServiceOfferingRecord
+		result2 error
+	}
+	ListServiceOfferingsStub        func(context.Context, authorization.Info, repositories.ListServiceOfferingMessage) ([]repositories.ServiceOfferingRecord, error)
+	listServiceOfferingsMutex       sync.RWMutex
+	listServiceOfferingsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListServiceOfferingMessage
+	}
+	listServiceOfferingsReturns struct {
+		result1 []repositories.ServiceOfferingRecord
+		result2 error
+	}
+	listServiceOfferingsReturnsOnCall map[int]struct {
+		result1 []repositories.ServiceOfferingRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CFServiceOfferingRepository) GetServiceOffering(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.ServiceOfferingRecord, error) {
+	fake.getServiceOfferingMutex.Lock()
+	ret, specificReturn := fake.getServiceOfferingReturnsOnCall[len(fake.getServiceOfferingArgsForCall)]
+	fake.getServiceOfferingArgsForCall = append(fake.getServiceOfferingArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetServiceOfferingStub
+	fakeReturns := fake.getServiceOfferingReturns
+	fake.recordInvocation("GetServiceOffering", []interface{}{arg1, arg2, arg3})
+	fake.getServiceOfferingMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFServiceOfferingRepository) GetServiceOfferingCallCount() int {
+	fake.getServiceOfferingMutex.RLock()
+	defer fake.getServiceOfferingMutex.RUnlock()
+	return len(fake.getServiceOfferingArgsForCall)
+}
+
+func (fake *CFServiceOfferingRepository) GetServiceOfferingCalls(stub func(context.Context, authorization.Info, string) (repositories.ServiceOfferingRecord, error)) {
+	fake.getServiceOfferingMutex.Lock()
+	defer fake.getServiceOfferingMutex.Unlock()
+	fake.GetServiceOfferingStub = stub
+}
+
+func (fake *CFServiceOfferingRepository) GetServiceOfferingArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getServiceOfferingMutex.RLock()
+	defer fake.getServiceOfferingMutex.RUnlock()
+	argsForCall := fake.getServiceOfferingArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServiceOfferingRepository) GetServiceOfferingReturns(result1 repositories.ServiceOfferingRecord, result2 error) {
+	fake.getServiceOfferingMutex.Lock()
+	defer fake.getServiceOfferingMutex.Unlock()
+	fake.GetServiceOfferingStub = nil
+	fake.getServiceOfferingReturns = struct {
+		result1 repositories.ServiceOfferingRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceOfferingRepository) GetServiceOfferingReturnsOnCall(i int, result1 repositories.ServiceOfferingRecord, result2 error) {
+	fake.getServiceOfferingMutex.Lock()
+	defer fake.getServiceOfferingMutex.Unlock()
+	fake.GetServiceOfferingStub = nil
+	if fake.getServiceOfferingReturnsOnCall == nil {
+		fake.getServiceOfferingReturnsOnCall = make(map[int]struct {
+			result1 repositories.ServiceOfferingRecord
+			result2 error
+		})
+	}
+	fake.getServiceOfferingReturnsOnCall[i] = struct {
+		result1 repositories.ServiceOfferingRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceOfferingRepository) ListServiceOfferings(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListServiceOfferingMessage) ([]repositories.ServiceOfferingRecord, error) {
+	fake.listServiceOfferingsMutex.Lock()
+	ret, specificReturn := fake.listServiceOfferingsReturnsOnCall[len(fake.listServiceOfferingsArgsForCall)]
+	fake.listServiceOfferingsArgsForCall = append(fake.listServiceOfferingsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListServiceOfferingMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListServiceOfferingsStub
+	fakeReturns := fake.listServiceOfferingsReturns
+	fake.recordInvocation("ListServiceOfferings", []interface{}{arg1, arg2, arg3})
+	fake.listServiceOfferingsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFServiceOfferingRepository) ListServiceOfferingsCallCount() int {
+	fake.listServiceOfferingsMutex.RLock()
+	defer fake.listServiceOfferingsMutex.RUnlock()
+	return len(fake.listServiceOfferingsArgsForCall)
+}
+
+func (fake *CFServiceOfferingRepository) ListServiceOfferingsCalls(stub func(context.Context, authorization.Info, repositories.ListServiceOfferingMessage) ([]repositories.ServiceOfferingRecord, error)) {
+	fake.listServiceOfferingsMutex.Lock()
+	defer fake.listServiceOfferingsMutex.Unlock()
+	fake.ListServiceOfferingsStub = stub
+}
+
+func (fake *CFServiceOfferingRepository) ListServiceOfferingsArgsForCall(i int) (context.Context, authorization.Info, repositories.ListServiceOfferingMessage) {
+	fake.listServiceOfferingsMutex.RLock()
+	defer fake.listServiceOfferingsMutex.RUnlock()
+	argsForCall := fake.listServiceOfferingsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServiceOfferingRepository) ListServiceOfferingsReturns(result1 []repositories.ServiceOfferingRecord, result2 error) {
+	fake.listServiceOfferingsMutex.Lock()
+	defer fake.listServiceOfferingsMutex.Unlock()
+	fake.ListServiceOfferingsStub = nil
+	fake.listServiceOfferingsReturns = struct {
+		result1 []repositories.ServiceOfferingRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceOfferingRepository) ListServiceOfferingsReturnsOnCall(i int, result1 []repositories.ServiceOfferingRecord, result2 error) {
+	fake.listServiceOfferingsMutex.Lock()
+	defer fake.listServiceOfferingsMutex.Unlock()
+	fake.ListServiceOfferingsStub = nil
+	if fake.listServiceOfferingsReturnsOnCall == nil {
+		fake.listServiceOfferingsReturnsOnCall = make(map[int]struct {
+			result1 []repositories.ServiceOfferingRecord
+			result2 error
+		})
+	}
+	fake.listServiceOfferingsReturnsOnCall[i] = struct {
+		result1 []repositories.ServiceOfferingRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServiceOfferingRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.getServiceOfferingMutex.RLock()
+	defer fake.getServiceOfferingMutex.RUnlock()
+	fake.listServiceOfferingsMutex.RLock()
+	defer fake.listServiceOfferingsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CFServiceOfferingRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.CFServiceOfferingRepository = new(CFServiceOfferingRepository)
@@ -0,0 +1,533 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type CFServicePlanRepository struct {
+	ApplyServicePlanVisibilityStub        func(context.Context, authorization.Info, repositories.ApplyServicePlanVisibilityMessage) (repositories.ServicePlanVisibilityRecord, error)
+	applyServicePlanVisibilityMutex       sync.RWMutex
+	applyServicePlanVisibilityArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ApplyServicePlanVisibilityMessage
+	}
+	applyServicePlanVisibilityReturns struct {
+		result1 repositories.ServicePlanVisibilityRecord
+		result2 error
+	}
+	applyServicePlanVisibilityReturnsOnCall map[int]struct {
+		result1 repositories.ServicePlanVisibilityRecord
+		result2 error
+	}
+	DeleteServicePlanVisibilityStub        func(context.Context, authorization.Info, repositories.DeleteServicePlanVisibilityMessage) error
+	deleteServicePlanVisibilityMutex       sync.RWMutex
+	deleteServicePlanVisibilityArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.DeleteServicePlanVisibilityMessage
+	}
+	deleteServicePlanVisibilityReturns struct {
+		result1 error
+	}
+	deleteServicePlanVisibilityReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetServicePlanStub        func(context.Context, authorization.Info, string) (repositories.ServicePlanRecord, error)
+	getServicePlanMutex       sync.RWMutex
+	getServicePlanArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getServicePlanReturns struct {
+		result1 repositories.ServicePlanRecord
+		result2 error
+	}
+	getServicePlanReturnsOnCall map[int]struct {
+		result1 repositories.ServicePlanRecord
+		result2 error
+	}
+	GetServicePlanVisibilityStub        func(context.Context, authorization.Info, string) (repositories.ServicePlanVisibilityRecord, error)
+	getServicePlanVisibilityMutex       sync.RWMutex
+	getServicePlanVisibilityArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getServicePlanVisibilityReturns struct {
+		result1 repositories.ServicePlanVisibilityRecord
+		result2 error
+	}
+	getServicePlanVisibilityReturnsOnCall map[int]struct {
+		result1 repositories.ServicePlanVisibilityRecord
+		result2 error
+	}
+	ListServicePlansStub        func(context.Context, authorization.Info, repositories.ListServicePlanMessage) ([]repositories.ServicePlanRecord, error)
+	listServicePlansMutex       sync.RWMutex
+	listServicePlansArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListServicePlanMessage
+	}
+	listServicePlansReturns struct {
+		result1 []repositories.ServicePlanRecord
+		result2 error
+	}
+	listServicePlansReturnsOnCall map[int]struct {
+		result1 []repositories.ServicePlanRecord
+		result2 error
+	}
+	UpdateServicePlanVisibilityStub        func(context.Context, authorization.Info, repositories.UpdateServicePlanVisibilityMessage) (repositories.ServicePlanVisibilityRecord, error)
+	updateServicePlanVisibilityMutex       sync.RWMutex
+	updateServicePlanVisibilityArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateServicePlanVisibilityMessage
+	}
+	updateServicePlanVisibilityReturns struct {
+		result1 repositories.ServicePlanVisibilityRecord
+		result2 error
+	}
+	updateServicePlanVisibilityReturnsOnCall map[int]struct {
+		result1 repositories.ServicePlanVisibilityRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CFServicePlanRepository) ApplyServicePlanVisibility(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ApplyServicePlanVisibilityMessage) (repositories.ServicePlanVisibilityRecord, error) {
+	fake.applyServicePlanVisibilityMutex.Lock()
+	ret, specificReturn := fake.applyServicePlanVisibilityReturnsOnCall[len(fake.applyServicePlanVisibilityArgsForCall)]
+	fake.applyServicePlanVisibilityArgsForCall = append(fake.applyServicePlanVisibilityArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ApplyServicePlanVisibilityMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ApplyServicePlanVisibilityStub
+	fakeReturns := fake.applyServicePlanVisibilityReturns
+	fake.recordInvocation("ApplyServicePlanVisibility", []interface{}{arg1, arg2, arg3})
+	fake.applyServicePlanVisibilityMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFServicePlanRepository) ApplyServicePlanVisibilityCallCount() int {
+	fake.applyServicePlanVisibilityMutex.RLock()
+	defer fake.applyServicePlanVisibilityMutex.RUnlock()
+	return len(fake.applyServicePlanVisibilityArgsForCall)
+}
+
+func (fake *CFServicePlanRepository) ApplyServicePlanVisibilityCalls(stub func(context.Context, authorization.Info, repositories.ApplyServicePlanVisibilityMessage) (repositories.ServicePlanVisibilityRecord, error)) {
+	fake.applyServicePlanVisibilityMutex.Lock()
+	defer fake.applyServicePlanVisibilityMutex.Unlock()
+	fake.ApplyServicePlanVisibilityStub = stub
+}
+
+func (fake *CFServicePlanRepository) ApplyServicePlanVisibilityArgsForCall(i int) (context.Context, authorization.Info, repositories.ApplyServicePlanVisibilityMessage) {
+	fake.applyServicePlanVisibilityMutex.RLock()
+	defer fake.applyServicePlanVisibilityMutex.RUnlock()
+	argsForCall := fake.applyServicePlanVisibilityArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServicePlanRepository) ApplyServicePlanVisibilityReturns(result1 repositories.ServicePlanVisibilityRecord, result2 error) {
+	fake.applyServicePlanVisibilityMutex.Lock()
+	defer fake.applyServicePlanVisibilityMutex.Unlock()
+	fake.ApplyServicePlanVisibilityStub = nil
+	fake.applyServicePlanVisibilityReturns = struct {
+		result1 repositories.ServicePlanVisibilityRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServicePlanRepository) ApplyServicePlanVisibilityReturnsOnCall(i int, result1 repositories.ServicePlanVisibilityRecord, result2 error) {
+	fake.applyServicePlanVisibilityMutex.Lock()
+	defer fake.applyServicePlanVisibilityMutex.Unlock()
+	fake.ApplyServicePlanVisibilityStub = nil
+	if fake.applyServicePlanVisibilityReturnsOnCall == nil {
+		fake.applyServicePlanVisibilityReturnsOnCall = make(map[int]struct {
+			result1 repositories.ServicePlanVisibilityRecord
+			result2 error
+		})
+	}
+	fake.applyServicePlanVisibilityReturnsOnCall[i] = struct {
+		result1 repositories.ServicePlanVisibilityRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServicePlanRepository) DeleteServicePlanVisibility(arg1 context.Context, arg2 authorization.Info, arg3 repositories.DeleteServicePlanVisibilityMessage) error {
+	fake.deleteServicePlanVisibilityMutex.Lock()
+	ret, specificReturn := fake.deleteServicePlanVisibilityReturnsOnCall[len(fake.deleteServicePlanVisibilityArgsForCall)]
+	fake.deleteServicePlanVisibilityArgsForCall = append(fake.deleteServicePlanVisibilityArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.DeleteServicePlanVisibilityMessage
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteServicePlanVisibilityStub
+	fakeReturns := fake.deleteServicePlanVisibilityReturns
+	fake.recordInvocation("DeleteServicePlanVisibility", []interface{}{arg1, arg2, arg3})
+	fake.deleteServicePlanVisibilityMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFServicePlanRepository) DeleteServicePlanVisibilityCallCount() int {
+	fake.deleteServicePlanVisibilityMutex.RLock()
+	defer fake.deleteServicePlanVisibilityMutex.RUnlock()
+	return len(fake.deleteServicePlanVisibilityArgsForCall)
+}
+
+func (fake *CFServicePlanRepository) DeleteServicePlanVisibilityCalls(stub func(context.Context, authorization.Info, repositories.DeleteServicePlanVisibilityMessage) error) {
+	fake.deleteServicePlanVisibilityMutex.Lock()
+	defer fake.deleteServicePlanVisibilityMutex.Unlock()
+	fake.DeleteServicePlanVisibilityStub = stub
+}
+
+func (fake *CFServicePlanRepository) DeleteServicePlanVisibilityArgsForCall(i int) (context.Context, authorization.Info, repositories.DeleteServicePlanVisibilityMessage) {
+	fake.deleteServicePlanVisibilityMutex.RLock()
+	defer fake.deleteServicePlanVisibilityMutex.RUnlock()
+	argsForCall := fake.deleteServicePlanVisibilityArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServicePlanRepository) DeleteServicePlanVisibilityReturns(result1 error) {
+	fake.deleteServicePlanVisibilityMutex.Lock()
+	defer fake.deleteServicePlanVisibilityMutex.Unlock()
+	fake.DeleteServicePlanVisibilityStub = nil
+	fake.deleteServicePlanVisibilityReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFServicePlanRepository) DeleteServicePlanVisibilityReturnsOnCall(i int, result1 error) {
+	fake.deleteServicePlanVisibilityMutex.Lock()
+	defer fake.deleteServicePlanVisibilityMutex.Unlock()
+	fake.DeleteServicePlanVisibilityStub = nil
+	if fake.deleteServicePlanVisibilityReturnsOnCall == nil {
+		fake.deleteServicePlanVisibilityReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteServicePlanVisibilityReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFServicePlanRepository) GetServicePlan(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.ServicePlanRecord, error) {
+	fake.getServicePlanMutex.Lock()
+	ret, specificReturn := fake.getServicePlanReturnsOnCall[len(fake.getServicePlanArgsForCall)]
+	fake.getServicePlanArgsForCall = append(fake.getServicePlanArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetServicePlanStub
+	fakeReturns := fake.getServicePlanReturns
+	fake.recordInvocation("GetServicePlan", []interface{}{arg1, arg2, arg3})
+	fake.getServicePlanMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFServicePlanRepository) GetServicePlanCallCount() int {
+	fake.getServicePlanMutex.RLock()
+	defer fake.getServicePlanMutex.RUnlock()
+	return len(fake.getServicePlanArgsForCall)
+}
+
+func (fake *CFServicePlanRepository) GetServicePlanCalls(stub func(context.Context, authorization.Info, string) (repositories.ServicePlanRecord, error)) {
+	fake.getServicePlanMutex.Lock()
+	defer fake.getServicePlanMutex.Unlock()
+	fake.GetServicePlanStub = stub
+}
+
+func (fake *CFServicePlanRepository) GetServicePlanArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getServicePlanMutex.RLock()
+	defer fake.getServicePlanMutex.RUnlock()
+	argsForCall := fake.getServicePlanArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServicePlanRepository) GetServicePlanReturns(result1 repositories.ServicePlanRecord, result2 error) {
+	fake.getServicePlanMutex.Lock()
+	defer fake.getServicePlanMutex.Unlock()
+	fake.GetServicePlanStub = nil
+	fake.getServicePlanReturns = struct {
+		result1 repositories.ServicePlanRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServicePlanRepository) GetServicePlanReturnsOnCall(i int, result1 repositories.ServicePlanRecord, result2 error) {
+	fake.getServicePlanMutex.Lock()
+	defer fake.getServicePlanMutex.Unlock()
+	fake.GetServicePlanStub = nil
+	if fake.getServicePlanReturnsOnCall == nil {
+		fake.getServicePlanReturnsOnCall = make(map[int]struct {
+			result1 repositories.ServicePlanRecord
+			result2 error
+		})
+	}
+	fake.getServicePlanReturnsOnCall[i] = struct {
+		result1 repositories.ServicePlanRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServicePlanRepository) GetServicePlanVisibility(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.ServicePlanVisibilityRecord, error) {
+	fake.getServicePlanVisibilityMutex.Lock()
+	ret, specificReturn := fake.getServicePlanVisibilityReturnsOnCall[len(fake.getServicePlanVisibilityArgsForCall)]
+	fake.getServicePlanVisibilityArgsForCall = append(fake.getServicePlanVisibilityArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetServicePlanVisibilityStub
+	fakeReturns := fake.getServicePlanVisibilityReturns
+	fake.recordInvocation("GetServicePlanVisibility", []interface{}{arg1, arg2, arg3})
+	fake.getServicePlanVisibilityMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFServicePlanRepository) GetServicePlanVisibilityCallCount() int {
+	fake.getServicePlanVisibilityMutex.RLock()
+	defer fake.getServicePlanVisibilityMutex.RUnlock()
+	return len(fake.getServicePlanVisibilityArgsForCall)
+}
+
+func (fake *CFServicePlanRepository) GetServicePlanVisibilityCalls(stub func(context.Context, authorization.Info, string) (repositories.ServicePlanVisibilityRecord, error)) {
+	fake.getServicePlanVisibilityMutex.Lock()
+	defer fake.getServicePlanVisibilityMutex.Unlock()
+	fake.GetServicePlanVisibilityStub = stub
+}
+
+func (fake *CFServicePlanRepository) GetServicePlanVisibilityArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getServicePlanVisibilityMutex.RLock()
+	defer fake.getServicePlanVisibilityMutex.RUnlock()
+	argsForCall := fake.getServicePlanVisibilityArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServicePlanRepository) GetServicePlanVisibilityReturns(result1 repositories.ServicePlanVisibilityRecord, result2 error) {
+	fake.getServicePlanVisibilityMutex.Lock()
+	defer fake.getServicePlanVisibilityMutex.Unlock()
+	fake.GetServicePlanVisibilityStub = nil
+	fake.getServicePlanVisibilityReturns = struct {
+		result1 repositories.ServicePlanVisibilityRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServicePlanRepository) GetServicePlanVisibilityReturnsOnCall(i int, result1 repositories.ServicePlanVisibilityRecord, result2 error) {
+	fake.getServicePlanVisibilityMutex.Lock()
+	defer fake.getServicePlanVisibilityMutex.Unlock()
+	fake.GetServicePlanVisibilityStub = nil
+	if fake.getServicePlanVisibilityReturnsOnCall == nil {
+		fake.getServicePlanVisibilityReturnsOnCall = make(map[int]struct {
+			result1 repositories.ServicePlanVisibilityRecord
+			result2 error
+		})
+	}
+	fake.getServicePlanVisibilityReturnsOnCall[i] = struct {
+		result1 repositories.ServicePlanVisibilityRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServicePlanRepository) ListServicePlans(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListServicePlanMessage) ([]repositories.ServicePlanRecord, error) {
+	fake.listServicePlansMutex.Lock()
+	ret, specificReturn := fake.listServicePlansReturnsOnCall[len(fake.listServicePlansArgsForCall)]
+	fake.listServicePlansArgsForCall = append(fake.listServicePlansArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListServicePlanMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListServicePlansStub
+	fakeReturns := fake.listServicePlansReturns
+	fake.recordInvocation("ListServicePlans", []interface{}{arg1, arg2, arg3})
+	fake.listServicePlansMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFServicePlanRepository) ListServicePlansCallCount() int {
+	fake.listServicePlansMutex.RLock()
+	defer fake.listServicePlansMutex.RUnlock()
+	return len(fake.listServicePlansArgsForCall)
+}
+
+func (fake *CFServicePlanRepository) ListServicePlansCalls(stub func(context.Context, authorization.Info, repositories.ListServicePlanMessage) ([]repositories.ServicePlanRecord, error)) {
+	fake.listServicePlansMutex.Lock()
+	defer fake.listServicePlansMutex.Unlock()
+	fake.ListServicePlansStub = stub
+}
+
+func (fake *CFServicePlanRepository) ListServicePlansArgsForCall(i int) (context.Context, authorization.Info, repositories.ListServicePlanMessage) {
+	fake.listServicePlansMutex.RLock()
+	defer fake.listServicePlansMutex.RUnlock()
+	argsForCall := fake.listServicePlansArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServicePlanRepository) ListServicePlansReturns(result1 []repositories.ServicePlanRecord, result2 error) {
+	fake.listServicePlansMutex.Lock()
+	defer fake.listServicePlansMutex.Unlock()
+	fake.ListServicePlansStub = nil
+	fake.listServicePlansReturns = struct {
+		result1 []repositories.ServicePlanRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServicePlanRepository) ListServicePlansReturnsOnCall(i int, result1 []repositories.ServicePlanRecord, result2 error) {
+	fake.listServicePlansMutex.Lock()
+	defer fake.listServicePlansMutex.Unlock()
+	fake.ListServicePlansStub = nil
+	if fake.listServicePlansReturnsOnCall == nil {
+		fake.listServicePlansReturnsOnCall = make(map[int]struct {
+			result1 []repositories.ServicePlanRecord
+			result2 error
+		})
+	}
+	fake.listServicePlansReturnsOnCall[i] = struct {
+		result1 []repositories.ServicePlanRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServicePlanRepository) UpdateServicePlanVisibility(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UpdateServicePlanVisibilityMessage) (repositories.ServicePlanVisibilityRecord, error) {
+	fake.updateServicePlanVisibilityMutex.Lock()
+	ret, specificReturn := fake.updateServicePlanVisibilityReturnsOnCall[len(fake.updateServicePlanVisibilityArgsForCall)]
+	fake.updateServicePlanVisibilityArgsForCall = append(fake.updateServicePlanVisibilityArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateServicePlanVisibilityMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateServicePlanVisibilityStub
+	fakeReturns := fake.updateServicePlanVisibilityReturns
+	fake.recordInvocation("UpdateServicePlanVisibility", []interface{}{arg1, arg2, arg3})
+	fake.updateServicePlanVisibilityMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFServicePlanRepository) UpdateServicePlanVisibilityCallCount() int {
+	fake.updateServicePlanVisibilityMutex.RLock()
+	defer fake.updateServicePlanVisibilityMutex.RUnlock()
+	return len(fake.updateServicePlanVisibilityArgsForCall)
+}
+
+func (fake *CFServicePlanRepository) UpdateServicePlanVisibilityCalls(stub func(context.Context, authorization.Info, repositories.UpdateServicePlanVisibilityMessage) (repositories.ServicePlanVisibilityRecord, error)) {
+	fake.updateServicePlanVisibilityMutex.Lock()
+	defer fake.updateServicePlanVisibilityMutex.Unlock()
+	fake.UpdateServicePlanVisibilityStub = stub
+}
+
+func (fake *CFServicePlanRepository) UpdateServicePlanVisibilityArgsForCall(i int) (context.Context, authorization.Info, repositories.UpdateServicePlanVisibilityMessage) {
+	fake.updateServicePlanVisibilityMutex.RLock()
+	defer fake.updateServicePlanVisibilityMutex.RUnlock()
+	argsForCall := fake.updateServicePlanVisibilityArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFServicePlanRepository) UpdateServicePlanVisibilityReturns(result1 repositories.ServicePlanVisibilityRecord, result2 error) {
+	fake.updateServicePlanVisibilityMutex.Lock()
+	defer fake.updateServicePlanVisibilityMutex.Unlock()
+	fake.UpdateServicePlanVisibilityStub = nil
+	fake.updateServicePlanVisibilityReturns = struct {
+		result1 repositories.ServicePlanVisibilityRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServicePlanRepository) UpdateServicePlanVisibilityReturnsOnCall(i int, result1 repositories.ServicePlanVisibilityRecord, result2 error) {
+	fake.updateServicePlanVisibilityMutex.Lock()
+	defer fake.updateServicePlanVisibilityMutex.Unlock()
+	fake.UpdateServicePlanVisibilityStub = nil
+	if fake.updateServicePlanVisibilityReturnsOnCall == nil {
+		fake.updateServicePlanVisibilityReturnsOnCall = make(map[int]struct {
+			result1 repositories.ServicePlanVisibilityRecord
+			result2 error
+		})
+	}
+	fake.updateServicePlanVisibilityReturnsOnCall[i] = struct {
+		result1 repositories.ServicePlanVisibilityRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFServicePlanRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.applyServicePlanVisibilityMutex.RLock()
+	defer fake.applyServicePlanVisibilityMutex.RUnlock()
+	fake.deleteServicePlanVisibilityMutex.RLock()
+	defer fake.deleteServicePlanVisibilityMutex.RUnlock()
+	fake.getServicePlanMutex.RLock()
+	defer fake.getServicePlanMutex.RUnlock()
+	fake.getServicePlanVisibilityMutex.RLock()
+	defer fake.getServicePlanVisibilityMutex.RUnlock()
+	fake.listServicePlansMutex.RLock()
+	defer fake.listServicePlansMutex.RUnlock()
+	fake.updateServicePlanVisibilityMutex.RLock()
+	defer fake.updateServicePlanVisibilityMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CFServicePlanRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.CFServicePlanRepository = new(CFServicePlanRepository)
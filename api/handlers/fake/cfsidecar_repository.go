@@ -0,0 +1,450 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type CFSidecarRepository struct {
+	CreateSidecarStub        func(context.Context, authorization.Info, repositories.CreateSidecarMessage) (repositories.SidecarRecord, error)
+	createSidecarMutex       sync.RWMutex
+	createSidecarArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateSidecarMessage
+	}
+	createSidecarReturns struct {
+		result1 repositories.SidecarRecord
+		result2 error
+	}
+	createSidecarReturnsOnCall map[int]struct {
+		result1 repositories.SidecarRecord
+		result2 error
+	}
+	DeleteSidecarStub        func(context.Context, authorization.Info, repositories.DeleteSidecarMessage) error
+	deleteSidecarMutex       sync.RWMutex
+	deleteSidecarArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.DeleteSidecarMessage
+	}
+	deleteSidecarReturns struct {
+		result1 error
+	}
+	deleteSidecarReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetSidecarStub        func(context.Context, authorization.Info, string) (repositories.SidecarRecord, error)
+	getSidecarMutex       sync.RWMutex
+	getSidecarArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getSidecarReturns struct {
+		result1 repositories.SidecarRecord
+		result2 error
+	}
+	getSidecarReturnsOnCall map[int]struct {
+		result1 repositories.SidecarRecord
+		result2 error
+	}
+	ListSidecarsStub        func(context.Context, authorization.Info, repositories.ListSidecarsMessage) ([]repositories.SidecarRecord, error)
+	listSidecarsMutex       sync.RWMutex
+	listSidecarsArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListSidecarsMessage
+	}
+	listSidecarsReturns struct {
+		result1 []repositories.SidecarRecord
+		result2 error
+	}
+	listSidecarsReturnsOnCall map[int]struct {
+		result1 []repositories.SidecarRecord
+		result2 error
+	}
+	PatchSidecarStub        func(context.Context, authorization.Info, repositories.PatchSidecarMessage) (repositories.SidecarRecord, error)
+	patchSidecarMutex       sync.RWMutex
+	patchSidecarArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.PatchSidecarMessage
+	}
+	patchSidecarReturns struct {
+		result1 repositories.SidecarRecord
+		result2 error
+	}
+	patchSidecarReturnsOnCall map[int]struct {
+		result1 repositories.SidecarRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CFSidecarRepository) CreateSidecar(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateSidecarMessage) (repositories.SidecarRecord, error) {
+	fake.createSidecarMutex.Lock()
+	ret, specificReturn := fake.createSidecarReturnsOnCall[len(fake.createSidecarArgsForCall)]
+	fake.createSidecarArgsForCall = append(fake.createSidecarArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateSidecarMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CreateSidecarStub
+	fakeReturns := fake.createSidecarReturns
+	fake.recordInvocation("CreateSidecar", []interface{}{arg1, arg2, arg3})
+	fake.createSidecarMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFSidecarRepository) CreateSidecarCallCount() int {
+	fake.createSidecarMutex.RLock()
+	defer fake.createSidecarMutex.RUnlock()
+	return len(fake.createSidecarArgsForCall)
+}
+
+func (fake *CFSidecarRepository) CreateSidecarCalls(stub func(context.Context, authorization.Info, repositories.CreateSidecarMessage) (repositories.SidecarRecord, error)) {
+	fake.createSidecarMutex.Lock()
+	defer fake.createSidecarMutex.Unlock()
+	fake.CreateSidecarStub = stub
+}
+
+func (fake *CFSidecarRepository) CreateSidecarArgsForCall(i int) (context.Context, authorization.Info, repositories.CreateSidecarMessage) {
+	fake.createSidecarMutex.RLock()
+	defer fake.createSidecarMutex.RUnlock()
+	argsForCall := fake.createSidecarArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFSidecarRepository) CreateSidecarReturns(result1 repositories.SidecarRecord, result2 error) {
+	fake.createSidecarMutex.Lock()
+	defer fake.createSidecarMutex.Unlock()
+	fake.CreateSidecarStub = nil
+	fake.createSidecarReturns = struct {
+		result1 repositories.SidecarRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFSidecarRepository) CreateSidecarReturnsOnCall(i int, result1 repositories.SidecarRecord, result2 error) {
+	fake.createSidecarMutex.Lock()
+	defer fake.createSidecarMutex.Unlock()
+	fake.CreateSidecarStub = nil
+	if fake.createSidecarReturnsOnCall == nil {
+		fake.createSidecarReturnsOnCall = make(map[int]struct {
+			result1 repositories.SidecarRecord
+			result2 error
+		})
+	}
+	fake.createSidecarReturnsOnCall[i] = struct {
+		result1 repositories.SidecarRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFSidecarRepository) DeleteSidecar(arg1 context.Context, arg2 authorization.Info, arg3 repositories.DeleteSidecarMessage) error {
+	fake.deleteSidecarMutex.Lock()
+	ret, specificReturn := fake.deleteSidecarReturnsOnCall[len(fake.deleteSidecarArgsForCall)]
+	fake.deleteSidecarArgsForCall = append(fake.deleteSidecarArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.DeleteSidecarMessage
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteSidecarStub
+	fakeReturns := fake.deleteSidecarReturns
+	fake.recordInvocation("DeleteSidecar", []interface{}{arg1, arg2, arg3})
+	fake.deleteSidecarMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFSidecarRepository) DeleteSidecarCallCount() int {
+	fake.deleteSidecarMutex.RLock()
+	defer fake.deleteSidecarMutex.RUnlock()
+	return len(fake.deleteSidecarArgsForCall)
+}
+
+func (fake *CFSidecarRepository) DeleteSidecarCalls(stub func(context.Context, authorization.Info, repositories.DeleteSidecarMessage) error) {
+	fake.deleteSidecarMutex.Lock()
+	defer fake.deleteSidecarMutex.Unlock()
+	fake.DeleteSidecarStub = stub
+}
+
+func (fake *CFSidecarRepository) DeleteSidecarArgsForCall(i int) (context.Context, authorization.Info, repositories.DeleteSidecarMessage) {
+	fake.deleteSidecarMutex.RLock()
+	defer fake.deleteSidecarMutex.RUnlock()
+	argsForCall := fake.deleteSidecarArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFSidecarRepository) DeleteSidecarReturns(result1 error) {
+	fake.deleteSidecarMutex.Lock()
+	defer fake.deleteSidecarMutex.Unlock()
+	fake.DeleteSidecarStub = nil
+	fake.deleteSidecarReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFSidecarRepository) DeleteSidecarReturnsOnCall(i int, result1 error) {
+	fake.deleteSidecarMutex.Lock()
+	defer fake.deleteSidecarMutex.Unlock()
+	fake.DeleteSidecarStub = nil
+	if fake.deleteSidecarReturnsOnCall == nil {
+		fake.deleteSidecarReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteSidecarReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFSidecarRepository) GetSidecar(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.SidecarRecord, error) {
+	fake.getSidecarMutex.Lock()
+	ret, specificReturn := fake.getSidecarReturnsOnCall[len(fake.getSidecarArgsForCall)]
+	fake.getSidecarArgsForCall = append(fake.getSidecarArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetSidecarStub
+	fakeReturns := fake.getSidecarReturns
+	fake.recordInvocation("GetSidecar", []interface{}{arg1, arg2, arg3})
+	fake.getSidecarMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFSidecarRepository) GetSidecarCallCount() int {
+	fake.getSidecarMutex.RLock()
+	defer fake.getSidecarMutex.RUnlock()
+	return len(fake.getSidecarArgsForCall)
+}
+
+func (fake *CFSidecarRepository) GetSidecarCalls(stub func(context.Context, authorization.Info, string) (repositories.SidecarRecord, error)) {
+	fake.getSidecarMutex.Lock()
+	defer fake.getSidecarMutex.Unlock()
+	fake.GetSidecarStub = stub
+}
+
+func (fake *CFSidecarRepository) GetSidecarArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getSidecarMutex.RLock()
+	defer fake.getSidecarMutex.RUnlock()
+	argsForCall := fake.getSidecarArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFSidecarRepository) GetSidecarReturns(result1 repositories.SidecarRecord, result2 error) {
+	fake.getSidecarMutex.Lock()
+	defer fake.getSidecarMutex.Unlock()
+	fake.GetSidecarStub = nil
+	fake.getSidecarReturns = struct {
+		result1 repositories.SidecarRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFSidecarRepository) GetSidecarReturnsOnCall(i int, result1 repositories.SidecarRecord, result2 error) {
+	fake.getSidecarMutex.Lock()
+	defer fake.getSidecarMutex.Unlock()
+	fake.GetSidecarStub = nil
+	if fake.getSidecarReturnsOnCall == nil {
+		fake.getSidecarReturnsOnCall = make(map[int]struct {
+			result1 repositories.SidecarRecord
+			result2 error
+		})
+	}
+	fake.getSidecarReturnsOnCall[i] = struct {
+		result1 repositories.SidecarRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFSidecarRepository) ListSidecars(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListSidecarsMessage) ([]repositories.SidecarRecord, error) {
+	fake.listSidecarsMutex.Lock()
+	ret, specificReturn := fake.listSidecarsReturnsOnCall[len(fake.listSidecarsArgsForCall)]
+	fake.listSidecarsArgsForCall = append(fake.listSidecarsArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListSidecarsMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListSidecarsStub
+	fakeReturns := fake.listSidecarsReturns
+	fake.recordInvocation("ListSidecars", []interface{}{arg1, arg2, arg3})
+	fake.listSidecarsMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFSidecarRepository) ListSidecarsCallCount() int {
+	fake.listSidecarsMutex.RLock()
+	defer fake.listSidecarsMutex.RUnlock()
+	return len(fake.listSidecarsArgsForCall)
+}
+
+func (fake *CFSidecarRepository) ListSidecarsCalls(stub func(context.Context, authorization.Info, repositories.ListSidecarsMessage) ([]repositories.SidecarRecord, error)) {
+	fake.listSidecarsMutex.Lock()
+	defer fake.listSidecarsMutex.Unlock()
+	fake.ListSidecarsStub = stub
+}
+
+func (fake *CFSidecarRepository) ListSidecarsArgsForCall(i int) (context.Context, authorization.Info, repositories.ListSidecarsMessage) {
+	fake.listSidecarsMutex.RLock()
+	defer fake.listSidecarsMutex.RUnlock()
+	argsForCall := fake.listSidecarsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFSidecarRepository) ListSidecarsReturns(result1 []repositories.SidecarRecord, result2 error) {
+	fake.listSidecarsMutex.Lock()
+	defer fake.listSidecarsMutex.Unlock()
+	fake.ListSidecarsStub = nil
+	fake.listSidecarsReturns = struct {
+		result1 []repositories.SidecarRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFSidecarRepository) ListSidecarsReturnsOnCall(i int, result1 []repositories.SidecarRecord, result2 error) {
+	fake.listSidecarsMutex.Lock()
+	defer fake.listSidecarsMutex.Unlock()
+	fake.ListSidecarsStub = nil
+	if fake.listSidecarsReturnsOnCall == nil {
+		fake.listSidecarsReturnsOnCall = make(map[int]struct {
+			result1 []repositories.SidecarRecord
+			result2 error
+		})
+	}
+	fake.listSidecarsReturnsOnCall[i] = struct {
+		result1 []repositories.SidecarRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFSidecarRepository) PatchSidecar(arg1 context.Context, arg2 authorization.Info, arg3 repositories.PatchSidecarMessage) (repositories.SidecarRecord, error) {
+	fake.patchSidecarMutex.Lock()
+	ret, specificReturn := fake.patchSidecarReturnsOnCall[len(fake.patchSidecarArgsForCall)]
+	fake.patchSidecarArgsForCall = append(fake.patchSidecarArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.PatchSidecarMessage
+	}{arg1, arg2, arg3})
+	stub := fake.PatchSidecarStub
+	fakeReturns := fake.patchSidecarReturns
+	fake.recordInvocation("PatchSidecar", []interface{}{arg1, arg2, arg3})
+	fake.patchSidecarMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFSidecarRepository) PatchSidecarCallCount() int {
+	fake.patchSidecarMutex.RLock()
+	defer fake.patchSidecarMutex.RUnlock()
+	return len(fake.patchSidecarArgsForCall)
+}
+
+func (fake *CFSidecarRepository) PatchSidecarCalls(stub func(context.Context, authorization.Info, repositories.PatchSidecarMessage) (repositories.SidecarRecord, error)) {
+	fake.patchSidecarMutex.Lock()
+	defer fake.patchSidecarMutex.Unlock()
+	fake.PatchSidecarStub = stub
+}
+
+func (fake *CFSidecarRepository) PatchSidecarArgsForCall(i int) (context.Context, authorization.Info, repositories.PatchSidecarMessage) {
+	fake.patchSidecarMutex.RLock()
+	defer fake.patchSidecarMutex.RUnlock()
+	argsForCall := fake.patchSidecarArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFSidecarRepository) PatchSidecarReturns(result1 repositories.SidecarRecord, result2 error) {
+	fake.patchSidecarMutex.Lock()
+	defer fake.patchSidecarMutex.Unlock()
+	fake.PatchSidecarStub = nil
+	fake.patchSidecarReturns = struct {
+		result1 repositories.SidecarRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFSidecarRepository) PatchSidecarReturnsOnCall(i int, result1 repositories.SidecarRecord, result2 error) {
+	fake.patchSidecarMutex.Lock()
+	defer fake.patchSidecarMutex.Unlock()
+	fake.PatchSidecarStub = nil
+	if fake.patchSidecarReturnsOnCall == nil {
+		fake.patchSidecarReturnsOnCall = make(map[int]struct {
+			result1 repositories.SidecarRecord
+			result2 error
+		})
+	}
+	fake.patchSidecarReturnsOnCall[i] = struct {
+		result1 repositories.SidecarRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFSidecarRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createSidecarMutex.RLock()
+	defer fake.createSidecarMutex.RUnlock()
+	fake.deleteSidecarMutex.RLock()
+	defer fake.deleteSidecarMutex.RUnlock()
+	fake.getSidecarMutex.RLock()
+	defer fake.getSidecarMutex.RUnlock()
+	fake.listSidecarsMutex.RLock()
+	defer fake.listSidecarsMutex.RUnlock()
+	fake.patchSidecarMutex.RLock()
+	defer fake.patchSidecarMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CFSidecarRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.CFSidecarRepository = new(CFSidecarRepository)
@@ -0,0 +1,694 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type CFSpaceQuotaRepository struct {
+	ApplySpaceQuotaStub        func(context.Context, authorization.Info, repositories.ApplySpaceQuotaMessage) (repositories.SpaceQuotaRecord, error)
+	applySpaceQuotaMutex       sync.RWMutex
+	applySpaceQuotaArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ApplySpaceQuotaMessage
+	}
+	applySpaceQuotaReturns struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}
+	applySpaceQuotaReturnsOnCall map[int]struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}
+	CreateSpaceQuotaStub        func(context.Context, authorization.Info, repositories.CreateSpaceQuotaMessage) (repositories.SpaceQuotaRecord, error)
+	createSpaceQuotaMutex       sync.RWMutex
+	createSpaceQuotaArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateSpaceQuotaMessage
+	}
+	createSpaceQuotaReturns struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}
+	createSpaceQuotaReturnsOnCall map[int]struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}
+	DeleteSpaceQuotaStub        func(context.Context, authorization.Info, string) error
+	deleteSpaceQuotaMutex       sync.RWMutex
+	deleteSpaceQuotaArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	deleteSpaceQuotaReturns struct {
+		result1 error
+	}
+	deleteSpaceQuotaReturnsOnCall map[int]struct {
+		result1 error
+	}
+	GetSpaceQuotaStub        func(context.Context, authorization.Info, string) (repositories.SpaceQuotaRecord, error)
+	getSpaceQuotaMutex       sync.RWMutex
+	getSpaceQuotaArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getSpaceQuotaReturns struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}
+	getSpaceQuotaReturnsOnCall map[int]struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}
+	GetSpaceUsageSummaryStub        func(context.Context, authorization.Info, string) (repositories.UsageSummaryRecord, error)
+	getSpaceUsageSummaryMutex       sync.RWMutex
+	getSpaceUsageSummaryArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}
+	getSpaceUsageSummaryReturns struct {
+		result1 repositories.UsageSummaryRecord
+		result2 error
+	}
+	getSpaceUsageSummaryReturnsOnCall map[int]struct {
+		result1 repositories.UsageSummaryRecord
+		result2 error
+	}
+	ListSpaceQuotasStub        func(context.Context, authorization.Info, repositories.ListSpaceQuotasMessage) ([]repositories.SpaceQuotaRecord, error)
+	listSpaceQuotasMutex       sync.RWMutex
+	listSpaceQuotasArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListSpaceQuotasMessage
+	}
+	listSpaceQuotasReturns struct {
+		result1 []repositories.SpaceQuotaRecord
+		result2 error
+	}
+	listSpaceQuotasReturnsOnCall map[int]struct {
+		result1 []repositories.SpaceQuotaRecord
+		result2 error
+	}
+	RemoveSpaceQuotaStub        func(context.Context, authorization.Info, repositories.RemoveSpaceQuotaMessage) error
+	removeSpaceQuotaMutex       sync.RWMutex
+	removeSpaceQuotaArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.RemoveSpaceQuotaMessage
+	}
+	removeSpaceQuotaReturns struct {
+		result1 error
+	}
+	removeSpaceQuotaReturnsOnCall map[int]struct {
+		result1 error
+	}
+	UpdateSpaceQuotaStub        func(context.Context, authorization.Info, repositories.UpdateSpaceQuotaMessage) (repositories.SpaceQuotaRecord, error)
+	updateSpaceQuotaMutex       sync.RWMutex
+	updateSpaceQuotaArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateSpaceQuotaMessage
+	}
+	updateSpaceQuotaReturns struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}
+	updateSpaceQuotaReturnsOnCall map[int]struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *CFSpaceQuotaRepository) ApplySpaceQuota(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ApplySpaceQuotaMessage) (repositories.SpaceQuotaRecord, error) {
+	fake.applySpaceQuotaMutex.Lock()
+	ret, specificReturn := fake.applySpaceQuotaReturnsOnCall[len(fake.applySpaceQuotaArgsForCall)]
+	fake.applySpaceQuotaArgsForCall = append(fake.applySpaceQuotaArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ApplySpaceQuotaMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ApplySpaceQuotaStub
+	fakeReturns := fake.applySpaceQuotaReturns
+	fake.recordInvocation("ApplySpaceQuota", []interface{}{arg1, arg2, arg3})
+	fake.applySpaceQuotaMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFSpaceQuotaRepository) ApplySpaceQuotaCallCount() int {
+	fake.applySpaceQuotaMutex.RLock()
+	defer fake.applySpaceQuotaMutex.RUnlock()
+	return len(fake.applySpaceQuotaArgsForCall)
+}
+
+func (fake *CFSpaceQuotaRepository) ApplySpaceQuotaCalls(stub func(context.Context, authorization.Info, repositories.ApplySpaceQuotaMessage) (repositories.SpaceQuotaRecord, error)) {
+	fake.applySpaceQuotaMutex.Lock()
+	defer fake.applySpaceQuotaMutex.Unlock()
+	fake.ApplySpaceQuotaStub = stub
+}
+
+func (fake *CFSpaceQuotaRepository) ApplySpaceQuotaArgsForCall(i int) (context.Context, authorization.Info, repositories.ApplySpaceQuotaMessage) {
+	fake.applySpaceQuotaMutex.RLock()
+	defer fake.applySpaceQuotaMutex.RUnlock()
+	argsForCall := fake.applySpaceQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFSpaceQuotaRepository) ApplySpaceQuotaReturns(result1 repositories.SpaceQuotaRecord, result2 error) {
+	fake.applySpaceQuotaMutex.Lock()
+	defer fake.applySpaceQuotaMutex.Unlock()
+	fake.ApplySpaceQuotaStub = nil
+	fake.applySpaceQuotaReturns = struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFSpaceQuotaRepository) ApplySpaceQuotaReturnsOnCall(i int, result1 repositories.SpaceQuotaRecord, result2 error) {
+	fake.applySpaceQuotaMutex.Lock()
+	defer fake.applySpaceQuotaMutex.Unlock()
+	fake.ApplySpaceQuotaStub = nil
+	if fake.applySpaceQuotaReturnsOnCall == nil {
+		fake.applySpaceQuotaReturnsOnCall = make(map[int]struct {
+			result1 repositories.SpaceQuotaRecord
+			result2 error
+		})
+	}
+	fake.applySpaceQuotaReturnsOnCall[i] = struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFSpaceQuotaRepository) CreateSpaceQuota(arg1 context.Context, arg2 authorization.Info, arg3 repositories.CreateSpaceQuotaMessage) (repositories.SpaceQuotaRecord, error) {
+	fake.createSpaceQuotaMutex.Lock()
+	ret, specificReturn := fake.createSpaceQuotaReturnsOnCall[len(fake.createSpaceQuotaArgsForCall)]
+	fake.createSpaceQuotaArgsForCall = append(fake.createSpaceQuotaArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.CreateSpaceQuotaMessage
+	}{arg1, arg2, arg3})
+	stub := fake.CreateSpaceQuotaStub
+	fakeReturns := fake.createSpaceQuotaReturns
+	fake.recordInvocation("CreateSpaceQuota", []interface{}{arg1, arg2, arg3})
+	fake.createSpaceQuotaMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFSpaceQuotaRepository) CreateSpaceQuotaCallCount() int {
+	fake.createSpaceQuotaMutex.RLock()
+	defer fake.createSpaceQuotaMutex.RUnlock()
+	return len(fake.createSpaceQuotaArgsForCall)
+}
+
+func (fake *CFSpaceQuotaRepository) CreateSpaceQuotaCalls(stub func(context.Context, authorization.Info, repositories.CreateSpaceQuotaMessage) (repositories.SpaceQuotaRecord, error)) {
+	fake.createSpaceQuotaMutex.Lock()
+	defer fake.createSpaceQuotaMutex.Unlock()
+	fake.CreateSpaceQuotaStub = stub
+}
+
+func (fake *CFSpaceQuotaRepository) CreateSpaceQuotaArgsForCall(i int) (context.Context, authorization.Info, repositories.CreateSpaceQuotaMessage) {
+	fake.createSpaceQuotaMutex.RLock()
+	defer fake.createSpaceQuotaMutex.RUnlock()
+	argsForCall := fake.createSpaceQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFSpaceQuotaRepository) CreateSpaceQuotaReturns(result1 repositories.SpaceQuotaRecord, result2 error) {
+	fake.createSpaceQuotaMutex.Lock()
+	defer fake.createSpaceQuotaMutex.Unlock()
+	fake.CreateSpaceQuotaStub = nil
+	fake.createSpaceQuotaReturns = struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFSpaceQuotaRepository) CreateSpaceQuotaReturnsOnCall(i int, result1 repositories.SpaceQuotaRecord, result2 error) {
+	fake.createSpaceQuotaMutex.Lock()
+	defer fake.createSpaceQuotaMutex.Unlock()
+	fake.CreateSpaceQuotaStub = nil
+	if fake.createSpaceQuotaReturnsOnCall == nil {
+		fake.createSpaceQuotaReturnsOnCall = make(map[int]struct {
+			result1 repositories.SpaceQuotaRecord
+			result2 error
+		})
+	}
+	fake.createSpaceQuotaReturnsOnCall[i] = struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFSpaceQuotaRepository) DeleteSpaceQuota(arg1 context.Context, arg2 authorization.Info, arg3 string) error {
+	fake.deleteSpaceQuotaMutex.Lock()
+	ret, specificReturn := fake.deleteSpaceQuotaReturnsOnCall[len(fake.deleteSpaceQuotaArgsForCall)]
+	fake.deleteSpaceQuotaArgsForCall = append(fake.deleteSpaceQuotaArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteSpaceQuotaStub
+	fakeReturns := fake.deleteSpaceQuotaReturns
+	fake.recordInvocation("DeleteSpaceQuota", []interface{}{arg1, arg2, arg3})
+	fake.deleteSpaceQuotaMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFSpaceQuotaRepository) DeleteSpaceQuotaCallCount() int {
+	fake.deleteSpaceQuotaMutex.RLock()
+	defer fake.deleteSpaceQuotaMutex.RUnlock()
+	return len(fake.deleteSpaceQuotaArgsForCall)
+}
+
+func (fake *CFSpaceQuotaRepository) DeleteSpaceQuotaCalls(stub func(context.Context, authorization.Info, string) error) {
+	fake.deleteSpaceQuotaMutex.Lock()
+	defer fake.deleteSpaceQuotaMutex.Unlock()
+	fake.DeleteSpaceQuotaStub = stub
+}
+
+func (fake *CFSpaceQuotaRepository) DeleteSpaceQuotaArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.deleteSpaceQuotaMutex.RLock()
+	defer fake.deleteSpaceQuotaMutex.RUnlock()
+	argsForCall := fake.deleteSpaceQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFSpaceQuotaRepository) DeleteSpaceQuotaReturns(result1 error) {
+	fake.deleteSpaceQuotaMutex.Lock()
+	defer fake.deleteSpaceQuotaMutex.Unlock()
+	fake.DeleteSpaceQuotaStub = nil
+	fake.deleteSpaceQuotaReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFSpaceQuotaRepository) DeleteSpaceQuotaReturnsOnCall(i int, result1 error) {
+	fake.deleteSpaceQuotaMutex.Lock()
+	defer fake.deleteSpaceQuotaMutex.Unlock()
+	fake.DeleteSpaceQuotaStub = nil
+	if fake.deleteSpaceQuotaReturnsOnCall == nil {
+		fake.deleteSpaceQuotaReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteSpaceQuotaReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFSpaceQuotaRepository) GetSpaceQuota(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.SpaceQuotaRecord, error) {
+	fake.getSpaceQuotaMutex.Lock()
+	ret, specificReturn := fake.getSpaceQuotaReturnsOnCall[len(fake.getSpaceQuotaArgsForCall)]
+	fake.getSpaceQuotaArgsForCall = append(fake.getSpaceQuotaArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetSpaceQuotaStub
+	fakeReturns := fake.getSpaceQuotaReturns
+	fake.recordInvocation("GetSpaceQuota", []interface{}{arg1, arg2, arg3})
+	fake.getSpaceQuotaMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFSpaceQuotaRepository) GetSpaceQuotaCallCount() int {
+	fake.getSpaceQuotaMutex.RLock()
+	defer fake.getSpaceQuotaMutex.RUnlock()
+	return len(fake.getSpaceQuotaArgsForCall)
+}
+
+func (fake *CFSpaceQuotaRepository) GetSpaceQuotaCalls(stub func(context.Context, authorization.Info, string) (repositories.SpaceQuotaRecord, error)) {
+	fake.getSpaceQuotaMutex.Lock()
+	defer fake.getSpaceQuotaMutex.Unlock()
+	fake.GetSpaceQuotaStub = stub
+}
+
+func (fake *CFSpaceQuotaRepository) GetSpaceQuotaArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getSpaceQuotaMutex.RLock()
+	defer fake.getSpaceQuotaMutex.RUnlock()
+	argsForCall := fake.getSpaceQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFSpaceQuotaRepository) GetSpaceQuotaReturns(result1 repositories.SpaceQuotaRecord, result2 error) {
+	fake.getSpaceQuotaMutex.Lock()
+	defer fake.getSpaceQuotaMutex.Unlock()
+	fake.GetSpaceQuotaStub = nil
+	fake.getSpaceQuotaReturns = struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFSpaceQuotaRepository) GetSpaceQuotaReturnsOnCall(i int, result1 repositories.SpaceQuotaRecord, result2 error) {
+	fake.getSpaceQuotaMutex.Lock()
+	defer fake.getSpaceQuotaMutex.Unlock()
+	fake.GetSpaceQuotaStub = nil
+	if fake.getSpaceQuotaReturnsOnCall == nil {
+		fake.getSpaceQuotaReturnsOnCall = make(map[int]struct {
+			result1 repositories.SpaceQuotaRecord
+			result2 error
+		})
+	}
+	fake.getSpaceQuotaReturnsOnCall[i] = struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFSpaceQuotaRepository) GetSpaceUsageSummary(arg1 context.Context, arg2 authorization.Info, arg3 string) (repositories.UsageSummaryRecord, error) {
+	fake.getSpaceUsageSummaryMutex.Lock()
+	ret, specificReturn := fake.getSpaceUsageSummaryReturnsOnCall[len(fake.getSpaceUsageSummaryArgsForCall)]
+	fake.getSpaceUsageSummaryArgsForCall = append(fake.getSpaceUsageSummaryArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+	}{arg1, arg2, arg3})
+	stub := fake.GetSpaceUsageSummaryStub
+	fakeReturns := fake.getSpaceUsageSummaryReturns
+	fake.recordInvocation("GetSpaceUsageSummary", []interface{}{arg1, arg2, arg3})
+	fake.getSpaceUsageSummaryMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFSpaceQuotaRepository) GetSpaceUsageSummaryCallCount() int {
+	fake.getSpaceUsageSummaryMutex.RLock()
+	defer fake.getSpaceUsageSummaryMutex.RUnlock()
+	return len(fake.getSpaceUsageSummaryArgsForCall)
+}
+
+func (fake *CFSpaceQuotaRepository) GetSpaceUsageSummaryCalls(stub func(context.Context, authorization.Info, string) (repositories.UsageSummaryRecord, error)) {
+	fake.getSpaceUsageSummaryMutex.Lock()
+	defer fake.getSpaceUsageSummaryMutex.Unlock()
+	fake.GetSpaceUsageSummaryStub = stub
+}
+
+func (fake *CFSpaceQuotaRepository) GetSpaceUsageSummaryArgsForCall(i int) (context.Context, authorization.Info, string) {
+	fake.getSpaceUsageSummaryMutex.RLock()
+	defer fake.getSpaceUsageSummaryMutex.RUnlock()
+	argsForCall := fake.getSpaceUsageSummaryArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFSpaceQuotaRepository) GetSpaceUsageSummaryReturns(result1 repositories.UsageSummaryRecord, result2 error) {
+	fake.getSpaceUsageSummaryMutex.Lock()
+	defer fake.getSpaceUsageSummaryMutex.Unlock()
+	fake.GetSpaceUsageSummaryStub = nil
+	fake.getSpaceUsageSummaryReturns = struct {
+		result1 repositories.UsageSummaryRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFSpaceQuotaRepository) GetSpaceUsageSummaryReturnsOnCall(i int, result1 repositories.UsageSummaryRecord, result2 error) {
+	fake.getSpaceUsageSummaryMutex.Lock()
+	defer fake.getSpaceUsageSummaryMutex.Unlock()
+	fake.GetSpaceUsageSummaryStub = nil
+	if fake.getSpaceUsageSummaryReturnsOnCall == nil {
+		fake.getSpaceUsageSummaryReturnsOnCall = make(map[int]struct {
+			result1 repositories.UsageSummaryRecord
+			result2 error
+		})
+	}
+	fake.getSpaceUsageSummaryReturnsOnCall[i] = struct {
+		result1 repositories.UsageSummaryRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFSpaceQuotaRepository) ListSpaceQuotas(arg1 context.Context, arg2 authorization.Info, arg3 repositories.ListSpaceQuotasMessage) ([]repositories.SpaceQuotaRecord, error) {
+	fake.listSpaceQuotasMutex.Lock()
+	ret, specificReturn := fake.listSpaceQuotasReturnsOnCall[len(fake.listSpaceQuotasArgsForCall)]
+	fake.listSpaceQuotasArgsForCall = append(fake.listSpaceQuotasArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.ListSpaceQuotasMessage
+	}{arg1, arg2, arg3})
+	stub := fake.ListSpaceQuotasStub
+	fakeReturns := fake.listSpaceQuotasReturns
+	fake.recordInvocation("ListSpaceQuotas", []interface{}{arg1, arg2, arg3})
+	fake.listSpaceQuotasMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFSpaceQuotaRepository) ListSpaceQuotasCallCount() int {
+	fake.listSpaceQuotasMutex.RLock()
+	defer fake.listSpaceQuotasMutex.RUnlock()
+	return len(fake.listSpaceQuotasArgsForCall)
+}
+
+func (fake *CFSpaceQuotaRepository) ListSpaceQuotasCalls(stub func(context.Context, authorization.Info, repositories.ListSpaceQuotasMessage) ([]repositories.SpaceQuotaRecord, error)) {
+	fake.listSpaceQuotasMutex.Lock()
+	defer fake.listSpaceQuotasMutex.Unlock()
+	fake.ListSpaceQuotasStub = stub
+}
+
+func (fake *CFSpaceQuotaRepository) ListSpaceQuotasArgsForCall(i int) (context.Context, authorization.Info, repositories.ListSpaceQuotasMessage) {
+	fake.listSpaceQuotasMutex.RLock()
+	defer fake.listSpaceQuotasMutex.RUnlock()
+	argsForCall := fake.listSpaceQuotasArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFSpaceQuotaRepository) ListSpaceQuotasReturns(result1 []repositories.SpaceQuotaRecord, result2 error) {
+	fake.listSpaceQuotasMutex.Lock()
+	defer fake.listSpaceQuotasMutex.Unlock()
+	fake.ListSpaceQuotasStub = nil
+	fake.listSpaceQuotasReturns = struct {
+		result1 []repositories.SpaceQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFSpaceQuotaRepository) ListSpaceQuotasReturnsOnCall(i int, result1 []repositories.SpaceQuotaRecord, result2 error) {
+	fake.listSpaceQuotasMutex.Lock()
+	defer fake.listSpaceQuotasMutex.Unlock()
+	fake.ListSpaceQuotasStub = nil
+	if fake.listSpaceQuotasReturnsOnCall == nil {
+		fake.listSpaceQuotasReturnsOnCall = make(map[int]struct {
+			result1 []repositories.SpaceQuotaRecord
+			result2 error
+		})
+	}
+	fake.listSpaceQuotasReturnsOnCall[i] = struct {
+		result1 []repositories.SpaceQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFSpaceQuotaRepository) RemoveSpaceQuota(arg1 context.Context, arg2 authorization.Info, arg3 repositories.RemoveSpaceQuotaMessage) error {
+	fake.removeSpaceQuotaMutex.Lock()
+	ret, specificReturn := fake.removeSpaceQuotaReturnsOnCall[len(fake.removeSpaceQuotaArgsForCall)]
+	fake.removeSpaceQuotaArgsForCall = append(fake.removeSpaceQuotaArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.RemoveSpaceQuotaMessage
+	}{arg1, arg2, arg3})
+	stub := fake.RemoveSpaceQuotaStub
+	fakeReturns := fake.removeSpaceQuotaReturns
+	fake.recordInvocation("RemoveSpaceQuota", []interface{}{arg1, arg2, arg3})
+	fake.removeSpaceQuotaMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *CFSpaceQuotaRepository) RemoveSpaceQuotaCallCount() int {
+	fake.removeSpaceQuotaMutex.RLock()
+	defer fake.removeSpaceQuotaMutex.RUnlock()
+	return len(fake.removeSpaceQuotaArgsForCall)
+}
+
+func (fake *CFSpaceQuotaRepository) RemoveSpaceQuotaCalls(stub func(context.Context, authorization.Info, repositories.RemoveSpaceQuotaMessage) error) {
+	fake.removeSpaceQuotaMutex.Lock()
+	defer fake.removeSpaceQuotaMutex.Unlock()
+	fake.RemoveSpaceQuotaStub = stub
+}
+
+func (fake *CFSpaceQuotaRepository) RemoveSpaceQuotaArgsForCall(i int) (context.Context, authorization.Info, repositories.RemoveSpaceQuotaMessage) {
+	fake.removeSpaceQuotaMutex.RLock()
+	defer fake.removeSpaceQuotaMutex.RUnlock()
+	argsForCall := fake.removeSpaceQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFSpaceQuotaRepository) RemoveSpaceQuotaReturns(result1 error) {
+	fake.removeSpaceQuotaMutex.Lock()
+	defer fake.removeSpaceQuotaMutex.Unlock()
+	fake.RemoveSpaceQuotaStub = nil
+	fake.removeSpaceQuotaReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFSpaceQuotaRepository) RemoveSpaceQuotaReturnsOnCall(i int, result1 error) {
+	fake.removeSpaceQuotaMutex.Lock()
+	defer fake.removeSpaceQuotaMutex.Unlock()
+	fake.RemoveSpaceQuotaStub = nil
+	if fake.removeSpaceQuotaReturnsOnCall == nil {
+		fake.removeSpaceQuotaReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.removeSpaceQuotaReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *CFSpaceQuotaRepository) UpdateSpaceQuota(arg1 context.Context, arg2 authorization.Info, arg3 repositories.UpdateSpaceQuotaMessage) (repositories.SpaceQuotaRecord, error) {
+	fake.updateSpaceQuotaMutex.Lock()
+	ret, specificReturn := fake.updateSpaceQuotaReturnsOnCall[len(fake.updateSpaceQuotaArgsForCall)]
+	fake.updateSpaceQuotaArgsForCall = append(fake.updateSpaceQuotaArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 repositories.UpdateSpaceQuotaMessage
+	}{arg1, arg2, arg3})
+	stub := fake.UpdateSpaceQuotaStub
+	fakeReturns := fake.updateSpaceQuotaReturns
+	fake.recordInvocation("UpdateSpaceQuota", []interface{}{arg1, arg2, arg3})
+	fake.updateSpaceQuotaMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *CFSpaceQuotaRepository) UpdateSpaceQuotaCallCount() int {
+	fake.updateSpaceQuotaMutex.RLock()
+	defer fake.updateSpaceQuotaMutex.RUnlock()
+	return len(fake.updateSpaceQuotaArgsForCall)
+}
+
+func (fake *CFSpaceQuotaRepository) UpdateSpaceQuotaCalls(stub func(context.Context, authorization.Info, repositories.UpdateSpaceQuotaMessage) (repositories.SpaceQuotaRecord, error)) {
+	fake.updateSpaceQuotaMutex.Lock()
+	defer fake.updateSpaceQuotaMutex.Unlock()
+	fake.UpdateSpaceQuotaStub = stub
+}
+
+func (fake *CFSpaceQuotaRepository) UpdateSpaceQuotaArgsForCall(i int) (context.Context, authorization.Info, repositories.UpdateSpaceQuotaMessage) {
+	fake.updateSpaceQuotaMutex.RLock()
+	defer fake.updateSpaceQuotaMutex.RUnlock()
+	argsForCall := fake.updateSpaceQuotaArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *CFSpaceQuotaRepository) UpdateSpaceQuotaReturns(result1 repositories.SpaceQuotaRecord, result2 error) {
+	fake.updateSpaceQuotaMutex.Lock()
+	defer fake.updateSpaceQuotaMutex.Unlock()
+	fake.UpdateSpaceQuotaStub = nil
+	fake.updateSpaceQuotaReturns = struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFSpaceQuotaRepository) UpdateSpaceQuotaReturnsOnCall(i int, result1 repositories.SpaceQuotaRecord, result2 error) {
+	fake.updateSpaceQuotaMutex.Lock()
+	defer fake.updateSpaceQuotaMutex.Unlock()
+	fake.UpdateSpaceQuotaStub = nil
+	if fake.updateSpaceQuotaReturnsOnCall == nil {
+		fake.updateSpaceQuotaReturnsOnCall = make(map[int]struct {
+			result1 repositories.SpaceQuotaRecord
+			result2 error
+		})
+	}
+	fake.updateSpaceQuotaReturnsOnCall[i] = struct {
+		result1 repositories.SpaceQuotaRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *CFSpaceQuotaRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.applySpaceQuotaMutex.RLock()
+	defer fake.applySpaceQuotaMutex.RUnlock()
+	fake.createSpaceQuotaMutex.RLock()
+	defer fake.createSpaceQuotaMutex.RUnlock()
+	fake.deleteSpaceQuotaMutex.RLock()
+	defer fake.deleteSpaceQuotaMutex.RUnlock()
+	fake.getSpaceQuotaMutex.RLock()
+	defer fake.getSpaceQuotaMutex.RUnlock()
+	fake.getSpaceUsageSummaryMutex.RLock()
+	defer fake.getSpaceUsageSummaryMutex.RUnlock()
+	fake.listSpaceQuotasMutex.RLock()
+	defer fake.listSpaceQuotasMutex.RUnlock()
+	fake.removeSpaceQuotaMutex.RLock()
+	defer fake.removeSpaceQuotaMutex.RUnlock()
+	fake.updateSpaceQuotaMutex.RLock()
+	defer fake.updateSpaceQuotaMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *CFSpaceQuotaRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.CFSpaceQuotaRepository = new(CFSpaceQuotaRepository)
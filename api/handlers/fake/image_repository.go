@@ -11,6 +11,74 @@ import (
 )
 
 type ImageRepository struct {
+	CopyDropletImageStub        func(context.Context, authorization.Info, string, string, string, ...string) (string, error)
+	copyDropletImageMutex       sync.RWMutex
+	copyDropletImageArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 []string
+	}
+	copyDropletImageReturns struct {
+		result1 string
+		result2 error
+	}
+	copyDropletImageReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	CopyPackageImageStub        func(context.Context, authorization.Info, string, string, string, ...string) (string, error)
+	copyPackageImageMutex       sync.RWMutex
+	copyPackageImageArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 []string
+	}
+	copyPackageImageReturns struct {
+		result1 string
+		result2 error
+	}
+	copyPackageImageReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	DownloadImageStub        func(context.Context, string) (io.ReadCloser, error)
+	downloadImageMutex       sync.RWMutex
+	downloadImageArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+	}
+	downloadImageReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	downloadImageReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	UploadDropletImageStub        func(context.Context, authorization.Info, string, io.Reader, string, ...string) (string, error)
+	uploadDropletImageMutex       sync.RWMutex
+	uploadDropletImageArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 io.Reader
+		arg5 string
+		arg6 []string
+	}
+	uploadDropletImageReturns struct {
+		result1 string
+		result2 error
+	}
+	uploadDropletImageReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
 	UploadSourceImageStub        func(context.Context, authorization.Info, string, io.Reader, string, ...string) (string, error)
 	uploadSourceImageMutex       sync.RWMutex
 	uploadSourceImageArgsForCall []struct {
@@ -33,6 +101,282 @@ type ImageRepository struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *ImageRepository) CopyDropletImage(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 string, arg5 string, arg6 ...string) (string, error) {
+	fake.copyDropletImageMutex.Lock()
+	ret, specificReturn := fake.copyDropletImageReturnsOnCall[len(fake.copyDropletImageArgsForCall)]
+	fake.copyDropletImageArgsForCall = append(fake.copyDropletImageArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 []string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.CopyDropletImageStub
+	fakeReturns := fake.copyDropletImageReturns
+	fake.recordInvocation("CopyDropletImage", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.copyDropletImageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImageRepository) CopyDropletImageCallCount() int {
+	fake.copyDropletImageMutex.RLock()
+	defer fake.copyDropletImageMutex.RUnlock()
+	fake.copyPackageImageMutex.RLock()
+	defer fake.copyPackageImageMutex.RUnlock()
+	return len(fake.copyDropletImageArgsForCall)
+}
+
+func (fake *ImageRepository) CopyDropletImageCalls(stub func(context.Context, authorization.Info, string, string, string, ...string) (string, error)) {
+	fake.copyDropletImageMutex.Lock()
+	defer fake.copyDropletImageMutex.Unlock()
+	fake.CopyDropletImageStub = stub
+}
+
+func (fake *ImageRepository) CopyDropletImageArgsForCall(i int) (context.Context, authorization.Info, string, string, string, []string) {
+	fake.copyDropletImageMutex.RLock()
+	defer fake.copyDropletImageMutex.RUnlock()
+	fake.copyPackageImageMutex.RLock()
+	defer fake.copyPackageImageMutex.RUnlock()
+	argsForCall := fake.copyDropletImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *ImageRepository) CopyDropletImageReturns(result1 string, result2 error) {
+	fake.copyDropletImageMutex.Lock()
+	defer fake.copyDropletImageMutex.Unlock()
+	fake.CopyDropletImageStub = nil
+	fake.copyDropletImageReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageRepository) CopyDropletImageReturnsOnCall(i int, result1 string, result2 error) {
+	fake.copyDropletImageMutex.Lock()
+	defer fake.copyDropletImageMutex.Unlock()
+	fake.CopyDropletImageStub = nil
+	if fake.copyDropletImageReturnsOnCall == nil {
+		fake.copyDropletImageReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.copyDropletImageReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageRepository) CopyPackageImage(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 string, arg5 string, arg6 ...string) (string, error) {
+	fake.copyPackageImageMutex.Lock()
+	ret, specificReturn := fake.copyPackageImageReturnsOnCall[len(fake.copyPackageImageArgsForCall)]
+	fake.copyPackageImageArgsForCall = append(fake.copyPackageImageArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 string
+		arg5 string
+		arg6 []string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.CopyPackageImageStub
+	fakeReturns := fake.copyPackageImageReturns
+	fake.recordInvocation("CopyPackageImage", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.copyPackageImageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImageRepository) CopyPackageImageCallCount() int {
+	fake.copyPackageImageMutex.RLock()
+	defer fake.copyPackageImageMutex.RUnlock()
+	return len(fake.copyPackageImageArgsForCall)
+}
+
+func (fake *ImageRepository) CopyPackageImageCalls(stub func(context.Context, authorization.Info, string, string, string, ...string) (string, error)) {
+	fake.copyPackageImageMutex.Lock()
+	defer fake.copyPackageImageMutex.Unlock()
+	fake.CopyPackageImageStub = stub
+}
+
+func (fake *ImageRepository) CopyPackageImageArgsForCall(i int) (context.Context, authorization.Info, string, string, string, []string) {
+	fake.copyPackageImageMutex.RLock()
+	defer fake.copyPackageImageMutex.RUnlock()
+	argsForCall := fake.copyPackageImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *ImageRepository) CopyPackageImageReturns(result1 string, result2 error) {
+	fake.copyPackageImageMutex.Lock()
+	defer fake.copyPackageImageMutex.Unlock()
+	fake.CopyPackageImageStub = nil
+	fake.copyPackageImageReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageRepository) CopyPackageImageReturnsOnCall(i int, result1 string, result2 error) {
+	fake.copyPackageImageMutex.Lock()
+	defer fake.copyPackageImageMutex.Unlock()
+	fake.CopyPackageImageStub = nil
+	if fake.copyPackageImageReturnsOnCall == nil {
+		fake.copyPackageImageReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.copyPackageImageReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageRepository) DownloadImage(arg1 context.Context, arg2 string) (io.ReadCloser, error) {
+	fake.downloadImageMutex.Lock()
+	ret, specificReturn := fake.downloadImageReturnsOnCall[len(fake.downloadImageArgsForCall)]
+	fake.downloadImageArgsForCall = append(fake.downloadImageArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+	}{arg1, arg2})
+	stub := fake.DownloadImageStub
+	fakeReturns := fake.downloadImageReturns
+	fake.recordInvocation("DownloadImage", []interface{}{arg1, arg2})
+	fake.downloadImageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImageRepository) DownloadImageCallCount() int {
+	fake.downloadImageMutex.RLock()
+	defer fake.downloadImageMutex.RUnlock()
+	return len(fake.downloadImageArgsForCall)
+}
+
+func (fake *ImageRepository) DownloadImageCalls(stub func(context.Context, string) (io.ReadCloser, error)) {
+	fake.downloadImageMutex.Lock()
+	defer fake.downloadImageMutex.Unlock()
+	fake.DownloadImageStub = stub
+}
+
+func (fake *ImageRepository) DownloadImageArgsForCall(i int) (context.Context, string) {
+	fake.downloadImageMutex.RLock()
+	defer fake.downloadImageMutex.RUnlock()
+	argsForCall := fake.downloadImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ImageRepository) DownloadImageReturns(result1 io.ReadCloser, result2 error) {
+	fake.downloadImageMutex.Lock()
+	defer fake.downloadImageMutex.Unlock()
+	fake.DownloadImageStub = nil
+	fake.downloadImageReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageRepository) DownloadImageReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.downloadImageMutex.Lock()
+	defer fake.downloadImageMutex.Unlock()
+	fake.DownloadImageStub = nil
+	if fake.downloadImageReturnsOnCall == nil {
+		fake.downloadImageReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.downloadImageReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageRepository) UploadDropletImage(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 io.Reader, arg5 string, arg6 ...string) (string, error) {
+	fake.uploadDropletImageMutex.Lock()
+	ret, specificReturn := fake.uploadDropletImageReturnsOnCall[len(fake.uploadDropletImageArgsForCall)]
+	fake.uploadDropletImageArgsForCall = append(fake.uploadDropletImageArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 io.Reader
+		arg5 string
+		arg6 []string
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	stub := fake.UploadDropletImageStub
+	fakeReturns := fake.uploadDropletImageReturns
+	fake.recordInvocation("UploadDropletImage", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.uploadDropletImageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4, arg5, arg6...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ImageRepository) UploadDropletImageCallCount() int {
+	fake.uploadDropletImageMutex.RLock()
+	defer fake.uploadDropletImageMutex.RUnlock()
+	return len(fake.uploadDropletImageArgsForCall)
+}
+
+func (fake *ImageRepository) UploadDropletImageCalls(stub func(context.Context, authorization.Info, string, io.Reader, string, ...string) (string, error)) {
+	fake.uploadDropletImageMutex.Lock()
+	defer fake.uploadDropletImageMutex.Unlock()
+	fake.UploadDropletImageStub = stub
+}
+
+func (fake *ImageRepository) UploadDropletImageArgsForCall(i int) (context.Context, authorization.Info, string, io.Reader, string, []string) {
+	fake.uploadDropletImageMutex.RLock()
+	defer fake.uploadDropletImageMutex.RUnlock()
+	argsForCall := fake.uploadDropletImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
+}
+
+func (fake *ImageRepository) UploadDropletImageReturns(result1 string, result2 error) {
+	fake.uploadDropletImageMutex.Lock()
+	defer fake.uploadDropletImageMutex.Unlock()
+	fake.UploadDropletImageStub = nil
+	fake.uploadDropletImageReturns = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ImageRepository) UploadDropletImageReturnsOnCall(i int, result1 string, result2 error) {
+	fake.uploadDropletImageMutex.Lock()
+	defer fake.uploadDropletImageMutex.Unlock()
+	fake.UploadDropletImageStub = nil
+	if fake.uploadDropletImageReturnsOnCall == nil {
+		fake.uploadDropletImageReturnsOnCall = make(map[int]struct {
+			result1 string
+			result2 error
+		})
+	}
+	fake.uploadDropletImageReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ImageRepository) UploadSourceImage(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 io.Reader, arg5 string, arg6 ...string) (string, error) {
 	fake.uploadSourceImageMutex.Lock()
 	ret, specificReturn := fake.uploadSourceImageReturnsOnCall[len(fake.uploadSourceImageArgsForCall)]
@@ -105,6 +449,14 @@ func (fake *ImageRepository) UploadSourceImageReturnsOnCall(i int, result1 strin
 func (fake *ImageRepository) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.copyDropletImageMutex.RLock()
+	defer fake.copyDropletImageMutex.RUnlock()
+	fake.copyPackageImageMutex.RLock()
+	defer fake.copyPackageImageMutex.RUnlock()
+	fake.downloadImageMutex.RLock()
+	defer fake.downloadImageMutex.RUnlock()
+	fake.uploadDropletImageMutex.RLock()
+	defer fake.uploadDropletImageMutex.RUnlock()
 	fake.uploadSourceImageMutex.RLock()
 	defer fake.uploadSourceImageMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
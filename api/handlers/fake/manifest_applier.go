@@ -5,6 +5,7 @@ import (
 	"context"
 	"sync"
 
+	"code.cloudfoundry.org/korifi/api/actions/manifest"
 	"code.cloudfoundry.org/korifi/api/authorization"
 	"code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/payloads"
@@ -25,6 +26,22 @@ type ManifestApplier struct {
 	applyReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DiffStub        func(context.Context, authorization.Info, string, payloads.Manifest) ([]manifest.Diff, error)
+	diffMutex       sync.RWMutex
+	diffArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 payloads.Manifest
+	}
+	diffReturns struct {
+		result1 []manifest.Diff
+		result2 error
+	}
+	diffReturnsOnCall map[int]struct {
+		result1 []manifest.Diff
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -93,11 +110,80 @@ func (fake *ManifestApplier) ApplyReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *ManifestApplier) Diff(arg1 context.Context, arg2 authorization.Info, arg3 string, arg4 payloads.Manifest) ([]manifest.Diff, error) {
+	fake.diffMutex.Lock()
+	ret, specificReturn := fake.diffReturnsOnCall[len(fake.diffArgsForCall)]
+	fake.diffArgsForCall = append(fake.diffArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 string
+		arg4 payloads.Manifest
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.DiffStub
+	fakeReturns := fake.diffReturns
+	fake.recordInvocation("Diff", []interface{}{arg1, arg2, arg3, arg4})
+	fake.diffMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ManifestApplier) DiffCallCount() int {
+	fake.diffMutex.RLock()
+	defer fake.diffMutex.RUnlock()
+	return len(fake.diffArgsForCall)
+}
+
+func (fake *ManifestApplier) DiffCalls(stub func(context.Context, authorization.Info, string, payloads.Manifest) ([]manifest.Diff, error)) {
+	fake.diffMutex.Lock()
+	defer fake.diffMutex.Unlock()
+	fake.DiffStub = stub
+}
+
+func (fake *ManifestApplier) DiffArgsForCall(i int) (context.Context, authorization.Info, string, payloads.Manifest) {
+	fake.diffMutex.RLock()
+	defer fake.diffMutex.RUnlock()
+	argsForCall := fake.diffArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ManifestApplier) DiffReturns(result1 []manifest.Diff, result2 error) {
+	fake.diffMutex.Lock()
+	defer fake.diffMutex.Unlock()
+	fake.DiffStub = nil
+	fake.diffReturns = struct {
+		result1 []manifest.Diff
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ManifestApplier) DiffReturnsOnCall(i int, result1 []manifest.Diff, result2 error) {
+	fake.diffMutex.Lock()
+	defer fake.diffMutex.Unlock()
+	fake.DiffStub = nil
+	if fake.diffReturnsOnCall == nil {
+		fake.diffReturnsOnCall = make(map[int]struct {
+			result1 []manifest.Diff
+			result2 error
+		})
+	}
+	fake.diffReturnsOnCall[i] = struct {
+		result1 []manifest.Diff
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *ManifestApplier) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.applyMutex.RLock()
 	defer fake.applyMutex.RUnlock()
+	fake.diffMutex.RLock()
+	defer fake.diffMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,294 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type ResourceCacheRepository struct {
+	AssemblePackageStub        func(context.Context, io.ReaderAt, int64, []repositories.ResourceRecord) (io.ReadCloser, error)
+	assemblePackageMutex       sync.RWMutex
+	assemblePackageArgsForCall []struct {
+		arg1 context.Context
+		arg2 io.ReaderAt
+		arg3 int64
+		arg4 []repositories.ResourceRecord
+	}
+	assemblePackageReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	assemblePackageReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	CacheResourcesStub        func(context.Context, io.ReaderAt, int64) error
+	cacheResourcesMutex       sync.RWMutex
+	cacheResourcesArgsForCall []struct {
+		arg1 context.Context
+		arg2 io.ReaderAt
+		arg3 int64
+	}
+	cacheResourcesReturns struct {
+		result1 error
+	}
+	cacheResourcesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	MatchResourcesStub        func(context.Context, []repositories.ResourceRecord) ([]repositories.ResourceRecord, error)
+	matchResourcesMutex       sync.RWMutex
+	matchResourcesArgsForCall []struct {
+		arg1 context.Context
+		arg2 []repositories.ResourceRecord
+	}
+	matchResourcesReturns struct {
+		result1 []repositories.ResourceRecord
+		result2 error
+	}
+	matchResourcesReturnsOnCall map[int]struct {
+		result1 []repositories.ResourceRecord
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *ResourceCacheRepository) AssemblePackage(arg1 context.Context, arg2 io.ReaderAt, arg3 int64, arg4 []repositories.ResourceRecord) (io.ReadCloser, error) {
+	var arg4Copy []repositories.ResourceRecord
+	if arg4 != nil {
+		arg4Copy = make([]repositories.ResourceRecord, len(arg4))
+		copy(arg4Copy, arg4)
+	}
+	fake.assemblePackageMutex.Lock()
+	ret, specificReturn := fake.assemblePackageReturnsOnCall[len(fake.assemblePackageArgsForCall)]
+	fake.assemblePackageArgsForCall = append(fake.assemblePackageArgsForCall, struct {
+		arg1 context.Context
+		arg2 io.ReaderAt
+		arg3 int64
+		arg4 []repositories.ResourceRecord
+	}{arg1, arg2, arg3, arg4Copy})
+	stub := fake.AssemblePackageStub
+	fakeReturns := fake.assemblePackageReturns
+	fake.recordInvocation("AssemblePackage", []interface{}{arg1, arg2, arg3, arg4Copy})
+	fake.assemblePackageMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ResourceCacheRepository) AssemblePackageCallCount() int {
+	fake.assemblePackageMutex.RLock()
+	defer fake.assemblePackageMutex.RUnlock()
+	return len(fake.assemblePackageArgsForCall)
+}
+
+func (fake *ResourceCacheRepository) AssemblePackageCalls(stub func(context.Context, io.ReaderAt, int64, []repositories.ResourceRecord) (io.ReadCloser, error)) {
+	fake.assemblePackageMutex.Lock()
+	defer fake.assemblePackageMutex.Unlock()
+	fake.AssemblePackageStub = stub
+}
+
+func (fake *ResourceCacheRepository) AssemblePackageArgsForCall(i int) (context.Context, io.ReaderAt, int64, []repositories.ResourceRecord) {
+	fake.assemblePackageMutex.RLock()
+	defer fake.assemblePackageMutex.RUnlock()
+	argsForCall := fake.assemblePackageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *ResourceCacheRepository) AssemblePackageReturns(result1 io.ReadCloser, result2 error) {
+	fake.assemblePackageMutex.Lock()
+	defer fake.assemblePackageMutex.Unlock()
+	fake.AssemblePackageStub = nil
+	fake.assemblePackageReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ResourceCacheRepository) AssemblePackageReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.assemblePackageMutex.Lock()
+	defer fake.assemblePackageMutex.Unlock()
+	fake.AssemblePackageStub = nil
+	if fake.assemblePackageReturnsOnCall == nil {
+		fake.assemblePackageReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.assemblePackageReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ResourceCacheRepository) CacheResources(arg1 context.Context, arg2 io.ReaderAt, arg3 int64) error {
+	fake.cacheResourcesMutex.Lock()
+	ret, specificReturn := fake.cacheResourcesReturnsOnCall[len(fake.cacheResourcesArgsForCall)]
+	fake.cacheResourcesArgsForCall = append(fake.cacheResourcesArgsForCall, struct {
+		arg1 context.Context
+		arg2 io.ReaderAt
+		arg3 int64
+	}{arg1, arg2, arg3})
+	stub := fake.CacheResourcesStub
+	fakeReturns := fake.cacheResourcesReturns
+	fake.recordInvocation("CacheResources", []interface{}{arg1, arg2, arg3})
+	fake.cacheResourcesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *ResourceCacheRepository) CacheResourcesCallCount() int {
+	fake.cacheResourcesMutex.RLock()
+	defer fake.cacheResourcesMutex.RUnlock()
+	return len(fake.cacheResourcesArgsForCall)
+}
+
+func (fake *ResourceCacheRepository) CacheResourcesCalls(stub func(context.Context, io.ReaderAt, int64) error) {
+	fake.cacheResourcesMutex.Lock()
+	defer fake.cacheResourcesMutex.Unlock()
+	fake.CacheResourcesStub = stub
+}
+
+func (fake *ResourceCacheRepository) CacheResourcesArgsForCall(i int) (context.Context, io.ReaderAt, int64) {
+	fake.cacheResourcesMutex.RLock()
+	defer fake.cacheResourcesMutex.RUnlock()
+	argsForCall := fake.cacheResourcesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *ResourceCacheRepository) CacheResourcesReturns(result1 error) {
+	fake.cacheResourcesMutex.Lock()
+	defer fake.cacheResourcesMutex.Unlock()
+	fake.CacheResourcesStub = nil
+	fake.cacheResourcesReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ResourceCacheRepository) CacheResourcesReturnsOnCall(i int, result1 error) {
+	fake.cacheResourcesMutex.Lock()
+	defer fake.cacheResourcesMutex.Unlock()
+	fake.CacheResourcesStub = nil
+	if fake.cacheResourcesReturnsOnCall == nil {
+		fake.cacheResourcesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.cacheResourcesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *ResourceCacheRepository) MatchResources(arg1 context.Context, arg2 []repositories.ResourceRecord) ([]repositories.ResourceRecord, error) {
+	var arg2Copy []repositories.ResourceRecord
+	if arg2 != nil {
+		arg2Copy = make([]repositories.ResourceRecord, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.matchResourcesMutex.Lock()
+	ret, specificReturn := fake.matchResourcesReturnsOnCall[len(fake.matchResourcesArgsForCall)]
+	fake.matchResourcesArgsForCall = append(fake.matchResourcesArgsForCall, struct {
+		arg1 context.Context
+		arg2 []repositories.ResourceRecord
+	}{arg1, arg2Copy})
+	stub := fake.MatchResourcesStub
+	fakeReturns := fake.matchResourcesReturns
+	fake.recordInvocation("MatchResources", []interface{}{arg1, arg2Copy})
+	fake.matchResourcesMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *ResourceCacheRepository) MatchResourcesCallCount() int {
+	fake.matchResourcesMutex.RLock()
+	defer fake.matchResourcesMutex.RUnlock()
+	return len(fake.matchResourcesArgsForCall)
+}
+
+func (fake *ResourceCacheRepository) MatchResourcesCalls(stub func(context.Context, []repositories.ResourceRecord) ([]repositories.ResourceRecord, error)) {
+	fake.matchResourcesMutex.Lock()
+	defer fake.matchResourcesMutex.Unlock()
+	fake.MatchResourcesStub = stub
+}
+
+func (fake *ResourceCacheRepository) MatchResourcesArgsForCall(i int) (context.Context, []repositories.ResourceRecord) {
+	fake.matchResourcesMutex.RLock()
+	defer fake.matchResourcesMutex.RUnlock()
+	argsForCall := fake.matchResourcesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *ResourceCacheRepository) MatchResourcesReturns(result1 []repositories.ResourceRecord, result2 error) {
+	fake.matchResourcesMutex.Lock()
+	defer fake.matchResourcesMutex.Unlock()
+	fake.MatchResourcesStub = nil
+	fake.matchResourcesReturns = struct {
+		result1 []repositories.ResourceRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ResourceCacheRepository) MatchResourcesReturnsOnCall(i int, result1 []repositories.ResourceRecord, result2 error) {
+	fake.matchResourcesMutex.Lock()
+	defer fake.matchResourcesMutex.Unlock()
+	fake.MatchResourcesStub = nil
+	if fake.matchResourcesReturnsOnCall == nil {
+		fake.matchResourcesReturnsOnCall = make(map[int]struct {
+			result1 []repositories.ResourceRecord
+			result2 error
+		})
+	}
+	fake.matchResourcesReturnsOnCall[i] = struct {
+		result1 []repositories.ResourceRecord
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *ResourceCacheRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.assemblePackageMutex.RLock()
+	defer fake.assemblePackageMutex.RUnlock()
+	fake.cacheResourcesMutex.RLock()
+	defer fake.cacheResourcesMutex.RUnlock()
+	fake.matchResourcesMutex.RLock()
+	defer fake.matchResourcesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *ResourceCacheRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ handlers.ResourceCacheRepository = new(ResourceCacheRepository)
@@ -0,0 +1,174 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FeatureFlag", func() {
+	var (
+		apiHandler       *handlers.FeatureFlag
+		featureFlagRepo  *fake.CFFeatureFlagRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		featureFlagRepo = new(fake.CFFeatureFlagRepository)
+
+		apiHandler = handlers.NewFeatureFlag(
+			*serverURL,
+			featureFlagRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/feature_flags", func() {
+		BeforeEach(func() {
+			featureFlagRepo.ListFeatureFlagsReturns([]repositories.FeatureFlagRecord{
+				{Name: "diego_docker", Enabled: false},
+				{Name: "task_creation", Enabled: true},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/feature_flags", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the feature flags", func() {
+			Expect(featureFlagRepo.ListFeatureFlagsCallCount()).To(Equal(1))
+			_, actualAuthInfo := featureFlagRepo.ListFeatureFlagsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].name", "diego_docker"),
+				MatchJSONPath("$.resources[0].enabled", BeFalse()),
+				MatchJSONPath("$.resources[1].name", "task_creation"),
+				MatchJSONPath("$.resources[1].enabled", BeTrue()),
+			)))
+		})
+
+		When("listing the feature flags fails", func() {
+			BeforeEach(func() {
+				featureFlagRepo.ListFeatureFlagsReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/feature_flags/:name", func() {
+		BeforeEach(func() {
+			featureFlagRepo.GetFeatureFlagReturns(repositories.FeatureFlagRecord{
+				Name:    "task_creation",
+				Enabled: true,
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/feature_flags/task_creation", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the feature flag", func() {
+			Expect(featureFlagRepo.GetFeatureFlagCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualName := featureFlagRepo.GetFeatureFlagArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualName).To(Equal("task_creation"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "task_creation"),
+				MatchJSONPath("$.enabled", BeTrue()),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/feature_flags/task_creation"),
+			)))
+		})
+
+		When("the feature flag does not exist", func() {
+			BeforeEach(func() {
+				featureFlagRepo.GetFeatureFlagReturns(repositories.FeatureFlagRecord{}, apierrors.NewNotFoundError(nil, repositories.FeatureFlagResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.FeatureFlagResourceType)
+			})
+		})
+	})
+
+	Describe("PATCH /v3/feature_flags/:name", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.FeatureFlagUpdate{
+				Enabled:            tools.PtrTo(false),
+				CustomErrorMessage: tools.PtrTo("no tasks today"),
+			})
+
+			featureFlagRepo.UpdateFeatureFlagReturns(repositories.FeatureFlagRecord{
+				Name:               "task_creation",
+				Enabled:            false,
+				CustomErrorMessage: "no tasks today",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/feature_flags/task_creation", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates the feature flag", func() {
+			Expect(featureFlagRepo.UpdateFeatureFlagCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := featureFlagRepo.UpdateFeatureFlagArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UpdateFeatureFlagMessage{
+				Name:               "task_creation",
+				Enabled:            tools.PtrTo(false),
+				CustomErrorMessage: tools.PtrTo("no tasks today"),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.enabled", BeFalse()),
+				MatchJSONPath("$.custom_error_message", "no tasks today"),
+			)))
+		})
+
+		When("decoding the payload fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("the user is not allowed to update feature flags", func() {
+			BeforeEach(func() {
+				featureFlagRepo.UpdateFeatureFlagReturns(repositories.FeatureFlagRecord{}, apierrors.NewForbiddenError(nil, repositories.FeatureFlagResourceType))
+			})
+
+			It("returns a forbidden error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+	})
+})
@@ -0,0 +1,288 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	IsolationSegmentsPath                 = "/v3/isolation_segments"
+	IsolationSegmentPath                  = "/v3/isolation_segments/{guid}"
+	IsolationSegmentOrganizationsPath     = "/v3/isolation_segments/{guid}/relationships/organizations"
+	IsolationSegmentOrganizationPath      = "/v3/isolation_segments/{guid}/relationships/organizations/{org_guid}"
+	IsolationSegmentSpacesPath            = "/v3/isolation_segments/{guid}/relationships/spaces"
+	SpaceIsolationSegmentRelationshipPath = "/v3/spaces/{guid}/relationships/isolation_segment"
+)
+
+//counterfeiter:generate -o fake -fake-name CFIsolationSegmentRepository . CFIsolationSegmentRepository
+type CFIsolationSegmentRepository interface {
+	CreateIsolationSegment(context.Context, authorization.Info, repositories.CreateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)
+	GetIsolationSegment(context.Context, authorization.Info, string) (repositories.IsolationSegmentRecord, error)
+	ListIsolationSegments(context.Context, authorization.Info, repositories.ListIsolationSegmentsMessage) ([]repositories.IsolationSegmentRecord, error)
+	UpdateIsolationSegment(context.Context, authorization.Info, repositories.UpdateIsolationSegmentMessage) (repositories.IsolationSegmentRecord, error)
+	DeleteIsolationSegment(context.Context, authorization.Info, string) error
+	ListIsolationSegmentOrgs(context.Context, authorization.Info, string) ([]string, error)
+	ListIsolationSegmentSpaces(context.Context, authorization.Info, string) ([]string, error)
+	EntitleIsolationSegment(context.Context, authorization.Info, repositories.EntitleIsolationSegmentMessage) ([]string, error)
+	RevokeIsolationSegment(context.Context, authorization.Info, repositories.RevokeIsolationSegmentMessage) error
+	GetSpaceIsolationSegment(context.Context, authorization.Info, string) (string, error)
+	AssignSpaceIsolationSegment(context.Context, authorization.Info, repositories.AssignSpaceIsolationSegmentMessage) error
+}
+
+type IsolationSegment struct {
+	serverURL            url.URL
+	isolationSegmentRepo CFIsolationSegmentRepository
+	requestValidator     RequestValidator
+}
+
+func NewIsolationSegment(
+	serverURL url.URL,
+	isolationSegmentRepo CFIsolationSegmentRepository,
+	requestValidator RequestValidator,
+) *IsolationSegment {
+	return &IsolationSegment{
+		serverURL:            serverURL,
+		isolationSegmentRepo: isolationSegmentRepo,
+		requestValidator:     requestValidator,
+	}
+}
+
+func (h *IsolationSegment) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.create")
+
+	var payload payloads.IsolationSegmentCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	isolationSegment, err := h.isolationSegmentRepo.CreateIsolationSegment(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to create isolation segment")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForIsolationSegment(isolationSegment, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.get")
+
+	isolationSegmentGUID := routing.URLParam(r, "guid")
+
+	isolationSegment, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, isolationSegmentGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get isolation segment", "guid", isolationSegmentGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForIsolationSegment(isolationSegment, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) list(r *http.Request) (*routing.Response, error) { //nolint:dupl
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.list")
+
+	listFilter := new(payloads.IsolationSegmentList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, listFilter); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	isolationSegments, err := h.isolationSegmentRepo.ListIsolationSegments(r.Context(), authInfo, listFilter.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list isolation segments")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForIsolationSegment, isolationSegments, h.serverURL, *r.URL)), nil
+}
+
+func (h *IsolationSegment) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.update")
+
+	isolationSegmentGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.IsolationSegmentUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, isolationSegmentGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get isolation segment", "guid", isolationSegmentGUID)
+	}
+
+	isolationSegment, err := h.isolationSegmentRepo.UpdateIsolationSegment(r.Context(), authInfo, payload.ToMessage(isolationSegmentGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to update isolation segment", "guid", isolationSegmentGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForIsolationSegment(isolationSegment, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.delete")
+
+	isolationSegmentGUID := routing.URLParam(r, "guid")
+
+	err := h.isolationSegmentRepo.DeleteIsolationSegment(r.Context(), authInfo, isolationSegmentGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to delete isolation segment", "guid", isolationSegmentGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *IsolationSegment) listOrgs(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.list-orgs")
+
+	isolationSegmentGUID := routing.URLParam(r, "guid")
+
+	_, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, isolationSegmentGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get isolation segment", "guid", isolationSegmentGUID)
+	}
+
+	orgGUIDs, err := h.isolationSegmentRepo.ListIsolationSegmentOrgs(r.Context(), authInfo, isolationSegmentGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list isolation segment orgs", "guid", isolationSegmentGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForIsolationSegmentOrganizations(orgGUIDs, isolationSegmentGUID, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) listSpaces(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.list-spaces")
+
+	isolationSegmentGUID := routing.URLParam(r, "guid")
+
+	_, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, isolationSegmentGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get isolation segment", "guid", isolationSegmentGUID)
+	}
+
+	spaceGUIDs, err := h.isolationSegmentRepo.ListIsolationSegmentSpaces(r.Context(), authInfo, isolationSegmentGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list isolation segment spaces", "guid", isolationSegmentGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForIsolationSegmentSpaces(spaceGUIDs, isolationSegmentGUID, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) entitle(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.entitle")
+
+	isolationSegmentGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.IsolationSegmentEntitle
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, isolationSegmentGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get isolation segment", "guid", isolationSegmentGUID)
+	}
+
+	orgGUIDs, err := h.isolationSegmentRepo.EntitleIsolationSegment(r.Context(), authInfo, payload.ToMessage(isolationSegmentGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to entitle isolation segment", "guid", isolationSegmentGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForIsolationSegmentOrganizations(orgGUIDs, isolationSegmentGUID, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) revoke(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.revoke")
+
+	isolationSegmentGUID := routing.URLParam(r, "guid")
+	orgGUID := routing.URLParam(r, "org_guid")
+
+	_, err := h.isolationSegmentRepo.GetIsolationSegment(r.Context(), authInfo, isolationSegmentGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get isolation segment", "guid", isolationSegmentGUID)
+	}
+
+	err = h.isolationSegmentRepo.RevokeIsolationSegment(r.Context(), authInfo, repositories.RevokeIsolationSegmentMessage{
+		GUID:    isolationSegmentGUID,
+		OrgGUID: orgGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to revoke isolation segment", "guid", isolationSegmentGUID, "orgGUID", orgGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *IsolationSegment) getForSpace(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.get-for-space")
+
+	spaceGUID := routing.URLParam(r, "guid")
+
+	isolationSegmentGUID, err := h.isolationSegmentRepo.GetSpaceIsolationSegment(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space isolation segment", "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceIsolationSegment(isolationSegmentGUID, spaceGUID, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) assignToSpace(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.isolation-segment.assign-to-space")
+
+	spaceGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.SpaceIsolationSegmentUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.isolationSegmentRepo.GetSpaceIsolationSegment(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space isolation segment", "spaceGUID", spaceGUID)
+	}
+
+	message := payload.ToMessage(spaceGUID)
+	err = h.isolationSegmentRepo.AssignSpaceIsolationSegment(r.Context(), authInfo, message)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to assign space isolation segment", "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceIsolationSegment(message.IsolationSegmentGUID, spaceGUID, h.serverURL)), nil
+}
+
+func (h *IsolationSegment) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *IsolationSegment) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "POST", Pattern: IsolationSegmentsPath, Handler: h.create},
+		{Method: "GET", Pattern: IsolationSegmentsPath, Handler: h.list},
+		{Method: "GET", Pattern: IsolationSegmentPath, Handler: h.get},
+		{Method: "PATCH", Pattern: IsolationSegmentPath, Handler: h.update},
+		{Method: "DELETE", Pattern: IsolationSegmentPath, Handler: h.delete},
+		{Method: "GET", Pattern: IsolationSegmentOrganizationsPath, Handler: h.listOrgs},
+		{Method: "POST", Pattern: IsolationSegmentOrganizationsPath, Handler: h.entitle},
+		{Method: "DELETE", Pattern: IsolationSegmentOrganizationPath, Handler: h.revoke},
+		{Method: "GET", Pattern: IsolationSegmentSpacesPath, Handler: h.listSpaces},
+		{Method: "GET", Pattern: SpaceIsolationSegmentRelationshipPath, Handler: h.getForSpace},
+		{Method: "PATCH", Pattern: SpaceIsolationSegmentRelationshipPath, Handler: h.assignToSpace},
+	}
+}
@@ -0,0 +1,461 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("IsolationSegment", func() {
+	var (
+		apiHandler           *handlers.IsolationSegment
+		isolationSegmentRepo *fake.CFIsolationSegmentRepository
+		requestValidator     *fake.RequestValidator
+		req                  *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		isolationSegmentRepo = new(fake.CFIsolationSegmentRepository)
+		isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{
+			GUID: "segment-guid",
+			Name: "regulated",
+		}, nil)
+
+		apiHandler = handlers.NewIsolationSegment(
+			*serverURL,
+			isolationSegmentRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/isolation_segments", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.IsolationSegmentCreate{
+				Name: "regulated",
+			})
+
+			isolationSegmentRepo.CreateIsolationSegmentReturns(repositories.IsolationSegmentRecord{
+				GUID: "segment-guid",
+				Name: "regulated",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/isolation_segments", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates the isolation segment", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(isolationSegmentRepo.CreateIsolationSegmentCallCount()).To(Equal(1))
+			_, actualAuthInfo, createMessage := isolationSegmentRepo.CreateIsolationSegmentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(createMessage).To(Equal(repositories.CreateIsolationSegmentMessage{Name: "regulated"}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "segment-guid"),
+				MatchJSONPath("$.name", "regulated"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/isolation_segments/segment-guid"),
+			)))
+		})
+
+		When("decoding the payload fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("creating the isolation segment fails", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.CreateIsolationSegmentReturns(repositories.IsolationSegmentRecord{}, errors.New("create-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/isolation_segments/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/isolation_segments/segment-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the isolation segment", func() {
+			Expect(isolationSegmentRepo.GetIsolationSegmentCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := isolationSegmentRepo.GetIsolationSegmentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("segment-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "segment-guid"),
+				MatchJSONPath("$.name", "regulated"),
+			)))
+		})
+
+		When("the user is not allowed to see the isolation segment", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{}, apierrors.NewForbiddenError(nil, repositories.IsolationSegmentResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.IsolationSegmentResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/isolation_segments", func() {
+		BeforeEach(func() {
+			isolationSegmentRepo.ListIsolationSegmentsReturns([]repositories.IsolationSegmentRecord{
+				{GUID: "segment-1"},
+				{GUID: "segment-2"},
+			}, nil)
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.IsolationSegmentList{
+				Names:             "n1,n2",
+				OrganizationGUIDs: "org-guid",
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/isolation_segments?names=n1,n2&organization_guids=org-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the isolation segments", func() {
+			Expect(isolationSegmentRepo.ListIsolationSegmentsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := isolationSegmentRepo.ListIsolationSegmentsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Names).To(ConsistOf("n1", "n2"))
+			Expect(message.OrgGUIDs).To(ConsistOf("org-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "segment-1"),
+				MatchJSONPath("$.resources[1].guid", "segment-2"),
+			)))
+		})
+
+		When("listing the isolation segments fails", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.ListIsolationSegmentsReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("PATCH /v3/isolation_segments/:guid", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.IsolationSegmentUpdate{
+				Name: tools.PtrTo("new-name"),
+			})
+
+			isolationSegmentRepo.UpdateIsolationSegmentReturns(repositories.IsolationSegmentRecord{
+				GUID: "segment-guid",
+				Name: "new-name",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/isolation_segments/segment-guid", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates the isolation segment", func() {
+			Expect(isolationSegmentRepo.UpdateIsolationSegmentCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := isolationSegmentRepo.UpdateIsolationSegmentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.GUID).To(Equal("segment-guid"))
+			Expect(message.Name).To(PointTo(Equal("new-name")))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.name", "new-name")))
+		})
+
+		When("the isolation segment does not exist", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{}, apierrors.NewNotFoundError(nil, repositories.IsolationSegmentResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.IsolationSegmentResourceType)
+				Expect(isolationSegmentRepo.UpdateIsolationSegmentCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("DELETE /v3/isolation_segments/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/isolation_segments/segment-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the isolation segment", func() {
+			Expect(isolationSegmentRepo.DeleteIsolationSegmentCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := isolationSegmentRepo.DeleteIsolationSegmentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("segment-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("orgs are still entitled to the isolation segment", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.DeleteIsolationSegmentReturns(apierrors.NewUnprocessableEntityError(nil, "still entitled"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("still entitled")
+			})
+		})
+	})
+
+	Describe("GET /v3/isolation_segments/:guid/relationships/organizations", func() {
+		BeforeEach(func() {
+			isolationSegmentRepo.ListIsolationSegmentOrgsReturns([]string{"org-1", "org-2"}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/isolation_segments/segment-guid/relationships/organizations", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the entitled orgs", func() {
+			Expect(isolationSegmentRepo.ListIsolationSegmentOrgsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := isolationSegmentRepo.ListIsolationSegmentOrgsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("segment-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[0].guid", "org-1"),
+				MatchJSONPath("$.data[1].guid", "org-2"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/isolation_segments/segment-guid/relationships/organizations"),
+			)))
+		})
+
+		When("the isolation segment does not exist", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetIsolationSegmentReturns(repositories.IsolationSegmentRecord{}, apierrors.NewNotFoundError(nil, repositories.IsolationSegmentResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.IsolationSegmentResourceType)
+			})
+		})
+	})
+
+	Describe("POST /v3/isolation_segments/:guid/relationships/organizations", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.IsolationSegmentEntitle{
+				Data: []payloads.RelationshipData{{GUID: "org-1"}},
+			})
+
+			isolationSegmentRepo.EntitleIsolationSegmentReturns([]string{"org-1", "org-2"}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/isolation_segments/segment-guid/relationships/organizations", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("entitles the orgs to the isolation segment", func() {
+			Expect(isolationSegmentRepo.EntitleIsolationSegmentCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := isolationSegmentRepo.EntitleIsolationSegmentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.EntitleIsolationSegmentMessage{
+				GUID:     "segment-guid",
+				OrgGUIDs: []string{"org-1"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[0].guid", "org-1"),
+				MatchJSONPath("$.data[1].guid", "org-2"),
+			)))
+		})
+
+		When("entitling the orgs fails", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.EntitleIsolationSegmentReturns(nil, apierrors.NewUnprocessableEntityError(nil, "no such org"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("no such org")
+			})
+		})
+	})
+
+	Describe("DELETE /v3/isolation_segments/:guid/relationships/organizations/:org_guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/isolation_segments/segment-guid/relationships/organizations/org-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("revokes the entitlement of the org", func() {
+			Expect(isolationSegmentRepo.RevokeIsolationSegmentCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := isolationSegmentRepo.RevokeIsolationSegmentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.RevokeIsolationSegmentMessage{
+				GUID:    "segment-guid",
+				OrgGUID: "org-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("spaces of the org are assigned to the isolation segment", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.RevokeIsolationSegmentReturns(apierrors.NewUnprocessableEntityError(nil, "still assigned"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("still assigned")
+			})
+		})
+	})
+
+	Describe("GET /v3/isolation_segments/:guid/relationships/spaces", func() {
+		BeforeEach(func() {
+			isolationSegmentRepo.ListIsolationSegmentSpacesReturns([]string{"space-1"}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/isolation_segments/segment-guid/relationships/spaces", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the assigned spaces", func() {
+			Expect(isolationSegmentRepo.ListIsolationSegmentSpacesCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := isolationSegmentRepo.ListIsolationSegmentSpacesArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("segment-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[0].guid", "space-1"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/isolation_segments/segment-guid/relationships/spaces"),
+			)))
+		})
+	})
+
+	Describe("GET /v3/spaces/:guid/relationships/isolation_segment", func() {
+		BeforeEach(func() {
+			isolationSegmentRepo.GetSpaceIsolationSegmentReturns("segment-guid", nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/spaces/space-guid/relationships/isolation_segment", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the isolation segment of the space", func() {
+			Expect(isolationSegmentRepo.GetSpaceIsolationSegmentCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSpaceGUID := isolationSegmentRepo.GetSpaceIsolationSegmentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal("space-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data.guid", "segment-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/spaces/space-guid/relationships/isolation_segment"),
+			)))
+		})
+
+		When("the space is not assigned to an isolation segment", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetSpaceIsolationSegmentReturns("", nil)
+			})
+
+			It("returns null data", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.data", BeNil())))
+			})
+		})
+
+		When("the user cannot see the space", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetSpaceIsolationSegmentReturns("", apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SpaceResourceType)
+			})
+		})
+	})
+
+	Describe("PATCH /v3/spaces/:guid/relationships/isolation_segment", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SpaceIsolationSegmentUpdate{
+				Data: &payloads.RelationshipData{GUID: "segment-guid"},
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/spaces/space-guid/relationships/isolation_segment", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("assigns the space to the isolation segment", func() {
+			Expect(isolationSegmentRepo.AssignSpaceIsolationSegmentCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := isolationSegmentRepo.AssignSpaceIsolationSegmentArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.AssignSpaceIsolationSegmentMessage{
+				SpaceGUID:            "space-guid",
+				IsolationSegmentGUID: "segment-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.data.guid", "segment-guid")))
+		})
+
+		When("the org is not entitled to the isolation segment", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.AssignSpaceIsolationSegmentReturns(apierrors.NewUnprocessableEntityError(nil, "not entitled"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("not entitled")
+			})
+		})
+
+		When("the space does not exist", func() {
+			BeforeEach(func() {
+				isolationSegmentRepo.GetSpaceIsolationSegmentReturns("", apierrors.NewNotFoundError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SpaceResourceType)
+				Expect(isolationSegmentRepo.AssignSpaceIsolationSegmentCallCount()).To(BeZero())
+			})
+		})
+	})
+})
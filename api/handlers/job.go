@@ -11,6 +11,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
 	"code.cloudfoundry.org/korifi/tools/logger"
 )
@@ -25,6 +26,21 @@ const (
 	DomainDeleteJobType = "domain.delete"
 	RoleDeleteJobType   = "role.delete"
 
+	DropletUploadJobType = "droplet.upload"
+
+	ServiceBrokerCreateJobType = "service_broker.create"
+	ServiceBrokerUpdateJobType = "service_broker.update"
+	ServiceBrokerDeleteJobType = "service_broker.delete"
+
+	ServiceInstanceCreateJobType = "service_instance.create"
+	ServiceInstanceUpdateJobType = "service_instance.update"
+	ServiceInstanceDeleteJobType = "service_instance.delete"
+
+	SecurityGroupDeleteJobType = "security_group.delete"
+
+	OrgQuotaDeleteJobType   = "organization_quota.delete"
+	SpaceQuotaDeleteJobType = "space_quota.delete"
+
 	JobTimeoutDuration = 120.0
 )
 
@@ -35,17 +51,33 @@ type DeletionRepository interface {
 	GetDeletedAt(context.Context, authorization.Info, string) (*time.Time, error)
 }
 
+//counterfeiter:generate -o fake -fake-name StateRepository . StateRepository
+type StateRepository interface {
+	GetState(context.Context, authorization.Info, string) (repositories.ResourceState, error)
+}
+
+// StateRepositoryFunc adapts a function reporting the state of a resource to a
+// StateRepository, so that resources can report the state of several
+// operations
+type StateRepositoryFunc func(context.Context, authorization.Info, string) (repositories.ResourceState, error)
+
+func (f StateRepositoryFunc) GetState(ctx context.Context, authInfo authorization.Info, guid string) (repositories.ResourceState, error) {
+	return f(ctx, authInfo, guid)
+}
+
 type Job struct {
-	serverURL       url.URL
-	repositories    map[string]DeletionRepository
-	pollingInterval time.Duration
+	serverURL         url.URL
+	repositories      map[string]DeletionRepository
+	stateRepositories map[string]StateRepository
+	pollingInterval   time.Duration
 }
 
-func NewJob(serverURL url.URL, repositories map[string]DeletionRepository, pollingInterval time.Duration) *Job {
+func NewJob(serverURL url.URL, repositories map[string]DeletionRepository, stateRepositories map[string]StateRepository, pollingInterval time.Duration) *Job {
 	return &Job{
-		serverURL:       serverURL,
-		repositories:    repositories,
-		pollingInterval: pollingInterval,
+		serverURL:         serverURL,
+		repositories:      repositories,
+		stateRepositories: stateRepositories,
+		pollingInterval:   pollingInterval,
 	}
 }
 
@@ -67,6 +99,15 @@ func (h *Job) get(r *http.Request) (*routing.Response, error) {
 		return routing.NewResponse(http.StatusOK).WithBody(presenter.ForManifestApplyJob(job, h.serverURL)), nil
 	}
 
+	if stateRepository, ok := h.stateRepositories[job.Type]; ok {
+		jobResponse, err := h.handleStateJob(ctx, stateRepository, job)
+		if err != nil {
+			return nil, err
+		}
+
+		return routing.NewResponse(http.StatusOK).WithBody(jobResponse), nil
+	}
+
 	repository, ok := h.repositories[job.Type]
 	if !ok {
 		return nil, apierrors.LogAndReturn(
@@ -84,6 +125,38 @@ func (h *Job) get(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(jobResponse), nil
 }
 
+func (h *Job) handleStateJob(ctx context.Context, repository StateRepository, job presenter.Job) (presenter.JobResponse, error) {
+	ctx, log := logger.FromContext(ctx, "handleStateJob")
+	authInfo, _ := authorization.InfoFromContext(ctx)
+
+	state, err := repository.GetState(ctx, authInfo, job.ResourceGUID)
+	if err != nil {
+		return presenter.JobResponse{}, apierrors.LogAndReturn(
+			log,
+			apierrors.ForbiddenAsNotFound(err),
+			"failed to fetch "+job.ResourceType+" state from Kubernetes",
+			job.ResourceType+"GUID", job.ResourceGUID,
+		)
+	}
+
+	switch state.Status {
+	case repositories.ResourceStatusReady:
+		return presenter.ForJob(job, []presenter.JobResponseError{}, presenter.StateComplete, h.serverURL), nil
+	case repositories.ResourceStatusFailed:
+		return presenter.ForJob(job,
+			[]presenter.JobResponseError{{
+				Code:   10008,
+				Detail: state.Details,
+				Title:  "CF-UnprocessableEntity",
+			}},
+			presenter.StateFailed,
+			h.serverURL,
+		), nil
+	default:
+		return presenter.ForJob(job, []presenter.JobResponseError{}, presenter.StateProcessing, h.serverURL), nil
+	}
+}
+
 func (h *Job) handleDeleteJob(ctx context.Context, repository DeletionRepository, job presenter.Job) (presenter.JobResponse, error) {
 	ctx, log := logger.FromContext(ctx, "handleDeleteJob")
 
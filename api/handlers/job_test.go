@@ -8,6 +8,7 @@ import (
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
 
@@ -19,16 +20,18 @@ var _ = Describe("Job", func() {
 	var (
 		handler       *handlers.Job
 		deletionRepos map[string]handlers.DeletionRepository
+		stateRepos    map[string]handlers.StateRepository
 		jobGUID       string
 		req           *http.Request
 	)
 
 	BeforeEach(func() {
 		deletionRepos = map[string]handlers.DeletionRepository{}
+		stateRepos = map[string]handlers.StateRepository{}
 	})
 
 	JustBeforeEach(func() {
-		handler = handlers.NewJob(*serverURL, deletionRepos, 0)
+		handler = handlers.NewJob(*serverURL, deletionRepos, stateRepos, 0)
 		routerBuilder.LoadRoutes(handler)
 
 		var err error
@@ -165,4 +168,92 @@ var _ = Describe("Job", func() {
 			})
 		})
 	})
+
+	Describe("GET /v3/jobs/* for asynchronous operations", func() {
+		var stateRepo *fake.StateRepository
+
+		BeforeEach(func() {
+			stateRepo = new(fake.StateRepository)
+			stateRepo.GetStateReturns(repositories.ResourceState{Status: repositories.ResourceStatusProcessing}, nil)
+			stateRepos["testing.create"] = stateRepo
+
+			jobGUID = "testing.create~my-resource-guid"
+		})
+
+		It("returns a processing status", func() {
+			Expect(stateRepo.GetStateCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualResourceGUID := stateRepo.GetStateArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualResourceGUID).To(Equal("my-resource-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", jobGUID),
+				MatchJSONPath("$.operation", "testing.create"),
+				MatchJSONPath("$.state", "PROCESSING"),
+				MatchJSONPath("$.errors", BeEmpty()),
+			)))
+		})
+
+		When("the resource is ready", func() {
+			BeforeEach(func() {
+				stateRepo.GetStateReturns(repositories.ResourceState{Status: repositories.ResourceStatusReady}, nil)
+			})
+
+			It("returns a complete status", func() {
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.state", "COMPLETE"),
+					MatchJSONPath("$.errors", BeEmpty()),
+				)))
+			})
+		})
+
+		When("the operation has failed", func() {
+			BeforeEach(func() {
+				stateRepo.GetStateReturns(repositories.ResourceState{
+					Status:  repositories.ResourceStatusFailed,
+					Details: "something went wrong",
+				}, nil)
+			})
+
+			It("returns a failed status", func() {
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.state", "FAILED"),
+					MatchJSONPath("$.errors", ConsistOf(map[string]interface{}{
+						"code":   float64(10008),
+						"detail": "something went wrong",
+						"title":  "CF-UnprocessableEntity",
+					})),
+				)))
+			})
+		})
+
+		When("the resource cannot be found", func() {
+			BeforeEach(func() {
+				stateRepo.GetStateReturns(repositories.ResourceState{}, apierrors.NewForbiddenError(nil, "Testing"))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError("Testing")
+			})
+		})
+
+		When("the state is reported by a function", func() {
+			BeforeEach(func() {
+				stateRepos["testing.delete"] = handlers.StateRepositoryFunc(stateRepo.GetState)
+				jobGUID = "testing.delete~my-resource-guid"
+			})
+
+			It("reports the state returned by the function", func() {
+				Expect(stateRepo.GetStateCallCount()).To(Equal(1))
+				_, _, actualResourceGUID := stateRepo.GetStateArgsForCall(0)
+				Expect(actualResourceGUID).To(Equal("my-resource-guid"))
+
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.operation", "testing.delete"),
+					MatchJSONPath("$.state", "PROCESSING"),
+				)))
+			})
+		})
+	})
 })
@@ -1,26 +1,50 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/url"
 	"time"
 
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/routing"
 
+	"github.com/go-logr/logr"
 	"github.com/golang-jwt/jwt"
 )
 
 const (
-	OAuthTokenPath = "/oauth/token"
+	OAuthTokenPath     = "/oauth/token"
+	OAuthAuthorizePath = "/oauth/authorize"
 )
 
+//counterfeiter:generate -o fake -fake-name SSHCodeRepository . SSHCodeRepository
+
+type SSHCodeRepository interface {
+	CreateSSHCode(context.Context, authorization.Info) (string, error)
+}
+
 type OAuth struct {
-	apiBaseURL url.URL
+	apiBaseURL       url.URL
+	sshCodeRepo      SSHCodeRepository
+	sshOAuthClient   string
+	requestValidator RequestValidator
 }
 
-func NewOAuth(apiBaseURL url.URL) *OAuth {
+func NewOAuth(
+	apiBaseURL url.URL,
+	sshCodeRepo SSHCodeRepository,
+	sshOAuthClient string,
+	requestValidator RequestValidator,
+) *OAuth {
 	return &OAuth{
-		apiBaseURL: apiBaseURL,
+		apiBaseURL:       apiBaseURL,
+		sshCodeRepo:      sshCodeRepo,
+		sshOAuthClient:   sshOAuthClient,
+		requestValidator: requestValidator,
 	}
 }
 
@@ -41,6 +65,38 @@ func (h *OAuth) token(r *http.Request) (*routing.Response, error) {
 	}), nil
 }
 
+// authorize issues the one-time code that `cf ssh-code` prints and `cf ssh`
+// uses as the password for the SSH proxy
+func (h *OAuth) authorize(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.oauth.authorize")
+
+	payload := new(payloads.OAuthAuthorize)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	if payload.ClientID != h.sshOAuthClient {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(errors.New("unknown client"), "Unknown client_id "+payload.ClientID),
+			"unknown oauth client",
+			"clientID", payload.ClientID,
+		)
+	}
+
+	code, err := h.sshCodeRepo.CreateSSHCode(r.Context(), authInfo)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to create ssh code")
+	}
+
+	redirectURL := h.apiBaseURL
+	redirectURL.Path = "/login"
+	redirectURL.RawQuery = url.Values{"code": []string{code}}.Encode()
+
+	return routing.NewResponse(http.StatusFound).WithHeader("Location", redirectURL.String()), nil
+}
+
 func (h *OAuth) UnauthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "POST", Pattern: OAuthTokenPath, Handler: h.token},
@@ -48,5 +104,7 @@ func (h *OAuth) UnauthenticatedRoutes() []routing.Route {
 }
 
 func (h *OAuth) AuthenticatedRoutes() []routing.Route {
-	return nil
+	return []routing.Route{
+		{Method: "GET", Pattern: OAuthAuthorizePath, Handler: h.authorize},
+	}
 }
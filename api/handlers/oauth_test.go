@@ -2,10 +2,14 @@ package handlers_test
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
 
 	"github.com/SermoDigital/jose/jws"
 	. "github.com/onsi/ginkgo/v2"
@@ -13,21 +17,32 @@ import (
 )
 
 var _ = Describe("OAuth", func() {
-	var apiHandler *handlers.OAuth
+	var (
+		apiHandler       *handlers.OAuth
+		sshCodeRepo      *fake.SSHCodeRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
 
 	BeforeEach(func() {
-		apiHandler = handlers.NewOAuth(*serverURL)
+		sshCodeRepo = new(fake.SSHCodeRepository)
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler = handlers.NewOAuth(*serverURL, sshCodeRepo, "ssh-proxy", requestValidator)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
 	JustBeforeEach(func() {
-		req, err := http.NewRequest(http.MethodPost, "/oauth/token", nil)
-		Expect(err).NotTo(HaveOccurred())
-
 		routerBuilder.Build().ServeHTTP(rr, req)
 	})
 
 	Describe("POST /oauth/token", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequest(http.MethodPost, "/oauth/token", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
 		It("returns 201 with appropriate success JSON", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
@@ -43,4 +58,64 @@ var _ = Describe("OAuth", func() {
 			Expect(expiration.Unix()).To(BeNumerically(">", time.Now().Add(time.Minute*59).Unix()))
 		})
 	})
+
+	Describe("GET /oauth/authorize", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.OAuthAuthorize{
+				ResponseType: "code",
+				ClientID:     "ssh-proxy",
+			})
+			sshCodeRepo.CreateSSHCodeReturns("the-code", nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, http.MethodGet, "/oauth/authorize?response_type=code&client_id=ssh-proxy", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("redirects with a one-time code", func() {
+			Expect(requestValidator.DecodeAndValidateURLValuesCallCount()).To(Equal(1))
+
+			Expect(sshCodeRepo.CreateSSHCodeCallCount()).To(Equal(1))
+			_, actualAuthInfo := sshCodeRepo.CreateSSHCodeArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusFound))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/login?code=the-code"))
+		})
+
+		When("the query is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				Expect(sshCodeRepo.CreateSSHCodeCallCount()).To(Equal(0))
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("the client is unknown", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.OAuthAuthorize{
+					ResponseType: "code",
+					ClientID:     "cf",
+				})
+			})
+
+			It("returns an error", func() {
+				Expect(sshCodeRepo.CreateSSHCodeCallCount()).To(Equal(0))
+				expectUnprocessableEntityError("Unknown client_id cf")
+			})
+		})
+
+		When("creating the code fails", func() {
+			BeforeEach(func() {
+				sshCodeRepo.CreateSSHCodeReturns("", errors.New("create-code"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
@@ -40,16 +40,18 @@ type Org struct {
 	apiBaseURL                               url.URL
 	orgRepo                                  CFOrgRepository
 	domainRepo                               CFDomainRepository
+	featureFlagChecker                       FeatureFlagChecker
 	requestValidator                         RequestValidator
 	userCertificateExpirationWarningDuration time.Duration
 	defaultDomainName                        string
 }
 
-func NewOrg(apiBaseURL url.URL, orgRepo CFOrgRepository, domainRepo CFDomainRepository, requestValidator RequestValidator, userCertificateExpirationWarningDuration time.Duration, defaultDomainName string) *Org {
+func NewOrg(apiBaseURL url.URL, orgRepo CFOrgRepository, domainRepo CFDomainRepository, featureFlagChecker FeatureFlagChecker, requestValidator RequestValidator, userCertificateExpirationWarningDuration time.Duration, defaultDomainName string) *Org {
 	return &Org{
 		apiBaseURL:                               apiBaseURL,
 		orgRepo:                                  orgRepo,
 		domainRepo:                               domainRepo,
+		featureFlagChecker:                       featureFlagChecker,
 		requestValidator:                         requestValidator,
 		userCertificateExpirationWarningDuration: userCertificateExpirationWarningDuration,
 		defaultDomainName:                        defaultDomainName,
@@ -65,6 +67,10 @@ func (h *Org) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "invalid-payload-for-create-org")
 	}
 
+	if err := h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, repositories.FeatureFlagUserOrgCreation); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "org creation is disabled")
+	}
+
 	org := payload.ToMessage()
 	record, err := h.orgRepo.CreateOrg(r.Context(), authInfo, org)
 	if err != nil {
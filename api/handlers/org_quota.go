@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	OrgQuotasPath             = "/v3/organization_quotas"
+	OrgQuotaPath              = "/v3/organization_quotas/{guid}"
+	OrgQuotaOrganizationsPath = "/v3/organization_quotas/{guid}/relationships/organizations"
+	OrgUsageSummaryPath       = "/v3/organizations/{guid}/usage_summary"
+)
+
+//counterfeiter:generate -o fake -fake-name CFOrgQuotaRepository . CFOrgQuotaRepository
+type CFOrgQuotaRepository interface {
+	CreateOrgQuota(context.Context, authorization.Info, repositories.CreateOrgQuotaMessage) (repositories.OrgQuotaRecord, error)
+	GetOrgQuota(context.Context, authorization.Info, string) (repositories.OrgQuotaRecord, error)
+	ListOrgQuotas(context.Context, authorization.Info, repositories.ListOrgQuotasMessage) ([]repositories.OrgQuotaRecord, error)
+	UpdateOrgQuota(context.Context, authorization.Info, repositories.UpdateOrgQuotaMessage) (repositories.OrgQuotaRecord, error)
+	DeleteOrgQuota(context.Context, authorization.Info, string) error
+	ApplyOrgQuota(context.Context, authorization.Info, repositories.ApplyOrgQuotaMessage) (repositories.OrgQuotaRecord, error)
+	GetOrgUsageSummary(context.Context, authorization.Info, string) (repositories.UsageSummaryRecord, error)
+}
+
+type OrgQuota struct {
+	serverURL        url.URL
+	orgQuotaRepo     CFOrgQuotaRepository
+	requestValidator RequestValidator
+}
+
+func NewOrgQuota(
+	serverURL url.URL,
+	orgQuotaRepo CFOrgQuotaRepository,
+	requestValidator RequestValidator,
+) *OrgQuota {
+	return &OrgQuota{
+		serverURL:        serverURL,
+		orgQuotaRepo:     orgQuotaRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *OrgQuota) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org-quota.create")
+
+	var payload payloads.OrgQuotaCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	orgQuota, err := h.orgQuotaRepo.CreateOrgQuota(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to create organization quota")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForOrgQuota(orgQuota, h.serverURL)), nil
+}
+
+func (h *OrgQuota) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org-quota.get")
+
+	orgQuotaGUID := routing.URLParam(r, "guid")
+
+	orgQuota, err := h.orgQuotaRepo.GetOrgQuota(r.Context(), authInfo, orgQuotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get organization quota", "guid", orgQuotaGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForOrgQuota(orgQuota, h.serverURL)), nil
+}
+
+func (h *OrgQuota) list(r *http.Request) (*routing.Response, error) { //nolint:dupl
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org-quota.list")
+
+	listFilter := new(payloads.OrgQuotaList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, listFilter); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	orgQuotas, err := h.orgQuotaRepo.ListOrgQuotas(r.Context(), authInfo, listFilter.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list organization quotas")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForOrgQuota, orgQuotas, h.serverURL, *r.URL)), nil
+}
+
+func (h *OrgQuota) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org-quota.update")
+
+	orgQuotaGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.OrgQuotaUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.orgQuotaRepo.GetOrgQuota(r.Context(), authInfo, orgQuotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get organization quota", "guid", orgQuotaGUID)
+	}
+
+	orgQuota, err := h.orgQuotaRepo.UpdateOrgQuota(r.Context(), authInfo, payload.ToMessage(orgQuotaGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to update organization quota", "guid", orgQuotaGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForOrgQuota(orgQuota, h.serverURL)), nil
+}
+
+func (h *OrgQuota) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org-quota.delete")
+
+	orgQuotaGUID := routing.URLParam(r, "guid")
+
+	err := h.orgQuotaRepo.DeleteOrgQuota(r.Context(), authInfo, orgQuotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to delete organization quota", "guid", orgQuotaGUID)
+	}
+
+	return routing.NewResponse(http.StatusAccepted).WithHeader(
+		"Location",
+		presenter.JobURLForRedirects(orgQuotaGUID, presenter.OrgQuotaDeleteOperation, h.serverURL),
+	), nil
+}
+
+func (h *OrgQuota) apply(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org-quota.apply")
+
+	orgQuotaGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.OrgQuotaApply
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.orgQuotaRepo.GetOrgQuota(r.Context(), authInfo, orgQuotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get organization quota", "guid", orgQuotaGUID)
+	}
+
+	orgQuota, err := h.orgQuotaRepo.ApplyOrgQuota(r.Context(), authInfo, payload.ToMessage(orgQuotaGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to apply organization quota", "guid", orgQuotaGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForOrgQuotaOrganizations(orgQuota.OrgGUIDs, orgQuotaGUID, h.serverURL)), nil
+}
+
+func (h *OrgQuota) usageSummary(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.org-quota.usage-summary")
+
+	orgGUID := routing.URLParam(r, "guid")
+
+	usage, err := h.orgQuotaRepo.GetOrgUsageSummary(r.Context(), authInfo, orgGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get organization usage summary", "guid", orgGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForOrgUsageSummary(usage, orgGUID, h.serverURL)), nil
+}
+
+func (h *OrgQuota) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *OrgQuota) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "POST", Pattern: OrgQuotasPath, Handler: h.create},
+		{Method: "GET", Pattern: OrgQuotasPath, Handler: h.list},
+		{Method: "GET", Pattern: OrgQuotaPath, Handler: h.get},
+		{Method: "PATCH", Pattern: OrgQuotaPath, Handler: h.update},
+		{Method: "DELETE", Pattern: OrgQuotaPath, Handler: h.delete},
+		{Method: "POST", Pattern: OrgQuotaOrganizationsPath, Handler: h.apply},
+		{Method: "GET", Pattern: OrgUsageSummaryPath, Handler: h.usageSummary},
+	}
+}
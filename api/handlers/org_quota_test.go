@@ -0,0 +1,370 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("OrgQuota", func() {
+	var (
+		apiHandler       *handlers.OrgQuota
+		orgQuotaRepo     *fake.CFOrgQuotaRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		orgQuotaRepo = new(fake.CFOrgQuotaRepository)
+		orgQuotaRepo.GetOrgQuotaReturns(repositories.OrgQuotaRecord{
+			GUID: "quota-guid",
+			Name: "my-quota",
+		}, nil)
+
+		apiHandler = handlers.NewOrgQuota(
+			*serverURL,
+			orgQuotaRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/organization_quotas", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.OrgQuotaCreate{
+				Name: "my-quota",
+				QuotaLimits: payloads.QuotaLimits{
+					Apps: &payloads.QuotaApps{TotalMemoryInMB: tools.PtrTo[int64](1024)},
+				},
+				Relationships: &payloads.OrgQuotaRelationships{
+					Organizations: payloads.ToManyRelationship{Data: []payloads.RelationshipData{{GUID: "org-guid"}}},
+				},
+			})
+
+			orgQuotaRepo.CreateOrgQuotaReturns(repositories.OrgQuotaRecord{
+				GUID: "quota-guid",
+				Name: "my-quota",
+				Limits: repositories.QuotaLimits{
+					Apps: repositories.QuotaAppLimits{TotalMemoryInMB: tools.PtrTo[int64](1024)},
+				},
+				OrgGUIDs: []string{"org-guid"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/organization_quotas", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates the organization quota", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(orgQuotaRepo.CreateOrgQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, createMessage := orgQuotaRepo.CreateOrgQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(createMessage).To(Equal(repositories.CreateOrgQuotaMessage{
+				Name: "my-quota",
+				Limits: repositories.QuotaLimits{
+					Apps: repositories.QuotaAppLimits{TotalMemoryInMB: tools.PtrTo[int64](1024)},
+				},
+				OrgGUIDs: []string{"org-guid"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "quota-guid"),
+				MatchJSONPath("$.name", "my-quota"),
+				MatchJSONPath("$.apps.total_memory_in_mb", BeEquivalentTo(1024)),
+				MatchJSONPath("$.relationships.organizations.data[0].guid", "org-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/organization_quotas/quota-guid"),
+			)))
+		})
+
+		When("decoding the payload fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("creating the organization quota fails", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.CreateOrgQuotaReturns(repositories.OrgQuotaRecord{}, errors.New("create-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/organization_quotas/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/organization_quotas/quota-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the organization quota", func() {
+			Expect(orgQuotaRepo.GetOrgQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := orgQuotaRepo.GetOrgQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("quota-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "quota-guid"),
+				MatchJSONPath("$.name", "my-quota"),
+			)))
+		})
+
+		When("the user is not allowed to see the organization quota", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.GetOrgQuotaReturns(repositories.OrgQuotaRecord{}, apierrors.NewForbiddenError(nil, repositories.OrgQuotaResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.OrgQuotaResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/organization_quotas", func() {
+		BeforeEach(func() {
+			orgQuotaRepo.ListOrgQuotasReturns([]repositories.OrgQuotaRecord{
+				{GUID: "quota-1"},
+				{GUID: "quota-2"},
+			}, nil)
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.OrgQuotaList{
+				Names:             "n1,n2",
+				OrganizationGUIDs: "org-guid",
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/organization_quotas?names=n1,n2&organization_guids=org-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the organization quotas", func() {
+			Expect(orgQuotaRepo.ListOrgQuotasCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := orgQuotaRepo.ListOrgQuotasArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Names).To(ConsistOf("n1", "n2"))
+			Expect(message.OrgGUIDs).To(ConsistOf("org-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "quota-1"),
+				MatchJSONPath("$.resources[1].guid", "quota-2"),
+			)))
+		})
+
+		When("listing the organization quotas fails", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.ListOrgQuotasReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("PATCH /v3/organization_quotas/:guid", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.OrgQuotaUpdate{
+				Name: tools.PtrTo("new-name"),
+				QuotaLimits: payloads.QuotaLimits{
+					Routes: &payloads.QuotaRoutes{TotalRoutes: tools.PtrTo[int64](3)},
+				},
+			})
+
+			orgQuotaRepo.UpdateOrgQuotaReturns(repositories.OrgQuotaRecord{
+				GUID: "quota-guid",
+				Name: "new-name",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/organization_quotas/quota-guid", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates the organization quota", func() {
+			Expect(orgQuotaRepo.UpdateOrgQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := orgQuotaRepo.UpdateOrgQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.GUID).To(Equal("quota-guid"))
+			Expect(message.Name).To(PointTo(Equal("new-name")))
+			Expect(message.Limits.Apps).To(BeNil())
+			Expect(message.Limits.Routes).To(PointTo(Equal(repositories.QuotaRouteLimits{TotalRoutes: tools.PtrTo[int64](3)})))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.name", "new-name")))
+		})
+
+		When("the organization quota does not exist", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.GetOrgQuotaReturns(repositories.OrgQuotaRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgQuotaResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.OrgQuotaResourceType)
+				Expect(orgQuotaRepo.UpdateOrgQuotaCallCount()).To(BeZero())
+			})
+		})
+
+		When("updating the organization quota fails", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.UpdateOrgQuotaReturns(repositories.OrgQuotaRecord{}, errors.New("update-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/organization_quotas/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/organization_quotas/quota-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the organization quota", func() {
+			Expect(orgQuotaRepo.DeleteOrgQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := orgQuotaRepo.DeleteOrgQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("quota-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/organization_quota.delete~quota-guid"))
+		})
+
+		When("deleting the organization quota fails", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.DeleteOrgQuotaReturns(errors.New("delete-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /v3/organization_quotas/:guid/relationships/organizations", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.OrgQuotaApply{
+				Data: []payloads.RelationshipData{{GUID: "org-1"}},
+			})
+			orgQuotaRepo.ApplyOrgQuotaReturns(repositories.OrgQuotaRecord{
+				GUID:     "quota-guid",
+				OrgGUIDs: []string{"org-1", "org-2"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/organization_quotas/quota-guid/relationships/organizations", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("applies the organization quota to the organizations", func() {
+			Expect(orgQuotaRepo.ApplyOrgQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := orgQuotaRepo.ApplyOrgQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ApplyOrgQuotaMessage{
+				GUID:     "quota-guid",
+				OrgGUIDs: []string{"org-1"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[*].guid", ConsistOf("org-1", "org-2")),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/organization_quotas/quota-guid/relationships/organizations"),
+			)))
+		})
+
+		When("the organization quota does not exist", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.GetOrgQuotaReturns(repositories.OrgQuotaRecord{}, apierrors.NewNotFoundError(nil, repositories.OrgQuotaResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.OrgQuotaResourceType)
+				Expect(orgQuotaRepo.ApplyOrgQuotaCallCount()).To(BeZero())
+			})
+		})
+
+		When("applying the organization quota fails", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.ApplyOrgQuotaReturns(repositories.OrgQuotaRecord{}, apierrors.NewUnprocessableEntityError(nil, "no such org"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("no such org")
+			})
+		})
+	})
+
+	Describe("GET /v3/organizations/:guid/usage_summary", func() {
+		BeforeEach(func() {
+			orgQuotaRepo.GetOrgUsageSummaryReturns(repositories.UsageSummaryRecord{
+				StartedInstances: 2,
+				MemoryInMB:       512,
+				Apps:             1,
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/organizations/org-guid/usage_summary", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the usage summary of the organization", func() {
+			Expect(orgQuotaRepo.GetOrgUsageSummaryCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualOrgGUID := orgQuotaRepo.GetOrgUsageSummaryArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualOrgGUID).To(Equal("org-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.usage_summary.started_instances", BeEquivalentTo(2)),
+				MatchJSONPath("$.usage_summary.memory_in_mb", BeEquivalentTo(512)),
+				MatchJSONPath("$.links.organization.href", "https://api.example.org/v3/organizations/org-guid"),
+			)))
+		})
+
+		When("the organization is not visible to the user", func() {
+			BeforeEach(func() {
+				orgQuotaRepo.GetOrgUsageSummaryReturns(repositories.UsageSummaryRecord{}, apierrors.NewForbiddenError(nil, repositories.OrgResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.OrgResourceType)
+			})
+		})
+	})
+})
@@ -21,11 +21,12 @@ import (
 
 var _ = Describe("Org", func() {
 	var (
-		apiHandler       *handlers.Org
-		orgRepo          *fake.CFOrgRepository
-		now              time.Time
-		domainRepo       *fake.CFDomainRepository
-		requestValidator *fake.RequestValidator
+		apiHandler         *handlers.Org
+		orgRepo            *fake.CFOrgRepository
+		now                time.Time
+		domainRepo         *fake.CFDomainRepository
+		featureFlagChecker *fake.FeatureFlagChecker
+		requestValidator   *fake.RequestValidator
 	)
 
 	BeforeEach(func() {
@@ -33,9 +34,10 @@ var _ = Describe("Org", func() {
 
 		orgRepo = new(fake.CFOrgRepository)
 		domainRepo = new(fake.CFDomainRepository)
+		featureFlagChecker = new(fake.FeatureFlagChecker)
 		requestValidator = new(fake.RequestValidator)
 
-		apiHandler = handlers.NewOrg(*serverURL, orgRepo, domainRepo, requestValidator, time.Hour, "the-default.domain")
+		apiHandler = handlers.NewOrg(*serverURL, orgRepo, domainRepo, featureFlagChecker, requestValidator, time.Hour, "the-default.domain")
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -96,6 +98,22 @@ var _ = Describe("Org", func() {
 			)))
 		})
 
+		When("the user_org_creation feature flag is disabled", func() {
+			BeforeEach(func() {
+				featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError(nil, repositories.FeatureFlagUserOrgCreation, ""))
+			})
+
+			It("returns a feature disabled error", func() {
+				Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualFlag := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualFlag).To(Equal(repositories.FeatureFlagUserOrgCreation))
+
+				expectErrorResponse(http.StatusForbidden, "CF-FeatureDisabled", "Feature Disabled: user_org_creation", 330002)
+				Expect(orgRepo.CreateOrgCallCount()).To(BeZero())
+			})
+		})
+
 		When("the org repo returns an error", func() {
 			BeforeEach(func() {
 				orgRepo.CreateOrgReturns(repositories.OrgRecord{}, errors.New("boom"))
@@ -1,11 +1,15 @@
 package handlers
 
 import (
+	"archive/tar"
+	"archive/zip"
 	"context"
+	"errors"
 	"io"
 	"net/http"
 	"net/url"
 	"sort"
+	"strings"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
@@ -13,6 +17,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
 	"github.com/go-logr/logr"
 )
@@ -22,6 +27,7 @@ const (
 	PackagesPath        = "/v3/packages"
 	PackageUploadPath   = "/v3/packages/{guid}/upload"
 	PackageDropletsPath = "/v3/packages/{guid}/droplets"
+	PackageDownloadPath = "/v3/packages/{guid}/download"
 )
 
 //counterfeiter:generate -o fake -fake-name CFPackageRepository . CFPackageRepository
@@ -34,10 +40,16 @@ type CFPackageRepository interface {
 	CreatePackage(context.Context, authorization.Info, repositories.CreatePackageMessage) (repositories.PackageRecord, error)
 	UpdatePackageSource(context.Context, authorization.Info, repositories.UpdatePackageSourceMessage) (repositories.PackageRecord, error)
 	UpdatePackage(context.Context, authorization.Info, repositories.UpdatePackageMessage) (repositories.PackageRecord, error)
+	CopyPackage(context.Context, authorization.Info, repositories.CopyPackageMessage) (repositories.PackageRecord, error)
+	DeletePackage(context.Context, authorization.Info, repositories.DeletePackageMessage) error
 }
 
 type ImageRepository interface {
 	UploadSourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, srcReader io.Reader, spaceGUID string, tags ...string) (imageRefWithDigest string, err error)
+	UploadDropletImage(ctx context.Context, authInfo authorization.Info, imageRef string, srcReader io.Reader, spaceGUID string, tags ...string) (imageRefWithDigest string, err error)
+	CopyDropletImage(ctx context.Context, authInfo authorization.Info, srcImageRef string, imageRef string, spaceGUID string, tags ...string) (imageRefWithDigest string, err error)
+	CopyPackageImage(ctx context.Context, authInfo authorization.Info, srcImageRef string, imageRef string, spaceGUID string, tags ...string) (imageRefWithDigest string, err error)
+	DownloadImage(ctx context.Context, imageRef string) (io.ReadCloser, error)
 }
 
 type Package struct {
@@ -46,6 +58,8 @@ type Package struct {
 	appRepo             CFAppRepository
 	dropletRepo         CFDropletRepository
 	imageRepo           ImageRepository
+	resourceCacheRepo   ResourceCacheRepository
+	featureFlagChecker  FeatureFlagChecker
 	requestValidator    RequestValidator
 	registrySecretNames []string
 }
@@ -56,6 +70,8 @@ func NewPackage(
 	appRepo CFAppRepository,
 	dropletRepo CFDropletRepository,
 	imageRepo ImageRepository,
+	resourceCacheRepo ResourceCacheRepository,
+	featureFlagChecker FeatureFlagChecker,
 	requestValidator RequestValidator,
 	registrySecretNames []string,
 ) *Package {
@@ -65,6 +81,8 @@ func NewPackage(
 		appRepo:             appRepo,
 		dropletRepo:         dropletRepo,
 		imageRepo:           imageRepo,
+		resourceCacheRepo:   resourceCacheRepo,
+		featureFlagChecker:  featureFlagChecker,
 		registrySecretNames: registrySecretNames,
 		requestValidator:    requestValidator,
 	}
@@ -122,6 +140,15 @@ func (h Package) create(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.create")
 
+	packageCopy := new(payloads.PackageCopy)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, packageCopy); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	if packageCopy.SourceGUID != "" {
+		return h.copy(r, logger, authInfo, packageCopy.SourceGUID)
+	}
+
 	var payload payloads.PackageCreate
 	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
@@ -142,6 +169,10 @@ func (h Package) create(r *http.Request) (*routing.Response, error) {
 		)
 	}
 
+	if err = h.checkPackageType(r.Context(), authInfo, payload.Type, appRecord); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "invalid package type", "type", payload.Type, "App GUID", appRecord.GUID)
+	}
+
 	record, err := h.packageRepo.CreatePackage(r.Context(), authInfo, payload.ToMessage(appRecord))
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Error creating package with repository")
@@ -150,6 +181,114 @@ func (h Package) create(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForPackage(record, h.serverURL)), nil
 }
 
+// copy creates a package for an app from a ready package. The image of a bits
+// package is copied into the package repository of the app, so that the copy
+// outlives the source package.
+func (h Package) copy(r *http.Request, logger logr.Logger, authInfo authorization.Info, sourceGUID string) (*routing.Response, error) {
+	var payload payloads.PackageCopyCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	appRecord, err := h.appRepo.GetApp(r.Context(), authInfo, payload.Relationships.App.Data.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(
+				err,
+				"App is invalid. Ensure it exists and you have access to it.",
+				apierrors.NotFoundError{},
+				apierrors.ForbiddenError{},
+			),
+			"Error finding App",
+			"App GUID", payload.Relationships.App.Data.GUID,
+		)
+	}
+
+	source, err := h.packageRepo.GetPackage(r.Context(), authInfo, sourceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(
+				err,
+				"Source package is invalid. Ensure it exists and you have access to it.",
+				apierrors.NotFoundError{},
+				apierrors.ForbiddenError{},
+			),
+			"Error finding source package",
+			"guid", sourceGUID,
+		)
+	}
+
+	if source.State != repositories.PackageStateReady {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(nil, "Source package is not ready."), "cannot copy package", "guid", sourceGUID)
+	}
+
+	if err = h.checkPackageType(r.Context(), authInfo, source.Type, appRecord); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "invalid package type", "type", source.Type, "App GUID", appRecord.GUID)
+	}
+
+	record, err := h.packageRepo.CopyPackage(r.Context(), authInfo, payload.ToMessage(sourceGUID, appRecord))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error copying package with repository")
+	}
+
+	if record.Type == string(korifiv1alpha1.BitsPackage) {
+		copiedImageRef, copyErr := h.imageRepo.CopyPackageImage(r.Context(), authInfo, source.SourceImage, record.ImageRef, record.SpaceGUID, record.GUID)
+		if copyErr != nil {
+			h.deletePackageCopy(r.Context(), logger, authInfo, record)
+			return nil, apierrors.LogAndReturn(logger, copyErr, "Error copying package image")
+		}
+
+		updatedRecord, updateErr := h.packageRepo.UpdatePackageSource(r.Context(), authInfo, repositories.UpdatePackageSourceMessage{
+			GUID:                record.GUID,
+			SpaceGUID:           record.SpaceGUID,
+			ImageRef:            copiedImageRef,
+			RegistrySecretNames: h.registrySecretNames,
+		})
+		if updateErr != nil {
+			h.deletePackageCopy(r.Context(), logger, authInfo, record)
+			return nil, apierrors.LogAndReturn(logger, updateErr, "Error calling UpdatePackageSource")
+		}
+		record = updatedRecord
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForPackage(record, h.serverURL)), nil
+}
+
+// deletePackageCopy deletes a package copy that never got its image, as it
+// would otherwise stay around waiting for an upload
+func (h Package) deletePackageCopy(ctx context.Context, logger logr.Logger, authInfo authorization.Info, record repositories.PackageRecord) {
+	err := h.packageRepo.DeletePackage(ctx, authInfo, repositories.DeletePackageMessage{
+		GUID:      record.GUID,
+		SpaceGUID: record.SpaceGUID,
+	})
+	if err != nil {
+		logger.Info("failed to delete package copy", "guid", record.GUID, "reason", err)
+	}
+}
+
+// checkPackageType ensures that docker packages are only created for apps
+// using the docker lifecycle, and bits packages for all the others
+func (h Package) checkPackageType(ctx context.Context, authInfo authorization.Info, packageType string, appRecord repositories.AppRecord) error {
+	if packageType != string(korifiv1alpha1.DockerPackage) {
+		if appRecord.Lifecycle.Type == string(korifiv1alpha1.DockerLifecycle) {
+			return apierrors.NewUnprocessableEntityError(nil, "Cannot create bits package for a Docker app.")
+		}
+		return nil
+	}
+
+	if err := h.featureFlagChecker.CheckFeatureFlag(ctx, authInfo, repositories.FeatureFlagDiegoDocker); err != nil {
+		return err
+	}
+
+	if appRecord.Lifecycle.Type != string(korifiv1alpha1.DockerLifecycle) {
+		return apierrors.NewUnprocessableEntityError(nil, "Cannot create Docker package for a buildpack app.")
+	}
+
+	return nil
+}
+
 func (h Package) update(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.update")
@@ -173,16 +312,33 @@ func (h Package) upload(r *http.Request) (*routing.Response, error) {
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.upload")
 
 	packageGUID := routing.URLParam(r, "guid")
+
+	if err := h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, repositories.FeatureFlagAppBitsUpload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "app bits upload is disabled")
+	}
+
 	err := r.ParseForm()
 	if err != nil { // untested - couldn't find a way to trigger this branch
 		return nil, apierrors.LogAndReturn(logger, apierrors.NewInvalidRequestError(err, "Unable to parse body as multipart form"), "Error parsing multipart form")
 	}
 
-	bitsFile, _, err := r.FormFile("bits")
-	if err != nil {
-		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(err, "Upload must include bits"), "Error reading form file \"bits\"")
+	bitsFile, bitsHeader, err := r.FormFile("bits")
+	if err != nil && !errors.Is(err, http.ErrMissingFile) {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(err, "Upload must include either resources or bits"), "Error reading form file \"bits\"")
+	}
+	if bitsFile != nil {
+		defer bitsFile.Close()
+	}
+
+	packageUpload := new(payloads.PackageUpload)
+	if err = h.requestValidator.DecodeAndValidateURLValues(r, packageUpload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request form values")
+	}
+	resources := packageUpload.ToMessage()
+
+	if bitsFile == nil && len(resources) == 0 {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(nil, "Upload must include either resources or bits"), "Error reading form file \"bits\"")
 	}
-	defer bitsFile.Close()
 
 	record, err := h.packageRepo.GetPackage(r.Context(), authInfo, packageGUID)
 	if err != nil {
@@ -193,7 +349,24 @@ func (h Package) upload(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.NewPackageBitsAlreadyUploadedError(err), "Error, cannot call package upload state was not AWAITING_UPLOAD", "packageGUID", packageGUID)
 	}
 
-	uploadedImageRef, err := h.imageRepo.UploadSourceImage(r.Context(), authInfo, record.ImageRef, bitsFile, record.SpaceGUID, packageGUID)
+	var bitsSize int64
+	if bitsHeader != nil {
+		bitsSize = bitsHeader.Size
+	}
+
+	var srcReader io.Reader = bitsFile
+	if len(resources) > 0 {
+		// files matched by the resource cache are left out of the bits, so
+		// put them back before pushing the package image
+		packageReader, assembleErr := h.resourceCacheRepo.AssemblePackage(r.Context(), bitsFile, bitsSize, resources)
+		if assembleErr != nil {
+			return nil, apierrors.LogAndReturn(logger, assembleErr, "Error assembling package from cached resources")
+		}
+		defer packageReader.Close()
+		srcReader = packageReader
+	}
+
+	uploadedImageRef, err := h.imageRepo.UploadSourceImage(r.Context(), authInfo, record.ImageRef, srcReader, record.SpaceGUID, packageGUID)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "Error calling uploadSourceImage")
 	}
@@ -208,9 +381,110 @@ func (h Package) upload(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Error calling UpdatePackageSource")
 	}
 
+	if bitsFile != nil {
+		if err = h.resourceCacheRepo.CacheResources(r.Context(), bitsFile, bitsSize); err != nil {
+			logger.Info("failed to cache package resources", "packageGUID", packageGUID, "reason", err)
+		}
+	}
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForPackage(record, h.serverURL)), nil
 }
 
+func (h Package) download(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.download")
+
+	packageGUID := routing.URLParam(r, "guid")
+	record, err := h.packageRepo.GetPackage(r.Context(), authInfo, packageGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error fetching package with repository")
+	}
+
+	if record.Type != string(korifiv1alpha1.BitsPackage) {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(nil, "Cannot download packages with type 'docker'."), "cannot download package", "guid", packageGUID)
+	}
+
+	if record.State != repositories.PackageStateReady {
+		return nil, apierrors.LogAndReturn(logger, apierrors.NewUnprocessableEntityError(nil, "Package has no bits to download."), "cannot download package", "guid", packageGUID)
+	}
+
+	imageReader, err := h.imageRepo.DownloadImage(r.Context(), record.SourceImage)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error downloading package image")
+	}
+
+	return routing.NewResponse(http.StatusOK).
+		WithHeader("Content-Type", "application/zip").
+		WithStream(zipStream(imageReader)), nil
+}
+
+// zipStream converts a tarball into a zip archive while it is being read
+func zipStream(reader io.ReadCloser) io.ReadCloser {
+	pipeReader, pipeWriter := io.Pipe()
+
+	go func() {
+		defer reader.Close()
+
+		zipWriter := zip.NewWriter(pipeWriter)
+		err := tarToZip(tar.NewReader(reader), zipWriter)
+		if closeErr := zipWriter.Close(); err == nil {
+			err = closeErr
+		}
+		pipeWriter.CloseWithError(err)
+	}()
+
+	return pipeReader
+}
+
+func tarToZip(tarReader *tar.Reader, zipWriter *zip.Writer) error {
+	for {
+		tarHeader, err := tarReader.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := strings.TrimPrefix(tarHeader.Name, "/")
+		if name == "" || name == "." || name == "./" {
+			continue
+		}
+
+		zipHeader, err := zip.FileInfoHeader(tarHeader.FileInfo())
+		if err != nil {
+			return err
+		}
+		zipHeader.Name = name
+		zipHeader.Method = zip.Deflate
+
+		switch tarHeader.Typeflag {
+		case tar.TypeDir:
+			zipHeader.Name = strings.TrimSuffix(name, "/") + "/"
+			zipHeader.Method = zip.Store
+			if _, err = zipWriter.CreateHeader(zipHeader); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			writer, err := zipWriter.CreateHeader(zipHeader)
+			if err != nil {
+				return err
+			}
+			if _, err = io.WriteString(writer, tarHeader.Linkname); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			writer, err := zipWriter.CreateHeader(zipHeader)
+			if err != nil {
+				return err
+			}
+			if _, err = io.Copy(writer, tarReader); err != nil {
+				return err
+			}
+		}
+	}
+}
+
 func (h Package) listDroplets(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.package.list-droplets")
@@ -247,5 +521,6 @@ func (h *Package) AuthenticatedRoutes() []routing.Route {
 		{Method: "POST", Pattern: PackagesPath, Handler: h.create},
 		{Method: "POST", Pattern: PackageUploadPath, Handler: h.upload},
 		{Method: "GET", Pattern: PackageDropletsPath, Handler: h.listDroplets},
+		{Method: "GET", Pattern: PackageDownloadPath, Handler: h.download},
 	}
 }
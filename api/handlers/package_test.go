@@ -1,6 +1,8 @@
 package handlers_test
 
 import (
+	"archive/tar"
+	"archive/zip"
 	"bytes"
 	"errors"
 	"fmt"
@@ -8,6 +10,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strings"
 	"time"
 
@@ -31,6 +34,8 @@ var _ = Describe("Package", func() {
 		appRepo                     *fake.CFAppRepository
 		dropletRepo                 *fake.CFDropletRepository
 		imageRepo                   *fake.ImageRepository
+		resourceCacheRepo           *fake.ResourceCacheRepository
+		featureFlagChecker          *fake.FeatureFlagChecker
 		requestValidator            *fake.RequestValidator
 		packageImagePullSecretNames []string
 
@@ -46,6 +51,8 @@ var _ = Describe("Package", func() {
 		appRepo = new(fake.CFAppRepository)
 		dropletRepo = new(fake.CFDropletRepository)
 		imageRepo = new(fake.ImageRepository)
+		resourceCacheRepo = new(fake.ResourceCacheRepository)
+		featureFlagChecker = new(fake.FeatureFlagChecker)
 		requestValidator = new(fake.RequestValidator)
 		packageImagePullSecretNames = []string{"package-image-pull-secret"}
 
@@ -61,6 +68,8 @@ var _ = Describe("Package", func() {
 			appRepo,
 			dropletRepo,
 			imageRepo,
+			resourceCacheRepo,
+			featureFlagChecker,
 			requestValidator,
 			packageImagePullSecretNames,
 		)
@@ -439,6 +448,108 @@ var _ = Describe("Package", func() {
 				expectUnknownError()
 			})
 		})
+
+		When("the app uses the docker lifecycle", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{
+					SpaceGUID: spaceGUID,
+					GUID:      appGUID,
+					Lifecycle: repositories.Lifecycle{Type: "docker"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Cannot create bits package for a Docker app.")
+			})
+
+			itDoesntCreateAPackage()
+		})
+
+		When("the package type is docker", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.PackageCreate{
+					Type: "docker",
+					Data: &payloads.PackageData{
+						Image:    "registry.example.org/my/image:latest",
+						Username: tools.PtrTo("user"),
+						Password: tools.PtrTo("pass"),
+					},
+					Relationships: &payloads.PackageRelationships{
+						App: &payloads.Relationship{
+							Data: &payloads.RelationshipData{
+								GUID: appGUID,
+							},
+						},
+					},
+				})
+
+				appRepo.GetAppReturns(repositories.AppRecord{
+					SpaceGUID: spaceGUID,
+					GUID:      appGUID,
+					Lifecycle: repositories.Lifecycle{Type: "docker"},
+				}, nil)
+
+				packageRepo.CreatePackageReturns(repositories.PackageRecord{
+					Type:        "docker",
+					AppGUID:     appGUID,
+					SpaceGUID:   spaceGUID,
+					GUID:        packageGUID,
+					State:       "READY",
+					DockerImage: "registry.example.org/my/image:latest",
+				}, nil)
+			})
+
+			It("creates a docker package", func() {
+				Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualFlag := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualFlag).To(Equal(repositories.FeatureFlagDiegoDocker))
+
+				Expect(packageRepo.CreatePackageCallCount()).To(Equal(1))
+				_, _, actualCreate := packageRepo.CreatePackageArgsForCall(0)
+				Expect(actualCreate.Type).To(Equal("docker"))
+				Expect(actualCreate.Data).To(Equal(&repositories.PackageData{
+					Image:    "registry.example.org/my/image:latest",
+					Username: tools.PtrTo("user"),
+					Password: tools.PtrTo("pass"),
+				}))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.type", "docker"),
+					MatchJSONPath("$.state", "READY"),
+					MatchJSONPath("$.data.image", "registry.example.org/my/image:latest"),
+				)))
+			})
+
+			When("the diego_docker feature flag is disabled", func() {
+				BeforeEach(func() {
+					featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError(nil, repositories.FeatureFlagDiegoDocker, ""))
+				})
+
+				It("returns a feature disabled error", func() {
+					expectErrorResponse(http.StatusForbidden, "CF-FeatureDisabled", "Feature Disabled: diego_docker", 330002)
+				})
+
+				itDoesntCreateAPackage()
+			})
+
+			When("the app uses the buildpack lifecycle", func() {
+				BeforeEach(func() {
+					appRepo.GetAppReturns(repositories.AppRecord{
+						SpaceGUID: spaceGUID,
+						GUID:      appGUID,
+						Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+					}, nil)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Cannot create Docker package for a buildpack app.")
+				})
+
+				itDoesntCreateAPackage()
+			})
+		})
 	})
 
 	Describe("the PATCH /v3/packages/:guid endpoint", func() {
@@ -630,6 +741,23 @@ var _ = Describe("Package", func() {
 			})
 		}
 
+		When("the app_bits_upload feature flag is disabled", func() {
+			BeforeEach(func() {
+				featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError(nil, repositories.FeatureFlagAppBitsUpload, ""))
+			})
+
+			It("returns a feature disabled error", func() {
+				Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualFlag := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualFlag).To(Equal(repositories.FeatureFlagAppBitsUpload))
+
+				expectErrorResponse(http.StatusForbidden, "CF-FeatureDisabled", "Feature Disabled: app_bits_upload", 330002)
+			})
+			itDoesntUploadSourceImage()
+			itDoesntUpdateAnyPackages()
+		})
+
 		When("getting the package is forbidden", func() {
 			BeforeEach(func() {
 				packageRepo.GetPackageReturns(repositories.PackageRecord{}, apierrors.NewForbiddenError(errors.New("Forbidden"), repositories.PackageResourceType))
@@ -674,12 +802,111 @@ var _ = Describe("Package", func() {
 			})
 
 			It("returns an error", func() {
-				expectUnprocessableEntityError("Upload must include bits")
+				expectUnprocessableEntityError("Upload must include either resources or bits")
 			})
 			itDoesntUploadSourceImage()
 			itDoesntUpdateAnyPackages()
 		})
 
+		It("caches the resources of the bits", func() {
+			Expect(resourceCacheRepo.CacheResourcesCallCount()).To(Equal(1))
+			_, actualBits, actualSize := resourceCacheRepo.CacheResourcesArgsForCall(0)
+			Expect(actualSize).To(BeEquivalentTo(len("the-src-file-contents")))
+			actualContents := make([]byte, actualSize)
+			_, err := actualBits.ReadAt(actualContents, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(actualContents)).To(Equal("the-src-file-contents"))
+		})
+
+		When("caching the resources fails", func() {
+			BeforeEach(func() {
+				resourceCacheRepo.CacheResourcesReturns(errors.New("boom"))
+			})
+
+			It("still uploads the package", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			})
+		})
+
+		When("decoding the form values fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnprocessableEntityError(nil, "resources are invalid"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("resources are invalid")
+			})
+			itDoesntUploadSourceImage()
+			itDoesntUpdateAnyPackages()
+		})
+
+		When("resources are given", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.PackageUpload{
+					Resources: []payloads.Resource{{
+						Checksum:    payloads.Checksum{Value: "002d760bea1be268e27077412e11a320d0f164d3"},
+						SizeInBytes: 65536,
+						Path:        "path/to/file",
+						Mode:        "755",
+					}},
+				})
+
+				resourceCacheRepo.AssemblePackageReturns(io.NopCloser(strings.NewReader("the-assembled-package")), nil)
+			})
+
+			It("uploads the bits assembled with the cached resources", func() {
+				Expect(resourceCacheRepo.AssemblePackageCallCount()).To(Equal(1))
+				_, actualBits, actualSize, actualResources := resourceCacheRepo.AssemblePackageArgsForCall(0)
+				Expect(actualBits).NotTo(BeNil())
+				Expect(actualSize).To(BeEquivalentTo(len("the-src-file-contents")))
+				Expect(actualResources).To(Equal([]repositories.ResourceRecord{{
+					SHA1: "002d760bea1be268e27077412e11a320d0f164d3",
+					Size: 65536,
+					Path: "path/to/file",
+					Mode: 0o755,
+				}}))
+
+				Expect(imageRepo.UploadSourceImageCallCount()).To(Equal(1))
+				_, _, _, srcFile, _, _ := imageRepo.UploadSourceImageArgsForCall(0)
+				Expect(io.ReadAll(srcFile)).To(Equal([]byte("the-assembled-package")))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			})
+
+			When("no bits file is given", func() {
+				BeforeEach(func() {
+					var b bytes.Buffer
+					writer := multipart.NewWriter(&b)
+					Expect(writer.Close()).To(Succeed())
+					body = &b
+					formDataHeader = writer.FormDataContentType()
+				})
+
+				It("uploads the cached resources only", func() {
+					Expect(resourceCacheRepo.AssemblePackageCallCount()).To(Equal(1))
+					_, actualBits, actualSize, _ := resourceCacheRepo.AssemblePackageArgsForCall(0)
+					Expect(actualBits).To(BeNil())
+					Expect(actualSize).To(BeZero())
+
+					Expect(imageRepo.UploadSourceImageCallCount()).To(Equal(1))
+					Expect(resourceCacheRepo.CacheResourcesCallCount()).To(Equal(0))
+					Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				})
+			})
+
+			When("assembling the package fails", func() {
+				BeforeEach(func() {
+					resourceCacheRepo.AssemblePackageReturns(nil, apierrors.NewUnprocessableEntityError(nil, "not cached"))
+				})
+
+				It("returns an error", func() {
+					expectUnprocessableEntityError("not cached")
+				})
+				itDoesntUploadSourceImage()
+				itDoesntUpdateAnyPackages()
+			})
+		})
+
 		When("preparing to upload the source image errors", func() {
 			BeforeEach(func() {
 				imageRepo.UploadSourceImageReturns("", errors.New("boom"))
@@ -744,6 +971,394 @@ var _ = Describe("Package", func() {
 		})
 	})
 
+	Describe("the POST /v3/packages?source_guid= endpoint", func() {
+		var sourceGUID string
+
+		BeforeEach(func() {
+			sourceGUID = generateGUID("source-package")
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.PackageCopy{
+				SourceGUID: sourceGUID,
+			})
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.PackageCopyCreate{
+				Relationships: &payloads.PackageRelationships{
+					App: &payloads.Relationship{
+						Data: &payloads.RelationshipData{
+							GUID: appGUID,
+						},
+					},
+				},
+			})
+
+			appRepo.GetAppReturns(repositories.AppRecord{
+				SpaceGUID: spaceGUID,
+				GUID:      appGUID,
+			}, nil)
+
+			packageRepo.GetPackageReturns(repositories.PackageRecord{
+				GUID:        sourceGUID,
+				Type:        "bits",
+				State:       "READY",
+				SourceImage: "registry.example.org/app-packages@sha256:abc",
+			}, nil)
+
+			packageRepo.CopyPackageReturns(repositories.PackageRecord{
+				GUID:      packageGUID,
+				Type:      "bits",
+				AppGUID:   appGUID,
+				SpaceGUID: spaceGUID,
+				State:     "AWAITING_UPLOAD",
+				ImageRef:  "registry.example.org/target-app-packages",
+			}, nil)
+
+			imageRepo.CopyPackageImageReturns("registry.example.org/target-app-packages@sha256:abc", nil)
+
+			packageRepo.UpdatePackageSourceReturns(repositories.PackageRecord{
+				GUID:        packageGUID,
+				Type:        "bits",
+				AppGUID:     appGUID,
+				SpaceGUID:   spaceGUID,
+				State:       "READY",
+				SourceImage: "registry.example.org/target-app-packages@sha256:abc",
+			}, nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequestWithContext(ctx, "POST", "/v3/packages?source_guid="+sourceGUID, strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+
+			routerBuilder.Build().ServeHTTP(rr, req)
+		})
+
+		It("copies the package", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(packageRepo.GetPackageCallCount()).To(Equal(1))
+			_, _, actualSourceGUID := packageRepo.GetPackageArgsForCall(0)
+			Expect(actualSourceGUID).To(Equal(sourceGUID))
+
+			Expect(packageRepo.CreatePackageCallCount()).To(Equal(0))
+			Expect(packageRepo.CopyPackageCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualCopy := packageRepo.CopyPackageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualCopy).To(Equal(repositories.CopyPackageMessage{
+				SourceGUID: sourceGUID,
+				AppGUID:    appGUID,
+				SpaceGUID:  spaceGUID,
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", packageGUID),
+				MatchJSONPath("$.state", "READY"),
+				MatchJSONPath("$.relationships.app.data.guid", appGUID),
+			)))
+		})
+
+		It("copies the image into the package repository of the app, tagged with the guid of the copy", func() {
+			Expect(imageRepo.CopyPackageImageCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSrcImageRef, actualImageRef, actualSpaceGUID, actualTags := imageRepo.CopyPackageImageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSrcImageRef).To(Equal("registry.example.org/app-packages@sha256:abc"))
+			Expect(actualImageRef).To(Equal("registry.example.org/target-app-packages"))
+			Expect(actualSpaceGUID).To(Equal(spaceGUID))
+			Expect(actualTags).To(ConsistOf(packageGUID))
+		})
+
+		It("keeps the package copy", func() {
+			Expect(packageRepo.DeletePackageCallCount()).To(Equal(0))
+		})
+
+		It("sets the copied image as the source of the copy", func() {
+			Expect(packageRepo.UpdatePackageSourceCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := packageRepo.UpdatePackageSourceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UpdatePackageSourceMessage{
+				GUID:                packageGUID,
+				SpaceGUID:           spaceGUID,
+				ImageRef:            "registry.example.org/target-app-packages@sha256:abc",
+				RegistrySecretNames: packageImagePullSecretNames,
+			}))
+
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.state", "READY")))
+		})
+
+		itDoesntCopyThePackage := func() {
+			It("doesn't copy the package", func() {
+				Expect(packageRepo.CopyPackageCallCount()).To(Equal(0))
+			})
+		}
+
+		When("the query is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(apierrors.NewUnknownKeyError(nil, []string{"source_guid"}))
+			})
+
+			It("returns an error", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusBadRequest))
+			})
+
+			itDoesntCopyThePackage()
+		})
+
+		When("the app is not accessible", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("App is invalid. Ensure it exists and you have access to it.")
+			})
+
+			itDoesntCopyThePackage()
+		})
+
+		When("the source package does not exist", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{}, apierrors.NewNotFoundError(nil, repositories.PackageResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Source package is invalid. Ensure it exists and you have access to it.")
+			})
+
+			itDoesntCopyThePackage()
+		})
+
+		When("the source package is not ready", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{
+					GUID:  sourceGUID,
+					Type:  "bits",
+					State: "AWAITING_UPLOAD",
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Source package is not ready.")
+			})
+
+			itDoesntCopyThePackage()
+		})
+
+		When("the target app uses the docker lifecycle", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{
+					SpaceGUID: spaceGUID,
+					GUID:      appGUID,
+					Lifecycle: repositories.Lifecycle{Type: "docker"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Cannot create bits package for a Docker app.")
+			})
+
+			itDoesntCopyThePackage()
+		})
+
+		When("the source package is a docker package", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{
+					GUID:  sourceGUID,
+					Type:  "docker",
+					State: "READY",
+				}, nil)
+				appRepo.GetAppReturns(repositories.AppRecord{
+					SpaceGUID: spaceGUID,
+					GUID:      appGUID,
+					Lifecycle: repositories.Lifecycle{Type: "docker"},
+				}, nil)
+				packageRepo.CopyPackageReturns(repositories.PackageRecord{
+					GUID:  packageGUID,
+					Type:  "docker",
+					State: "READY",
+				}, nil)
+			})
+
+			It("copies the package without copying an image", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(packageRepo.CopyPackageCallCount()).To(Equal(1))
+				Expect(imageRepo.CopyPackageImageCallCount()).To(Equal(0))
+				Expect(packageRepo.UpdatePackageSourceCallCount()).To(Equal(0))
+			})
+		})
+
+		When("copying the package fails", func() {
+			BeforeEach(func() {
+				packageRepo.CopyPackageReturns(repositories.PackageRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+				Expect(imageRepo.CopyPackageImageCallCount()).To(Equal(0))
+			})
+		})
+
+		When("copying the image fails", func() {
+			BeforeEach(func() {
+				imageRepo.CopyPackageImageReturns("", apierrors.NewBlobstoreUnavailableError(errors.New("boom")))
+			})
+
+			It("returns an error", func() {
+				expectBlobstoreUnavailableError()
+				Expect(packageRepo.UpdatePackageSourceCallCount()).To(Equal(0))
+			})
+
+			It("deletes the package copy", func() {
+				Expect(packageRepo.DeletePackageCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualMessage := packageRepo.DeletePackageArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualMessage).To(Equal(repositories.DeletePackageMessage{
+					GUID:      packageGUID,
+					SpaceGUID: spaceGUID,
+				}))
+			})
+
+			When("deleting the package copy fails", func() {
+				BeforeEach(func() {
+					packageRepo.DeletePackageReturns(errors.New("delete-failed"))
+				})
+
+				It("returns the copy error", func() {
+					expectBlobstoreUnavailableError()
+				})
+			})
+		})
+
+		When("setting the source of the copy fails", func() {
+			BeforeEach(func() {
+				packageRepo.UpdatePackageSourceReturns(repositories.PackageRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+
+			It("deletes the package copy", func() {
+				Expect(packageRepo.DeletePackageCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualMessage := packageRepo.DeletePackageArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualMessage).To(Equal(repositories.DeletePackageMessage{
+					GUID:      packageGUID,
+					SpaceGUID: spaceGUID,
+				}))
+			})
+		})
+	})
+
+	Describe("the GET /v3/packages/:guid/download endpoint", func() {
+		BeforeEach(func() {
+			packageRepo.GetPackageReturns(repositories.PackageRecord{
+				GUID:        packageGUID,
+				Type:        "bits",
+				State:       "READY",
+				SourceImage: "registry.example.org/app-packages@sha256:abc",
+			}, nil)
+
+			tarBuffer := new(bytes.Buffer)
+			tarWriter := tar.NewWriter(tarBuffer)
+			Expect(tarWriter.WriteHeader(&tar.Header{Name: "/", Typeflag: tar.TypeDir, Mode: 0o755})).To(Succeed())
+			Expect(tarWriter.WriteHeader(&tar.Header{Name: "/app", Typeflag: tar.TypeDir, Mode: 0o755})).To(Succeed())
+			Expect(tarWriter.WriteHeader(&tar.Header{Name: "/app/main.go", Typeflag: tar.TypeReg, Mode: 0o644, Size: 12})).To(Succeed())
+			_, err := tarWriter.Write([]byte("package main"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tarWriter.WriteHeader(&tar.Header{Name: "/app/link", Typeflag: tar.TypeSymlink, Linkname: "main.go", Mode: 0o777})).To(Succeed())
+			Expect(tarWriter.Close()).To(Succeed())
+
+			imageRepo.DownloadImageReturns(io.NopCloser(tarBuffer), nil)
+		})
+
+		JustBeforeEach(func() {
+			req, err := http.NewRequestWithContext(ctx, "GET", "/v3/packages/"+packageGUID+"/download", nil)
+			Expect(err).NotTo(HaveOccurred())
+			routerBuilder.Build().ServeHTTP(rr, req)
+		})
+
+		It("streams the package bits as a zip", func() {
+			Expect(packageRepo.GetPackageCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualPackageGUID := packageRepo.GetPackageArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualPackageGUID).To(Equal(packageGUID))
+
+			Expect(imageRepo.DownloadImageCallCount()).To(Equal(1))
+			_, actualImageRef := imageRepo.DownloadImageArgsForCall(0)
+			Expect(actualImageRef).To(Equal("registry.example.org/app-packages@sha256:abc"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/zip"))
+
+			body := rr.Body.Bytes()
+			zipReader, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+			Expect(err).NotTo(HaveOccurred())
+
+			files := map[string]*zip.File{}
+			for _, f := range zipReader.File {
+				files[f.Name] = f
+			}
+			Expect(files).To(HaveLen(3))
+			Expect(files).To(HaveKey("app/"))
+			Expect(files["app/link"].Mode() & os.ModeSymlink).NotTo(BeZero())
+
+			fileReader, err := files["app/main.go"].Open()
+			Expect(err).NotTo(HaveOccurred())
+			defer fileReader.Close()
+			Expect(io.ReadAll(fileReader)).To(Equal([]byte("package main")))
+		})
+
+		When("the package is forbidden", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{}, apierrors.NewForbiddenError(nil, repositories.PackageResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.PackageResourceType)
+			})
+		})
+
+		When("the package is a docker package", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{
+					GUID:  packageGUID,
+					Type:  "docker",
+					State: "READY",
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Cannot download packages with type 'docker'.")
+				Expect(imageRepo.DownloadImageCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the package has no bits", func() {
+			BeforeEach(func() {
+				packageRepo.GetPackageReturns(repositories.PackageRecord{
+					GUID:  packageGUID,
+					Type:  "bits",
+					State: "AWAITING_UPLOAD",
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Package has no bits to download.")
+				Expect(imageRepo.DownloadImageCallCount()).To(Equal(0))
+			})
+		})
+
+		When("downloading the image fails", func() {
+			BeforeEach(func() {
+				imageRepo.DownloadImageReturns(nil, apierrors.NewBlobstoreUnavailableError(errors.New("boom")))
+			})
+
+			It("returns an error", func() {
+				expectBlobstoreUnavailableError()
+			})
+		})
+	})
+
 	Describe("the GET /v3/packages/:guid/droplets endpoint", func() {
 		var dropletGUID string
 		var queryString string
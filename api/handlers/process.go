@@ -2,7 +2,6 @@ package handlers
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"net/url"
 
@@ -41,23 +40,29 @@ type ProcessStats interface {
 }
 
 type Process struct {
-	serverURL        url.URL
-	processRepo      CFProcessRepository
-	processStats     ProcessStats
-	requestValidator RequestValidator
+	serverURL          url.URL
+	processRepo        CFProcessRepository
+	processStats       ProcessStats
+	sidecarRepo        CFSidecarRepository
+	requestValidator   RequestValidator
+	featureFlagChecker FeatureFlagChecker
 }
 
 func NewProcess(
 	serverURL url.URL,
 	processRepo CFProcessRepository,
 	processStatsFetcher ProcessStats,
+	sidecarRepo CFSidecarRepository,
 	requestValidator RequestValidator,
+	featureFlagChecker FeatureFlagChecker,
 ) *Process {
 	return &Process{
-		serverURL:        serverURL,
-		processRepo:      processRepo,
-		processStats:     processStatsFetcher,
-		requestValidator: requestValidator,
+		serverURL:          serverURL,
+		processRepo:        processRepo,
+		processStats:       processStatsFetcher,
+		sidecarRepo:        sidecarRepo,
+		requestValidator:   requestValidator,
+		featureFlagChecker: featureFlagChecker,
 	}
 }
 
@@ -81,26 +86,21 @@ func (h *Process) getSidecars(r *http.Request) (*routing.Response, error) {
 
 	processGUID := routing.URLParam(r, "guid")
 
-	_, err := h.processRepo.GetProcess(r.Context(), authInfo, processGUID)
+	process, err := h.processRepo.GetProcess(r.Context(), authInfo, processGUID)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Failed to fetch process from Kubernetes", "ProcessGUID", processGUID)
 	}
 
-	return routing.NewResponse(http.StatusOK).WithBody(map[string]interface{}{
-		"pagination": map[string]interface{}{
-			"total_results": 0,
-			"total_pages":   1,
-			"first": map[string]interface{}{
-				"href": fmt.Sprintf("%s/v3/processes/%s/sidecars", h.serverURL.String(), processGUID),
-			},
-			"last": map[string]interface{}{
-				"href": fmt.Sprintf("%s/v3/processes/%s/sidecars", h.serverURL.String(), processGUID),
-			},
-			"next":     nil,
-			"previous": nil,
-		},
-		"resources": []string{},
-	}), nil
+	sidecars, err := h.sidecarRepo.ListSidecars(r.Context(), authInfo, repositories.ListSidecarsMessage{
+		AppGUID:     process.AppGUID,
+		SpaceGUID:   process.SpaceGUID,
+		ProcessType: process.Type,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Failed to list sidecars", "ProcessGUID", processGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForSidecar, sidecars, h.serverURL, *r.URL)), nil
 }
 
 func (h *Process) scale(r *http.Request) (*routing.Response, error) {
@@ -114,6 +114,10 @@ func (h *Process) scale(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
 	}
 
+	if err := h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, repositories.FeatureFlagAppScaling); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "app scaling is disabled")
+	}
+
 	process, err := h.processRepo.GetProcess(r.Context(), authInfo, processGUID)
 	if err != nil {
 		return nil, apierrors.ForbiddenAsNotFound(err)
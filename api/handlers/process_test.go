@@ -20,21 +20,27 @@ import (
 
 var _ = Describe("Process", func() {
 	var (
-		processRepo      *fake.CFProcessRepository
-		processStats     *fake.ProcessStats
-		requestValidator *fake.RequestValidator
+		processRepo        *fake.CFProcessRepository
+		processStats       *fake.ProcessStats
+		sidecarRepo        *fake.CFSidecarRepository
+		requestValidator   *fake.RequestValidator
+		featureFlagChecker *fake.FeatureFlagChecker
 	)
 
 	BeforeEach(func() {
 		processRepo = new(fake.CFProcessRepository)
 		processStats = new(fake.ProcessStats)
+		sidecarRepo = new(fake.CFSidecarRepository)
 		requestValidator = new(fake.RequestValidator)
+		featureFlagChecker = new(fake.FeatureFlagChecker)
 
 		apiHandler := NewProcess(
 			*serverURL,
 			processRepo,
 			processStats,
+			sidecarRepo,
 			requestValidator,
+			featureFlagChecker,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
@@ -90,7 +96,16 @@ var _ = Describe("Process", func() {
 
 	Describe("the GET /v3/processes/:guid/sidecars endpoint", func() {
 		BeforeEach(func() {
-			processRepo.GetProcessReturns(repositories.ProcessRecord{}, nil)
+			processRepo.GetProcessReturns(repositories.ProcessRecord{
+				GUID:      "process-guid",
+				AppGUID:   "app-guid",
+				SpaceGUID: spaceGUID,
+				Type:      "web",
+			}, nil)
+
+			sidecarRepo.ListSidecarsReturns([]repositories.SidecarRecord{
+				{GUID: "sidecar-guid", AppGUID: "app-guid", Name: "my-sidecar"},
+			}, nil)
 		})
 
 		JustBeforeEach(func() {
@@ -99,19 +114,40 @@ var _ = Describe("Process", func() {
 			routerBuilder.Build().ServeHTTP(rr, req)
 		})
 
-		It("returns the empty list of sidecars", func() {
+		It("returns the sidecars running alongside the process", func() {
 			Expect(processRepo.GetProcessCallCount()).To(Equal(1))
 			_, actualAuthInfo, _ := processRepo.GetProcessArgsForCall(0)
 			Expect(actualAuthInfo).To(Equal(authInfo))
 
+			Expect(sidecarRepo.ListSidecarsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := sidecarRepo.ListSidecarsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.ListSidecarsMessage{
+				AppGUID:     "app-guid",
+				SpaceGUID:   spaceGUID,
+				ProcessType: "web",
+			}))
+
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.pagination.total_results", BeZero()),
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
 				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/processes/process-guid/sidecars"),
+				MatchJSONPath("$.resources[0].guid", "sidecar-guid"),
+				MatchJSONPath("$.resources[0].name", "my-sidecar"),
 			)))
 		})
 
+		When("listing the sidecars fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.ListSidecarsReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
 		When("the process isn't accessible to the user", func() {
 			BeforeEach(func() {
 				processRepo.GetProcessReturns(repositories.ProcessRecord{}, apierrors.NewForbiddenError(nil, repositories.ProcessResourceType))
@@ -190,6 +226,22 @@ var _ = Describe("Process", func() {
 			)))
 		})
 
+		When("the app_scaling feature flag is disabled", func() {
+			BeforeEach(func() {
+				featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError(nil, repositories.FeatureFlagAppScaling, ""))
+			})
+
+			It("returns a feature disabled error", func() {
+				Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualFlag := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualFlag).To(Equal(repositories.FeatureFlagAppScaling))
+
+				expectErrorResponse(http.StatusForbidden, "CF-FeatureDisabled", "Feature Disabled: app_scaling", 330002)
+				Expect(processRepo.ScaleProcessCallCount()).To(BeZero())
+			})
+		})
+
 		When("the request JSON is invalid", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
@@ -1,25 +1,74 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"io"
 	"net/http"
 
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
 )
 
 const (
 	ResourceMatchesPath = "/v3/resource_matches"
 )
 
-type ResourceMatches struct{}
+//counterfeiter:generate -o fake -fake-name ResourceCacheRepository . ResourceCacheRepository
+
+type ResourceCacheRepository interface {
+	MatchResources(context.Context, []repositories.ResourceRecord) ([]repositories.ResourceRecord, error)
+	CacheResources(ctx context.Context, bits io.ReaderAt, size int64) error
+	AssemblePackage(ctx context.Context, bits io.ReaderAt, size int64, resources []repositories.ResourceRecord) (io.ReadCloser, error)
+}
 
-func NewResourceMatches() *ResourceMatches {
-	return &ResourceMatches{}
+type ResourceMatches struct {
+	resourceCacheRepo  ResourceCacheRepository
+	featureFlagChecker FeatureFlagChecker
+	requestValidator   RequestValidator
+}
+
+func NewResourceMatches(
+	resourceCacheRepo ResourceCacheRepository,
+	featureFlagChecker FeatureFlagChecker,
+	requestValidator RequestValidator,
+) *ResourceMatches {
+	return &ResourceMatches{
+		resourceCacheRepo:  resourceCacheRepo,
+		featureFlagChecker: featureFlagChecker,
+		requestValidator:   requestValidator,
+	}
 }
 
 func (h *ResourceMatches) create(r *http.Request) (*routing.Response, error) {
-	return routing.NewResponse(http.StatusCreated).WithBody(map[string]interface{}{
-		"resources": []interface{}{},
-	}), nil
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.resource-matches.create")
+
+	var payload payloads.ResourceMatches
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	err := h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, repositories.FeatureFlagResourceMatching)
+	if errors.As(err, new(apierrors.FeatureDisabledError)) {
+		return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForResourceMatches(nil)), nil
+	}
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error checking resource_matching feature flag")
+	}
+
+	matches, err := h.resourceCacheRepo.MatchResources(r.Context(), payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Error matching resources")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForResourceMatches(matches)), nil
 }
 
 func (h *ResourceMatches) UnauthenticatedRoutes() []routing.Route {
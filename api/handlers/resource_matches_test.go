@@ -1,20 +1,34 @@
 package handlers_test
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
 
 var _ = Describe("ResourceMatches", func() {
-	var req *http.Request
+	var (
+		req                *http.Request
+		resourceCacheRepo  *fake.ResourceCacheRepository
+		featureFlagChecker *fake.FeatureFlagChecker
+		requestValidator   *fake.RequestValidator
+	)
 
 	BeforeEach(func() {
-		apiHandler := NewResourceMatches()
+		resourceCacheRepo = new(fake.ResourceCacheRepository)
+		featureFlagChecker = new(fake.FeatureFlagChecker)
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := NewResourceMatches(resourceCacheRepo, featureFlagChecker, requestValidator)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -22,19 +36,109 @@ var _ = Describe("ResourceMatches", func() {
 		routerBuilder.Build().ServeHTTP(rr, req)
 	})
 
-	Describe("Get Resource Match Endpoint", func() {
+	Describe("Create Resource Match Endpoint", func() {
 		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ResourceMatches{
+				Resources: []payloads.Resource{
+					{
+						Checksum:    payloads.Checksum{Value: "002d760bea1be268e27077412e11a320d0f164d3"},
+						SizeInBytes: 65536,
+						Path:        "path/to/file",
+						Mode:        "644",
+					},
+					{
+						Checksum:    payloads.Checksum{Value: "a9993e364706816aba3e25717850c26c9cd0d89d"},
+						SizeInBytes: 131072,
+						Path:        "path/to/other/file",
+						Mode:        "755",
+					},
+				},
+			})
+
+			resourceCacheRepo.MatchResourcesReturns([]repositories.ResourceRecord{{
+				SHA1: "002d760bea1be268e27077412e11a320d0f164d3",
+				Size: 65536,
+				Path: "path/to/file",
+				Mode: 0o644,
+			}}, nil)
+
 			var err error
-			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/resource_matches", strings.NewReader("{}"))
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/resource_matches", strings.NewReader("the-json-body"))
 			Expect(err).NotTo(HaveOccurred())
 		})
 
-		It("returns an empty list", func() {
+		It("returns the resources that are in the cache", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualFlag := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualFlag).To(Equal(repositories.FeatureFlagResourceMatching))
+
+			Expect(resourceCacheRepo.MatchResourcesCallCount()).To(Equal(1))
+			_, actualResources := resourceCacheRepo.MatchResourcesArgsForCall(0)
+			Expect(actualResources).To(Equal([]repositories.ResourceRecord{
+				{SHA1: "002d760bea1be268e27077412e11a320d0f164d3", Size: 65536, Path: "path/to/file", Mode: 0o644},
+				{SHA1: "a9993e364706816aba3e25717850c26c9cd0d89d", Size: 131072, Path: "path/to/other/file", Mode: 0o755},
+			}))
+
 			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(MatchJSON(`{
-				"resources": []
-			  }`)))
+				"resources": [
+					{
+						"checksum": { "value": "002d760bea1be268e27077412e11a320d0f164d3" },
+						"size_in_bytes": 65536,
+						"path": "path/to/file",
+						"mode": "644"
+					}
+				]
+			}`)))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+				Expect(resourceCacheRepo.MatchResourcesCallCount()).To(Equal(0))
+			})
+		})
+
+		When("resource matching is disabled", func() {
+			BeforeEach(func() {
+				featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError(nil, repositories.FeatureFlagResourceMatching, ""))
+			})
+
+			It("returns an empty list", func() {
+				Expect(resourceCacheRepo.MatchResourcesCallCount()).To(Equal(0))
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(rr).To(HaveHTTPBody(MatchJSON(`{"resources": []}`)))
+			})
+		})
+
+		When("checking the feature flag fails", func() {
+			BeforeEach(func() {
+				featureFlagChecker.CheckFeatureFlagReturns(errors.New("check-flag"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("matching the resources fails", func() {
+			BeforeEach(func() {
+				resourceCacheRepo.MatchResourcesReturns(nil, apierrors.NewBlobstoreUnavailableError(errors.New("boom")))
+			})
+
+			It("returns an error", func() {
+				expectBlobstoreUnavailableError()
+			})
 		})
 	})
 })
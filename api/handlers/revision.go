@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	RevisionPath             = "/v3/revisions/{guid}"
+	RevisionEnvVarsPath      = "/v3/revisions/{guid}/environment_variables"
+	AppRevisionsPath         = "/v3/apps/{guid}/revisions"
+	AppDeployedRevisionsPath = "/v3/apps/{guid}/revisions/deployed"
+)
+
+//counterfeiter:generate -o fake -fake-name CFRevisionRepository . CFRevisionRepository
+type CFRevisionRepository interface {
+	GetRevision(context.Context, authorization.Info, string) (repositories.RevisionRecord, error)
+	ListRevisions(context.Context, authorization.Info, repositories.ListRevisionsMessage) ([]repositories.RevisionRecord, error)
+	GetRevisionEnvironmentVariables(context.Context, authorization.Info, string) (repositories.RevisionEnvVarsRecord, error)
+}
+
+type Revision struct {
+	serverURL        url.URL
+	revisionRepo     CFRevisionRepository
+	appRepo          CFAppRepository
+	requestValidator RequestValidator
+}
+
+func NewRevision(
+	serverURL url.URL,
+	revisionRepo CFRevisionRepository,
+	appRepo CFAppRepository,
+	requestValidator RequestValidator,
+) *Revision {
+	return &Revision{
+		serverURL:        serverURL,
+		revisionRepo:     revisionRepo,
+		appRepo:          appRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *Revision) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.revision.get")
+
+	revisionGUID := routing.URLParam(r, "guid")
+
+	revision, err := h.revisionRepo.GetRevision(r.Context(), authInfo, revisionGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get revision", "guid", revisionGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRevision(revision, h.serverURL)), nil
+}
+
+func (h *Revision) getEnvVars(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.revision.get-env-vars")
+
+	revisionGUID := routing.URLParam(r, "guid")
+
+	envVars, err := h.revisionRepo.GetRevisionEnvironmentVariables(r.Context(), authInfo, revisionGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get revision environment variables", "guid", revisionGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRevisionEnvVars(envVars, h.serverURL)), nil
+}
+
+func (h *Revision) listForApp(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.revision.list-for-app")
+
+	appGUID := routing.URLParam(r, "guid")
+
+	listFilter := new(payloads.RevisionList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, listFilter); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	if _, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app", "guid", appGUID)
+	}
+
+	revisions, err := h.revisionRepo.ListRevisions(r.Context(), authInfo, listFilter.ToMessage(appGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list revisions", "appGUID", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForRevision, revisions, h.serverURL, *r.URL)), nil
+}
+
+func (h *Revision) listDeployedForApp(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.revision.list-deployed-for-app")
+
+	appGUID := routing.URLParam(r, "guid")
+
+	if _, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app", "guid", appGUID)
+	}
+
+	revisions, err := h.revisionRepo.ListRevisions(r.Context(), authInfo, repositories.ListRevisionsMessage{
+		AppGUID:  appGUID,
+		Deployed: true,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list deployed revisions", "appGUID", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForRevision, revisions, h.serverURL, *r.URL)), nil
+}
+
+func (h *Revision) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *Revision) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: RevisionPath, Handler: h.get},
+		{Method: "GET", Pattern: RevisionEnvVarsPath, Handler: h.getEnvVars},
+		{Method: "GET", Pattern: AppRevisionsPath, Handler: h.listForApp},
+		{Method: "GET", Pattern: AppDeployedRevisionsPath, Handler: h.listDeployedForApp},
+	}
+}
@@ -0,0 +1,234 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Revision", func() {
+	var (
+		apiHandler       *handlers.Revision
+		revisionRepo     *fake.CFRevisionRepository
+		appRepo          *fake.CFAppRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		revisionRepo = new(fake.CFRevisionRepository)
+		appRepo = new(fake.CFAppRepository)
+		appRepo.GetAppReturns(repositories.AppRecord{GUID: "app-guid"}, nil)
+
+		apiHandler = handlers.NewRevision(
+			*serverURL,
+			revisionRepo,
+			appRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/revisions/{guid}", func() {
+		BeforeEach(func() {
+			revisionRepo.GetRevisionReturns(repositories.RevisionRecord{
+				GUID:    "revision-guid",
+				AppGUID: "app-guid",
+				Version: 2,
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/revisions/revision-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the revision", func() {
+			Expect(revisionRepo.GetRevisionCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := revisionRepo.GetRevisionArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("revision-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "revision-guid"),
+				MatchJSONPath("$.version", BeEquivalentTo(2)),
+				MatchJSONPath("$.relationships.app.data.guid", "app-guid"),
+			)))
+		})
+
+		When("the user is not authorized to get the revision", func() {
+			BeforeEach(func() {
+				revisionRepo.GetRevisionReturns(repositories.RevisionRecord{}, apierrors.NewForbiddenError(nil, repositories.RevisionResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.RevisionResourceType)
+			})
+		})
+
+		When("getting the revision fails", func() {
+			BeforeEach(func() {
+				revisionRepo.GetRevisionReturns(repositories.RevisionRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/revisions/{guid}/environment_variables", func() {
+		BeforeEach(func() {
+			revisionRepo.GetRevisionEnvironmentVariablesReturns(repositories.RevisionEnvVarsRecord{
+				RevisionGUID:         "revision-guid",
+				EnvironmentVariables: map[string]string{"FOO": "bar"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/revisions/revision-guid/environment_variables", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the environment variables of the revision", func() {
+			Expect(revisionRepo.GetRevisionEnvironmentVariablesCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := revisionRepo.GetRevisionEnvironmentVariablesArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("revision-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.var.FOO", "bar"),
+				MatchJSONPath("$.links.revision.href", "https://api.example.org/v3/revisions/revision-guid"),
+			)))
+		})
+
+		When("the user is not authorized to get the revision", func() {
+			BeforeEach(func() {
+				revisionRepo.GetRevisionEnvironmentVariablesReturns(repositories.RevisionEnvVarsRecord{}, apierrors.NewForbiddenError(nil, repositories.RevisionResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.RevisionResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/apps/{guid}/revisions", func() {
+		BeforeEach(func() {
+			revisionRepo.ListRevisionsReturns([]repositories.RevisionRecord{
+				{GUID: "revision-1", AppGUID: "app-guid", Version: 1},
+				{GUID: "revision-2", AppGUID: "app-guid", Version: 2},
+			}, nil)
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.RevisionList{
+				Versions: "1,2",
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/apps/app-guid/revisions?versions=1,2", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the revisions of the app", func() {
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, _, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAppGUID).To(Equal("app-guid"))
+
+			Expect(revisionRepo.ListRevisionsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := revisionRepo.ListRevisionsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ListRevisionsMessage{
+				AppGUID:  "app-guid",
+				Versions: []string{"1", "2"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "revision-1"),
+				MatchJSONPath("$.resources[1].guid", "revision-2"),
+			)))
+		})
+
+		When("the query is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("the user is not authorized to get the app", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppResourceType)
+			})
+		})
+
+		When("listing the revisions fails", func() {
+			BeforeEach(func() {
+				revisionRepo.ListRevisionsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/apps/{guid}/revisions/deployed", func() {
+		BeforeEach(func() {
+			revisionRepo.ListRevisionsReturns([]repositories.RevisionRecord{
+				{GUID: "revision-2", AppGUID: "app-guid", Version: 2},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/apps/app-guid/revisions/deployed", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the deployed revisions of the app", func() {
+			Expect(revisionRepo.ListRevisionsCallCount()).To(Equal(1))
+			_, _, message := revisionRepo.ListRevisionsArgsForCall(0)
+			Expect(message).To(Equal(repositories.ListRevisionsMessage{
+				AppGUID:  "app-guid",
+				Deployed: true,
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.resources[0].guid", "revision-2"),
+			)))
+		})
+
+		When("the app does not exist", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppResourceType)
+			})
+		})
+	})
+})
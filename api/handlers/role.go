@@ -31,16 +31,18 @@ type CFRoleRepository interface {
 }
 
 type Role struct {
-	apiBaseURL       url.URL
-	roleRepo         CFRoleRepository
-	requestValidator RequestValidator
+	apiBaseURL         url.URL
+	roleRepo           CFRoleRepository
+	requestValidator   RequestValidator
+	featureFlagChecker FeatureFlagChecker
 }
 
-func NewRole(apiBaseURL url.URL, roleRepo CFRoleRepository, requestValidator RequestValidator) *Role {
+func NewRole(apiBaseURL url.URL, roleRepo CFRoleRepository, requestValidator RequestValidator, featureFlagChecker FeatureFlagChecker) *Role {
 	return &Role{
-		apiBaseURL:       apiBaseURL,
-		roleRepo:         roleRepo,
-		requestValidator: requestValidator,
+		apiBaseURL:         apiBaseURL,
+		roleRepo:           roleRepo,
+		requestValidator:   requestValidator,
+		featureFlagChecker: featureFlagChecker,
 	}
 }
 
@@ -53,6 +55,12 @@ func (h *Role) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
 	}
 
+	if payload.Relationships.User.Data.GUID == "" {
+		if err := h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, repositories.FeatureFlagSetRolesByUsername); err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "setting roles by username is disabled")
+		}
+	}
+
 	role := payload.ToMessage()
 	role.GUID = uuid.NewString()
 
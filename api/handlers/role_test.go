@@ -26,16 +26,18 @@ const (
 
 var _ = Describe("Role", func() {
 	var (
-		apiHandler       *handlers.Role
-		roleRepo         *fake.CFRoleRepository
-		requestValidator *fake.RequestValidator
+		apiHandler         *handlers.Role
+		roleRepo           *fake.CFRoleRepository
+		requestValidator   *fake.RequestValidator
+		featureFlagChecker *fake.FeatureFlagChecker
 	)
 
 	BeforeEach(func() {
 		roleRepo = new(fake.CFRoleRepository)
 		requestValidator = new(fake.RequestValidator)
+		featureFlagChecker = new(fake.FeatureFlagChecker)
 
-		apiHandler = handlers.NewRole(*serverURL, roleRepo, requestValidator)
+		apiHandler = handlers.NewRole(*serverURL, roleRepo, requestValidator, featureFlagChecker)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -90,6 +92,24 @@ var _ = Describe("Role", func() {
 			)))
 		})
 
+		It("checks the set_roles_by_username feature flag", func() {
+			Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualFlag := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualFlag).To(Equal(repositories.FeatureFlagSetRolesByUsername))
+		})
+
+		When("the set_roles_by_username feature flag is disabled", func() {
+			BeforeEach(func() {
+				featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError(nil, repositories.FeatureFlagSetRolesByUsername, ""))
+			})
+
+			It("returns a feature disabled error", func() {
+				expectErrorResponse(http.StatusForbidden, "CF-FeatureDisabled", "Feature Disabled: set_roles_by_username", 330002)
+				Expect(roleRepo.CreateRoleCallCount()).To(BeZero())
+			})
+		})
+
 		When("username is passed in the guid field", func() {
 			BeforeEach(func() {
 				roleCreate.Relationships.User.Data.Username = ""
@@ -101,6 +121,10 @@ var _ = Describe("Role", func() {
 				_, _, roleMessage := roleRepo.CreateRoleArgsForCall(0)
 				Expect(roleMessage.User).To(Equal("my-user"))
 			})
+
+			It("does not check the set_roles_by_username feature flag", func() {
+				Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(BeZero())
+			})
 		})
 
 		When("the role is an organisation role", func() {
@@ -4,31 +4,40 @@ import (
 	"net/http"
 	"net/url"
 
+	"code.cloudfoundry.org/korifi/api/config"
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/routing"
 )
 
 const (
-	RootPath = "/"
+	RootPath   = "/"
+	V2InfoPath = "/v2/info"
 )
 
 type Root struct {
-	baseURL url.URL
+	baseURL  url.URL
+	sshProxy config.SSHProxyConfig
 }
 
-func NewRoot(baseURL url.URL) *Root {
+func NewRoot(baseURL url.URL, sshProxy config.SSHProxyConfig) *Root {
 	return &Root{
-		baseURL: baseURL,
+		baseURL:  baseURL,
+		sshProxy: sshProxy,
 	}
 }
 
 func (h *Root) get(r *http.Request) (*routing.Response, error) {
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRoot(h.baseURL)), nil
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRoot(h.baseURL, h.sshProxy)), nil
+}
+
+func (h *Root) getV2Info(r *http.Request) (*routing.Response, error) {
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForV2Info(h.baseURL, h.sshProxy)), nil
 }
 
 func (h *Root) UnauthenticatedRoutes() []routing.Route {
 	return []routing.Route{
 		{Method: "GET", Pattern: RootPath, Handler: h.get},
+		{Method: "GET", Pattern: V2InfoPath, Handler: h.getV2Info},
 	}
 }
 
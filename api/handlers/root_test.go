@@ -3,6 +3,7 @@ package handlers_test
 import (
 	"net/http"
 
+	"code.cloudfoundry.org/korifi/api/config"
 	"code.cloudfoundry.org/korifi/api/handlers"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
 
@@ -14,7 +15,11 @@ var _ = Describe("Root", func() {
 	var req *http.Request
 
 	BeforeEach(func() {
-		apiHandler := handlers.NewRoot(*serverURL)
+		apiHandler := handlers.NewRoot(*serverURL, config.SSHProxyConfig{
+			Endpoint:           "ssh.example.org:2222",
+			HostKeyFingerprint: "the-fingerprint",
+			OAuthClient:        "ssh-proxy",
+		})
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -36,6 +41,29 @@ var _ = Describe("Root", func() {
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
 				MatchJSONPath("$.links.self.href", "https://api.example.org"),
 				MatchJSONPath("$.links.cloud_controller_v3.href", "https://api.example.org/v3"),
+				MatchJSONPath("$.links.app_ssh.href", "ssh.example.org:2222"),
+				MatchJSONPath("$.links.app_ssh.meta.host_key_fingerprint", "the-fingerprint"),
+				MatchJSONPath("$.links.app_ssh.meta.oauth_client", "ssh-proxy"),
+			)))
+		})
+	})
+
+	Describe("GET /v2/info endpoint", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequest("GET", "/v2/info", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the expected response", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.authorization_endpoint", "https://api.example.org"),
+				MatchJSONPath("$.app_ssh_endpoint", "ssh.example.org:2222"),
+				MatchJSONPath("$.app_ssh_host_key_fingerprint", "the-fingerprint"),
+				MatchJSONPath("$.app_ssh_oauth_client", "ssh-proxy"),
 			)))
 		})
 	})
@@ -11,6 +11,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
 	"github.com/go-logr/logr"
 )
@@ -36,12 +37,14 @@ type CFRouteRepository interface {
 }
 
 type Route struct {
-	serverURL        url.URL
-	routeRepo        CFRouteRepository
-	domainRepo       CFDomainRepository
-	appRepo          CFAppRepository
-	spaceRepo        CFSpaceRepository
-	requestValidator RequestValidator
+	serverURL          url.URL
+	routeRepo          CFRouteRepository
+	domainRepo         CFDomainRepository
+	appRepo            CFAppRepository
+	spaceRepo          CFSpaceRepository
+	featureFlagChecker FeatureFlagChecker
+	auditRecorder      AuditEventRecorder
+	requestValidator   RequestValidator
 }
 
 func NewRoute(
@@ -50,15 +53,19 @@ func NewRoute(
 	domainRepo CFDomainRepository,
 	appRepo CFAppRepository,
 	spaceRepo CFSpaceRepository,
+	featureFlagChecker FeatureFlagChecker,
+	auditRecorder AuditEventRecorder,
 	requestValidator RequestValidator,
 ) *Route {
 	return &Route{
-		serverURL:        serverURL,
-		routeRepo:        routeRepo,
-		domainRepo:       domainRepo,
-		appRepo:          appRepo,
-		spaceRepo:        spaceRepo,
-		requestValidator: requestValidator,
+		serverURL:          serverURL,
+		routeRepo:          routeRepo,
+		domainRepo:         domainRepo,
+		appRepo:            appRepo,
+		spaceRepo:          spaceRepo,
+		featureFlagChecker: featureFlagChecker,
+		auditRecorder:      auditRecorder,
+		requestValidator:   requestValidator,
 	}
 }
 
@@ -116,6 +123,10 @@ func (h *Route) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
 	}
 
+	if err := h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, repositories.FeatureFlagRouteCreation); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "route creation is disabled")
+	}
+
 	spaceGUID := payload.Relationships.Space.Data.GUID
 	_, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID)
 	if err != nil {
@@ -180,6 +191,20 @@ func (h *Route) insertDestinations(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to add destination on route", "Route GUID", routeRecord.GUID)
 	}
 
+	for _, destination := range destinationListCreateMessage.NewDestinations {
+		recordAuditEvent(r.Context(), logger, h.auditRecorder, authInfo, repositories.RecordAuditEventMessage{
+			Type:       korifiv1alpha1.AuditEventTypeRouteMap,
+			TargetGUID: routeRecord.GUID,
+			TargetType: korifiv1alpha1.AuditEventTargetTypeRoute,
+			TargetName: routeRecord.Host,
+			SpaceGUID:  routeRecord.SpaceGUID,
+			Data: map[string]string{
+				"app_guid":     destination.AppGUID,
+				"process_type": destination.ProcessType,
+			},
+		})
+	}
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForRouteDestinations(responseRouteRecord, h.serverURL)), nil
 }
 
@@ -21,11 +21,13 @@ import (
 
 var _ = Describe("Route", func() {
 	var (
-		routeRepo        *fake.CFRouteRepository
-		domainRepo       *fake.CFDomainRepository
-		appRepo          *fake.CFAppRepository
-		spaceRepo        *fake.CFSpaceRepository
-		requestValidator *fake.RequestValidator
+		routeRepo          *fake.CFRouteRepository
+		domainRepo         *fake.CFDomainRepository
+		appRepo            *fake.CFAppRepository
+		spaceRepo          *fake.CFSpaceRepository
+		featureFlagChecker *fake.FeatureFlagChecker
+		auditRecorder      *fake.AuditEventRecorder
+		requestValidator   *fake.RequestValidator
 
 		requestMethod string
 		requestPath   string
@@ -64,6 +66,8 @@ var _ = Describe("Route", func() {
 			Name: "test-space-guid",
 		}, nil)
 
+		featureFlagChecker = new(fake.FeatureFlagChecker)
+		auditRecorder = new(fake.AuditEventRecorder)
 		requestValidator = new(fake.RequestValidator)
 
 		apiHandler := NewRoute(
@@ -72,6 +76,8 @@ var _ = Describe("Route", func() {
 			domainRepo,
 			appRepo,
 			spaceRepo,
+			featureFlagChecker,
+			auditRecorder,
 			requestValidator,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
@@ -319,6 +325,22 @@ var _ = Describe("Route", func() {
 			)))
 		})
 
+		When("the route_creation feature flag is disabled", func() {
+			BeforeEach(func() {
+				featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError(nil, repositories.FeatureFlagRouteCreation, ""))
+			})
+
+			It("returns a feature disabled error", func() {
+				Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualFlag := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualFlag).To(Equal(repositories.FeatureFlagRouteCreation))
+
+				expectErrorResponse(http.StatusForbidden, "CF-FeatureDisabled", "Feature Disabled: route_creation", 330002)
+				Expect(routeRepo.CreateRouteCallCount()).To(BeZero())
+			})
+		})
+
 		When("the request body is invalid JSON", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
@@ -626,6 +648,29 @@ var _ = Describe("Route", func() {
 			)))
 		})
 
+		It("records a route map audit event for each destination", func() {
+			Expect(auditRecorder.RecordAuditEventCallCount()).To(Equal(2))
+			_, actualAuthInfo, message := auditRecorder.RecordAuditEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.RecordAuditEventMessage{
+				Type:       "audit.route.map",
+				TargetGUID: "test-route-guid",
+				TargetType: "route",
+				TargetName: "test-route-host",
+				SpaceGUID:  "test-space-guid",
+				Data: map[string]string{
+					"app_guid":     "app-1-guid",
+					"process_type": "web",
+				},
+			}))
+
+			_, _, message = auditRecorder.RecordAuditEventArgsForCall(1)
+			Expect(message.Data).To(Equal(map[string]string{
+				"app_guid":     "app-2-guid",
+				"process_type": "queue",
+			}))
+		})
+
 		When("the route doesn't exist", func() {
 			BeforeEach(func() {
 				routeRepo.GetRouteReturns(repositories.RouteRecord{}, apierrors.NewNotFoundError(nil, repositories.RouteResourceType))
@@ -667,6 +712,10 @@ var _ = Describe("Route", func() {
 			It("responds with an Unknown Error", func() {
 				expectUnknownError()
 			})
+
+			It("does not record any audit event", func() {
+				Expect(auditRecorder.RecordAuditEventCallCount()).To(BeZero())
+			})
 		})
 
 		When("request is invalid", func() {
@@ -0,0 +1,230 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	SecurityGroupsPath             = "/v3/security_groups"
+	SecurityGroupPath              = "/v3/security_groups/{guid}"
+	SecurityGroupRunningSpacesPath = "/v3/security_groups/{guid}/relationships/running_spaces"
+	SecurityGroupRunningSpacePath  = "/v3/security_groups/{guid}/relationships/running_spaces/{space_guid}"
+	SecurityGroupStagingSpacesPath = "/v3/security_groups/{guid}/relationships/staging_spaces"
+	SecurityGroupStagingSpacePath  = "/v3/security_groups/{guid}/relationships/staging_spaces/{space_guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFSecurityGroupRepository . CFSecurityGroupRepository
+type CFSecurityGroupRepository interface {
+	CreateSecurityGroup(context.Context, authorization.Info, repositories.CreateSecurityGroupMessage) (repositories.SecurityGroupRecord, error)
+	GetSecurityGroup(context.Context, authorization.Info, string) (repositories.SecurityGroupRecord, error)
+	ListSecurityGroups(context.Context, authorization.Info, repositories.ListSecurityGroupsMessage) ([]repositories.SecurityGroupRecord, error)
+	UpdateSecurityGroup(context.Context, authorization.Info, repositories.UpdateSecurityGroupMessage) (repositories.SecurityGroupRecord, error)
+	DeleteSecurityGroup(context.Context, authorization.Info, string) error
+	BindSecurityGroup(context.Context, authorization.Info, repositories.BindSecurityGroupMessage) (repositories.SecurityGroupRecord, error)
+	UnbindSecurityGroup(context.Context, authorization.Info, repositories.UnbindSecurityGroupMessage) error
+}
+
+type SecurityGroup struct {
+	serverURL         url.URL
+	securityGroupRepo CFSecurityGroupRepository
+	requestValidator  RequestValidator
+}
+
+func NewSecurityGroup(
+	serverURL url.URL,
+	securityGroupRepo CFSecurityGroupRepository,
+	requestValidator RequestValidator,
+) *SecurityGroup {
+	return &SecurityGroup{
+		serverURL:         serverURL,
+		securityGroupRepo: securityGroupRepo,
+		requestValidator:  requestValidator,
+	}
+}
+
+func (h *SecurityGroup) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.create")
+
+	var payload payloads.SecurityGroupCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	securityGroup, err := h.securityGroupRepo.CreateSecurityGroup(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to create security group")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForSecurityGroup(securityGroup, h.serverURL)), nil
+}
+
+func (h *SecurityGroup) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.get")
+
+	securityGroupGUID := routing.URLParam(r, "guid")
+
+	securityGroup, err := h.securityGroupRepo.GetSecurityGroup(r.Context(), authInfo, securityGroupGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get security group", "guid", securityGroupGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSecurityGroup(securityGroup, h.serverURL)), nil
+}
+
+func (h *SecurityGroup) list(r *http.Request) (*routing.Response, error) { //nolint:dupl
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.list")
+
+	listFilter := new(payloads.SecurityGroupList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, listFilter); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	securityGroups, err := h.securityGroupRepo.ListSecurityGroups(r.Context(), authInfo, listFilter.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list security groups")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForSecurityGroup, securityGroups, h.serverURL, *r.URL)), nil
+}
+
+func (h *SecurityGroup) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.update")
+
+	securityGroupGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.SecurityGroupUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.securityGroupRepo.GetSecurityGroup(r.Context(), authInfo, securityGroupGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get security group", "guid", securityGroupGUID)
+	}
+
+	securityGroup, err := h.securityGroupRepo.UpdateSecurityGroup(r.Context(), authInfo, payload.ToMessage(securityGroupGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to update security group", "guid", securityGroupGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSecurityGroup(securityGroup, h.serverURL)), nil
+}
+
+func (h *SecurityGroup) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.delete")
+
+	securityGroupGUID := routing.URLParam(r, "guid")
+
+	err := h.securityGroupRepo.DeleteSecurityGroup(r.Context(), authInfo, securityGroupGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to delete security group", "guid", securityGroupGUID)
+	}
+
+	return routing.NewResponse(http.StatusAccepted).WithHeader(
+		"Location",
+		presenter.JobURLForRedirects(securityGroupGUID, presenter.SecurityGroupDeleteOperation, h.serverURL),
+	), nil
+}
+
+func (h *SecurityGroup) bindRunning(r *http.Request) (*routing.Response, error) {
+	return h.bind(r, repositories.SecurityGroupRunningWorkload)
+}
+
+func (h *SecurityGroup) bindStaging(r *http.Request) (*routing.Response, error) {
+	return h.bind(r, repositories.SecurityGroupStagingWorkload)
+}
+
+func (h *SecurityGroup) bind(r *http.Request, workload string) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.bind")
+
+	securityGroupGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.SecurityGroupBind
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.securityGroupRepo.GetSecurityGroup(r.Context(), authInfo, securityGroupGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get security group", "guid", securityGroupGUID)
+	}
+
+	securityGroup, err := h.securityGroupRepo.BindSecurityGroup(r.Context(), authInfo, payload.ToMessage(securityGroupGUID, workload))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to bind security group", "guid", securityGroupGUID, "workload", workload)
+	}
+
+	spaceGUIDs := securityGroup.RunningSpaceGUIDs
+	if workload == repositories.SecurityGroupStagingWorkload {
+		spaceGUIDs = securityGroup.StagingSpaceGUIDs
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSecurityGroupSpaces(spaceGUIDs, securityGroupGUID, workload, h.serverURL)), nil
+}
+
+func (h *SecurityGroup) unbindRunning(r *http.Request) (*routing.Response, error) {
+	return h.unbind(r, repositories.SecurityGroupRunningWorkload)
+}
+
+func (h *SecurityGroup) unbindStaging(r *http.Request) (*routing.Response, error) {
+	return h.unbind(r, repositories.SecurityGroupStagingWorkload)
+}
+
+func (h *SecurityGroup) unbind(r *http.Request, workload string) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.security-group.unbind")
+
+	securityGroupGUID := routing.URLParam(r, "guid")
+	spaceGUID := routing.URLParam(r, "space_guid")
+
+	_, err := h.securityGroupRepo.GetSecurityGroup(r.Context(), authInfo, securityGroupGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get security group", "guid", securityGroupGUID)
+	}
+
+	err = h.securityGroupRepo.UnbindSecurityGroup(r.Context(), authInfo, repositories.UnbindSecurityGroupMessage{
+		GUID:      securityGroupGUID,
+		SpaceGUID: spaceGUID,
+		Workload:  workload,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to unbind security group", "guid", securityGroupGUID, "spaceGUID", spaceGUID, "workload", workload)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *SecurityGroup) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *SecurityGroup) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "POST", Pattern: SecurityGroupsPath, Handler: h.create},
+		{Method: "GET", Pattern: SecurityGroupsPath, Handler: h.list},
+		{Method: "GET", Pattern: SecurityGroupPath, Handler: h.get},
+		{Method: "PATCH", Pattern: SecurityGroupPath, Handler: h.update},
+		{Method: "DELETE", Pattern: SecurityGroupPath, Handler: h.delete},
+		{Method: "POST", Pattern: SecurityGroupRunningSpacesPath, Handler: h.bindRunning},
+		{Method: "DELETE", Pattern: SecurityGroupRunningSpacePath, Handler: h.unbindRunning},
+		{Method: "POST", Pattern: SecurityGroupStagingSpacesPath, Handler: h.bindStaging},
+		{Method: "DELETE", Pattern: SecurityGroupStagingSpacePath, Handler: h.unbindStaging},
+	}
+}
@@ -0,0 +1,400 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("SecurityGroup", func() {
+	var (
+		apiHandler        *handlers.SecurityGroup
+		securityGroupRepo *fake.CFSecurityGroupRepository
+		requestValidator  *fake.RequestValidator
+		req               *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		securityGroupRepo = new(fake.CFSecurityGroupRepository)
+		securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{
+			GUID: "sg-guid",
+			Name: "my-security-group",
+		}, nil)
+
+		apiHandler = handlers.NewSecurityGroup(
+			*serverURL,
+			securityGroupRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/security_groups", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SecurityGroupCreate{
+				Name:            "my-security-group",
+				GloballyEnabled: payloads.SecurityGroupWorkloads{Running: true},
+				Rules: []payloads.SecurityGroupRule{
+					{Protocol: "tcp", Destination: "10.0.0.1", Ports: "443"},
+				},
+				Relationships: &payloads.SecurityGroupRelationships{
+					RunningSpaces: payloads.ToManyRelationship{Data: []payloads.RelationshipData{{GUID: "space-guid"}}},
+				},
+			})
+
+			securityGroupRepo.CreateSecurityGroupReturns(repositories.SecurityGroupRecord{
+				GUID: "sg-guid",
+				Name: "my-security-group",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/security_groups", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates the security group", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(securityGroupRepo.CreateSecurityGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, createMessage := securityGroupRepo.CreateSecurityGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(createMessage).To(Equal(repositories.CreateSecurityGroupMessage{
+				Name:              "my-security-group",
+				Rules:             []repositories.SecurityGroupRule{{Protocol: "tcp", Destination: "10.0.0.1", Ports: "443"}},
+				GloballyEnabled:   repositories.SecurityGroupWorkloads{Running: true},
+				RunningSpaceGUIDs: []string{"space-guid"},
+				StagingSpaceGUIDs: []string{},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "sg-guid"),
+				MatchJSONPath("$.name", "my-security-group"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/security_groups/sg-guid"),
+			)))
+		})
+
+		When("decoding the payload fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("creating the security group fails", func() {
+			BeforeEach(func() {
+				securityGroupRepo.CreateSecurityGroupReturns(repositories.SecurityGroupRecord{}, errors.New("create-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/security_groups/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/security_groups/sg-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the security group", func() {
+			Expect(securityGroupRepo.GetSecurityGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := securityGroupRepo.GetSecurityGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("sg-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "sg-guid"),
+				MatchJSONPath("$.name", "my-security-group"),
+			)))
+		})
+
+		When("the user is not allowed to see the security group", func() {
+			BeforeEach(func() {
+				securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewForbiddenError(nil, repositories.SecurityGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SecurityGroupResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/security_groups", func() {
+		BeforeEach(func() {
+			securityGroupRepo.ListSecurityGroupsReturns([]repositories.SecurityGroupRecord{
+				{GUID: "sg-1"},
+				{GUID: "sg-2"},
+			}, nil)
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.SecurityGroupList{
+				Names:                  "n1,n2",
+				GloballyEnabledRunning: tools.PtrTo(true),
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/security_groups?names=n1,n2&globally_enabled_running=true", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the security groups", func() {
+			Expect(securityGroupRepo.ListSecurityGroupsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := securityGroupRepo.ListSecurityGroupsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Names).To(ConsistOf("n1", "n2"))
+			Expect(message.GloballyEnabledRunning).To(PointTo(BeTrue()))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "sg-1"),
+				MatchJSONPath("$.resources[1].guid", "sg-2"),
+			)))
+		})
+
+		When("listing the security groups fails", func() {
+			BeforeEach(func() {
+				securityGroupRepo.ListSecurityGroupsReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("PATCH /v3/security_groups/:guid", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SecurityGroupUpdate{
+				Name: tools.PtrTo("new-name"),
+			})
+
+			securityGroupRepo.UpdateSecurityGroupReturns(repositories.SecurityGroupRecord{
+				GUID: "sg-guid",
+				Name: "new-name",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/security_groups/sg-guid", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates the security group", func() {
+			Expect(securityGroupRepo.UpdateSecurityGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := securityGroupRepo.UpdateSecurityGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.GUID).To(Equal("sg-guid"))
+			Expect(message.Name).To(PointTo(Equal("new-name")))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.name", "new-name")))
+		})
+
+		When("the security group does not exist", func() {
+			BeforeEach(func() {
+				securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewNotFoundError(nil, repositories.SecurityGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SecurityGroupResourceType)
+				Expect(securityGroupRepo.UpdateSecurityGroupCallCount()).To(BeZero())
+			})
+		})
+
+		When("updating the security group fails", func() {
+			BeforeEach(func() {
+				securityGroupRepo.UpdateSecurityGroupReturns(repositories.SecurityGroupRecord{}, errors.New("update-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/security_groups/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/security_groups/sg-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the security group", func() {
+			Expect(securityGroupRepo.DeleteSecurityGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := securityGroupRepo.DeleteSecurityGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("sg-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/security_group.delete~sg-guid"))
+		})
+
+		When("deleting the security group fails", func() {
+			BeforeEach(func() {
+				securityGroupRepo.DeleteSecurityGroupReturns(errors.New("delete-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /v3/security_groups/:guid/relationships/running_spaces", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SecurityGroupBind{
+				Data: []payloads.RelationshipData{{GUID: "space-1"}},
+			})
+			securityGroupRepo.BindSecurityGroupReturns(repositories.SecurityGroupRecord{
+				GUID:              "sg-guid",
+				RunningSpaceGUIDs: []string{"space-1", "space-2"},
+				StagingSpaceGUIDs: []string{"space-3"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/security_groups/sg-guid/relationships/running_spaces", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("binds the security group to the spaces for running apps", func() {
+			Expect(securityGroupRepo.BindSecurityGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := securityGroupRepo.BindSecurityGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.BindSecurityGroupMessage{
+				GUID:       "sg-guid",
+				SpaceGUIDs: []string{"space-1"},
+				Workload:   repositories.SecurityGroupRunningWorkload,
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[*].guid", ConsistOf("space-1", "space-2")),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/security_groups/sg-guid/relationships/running_spaces"),
+			)))
+		})
+
+		When("the security group does not exist", func() {
+			BeforeEach(func() {
+				securityGroupRepo.GetSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewNotFoundError(nil, repositories.SecurityGroupResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SecurityGroupResourceType)
+				Expect(securityGroupRepo.BindSecurityGroupCallCount()).To(BeZero())
+			})
+		})
+
+		When("binding the security group fails", func() {
+			BeforeEach(func() {
+				securityGroupRepo.BindSecurityGroupReturns(repositories.SecurityGroupRecord{}, apierrors.NewUnprocessableEntityError(nil, "no such space"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("no such space")
+			})
+		})
+	})
+
+	Describe("POST /v3/security_groups/:guid/relationships/staging_spaces", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SecurityGroupBind{
+				Data: []payloads.RelationshipData{{GUID: "space-3"}},
+			})
+			securityGroupRepo.BindSecurityGroupReturns(repositories.SecurityGroupRecord{
+				GUID:              "sg-guid",
+				RunningSpaceGUIDs: []string{"space-1", "space-2"},
+				StagingSpaceGUIDs: []string{"space-3"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/security_groups/sg-guid/relationships/staging_spaces", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("binds the security group to the spaces for staging", func() {
+			Expect(securityGroupRepo.BindSecurityGroupCallCount()).To(Equal(1))
+			_, _, message := securityGroupRepo.BindSecurityGroupArgsForCall(0)
+			Expect(message.Workload).To(Equal(repositories.SecurityGroupStagingWorkload))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[*].guid", ConsistOf("space-3")),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/security_groups/sg-guid/relationships/staging_spaces"),
+			)))
+		})
+	})
+
+	Describe("DELETE /v3/security_groups/:guid/relationships/running_spaces/:space_guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/security_groups/sg-guid/relationships/running_spaces/space-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("unbinds the security group from the space for running apps", func() {
+			Expect(securityGroupRepo.UnbindSecurityGroupCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := securityGroupRepo.UnbindSecurityGroupArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UnbindSecurityGroupMessage{
+				GUID:      "sg-guid",
+				SpaceGUID: "space-guid",
+				Workload:  repositories.SecurityGroupRunningWorkload,
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the space is not bound", func() {
+			BeforeEach(func() {
+				securityGroupRepo.UnbindSecurityGroupReturns(apierrors.NewUnprocessableEntityError(nil, "not bound"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("not bound")
+			})
+		})
+	})
+
+	Describe("DELETE /v3/security_groups/:guid/relationships/staging_spaces/:space_guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/security_groups/sg-guid/relationships/staging_spaces/space-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("unbinds the security group from the space for staging", func() {
+			Expect(securityGroupRepo.UnbindSecurityGroupCallCount()).To(Equal(1))
+			_, _, message := securityGroupRepo.UnbindSecurityGroupArgsForCall(0)
+			Expect(message.Workload).To(Equal(repositories.SecurityGroupStagingWorkload))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+	})
+})
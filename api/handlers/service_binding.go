@@ -13,17 +13,20 @@ import (
 	"code.cloudfoundry.org/korifi/api/presenter"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 )
 
 const (
-	ServiceBindingsPath = "/v3/service_credential_bindings"
-	ServiceBindingPath  = "/v3/service_credential_bindings/{guid}"
+	ServiceBindingsPath       = "/v3/service_credential_bindings"
+	ServiceBindingPath        = "/v3/service_credential_bindings/{guid}"
+	ServiceBindingDetailsPath = "/v3/service_credential_bindings/{guid}/details"
 )
 
 type ServiceBinding struct {
 	appRepo             CFAppRepository
 	serviceBindingRepo  CFServiceBindingRepository
 	serviceInstanceRepo CFServiceInstanceRepository
+	auditRecorder       AuditEventRecorder
 	serverURL           url.URL
 	requestValidator    RequestValidator
 }
@@ -34,14 +37,16 @@ type CFServiceBindingRepository interface {
 	DeleteServiceBinding(context.Context, authorization.Info, string) error
 	ListServiceBindings(context.Context, authorization.Info, repositories.ListServiceBindingsMessage) ([]repositories.ServiceBindingRecord, error)
 	GetServiceBinding(context.Context, authorization.Info, string) (repositories.ServiceBindingRecord, error)
+	GetServiceBindingDetails(context.Context, authorization.Info, string) (repositories.ServiceBindingDetailsRecord, error)
 	UpdateServiceBinding(context.Context, authorization.Info, repositories.UpdateServiceBindingMessage) (repositories.ServiceBindingRecord, error)
 }
 
-func NewServiceBinding(serverURL url.URL, serviceBindingRepo CFServiceBindingRepository, appRepo CFAppRepository, serviceInstanceRepo CFServiceInstanceRepository, requestValidator RequestValidator) *ServiceBinding {
+func NewServiceBinding(serverURL url.URL, serviceBindingRepo CFServiceBindingRepository, appRepo CFAppRepository, serviceInstanceRepo CFServiceInstanceRepository, auditRecorder AuditEventRecorder, requestValidator RequestValidator) *ServiceBinding {
 	return &ServiceBinding{
 		appRepo:             appRepo,
 		serviceInstanceRepo: serviceInstanceRepo,
 		serviceBindingRepo:  serviceBindingRepo,
+		auditRecorder:       auditRecorder,
 		serverURL:           serverURL,
 		requestValidator:    requestValidator,
 	}
@@ -56,17 +61,26 @@ func (h *ServiceBinding) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
 	}
 
-	app, err := h.appRepo.GetApp(r.Context(), authInfo, payload.Relationships.App.Data.GUID)
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, payload.Relationships.ServiceInstance.Data.GUID)
 	if err != nil {
-		return nil, apierrors.LogAndReturn(logger, err, "failed to get "+repositories.AppResourceType)
+		return nil, apierrors.LogAndReturn(logger, err, "failed to get "+repositories.ServiceInstanceResourceType)
 	}
 
-	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, payload.Relationships.ServiceInstance.Data.GUID)
+	if payload.Type == repositories.ServiceBindingTypeKey {
+		serviceBinding, err := h.serviceBindingRepo.CreateServiceBinding(r.Context(), authInfo, payload.ToMessage(serviceInstance.SpaceGUID))
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "failed to create service key", "ServiceInstance GUID", serviceInstance.GUID)
+		}
+
+		return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForServiceBinding(serviceBinding, h.serverURL)), nil
+	}
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, payload.Relationships.App.Data.GUID)
 	if err != nil {
-		return nil, apierrors.LogAndReturn(logger, err, "failed to get "+repositories.ServiceInstanceResourceType)
+		return nil, apierrors.LogAndReturn(logger, err, "failed to get "+repositories.AppResourceType)
 	}
 
-	if app.SpaceGUID != serviceInstance.SpaceGUID {
+	if app.SpaceGUID != serviceInstance.SpaceGUID && !repositories.NewSet(serviceInstance.SharedSpaceGUIDs...).Includes(app.SpaceGUID) {
 		return nil, apierrors.LogAndReturn(
 			logger,
 			apierrors.NewUnprocessableEntityError(nil, "The service instance and the app are in different spaces"),
@@ -75,11 +89,29 @@ func (h *ServiceBinding) create(r *http.Request) (*routing.Response, error) {
 		)
 	}
 
-	serviceBinding, err := h.serviceBindingRepo.CreateServiceBinding(r.Context(), authInfo, payload.ToMessage(app.SpaceGUID))
+	createMessage := payload.ToMessage(app.SpaceGUID)
+	createMessage.ServiceInstanceSpaceGUID = serviceInstance.SpaceGUID
+	serviceBinding, err := h.serviceBindingRepo.CreateServiceBinding(r.Context(), authInfo, createMessage)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to create ServiceBinding", "App GUID", app.GUID, "ServiceInstance GUID", serviceInstance.GUID)
 	}
 
+	bindingName := ""
+	if serviceBinding.Name != nil {
+		bindingName = *serviceBinding.Name
+	}
+	recordAuditEvent(r.Context(), logger, h.auditRecorder, authInfo, repositories.RecordAuditEventMessage{
+		Type:       korifiv1alpha1.AuditEventTypeServiceBindingCreate,
+		TargetGUID: serviceBinding.GUID,
+		TargetType: korifiv1alpha1.AuditEventTargetTypeServiceBinding,
+		TargetName: bindingName,
+		SpaceGUID:  serviceBinding.SpaceGUID,
+		Data: map[string]string{
+			"app_guid":              app.GUID,
+			"service_instance_guid": serviceInstance.GUID,
+		},
+	})
+
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForServiceBinding(serviceBinding, h.serverURL)), nil
 }
 
@@ -116,7 +148,9 @@ func (h *ServiceBinding) list(r *http.Request) (*routing.Response, error) {
 		listAppsMessage := repositories.ListAppsMessage{}
 
 		for _, serviceBinding := range serviceBindingList {
-			listAppsMessage.Guids = append(listAppsMessage.Guids, serviceBinding.AppGUID)
+			if serviceBinding.AppGUID != "" {
+				listAppsMessage.Guids = append(listAppsMessage.Guids, serviceBinding.AppGUID)
+			}
 		}
 
 		appRecords, err = h.appRepo.ListApps(r.Context(), authInfo, listAppsMessage)
@@ -165,6 +199,19 @@ func (h *ServiceBinding) get(r *http.Request) (*routing.Response, error) {
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceBinding(serviceBinding, h.serverURL)), nil
 }
 
+func (h *ServiceBinding) getDetails(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-binding.get-details")
+
+	serviceBindingGUID := routing.URLParam(r, "guid")
+
+	details, err := h.serviceBindingRepo.GetServiceBindingDetails(r.Context(), authInfo, serviceBindingGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "Error getting service binding details in repository")
+	}
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceBindingDetails(details)), nil
+}
+
 func (h *ServiceBinding) UnauthenticatedRoutes() []routing.Route {
 	return nil
 }
@@ -176,5 +223,6 @@ func (h *ServiceBinding) AuthenticatedRoutes() []routing.Route {
 		{Method: "DELETE", Pattern: ServiceBindingPath, Handler: h.delete},
 		{Method: "PATCH", Pattern: ServiceBindingPath, Handler: h.update},
 		{Method: "GET", Pattern: ServiceBindingPath, Handler: h.get},
+		{Method: "GET", Pattern: ServiceBindingDetailsPath, Handler: h.getDetails},
 	}
 }
@@ -15,6 +15,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 )
 
 var _ = Describe("ServiceBinding", func() {
@@ -26,6 +27,7 @@ var _ = Describe("ServiceBinding", func() {
 		serviceBindingRepo  *fake.CFServiceBindingRepository
 		appRepo             *fake.CFAppRepository
 		serviceInstanceRepo *fake.CFServiceInstanceRepository
+		auditRecorder       *fake.AuditEventRecorder
 		requestValidator    *fake.RequestValidator
 	)
 
@@ -47,6 +49,7 @@ var _ = Describe("ServiceBinding", func() {
 			SpaceGUID: "space-guid",
 		}, nil)
 
+		auditRecorder = new(fake.AuditEventRecorder)
 		requestValidator = new(fake.RequestValidator)
 
 		apiHandler := NewServiceBinding(
@@ -54,6 +57,7 @@ var _ = Describe("ServiceBinding", func() {
 			serviceBindingRepo,
 			appRepo,
 			serviceInstanceRepo,
+			auditRecorder,
 			requestValidator,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
@@ -75,7 +79,9 @@ var _ = Describe("ServiceBinding", func() {
 			requestBody = "the-json-body"
 
 			serviceBindingRepo.CreateServiceBindingReturns(repositories.ServiceBindingRecord{
-				GUID: "service-binding-guid",
+				GUID:      "service-binding-guid",
+				Name:      tools.PtrTo("my-binding"),
+				SpaceGUID: "space-guid",
 			}, nil)
 
 			payload = payloads.ServiceBindingCreate{
@@ -126,6 +132,23 @@ var _ = Describe("ServiceBinding", func() {
 			)))
 		})
 
+		It("records a service binding create audit event", func() {
+			Expect(auditRecorder.RecordAuditEventCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := auditRecorder.RecordAuditEventArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.RecordAuditEventMessage{
+				Type:       "audit.service_binding.create",
+				TargetGUID: "service-binding-guid",
+				TargetType: "service_binding",
+				TargetName: "my-binding",
+				SpaceGUID:  "space-guid",
+				Data: map[string]string{
+					"app_guid":              "app-guid",
+					"service_instance_guid": "service-instance-guid",
+				},
+			}))
+		})
+
 		When("the request body is invalid json", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
@@ -148,6 +171,25 @@ var _ = Describe("ServiceBinding", func() {
 			})
 		})
 
+		When("the ServiceInstance is shared with the space of the App", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID:             "service-instance-guid",
+					SpaceGUID:        "another-space-guid",
+					SharedSpaceGUIDs: []string{"space-guid"},
+				}, nil)
+			})
+
+			It("creates the binding in the space of the App", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+
+				Expect(serviceBindingRepo.CreateServiceBindingCallCount()).To(Equal(1))
+				_, _, createServiceBindingMessage := serviceBindingRepo.CreateServiceBindingArgsForCall(0)
+				Expect(createServiceBindingMessage.SpaceGUID).To(Equal("space-guid"))
+				Expect(createServiceBindingMessage.ServiceInstanceSpaceGUID).To(Equal("another-space-guid"))
+			})
+		})
+
 		When("getting the App errors", func() {
 			BeforeEach(func() {
 				appRepo.GetAppReturns(repositories.AppRecord{}, errors.New("boom"))
@@ -178,6 +220,91 @@ var _ = Describe("ServiceBinding", func() {
 			It("returns an error", func() {
 				expectUnknownError()
 			})
+
+			It("does not record an audit event", func() {
+				Expect(auditRecorder.RecordAuditEventCallCount()).To(BeZero())
+			})
+		})
+
+		When("the binding is a service key", func() {
+			BeforeEach(func() {
+				payload.Type = "key"
+				payload.Name = tools.PtrTo("my-key")
+				payload.Relationships.App = nil
+			})
+
+			It("creates the service key in the space of the service instance", func() {
+				Expect(appRepo.GetAppCallCount()).To(Equal(0))
+
+				Expect(serviceBindingRepo.CreateServiceBindingCallCount()).To(Equal(1))
+				_, actualAuthInfo, createServiceBindingMessage := serviceBindingRepo.CreateServiceBindingArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(createServiceBindingMessage.Type).To(Equal("key"))
+				Expect(createServiceBindingMessage.Name).To(PointTo(Equal("my-key")))
+				Expect(createServiceBindingMessage.AppGUID).To(BeEmpty())
+				Expect(createServiceBindingMessage.ServiceInstanceGUID).To(Equal("service-instance-guid"))
+				Expect(createServiceBindingMessage.SpaceGUID).To(Equal("space-guid"))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.guid", "service-binding-guid")))
+			})
+
+			It("does not record an audit event", func() {
+				Expect(auditRecorder.RecordAuditEventCallCount()).To(BeZero())
+			})
+
+			When("creating the service key errors", func() {
+				BeforeEach(func() {
+					serviceBindingRepo.CreateServiceBindingReturns(repositories.ServiceBindingRecord{}, errors.New("boom"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+		})
+	})
+
+	Describe("GET /v3/service_credential_bindings/{guid}/details", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/service_credential_bindings/service-binding-guid/details"
+			requestBody = ""
+
+			serviceBindingRepo.GetServiceBindingDetailsReturns(repositories.ServiceBindingDetailsRecord{
+				Credentials: map[string]any{"username": "admin"},
+			}, nil)
+		})
+
+		It("returns the service binding credentials", func() {
+			Expect(serviceBindingRepo.GetServiceBindingDetailsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceBindingRepo.GetServiceBindingDetailsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("service-binding-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.credentials.username", "admin")))
+		})
+
+		When("the service binding repo returns an error", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.GetServiceBindingDetailsReturns(repositories.ServiceBindingDetailsRecord{}, errors.New("get-details-error"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("the user is not authorized", func() {
+			BeforeEach(func() {
+				serviceBindingRepo.GetServiceBindingDetailsReturns(repositories.ServiceBindingDetailsRecord{}, apierrors.NewForbiddenError(nil, "CFServiceBinding"))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError("CFServiceBinding")
+			})
 		})
 	})
 
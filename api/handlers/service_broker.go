@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	ServiceBrokersPath = "/v3/service_brokers"
+	ServiceBrokerPath  = "/v3/service_brokers/{guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFServiceBrokerRepository . CFServiceBrokerRepository
+type CFServiceBrokerRepository interface {
+	CreateServiceBroker(context.Context, authorization.Info, repositories.CreateServiceBrokerMessage) (repositories.ServiceBrokerRecord, error)
+	GetServiceBroker(context.Context, authorization.Info, string) (repositories.ServiceBrokerRecord, error)
+	ListServiceBrokers(context.Context, authorization.Info, repositories.ListServiceBrokersMessage) ([]repositories.ServiceBrokerRecord, error)
+	UpdateServiceBroker(context.Context, authorization.Info, repositories.UpdateServiceBrokerMessage) (repositories.ServiceBrokerRecord, error)
+	DeleteServiceBroker(context.Context, authorization.Info, string) error
+}
+
+type ServiceBroker struct {
+	serverURL           url.URL
+	serviceBrokerRepo   CFServiceBrokerRepository
+	servicePlanRepo     CFServicePlanRepository
+	serviceInstanceRepo CFServiceInstanceRepository
+	requestValidator    RequestValidator
+}
+
+func NewServiceBroker(
+	serverURL url.URL,
+	serviceBrokerRepo CFServiceBrokerRepository,
+	servicePlanRepo CFServicePlanRepository,
+	serviceInstanceRepo CFServiceInstanceRepository,
+	requestValidator RequestValidator,
+) *ServiceBroker {
+	return &ServiceBroker{
+		serverURL:           serverURL,
+		serviceBrokerRepo:   serviceBrokerRepo,
+		servicePlanRepo:     servicePlanRepo,
+		serviceInstanceRepo: serviceInstanceRepo,
+		requestValidator:    requestValidator,
+	}
+}
+
+func (h *ServiceBroker) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-broker.create")
+
+	var payload payloads.ServiceBrokerCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	message, err := payload.ToMessage()
+	if err != nil {
+		apierr := apierrors.NewUnprocessableEntityError(err, err.Error())
+		return nil, apierrors.LogAndReturn(logger, apierr, apierr.Detail())
+	}
+
+	serviceBroker, err := h.serviceBrokerRepo.CreateServiceBroker(r.Context(), authInfo, message)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to create service broker")
+	}
+
+	return routing.NewResponse(http.StatusAccepted).WithHeader(
+		"Location",
+		presenter.JobURLForRedirects(serviceBroker.GUID, presenter.ServiceBrokerCreateOperation, h.serverURL),
+	), nil
+}
+
+func (h *ServiceBroker) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-broker.get")
+
+	serviceBrokerGUID := routing.URLParam(r, "guid")
+
+	serviceBroker, err := h.serviceBrokerRepo.GetServiceBroker(r.Context(), authInfo, serviceBrokerGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service broker", "guid", serviceBrokerGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceBroker(serviceBroker, h.serverURL)), nil
+}
+
+func (h *ServiceBroker) list(r *http.Request) (*routing.Response, error) { //nolint:dupl
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-broker.list")
+
+	listFilter := new(payloads.ServiceBrokerList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, listFilter); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	serviceBrokers, err := h.serviceBrokerRepo.ListServiceBrokers(r.Context(), authInfo, listFilter.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list service brokers")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForServiceBroker, serviceBrokers, h.serverURL, *r.URL)), nil
+}
+
+func (h *ServiceBroker) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-broker.update")
+
+	serviceBrokerGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.ServiceBrokerUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.serviceBrokerRepo.GetServiceBroker(r.Context(), authInfo, serviceBrokerGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service broker", "guid", serviceBrokerGUID)
+	}
+
+	serviceBroker, err := h.serviceBrokerRepo.UpdateServiceBroker(r.Context(), authInfo, payload.ToMessage(serviceBrokerGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to update service broker", "guid", serviceBrokerGUID)
+	}
+
+	if payload.OnlyMetadataChanged() {
+		return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceBroker(serviceBroker, h.serverURL)), nil
+	}
+
+	return routing.NewResponse(http.StatusAccepted).WithHeader(
+		"Location",
+		presenter.JobURLForRedirects(serviceBroker.GUID, presenter.ServiceBrokerUpdateOperation, h.serverURL),
+	), nil
+}
+
+func (h *ServiceBroker) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-broker.delete")
+
+	serviceBrokerGUID := routing.URLParam(r, "guid")
+
+	if err := h.ensureNoServiceInstances(r.Context(), authInfo, serviceBrokerGUID); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "cannot delete service broker", "guid", serviceBrokerGUID)
+	}
+
+	err := h.serviceBrokerRepo.DeleteServiceBroker(r.Context(), authInfo, serviceBrokerGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to delete service broker", "guid", serviceBrokerGUID)
+	}
+
+	return routing.NewResponse(http.StatusAccepted).WithHeader(
+		"Location",
+		presenter.JobURLForRedirects(serviceBrokerGUID, presenter.ServiceBrokerDeleteOperation, h.serverURL),
+	), nil
+}
+
+func (h *ServiceBroker) ensureNoServiceInstances(ctx context.Context, authInfo authorization.Info, serviceBrokerGUID string) error {
+	plans, err := h.servicePlanRepo.ListServicePlans(ctx, authInfo, repositories.ListServicePlanMessage{
+		ServiceBrokerGUIDs: []string{serviceBrokerGUID},
+	})
+	if err != nil {
+		return err
+	}
+	if len(plans) == 0 {
+		return nil
+	}
+
+	planGUIDs := []string{}
+	for _, plan := range plans {
+		planGUIDs = append(planGUIDs, plan.GUID)
+	}
+
+	serviceInstances, err := h.serviceInstanceRepo.ListServiceInstances(ctx, authInfo, repositories.ListServiceInstanceMessage{
+		PlanGUIDs: planGUIDs,
+	})
+	if err != nil {
+		return err
+	}
+	if len(serviceInstances) == 0 {
+		return nil
+	}
+
+	names := []string{}
+	for _, serviceInstance := range serviceInstances {
+		names = append(names, serviceInstance.Name)
+	}
+
+	return apierrors.NewUnprocessableEntityError(nil, "Can not remove brokers that have associated service instances: "+strings.Join(names, ", "))
+}
+
+func (h *ServiceBroker) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *ServiceBroker) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "POST", Pattern: ServiceBrokersPath, Handler: h.create},
+		{Method: "GET", Pattern: ServiceBrokersPath, Handler: h.list},
+		{Method: "GET", Pattern: ServiceBrokerPath, Handler: h.get},
+		{Method: "PATCH", Pattern: ServiceBrokerPath, Handler: h.update},
+		{Method: "DELETE", Pattern: ServiceBrokerPath, Handler: h.delete},
+	}
+}
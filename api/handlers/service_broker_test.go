@@ -0,0 +1,359 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("ServiceBroker", func() {
+	var (
+		apiHandler          *handlers.ServiceBroker
+		serviceBrokerRepo   *fake.CFServiceBrokerRepository
+		servicePlanRepo     *fake.CFServicePlanRepository
+		serviceInstanceRepo *fake.CFServiceInstanceRepository
+		requestValidator    *fake.RequestValidator
+		req                 *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		serviceBrokerRepo = new(fake.CFServiceBrokerRepository)
+		servicePlanRepo = new(fake.CFServicePlanRepository)
+		serviceInstanceRepo = new(fake.CFServiceInstanceRepository)
+		serviceBrokerRepo.GetServiceBrokerReturns(repositories.ServiceBrokerRecord{
+			GUID: "broker-guid",
+			Name: "my-broker",
+			URL:  "https://my.broker",
+		}, nil)
+
+		apiHandler = handlers.NewServiceBroker(
+			*serverURL,
+			serviceBrokerRepo,
+			servicePlanRepo,
+			serviceInstanceRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/service_brokers", func() {
+		var payload *payloads.ServiceBrokerCreate
+
+		BeforeEach(func() {
+			payload = &payloads.ServiceBrokerCreate{
+				Name: "my-broker",
+				URL:  "https://my.broker",
+				Authentication: &payloads.BrokerAuthentication{
+					Type: "basic",
+					Credentials: payloads.BrokerCredentials{
+						Username: "user",
+						Password: "pass",
+					},
+				},
+			}
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(payload)
+
+			serviceBrokerRepo.CreateServiceBrokerReturns(repositories.ServiceBrokerRecord{
+				GUID: "broker-guid",
+				Name: "my-broker",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/service_brokers", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates the service broker", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(serviceBrokerRepo.CreateServiceBrokerCallCount()).To(Equal(1))
+			_, actualAuthInfo, createMessage := serviceBrokerRepo.CreateServiceBrokerArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(createMessage.Name).To(Equal("my-broker"))
+			Expect(createMessage.URL).To(Equal("https://my.broker"))
+			Expect(createMessage.Credentials).To(Equal(repositories.BasicAuthentication{
+				Username: "user",
+				Password: "pass",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/service_broker.create~broker-guid"))
+		})
+
+		When("decoding the payload fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("the broker is space scoped", func() {
+			BeforeEach(func() {
+				payload.Relationships = &payloads.ServiceBrokerRelationships{
+					Space: &payloads.Relationship{Data: &payloads.RelationshipData{GUID: "space-guid"}},
+				}
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("space-scoped service brokers are not supported")
+			})
+		})
+
+		When("creating the service broker fails", func() {
+			BeforeEach(func() {
+				serviceBrokerRepo.CreateServiceBrokerReturns(repositories.ServiceBrokerRecord{}, errors.New("create-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/service_brokers/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/service_brokers/broker-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the service broker", func() {
+			Expect(serviceBrokerRepo.GetServiceBrokerCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceBrokerRepo.GetServiceBrokerArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("broker-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "broker-guid"),
+				MatchJSONPath("$.name", "my-broker"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/service_brokers/broker-guid"),
+			)))
+		})
+
+		When("the user is not allowed to see the broker", func() {
+			BeforeEach(func() {
+				serviceBrokerRepo.GetServiceBrokerReturns(repositories.ServiceBrokerRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceBrokerResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServiceBrokerResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/service_brokers", func() {
+		BeforeEach(func() {
+			serviceBrokerRepo.ListServiceBrokersReturns([]repositories.ServiceBrokerRecord{
+				{GUID: "broker-1"},
+				{GUID: "broker-2"},
+			}, nil)
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.ServiceBrokerList{
+				Names: "b1,b2",
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/service_brokers?names=b1,b2", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the service brokers", func() {
+			Expect(serviceBrokerRepo.ListServiceBrokersCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := serviceBrokerRepo.ListServiceBrokersArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Names).To(ConsistOf("b1", "b2"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "broker-1"),
+				MatchJSONPath("$.resources[1].guid", "broker-2"),
+			)))
+		})
+
+		When("listing the brokers fails", func() {
+			BeforeEach(func() {
+				serviceBrokerRepo.ListServiceBrokersReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("PATCH /v3/service_brokers/:guid", func() {
+		var payload *payloads.ServiceBrokerUpdate
+
+		BeforeEach(func() {
+			payload = &payloads.ServiceBrokerUpdate{
+				Name: tools.PtrTo("new-name"),
+			}
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(payload)
+
+			serviceBrokerRepo.UpdateServiceBrokerReturns(repositories.ServiceBrokerRecord{
+				GUID: "broker-guid",
+				Name: "new-name",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/service_brokers/broker-guid", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates the broker and returns a job", func() {
+			Expect(serviceBrokerRepo.UpdateServiceBrokerCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := serviceBrokerRepo.UpdateServiceBrokerArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.GUID).To(Equal("broker-guid"))
+			Expect(message.Name).To(PointTo(Equal("new-name")))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/service_broker.update~broker-guid"))
+		})
+
+		When("only the metadata is updated", func() {
+			BeforeEach(func() {
+				payload.Name = nil
+				payload.Metadata = payloads.MetadataPatch{
+					Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+				}
+			})
+
+			It("returns the updated broker", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.guid", "broker-guid")))
+			})
+		})
+
+		When("the broker does not exist", func() {
+			BeforeEach(func() {
+				serviceBrokerRepo.GetServiceBrokerReturns(repositories.ServiceBrokerRecord{}, apierrors.NewNotFoundError(nil, repositories.ServiceBrokerResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServiceBrokerResourceType)
+				Expect(serviceBrokerRepo.UpdateServiceBrokerCallCount()).To(BeZero())
+			})
+		})
+
+		When("updating the broker fails", func() {
+			BeforeEach(func() {
+				serviceBrokerRepo.UpdateServiceBrokerReturns(repositories.ServiceBrokerRecord{}, errors.New("update-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/service_brokers/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/service_brokers/broker-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the broker", func() {
+			Expect(serviceBrokerRepo.DeleteServiceBrokerCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceBrokerRepo.DeleteServiceBrokerArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("broker-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/service_broker.delete~broker-guid"))
+		})
+
+		When("the broker has plans", func() {
+			BeforeEach(func() {
+				servicePlanRepo.ListServicePlansReturns([]repositories.ServicePlanRecord{
+					{GUID: "plan-1"},
+					{GUID: "plan-2"},
+				}, nil)
+			})
+
+			It("looks up the service instances of the broker plans", func() {
+				Expect(servicePlanRepo.ListServicePlansCallCount()).To(Equal(1))
+				_, _, actualPlanMessage := servicePlanRepo.ListServicePlansArgsForCall(0)
+				Expect(actualPlanMessage.ServiceBrokerGUIDs).To(ConsistOf("broker-guid"))
+
+				Expect(serviceInstanceRepo.ListServiceInstancesCallCount()).To(Equal(1))
+				_, _, actualInstanceMessage := serviceInstanceRepo.ListServiceInstancesArgsForCall(0)
+				Expect(actualInstanceMessage.PlanGUIDs).To(ConsistOf("plan-1", "plan-2"))
+
+				Expect(serviceBrokerRepo.DeleteServiceBrokerCallCount()).To(Equal(1))
+			})
+
+			When("service instances of the broker plans exist", func() {
+				BeforeEach(func() {
+					serviceInstanceRepo.ListServiceInstancesReturns([]repositories.ServiceInstanceRecord{
+						{Name: "instance-1"},
+						{Name: "instance-2"},
+					}, nil)
+				})
+
+				It("refuses to delete the broker", func() {
+					expectUnprocessableEntityError("Can not remove brokers that have associated service instances: instance-1, instance-2")
+					Expect(serviceBrokerRepo.DeleteServiceBrokerCallCount()).To(Equal(0))
+				})
+			})
+
+			When("listing the service instances fails", func() {
+				BeforeEach(func() {
+					serviceInstanceRepo.ListServiceInstancesReturns(nil, errors.New("list-err"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+					Expect(serviceBrokerRepo.DeleteServiceBrokerCallCount()).To(Equal(0))
+				})
+			})
+		})
+
+		When("listing the broker plans fails", func() {
+			BeforeEach(func() {
+				servicePlanRepo.ListServicePlansReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+				Expect(serviceBrokerRepo.DeleteServiceBrokerCallCount()).To(Equal(0))
+			})
+		})
+
+		When("deleting the broker fails", func() {
+			BeforeEach(func() {
+				serviceBrokerRepo.DeleteServiceBrokerReturns(errors.New("delete-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"fmt"
 	"net/http"
 	"net/url"
 	"sort"
@@ -15,13 +16,18 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
 	"github.com/go-logr/logr"
 )
 
 const (
-	ServiceInstancesPath = "/v3/service_instances"
-	ServiceInstancePath  = "/v3/service_instances/{guid}"
+	ServiceInstancesPath            = "/v3/service_instances"
+	ServiceInstancePath             = "/v3/service_instances/{guid}"
+	ServiceInstanceCredentialsPath  = "/v3/service_instances/{guid}/credentials"
+	ServiceInstanceParametersPath   = "/v3/service_instances/{guid}/parameters"
+	ServiceInstanceSharedSpacesPath = "/v3/service_instances/{guid}/relationships/shared_spaces"
+	ServiceInstanceSharedSpacePath  = "/v3/service_instances/{guid}/relationships/shared_spaces/{space_guid}"
 )
 
 //counterfeiter:generate -o fake -fake-name CFServiceInstanceRepository . CFServiceInstanceRepository
@@ -30,13 +36,18 @@ type CFServiceInstanceRepository interface {
 	PatchServiceInstance(context.Context, authorization.Info, repositories.PatchServiceInstanceMessage) (repositories.ServiceInstanceRecord, error)
 	ListServiceInstances(context.Context, authorization.Info, repositories.ListServiceInstanceMessage) ([]repositories.ServiceInstanceRecord, error)
 	GetServiceInstance(context.Context, authorization.Info, string) (repositories.ServiceInstanceRecord, error)
+	GetServiceInstanceCredentials(context.Context, authorization.Info, string) (map[string]string, error)
+	GetServiceInstanceParameters(context.Context, authorization.Info, string) (map[string]any, error)
 	DeleteServiceInstance(context.Context, authorization.Info, repositories.DeleteServiceInstanceMessage) error
+	ShareServiceInstance(context.Context, authorization.Info, repositories.ShareServiceInstanceMessage) (repositories.ServiceInstanceRecord, error)
+	UnshareServiceInstance(context.Context, authorization.Info, repositories.UnshareServiceInstanceMessage) error
 }
 
 type ServiceInstance struct {
 	serverURL           url.URL
 	serviceInstanceRepo CFServiceInstanceRepository
 	spaceRepo           CFSpaceRepository
+	featureFlagChecker  FeatureFlagChecker
 	requestValidator    RequestValidator
 }
 
@@ -44,12 +55,14 @@ func NewServiceInstance(
 	serverURL url.URL,
 	serviceInstanceRepo CFServiceInstanceRepository,
 	spaceRepo CFSpaceRepository,
+	featureFlagChecker FeatureFlagChecker,
 	requestValidator RequestValidator,
 ) *ServiceInstance {
 	return &ServiceInstance{
 		serverURL:           serverURL,
 		serviceInstanceRepo: serviceInstanceRepo,
 		spaceRepo:           spaceRepo,
+		featureFlagChecker:  featureFlagChecker,
 		requestValidator:    requestValidator,
 	}
 }
@@ -64,6 +77,10 @@ func (h *ServiceInstance) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
 	}
 
+	if err := h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, repositories.FeatureFlagServiceInstanceCreation); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "service instance creation is disabled")
+	}
+
 	spaceGUID := payload.Relationships.Space.Data.GUID
 	_, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID)
 	if err != nil {
@@ -80,6 +97,13 @@ func (h *ServiceInstance) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "Failed to create service instance", "Service Instance Name", serviceInstanceRecord.Name)
 	}
 
+	if serviceInstanceRecord.Type == korifiv1alpha1.ManagedType {
+		return routing.NewResponse(http.StatusAccepted).WithHeader(
+			"Location",
+			presenter.JobURLForRedirects(serviceInstanceRecord.GUID, presenter.ServiceInstanceCreateOperation, h.serverURL),
+		), nil
+	}
+
 	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForServiceInstance(serviceInstanceRecord, h.serverURL)), nil
 }
 
@@ -99,15 +123,92 @@ func (h *ServiceInstance) patch(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance")
 	}
 
+	if err = validatePatchForType(payload, serviceInstance.Type); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "invalid service instance patch", "guid", serviceInstanceGUID)
+	}
+
+	if serviceInstance.LastOperation != nil && serviceInstance.LastOperation.State == korifiv1alpha1.InProgressLastOperationState {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewServiceInstanceOperationInProgressError(nil, serviceInstance.Name),
+			"service instance operation in progress", "guid", serviceInstanceGUID,
+		)
+	}
+
 	patchMessage := payload.ToServiceInstancePatchMessage(serviceInstance.SpaceGUID, serviceInstance.GUID)
-	serviceInstance, err = h.serviceInstanceRepo.PatchServiceInstance(r.Context(), authInfo, patchMessage)
+	patchedServiceInstance, err := h.serviceInstanceRepo.PatchServiceInstance(r.Context(), authInfo, patchMessage)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to patch service instance")
 	}
 
+	if serviceInstance.Type == korifiv1alpha1.ManagedType && payload.ChangesServiceInstance() {
+		return routing.NewResponse(http.StatusAccepted).WithHeader(
+			"Location",
+			presenter.JobURLForRedirects(serviceInstance.GUID, presenter.ServiceInstanceUpdateOperation, h.serverURL),
+		), nil
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceInstance(patchedServiceInstance, h.serverURL)), nil
+}
+
+func validatePatchForType(payload payloads.ServiceInstancePatch, instanceType string) error {
+	if instanceType == korifiv1alpha1.ManagedType && payload.Credentials != nil {
+		return apierrors.NewUnprocessableEntityError(nil, "Credentials can only be set for user-provided service instances.")
+	}
+
+	if instanceType == korifiv1alpha1.ManagedType && (payload.SyslogDrainURL != nil || payload.RouteServiceURL != nil) {
+		return apierrors.NewUnprocessableEntityError(nil, "Syslog drain and route service URLs can only be set for user-provided service instances.")
+	}
+
+	if instanceType != korifiv1alpha1.ManagedType && (payload.Parameters != nil || payload.Relationships != nil) {
+		return apierrors.NewUnprocessableEntityError(nil, "Parameters and service plans can only be set for managed service instances.")
+	}
+
+	return nil
+}
+
+func (h *ServiceInstance) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.get")
+
+	serviceInstanceGUID := routing.URLParam(r, "guid")
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance", "guid", serviceInstanceGUID)
+	}
+
 	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceInstance(serviceInstance, h.serverURL)), nil
 }
 
+func (h *ServiceInstance) getCredentials(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.get-credentials")
+
+	serviceInstanceGUID := routing.URLParam(r, "guid")
+
+	credentials, err := h.serviceInstanceRepo.GetServiceInstanceCredentials(r.Context(), authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance credentials", "guid", serviceInstanceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(credentials), nil
+}
+
+func (h *ServiceInstance) getParameters(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.get-parameters")
+
+	serviceInstanceGUID := routing.URLParam(r, "guid")
+
+	parameters, err := h.serviceInstanceRepo.GetServiceInstanceParameters(r.Context(), authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance parameters", "guid", serviceInstanceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(parameters), nil
+}
+
 func (h *ServiceInstance) list(r *http.Request) (*routing.Response, error) {
 	authInfo, _ := authorization.InfoFromContext(r.Context())
 	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.list")
@@ -166,6 +267,105 @@ func (h *ServiceInstance) delete(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "error when deleting service instance", "guid", serviceInstanceGUID)
 	}
 
+	if serviceInstance.Type == korifiv1alpha1.ManagedType {
+		return routing.NewResponse(http.StatusAccepted).WithHeader(
+			"Location",
+			presenter.JobURLForRedirects(serviceInstanceGUID, presenter.ServiceInstanceDeleteOperation, h.serverURL),
+		), nil
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *ServiceInstance) share(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.share")
+
+	var payload payloads.ServiceInstanceShare
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	if err := h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, repositories.FeatureFlagServiceInstanceSharing); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "service instance sharing is disabled")
+	}
+
+	serviceInstanceGUID := routing.URLParam(r, "guid")
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance", "guid", serviceInstanceGUID)
+	}
+
+	if serviceInstance.Type != korifiv1alpha1.ManagedType {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "User-provided services cannot be shared."),
+			"cannot share user-provided service instance", "guid", serviceInstanceGUID,
+		)
+	}
+
+	for _, data := range payload.Data {
+		if data.GUID == serviceInstance.SpaceGUID {
+			return nil, apierrors.LogAndReturn(
+				logger,
+				apierrors.NewUnprocessableEntityError(nil, "Service instances cannot be shared into the space where they were created."),
+				"cannot share service instance into its own space", "guid", serviceInstanceGUID,
+			)
+		}
+	}
+
+	serviceInstance, err = h.serviceInstanceRepo.ShareServiceInstance(r.Context(), authInfo, payload.ToMessage(serviceInstance.GUID, serviceInstance.SpaceGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to share service instance", "guid", serviceInstanceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceInstanceSharedSpaces(serviceInstance, h.serverURL)), nil
+}
+
+func (h *ServiceInstance) listSharedSpaces(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.list-shared-spaces")
+
+	serviceInstanceGUID := routing.URLParam(r, "guid")
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance", "guid", serviceInstanceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceInstanceSharedSpaces(serviceInstance, h.serverURL)), nil
+}
+
+func (h *ServiceInstance) unshare(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-instance.unshare")
+
+	serviceInstanceGUID := routing.URLParam(r, "guid")
+	spaceGUID := routing.URLParam(r, "space_guid")
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, serviceInstanceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service instance", "guid", serviceInstanceGUID)
+	}
+
+	if !repositories.NewSet(serviceInstance.SharedSpaceGUIDs...).Includes(spaceGUID) {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Unable to unshare service instance from space %s. Ensure the space exists and the service instance has been shared to this space.", spaceGUID)),
+			"service instance is not shared with space", "guid", serviceInstanceGUID, "spaceGUID", spaceGUID,
+		)
+	}
+
+	err = h.serviceInstanceRepo.UnshareServiceInstance(r.Context(), authInfo, repositories.UnshareServiceInstanceMessage{
+		GUID:            serviceInstance.GUID,
+		SpaceGUID:       serviceInstance.SpaceGUID,
+		TargetSpaceGUID: spaceGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to unshare service instance", "guid", serviceInstanceGUID, "spaceGUID", spaceGUID)
+	}
+
 	return routing.NewResponse(http.StatusNoContent), nil
 }
 
@@ -178,6 +378,12 @@ func (h *ServiceInstance) AuthenticatedRoutes() []routing.Route {
 		{Method: "POST", Pattern: ServiceInstancesPath, Handler: h.create},
 		{Method: "PATCH", Pattern: ServiceInstancePath, Handler: h.patch},
 		{Method: "GET", Pattern: ServiceInstancesPath, Handler: h.list},
+		{Method: "GET", Pattern: ServiceInstancePath, Handler: h.get},
+		{Method: "GET", Pattern: ServiceInstanceCredentialsPath, Handler: h.getCredentials},
+		{Method: "GET", Pattern: ServiceInstanceParametersPath, Handler: h.getParameters},
 		{Method: "DELETE", Pattern: ServiceInstancePath, Handler: h.delete},
+		{Method: "POST", Pattern: ServiceInstanceSharedSpacesPath, Handler: h.share},
+		{Method: "GET", Pattern: ServiceInstanceSharedSpacesPath, Handler: h.listSharedSpaces},
+		{Method: "DELETE", Pattern: ServiceInstanceSharedSpacePath, Handler: h.unshare},
 	}
 }
@@ -17,12 +17,14 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
 )
 
 var _ = Describe("ServiceInstance", func() {
 	var (
 		serviceInstanceRepo *fake.CFServiceInstanceRepository
 		spaceRepo           *fake.CFSpaceRepository
+		featureFlagChecker  *fake.FeatureFlagChecker
 		requestValidator    *fake.RequestValidator
 
 		reqMethod string
@@ -38,12 +40,14 @@ var _ = Describe("ServiceInstance", func() {
 
 		spaceRepo = new(fake.CFSpaceRepository)
 
+		featureFlagChecker = new(fake.FeatureFlagChecker)
 		requestValidator = new(fake.RequestValidator)
 
 		apiHandler := NewServiceInstance(
 			*serverURL,
 			serviceInstanceRepo,
 			spaceRepo,
+			featureFlagChecker,
 			requestValidator,
 		)
 		routerBuilder.LoadRoutes(apiHandler)
@@ -102,6 +106,37 @@ var _ = Describe("ServiceInstance", func() {
 			)))
 		})
 
+		When("the service_instance_creation feature flag is disabled", func() {
+			BeforeEach(func() {
+				featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError(nil, repositories.FeatureFlagServiceInstanceCreation, ""))
+			})
+
+			It("returns a feature disabled error", func() {
+				Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualFlag := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualFlag).To(Equal(repositories.FeatureFlagServiceInstanceCreation))
+
+				expectErrorResponse(http.StatusForbidden, "CF-FeatureDisabled", "Feature Disabled: service_instance_creation", 330002)
+				Expect(serviceInstanceRepo.CreateServiceInstanceCallCount()).To(BeZero())
+			})
+		})
+
+		When("the service instance is managed", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.CreateServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID: "service-instance-guid",
+					Type: "managed",
+				}, nil)
+			})
+
+			It("returns a job to track the provisioning", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+				Expect(rr).To(HaveHTTPHeaderWithValue("Location",
+					ContainSubstring("/v3/jobs/service_instance.create~service-instance-guid")))
+			})
+		})
+
 		When("the request body is not valid", func() {
 			BeforeEach(func() {
 				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "nope"))
@@ -355,6 +390,129 @@ var _ = Describe("ServiceInstance", func() {
 			})
 		})
 
+		When("the service instance is managed", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID:      "service-instance-guid",
+					SpaceGUID: "space-guid",
+					Type:      "managed",
+				}, nil)
+
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceInstancePatch{
+					Parameters: &map[string]any{"foo": "bar"},
+					Relationships: &payloads.ServiceInstancePatchRelationships{
+						ServicePlan: &payloads.Relationship{
+							Data: &payloads.RelationshipData{GUID: "plan-guid"},
+						},
+					},
+				})
+			})
+
+			When("an operation on the service instance is in progress", func() {
+				BeforeEach(func() {
+					serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+						Name:      "my-instance",
+						GUID:      "service-instance-guid",
+						SpaceGUID: "space-guid",
+						Type:      "managed",
+						LastOperation: &repositories.ServiceInstanceLastOperation{
+							Type:  "create",
+							State: "in progress",
+						},
+					}, nil)
+				})
+
+				It("returns a conflict error", func() {
+					expectErrorResponse(http.StatusConflict, "CF-AsyncServiceInstanceOperationInProgress", "An operation for service instance my-instance is in progress.", 60016)
+					Expect(serviceInstanceRepo.PatchServiceInstanceCallCount()).To(Equal(0))
+				})
+			})
+
+			It("passes the plan and parameters to the repository", func() {
+				Expect(serviceInstanceRepo.PatchServiceInstanceCallCount()).To(Equal(1))
+				_, _, patchMessage := serviceInstanceRepo.PatchServiceInstanceArgsForCall(0)
+				Expect(patchMessage.PlanGUID).To(PointTo(Equal("plan-guid")))
+				Expect(patchMessage.Parameters).To(PointTo(Equal(map[string]any{"foo": "bar"})))
+			})
+
+			It("returns a job to track the update", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+				Expect(rr).To(HaveHTTPHeaderWithValue("Location",
+					ContainSubstring("/v3/jobs/service_instance.update~service-instance-guid")))
+			})
+
+			When("only metadata is patched", func() {
+				BeforeEach(func() {
+					requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceInstancePatch{
+						Metadata: payloads.MetadataPatch{
+							Labels: map[string]*string{"lab2": tools.PtrTo("lab_val2")},
+						},
+					})
+				})
+
+				It("returns the service instance", func() {
+					Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+					Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.guid", "service-instance-guid")))
+				})
+			})
+
+			When("credentials are patched", func() {
+				BeforeEach(func() {
+					requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceInstancePatch{
+						Credentials: &map[string]string{"foo": "bar"},
+					})
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Credentials can only be set for user-provided service instances.")
+					Expect(serviceInstanceRepo.PatchServiceInstanceCallCount()).To(BeZero())
+				})
+			})
+
+			When("the syslog drain URL is patched", func() {
+				BeforeEach(func() {
+					requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceInstancePatch{
+						SyslogDrainURL: tools.PtrTo("syslog://logs.example.com:514"),
+					})
+				})
+
+				It("returns an unprocessable entity error", func() {
+					expectUnprocessableEntityError("Syslog drain and route service URLs can only be set for user-provided service instances.")
+					Expect(serviceInstanceRepo.PatchServiceInstanceCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		When("the syslog drain and route service URLs are patched on a user-provided service instance", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceInstancePatch{
+					SyslogDrainURL:  tools.PtrTo("syslog://logs.example.com:514"),
+					RouteServiceURL: tools.PtrTo("https://route-service.example.com"),
+				})
+			})
+
+			It("passes them to the repository", func() {
+				Expect(serviceInstanceRepo.PatchServiceInstanceCallCount()).To(Equal(1))
+				_, _, patchMessage := serviceInstanceRepo.PatchServiceInstanceArgsForCall(0)
+				Expect(patchMessage.SyslogDrainURL).To(PointTo(Equal("syslog://logs.example.com:514")))
+				Expect(patchMessage.RouteServiceURL).To(PointTo(Equal("https://route-service.example.com")))
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			})
+		})
+
+		When("parameters are patched on a user-provided service instance", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceInstancePatch{
+					Parameters: &map[string]any{"foo": "bar"},
+				})
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("Parameters and service plans can only be set for managed service instances.")
+				Expect(serviceInstanceRepo.PatchServiceInstanceCallCount()).To(BeZero())
+			})
+		})
+
 		When("patching the service instances fails", func() {
 			BeforeEach(func() {
 				serviceInstanceRepo.PatchServiceInstanceReturns(repositories.ServiceInstanceRecord{}, errors.New("oops"))
@@ -366,6 +524,123 @@ var _ = Describe("ServiceInstance", func() {
 		})
 	})
 
+	Describe("GET /v3/service_instances/:guid", func() {
+		BeforeEach(func() {
+			reqPath += "/service-instance-guid"
+		})
+
+		It("returns the service instance", func() {
+			Expect(serviceInstanceRepo.GetServiceInstanceCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceInstanceRepo.GetServiceInstanceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("service-instance-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "service-instance-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/service_instances/service-instance-guid"),
+			)))
+		})
+
+		When("getting the service instance fails with forbidden", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(
+					repositories.ServiceInstanceRecord{},
+					apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType),
+				)
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("Service Instance")
+			})
+		})
+
+		When("getting the service instance fails", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, errors.New("boom"))
+			})
+
+			It("returns 500 Internal Server Error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/service_instances/:guid/credentials", func() {
+		BeforeEach(func() {
+			reqPath += "/service-instance-guid/credentials"
+			serviceInstanceRepo.GetServiceInstanceCredentialsReturns(map[string]string{"username": "bob"}, nil)
+		})
+
+		It("returns the credentials", func() {
+			Expect(serviceInstanceRepo.GetServiceInstanceCredentialsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceInstanceRepo.GetServiceInstanceCredentialsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("service-instance-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(`{"username": "bob"}`)))
+		})
+
+		When("getting the credentials fails with forbidden", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceCredentialsReturns(nil, apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType))
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("Service Instance")
+			})
+		})
+
+		When("getting the credentials fails", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceCredentialsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns 500 Internal Server Error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/service_instances/:guid/parameters", func() {
+		BeforeEach(func() {
+			reqPath += "/service-instance-guid/parameters"
+			serviceInstanceRepo.GetServiceInstanceParametersReturns(map[string]any{"size": "xs"}, nil)
+		})
+
+		It("returns the parameters", func() {
+			Expect(serviceInstanceRepo.GetServiceInstanceParametersCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceInstanceRepo.GetServiceInstanceParametersArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("service-instance-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSON(`{"size": "xs"}`)))
+		})
+
+		When("getting the parameters fails with not found", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceParametersReturns(nil, apierrors.NewNotFoundError(nil, repositories.ServiceInstanceResourceType))
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("Service Instance")
+			})
+		})
+
+		When("getting the parameters fails", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceParametersReturns(nil, errors.New("boom"))
+			})
+
+			It("returns 500 Internal Server Error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
 	Describe("DELETE /v3/service_instances/:guid", func() {
 		BeforeEach(func() {
 			reqPath += "/service-instance-guid"
@@ -382,6 +657,23 @@ var _ = Describe("ServiceInstance", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
 		})
 
+		When("the service instance is managed", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID:      "service-instance-guid",
+					SpaceGUID: "space-guid",
+					Type:      "managed",
+				}, nil)
+			})
+
+			It("returns a job to track the deprovisioning", func() {
+				Expect(serviceInstanceRepo.DeleteServiceInstanceCallCount()).To(Equal(1))
+				Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+				Expect(rr).To(HaveHTTPHeaderWithValue("Location",
+					ContainSubstring("/v3/jobs/service_instance.delete~service-instance-guid")))
+			})
+		})
+
 		When("getting the service instance fails with not found", func() {
 			BeforeEach(func() {
 				serviceInstanceRepo.GetServiceInstanceReturns(
@@ -428,4 +720,217 @@ var _ = Describe("ServiceInstance", func() {
 			})
 		})
 	})
+
+	Describe("POST /v3/service_instances/:guid/relationships/shared_spaces", func() {
+		BeforeEach(func() {
+			reqPath += "/service-instance-guid/relationships/shared_spaces"
+			reqMethod = http.MethodPost
+
+			serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+				GUID:      "service-instance-guid",
+				SpaceGUID: "space-guid",
+				Type:      "managed",
+			}, nil)
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceInstanceShare{
+				Data: []payloads.RelationshipData{{GUID: "target-space-guid"}},
+			})
+			serviceInstanceRepo.ShareServiceInstanceReturns(repositories.ServiceInstanceRecord{
+				GUID:             "service-instance-guid",
+				SpaceGUID:        "space-guid",
+				SharedSpaceGUIDs: []string{"other-space-guid", "target-space-guid"},
+			}, nil)
+		})
+
+		It("shares the service instance", func() {
+			Expect(serviceInstanceRepo.ShareServiceInstanceCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := serviceInstanceRepo.ShareServiceInstanceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ShareServiceInstanceMessage{
+				GUID:             "service-instance-guid",
+				SpaceGUID:        "space-guid",
+				TargetSpaceGUIDs: []string{"target-space-guid"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[*].guid", ConsistOf("other-space-guid", "target-space-guid")),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/service_instances/service-instance-guid/relationships/shared_spaces"),
+			)))
+		})
+
+		When("the service_instance_sharing feature flag is disabled", func() {
+			BeforeEach(func() {
+				featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError(nil, repositories.FeatureFlagServiceInstanceSharing, ""))
+			})
+
+			It("returns a feature disabled error", func() {
+				Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualFlag := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualFlag).To(Equal(repositories.FeatureFlagServiceInstanceSharing))
+
+				expectErrorResponse(http.StatusForbidden, "CF-FeatureDisabled", "Feature Disabled: service_instance_sharing", 330002)
+				Expect(serviceInstanceRepo.ShareServiceInstanceCallCount()).To(BeZero())
+			})
+		})
+
+		When("the request body is not valid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "nope"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("nope")
+			})
+		})
+
+		When("getting the service instance fails with forbidden", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(
+					repositories.ServiceInstanceRecord{},
+					apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType),
+				)
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("Service Instance")
+			})
+		})
+
+		When("the service instance is user-provided", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID:      "service-instance-guid",
+					SpaceGUID: "space-guid",
+					Type:      "user-provided",
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(serviceInstanceRepo.ShareServiceInstanceCallCount()).To(BeZero())
+				expectUnprocessableEntityError("User-provided services cannot be shared.")
+			})
+		})
+
+		When("sharing into the space of the service instance", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServiceInstanceShare{
+					Data: []payloads.RelationshipData{{GUID: "space-guid"}},
+				})
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(serviceInstanceRepo.ShareServiceInstanceCallCount()).To(BeZero())
+				expectUnprocessableEntityError("Service instances cannot be shared into the space where they were created.")
+			})
+		})
+
+		When("sharing the service instance fails", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.ShareServiceInstanceReturns(repositories.ServiceInstanceRecord{}, errors.New("boom"))
+			})
+
+			It("returns 500 Internal Server Error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/service_instances/:guid/relationships/shared_spaces", func() {
+		BeforeEach(func() {
+			reqPath += "/service-instance-guid/relationships/shared_spaces"
+
+			serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+				GUID:             "service-instance-guid",
+				SpaceGUID:        "space-guid",
+				SharedSpaceGUIDs: []string{"target-space-guid"},
+			}, nil)
+		})
+
+		It("returns the shared spaces", func() {
+			Expect(serviceInstanceRepo.GetServiceInstanceCallCount()).To(Equal(1))
+			_, actualAuthInfo, guid := serviceInstanceRepo.GetServiceInstanceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(guid).To(Equal("service-instance-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.data[*].guid", ConsistOf("target-space-guid"))))
+		})
+
+		When("getting the service instance fails with forbidden", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(
+					repositories.ServiceInstanceRecord{},
+					apierrors.NewForbiddenError(nil, repositories.ServiceInstanceResourceType),
+				)
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("Service Instance")
+			})
+		})
+	})
+
+	Describe("DELETE /v3/service_instances/:guid/relationships/shared_spaces/:space_guid", func() {
+		BeforeEach(func() {
+			reqPath += "/service-instance-guid/relationships/shared_spaces/target-space-guid"
+			reqMethod = http.MethodDelete
+
+			serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+				GUID:             "service-instance-guid",
+				SpaceGUID:        "space-guid",
+				SharedSpaceGUIDs: []string{"target-space-guid"},
+			}, nil)
+		})
+
+		It("unshares the service instance", func() {
+			Expect(serviceInstanceRepo.UnshareServiceInstanceCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := serviceInstanceRepo.UnshareServiceInstanceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UnshareServiceInstanceMessage{
+				GUID:            "service-instance-guid",
+				SpaceGUID:       "space-guid",
+				TargetSpaceGUID: "target-space-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the service instance is not shared with the space", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID:      "service-instance-guid",
+					SpaceGUID: "space-guid",
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(serviceInstanceRepo.UnshareServiceInstanceCallCount()).To(BeZero())
+				expectUnprocessableEntityError("Unable to unshare service instance from space target-space-guid. Ensure the space exists and the service instance has been shared to this space.")
+			})
+		})
+
+		When("getting the service instance fails with not found", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(
+					repositories.ServiceInstanceRecord{},
+					apierrors.NewNotFoundError(nil, repositories.ServiceInstanceResourceType),
+				)
+			})
+
+			It("returns 404 Not Found", func() {
+				expectNotFoundError("Service Instance")
+			})
+		})
+
+		When("unsharing the service instance fails", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.UnshareServiceInstanceReturns(errors.New("boom"))
+			})
+
+			It("returns 500 Internal Server Error", func() {
+				expectUnknownError()
+			})
+		})
+	})
 })
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	ServiceOfferingsPath = "/v3/service_offerings"
+	ServiceOfferingPath  = "/v3/service_offerings/{guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFServiceOfferingRepository . CFServiceOfferingRepository
+type CFServiceOfferingRepository interface {
+	GetServiceOffering(context.Context, authorization.Info, string) (repositories.ServiceOfferingRecord, error)
+	ListServiceOfferings(context.Context, authorization.Info, repositories.ListServiceOfferingMessage) ([]repositories.ServiceOfferingRecord, error)
+}
+
+type ServiceOffering struct {
+	serverURL           url.URL
+	serviceOfferingRepo CFServiceOfferingRepository
+	requestValidator    RequestValidator
+}
+
+func NewServiceOffering(
+	serverURL url.URL,
+	serviceOfferingRepo CFServiceOfferingRepository,
+	requestValidator RequestValidator,
+) *ServiceOffering {
+	return &ServiceOffering{
+		serverURL:           serverURL,
+		serviceOfferingRepo: serviceOfferingRepo,
+		requestValidator:    requestValidator,
+	}
+}
+
+func (h *ServiceOffering) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-offering.get")
+
+	serviceOfferingGUID := routing.URLParam(r, "guid")
+
+	serviceOffering, err := h.serviceOfferingRepo.GetServiceOffering(r.Context(), authInfo, serviceOfferingGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service offering", "guid", serviceOfferingGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceOffering(serviceOffering, h.serverURL)), nil
+}
+
+func (h *ServiceOffering) list(r *http.Request) (*routing.Response, error) { //nolint:dupl
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-offering.list")
+
+	listFilter := new(payloads.ServiceOfferingList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, listFilter); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	serviceOfferings, err := h.serviceOfferingRepo.ListServiceOfferings(r.Context(), authInfo, listFilter.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list service offerings")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForServiceOffering, serviceOfferings, h.serverURL, *r.URL)), nil
+}
+
+func (h *ServiceOffering) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *ServiceOffering) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: ServiceOfferingsPath, Handler: h.list},
+		{Method: "GET", Pattern: ServiceOfferingPath, Handler: h.get},
+	}
+}
@@ -0,0 +1,124 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ServiceOffering", func() {
+	var (
+		apiHandler          *handlers.ServiceOffering
+		serviceOfferingRepo *fake.CFServiceOfferingRepository
+		requestValidator    *fake.RequestValidator
+		req                 *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		serviceOfferingRepo = new(fake.CFServiceOfferingRepository)
+
+		apiHandler = handlers.NewServiceOffering(
+			*serverURL,
+			serviceOfferingRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/service_offerings/:guid", func() {
+		BeforeEach(func() {
+			serviceOfferingRepo.GetServiceOfferingReturns(repositories.ServiceOfferingRecord{
+				GUID:              "offering-guid",
+				Name:              "my-offering",
+				ServiceBrokerGUID: "broker-guid",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/service_offerings/offering-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the service offering", func() {
+			Expect(serviceOfferingRepo.GetServiceOfferingCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceOfferingRepo.GetServiceOfferingArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("offering-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "offering-guid"),
+				MatchJSONPath("$.name", "my-offering"),
+				MatchJSONPath("$.relationships.service_broker.data.guid", "broker-guid"),
+			)))
+		})
+
+		When("the offering is not visible to the user", func() {
+			BeforeEach(func() {
+				serviceOfferingRepo.GetServiceOfferingReturns(repositories.ServiceOfferingRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceOfferingResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServiceOfferingResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/service_offerings", func() {
+		BeforeEach(func() {
+			serviceOfferingRepo.ListServiceOfferingsReturns([]repositories.ServiceOfferingRecord{
+				{GUID: "offering-1"},
+				{GUID: "offering-2"},
+			}, nil)
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.ServiceOfferingList{
+				Names:              "o1,o2",
+				ServiceBrokerGUIDs: "b1",
+				SpaceGUIDs:         "s1",
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/service_offerings?names=o1,o2&service_broker_guids=b1&space_guids=s1", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the service offerings", func() {
+			Expect(serviceOfferingRepo.ListServiceOfferingsCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := serviceOfferingRepo.ListServiceOfferingsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Names).To(ConsistOf("o1", "o2"))
+			Expect(message.ServiceBrokerGUIDs).To(ConsistOf("b1"))
+			Expect(message.SpaceGUIDs).To(ConsistOf("s1"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "offering-1"),
+				MatchJSONPath("$.resources[1].guid", "offering-2"),
+			)))
+		})
+
+		When("listing the offerings fails", func() {
+			BeforeEach(func() {
+				serviceOfferingRepo.ListServiceOfferingsReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
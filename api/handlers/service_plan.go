@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	ServicePlansPath                = "/v3/service_plans"
+	ServicePlanPath                 = "/v3/service_plans/{guid}"
+	ServicePlanVisibilityPath       = "/v3/service_plans/{guid}/visibility"
+	ServicePlanVisibilityOrgPath    = "/v3/service_plans/{guid}/visibility/{org_guid}"
+	includeServiceOfferingParameter = "service_offering"
+)
+
+//counterfeiter:generate -o fake -fake-name CFServicePlanRepository . CFServicePlanRepository
+type CFServicePlanRepository interface {
+	GetServicePlan(context.Context, authorization.Info, string) (repositories.ServicePlanRecord, error)
+	ListServicePlans(context.Context, authorization.Info, repositories.ListServicePlanMessage) ([]repositories.ServicePlanRecord, error)
+	GetServicePlanVisibility(context.Context, authorization.Info, string) (repositories.ServicePlanVisibilityRecord, error)
+	UpdateServicePlanVisibility(context.Context, authorization.Info, repositories.UpdateServicePlanVisibilityMessage) (repositories.ServicePlanVisibilityRecord, error)
+	ApplyServicePlanVisibility(context.Context, authorization.Info, repositories.ApplyServicePlanVisibilityMessage) (repositories.ServicePlanVisibilityRecord, error)
+	DeleteServicePlanVisibility(context.Context, authorization.Info, repositories.DeleteServicePlanVisibilityMessage) error
+}
+
+type ServicePlan struct {
+	serverURL           url.URL
+	servicePlanRepo     CFServicePlanRepository
+	serviceOfferingRepo CFServiceOfferingRepository
+	requestValidator    RequestValidator
+}
+
+func NewServicePlan(
+	serverURL url.URL,
+	servicePlanRepo CFServicePlanRepository,
+	serviceOfferingRepo CFServiceOfferingRepository,
+	requestValidator RequestValidator,
+) *ServicePlan {
+	return &ServicePlan{
+		serverURL:           serverURL,
+		servicePlanRepo:     servicePlanRepo,
+		serviceOfferingRepo: serviceOfferingRepo,
+		requestValidator:    requestValidator,
+	}
+}
+
+func (h *ServicePlan) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-plan.get")
+
+	servicePlanGUID := routing.URLParam(r, "guid")
+
+	servicePlan, err := h.servicePlanRepo.GetServicePlan(r.Context(), authInfo, servicePlanGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service plan", "guid", servicePlanGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServicePlan(servicePlan, h.serverURL)), nil
+}
+
+func (h *ServicePlan) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-plan.list")
+
+	listFilter := new(payloads.ServicePlanList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, listFilter); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	servicePlans, err := h.servicePlanRepo.ListServicePlans(r.Context(), authInfo, listFilter.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list service plans")
+	}
+
+	var serviceOfferings []repositories.ServiceOfferingRecord
+	if listFilter.Include == includeServiceOfferingParameter && len(servicePlans) > 0 {
+		listOfferingsMessage := repositories.ListServiceOfferingMessage{}
+		for _, servicePlan := range servicePlans {
+			listOfferingsMessage.GUIDs = append(listOfferingsMessage.GUIDs, servicePlan.ServiceOfferingGUID)
+		}
+
+		serviceOfferings, err = h.serviceOfferingRepo.ListServiceOfferings(r.Context(), authInfo, listOfferingsMessage)
+		if err != nil {
+			return nil, apierrors.LogAndReturn(logger, err, "failed to list service offerings")
+		}
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServicePlanList(servicePlans, serviceOfferings, h.serverURL, *r.URL)), nil
+}
+
+func (h *ServicePlan) getVisibility(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-plan.get-visibility")
+
+	servicePlanGUID := routing.URLParam(r, "guid")
+
+	visibility, err := h.servicePlanRepo.GetServicePlanVisibility(r.Context(), authInfo, servicePlanGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get service plan visibility", "guid", servicePlanGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServicePlanVisibility(visibility)), nil
+}
+
+func (h *ServicePlan) updateVisibility(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-plan.update-visibility")
+
+	servicePlanGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.ServicePlanVisibilityUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	visibility, err := h.servicePlanRepo.UpdateServicePlanVisibility(r.Context(), authInfo, payload.ToMessage(servicePlanGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to update service plan visibility", "guid", servicePlanGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServicePlanVisibility(visibility)), nil
+}
+
+func (h *ServicePlan) applyVisibility(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-plan.apply-visibility")
+
+	servicePlanGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.ServicePlanVisibilityApply
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	visibility, err := h.servicePlanRepo.ApplyServicePlanVisibility(r.Context(), authInfo, payload.ToMessage(servicePlanGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to apply service plan visibility", "guid", servicePlanGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServicePlanVisibility(visibility)), nil
+}
+
+func (h *ServicePlan) deleteVisibility(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-plan.delete-visibility")
+
+	servicePlanGUID := routing.URLParam(r, "guid")
+	orgGUID := routing.URLParam(r, "org_guid")
+
+	err := h.servicePlanRepo.DeleteServicePlanVisibility(r.Context(), authInfo, repositories.DeleteServicePlanVisibilityMessage{
+		PlanGUID: servicePlanGUID,
+		OrgGUID:  orgGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to delete service plan visibility", "guid", servicePlanGUID, "orgGUID", orgGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *ServicePlan) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *ServicePlan) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: ServicePlansPath, Handler: h.list},
+		{Method: "GET", Pattern: ServicePlanPath, Handler: h.get},
+		{Method: "GET", Pattern: ServicePlanVisibilityPath, Handler: h.getVisibility},
+		{Method: "PATCH", Pattern: ServicePlanVisibilityPath, Handler: h.updateVisibility},
+		{Method: "POST", Pattern: ServicePlanVisibilityPath, Handler: h.applyVisibility},
+		{Method: "DELETE", Pattern: ServicePlanVisibilityOrgPath, Handler: h.deleteVisibility},
+	}
+}
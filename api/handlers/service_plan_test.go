@@ -0,0 +1,326 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ServicePlan", func() {
+	var (
+		apiHandler          *handlers.ServicePlan
+		servicePlanRepo     *fake.CFServicePlanRepository
+		serviceOfferingRepo *fake.CFServiceOfferingRepository
+		requestValidator    *fake.RequestValidator
+		req                 *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		servicePlanRepo = new(fake.CFServicePlanRepository)
+		serviceOfferingRepo = new(fake.CFServiceOfferingRepository)
+
+		apiHandler = handlers.NewServicePlan(
+			*serverURL,
+			servicePlanRepo,
+			serviceOfferingRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/service_plans/:guid", func() {
+		BeforeEach(func() {
+			servicePlanRepo.GetServicePlanReturns(repositories.ServicePlanRecord{
+				GUID:                "plan-guid",
+				Name:                "my-plan",
+				ServiceOfferingGUID: "offering-guid",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/service_plans/plan-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the service plan", func() {
+			Expect(servicePlanRepo.GetServicePlanCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := servicePlanRepo.GetServicePlanArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("plan-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "plan-guid"),
+				MatchJSONPath("$.relationships.service_offering.data.guid", "offering-guid"),
+			)))
+		})
+
+		When("the plan is not visible to the user", func() {
+			BeforeEach(func() {
+				servicePlanRepo.GetServicePlanReturns(repositories.ServicePlanRecord{}, apierrors.NewForbiddenError(nil, repositories.ServicePlanResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServicePlanResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/service_plans", func() {
+		var listFilter *payloads.ServicePlanList
+
+		BeforeEach(func() {
+			servicePlanRepo.ListServicePlansReturns([]repositories.ServicePlanRecord{
+				{GUID: "plan-1", ServiceOfferingGUID: "offering-1"},
+				{GUID: "plan-2", ServiceOfferingGUID: "offering-2"},
+			}, nil)
+
+			listFilter = &payloads.ServicePlanList{
+				Names:                "p1,p2",
+				ServiceOfferingGUIDs: "offering-1",
+				OrganizationGUIDs:    "org-1",
+			}
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(listFilter)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/service_plans?names=p1,p2", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the service plans", func() {
+			Expect(servicePlanRepo.ListServicePlansCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := servicePlanRepo.ListServicePlansArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Names).To(ConsistOf("p1", "p2"))
+			Expect(message.ServiceOfferingGUIDs).To(ConsistOf("offering-1"))
+			Expect(message.OrganizationGUIDs).To(ConsistOf("org-1"))
+
+			Expect(serviceOfferingRepo.ListServiceOfferingsCallCount()).To(BeZero())
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "plan-1"),
+				MatchJSONPath("$.resources[1].guid", "plan-2"),
+				Not(ContainSubstring("included")),
+			)))
+		})
+
+		When("the service offerings are included", func() {
+			BeforeEach(func() {
+				listFilter.Include = "service_offering"
+				serviceOfferingRepo.ListServiceOfferingsReturns([]repositories.ServiceOfferingRecord{
+					{GUID: "offering-1"},
+					{GUID: "offering-2"},
+				}, nil)
+			})
+
+			It("includes the offerings of the listed plans", func() {
+				Expect(serviceOfferingRepo.ListServiceOfferingsCallCount()).To(Equal(1))
+				_, actualAuthInfo, message := serviceOfferingRepo.ListServiceOfferingsArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(message.GUIDs).To(ConsistOf("offering-1", "offering-2"))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.included.service_offerings[0].guid", "offering-1"),
+					MatchJSONPath("$.included.service_offerings[1].guid", "offering-2"),
+				)))
+			})
+
+			When("listing the offerings fails", func() {
+				BeforeEach(func() {
+					serviceOfferingRepo.ListServiceOfferingsReturns(nil, errors.New("list-err"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+		})
+
+		When("listing the plans fails", func() {
+			BeforeEach(func() {
+				servicePlanRepo.ListServicePlansReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/service_plans/:guid/visibility", func() {
+		BeforeEach(func() {
+			servicePlanRepo.GetServicePlanVisibilityReturns(repositories.ServicePlanVisibilityRecord{
+				Type: "organization",
+				Organizations: []repositories.VisibilityOrganization{
+					{GUID: "org-guid", Name: "my-org"},
+				},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/service_plans/plan-guid/visibility", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the plan visibility", func() {
+			Expect(servicePlanRepo.GetServicePlanVisibilityCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := servicePlanRepo.GetServicePlanVisibilityArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("plan-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.type", "organization"),
+				MatchJSONPath("$.organizations[0].guid", "org-guid"),
+				MatchJSONPath("$.organizations[0].name", "my-org"),
+			)))
+		})
+
+		When("the plan is not visible to the user", func() {
+			BeforeEach(func() {
+				servicePlanRepo.GetServicePlanVisibilityReturns(repositories.ServicePlanVisibilityRecord{}, apierrors.NewForbiddenError(nil, repositories.ServicePlanResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServicePlanResourceType)
+			})
+		})
+	})
+
+	Describe("PATCH /v3/service_plans/:guid/visibility", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServicePlanVisibilityUpdate{
+				Type: "public",
+			})
+
+			servicePlanRepo.UpdateServicePlanVisibilityReturns(repositories.ServicePlanVisibilityRecord{
+				Type: "public",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/service_plans/plan-guid/visibility", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates the plan visibility", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(servicePlanRepo.UpdateServicePlanVisibilityCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := servicePlanRepo.UpdateServicePlanVisibilityArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.UpdateServicePlanVisibilityMessage{
+				PlanGUID:      "plan-guid",
+				Type:          "public",
+				Organizations: []string{},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.type", "public")))
+		})
+
+		When("the user is not allowed to update the visibility", func() {
+			BeforeEach(func() {
+				servicePlanRepo.UpdateServicePlanVisibilityReturns(repositories.ServicePlanVisibilityRecord{}, apierrors.NewForbiddenError(nil, repositories.ServicePlanVisibilityResourceType))
+			})
+
+			It("returns a forbidden error", func() {
+				expectNotAuthorizedError()
+			})
+		})
+	})
+
+	Describe("POST /v3/service_plans/:guid/visibility", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.ServicePlanVisibilityApply{
+				Type:          "organization",
+				Organizations: []payloads.VisibilityOrganization{{GUID: "org-guid"}},
+			})
+
+			servicePlanRepo.ApplyServicePlanVisibilityReturns(repositories.ServicePlanVisibilityRecord{
+				Type: "organization",
+				Organizations: []repositories.VisibilityOrganization{
+					{GUID: "org-guid", Name: "my-org"},
+				},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/service_plans/plan-guid/visibility", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("applies the plan visibility", func() {
+			Expect(servicePlanRepo.ApplyServicePlanVisibilityCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := servicePlanRepo.ApplyServicePlanVisibilityArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ApplyServicePlanVisibilityMessage{
+				PlanGUID:      "plan-guid",
+				Type:          "organization",
+				Organizations: []string{"org-guid"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.type", "organization"),
+				MatchJSONPath("$.organizations[0].guid", "org-guid"),
+			)))
+		})
+
+		When("applying the visibility fails", func() {
+			BeforeEach(func() {
+				servicePlanRepo.ApplyServicePlanVisibilityReturns(repositories.ServicePlanVisibilityRecord{}, errors.New("apply-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/service_plans/:guid/visibility/:org_guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/service_plans/plan-guid/visibility/org-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("removes the org from the plan visibility", func() {
+			Expect(servicePlanRepo.DeleteServicePlanVisibilityCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := servicePlanRepo.DeleteServicePlanVisibilityArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.DeleteServicePlanVisibilityMessage{
+				PlanGUID: "plan-guid",
+				OrgGUID:  "org-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the plan is not visible in the org", func() {
+			BeforeEach(func() {
+				servicePlanRepo.DeleteServicePlanVisibilityReturns(apierrors.NewNotFoundError(nil, repositories.ServicePlanVisibilityResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.ServicePlanVisibilityResourceType)
+			})
+		})
+	})
+})
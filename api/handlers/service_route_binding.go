@@ -1,31 +1,199 @@
 package handlers
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/url"
 
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
 	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/api/routing"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/go-logr/logr"
 )
 
 const (
 	ServiceRouteBindingsPath = "/v3/service_route_bindings"
+	ServiceRouteBindingPath  = "/v3/service_route_bindings/{guid}"
 )
 
+//counterfeiter:generate -o fake -fake-name CFServiceRouteBindingRepository . CFServiceRouteBindingRepository
+type CFServiceRouteBindingRepository interface {
+	CreateServiceRouteBinding(context.Context, authorization.Info, repositories.CreateServiceRouteBindingMessage) (repositories.ServiceRouteBindingRecord, error)
+	GetServiceRouteBinding(context.Context, authorization.Info, string) (repositories.ServiceRouteBindingRecord, error)
+	ListServiceRouteBindings(context.Context, authorization.Info, repositories.ListServiceRouteBindingsMessage) ([]repositories.ServiceRouteBindingRecord, error)
+	UpdateServiceRouteBinding(context.Context, authorization.Info, repositories.UpdateServiceRouteBindingMessage) (repositories.ServiceRouteBindingRecord, error)
+	DeleteServiceRouteBinding(context.Context, authorization.Info, string) error
+}
+
 type ServiceRouteBinding struct {
-	serverURL url.URL
+	serverURL               url.URL
+	serviceRouteBindingRepo CFServiceRouteBindingRepository
+	serviceInstanceRepo     CFServiceInstanceRepository
+	routeRepo               CFRouteRepository
+	requestValidator        RequestValidator
 }
 
 func NewServiceRouteBinding(
 	serverURL url.URL,
+	serviceRouteBindingRepo CFServiceRouteBindingRepository,
+	serviceInstanceRepo CFServiceInstanceRepository,
+	routeRepo CFRouteRepository,
+	requestValidator RequestValidator,
 ) *ServiceRouteBinding {
 	return &ServiceRouteBinding{
-		serverURL: serverURL,
+		serverURL:               serverURL,
+		serviceRouteBindingRepo: serviceRouteBindingRepo,
+		serviceInstanceRepo:     serviceInstanceRepo,
+		routeRepo:               routeRepo,
+		requestValidator:        requestValidator,
+	}
+}
+
+func (h *ServiceRouteBinding) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-route-binding.create")
+
+	var payload payloads.ServiceRouteBindingCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	serviceInstance, err := h.serviceInstanceRepo.GetServiceInstance(r.Context(), authInfo, payload.Relationships.ServiceInstance.Data.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(err, "The service instance could not be found: "+payload.Relationships.ServiceInstance.Data.GUID, apierrors.ForbiddenError{}, apierrors.NotFoundError{}),
+			"failed to get "+repositories.ServiceInstanceResourceType,
+		)
+	}
+
+	route, err := h.routeRepo.GetRoute(r.Context(), authInfo, payload.Relationships.Route.Data.GUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.AsUnprocessableEntity(err, "The route could not be found: "+payload.Relationships.Route.Data.GUID, apierrors.ForbiddenError{}, apierrors.NotFoundError{}),
+			"failed to get "+repositories.RouteResourceType,
+		)
+	}
+
+	if serviceInstance.Type != korifiv1alpha1.UserProvidedType || serviceInstance.RouteServiceURL == nil || *serviceInstance.RouteServiceURL == "" {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(errors.New("service instance has no route service URL"), "This service instance does not support route binding."),
+			"service instance does not support route binding", "ServiceInstance GUID", serviceInstance.GUID,
+		)
+	}
+
+	if route.SpaceGUID != serviceInstance.SpaceGUID {
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, "The service instance and the route are in different spaces."),
+			"Route and ServiceInstance in different spaces", "Route GUID", route.GUID,
+			"ServiceInstance GUID", serviceInstance.GUID,
+		)
+	}
+
+	existingBindings, err := h.serviceRouteBindingRepo.ListServiceRouteBindings(r.Context(), authInfo, repositories.ListServiceRouteBindingsMessage{
+		RouteGUIDs: []string{route.GUID},
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list "+repositories.ServiceRouteBindingResourceType)
+	}
+
+	if len(existingBindings) > 0 {
+		message := "A route may only be bound to a single service instance"
+		if existingBindings[0].ServiceInstanceGUID == serviceInstance.GUID {
+			message = "The route and service instance are already bound."
+		}
+
+		return nil, apierrors.LogAndReturn(
+			logger,
+			apierrors.NewUnprocessableEntityError(nil, message),
+			"route is already bound to a service instance", "Route GUID", route.GUID,
+		)
+	}
+
+	serviceRouteBinding, err := h.serviceRouteBindingRepo.CreateServiceRouteBinding(r.Context(), authInfo, payload.ToMessage(route.SpaceGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to create "+repositories.ServiceRouteBindingResourceType, "Route GUID", route.GUID, "ServiceInstance GUID", serviceInstance.GUID)
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForServiceRouteBinding(serviceRouteBinding, h.serverURL)), nil
+}
+
+func (h *ServiceRouteBinding) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-route-binding.get")
+
+	serviceRouteBindingGUID := routing.URLParam(r, "guid")
+
+	serviceRouteBinding, err := h.serviceRouteBindingRepo.GetServiceRouteBinding(r.Context(), authInfo, serviceRouteBindingGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get "+repositories.ServiceRouteBindingResourceType, "guid", serviceRouteBindingGUID)
 	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceRouteBinding(serviceRouteBinding, h.serverURL)), nil
 }
 
 func (h *ServiceRouteBinding) list(r *http.Request) (*routing.Response, error) {
-	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceRouteBindingsList(h.serverURL, *r.URL)), nil
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-route-binding.list")
+
+	listFilter := new(payloads.ServiceRouteBindingList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, listFilter); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	serviceRouteBindings, err := h.serviceRouteBindingRepo.ListServiceRouteBindings(r.Context(), authInfo, listFilter.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list "+repositories.ServiceRouteBindingResourceType)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceRouteBindingList(serviceRouteBindings, h.serverURL, *r.URL)), nil
+}
+
+func (h *ServiceRouteBinding) update(r *http.Request) (*routing.Response, error) { //nolint:dupl
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-route-binding.update")
+
+	serviceRouteBindingGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.ServiceRouteBindingUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.serviceRouteBindingRepo.GetServiceRouteBinding(r.Context(), authInfo, serviceRouteBindingGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get "+repositories.ServiceRouteBindingResourceType, "guid", serviceRouteBindingGUID)
+	}
+
+	serviceRouteBinding, err := h.serviceRouteBindingRepo.UpdateServiceRouteBinding(r.Context(), authInfo, payload.ToMessage(serviceRouteBindingGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to update "+repositories.ServiceRouteBindingResourceType, "guid", serviceRouteBindingGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForServiceRouteBinding(serviceRouteBinding, h.serverURL)), nil
+}
+
+func (h *ServiceRouteBinding) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.service-route-binding.delete")
+
+	serviceRouteBindingGUID := routing.URLParam(r, "guid")
+
+	err := h.serviceRouteBindingRepo.DeleteServiceRouteBinding(r.Context(), authInfo, serviceRouteBindingGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to delete "+repositories.ServiceRouteBindingResourceType, "guid", serviceRouteBindingGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
 }
 
 func (h *ServiceRouteBinding) UnauthenticatedRoutes() []routing.Route {
@@ -34,6 +202,10 @@ func (h *ServiceRouteBinding) UnauthenticatedRoutes() []routing.Route {
 
 func (h *ServiceRouteBinding) AuthenticatedRoutes() []routing.Route {
 	return []routing.Route{
+		{Method: "POST", Pattern: ServiceRouteBindingsPath, Handler: h.create},
 		{Method: "GET", Pattern: ServiceRouteBindingsPath, Handler: h.list},
+		{Method: "GET", Pattern: ServiceRouteBindingPath, Handler: h.get},
+		{Method: "PATCH", Pattern: ServiceRouteBindingPath, Handler: h.update},
+		{Method: "DELETE", Pattern: ServiceRouteBindingPath, Handler: h.delete},
 	}
 }
@@ -1,35 +1,396 @@
 package handlers_test
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
-	"code.cloudfoundry.org/korifi/api/handlers"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 )
 
 var _ = Describe("ServiceRouteBinding", func() {
-	Describe("the GET /v3/service_route_binding endpoint", func() {
+	var (
+		requestMethod string
+		requestPath   string
+		requestBody   string
+
+		serviceRouteBindingRepo *fake.CFServiceRouteBindingRepository
+		serviceInstanceRepo     *fake.CFServiceInstanceRepository
+		routeRepo               *fake.CFRouteRepository
+		requestValidator        *fake.RequestValidator
+	)
+
+	BeforeEach(func() {
+		serviceRouteBindingRepo = new(fake.CFServiceRouteBindingRepository)
+		serviceRouteBindingRepo.GetServiceRouteBindingReturns(repositories.ServiceRouteBindingRecord{
+			GUID: "service-route-binding-guid",
+		}, nil)
+
+		serviceInstanceRepo = new(fake.CFServiceInstanceRepository)
+		serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+			GUID:            "service-instance-guid",
+			SpaceGUID:       "space-guid",
+			Type:            "user-provided",
+			RouteServiceURL: tools.PtrTo("https://route-service.example.com"),
+		}, nil)
+
+		routeRepo = new(fake.CFRouteRepository)
+		routeRepo.GetRouteReturns(repositories.RouteRecord{
+			GUID:      "route-guid",
+			SpaceGUID: "space-guid",
+		}, nil)
+
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler := NewServiceRouteBinding(
+			*serverURL,
+			serviceRouteBindingRepo,
+			serviceInstanceRepo,
+			routeRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		req, err := http.NewRequestWithContext(ctx, requestMethod, requestPath, strings.NewReader(requestBody))
+		Expect(err).NotTo(HaveOccurred())
+
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/service_route_bindings", func() {
 		BeforeEach(func() {
-			req, err := http.NewRequestWithContext(ctx, "GET", "/v3/service_route_bindings", nil)
-			Expect(err).NotTo(HaveOccurred())
+			requestMethod = http.MethodPost
+			requestPath = "/v3/service_route_bindings"
+			requestBody = "the-json-body"
 
-			apiHandler := handlers.NewServiceRouteBinding(
-				*serverURL)
+			serviceRouteBindingRepo.CreateServiceRouteBindingReturns(repositories.ServiceRouteBindingRecord{
+				GUID: "service-route-binding-guid",
+			}, nil)
 
-			routerBuilder.LoadRoutes(apiHandler)
-			routerBuilder.Build().ServeHTTP(rr, req)
+			payload := payloads.ServiceRouteBindingCreate{
+				Relationships: &payloads.ServiceRouteBindingRelationships{
+					Route: &payloads.Relationship{
+						Data: &payloads.RelationshipData{GUID: "route-guid"},
+					},
+					ServiceInstance: &payloads.Relationship{
+						Data: &payloads.RelationshipData{GUID: "service-instance-guid"},
+					},
+				},
+			}
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payload)
 		})
 
-		It("returns an empty list", func() {
+		It("creates a service route binding", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(serviceInstanceRepo.GetServiceInstanceCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualServiceInstanceGUID := serviceInstanceRepo.GetServiceInstanceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualServiceInstanceGUID).To(Equal("service-instance-guid"))
+
+			Expect(routeRepo.GetRouteCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualRouteGUID := routeRepo.GetRouteArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualRouteGUID).To(Equal("route-guid"))
+
+			Expect(serviceRouteBindingRepo.CreateServiceRouteBindingCallCount()).To(Equal(1))
+			_, actualAuthInfo, createMessage := serviceRouteBindingRepo.CreateServiceRouteBindingArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(createMessage.RouteGUID).To(Equal("route-guid"))
+			Expect(createMessage.ServiceInstanceGUID).To(Equal("service-instance-guid"))
+			Expect(createMessage.SpaceGUID).To(Equal("space-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "service-route-binding-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/service_route_bindings/service-route-binding-guid"),
+			)))
+		})
+
+		When("the request body is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("the service instance does not exist", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{}, apierrors.NewNotFoundError(nil, repositories.ServiceInstanceResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("The service instance could not be found: service-instance-guid")
+				Expect(serviceRouteBindingRepo.CreateServiceRouteBindingCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the route does not exist", func() {
+			BeforeEach(func() {
+				routeRepo.GetRouteReturns(repositories.RouteRecord{}, apierrors.NewNotFoundError(nil, repositories.RouteResourceType))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("The route could not be found: route-guid")
+				Expect(serviceRouteBindingRepo.CreateServiceRouteBindingCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the service instance has no route service URL", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID:      "service-instance-guid",
+					SpaceGUID: "space-guid",
+					Type:      "user-provided",
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("This service instance does not support route binding.")
+				Expect(serviceRouteBindingRepo.CreateServiceRouteBindingCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the service instance is managed", func() {
+			BeforeEach(func() {
+				serviceInstanceRepo.GetServiceInstanceReturns(repositories.ServiceInstanceRecord{
+					GUID:      "service-instance-guid",
+					SpaceGUID: "space-guid",
+					Type:      "managed",
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("This service instance does not support route binding.")
+				Expect(serviceRouteBindingRepo.CreateServiceRouteBindingCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the route and the service instance are in different spaces", func() {
+			BeforeEach(func() {
+				routeRepo.GetRouteReturns(repositories.RouteRecord{
+					GUID:      "route-guid",
+					SpaceGUID: "another-space-guid",
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("The service instance and the route are in different spaces.")
+				Expect(serviceRouteBindingRepo.CreateServiceRouteBindingCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the route is already bound to the service instance", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.ListServiceRouteBindingsReturns([]repositories.ServiceRouteBindingRecord{
+					{GUID: "existing-binding-guid", RouteGUID: "route-guid", ServiceInstanceGUID: "service-instance-guid"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(serviceRouteBindingRepo.ListServiceRouteBindingsCallCount()).To(Equal(1))
+				_, _, listMessage := serviceRouteBindingRepo.ListServiceRouteBindingsArgsForCall(0)
+				Expect(listMessage.RouteGUIDs).To(ConsistOf("route-guid"))
+
+				expectUnprocessableEntityError("The route and service instance are already bound.")
+				Expect(serviceRouteBindingRepo.CreateServiceRouteBindingCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the route is already bound to another service instance", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.ListServiceRouteBindingsReturns([]repositories.ServiceRouteBindingRecord{
+					{GUID: "existing-binding-guid", RouteGUID: "route-guid", ServiceInstanceGUID: "another-service-instance-guid"},
+				}, nil)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("A route may only be bound to a single service instance")
+				Expect(serviceRouteBindingRepo.CreateServiceRouteBindingCallCount()).To(Equal(0))
+			})
+		})
+
+		When("creating the service route binding errors", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.CreateServiceRouteBindingReturns(repositories.ServiceRouteBindingRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/service_route_bindings", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/service_route_bindings?foo=bar"
+			requestBody = ""
+
+			serviceRouteBindingRepo.ListServiceRouteBindingsReturns([]repositories.ServiceRouteBindingRecord{
+				{GUID: "service-route-binding-guid"},
+			}, nil)
+
+			payload := payloads.ServiceRouteBindingList{
+				RouteGUIDs:           "r1,r2",
+				ServiceInstanceGUIDs: "s1,s2",
+			}
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payload)
+		})
+
+		It("returns the list of service route bindings", func() {
+			Expect(requestValidator.DecodeAndValidateURLValuesCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateURLValuesArgsForCall(0)
+			Expect(actualReq.URL.String()).To(HaveSuffix(requestPath))
+
+			Expect(serviceRouteBindingRepo.ListServiceRouteBindingsCallCount()).To(Equal(1))
+			_, _, message := serviceRouteBindingRepo.ListServiceRouteBindingsArgsForCall(0)
+			Expect(message.RouteGUIDs).To(ConsistOf("r1", "r2"))
+			Expect(message.ServiceInstanceGUIDs).To(ConsistOf("s1", "s2"))
+
 			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(SatisfyAll(
-				MatchJSONPath("$.pagination.total_results", BeZero()),
-				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/service_route_bindings"),
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/service_route_bindings?foo=bar"),
+				MatchJSONPath("$.resources[0].guid", "service-route-binding-guid"),
 			)))
 		})
+
+		When("listing the service route bindings fails", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.ListServiceRouteBindingsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("decoding URL params fails", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/service_route_bindings/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodGet
+			requestPath = "/v3/service_route_bindings/service-route-binding-guid"
+			requestBody = ""
+		})
+
+		It("returns the service route binding", func() {
+			Expect(serviceRouteBindingRepo.GetServiceRouteBindingCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := serviceRouteBindingRepo.GetServiceRouteBindingArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("service-route-binding-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.guid", "service-route-binding-guid")))
+		})
+
+		When("the user is not authorized", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.GetServiceRouteBindingReturns(repositories.ServiceRouteBindingRecord{}, apierrors.NewForbiddenError(nil, repositories.ServiceRouteBindingResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.ServiceRouteBindingResourceType)
+			})
+		})
+	})
+
+	Describe("PATCH /v3/service_route_bindings/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodPatch
+			requestPath = "/v3/service_route_bindings/service-route-binding-guid"
+			requestBody = "the-json-body"
+
+			serviceRouteBindingRepo.UpdateServiceRouteBindingReturns(repositories.ServiceRouteBindingRecord{
+				GUID: "service-route-binding-guid",
+			}, nil)
+
+			payload := payloads.ServiceRouteBindingUpdate{
+				Metadata: payloads.MetadataPatch{
+					Labels:      map[string]*string{"foo": tools.PtrTo("bar")},
+					Annotations: map[string]*string{"bar": tools.PtrTo("baz")},
+				},
+			}
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payload)
+		})
+
+		It("updates the service route binding", func() {
+			Expect(serviceRouteBindingRepo.UpdateServiceRouteBindingCallCount()).To(Equal(1))
+			_, actualAuthInfo, updateMessage := serviceRouteBindingRepo.UpdateServiceRouteBindingArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(updateMessage.GUID).To(Equal("service-route-binding-guid"))
+			Expect(updateMessage.MetadataPatch.Labels).To(HaveKeyWithValue("foo", tools.PtrTo("bar")))
+			Expect(updateMessage.MetadataPatch.Annotations).To(HaveKeyWithValue("bar", tools.PtrTo("baz")))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.guid", "service-route-binding-guid")))
+		})
+
+		When("the service route binding does not exist", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.GetServiceRouteBindingReturns(repositories.ServiceRouteBindingRecord{}, apierrors.NewNotFoundError(nil, repositories.ServiceRouteBindingResourceType))
+			})
+
+			It("returns 404 NotFound", func() {
+				expectNotFoundError(repositories.ServiceRouteBindingResourceType)
+				Expect(serviceRouteBindingRepo.UpdateServiceRouteBindingCallCount()).To(Equal(0))
+			})
+		})
+	})
+
+	Describe("DELETE /v3/service_route_bindings/{guid}", func() {
+		BeforeEach(func() {
+			requestMethod = http.MethodDelete
+			requestPath = "/v3/service_route_bindings/service-route-binding-guid"
+			requestBody = ""
+		})
+
+		It("deletes the service route binding", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+			Expect(rr).To(HaveHTTPBody(BeEmpty()))
+
+			Expect(serviceRouteBindingRepo.DeleteServiceRouteBindingCallCount()).To(Equal(1))
+			_, _, guid := serviceRouteBindingRepo.DeleteServiceRouteBindingArgsForCall(0)
+			Expect(guid).To(Equal("service-route-binding-guid"))
+		})
+
+		When("deleting the service route binding fails", func() {
+			BeforeEach(func() {
+				serviceRouteBindingRepo.DeleteServiceRouteBindingReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
 	})
 })
@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"context"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+)
+
+const (
+	ServiceUsageEventsPath               = "/v3/service_usage_events"
+	ServiceUsageEventPath                = "/v3/service_usage_events/{guid}"
+	ServiceUsageEventsPurgeAndReseedPath = "/v3/service_usage_events/actions/destructively_purge_all_and_reseed"
+)
+
+//counterfeiter:generate -o fake -fake-name CFServiceUsageEventRepository . CFServiceUsageEventRepository
+type CFServiceUsageEventRepository interface {
+	ListServiceUsageEvents(context.Context, authorization.Info, repositories.ListServiceUsageEventsMessage) ([]repositories.ServiceUsageEventRecord, error)
+	GetServiceUsageEvent(context.Context, authorization.Info, string) (repositories.ServiceUsageEventRecord, error)
+	PurgeAndReseedServiceUsageEvents(context.Context, authorization.Info) error
+}
+
+type ServiceUsageEvent struct {
+	usageEvents[repositories.ListServiceUsageEventsMessage, repositories.ServiceUsageEventRecord, presenter.ServiceUsageEventResponse]
+}
+
+func NewServiceUsageEvent(
+	serverURL url.URL,
+	serviceUsageEventRepo CFServiceUsageEventRepository,
+	requestValidator RequestValidator,
+) *ServiceUsageEvent {
+	return &ServiceUsageEvent{
+		usageEvents: usageEvents[repositories.ListServiceUsageEventsMessage, repositories.ServiceUsageEventRecord, presenter.ServiceUsageEventResponse]{
+			serverURL:        serverURL,
+			requestValidator: requestValidator,
+			loggerName:       "handlers.service-usage-event",
+			eventsName:       "service usage events",
+			newListFilter: func() usageEventListFilter[repositories.ListServiceUsageEventsMessage] {
+				return new(payloads.ServiceUsageEventList)
+			},
+			listEvents:     serviceUsageEventRepo.ListServiceUsageEvents,
+			getEvent:       serviceUsageEventRepo.GetServiceUsageEvent,
+			purgeAndReseed: serviceUsageEventRepo.PurgeAndReseedServiceUsageEvents,
+			present:        presenter.ForServiceUsageEvent,
+		},
+	}
+}
+
+func (h *ServiceUsageEvent) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *ServiceUsageEvent) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: ServiceUsageEventsPath, Handler: h.list},
+		{Method: "GET", Pattern: ServiceUsageEventPath, Handler: h.get},
+		{Method: "POST", Pattern: ServiceUsageEventsPurgeAndReseedPath, Handler: h.purgeAndReseedEvents},
+	}
+}
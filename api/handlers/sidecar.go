@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	SidecarPath     = "/v3/sidecars/{guid}"
+	AppSidecarsPath = "/v3/apps/{guid}/sidecars"
+)
+
+//counterfeiter:generate -o fake -fake-name CFSidecarRepository . CFSidecarRepository
+type CFSidecarRepository interface {
+	GetSidecar(context.Context, authorization.Info, string) (repositories.SidecarRecord, error)
+	ListSidecars(context.Context, authorization.Info, repositories.ListSidecarsMessage) ([]repositories.SidecarRecord, error)
+	CreateSidecar(context.Context, authorization.Info, repositories.CreateSidecarMessage) (repositories.SidecarRecord, error)
+	PatchSidecar(context.Context, authorization.Info, repositories.PatchSidecarMessage) (repositories.SidecarRecord, error)
+	DeleteSidecar(context.Context, authorization.Info, repositories.DeleteSidecarMessage) error
+}
+
+type Sidecar struct {
+	serverURL        url.URL
+	sidecarRepo      CFSidecarRepository
+	appRepo          CFAppRepository
+	requestValidator RequestValidator
+}
+
+func NewSidecar(
+	serverURL url.URL,
+	sidecarRepo CFSidecarRepository,
+	appRepo CFAppRepository,
+	requestValidator RequestValidator,
+) *Sidecar {
+	return &Sidecar{
+		serverURL:        serverURL,
+		sidecarRepo:      sidecarRepo,
+		appRepo:          appRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *Sidecar) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.sidecar.create")
+
+	appGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.SidecarCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app", "guid", appGUID)
+	}
+
+	sidecar, err := h.sidecarRepo.CreateSidecar(r.Context(), authInfo, payload.ToMessage(app.GUID, app.SpaceGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to create sidecar", "appGUID", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForSidecar(sidecar, h.serverURL)), nil
+}
+
+func (h *Sidecar) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.sidecar.get")
+
+	sidecarGUID := routing.URLParam(r, "guid")
+
+	sidecar, err := h.sidecarRepo.GetSidecar(r.Context(), authInfo, sidecarGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get sidecar", "guid", sidecarGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSidecar(sidecar, h.serverURL)), nil
+}
+
+func (h *Sidecar) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.sidecar.update")
+
+	sidecarGUID := routing.URLParam(r, "guid")
+
+	sidecar, err := h.sidecarRepo.GetSidecar(r.Context(), authInfo, sidecarGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get sidecar", "guid", sidecarGUID)
+	}
+
+	var payload payloads.SidecarPatch
+	if err = h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	sidecar, err = h.sidecarRepo.PatchSidecar(r.Context(), authInfo, payload.ToMessage(sidecarGUID, sidecar.SpaceGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to patch sidecar", "guid", sidecarGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSidecar(sidecar, h.serverURL)), nil
+}
+
+func (h *Sidecar) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.sidecar.delete")
+
+	sidecarGUID := routing.URLParam(r, "guid")
+
+	sidecar, err := h.sidecarRepo.GetSidecar(r.Context(), authInfo, sidecarGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get sidecar", "guid", sidecarGUID)
+	}
+
+	err = h.sidecarRepo.DeleteSidecar(r.Context(), authInfo, repositories.DeleteSidecarMessage{
+		GUID:      sidecar.GUID,
+		SpaceGUID: sidecar.SpaceGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to delete sidecar", "guid", sidecarGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *Sidecar) listForApp(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.sidecar.list-for-app")
+
+	appGUID := routing.URLParam(r, "guid")
+
+	app, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get app", "guid", appGUID)
+	}
+
+	sidecars, err := h.sidecarRepo.ListSidecars(r.Context(), authInfo, repositories.ListSidecarsMessage{
+		AppGUID:   app.GUID,
+		SpaceGUID: app.SpaceGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list sidecars", "appGUID", appGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForSidecar, sidecars, h.serverURL, *r.URL)), nil
+}
+
+func (h *Sidecar) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *Sidecar) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: SidecarPath, Handler: h.get},
+		{Method: "PATCH", Pattern: SidecarPath, Handler: h.update},
+		{Method: "DELETE", Pattern: SidecarPath, Handler: h.delete},
+		{Method: "GET", Pattern: AppSidecarsPath, Handler: h.listForApp},
+		{Method: "POST", Pattern: AppSidecarsPath, Handler: h.create},
+	}
+}
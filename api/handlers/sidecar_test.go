@@ -0,0 +1,331 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sidecar", func() {
+	var (
+		apiHandler       *handlers.Sidecar
+		sidecarRepo      *fake.CFSidecarRepository
+		appRepo          *fake.CFAppRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		sidecarRepo = new(fake.CFSidecarRepository)
+		appRepo = new(fake.CFAppRepository)
+		appRepo.GetAppReturns(repositories.AppRecord{GUID: "app-guid", SpaceGUID: spaceGUID}, nil)
+
+		sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{
+			GUID:      "sidecar-guid",
+			AppGUID:   "app-guid",
+			SpaceGUID: spaceGUID,
+			Name:      "my-sidecar",
+		}, nil)
+
+		apiHandler = handlers.NewSidecar(
+			*serverURL,
+			sidecarRepo,
+			appRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/apps/{guid}/sidecars", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SidecarCreate{
+				Name:         "my-sidecar",
+				Command:      "bin/sidecar",
+				ProcessTypes: []string{"web"},
+				MemoryInMB:   tools.PtrTo[int64](64),
+			})
+
+			sidecarRepo.CreateSidecarReturns(repositories.SidecarRecord{
+				GUID:    "sidecar-guid",
+				AppGUID: "app-guid",
+				Name:    "my-sidecar",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/apps/app-guid/sidecars", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates the sidecar", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(appRepo.GetAppCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualAppGUID := appRepo.GetAppArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualAppGUID).To(Equal("app-guid"))
+
+			Expect(sidecarRepo.CreateSidecarCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := sidecarRepo.CreateSidecarArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.CreateSidecarMessage{
+				AppGUID:      "app-guid",
+				SpaceGUID:    spaceGUID,
+				Name:         "my-sidecar",
+				Command:      "bin/sidecar",
+				ProcessTypes: []string{"web"},
+				MemoryMB:     tools.PtrTo[int64](64),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "sidecar-guid"),
+				MatchJSONPath("$.name", "my-sidecar"),
+				MatchJSONPath("$.relationships.app.data.guid", "app-guid"),
+			)))
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(errors.New("validation-err"), "validation error"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("validation error")
+			})
+		})
+
+		When("the user is not authorized to get the app", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppResourceType)
+			})
+		})
+
+		When("creating the sidecar fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.CreateSidecarReturns(repositories.SidecarRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/sidecars/{guid}", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/sidecars/sidecar-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the sidecar", func() {
+			Expect(sidecarRepo.GetSidecarCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := sidecarRepo.GetSidecarArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("sidecar-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "sidecar-guid"),
+				MatchJSONPath("$.name", "my-sidecar"),
+			)))
+		})
+
+		When("the user is not authorized to get the sidecar", func() {
+			BeforeEach(func() {
+				sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{}, apierrors.NewForbiddenError(nil, repositories.SidecarResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SidecarResourceType)
+			})
+		})
+
+		When("getting the sidecar fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("PATCH /v3/sidecars/{guid}", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SidecarPatch{
+				Command: tools.PtrTo("bin/other-sidecar"),
+			})
+
+			sidecarRepo.PatchSidecarReturns(repositories.SidecarRecord{
+				GUID:    "sidecar-guid",
+				Command: "bin/other-sidecar",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/sidecars/sidecar-guid", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("patches the sidecar", func() {
+			Expect(sidecarRepo.PatchSidecarCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := sidecarRepo.PatchSidecarArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.PatchSidecarMessage{
+				GUID:      "sidecar-guid",
+				SpaceGUID: spaceGUID,
+				Command:   tools.PtrTo("bin/other-sidecar"),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "sidecar-guid"),
+				MatchJSONPath("$.command", "bin/other-sidecar"),
+			)))
+		})
+
+		When("the user is not authorized to get the sidecar", func() {
+			BeforeEach(func() {
+				sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{}, apierrors.NewForbiddenError(nil, repositories.SidecarResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SidecarResourceType)
+			})
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(errors.New("validation-err"), "validation error"))
+			})
+
+			It("returns an unprocessable entity error", func() {
+				expectUnprocessableEntityError("validation error")
+			})
+		})
+
+		When("patching the sidecar fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.PatchSidecarReturns(repositories.SidecarRecord{}, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("DELETE /v3/sidecars/{guid}", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/sidecars/sidecar-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the sidecar", func() {
+			Expect(sidecarRepo.DeleteSidecarCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := sidecarRepo.DeleteSidecarArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.DeleteSidecarMessage{
+				GUID:      "sidecar-guid",
+				SpaceGUID: spaceGUID,
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the user is not authorized to get the sidecar", func() {
+			BeforeEach(func() {
+				sidecarRepo.GetSidecarReturns(repositories.SidecarRecord{}, apierrors.NewForbiddenError(nil, repositories.SidecarResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SidecarResourceType)
+			})
+		})
+
+		When("deleting the sidecar fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.DeleteSidecarReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/apps/{guid}/sidecars", func() {
+		BeforeEach(func() {
+			sidecarRepo.ListSidecarsReturns([]repositories.SidecarRecord{
+				{GUID: "sidecar-1", AppGUID: "app-guid"},
+				{GUID: "sidecar-2", AppGUID: "app-guid"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/apps/app-guid/sidecars", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the sidecars of the app", func() {
+			Expect(sidecarRepo.ListSidecarsCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := sidecarRepo.ListSidecarsArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.ListSidecarsMessage{
+				AppGUID:   "app-guid",
+				SpaceGUID: spaceGUID,
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/apps/app-guid/sidecars"),
+				MatchJSONPath("$.resources[0].guid", "sidecar-1"),
+				MatchJSONPath("$.resources[1].guid", "sidecar-2"),
+			)))
+		})
+
+		When("the user is not authorized to get the app", func() {
+			BeforeEach(func() {
+				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewForbiddenError(nil, repositories.AppResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.AppResourceType)
+			})
+		})
+
+		When("listing the sidecars fails", func() {
+			BeforeEach(func() {
+				sidecarRepo.ListSidecarsReturns(nil, errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
@@ -0,0 +1,85 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	SpaceSSHFeaturePath = "/v3/spaces/{guid}/features/ssh"
+)
+
+type SpaceFeature struct {
+	serverURL        url.URL
+	spaceRepo        CFSpaceRepository
+	requestValidator RequestValidator
+}
+
+func NewSpaceFeature(
+	serverURL url.URL,
+	spaceRepo CFSpaceRepository,
+	requestValidator RequestValidator,
+) *SpaceFeature {
+	return &SpaceFeature{
+		serverURL:        serverURL,
+		spaceRepo:        spaceRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *SpaceFeature) getSSH(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-feature.get-ssh")
+
+	spaceGUID := routing.URLParam(r, "guid")
+
+	space, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space", "guid", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceSSHFeature(space)), nil
+}
+
+func (h *SpaceFeature) updateSSH(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-feature.update-ssh")
+
+	spaceGUID := routing.URLParam(r, "guid")
+
+	space, err := h.spaceRepo.GetSpace(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space", "guid", spaceGUID)
+	}
+
+	var payload payloads.SpaceFeatureUpdate
+	if err = h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	space, err = h.spaceRepo.PatchSpaceFeatures(r.Context(), authInfo, payload.ToMessage(space.GUID, space.OrganizationGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to update space ssh feature", "guid", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceSSHFeature(space)), nil
+}
+
+func (h *SpaceFeature) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *SpaceFeature) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: SpaceSSHFeaturePath, Handler: h.getSSH},
+		{Method: "PATCH", Pattern: SpaceSSHFeaturePath, Handler: h.updateSSH},
+	}
+}
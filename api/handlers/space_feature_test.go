@@ -0,0 +1,147 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("SpaceFeature", func() {
+	var (
+		apiHandler       *handlers.SpaceFeature
+		spaceRepo        *fake.CFSpaceRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		spaceRepo = new(fake.CFSpaceRepository)
+		spaceRepo.GetSpaceReturns(repositories.SpaceRecord{
+			GUID:             spaceGUID,
+			OrganizationGUID: "org-guid",
+			SSHEnabled:       true,
+		}, nil)
+		requestValidator = new(fake.RequestValidator)
+
+		apiHandler = handlers.NewSpaceFeature(*serverURL, spaceRepo, requestValidator)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/spaces/{guid}/features/ssh", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/spaces/"+spaceGUID+"/features/ssh", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the space ssh feature", func() {
+			Expect(spaceRepo.GetSpaceCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSpaceGUID := spaceRepo.GetSpaceArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal(spaceGUID))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "ssh"),
+				MatchJSONPath("$.description", "Enable SSHing into apps in the space."),
+				MatchJSONPath("$.enabled", BeTrue()),
+			)))
+		})
+
+		When("getting the space is forbidden", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SpaceResourceType)
+			})
+		})
+	})
+
+	Describe("PATCH /v3/spaces/{guid}/features/ssh", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SpaceFeatureUpdate{
+				Enabled: tools.PtrTo(false),
+			})
+
+			spaceRepo.PatchSpaceFeaturesReturns(repositories.SpaceRecord{
+				GUID:       spaceGUID,
+				SSHEnabled: false,
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/spaces/"+spaceGUID+"/features/ssh", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates the space ssh feature", func() {
+			Expect(requestValidator.DecodeAndValidateJSONPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateJSONPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-json-body"))
+
+			Expect(spaceRepo.PatchSpaceFeaturesCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualMessage := spaceRepo.PatchSpaceFeaturesArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualMessage).To(Equal(repositories.PatchSpaceFeaturesMessage{
+				GUID:       spaceGUID,
+				OrgGUID:    "org-guid",
+				SSHEnabled: tools.PtrTo(false),
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.name", "ssh"),
+				MatchJSONPath("$.enabled", BeFalse()),
+			)))
+		})
+
+		When("the space cannot be found", func() {
+			BeforeEach(func() {
+				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, apierrors.NewNotFoundError(nil, repositories.SpaceResourceType))
+			})
+
+			It("returns a not found error", func() {
+				Expect(spaceRepo.PatchSpaceFeaturesCallCount()).To(Equal(0))
+				expectNotFoundError(repositories.SpaceResourceType)
+			})
+		})
+
+		When("the payload is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateJSONPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				Expect(spaceRepo.PatchSpaceFeaturesCallCount()).To(Equal(0))
+				expectUnprocessableEntityError("oops")
+			})
+		})
+
+		When("patching the space fails", func() {
+			BeforeEach(func() {
+				spaceRepo.PatchSpaceFeaturesReturns(repositories.SpaceRecord{}, errors.New("patch-space"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
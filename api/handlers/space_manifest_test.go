@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 
+	"code.cloudfoundry.org/korifi/api/actions/manifest"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	. "code.cloudfoundry.org/korifi/api/handlers"
 	"code.cloudfoundry.org/korifi/api/handlers/fake"
@@ -120,16 +121,76 @@ var _ = Describe("SpaceManifest", func() {
 	Describe("POST /v3/spaces/{spaceGUID}/manifest_diff", func() {
 		BeforeEach(func() {
 			requestPath = "/v3/spaces/test-space-guid/manifest_diff"
+			requestValidator.DecodeAndValidateYAMLPayloadStub = decodeAndValidatePayloadStub(&payloads.Manifest{
+				Version: 1,
+				Applications: []payloads.ManifestApplication{{
+					Name:      "app1",
+					Instances: tools.PtrTo(2),
+				}},
+			})
+			manifestApplier.DiffReturns([]manifest.Diff{
+				{Op: manifest.DiffOpReplace, Path: "/applications/0/processes/0/instances", Was: 1, Value: 2},
+				{Op: manifest.DiffOpAdd, Path: "/applications/0/env/FOO", Value: "bar"},
+			}, nil)
 		})
 
-		It("returns 202 with an empty diff", func() {
+		It("returns 202 with the diff", func() {
 			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
 			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
 			Expect(rr).To(HaveHTTPBody(MatchJSON(`{
-				"diff": []
+				"diff": [
+					{ "op": "replace", "path": "/applications/0/processes/0/instances", "was": 1, "value": 2 },
+					{ "op": "add", "path": "/applications/0/env/FOO", "was": null, "value": "bar" }
+				]
 			}`)))
 		})
 
+		It("diffs the manifest", func() {
+			Expect(requestValidator.DecodeAndValidateYAMLPayloadCallCount()).To(Equal(1))
+			actualReq, _ := requestValidator.DecodeAndValidateYAMLPayloadArgsForCall(0)
+			Expect(bodyString(actualReq)).To(Equal("the-yaml-body"))
+
+			Expect(manifestApplier.DiffCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSpaceGUID, payload := manifestApplier.DiffArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal("test-space-guid"))
+			Expect(payload.Applications).To(HaveLen(1))
+			Expect(payload.Applications[0].Name).To(Equal("app1"))
+			Expect(payload.Applications[0].Instances).To(PointTo(Equal(2)))
+		})
+
+		When("there are no changes", func() {
+			BeforeEach(func() {
+				manifestApplier.DiffReturns(nil, nil)
+			})
+
+			It("returns an empty diff", func() {
+				Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+				Expect(rr).To(HaveHTTPBody(MatchJSON(`{"diff": []}`)))
+			})
+		})
+
+		When("the manifest is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateYAMLPayloadReturns(apierrors.NewUnprocessableEntityError(nil, "oops"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("oops")
+				Expect(manifestApplier.DiffCallCount()).To(Equal(0))
+			})
+		})
+
+		When("diffing the manifest fails", func() {
+			BeforeEach(func() {
+				manifestApplier.DiffReturns(nil, errors.New("diff-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
 		When("getting the space errors", func() {
 			BeforeEach(func() {
 				spaceRepo.GetSpaceReturns(repositories.SpaceRecord{}, errors.New("foo"))
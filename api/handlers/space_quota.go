@@ -0,0 +1,221 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	SpaceQuotasPath       = "/v3/space_quotas"
+	SpaceQuotaPath        = "/v3/space_quotas/{guid}"
+	SpaceQuotaSpacesPath  = "/v3/space_quotas/{guid}/relationships/spaces"
+	SpaceQuotaSpacePath   = "/v3/space_quotas/{guid}/relationships/spaces/{space_guid}"
+	SpaceUsageSummaryPath = "/v3/spaces/{guid}/usage_summary"
+)
+
+//counterfeiter:generate -o fake -fake-name CFSpaceQuotaRepository . CFSpaceQuotaRepository
+type CFSpaceQuotaRepository interface {
+	CreateSpaceQuota(context.Context, authorization.Info, repositories.CreateSpaceQuotaMessage) (repositories.SpaceQuotaRecord, error)
+	GetSpaceQuota(context.Context, authorization.Info, string) (repositories.SpaceQuotaRecord, error)
+	ListSpaceQuotas(context.Context, authorization.Info, repositories.ListSpaceQuotasMessage) ([]repositories.SpaceQuotaRecord, error)
+	UpdateSpaceQuota(context.Context, authorization.Info, repositories.UpdateSpaceQuotaMessage) (repositories.SpaceQuotaRecord, error)
+	DeleteSpaceQuota(context.Context, authorization.Info, string) error
+	ApplySpaceQuota(context.Context, authorization.Info, repositories.ApplySpaceQuotaMessage) (repositories.SpaceQuotaRecord, error)
+	RemoveSpaceQuota(context.Context, authorization.Info, repositories.RemoveSpaceQuotaMessage) error
+	GetSpaceUsageSummary(context.Context, authorization.Info, string) (repositories.UsageSummaryRecord, error)
+}
+
+type SpaceQuota struct {
+	serverURL        url.URL
+	spaceQuotaRepo   CFSpaceQuotaRepository
+	requestValidator RequestValidator
+}
+
+func NewSpaceQuota(
+	serverURL url.URL,
+	spaceQuotaRepo CFSpaceQuotaRepository,
+	requestValidator RequestValidator,
+) *SpaceQuota {
+	return &SpaceQuota{
+		serverURL:        serverURL,
+		spaceQuotaRepo:   spaceQuotaRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *SpaceQuota) create(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.create")
+
+	var payload payloads.SpaceQuotaCreate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	spaceQuota, err := h.spaceQuotaRepo.CreateSpaceQuota(r.Context(), authInfo, payload.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to create space quota")
+	}
+
+	return routing.NewResponse(http.StatusCreated).WithBody(presenter.ForSpaceQuota(spaceQuota, h.serverURL)), nil
+}
+
+func (h *SpaceQuota) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.get")
+
+	spaceQuotaGUID := routing.URLParam(r, "guid")
+
+	spaceQuota, err := h.spaceQuotaRepo.GetSpaceQuota(r.Context(), authInfo, spaceQuotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space quota", "guid", spaceQuotaGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceQuota(spaceQuota, h.serverURL)), nil
+}
+
+func (h *SpaceQuota) list(r *http.Request) (*routing.Response, error) { //nolint:dupl
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.list")
+
+	listFilter := new(payloads.SpaceQuotaList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, listFilter); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	spaceQuotas, err := h.spaceQuotaRepo.ListSpaceQuotas(r.Context(), authInfo, listFilter.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list space quotas")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForSpaceQuota, spaceQuotas, h.serverURL, *r.URL)), nil
+}
+
+func (h *SpaceQuota) update(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.update")
+
+	spaceQuotaGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.SpaceQuotaUpdate
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.spaceQuotaRepo.GetSpaceQuota(r.Context(), authInfo, spaceQuotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space quota", "guid", spaceQuotaGUID)
+	}
+
+	spaceQuota, err := h.spaceQuotaRepo.UpdateSpaceQuota(r.Context(), authInfo, payload.ToMessage(spaceQuotaGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to update space quota", "guid", spaceQuotaGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceQuota(spaceQuota, h.serverURL)), nil
+}
+
+func (h *SpaceQuota) delete(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.delete")
+
+	spaceQuotaGUID := routing.URLParam(r, "guid")
+
+	err := h.spaceQuotaRepo.DeleteSpaceQuota(r.Context(), authInfo, spaceQuotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to delete space quota", "guid", spaceQuotaGUID)
+	}
+
+	return routing.NewResponse(http.StatusAccepted).WithHeader(
+		"Location",
+		presenter.JobURLForRedirects(spaceQuotaGUID, presenter.SpaceQuotaDeleteOperation, h.serverURL),
+	), nil
+}
+
+func (h *SpaceQuota) apply(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.apply")
+
+	spaceQuotaGUID := routing.URLParam(r, "guid")
+
+	var payload payloads.SpaceQuotaApply
+	if err := h.requestValidator.DecodeAndValidateJSONPayload(r, &payload); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
+	}
+
+	_, err := h.spaceQuotaRepo.GetSpaceQuota(r.Context(), authInfo, spaceQuotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space quota", "guid", spaceQuotaGUID)
+	}
+
+	spaceQuota, err := h.spaceQuotaRepo.ApplySpaceQuota(r.Context(), authInfo, payload.ToMessage(spaceQuotaGUID))
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to apply space quota", "guid", spaceQuotaGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceQuotaSpaces(spaceQuota.SpaceGUIDs, spaceQuotaGUID, h.serverURL)), nil
+}
+
+func (h *SpaceQuota) remove(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.remove")
+
+	spaceQuotaGUID := routing.URLParam(r, "guid")
+	spaceGUID := routing.URLParam(r, "space_guid")
+
+	_, err := h.spaceQuotaRepo.GetSpaceQuota(r.Context(), authInfo, spaceQuotaGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space quota", "guid", spaceQuotaGUID)
+	}
+
+	err = h.spaceQuotaRepo.RemoveSpaceQuota(r.Context(), authInfo, repositories.RemoveSpaceQuotaMessage{
+		GUID:      spaceQuotaGUID,
+		SpaceGUID: spaceGUID,
+	})
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to remove space quota", "guid", spaceQuotaGUID, "spaceGUID", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusNoContent), nil
+}
+
+func (h *SpaceQuota) usageSummary(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.space-quota.usage-summary")
+
+	spaceGUID := routing.URLParam(r, "guid")
+
+	usage, err := h.spaceQuotaRepo.GetSpaceUsageSummary(r.Context(), authInfo, spaceGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get space usage summary", "guid", spaceGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForSpaceUsageSummary(usage, spaceGUID, h.serverURL)), nil
+}
+
+func (h *SpaceQuota) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *SpaceQuota) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "POST", Pattern: SpaceQuotasPath, Handler: h.create},
+		{Method: "GET", Pattern: SpaceQuotasPath, Handler: h.list},
+		{Method: "GET", Pattern: SpaceQuotaPath, Handler: h.get},
+		{Method: "PATCH", Pattern: SpaceQuotaPath, Handler: h.update},
+		{Method: "DELETE", Pattern: SpaceQuotaPath, Handler: h.delete},
+		{Method: "POST", Pattern: SpaceQuotaSpacesPath, Handler: h.apply},
+		{Method: "DELETE", Pattern: SpaceQuotaSpacePath, Handler: h.remove},
+		{Method: "GET", Pattern: SpaceUsageSummaryPath, Handler: h.usageSummary},
+	}
+}
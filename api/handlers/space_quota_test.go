@@ -0,0 +1,353 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("SpaceQuota", func() {
+	var (
+		apiHandler       *handlers.SpaceQuota
+		spaceQuotaRepo   *fake.CFSpaceQuotaRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		spaceQuotaRepo = new(fake.CFSpaceQuotaRepository)
+		spaceQuotaRepo.GetSpaceQuotaReturns(repositories.SpaceQuotaRecord{
+			GUID:    "quota-guid",
+			Name:    "my-quota",
+			OrgGUID: "org-guid",
+		}, nil)
+
+		apiHandler = handlers.NewSpaceQuota(
+			*serverURL,
+			spaceQuotaRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("POST /v3/space_quotas", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SpaceQuotaCreate{
+				Name: "my-quota",
+				QuotaLimits: payloads.QuotaLimits{
+					Services: &payloads.QuotaServices{TotalServiceInstances: tools.PtrTo[int64](2)},
+				},
+				Relationships: &payloads.SpaceQuotaRelationships{
+					Organization: &payloads.Relationship{Data: &payloads.RelationshipData{GUID: "org-guid"}},
+				},
+			})
+
+			spaceQuotaRepo.CreateSpaceQuotaReturns(repositories.SpaceQuotaRecord{
+				GUID:    "quota-guid",
+				Name:    "my-quota",
+				OrgGUID: "org-guid",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/space_quotas", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("creates the space quota", func() {
+			Expect(spaceQuotaRepo.CreateSpaceQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, createMessage := spaceQuotaRepo.CreateSpaceQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(createMessage).To(Equal(repositories.CreateSpaceQuotaMessage{
+				Name: "my-quota",
+				Limits: repositories.QuotaLimits{
+					Services: repositories.QuotaServiceLimits{TotalServiceInstances: tools.PtrTo[int64](2)},
+				},
+				OrgGUID:    "org-guid",
+				SpaceGUIDs: []string{},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusCreated))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/json"))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "quota-guid"),
+				MatchJSONPath("$.relationships.organization.data.guid", "org-guid"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/space_quotas/quota-guid"),
+			)))
+		})
+
+		When("creating the space quota fails", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.CreateSpaceQuotaReturns(repositories.SpaceQuotaRecord{}, apierrors.NewUnprocessableEntityError(nil, "Space Quota 'my-quota' already exists."))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("Space Quota 'my-quota' already exists.")
+			})
+		})
+	})
+
+	Describe("GET /v3/space_quotas/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/space_quotas/quota-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the space quota", func() {
+			Expect(spaceQuotaRepo.GetSpaceQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := spaceQuotaRepo.GetSpaceQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("quota-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "quota-guid"),
+				MatchJSONPath("$.name", "my-quota"),
+			)))
+		})
+
+		When("the user is not allowed to see the space quota", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.GetSpaceQuotaReturns(repositories.SpaceQuotaRecord{}, apierrors.NewForbiddenError(nil, repositories.SpaceQuotaResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SpaceQuotaResourceType)
+			})
+		})
+	})
+
+	Describe("GET /v3/space_quotas", func() {
+		BeforeEach(func() {
+			spaceQuotaRepo.ListSpaceQuotasReturns([]repositories.SpaceQuotaRecord{
+				{GUID: "quota-1"},
+				{GUID: "quota-2"},
+			}, nil)
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.SpaceQuotaList{
+				SpaceGUIDs: "space-guid",
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/space_quotas?space_guids=space-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the space quotas", func() {
+			Expect(spaceQuotaRepo.ListSpaceQuotasCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := spaceQuotaRepo.ListSpaceQuotasArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.SpaceGUIDs).To(ConsistOf("space-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "quota-1"),
+				MatchJSONPath("$.resources[1].guid", "quota-2"),
+			)))
+		})
+
+		When("listing the space quotas fails", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.ListSpaceQuotasReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("PATCH /v3/space_quotas/:guid", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SpaceQuotaUpdate{
+				Name: tools.PtrTo("new-name"),
+			})
+
+			spaceQuotaRepo.UpdateSpaceQuotaReturns(repositories.SpaceQuotaRecord{
+				GUID: "quota-guid",
+				Name: "new-name",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "PATCH", "/v3/space_quotas/quota-guid", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("updates the space quota", func() {
+			Expect(spaceQuotaRepo.UpdateSpaceQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := spaceQuotaRepo.UpdateSpaceQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.GUID).To(Equal("quota-guid"))
+			Expect(message.Name).To(PointTo(Equal("new-name")))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(MatchJSONPath("$.name", "new-name")))
+		})
+
+		When("the space quota does not exist", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.GetSpaceQuotaReturns(repositories.SpaceQuotaRecord{}, apierrors.NewNotFoundError(nil, repositories.SpaceQuotaResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.SpaceQuotaResourceType)
+				Expect(spaceQuotaRepo.UpdateSpaceQuotaCallCount()).To(BeZero())
+			})
+		})
+	})
+
+	Describe("DELETE /v3/space_quotas/:guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/space_quotas/quota-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("deletes the space quota", func() {
+			Expect(spaceQuotaRepo.DeleteSpaceQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := spaceQuotaRepo.DeleteSpaceQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("quota-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusAccepted))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Location", "https://api.example.org/v3/jobs/space_quota.delete~quota-guid"))
+		})
+
+		When("deleting the space quota fails", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.DeleteSpaceQuotaReturns(errors.New("delete-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("POST /v3/space_quotas/:guid/relationships/spaces", func() {
+		BeforeEach(func() {
+			requestValidator.DecodeAndValidateJSONPayloadStub = decodeAndValidatePayloadStub(&payloads.SpaceQuotaApply{
+				Data: []payloads.RelationshipData{{GUID: "space-1"}},
+			})
+			spaceQuotaRepo.ApplySpaceQuotaReturns(repositories.SpaceQuotaRecord{
+				GUID:       "quota-guid",
+				SpaceGUIDs: []string{"space-1", "space-2"},
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "POST", "/v3/space_quotas/quota-guid/relationships/spaces", strings.NewReader("the-json-body"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("applies the space quota to the spaces", func() {
+			Expect(spaceQuotaRepo.ApplySpaceQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := spaceQuotaRepo.ApplySpaceQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.ApplySpaceQuotaMessage{
+				GUID:       "quota-guid",
+				SpaceGUIDs: []string{"space-1"},
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.data[*].guid", ConsistOf("space-1", "space-2")),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/space_quotas/quota-guid/relationships/spaces"),
+			)))
+		})
+
+		When("applying the space quota fails", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.ApplySpaceQuotaReturns(repositories.SpaceQuotaRecord{}, apierrors.NewUnprocessableEntityError(nil, "not in org"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("not in org")
+			})
+		})
+	})
+
+	Describe("DELETE /v3/space_quotas/:guid/relationships/spaces/:space_guid", func() {
+		BeforeEach(func() {
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "DELETE", "/v3/space_quotas/quota-guid/relationships/spaces/space-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("removes the space quota from the space", func() {
+			Expect(spaceQuotaRepo.RemoveSpaceQuotaCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := spaceQuotaRepo.RemoveSpaceQuotaArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message).To(Equal(repositories.RemoveSpaceQuotaMessage{
+				GUID:      "quota-guid",
+				SpaceGUID: "space-guid",
+			}))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusNoContent))
+		})
+
+		When("the space quota is not applied to the space", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.RemoveSpaceQuotaReturns(apierrors.NewUnprocessableEntityError(nil, "not applied"))
+			})
+
+			It("returns an error", func() {
+				expectUnprocessableEntityError("not applied")
+			})
+		})
+	})
+
+	Describe("GET /v3/spaces/:guid/usage_summary", func() {
+		BeforeEach(func() {
+			spaceQuotaRepo.GetSpaceUsageSummaryReturns(repositories.UsageSummaryRecord{
+				Routes:           3,
+				ServiceInstances: 1,
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/spaces/space-guid/usage_summary", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the usage summary of the space", func() {
+			Expect(spaceQuotaRepo.GetSpaceUsageSummaryCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualSpaceGUID := spaceQuotaRepo.GetSpaceUsageSummaryArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualSpaceGUID).To(Equal("space-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.usage_summary.routes", BeEquivalentTo(3)),
+				MatchJSONPath("$.usage_summary.service_instances", BeEquivalentTo(1)),
+				MatchJSONPath("$.links.space.href", "https://api.example.org/v3/spaces/space-guid"),
+			)))
+		})
+
+		When("getting the usage summary fails", func() {
+			BeforeEach(func() {
+				spaceQuotaRepo.GetSpaceUsageSummaryReturns(repositories.UsageSummaryRecord{}, errors.New("usage-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+})
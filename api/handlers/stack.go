@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+const (
+	StacksPath = "/v3/stacks"
+	StackPath  = "/v3/stacks/{guid}"
+)
+
+//counterfeiter:generate -o fake -fake-name CFStackRepository . CFStackRepository
+type CFStackRepository interface {
+	ListStacks(context.Context, authorization.Info, repositories.ListStacksMessage) ([]repositories.StackRecord, error)
+	GetStack(context.Context, authorization.Info, string) (repositories.StackRecord, error)
+}
+
+//counterfeiter:generate -o fake -fake-name StackChecker . StackChecker
+type StackChecker interface {
+	CheckStack(context.Context, authorization.Info, string) error
+}
+
+type Stack struct {
+	serverURL        url.URL
+	stackRepo        CFStackRepository
+	requestValidator RequestValidator
+}
+
+func NewStack(
+	serverURL url.URL,
+	stackRepo CFStackRepository,
+	requestValidator RequestValidator,
+) *Stack {
+	return &Stack{
+		serverURL:        serverURL,
+		stackRepo:        stackRepo,
+		requestValidator: requestValidator,
+	}
+}
+
+func (h *Stack) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.stack.list")
+
+	listFilter := new(payloads.StackList)
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, listFilter); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	stacks, err := h.stackRepo.ListStacks(r.Context(), authInfo, listFilter.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list stacks")
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(presenter.ForStack, stacks, h.serverURL, *r.URL)), nil
+}
+
+func (h *Stack) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName("handlers.stack.get")
+
+	stackGUID := routing.URLParam(r, "guid")
+
+	stack, err := h.stackRepo.GetStack(r.Context(), authInfo, stackGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get stack", "guid", stackGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForStack(stack, h.serverURL)), nil
+}
+
+func (h *Stack) UnauthenticatedRoutes() []routing.Route {
+	return nil
+}
+
+func (h *Stack) AuthenticatedRoutes() []routing.Route {
+	return []routing.Route{
+		{Method: "GET", Pattern: StacksPath, Handler: h.list},
+		{Method: "GET", Pattern: StackPath, Handler: h.get},
+	}
+}
@@ -0,0 +1,130 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stack", func() {
+	var (
+		apiHandler       *handlers.Stack
+		stackRepo        *fake.CFStackRepository
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+		stackRepo = new(fake.CFStackRepository)
+
+		apiHandler = handlers.NewStack(
+			*serverURL,
+			stackRepo,
+			requestValidator,
+		)
+		routerBuilder.LoadRoutes(apiHandler)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	Describe("GET /v3/stacks", func() {
+		BeforeEach(func() {
+			stackRepo.ListStacksReturns([]repositories.StackRecord{
+				{GUID: "stack-1", Name: "io.buildpacks.stacks.bionic"},
+				{GUID: "stack-2", Name: "io.buildpacks.stacks.jammy"},
+			}, nil)
+
+			requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.StackList{
+				Names: "n1,n2",
+			})
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/stacks?names=n1,n2", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("lists the stacks", func() {
+			Expect(stackRepo.ListStacksCallCount()).To(Equal(1))
+			_, actualAuthInfo, message := stackRepo.ListStacksArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(message.Names).To(ConsistOf("n1", "n2"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+				MatchJSONPath("$.resources[0].guid", "stack-1"),
+				MatchJSONPath("$.resources[0].name", "io.buildpacks.stacks.bionic"),
+				MatchJSONPath("$.resources[1].guid", "stack-2"),
+			)))
+		})
+
+		When("the query is invalid", func() {
+			BeforeEach(func() {
+				requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+
+		When("listing the stacks fails", func() {
+			BeforeEach(func() {
+				stackRepo.ListStacksReturns(nil, errors.New("list-err"))
+			})
+
+			It("returns an error", func() {
+				expectUnknownError()
+			})
+		})
+	})
+
+	Describe("GET /v3/stacks/:guid", func() {
+		BeforeEach(func() {
+			stackRepo.GetStackReturns(repositories.StackRecord{
+				GUID: "stack-guid",
+				Name: "io.buildpacks.stacks.jammy",
+			}, nil)
+
+			var err error
+			req, err = http.NewRequestWithContext(ctx, "GET", "/v3/stacks/stack-guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the stack", func() {
+			Expect(stackRepo.GetStackCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualGUID := stackRepo.GetStackArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authInfo))
+			Expect(actualGUID).To(Equal("stack-guid"))
+
+			Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+			Expect(rr).To(HaveHTTPBody(SatisfyAll(
+				MatchJSONPath("$.guid", "stack-guid"),
+				MatchJSONPath("$.name", "io.buildpacks.stacks.jammy"),
+				MatchJSONPath("$.links.self.href", "https://api.example.org/v3/stacks/stack-guid"),
+			)))
+		})
+
+		When("the stack does not exist", func() {
+			BeforeEach(func() {
+				stackRepo.GetStackReturns(repositories.StackRecord{}, apierrors.NewNotFoundError(nil, repositories.StackResourceType))
+			})
+
+			It("returns a not found error", func() {
+				expectNotFoundError(repositories.StackResourceType)
+			})
+		})
+	})
+})
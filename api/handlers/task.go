@@ -33,23 +33,26 @@ type CFTaskRepository interface {
 }
 
 type Task struct {
-	serverURL        url.URL
-	appRepo          CFAppRepository
-	taskRepo         CFTaskRepository
-	requestValidator RequestValidator
+	serverURL          url.URL
+	appRepo            CFAppRepository
+	taskRepo           CFTaskRepository
+	featureFlagChecker FeatureFlagChecker
+	requestValidator   RequestValidator
 }
 
 func NewTask(
 	serverURL url.URL,
 	appRepo CFAppRepository,
 	taskRepo CFTaskRepository,
+	featureFlagChecker FeatureFlagChecker,
 	requestValidator RequestValidator,
 ) *Task {
 	return &Task{
-		serverURL:        serverURL,
-		taskRepo:         taskRepo,
-		appRepo:          appRepo,
-		requestValidator: requestValidator,
+		serverURL:          serverURL,
+		taskRepo:           taskRepo,
+		appRepo:            appRepo,
+		featureFlagChecker: featureFlagChecker,
+		requestValidator:   requestValidator,
 	}
 }
 
@@ -90,6 +93,10 @@ func (h *Task) create(r *http.Request) (*routing.Response, error) {
 		return nil, apierrors.LogAndReturn(logger, err, "failed to decode payload")
 	}
 
+	if err := h.featureFlagChecker.CheckFeatureFlag(r.Context(), authInfo, repositories.FeatureFlagTaskCreation); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "task creation is disabled")
+	}
+
 	appRecord, err := h.appRepo.GetApp(r.Context(), authInfo, appGUID)
 	if err != nil {
 		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "error finding app", "appGUID", appGUID)
@@ -22,11 +22,12 @@ import (
 
 var _ = Describe("Task", func() {
 	var (
-		requestMethod    string
-		requestPath      string
-		appRepo          *fake.CFAppRepository
-		taskRepo         *fake.CFTaskRepository
-		requestValidator *fake.RequestValidator
+		requestMethod      string
+		requestPath        string
+		appRepo            *fake.CFAppRepository
+		taskRepo           *fake.CFTaskRepository
+		featureFlagChecker *fake.FeatureFlagChecker
+		requestValidator   *fake.RequestValidator
 	)
 
 	BeforeEach(func() {
@@ -47,9 +48,10 @@ var _ = Describe("Task", func() {
 			SpaceGUID: "the-space-guid",
 		}, nil)
 
+		featureFlagChecker = new(fake.FeatureFlagChecker)
 		requestValidator = new(fake.RequestValidator)
 
-		apiHandler := handlers.NewTask(*serverURL, appRepo, taskRepo, requestValidator)
+		apiHandler := handlers.NewTask(*serverURL, appRepo, taskRepo, featureFlagChecker, requestValidator)
 		routerBuilder.LoadRoutes(apiHandler)
 	})
 
@@ -99,6 +101,22 @@ var _ = Describe("Task", func() {
 			)))
 		})
 
+		When("the task_creation feature flag is disabled", func() {
+			BeforeEach(func() {
+				featureFlagChecker.CheckFeatureFlagReturns(apierrors.NewFeatureDisabledError(nil, repositories.FeatureFlagTaskCreation, ""))
+			})
+
+			It("returns a feature disabled error", func() {
+				Expect(featureFlagChecker.CheckFeatureFlagCallCount()).To(Equal(1))
+				_, actualAuthInfo, actualFlag := featureFlagChecker.CheckFeatureFlagArgsForCall(0)
+				Expect(actualAuthInfo).To(Equal(authInfo))
+				Expect(actualFlag).To(Equal(repositories.FeatureFlagTaskCreation))
+
+				expectErrorResponse(http.StatusForbidden, "CF-FeatureDisabled", "Feature Disabled: task_creation", 330002)
+				Expect(taskRepo.CreateTaskCallCount()).To(BeZero())
+			})
+		})
+
 		When("the app does not exist", func() {
 			BeforeEach(func() {
 				appRepo.GetAppReturns(repositories.AppRecord{}, apierrors.NewNotFoundError(nil, repositories.AppResourceType))
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/routing"
+
+	"github.com/go-logr/logr"
+)
+
+type usageEventListFilter[M any] interface {
+	validation.KeyedPayload
+	ToMessage() M
+}
+
+// usageEvents implements the endpoints the app and the service usage events
+// have in common. M is the list message, R the record and S the presented
+// response of the usage event.
+type usageEvents[M, R, S any] struct {
+	serverURL        url.URL
+	requestValidator RequestValidator
+	loggerName       string
+	eventsName       string
+	newListFilter    func() usageEventListFilter[M]
+	listEvents       func(context.Context, authorization.Info, M) ([]R, error)
+	getEvent         func(context.Context, authorization.Info, string) (R, error)
+	purgeAndReseed   func(context.Context, authorization.Info) error
+	present          func(R, url.URL) S
+}
+
+func (h usageEvents[M, R, S]) list(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName(h.loggerName + ".list")
+
+	listFilter := h.newListFilter()
+	if err := h.requestValidator.DecodeAndValidateURLValues(r, listFilter); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "Unable to decode request query parameters")
+	}
+
+	events, err := h.listEvents(r.Context(), authInfo, listFilter.ToMessage())
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to list "+h.eventsName)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(presenter.ForList(h.present, events, h.serverURL, *r.URL)), nil
+}
+
+func (h usageEvents[M, R, S]) get(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName(h.loggerName + ".get")
+
+	eventGUID := routing.URLParam(r, "guid")
+
+	event, err := h.getEvent(r.Context(), authInfo, eventGUID)
+	if err != nil {
+		return nil, apierrors.LogAndReturn(logger, apierrors.ForbiddenAsNotFound(err), "failed to get usage event", "guid", eventGUID)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(h.present(event, h.serverURL)), nil
+}
+
+func (h usageEvents[M, R, S]) purgeAndReseedEvents(r *http.Request) (*routing.Response, error) {
+	authInfo, _ := authorization.InfoFromContext(r.Context())
+	logger := logr.FromContextOrDiscard(r.Context()).WithName(h.loggerName + ".purge-and-reseed")
+
+	if err := h.purgeAndReseed(r.Context(), authInfo); err != nil {
+		return nil, apierrors.LogAndReturn(logger, err, "failed to purge and reseed "+h.eventsName)
+	}
+
+	return routing.NewResponse(http.StatusOK).WithBody(map[string]interface{}{}), nil
+}
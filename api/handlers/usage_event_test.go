@@ -0,0 +1,241 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/handlers"
+	"code.cloudfoundry.org/korifi/api/handlers/fake"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/routing"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/types"
+)
+
+// usageEventsFixture adapts the app and the service usage event handlers to
+// the specs they share
+type usageEventsFixture struct {
+	path              string
+	resourceType      string
+	listQuery         string
+	handler           routing.Routable
+	stubListFilter    func()
+	listReturns       func(guids []string, err error)
+	expectListMessage func()
+	listedEvent       types.GomegaMatcher
+	getReturns        func(guid string, err error)
+	getGUID           func() string
+	purgeReturns      func(err error)
+	purgeCallCount    func() int
+}
+
+var _ = Describe("UsageEvent", func() {
+	var (
+		fixture          usageEventsFixture
+		requestValidator *fake.RequestValidator
+		req              *http.Request
+	)
+
+	BeforeEach(func() {
+		requestValidator = new(fake.RequestValidator)
+	})
+
+	JustBeforeEach(func() {
+		routerBuilder.LoadRoutes(fixture.handler)
+		routerBuilder.Build().ServeHTTP(rr, req)
+	})
+
+	usageEventSpecs := func() {
+		Describe("GET the usage events", func() {
+			BeforeEach(func() {
+				fixture.listReturns([]string{"event-1", "event-2"}, nil)
+				fixture.stubListFilter()
+
+				var err error
+				req, err = http.NewRequestWithContext(ctx, "GET", fixture.path+"?"+fixture.listQuery, nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("lists the usage events", func() {
+				fixture.expectListMessage()
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.pagination.total_results", BeEquivalentTo(2)),
+					MatchJSONPath("$.resources[0].guid", "event-1"),
+					MatchJSONPath("$.resources[1].guid", "event-2"),
+					fixture.listedEvent,
+				)))
+			})
+
+			When("the query is invalid", func() {
+				BeforeEach(func() {
+					requestValidator.DecodeAndValidateURLValuesStub = nil
+					requestValidator.DecodeAndValidateURLValuesReturns(errors.New("boom"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+
+			When("listing the usage events fails", func() {
+				BeforeEach(func() {
+					fixture.listReturns(nil, errors.New("list-err"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+		})
+
+		Describe("GET a usage event", func() {
+			BeforeEach(func() {
+				fixture.getReturns("event-guid", nil)
+
+				var err error
+				req, err = http.NewRequestWithContext(ctx, "GET", fixture.path+"/event-guid", nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns the usage event", func() {
+				Expect(fixture.getGUID()).To(Equal("event-guid"))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(SatisfyAll(
+					MatchJSONPath("$.guid", "event-guid"),
+					MatchJSONPath("$.links.self.href", "https://api.example.org"+fixture.path+"/event-guid"),
+				)))
+			})
+
+			When("the user is not allowed to get the event", func() {
+				BeforeEach(func() {
+					fixture.getReturns("", apierrors.NewForbiddenError(nil, fixture.resourceType))
+				})
+
+				It("returns a not found error", func() {
+					expectNotFoundError(fixture.resourceType)
+				})
+			})
+		})
+
+		Describe("POST destructively_purge_all_and_reseed", func() {
+			BeforeEach(func() {
+				var err error
+				req, err = http.NewRequestWithContext(ctx, "POST", fixture.path+"/actions/destructively_purge_all_and_reseed", nil)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("purges and reseeds the usage events", func() {
+				Expect(fixture.purgeCallCount()).To(Equal(1))
+
+				Expect(rr).To(HaveHTTPStatus(http.StatusOK))
+				Expect(rr).To(HaveHTTPBody(MatchJSON("{}")))
+			})
+
+			When("purging the usage events fails", func() {
+				BeforeEach(func() {
+					fixture.purgeReturns(errors.New("purge-err"))
+				})
+
+				It("returns an error", func() {
+					expectUnknownError()
+				})
+			})
+		})
+	}
+
+	Describe("app usage events", func() {
+		BeforeEach(func() {
+			appUsageEventRepo := new(fake.CFAppUsageEventRepository)
+			fixture = usageEventsFixture{
+				path:         "/v3/app_usage_events",
+				resourceType: repositories.AppUsageEventResourceType,
+				listQuery:    "after_guid=event-0",
+				handler:      handlers.NewAppUsageEvent(*serverURL, appUsageEventRepo, requestValidator),
+				stubListFilter: func() {
+					requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.AppUsageEventList{
+						AfterGUID: "event-0",
+					})
+				},
+				listReturns: func(guids []string, err error) {
+					records := []repositories.AppUsageEventRecord{}
+					for _, guid := range guids {
+						records = append(records, repositories.AppUsageEventRecord{GUID: guid, State: "STOPPED", PreviousState: "STARTED"})
+					}
+					appUsageEventRepo.ListAppUsageEventsReturns(records, err)
+				},
+				expectListMessage: func() {
+					Expect(appUsageEventRepo.ListAppUsageEventsCallCount()).To(Equal(1))
+					_, actualAuthInfo, message := appUsageEventRepo.ListAppUsageEventsArgsForCall(0)
+					Expect(actualAuthInfo).To(Equal(authInfo))
+					Expect(message.AfterGUID).To(Equal("event-0"))
+				},
+				listedEvent: MatchJSONPath("$.resources[0].state.previous", "STARTED"),
+				getReturns: func(guid string, err error) {
+					appUsageEventRepo.GetAppUsageEventReturns(repositories.AppUsageEventRecord{GUID: guid}, err)
+				},
+				getGUID: func() string {
+					Expect(appUsageEventRepo.GetAppUsageEventCallCount()).To(Equal(1))
+					_, actualAuthInfo, guid := appUsageEventRepo.GetAppUsageEventArgsForCall(0)
+					Expect(actualAuthInfo).To(Equal(authInfo))
+					return guid
+				},
+				purgeReturns:   appUsageEventRepo.PurgeAndReseedAppUsageEventsReturns,
+				purgeCallCount: appUsageEventRepo.PurgeAndReseedAppUsageEventsCallCount,
+			}
+		})
+
+		usageEventSpecs()
+	})
+
+	Describe("service usage events", func() {
+		BeforeEach(func() {
+			serviceUsageEventRepo := new(fake.CFServiceUsageEventRepository)
+			fixture = usageEventsFixture{
+				path:         "/v3/service_usage_events",
+				resourceType: repositories.ServiceUsageEventResourceType,
+				listQuery:    "service_instance_types=managed_service_instance",
+				handler:      handlers.NewServiceUsageEvent(*serverURL, serviceUsageEventRepo, requestValidator),
+				stubListFilter: func() {
+					requestValidator.DecodeAndValidateURLValuesStub = decodeAndValidateURLValuesStub(&payloads.ServiceUsageEventList{
+						ServiceInstanceTypes: "managed_service_instance",
+					})
+				},
+				listReturns: func(guids []string, err error) {
+					records := []repositories.ServiceUsageEventRecord{}
+					for _, guid := range guids {
+						records = append(records, repositories.ServiceUsageEventRecord{GUID: guid, State: "CREATED", ServiceInstanceType: "managed_service_instance"})
+					}
+					serviceUsageEventRepo.ListServiceUsageEventsReturns(records, err)
+				},
+				expectListMessage: func() {
+					Expect(serviceUsageEventRepo.ListServiceUsageEventsCallCount()).To(Equal(1))
+					_, actualAuthInfo, message := serviceUsageEventRepo.ListServiceUsageEventsArgsForCall(0)
+					Expect(actualAuthInfo).To(Equal(authInfo))
+					Expect(message.ServiceInstanceTypes).To(ConsistOf("managed_service_instance"))
+				},
+				listedEvent: MatchJSONPath("$.resources[0].service_instance.type", "managed_service_instance"),
+				getReturns: func(guid string, err error) {
+					serviceUsageEventRepo.GetServiceUsageEventReturns(repositories.ServiceUsageEventRecord{GUID: guid}, err)
+				},
+				getGUID: func() string {
+					Expect(serviceUsageEventRepo.GetServiceUsageEventCallCount()).To(Equal(1))
+					_, actualAuthInfo, guid := serviceUsageEventRepo.GetServiceUsageEventArgsForCall(0)
+					Expect(actualAuthInfo).To(Equal(authInfo))
+					return guid
+				},
+				purgeReturns:   serviceUsageEventRepo.PurgeAndReseedServiceUsageEventsReturns,
+				purgeCallCount: serviceUsageEventRepo.PurgeAndReseedServiceUsageEventsCallCount,
+			}
+		})
+
+		usageEventSpecs()
+	})
+})
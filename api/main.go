@@ -155,6 +155,8 @@ func main() {
 		userClientFactory,
 		namespaceRetriever,
 		nsPermissions,
+		toolsregistry.NewRepositoryCreator(cfg.ContainerRegistryType),
+		cfg.ContainerRepositoryPrefix,
 	)
 	routeRepo := repositories.NewRouteRepo(
 		namespaceRetriever,
@@ -191,6 +193,8 @@ func main() {
 		namespaceRetriever,
 		userClientFactory,
 		nsPermissions,
+		privilegedCRClient,
+		cfg.RootNamespace,
 	)
 	serviceBindingRepo := repositories.NewServiceBindingRepo(
 		namespaceRetriever,
@@ -198,10 +202,76 @@ func main() {
 		nsPermissions,
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFServiceBinding, korifiv1alpha1.CFServiceBindingList](createTimeout),
 	)
+	serviceRouteBindingRepo := repositories.NewServiceRouteBindingRepo(
+		namespaceRetriever,
+		userClientFactory,
+		nsPermissions,
+		conditions.NewConditionAwaiter[*korifiv1alpha1.CFServiceRouteBinding, korifiv1alpha1.CFServiceRouteBindingList](createTimeout),
+	)
+	serviceBrokerRepo := repositories.NewServiceBrokerRepo(
+		userClientFactory,
+		cfg.RootNamespace,
+	)
+	serviceOfferingRepo := repositories.NewServiceOfferingRepo(
+		userClientFactory,
+		nsPermissions,
+		namespaceRetriever,
+		cfg.RootNamespace,
+	)
+	servicePlanRepo := repositories.NewServicePlanRepo(
+		userClientFactory,
+		nsPermissions,
+		namespaceRetriever,
+		cfg.RootNamespace,
+	)
+	securityGroupRepo := repositories.NewSecurityGroupRepo(
+		userClientFactory,
+		namespaceRetriever,
+		nsPermissions,
+		cfg.RootNamespace,
+	)
+	orgQuotaRepo := repositories.NewOrgQuotaRepo(
+		userClientFactory,
+		nsPermissions,
+		privilegedCRClient,
+		cfg.RootNamespace,
+	)
+	spaceQuotaRepo := repositories.NewSpaceQuotaRepo(
+		userClientFactory,
+		namespaceRetriever,
+		nsPermissions,
+		privilegedCRClient,
+		cfg.RootNamespace,
+	)
+	featureFlagRepo := repositories.NewFeatureFlagRepo(
+		userClientFactory,
+		privilegedCRClient,
+		cfg.RootNamespace,
+	)
+	sshCodeRepo := repositories.NewSSHCodeRepo(
+		privilegedCRClient,
+		cfg.RootNamespace,
+	)
+	envVarGroupRepo := repositories.NewEnvVarGroupRepo(
+		userClientFactory,
+		cfg.RootNamespace,
+	)
+	isolationSegmentRepo := repositories.NewIsolationSegmentRepo(
+		userClientFactory,
+		namespaceRetriever,
+		nsPermissions,
+		privilegedCRClient,
+		cfg.RootNamespace,
+	)
 	buildpackRepo := repositories.NewBuildpackRepository(cfg.BuilderName,
 		userClientFactory,
 		cfg.RootNamespace,
 	)
+	stackRepo := repositories.NewStackRepository(cfg.BuilderName,
+		userClientFactory,
+		cfg.RootNamespace,
+		cfg.DefaultLifecycleConfig.Stack,
+	)
 	roleRepo := repositories.NewRoleRepo(
 		userClientFactory,
 		spaceRepo,
@@ -219,6 +289,13 @@ func main() {
 		cfg.PackageRegistrySecretNames,
 		cfg.RootNamespace,
 	)
+	resourceCacheRepo := repositories.NewResourceCacheRepo(
+		imageClient,
+		toolsregistry.NewRepositoryCreator(cfg.ContainerRegistryType),
+		cfg.ContainerRepositoryPrefix,
+		cfg.PackageRegistrySecretNames,
+		cfg.RootNamespace,
+	)
 	taskRepo := repositories.NewTaskRepo(
 		userClientFactory,
 		namespaceRetriever,
@@ -226,16 +303,43 @@ func main() {
 		conditions.NewConditionAwaiter[*korifiv1alpha1.CFTask, korifiv1alpha1.CFTaskList](createTimeout),
 	)
 	metricsRepo := repositories.NewMetricsRepo(userClientFactory)
+	auditEventRepo := repositories.NewAuditEventRepo(
+		userClientFactory,
+		namespaceRetriever,
+		nsPermissions,
+		privilegedCRClient,
+		cachingIdentityProvider,
+	)
+	appUsageEventRepo := repositories.NewAppUsageEventRepo(
+		userClientFactory,
+		nsPermissions,
+		cfg.RootNamespace,
+	)
+	serviceUsageEventRepo := repositories.NewServiceUsageEventRepo(
+		userClientFactory,
+		nsPermissions,
+		cfg.RootNamespace,
+	)
+	revisionRepo := repositories.NewRevisionRepo(
+		userClientFactory,
+		namespaceRetriever,
+	)
+	sidecarRepo := repositories.NewSidecarRepo(
+		userClientFactory,
+		namespaceRetriever,
+	)
 
 	processStats := actions.NewProcessStats(processRepo, appRepo, metricsRepo)
 	manifest := actions.NewManifest(
 		domainRepo,
 		cfg.DefaultDomainName,
-		manifest.NewStateCollector(appRepo, domainRepo, processRepo, routeRepo),
+		manifest.NewStateCollector(appRepo, domainRepo, processRepo, routeRepo, sidecarRepo),
 		manifest.NewNormalizer(cfg.DefaultDomainName),
-		manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo),
+		manifest.NewApplier(appRepo, domainRepo, processRepo, routeRepo, sidecarRepo),
+		manifest.NewDiffer(),
 	)
 	appLogs := actions.NewAppLogs(appRepo, buildRepo, podRepo)
+	sshAccess := actions.NewSSHAccess(processRepo, appRepo, spaceRepo, podRepo, featureFlagRepo)
 
 	requestValidator := validation.NewDefaultDecoderValidator()
 
@@ -262,8 +366,12 @@ func main() {
 
 	apiHandlers := []routing.Routable{
 		handlers.NewRootV3(*serverURL),
-		handlers.NewRoot(*serverURL),
-		handlers.NewResourceMatches(),
+		handlers.NewRoot(*serverURL, cfg.SSHProxy),
+		handlers.NewResourceMatches(
+			resourceCacheRepo,
+			featureFlagRepo,
+			requestValidator,
+		),
 		handlers.NewApp(
 			*serverURL,
 			appRepo,
@@ -273,6 +381,16 @@ func main() {
 			domainRepo,
 			spaceRepo,
 			packageRepo,
+			envVarGroupRepo,
+			stackRepo,
+			featureFlagRepo,
+			auditEventRepo,
+			requestValidator,
+		),
+		handlers.NewAppFeature(
+			*serverURL,
+			appRepo,
+			sshAccess,
 			requestValidator,
 		),
 		handlers.NewRoute(
@@ -281,10 +399,16 @@ func main() {
 			domainRepo,
 			appRepo,
 			spaceRepo,
+			featureFlagRepo,
+			auditEventRepo,
 			requestValidator,
 		),
 		handlers.NewServiceRouteBinding(
 			*serverURL,
+			serviceRouteBindingRepo,
+			serviceInstanceRepo,
+			routeRepo,
+			requestValidator,
 		),
 		handlers.NewPackage(
 			*serverURL,
@@ -292,6 +416,8 @@ func main() {
 			appRepo,
 			dropletRepo,
 			imageRepo,
+			resourceCacheRepo,
+			featureFlagRepo,
 			requestValidator,
 			cfg.PackageRegistrySecretNames,
 		),
@@ -300,18 +426,24 @@ func main() {
 			buildRepo,
 			packageRepo,
 			appRepo,
+			stackRepo,
 			requestValidator,
 		),
 		handlers.NewDroplet(
 			*serverURL,
 			dropletRepo,
+			appRepo,
+			imageRepo,
 			requestValidator,
+			cfg.PackageRegistrySecretNames,
 		),
 		handlers.NewProcess(
 			*serverURL,
 			processRepo,
 			processStats,
+			sidecarRepo,
 			requestValidator,
+			featureFlagRepo,
 		),
 		handlers.NewDomain(
 			*serverURL,
@@ -334,6 +466,19 @@ func main() {
 				handlers.RouteDeleteJobType:  routeRepo,
 				handlers.DomainDeleteJobType: domainRepo,
 				handlers.RoleDeleteJobType:   roleRepo,
+
+				handlers.ServiceBrokerDeleteJobType: serviceBrokerRepo,
+				handlers.SecurityGroupDeleteJobType: securityGroupRepo,
+				handlers.OrgQuotaDeleteJobType:      orgQuotaRepo,
+				handlers.SpaceQuotaDeleteJobType:    spaceQuotaRepo,
+			},
+			map[string]handlers.StateRepository{
+				handlers.ServiceBrokerCreateJobType:   serviceBrokerRepo,
+				handlers.ServiceBrokerUpdateJobType:   serviceBrokerRepo,
+				handlers.ServiceInstanceCreateJobType: serviceInstanceRepo,
+				handlers.ServiceInstanceUpdateJobType: serviceInstanceRepo,
+				handlers.ServiceInstanceDeleteJobType: handlers.StateRepositoryFunc(serviceInstanceRepo.GetDeletionState),
+				handlers.DropletUploadJobType:         dropletRepo,
 			},
 			500*time.Millisecond,
 		),
@@ -347,6 +492,7 @@ func main() {
 			*serverURL,
 			orgRepo,
 			domainRepo,
+			featureFlagRepo,
 			requestValidator,
 			cfg.GetUserCertificateDuration(),
 			cfg.DefaultDomainName,
@@ -356,6 +502,11 @@ func main() {
 			spaceRepo,
 			requestValidator,
 		),
+		handlers.NewSpaceFeature(
+			*serverURL,
+			spaceRepo,
+			requestValidator,
+		),
 		handlers.NewSpaceManifest(
 			*serverURL,
 			manifest,
@@ -366,6 +517,7 @@ func main() {
 			*serverURL,
 			roleRepo,
 			requestValidator,
+			featureFlagRepo,
 		),
 		handlers.NewWhoAmI(cachingIdentityProvider, *serverURL),
 		handlers.NewUser(*serverURL),
@@ -374,10 +526,61 @@ func main() {
 			buildpackRepo,
 			requestValidator,
 		),
+		handlers.NewStack(
+			*serverURL,
+			stackRepo,
+			requestValidator,
+		),
+		handlers.NewAuditEvent(
+			*serverURL,
+			auditEventRepo,
+			requestValidator,
+		),
+		handlers.NewAppUsageEvent(
+			*serverURL,
+			appUsageEventRepo,
+			requestValidator,
+		),
+		handlers.NewServiceUsageEvent(
+			*serverURL,
+			serviceUsageEventRepo,
+			requestValidator,
+		),
+		handlers.NewRevision(
+			*serverURL,
+			revisionRepo,
+			appRepo,
+			requestValidator,
+		),
+		handlers.NewSidecar(
+			*serverURL,
+			sidecarRepo,
+			appRepo,
+			requestValidator,
+		),
 		handlers.NewServiceInstance(
 			*serverURL,
 			serviceInstanceRepo,
 			spaceRepo,
+			featureFlagRepo,
+			requestValidator,
+		),
+		handlers.NewServiceBroker(
+			*serverURL,
+			serviceBrokerRepo,
+			servicePlanRepo,
+			serviceInstanceRepo,
+			requestValidator,
+		),
+		handlers.NewServiceOffering(
+			*serverURL,
+			serviceOfferingRepo,
+			requestValidator,
+		),
+		handlers.NewServicePlan(
+			*serverURL,
+			servicePlanRepo,
+			serviceOfferingRepo,
 			requestValidator,
 		),
 		handlers.NewServiceBinding(
@@ -385,16 +588,51 @@ func main() {
 			serviceBindingRepo,
 			appRepo,
 			serviceInstanceRepo,
+			auditEventRepo,
+			requestValidator,
+		),
+		handlers.NewSecurityGroup(
+			*serverURL,
+			securityGroupRepo,
+			requestValidator,
+		),
+		handlers.NewOrgQuota(
+			*serverURL,
+			orgQuotaRepo,
+			requestValidator,
+		),
+		handlers.NewSpaceQuota(
+			*serverURL,
+			spaceQuotaRepo,
+			requestValidator,
+		),
+		handlers.NewIsolationSegment(
+			*serverURL,
+			isolationSegmentRepo,
+			requestValidator,
+		),
+		handlers.NewFeatureFlag(
+			*serverURL,
+			featureFlagRepo,
+			requestValidator,
+		),
+		handlers.NewEnvVarGroup(
+			*serverURL,
+			envVarGroupRepo,
 			requestValidator,
 		),
 		handlers.NewTask(
 			*serverURL,
 			appRepo,
 			taskRepo,
+			featureFlagRepo,
 			requestValidator,
 		),
 		handlers.NewOAuth(
 			*serverURL,
+			sshCodeRepo,
+			cfg.SSHProxy.OAuthClient,
+			requestValidator,
 		),
 	}
 	for _, handler := range apiHandlers {
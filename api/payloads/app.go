@@ -56,8 +56,7 @@ func (p AppCreate) ToAppCreateMessage() repositories.CreateAppMessage {
 		},
 	}
 	if p.Lifecycle != nil {
-		lifecycleBlock.Data.Stack = p.Lifecycle.Data.Stack
-		lifecycleBlock.Data.Buildpacks = p.Lifecycle.Data.Buildpacks
+		lifecycleBlock = p.Lifecycle.ToRecord()
 	}
 
 	return repositories.CreateAppMessage{
@@ -130,31 +129,37 @@ func (p AppPatchEnvVars) Validate() error {
 }
 
 func (a *AppPatchEnvVars) ToMessage(appGUID, spaceGUID string) repositories.PatchAppEnvVarsMessage {
-	message := repositories.PatchAppEnvVarsMessage{
+	return repositories.PatchAppEnvVarsMessage{
 		AppGUID:              appGUID,
 		SpaceGUID:            spaceGUID,
-		EnvironmentVariables: map[string]*string{},
+		EnvironmentVariables: toEnvVarValues(a.Var),
 	}
+}
+
+// toEnvVarValues converts the JSON values of environment variables to
+// strings, null values are kept as nil so that the variable gets removed
+func toEnvVarValues(vars map[string]interface{}) map[string]*string {
+	envVars := map[string]*string{}
 
-	for k, v := range a.Var {
+	for k, v := range vars {
 		switch v := v.(type) {
 		case nil:
-			message.EnvironmentVariables[k] = nil
+			envVars[k] = nil
 		case bool:
 			stringVar := fmt.Sprintf("%t", v)
-			message.EnvironmentVariables[k] = &stringVar
+			envVars[k] = &stringVar
 		case float32:
 			stringVar := fmt.Sprintf("%f", v)
-			message.EnvironmentVariables[k] = &stringVar
+			envVars[k] = &stringVar
 		case int:
 			stringVar := fmt.Sprintf("%d", v)
-			message.EnvironmentVariables[k] = &stringVar
+			envVars[k] = &stringVar
 		case string:
-			message.EnvironmentVariables[k] = &v
+			envVars[k] = &v
 		}
 	}
 
-	return message
+	return envVars
 }
 
 type AppPatch struct {
@@ -154,6 +154,25 @@ var _ = Describe("App payload validation", func() {
 				expectUnprocessableEntityError(validatorErr, "label/annotation key cannot use the cloudfoundry.org domain")
 			})
 		})
+
+		Describe("ToAppCreateMessage", func() {
+			It("uses the default lifecycle", func() {
+				Expect(payload.ToAppCreateMessage().Lifecycle).To(Equal(repositories.Lifecycle{
+					Type: "buildpack",
+					Data: repositories.LifecycleData{Stack: "cflinuxfs3"},
+				}))
+			})
+
+			When("the lifecycle is set", func() {
+				BeforeEach(func() {
+					payload.Lifecycle = &payloads.Lifecycle{Type: "docker"}
+				})
+
+				It("uses it", func() {
+					Expect(payload.ToAppCreateMessage().Lifecycle).To(Equal(repositories.Lifecycle{Type: "docker"}))
+				})
+			})
+		})
 	})
 
 	Describe("AppPatch", func() {
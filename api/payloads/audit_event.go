@@ -0,0 +1,100 @@
+package payloads
+
+import (
+	"errors"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type AuditEventList struct {
+	TargetGUIDs   string
+	Types         string
+	SpaceGUIDs    string
+	CreatedAts    string
+	CreatedAtsLT  string
+	CreatedAtsLTE string
+	CreatedAtsGT  string
+	CreatedAtsGTE string
+}
+
+func (l AuditEventList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.CreatedAts, jellidation.By(validateTimestamps)),
+		jellidation.Field(&l.CreatedAtsLT, jellidation.By(validateTimestamps)),
+		jellidation.Field(&l.CreatedAtsLTE, jellidation.By(validateTimestamps)),
+		jellidation.Field(&l.CreatedAtsGT, jellidation.By(validateTimestamps)),
+		jellidation.Field(&l.CreatedAtsGTE, jellidation.By(validateTimestamps)),
+	)
+}
+
+func validateTimestamps(value any) error {
+	for _, timestamp := range parse.ArrayParam(value.(string)) {
+		if _, err := time.Parse(time.RFC3339, timestamp); err != nil {
+			return errors.New("must be a list of timestamps in the format YYYY-MM-DDThh:mm:ssZ")
+		}
+	}
+	return nil
+}
+
+func (l *AuditEventList) ToMessage() repositories.ListAuditEventsMessage {
+	return repositories.ListAuditEventsMessage{
+		TargetGUIDs: parse.ArrayParam(l.TargetGUIDs),
+		Types:       parse.ArrayParam(l.Types),
+		SpaceGUIDs:  parse.ArrayParam(l.SpaceGUIDs),
+		CreatedAts: repositories.TimestampFilter{
+			Values:             parseTimestamps(l.CreatedAts),
+			LessThan:           parseTimestamp(l.CreatedAtsLT),
+			LessThanOrEqual:    parseTimestamp(l.CreatedAtsLTE),
+			GreaterThan:        parseTimestamp(l.CreatedAtsGT),
+			GreaterThanOrEqual: parseTimestamp(l.CreatedAtsGTE),
+		},
+	}
+}
+
+func parseTimestamps(timestamps string) []time.Time {
+	result := []time.Time{}
+	for _, timestamp := range parse.ArrayParam(timestamps) {
+		t, _ := time.Parse(time.RFC3339, timestamp)
+		result = append(result, t)
+	}
+	return result
+}
+
+func parseTimestamp(timestamp string) *time.Time {
+	if timestamp == "" {
+		return nil
+	}
+	t, _ := time.Parse(time.RFC3339, timestamp)
+	return &t
+}
+
+func (l *AuditEventList) SupportedKeys() []string {
+	return []string{
+		"target_guids",
+		"types",
+		"space_guids",
+		"created_ats",
+		"created_ats[lt]",
+		"created_ats[lte]",
+		"created_ats[gt]",
+		"created_ats[gte]",
+		"per_page",
+		"page",
+	}
+}
+
+func (l *AuditEventList) DecodeFromURLValues(values url.Values) error {
+	l.TargetGUIDs = values.Get("target_guids")
+	l.Types = values.Get("types")
+	l.SpaceGUIDs = values.Get("space_guids")
+	l.CreatedAts = values.Get("created_ats")
+	l.CreatedAtsLT = values.Get("created_ats[lt]")
+	l.CreatedAtsLTE = values.Get("created_ats[lte]")
+	l.CreatedAtsGT = values.Get("created_ats[gt]")
+	l.CreatedAtsGTE = values.Get("created_ats[gte]")
+	return nil
+}
@@ -0,0 +1,67 @@
+package payloads_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditEventList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedAuditEventList payloads.AuditEventList) {
+			actualAuditEventList, decodeErr := decodeQuery[payloads.AuditEventList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualAuditEventList).To(Equal(expectedAuditEventList))
+		},
+		Entry("target_guids", "target_guids=t1,t2", payloads.AuditEventList{TargetGUIDs: "t1,t2"}),
+		Entry("types", "types=audit.app.create", payloads.AuditEventList{Types: "audit.app.create"}),
+		Entry("space_guids", "space_guids=s1,s2", payloads.AuditEventList{SpaceGUIDs: "s1,s2"}),
+		Entry("created_ats", "created_ats=2023-01-01T00:00:00Z", payloads.AuditEventList{CreatedAts: "2023-01-01T00:00:00Z"}),
+		Entry("created_ats[lt]", "created_ats[lt]=2023-01-01T00:00:00Z", payloads.AuditEventList{CreatedAtsLT: "2023-01-01T00:00:00Z"}),
+		Entry("created_ats[lte]", "created_ats[lte]=2023-01-01T00:00:00Z", payloads.AuditEventList{CreatedAtsLTE: "2023-01-01T00:00:00Z"}),
+		Entry("created_ats[gt]", "created_ats[gt]=2023-01-01T00:00:00Z", payloads.AuditEventList{CreatedAtsGT: "2023-01-01T00:00:00Z"}),
+		Entry("created_ats[gte]", "created_ats[gte]=2023-01-01T00:00:00Z", payloads.AuditEventList{CreatedAtsGTE: "2023-01-01T00:00:00Z"}),
+		Entry("per_page", "per_page=10", payloads.AuditEventList{}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.AuditEventList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("unknown key", "foo=bar", "unsupported query parameter"),
+		Entry("invalid created_ats", "created_ats=yesterday", "must be a list of timestamps"),
+		Entry("invalid created_ats[gt]", "created_ats[gt]=2023-01-01", "must be a list of timestamps"),
+	)
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			list := payloads.AuditEventList{
+				TargetGUIDs:  "t1,t2",
+				Types:        "audit.app.create",
+				SpaceGUIDs:   "s1",
+				CreatedAts:   "2023-01-01T00:00:00Z,2023-01-02T00:00:00Z",
+				CreatedAtsLT: "2023-02-01T00:00:00Z",
+				CreatedAtsGT: "2022-12-01T00:00:00Z",
+			}
+			Expect(list.ToMessage()).To(Equal(repositories.ListAuditEventsMessage{
+				TargetGUIDs: []string{"t1", "t2"},
+				Types:       []string{"audit.app.create"},
+				SpaceGUIDs:  []string{"s1"},
+				CreatedAts: repositories.TimestampFilter{
+					Values: []time.Time{
+						time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+						time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC),
+					},
+					LessThan:    tools.PtrTo(time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)),
+					GreaterThan: tools.PtrTo(time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC)),
+				},
+			}))
+		})
+	})
+})
@@ -17,6 +17,7 @@ type BuildCreate struct {
 func (b BuildCreate) Validate() error {
 	return validation.ValidateStruct(&b,
 		validation.Field(&b.Package, payload_validation.StrictlyRequired),
+		validation.Field(&b.Lifecycle),
 		validation.Field(&b.Metadata),
 	)
 }
@@ -32,5 +33,9 @@ func (c *BuildCreate) ToMessage(appRecord repositories.AppRecord) repositories.C
 		Annotations:     c.Metadata.Annotations,
 	}
 
+	if c.Lifecycle != nil {
+		toReturn.Lifecycle = c.Lifecycle.ToRecord()
+	}
+
 	return toReturn
 }
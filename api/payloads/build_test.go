@@ -6,6 +6,7 @@ import (
 	"github.com/onsi/gomega/gstruct"
 
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
 )
 
 var _ = Describe("BuildCreate", func() {
@@ -66,6 +67,19 @@ var _ = Describe("BuildCreate", func() {
 			})
 		})
 
+		When("the lifecycle has no stack", func() {
+			BeforeEach(func() {
+				createPayload.Lifecycle = &payloads.Lifecycle{
+					Type: "buildpack",
+					Data: &payloads.LifecycleData{},
+				}
+			})
+
+			It("says stack is required", func() {
+				expectUnprocessableEntityError(validatorErr, "lifecycle.data.stack cannot be blank")
+			})
+		})
+
 		When("the metadata labels is not empty", func() {
 			BeforeEach(func() {
 				createPayload.Metadata.Labels = map[string]string{
@@ -78,4 +92,51 @@ var _ = Describe("BuildCreate", func() {
 			})
 		})
 	})
+
+	Describe("ToMessage", func() {
+		var (
+			createPayload payloads.BuildCreate
+			appRecord     repositories.AppRecord
+		)
+
+		BeforeEach(func() {
+			createPayload = payloads.BuildCreate{
+				Package: &payloads.RelationshipData{GUID: "package-guid"},
+			}
+			appRecord = repositories.AppRecord{
+				GUID:      "app-guid",
+				SpaceGUID: "space-guid",
+				Lifecycle: repositories.Lifecycle{
+					Type: "buildpack",
+					Data: repositories.LifecycleData{Stack: "app-stack"},
+				},
+			}
+		})
+
+		It("uses the lifecycle of the app", func() {
+			Expect(createPayload.ToMessage(appRecord).Lifecycle).To(Equal(appRecord.Lifecycle))
+		})
+
+		When("the payload has a lifecycle", func() {
+			BeforeEach(func() {
+				createPayload.Lifecycle = &payloads.Lifecycle{
+					Type: "buildpack",
+					Data: &payloads.LifecycleData{
+						Buildpacks: []string{"bp"},
+						Stack:      "build-stack",
+					},
+				}
+			})
+
+			It("overrides the lifecycle of the app", func() {
+				Expect(createPayload.ToMessage(appRecord).Lifecycle).To(Equal(repositories.Lifecycle{
+					Type: "buildpack",
+					Data: repositories.LifecycleData{
+						Buildpacks: []string{"bp"},
+						Stack:      "build-stack",
+					},
+				}))
+			})
+		})
+	})
 })
@@ -9,21 +9,38 @@ type DropletGUID struct {
 	Guid string `json:"guid"`
 }
 
+type RevisionGUID struct {
+	Guid string `json:"guid"`
+}
+
+func (r RevisionGUID) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Guid, validation.Required))
+}
+
 type DeploymentCreate struct {
 	Droplet       DropletGUID              `json:"droplet"`
+	Revision      *RevisionGUID            `json:"revision,omitempty"`
 	Relationships *DeploymentRelationships `json:"relationships"`
 }
 
 func (c DeploymentCreate) Validate() error {
 	return validation.ValidateStruct(&c,
+		validation.Field(&c.Revision, validation.When(c.Droplet.Guid != "", validation.Nil.Error("cannot be set together with droplet"))),
 		validation.Field(&c.Relationships, validation.NotNil))
 }
 
 func (c *DeploymentCreate) ToMessage() repositories.CreateDeploymentMessage {
-	return repositories.CreateDeploymentMessage{
+	message := repositories.CreateDeploymentMessage{
 		AppGUID:     c.Relationships.App.Data.GUID,
 		DropletGUID: c.Droplet.Guid,
 	}
+
+	if c.Revision != nil {
+		message.RevisionGUID = c.Revision.Guid
+	}
+
+	return message
 }
 
 type DeploymentRelationships struct {
@@ -1,10 +1,97 @@
 package payloads
 
 import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	"github.com/jellydator/validation"
 )
 
+type DropletCreate struct {
+	Relationships *DropletRelationships `json:"relationships"`
+	ProcessTypes  map[string]string     `json:"process_types"`
+	Metadata      Metadata              `json:"metadata"`
+}
+
+func (c DropletCreate) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.Relationships, validation.NotNil),
+		validation.Field(&c.Metadata),
+	)
+}
+
+func (c DropletCreate) ToMessage(appRecord repositories.AppRecord) repositories.CreateDropletMessage {
+	processTypes := c.ProcessTypes
+	if processTypes == nil {
+		processTypes = map[string]string{"web": ""}
+	}
+
+	return repositories.CreateDropletMessage{
+		AppGUID:      appRecord.GUID,
+		SpaceGUID:    appRecord.SpaceGUID,
+		Lifecycle:    appRecord.Lifecycle,
+		ProcessTypes: processTypes,
+		Metadata: repositories.Metadata{
+			Labels:      c.Metadata.Labels,
+			Annotations: c.Metadata.Annotations,
+		},
+	}
+}
+
+type DropletRelationships struct {
+	App *Relationship `json:"app"`
+}
+
+func (r DropletRelationships) Validate() error {
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.App, validation.NotNil),
+	)
+}
+
+// DropletCopy holds the query of a droplet create request copying an
+// existing droplet
+type DropletCopy struct {
+	SourceGUID string
+}
+
+func (c *DropletCopy) SupportedKeys() []string {
+	return []string{"source_guid"}
+}
+
+func (c *DropletCopy) DecodeFromURLValues(values url.Values) error {
+	c.SourceGUID = values.Get("source_guid")
+	return nil
+}
+
+type DropletList struct {
+	GUIDs      string
+	AppGUIDs   string
+	SpaceGUIDs string
+	States     string
+}
+
+func (l *DropletList) ToMessage() repositories.ListDropletsMessage {
+	return repositories.ListDropletsMessage{
+		GUIDs:      parse.ArrayParam(l.GUIDs),
+		AppGUIDs:   parse.ArrayParam(l.AppGUIDs),
+		SpaceGUIDs: parse.ArrayParam(l.SpaceGUIDs),
+		States:     parse.ArrayParam(l.States),
+	}
+}
+
+func (l *DropletList) SupportedKeys() []string {
+	return []string{"guids", "app_guids", "space_guids", "states", "per_page", "page"}
+}
+
+func (l *DropletList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.AppGUIDs = values.Get("app_guids")
+	l.SpaceGUIDs = values.Get("space_guids")
+	l.States = values.Get("states")
+	return nil
+}
+
 type DropletUpdate struct {
 	Metadata MetadataPatch `json:"metadata"`
 }
@@ -2,6 +2,7 @@ package payloads_test
 
 import (
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/tools"
 	"github.com/onsi/gomega/gstruct"
 
@@ -9,6 +10,121 @@ import (
 	. "github.com/onsi/gomega"
 )
 
+var _ = Describe("DropletCreate", func() {
+	var (
+		createPayload         payloads.DropletCreate
+		decodedDropletPayload *payloads.DropletCreate
+		validatorErr          error
+	)
+
+	BeforeEach(func() {
+		decodedDropletPayload = new(payloads.DropletCreate)
+		createPayload = payloads.DropletCreate{
+			Relationships: &payloads.DropletRelationships{
+				App: &payloads.Relationship{
+					Data: &payloads.RelationshipData{GUID: "app-guid"},
+				},
+			},
+			ProcessTypes: map[string]string{"web": "bundle exec rackup"},
+			Metadata: payloads.Metadata{
+				Labels: map[string]string{"foo": "bar"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), decodedDropletPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedDropletPayload).To(gstruct.PointTo(Equal(createPayload)))
+	})
+
+	When("the relationships are missing", func() {
+		BeforeEach(func() {
+			createPayload.Relationships = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "relationships is required")
+		})
+	})
+
+	When("the app relationship is missing", func() {
+		BeforeEach(func() {
+			createPayload.Relationships.App = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "app is required")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("creates a droplet for the app", func() {
+			Expect(createPayload.ToMessage(repositories.AppRecord{
+				GUID:      "app-guid",
+				SpaceGUID: "space-guid",
+				Lifecycle: repositories.Lifecycle{Type: "buildpack"},
+			})).To(Equal(repositories.CreateDropletMessage{
+				AppGUID:      "app-guid",
+				SpaceGUID:    "space-guid",
+				Lifecycle:    repositories.Lifecycle{Type: "buildpack"},
+				ProcessTypes: map[string]string{"web": "bundle exec rackup"},
+				Metadata: repositories.Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			}))
+		})
+
+		When("no process types are given", func() {
+			BeforeEach(func() {
+				createPayload.ProcessTypes = nil
+			})
+
+			It("defaults to a web process", func() {
+				Expect(createPayload.ToMessage(repositories.AppRecord{}).ProcessTypes).To(Equal(map[string]string{"web": ""}))
+			})
+		})
+	})
+})
+
+var _ = Describe("DropletCopy", func() {
+	It("decodes the source guid", func() {
+		dropletCopy, decodeErr := decodeQuery[payloads.DropletCopy]("source_guid=droplet-guid")
+		Expect(decodeErr).NotTo(HaveOccurred())
+		Expect(*dropletCopy).To(Equal(payloads.DropletCopy{SourceGUID: "droplet-guid"}))
+	})
+})
+
+var _ = Describe("DropletList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedDropletList payloads.DropletList) {
+			actualDropletList, decodeErr := decodeQuery[payloads.DropletList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualDropletList).To(Equal(expectedDropletList))
+		},
+		Entry("guids", "guids=g1,g2", payloads.DropletList{GUIDs: "g1,g2"}),
+		Entry("app_guids", "app_guids=g1,g2", payloads.DropletList{AppGUIDs: "g1,g2"}),
+		Entry("space_guids", "space_guids=g1,g2", payloads.DropletList{SpaceGUIDs: "g1,g2"}),
+		Entry("states", "states=s1,s2", payloads.DropletList{States: "s1,s2"}),
+	)
+
+	Describe("ToMessage", func() {
+		It("splits the guids and states", func() {
+			dropletList := payloads.DropletList{GUIDs: "g1,g2", AppGUIDs: "a1", SpaceGUIDs: "s1", States: "STAGED"}
+			Expect(dropletList.ToMessage()).To(Equal(repositories.ListDropletsMessage{
+				GUIDs:      []string{"g1", "g2"},
+				AppGUIDs:   []string{"a1"},
+				SpaceGUIDs: []string{"s1"},
+				States:     []string{"STAGED"},
+			}))
+		})
+	})
+})
+
 var _ = Describe("DropletUpdate", func() {
 	Describe("Decode", func() {
 		var (
@@ -0,0 +1,30 @@
+package payloads
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type EnvVarGroupUpdate struct {
+	Var map[string]interface{} `json:"var"`
+}
+
+func (p EnvVarGroupUpdate) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Var,
+			validation.StrictlyRequired,
+			jellidation.Map().Keys(
+				validation.NotStartWith("VCAP_"),
+				validation.NotStartWith("VMC_"),
+				validation.NotEqual("PORT"),
+			).AllowExtraKeys(),
+		))
+}
+
+func (p EnvVarGroupUpdate) ToMessage(name string) repositories.PatchEnvVarGroupMessage {
+	return repositories.PatchEnvVarGroupMessage{
+		Name:                 name,
+		EnvironmentVariables: toEnvVarValues(p.Var),
+	}
+}
@@ -0,0 +1,76 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("EnvVarGroupUpdate", func() {
+	var (
+		payload        payloads.EnvVarGroupUpdate
+		decodedPayload *payloads.EnvVarGroupUpdate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		payload = payloads.EnvVarGroupUpdate{
+			Var: map[string]interface{}{
+				"foo": "bar",
+				"baz": nil,
+			},
+		}
+
+		decodedPayload = new(payloads.EnvVarGroupUpdate)
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(payload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+	})
+
+	It("converts to a message", func() {
+		Expect(decodedPayload.ToMessage("running").Name).To(Equal("running"))
+		Expect(decodedPayload.ToMessage("running").EnvironmentVariables).To(Equal(map[string]*string{
+			"foo": tools.PtrTo("bar"),
+			"baz": nil,
+		}))
+	})
+
+	When("var is missing", func() {
+		BeforeEach(func() {
+			payload.Var = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "var cannot be blank")
+		})
+	})
+
+	When("it contains a 'PORT' key", func() {
+		BeforeEach(func() {
+			payload.Var["PORT"] = "2222"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "value PORT is not allowed")
+		})
+	})
+
+	When("it contains a key with prefix 'VCAP_'", func() {
+		BeforeEach(func() {
+			payload.Var["VCAP_foo"] = "bar"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "prefix VCAP_ is not allowed")
+		})
+	})
+})
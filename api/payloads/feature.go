@@ -0,0 +1,50 @@
+package payloads
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type AppFeatureUpdate struct {
+	Enabled *bool `json:"enabled"`
+}
+
+func (p AppFeatureUpdate) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Enabled, jellidation.NotNil),
+	)
+}
+
+func (p AppFeatureUpdate) ToMessage(appGUID, spaceGUID, featureName string) repositories.PatchAppFeaturesMessage {
+	message := repositories.PatchAppFeaturesMessage{
+		AppGUID:   appGUID,
+		SpaceGUID: spaceGUID,
+	}
+
+	switch featureName {
+	case repositories.AppFeatureSSH:
+		message.SSHEnabled = p.Enabled
+	case repositories.AppFeatureRevisions:
+		message.RevisionsEnabled = p.Enabled
+	}
+
+	return message
+}
+
+type SpaceFeatureUpdate struct {
+	Enabled *bool `json:"enabled"`
+}
+
+func (p SpaceFeatureUpdate) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Enabled, jellidation.NotNil),
+	)
+}
+
+func (p SpaceFeatureUpdate) ToMessage(spaceGUID, orgGUID string) repositories.PatchSpaceFeaturesMessage {
+	return repositories.PatchSpaceFeaturesMessage{
+		GUID:       spaceGUID,
+		OrgGUID:    orgGUID,
+		SSHEnabled: p.Enabled,
+	}
+}
@@ -0,0 +1,25 @@
+package payloads
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type FeatureFlagUpdate struct {
+	Enabled            *bool   `json:"enabled"`
+	CustomErrorMessage *string `json:"custom_error_message"`
+}
+
+func (u FeatureFlagUpdate) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.CustomErrorMessage, jellidation.Length(0, 250)),
+	)
+}
+
+func (u FeatureFlagUpdate) ToMessage(name string) repositories.UpdateFeatureFlagMessage {
+	return repositories.UpdateFeatureFlagMessage{
+		Name:               name,
+		Enabled:            u.Enabled,
+		CustomErrorMessage: u.CustomErrorMessage,
+	}
+}
@@ -0,0 +1,53 @@
+package payloads_test
+
+import (
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("FeatureFlagUpdate", func() {
+	var (
+		updatePayload  payloads.FeatureFlagUpdate
+		decodedPayload *payloads.FeatureFlagUpdate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.FeatureFlagUpdate)
+		updatePayload = payloads.FeatureFlagUpdate{
+			Enabled:            tools.PtrTo(false),
+			CustomErrorMessage: tools.PtrTo("no tasks today"),
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(updatePayload)))
+		Expect(decodedPayload.ToMessage("task_creation")).To(Equal(repositories.UpdateFeatureFlagMessage{
+			Name:               "task_creation",
+			Enabled:            tools.PtrTo(false),
+			CustomErrorMessage: tools.PtrTo("no tasks today"),
+		}))
+	})
+
+	When("the custom error message is too long", func() {
+		BeforeEach(func() {
+			updatePayload.CustomErrorMessage = tools.PtrTo(strings.Repeat("a", 251))
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "custom_error_message the length must be no more than 250")
+		})
+	})
+})
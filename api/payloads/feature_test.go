@@ -0,0 +1,102 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("AppFeatureUpdate", func() {
+	var (
+		updatePayload    payloads.AppFeatureUpdate
+		appFeatureUpdate *payloads.AppFeatureUpdate
+		validatorErr     error
+	)
+
+	BeforeEach(func() {
+		appFeatureUpdate = new(payloads.AppFeatureUpdate)
+		updatePayload = payloads.AppFeatureUpdate{Enabled: tools.PtrTo(false)}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), appFeatureUpdate)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(appFeatureUpdate).To(gstruct.PointTo(Equal(updatePayload)))
+	})
+
+	When("enabled is not set", func() {
+		BeforeEach(func() {
+			updatePayload.Enabled = nil
+		})
+
+		It("fails", func() {
+			expectUnprocessableEntityError(validatorErr, "enabled is required")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("sets the ssh feature", func() {
+			Expect(updatePayload.ToMessage("app-guid", "space-guid", repositories.AppFeatureSSH)).To(Equal(repositories.PatchAppFeaturesMessage{
+				AppGUID:    "app-guid",
+				SpaceGUID:  "space-guid",
+				SSHEnabled: tools.PtrTo(false),
+			}))
+		})
+
+		It("sets the revisions feature", func() {
+			Expect(updatePayload.ToMessage("app-guid", "space-guid", repositories.AppFeatureRevisions)).To(Equal(repositories.PatchAppFeaturesMessage{
+				AppGUID:          "app-guid",
+				SpaceGUID:        "space-guid",
+				RevisionsEnabled: tools.PtrTo(false),
+			}))
+		})
+	})
+})
+
+var _ = Describe("SpaceFeatureUpdate", func() {
+	var (
+		updatePayload      payloads.SpaceFeatureUpdate
+		spaceFeatureUpdate *payloads.SpaceFeatureUpdate
+		validatorErr       error
+	)
+
+	BeforeEach(func() {
+		spaceFeatureUpdate = new(payloads.SpaceFeatureUpdate)
+		updatePayload = payloads.SpaceFeatureUpdate{Enabled: tools.PtrTo(true)}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), spaceFeatureUpdate)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(spaceFeatureUpdate).To(gstruct.PointTo(Equal(updatePayload)))
+	})
+
+	When("enabled is not set", func() {
+		BeforeEach(func() {
+			updatePayload.Enabled = nil
+		})
+
+		It("fails", func() {
+			expectUnprocessableEntityError(validatorErr, "enabled is required")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			Expect(updatePayload.ToMessage("space-guid", "org-guid")).To(Equal(repositories.PatchSpaceFeaturesMessage{
+				GUID:       "space-guid",
+				OrgGUID:    "org-guid",
+				SSHEnabled: tools.PtrTo(true),
+			}))
+		})
+	})
+})
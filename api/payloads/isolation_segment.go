@@ -0,0 +1,107 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type IsolationSegmentCreate struct {
+	Name string `json:"name"`
+}
+
+func (c IsolationSegmentCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, jellidation.Required),
+	)
+}
+
+func (c IsolationSegmentCreate) ToMessage() repositories.CreateIsolationSegmentMessage {
+	return repositories.CreateIsolationSegmentMessage{
+		Name: c.Name,
+	}
+}
+
+type IsolationSegmentUpdate struct {
+	Name *string `json:"name"`
+}
+
+func (u IsolationSegmentUpdate) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.Name, jellidation.NilOrNotEmpty),
+	)
+}
+
+func (u IsolationSegmentUpdate) ToMessage(guid string) repositories.UpdateIsolationSegmentMessage {
+	return repositories.UpdateIsolationSegmentMessage{
+		GUID: guid,
+		Name: u.Name,
+	}
+}
+
+type IsolationSegmentEntitle struct {
+	Data []RelationshipData `json:"data"`
+}
+
+func (e IsolationSegmentEntitle) Validate() error {
+	return jellidation.ValidateStruct(&e,
+		jellidation.Field(&e.Data, jellidation.Required),
+	)
+}
+
+func (e IsolationSegmentEntitle) ToMessage(guid string) repositories.EntitleIsolationSegmentMessage {
+	return repositories.EntitleIsolationSegmentMessage{
+		GUID:     guid,
+		OrgGUIDs: ToManyRelationship(e).GUIDs(),
+	}
+}
+
+type IsolationSegmentList struct {
+	GUIDs             string
+	Names             string
+	OrganizationGUIDs string
+}
+
+func (l *IsolationSegmentList) ToMessage() repositories.ListIsolationSegmentsMessage {
+	return repositories.ListIsolationSegmentsMessage{
+		GUIDs:    parse.ArrayParam(l.GUIDs),
+		Names:    parse.ArrayParam(l.Names),
+		OrgGUIDs: parse.ArrayParam(l.OrganizationGUIDs),
+	}
+}
+
+func (l *IsolationSegmentList) SupportedKeys() []string {
+	return []string{"guids", "names", "organization_guids", "per_page", "page"}
+}
+
+func (l *IsolationSegmentList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.Names = values.Get("names")
+	l.OrganizationGUIDs = values.Get("organization_guids")
+	return nil
+}
+
+// SpaceIsolationSegmentUpdate assigns a space to an isolation segment, null
+// data resets the space to the default placement
+type SpaceIsolationSegmentUpdate struct {
+	Data *RelationshipData `json:"data"`
+}
+
+func (u SpaceIsolationSegmentUpdate) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.Data),
+	)
+}
+
+func (u SpaceIsolationSegmentUpdate) ToMessage(spaceGUID string) repositories.AssignSpaceIsolationSegmentMessage {
+	message := repositories.AssignSpaceIsolationSegmentMessage{
+		SpaceGUID: spaceGUID,
+	}
+	if u.Data != nil {
+		message.IsolationSegmentGUID = u.Data.GUID
+	}
+
+	return message
+}
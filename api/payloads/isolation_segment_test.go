@@ -0,0 +1,199 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("IsolationSegmentCreate", func() {
+	var (
+		createPayload  payloads.IsolationSegmentCreate
+		decodedPayload *payloads.IsolationSegmentCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.IsolationSegmentCreate)
+		createPayload = payloads.IsolationSegmentCreate{Name: "regulated"}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(createPayload)))
+		Expect(decodedPayload.ToMessage()).To(Equal(repositories.CreateIsolationSegmentMessage{Name: "regulated"}))
+	})
+
+	When("name is not set", func() {
+		BeforeEach(func() {
+			createPayload.Name = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+})
+
+var _ = Describe("IsolationSegmentUpdate", func() {
+	var (
+		updatePayload  payloads.IsolationSegmentUpdate
+		decodedPayload *payloads.IsolationSegmentUpdate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.IsolationSegmentUpdate)
+		updatePayload = payloads.IsolationSegmentUpdate{Name: tools.PtrTo("new-name")}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.ToMessage("segment-guid")).To(Equal(repositories.UpdateIsolationSegmentMessage{
+			GUID: "segment-guid",
+			Name: tools.PtrTo("new-name"),
+		}))
+	})
+
+	When("the name is empty", func() {
+		BeforeEach(func() {
+			updatePayload.Name = tools.PtrTo("")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+})
+
+var _ = Describe("IsolationSegmentEntitle", func() {
+	var (
+		entitlePayload payloads.IsolationSegmentEntitle
+		decodedPayload *payloads.IsolationSegmentEntitle
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.IsolationSegmentEntitle)
+		entitlePayload = payloads.IsolationSegmentEntitle{
+			Data: []payloads.RelationshipData{{GUID: "org-1"}, {GUID: "org-2"}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(entitlePayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.ToMessage("segment-guid")).To(Equal(repositories.EntitleIsolationSegmentMessage{
+			GUID:     "segment-guid",
+			OrgGUIDs: []string{"org-1", "org-2"},
+		}))
+	})
+
+	When("data is empty", func() {
+		BeforeEach(func() {
+			entitlePayload.Data = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "data cannot be blank")
+		})
+	})
+})
+
+var _ = Describe("IsolationSegmentList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedIsolationSegmentList payloads.IsolationSegmentList) {
+			actualIsolationSegmentList, decodeErr := decodeQuery[payloads.IsolationSegmentList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualIsolationSegmentList).To(Equal(expectedIsolationSegmentList))
+		},
+		Entry("guids", "guids=g1,g2", payloads.IsolationSegmentList{GUIDs: "g1,g2"}),
+		Entry("names", "names=name", payloads.IsolationSegmentList{Names: "name"}),
+		Entry("organization_guids", "organization_guids=org-guid", payloads.IsolationSegmentList{OrganizationGUIDs: "org-guid"}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.IsolationSegmentList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("unknown key", "foo=bar", "unsupported query parameter"),
+	)
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			list := payloads.IsolationSegmentList{GUIDs: "g1,g2", Names: "n1", OrganizationGUIDs: "o1"}
+			Expect(list.ToMessage()).To(Equal(repositories.ListIsolationSegmentsMessage{
+				GUIDs:    []string{"g1", "g2"},
+				Names:    []string{"n1"},
+				OrgGUIDs: []string{"o1"},
+			}))
+		})
+	})
+})
+
+var _ = Describe("SpaceIsolationSegmentUpdate", func() {
+	var (
+		updatePayload  payloads.SpaceIsolationSegmentUpdate
+		decodedPayload *payloads.SpaceIsolationSegmentUpdate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.SpaceIsolationSegmentUpdate)
+		updatePayload = payloads.SpaceIsolationSegmentUpdate{
+			Data: &payloads.RelationshipData{GUID: "segment-guid"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.ToMessage("space-guid")).To(Equal(repositories.AssignSpaceIsolationSegmentMessage{
+			SpaceGUID:            "space-guid",
+			IsolationSegmentGUID: "segment-guid",
+		}))
+	})
+
+	When("data is null", func() {
+		BeforeEach(func() {
+			updatePayload.Data = nil
+		})
+
+		It("resets the space assignment", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload.ToMessage("space-guid")).To(Equal(repositories.AssignSpaceIsolationSegmentMessage{
+				SpaceGUID: "space-guid",
+			}))
+		})
+	})
+
+	When("the guid is empty", func() {
+		BeforeEach(func() {
+			updatePayload.Data = &payloads.RelationshipData{}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "guid cannot be blank")
+		})
+	})
+})
@@ -2,6 +2,7 @@ package payloads
 
 import (
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	jellidation "github.com/jellydator/validation"
 )
 
@@ -12,11 +13,24 @@ type Lifecycle struct {
 
 func (l Lifecycle) Validate() error {
 	return jellidation.ValidateStruct(&l,
-		jellidation.Field(&l.Type, jellidation.Required),
-		jellidation.Field(&l.Data, jellidation.NotNil),
+		jellidation.Field(&l.Type, jellidation.Required, validation.OneOf("buildpack", "docker")),
+		// the docker lifecycle has no data: the image is set on the package
+		jellidation.Field(&l.Data, jellidation.Skip.When(l.Type == "docker"), jellidation.NotNil),
 	)
 }
 
+func (l Lifecycle) ToRecord() repositories.Lifecycle {
+	lifecycle := repositories.Lifecycle{Type: l.Type}
+	if l.Data != nil {
+		lifecycle.Data = repositories.LifecycleData{
+			Buildpacks: l.Data.Buildpacks,
+			Stack:      l.Data.Stack,
+		}
+	}
+
+	return lifecycle
+}
+
 type LifecycleData struct {
 	Buildpacks []string `json:"buildpacks"`
 	Stack      string   `json:"stack"`
@@ -35,7 +49,7 @@ type LifecyclePatch struct {
 
 func (p LifecyclePatch) Validate() error {
 	return jellidation.ValidateStruct(&p,
-		jellidation.Field(&p.Type, validation.OneOf("buildpack")),
+		jellidation.Field(&p.Type, validation.OneOf("buildpack", "docker")),
 		jellidation.Field(&p.Data, jellidation.NotNil),
 	)
 }
@@ -64,6 +64,27 @@ var _ = Describe("Lifecycle", func() {
 			expectUnprocessableEntityError(validatorErr, "data.stack cannot be blank")
 		})
 	})
+
+	When("type is not supported", func() {
+		BeforeEach(func() {
+			payload.Type = "cnb"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "type value must be one of: buildpack, docker")
+		})
+	})
+
+	When("type is docker", func() {
+		BeforeEach(func() {
+			payload = payloads.Lifecycle{Type: "docker"}
+		})
+
+		It("does not require data", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(decodedPayload).To(gstruct.PointTo(Equal(payload)))
+		})
+	})
 })
 
 var _ = Describe("LifecyclePatch", func() {
@@ -103,13 +124,13 @@ var _ = Describe("LifecyclePatch", func() {
 		})
 	})
 
-	When("lifecycle.type is not buildpack", func() {
+	When("lifecycle.type is not buildpack or docker", func() {
 		BeforeEach(func() {
 			payload.Type = "not-buildpack"
 		})
 
 		It("returns an error", func() {
-			expectUnprocessableEntityError(validatorErr, "type value must be one of: buildpack")
+			expectUnprocessableEntityError(validatorErr, "type value must be one of: buildpack, docker")
 		})
 	})
 
@@ -39,6 +39,7 @@ type ManifestApplication struct {
 	Timeout                      *int64                       `json:"timeout" yaml:"timeout"`
 	Processes                    []ManifestApplicationProcess `json:"processes" yaml:"processes"`
 	Routes                       []ManifestRoute              `json:"routes" yaml:"routes"`
+	Sidecars                     []ManifestApplicationSidecar `json:"sidecars" yaml:"sidecars"`
 	Buildpacks                   []string                     `yaml:"buildpacks"`
 	// Deprecated: Use Buildpacks instead
 	Buildpack string        `yaml:"buildpack"`
@@ -64,6 +65,13 @@ type ManifestApplicationProcess struct {
 	Timeout                      *int64  `json:"timeout" yaml:"timeout"`
 }
 
+type ManifestApplicationSidecar struct {
+	Name         string   `json:"name" yaml:"name"`
+	Command      string   `json:"command" yaml:"command"`
+	ProcessTypes []string `json:"process_types" yaml:"process_types"`
+	Memory       *string  `json:"memory" yaml:"memory"`
+}
+
 type ManifestRoute struct {
 	Route *string `json:"route" yaml:"route"`
 }
@@ -184,6 +192,37 @@ func (p ManifestApplicationProcess) ToProcessPatchMessage(processGUID, spaceGUID
 	return message
 }
 
+func (s ManifestApplicationSidecar) ToSidecarCreateMessage(appGUID, spaceGUID string) repositories.CreateSidecarMessage {
+	return repositories.CreateSidecarMessage{
+		AppGUID:      appGUID,
+		SpaceGUID:    spaceGUID,
+		Name:         s.Name,
+		Command:      s.Command,
+		ProcessTypes: s.ProcessTypes,
+		MemoryMB:     s.memoryMB(),
+	}
+}
+
+func (s ManifestApplicationSidecar) ToSidecarPatchMessage(sidecarGUID, spaceGUID string) repositories.PatchSidecarMessage {
+	return repositories.PatchSidecarMessage{
+		GUID:         sidecarGUID,
+		SpaceGUID:    spaceGUID,
+		Command:      tools.PtrTo(s.Command),
+		ProcessTypes: s.ProcessTypes,
+		MemoryMB:     s.memoryMB(),
+	}
+}
+
+func (s ManifestApplicationSidecar) memoryMB() *int64 {
+	if s.Memory == nil {
+		return nil
+	}
+
+	// error ignored intentionally, since the manifest yaml is validated in handlers
+	memoryMB, _ := bytefmt.ToMegabytes(*s.Memory)
+	return tools.PtrTo(int64(memoryMB))
+}
+
 func (m Manifest) Validate() error {
 	return validation.ValidateStruct(&m,
 		validation.Field(&m.Applications))
@@ -202,6 +241,7 @@ func (a ManifestApplication) Validate() error {
 		validation.Field(&a.Timeout, validation.Min(1), validation.NilOrNotEmpty.Error("must be no less than 1")),
 		validation.Field(&a.Processes),
 		validation.Field(&a.Routes),
+		validation.Field(&a.Sidecars),
 	)
 }
 
@@ -218,6 +258,15 @@ func (p ManifestApplicationProcess) Validate() error {
 	)
 }
 
+func (s ManifestApplicationSidecar) Validate() error {
+	return validation.ValidateStruct(&s,
+		validation.Field(&s.Name, payload_validation.StrictlyRequired),
+		validation.Field(&s.Command, payload_validation.StrictlyRequired),
+		validation.Field(&s.ProcessTypes, validation.Required, validation.Each(validation.Required)),
+		validation.Field(&s.Memory, validation.By(validateAmountWithUnit)),
+	)
+}
+
 func (m ManifestRoute) Validate() error {
 	routeRegex := regexp.MustCompile(
 		`^(?:https?://|tcp://)?(?:(?:[\w-]+\.)|(?:[*]\.))+\w+(?:\:\d+)?(?:/.*)*(?:\.\w+)?$`,
@@ -558,6 +558,108 @@ var _ = Describe("Manifest payload", func() {
 		})
 	})
 
+	Describe("ManifestApplicationSidecar", func() {
+		var testManifestSidecar ManifestApplicationSidecar
+
+		BeforeEach(func() {
+			testManifestSidecar = ManifestApplicationSidecar{
+				Name:         "my-sidecar",
+				Command:      "bin/sidecar",
+				ProcessTypes: []string{"web", "worker"},
+			}
+		})
+
+		Describe("Validate", func() {
+			var validateErr error
+
+			JustBeforeEach(func() {
+				validateErr = validator.DecodeAndValidateYAMLPayload(createYAMLRequest(testManifestSidecar), &ManifestApplicationSidecar{})
+			})
+
+			It("validates the struct", func() {
+				Expect(validateErr).NotTo(HaveOccurred())
+			})
+
+			When("the name is empty", func() {
+				BeforeEach(func() {
+					testManifestSidecar.Name = ""
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "name cannot be blank")
+				})
+			})
+
+			When("the command is empty", func() {
+				BeforeEach(func() {
+					testManifestSidecar.Command = ""
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "command cannot be blank")
+				})
+			})
+
+			When("the process types are empty", func() {
+				BeforeEach(func() {
+					testManifestSidecar.ProcessTypes = nil
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "process_types cannot be blank")
+				})
+			})
+
+			When("the memory doesn't supply a unit", func() {
+				BeforeEach(func() {
+					testManifestSidecar.Memory = tools.PtrTo("64")
+				})
+
+				It("returns a validation error", func() {
+					expectUnprocessableEntityError(validateErr, "memory must use a supported unit")
+				})
+			})
+		})
+
+		Describe("ToSidecarCreateMessage", func() {
+			BeforeEach(func() {
+				testManifestSidecar.Memory = tools.PtrTo("1G")
+			})
+
+			It("returns a CreateSidecarMessage with the parsed values", func() {
+				Expect(testManifestSidecar.ToSidecarCreateMessage("the-app-guid", spaceGUID)).To(Equal(repositories.CreateSidecarMessage{
+					AppGUID:      "the-app-guid",
+					SpaceGUID:    spaceGUID,
+					Name:         "my-sidecar",
+					Command:      "bin/sidecar",
+					ProcessTypes: []string{"web", "worker"},
+					MemoryMB:     tools.PtrTo[int64](1024),
+				}))
+			})
+
+			When("the memory is unspecified", func() {
+				BeforeEach(func() {
+					testManifestSidecar.Memory = nil
+				})
+
+				It("returns a message with MemoryMB unset", func() {
+					Expect(testManifestSidecar.ToSidecarCreateMessage("the-app-guid", spaceGUID).MemoryMB).To(BeNil())
+				})
+			})
+		})
+
+		Describe("ToSidecarPatchMessage", func() {
+			It("returns a PatchSidecarMessage with the manifest values", func() {
+				Expect(testManifestSidecar.ToSidecarPatchMessage("the-sidecar-guid", spaceGUID)).To(Equal(repositories.PatchSidecarMessage{
+					GUID:         "the-sidecar-guid",
+					SpaceGUID:    spaceGUID,
+					Command:      tools.PtrTo("bin/sidecar"),
+					ProcessTypes: []string{"web", "worker"},
+				}))
+			})
+		})
+	})
+
 	Describe("ManifestRoute", func() {
 		var (
 			validateErr       error
@@ -0,0 +1,30 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	jellidation "github.com/jellydator/validation"
+)
+
+type OAuthAuthorize struct {
+	ResponseType string
+	ClientID     string
+}
+
+func (a OAuthAuthorize) Validate() error {
+	return jellidation.ValidateStruct(&a,
+		jellidation.Field(&a.ResponseType, jellidation.Required, validation.OneOf("code")),
+		jellidation.Field(&a.ClientID, jellidation.Required),
+	)
+}
+
+func (a *OAuthAuthorize) SupportedKeys() []string {
+	return []string{"response_type", "client_id", "redirect_uri", "scope", "state"}
+}
+
+func (a *OAuthAuthorize) DecodeFromURLValues(values url.Values) error {
+	a.ResponseType = values.Get("response_type")
+	a.ClientID = values.Get("client_id")
+	return nil
+}
@@ -0,0 +1,31 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("OAuthAuthorize", func() {
+	DescribeTable("valid query",
+		func(query string, expectedAuthorize payloads.OAuthAuthorize) {
+			actualAuthorize, decodeErr := decodeQuery[payloads.OAuthAuthorize](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualAuthorize).To(Equal(expectedAuthorize))
+		},
+		Entry("code request", "response_type=code&client_id=ssh-proxy", payloads.OAuthAuthorize{ResponseType: "code", ClientID: "ssh-proxy"}),
+		Entry("with ignored keys", "response_type=code&client_id=ssh-proxy&state=s&scope=openid", payloads.OAuthAuthorize{ResponseType: "code", ClientID: "ssh-proxy"}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.OAuthAuthorize](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("missing response_type", "client_id=ssh-proxy", "ResponseType: cannot be blank"),
+		Entry("invalid response_type", "response_type=token&client_id=ssh-proxy", "value must be one of"),
+		Entry("missing client_id", "response_type=code", "ClientID: cannot be blank"),
+		Entry("unsupported key", "response_type=code&client_id=ssh-proxy&foo=bar", "unsupported query parameter: foo"),
+	)
+})
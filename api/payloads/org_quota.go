@@ -0,0 +1,199 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type QuotaApps struct {
+	TotalMemoryInMB      *int64 `json:"total_memory_in_mb"`
+	PerProcessMemoryInMB *int64 `json:"per_process_memory_in_mb"`
+	TotalInstances       *int64 `json:"total_instances"`
+	PerAppTasks          *int64 `json:"per_app_tasks"`
+	TotalApps            *int64 `json:"total_apps"`
+}
+
+func (a QuotaApps) Validate() error {
+	return jellidation.ValidateStruct(&a,
+		jellidation.Field(&a.TotalMemoryInMB, jellidation.Min(int64(0))),
+		jellidation.Field(&a.PerProcessMemoryInMB, jellidation.Min(int64(0))),
+		jellidation.Field(&a.TotalInstances, jellidation.Min(int64(0))),
+		jellidation.Field(&a.PerAppTasks, jellidation.Min(int64(0))),
+		jellidation.Field(&a.TotalApps, jellidation.Min(int64(0))),
+	)
+}
+
+type QuotaServices struct {
+	TotalServiceInstances *int64 `json:"total_service_instances"`
+}
+
+func (s QuotaServices) Validate() error {
+	return jellidation.ValidateStruct(&s,
+		jellidation.Field(&s.TotalServiceInstances, jellidation.Min(int64(0))),
+	)
+}
+
+type QuotaRoutes struct {
+	TotalRoutes *int64 `json:"total_routes"`
+}
+
+func (r QuotaRoutes) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.TotalRoutes, jellidation.Min(int64(0))),
+	)
+}
+
+// QuotaLimits are the limits shared by org and space quotas, a missing limit
+// means unlimited
+type QuotaLimits struct {
+	Apps     *QuotaApps     `json:"apps"`
+	Services *QuotaServices `json:"services"`
+	Routes   *QuotaRoutes   `json:"routes"`
+}
+
+func (l QuotaLimits) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.Apps),
+		jellidation.Field(&l.Services),
+		jellidation.Field(&l.Routes),
+	)
+}
+
+func (l QuotaLimits) toRecord() repositories.QuotaLimits {
+	limits := repositories.QuotaLimits{}
+	update := l.toUpdateRecord()
+	if update.Apps != nil {
+		limits.Apps = *update.Apps
+	}
+	if update.Services != nil {
+		limits.Services = *update.Services
+	}
+	if update.Routes != nil {
+		limits.Routes = *update.Routes
+	}
+
+	return limits
+}
+
+func (l QuotaLimits) toUpdateRecord() repositories.UpdateQuotaLimits {
+	update := repositories.UpdateQuotaLimits{}
+	if l.Apps != nil {
+		update.Apps = &repositories.QuotaAppLimits{
+			TotalMemoryInMB:      l.Apps.TotalMemoryInMB,
+			PerProcessMemoryInMB: l.Apps.PerProcessMemoryInMB,
+			TotalInstances:       l.Apps.TotalInstances,
+			PerAppTasks:          l.Apps.PerAppTasks,
+			TotalApps:            l.Apps.TotalApps,
+		}
+	}
+	if l.Services != nil {
+		update.Services = &repositories.QuotaServiceLimits{
+			TotalServiceInstances: l.Services.TotalServiceInstances,
+		}
+	}
+	if l.Routes != nil {
+		update.Routes = &repositories.QuotaRouteLimits{
+			TotalRoutes: l.Routes.TotalRoutes,
+		}
+	}
+
+	return update
+}
+
+type OrgQuotaRelationships struct {
+	Organizations ToManyRelationship `json:"organizations"`
+}
+
+type OrgQuotaCreate struct {
+	Name string `json:"name"`
+	QuotaLimits
+	Relationships *OrgQuotaRelationships `json:"relationships"`
+}
+
+func (c OrgQuotaCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, jellidation.Required),
+		jellidation.Field(&c.QuotaLimits),
+		jellidation.Field(&c.Relationships),
+	)
+}
+
+func (c OrgQuotaCreate) ToMessage() repositories.CreateOrgQuotaMessage {
+	message := repositories.CreateOrgQuotaMessage{
+		Name:   c.Name,
+		Limits: c.QuotaLimits.toRecord(),
+	}
+
+	if c.Relationships != nil {
+		message.OrgGUIDs = c.Relationships.Organizations.GUIDs()
+	}
+
+	return message
+}
+
+// OrgQuotaUpdate replaces the limits of the apps, services and routes groups
+// that are set in the request, limits missing from a set group become unlimited
+type OrgQuotaUpdate struct {
+	Name *string `json:"name"`
+	QuotaLimits
+}
+
+func (u OrgQuotaUpdate) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.Name, jellidation.NilOrNotEmpty),
+		jellidation.Field(&u.QuotaLimits),
+	)
+}
+
+func (u OrgQuotaUpdate) ToMessage(guid string) repositories.UpdateOrgQuotaMessage {
+	return repositories.UpdateOrgQuotaMessage{
+		GUID:   guid,
+		Name:   u.Name,
+		Limits: u.QuotaLimits.toUpdateRecord(),
+	}
+}
+
+type OrgQuotaApply struct {
+	Data []RelationshipData `json:"data"`
+}
+
+func (a OrgQuotaApply) Validate() error {
+	return jellidation.ValidateStruct(&a,
+		jellidation.Field(&a.Data, jellidation.Required),
+	)
+}
+
+func (a OrgQuotaApply) ToMessage(guid string) repositories.ApplyOrgQuotaMessage {
+	return repositories.ApplyOrgQuotaMessage{
+		GUID:     guid,
+		OrgGUIDs: ToManyRelationship(a).GUIDs(),
+	}
+}
+
+type OrgQuotaList struct {
+	GUIDs             string
+	Names             string
+	OrganizationGUIDs string
+}
+
+func (l *OrgQuotaList) ToMessage() repositories.ListOrgQuotasMessage {
+	return repositories.ListOrgQuotasMessage{
+		GUIDs:    parse.ArrayParam(l.GUIDs),
+		Names:    parse.ArrayParam(l.Names),
+		OrgGUIDs: parse.ArrayParam(l.OrganizationGUIDs),
+	}
+}
+
+func (l *OrgQuotaList) SupportedKeys() []string {
+	return []string{"guids", "names", "organization_guids", "per_page", "page"}
+}
+
+func (l *OrgQuotaList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.Names = values.Get("names")
+	l.OrganizationGUIDs = values.Get("organization_guids")
+	return nil
+}
@@ -0,0 +1,218 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("OrgQuotaCreate", func() {
+	var (
+		createPayload  payloads.OrgQuotaCreate
+		decodedPayload *payloads.OrgQuotaCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.OrgQuotaCreate)
+		createPayload = payloads.OrgQuotaCreate{
+			Name: "my-quota",
+			QuotaLimits: payloads.QuotaLimits{
+				Apps: &payloads.QuotaApps{
+					TotalMemoryInMB:      tools.PtrTo[int64](2048),
+					PerProcessMemoryInMB: tools.PtrTo[int64](1024),
+					TotalInstances:       tools.PtrTo[int64](10),
+					PerAppTasks:          tools.PtrTo[int64](2),
+					TotalApps:            tools.PtrTo[int64](5),
+				},
+				Services: &payloads.QuotaServices{TotalServiceInstances: tools.PtrTo[int64](3)},
+				Routes:   &payloads.QuotaRoutes{TotalRoutes: tools.PtrTo[int64](4)},
+			},
+			Relationships: &payloads.OrgQuotaRelationships{
+				Organizations: payloads.ToManyRelationship{Data: []payloads.RelationshipData{{GUID: "org-1"}}},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(createPayload)))
+	})
+
+	When("name is not set", func() {
+		BeforeEach(func() {
+			createPayload.Name = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("a limit is negative", func() {
+		BeforeEach(func() {
+			createPayload.Apps.TotalInstances = tools.PtrTo[int64](-1)
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "total_instances must be no less than 0")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			Expect(createPayload.ToMessage()).To(Equal(repositories.CreateOrgQuotaMessage{
+				Name: "my-quota",
+				Limits: repositories.QuotaLimits{
+					Apps: repositories.QuotaAppLimits{
+						TotalMemoryInMB:      tools.PtrTo[int64](2048),
+						PerProcessMemoryInMB: tools.PtrTo[int64](1024),
+						TotalInstances:       tools.PtrTo[int64](10),
+						PerAppTasks:          tools.PtrTo[int64](2),
+						TotalApps:            tools.PtrTo[int64](5),
+					},
+					Services: repositories.QuotaServiceLimits{TotalServiceInstances: tools.PtrTo[int64](3)},
+					Routes:   repositories.QuotaRouteLimits{TotalRoutes: tools.PtrTo[int64](4)},
+				},
+				OrgGUIDs: []string{"org-1"},
+			}))
+		})
+
+		When("no limits are set", func() {
+			BeforeEach(func() {
+				createPayload.QuotaLimits = payloads.QuotaLimits{}
+			})
+
+			It("creates an unlimited quota", func() {
+				Expect(createPayload.ToMessage().Limits).To(Equal(repositories.QuotaLimits{}))
+			})
+		})
+	})
+})
+
+var _ = Describe("OrgQuotaUpdate", func() {
+	var (
+		updatePayload  payloads.OrgQuotaUpdate
+		decodedPayload *payloads.OrgQuotaUpdate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.OrgQuotaUpdate)
+		updatePayload = payloads.OrgQuotaUpdate{
+			Name: tools.PtrTo("new-name"),
+			QuotaLimits: payloads.QuotaLimits{
+				Routes: &payloads.QuotaRoutes{TotalRoutes: tools.PtrTo[int64](7)},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(updatePayload)))
+	})
+
+	When("the name is empty", func() {
+		BeforeEach(func() {
+			updatePayload.Name = tools.PtrTo("")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("only updates the groups that are set", func() {
+			Expect(updatePayload.ToMessage("quota-guid")).To(Equal(repositories.UpdateOrgQuotaMessage{
+				GUID: "quota-guid",
+				Name: tools.PtrTo("new-name"),
+				Limits: repositories.UpdateQuotaLimits{
+					Routes: &repositories.QuotaRouteLimits{TotalRoutes: tools.PtrTo[int64](7)},
+				},
+			}))
+		})
+	})
+})
+
+var _ = Describe("OrgQuotaApply", func() {
+	var (
+		applyPayload   payloads.OrgQuotaApply
+		decodedPayload *payloads.OrgQuotaApply
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.OrgQuotaApply)
+		applyPayload = payloads.OrgQuotaApply{
+			Data: []payloads.RelationshipData{{GUID: "org-1"}, {GUID: "org-2"}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(applyPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.ToMessage("quota-guid")).To(Equal(repositories.ApplyOrgQuotaMessage{
+			GUID:     "quota-guid",
+			OrgGUIDs: []string{"org-1", "org-2"},
+		}))
+	})
+
+	When("data is empty", func() {
+		BeforeEach(func() {
+			applyPayload.Data = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "data cannot be blank")
+		})
+	})
+})
+
+var _ = Describe("OrgQuotaList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedOrgQuotaList payloads.OrgQuotaList) {
+			actualOrgQuotaList, decodeErr := decodeQuery[payloads.OrgQuotaList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualOrgQuotaList).To(Equal(expectedOrgQuotaList))
+		},
+		Entry("guids", "guids=g1,g2", payloads.OrgQuotaList{GUIDs: "g1,g2"}),
+		Entry("names", "names=name", payloads.OrgQuotaList{Names: "name"}),
+		Entry("organization_guids", "organization_guids=org-guid", payloads.OrgQuotaList{OrganizationGUIDs: "org-guid"}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.OrgQuotaList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("unknown key", "foo=bar", "unsupported query parameter"),
+	)
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			list := payloads.OrgQuotaList{GUIDs: "g1,g2", Names: "n1", OrganizationGUIDs: "o1"}
+			Expect(list.ToMessage()).To(Equal(repositories.ListOrgQuotasMessage{
+				GUIDs:    []string{"g1", "g2"},
+				Names:    []string{"n1"},
+				OrgGUIDs: []string{"o1"},
+			}))
+		})
+	})
+})
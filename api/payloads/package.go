@@ -1,30 +1,34 @@
 package payloads
 
 import (
+	"errors"
 	"net/url"
 
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"github.com/google/go-containerregistry/pkg/name"
 	jellidation "github.com/jellydator/validation"
 )
 
 type PackageCreate struct {
 	Type          string                `json:"type"`
+	Data          *PackageData          `json:"data"`
 	Relationships *PackageRelationships `json:"relationships"`
 	Metadata      Metadata              `json:"metadata"`
 }
 
 func (c PackageCreate) Validate() error {
 	return jellidation.ValidateStruct(&c,
-		jellidation.Field(&c.Type, validation.OneOf("bits"), jellidation.Required),
+		jellidation.Field(&c.Type, validation.OneOf("bits", "docker"), jellidation.Required),
+		jellidation.Field(&c.Data, jellidation.When(c.Type == "docker", jellidation.NotNil)),
 		jellidation.Field(&c.Relationships, jellidation.NotNil),
 		jellidation.Field(&c.Metadata),
 	)
 }
 
 func (c PackageCreate) ToMessage(record repositories.AppRecord) repositories.CreatePackageMessage {
-	return repositories.CreatePackageMessage{
+	message := repositories.CreatePackageMessage{
 		Type:      c.Type,
 		AppGUID:   record.GUID,
 		SpaceGUID: record.SpaceGUID,
@@ -33,6 +37,39 @@ func (c PackageCreate) ToMessage(record repositories.AppRecord) repositories.Cre
 			Labels:      c.Metadata.Labels,
 		},
 	}
+
+	if c.Data != nil {
+		message.Data = &repositories.PackageData{
+			Image:    c.Data.Image,
+			Username: c.Data.Username,
+			Password: c.Data.Password,
+		}
+	}
+
+	return message
+}
+
+// PackageData holds the image of a docker package and the credentials
+// needed to pull it from a private registry
+type PackageData struct {
+	Image    string  `json:"image"`
+	Username *string `json:"username"`
+	Password *string `json:"password"`
+}
+
+func (d PackageData) Validate() error {
+	return jellidation.ValidateStruct(&d,
+		jellidation.Field(&d.Image, jellidation.Required, jellidation.By(validateImageReference)),
+		jellidation.Field(&d.Username, jellidation.When(d.Password != nil, jellidation.NotNil.Error("must be provided along with a password"))),
+		jellidation.Field(&d.Password, jellidation.When(d.Username != nil, jellidation.NotNil.Error("must be provided along with a username"))),
+	)
+}
+
+func validateImageReference(value any) error {
+	if _, err := name.ParseReference(value.(string)); err != nil {
+		return errors.New("must be a valid image reference")
+	}
+	return nil
 }
 
 type PackageRelationships struct {
@@ -44,6 +81,41 @@ func (r PackageRelationships) Validate() error {
 		jellidation.Field(&r.App, jellidation.NotNil))
 }
 
+// PackageCopy holds the query of a package create request copying an
+// existing package
+type PackageCopy struct {
+	SourceGUID string
+}
+
+func (c *PackageCopy) SupportedKeys() []string {
+	return []string{"source_guid"}
+}
+
+func (c *PackageCopy) DecodeFromURLValues(values url.Values) error {
+	c.SourceGUID = values.Get("source_guid")
+	return nil
+}
+
+// PackageCopyCreate is the body of a package create request copying an
+// existing package, which takes the type and data from the source package
+type PackageCopyCreate struct {
+	Relationships *PackageRelationships `json:"relationships"`
+}
+
+func (c PackageCopyCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Relationships, jellidation.NotNil),
+	)
+}
+
+func (c PackageCopyCreate) ToMessage(sourceGUID string, record repositories.AppRecord) repositories.CopyPackageMessage {
+	return repositories.CopyPackageMessage{
+		SourceGUID: sourceGUID,
+		AppGUID:    record.GUID,
+		SpaceGUID:  record.SpaceGUID,
+	}
+}
+
 type PackageUpdate struct {
 	Metadata MetadataPatch `json:"metadata"`
 }
@@ -2,6 +2,7 @@ package payloads_test
 
 import (
 	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
 	"code.cloudfoundry.org/korifi/tools"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -62,7 +63,73 @@ var _ = Describe("PackageCreate", func() {
 		})
 
 		It("returns an appropriate error", func() {
-			expectUnprocessableEntityError(validatorErr, "type value must be one of: bits")
+			expectUnprocessableEntityError(validatorErr, "type value must be one of: bits, docker")
+		})
+	})
+
+	When("type is docker", func() {
+		BeforeEach(func() {
+			createPayload.Type = "docker"
+			createPayload.Data = &payloads.PackageData{
+				Image:    "registry.example.org/my/image:latest",
+				Username: tools.PtrTo("user"),
+				Password: tools.PtrTo("pass"),
+			}
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(packageCreate).To(gstruct.PointTo(Equal(createPayload)))
+		})
+
+		When("data is not set", func() {
+			BeforeEach(func() {
+				createPayload.Data = nil
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "data is required")
+			})
+		})
+
+		When("the image is not set", func() {
+			BeforeEach(func() {
+				createPayload.Data.Image = ""
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "data.image cannot be blank")
+			})
+		})
+
+		When("the image is invalid", func() {
+			BeforeEach(func() {
+				createPayload.Data.Image = "Not An Image"
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "data.image must be a valid image reference")
+			})
+		})
+
+		When("the password is set without a username", func() {
+			BeforeEach(func() {
+				createPayload.Data.Username = nil
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "data.username must be provided along with a password")
+			})
+		})
+
+		When("the username is set without a password", func() {
+			BeforeEach(func() {
+				createPayload.Data.Password = nil
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "data.password must be provided along with a username")
+			})
 		})
 	})
 
@@ -111,6 +178,74 @@ var _ = Describe("PackageCreate", func() {
 	})
 })
 
+var _ = Describe("PackageCopy", func() {
+	It("decodes the source guid", func() {
+		packageCopy, decodeErr := decodeQuery[payloads.PackageCopy]("source_guid=package-guid")
+		Expect(decodeErr).NotTo(HaveOccurred())
+		Expect(*packageCopy).To(Equal(payloads.PackageCopy{SourceGUID: "package-guid"}))
+	})
+})
+
+var _ = Describe("PackageCopyCreate", func() {
+	var (
+		copyPayload       payloads.PackageCopyCreate
+		packageCopyCreate *payloads.PackageCopyCreate
+		validatorErr      error
+	)
+
+	BeforeEach(func() {
+		packageCopyCreate = new(payloads.PackageCopyCreate)
+		copyPayload = payloads.PackageCopyCreate{
+			Relationships: &payloads.PackageRelationships{
+				App: &payloads.Relationship{
+					Data: &payloads.RelationshipData{
+						GUID: "app-guid",
+					},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(copyPayload), packageCopyCreate)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(packageCopyCreate).To(gstruct.PointTo(Equal(copyPayload)))
+	})
+
+	When("relationships is not set", func() {
+		BeforeEach(func() {
+			copyPayload.Relationships = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "relationships is required")
+		})
+	})
+
+	When("relationships.app is not set", func() {
+		BeforeEach(func() {
+			copyPayload.Relationships.App = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "app is required")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			Expect(copyPayload.ToMessage("package-guid", repositories.AppRecord{GUID: "app-guid", SpaceGUID: "space-guid"})).To(Equal(repositories.CopyPackageMessage{
+				SourceGUID: "package-guid",
+				AppGUID:    "app-guid",
+				SpaceGUID:  "space-guid",
+			}))
+		})
+	})
+})
+
 var _ = Describe("PackageUpdate", func() {
 	var payload payloads.PackageUpdate
 
@@ -0,0 +1,152 @@
+package payloads
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+const defaultResourceMode = 0o644
+
+var sha1Regex = regexp.MustCompile(`^[0-9a-fA-F]{40}$`)
+
+// Resource identifies a file of an app by its checksum and size
+type Resource struct {
+	Checksum    Checksum `json:"checksum"`
+	SizeInBytes int64    `json:"size_in_bytes"`
+	Path        string   `json:"path,omitempty"`
+	Mode        string   `json:"mode,omitempty"`
+}
+
+type Checksum struct {
+	Value string `json:"value"`
+}
+
+func (r Resource) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.Checksum),
+		jellidation.Field(&r.SizeInBytes, jellidation.Min(int64(0))),
+		jellidation.Field(&r.Path, jellidation.By(validateResourcePath)),
+		jellidation.Field(&r.Mode, jellidation.By(validateResourceMode)),
+	)
+}
+
+func (c Checksum) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Value, jellidation.Required, jellidation.Match(sha1Regex).Error("must be a SHA1 checksum")),
+	)
+}
+
+func validateResourcePath(value any) error {
+	p := value.(string)
+	if p == "" {
+		return nil
+	}
+
+	cleaned := path.Clean(p)
+	if path.IsAbs(p) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return errors.New("must be a relative path within the app")
+	}
+
+	return nil
+}
+
+func validateResourceMode(value any) error {
+	mode := value.(string)
+	if mode == "" {
+		return nil
+	}
+
+	if _, err := strconv.ParseUint(mode, 8, 32); err != nil {
+		return errors.New("must be an octal file mode")
+	}
+
+	return nil
+}
+
+func (r Resource) ToRecord() repositories.ResourceRecord {
+	mode := fs.FileMode(defaultResourceMode)
+	if parsed, err := strconv.ParseUint(r.Mode, 8, 32); err == nil {
+		mode = fs.FileMode(parsed).Perm()
+	}
+
+	resourcePath := r.Path
+	if resourcePath != "" {
+		resourcePath = path.Clean(resourcePath)
+	}
+
+	return repositories.ResourceRecord{
+		SHA1: strings.ToLower(r.Checksum.Value),
+		Size: r.SizeInBytes,
+		Path: resourcePath,
+		Mode: mode,
+	}
+}
+
+type ResourceMatches struct {
+	Resources []Resource `json:"resources"`
+}
+
+func (m ResourceMatches) Validate() error {
+	return jellidation.ValidateStruct(&m,
+		jellidation.Field(&m.Resources),
+	)
+}
+
+func (m ResourceMatches) ToMessage() []repositories.ResourceRecord {
+	return toResourceRecords(m.Resources)
+}
+
+// PackageUpload holds the form values of a package upload request, besides
+// the bits file
+type PackageUpload struct {
+	Resources []Resource
+}
+
+func (u *PackageUpload) SupportedKeys() []string {
+	return []string{"resources"}
+}
+
+func (u *PackageUpload) DecodeFromURLValues(values url.Values) error {
+	resources := values.Get("resources")
+	if resources == "" {
+		return nil
+	}
+
+	return json.Unmarshal([]byte(resources), &u.Resources)
+}
+
+func (u PackageUpload) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.Resources, jellidation.Each(jellidation.By(requireResourcePath))),
+	)
+}
+
+func requireResourcePath(value any) error {
+	if value.(Resource).Path == "" {
+		return errors.New("path cannot be blank")
+	}
+
+	return nil
+}
+
+func (u PackageUpload) ToMessage() []repositories.ResourceRecord {
+	return toResourceRecords(u.Resources)
+}
+
+func toResourceRecords(resources []Resource) []repositories.ResourceRecord {
+	records := []repositories.ResourceRecord{}
+	for _, resource := range resources {
+		records = append(records, resource.ToRecord())
+	}
+
+	return records
+}
@@ -0,0 +1,129 @@
+package payloads_test
+
+import (
+	"net/url"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("ResourceMatches", func() {
+	var (
+		matchesPayload  payloads.ResourceMatches
+		resourceMatches *payloads.ResourceMatches
+		validatorErr    error
+	)
+
+	BeforeEach(func() {
+		resourceMatches = new(payloads.ResourceMatches)
+		matchesPayload = payloads.ResourceMatches{
+			Resources: []payloads.Resource{{
+				Checksum:    payloads.Checksum{Value: "002d760bea1be268e27077412e11a320d0f164d3"},
+				SizeInBytes: 65536,
+				Path:        "path/to/file",
+				Mode:        "644",
+			}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(matchesPayload), resourceMatches)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(resourceMatches).To(gstruct.PointTo(Equal(matchesPayload)))
+	})
+
+	When("the checksum is not a SHA1", func() {
+		BeforeEach(func() {
+			matchesPayload.Resources[0].Checksum.Value = "not-a-sha"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "value must be a SHA1 checksum")
+		})
+	})
+
+	When("the size is negative", func() {
+		BeforeEach(func() {
+			matchesPayload.Resources[0].SizeInBytes = -1
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "size_in_bytes must be no less than 0")
+		})
+	})
+
+	When("the path leaves the app", func() {
+		BeforeEach(func() {
+			matchesPayload.Resources[0].Path = "../../etc/passwd"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "path must be a relative path within the app")
+		})
+	})
+
+	When("the mode is not octal", func() {
+		BeforeEach(func() {
+			matchesPayload.Resources[0].Mode = "rwx"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "mode must be an octal file mode")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts the resources to records", func() {
+			matchesPayload.Resources = append(matchesPayload.Resources, payloads.Resource{
+				Checksum:    payloads.Checksum{Value: "A9993E364706816ABA3E25717850C26C9CD0D89D"},
+				SizeInBytes: 131072,
+			})
+
+			Expect(matchesPayload.ToMessage()).To(Equal([]repositories.ResourceRecord{
+				{SHA1: "002d760bea1be268e27077412e11a320d0f164d3", Size: 65536, Path: "path/to/file", Mode: 0o644},
+				{SHA1: "a9993e364706816aba3e25717850c26c9cd0d89d", Size: 131072, Mode: 0o644},
+			}))
+		})
+	})
+})
+
+var _ = Describe("PackageUpload", func() {
+	It("decodes the resources", func() {
+		packageUpload, decodeErr := decodeQuery[payloads.PackageUpload]("resources=" + url.QueryEscape(`[{
+			"checksum": { "value": "002d760bea1be268e27077412e11a320d0f164d3" },
+			"size_in_bytes": 65536,
+			"path": "path/to/file",
+			"mode": "755"
+		}]`))
+		Expect(decodeErr).NotTo(HaveOccurred())
+		Expect(packageUpload.ToMessage()).To(Equal([]repositories.ResourceRecord{
+			{SHA1: "002d760bea1be268e27077412e11a320d0f164d3", Size: 65536, Path: "path/to/file", Mode: 0o755},
+		}))
+	})
+
+	It("succeeds without resources", func() {
+		packageUpload, decodeErr := decodeQuery[payloads.PackageUpload]("")
+		Expect(decodeErr).NotTo(HaveOccurred())
+		Expect(packageUpload.ToMessage()).To(BeEmpty())
+	})
+
+	It("requires the path of the resources", func() {
+		_, decodeErr := decodeQuery[payloads.PackageUpload]("resources=" + url.QueryEscape(`[{
+			"checksum": { "value": "002d760bea1be268e27077412e11a320d0f164d3" },
+			"size_in_bytes": 65536
+		}]`))
+		expectUnprocessableEntityError(decodeErr, "path cannot be blank")
+	})
+
+	It("fails when the resources are not valid json", func() {
+		_, decodeErr := decodeQuery[payloads.PackageUpload]("resources=not-json")
+		Expect(decodeErr).To(BeAssignableToTypeOf(apierrors.MessageParseError{}))
+	})
+})
@@ -0,0 +1,28 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type RevisionList struct {
+	Versions string
+}
+
+func (l *RevisionList) ToMessage(appGUID string) repositories.ListRevisionsMessage {
+	return repositories.ListRevisionsMessage{
+		AppGUID:  appGUID,
+		Versions: parse.ArrayParam(l.Versions),
+	}
+}
+
+func (l *RevisionList) SupportedKeys() []string {
+	return []string{"versions", "order_by", "per_page", "page"}
+}
+
+func (l *RevisionList) DecodeFromURLValues(values url.Values) error {
+	l.Versions = values.Get("versions")
+	return nil
+}
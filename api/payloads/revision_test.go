@@ -0,0 +1,40 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("RevisionList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedRevisionList payloads.RevisionList) {
+			actualRevisionList, decodeErr := decodeQuery[payloads.RevisionList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualRevisionList).To(Equal(expectedRevisionList))
+		},
+		Entry("versions", "versions=1,2", payloads.RevisionList{Versions: "1,2"}),
+		Entry("order_by", "order_by=created_at", payloads.RevisionList{}),
+		Entry("per_page", "per_page=10", payloads.RevisionList{}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.RevisionList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("unknown key", "foo=bar", "unsupported query parameter"),
+	)
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			list := payloads.RevisionList{Versions: "1,2"}
+			Expect(list.ToMessage("app-guid")).To(Equal(repositories.ListRevisionsMessage{
+				AppGUID:  "app-guid",
+				Versions: []string{"1", "2"},
+			}))
+		})
+	})
+})
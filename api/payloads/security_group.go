@@ -0,0 +1,305 @@
+package payloads
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+	jellidation "github.com/jellydator/validation"
+)
+
+type SecurityGroupRule struct {
+	Protocol    string `json:"protocol"`
+	Destination string `json:"destination"`
+	Ports       string `json:"ports,omitempty"`
+	Type        *int32 `json:"type,omitempty"`
+	Code        *int32 `json:"code,omitempty"`
+	Description string `json:"description,omitempty"`
+	Log         bool   `json:"log,omitempty"`
+}
+
+func (r SecurityGroupRule) Validate() error {
+	isICMP := r.Protocol == korifiv1alpha1.ProtocolICMP
+	hasPorts := r.Protocol == korifiv1alpha1.ProtocolTCP || r.Protocol == korifiv1alpha1.ProtocolUDP
+
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.Protocol, jellidation.Required, validation.OneOf(
+			korifiv1alpha1.ProtocolTCP,
+			korifiv1alpha1.ProtocolUDP,
+			korifiv1alpha1.ProtocolICMP,
+			korifiv1alpha1.ProtocolAll,
+		)),
+		jellidation.Field(&r.Destination, jellidation.Required, jellidation.By(validateSecurityGroupDestination)),
+		jellidation.Field(&r.Ports,
+			jellidation.When(hasPorts, jellidation.Required.Error("are required for protocols of type TCP and UDP"), jellidation.By(validateSecurityGroupPorts)),
+			jellidation.When(!hasPorts, jellidation.Empty.Error("are not allowed for protocols of type ICMP and ALL")),
+		),
+		jellidation.Field(&r.Type,
+			jellidation.When(isICMP, jellidation.NotNil.Error("is required for protocols of type ICMP"), jellidation.Min(int32(-1)), jellidation.Max(int32(255))),
+			jellidation.When(!isICMP, jellidation.Nil.Error("is only allowed for protocols of type ICMP")),
+		),
+		jellidation.Field(&r.Code,
+			jellidation.When(isICMP, jellidation.NotNil.Error("is required for protocols of type ICMP"), jellidation.Min(int32(-1)), jellidation.Max(int32(255))),
+			jellidation.When(!isICMP, jellidation.Nil.Error("is only allowed for protocols of type ICMP")),
+		),
+	)
+}
+
+func (r SecurityGroupRule) toRecord() repositories.SecurityGroupRule {
+	return repositories.SecurityGroupRule{
+		Protocol:    r.Protocol,
+		Destination: r.Destination,
+		Ports:       r.Ports,
+		Type:        r.Type,
+		Code:        r.Code,
+		Description: r.Description,
+		Log:         r.Log,
+	}
+}
+
+// validateSecurityGroupDestination accepts a comma separated list of IP
+// addresses, CIDRs and IPv4 ranges
+func validateSecurityGroupDestination(value any) error {
+	destination, ok := value.(string)
+	if !ok {
+		return errors.New("wrong input")
+	}
+
+	for _, dest := range strings.Split(destination, ",") {
+		dest = strings.TrimSpace(dest)
+
+		if _, _, err := net.ParseCIDR(dest); err == nil {
+			continue
+		}
+		if net.ParseIP(dest) != nil {
+			continue
+		}
+
+		start, end, isRange := strings.Cut(dest, "-")
+		if !isRange {
+			return errors.New("must be a valid IP address, CIDR or IP range")
+		}
+
+		startIP := net.ParseIP(strings.TrimSpace(start)).To4()
+		endIP := net.ParseIP(strings.TrimSpace(end)).To4()
+		if startIP == nil || endIP == nil || bytes.Compare(startIP, endIP) > 0 {
+			return errors.New("must be a valid IP address, CIDR or IP range")
+		}
+	}
+
+	return nil
+}
+
+// validateSecurityGroupPorts accepts a comma separated list of ports and port
+// ranges
+func validateSecurityGroupPorts(value any) error {
+	ports, ok := value.(string)
+	if !ok {
+		return errors.New("wrong input")
+	}
+
+	for _, portSpec := range strings.Split(ports, ",") {
+		start, end, isRange := strings.Cut(strings.TrimSpace(portSpec), "-")
+
+		startPort, err := strconv.Atoi(strings.TrimSpace(start))
+		if err != nil || startPort < 1 || startPort > 65535 {
+			return errors.New("must be a valid single port, comma separated list of ports, or range of ports")
+		}
+
+		if !isRange {
+			continue
+		}
+
+		endPort, err := strconv.Atoi(strings.TrimSpace(end))
+		if err != nil || endPort < startPort || endPort > 65535 {
+			return errors.New("must be a valid single port, comma separated list of ports, or range of ports")
+		}
+	}
+
+	return nil
+}
+
+type SecurityGroupWorkloads struct {
+	Running bool `json:"running"`
+	Staging bool `json:"staging"`
+}
+
+type SecurityGroupRelationships struct {
+	RunningSpaces ToManyRelationship `json:"running_spaces"`
+	StagingSpaces ToManyRelationship `json:"staging_spaces"`
+}
+
+type ToManyRelationship struct {
+	Data []RelationshipData `json:"data"`
+}
+
+func (r ToManyRelationship) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.Data),
+	)
+}
+
+func (r ToManyRelationship) GUIDs() []string {
+	guids := make([]string, 0, len(r.Data))
+	for _, data := range r.Data {
+		guids = append(guids, data.GUID)
+	}
+
+	return guids
+}
+
+type SecurityGroupCreate struct {
+	Name            string                      `json:"name"`
+	GloballyEnabled SecurityGroupWorkloads      `json:"globally_enabled"`
+	Rules           []SecurityGroupRule         `json:"rules"`
+	Relationships   *SecurityGroupRelationships `json:"relationships"`
+}
+
+func (c SecurityGroupCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, jellidation.Required),
+		jellidation.Field(&c.Rules),
+		jellidation.Field(&c.Relationships),
+	)
+}
+
+func (c SecurityGroupCreate) ToMessage() repositories.CreateSecurityGroupMessage {
+	message := repositories.CreateSecurityGroupMessage{
+		Name:  c.Name,
+		Rules: toSecurityGroupRuleRecords(c.Rules),
+		GloballyEnabled: repositories.SecurityGroupWorkloads{
+			Running: c.GloballyEnabled.Running,
+			Staging: c.GloballyEnabled.Staging,
+		},
+	}
+
+	if c.Relationships != nil {
+		message.RunningSpaceGUIDs = c.Relationships.RunningSpaces.GUIDs()
+		message.StagingSpaceGUIDs = c.Relationships.StagingSpaces.GUIDs()
+	}
+
+	return message
+}
+
+type SecurityGroupWorkloadsUpdate struct {
+	Running *bool `json:"running"`
+	Staging *bool `json:"staging"`
+}
+
+type SecurityGroupUpdate struct {
+	Name            *string                       `json:"name"`
+	GloballyEnabled *SecurityGroupWorkloadsUpdate `json:"globally_enabled"`
+	Rules           *[]SecurityGroupRule          `json:"rules"`
+}
+
+func (u SecurityGroupUpdate) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.Name, jellidation.NilOrNotEmpty),
+		jellidation.Field(&u.Rules),
+	)
+}
+
+func (u SecurityGroupUpdate) ToMessage(guid string) repositories.UpdateSecurityGroupMessage {
+	message := repositories.UpdateSecurityGroupMessage{
+		GUID: guid,
+		Name: u.Name,
+	}
+
+	if u.Rules != nil {
+		message.Rules = tools.PtrTo(toSecurityGroupRuleRecords(*u.Rules))
+	}
+
+	if u.GloballyEnabled != nil {
+		message.GloballyEnabledRunning = u.GloballyEnabled.Running
+		message.GloballyEnabledStaging = u.GloballyEnabled.Staging
+	}
+
+	return message
+}
+
+func toSecurityGroupRuleRecords(rules []SecurityGroupRule) []repositories.SecurityGroupRule {
+	records := make([]repositories.SecurityGroupRule, 0, len(rules))
+	for _, rule := range rules {
+		records = append(records, rule.toRecord())
+	}
+
+	return records
+}
+
+type SecurityGroupBind struct {
+	Data []RelationshipData `json:"data"`
+}
+
+func (b SecurityGroupBind) Validate() error {
+	return jellidation.ValidateStruct(&b,
+		jellidation.Field(&b.Data, jellidation.Required),
+	)
+}
+
+func (b SecurityGroupBind) ToMessage(guid, workload string) repositories.BindSecurityGroupMessage {
+	return repositories.BindSecurityGroupMessage{
+		GUID:       guid,
+		SpaceGUIDs: ToManyRelationship(b).GUIDs(),
+		Workload:   workload,
+	}
+}
+
+type SecurityGroupList struct {
+	GUIDs                  string
+	Names                  string
+	GloballyEnabledRunning *bool
+	GloballyEnabledStaging *bool
+	RunningSpaceGUIDs      string
+	StagingSpaceGUIDs      string
+}
+
+func (l *SecurityGroupList) ToMessage() repositories.ListSecurityGroupsMessage {
+	return repositories.ListSecurityGroupsMessage{
+		GUIDs:                  parse.ArrayParam(l.GUIDs),
+		Names:                  parse.ArrayParam(l.Names),
+		GloballyEnabledRunning: l.GloballyEnabledRunning,
+		GloballyEnabledStaging: l.GloballyEnabledStaging,
+		RunningSpaceGUIDs:      parse.ArrayParam(l.RunningSpaceGUIDs),
+		StagingSpaceGUIDs:      parse.ArrayParam(l.StagingSpaceGUIDs),
+	}
+}
+
+func (l *SecurityGroupList) SupportedKeys() []string {
+	return []string{"guids", "names", "globally_enabled_running", "globally_enabled_staging", "running_space_guids", "staging_space_guids", "per_page", "page"}
+}
+
+func (l *SecurityGroupList) DecodeFromURLValues(values url.Values) error {
+	var err error
+	l.GUIDs = values.Get("guids")
+	l.Names = values.Get("names")
+	l.RunningSpaceGUIDs = values.Get("running_space_guids")
+	l.StagingSpaceGUIDs = values.Get("staging_space_guids")
+	if l.GloballyEnabledRunning, err = getOptionalBool(values, "globally_enabled_running"); err != nil {
+		return err
+	}
+	if l.GloballyEnabledStaging, err = getOptionalBool(values, "globally_enabled_staging"); err != nil {
+		return err
+	}
+	return nil
+}
+
+func getOptionalBool(values url.Values, key string) (*bool, error) {
+	if values.Get(key) == "" {
+		return nil, nil
+	}
+
+	value, err := strconv.ParseBool(values.Get(key))
+	if err != nil {
+		return nil, err
+	}
+
+	return &value, nil
+}
@@ -0,0 +1,283 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("SecurityGroupCreate", func() {
+	var (
+		createPayload  payloads.SecurityGroupCreate
+		decodedPayload *payloads.SecurityGroupCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.SecurityGroupCreate)
+		createPayload = payloads.SecurityGroupCreate{
+			Name:            "my-security-group",
+			GloballyEnabled: payloads.SecurityGroupWorkloads{Running: true},
+			Rules: []payloads.SecurityGroupRule{
+				{Protocol: "tcp", Destination: "10.0.0.1", Ports: "443,8000-9000"},
+				{Protocol: "udp", Destination: "10.0.0.0/24, 10.0.1.1-10.0.1.5", Ports: "53"},
+				{Protocol: "icmp", Destination: "0.0.0.0/0", Type: tools.PtrTo[int32](0), Code: tools.PtrTo[int32](-1)},
+				{Protocol: "all", Destination: "192.168.0.1"},
+			},
+			Relationships: &payloads.SecurityGroupRelationships{
+				RunningSpaces: payloads.ToManyRelationship{Data: []payloads.RelationshipData{{GUID: "space-1"}}},
+				StagingSpaces: payloads.ToManyRelationship{Data: []payloads.RelationshipData{{GUID: "space-2"}}},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(createPayload)))
+	})
+
+	When("name is not set", func() {
+		BeforeEach(func() {
+			createPayload.Name = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("the protocol is invalid", func() {
+		BeforeEach(func() {
+			createPayload.Rules[0].Protocol = "sctp"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "protocol value must be one of")
+		})
+	})
+
+	When("the destination is invalid", func() {
+		BeforeEach(func() {
+			createPayload.Rules[0].Destination = "10.0.0.5-10.0.0.1"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "destination must be a valid IP address, CIDR or IP range")
+		})
+	})
+
+	When("the ports are invalid", func() {
+		BeforeEach(func() {
+			createPayload.Rules[0].Ports = "9000-8000"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "ports must be a valid single port, comma separated list of ports, or range of ports")
+		})
+	})
+
+	When("the ports of a tcp rule are missing", func() {
+		BeforeEach(func() {
+			createPayload.Rules[0].Ports = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "ports are required for protocols of type TCP and UDP")
+		})
+	})
+
+	When("ports are set for an all rule", func() {
+		BeforeEach(func() {
+			createPayload.Rules[3].Ports = "80"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "ports are not allowed for protocols of type ICMP and ALL")
+		})
+	})
+
+	When("the type of an icmp rule is missing", func() {
+		BeforeEach(func() {
+			createPayload.Rules[2].Type = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "type is required for protocols of type ICMP")
+		})
+	})
+
+	When("a code is set for a tcp rule", func() {
+		BeforeEach(func() {
+			createPayload.Rules[0].Code = tools.PtrTo[int32](0)
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "code is only allowed for protocols of type ICMP")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			Expect(createPayload.ToMessage()).To(Equal(repositories.CreateSecurityGroupMessage{
+				Name: "my-security-group",
+				Rules: []repositories.SecurityGroupRule{
+					{Protocol: "tcp", Destination: "10.0.0.1", Ports: "443,8000-9000"},
+					{Protocol: "udp", Destination: "10.0.0.0/24, 10.0.1.1-10.0.1.5", Ports: "53"},
+					{Protocol: "icmp", Destination: "0.0.0.0/0", Type: tools.PtrTo[int32](0), Code: tools.PtrTo[int32](-1)},
+					{Protocol: "all", Destination: "192.168.0.1"},
+				},
+				GloballyEnabled:   repositories.SecurityGroupWorkloads{Running: true},
+				RunningSpaceGUIDs: []string{"space-1"},
+				StagingSpaceGUIDs: []string{"space-2"},
+			}))
+		})
+	})
+})
+
+var _ = Describe("SecurityGroupUpdate", func() {
+	var (
+		updatePayload  payloads.SecurityGroupUpdate
+		decodedPayload *payloads.SecurityGroupUpdate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.SecurityGroupUpdate)
+		updatePayload = payloads.SecurityGroupUpdate{
+			Name:            tools.PtrTo("new-name"),
+			GloballyEnabled: &payloads.SecurityGroupWorkloadsUpdate{Staging: tools.PtrTo(true)},
+			Rules:           &[]payloads.SecurityGroupRule{{Protocol: "tcp", Destination: "10.0.0.1", Ports: "80"}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(updatePayload)))
+	})
+
+	When("the name is empty", func() {
+		BeforeEach(func() {
+			updatePayload.Name = tools.PtrTo("")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("a rule is invalid", func() {
+		BeforeEach(func() {
+			(*updatePayload.Rules)[0].Destination = "not-an-ip"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "destination must be a valid IP address, CIDR or IP range")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			Expect(updatePayload.ToMessage("sg-guid")).To(Equal(repositories.UpdateSecurityGroupMessage{
+				GUID:                   "sg-guid",
+				Name:                   tools.PtrTo("new-name"),
+				Rules:                  &[]repositories.SecurityGroupRule{{Protocol: "tcp", Destination: "10.0.0.1", Ports: "80"}},
+				GloballyEnabledStaging: tools.PtrTo(true),
+			}))
+		})
+	})
+})
+
+var _ = Describe("SecurityGroupBind", func() {
+	var (
+		bindPayload    payloads.SecurityGroupBind
+		decodedPayload *payloads.SecurityGroupBind
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.SecurityGroupBind)
+		bindPayload = payloads.SecurityGroupBind{
+			Data: []payloads.RelationshipData{{GUID: "space-1"}, {GUID: "space-2"}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(bindPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.ToMessage("sg-guid", repositories.SecurityGroupStagingWorkload)).To(Equal(repositories.BindSecurityGroupMessage{
+			GUID:       "sg-guid",
+			SpaceGUIDs: []string{"space-1", "space-2"},
+			Workload:   repositories.SecurityGroupStagingWorkload,
+		}))
+	})
+
+	When("data is empty", func() {
+		BeforeEach(func() {
+			bindPayload.Data = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "data cannot be blank")
+		})
+	})
+})
+
+var _ = Describe("SecurityGroupList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedSecurityGroupList payloads.SecurityGroupList) {
+			actualSecurityGroupList, decodeErr := decodeQuery[payloads.SecurityGroupList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualSecurityGroupList).To(Equal(expectedSecurityGroupList))
+		},
+		Entry("guids", "guids=sg-guid", payloads.SecurityGroupList{GUIDs: "sg-guid"}),
+		Entry("names", "names=name", payloads.SecurityGroupList{Names: "name"}),
+		Entry("globally_enabled_running", "globally_enabled_running=true", payloads.SecurityGroupList{GloballyEnabledRunning: tools.PtrTo(true)}),
+		Entry("globally_enabled_staging", "globally_enabled_staging=false", payloads.SecurityGroupList{GloballyEnabledStaging: tools.PtrTo(false)}),
+		Entry("running_space_guids", "running_space_guids=space-guid", payloads.SecurityGroupList{RunningSpaceGUIDs: "space-guid"}),
+		Entry("staging_space_guids", "staging_space_guids=space-guid", payloads.SecurityGroupList{StagingSpaceGUIDs: "space-guid"}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.SecurityGroupList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("unknown key", "foo=bar", "unsupported query parameter"),
+		Entry("invalid globally_enabled_running", "globally_enabled_running=maybe", "invalid syntax"),
+	)
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			list := payloads.SecurityGroupList{
+				GUIDs:                  "g1,g2",
+				Names:                  "n1",
+				GloballyEnabledRunning: tools.PtrTo(true),
+				RunningSpaceGUIDs:      "s1",
+				StagingSpaceGUIDs:      "s2,s3",
+			}
+			Expect(list.ToMessage()).To(Equal(repositories.ListSecurityGroupsMessage{
+				GUIDs:                  []string{"g1", "g2"},
+				Names:                  []string{"n1"},
+				GloballyEnabledRunning: tools.PtrTo(true),
+				RunningSpaceGUIDs:      []string{"s1"},
+				StagingSpaceGUIDs:      []string{"s2", "s3"},
+			}))
+		})
+	})
+})
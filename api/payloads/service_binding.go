@@ -1,11 +1,13 @@
 package payloads
 
 import (
+	"errors"
 	"net/url"
 
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	jellidation "github.com/jellydator/validation"
 )
 
@@ -17,35 +19,72 @@ type ServiceBindingCreate struct {
 
 func (p ServiceBindingCreate) ToMessage(spaceGUID string) repositories.CreateServiceBindingMessage {
 	return repositories.CreateServiceBindingMessage{
+		Type:                p.Type,
 		Name:                p.Name,
 		ServiceInstanceGUID: p.Relationships.ServiceInstance.Data.GUID,
-		AppGUID:             p.Relationships.App.Data.GUID,
+		AppGUID:             p.Relationships.appGUID(),
 		SpaceGUID:           spaceGUID,
 	}
 }
 
 func (p ServiceBindingCreate) Validate() error {
 	return jellidation.ValidateStruct(&p,
-		jellidation.Field(&p.Type, validation.OneOf("app")),
-		jellidation.Field(&p.Relationships, jellidation.NotNil),
+		jellidation.Field(&p.Type, validation.OneOf(korifiv1alpha1.AppBindingType, korifiv1alpha1.KeyBindingType)),
+		jellidation.Field(&p.Name,
+			jellidation.When(p.Type == korifiv1alpha1.KeyBindingType, jellidation.Required.Error("is required for service keys")),
+		),
+		jellidation.Field(&p.Relationships,
+			jellidation.NotNil,
+			jellidation.By(validateAppRelationship(p.Type)),
+		),
 	)
 }
 
+// validateAppRelationship requires the app relationship for app bindings and
+// rejects it for service keys
+func validateAppRelationship(bindingType string) jellidation.RuleFunc {
+	return func(value any) error {
+		relationships, ok := value.(*ServiceBindingRelationships)
+		if !ok || relationships == nil {
+			return nil
+		}
+
+		if bindingType == korifiv1alpha1.KeyBindingType && relationships.App != nil {
+			return jellidation.Errors{"app": errors.New("must not be set for service keys")}
+		}
+
+		if bindingType != korifiv1alpha1.KeyBindingType && relationships.App == nil {
+			return jellidation.Errors{"app": errors.New("is required")}
+		}
+
+		return nil
+	}
+}
+
 type ServiceBindingRelationships struct {
-	App             *Relationship `json:"app"`
+	App             *Relationship `json:"app,omitempty"`
 	ServiceInstance *Relationship `json:"service_instance"`
 }
 
 func (r ServiceBindingRelationships) Validate() error {
 	return jellidation.ValidateStruct(&r,
-		jellidation.Field(&r.App, jellidation.NotNil),
+		jellidation.Field(&r.App),
 		jellidation.Field(&r.ServiceInstance, jellidation.NotNil),
 	)
 }
 
+func (r *ServiceBindingRelationships) appGUID() string {
+	if r == nil || r.App == nil || r.App.Data == nil {
+		return ""
+	}
+
+	return r.App.Data.GUID
+}
+
 type ServiceBindingList struct {
 	AppGUIDs             string
 	ServiceInstanceGUIDs string
+	Types                string
 	Include              string
 }
 
@@ -53,6 +92,7 @@ func (l *ServiceBindingList) ToMessage() repositories.ListServiceBindingsMessage
 	return repositories.ListServiceBindingsMessage{
 		ServiceInstanceGUIDs: parse.ArrayParam(l.ServiceInstanceGUIDs),
 		AppGUIDs:             parse.ArrayParam(l.AppGUIDs),
+		Types:                parse.ArrayParam(l.Types),
 	}
 }
 
@@ -63,6 +103,7 @@ func (l *ServiceBindingList) SupportedKeys() []string {
 func (l *ServiceBindingList) DecodeFromURLValues(values url.Values) error {
 	l.AppGUIDs = values.Get("app_guids")
 	l.ServiceInstanceGUIDs = values.Get("service_instance_guids")
+	l.Types = values.Get("type")
 	l.Include = values.Get("include")
 	return nil
 }
@@ -15,7 +15,7 @@ var _ = Describe("ServiceBindingList", func() {
 	Describe("decode from url values", func() {
 		It("succeeds", func() {
 			serviceBindingList := payloads.ServiceBindingList{}
-			req, err := http.NewRequest("GET", "http://foo.com/bar?app_guids=app_guid&service_instance_guids=service_instance_guid&include=include", nil)
+			req, err := http.NewRequest("GET", "http://foo.com/bar?app_guids=app_guid&service_instance_guids=service_instance_guid&type=key&include=include", nil)
 			Expect(err).NotTo(HaveOccurred())
 			err = validator.DecodeAndValidateURLValues(req, &serviceBindingList)
 
@@ -23,6 +23,7 @@ var _ = Describe("ServiceBindingList", func() {
 			Expect(serviceBindingList).To(Equal(payloads.ServiceBindingList{
 				AppGUIDs:             "app_guid",
 				ServiceInstanceGUIDs: "service_instance_guid",
+				Types:                "key",
 				Include:              "include",
 			}))
 		})
@@ -66,14 +67,51 @@ var _ = Describe("ServiceBindingCreate", func() {
 		Expect(serviceBindingCreate).To(gstruct.PointTo(Equal(createPayload)))
 	})
 
-	When(`the type is "key"`, func() {
+	When("the type is invalid", func() {
 		BeforeEach(func() {
-			createPayload.Type = "key"
+			createPayload.Type = "route"
 		})
 
 		It("fails", func() {
 			Expect(apiError).To(HaveOccurred())
-			Expect(apiError.Detail()).To(ContainSubstring("type value must be one of: app"))
+			Expect(apiError.Detail()).To(ContainSubstring("type value must be one of: app, key"))
+		})
+	})
+
+	When(`the type is "key"`, func() {
+		BeforeEach(func() {
+			createPayload.Type = "key"
+			createPayload.Name = tools.PtrTo("my-key")
+			createPayload.Relationships.App = nil
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(serviceBindingCreate).To(gstruct.PointTo(Equal(createPayload)))
+		})
+
+		When("the name is missing", func() {
+			BeforeEach(func() {
+				createPayload.Name = nil
+			})
+
+			It("fails", func() {
+				Expect(apiError).To(HaveOccurred())
+				Expect(apiError.Detail()).To(ContainSubstring("name is required for service keys"))
+			})
+		})
+
+		When("the app relationship is set", func() {
+			BeforeEach(func() {
+				createPayload.Relationships.App = &payloads.Relationship{
+					Data: &payloads.RelationshipData{GUID: "app-guid"},
+				}
+			})
+
+			It("fails", func() {
+				Expect(apiError).To(HaveOccurred())
+				Expect(apiError.Detail()).To(ContainSubstring("relationships.app must not be set for service keys"))
+			})
 		})
 	})
 
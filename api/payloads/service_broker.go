@@ -0,0 +1,159 @@
+package payloads
+
+import (
+	"errors"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type ServiceBrokerCreate struct {
+	Name           string                      `json:"name"`
+	URL            string                      `json:"url"`
+	Authentication *BrokerAuthentication       `json:"authentication"`
+	Relationships  *ServiceBrokerRelationships `json:"relationships"`
+	Metadata       Metadata                    `json:"metadata"`
+}
+
+func (c ServiceBrokerCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, jellidation.Required),
+		jellidation.Field(&c.URL, jellidation.Required, jellidation.By(validateBrokerURL)),
+		jellidation.Field(&c.Authentication, jellidation.NotNil),
+		jellidation.Field(&c.Metadata),
+	)
+}
+
+func (c ServiceBrokerCreate) ToMessage() (repositories.CreateServiceBrokerMessage, error) {
+	if c.Relationships != nil && c.Relationships.Space != nil {
+		return repositories.CreateServiceBrokerMessage{}, errors.New("space-scoped service brokers are not supported")
+	}
+
+	return repositories.CreateServiceBrokerMessage{
+		Name:        c.Name,
+		URL:         c.URL,
+		Credentials: c.Authentication.toBasicAuthentication(),
+		Metadata: repositories.Metadata{
+			Labels:      c.Metadata.Labels,
+			Annotations: c.Metadata.Annotations,
+		},
+	}, nil
+}
+
+type ServiceBrokerRelationships struct {
+	Space *Relationship `json:"space"`
+}
+
+type BrokerAuthentication struct {
+	Type        string            `json:"type"`
+	Credentials BrokerCredentials `json:"credentials"`
+}
+
+func (a BrokerAuthentication) Validate() error {
+	return jellidation.ValidateStruct(&a,
+		jellidation.Field(&a.Type, jellidation.Required, validation.OneOf("basic")),
+		jellidation.Field(&a.Credentials),
+	)
+}
+
+func (a *BrokerAuthentication) toBasicAuthentication() repositories.BasicAuthentication {
+	return repositories.BasicAuthentication{
+		Username: a.Credentials.Username,
+		Password: a.Credentials.Password,
+	}
+}
+
+type BrokerCredentials struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+func (c BrokerCredentials) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Username, jellidation.Required),
+		jellidation.Field(&c.Password, jellidation.Required),
+	)
+}
+
+func validateBrokerURL(value any) error {
+	brokerURL, ok := value.(string)
+	if !ok {
+		return errors.New("wrong input")
+	}
+
+	parsedURL, err := url.ParseRequestURI(brokerURL)
+	if err != nil || parsedURL.Host == "" || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") {
+		return errors.New("must be a valid http(s) URL")
+	}
+
+	return nil
+}
+
+type ServiceBrokerUpdate struct {
+	Name           *string               `json:"name"`
+	URL            *string               `json:"url"`
+	Authentication *BrokerAuthentication `json:"authentication"`
+	Metadata       MetadataPatch         `json:"metadata"`
+}
+
+func (u ServiceBrokerUpdate) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.Name, jellidation.NilOrNotEmpty),
+		jellidation.Field(&u.URL, jellidation.NilOrNotEmpty, jellidation.By(func(value any) error {
+			brokerURL, ok := value.(*string)
+			if !ok || brokerURL == nil {
+				return nil
+			}
+			return validateBrokerURL(*brokerURL)
+		})),
+		jellidation.Field(&u.Authentication),
+		jellidation.Field(&u.Metadata),
+	)
+}
+
+// OnlyMetadataChanged tells whether the update can be applied synchronously,
+// i.e. without the broker catalog having to be fetched again
+func (u ServiceBrokerUpdate) OnlyMetadataChanged() bool {
+	return u.Name == nil && u.URL == nil && u.Authentication == nil
+}
+
+func (u ServiceBrokerUpdate) ToMessage(guid string) repositories.UpdateServiceBrokerMessage {
+	message := repositories.UpdateServiceBrokerMessage{
+		GUID: guid,
+		Name: u.Name,
+		URL:  u.URL,
+		MetadataPatch: repositories.MetadataPatch{
+			Labels:      u.Metadata.Labels,
+			Annotations: u.Metadata.Annotations,
+		},
+	}
+
+	if u.Authentication != nil {
+		credentials := u.Authentication.toBasicAuthentication()
+		message.Credentials = &credentials
+	}
+
+	return message
+}
+
+type ServiceBrokerList struct {
+	Names string
+}
+
+func (l *ServiceBrokerList) ToMessage() repositories.ListServiceBrokersMessage {
+	return repositories.ListServiceBrokersMessage{
+		Names: parse.ArrayParam(l.Names),
+	}
+}
+
+func (l *ServiceBrokerList) SupportedKeys() []string {
+	return []string{"names", "per_page", "page"}
+}
+
+func (l *ServiceBrokerList) DecodeFromURLValues(values url.Values) error {
+	l.Names = values.Get("names")
+	return nil
+}
@@ -0,0 +1,211 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("ServiceBrokerCreate", func() {
+	var (
+		createPayload  payloads.ServiceBrokerCreate
+		decodedPayload *payloads.ServiceBrokerCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.ServiceBrokerCreate)
+		createPayload = payloads.ServiceBrokerCreate{
+			Name: "my-broker",
+			URL:  "https://my.broker",
+			Authentication: &payloads.BrokerAuthentication{
+				Type: "basic",
+				Credentials: payloads.BrokerCredentials{
+					Username: "user",
+					Password: "pass",
+				},
+			},
+			Metadata: payloads.Metadata{
+				Labels:      map[string]string{"foo": "bar"},
+				Annotations: map[string]string{"bar": "baz"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(createPayload)))
+	})
+
+	When("name is not set", func() {
+		BeforeEach(func() {
+			createPayload.Name = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("url is invalid", func() {
+		BeforeEach(func() {
+			createPayload.URL = "my.broker"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "url must be a valid http(s) URL")
+		})
+	})
+
+	When("authentication is not set", func() {
+		BeforeEach(func() {
+			createPayload.Authentication = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "authentication is required")
+		})
+	})
+
+	When("the authentication type is not basic", func() {
+		BeforeEach(func() {
+			createPayload.Authentication.Type = "oauth"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "type value must be one of: basic")
+		})
+	})
+
+	When("the password is not set", func() {
+		BeforeEach(func() {
+			createPayload.Authentication.Credentials.Password = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "password cannot be blank")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			message, err := createPayload.ToMessage()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(message).To(Equal(repositories.CreateServiceBrokerMessage{
+				Name: "my-broker",
+				URL:  "https://my.broker",
+				Credentials: repositories.BasicAuthentication{
+					Username: "user",
+					Password: "pass",
+				},
+				Metadata: repositories.Metadata{
+					Labels:      map[string]string{"foo": "bar"},
+					Annotations: map[string]string{"bar": "baz"},
+				},
+			}))
+		})
+
+		When("the broker is space scoped", func() {
+			BeforeEach(func() {
+				createPayload.Relationships = &payloads.ServiceBrokerRelationships{
+					Space: &payloads.Relationship{Data: &payloads.RelationshipData{GUID: "space-guid"}},
+				}
+			})
+
+			It("returns an error", func() {
+				_, err := createPayload.ToMessage()
+				Expect(err).To(MatchError(ContainSubstring("space-scoped service brokers are not supported")))
+			})
+		})
+	})
+})
+
+var _ = Describe("ServiceBrokerUpdate", func() {
+	var (
+		updatePayload  payloads.ServiceBrokerUpdate
+		decodedPayload *payloads.ServiceBrokerUpdate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.ServiceBrokerUpdate)
+		updatePayload = payloads.ServiceBrokerUpdate{
+			Name: tools.PtrTo("new-name"),
+			URL:  tools.PtrTo("https://new.broker"),
+			Metadata: payloads.MetadataPatch{
+				Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(updatePayload)))
+	})
+
+	When("the url is invalid", func() {
+		BeforeEach(func() {
+			updatePayload.URL = tools.PtrTo("not-a-url")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "url must be a valid http(s) URL")
+		})
+	})
+
+	Describe("OnlyMetadataChanged", func() {
+		It("returns false when the broker itself is updated", func() {
+			Expect(updatePayload.OnlyMetadataChanged()).To(BeFalse())
+		})
+
+		When("only the metadata is updated", func() {
+			BeforeEach(func() {
+				updatePayload.Name = nil
+				updatePayload.URL = nil
+			})
+
+			It("returns true", func() {
+				Expect(updatePayload.OnlyMetadataChanged()).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("ToMessage", func() {
+		BeforeEach(func() {
+			updatePayload.Authentication = &payloads.BrokerAuthentication{
+				Type: "basic",
+				Credentials: payloads.BrokerCredentials{
+					Username: "user",
+					Password: "pass",
+				},
+			}
+		})
+
+		It("converts to a repo message", func() {
+			Expect(updatePayload.ToMessage("broker-guid")).To(Equal(repositories.UpdateServiceBrokerMessage{
+				GUID: "broker-guid",
+				Name: tools.PtrTo("new-name"),
+				URL:  tools.PtrTo("https://new.broker"),
+				Credentials: &repositories.BasicAuthentication{
+					Username: "user",
+					Password: "pass",
+				},
+				MetadataPatch: repositories.MetadataPatch{
+					Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+				},
+			}))
+		})
+	})
+})
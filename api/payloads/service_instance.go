@@ -1,28 +1,38 @@
 package payloads
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
 	"regexp"
+	"time"
 
 	"code.cloudfoundry.org/korifi/api/payloads/parse"
 	"code.cloudfoundry.org/korifi/api/payloads/validation"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
 	jellidation "github.com/jellydator/validation"
 )
 
 type ServiceInstanceCreate struct {
-	Name          string                        `json:"name"`
-	Type          string                        `json:"type"`
-	Tags          []string                      `json:"tags"`
-	Credentials   map[string]string             `json:"credentials"`
-	Relationships *ServiceInstanceRelationships `json:"relationships"`
-	Metadata      Metadata                      `json:"metadata"`
+	Name            string                        `json:"name"`
+	Type            string                        `json:"type"`
+	Tags            []string                      `json:"tags"`
+	Credentials     map[string]string             `json:"credentials"`
+	SyslogDrainURL  *string                       `json:"syslog_drain_url"`
+	RouteServiceURL *string                       `json:"route_service_url"`
+	Parameters      map[string]any                `json:"parameters"`
+	Relationships   *ServiceInstanceRelationships `json:"relationships"`
+	Metadata        Metadata                      `json:"metadata"`
 }
 
-const maxTagsLength = 2048
+const (
+	maxTagsLength                 = 2048
+	routeServiceHostLookupTimeout = 5 * time.Second
+)
 
 func validateTagLength(tags any) error {
 	tagSlice, ok := tags.([]string)
@@ -41,58 +51,174 @@ func validateTagLength(tags any) error {
 	return nil
 }
 
+func validateSyslogDrainURL(value any) error {
+	syslogDrainURL, ok := value.(*string)
+	if !ok {
+		return errors.New("wrong input")
+	}
+
+	if syslogDrainURL == nil || *syslogDrainURL == "" {
+		return nil
+	}
+
+	parsedURL, err := url.ParseRequestURI(*syslogDrainURL)
+	if err != nil || parsedURL.Host == "" {
+		return errors.New("must be a valid URL")
+	}
+
+	return nil
+}
+
+func validateRouteServiceURL(value any) error {
+	routeServiceURL, ok := value.(*string)
+	if !ok {
+		return errors.New("wrong input")
+	}
+
+	if routeServiceURL == nil || *routeServiceURL == "" {
+		return nil
+	}
+
+	parsedURL, err := url.ParseRequestURI(*routeServiceURL)
+	if err != nil || parsedURL.Host == "" || parsedURL.Scheme != "https" {
+		return errors.New("must be a valid https URL")
+	}
+
+	// hosts that cannot be resolved yet are left to the route binding
+	// controller, which resolves them again before routing any traffic
+	ctx, cancel := context.WithTimeout(context.Background(), routeServiceHostLookupTimeout)
+	defer cancel()
+	if _, err = tools.ResolvePublicHost(ctx, parsedURL.Hostname()); errors.Is(err, tools.ErrHostNotPublic) {
+		return errors.New("must not point at a loopback, link-local, private or cluster host")
+	}
+
+	return nil
+}
+
 func (c ServiceInstanceCreate) Validate() error {
 	return jellidation.ValidateStruct(&c,
 		jellidation.Field(&c.Name, jellidation.Required),
-		jellidation.Field(&c.Type, jellidation.Required, validation.OneOf("user-provided")),
+		jellidation.Field(&c.Type, jellidation.Required, validation.OneOf(korifiv1alpha1.UserProvidedType, korifiv1alpha1.ManagedType)),
 		jellidation.Field(&c.Tags, jellidation.By(validateTagLength)),
-		jellidation.Field(&c.Relationships, jellidation.NotNil),
+		jellidation.Field(&c.Credentials,
+			jellidation.When(c.Type == korifiv1alpha1.ManagedType, jellidation.Empty.Error("can only be set for user-provided service instances")),
+		),
+		jellidation.Field(&c.SyslogDrainURL,
+			jellidation.When(c.Type == korifiv1alpha1.ManagedType, jellidation.Nil.Error("can only be set for user-provided service instances")),
+			jellidation.By(validateSyslogDrainURL),
+		),
+		jellidation.Field(&c.RouteServiceURL,
+			jellidation.When(c.Type == korifiv1alpha1.ManagedType, jellidation.Nil.Error("can only be set for user-provided service instances")),
+			jellidation.By(validateRouteServiceURL),
+		),
+		jellidation.Field(&c.Parameters,
+			jellidation.When(c.Type != korifiv1alpha1.ManagedType, jellidation.Empty.Error("can only be set for managed service instances")),
+		),
+		jellidation.Field(&c.Relationships,
+			jellidation.NotNil,
+			jellidation.When(c.Type == korifiv1alpha1.ManagedType, jellidation.By(requireServicePlanRelationship)),
+		),
 		jellidation.Field(&c.Metadata),
 	)
 }
 
+func requireServicePlanRelationship(value any) error {
+	relationships, ok := value.(*ServiceInstanceRelationships)
+	if !ok || relationships == nil {
+		return nil
+	}
+
+	if relationships.ServicePlan == nil {
+		return errors.New("service_plan is required for managed service instances")
+	}
+
+	return nil
+}
+
 func (p ServiceInstanceCreate) ToServiceInstanceCreateMessage() repositories.CreateServiceInstanceMessage {
 	return repositories.CreateServiceInstanceMessage{
-		Name:        p.Name,
-		SpaceGUID:   p.Relationships.Space.Data.GUID,
-		Credentials: p.Credentials,
-		Type:        p.Type,
-		Tags:        p.Tags,
-		Labels:      p.Metadata.Labels,
-		Annotations: p.Metadata.Annotations,
+		Name:            p.Name,
+		SpaceGUID:       p.Relationships.Space.Data.GUID,
+		Credentials:     p.Credentials,
+		SyslogDrainURL:  p.SyslogDrainURL,
+		RouteServiceURL: p.RouteServiceURL,
+		Parameters:      p.Parameters,
+		PlanGUID:        p.Relationships.servicePlanGUID(),
+		Type:            p.Type,
+		Tags:            p.Tags,
+		Labels:          p.Metadata.Labels,
+		Annotations:     p.Metadata.Annotations,
 	}
 }
 
 type ServiceInstanceRelationships struct {
-	Space *Relationship `json:"space"`
+	Space       *Relationship `json:"space"`
+	ServicePlan *Relationship `json:"service_plan,omitempty"`
 }
 
 func (r ServiceInstanceRelationships) Validate() error {
 	return jellidation.ValidateStruct(&r,
 		jellidation.Field(&r.Space, jellidation.NotNil),
+		jellidation.Field(&r.ServicePlan),
+	)
+}
+
+func (r *ServiceInstanceRelationships) servicePlanGUID() string {
+	if r == nil || r.ServicePlan == nil || r.ServicePlan.Data == nil {
+		return ""
+	}
+
+	return r.ServicePlan.Data.GUID
+}
+
+type ServiceInstancePatchRelationships struct {
+	ServicePlan *Relationship `json:"service_plan"`
+}
+
+func (r ServiceInstancePatchRelationships) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.ServicePlan, jellidation.NotNil),
 	)
 }
 
 type ServiceInstancePatch struct {
-	Name        *string            `json:"name,omitempty"`
-	Tags        *[]string          `json:"tags,omitempty"`
-	Credentials *map[string]string `json:"credentials,omitempty"`
-	Metadata    MetadataPatch      `json:"metadata"`
+	Name            *string                            `json:"name,omitempty"`
+	Tags            *[]string                          `json:"tags,omitempty"`
+	Credentials     *map[string]string                 `json:"credentials,omitempty"`
+	SyslogDrainURL  *string                            `json:"syslog_drain_url,omitempty"`
+	RouteServiceURL *string                            `json:"route_service_url,omitempty"`
+	Parameters      *map[string]any                    `json:"parameters,omitempty"`
+	Relationships   *ServiceInstancePatchRelationships `json:"relationships,omitempty"`
+	Metadata        MetadataPatch                      `json:"metadata"`
 }
 
 func (p ServiceInstancePatch) Validate() error {
 	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.SyslogDrainURL, jellidation.By(validateSyslogDrainURL)),
+		jellidation.Field(&p.RouteServiceURL, jellidation.By(validateRouteServiceURL)),
+		jellidation.Field(&p.Relationships),
 		jellidation.Field(&p.Metadata),
 	)
 }
 
+// ChangesServiceInstance reports whether the patch changes anything other
+// than metadata, i.e. whether a managed service instance has to be updated by
+// its broker
+func (p ServiceInstancePatch) ChangesServiceInstance() bool {
+	return p.Name != nil || p.Tags != nil || p.Parameters != nil || p.Relationships != nil
+}
+
 func (p ServiceInstancePatch) ToServiceInstancePatchMessage(spaceGUID, appGUID string) repositories.PatchServiceInstanceMessage {
 	return repositories.PatchServiceInstanceMessage{
-		SpaceGUID:   spaceGUID,
-		GUID:        appGUID,
-		Name:        p.Name,
-		Credentials: p.Credentials,
-		Tags:        p.Tags,
+		SpaceGUID:       spaceGUID,
+		GUID:            appGUID,
+		Name:            p.Name,
+		Credentials:     p.Credentials,
+		SyslogDrainURL:  p.SyslogDrainURL,
+		RouteServiceURL: p.RouteServiceURL,
+		Parameters:      p.Parameters,
+		PlanGUID:        p.servicePlanGUID(),
+		Tags:            p.Tags,
 		MetadataPatch: repositories.MetadataPatch{
 			Labels:      p.Metadata.Labels,
 			Annotations: p.Metadata.Annotations,
@@ -100,6 +226,14 @@ func (p ServiceInstancePatch) ToServiceInstancePatchMessage(spaceGUID, appGUID s
 	}
 }
 
+func (p ServiceInstancePatch) servicePlanGUID() *string {
+	if p.Relationships == nil || p.Relationships.ServicePlan == nil || p.Relationships.ServicePlan.Data == nil {
+		return nil
+	}
+
+	return &p.Relationships.ServicePlan.Data.GUID
+}
+
 func (p *ServiceInstancePatch) UnmarshalJSON(data []byte) error {
 	type alias ServiceInstancePatch
 
@@ -123,6 +257,14 @@ func (p *ServiceInstancePatch) UnmarshalJSON(data []byte) error {
 		patch.Credentials = &map[string]string{}
 	}
 
+	if v, ok := patchMap["syslog_drain_url"]; ok && v == nil {
+		patch.SyslogDrainURL = tools.PtrTo("")
+	}
+
+	if v, ok := patchMap["route_service_url"]; ok && v == nil {
+		patch.RouteServiceURL = tools.PtrTo("")
+	}
+
 	*p = ServiceInstancePatch(patch)
 
 	return nil
@@ -161,3 +303,26 @@ func (l *ServiceInstanceList) DecodeFromURLValues(values url.Values) error {
 	l.OrderBy = values.Get("order_by")
 	return nil
 }
+
+type ServiceInstanceShare struct {
+	Data []RelationshipData `json:"data"`
+}
+
+func (s ServiceInstanceShare) Validate() error {
+	return jellidation.ValidateStruct(&s,
+		jellidation.Field(&s.Data, jellidation.Required),
+	)
+}
+
+func (s ServiceInstanceShare) ToMessage(guid, spaceGUID string) repositories.ShareServiceInstanceMessage {
+	targetSpaceGUIDs := make([]string, 0, len(s.Data))
+	for _, data := range s.Data {
+		targetSpaceGUIDs = append(targetSpaceGUIDs, data.GUID)
+	}
+
+	return repositories.ShareServiceInstanceMessage{
+		GUID:             guid,
+		SpaceGUID:        spaceGUID,
+		TargetSpaceGUIDs: targetSpaceGUIDs,
+	}
+}
@@ -105,7 +105,130 @@ var _ = Describe("ServiceInstanceCreate", func() {
 		})
 
 		It("returns an appropriate error", func() {
-			expectUnprocessableEntityError(validatorErr, "type value must be one of: user-provided")
+			expectUnprocessableEntityError(validatorErr, "type value must be one of: user-provided, managed")
+		})
+	})
+
+	When("parameters are set for a user-provided service instance", func() {
+		BeforeEach(func() {
+			createPayload.Parameters = map[string]any{"foo": "bar"}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "parameters can only be set for managed service instances")
+		})
+	})
+
+	When("the syslog drain and route service URLs are set", func() {
+		BeforeEach(func() {
+			createPayload.SyslogDrainURL = tools.PtrTo("syslog://logs.example.com:514")
+			createPayload.RouteServiceURL = tools.PtrTo("https://route-service.example.com")
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(serviceInstanceCreate).To(PointTo(Equal(createPayload)))
+		})
+
+		It("converts to repo message correctly", func() {
+			msg := serviceInstanceCreate.ToServiceInstanceCreateMessage()
+			Expect(msg.SyslogDrainURL).To(PointTo(Equal("syslog://logs.example.com:514")))
+			Expect(msg.RouteServiceURL).To(PointTo(Equal("https://route-service.example.com")))
+		})
+	})
+
+	When("the syslog drain URL is invalid", func() {
+		BeforeEach(func() {
+			createPayload.SyslogDrainURL = tools.PtrTo("not-a-url")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "syslog_drain_url must be a valid URL")
+		})
+	})
+
+	When("the route service URL points at a non-public host", func() {
+		BeforeEach(func() {
+			createPayload.RouteServiceURL = tools.PtrTo("https://169.254.169.254/latest")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "route_service_url must not point at a loopback, link-local, private or cluster host")
+		})
+	})
+
+	When("the route service URL points at a cluster host", func() {
+		BeforeEach(func() {
+			createPayload.RouteServiceURL = tools.PtrTo("https://kubernetes.default.svc.cluster.local")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "route_service_url must not point at a loopback, link-local, private or cluster host")
+		})
+	})
+
+	When("the route service URL is not https", func() {
+		BeforeEach(func() {
+			createPayload.RouteServiceURL = tools.PtrTo("http://route-service.example.com")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "route_service_url must be a valid https URL")
+		})
+	})
+
+	When("the service instance is managed", func() {
+		BeforeEach(func() {
+			createPayload.Type = "managed"
+			createPayload.Credentials = nil
+			createPayload.Parameters = map[string]any{"foo": "bar"}
+			createPayload.Relationships.ServicePlan = &payloads.Relationship{
+				Data: &payloads.RelationshipData{
+					GUID: "plan-guid",
+				},
+			}
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(serviceInstanceCreate).To(PointTo(Equal(createPayload)))
+		})
+
+		It("converts to repo message correctly", func() {
+			msg := serviceInstanceCreate.ToServiceInstanceCreateMessage()
+			Expect(msg.Type).To(Equal("managed"))
+			Expect(msg.PlanGUID).To(Equal("plan-guid"))
+			Expect(msg.Parameters).To(Equal(map[string]any{"foo": "bar"}))
+		})
+
+		When("credentials are set", func() {
+			BeforeEach(func() {
+				createPayload.Credentials = map[string]string{"username": "bob"}
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "credentials can only be set for user-provided service instances")
+			})
+		})
+
+		When("a syslog drain URL is set", func() {
+			BeforeEach(func() {
+				createPayload.SyslogDrainURL = tools.PtrTo("syslog://logs.example.com:514")
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "syslog_drain_url can only be set for user-provided service instances")
+			})
+		})
+
+		When("the service plan relationship is not set", func() {
+			BeforeEach(func() {
+				createPayload.Relationships.ServicePlan = nil
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "service_plan is required for managed service instances")
+			})
 		})
 	})
 
@@ -202,6 +325,17 @@ var _ = Describe("ServiceInstancePatch custom unmarshalling", func() {
 		})
 	})
 
+	When("the syslog drain and route service URLs are present but null", func() {
+		BeforeEach(func() {
+			payload = `{"syslog_drain_url": null, "route_service_url": null}`
+		})
+
+		It("defaults them to empty strings", func() {
+			Expect(patch.SyslogDrainURL).To(PointTo(BeEmpty()))
+			Expect(patch.RouteServiceURL).To(PointTo(BeEmpty()))
+		})
+	})
+
 	When("tags and credentials are present but null", func() {
 		BeforeEach(func() {
 			payload = `{"tags": null, "credentials": null}`
@@ -259,6 +393,88 @@ var _ = Describe("ServiceInstancePatch", func() {
 		})
 	})
 
+	When("the service plan relationship is set", func() {
+		BeforeEach(func() {
+			patchPayload.Parameters = &map[string]any{"foo": "bar"}
+			patchPayload.Relationships = &payloads.ServiceInstancePatchRelationships{
+				ServicePlan: &payloads.Relationship{
+					Data: &payloads.RelationshipData{
+						GUID: "plan-guid",
+					},
+				},
+			}
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(serviceInstancePatch).To(PointTo(Equal(patchPayload)))
+		})
+
+		It("converts to repo message correctly", func() {
+			msg := serviceInstancePatch.ToServiceInstancePatchMessage("space-guid", "app-guid")
+			Expect(msg.PlanGUID).To(PointTo(Equal("plan-guid")))
+			Expect(msg.Parameters).To(PointTo(Equal(map[string]any{"foo": "bar"})))
+		})
+
+		When("the relationship data is missing", func() {
+			BeforeEach(func() {
+				patchPayload.Relationships.ServicePlan.Data = nil
+			})
+
+			It("returns an appropriate error", func() {
+				expectUnprocessableEntityError(validatorErr, "data is required")
+			})
+		})
+	})
+
+	When("the syslog drain and route service URLs are set", func() {
+		BeforeEach(func() {
+			patchPayload.SyslogDrainURL = tools.PtrTo("syslog://logs.example.com:514")
+			patchPayload.RouteServiceURL = tools.PtrTo("https://route-service.example.com")
+		})
+
+		It("succeeds", func() {
+			Expect(validatorErr).NotTo(HaveOccurred())
+			Expect(serviceInstancePatch).To(PointTo(Equal(patchPayload)))
+		})
+
+		It("converts to repo message correctly", func() {
+			msg := serviceInstancePatch.ToServiceInstancePatchMessage("space-guid", "app-guid")
+			Expect(msg.SyslogDrainURL).To(PointTo(Equal("syslog://logs.example.com:514")))
+			Expect(msg.RouteServiceURL).To(PointTo(Equal("https://route-service.example.com")))
+		})
+	})
+
+	When("the route service URL points at localhost", func() {
+		BeforeEach(func() {
+			patchPayload.RouteServiceURL = tools.PtrTo("https://localhost:8443")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "route_service_url must not point at a loopback, link-local, private or cluster host")
+		})
+	})
+
+	When("the route service URL is invalid", func() {
+		BeforeEach(func() {
+			patchPayload.RouteServiceURL = tools.PtrTo("https://")
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "route_service_url must be a valid https URL")
+		})
+	})
+
+	Context("ChangesServiceInstance", func() {
+		It("returns true when non-metadata fields are set", func() {
+			Expect(patchPayload.ChangesServiceInstance()).To(BeTrue())
+		})
+
+		It("returns false when only metadata is set", func() {
+			Expect(payloads.ServiceInstancePatch{Metadata: patchPayload.Metadata}.ChangesServiceInstance()).To(BeFalse())
+		})
+	})
+
 	When("metadata is invalid", func() {
 		BeforeEach(func() {
 			patchPayload.Metadata.Labels["foo.cloudfoundry.org/bar"] = tools.PtrTo("baz")
@@ -289,3 +505,59 @@ var _ = Describe("ServiceInstancePatch", func() {
 		})
 	})
 })
+
+var _ = Describe("ServiceInstanceShare", func() {
+	var (
+		sharePayload        payloads.ServiceInstanceShare
+		decodedSharePayload *payloads.ServiceInstanceShare
+		validatorErr        error
+	)
+
+	BeforeEach(func() {
+		decodedSharePayload = new(payloads.ServiceInstanceShare)
+		sharePayload = payloads.ServiceInstanceShare{
+			Data: []payloads.RelationshipData{
+				{GUID: "space-1"},
+				{GUID: "space-2"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(sharePayload), decodedSharePayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedSharePayload).To(PointTo(Equal(sharePayload)))
+	})
+
+	When("data is empty", func() {
+		BeforeEach(func() {
+			sharePayload.Data = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "data cannot be blank")
+		})
+	})
+
+	When("a space guid is empty", func() {
+		BeforeEach(func() {
+			sharePayload.Data[1].GUID = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "guid cannot be blank")
+		})
+	})
+
+	Context("ToMessage", func() {
+		It("converts to repo message correctly", func() {
+			msg := sharePayload.ToMessage("instance-guid", "space-guid")
+			Expect(msg.GUID).To(Equal("instance-guid"))
+			Expect(msg.SpaceGUID).To(Equal("space-guid"))
+			Expect(msg.TargetSpaceGUIDs).To(Equal([]string{"space-1", "space-2"}))
+		})
+	})
+})
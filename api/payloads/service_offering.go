@@ -0,0 +1,41 @@
+package payloads
+
+import (
+	"net/url"
+	"regexp"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type ServiceOfferingList struct {
+	Names              string
+	ServiceBrokerGUIDs string
+	OrganizationGUIDs  string
+	SpaceGUIDs         string
+}
+
+func (l *ServiceOfferingList) ToMessage() repositories.ListServiceOfferingMessage {
+	return repositories.ListServiceOfferingMessage{
+		Names:              parse.ArrayParam(l.Names),
+		ServiceBrokerGUIDs: parse.ArrayParam(l.ServiceBrokerGUIDs),
+		OrganizationGUIDs:  parse.ArrayParam(l.OrganizationGUIDs),
+		SpaceGUIDs:         parse.ArrayParam(l.SpaceGUIDs),
+	}
+}
+
+func (l *ServiceOfferingList) SupportedKeys() []string {
+	return []string{"names", "service_broker_guids", "organization_guids", "space_guids", "per_page", "page"}
+}
+
+func (l *ServiceOfferingList) IgnoredKeys() []*regexp.Regexp {
+	return []*regexp.Regexp{regexp.MustCompile(`fields\[.+\]`)}
+}
+
+func (l *ServiceOfferingList) DecodeFromURLValues(values url.Values) error {
+	l.Names = values.Get("names")
+	l.ServiceBrokerGUIDs = values.Get("service_broker_guids")
+	l.OrganizationGUIDs = values.Get("organization_guids")
+	l.SpaceGUIDs = values.Get("space_guids")
+	return nil
+}
@@ -0,0 +1,32 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ServiceOfferingList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedServiceOfferingList payloads.ServiceOfferingList) {
+			actualServiceOfferingList, decodeErr := decodeQuery[payloads.ServiceOfferingList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualServiceOfferingList).To(Equal(expectedServiceOfferingList))
+		},
+		Entry("names", "names=name", payloads.ServiceOfferingList{Names: "name"}),
+		Entry("service_broker_guids", "service_broker_guids=broker-guid", payloads.ServiceOfferingList{ServiceBrokerGUIDs: "broker-guid"}),
+		Entry("organization_guids", "organization_guids=org-guid", payloads.ServiceOfferingList{OrganizationGUIDs: "org-guid"}),
+		Entry("space_guids", "space_guids=space-guid", payloads.ServiceOfferingList{SpaceGUIDs: "space-guid"}),
+		Entry("fields[xxx]", "fields[service_broker]=name", payloads.ServiceOfferingList{}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.ServiceOfferingList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("unknown key", "foo=bar", "unsupported query parameter"),
+	)
+})
@@ -0,0 +1,126 @@
+package payloads
+
+import (
+	"net/url"
+	"regexp"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/payloads/validation"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	jellidation "github.com/jellydator/validation"
+)
+
+type ServicePlanList struct {
+	Names                string
+	ServiceOfferingGUIDs string
+	ServiceBrokerGUIDs   string
+	OrganizationGUIDs    string
+	SpaceGUIDs           string
+	Include              string
+}
+
+func (l ServicePlanList) Validate() error {
+	return jellidation.ValidateStruct(&l,
+		jellidation.Field(&l.Include, validation.OneOf("service_offering")),
+	)
+}
+
+func (l *ServicePlanList) ToMessage() repositories.ListServicePlanMessage {
+	return repositories.ListServicePlanMessage{
+		Names:                parse.ArrayParam(l.Names),
+		ServiceOfferingGUIDs: parse.ArrayParam(l.ServiceOfferingGUIDs),
+		ServiceBrokerGUIDs:   parse.ArrayParam(l.ServiceBrokerGUIDs),
+		OrganizationGUIDs:    parse.ArrayParam(l.OrganizationGUIDs),
+		SpaceGUIDs:           parse.ArrayParam(l.SpaceGUIDs),
+	}
+}
+
+func (l *ServicePlanList) SupportedKeys() []string {
+	return []string{"names", "service_offering_guids", "service_broker_guids", "organization_guids", "space_guids", "include", "per_page", "page"}
+}
+
+func (l *ServicePlanList) IgnoredKeys() []*regexp.Regexp {
+	return []*regexp.Regexp{regexp.MustCompile(`fields\[.+\]`)}
+}
+
+func (l *ServicePlanList) DecodeFromURLValues(values url.Values) error {
+	l.Names = values.Get("names")
+	l.ServiceOfferingGUIDs = values.Get("service_offering_guids")
+	l.ServiceBrokerGUIDs = values.Get("service_broker_guids")
+	l.OrganizationGUIDs = values.Get("organization_guids")
+	l.SpaceGUIDs = values.Get("space_guids")
+	l.Include = values.Get("include")
+	return nil
+}
+
+type VisibilityOrganization struct {
+	GUID string `json:"guid"`
+}
+
+func (o VisibilityOrganization) Validate() error {
+	return jellidation.ValidateStruct(&o,
+		jellidation.Field(&o.GUID, jellidation.Required),
+	)
+}
+
+type ServicePlanVisibilityUpdate struct {
+	Type          string                   `json:"type"`
+	Organizations []VisibilityOrganization `json:"organizations"`
+}
+
+func (u ServicePlanVisibilityUpdate) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.Type,
+			jellidation.Required,
+			validation.OneOf(
+				korifiv1alpha1.AdminServicePlanVisibilityType,
+				korifiv1alpha1.PublicServicePlanVisibilityType,
+				korifiv1alpha1.OrganizationServicePlanVisibilityType,
+			),
+		),
+		jellidation.Field(&u.Organizations,
+			jellidation.When(u.Type == korifiv1alpha1.OrganizationServicePlanVisibilityType, jellidation.Required),
+			jellidation.When(u.Type != korifiv1alpha1.OrganizationServicePlanVisibilityType, jellidation.Empty.Error("can only be set for the organization visibility type")),
+		),
+	)
+}
+
+func (u ServicePlanVisibilityUpdate) ToMessage(planGUID string) repositories.UpdateServicePlanVisibilityMessage {
+	return repositories.UpdateServicePlanVisibilityMessage{
+		PlanGUID:      planGUID,
+		Type:          u.Type,
+		Organizations: toOrgGUIDs(u.Organizations),
+	}
+}
+
+type ServicePlanVisibilityApply struct {
+	Type          string                   `json:"type"`
+	Organizations []VisibilityOrganization `json:"organizations"`
+}
+
+func (a ServicePlanVisibilityApply) Validate() error {
+	return jellidation.ValidateStruct(&a,
+		jellidation.Field(&a.Type,
+			jellidation.Required,
+			validation.OneOf(korifiv1alpha1.OrganizationServicePlanVisibilityType),
+		),
+		jellidation.Field(&a.Organizations, jellidation.Required),
+	)
+}
+
+func (a ServicePlanVisibilityApply) ToMessage(planGUID string) repositories.ApplyServicePlanVisibilityMessage {
+	return repositories.ApplyServicePlanVisibilityMessage{
+		PlanGUID:      planGUID,
+		Type:          a.Type,
+		Organizations: toOrgGUIDs(a.Organizations),
+	}
+}
+
+func toOrgGUIDs(orgs []VisibilityOrganization) []string {
+	guids := []string{}
+	for _, org := range orgs {
+		guids = append(guids, org.GUID)
+	}
+	return guids
+}
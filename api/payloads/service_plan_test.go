@@ -0,0 +1,177 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("ServicePlanList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedServicePlanList payloads.ServicePlanList) {
+			actualServicePlanList, decodeErr := decodeQuery[payloads.ServicePlanList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualServicePlanList).To(Equal(expectedServicePlanList))
+		},
+		Entry("names", "names=name", payloads.ServicePlanList{Names: "name"}),
+		Entry("service_offering_guids", "service_offering_guids=offering-guid", payloads.ServicePlanList{ServiceOfferingGUIDs: "offering-guid"}),
+		Entry("service_broker_guids", "service_broker_guids=broker-guid", payloads.ServicePlanList{ServiceBrokerGUIDs: "broker-guid"}),
+		Entry("organization_guids", "organization_guids=org-guid", payloads.ServicePlanList{OrganizationGUIDs: "org-guid"}),
+		Entry("space_guids", "space_guids=space-guid", payloads.ServicePlanList{SpaceGUIDs: "space-guid"}),
+		Entry("include", "include=service_offering", payloads.ServicePlanList{Include: "service_offering"}),
+		Entry("fields[xxx]", "fields[service_offering.service_broker]=name", payloads.ServicePlanList{}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.ServicePlanList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("invalid include", "include=space", "value must be one of"),
+	)
+
+	Describe("ToMessage", func() {
+		It("splits the comma separated filters", func() {
+			list := payloads.ServicePlanList{
+				Names:                "n1,n2",
+				ServiceOfferingGUIDs: "o1",
+				ServiceBrokerGUIDs:   "b1",
+				OrganizationGUIDs:    "org1,org2",
+				SpaceGUIDs:           "s1",
+			}
+			Expect(list.ToMessage()).To(Equal(repositories.ListServicePlanMessage{
+				Names:                []string{"n1", "n2"},
+				ServiceOfferingGUIDs: []string{"o1"},
+				ServiceBrokerGUIDs:   []string{"b1"},
+				OrganizationGUIDs:    []string{"org1", "org2"},
+				SpaceGUIDs:           []string{"s1"},
+			}))
+		})
+	})
+})
+
+var _ = Describe("ServicePlanVisibilityUpdate", func() {
+	var (
+		updatePayload  payloads.ServicePlanVisibilityUpdate
+		decodedPayload *payloads.ServicePlanVisibilityUpdate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.ServicePlanVisibilityUpdate)
+		updatePayload = payloads.ServicePlanVisibilityUpdate{
+			Type:          "organization",
+			Organizations: []payloads.VisibilityOrganization{{GUID: "org-guid"}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(updatePayload)))
+	})
+
+	It("converts to a message", func() {
+		Expect(decodedPayload.ToMessage("plan-guid")).To(Equal(repositories.UpdateServicePlanVisibilityMessage{
+			PlanGUID:      "plan-guid",
+			Type:          "organization",
+			Organizations: []string{"org-guid"},
+		}))
+	})
+
+	When("the type is invalid", func() {
+		BeforeEach(func() {
+			updatePayload.Type = "space"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "type value must be one of: admin, public, organization")
+		})
+	})
+
+	When("no orgs are given for the organization type", func() {
+		BeforeEach(func() {
+			updatePayload.Organizations = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "organizations cannot be blank")
+		})
+	})
+
+	When("an org guid is empty", func() {
+		BeforeEach(func() {
+			updatePayload.Organizations = []payloads.VisibilityOrganization{{}}
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "guid cannot be blank")
+		})
+	})
+
+	When("orgs are given for the public type", func() {
+		BeforeEach(func() {
+			updatePayload.Type = "public"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "organizations can only be set for the organization visibility type")
+		})
+	})
+})
+
+var _ = Describe("ServicePlanVisibilityApply", func() {
+	var (
+		applyPayload   payloads.ServicePlanVisibilityApply
+		decodedPayload *payloads.ServicePlanVisibilityApply
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.ServicePlanVisibilityApply)
+		applyPayload = payloads.ServicePlanVisibilityApply{
+			Type:          "organization",
+			Organizations: []payloads.VisibilityOrganization{{GUID: "org-guid"}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(applyPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload.ToMessage("plan-guid")).To(Equal(repositories.ApplyServicePlanVisibilityMessage{
+			PlanGUID:      "plan-guid",
+			Type:          "organization",
+			Organizations: []string{"org-guid"},
+		}))
+	})
+
+	When("the type is not organization", func() {
+		BeforeEach(func() {
+			applyPayload.Type = "public"
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "type value must be one of: organization")
+		})
+	})
+
+	When("no orgs are given", func() {
+		BeforeEach(func() {
+			applyPayload.Organizations = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "organizations cannot be blank")
+		})
+	})
+})
@@ -0,0 +1,85 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type ServiceRouteBindingCreate struct {
+	Relationships *ServiceRouteBindingRelationships `json:"relationships"`
+	Metadata      Metadata                          `json:"metadata"`
+}
+
+func (p ServiceRouteBindingCreate) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Relationships, jellidation.NotNil),
+		jellidation.Field(&p.Metadata),
+	)
+}
+
+func (p ServiceRouteBindingCreate) ToMessage(spaceGUID string) repositories.CreateServiceRouteBindingMessage {
+	return repositories.CreateServiceRouteBindingMessage{
+		ServiceInstanceGUID: p.Relationships.ServiceInstance.Data.GUID,
+		RouteGUID:           p.Relationships.Route.Data.GUID,
+		SpaceGUID:           spaceGUID,
+		Labels:              p.Metadata.Labels,
+		Annotations:         p.Metadata.Annotations,
+	}
+}
+
+type ServiceRouteBindingRelationships struct {
+	Route           *Relationship `json:"route"`
+	ServiceInstance *Relationship `json:"service_instance"`
+}
+
+func (r ServiceRouteBindingRelationships) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.Route, jellidation.NotNil),
+		jellidation.Field(&r.ServiceInstance, jellidation.NotNil),
+	)
+}
+
+type ServiceRouteBindingList struct {
+	RouteGUIDs           string
+	ServiceInstanceGUIDs string
+}
+
+func (l *ServiceRouteBindingList) ToMessage() repositories.ListServiceRouteBindingsMessage {
+	return repositories.ListServiceRouteBindingsMessage{
+		RouteGUIDs:           parse.ArrayParam(l.RouteGUIDs),
+		ServiceInstanceGUIDs: parse.ArrayParam(l.ServiceInstanceGUIDs),
+	}
+}
+
+func (l *ServiceRouteBindingList) SupportedKeys() []string {
+	return []string{"route_guids", "service_instance_guids", "per_page", "page"}
+}
+
+func (l *ServiceRouteBindingList) DecodeFromURLValues(values url.Values) error {
+	l.RouteGUIDs = values.Get("route_guids")
+	l.ServiceInstanceGUIDs = values.Get("service_instance_guids")
+	return nil
+}
+
+type ServiceRouteBindingUpdate struct {
+	Metadata MetadataPatch `json:"metadata"`
+}
+
+func (u ServiceRouteBindingUpdate) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.Metadata),
+	)
+}
+
+func (u *ServiceRouteBindingUpdate) ToMessage(serviceRouteBindingGUID string) repositories.UpdateServiceRouteBindingMessage {
+	return repositories.UpdateServiceRouteBindingMessage{
+		GUID: serviceRouteBindingGUID,
+		MetadataPatch: repositories.MetadataPatch{
+			Labels:      u.Metadata.Labels,
+			Annotations: u.Metadata.Annotations,
+		},
+	}
+}
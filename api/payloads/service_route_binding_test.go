@@ -0,0 +1,107 @@
+package payloads_test
+
+import (
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("ServiceRouteBindingList", func() {
+	Describe("decode from url values", func() {
+		It("succeeds", func() {
+			serviceRouteBindingList := payloads.ServiceRouteBindingList{}
+			req, err := http.NewRequest("GET", "http://foo.com/bar?route_guids=route_guid&service_instance_guids=service_instance_guid", nil)
+			Expect(err).NotTo(HaveOccurred())
+			err = validator.DecodeAndValidateURLValues(req, &serviceRouteBindingList)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(serviceRouteBindingList).To(Equal(payloads.ServiceRouteBindingList{
+				RouteGUIDs:           "route_guid",
+				ServiceInstanceGUIDs: "service_instance_guid",
+			}))
+		})
+	})
+})
+
+var _ = Describe("ServiceRouteBindingCreate", func() {
+	var (
+		createPayload             payloads.ServiceRouteBindingCreate
+		serviceRouteBindingCreate *payloads.ServiceRouteBindingCreate
+		validatorErr              error
+		apiError                  errors.ApiError
+	)
+
+	BeforeEach(func() {
+		serviceRouteBindingCreate = new(payloads.ServiceRouteBindingCreate)
+		createPayload = payloads.ServiceRouteBindingCreate{
+			Relationships: &payloads.ServiceRouteBindingRelationships{
+				Route: &payloads.Relationship{
+					Data: &payloads.RelationshipData{GUID: "route-guid"},
+				},
+				ServiceInstance: &payloads.Relationship{
+					Data: &payloads.RelationshipData{GUID: "service-instance-guid"},
+				},
+			},
+			Metadata: payloads.Metadata{
+				Labels: map[string]string{"foo": "bar"},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), serviceRouteBindingCreate)
+		apiError, _ = validatorErr.(errors.ApiError)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(serviceRouteBindingCreate).To(gstruct.PointTo(Equal(createPayload)))
+	})
+
+	It("converts to a repository message", func() {
+		Expect(serviceRouteBindingCreate.ToMessage("space-guid")).To(Equal(repositories.CreateServiceRouteBindingMessage{
+			ServiceInstanceGUID: "service-instance-guid",
+			RouteGUID:           "route-guid",
+			SpaceGUID:           "space-guid",
+			Labels:              map[string]string{"foo": "bar"},
+		}))
+	})
+
+	When("all relationships are missing", func() {
+		BeforeEach(func() {
+			createPayload.Relationships = nil
+		})
+
+		It("fails", func() {
+			Expect(apiError).To(HaveOccurred())
+			Expect(apiError.Detail()).To(ContainSubstring("relationships is required"))
+		})
+	})
+
+	When("the route relationship is missing", func() {
+		BeforeEach(func() {
+			createPayload.Relationships.Route = nil
+		})
+
+		It("fails", func() {
+			Expect(apiError).To(HaveOccurred())
+			Expect(apiError.Detail()).To(ContainSubstring("relationships.route is required"))
+		})
+	})
+
+	When("the service instance relationship is missing", func() {
+		BeforeEach(func() {
+			createPayload.Relationships.ServiceInstance = nil
+		})
+
+		It("fails", func() {
+			Expect(apiError).To(HaveOccurred())
+			Expect(apiError.Detail()).To(ContainSubstring("relationships.service_instance is required"))
+		})
+	})
+})
@@ -0,0 +1,60 @@
+package payloads
+
+import (
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type SidecarCreate struct {
+	Name         string   `json:"name"`
+	Command      string   `json:"command"`
+	ProcessTypes []string `json:"process_types"`
+	MemoryInMB   *int64   `json:"memory_in_mb"`
+}
+
+func (p SidecarCreate) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Name, jellidation.Required),
+		jellidation.Field(&p.Command, jellidation.Required),
+		jellidation.Field(&p.ProcessTypes, jellidation.Required, jellidation.Each(jellidation.Required)),
+		jellidation.Field(&p.MemoryInMB, jellidation.Min(int64(1)).Error("must be greater than 0")),
+	)
+}
+
+func (p SidecarCreate) ToMessage(appGUID, spaceGUID string) repositories.CreateSidecarMessage {
+	return repositories.CreateSidecarMessage{
+		AppGUID:      appGUID,
+		SpaceGUID:    spaceGUID,
+		Name:         p.Name,
+		Command:      p.Command,
+		ProcessTypes: p.ProcessTypes,
+		MemoryMB:     p.MemoryInMB,
+	}
+}
+
+type SidecarPatch struct {
+	Name         *string  `json:"name"`
+	Command      *string  `json:"command"`
+	ProcessTypes []string `json:"process_types"`
+	MemoryInMB   *int64   `json:"memory_in_mb"`
+}
+
+func (p SidecarPatch) Validate() error {
+	return jellidation.ValidateStruct(&p,
+		jellidation.Field(&p.Name, jellidation.NilOrNotEmpty),
+		jellidation.Field(&p.Command, jellidation.NilOrNotEmpty),
+		jellidation.Field(&p.ProcessTypes, jellidation.NilOrNotEmpty, jellidation.Each(jellidation.Required)),
+		jellidation.Field(&p.MemoryInMB, jellidation.Min(int64(1)).Error("must be greater than 0")),
+	)
+}
+
+func (p SidecarPatch) ToMessage(sidecarGUID, spaceGUID string) repositories.PatchSidecarMessage {
+	return repositories.PatchSidecarMessage{
+		GUID:         sidecarGUID,
+		SpaceGUID:    spaceGUID,
+		Name:         p.Name,
+		Command:      p.Command,
+		ProcessTypes: p.ProcessTypes,
+		MemoryMB:     p.MemoryInMB,
+	}
+}
@@ -0,0 +1,146 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("SidecarCreate", func() {
+	var (
+		createPayload payloads.SidecarCreate
+		sidecarCreate *payloads.SidecarCreate
+		validatorErr  error
+	)
+
+	BeforeEach(func() {
+		sidecarCreate = new(payloads.SidecarCreate)
+		createPayload = payloads.SidecarCreate{
+			Name:         "my-sidecar",
+			Command:      "bin/sidecar",
+			ProcessTypes: []string{"web"},
+			MemoryInMB:   tools.PtrTo[int64](64),
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), sidecarCreate)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(sidecarCreate).To(gstruct.PointTo(Equal(createPayload)))
+	})
+
+	When("name is blank", func() {
+		BeforeEach(func() {
+			createPayload.Name = ""
+		})
+
+		It("fails", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("command is blank", func() {
+		BeforeEach(func() {
+			createPayload.Command = ""
+		})
+
+		It("fails", func() {
+			expectUnprocessableEntityError(validatorErr, "command cannot be blank")
+		})
+	})
+
+	When("process_types is empty", func() {
+		BeforeEach(func() {
+			createPayload.ProcessTypes = []string{}
+		})
+
+		It("fails", func() {
+			expectUnprocessableEntityError(validatorErr, "process_types cannot be blank")
+		})
+	})
+
+	When("memory_in_mb is not positive", func() {
+		BeforeEach(func() {
+			createPayload.MemoryInMB = tools.PtrTo[int64](-1)
+		})
+
+		It("fails", func() {
+			expectUnprocessableEntityError(validatorErr, "memory_in_mb must be greater than 0")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			Expect(createPayload.ToMessage("app-guid", "space-guid")).To(Equal(repositories.CreateSidecarMessage{
+				AppGUID:      "app-guid",
+				SpaceGUID:    "space-guid",
+				Name:         "my-sidecar",
+				Command:      "bin/sidecar",
+				ProcessTypes: []string{"web"},
+				MemoryMB:     tools.PtrTo[int64](64),
+			}))
+		})
+	})
+})
+
+var _ = Describe("SidecarPatch", func() {
+	var (
+		patchPayload payloads.SidecarPatch
+		sidecarPatch *payloads.SidecarPatch
+		validatorErr error
+	)
+
+	BeforeEach(func() {
+		sidecarPatch = new(payloads.SidecarPatch)
+		patchPayload = payloads.SidecarPatch{
+			Command:      tools.PtrTo("bin/other-sidecar"),
+			ProcessTypes: []string{"worker"},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(patchPayload), sidecarPatch)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(sidecarPatch).To(gstruct.PointTo(Equal(patchPayload)))
+	})
+
+	When("name is blank", func() {
+		BeforeEach(func() {
+			patchPayload.Name = tools.PtrTo("")
+		})
+
+		It("fails", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("memory_in_mb is not positive", func() {
+		BeforeEach(func() {
+			patchPayload.MemoryInMB = tools.PtrTo[int64](-1)
+		})
+
+		It("fails", func() {
+			expectUnprocessableEntityError(validatorErr, "memory_in_mb must be greater than 0")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			Expect(patchPayload.ToMessage("sidecar-guid", "space-guid")).To(Equal(repositories.PatchSidecarMessage{
+				GUID:         "sidecar-guid",
+				SpaceGUID:    "space-guid",
+				Command:      tools.PtrTo("bin/other-sidecar"),
+				ProcessTypes: []string{"worker"},
+			}))
+		})
+	})
+})
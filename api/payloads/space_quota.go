@@ -0,0 +1,110 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	jellidation "github.com/jellydator/validation"
+)
+
+type SpaceQuotaRelationships struct {
+	Organization *Relationship      `json:"organization"`
+	Spaces       ToManyRelationship `json:"spaces"`
+}
+
+func (r SpaceQuotaRelationships) Validate() error {
+	return jellidation.ValidateStruct(&r,
+		jellidation.Field(&r.Organization, jellidation.NotNil),
+	)
+}
+
+type SpaceQuotaCreate struct {
+	Name string `json:"name"`
+	QuotaLimits
+	Relationships *SpaceQuotaRelationships `json:"relationships"`
+}
+
+func (c SpaceQuotaCreate) Validate() error {
+	return jellidation.ValidateStruct(&c,
+		jellidation.Field(&c.Name, jellidation.Required),
+		jellidation.Field(&c.QuotaLimits),
+		jellidation.Field(&c.Relationships, jellidation.NotNil),
+	)
+}
+
+func (c SpaceQuotaCreate) ToMessage() repositories.CreateSpaceQuotaMessage {
+	return repositories.CreateSpaceQuotaMessage{
+		Name:       c.Name,
+		Limits:     c.QuotaLimits.toRecord(),
+		OrgGUID:    c.Relationships.Organization.Data.GUID,
+		SpaceGUIDs: c.Relationships.Spaces.GUIDs(),
+	}
+}
+
+// SpaceQuotaUpdate replaces the limits of the apps, services and routes groups
+// that are set in the request, limits missing from a set group become unlimited
+type SpaceQuotaUpdate struct {
+	Name *string `json:"name"`
+	QuotaLimits
+}
+
+func (u SpaceQuotaUpdate) Validate() error {
+	return jellidation.ValidateStruct(&u,
+		jellidation.Field(&u.Name, jellidation.NilOrNotEmpty),
+		jellidation.Field(&u.QuotaLimits),
+	)
+}
+
+func (u SpaceQuotaUpdate) ToMessage(guid string) repositories.UpdateSpaceQuotaMessage {
+	return repositories.UpdateSpaceQuotaMessage{
+		GUID:   guid,
+		Name:   u.Name,
+		Limits: u.QuotaLimits.toUpdateRecord(),
+	}
+}
+
+type SpaceQuotaApply struct {
+	Data []RelationshipData `json:"data"`
+}
+
+func (a SpaceQuotaApply) Validate() error {
+	return jellidation.ValidateStruct(&a,
+		jellidation.Field(&a.Data, jellidation.Required),
+	)
+}
+
+func (a SpaceQuotaApply) ToMessage(guid string) repositories.ApplySpaceQuotaMessage {
+	return repositories.ApplySpaceQuotaMessage{
+		GUID:       guid,
+		SpaceGUIDs: ToManyRelationship(a).GUIDs(),
+	}
+}
+
+type SpaceQuotaList struct {
+	GUIDs             string
+	Names             string
+	OrganizationGUIDs string
+	SpaceGUIDs        string
+}
+
+func (l *SpaceQuotaList) ToMessage() repositories.ListSpaceQuotasMessage {
+	return repositories.ListSpaceQuotasMessage{
+		GUIDs:      parse.ArrayParam(l.GUIDs),
+		Names:      parse.ArrayParam(l.Names),
+		OrgGUIDs:   parse.ArrayParam(l.OrganizationGUIDs),
+		SpaceGUIDs: parse.ArrayParam(l.SpaceGUIDs),
+	}
+}
+
+func (l *SpaceQuotaList) SupportedKeys() []string {
+	return []string{"guids", "names", "organization_guids", "space_guids", "per_page", "page"}
+}
+
+func (l *SpaceQuotaList) DecodeFromURLValues(values url.Values) error {
+	l.GUIDs = values.Get("guids")
+	l.Names = values.Get("names")
+	l.OrganizationGUIDs = values.Get("organization_guids")
+	l.SpaceGUIDs = values.Get("space_guids")
+	return nil
+}
@@ -0,0 +1,142 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("SpaceQuotaCreate", func() {
+	var (
+		createPayload  payloads.SpaceQuotaCreate
+		decodedPayload *payloads.SpaceQuotaCreate
+		validatorErr   error
+	)
+
+	BeforeEach(func() {
+		decodedPayload = new(payloads.SpaceQuotaCreate)
+		createPayload = payloads.SpaceQuotaCreate{
+			Name: "my-quota",
+			QuotaLimits: payloads.QuotaLimits{
+				Apps: &payloads.QuotaApps{TotalMemoryInMB: tools.PtrTo[int64](1024)},
+			},
+			Relationships: &payloads.SpaceQuotaRelationships{
+				Organization: &payloads.Relationship{Data: &payloads.RelationshipData{GUID: "org-guid"}},
+				Spaces:       payloads.ToManyRelationship{Data: []payloads.RelationshipData{{GUID: "space-1"}}},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		validatorErr = validator.DecodeAndValidateJSONPayload(createJSONRequest(createPayload), decodedPayload)
+	})
+
+	It("succeeds", func() {
+		Expect(validatorErr).NotTo(HaveOccurred())
+		Expect(decodedPayload).To(gstruct.PointTo(Equal(createPayload)))
+	})
+
+	When("name is not set", func() {
+		BeforeEach(func() {
+			createPayload.Name = ""
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "name cannot be blank")
+		})
+	})
+
+	When("the relationships are not set", func() {
+		BeforeEach(func() {
+			createPayload.Relationships = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "relationships is required")
+		})
+	})
+
+	When("the organization is not set", func() {
+		BeforeEach(func() {
+			createPayload.Relationships.Organization = nil
+		})
+
+		It("returns an appropriate error", func() {
+			expectUnprocessableEntityError(validatorErr, "organization is required")
+		})
+	})
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			Expect(createPayload.ToMessage()).To(Equal(repositories.CreateSpaceQuotaMessage{
+				Name: "my-quota",
+				Limits: repositories.QuotaLimits{
+					Apps: repositories.QuotaAppLimits{TotalMemoryInMB: tools.PtrTo[int64](1024)},
+				},
+				OrgGUID:    "org-guid",
+				SpaceGUIDs: []string{"space-1"},
+			}))
+		})
+	})
+})
+
+var _ = Describe("SpaceQuotaUpdate", func() {
+	It("converts to a repo message", func() {
+		updatePayload := payloads.SpaceQuotaUpdate{
+			Name: tools.PtrTo("new-name"),
+			QuotaLimits: payloads.QuotaLimits{
+				Services: &payloads.QuotaServices{},
+			},
+		}
+
+		decodedPayload := new(payloads.SpaceQuotaUpdate)
+		Expect(validator.DecodeAndValidateJSONPayload(createJSONRequest(updatePayload), decodedPayload)).To(Succeed())
+		Expect(decodedPayload.ToMessage("quota-guid")).To(Equal(repositories.UpdateSpaceQuotaMessage{
+			GUID: "quota-guid",
+			Name: tools.PtrTo("new-name"),
+			Limits: repositories.UpdateQuotaLimits{
+				Services: &repositories.QuotaServiceLimits{},
+			},
+		}))
+	})
+})
+
+var _ = Describe("SpaceQuotaApply", func() {
+	It("converts to a repo message", func() {
+		decodedPayload := new(payloads.SpaceQuotaApply)
+		applyPayload := payloads.SpaceQuotaApply{Data: []payloads.RelationshipData{{GUID: "space-1"}}}
+
+		Expect(validator.DecodeAndValidateJSONPayload(createJSONRequest(applyPayload), decodedPayload)).To(Succeed())
+		Expect(decodedPayload.ToMessage("quota-guid")).To(Equal(repositories.ApplySpaceQuotaMessage{
+			GUID:       "quota-guid",
+			SpaceGUIDs: []string{"space-1"},
+		}))
+	})
+})
+
+var _ = Describe("SpaceQuotaList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedSpaceQuotaList payloads.SpaceQuotaList) {
+			actualSpaceQuotaList, decodeErr := decodeQuery[payloads.SpaceQuotaList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualSpaceQuotaList).To(Equal(expectedSpaceQuotaList))
+		},
+		Entry("guids", "guids=g1", payloads.SpaceQuotaList{GUIDs: "g1"}),
+		Entry("names", "names=name", payloads.SpaceQuotaList{Names: "name"}),
+		Entry("organization_guids", "organization_guids=org-guid", payloads.SpaceQuotaList{OrganizationGUIDs: "org-guid"}),
+		Entry("space_guids", "space_guids=space-guid", payloads.SpaceQuotaList{SpaceGUIDs: "space-guid"}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.SpaceQuotaList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("unknown key", "foo=bar", "unsupported query parameter"),
+	)
+})
@@ -0,0 +1,27 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type StackList struct {
+	Names string
+}
+
+func (l *StackList) ToMessage() repositories.ListStacksMessage {
+	return repositories.ListStacksMessage{
+		Names: parse.ArrayParam(l.Names),
+	}
+}
+
+func (l *StackList) SupportedKeys() []string {
+	return []string{"names", "per_page", "page"}
+}
+
+func (l *StackList) DecodeFromURLValues(values url.Values) error {
+	l.Names = values.Get("names")
+	return nil
+}
@@ -0,0 +1,38 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StackList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedStackList payloads.StackList) {
+			actualStackList, decodeErr := decodeQuery[payloads.StackList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualStackList).To(Equal(expectedStackList))
+		},
+		Entry("names", "names=n1,n2", payloads.StackList{Names: "n1,n2"}),
+		Entry("per_page", "per_page=10", payloads.StackList{}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.StackList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("unknown key", "foo=bar", "unsupported query parameter"),
+	)
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			list := payloads.StackList{Names: "n1,n2"}
+			Expect(list.ToMessage()).To(Equal(repositories.ListStacksMessage{
+				Names: []string{"n1", "n2"},
+			}))
+		})
+	})
+})
@@ -0,0 +1,58 @@
+package payloads
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/payloads/parse"
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type AppUsageEventList struct {
+	AfterGUID string
+	GUIDs     string
+}
+
+func (l *AppUsageEventList) ToMessage() repositories.ListAppUsageEventsMessage {
+	return repositories.ListAppUsageEventsMessage{
+		AfterGUID: l.AfterGUID,
+		GUIDs:     parse.ArrayParam(l.GUIDs),
+	}
+}
+
+func (l *AppUsageEventList) SupportedKeys() []string {
+	return []string{"after_guid", "guids", "order_by", "per_page", "page"}
+}
+
+func (l *AppUsageEventList) DecodeFromURLValues(values url.Values) error {
+	l.AfterGUID = values.Get("after_guid")
+	l.GUIDs = values.Get("guids")
+	return nil
+}
+
+type ServiceUsageEventList struct {
+	AfterGUID            string
+	GUIDs                string
+	ServiceInstanceTypes string
+	ServiceOfferingGUIDs string
+}
+
+func (l *ServiceUsageEventList) ToMessage() repositories.ListServiceUsageEventsMessage {
+	return repositories.ListServiceUsageEventsMessage{
+		AfterGUID:            l.AfterGUID,
+		GUIDs:                parse.ArrayParam(l.GUIDs),
+		ServiceInstanceTypes: parse.ArrayParam(l.ServiceInstanceTypes),
+		ServiceOfferingGUIDs: parse.ArrayParam(l.ServiceOfferingGUIDs),
+	}
+}
+
+func (l *ServiceUsageEventList) SupportedKeys() []string {
+	return []string{"after_guid", "guids", "service_instance_types", "service_offering_guids", "order_by", "per_page", "page"}
+}
+
+func (l *ServiceUsageEventList) DecodeFromURLValues(values url.Values) error {
+	l.AfterGUID = values.Get("after_guid")
+	l.GUIDs = values.Get("guids")
+	l.ServiceInstanceTypes = values.Get("service_instance_types")
+	l.ServiceOfferingGUIDs = values.Get("service_offering_guids")
+	return nil
+}
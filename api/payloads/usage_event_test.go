@@ -0,0 +1,82 @@
+package payloads_test
+
+import (
+	"code.cloudfoundry.org/korifi/api/payloads"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AppUsageEventList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedAppUsageEventList payloads.AppUsageEventList) {
+			actualAppUsageEventList, decodeErr := decodeQuery[payloads.AppUsageEventList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualAppUsageEventList).To(Equal(expectedAppUsageEventList))
+		},
+		Entry("after_guid", "after_guid=e1", payloads.AppUsageEventList{AfterGUID: "e1"}),
+		Entry("guids", "guids=e1,e2", payloads.AppUsageEventList{GUIDs: "e1,e2"}),
+		Entry("order_by", "order_by=created_at", payloads.AppUsageEventList{}),
+		Entry("per_page", "per_page=10", payloads.AppUsageEventList{}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.AppUsageEventList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("unknown key", "foo=bar", "unsupported query parameter"),
+	)
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			list := payloads.AppUsageEventList{AfterGUID: "e1", GUIDs: "e2,e3"}
+			Expect(list.ToMessage()).To(Equal(repositories.ListAppUsageEventsMessage{
+				AfterGUID: "e1",
+				GUIDs:     []string{"e2", "e3"},
+			}))
+		})
+	})
+})
+
+var _ = Describe("ServiceUsageEventList", func() {
+	DescribeTable("valid query",
+		func(query string, expectedServiceUsageEventList payloads.ServiceUsageEventList) {
+			actualServiceUsageEventList, decodeErr := decodeQuery[payloads.ServiceUsageEventList](query)
+
+			Expect(decodeErr).NotTo(HaveOccurred())
+			Expect(*actualServiceUsageEventList).To(Equal(expectedServiceUsageEventList))
+		},
+		Entry("after_guid", "after_guid=e1", payloads.ServiceUsageEventList{AfterGUID: "e1"}),
+		Entry("guids", "guids=e1,e2", payloads.ServiceUsageEventList{GUIDs: "e1,e2"}),
+		Entry("service_instance_types", "service_instance_types=managed_service_instance", payloads.ServiceUsageEventList{ServiceInstanceTypes: "managed_service_instance"}),
+		Entry("service_offering_guids", "service_offering_guids=o1,o2", payloads.ServiceUsageEventList{ServiceOfferingGUIDs: "o1,o2"}),
+		Entry("per_page", "per_page=10", payloads.ServiceUsageEventList{}),
+	)
+
+	DescribeTable("invalid query",
+		func(query string, expectedErrMsg string) {
+			_, decodeErr := decodeQuery[payloads.ServiceUsageEventList](query)
+			Expect(decodeErr).To(MatchError(ContainSubstring(expectedErrMsg)))
+		},
+		Entry("unknown key", "foo=bar", "unsupported query parameter"),
+	)
+
+	Describe("ToMessage", func() {
+		It("converts to a repo message", func() {
+			list := payloads.ServiceUsageEventList{
+				AfterGUID:            "e1",
+				GUIDs:                "e2",
+				ServiceInstanceTypes: "managed_service_instance,user_provided_service_instance",
+				ServiceOfferingGUIDs: "o1",
+			}
+			Expect(list.ToMessage()).To(Equal(repositories.ListServiceUsageEventsMessage{
+				AfterGUID:            "e1",
+				GUIDs:                []string{"e2"},
+				ServiceInstanceTypes: []string{"managed_service_instance", "user_provided_service_instance"},
+				ServiceOfferingGUIDs: []string{"o1"},
+			}))
+		})
+	})
+})
@@ -3,6 +3,7 @@ package presenter
 import (
 	"net/url"
 
+	"code.cloudfoundry.org/korifi/api/actions"
 	"code.cloudfoundry.org/korifi/api/repositories"
 )
 
@@ -170,16 +171,24 @@ type AppEnvResponse struct {
 	ApplicationEnvJSON   map[string]any    `json:"application_env_json"`
 }
 
-func ForAppEnv(envVarRecord repositories.AppEnvRecord) AppEnvResponse {
+func ForAppEnv(envVarRecord repositories.AppEnvRecord, runningEnvVarGroup, stagingEnvVarGroup repositories.EnvVarGroupRecord) AppEnvResponse {
 	return AppEnvResponse{
 		EnvironmentVariables: envVarRecord.EnvironmentVariables,
-		StagingEnvJSON:       map[string]string{},
-		RunningEnvJSON:       map[string]string{},
+		StagingEnvJSON:       emptyStringMapIfNil(stagingEnvVarGroup.EnvironmentVariables),
+		RunningEnvJSON:       emptyStringMapIfNil(runningEnvVarGroup.EnvironmentVariables),
 		SystemEnvJSON:        emptyMapToAnyIfEmpty(envVarRecord.SystemEnv),
 		ApplicationEnvJSON:   emptyMapToAnyIfEmpty(envVarRecord.AppEnv),
 	}
 }
 
+func emptyStringMapIfNil(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+
+	return m
+}
+
 func emptyMapToAnyIfEmpty(m map[string]any) map[string]any {
 	if m == nil {
 		return map[string]any{}
@@ -192,3 +201,10 @@ type AppSSHEnabled struct {
 	Enabled bool   `json:"enabled"`
 	Reason  string `json:"reason"`
 }
+
+func ForAppSSHEnabled(record actions.SSHEnabledRecord) AppSSHEnabled {
+	return AppSSHEnabled{
+		Enabled: record.Enabled,
+		Reason:  record.Reason,
+	}
+}
@@ -197,9 +197,16 @@ var _ = Describe("App", func() {
 	})
 
 	Describe("App Env", func() {
-		var record repositories.AppEnvRecord
+		var (
+			record             repositories.AppEnvRecord
+			runningEnvVarGroup repositories.EnvVarGroupRecord
+			stagingEnvVarGroup repositories.EnvVarGroupRecord
+		)
 
 		BeforeEach(func() {
+			runningEnvVarGroup = repositories.EnvVarGroupRecord{}
+			stagingEnvVarGroup = repositories.EnvVarGroupRecord{}
+
 			record = repositories.AppEnvRecord{
 				EnvironmentVariables: map[string]string{"VAR": "VAL"},
 				SystemEnv: map[string]any{
@@ -218,7 +225,7 @@ var _ = Describe("App", func() {
 		})
 
 		JustBeforeEach(func() {
-			response := presenter.ForAppEnv(record)
+			response := presenter.ForAppEnv(record, runningEnvVarGroup, stagingEnvVarGroup)
 			var err error
 			output, err = json.Marshal(response)
 			Expect(err).NotTo(HaveOccurred())
@@ -246,6 +253,18 @@ var _ = Describe("App", func() {
 			}`))
 		})
 
+		When("the environment variable groups are set", func() {
+			BeforeEach(func() {
+				runningEnvVarGroup.EnvironmentVariables = map[string]string{"RUNNING": "yes"}
+				stagingEnvVarGroup.EnvironmentVariables = map[string]string{"STAGING": "yes"}
+			})
+
+			It("includes the groups", func() {
+				Expect(output).To(MatchJSONPath("$.running_env_json", map[string]interface{}{"RUNNING": "yes"}))
+				Expect(output).To(MatchJSONPath("$.staging_env_json", map[string]interface{}{"STAGING": "yes"}))
+			})
+		})
+
 		When("system env is nil", func() {
 			BeforeEach(func() {
 				record.SystemEnv = nil
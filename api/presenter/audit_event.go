@@ -0,0 +1,71 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const auditEventsBase = "/v3/audit_events"
+
+type AuditEventResponse struct {
+	GUID         string                        `json:"guid"`
+	CreatedAt    string                        `json:"created_at"`
+	UpdatedAt    string                        `json:"updated_at"`
+	Type         string                        `json:"type"`
+	Actor        AuditEventParticipantResponse `json:"actor"`
+	Target       AuditEventParticipantResponse `json:"target"`
+	Data         map[string]string             `json:"data"`
+	Space        *RelationshipData             `json:"space"`
+	Organization *RelationshipData             `json:"organization"`
+	Links        AuditEventLinks               `json:"links"`
+}
+
+type AuditEventParticipantResponse struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+type AuditEventLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForAuditEvent(auditEventRecord repositories.AuditEventRecord, baseURL url.URL) AuditEventResponse {
+	data := auditEventRecord.Data
+	if data == nil {
+		data = map[string]string{}
+	}
+
+	return AuditEventResponse{
+		GUID:      auditEventRecord.GUID,
+		CreatedAt: formatTimestamp(&auditEventRecord.CreatedAt),
+		UpdatedAt: formatTimestamp(auditEventRecord.UpdatedAt),
+		Type:      auditEventRecord.Type,
+		Actor: AuditEventParticipantResponse{
+			GUID: auditEventRecord.Actor.GUID,
+			Type: auditEventRecord.Actor.Type,
+			Name: auditEventRecord.Actor.Name,
+		},
+		Target: AuditEventParticipantResponse{
+			GUID: auditEventRecord.Target.GUID,
+			Type: auditEventRecord.Target.Type,
+			Name: auditEventRecord.Target.Name,
+		},
+		Data:         data,
+		Space:        auditEventRelationship(auditEventRecord.SpaceGUID),
+		Organization: auditEventRelationship(auditEventRecord.OrganizationGUID),
+		Links: AuditEventLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(auditEventsBase, auditEventRecord.GUID).build(),
+			},
+		},
+	}
+}
+
+func auditEventRelationship(guid string) *RelationshipData {
+	if guid == "" {
+		return nil
+	}
+	return &RelationshipData{GUID: guid}
+}
@@ -0,0 +1,102 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit Events", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.AuditEventRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+
+		record = repositories.AuditEventRecord{
+			GUID: "audit-event-guid",
+			Type: "audit.app.process.crash",
+			Actor: repositories.AuditEventParticipantRecord{
+				GUID: "process-guid",
+				Type: "process",
+				Name: "web",
+			},
+			Target: repositories.AuditEventParticipantRecord{
+				GUID: "app-guid",
+				Type: "app",
+				Name: "my-app",
+			},
+			SpaceGUID:        "space-guid",
+			OrganizationGUID: "org-guid",
+			Data:             map[string]string{"exit_status": "137"},
+			CreatedAt:        time.UnixMilli(1000),
+			UpdatedAt:        tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForAuditEvent(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces expected audit event json", func() {
+		Expect(output).To(MatchJSON(`{
+			"guid": "audit-event-guid",
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z",
+			"type": "audit.app.process.crash",
+			"actor": {
+				"guid": "process-guid",
+				"type": "process",
+				"name": "web"
+			},
+			"target": {
+				"guid": "app-guid",
+				"type": "app",
+				"name": "my-app"
+			},
+			"data": {
+				"exit_status": "137"
+			},
+			"space": {
+				"guid": "space-guid"
+			},
+			"organization": {
+				"guid": "org-guid"
+			},
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/audit_events/audit-event-guid"
+				}
+			}
+		}`))
+	})
+
+	When("the event has no space, organization or data", func() {
+		BeforeEach(func() {
+			record.SpaceGUID = ""
+			record.OrganizationGUID = ""
+			record.Data = nil
+		})
+
+		It("presents them as null and empty", func() {
+			Expect(output).To(MatchJSONPath("$.space", BeNil()))
+			Expect(output).To(MatchJSONPath("$.organization", BeNil()))
+			Expect(output).To(MatchJSONPath("$.data", BeEmpty()))
+		})
+	})
+})
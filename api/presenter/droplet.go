@@ -84,5 +84,23 @@ func ForDroplet(dropletRecord repositories.DropletRecord, baseURL url.URL) Dropl
 	if dropletRecord.DropletErrorMsg != "" {
 		toReturn.Error = &dropletRecord.DropletErrorMsg
 	}
+	if dropletRecord.PackageGUID == "" {
+		toReturn.Links["package"] = nil
+	}
+
+	switch {
+	case dropletRecord.Lifecycle.Type == "docker":
+		toReturn.Image = &dropletRecord.Image
+	case dropletRecord.State == repositories.DropletStateStaged:
+		toReturn.Links["download"] = &Link{
+			HRef: buildURL(baseURL).appendPath(dropletsBase, dropletRecord.GUID, "download").build(),
+		}
+	case dropletRecord.State == repositories.DropletStateAwaitingUpload:
+		toReturn.Links["upload"] = &Link{
+			HRef:   buildURL(baseURL).appendPath(dropletsBase, dropletRecord.GUID, "upload").build(),
+			Method: "POST",
+		}
+	}
+
 	return toReturn
 }
@@ -96,7 +96,9 @@ var _ = Describe("Droplet", func() {
 					"href": "https://api.example.org/v3/apps/the-app-guid/relationships/current_droplet",
 					"method": "PATCH"
 				},
-				"download": null
+				"download": {
+					"href": "https://api.example.org/v3/droplets/the-droplet-guid/download"
+				}
 			},
 			"metadata": {
 				"labels": {
@@ -128,4 +130,34 @@ var _ = Describe("Droplet", func() {
 			Expect(output).To(MatchJSONPath("$.metadata.annotations", Not(BeNil())))
 		})
 	})
+
+	When("the droplet is awaiting upload", func() {
+		BeforeEach(func() {
+			record.State = "AWAITING_UPLOAD"
+			record.PackageGUID = ""
+		})
+
+		It("links to the upload endpoint", func() {
+			Expect(output).To(SatisfyAll(
+				MatchJSONPath("$.links.upload.href", "https://api.example.org/v3/droplets/the-droplet-guid/upload"),
+				MatchJSONPath("$.links.upload.method", "POST"),
+				MatchJSONPath("$.links.download", BeNil()),
+				MatchJSONPath("$.links.package", BeNil()),
+			))
+		})
+	})
+
+	When("the droplet has the docker lifecycle", func() {
+		BeforeEach(func() {
+			record.Lifecycle.Type = "docker"
+			record.Image = "nginx:latest"
+		})
+
+		It("shows the image and cannot be downloaded", func() {
+			Expect(output).To(SatisfyAll(
+				MatchJSONPath("$.image", "nginx:latest"),
+				MatchJSONPath("$.links.download", BeNil()),
+			))
+		})
+	})
 })
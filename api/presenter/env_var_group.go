@@ -0,0 +1,39 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const envVarGroupsBase = "/v3/environment_variable_groups"
+
+type EnvVarGroupResponse struct {
+	Name      string            `json:"name"`
+	Var       map[string]string `json:"var"`
+	UpdatedAt *string           `json:"updated_at"`
+	Links     EnvVarGroupLinks  `json:"links"`
+}
+
+type EnvVarGroupLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForEnvVarGroup(record repositories.EnvVarGroupRecord, baseURL url.URL) EnvVarGroupResponse {
+	response := EnvVarGroupResponse{
+		Name: record.Name,
+		Var:  emptyStringMapIfNil(record.EnvironmentVariables),
+		Links: EnvVarGroupLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(envVarGroupsBase, record.Name).build(),
+			},
+		},
+	}
+
+	if record.UpdatedAt != nil {
+		updatedAt := formatTimestamp(record.UpdatedAt)
+		response.UpdatedAt = &updatedAt
+	}
+
+	return response
+}
@@ -0,0 +1,50 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+var appFeatureDescriptions = map[string]string{
+	repositories.AppFeatureSSH:       "Enable SSHing into the app.",
+	repositories.AppFeatureRevisions: "Enable versioning of an application",
+}
+
+type FeatureResponse struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Enabled     bool   `json:"enabled"`
+}
+
+func ForAppFeature(app repositories.AppRecord, name string) FeatureResponse {
+	enabled := app.SSHEnabled
+	if name == repositories.AppFeatureRevisions {
+		enabled = app.RevisionsEnabled
+	}
+
+	return FeatureResponse{
+		Name:        name,
+		Description: appFeatureDescriptions[name],
+		Enabled:     enabled,
+	}
+}
+
+func ForAppFeatures(app repositories.AppRecord, baseURL, requestURL url.URL) ListResponse[FeatureResponse] {
+	features := []FeatureResponse{
+		ForAppFeature(app, repositories.AppFeatureSSH),
+		ForAppFeature(app, repositories.AppFeatureRevisions),
+	}
+
+	return ForList(func(feature FeatureResponse, _ url.URL) FeatureResponse {
+		return feature
+	}, features, baseURL, requestURL)
+}
+
+func ForSpaceSSHFeature(space repositories.SpaceRecord) FeatureResponse {
+	return FeatureResponse{
+		Name:        repositories.SpaceFeatureSSH,
+		Description: "Enable SSHing into apps in the space.",
+		Enabled:     space.SSHEnabled,
+	}
+}
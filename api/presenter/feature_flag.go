@@ -0,0 +1,43 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const featureFlagsBase = "/v3/feature_flags"
+
+type FeatureFlagResponse struct {
+	Name               string           `json:"name"`
+	Enabled            bool             `json:"enabled"`
+	UpdatedAt          *string          `json:"updated_at"`
+	CustomErrorMessage *string          `json:"custom_error_message"`
+	Links              FeatureFlagLinks `json:"links"`
+}
+
+type FeatureFlagLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForFeatureFlag(record repositories.FeatureFlagRecord, baseURL url.URL) FeatureFlagResponse {
+	response := FeatureFlagResponse{
+		Name:    record.Name,
+		Enabled: record.Enabled,
+		Links: FeatureFlagLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(featureFlagsBase, record.Name).build(),
+			},
+		},
+	}
+
+	if record.UpdatedAt != nil {
+		updatedAt := formatTimestamp(record.UpdatedAt)
+		response.UpdatedAt = &updatedAt
+	}
+	if record.CustomErrorMessage != "" {
+		response.CustomErrorMessage = &record.CustomErrorMessage
+	}
+
+	return response
+}
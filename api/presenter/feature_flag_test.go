@@ -0,0 +1,78 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Feature Flags", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.FeatureFlagRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.FeatureFlagRecord{
+			Name:               "task_creation",
+			Enabled:            false,
+			CustomErrorMessage: "no tasks today",
+			UpdatedAt:          tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForFeatureFlag(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces the expected json", func() {
+		Expect(output).To(MatchJSON(`{
+			"name": "task_creation",
+			"enabled": false,
+			"updated_at": "1970-01-01T00:00:02Z",
+			"custom_error_message": "no tasks today",
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/feature_flags/task_creation"
+				}
+			}
+		}`))
+	})
+
+	When("the flag has its default value", func() {
+		BeforeEach(func() {
+			record = repositories.FeatureFlagRecord{
+				Name:    "user_org_creation",
+				Enabled: false,
+			}
+		})
+
+		It("presents null timestamps and messages", func() {
+			Expect(output).To(MatchJSON(`{
+				"name": "user_org_creation",
+				"enabled": false,
+				"updated_at": null,
+				"custom_error_message": null,
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/feature_flags/user_org_creation"
+					}
+				}
+			}`))
+		})
+	})
+})
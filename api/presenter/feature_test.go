@@ -0,0 +1,80 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Features", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("ForAppFeatures", func() {
+		JustBeforeEach(func() {
+			requestURL, err := url.Parse("/v3/apps/app-guid/features")
+			Expect(err).NotTo(HaveOccurred())
+
+			app := repositories.AppRecord{GUID: "app-guid", SSHEnabled: false, RevisionsEnabled: true}
+			output, err = json.Marshal(presenter.ForAppFeatures(app, *baseURL, *requestURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected app features json", func() {
+			Expect(output).To(MatchJSON(`{
+				"pagination": {
+					"total_results": 2,
+					"total_pages": 1,
+					"first": {
+						"href": "https://api.example.org/v3/apps/app-guid/features"
+					},
+					"last": {
+						"href": "https://api.example.org/v3/apps/app-guid/features"
+					},
+					"next": null,
+					"previous": null
+				},
+				"resources": [
+					{
+						"name": "ssh",
+						"description": "Enable SSHing into the app.",
+						"enabled": false
+					},
+					{
+						"name": "revisions",
+						"description": "Enable versioning of an application",
+						"enabled": true
+					}
+				]
+			}`))
+		})
+	})
+
+	Describe("ForSpaceSSHFeature", func() {
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForSpaceSSHFeature(repositories.SpaceRecord{GUID: "space-guid", SSHEnabled: true}))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected space feature json", func() {
+			Expect(output).To(MatchJSON(`{
+				"name": "ssh",
+				"description": "Enable SSHing into apps in the space.",
+				"enabled": true
+			}`))
+		})
+	})
+})
@@ -0,0 +1,98 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const isolationSegmentsBase = "/v3/isolation_segments"
+
+type IsolationSegmentResponse struct {
+	GUID      string                `json:"guid"`
+	Name      string                `json:"name"`
+	CreatedAt string                `json:"created_at"`
+	UpdatedAt string                `json:"updated_at"`
+	Links     IsolationSegmentLinks `json:"links"`
+}
+
+type IsolationSegmentLinks struct {
+	Self          Link `json:"self"`
+	Organizations Link `json:"organizations"`
+}
+
+type IsolationSegmentRelationshipLinks struct {
+	Self    Link  `json:"self"`
+	Related *Link `json:"related,omitempty"`
+}
+
+type IsolationSegmentRelationshipsResponse struct {
+	Data  []RelationshipData                `json:"data"`
+	Links IsolationSegmentRelationshipLinks `json:"links"`
+}
+
+type SpaceIsolationSegmentResponse struct {
+	Data  *RelationshipData                 `json:"data"`
+	Links IsolationSegmentRelationshipLinks `json:"links"`
+}
+
+func ForIsolationSegment(record repositories.IsolationSegmentRecord, baseURL url.URL) IsolationSegmentResponse {
+	return IsolationSegmentResponse{
+		GUID:      record.GUID,
+		Name:      record.Name,
+		CreatedAt: formatTimestamp(&record.CreatedAt),
+		UpdatedAt: formatTimestamp(record.UpdatedAt),
+		Links: IsolationSegmentLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, record.GUID).build(),
+			},
+			Organizations: Link{
+				HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, record.GUID, "organizations").build(),
+			},
+		},
+	}
+}
+
+func ForIsolationSegmentOrganizations(orgGUIDs []string, isolationSegmentGUID string, baseURL url.URL) IsolationSegmentRelationshipsResponse {
+	return IsolationSegmentRelationshipsResponse{
+		Data: toRelationshipData(orgGUIDs),
+		Links: IsolationSegmentRelationshipLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, isolationSegmentGUID, "relationships", "organizations").build(),
+			},
+			Related: &Link{
+				HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, isolationSegmentGUID, "organizations").build(),
+			},
+		},
+	}
+}
+
+func ForIsolationSegmentSpaces(spaceGUIDs []string, isolationSegmentGUID string, baseURL url.URL) IsolationSegmentRelationshipsResponse {
+	return IsolationSegmentRelationshipsResponse{
+		Data: toRelationshipData(spaceGUIDs),
+		Links: IsolationSegmentRelationshipLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, isolationSegmentGUID, "relationships", "spaces").build(),
+			},
+		},
+	}
+}
+
+func ForSpaceIsolationSegment(isolationSegmentGUID string, spaceGUID string, baseURL url.URL) SpaceIsolationSegmentResponse {
+	response := SpaceIsolationSegmentResponse{
+		Links: IsolationSegmentRelationshipLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(spacesBase, spaceGUID, "relationships", "isolation_segment").build(),
+			},
+		},
+	}
+
+	if isolationSegmentGUID != "" {
+		response.Data = &RelationshipData{GUID: isolationSegmentGUID}
+		response.Links.Related = &Link{
+			HRef: buildURL(baseURL).appendPath(isolationSegmentsBase, isolationSegmentGUID).build(),
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,149 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Isolation Segments", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.IsolationSegmentRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.IsolationSegmentRecord{
+			GUID:      "segment-guid",
+			Name:      "regulated",
+			CreatedAt: time.UnixMilli(1000),
+			UpdatedAt: tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	Describe("ForIsolationSegment", func() {
+		JustBeforeEach(func() {
+			response := presenter.ForIsolationSegment(record, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "segment-guid",
+				"name": "regulated",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/isolation_segments/segment-guid"
+					},
+					"organizations": {
+						"href": "https://api.example.org/v3/isolation_segments/segment-guid/organizations"
+					}
+				}
+			}`))
+		})
+	})
+
+	Describe("ForIsolationSegmentOrganizations", func() {
+		JustBeforeEach(func() {
+			response := presenter.ForIsolationSegmentOrganizations([]string{"org-1", "org-2"}, record.GUID, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"data": [{"guid": "org-1"}, {"guid": "org-2"}],
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/isolation_segments/segment-guid/relationships/organizations"
+					},
+					"related": {
+						"href": "https://api.example.org/v3/isolation_segments/segment-guid/organizations"
+					}
+				}
+			}`))
+		})
+	})
+
+	Describe("ForIsolationSegmentSpaces", func() {
+		JustBeforeEach(func() {
+			response := presenter.ForIsolationSegmentSpaces([]string{"space-1"}, record.GUID, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"data": [{"guid": "space-1"}],
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/isolation_segments/segment-guid/relationships/spaces"
+					}
+				}
+			}`))
+		})
+	})
+
+	Describe("ForSpaceIsolationSegment", func() {
+		var isolationSegmentGUID string
+
+		BeforeEach(func() {
+			isolationSegmentGUID = "segment-guid"
+		})
+
+		JustBeforeEach(func() {
+			response := presenter.ForSpaceIsolationSegment(isolationSegmentGUID, "space-guid", *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"data": {"guid": "segment-guid"},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/spaces/space-guid/relationships/isolation_segment"
+					},
+					"related": {
+						"href": "https://api.example.org/v3/isolation_segments/segment-guid"
+					}
+				}
+			}`))
+		})
+
+		When("the space is not assigned to an isolation segment", func() {
+			BeforeEach(func() {
+				isolationSegmentGUID = ""
+			})
+
+			It("presents null data", func() {
+				Expect(output).To(MatchJSON(`{
+					"data": null,
+					"links": {
+						"self": {
+							"href": "https://api.example.org/v3/spaces/space-guid/relationships/isolation_segment"
+						}
+					}
+				}`))
+			})
+		})
+	})
+})
@@ -23,6 +23,20 @@ const (
 	SpaceDeleteOperation        = "space.delete"
 	DomainDeleteOperation       = "domain.delete"
 	RoleDeleteOperation         = "role.delete"
+	DropletUploadOperation      = "droplet.upload"
+
+	ServiceBrokerCreateOperation = "service_broker.create"
+	ServiceBrokerUpdateOperation = "service_broker.update"
+	ServiceBrokerDeleteOperation = "service_broker.delete"
+
+	ServiceInstanceCreateOperation = "service_instance.create"
+	ServiceInstanceUpdateOperation = "service_instance.update"
+	ServiceInstanceDeleteOperation = "service_instance.delete"
+
+	SecurityGroupDeleteOperation = "security_group.delete"
+
+	OrgQuotaDeleteOperation   = "organization_quota.delete"
+	SpaceQuotaDeleteOperation = "space_quota.delete"
 )
 
 var (
@@ -0,0 +1,28 @@
+package presenter
+
+import "code.cloudfoundry.org/korifi/api/actions/manifest"
+
+type ManifestDiffResponse struct {
+	Diff []ManifestDiffEntry `json:"diff"`
+}
+
+type ManifestDiffEntry struct {
+	Op    string `json:"op"`
+	Path  string `json:"path"`
+	Was   any    `json:"was"`
+	Value any    `json:"value"`
+}
+
+func ForManifestDiff(diffs []manifest.Diff) ManifestDiffResponse {
+	entries := []ManifestDiffEntry{}
+	for _, diff := range diffs {
+		entries = append(entries, ManifestDiffEntry{
+			Op:    diff.Op,
+			Path:  diff.Path,
+			Was:   diff.Was,
+			Value: diff.Value,
+		})
+	}
+
+	return ManifestDiffResponse{Diff: entries}
+}
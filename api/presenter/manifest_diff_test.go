@@ -0,0 +1,51 @@
+package presenter_test
+
+import (
+	"encoding/json"
+
+	"code.cloudfoundry.org/korifi/api/actions/manifest"
+	"code.cloudfoundry.org/korifi/api/presenter"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ManifestDiff", func() {
+	var (
+		diffs  []manifest.Diff
+		output []byte
+	)
+
+	BeforeEach(func() {
+		diffs = []manifest.Diff{
+			{Op: manifest.DiffOpAdd, Path: "/applications/0/env/FOO", Value: "bar"},
+			{Op: manifest.DiffOpReplace, Path: "/applications/0/processes/0/instances", Was: 1, Value: 0},
+			{Op: manifest.DiffOpRemove, Path: "/applications/0/routes/0", Was: map[string]any{"route": "my-app.my.domain"}},
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		output, err = json.Marshal(presenter.ForManifestDiff(diffs))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces the expected json", func() {
+		Expect(output).To(MatchJSON(`{
+			"diff": [
+				{ "op": "add", "path": "/applications/0/env/FOO", "was": null, "value": "bar" },
+				{ "op": "replace", "path": "/applications/0/processes/0/instances", "was": 1, "value": 0 },
+				{ "op": "remove", "path": "/applications/0/routes/0", "was": { "route": "my-app.my.domain" }, "value": null }
+			]
+		}`))
+	})
+
+	When("there are no diffs", func() {
+		BeforeEach(func() {
+			diffs = nil
+		})
+
+		It("returns an empty list", func() {
+			Expect(output).To(MatchJSON(`{"diff": []}`))
+		})
+	})
+})
@@ -0,0 +1,114 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const orgQuotasBase = "/v3/organization_quotas"
+
+type QuotaAppsResponse struct {
+	TotalMemoryInMB      *int64 `json:"total_memory_in_mb"`
+	PerProcessMemoryInMB *int64 `json:"per_process_memory_in_mb"`
+	TotalInstances       *int64 `json:"total_instances"`
+	PerAppTasks          *int64 `json:"per_app_tasks"`
+	TotalApps            *int64 `json:"total_apps"`
+}
+
+type QuotaServicesResponse struct {
+	TotalServiceInstances *int64 `json:"total_service_instances"`
+}
+
+type QuotaRoutesResponse struct {
+	TotalRoutes *int64 `json:"total_routes"`
+}
+
+type OrgQuotaResponse struct {
+	GUID          string                `json:"guid"`
+	CreatedAt     string                `json:"created_at"`
+	UpdatedAt     string                `json:"updated_at"`
+	Name          string                `json:"name"`
+	Apps          QuotaAppsResponse     `json:"apps"`
+	Services      QuotaServicesResponse `json:"services"`
+	Routes        QuotaRoutesResponse   `json:"routes"`
+	Relationships OrgQuotaRelationships `json:"relationships"`
+	Links         QuotaLinks            `json:"links"`
+}
+
+type OrgQuotaRelationships struct {
+	Organizations ToManyRelationship `json:"organizations"`
+}
+
+type QuotaLinks struct {
+	Self Link `json:"self"`
+}
+
+type OrgQuotaOrganizationsResponse struct {
+	Data  []RelationshipData `json:"data"`
+	Links QuotaLinks         `json:"links"`
+}
+
+type UsageSummaryResponse struct {
+	UsageSummary UsageSummary      `json:"usage_summary"`
+	Links        UsageSummaryLinks `json:"links"`
+}
+
+type UsageSummary struct {
+	StartedInstances int64 `json:"started_instances"`
+	MemoryInMB       int64 `json:"memory_in_mb"`
+	Apps             int64 `json:"apps"`
+	Routes           int64 `json:"routes"`
+	ServiceInstances int64 `json:"service_instances"`
+}
+
+type UsageSummaryLinks struct {
+	Self         Link  `json:"self"`
+	Organization *Link `json:"organization,omitempty"`
+	Space        *Link `json:"space,omitempty"`
+}
+
+func ForOrgQuota(record repositories.OrgQuotaRecord, baseURL url.URL) OrgQuotaResponse {
+	return OrgQuotaResponse{
+		GUID:      record.GUID,
+		CreatedAt: formatTimestamp(&record.CreatedAt),
+		UpdatedAt: formatTimestamp(record.UpdatedAt),
+		Name:      record.Name,
+		Apps:      QuotaAppsResponse(record.Limits.Apps),
+		Services:  QuotaServicesResponse(record.Limits.Services),
+		Routes:    QuotaRoutesResponse(record.Limits.Routes),
+		Relationships: OrgQuotaRelationships{
+			Organizations: ToManyRelationship{Data: toRelationshipData(record.OrgGUIDs)},
+		},
+		Links: QuotaLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(orgQuotasBase, record.GUID).build(),
+			},
+		},
+	}
+}
+
+func ForOrgQuotaOrganizations(orgGUIDs []string, orgQuotaGUID string, baseURL url.URL) OrgQuotaOrganizationsResponse {
+	return OrgQuotaOrganizationsResponse{
+		Data: toRelationshipData(orgGUIDs),
+		Links: QuotaLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(orgQuotasBase, orgQuotaGUID, "relationships", "organizations").build(),
+			},
+		},
+	}
+}
+
+func ForOrgUsageSummary(record repositories.UsageSummaryRecord, orgGUID string, baseURL url.URL) UsageSummaryResponse {
+	return UsageSummaryResponse{
+		UsageSummary: UsageSummary(record),
+		Links: UsageSummaryLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(orgsBase, orgGUID, "usage_summary").build(),
+			},
+			Organization: &Link{
+				HRef: buildURL(baseURL).appendPath(orgsBase, orgGUID).build(),
+			},
+		},
+	}
+}
@@ -0,0 +1,139 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Organization Quotas", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.OrgQuotaRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.OrgQuotaRecord{
+			GUID: "quota-guid",
+			Name: "my-quota",
+			Limits: repositories.QuotaLimits{
+				Apps: repositories.QuotaAppLimits{
+					TotalMemoryInMB: tools.PtrTo[int64](2048),
+					TotalInstances:  tools.PtrTo[int64](10),
+					TotalApps:       tools.PtrTo[int64](0),
+				},
+				Routes: repositories.QuotaRouteLimits{TotalRoutes: tools.PtrTo[int64](5)},
+			},
+			OrgGUIDs:  []string{"org-1", "org-2"},
+			CreatedAt: time.UnixMilli(1000),
+			UpdatedAt: tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	Describe("ForOrgQuota", func() {
+		JustBeforeEach(func() {
+			response := presenter.ForOrgQuota(record, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "quota-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"name": "my-quota",
+				"apps": {
+					"total_memory_in_mb": 2048,
+					"per_process_memory_in_mb": null,
+					"total_instances": 10,
+					"per_app_tasks": null,
+					"total_apps": 0
+				},
+				"services": {
+					"total_service_instances": null
+				},
+				"routes": {
+					"total_routes": 5
+				},
+				"relationships": {
+					"organizations": {
+						"data": [{"guid": "org-1"}, {"guid": "org-2"}]
+					}
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/organization_quotas/quota-guid"
+					}
+				}
+			}`))
+		})
+	})
+
+	Describe("ForOrgQuotaOrganizations", func() {
+		JustBeforeEach(func() {
+			response := presenter.ForOrgQuotaOrganizations(record.OrgGUIDs, record.GUID, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"data": [{"guid": "org-1"}, {"guid": "org-2"}],
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/organization_quotas/quota-guid/relationships/organizations"
+					}
+				}
+			}`))
+		})
+	})
+
+	Describe("ForOrgUsageSummary", func() {
+		JustBeforeEach(func() {
+			response := presenter.ForOrgUsageSummary(repositories.UsageSummaryRecord{
+				StartedInstances: 3,
+				MemoryInMB:       768,
+				Apps:             2,
+				Routes:           4,
+				ServiceInstances: 1,
+			}, "org-guid", *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"usage_summary": {
+					"started_instances": 3,
+					"memory_in_mb": 768,
+					"apps": 2,
+					"routes": 4,
+					"service_instances": 1
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/organizations/org-guid/usage_summary"
+					},
+					"organization": {
+						"href": "https://api.example.org/v3/organizations/org-guid"
+					}
+				}
+			}`))
+		})
+	})
+})
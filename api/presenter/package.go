@@ -22,7 +22,9 @@ type PackageResponse struct {
 	UpdatedAt     string        `json:"updated_at"`
 }
 
-type PackageData struct{}
+type PackageData struct {
+	Image string `json:"image,omitempty"`
+}
 
 type PackageLinks struct {
 	Self     Link `json:"self"`
@@ -35,6 +37,7 @@ func ForPackage(record repositories.PackageRecord, baseURL url.URL) PackageRespo
 	return PackageResponse{
 		GUID:      record.GUID,
 		Type:      record.Type,
+		Data:      PackageData{Image: record.DockerImage},
 		State:     record.State,
 		CreatedAt: formatTimestamp(&record.CreatedAt),
 		UpdatedAt: formatTimestamp(record.UpdatedAt),
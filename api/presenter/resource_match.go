@@ -0,0 +1,39 @@
+package presenter
+
+import (
+	"strconv"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type ResourceMatchesResponse struct {
+	Resources []ResourceResponse `json:"resources"`
+}
+
+type ResourceResponse struct {
+	Checksum    ChecksumResponse `json:"checksum"`
+	SizeInBytes int64            `json:"size_in_bytes"`
+	Path        string           `json:"path,omitempty"`
+	Mode        string           `json:"mode"`
+}
+
+type ChecksumResponse struct {
+	Value string `json:"value"`
+}
+
+func ForResourceMatches(resources []repositories.ResourceRecord) ResourceMatchesResponse {
+	response := ResourceMatchesResponse{
+		Resources: []ResourceResponse{},
+	}
+
+	for _, resource := range resources {
+		response.Resources = append(response.Resources, ResourceResponse{
+			Checksum:    ChecksumResponse{Value: resource.SHA1},
+			SizeInBytes: resource.Size,
+			Path:        resource.Path,
+			Mode:        strconv.FormatUint(uint64(resource.Mode.Perm()), 8),
+		})
+	}
+
+	return response
+}
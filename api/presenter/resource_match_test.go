@@ -0,0 +1,57 @@
+package presenter_test
+
+import (
+	"encoding/json"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResourceMatches", func() {
+	var (
+		resources []repositories.ResourceRecord
+		output    []byte
+	)
+
+	BeforeEach(func() {
+		resources = []repositories.ResourceRecord{{
+			SHA1: "002d760bea1be268e27077412e11a320d0f164d3",
+			Size: 65536,
+			Path: "path/to/file",
+			Mode: 0o644,
+		}}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		output, err = json.Marshal(presenter.ForResourceMatches(resources))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces the expected json", func() {
+		Expect(output).To(MatchJSON(`{
+			"resources": [
+				{
+					"checksum": {
+						"value": "002d760bea1be268e27077412e11a320d0f164d3"
+					},
+					"size_in_bytes": 65536,
+					"path": "path/to/file",
+					"mode": "644"
+				}
+			]
+		}`))
+	})
+
+	When("there are no matches", func() {
+		BeforeEach(func() {
+			resources = nil
+		})
+
+		It("returns an empty list", func() {
+			Expect(output).To(MatchJSON(`{"resources": []}`))
+		})
+	})
+})
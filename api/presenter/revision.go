@@ -0,0 +1,101 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	revisionsBase = "/v3/revisions"
+)
+
+type RevisionResponse struct {
+	GUID          string                             `json:"guid"`
+	Version       int                                `json:"version"`
+	Droplet       DropletGUID                        `json:"droplet"`
+	Processes     map[string]RevisionProcessResponse `json:"processes"`
+	Sidecars      []any                              `json:"sidecars"`
+	Description   string                             `json:"description"`
+	Deployable    bool                               `json:"deployable"`
+	Relationships Relationships                      `json:"relationships"`
+	Metadata      Metadata                           `json:"metadata"`
+	CreatedAt     string                             `json:"created_at"`
+	UpdatedAt     string                             `json:"updated_at"`
+	Links         RevisionLinks                      `json:"links"`
+}
+
+type RevisionProcessResponse struct {
+	Command string `json:"command"`
+}
+
+type RevisionLinks struct {
+	Self                 Link `json:"self"`
+	App                  Link `json:"app"`
+	EnvironmentVariables Link `json:"environment_variables"`
+}
+
+func ForRevision(record repositories.RevisionRecord, baseURL url.URL) RevisionResponse {
+	processes := map[string]RevisionProcessResponse{}
+	for processType, command := range record.Processes {
+		processes[processType] = RevisionProcessResponse{Command: command}
+	}
+
+	return RevisionResponse{
+		GUID:        record.GUID,
+		Version:     record.Version,
+		Droplet:     DropletGUID{Guid: record.DropletGUID},
+		Processes:   processes,
+		Sidecars:    []any{},
+		Description: record.Description,
+		Deployable:  record.Deployable,
+		Relationships: map[string]Relationship{
+			"app": {
+				Data: &RelationshipData{
+					GUID: record.AppGUID,
+				},
+			},
+		},
+		Metadata: Metadata{
+			Labels:      emptyMapIfNil(record.Labels),
+			Annotations: emptyMapIfNil(record.Annotations),
+		},
+		CreatedAt: formatTimestamp(&record.CreatedAt),
+		UpdatedAt: formatTimestamp(record.UpdatedAt),
+		Links: RevisionLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(revisionsBase, record.GUID).build(),
+			},
+			App: Link{
+				HRef: buildURL(baseURL).appendPath(appsBase, record.AppGUID).build(),
+			},
+			EnvironmentVariables: Link{
+				HRef: buildURL(baseURL).appendPath(revisionsBase, record.GUID, "environment_variables").build(),
+			},
+		},
+	}
+}
+
+type RevisionEnvVarsResponse struct {
+	Var   map[string]string    `json:"var"`
+	Links RevisionEnvVarsLinks `json:"links"`
+}
+
+type RevisionEnvVarsLinks struct {
+	Self     Link `json:"self"`
+	Revision Link `json:"revision"`
+}
+
+func ForRevisionEnvVars(record repositories.RevisionEnvVarsRecord, baseURL url.URL) RevisionEnvVarsResponse {
+	return RevisionEnvVarsResponse{
+		Var: record.EnvironmentVariables,
+		Links: RevisionEnvVarsLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(revisionsBase, record.RevisionGUID, "environment_variables").build(),
+			},
+			Revision: Link{
+				HRef: buildURL(baseURL).appendPath(revisionsBase, record.RevisionGUID).build(),
+			},
+		},
+	}
+}
@@ -0,0 +1,119 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Revisions", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("ForRevision", func() {
+		var record repositories.RevisionRecord
+
+		BeforeEach(func() {
+			record = repositories.RevisionRecord{
+				GUID:        "revision-guid",
+				AppGUID:     "app-guid",
+				Version:     2,
+				DropletGUID: "droplet-guid",
+				Processes:   map[string]string{"web": "bundle exec rackup"},
+				Description: "New droplet deployed.",
+				Deployable:  true,
+				CreatedAt:   time.UnixMilli(1000),
+				UpdatedAt:   tools.PtrTo(time.UnixMilli(2000)),
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForRevision(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected revision json", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "revision-guid",
+				"version": 2,
+				"droplet": {
+					"guid": "droplet-guid"
+				},
+				"processes": {
+					"web": {
+						"command": "bundle exec rackup"
+					}
+				},
+				"sidecars": [],
+				"description": "New droplet deployed.",
+				"deployable": true,
+				"relationships": {
+					"app": {
+						"data": {
+							"guid": "app-guid"
+						}
+					}
+				},
+				"metadata": {
+					"labels": {},
+					"annotations": {}
+				},
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/revisions/revision-guid"
+					},
+					"app": {
+						"href": "https://api.example.org/v3/apps/app-guid"
+					},
+					"environment_variables": {
+						"href": "https://api.example.org/v3/revisions/revision-guid/environment_variables"
+					}
+				}
+			}`))
+		})
+	})
+
+	Describe("ForRevisionEnvVars", func() {
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForRevisionEnvVars(repositories.RevisionEnvVarsRecord{
+				RevisionGUID:         "revision-guid",
+				EnvironmentVariables: map[string]string{"FOO": "bar"},
+			}, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected environment variables json", func() {
+			Expect(output).To(MatchJSON(`{
+				"var": {
+					"FOO": "bar"
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/revisions/revision-guid/environment_variables"
+					},
+					"revision": {
+						"href": "https://api.example.org/v3/revisions/revision-guid"
+					}
+				}
+			}`))
+		})
+	})
+})
@@ -1,6 +1,10 @@
 package presenter
 
-import "net/url"
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/config"
+)
 
 type APILink struct {
 	Link
@@ -8,7 +12,9 @@ type APILink struct {
 }
 
 type APILinkMeta struct {
-	Version string `json:"version"`
+	Version            string `json:"version"`
+	HostKeyFingerprint string `json:"host_key_fingerprint,omitempty"`
+	OAuthClient        string `json:"oauth_client,omitempty"`
 }
 
 type RootResponse struct {
@@ -18,7 +24,7 @@ type RootResponse struct {
 
 const V3APIVersion = "3.117.0+cf-k8s"
 
-func ForRoot(baseURL url.URL) RootResponse {
+func ForRoot(baseURL url.URL, sshProxy config.SSHProxyConfig) RootResponse {
 	return RootResponse{
 		Links: map[string]*APILink{
 			"self": {
@@ -53,12 +59,48 @@ func ForRoot(baseURL url.URL) RootResponse {
 				},
 			},
 			"log_stream": nil,
-			"app_ssh":    nil,
+			"app_ssh":    appSSHLink(sshProxy),
 		},
 		CFOnK8s: true,
 	}
 }
 
+func appSSHLink(sshProxy config.SSHProxyConfig) *APILink {
+	if sshProxy.Endpoint == "" {
+		return nil
+	}
+
+	return &APILink{
+		Link: Link{
+			HRef: sshProxy.Endpoint,
+		},
+		Meta: APILinkMeta{
+			HostKeyFingerprint: sshProxy.HostKeyFingerprint,
+			OAuthClient:        sshProxy.OAuthClient,
+		},
+	}
+}
+
+// V2InfoResponse only contains the fields of the V2 info endpoint that clients
+// use to discover the login and SSH endpoints
+type V2InfoResponse struct {
+	AuthorizationEndpoint    string `json:"authorization_endpoint"`
+	TokenEndpoint            string `json:"token_endpoint"`
+	AppSSHEndpoint           string `json:"app_ssh_endpoint"`
+	AppSSHHostKeyFingerprint string `json:"app_ssh_host_key_fingerprint"`
+	AppSSHOAuthClient        string `json:"app_ssh_oauth_client"`
+}
+
+func ForV2Info(baseURL url.URL, sshProxy config.SSHProxyConfig) V2InfoResponse {
+	return V2InfoResponse{
+		AuthorizationEndpoint:    buildURL(baseURL).build(),
+		TokenEndpoint:            buildURL(baseURL).build(),
+		AppSSHEndpoint:           sshProxy.Endpoint,
+		AppSSHHostKeyFingerprint: sshProxy.HostKeyFingerprint,
+		AppSSHOAuthClient:        sshProxy.OAuthClient,
+	}
+}
+
 type RootV3Response struct {
 	Links map[string]Link `json:"links"`
 }
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/url"
 
+	"code.cloudfoundry.org/korifi/api/config"
 	"code.cloudfoundry.org/korifi/api/presenter"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -12,19 +13,21 @@ import (
 
 var _ = Describe("Root endpoints", func() {
 	var (
-		baseURL *url.URL
-		output  []byte
+		baseURL  *url.URL
+		sshProxy config.SSHProxyConfig
+		output   []byte
 	)
 
 	BeforeEach(func() {
 		var err error
 		baseURL, err = url.Parse("https://api.example.org")
 		Expect(err).NotTo(HaveOccurred())
+		sshProxy = config.SSHProxyConfig{}
 	})
 
 	Context("/", func() {
 		JustBeforeEach(func() {
-			response := presenter.ForRoot(*baseURL)
+			response := presenter.ForRoot(*baseURL, sshProxy)
 			var err error
 			output, err = json.Marshal(response)
 			Expect(err).NotTo(HaveOccurred())
@@ -71,6 +74,56 @@ var _ = Describe("Root endpoints", func() {
 				"cf_on_k8s": true
 			}`))
 		})
+
+		When("the ssh proxy is configured", func() {
+			BeforeEach(func() {
+				sshProxy = config.SSHProxyConfig{
+					Endpoint:           "ssh.example.org:2222",
+					HostKeyFingerprint: "the-fingerprint",
+					OAuthClient:        "ssh-proxy",
+				}
+			})
+
+			It("links to the ssh proxy", func() {
+				var root map[string]any
+				Expect(json.Unmarshal(output, &root)).To(Succeed())
+				Expect(root).To(HaveKeyWithValue("links", HaveKeyWithValue("app_ssh", Equal(map[string]any{
+					"href": "ssh.example.org:2222",
+					"meta": map[string]any{
+						"version":              "",
+						"host_key_fingerprint": "the-fingerprint",
+						"oauth_client":         "ssh-proxy",
+					},
+				}))))
+			})
+		})
+	})
+
+	Context("/v2/info", func() {
+		BeforeEach(func() {
+			sshProxy = config.SSHProxyConfig{
+				Endpoint:           "ssh.example.org:2222",
+				HostKeyFingerprint: "the-fingerprint",
+				OAuthClient:        "ssh-proxy",
+			}
+		})
+
+		JustBeforeEach(func() {
+			response := presenter.ForV2Info(*baseURL, sshProxy)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces expected v2 info json", func() {
+			Expect(output).To(MatchJSON(`{
+				"authorization_endpoint": "https://api.example.org",
+				"token_endpoint": "https://api.example.org",
+				"app_ssh_endpoint": "ssh.example.org:2222",
+				"app_ssh_host_key_fingerprint": "the-fingerprint",
+				"app_ssh_oauth_client": "ssh-proxy"
+			}`))
+		})
 	})
 
 	Context("/v3", func() {
@@ -0,0 +1,101 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const securityGroupsBase = "/v3/security_groups"
+
+type SecurityGroupResponse struct {
+	GUID            string                         `json:"guid"`
+	Name            string                         `json:"name"`
+	CreatedAt       string                         `json:"created_at"`
+	UpdatedAt       string                         `json:"updated_at"`
+	GloballyEnabled SecurityGroupWorkloadsResponse `json:"globally_enabled"`
+	Rules           []SecurityGroupRuleResponse    `json:"rules"`
+	Relationships   SecurityGroupRelationships     `json:"relationships"`
+	Links           SecurityGroupLinks             `json:"links"`
+}
+
+type SecurityGroupWorkloadsResponse struct {
+	Running bool `json:"running"`
+	Staging bool `json:"staging"`
+}
+
+type SecurityGroupRuleResponse struct {
+	Protocol    string `json:"protocol"`
+	Destination string `json:"destination"`
+	Ports       string `json:"ports,omitempty"`
+	Type        *int32 `json:"type,omitempty"`
+	Code        *int32 `json:"code,omitempty"`
+	Description string `json:"description,omitempty"`
+	Log         bool   `json:"log,omitempty"`
+}
+
+type SecurityGroupRelationships struct {
+	RunningSpaces ToManyRelationship `json:"running_spaces"`
+	StagingSpaces ToManyRelationship `json:"staging_spaces"`
+}
+
+type ToManyRelationship struct {
+	Data []RelationshipData `json:"data"`
+}
+
+type SecurityGroupLinks struct {
+	Self Link `json:"self"`
+}
+
+type SecurityGroupSpacesResponse struct {
+	Data  []RelationshipData `json:"data"`
+	Links SecurityGroupLinks `json:"links"`
+}
+
+func ForSecurityGroup(record repositories.SecurityGroupRecord, baseURL url.URL) SecurityGroupResponse {
+	rules := make([]SecurityGroupRuleResponse, 0, len(record.Rules))
+	for _, rule := range record.Rules {
+		rules = append(rules, SecurityGroupRuleResponse(rule))
+	}
+
+	return SecurityGroupResponse{
+		GUID:      record.GUID,
+		Name:      record.Name,
+		CreatedAt: formatTimestamp(&record.CreatedAt),
+		UpdatedAt: formatTimestamp(record.UpdatedAt),
+		GloballyEnabled: SecurityGroupWorkloadsResponse{
+			Running: record.GloballyEnabled.Running,
+			Staging: record.GloballyEnabled.Staging,
+		},
+		Rules: rules,
+		Relationships: SecurityGroupRelationships{
+			RunningSpaces: ToManyRelationship{Data: toRelationshipData(record.RunningSpaceGUIDs)},
+			StagingSpaces: ToManyRelationship{Data: toRelationshipData(record.StagingSpaceGUIDs)},
+		},
+		Links: SecurityGroupLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(securityGroupsBase, record.GUID).build(),
+			},
+		},
+	}
+}
+
+func ForSecurityGroupSpaces(spaceGUIDs []string, securityGroupGUID string, workload string, baseURL url.URL) SecurityGroupSpacesResponse {
+	return SecurityGroupSpacesResponse{
+		Data: toRelationshipData(spaceGUIDs),
+		Links: SecurityGroupLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(securityGroupsBase, securityGroupGUID, "relationships", workload+"_spaces").build(),
+			},
+		},
+	}
+}
+
+func toRelationshipData(guids []string) []RelationshipData {
+	data := make([]RelationshipData, 0, len(guids))
+	for _, guid := range guids {
+		data = append(data, RelationshipData{GUID: guid})
+	}
+
+	return data
+}
@@ -0,0 +1,146 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Security Groups", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.SecurityGroupRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.SecurityGroupRecord{
+			GUID: "sg-guid",
+			Name: "my-security-group",
+			Rules: []repositories.SecurityGroupRule{
+				{Protocol: "tcp", Destination: "10.0.0.1", Ports: "443", Description: "https", Log: true},
+				{Protocol: "icmp", Destination: "0.0.0.0/0", Type: tools.PtrTo[int32](0), Code: tools.PtrTo[int32](-1)},
+			},
+			GloballyEnabled:   repositories.SecurityGroupWorkloads{Running: true},
+			RunningSpaceGUIDs: []string{"space-1"},
+			StagingSpaceGUIDs: []string{"space-2", "space-3"},
+			CreatedAt:         time.UnixMilli(1000),
+			UpdatedAt:         tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	Describe("ForSecurityGroup", func() {
+		JustBeforeEach(func() {
+			response := presenter.ForSecurityGroup(record, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "sg-guid",
+				"name": "my-security-group",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"globally_enabled": {
+					"running": true,
+					"staging": false
+				},
+				"rules": [
+					{
+						"protocol": "tcp",
+						"destination": "10.0.0.1",
+						"ports": "443",
+						"description": "https",
+						"log": true
+					},
+					{
+						"protocol": "icmp",
+						"destination": "0.0.0.0/0",
+						"type": 0,
+						"code": -1
+					}
+				],
+				"relationships": {
+					"running_spaces": {
+						"data": [{"guid": "space-1"}]
+					},
+					"staging_spaces": {
+						"data": [{"guid": "space-2"}, {"guid": "space-3"}]
+					}
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/security_groups/sg-guid"
+					}
+				}
+			}`))
+		})
+
+		When("the security group has no rules and no spaces", func() {
+			BeforeEach(func() {
+				record.Rules = nil
+				record.RunningSpaceGUIDs = nil
+				record.StagingSpaceGUIDs = nil
+			})
+
+			It("renders empty lists", func() {
+				Expect(output).To(MatchJSON(`{
+					"guid": "sg-guid",
+					"name": "my-security-group",
+					"created_at": "1970-01-01T00:00:01Z",
+					"updated_at": "1970-01-01T00:00:02Z",
+					"globally_enabled": {
+						"running": true,
+						"staging": false
+					},
+					"rules": [],
+					"relationships": {
+						"running_spaces": {
+							"data": []
+						},
+						"staging_spaces": {
+							"data": []
+						}
+					},
+					"links": {
+						"self": {
+							"href": "https://api.example.org/v3/security_groups/sg-guid"
+						}
+					}
+				}`))
+			})
+		})
+	})
+
+	Describe("ForSecurityGroupSpaces", func() {
+		JustBeforeEach(func() {
+			response := presenter.ForSecurityGroupSpaces(record.StagingSpaceGUIDs, record.GUID, repositories.SecurityGroupStagingWorkload, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"data": [{"guid": "space-2"}, {"guid": "space-3"}],
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/security_groups/sg-guid/relationships/staging_spaces"
+					}
+				}
+			}`))
+		})
+	})
+})
@@ -31,14 +31,18 @@ type ServiceBindingLastOperationResponse struct {
 }
 
 type ServiceBindingLinks struct {
-	App             Link `json:"app"`
-	ServiceInstance Link `json:"service_instance"`
-	Self            Link `json:"self"`
-	Details         Link `json:"details"`
+	App             *Link `json:"app,omitempty"`
+	ServiceInstance Link  `json:"service_instance"`
+	Self            Link  `json:"self"`
+	Details         Link  `json:"details"`
+}
+
+type ServiceBindingDetailsResponse struct {
+	Credentials map[string]any `json:"credentials"`
 }
 
 func ForServiceBinding(record repositories.ServiceBindingRecord, baseURL url.URL) ServiceBindingResponse {
-	return ServiceBindingResponse{
+	response := ServiceBindingResponse{
 		GUID:      record.GUID,
 		Type:      record.Type,
 		Name:      record.Name,
@@ -52,13 +56,9 @@ func ForServiceBinding(record repositories.ServiceBindingRecord, baseURL url.URL
 			UpdatedAt:   formatTimestamp(record.LastOperation.UpdatedAt),
 		},
 		Relationships: map[string]Relationship{
-			"app":              {&RelationshipData{record.AppGUID}},
 			"service_instance": {&RelationshipData{record.ServiceInstanceGUID}},
 		},
 		Links: ServiceBindingLinks{
-			App: Link{
-				HRef: buildURL(baseURL).appendPath(appsBase, record.AppGUID).build(),
-			},
 			ServiceInstance: Link{
 				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, record.ServiceInstanceGUID).build(),
 			},
@@ -74,6 +74,21 @@ func ForServiceBinding(record repositories.ServiceBindingRecord, baseURL url.URL
 			Annotations: emptyMapIfNil(record.Annotations),
 		},
 	}
+
+	if record.Type != repositories.ServiceBindingTypeKey {
+		response.Relationships["app"] = Relationship{&RelationshipData{record.AppGUID}}
+		response.Links.App = &Link{
+			HRef: buildURL(baseURL).appendPath(appsBase, record.AppGUID).build(),
+		}
+	}
+
+	return response
+}
+
+func ForServiceBindingDetails(record repositories.ServiceBindingDetailsRecord) ServiceBindingDetailsResponse {
+	return ServiceBindingDetailsResponse{
+		Credentials: emptyAnyMapIfNil(record.Credentials),
+	}
 }
 
 func ForServiceBindingList(serviceBindingRecords []repositories.ServiceBindingRecord, appRecords []repositories.AppRecord, baseURL, requestURL url.URL) ListResponse[ServiceBindingResponse] {
@@ -127,6 +127,39 @@ var _ = Describe("Service Binding", func() {
 				Expect(output).To(MatchJSONPath("$.metadata.annotations", Not(BeNil())))
 			})
 		})
+
+		When("the binding is a service key", func() {
+			BeforeEach(func() {
+				record.Type = "key"
+				record.AppGUID = ""
+			})
+
+			It("omits the app relationship and link", func() {
+				Expect(output).To(MatchJSONPath("$.type", "key"))
+				Expect(output).To(MatchJSONPath("$.relationships", Not(HaveKey("app"))))
+				Expect(output).To(MatchJSONPath("$.links", Not(HaveKey("app"))))
+				Expect(output).To(MatchJSONPath("$.relationships.service_instance.data.guid", "service-instance-guid"))
+			})
+		})
+	})
+
+	Describe("ForServiceBindingDetails", func() {
+		JustBeforeEach(func() {
+			response := presenter.ForServiceBindingDetails(repositories.ServiceBindingDetailsRecord{
+				Credentials: map[string]any{"username": "admin"},
+			})
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the expected JSON", func() {
+			Expect(output).To(MatchJSON(`{
+				"credentials": {
+					"username": "admin"
+				}
+			}`))
+		})
 	})
 
 	Describe("ForServiceBindingList", func() {
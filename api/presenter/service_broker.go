@@ -0,0 +1,51 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	serviceBrokersBase   = "/v3/service_brokers"
+	serviceOfferingsBase = "/v3/service_offerings"
+)
+
+type ServiceBrokerResponse struct {
+	GUID          string             `json:"guid"`
+	Name          string             `json:"name"`
+	URL           string             `json:"url"`
+	CreatedAt     string             `json:"created_at"`
+	UpdatedAt     string             `json:"updated_at"`
+	Relationships Relationships      `json:"relationships"`
+	Metadata      Metadata           `json:"metadata"`
+	Links         ServiceBrokerLinks `json:"links"`
+}
+
+type ServiceBrokerLinks struct {
+	Self             Link `json:"self"`
+	ServiceOfferings Link `json:"service_offerings"`
+}
+
+func ForServiceBroker(record repositories.ServiceBrokerRecord, baseURL url.URL) ServiceBrokerResponse {
+	return ServiceBrokerResponse{
+		GUID:          record.GUID,
+		Name:          record.Name,
+		URL:           record.URL,
+		CreatedAt:     formatTimestamp(&record.CreatedAt),
+		UpdatedAt:     formatTimestamp(record.UpdatedAt),
+		Relationships: Relationships{},
+		Metadata: Metadata{
+			Labels:      emptyMapIfNil(record.Labels),
+			Annotations: emptyMapIfNil(record.Annotations),
+		},
+		Links: ServiceBrokerLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(serviceBrokersBase, record.GUID).build(),
+			},
+			ServiceOfferings: Link{
+				HRef: buildURL(baseURL).appendPath(serviceOfferingsBase).setQuery("service_broker_guids=" + record.GUID).build(),
+			},
+		},
+	}
+}
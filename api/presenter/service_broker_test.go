@@ -0,0 +1,71 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Service Brokers", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.ServiceBrokerRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.ServiceBrokerRecord{
+			Name:        "my-broker",
+			GUID:        "broker-guid",
+			URL:         "https://my.broker",
+			Labels:      map[string]string{"foo": "bar"},
+			Annotations: map[string]string{"bar": "baz"},
+			CreatedAt:   time.UnixMilli(1000),
+			UpdatedAt:   tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForServiceBroker(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces the expected json", func() {
+		Expect(output).To(MatchJSON(`{
+			"guid": "broker-guid",
+			"name": "my-broker",
+			"url": "https://my.broker",
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z",
+			"relationships": {},
+			"metadata": {
+				"labels": {
+					"foo": "bar"
+				},
+				"annotations": {
+					"bar": "baz"
+				}
+			},
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/service_brokers/broker-guid"
+				},
+				"service_offerings": {
+					"href": "https://api.example.org/v3/service_offerings?service_broker_guids=broker-guid"
+				}
+			}
+		}`))
+	})
+})
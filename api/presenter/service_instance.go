@@ -4,6 +4,7 @@ import (
 	"net/url"
 
 	"code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 )
 
 const (
@@ -36,60 +37,130 @@ type lastOperation struct {
 }
 
 type ServiceInstanceLinks struct {
-	Self                      Link `json:"self"`
-	Space                     Link `json:"space"`
-	Credentials               Link `json:"credentials"`
-	ServiceCredentialBindings Link `json:"service_credential_bindings"`
-	ServiceRouteBindings      Link `json:"service_route_bindings"`
+	Self                      Link  `json:"self"`
+	Space                     Link  `json:"space"`
+	Credentials               *Link `json:"credentials,omitempty"`
+	Parameters                *Link `json:"parameters,omitempty"`
+	ServicePlan               *Link `json:"service_plan,omitempty"`
+	ServiceCredentialBindings Link  `json:"service_credential_bindings"`
+	ServiceRouteBindings      Link  `json:"service_route_bindings"`
+	SharedSpaces              Link  `json:"shared_spaces"`
+}
+
+type ServiceInstanceSharedSpacesResponse struct {
+	Data  []RelationshipData               `json:"data"`
+	Links ServiceInstanceSharedSpacesLinks `json:"links"`
+}
+
+type ServiceInstanceSharedSpacesLinks struct {
+	Self Link `json:"self"`
 }
 
 func ForServiceInstance(serviceInstanceRecord repositories.ServiceInstanceRecord, baseURL url.URL) ServiceInstanceResponse {
-	lastOperationType := "update"
-	if serviceInstanceRecord.UpdatedAt == nil || serviceInstanceRecord.CreatedAt == *serviceInstanceRecord.UpdatedAt {
-		lastOperationType = "create"
+	relationships := Relationships{
+		"space": Relationship{
+			Data: &RelationshipData{
+				GUID: serviceInstanceRecord.SpaceGUID,
+			},
+		},
 	}
-
-	return ServiceInstanceResponse{
-		Name: serviceInstanceRecord.Name,
-		GUID: serviceInstanceRecord.GUID,
-		Type: serviceInstanceRecord.Type,
-		Tags: emptySliceIfNil(serviceInstanceRecord.Tags),
-		LastOperation: lastOperation{
-			CreatedAt:   formatTimestamp(&serviceInstanceRecord.CreatedAt),
-			UpdatedAt:   formatTimestamp(serviceInstanceRecord.UpdatedAt),
-			Description: "Operation succeeded",
-			State:       "succeeded",
-			Type:        lastOperationType,
+	links := ServiceInstanceLinks{
+		Self: Link{
+			HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceRecord.GUID).build(),
 		},
-		CreatedAt: formatTimestamp(&serviceInstanceRecord.CreatedAt),
-		UpdatedAt: formatTimestamp(serviceInstanceRecord.UpdatedAt),
-		Relationships: Relationships{
-			"space": Relationship{
-				Data: &RelationshipData{
-					GUID: serviceInstanceRecord.SpaceGUID,
-				},
-			},
+		Space: Link{
+			HRef: buildURL(baseURL).appendPath(spacesBase, serviceInstanceRecord.SpaceGUID).build(),
+		},
+		ServiceCredentialBindings: Link{
+			HRef: buildURL(baseURL).appendPath(serviceCredentialBindingsBase).setQuery("service_instance_guids=" + serviceInstanceRecord.GUID).build(),
+		},
+		ServiceRouteBindings: Link{
+			HRef: buildURL(baseURL).appendPath(serviceRouteBindingsBase).setQuery("service_instance_guids=" + serviceInstanceRecord.GUID).build(),
 		},
+		SharedSpaces: Link{
+			HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceRecord.GUID, "relationships", "shared_spaces").build(),
+		},
+	}
+
+	if serviceInstanceRecord.Type == korifiv1alpha1.ManagedType {
+		relationships["service_plan"] = Relationship{
+			Data: &RelationshipData{
+				GUID: serviceInstanceRecord.PlanGUID,
+			},
+		}
+		links.Parameters = &Link{
+			HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceRecord.GUID, "parameters").build(),
+		}
+		links.ServicePlan = &Link{
+			HRef: buildURL(baseURL).appendPath(servicePlansBase, serviceInstanceRecord.PlanGUID).build(),
+		}
+	} else {
+		links.Credentials = &Link{
+			HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceRecord.GUID, "credentials").build(),
+		}
+	}
+
+	return ServiceInstanceResponse{
+		Name:            serviceInstanceRecord.Name,
+		GUID:            serviceInstanceRecord.GUID,
+		Type:            serviceInstanceRecord.Type,
+		Tags:            emptySliceIfNil(serviceInstanceRecord.Tags),
+		LastOperation:   forServiceInstanceLastOperation(serviceInstanceRecord),
+		RouteServiceURL: serviceInstanceRecord.RouteServiceURL,
+		SyslogDrainURL:  serviceInstanceRecord.SyslogDrainURL,
+		CreatedAt:       formatTimestamp(&serviceInstanceRecord.CreatedAt),
+		UpdatedAt:       formatTimestamp(serviceInstanceRecord.UpdatedAt),
+		Relationships:   relationships,
 		Metadata: Metadata{
 			Labels:      emptyMapIfNil(serviceInstanceRecord.Labels),
 			Annotations: emptyMapIfNil(serviceInstanceRecord.Annotations),
 		},
-		Links: ServiceInstanceLinks{
+		Links: links,
+	}
+}
+
+func ForServiceInstanceSharedSpaces(serviceInstanceRecord repositories.ServiceInstanceRecord, baseURL url.URL) ServiceInstanceSharedSpacesResponse {
+	data := make([]RelationshipData, 0, len(serviceInstanceRecord.SharedSpaceGUIDs))
+	for _, spaceGUID := range serviceInstanceRecord.SharedSpaceGUIDs {
+		data = append(data, RelationshipData{GUID: spaceGUID})
+	}
+
+	return ServiceInstanceSharedSpacesResponse{
+		Data: data,
+		Links: ServiceInstanceSharedSpacesLinks{
 			Self: Link{
-				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceRecord.GUID).build(),
-			},
-			Space: Link{
-				HRef: buildURL(baseURL).appendPath(spacesBase, serviceInstanceRecord.SpaceGUID).build(),
-			},
-			Credentials: Link{
-				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceRecord.GUID, "credentials").build(),
-			},
-			ServiceCredentialBindings: Link{
-				HRef: buildURL(baseURL).appendPath(serviceCredentialBindingsBase).setQuery("service_instance_guids=" + serviceInstanceRecord.GUID).build(),
-			},
-			ServiceRouteBindings: Link{
-				HRef: buildURL(baseURL).appendPath(serviceRouteBindingsBase).setQuery("service_instance_guids=" + serviceInstanceRecord.GUID).build(),
+				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, serviceInstanceRecord.GUID, "relationships", "shared_spaces").build(),
 			},
 		},
 	}
 }
+
+func forServiceInstanceLastOperation(serviceInstanceRecord repositories.ServiceInstanceRecord) lastOperation {
+	operation := lastOperation{
+		CreatedAt: formatTimestamp(&serviceInstanceRecord.CreatedAt),
+		UpdatedAt: formatTimestamp(serviceInstanceRecord.UpdatedAt),
+	}
+
+	if serviceInstanceRecord.LastOperation != nil {
+		operation.Type = serviceInstanceRecord.LastOperation.Type
+		operation.State = serviceInstanceRecord.LastOperation.State
+		operation.Description = serviceInstanceRecord.LastOperation.Description
+		return operation
+	}
+
+	if serviceInstanceRecord.Type == korifiv1alpha1.ManagedType {
+		// the controller has not talked to the broker yet
+		operation.Type = korifiv1alpha1.CreateLastOperationType
+		operation.State = "initial"
+		return operation
+	}
+
+	operation.Type = "update"
+	if serviceInstanceRecord.UpdatedAt == nil || serviceInstanceRecord.CreatedAt == *serviceInstanceRecord.UpdatedAt {
+		operation.Type = "create"
+	}
+	operation.Description = "Operation succeeded"
+	operation.State = "succeeded"
+
+	return operation
+}
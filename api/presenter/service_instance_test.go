@@ -67,6 +67,9 @@ var _ = Describe("Service Instance", func() {
 				"service_route_bindings": {
 					"href": "https://api.example.org/v3/service_route_bindings?service_instance_guids=service-instance-guid"
 				},
+				"shared_spaces": {
+					"href": "https://api.example.org/v3/service_instances/service-instance-guid/relationships/shared_spaces"
+				},
 				"space": {
 					"href": "https://api.example.org/v3/spaces/space-guid"
 				}
@@ -114,6 +117,65 @@ var _ = Describe("Service Instance", func() {
 		})
 	})
 
+	When("the service instance has a route service URL", func() {
+		BeforeEach(func() {
+			record.RouteServiceURL = tools.PtrTo("https://route-service.example.com")
+		})
+
+		It("includes the route service URL", func() {
+			Expect(output).To(MatchJSONPath("$.route_service_url", "https://route-service.example.com"))
+		})
+	})
+
+	When("the service instance has a syslog drain URL", func() {
+		BeforeEach(func() {
+			record.SyslogDrainURL = tools.PtrTo("syslog://logs.example.com:514")
+		})
+
+		It("includes the syslog drain URL", func() {
+			Expect(output).To(MatchJSONPath("$.syslog_drain_url", "syslog://logs.example.com:514"))
+		})
+	})
+
+	When("the service instance is managed", func() {
+		BeforeEach(func() {
+			record.Type = "managed"
+			record.SecretName = ""
+			record.PlanGUID = "plan-guid"
+		})
+
+		It("includes the service plan relationship", func() {
+			Expect(output).To(MatchJSONPath("$.relationships.service_plan.data.guid", "plan-guid"))
+		})
+
+		It("links to the parameters and the service plan instead of the credentials", func() {
+			Expect(output).To(MatchJSONPath("$.links.parameters.href", "https://api.example.org/v3/service_instances/service-instance-guid/parameters"))
+			Expect(output).To(MatchJSONPath("$.links.service_plan.href", "https://api.example.org/v3/service_plans/plan-guid"))
+			Expect(output).To(MatchJSONPath("$.links", Not(HaveKey("credentials"))))
+		})
+
+		It("reports the initial last operation", func() {
+			Expect(output).To(MatchJSONPath("$.last_operation.type", "create"))
+			Expect(output).To(MatchJSONPath("$.last_operation.state", "initial"))
+		})
+
+		When("the broker has reported an operation", func() {
+			BeforeEach(func() {
+				record.LastOperation = &repositories.ServiceInstanceLastOperation{
+					Type:        "update",
+					State:       "in progress",
+					Description: "updating",
+				}
+			})
+
+			It("presents the last operation", func() {
+				Expect(output).To(MatchJSONPath("$.last_operation.type", "update"))
+				Expect(output).To(MatchJSONPath("$.last_operation.state", "in progress"))
+				Expect(output).To(MatchJSONPath("$.last_operation.description", "updating"))
+			})
+		})
+	})
+
 	When("labels is nil", func() {
 		BeforeEach(func() {
 			record.Labels = nil
@@ -133,4 +195,34 @@ var _ = Describe("Service Instance", func() {
 			Expect(output).To(MatchJSONPath("$.metadata.annotations", Not(BeNil())))
 		})
 	})
+
+	Describe("shared spaces", func() {
+		JustBeforeEach(func() {
+			response := presenter.ForServiceInstanceSharedSpaces(record, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns an empty list", func() {
+			Expect(output).To(MatchJSON(`{
+				"data": [],
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/service_instances/service-instance-guid/relationships/shared_spaces"
+					}
+				}
+			}`))
+		})
+
+		When("the service instance is shared", func() {
+			BeforeEach(func() {
+				record.SharedSpaceGUIDs = []string{"space-1", "space-2"}
+			})
+
+			It("lists the spaces", func() {
+				Expect(output).To(MatchJSONPath("$.data[*].guid", ConsistOf("space-1", "space-2")))
+			})
+		})
+	})
 })
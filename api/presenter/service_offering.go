@@ -0,0 +1,92 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type ServiceOfferingResponse struct {
+	GUID             string                       `json:"guid"`
+	Name             string                       `json:"name"`
+	Description      string                       `json:"description"`
+	Available        bool                         `json:"available"`
+	Tags             []string                     `json:"tags"`
+	Requires         []string                     `json:"requires"`
+	Shareable        bool                         `json:"shareable"`
+	DocumentationURL *string                      `json:"documentation_url"`
+	BrokerCatalog    ServiceOfferingBrokerCatalog `json:"broker_catalog"`
+	CreatedAt        string                       `json:"created_at"`
+	UpdatedAt        string                       `json:"updated_at"`
+	Relationships    Relationships                `json:"relationships"`
+	Metadata         Metadata                     `json:"metadata"`
+	Links            ServiceOfferingLinks         `json:"links"`
+}
+
+type ServiceOfferingBrokerCatalog struct {
+	ID       string                               `json:"id"`
+	Metadata map[string]any                       `json:"metadata"`
+	Features ServiceOfferingBrokerCatalogFeatures `json:"features"`
+}
+
+type ServiceOfferingBrokerCatalogFeatures struct {
+	PlanUpdateable       bool `json:"plan_updateable"`
+	Bindable             bool `json:"bindable"`
+	InstancesRetrievable bool `json:"instances_retrievable"`
+	BindingsRetrievable  bool `json:"bindings_retrievable"`
+	AllowContextUpdates  bool `json:"allow_context_updates"`
+}
+
+type ServiceOfferingLinks struct {
+	Self          Link `json:"self"`
+	ServicePlans  Link `json:"service_plans"`
+	ServiceBroker Link `json:"service_broker"`
+}
+
+func ForServiceOffering(record repositories.ServiceOfferingRecord, baseURL url.URL) ServiceOfferingResponse {
+	return ServiceOfferingResponse{
+		GUID:             record.GUID,
+		Name:             record.Name,
+		Description:      record.Description,
+		Available:        true,
+		Tags:             emptySliceIfNil(record.Tags),
+		Requires:         emptySliceIfNil(record.Requires),
+		Shareable:        record.Shareable,
+		DocumentationURL: record.DocumentationURL,
+		BrokerCatalog: ServiceOfferingBrokerCatalog{
+			ID:       record.BrokerCatalog.ID,
+			Metadata: emptyAnyMapIfNil(record.BrokerCatalog.Metadata),
+			Features: ServiceOfferingBrokerCatalogFeatures{
+				PlanUpdateable:       record.BrokerCatalog.PlanUpdateable,
+				Bindable:             record.BrokerCatalog.Bindable,
+				InstancesRetrievable: record.BrokerCatalog.InstancesRetrievable,
+				BindingsRetrievable:  record.BrokerCatalog.BindingsRetrievable,
+				AllowContextUpdates:  record.BrokerCatalog.AllowContextUpdates,
+			},
+		},
+		CreatedAt: formatTimestamp(&record.CreatedAt),
+		UpdatedAt: formatTimestamp(record.UpdatedAt),
+		Relationships: Relationships{
+			"service_broker": Relationship{
+				Data: &RelationshipData{
+					GUID: record.ServiceBrokerGUID,
+				},
+			},
+		},
+		Metadata: Metadata{
+			Labels:      emptyMapIfNil(record.Labels),
+			Annotations: emptyMapIfNil(record.Annotations),
+		},
+		Links: ServiceOfferingLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(serviceOfferingsBase, record.GUID).build(),
+			},
+			ServicePlans: Link{
+				HRef: buildURL(baseURL).appendPath(servicePlansBase).setQuery("service_offering_guids=" + record.GUID).build(),
+			},
+			ServiceBroker: Link{
+				HRef: buildURL(baseURL).appendPath(serviceBrokersBase, record.ServiceBrokerGUID).build(),
+			},
+		},
+	}
+}
@@ -0,0 +1,108 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Service Offerings", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.ServiceOfferingRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.ServiceOfferingRecord{
+			Name:              "my-offering",
+			GUID:              "offering-guid",
+			Description:       "my offering",
+			Tags:              []string{"sql"},
+			Shareable:         true,
+			DocumentationURL:  tools.PtrTo("https://docs.my.broker"),
+			ServiceBrokerGUID: "broker-guid",
+			BrokerCatalog: repositories.ServiceOfferingBrokerCatalog{
+				ID:                   "catalog-offering-id",
+				Metadata:             map[string]any{"shareable": true},
+				Bindable:             true,
+				InstancesRetrievable: true,
+			},
+			Labels:      map[string]string{"foo": "bar"},
+			Annotations: map[string]string{"bar": "baz"},
+			CreatedAt:   time.UnixMilli(1000),
+			UpdatedAt:   tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForServiceOffering(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces the expected json", func() {
+		Expect(output).To(MatchJSON(`{
+			"guid": "offering-guid",
+			"name": "my-offering",
+			"description": "my offering",
+			"available": true,
+			"tags": ["sql"],
+			"requires": [],
+			"shareable": true,
+			"documentation_url": "https://docs.my.broker",
+			"broker_catalog": {
+				"id": "catalog-offering-id",
+				"metadata": {
+					"shareable": true
+				},
+				"features": {
+					"plan_updateable": false,
+					"bindable": true,
+					"instances_retrievable": true,
+					"bindings_retrievable": false,
+					"allow_context_updates": false
+				}
+			},
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z",
+			"relationships": {
+				"service_broker": {
+					"data": {
+						"guid": "broker-guid"
+					}
+				}
+			},
+			"metadata": {
+				"labels": {
+					"foo": "bar"
+				},
+				"annotations": {
+					"bar": "baz"
+				}
+			},
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/service_offerings/offering-guid"
+				},
+				"service_plans": {
+					"href": "https://api.example.org/v3/service_plans?service_offering_guids=offering-guid"
+				},
+				"service_broker": {
+					"href": "https://api.example.org/v3/service_brokers/broker-guid"
+				}
+			}
+		}`))
+	})
+})
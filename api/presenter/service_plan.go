@@ -0,0 +1,153 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	servicePlansBase = "/v3/service_plans"
+)
+
+type ServicePlanResponse struct {
+	GUID            string                   `json:"guid"`
+	Name            string                   `json:"name"`
+	Description     string                   `json:"description"`
+	Available       bool                     `json:"available"`
+	VisibilityType  string                   `json:"visibility_type"`
+	Free            bool                     `json:"free"`
+	Costs           []any                    `json:"costs"`
+	MaintenanceInfo map[string]any           `json:"maintenance_info"`
+	BrokerCatalog   ServicePlanBrokerCatalog `json:"broker_catalog"`
+	Schemas         ServicePlanSchemas       `json:"schemas"`
+	CreatedAt       string                   `json:"created_at"`
+	UpdatedAt       string                   `json:"updated_at"`
+	Relationships   Relationships            `json:"relationships"`
+	Metadata        Metadata                 `json:"metadata"`
+	Links           ServicePlanLinks         `json:"links"`
+}
+
+type ServicePlanBrokerCatalog struct {
+	ID       string                           `json:"id"`
+	Metadata map[string]any                   `json:"metadata"`
+	Features ServicePlanBrokerCatalogFeatures `json:"features"`
+}
+
+type ServicePlanBrokerCatalogFeatures struct {
+	PlanUpdateable bool `json:"plan_updateable"`
+	Bindable       bool `json:"bindable"`
+}
+
+type ServicePlanSchemas struct {
+	ServiceInstance ServicePlanServiceInstanceSchemas `json:"service_instance"`
+	ServiceBinding  ServicePlanServiceBindingSchemas  `json:"service_binding"`
+}
+
+type ServicePlanServiceInstanceSchemas struct {
+	Create ServicePlanInputParameters `json:"create"`
+	Update ServicePlanInputParameters `json:"update"`
+}
+
+type ServicePlanServiceBindingSchemas struct {
+	Create ServicePlanInputParameters `json:"create"`
+}
+
+type ServicePlanInputParameters struct {
+	Parameters map[string]any `json:"parameters"`
+}
+
+type ServicePlanLinks struct {
+	Self            Link `json:"self"`
+	ServiceOffering Link `json:"service_offering"`
+	Visibility      Link `json:"visibility"`
+}
+
+type ServicePlanVisibilityResponse struct {
+	Type          string                              `json:"type"`
+	Organizations []ServicePlanVisibilityOrganization `json:"organizations,omitempty"`
+}
+
+type ServicePlanVisibilityOrganization struct {
+	GUID string `json:"guid"`
+	Name string `json:"name"`
+}
+
+func ForServicePlan(record repositories.ServicePlanRecord, baseURL url.URL) ServicePlanResponse {
+	return ServicePlanResponse{
+		GUID:            record.GUID,
+		Name:            record.Name,
+		Description:     record.Description,
+		Available:       true,
+		VisibilityType:  record.VisibilityType,
+		Free:            record.Free,
+		Costs:           []any{},
+		MaintenanceInfo: map[string]any{},
+		BrokerCatalog: ServicePlanBrokerCatalog{
+			ID:       record.BrokerCatalog.ID,
+			Metadata: emptyAnyMapIfNil(record.BrokerCatalog.Metadata),
+			Features: ServicePlanBrokerCatalogFeatures{
+				PlanUpdateable: record.BrokerCatalog.PlanUpdateable,
+				Bindable:       record.BrokerCatalog.Bindable,
+			},
+		},
+		Schemas: ServicePlanSchemas{
+			ServiceInstance: ServicePlanServiceInstanceSchemas{
+				Create: ServicePlanInputParameters{Parameters: emptyAnyMapIfNil(record.Schemas.ServiceInstanceCreate)},
+				Update: ServicePlanInputParameters{Parameters: emptyAnyMapIfNil(record.Schemas.ServiceInstanceUpdate)},
+			},
+			ServiceBinding: ServicePlanServiceBindingSchemas{
+				Create: ServicePlanInputParameters{Parameters: emptyAnyMapIfNil(record.Schemas.ServiceBindingCreate)},
+			},
+		},
+		CreatedAt: formatTimestamp(&record.CreatedAt),
+		UpdatedAt: formatTimestamp(record.UpdatedAt),
+		Relationships: Relationships{
+			"service_offering": Relationship{
+				Data: &RelationshipData{
+					GUID: record.ServiceOfferingGUID,
+				},
+			},
+		},
+		Metadata: Metadata{
+			Labels:      emptyMapIfNil(record.Labels),
+			Annotations: emptyMapIfNil(record.Annotations),
+		},
+		Links: ServicePlanLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(servicePlansBase, record.GUID).build(),
+			},
+			ServiceOffering: Link{
+				HRef: buildURL(baseURL).appendPath(serviceOfferingsBase, record.ServiceOfferingGUID).build(),
+			},
+			Visibility: Link{
+				HRef: buildURL(baseURL).appendPath(servicePlansBase, record.GUID, "visibility").build(),
+			},
+		},
+	}
+}
+
+func ForServicePlanList(servicePlanRecords []repositories.ServicePlanRecord, serviceOfferingRecords []repositories.ServiceOfferingRecord, baseURL, requestURL url.URL) ListResponse[ServicePlanResponse] {
+	ret := ForList(ForServicePlan, servicePlanRecords, baseURL, requestURL)
+	if len(serviceOfferingRecords) > 0 {
+		offeringData := IncludedData{}
+		for _, offeringRecord := range serviceOfferingRecords {
+			offeringData.ServiceOfferings = append(offeringData.ServiceOfferings, ForServiceOffering(offeringRecord, baseURL))
+		}
+		ret.Included = &offeringData
+	}
+	return ret
+}
+
+func ForServicePlanVisibility(record repositories.ServicePlanVisibilityRecord) ServicePlanVisibilityResponse {
+	response := ServicePlanVisibilityResponse{
+		Type: record.Type,
+	}
+	for _, org := range record.Organizations {
+		response.Organizations = append(response.Organizations, ServicePlanVisibilityOrganization{
+			GUID: org.GUID,
+			Name: org.Name,
+		})
+	}
+	return response
+}
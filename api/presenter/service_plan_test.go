@@ -0,0 +1,205 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Service Plans", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.ServicePlanRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.ServicePlanRecord{
+			Name:                "my-plan",
+			GUID:                "plan-guid",
+			Description:         "my plan",
+			Free:                true,
+			VisibilityType:      "public",
+			ServiceOfferingGUID: "offering-guid",
+			ServiceBrokerGUID:   "broker-guid",
+			BrokerCatalog: repositories.ServicePlanBrokerCatalog{
+				ID:             "catalog-plan-id",
+				Metadata:       map[string]any{"foo": "bar"},
+				Bindable:       true,
+				PlanUpdateable: true,
+			},
+			Schemas: repositories.ServicePlanSchemas{
+				ServiceInstanceCreate: map[string]any{"type": "object"},
+			},
+			Labels:      map[string]string{"foo": "bar"},
+			Annotations: map[string]string{"bar": "baz"},
+			CreatedAt:   time.UnixMilli(1000),
+			UpdatedAt:   tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	Describe("ForServicePlan", func() {
+		JustBeforeEach(func() {
+			response := presenter.ForServicePlan(record, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "plan-guid",
+				"name": "my-plan",
+				"description": "my plan",
+				"available": true,
+				"visibility_type": "public",
+				"free": true,
+				"costs": [],
+				"maintenance_info": {},
+				"broker_catalog": {
+					"id": "catalog-plan-id",
+					"metadata": {
+						"foo": "bar"
+					},
+					"features": {
+						"plan_updateable": true,
+						"bindable": true
+					}
+				},
+				"schemas": {
+					"service_instance": {
+						"create": {
+							"parameters": {
+								"type": "object"
+							}
+						},
+						"update": {
+							"parameters": {}
+						}
+					},
+					"service_binding": {
+						"create": {
+							"parameters": {}
+						}
+					}
+				},
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"relationships": {
+					"service_offering": {
+						"data": {
+							"guid": "offering-guid"
+						}
+					}
+				},
+				"metadata": {
+					"labels": {
+						"foo": "bar"
+					},
+					"annotations": {
+						"bar": "baz"
+					}
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/service_plans/plan-guid"
+					},
+					"service_offering": {
+						"href": "https://api.example.org/v3/service_offerings/offering-guid"
+					},
+					"visibility": {
+						"href": "https://api.example.org/v3/service_plans/plan-guid/visibility"
+					}
+				}
+			}`))
+		})
+	})
+
+	Describe("ForServicePlanList", func() {
+		var offeringRecords []repositories.ServiceOfferingRecord
+
+		BeforeEach(func() {
+			offeringRecords = nil
+		})
+
+		JustBeforeEach(func() {
+			requestURL, err := url.Parse("/v3/service_plans?include=service_offering")
+			Expect(err).NotTo(HaveOccurred())
+			response := presenter.ForServicePlanList([]repositories.ServicePlanRecord{record}, offeringRecords, *baseURL, *requestURL)
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("does not include anything", func() {
+			Expect(string(output)).NotTo(ContainSubstring(`"included"`))
+		})
+
+		When("service offerings are included", func() {
+			BeforeEach(func() {
+				offeringRecords = []repositories.ServiceOfferingRecord{{GUID: "offering-guid", Name: "my-offering"}}
+			})
+
+			It("includes the service offerings", func() {
+				var response map[string]any
+				Expect(json.Unmarshal(output, &response)).To(Succeed())
+				Expect(response).To(HaveKeyWithValue("included", HaveKeyWithValue("service_offerings", ConsistOf(
+					HaveKeyWithValue("guid", "offering-guid"),
+				))))
+			})
+		})
+	})
+
+	Describe("ForServicePlanVisibility", func() {
+		var visibilityRecord repositories.ServicePlanVisibilityRecord
+
+		BeforeEach(func() {
+			visibilityRecord = repositories.ServicePlanVisibilityRecord{
+				Type: "organization",
+				Organizations: []repositories.VisibilityOrganization{
+					{GUID: "org-guid", Name: "my-org"},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForServicePlanVisibility(visibilityRecord))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"type": "organization",
+				"organizations": [
+					{
+						"guid": "org-guid",
+						"name": "my-org"
+					}
+				]
+			}`))
+		})
+
+		When("the plan is public", func() {
+			BeforeEach(func() {
+				visibilityRecord = repositories.ServicePlanVisibilityRecord{
+					Type:          "public",
+					Organizations: []repositories.VisibilityOrganization{},
+				}
+			})
+
+			It("omits the organizations", func() {
+				Expect(output).To(MatchJSON(`{"type": "public"}`))
+			})
+		})
+	})
+})
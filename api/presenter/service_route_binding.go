@@ -1,9 +1,70 @@
 package presenter
 
-import "net/url"
+import (
+	"net/url"
 
-type ServiceRouteBinding struct{}
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
 
-func ForServiceRouteBindingsList(baseURL, requestURL url.URL) ListResponse[any] {
-	return ForList(func(a any, _ url.URL) any { return a }, []any{}, baseURL, requestURL)
+type ServiceRouteBindingResponse struct {
+	GUID            string                                   `json:"guid"`
+	RouteServiceURL string                                   `json:"route_service_url"`
+	CreatedAt       string                                   `json:"created_at"`
+	UpdatedAt       string                                   `json:"updated_at"`
+	LastOperation   ServiceRouteBindingLastOperationResponse `json:"last_operation"`
+	Relationships   Relationships                            `json:"relationships"`
+	Metadata        Metadata                                 `json:"metadata"`
+	Links           ServiceRouteBindingLinks                 `json:"links"`
+}
+
+type ServiceRouteBindingLastOperationResponse struct {
+	Type        string  `json:"type"`
+	State       string  `json:"state"`
+	Description *string `json:"description"`
+	CreatedAt   string  `json:"created_at"`
+	UpdatedAt   string  `json:"updated_at"`
+}
+
+type ServiceRouteBindingLinks struct {
+	Self            Link `json:"self"`
+	ServiceInstance Link `json:"service_instance"`
+	Route           Link `json:"route"`
+}
+
+func ForServiceRouteBinding(record repositories.ServiceRouteBindingRecord, baseURL url.URL) ServiceRouteBindingResponse {
+	return ServiceRouteBindingResponse{
+		GUID:            record.GUID,
+		RouteServiceURL: record.RouteServiceURL,
+		CreatedAt:       formatTimestamp(&record.CreatedAt),
+		UpdatedAt:       formatTimestamp(record.UpdatedAt),
+		LastOperation: ServiceRouteBindingLastOperationResponse{
+			Type:      "create",
+			State:     "succeeded",
+			CreatedAt: formatTimestamp(&record.CreatedAt),
+			UpdatedAt: formatTimestamp(record.UpdatedAt),
+		},
+		Relationships: Relationships{
+			"service_instance": Relationship{Data: &RelationshipData{GUID: record.ServiceInstanceGUID}},
+			"route":            Relationship{Data: &RelationshipData{GUID: record.RouteGUID}},
+		},
+		Metadata: Metadata{
+			Labels:      emptyMapIfNil(record.Labels),
+			Annotations: emptyMapIfNil(record.Annotations),
+		},
+		Links: ServiceRouteBindingLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(serviceRouteBindingsBase, record.GUID).build(),
+			},
+			ServiceInstance: Link{
+				HRef: buildURL(baseURL).appendPath(serviceInstancesBase, record.ServiceInstanceGUID).build(),
+			},
+			Route: Link{
+				HRef: buildURL(baseURL).appendPath(routesBase, record.RouteGUID).build(),
+			},
+		},
+	}
+}
+
+func ForServiceRouteBindingList(records []repositories.ServiceRouteBindingRecord, baseURL, requestURL url.URL) ListResponse[ServiceRouteBindingResponse] {
+	return ForList(ForServiceRouteBinding, records, baseURL, requestURL)
 }
@@ -0,0 +1,129 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Service Route Binding", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.ServiceRouteBindingRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.ServiceRouteBindingRecord{
+			GUID:                "binding-guid",
+			RouteServiceURL:     "https://route-service.example.com",
+			ServiceInstanceGUID: "service-instance-guid",
+			RouteGUID:           "route-guid",
+			SpaceGUID:           "space-guid",
+			Labels: map[string]string{
+				"label-key": "label-val",
+			},
+			Annotations: map[string]string{
+				"annotation-key": "annotation-val",
+			},
+			CreatedAt: time.UnixMilli(1000),
+			UpdatedAt: tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	Describe("ForServiceRouteBinding", func() {
+		JustBeforeEach(func() {
+			response := presenter.ForServiceRouteBinding(record, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the expected JSON", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "binding-guid",
+				"route_service_url": "https://route-service.example.com",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"last_operation": {
+					"type": "create",
+					"state": "succeeded",
+					"description": null,
+					"created_at": "1970-01-01T00:00:01Z",
+					"updated_at": "1970-01-01T00:00:02Z"
+				},
+				"relationships": {
+					"route": {
+						"data": {
+							"guid": "route-guid"
+						}
+					},
+					"service_instance": {
+						"data": {
+							"guid": "service-instance-guid"
+						}
+					}
+				},
+				"metadata": {
+					"labels": {
+						"label-key": "label-val"
+					},
+					"annotations": {
+						"annotation-key": "annotation-val"
+					}
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/service_route_bindings/binding-guid"
+					},
+					"service_instance": {
+						"href": "https://api.example.org/v3/service_instances/service-instance-guid"
+					},
+					"route": {
+						"href": "https://api.example.org/v3/routes/route-guid"
+					}
+				}
+			}`))
+		})
+
+		When("labels and annotations are nil", func() {
+			BeforeEach(func() {
+				record.Labels = nil
+				record.Annotations = nil
+			})
+
+			It("returns empty maps", func() {
+				Expect(output).To(MatchJSONPath("$.metadata.labels", Not(BeNil())))
+				Expect(output).To(MatchJSONPath("$.metadata.annotations", Not(BeNil())))
+			})
+		})
+	})
+
+	Describe("ForServiceRouteBindingList", func() {
+		JustBeforeEach(func() {
+			requestURL, err := url.Parse("https://api.example.org/v3/service_route_bindings?route_guids=route-guid")
+			Expect(err).NotTo(HaveOccurred())
+			response := presenter.ForServiceRouteBindingList([]repositories.ServiceRouteBindingRecord{record}, *baseURL, *requestURL)
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("returns the list of bindings", func() {
+			Expect(output).To(SatisfyAll(
+				MatchJSONPath("$.pagination.total_results", BeEquivalentTo(1)),
+				MatchJSONPath("$.pagination.first.href", "https://api.example.org/v3/service_route_bindings?route_guids=route-guid"),
+				MatchJSONPath("$.resources[0].guid", "binding-guid"),
+			))
+		})
+	})
+})
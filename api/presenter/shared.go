@@ -52,7 +52,8 @@ type PaginationData struct {
 }
 
 type IncludedData struct {
-	Apps []interface{} `json:"apps"`
+	Apps             []interface{} `json:"apps,omitempty"`
+	ServiceOfferings []interface{} `json:"service_offerings,omitempty"`
 }
 
 type PageRef struct {
@@ -114,6 +115,13 @@ func emptyMapIfNil(m map[string]string) map[string]string {
 	return m
 }
 
+func emptyAnyMapIfNil(m map[string]any) map[string]any {
+	if m == nil {
+		return map[string]any{}
+	}
+	return m
+}
+
 func emptySliceIfNil(m []string) []string {
 	if m == nil {
 		return []string{}
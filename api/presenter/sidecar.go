@@ -0,0 +1,39 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+type SidecarResponse struct {
+	GUID          string        `json:"guid"`
+	Name          string        `json:"name"`
+	Command       string        `json:"command"`
+	ProcessTypes  []string      `json:"process_types"`
+	MemoryInMB    *int64        `json:"memory_in_mb"`
+	Origin        string        `json:"origin"`
+	Relationships Relationships `json:"relationships"`
+	CreatedAt     string        `json:"created_at"`
+	UpdatedAt     string        `json:"updated_at"`
+}
+
+func ForSidecar(record repositories.SidecarRecord, _ url.URL) SidecarResponse {
+	return SidecarResponse{
+		GUID:         record.GUID,
+		Name:         record.Name,
+		Command:      record.Command,
+		ProcessTypes: record.ProcessTypes,
+		MemoryInMB:   record.MemoryMB,
+		Origin:       record.Origin,
+		Relationships: map[string]Relationship{
+			"app": {
+				Data: &RelationshipData{
+					GUID: record.AppGUID,
+				},
+			},
+		},
+		CreatedAt: formatTimestamp(&record.CreatedAt),
+		UpdatedAt: formatTimestamp(record.UpdatedAt),
+	}
+}
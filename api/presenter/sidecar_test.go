@@ -0,0 +1,77 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sidecars", func() {
+	var (
+		baseURL *url.URL
+		record  repositories.SidecarRecord
+		output  []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+
+		record = repositories.SidecarRecord{
+			GUID:         "sidecar-guid",
+			AppGUID:      "app-guid",
+			SpaceGUID:    "space-guid",
+			Name:         "my-sidecar",
+			Command:      "bin/sidecar",
+			ProcessTypes: []string{"web", "worker"},
+			MemoryMB:     tools.PtrTo[int64](64),
+			Origin:       "user",
+			CreatedAt:    time.UnixMilli(1000),
+			UpdatedAt:    tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		output, err = json.Marshal(presenter.ForSidecar(record, *baseURL))
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces the expected sidecar json", func() {
+		Expect(output).To(MatchJSON(`{
+			"guid": "sidecar-guid",
+			"name": "my-sidecar",
+			"command": "bin/sidecar",
+			"process_types": ["web", "worker"],
+			"memory_in_mb": 64,
+			"origin": "user",
+			"relationships": {
+				"app": {
+					"data": {
+						"guid": "app-guid"
+					}
+				}
+			},
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z"
+		}`))
+	})
+
+	When("the memory is not set", func() {
+		BeforeEach(func() {
+			record.MemoryMB = nil
+		})
+
+		It("renders the memory as null", func() {
+			Expect(output).To(MatchJSONPath("$.memory_in_mb", BeNil()))
+		})
+	})
+})
@@ -0,0 +1,85 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const spaceQuotasBase = "/v3/space_quotas"
+
+type SpaceQuotaResponse struct {
+	GUID          string                  `json:"guid"`
+	CreatedAt     string                  `json:"created_at"`
+	UpdatedAt     string                  `json:"updated_at"`
+	Name          string                  `json:"name"`
+	Apps          QuotaAppsResponse       `json:"apps"`
+	Services      QuotaServicesResponse   `json:"services"`
+	Routes        QuotaRoutesResponse     `json:"routes"`
+	Relationships SpaceQuotaRelationships `json:"relationships"`
+	Links         SpaceQuotaLinks         `json:"links"`
+}
+
+type SpaceQuotaRelationships struct {
+	Organization Relationship       `json:"organization"`
+	Spaces       ToManyRelationship `json:"spaces"`
+}
+
+type SpaceQuotaLinks struct {
+	Self         Link `json:"self"`
+	Organization Link `json:"organization"`
+}
+
+type SpaceQuotaSpacesResponse struct {
+	Data  []RelationshipData `json:"data"`
+	Links QuotaLinks         `json:"links"`
+}
+
+func ForSpaceQuota(record repositories.SpaceQuotaRecord, baseURL url.URL) SpaceQuotaResponse {
+	return SpaceQuotaResponse{
+		GUID:      record.GUID,
+		CreatedAt: formatTimestamp(&record.CreatedAt),
+		UpdatedAt: formatTimestamp(record.UpdatedAt),
+		Name:      record.Name,
+		Apps:      QuotaAppsResponse(record.Limits.Apps),
+		Services:  QuotaServicesResponse(record.Limits.Services),
+		Routes:    QuotaRoutesResponse(record.Limits.Routes),
+		Relationships: SpaceQuotaRelationships{
+			Organization: Relationship{Data: &RelationshipData{GUID: record.OrgGUID}},
+			Spaces:       ToManyRelationship{Data: toRelationshipData(record.SpaceGUIDs)},
+		},
+		Links: SpaceQuotaLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(spaceQuotasBase, record.GUID).build(),
+			},
+			Organization: Link{
+				HRef: buildURL(baseURL).appendPath(orgsBase, record.OrgGUID).build(),
+			},
+		},
+	}
+}
+
+func ForSpaceQuotaSpaces(spaceGUIDs []string, spaceQuotaGUID string, baseURL url.URL) SpaceQuotaSpacesResponse {
+	return SpaceQuotaSpacesResponse{
+		Data: toRelationshipData(spaceGUIDs),
+		Links: QuotaLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(spaceQuotasBase, spaceQuotaGUID, "relationships", "spaces").build(),
+			},
+		},
+	}
+}
+
+func ForSpaceUsageSummary(record repositories.UsageSummaryRecord, spaceGUID string, baseURL url.URL) UsageSummaryResponse {
+	return UsageSummaryResponse{
+		UsageSummary: UsageSummary(record),
+		Links: UsageSummaryLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(spacesBase, spaceGUID, "usage_summary").build(),
+			},
+			Space: &Link{
+				HRef: buildURL(baseURL).appendPath(spacesBase, spaceGUID).build(),
+			},
+		},
+	}
+}
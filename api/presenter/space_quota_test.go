@@ -0,0 +1,140 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Space Quotas", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.SpaceQuotaRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+		record = repositories.SpaceQuotaRecord{
+			GUID: "quota-guid",
+			Name: "my-quota",
+			Limits: repositories.QuotaLimits{
+				Apps:     repositories.QuotaAppLimits{PerProcessMemoryInMB: tools.PtrTo[int64](512)},
+				Services: repositories.QuotaServiceLimits{TotalServiceInstances: tools.PtrTo[int64](2)},
+			},
+			OrgGUID:    "org-guid",
+			SpaceGUIDs: []string{"space-1"},
+			CreatedAt:  time.UnixMilli(1000),
+			UpdatedAt:  tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	Describe("ForSpaceQuota", func() {
+		JustBeforeEach(func() {
+			response := presenter.ForSpaceQuota(record, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "quota-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"name": "my-quota",
+				"apps": {
+					"total_memory_in_mb": null,
+					"per_process_memory_in_mb": 512,
+					"total_instances": null,
+					"per_app_tasks": null,
+					"total_apps": null
+				},
+				"services": {
+					"total_service_instances": 2
+				},
+				"routes": {
+					"total_routes": null
+				},
+				"relationships": {
+					"organization": {
+						"data": {"guid": "org-guid"}
+					},
+					"spaces": {
+						"data": [{"guid": "space-1"}]
+					}
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/space_quotas/quota-guid"
+					},
+					"organization": {
+						"href": "https://api.example.org/v3/organizations/org-guid"
+					}
+				}
+			}`))
+		})
+	})
+
+	Describe("ForSpaceQuotaSpaces", func() {
+		JustBeforeEach(func() {
+			response := presenter.ForSpaceQuotaSpaces(record.SpaceGUIDs, record.GUID, *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"data": [{"guid": "space-1"}],
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/space_quotas/quota-guid/relationships/spaces"
+					}
+				}
+			}`))
+		})
+	})
+
+	Describe("ForSpaceUsageSummary", func() {
+		JustBeforeEach(func() {
+			response := presenter.ForSpaceUsageSummary(repositories.UsageSummaryRecord{
+				StartedInstances: 1,
+				MemoryInMB:       256,
+				Apps:             1,
+			}, "space-guid", *baseURL)
+			var err error
+			output, err = json.Marshal(response)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces the expected json", func() {
+			Expect(output).To(MatchJSON(`{
+				"usage_summary": {
+					"started_instances": 1,
+					"memory_in_mb": 256,
+					"apps": 1,
+					"routes": 0,
+					"service_instances": 0
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/spaces/space-guid/usage_summary"
+					},
+					"space": {
+						"href": "https://api.example.org/v3/spaces/space-guid"
+					}
+				}
+			}`))
+		})
+	})
+})
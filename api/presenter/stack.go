@@ -0,0 +1,48 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const stacksBase = "/v3/stacks"
+
+type StackResponse struct {
+	GUID             string     `json:"guid"`
+	CreatedAt        string     `json:"created_at"`
+	UpdatedAt        string     `json:"updated_at"`
+	Name             string     `json:"name"`
+	Description      string     `json:"description"`
+	BuildRootFSImage string     `json:"build_rootfs_image"`
+	RunRootFSImage   string     `json:"run_rootfs_image"`
+	Default          bool       `json:"default"`
+	Metadata         Metadata   `json:"metadata"`
+	Links            StackLinks `json:"links"`
+}
+
+type StackLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForStack(stackRecord repositories.StackRecord, baseURL url.URL) StackResponse {
+	return StackResponse{
+		GUID:             stackRecord.GUID,
+		CreatedAt:        formatTimestamp(&stackRecord.CreatedAt),
+		UpdatedAt:        formatTimestamp(stackRecord.UpdatedAt),
+		Name:             stackRecord.Name,
+		Description:      stackRecord.Description,
+		BuildRootFSImage: stackRecord.BuildRootFSImage,
+		RunRootFSImage:   stackRecord.RunRootFSImage,
+		Default:          stackRecord.Default,
+		Metadata: Metadata{
+			Labels:      map[string]string{},
+			Annotations: map[string]string{},
+		},
+		Links: StackLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(stacksBase, stackRecord.GUID).build(),
+			},
+		},
+	}
+}
@@ -0,0 +1,67 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Stacks", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+		record  repositories.StackRecord
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+
+		record = repositories.StackRecord{
+			GUID:             "stack-guid",
+			Name:             "io.buildpacks.stacks.jammy",
+			Description:      "the jammy stack",
+			BuildRootFSImage: "jammy-build",
+			RunRootFSImage:   "jammy-run",
+			Default:          true,
+			CreatedAt:        time.UnixMilli(1000),
+			UpdatedAt:        tools.PtrTo(time.UnixMilli(2000)),
+		}
+	})
+
+	JustBeforeEach(func() {
+		response := presenter.ForStack(record, *baseURL)
+		var err error
+		output, err = json.Marshal(response)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	It("produces expected stack json", func() {
+		Expect(output).To(MatchJSON(`{
+			"guid": "stack-guid",
+			"created_at": "1970-01-01T00:00:01Z",
+			"updated_at": "1970-01-01T00:00:02Z",
+			"name": "io.buildpacks.stacks.jammy",
+			"description": "the jammy stack",
+			"build_rootfs_image": "jammy-build",
+			"run_rootfs_image": "jammy-run",
+			"default": true,
+			"metadata": {
+				"labels": {},
+				"annotations": {}
+			},
+			"links": {
+				"self": {
+					"href": "https://api.example.org/v3/stacks/stack-guid"
+				}
+			}
+		}`))
+	})
+})
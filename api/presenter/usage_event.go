@@ -0,0 +1,133 @@
+package presenter
+
+import (
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/repositories"
+)
+
+const (
+	appUsageEventsBase     = "/v3/app_usage_events"
+	serviceUsageEventsBase = "/v3/service_usage_events"
+)
+
+type AppUsageEventResponse struct {
+	GUID                  string                      `json:"guid"`
+	CreatedAt             string                      `json:"created_at"`
+	UpdatedAt             string                      `json:"updated_at"`
+	State                 UsageEventChange[string]    `json:"state"`
+	App                   UsageEventResourceResponse  `json:"app"`
+	Process               UsageEventProcessResponse   `json:"process"`
+	Space                 UsageEventResourceResponse  `json:"space"`
+	Organization          RelationshipData            `json:"organization"`
+	Buildpack             *UsageEventResourceResponse `json:"buildpack"`
+	Task                  *UsageEventResourceResponse `json:"task"`
+	MemoryInMBPerInstance UsageEventChange[int64]     `json:"memory_in_mb_per_instance"`
+	InstanceCount         UsageEventChange[int32]     `json:"instance_count"`
+	Links                 UsageEventLinks             `json:"links"`
+}
+
+type ServiceUsageEventResponse struct {
+	GUID            string                            `json:"guid"`
+	CreatedAt       string                            `json:"created_at"`
+	UpdatedAt       string                            `json:"updated_at"`
+	State           string                            `json:"state"`
+	Space           UsageEventResourceResponse        `json:"space"`
+	Organization    RelationshipData                  `json:"organization"`
+	ServiceInstance UsageEventServiceInstanceResponse `json:"service_instance"`
+	ServicePlan     *UsageEventResourceResponse       `json:"service_plan"`
+	ServiceOffering *UsageEventResourceResponse       `json:"service_offering"`
+	ServiceBroker   *UsageEventResourceResponse       `json:"service_broker"`
+	Links           UsageEventLinks                   `json:"links"`
+}
+
+// UsageEventChange holds the value after and before the event, the previous
+// value is null when the event does not follow a previous one
+type UsageEventChange[T any] struct {
+	Current  T  `json:"current"`
+	Previous *T `json:"previous"`
+}
+
+type UsageEventResourceResponse struct {
+	GUID string `json:"guid"`
+	Name string `json:"name"`
+}
+
+type UsageEventProcessResponse struct {
+	GUID string `json:"guid"`
+	Type string `json:"type"`
+}
+
+type UsageEventServiceInstanceResponse struct {
+	GUID string `json:"guid"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type UsageEventLinks struct {
+	Self Link `json:"self"`
+}
+
+func ForAppUsageEvent(record repositories.AppUsageEventRecord, baseURL url.URL) AppUsageEventResponse {
+	response := AppUsageEventResponse{
+		GUID:      record.GUID,
+		CreatedAt: formatTimestamp(&record.CreatedAt),
+		UpdatedAt: formatTimestamp(record.UpdatedAt),
+		State:     UsageEventChange[string]{Current: record.State},
+		App:       UsageEventResourceResponse(record.App),
+		Process: UsageEventProcessResponse{
+			GUID: record.Process.GUID,
+			Type: record.Process.Name,
+		},
+		Space:                 UsageEventResourceResponse(record.Space),
+		Organization:          RelationshipData{GUID: record.OrganizationGUID},
+		MemoryInMBPerInstance: UsageEventChange[int64]{Current: record.MemoryInMBPerInstance},
+		InstanceCount:         UsageEventChange[int32]{Current: record.InstanceCount},
+		Links: UsageEventLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(appUsageEventsBase, record.GUID).build(),
+			},
+		},
+	}
+
+	if record.PreviousState != "" {
+		response.State.Previous = &record.PreviousState
+		response.MemoryInMBPerInstance.Previous = &record.PreviousMemoryInMBPerInstance
+		response.InstanceCount.Previous = &record.PreviousInstanceCount
+	}
+
+	return response
+}
+
+func ForServiceUsageEvent(record repositories.ServiceUsageEventRecord, baseURL url.URL) ServiceUsageEventResponse {
+	return ServiceUsageEventResponse{
+		GUID:         record.GUID,
+		CreatedAt:    formatTimestamp(&record.CreatedAt),
+		UpdatedAt:    formatTimestamp(record.UpdatedAt),
+		State:        record.State,
+		Space:        UsageEventResourceResponse(record.Space),
+		Organization: RelationshipData{GUID: record.OrganizationGUID},
+		ServiceInstance: UsageEventServiceInstanceResponse{
+			GUID: record.ServiceInstance.GUID,
+			Name: record.ServiceInstance.Name,
+			Type: record.ServiceInstanceType,
+		},
+		ServicePlan:     usageEventResource(record.ServicePlan),
+		ServiceOffering: usageEventResource(record.ServiceOffering),
+		ServiceBroker:   usageEventResource(record.ServiceBroker),
+		Links: UsageEventLinks{
+			Self: Link{
+				HRef: buildURL(baseURL).appendPath(serviceUsageEventsBase, record.GUID).build(),
+			},
+		},
+	}
+}
+
+func usageEventResource(record *repositories.UsageEventResourceRecord) *UsageEventResourceResponse {
+	if record == nil {
+		return nil
+	}
+
+	response := UsageEventResourceResponse(*record)
+	return &response
+}
@@ -0,0 +1,188 @@
+package presenter_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/presenter"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	. "code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Usage Events", func() {
+	var (
+		baseURL *url.URL
+		output  []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+		baseURL, err = url.Parse("https://api.example.org")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("ForAppUsageEvent", func() {
+		var record repositories.AppUsageEventRecord
+
+		BeforeEach(func() {
+			record = repositories.AppUsageEventRecord{
+				GUID:                          "app-usage-event-guid",
+				State:                         "SCALED",
+				PreviousState:                 "STARTED",
+				App:                           repositories.UsageEventResourceRecord{GUID: "app-guid", Name: "my-app"},
+				Process:                       repositories.UsageEventResourceRecord{GUID: "process-guid", Name: "web"},
+				Space:                         repositories.UsageEventResourceRecord{GUID: "space-guid", Name: "my-space"},
+				OrganizationGUID:              "org-guid",
+				InstanceCount:                 3,
+				PreviousInstanceCount:         1,
+				MemoryInMBPerInstance:         512,
+				PreviousMemoryInMBPerInstance: 256,
+				CreatedAt:                     time.UnixMilli(1000),
+				UpdatedAt:                     tools.PtrTo(time.UnixMilli(2000)),
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForAppUsageEvent(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces expected app usage event json", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "app-usage-event-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"state": {
+					"current": "SCALED",
+					"previous": "STARTED"
+				},
+				"app": {
+					"guid": "app-guid",
+					"name": "my-app"
+				},
+				"process": {
+					"guid": "process-guid",
+					"type": "web"
+				},
+				"space": {
+					"guid": "space-guid",
+					"name": "my-space"
+				},
+				"organization": {
+					"guid": "org-guid"
+				},
+				"buildpack": null,
+				"task": null,
+				"memory_in_mb_per_instance": {
+					"current": 512,
+					"previous": 256
+				},
+				"instance_count": {
+					"current": 3,
+					"previous": 1
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/app_usage_events/app-usage-event-guid"
+					}
+				}
+			}`))
+		})
+
+		When("the event has no previous state", func() {
+			BeforeEach(func() {
+				record.PreviousState = ""
+			})
+
+			It("presents the previous values as null", func() {
+				Expect(output).To(MatchJSONPath("$.state.previous", BeNil()))
+				Expect(output).To(MatchJSONPath("$.memory_in_mb_per_instance.previous", BeNil()))
+				Expect(output).To(MatchJSONPath("$.instance_count.previous", BeNil()))
+			})
+		})
+	})
+
+	Describe("ForServiceUsageEvent", func() {
+		var record repositories.ServiceUsageEventRecord
+
+		BeforeEach(func() {
+			record = repositories.ServiceUsageEventRecord{
+				GUID:                "service-usage-event-guid",
+				State:               "CREATED",
+				ServiceInstance:     repositories.UsageEventResourceRecord{GUID: "instance-guid", Name: "my-instance"},
+				ServiceInstanceType: "managed_service_instance",
+				ServicePlan:         &repositories.UsageEventResourceRecord{GUID: "plan-guid", Name: "small"},
+				ServiceOffering:     &repositories.UsageEventResourceRecord{GUID: "offering-guid", Name: "my-offering"},
+				ServiceBroker:       &repositories.UsageEventResourceRecord{GUID: "broker-guid", Name: "my-broker"},
+				Space:               repositories.UsageEventResourceRecord{GUID: "space-guid", Name: "my-space"},
+				OrganizationGUID:    "org-guid",
+				CreatedAt:           time.UnixMilli(1000),
+				UpdatedAt:           tools.PtrTo(time.UnixMilli(2000)),
+			}
+		})
+
+		JustBeforeEach(func() {
+			var err error
+			output, err = json.Marshal(presenter.ForServiceUsageEvent(record, *baseURL))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("produces expected service usage event json", func() {
+			Expect(output).To(MatchJSON(`{
+				"guid": "service-usage-event-guid",
+				"created_at": "1970-01-01T00:00:01Z",
+				"updated_at": "1970-01-01T00:00:02Z",
+				"state": "CREATED",
+				"space": {
+					"guid": "space-guid",
+					"name": "my-space"
+				},
+				"organization": {
+					"guid": "org-guid"
+				},
+				"service_instance": {
+					"guid": "instance-guid",
+					"name": "my-instance",
+					"type": "managed_service_instance"
+				},
+				"service_plan": {
+					"guid": "plan-guid",
+					"name": "small"
+				},
+				"service_offering": {
+					"guid": "offering-guid",
+					"name": "my-offering"
+				},
+				"service_broker": {
+					"guid": "broker-guid",
+					"name": "my-broker"
+				},
+				"links": {
+					"self": {
+						"href": "https://api.example.org/v3/service_usage_events/service-usage-event-guid"
+					}
+				}
+			}`))
+		})
+
+		When("the service instance is user-provided", func() {
+			BeforeEach(func() {
+				record.ServiceInstanceType = "user_provided_service_instance"
+				record.ServicePlan = nil
+				record.ServiceOffering = nil
+				record.ServiceBroker = nil
+			})
+
+			It("presents the catalog as null", func() {
+				Expect(output).To(MatchJSONPath("$.service_plan", BeNil()))
+				Expect(output).To(MatchJSONPath("$.service_offering", BeNil()))
+				Expect(output).To(MatchJSONPath("$.service_broker", BeNil()))
+			})
+		})
+	})
+})
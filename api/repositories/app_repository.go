@@ -35,6 +35,10 @@ const (
 	CFAppGUIDLabel     string = "korifi.cloudfoundry.org/app-guid"
 	AppResourceType    string = "App"
 	AppEnvResourceType string = "App Env"
+
+	FeatureResourceType = "Feature"
+	AppFeatureSSH       = "ssh"
+	AppFeatureRevisions = "revisions"
 )
 
 type AppRepo struct {
@@ -73,6 +77,8 @@ type AppRecord struct {
 	UpdatedAt             *time.Time
 	DeletedAt             *time.Time
 	IsStaged              bool
+	SSHEnabled            bool
+	RevisionsEnabled      bool
 	envSecretName         string
 	vcapServiceSecretName string
 	vcapAppSecretName     string
@@ -167,6 +173,13 @@ type SetAppDesiredStateMessage struct {
 	DesiredState string
 }
 
+type PatchAppFeaturesMessage struct {
+	AppGUID          string
+	SpaceGUID        string
+	SSHEnabled       *bool
+	RevisionsEnabled *bool
+}
+
 type ListAppsMessage struct {
 	Names      []string
 	Guids      []string
@@ -456,6 +469,34 @@ func (f *AppRepo) SetAppDesiredState(ctx context.Context, authInfo authorization
 	return cfAppToAppRecord(*cfApp), nil
 }
 
+func (f *AppRepo) PatchAppFeatures(ctx context.Context, authInfo authorization.Info, message PatchAppFeaturesMessage) (AppRecord, error) {
+	userClient, err := f.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return AppRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfApp := &korifiv1alpha1.CFApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      message.AppGUID,
+			Namespace: message.SpaceGUID,
+		},
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfApp, func() {
+		if message.SSHEnabled != nil {
+			cfApp.Spec.EnableSSH = message.SSHEnabled
+		}
+		if message.RevisionsEnabled != nil {
+			cfApp.Spec.EnableRevisions = message.RevisionsEnabled
+		}
+	})
+	if err != nil {
+		return AppRecord{}, fmt.Errorf("failed to patch app features: %w", apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	return cfAppToAppRecord(*cfApp), nil
+}
+
 func (f *AppRepo) DeleteApp(ctx context.Context, authInfo authorization.Info, message DeleteAppMessage) error {
 	cfApp := &korifiv1alpha1.CFApp{
 		ObjectMeta: metav1.ObjectMeta{
@@ -652,6 +693,8 @@ func cfAppToAppRecord(cfApp korifiv1alpha1.CFApp) AppRecord {
 		UpdatedAt:             getLastUpdatedTime(&cfApp),
 		DeletedAt:             golangTime(cfApp.DeletionTimestamp),
 		IsStaged:              meta.IsStatusConditionTrue(cfApp.Status.Conditions, shared.StatusConditionReady),
+		SSHEnabled:            cfApp.SSHEnabled(),
+		RevisionsEnabled:      cfApp.RevisionsEnabled(),
 		envSecretName:         cfApp.Spec.EnvSecretName,
 		vcapServiceSecretName: cfApp.Status.VCAPServicesSecretName,
 		vcapAppSecretName:     cfApp.Status.VCAPApplicationSecretName,
@@ -1182,6 +1182,79 @@ var _ = Describe("AppRepository", func() {
 		})
 	})
 
+	Describe("PatchAppFeatures", func() {
+		var (
+			message     PatchAppFeaturesMessage
+			returnedApp AppRecord
+			returnedErr error
+		)
+
+		BeforeEach(func() {
+			message = PatchAppFeaturesMessage{
+				AppGUID:          cfApp.Name,
+				SpaceGUID:        cfSpace.Name,
+				SSHEnabled:       tools.PtrTo(false),
+				RevisionsEnabled: tools.PtrTo(true),
+			}
+		})
+
+		JustBeforeEach(func() {
+			returnedApp, returnedErr = appRepo.PatchAppFeatures(testCtx, authInfo, message)
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns the updated app record", func() {
+				Expect(returnedErr).NotTo(HaveOccurred())
+				Expect(returnedApp.GUID).To(Equal(cfApp.Name))
+				Expect(returnedApp.SSHEnabled).To(BeFalse())
+				Expect(returnedApp.RevisionsEnabled).To(BeTrue())
+			})
+
+			It("updates the CFApp", func() {
+				Expect(returnedErr).NotTo(HaveOccurred())
+				updatedApp := new(korifiv1alpha1.CFApp)
+				Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(cfApp), updatedApp)).To(Succeed())
+				Expect(updatedApp.Spec.EnableSSH).To(PointTo(BeFalse()))
+				Expect(updatedApp.Spec.EnableRevisions).To(PointTo(BeTrue()))
+			})
+
+			When("a feature is not specified", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(testCtx, k8sClient, cfApp, func() {
+						cfApp.Spec.EnableRevisions = tools.PtrTo(false)
+					})).To(Succeed())
+					message.RevisionsEnabled = nil
+				})
+
+				It("leaves it unchanged", func() {
+					Expect(returnedErr).NotTo(HaveOccurred())
+					Expect(returnedApp.SSHEnabled).To(BeFalse())
+					Expect(returnedApp.RevisionsEnabled).To(BeFalse())
+				})
+			})
+
+			When("the app does not exist", func() {
+				BeforeEach(func() {
+					message.AppGUID = "no-such-app"
+				})
+
+				It("returns a not found error", func() {
+					Expect(returnedErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+
+		When("the user is not authorized in the space", func() {
+			It("returns a forbidden error", func() {
+				Expect(returnedErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
 	Describe("DeleteApp", func() {
 		var (
 			appGUID      string
@@ -1544,7 +1617,7 @@ func generateVcapServiceSecretDataByte() (map[string][]byte, error) {
 		InstanceName: "myupsi",
 		BindingGUID:  "73f68d28-4602-47a3-8110-74ca991d5032",
 		BindingName:  nil,
-		Credentials: map[string]string{
+		Credentials: map[string]any{
 			"foo": "bar",
 		},
 		SyslogDrainURL: nil,
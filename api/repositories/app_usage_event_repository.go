@@ -0,0 +1,195 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const AppUsageEventResourceType = "App Usage Event"
+
+type AppUsageEventRepo struct {
+	usageEventRepo
+}
+
+func NewAppUsageEventRepo(
+	userClientFactory authorization.UserK8sClientFactory,
+	namespacePermissions *authorization.NamespacePermissions,
+	rootNamespace string,
+) *AppUsageEventRepo {
+	return &AppUsageEventRepo{
+		usageEventRepo: usageEventRepo{
+			userClientFactory:    userClientFactory,
+			namespacePermissions: namespacePermissions,
+			rootNamespace:        rootNamespace,
+		},
+	}
+}
+
+type AppUsageEventRecord struct {
+	GUID                          string
+	State                         string
+	PreviousState                 string
+	App                           UsageEventResourceRecord
+	Process                       UsageEventResourceRecord
+	Space                         UsageEventResourceRecord
+	OrganizationGUID              string
+	InstanceCount                 int32
+	PreviousInstanceCount         int32
+	MemoryInMBPerInstance         int64
+	PreviousMemoryInMBPerInstance int64
+	CreatedAt                     time.Time
+	UpdatedAt                     *time.Time
+}
+
+type ListAppUsageEventsMessage struct {
+	AfterGUID string
+	GUIDs     []string
+}
+
+func (r *AppUsageEventRepo) GetAppUsageEvent(ctx context.Context, authInfo authorization.Info, guid string) (AppUsageEventRecord, error) {
+	cfAppUsageEvent := new(korifiv1alpha1.CFAppUsageEvent)
+	if err := r.getUsageEvent(ctx, authInfo, guid, cfAppUsageEvent, AppUsageEventResourceType); err != nil {
+		return AppUsageEventRecord{}, err
+	}
+
+	return cfAppUsageEventToRecord(*cfAppUsageEvent), nil
+}
+
+// ListAppUsageEvents lists the events in the order in which they occurred,
+// starting after the AfterGUID event when set
+func (r *AppUsageEventRepo) ListAppUsageEvents(ctx context.Context, authInfo authorization.Info, message ListAppUsageEventsMessage) ([]AppUsageEventRecord, error) {
+	cfAppUsageEventList := new(korifiv1alpha1.CFAppUsageEventList)
+	if err := r.listUsageEvents(ctx, authInfo, cfAppUsageEventList, AppUsageEventResourceType); err != nil {
+		return []AppUsageEventRecord{}, err
+	}
+
+	cfAppUsageEvents, err := filterUsageEvents(
+		cfAppUsageEventList.Items,
+		func(e korifiv1alpha1.CFAppUsageEvent) string { return e.Name },
+		func(e korifiv1alpha1.CFAppUsageEvent) metav1.MicroTime { return e.Spec.Timestamp },
+		message.AfterGUID,
+		message.GUIDs,
+	)
+	if err != nil {
+		return []AppUsageEventRecord{}, err
+	}
+
+	records := []AppUsageEventRecord{}
+	for _, cfAppUsageEvent := range cfAppUsageEvents {
+		records = append(records, cfAppUsageEventToRecord(cfAppUsageEvent))
+	}
+
+	return records, nil
+}
+
+// PurgeAndReseedAppUsageEvents deletes all app usage events and records a
+// STARTED event for each process of the apps that are currently started
+func (r *AppUsageEventRepo) PurgeAndReseedAppUsageEvents(ctx context.Context, authInfo authorization.Info) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	spaces, err := r.purgeUsageEvents(ctx, authInfo, userClient, new(korifiv1alpha1.CFAppUsageEvent), AppUsageEventResourceType)
+	if err != nil {
+		return err
+	}
+
+	for _, space := range spaces {
+		cfAppList := new(korifiv1alpha1.CFAppList)
+		err = userClient.List(ctx, cfAppList, client.InNamespace(space.Name))
+		if k8serrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list apps in namespace %s: %w", space.Name, apierrors.FromK8sError(err, AppResourceType))
+		}
+
+		for _, cfApp := range cfAppList.Items {
+			if cfApp.Spec.DesiredState != korifiv1alpha1.StartedState {
+				continue
+			}
+
+			err = r.reseedApp(ctx, userClient, space, cfApp)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *AppUsageEventRepo) reseedApp(ctx context.Context, userClient client.Client, space korifiv1alpha1.CFSpace, cfApp korifiv1alpha1.CFApp) error {
+	cfProcessList := new(korifiv1alpha1.CFProcessList)
+	err := userClient.List(ctx, cfProcessList, client.InNamespace(cfApp.Namespace), client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name})
+	if err != nil {
+		return fmt.Errorf("failed to list processes of app %s: %w", cfApp.Name, apierrors.FromK8sError(err, ProcessResourceType))
+	}
+
+	for _, cfProcess := range cfProcessList.Items {
+		var instances int32
+		if cfProcess.Spec.DesiredInstances != nil {
+			instances = int32(*cfProcess.Spec.DesiredInstances)
+		}
+
+		err = userClient.Create(ctx, &korifiv1alpha1.CFAppUsageEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: r.rootNamespace,
+			},
+			Spec: korifiv1alpha1.CFAppUsageEventSpec{
+				State: korifiv1alpha1.AppUsageEventStateStarted,
+				App: korifiv1alpha1.UsageEventResource{
+					GUID: cfApp.Name,
+					Name: cfApp.Spec.DisplayName,
+				},
+				Process: korifiv1alpha1.UsageEventResource{
+					GUID: cfProcess.Name,
+					Name: cfProcess.Spec.ProcessType,
+				},
+				Space: korifiv1alpha1.UsageEventResource{
+					GUID: space.Name,
+					Name: space.Spec.DisplayName,
+				},
+				OrganizationGUID:      space.Namespace,
+				InstanceCount:         instances,
+				MemoryInMBPerInstance: cfProcess.Spec.MemoryMB,
+				Timestamp:             metav1.NowMicro(),
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create app usage event: %w", apierrors.FromK8sError(err, AppUsageEventResourceType))
+		}
+	}
+
+	return nil
+}
+
+func cfAppUsageEventToRecord(cfAppUsageEvent korifiv1alpha1.CFAppUsageEvent) AppUsageEventRecord {
+	return AppUsageEventRecord{
+		GUID:                          cfAppUsageEvent.Name,
+		State:                         cfAppUsageEvent.Spec.State,
+		PreviousState:                 cfAppUsageEvent.Spec.PreviousState,
+		App:                           UsageEventResourceRecord(cfAppUsageEvent.Spec.App),
+		Process:                       UsageEventResourceRecord(cfAppUsageEvent.Spec.Process),
+		Space:                         UsageEventResourceRecord(cfAppUsageEvent.Spec.Space),
+		OrganizationGUID:              cfAppUsageEvent.Spec.OrganizationGUID,
+		InstanceCount:                 cfAppUsageEvent.Spec.InstanceCount,
+		PreviousInstanceCount:         cfAppUsageEvent.Spec.PreviousInstanceCount,
+		MemoryInMBPerInstance:         cfAppUsageEvent.Spec.MemoryInMBPerInstance,
+		PreviousMemoryInMBPerInstance: cfAppUsageEvent.Spec.PreviousMemoryInMBPerInstance,
+		CreatedAt:                     cfAppUsageEvent.Spec.Timestamp.Time,
+		UpdatedAt:                     getLastUpdatedTime(&cfAppUsageEvent),
+	}
+}
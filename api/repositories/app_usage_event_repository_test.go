@@ -0,0 +1,227 @@
+package repositories_test
+
+import (
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("AppUsageEventRepo", func() {
+	var (
+		repo      *AppUsageEventRepo
+		timestamp time.Time
+	)
+
+	createAppUsageEvent := func(guid, state string, offset time.Duration) {
+		Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFAppUsageEvent{
+			ObjectMeta: metav1.ObjectMeta{Name: guid, Namespace: rootNamespace},
+			Spec: korifiv1alpha1.CFAppUsageEventSpec{
+				State:                 state,
+				App:                   korifiv1alpha1.UsageEventResource{GUID: "app-guid", Name: "my-app"},
+				Process:               korifiv1alpha1.UsageEventResource{GUID: "process-guid", Name: "web"},
+				Space:                 korifiv1alpha1.UsageEventResource{GUID: "space-guid", Name: "my-space"},
+				OrganizationGUID:      "org-guid",
+				InstanceCount:         2,
+				MemoryInMBPerInstance: 256,
+				Timestamp:             metav1.NewMicroTime(timestamp.Add(offset)),
+			},
+		})).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		repo = NewAppUsageEventRepo(userClientFactory, nsPerms, rootNamespace)
+		timestamp = time.Now().Truncate(time.Microsecond)
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.DeleteAllOf(ctx, &korifiv1alpha1.CFAppUsageEvent{}, client.InNamespace(rootNamespace))).To(Succeed())
+	})
+
+	Describe("GetAppUsageEvent", func() {
+		var (
+			record AppUsageEventRecord
+			getErr error
+		)
+
+		BeforeEach(func() {
+			createAppUsageEvent("event-guid", korifiv1alpha1.AppUsageEventStateStarted, 0)
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = repo.GetAppUsageEvent(ctx, authInfo, "event-guid")
+		})
+
+		It("fails because the user is not a CF admin", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the event", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record).To(MatchFields(IgnoreExtras, Fields{
+					"GUID":                  Equal("event-guid"),
+					"State":                 Equal(korifiv1alpha1.AppUsageEventStateStarted),
+					"App":                   Equal(UsageEventResourceRecord{GUID: "app-guid", Name: "my-app"}),
+					"Process":               Equal(UsageEventResourceRecord{GUID: "process-guid", Name: "web"}),
+					"Space":                 Equal(UsageEventResourceRecord{GUID: "space-guid", Name: "my-space"}),
+					"OrganizationGUID":      Equal("org-guid"),
+					"InstanceCount":         BeEquivalentTo(2),
+					"MemoryInMBPerInstance": BeEquivalentTo(256),
+					"CreatedAt":             BeTemporally("==", timestamp),
+				}))
+			})
+
+			When("the event does not exist", func() {
+				JustBeforeEach(func() {
+					_, getErr = repo.GetAppUsageEvent(ctx, authInfo, "i-do-not-exist")
+				})
+
+				It("returns a not found error", func() {
+					Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ListAppUsageEvents", func() {
+		var (
+			message ListAppUsageEventsMessage
+			records []AppUsageEventRecord
+			listErr error
+		)
+
+		BeforeEach(func() {
+			message = ListAppUsageEventsMessage{}
+			createAppUsageEvent("event-2", korifiv1alpha1.AppUsageEventStateStopped, time.Second)
+			createAppUsageEvent("event-1", korifiv1alpha1.AppUsageEventStateStarted, 0)
+			createAppUsageEvent("event-3", korifiv1alpha1.AppUsageEventStateStarted, 2*time.Second)
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = repo.ListAppUsageEvents(ctx, authInfo, message)
+		})
+
+		It("fails because the user is not a CF admin", func() {
+			Expect(listErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the events in the order in which they occurred", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(HaveLen(3))
+				Expect(records[0].GUID).To(Equal("event-1"))
+				Expect(records[1].GUID).To(Equal("event-2"))
+				Expect(records[2].GUID).To(Equal("event-3"))
+			})
+
+			When("after_guid is specified", func() {
+				BeforeEach(func() {
+					message.AfterGUID = "event-1"
+				})
+
+				It("returns the events following that event", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(HaveLen(2))
+					Expect(records[0].GUID).To(Equal("event-2"))
+					Expect(records[1].GUID).To(Equal("event-3"))
+				})
+			})
+
+			When("after_guid does not match an event", func() {
+				BeforeEach(func() {
+					message.AfterGUID = "i-do-not-exist"
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(listErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
+			When("guids are specified", func() {
+				BeforeEach(func() {
+					message.GUIDs = []string{"event-1", "event-3"}
+				})
+
+				It("returns the matching events", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(HaveLen(2))
+					Expect(records[0].GUID).To(Equal("event-1"))
+					Expect(records[1].GUID).To(Equal("event-3"))
+				})
+			})
+		})
+	})
+
+	Describe("PurgeAndReseedAppUsageEvents", func() {
+		var (
+			cfOrg      *korifiv1alpha1.CFOrg
+			cfSpace    *korifiv1alpha1.CFSpace
+			startedApp *korifiv1alpha1.CFApp
+			process    *korifiv1alpha1.CFProcess
+			purgeErr   error
+		)
+
+		BeforeEach(func() {
+			cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+			cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space"))
+
+			startedApp = createAppCR(ctx, k8sClient, "started-app", prefixedGUID("app"), cfSpace.Name, string(korifiv1alpha1.StartedState))
+			process = createProcessCR(ctx, k8sClient, prefixedGUID("process"), cfSpace.Name, startedApp.Name)
+			stoppedApp := createAppCR(ctx, k8sClient, "stopped-app", prefixedGUID("app"), cfSpace.Name, string(korifiv1alpha1.StoppedState))
+			createProcessCR(ctx, k8sClient, prefixedGUID("process"), cfSpace.Name, stoppedApp.Name)
+
+			createAppUsageEvent("old-event", korifiv1alpha1.AppUsageEventStateStopped, 0)
+		})
+
+		JustBeforeEach(func() {
+			purgeErr = repo.PurgeAndReseedAppUsageEvents(ctx, authInfo)
+		})
+
+		It("fails because the user is not a CF admin", func() {
+			Expect(purgeErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+				createRoleBinding(ctx, userName, adminRole.Name, cfSpace.Name)
+			})
+
+			It("replaces the events with a STARTED event for each process of the started apps", func() {
+				Expect(purgeErr).NotTo(HaveOccurred())
+
+				cfAppUsageEvents := new(korifiv1alpha1.CFAppUsageEventList)
+				Expect(k8sClient.List(ctx, cfAppUsageEvents, client.InNamespace(rootNamespace))).To(Succeed())
+				Expect(cfAppUsageEvents.Items).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"State":                 Equal(korifiv1alpha1.AppUsageEventStateStarted),
+						"App":                   Equal(korifiv1alpha1.UsageEventResource{GUID: startedApp.Name, Name: "started-app"}),
+						"Process":               Equal(korifiv1alpha1.UsageEventResource{GUID: process.Name, Name: "web"}),
+						"Space":                 Equal(korifiv1alpha1.UsageEventResource{GUID: cfSpace.Name, Name: cfSpace.Spec.DisplayName}),
+						"OrganizationGUID":      Equal(cfOrg.Name),
+						"InstanceCount":         BeEquivalentTo(1),
+						"MemoryInMBPerInstance": BeEquivalentTo(500),
+					}),
+				})))
+			})
+		})
+	})
+})
@@ -0,0 +1,246 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfauditevents,verbs=create
+
+const AuditEventResourceType = "Audit Event"
+
+type AuditEventRepo struct {
+	userClientFactory    authorization.UserK8sClientFactory
+	namespaceRetriever   NamespaceRetriever
+	namespacePermissions *authorization.NamespacePermissions
+	privilegedClient     client.Client
+	identityProvider     authorization.IdentityProvider
+}
+
+func NewAuditEventRepo(
+	userClientFactory authorization.UserK8sClientFactory,
+	namespaceRetriever NamespaceRetriever,
+	namespacePermissions *authorization.NamespacePermissions,
+	privilegedClient client.Client,
+	identityProvider authorization.IdentityProvider,
+) *AuditEventRepo {
+	return &AuditEventRepo{
+		userClientFactory:    userClientFactory,
+		namespaceRetriever:   namespaceRetriever,
+		namespacePermissions: namespacePermissions,
+		privilegedClient:     privilegedClient,
+		identityProvider:     identityProvider,
+	}
+}
+
+type AuditEventParticipantRecord struct {
+	GUID string
+	Type string
+	Name string
+}
+
+type AuditEventRecord struct {
+	GUID             string
+	Type             string
+	Actor            AuditEventParticipantRecord
+	Target           AuditEventParticipantRecord
+	SpaceGUID        string
+	OrganizationGUID string
+	Data             map[string]string
+	CreatedAt        time.Time
+	UpdatedAt        *time.Time
+}
+
+type RecordAuditEventMessage struct {
+	Type       string
+	TargetGUID string
+	TargetType string
+	TargetName string
+	SpaceGUID  string
+	Data       map[string]string
+}
+
+// TimestampFilter selects timestamps matching all of its conditions
+type TimestampFilter struct {
+	Values             []time.Time
+	LessThan           *time.Time
+	LessThanOrEqual    *time.Time
+	GreaterThan        *time.Time
+	GreaterThanOrEqual *time.Time
+}
+
+func (f TimestampFilter) Matches(t time.Time) bool {
+	if len(f.Values) > 0 {
+		found := false
+		for _, value := range f.Values {
+			if t.Equal(value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return (f.LessThan == nil || t.Before(*f.LessThan)) &&
+		(f.LessThanOrEqual == nil || !t.After(*f.LessThanOrEqual)) &&
+		(f.GreaterThan == nil || t.After(*f.GreaterThan)) &&
+		(f.GreaterThanOrEqual == nil || !t.Before(*f.GreaterThanOrEqual))
+}
+
+type ListAuditEventsMessage struct {
+	TargetGUIDs []string
+	Types       []string
+	SpaceGUIDs  []string
+	CreatedAts  TimestampFilter
+}
+
+// RecordAuditEvent records an event caused by the user in the namespace of
+// the space of the target. Events are created with the privileged client, as
+// users must not be able to forge them
+func (r *AuditEventRepo) RecordAuditEvent(ctx context.Context, authInfo authorization.Info, message RecordAuditEventMessage) (AuditEventRecord, error) {
+	identity, err := r.identityProvider.GetIdentity(ctx, authInfo)
+	if err != nil {
+		return AuditEventRecord{}, fmt.Errorf("failed to get identity: %w", err)
+	}
+
+	orgGUID, err := r.namespaceRetriever.NamespaceFor(ctx, message.SpaceGUID, SpaceResourceType)
+	if err != nil {
+		return AuditEventRecord{}, fmt.Errorf("failed to get namespace for space: %w", err)
+	}
+
+	cfAuditEvent := &korifiv1alpha1.CFAuditEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: message.SpaceGUID,
+			Labels: map[string]string{
+				korifiv1alpha1.CFAuditEventTypeLabelKey:       message.Type,
+				korifiv1alpha1.CFAuditEventTargetGUIDLabelKey: message.TargetGUID,
+			},
+		},
+		Spec: korifiv1alpha1.CFAuditEventSpec{
+			Type: message.Type,
+			Actor: korifiv1alpha1.CFAuditEventParticipant{
+				GUID: identity.Name,
+				Type: korifiv1alpha1.AuditEventActorTypeUser,
+				Name: identity.Name,
+			},
+			Target: korifiv1alpha1.CFAuditEventParticipant{
+				GUID: message.TargetGUID,
+				Type: message.TargetType,
+				Name: message.TargetName,
+			},
+			SpaceGUID:        message.SpaceGUID,
+			OrganizationGUID: orgGUID,
+			Data:             message.Data,
+		},
+	}
+
+	err = r.privilegedClient.Create(ctx, cfAuditEvent)
+	if err != nil {
+		return AuditEventRecord{}, fmt.Errorf("failed to create audit event: %w", apierrors.FromK8sError(err, AuditEventResourceType))
+	}
+
+	return cfAuditEventToRecord(*cfAuditEvent), nil
+}
+
+func (r *AuditEventRepo) GetAuditEvent(ctx context.Context, authInfo authorization.Info, guid string) (AuditEventRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, guid, AuditEventResourceType)
+	if err != nil {
+		return AuditEventRecord{}, fmt.Errorf("failed to get namespace for audit event: %w", err)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return AuditEventRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfAuditEvent := new(korifiv1alpha1.CFAuditEvent)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: guid}, cfAuditEvent)
+	if err != nil {
+		return AuditEventRecord{}, fmt.Errorf("failed to get audit event %q: %w", guid, apierrors.FromK8sError(err, AuditEventResourceType))
+	}
+
+	return cfAuditEventToRecord(*cfAuditEvent), nil
+}
+
+func (r *AuditEventRepo) ListAuditEvents(ctx context.Context, authInfo authorization.Info, message ListAuditEventsMessage) ([]AuditEventRecord, error) {
+	nsList, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []AuditEventRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	preds := []func(korifiv1alpha1.CFAuditEvent) bool{
+		SetPredicate(message.TargetGUIDs, func(e korifiv1alpha1.CFAuditEvent) string { return e.Spec.Target.GUID }),
+		SetPredicate(message.Types, func(e korifiv1alpha1.CFAuditEvent) string { return e.Spec.Type }),
+		func(e korifiv1alpha1.CFAuditEvent) bool { return message.CreatedAts.Matches(e.CreationTimestamp.Time) },
+	}
+
+	filteredAuditEvents := []korifiv1alpha1.CFAuditEvent{}
+	spaceGUIDSet := NewSet(message.SpaceGUIDs...)
+	for ns := range nsList {
+		if len(spaceGUIDSet) > 0 && !spaceGUIDSet.Includes(ns) {
+			continue
+		}
+
+		cfAuditEventList := new(korifiv1alpha1.CFAuditEventList)
+		err := userClient.List(ctx, cfAuditEventList, client.InNamespace(ns))
+		if k8serrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return []AuditEventRecord{}, fmt.Errorf("failed to list audit events in namespace %s: %w", ns, apierrors.FromK8sError(err, AuditEventResourceType))
+		}
+		filteredAuditEvents = append(filteredAuditEvents, Filter(cfAuditEventList.Items, preds...)...)
+	}
+
+	records := make([]AuditEventRecord, 0, len(filteredAuditEvents))
+	for _, cfAuditEvent := range filteredAuditEvents {
+		records = append(records, cfAuditEventToRecord(cfAuditEvent))
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].CreatedAt.Before(records[j].CreatedAt)
+	})
+
+	return records, nil
+}
+
+func cfAuditEventToRecord(cfAuditEvent korifiv1alpha1.CFAuditEvent) AuditEventRecord {
+	return AuditEventRecord{
+		GUID: cfAuditEvent.Name,
+		Type: cfAuditEvent.Spec.Type,
+		Actor: AuditEventParticipantRecord{
+			GUID: cfAuditEvent.Spec.Actor.GUID,
+			Type: cfAuditEvent.Spec.Actor.Type,
+			Name: cfAuditEvent.Spec.Actor.Name,
+		},
+		Target: AuditEventParticipantRecord{
+			GUID: cfAuditEvent.Spec.Target.GUID,
+			Type: cfAuditEvent.Spec.Target.Type,
+			Name: cfAuditEvent.Spec.Target.Name,
+		},
+		SpaceGUID:        cfAuditEvent.Spec.SpaceGUID,
+		OrganizationGUID: cfAuditEvent.Spec.OrganizationGUID,
+		Data:             cfAuditEvent.Spec.Data,
+		CreatedAt:        cfAuditEvent.CreationTimestamp.Time,
+		UpdatedAt:        getLastUpdatedTime(&cfAuditEvent),
+	}
+}
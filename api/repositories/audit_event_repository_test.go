@@ -0,0 +1,223 @@
+package repositories_test
+
+import (
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("AuditEventRepository", func() {
+	var (
+		auditEventRepo *AuditEventRepo
+		org            *korifiv1alpha1.CFOrg
+		space          *korifiv1alpha1.CFSpace
+	)
+
+	BeforeEach(func() {
+		auditEventRepo = NewAuditEventRepo(userClientFactory, namespaceRetriever, nsPerms, k8sClient, idProvider)
+
+		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+	})
+
+	Describe("RecordAuditEvent", func() {
+		var (
+			auditEventRecord AuditEventRecord
+			recordErr        error
+		)
+
+		JustBeforeEach(func() {
+			auditEventRecord, recordErr = auditEventRepo.RecordAuditEvent(ctx, authInfo, RecordAuditEventMessage{
+				Type:       korifiv1alpha1.AuditEventTypeAppCreate,
+				TargetGUID: "app-guid",
+				TargetType: korifiv1alpha1.AuditEventTargetTypeApp,
+				TargetName: "my-app",
+				SpaceGUID:  space.Name,
+				Data:       map[string]string{"foo": "bar"},
+			})
+		})
+
+		It("records the event in the space namespace on behalf of the user", func() {
+			Expect(recordErr).NotTo(HaveOccurred())
+			Expect(auditEventRecord).To(MatchFields(IgnoreExtras, Fields{
+				"GUID":             Not(BeEmpty()),
+				"Type":             Equal("audit.app.create"),
+				"Actor":            Equal(AuditEventParticipantRecord{GUID: userName, Type: "user", Name: userName}),
+				"Target":           Equal(AuditEventParticipantRecord{GUID: "app-guid", Type: "app", Name: "my-app"}),
+				"SpaceGUID":        Equal(space.Name),
+				"OrganizationGUID": Equal(org.Name),
+				"Data":             Equal(map[string]string{"foo": "bar"}),
+				"CreatedAt":        BeTemporally("~", time.Now(), timeCheckThreshold),
+			}))
+
+			cfAuditEvent := new(korifiv1alpha1.CFAuditEvent)
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: space.Name, Name: auditEventRecord.GUID}, cfAuditEvent)).To(Succeed())
+			Expect(cfAuditEvent.Labels).To(SatisfyAll(
+				HaveKeyWithValue(korifiv1alpha1.CFAuditEventTypeLabelKey, "audit.app.create"),
+				HaveKeyWithValue(korifiv1alpha1.CFAuditEventTargetGUIDLabelKey, "app-guid"),
+			))
+		})
+
+		When("the space does not exist", func() {
+			BeforeEach(func() {
+				space = &korifiv1alpha1.CFSpace{ObjectMeta: metav1.ObjectMeta{Name: "not-a-space"}}
+			})
+
+			It("returns an error", func() {
+				Expect(recordErr).To(MatchError(ContainSubstring("failed to get namespace for space")))
+			})
+		})
+	})
+
+	Describe("ListAuditEvents and GetAuditEvent", func() {
+		var (
+			otherSpace     *korifiv1alpha1.CFSpace
+			appCreateEvent *korifiv1alpha1.CFAuditEvent
+			routeMapEvent  *korifiv1alpha1.CFAuditEvent
+			otherEvent     *korifiv1alpha1.CFAuditEvent
+		)
+
+		createAuditEvent := func(namespace, eventType, targetGUID string) *korifiv1alpha1.CFAuditEvent {
+			cfAuditEvent := &korifiv1alpha1.CFAuditEvent{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      prefixedGUID("audit-event"),
+				},
+				Spec: korifiv1alpha1.CFAuditEventSpec{
+					Type:      eventType,
+					Actor:     korifiv1alpha1.CFAuditEventParticipant{GUID: "some-user", Type: "user"},
+					Target:    korifiv1alpha1.CFAuditEventParticipant{GUID: targetGUID, Type: "app"},
+					SpaceGUID: namespace,
+				},
+			}
+			Expect(k8sClient.Create(ctx, cfAuditEvent)).To(Succeed())
+			return cfAuditEvent
+		}
+
+		BeforeEach(func() {
+			otherSpace = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("other-space"))
+
+			appCreateEvent = createAuditEvent(space.Name, korifiv1alpha1.AuditEventTypeAppCreate, "app-guid")
+			routeMapEvent = createAuditEvent(space.Name, korifiv1alpha1.AuditEventTypeRouteMap, "route-guid")
+			otherEvent = createAuditEvent(otherSpace.Name, korifiv1alpha1.AuditEventTypeAppCreate, "other-app-guid")
+		})
+
+		It("returns not found errors when the user has no access to the audit event", func() {
+			_, err := auditEventRepo.GetAuditEvent(ctx, authInfo, appCreateEvent.Name)
+			Expect(err).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		It("lists no audit events when the user has no space roles", func() {
+			records, err := auditEventRepo.ListAuditEvents(ctx, authInfo, ListAuditEventsMessage{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(BeEmpty())
+		})
+
+		When("the user is a space developer in both spaces", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, otherSpace.Name)
+			})
+
+			It("gets the audit event", func() {
+				record, err := auditEventRepo.GetAuditEvent(ctx, authInfo, appCreateEvent.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(record.GUID).To(Equal(appCreateEvent.Name))
+				Expect(record.Type).To(Equal("audit.app.create"))
+				Expect(record.Target.GUID).To(Equal("app-guid"))
+			})
+
+			It("returns a not found error for unknown audit events", func() {
+				_, err := auditEventRepo.GetAuditEvent(ctx, authInfo, "not-an-event")
+				Expect(err).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+
+			It("lists all audit events", func() {
+				records, err := auditEventRepo.ListAuditEvents(ctx, authInfo, ListAuditEventsMessage{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(ConsistOf(
+					HaveField("GUID", appCreateEvent.Name),
+					HaveField("GUID", routeMapEvent.Name),
+					HaveField("GUID", otherEvent.Name),
+				))
+			})
+
+			It("filters by target guids", func() {
+				records, err := auditEventRepo.ListAuditEvents(ctx, authInfo, ListAuditEventsMessage{TargetGUIDs: []string{"route-guid"}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(ConsistOf(HaveField("GUID", routeMapEvent.Name)))
+			})
+
+			It("filters by types", func() {
+				records, err := auditEventRepo.ListAuditEvents(ctx, authInfo, ListAuditEventsMessage{Types: []string{"audit.app.create"}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(ConsistOf(
+					HaveField("GUID", appCreateEvent.Name),
+					HaveField("GUID", otherEvent.Name),
+				))
+			})
+
+			It("filters by space guids", func() {
+				records, err := auditEventRepo.ListAuditEvents(ctx, authInfo, ListAuditEventsMessage{SpaceGUIDs: []string{otherSpace.Name}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(ConsistOf(HaveField("GUID", otherEvent.Name)))
+			})
+
+			It("filters by creation time", func() {
+				future := time.Now().Add(time.Hour)
+				records, err := auditEventRepo.ListAuditEvents(ctx, authInfo, ListAuditEventsMessage{
+					CreatedAts: TimestampFilter{GreaterThan: &future},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(BeEmpty())
+
+				records, err = auditEventRepo.ListAuditEvents(ctx, authInfo, ListAuditEventsMessage{
+					CreatedAts: TimestampFilter{LessThan: &future},
+				})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(records).To(HaveLen(3))
+			})
+		})
+	})
+})
+
+var _ = Describe("TimestampFilter", func() {
+	var (
+		now    time.Time
+		before time.Time
+		after  time.Time
+	)
+
+	BeforeEach(func() {
+		now = time.Now().Truncate(time.Second)
+		before = now.Add(-time.Minute)
+		after = now.Add(time.Minute)
+	})
+
+	It("matches everything when empty", func() {
+		Expect(TimestampFilter{}.Matches(now)).To(BeTrue())
+	})
+
+	It("matches the listed values", func() {
+		Expect(TimestampFilter{Values: []time.Time{before, now}}.Matches(now)).To(BeTrue())
+		Expect(TimestampFilter{Values: []time.Time{before, after}}.Matches(now)).To(BeFalse())
+	})
+
+	It("applies the comparison operators", func() {
+		Expect(TimestampFilter{LessThan: &after}.Matches(now)).To(BeTrue())
+		Expect(TimestampFilter{LessThan: &now}.Matches(now)).To(BeFalse())
+		Expect(TimestampFilter{LessThanOrEqual: &now}.Matches(now)).To(BeTrue())
+		Expect(TimestampFilter{GreaterThan: &before}.Matches(now)).To(BeTrue())
+		Expect(TimestampFilter{GreaterThan: &now}.Matches(now)).To(BeFalse())
+		Expect(TimestampFilter{GreaterThanOrEqual: &now}.Matches(now)).To(BeTrue())
+		Expect(TimestampFilter{GreaterThan: &before, LessThan: &before}.Matches(now)).To(BeFalse())
+	})
+})
@@ -41,27 +41,43 @@ func NewBuildpackRepository(builderName string, userClientFactory authorization.
 }
 
 func (r *BuildpackRepository) ListBuildpacks(ctx context.Context, authInfo authorization.Info) ([]BuildpackRecord, error) {
+	builderInfo, err := getReadyBuilderInfo(ctx, r.userClientFactory, authInfo, r.rootNamespace, r.builderName, BuildpackResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	return builderInfoToBuildpackRecords(builderInfo), nil
+}
+
+func getReadyBuilderInfo(
+	ctx context.Context,
+	userClientFactory authorization.UserK8sClientFactory,
+	authInfo authorization.Info,
+	rootNamespace string,
+	builderName string,
+	resourceType string,
+) (v1alpha1.BuilderInfo, error) {
 	var builderInfo v1alpha1.BuilderInfo
 
-	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	userClient, err := userClientFactory.BuildClient(authInfo)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build user client: %w", err)
+		return v1alpha1.BuilderInfo{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
 	err = userClient.Get(
 		ctx,
 		types.NamespacedName{
-			Namespace: r.rootNamespace,
-			Name:      r.builderName,
+			Namespace: rootNamespace,
+			Name:      builderName,
 		},
 		&builderInfo,
 	)
 	if err != nil {
 		if errors.IsNotFound(err) {
-			return nil, apierrors.NewResourceNotReadyError(fmt.Errorf("BuilderInfo %q not found in namespace %q", r.builderName, r.rootNamespace))
+			return v1alpha1.BuilderInfo{}, apierrors.NewResourceNotReadyError(fmt.Errorf("BuilderInfo %q not found in namespace %q", builderName, rootNamespace))
 		}
 
-		return nil, apierrors.FromK8sError(err, BuildpackResourceType)
+		return v1alpha1.BuilderInfo{}, apierrors.FromK8sError(err, resourceType)
 	}
 
 	if !meta.IsStatusConditionTrue(builderInfo.Status.Conditions, StatusConditionReady) {
@@ -76,10 +92,10 @@ func (r *BuildpackRepository) ListBuildpacks(ctx context.Context, authInfo autho
 			conditionNotReadyMessage = "resource not reconciled"
 		}
 
-		return nil, apierrors.NewResourceNotReadyError(fmt.Errorf("BuilderInfo %q not ready: %s", r.builderName, conditionNotReadyMessage))
+		return v1alpha1.BuilderInfo{}, apierrors.NewResourceNotReadyError(fmt.Errorf("BuilderInfo %q not ready: %s", builderName, conditionNotReadyMessage))
 	}
 
-	return builderInfoToBuildpackRecords(builderInfo), nil
+	return builderInfo, nil
 }
 
 func builderInfoToBuildpackRecords(info v1alpha1.BuilderInfo) []BuildpackRecord {
@@ -53,8 +53,9 @@ type DeploymentStatus struct {
 }
 
 type CreateDeploymentMessage struct {
-	AppGUID     string
-	DropletGUID string
+	AppGUID      string
+	DropletGUID  string
+	RevisionGUID string
 }
 
 func NewDeploymentRepo(
@@ -108,10 +109,37 @@ func (r *DeploymentRepo) CreateDeployment(ctx context.Context, authInfo authoriz
 		return DeploymentRecord{}, err
 	}
 
+	latestRevision, err := getLatestRevision(ctx, userClient, app)
+	if err != nil {
+		return DeploymentRecord{}, err
+	}
+
+	description := ""
+	if latestRevision == nil {
+		description = RevisionDescriptionInitial
+	}
+
 	dropletGUID := app.Spec.CurrentDropletRef.Name
 	if message.DropletGUID != "" {
 		dropletGUID = message.DropletGUID
 	}
+	if dropletGUID != app.Spec.CurrentDropletRef.Name && description == "" {
+		description = RevisionDescriptionNewDroplet
+	}
+
+	if message.RevisionGUID != "" {
+		rollbackRevision, rollbackErr := getRollbackRevision(ctx, userClient, app, message.RevisionGUID)
+		if rollbackErr != nil {
+			return DeploymentRecord{}, rollbackErr
+		}
+
+		if rollbackErr = restoreRevision(ctx, userClient, app, rollbackRevision); rollbackErr != nil {
+			return DeploymentRecord{}, rollbackErr
+		}
+
+		dropletGUID = rollbackRevision.Spec.DropletRef.Name
+		description = fmt.Sprintf(RevisionDescriptionRollback, rollbackRevision.Spec.Version)
+	}
 
 	appRev := app.Annotations[korifiv1alpha1.CFAppRevisionKey]
 	newRev, err := bumpAppRev(appRev)
@@ -131,6 +159,25 @@ func (r *DeploymentRepo) CreateDeployment(ctx context.Context, authInfo authoriz
 		return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
 	}
 
+	if app.RevisionsEnabled() {
+		version := 1
+		if latestRevision != nil {
+			version = latestRevision.Spec.Version + 1
+		}
+
+		cfRevision, revisionErr := createRevision(ctx, userClient, app, version, description)
+		if revisionErr != nil {
+			return DeploymentRecord{}, revisionErr
+		}
+
+		err = k8s.PatchResource(ctx, userClient, app, func() {
+			app.Annotations[korifiv1alpha1.CFAppDeployedRevisionKey] = cfRevision.Name
+		})
+		if err != nil {
+			return DeploymentRecord{}, apierrors.FromK8sError(err, DeploymentResourceType)
+		}
+	}
+
 	return appToDeploymentRecord(app), nil
 }
 
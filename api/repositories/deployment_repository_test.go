@@ -8,8 +8,10 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 	"code.cloudfoundry.org/korifi/version"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -210,6 +212,210 @@ var _ = Describe("DeploymentRepository", func() {
 				})
 			})
 
+			It("snapshots the app as a new revision", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				cfRevisions := new(korifiv1alpha1.CFRevisionList)
+				Expect(k8sClient.List(ctx, cfRevisions, client.InNamespace(cfApp.Namespace), client.MatchingLabels{
+					korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+				})).To(Succeed())
+				Expect(cfRevisions.Items).To(ConsistOf(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+					"Spec": gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+						"Version":     Equal(1),
+						"DropletRef":  Equal(cfApp.Spec.CurrentDropletRef),
+						"Description": Equal(repositories.RevisionDescriptionInitial),
+					}),
+				})))
+			})
+
+			It("records the new revision as the deployed one on the app", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				cfRevisions := new(korifiv1alpha1.CFRevisionList)
+				Expect(k8sClient.List(ctx, cfRevisions, client.InNamespace(cfApp.Namespace), client.MatchingLabels{
+					korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+				})).To(Succeed())
+				Expect(cfRevisions.Items).To(HaveLen(1))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+				Expect(cfApp.Annotations).To(HaveKeyWithValue(korifiv1alpha1.CFAppDeployedRevisionKey, cfRevisions.Items[0].Name))
+			})
+
+			When("the app has been stopped since its previous deployment", func() {
+				BeforeEach(func() {
+					// stopping the app bumps its app-rev
+					Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+						cfApp.Annotations[CFAppRevisionKey] = "5"
+					})).To(Succeed())
+				})
+
+				It("numbers the revision after the latest one", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					cfRevisions := new(korifiv1alpha1.CFRevisionList)
+					Expect(k8sClient.List(ctx, cfRevisions, client.InNamespace(cfApp.Namespace), client.MatchingLabels{
+						korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+					})).To(Succeed())
+					Expect(cfRevisions.Items).To(ConsistOf(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+						"Spec": gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+							"Version": Equal(1),
+						}),
+					})))
+				})
+			})
+
+			When("revisions are disabled for the app", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+						cfApp.Spec.EnableRevisions = tools.PtrTo(false)
+					})).To(Succeed())
+				})
+
+				It("does not record a revision", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					cfRevisions := new(korifiv1alpha1.CFRevisionList)
+					Expect(k8sClient.List(ctx, cfRevisions, client.InNamespace(cfApp.Namespace), client.MatchingLabels{
+						korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+					})).To(Succeed())
+					Expect(cfRevisions.Items).To(BeEmpty())
+				})
+			})
+
+			When("a revision guid is set on the create message", func() {
+				var (
+					cfProcess          *korifiv1alpha1.CFProcess
+					workerProcess      *korifiv1alpha1.CFProcess
+					appEnvSecret       *corev1.Secret
+					previousRevision   *korifiv1alpha1.CFRevision
+					previousDropletRef corev1.LocalObjectReference
+				)
+
+				BeforeEach(func() {
+					cfProcess = createProcessCR(ctx, k8sClient, generateGUID(), cfSpace.Name, cfApp.Name)
+					Expect(k8s.Patch(ctx, k8sClient, cfProcess, func() {
+						cfProcess.Spec.Command = "new command"
+					})).To(Succeed())
+
+					workerProcess = createProcessCR(ctx, k8sClient, generateGUID(), cfSpace.Name, cfApp.Name)
+					Expect(k8s.Patch(ctx, k8sClient, workerProcess, func() {
+						workerProcess.Spec.ProcessType = "worker"
+						workerProcess.Spec.Command = "new worker command"
+					})).To(Succeed())
+
+					appEnvSecret = &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      cfApp.Spec.EnvSecretName,
+							Namespace: cfApp.Namespace,
+						},
+						StringData: map[string]string{"FOO": "new"},
+					}
+					Expect(k8sClient.Create(ctx, appEnvSecret)).To(Succeed())
+
+					revisionEnvSecret := &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      generateGUID(),
+							Namespace: cfApp.Namespace,
+						},
+						StringData: map[string]string{"FOO": "old"},
+					}
+					Expect(k8sClient.Create(ctx, revisionEnvSecret)).To(Succeed())
+
+					previousDropletRef = corev1.LocalObjectReference{Name: generateGUID()}
+					previousDroplet := createDropletCR(ctx, k8sClient, previousDropletRef.Name, cfApp.Name, cfApp.Namespace)
+					Expect(k8s.Patch(ctx, k8sClient, previousDroplet, func() {
+						previousDroplet.Spec.Droplet = &korifiv1alpha1.BuildDropletStatus{
+							Stack: "cflinuxfs3",
+							ProcessTypes: []korifiv1alpha1.ProcessType{
+								{Type: "web", Command: "droplet command"},
+								{Type: "worker", Command: "droplet worker command"},
+							},
+							Ports: []int32{},
+						}
+					})).To(Succeed())
+
+					previousRevision = &korifiv1alpha1.CFRevision{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      generateGUID(),
+							Namespace: cfApp.Namespace,
+							Labels: map[string]string{
+								korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+							},
+						},
+						Spec: korifiv1alpha1.CFRevisionSpec{
+							AppRef:     corev1.LocalObjectReference{Name: cfApp.Name},
+							Version:    1,
+							DropletRef: previousDropletRef,
+							Processes: []korifiv1alpha1.CFRevisionProcess{
+								{Type: "web", Command: "old command", UserProvided: true},
+								{Type: "worker", Command: "droplet worker command"},
+							},
+							EnvSecretName: revisionEnvSecret.Name,
+						},
+					}
+					Expect(k8sClient.Create(ctx, previousRevision)).To(Succeed())
+
+					createDeploymentMessage.RevisionGUID = previousRevision.Name
+				})
+
+				It("deploys the droplet of the revision", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					Expect(cfApp.Spec.CurrentDropletRef).To(Equal(previousDropletRef))
+				})
+
+				It("restores the environment variables of the revision", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(appEnvSecret), appEnvSecret)).To(Succeed())
+					Expect(appEnvSecret.Data).To(HaveKeyWithValue("FOO", []byte("old")))
+				})
+
+				It("restores the process commands of the revision", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+					Expect(cfProcess.Spec.Command).To(Equal("old command"))
+				})
+
+				It("clears the process commands the user had not set in the revision", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(workerProcess), workerProcess)).To(Succeed())
+					Expect(workerProcess.Spec.Command).To(BeEmpty())
+				})
+
+				It("records the rollback as a new revision", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					cfRevisions := new(korifiv1alpha1.CFRevisionList)
+					Expect(k8sClient.List(ctx, cfRevisions, client.InNamespace(cfApp.Namespace), client.MatchingLabels{
+						korifiv1alpha1.CFRevisionVersionLabelKey: "2",
+					})).To(Succeed())
+					Expect(cfRevisions.Items).To(ConsistOf(gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+						"Spec": gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{
+							"DropletRef": Equal(previousDropletRef),
+							"Processes": ConsistOf(
+								korifiv1alpha1.CFRevisionProcess{Type: "web", Command: "old command", UserProvided: true},
+								korifiv1alpha1.CFRevisionProcess{Type: "worker", Command: "droplet worker command"},
+							),
+							"Description": Equal("Rolled back to revision 1."),
+						}),
+					})))
+				})
+
+				When("the revision does not exist", func() {
+					BeforeEach(func() {
+						createDeploymentMessage.RevisionGUID = "i-do-not-exist"
+					})
+
+					It("returns an unprocessable entity error", func() {
+						Expect(createErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					})
+				})
+			})
+
 			When("the app does not exist", func() {
 				BeforeEach(func() {
 					createDeploymentMessage.AppGUID = "i-do-not-exist"
@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"sort"
 	"time"
 
 	"code.cloudfoundry.org/korifi/tools/k8s"
@@ -11,7 +12,10 @@ import (
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -20,23 +24,34 @@ import (
 
 const (
 	DropletResourceType = "Droplet"
+
+	DropletStateAwaitingUpload   = "AWAITING_UPLOAD"
+	DropletStateProcessingUpload = "PROCESSING_UPLOAD"
+	DropletStateStaged           = "STAGED"
+	DropletStateFailed           = "FAILED"
 )
 
 type DropletRepo struct {
 	userClientFactory    authorization.UserK8sClientFactory
 	namespaceRetriever   NamespaceRetriever
 	namespacePermissions *authorization.NamespacePermissions
+	repositoryCreator    RepositoryCreator
+	repositoryPrefix     string
 }
 
 func NewDropletRepo(
 	userClientFactory authorization.UserK8sClientFactory,
 	namespaceRetriever NamespaceRetriever,
 	namespacePermissions *authorization.NamespacePermissions,
+	repositoryCreator RepositoryCreator,
+	repositoryPrefix string,
 ) *DropletRepo {
 	return &DropletRepo{
 		userClientFactory:    userClientFactory,
 		namespaceRetriever:   namespaceRetriever,
 		namespacePermissions: namespacePermissions,
+		repositoryCreator:    repositoryCreator,
+		repositoryPrefix:     repositoryPrefix,
 	}
 }
 
@@ -50,22 +65,200 @@ type DropletRecord struct {
 	Stack           string
 	ProcessTypes    map[string]string
 	AppGUID         string
+	SpaceGUID       string
 	PackageGUID     string
 	Labels          map[string]string
 	Annotations     map[string]string
+	// Image is the image of the staged droplet
+	Image string
+	// ImageRef is the repository droplet images are uploaded to
+	ImageRef string
 }
 
 type ListDropletsMessage struct {
+	GUIDs        []string
+	AppGUIDs     []string
+	SpaceGUIDs   []string
+	States       []string
 	PackageGUIDs []string
 }
 
+type CreateDropletMessage struct {
+	AppGUID      string
+	SpaceGUID    string
+	Lifecycle    Lifecycle
+	ProcessTypes map[string]string
+	Metadata     Metadata
+}
+
+type CopyDropletMessage struct {
+	SourceGUID string
+	AppGUID    string
+	SpaceGUID  string
+}
+
+type DeleteDropletMessage struct {
+	GUID      string
+	SpaceGUID string
+}
+
+type UpdateDropletSourceMessage struct {
+	GUID                string
+	SpaceGUID           string
+	ImageRef            string
+	RegistrySecretNames []string
+}
+
 func (r *DropletRepo) GetDroplet(ctx context.Context, authInfo authorization.Info, dropletGUID string) (DropletRecord, error) {
 	build, _, err := r.getBuildAssociatedWithDroplet(ctx, authInfo, dropletGUID)
 	if err != nil {
 		return DropletRecord{}, err
 	}
 
-	return returnDroplet(*build)
+	return r.returnDroplet(*build)
+}
+
+// CreateDroplet creates a droplet that is staged once its image is uploaded
+func (r *DropletRepo) CreateDroplet(ctx context.Context, authInfo authorization.Info, message CreateDropletMessage) (DropletRecord, error) {
+	processTypes := []korifiv1alpha1.ProcessType{}
+	for processType, command := range message.ProcessTypes {
+		processTypes = append(processTypes, korifiv1alpha1.ProcessType{Type: processType, Command: command})
+	}
+	sort.Slice(processTypes, func(i, j int) bool { return processTypes[i].Type < processTypes[j].Type })
+
+	lifecycle := korifiv1alpha1.Lifecycle{
+		Type: korifiv1alpha1.LifecycleType(message.Lifecycle.Type),
+		Data: korifiv1alpha1.LifecycleData{
+			Buildpacks: message.Lifecycle.Data.Buildpacks,
+			Stack:      message.Lifecycle.Data.Stack,
+		},
+	}
+	cfBuild := newDropletBuild(message.AppGUID, message.SpaceGUID, lifecycle, korifiv1alpha1.BuildDropletStatus{
+		Stack:        message.Lifecycle.Data.Stack,
+		ProcessTypes: processTypes,
+		Ports:        []int32{},
+	})
+	cfBuild.Labels = message.Metadata.Labels
+	cfBuild.Annotations = message.Metadata.Annotations
+
+	return r.createDropletBuild(ctx, authInfo, cfBuild)
+}
+
+// CopyDroplet creates a droplet for another app with the process types, ports
+// and stack of the source droplet. The copy is staged once its image is set.
+func (r *DropletRepo) CopyDroplet(ctx context.Context, authInfo authorization.Info, message CopyDropletMessage) (DropletRecord, error) {
+	sourceBuild, _, err := r.getBuildAssociatedWithDroplet(ctx, authInfo, message.SourceGUID)
+	if err != nil {
+		return DropletRecord{}, err
+	}
+
+	if sourceBuild.Status.Droplet == nil {
+		return DropletRecord{}, apierrors.NewUnprocessableEntityError(nil, "Source droplet is not staged.")
+	}
+
+	droplet := sourceBuild.Status.Droplet.DeepCopy()
+	droplet.Registry = korifiv1alpha1.Registry{}
+
+	return r.createDropletBuild(ctx, authInfo, newDropletBuild(message.AppGUID, message.SpaceGUID, sourceBuild.Spec.Lifecycle, *droplet))
+}
+
+func newDropletBuild(appGUID, spaceGUID string, lifecycle korifiv1alpha1.Lifecycle, droplet korifiv1alpha1.BuildDropletStatus) *korifiv1alpha1.CFBuild {
+	return &korifiv1alpha1.CFBuild{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: spaceGUID,
+		},
+		Spec: korifiv1alpha1.CFBuildSpec{
+			AppRef: corev1.LocalObjectReference{
+				Name: appGUID,
+			},
+			Lifecycle: lifecycle,
+			Droplet:   &droplet,
+		},
+	}
+}
+
+func (r *DropletRepo) createDropletBuild(ctx context.Context, authInfo authorization.Info, cfBuild *korifiv1alpha1.CFBuild) (DropletRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return DropletRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	if err = userClient.Create(ctx, cfBuild); err != nil {
+		return DropletRecord{}, apierrors.FromK8sError(err, DropletResourceType)
+	}
+
+	err = r.repositoryCreator.CreateRepository(ctx, r.repositoryRef(cfBuild.Spec.AppRef.Name))
+	if err != nil {
+		return DropletRecord{}, fmt.Errorf("failed to create droplet repository: %w", err)
+	}
+
+	return r.cfBuildToDropletRecord(*cfBuild), nil
+}
+
+func (r *DropletRepo) DeleteDroplet(ctx context.Context, authInfo authorization.Info, message DeleteDropletMessage) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	err = userClient.Delete(ctx, &korifiv1alpha1.CFBuild{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      message.GUID,
+			Namespace: message.SpaceGUID,
+		},
+	})
+
+	return apierrors.FromK8sError(err, DropletResourceType)
+}
+
+// UpdateDropletSource sets the uploaded image of a droplet. The droplet is
+// staged asynchronously, see GetState.
+func (r *DropletRepo) UpdateDropletSource(ctx context.Context, authInfo authorization.Info, message UpdateDropletSourceMessage) (DropletRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return DropletRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfBuild := new(korifiv1alpha1.CFBuild)
+	if err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.GUID}, cfBuild); err != nil {
+		return DropletRecord{}, fmt.Errorf("failed to get droplet: %w", apierrors.FromK8sError(err, DropletResourceType))
+	}
+
+	if cfBuild.Spec.Droplet == nil {
+		return DropletRecord{}, apierrors.NewUnprocessableEntityError(nil, "Only droplets created via the droplets endpoint can be uploaded.")
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfBuild, func() {
+		cfBuild.Spec.Droplet.Registry.Image = message.ImageRef
+		imagePullSecrets := []corev1.LocalObjectReference{}
+		for _, secret := range message.RegistrySecretNames {
+			imagePullSecrets = append(imagePullSecrets, corev1.LocalObjectReference{Name: secret})
+		}
+		cfBuild.Spec.Droplet.Registry.ImagePullSecrets = imagePullSecrets
+	})
+	if err != nil {
+		return DropletRecord{}, fmt.Errorf("failed to update droplet source: %w", apierrors.FromK8sError(err, DropletResourceType))
+	}
+
+	return r.cfBuildToDropletRecord(*cfBuild), nil
+}
+
+// GetState reports whether an uploaded droplet has been staged
+func (r *DropletRepo) GetState(ctx context.Context, authInfo authorization.Info, dropletGUID string) (ResourceState, error) {
+	build, _, err := r.getBuildAssociatedWithDroplet(ctx, authInfo, dropletGUID)
+	if err != nil {
+		return ResourceState{}, err
+	}
+
+	switch getConditionValue(&build.Status.Conditions, SucceededConditionType) {
+	case metav1.ConditionTrue:
+		return ResourceState{Status: ResourceStatusReady}, nil
+	case metav1.ConditionFalse:
+		return ResourceState{Status: ResourceStatusFailed, Details: meta.FindStatusCondition(build.Status.Conditions, SucceededConditionType).Message}, nil
+	default:
+		return ResourceState{Status: ResourceStatusProcessing}, nil
+	}
 }
 
 func (r *DropletRepo) getBuildAssociatedWithDroplet(ctx context.Context, authInfo authorization.Info, dropletGUID string) (*korifiv1alpha1.CFBuild, client.WithWatch, error) {
@@ -88,26 +281,58 @@ func (r *DropletRepo) getBuildAssociatedWithDroplet(ctx context.Context, authInf
 	return &build, userClient, nil
 }
 
-func returnDroplet(cfBuild korifiv1alpha1.CFBuild) (DropletRecord, error) {
+func (r *DropletRepo) returnDroplet(cfBuild korifiv1alpha1.CFBuild) (DropletRecord, error) {
+	if !isDroplet(cfBuild) {
+		return DropletRecord{}, apierrors.NewNotFoundError(nil, DropletResourceType)
+	}
+	return r.cfBuildToDropletRecord(cfBuild), nil
+}
+
+// isDroplet tells whether the build has a droplet, i.e. whether it has been
+// staged or it has been created with a droplet to upload
+func isDroplet(cfBuild korifiv1alpha1.CFBuild) bool {
+	if cfBuild.Spec.Droplet != nil {
+		return true
+	}
+
 	stagingStatus := getConditionValue(&cfBuild.Status.Conditions, StagingConditionType)
 	succeededStatus := getConditionValue(&cfBuild.Status.Conditions, SucceededConditionType)
-	if stagingStatus == metav1.ConditionFalse &&
-		succeededStatus == metav1.ConditionTrue {
-		return cfBuildToDropletRecord(cfBuild), nil
+	return stagingStatus == metav1.ConditionFalse && succeededStatus == metav1.ConditionTrue
+}
+
+func dropletState(cfBuild korifiv1alpha1.CFBuild) string {
+	switch getConditionValue(&cfBuild.Status.Conditions, SucceededConditionType) {
+	case metav1.ConditionTrue:
+		return DropletStateStaged
+	case metav1.ConditionFalse:
+		return DropletStateFailed
+	}
+
+	if cfBuild.Spec.Droplet != nil && cfBuild.Spec.Droplet.Registry.Image == "" {
+		return DropletStateAwaitingUpload
 	}
-	return DropletRecord{}, apierrors.NewNotFoundError(nil, DropletResourceType)
+
+	return DropletStateProcessingUpload
 }
 
-func cfBuildToDropletRecord(cfBuild korifiv1alpha1.CFBuild) DropletRecord {
+func (r *DropletRepo) cfBuildToDropletRecord(cfBuild korifiv1alpha1.CFBuild) DropletRecord {
+	droplet := cfBuild.Status.Droplet
+	if droplet == nil {
+		droplet = cfBuild.Spec.Droplet
+	}
+	if droplet == nil {
+		droplet = &korifiv1alpha1.BuildDropletStatus{}
+	}
+
 	processTypesMap := make(map[string]string)
-	processTypesArrayObject := cfBuild.Status.Droplet.ProcessTypes
+	processTypesArrayObject := droplet.ProcessTypes
 	for index := range processTypesArrayObject {
 		processTypesMap[processTypesArrayObject[index].Type] = processTypesArrayObject[index].Command
 	}
 
-	return DropletRecord{
+	record := DropletRecord{
 		GUID:      cfBuild.Name,
-		State:     "STAGED",
+		State:     dropletState(cfBuild),
 		CreatedAt: cfBuild.CreationTimestamp.Time,
 		UpdatedAt: getLastUpdatedTime(&cfBuild),
 		Lifecycle: Lifecycle{
@@ -117,13 +342,26 @@ func cfBuildToDropletRecord(cfBuild korifiv1alpha1.CFBuild) DropletRecord {
 				Stack:      cfBuild.Spec.Lifecycle.Data.Stack,
 			},
 		},
-		Stack:        cfBuild.Status.Droplet.Stack,
+		Stack:        droplet.Stack,
 		ProcessTypes: processTypesMap,
 		AppGUID:      cfBuild.Spec.AppRef.Name,
+		SpaceGUID:    cfBuild.Namespace,
 		PackageGUID:  cfBuild.Spec.PackageRef.Name,
 		Labels:       cfBuild.Labels,
 		Annotations:  cfBuild.Annotations,
+		Image:        droplet.Registry.Image,
+		ImageRef:     r.repositoryRef(cfBuild.Spec.AppRef.Name),
+	}
+
+	if record.State == DropletStateFailed {
+		record.DropletErrorMsg = meta.FindStatusCondition(cfBuild.Status.Conditions, SucceededConditionType).Message
 	}
+
+	return record
+}
+
+func (r *DropletRepo) repositoryRef(appGUID string) string {
+	return r.repositoryPrefix + appGUID + "-droplets"
 }
 
 func (r *DropletRepo) ListDroplets(ctx context.Context, authInfo authorization.Info, message ListDropletsMessage) ([]DropletRecord, error) {
@@ -151,13 +389,12 @@ func (r *DropletRepo) ListDroplets(ctx context.Context, authInfo authorization.I
 		allBuilds = append(allBuilds, buildList.Items...)
 	}
 
-	return returnDropletList(Filter(allBuilds,
-		func(a korifiv1alpha1.CFBuild) bool {
-			return getConditionValue(&a.Status.Conditions, StagingConditionType) == metav1.ConditionFalse
-		},
-		func(a korifiv1alpha1.CFBuild) bool {
-			return getConditionValue(&a.Status.Conditions, SucceededConditionType) == metav1.ConditionTrue
-		},
+	return r.returnDropletList(Filter(allBuilds,
+		isDroplet,
+		SetPredicate(message.GUIDs, func(s korifiv1alpha1.CFBuild) string { return s.Name }),
+		SetPredicate(message.AppGUIDs, func(s korifiv1alpha1.CFBuild) string { return s.Spec.AppRef.Name }),
+		SetPredicate(message.SpaceGUIDs, func(s korifiv1alpha1.CFBuild) string { return s.Namespace }),
+		SetPredicate(message.States, dropletState),
 		SetPredicate(message.PackageGUIDs, func(s korifiv1alpha1.CFBuild) string { return s.Spec.PackageRef.Name }),
 	)), nil
 }
@@ -180,14 +417,14 @@ func (r *DropletRepo) UpdateDroplet(ctx context.Context, authInfo authorization.
 		return DropletRecord{}, fmt.Errorf("failed to patch droplet metadata: %w", apierrors.FromK8sError(err, DropletResourceType))
 	}
 
-	return returnDroplet(*build)
+	return r.returnDroplet(*build)
 }
 
-func returnDropletList(droplets []korifiv1alpha1.CFBuild) []DropletRecord {
+func (r *DropletRepo) returnDropletList(droplets []korifiv1alpha1.CFBuild) []DropletRecord {
 	dropletRecords := make([]DropletRecord, 0, len(droplets))
 
 	for _, currentBuild := range droplets {
-		dropletRecords = append(dropletRecords, cfBuildToDropletRecord(currentBuild))
+		dropletRecords = append(dropletRecords, r.cfBuildToDropletRecord(currentBuild))
 	}
 	return dropletRecords
 }
@@ -2,10 +2,12 @@ package repositories_test
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/fake"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tests/matchers"
 
@@ -13,6 +15,7 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -30,6 +33,7 @@ var _ = Describe("DropletRepository", func() {
 
 	var (
 		testCtx     context.Context
+		repoCreator *fake.RepositoryCreator
 		dropletRepo *repositories.DropletRepo
 		org         *korifiv1alpha1.CFOrg
 		space       *korifiv1alpha1.CFSpace
@@ -47,7 +51,8 @@ var _ = Describe("DropletRepository", func() {
 		org = createOrgWithCleanup(testCtx, orgName)
 		space = createSpaceWithCleanup(testCtx, org.Name, spaceName)
 
-		dropletRepo = repositories.NewDropletRepo(userClientFactory, namespaceRetriever, nsPerms)
+		repoCreator = new(fake.RepositoryCreator)
+		dropletRepo = repositories.NewDropletRepo(userClientFactory, namespaceRetriever, nsPerms, repoCreator, "container.registry/foo/my/prefix-")
 
 		build = &korifiv1alpha1.CFBuild{
 			ObjectMeta: metav1.ObjectMeta{
@@ -174,6 +179,12 @@ var _ = Describe("DropletRepository", func() {
 						Expect(dropletRecord.PackageGUID).To(Equal(build.Spec.PackageRef.Name))
 					})
 
+					By("returning a record with the image of the droplet", func() {
+						Expect(dropletRecord.SpaceGUID).To(Equal(space.Name))
+						Expect(dropletRecord.Image).To(Equal(registryImage))
+						Expect(dropletRecord.ImageRef).To(Equal("container.registry/foo/my/prefix-" + appGUID + "-droplets"))
+					})
+
 					By("returning a record with all process types and commands matching the CR", func() {
 						processTypesArray := build.Status.Droplet.ProcessTypes
 						for index := range processTypesArray {
@@ -265,6 +276,20 @@ var _ = Describe("DropletRepository", func() {
 				})
 			})
 
+			When("the build has a droplet awaiting upload", func() {
+				BeforeEach(func() {
+					build = createDropletBuild(testCtx, space.Name)
+					fetchBuildGUID = build.Name
+				})
+
+				It("returns an awaiting upload droplet", func() {
+					Expect(fetchErr).NotTo(HaveOccurred())
+					Expect(dropletRecord.State).To(Equal(repositories.DropletStateAwaitingUpload))
+					Expect(dropletRecord.ProcessTypes).To(Equal(map[string]string{"web": "bundle exec rackup"}))
+					Expect(dropletRecord.Image).To(BeEmpty())
+				})
+			})
+
 			When("build does not exist", func() {
 				BeforeEach(func() {
 					meta.SetStatusCondition(&build.Status.Conditions, metav1.Condition{
@@ -365,10 +390,16 @@ var _ = Describe("DropletRepository", func() {
 			Expect(k8sClient.Status().Update(testCtx, build)).To(Succeed())
 		})
 
-		JustBeforeEach(func() {
-			dropletRecords, listErr = dropletRepo.ListDroplets(testCtx, authInfo, repositories.ListDropletsMessage{
+		var message repositories.ListDropletsMessage
+
+		BeforeEach(func() {
+			message = repositories.ListDropletsMessage{
 				PackageGUIDs: []string{packageGUID},
-			})
+			}
+		})
+
+		JustBeforeEach(func() {
+			dropletRecords, listErr = dropletRepo.ListDroplets(testCtx, authInfo, message)
 		})
 
 		When("the user is not authorized to list the droplet", func() {
@@ -389,6 +420,60 @@ var _ = Describe("DropletRepository", func() {
 				Expect(dropletRecords[0].GUID).To(Equal(build.Name))
 			})
 
+			When("there are droplets awaiting upload", func() {
+				var awaitingBuild *korifiv1alpha1.CFBuild
+
+				BeforeEach(func() {
+					awaitingBuild = createDropletBuild(testCtx, space.Name)
+					message = repositories.ListDropletsMessage{}
+				})
+
+				It("lists them too", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(dropletRecords).To(ConsistOf(
+						gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{"GUID": Equal(build.Name), "State": Equal(repositories.DropletStateStaged)}),
+						gstruct.MatchFields(gstruct.IgnoreExtras, gstruct.Fields{"GUID": Equal(awaitingBuild.Name), "State": Equal(repositories.DropletStateAwaitingUpload)}),
+					))
+				})
+
+				When("filtering by state", func() {
+					BeforeEach(func() {
+						message.States = []string{repositories.DropletStateAwaitingUpload}
+					})
+
+					It("returns the matching droplets", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(dropletRecords).To(HaveLen(1))
+						Expect(dropletRecords[0].GUID).To(Equal(awaitingBuild.Name))
+					})
+				})
+
+				When("filtering by guid", func() {
+					BeforeEach(func() {
+						message.GUIDs = []string{build.Name}
+					})
+
+					It("returns the matching droplets", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(dropletRecords).To(HaveLen(1))
+						Expect(dropletRecords[0].GUID).To(Equal(build.Name))
+					})
+				})
+
+				When("filtering by app and space", func() {
+					BeforeEach(func() {
+						message.AppGUIDs = []string{appGUID}
+						message.SpaceGUIDs = []string{space.Name}
+					})
+
+					It("returns the matching droplets", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(dropletRecords).To(HaveLen(1))
+						Expect(dropletRecords[0].GUID).To(Equal(build.Name))
+					})
+				})
+			})
+
 			When("a space exists with a rolebinding for the user, but without permission to list droplets", func() {
 				BeforeEach(func() {
 					anotherSpace := createSpaceWithCleanup(testCtx, org.Name, "space-without-droplet-space-perm")
@@ -669,4 +754,352 @@ var _ = Describe("DropletRepository", func() {
 			})
 		})
 	})
+
+	Describe("CreateDroplet", func() {
+		var (
+			dropletRecord repositories.DropletRecord
+			createErr     error
+		)
+
+		JustBeforeEach(func() {
+			dropletRecord, createErr = dropletRepo.CreateDroplet(testCtx, authInfo, repositories.CreateDropletMessage{
+				AppGUID:   "the-app-guid",
+				SpaceGUID: space.Name,
+				Lifecycle: repositories.Lifecycle{
+					Type: "buildpack",
+					Data: repositories.LifecycleData{Stack: "cflinuxfs3"},
+				},
+				ProcessTypes: map[string]string{
+					"worker": "bundle exec work",
+					"web":    "bundle exec rackup",
+				},
+				Metadata: repositories.Metadata{
+					Labels: map[string]string{"foo": "bar"},
+				},
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns a droplet awaiting upload", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(dropletRecord.State).To(Equal(repositories.DropletStateAwaitingUpload))
+				Expect(dropletRecord.AppGUID).To(Equal("the-app-guid"))
+				Expect(dropletRecord.SpaceGUID).To(Equal(space.Name))
+				Expect(dropletRecord.Stack).To(Equal("cflinuxfs3"))
+				Expect(dropletRecord.ProcessTypes).To(Equal(map[string]string{
+					"worker": "bundle exec work",
+					"web":    "bundle exec rackup",
+				}))
+				Expect(dropletRecord.Labels).To(Equal(map[string]string{"foo": "bar"}))
+				Expect(dropletRecord.ImageRef).To(Equal("container.registry/foo/my/prefix-the-app-guid-droplets"))
+			})
+
+			It("creates a build with the droplet", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				createdBuild := new(korifiv1alpha1.CFBuild)
+				Expect(k8sClient.Get(testCtx, client.ObjectKey{Namespace: space.Name, Name: dropletRecord.GUID}, createdBuild)).To(Succeed())
+				Expect(createdBuild.Spec.AppRef.Name).To(Equal("the-app-guid"))
+				Expect(createdBuild.Spec.PackageRef.Name).To(BeEmpty())
+				Expect(createdBuild.Spec.Lifecycle.Data.Stack).To(Equal("cflinuxfs3"))
+				Expect(createdBuild.Spec.Droplet).To(gstruct.PointTo(Equal(korifiv1alpha1.BuildDropletStatus{
+					Stack: "cflinuxfs3",
+					ProcessTypes: []korifiv1alpha1.ProcessType{
+						{Type: "web", Command: "bundle exec rackup"},
+						{Type: "worker", Command: "bundle exec work"},
+					},
+					Ports: []int32{},
+				})))
+			})
+
+			It("creates the droplet repository", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(repoCreator.CreateRepositoryCallCount()).To(Equal(1))
+				_, repoName := repoCreator.CreateRepositoryArgsForCall(0)
+				Expect(repoName).To(Equal("container.registry/foo/my/prefix-the-app-guid-droplets"))
+			})
+
+			When("creating the repository fails", func() {
+				BeforeEach(func() {
+					repoCreator.CreateRepositoryReturns(errors.New("repo create error"))
+				})
+
+				It("returns an error", func() {
+					Expect(createErr).To(MatchError(ContainSubstring("repo create error")))
+				})
+			})
+		})
+	})
+
+	Describe("CopyDroplet", func() {
+		var (
+			targetSpace   *korifiv1alpha1.CFSpace
+			dropletRecord repositories.DropletRecord
+			copyErr       error
+		)
+
+		BeforeEach(func() {
+			targetSpace = createSpaceWithCleanup(testCtx, org.Name, prefixedGUID("target-space-"))
+
+			meta.SetStatusCondition(&build.Status.Conditions, metav1.Condition{
+				Type:   "Staging",
+				Status: metav1.ConditionFalse,
+				Reason: "kpack",
+			})
+			meta.SetStatusCondition(&build.Status.Conditions, metav1.Condition{
+				Type:   "Succeeded",
+				Status: metav1.ConditionTrue,
+				Reason: "kpack",
+			})
+			build.Status.Droplet = &korifiv1alpha1.BuildDropletStatus{
+				Stack: dropletStack,
+				Registry: korifiv1alpha1.Registry{
+					Image:            registryImage,
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: registryImageSecret}},
+				},
+				ProcessTypes: []korifiv1alpha1.ProcessType{{Type: "web", Command: "bundle exec rackup"}},
+				Ports:        []int32{8080},
+			}
+			Expect(k8sClient.Status().Update(testCtx, build)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			dropletRecord, copyErr = dropletRepo.CopyDroplet(testCtx, authInfo, repositories.CopyDropletMessage{
+				SourceGUID: build.Name,
+				AppGUID:    "target-app-guid",
+				SpaceGUID:  targetSpace.Name,
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(copyErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer in both spaces", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, targetSpace.Name)
+			})
+
+			It("creates a droplet for the target app awaiting its image", func() {
+				Expect(copyErr).NotTo(HaveOccurred())
+				Expect(dropletRecord.GUID).NotTo(Equal(build.Name))
+				Expect(dropletRecord.State).To(Equal(repositories.DropletStateAwaitingUpload))
+				Expect(dropletRecord.AppGUID).To(Equal("target-app-guid"))
+				Expect(dropletRecord.SpaceGUID).To(Equal(targetSpace.Name))
+				Expect(dropletRecord.ImageRef).To(Equal("container.registry/foo/my/prefix-target-app-guid-droplets"))
+
+				copiedBuild := new(korifiv1alpha1.CFBuild)
+				Expect(k8sClient.Get(testCtx, client.ObjectKey{Namespace: targetSpace.Name, Name: dropletRecord.GUID}, copiedBuild)).To(Succeed())
+				Expect(copiedBuild.Spec.Lifecycle).To(Equal(build.Spec.Lifecycle))
+				Expect(copiedBuild.Spec.Droplet).To(gstruct.PointTo(Equal(korifiv1alpha1.BuildDropletStatus{
+					Stack:        dropletStack,
+					ProcessTypes: []korifiv1alpha1.ProcessType{{Type: "web", Command: "bundle exec rackup"}},
+					Ports:        []int32{8080},
+				})))
+			})
+
+			When("the source droplet is not staged", func() {
+				BeforeEach(func() {
+					build = createDropletBuild(testCtx, space.Name)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(copyErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+
+		When("the user is a space developer in the source space only", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(copyErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
+	Describe("UpdateDropletSource", func() {
+		var (
+			dropletRecord repositories.DropletRecord
+			updateErr     error
+		)
+
+		BeforeEach(func() {
+			build = createDropletBuild(testCtx, space.Name)
+		})
+
+		JustBeforeEach(func() {
+			dropletRecord, updateErr = dropletRepo.UpdateDropletSource(testCtx, authInfo, repositories.UpdateDropletSourceMessage{
+				GUID:                build.Name,
+				SpaceGUID:           space.Name,
+				ImageRef:            "my-image@sha256:123",
+				RegistrySecretNames: []string{"image-pull-secret"},
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("sets the image of the droplet", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(dropletRecord.State).To(Equal(repositories.DropletStateProcessingUpload))
+				Expect(dropletRecord.Image).To(Equal("my-image@sha256:123"))
+
+				updatedBuild := new(korifiv1alpha1.CFBuild)
+				Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(build), updatedBuild)).To(Succeed())
+				Expect(updatedBuild.Spec.Droplet.Registry).To(Equal(korifiv1alpha1.Registry{
+					Image:            "my-image@sha256:123",
+					ImagePullSecrets: []corev1.LocalObjectReference{{Name: "image-pull-secret"}},
+				}))
+			})
+
+			When("the build has no droplet to upload", func() {
+				BeforeEach(func() {
+					build.Name = buildGUID
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("DeleteDroplet", func() {
+		var deleteErr error
+
+		BeforeEach(func() {
+			build = createDropletBuild(testCtx, space.Name)
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = dropletRepo.DeleteDroplet(testCtx, authInfo, repositories.DeleteDropletMessage{
+				GUID:      build.Name,
+				SpaceGUID: space.Name,
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("deletes the droplet build", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				err := k8sClient.Get(testCtx, client.ObjectKeyFromObject(build), new(korifiv1alpha1.CFBuild))
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+
+			When("the droplet does not exist", func() {
+				BeforeEach(func() {
+					build.Name = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("GetState", func() {
+		var (
+			state    repositories.ResourceState
+			stateErr error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			build = createDropletBuild(testCtx, space.Name)
+		})
+
+		JustBeforeEach(func() {
+			state, stateErr = dropletRepo.GetState(testCtx, authInfo, build.Name)
+		})
+
+		It("is processing", func() {
+			Expect(stateErr).NotTo(HaveOccurred())
+			Expect(state).To(Equal(repositories.ResourceState{Status: repositories.ResourceStatusProcessing}))
+		})
+
+		When("the droplet is staged", func() {
+			BeforeEach(func() {
+				meta.SetStatusCondition(&build.Status.Conditions, metav1.Condition{
+					Type:   "Succeeded",
+					Status: metav1.ConditionTrue,
+					Reason: "BuildSucceeded",
+				})
+				Expect(k8sClient.Status().Update(testCtx, build)).To(Succeed())
+			})
+
+			It("is ready", func() {
+				Expect(stateErr).NotTo(HaveOccurred())
+				Expect(state).To(Equal(repositories.ResourceState{Status: repositories.ResourceStatusReady}))
+			})
+		})
+
+		When("staging the droplet failed", func() {
+			BeforeEach(func() {
+				meta.SetStatusCondition(&build.Status.Conditions, metav1.Condition{
+					Type:    "Succeeded",
+					Status:  metav1.ConditionFalse,
+					Reason:  "BuildFailed",
+					Message: "something went wrong",
+				})
+				Expect(k8sClient.Status().Update(testCtx, build)).To(Succeed())
+			})
+
+			It("is failed", func() {
+				Expect(stateErr).NotTo(HaveOccurred())
+				Expect(state).To(Equal(repositories.ResourceState{Status: repositories.ResourceStatusFailed, Details: "something went wrong"}))
+			})
+		})
+	})
 })
+
+func createDropletBuild(ctx context.Context, spaceGUID string) *korifiv1alpha1.CFBuild {
+	build := &korifiv1alpha1.CFBuild{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      prefixedGUID("droplet-build-"),
+			Namespace: spaceGUID,
+		},
+		Spec: korifiv1alpha1.CFBuildSpec{
+			AppRef: corev1.LocalObjectReference{
+				Name: "app-1-guid",
+			},
+			Lifecycle: korifiv1alpha1.Lifecycle{
+				Type: "buildpack",
+			},
+			Droplet: &korifiv1alpha1.BuildDropletStatus{
+				Stack:        "cflinuxfs3",
+				ProcessTypes: []korifiv1alpha1.ProcessType{{Type: "web", Command: "bundle exec rackup"}},
+				Ports:        []int32{},
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, build)).To(Succeed())
+
+	return build
+}
@@ -0,0 +1,122 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const EnvVarGroupResourceType = "Environment Variable Group"
+
+type EnvVarGroupRepo struct {
+	userClientFactory authorization.UserK8sClientFactory
+	rootNamespace     string
+}
+
+func NewEnvVarGroupRepo(
+	userClientFactory authorization.UserK8sClientFactory,
+	rootNamespace string,
+) *EnvVarGroupRepo {
+	return &EnvVarGroupRepo{
+		userClientFactory: userClientFactory,
+		rootNamespace:     rootNamespace,
+	}
+}
+
+type EnvVarGroupRecord struct {
+	Name                 string
+	EnvironmentVariables map[string]string
+	UpdatedAt            *time.Time
+}
+
+type PatchEnvVarGroupMessage struct {
+	Name                 string
+	EnvironmentVariables map[string]*string
+}
+
+func (r *EnvVarGroupRepo) GetEnvVarGroup(ctx context.Context, authInfo authorization.Info, name string) (EnvVarGroupRecord, error) {
+	if !isEnvVarGroupName(name) {
+		return EnvVarGroupRecord{}, apierrors.NewNotFoundError(fmt.Errorf("unknown environment variable group %q", name), EnvVarGroupResourceType)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return EnvVarGroupRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfEnvVarGroup := new(korifiv1alpha1.CFEnvVarGroup)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: name}, cfEnvVarGroup)
+	if k8serrors.IsNotFound(err) {
+		return EnvVarGroupRecord{Name: name, EnvironmentVariables: map[string]string{}}, nil
+	}
+	if err != nil {
+		return EnvVarGroupRecord{}, fmt.Errorf("failed to get environment variable group %q: %w", name, apierrors.FromK8sError(err, EnvVarGroupResourceType))
+	}
+
+	return envVarGroupToRecord(cfEnvVarGroup), nil
+}
+
+// PatchEnvVarGroup merges the message variables into the group, nil values
+// remove the variable from the group
+func (r *EnvVarGroupRepo) PatchEnvVarGroup(ctx context.Context, authInfo authorization.Info, message PatchEnvVarGroupMessage) (EnvVarGroupRecord, error) {
+	if !isEnvVarGroupName(message.Name) {
+		return EnvVarGroupRecord{}, apierrors.NewNotFoundError(fmt.Errorf("unknown environment variable group %q", message.Name), EnvVarGroupResourceType)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return EnvVarGroupRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfEnvVarGroup := &korifiv1alpha1.CFEnvVarGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      message.Name,
+			Namespace: r.rootNamespace,
+		},
+	}
+
+	_, err = controllerutil.CreateOrPatch(ctx, userClient, cfEnvVarGroup, func() error {
+		if cfEnvVarGroup.Spec.Env == nil {
+			cfEnvVarGroup.Spec.Env = map[string]string{}
+		}
+		for k, v := range message.EnvironmentVariables {
+			if v == nil {
+				delete(cfEnvVarGroup.Spec.Env, k)
+			} else {
+				cfEnvVarGroup.Spec.Env[k] = *v
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return EnvVarGroupRecord{}, fmt.Errorf("failed to patch environment variable group %q: %w", message.Name, apierrors.FromK8sError(err, EnvVarGroupResourceType))
+	}
+
+	return envVarGroupToRecord(cfEnvVarGroup), nil
+}
+
+func isEnvVarGroupName(name string) bool {
+	return name == korifiv1alpha1.RunningEnvVarGroupName || name == korifiv1alpha1.StagingEnvVarGroupName
+}
+
+func envVarGroupToRecord(cfEnvVarGroup *korifiv1alpha1.CFEnvVarGroup) EnvVarGroupRecord {
+	env := map[string]string{}
+	for k, v := range cfEnvVarGroup.Spec.Env {
+		env[k] = v
+	}
+
+	return EnvVarGroupRecord{
+		Name:                 cfEnvVarGroup.Name,
+		EnvironmentVariables: env,
+		UpdatedAt:            getLastUpdatedTime(cfEnvVarGroup),
+	}
+}
@@ -0,0 +1,113 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("EnvVarGroupRepo", func() {
+	var repo *EnvVarGroupRepo
+
+	BeforeEach(func() {
+		repo = NewEnvVarGroupRepo(userClientFactory, rootNamespace)
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.DeleteAllOf(ctx, &korifiv1alpha1.CFEnvVarGroup{}, client.InNamespace(rootNamespace))).To(Succeed())
+	})
+
+	Describe("GetEnvVarGroup", func() {
+		It("returns an empty group when the group has not been set", func() {
+			record, err := repo.GetEnvVarGroup(ctx, authInfo, korifiv1alpha1.RunningEnvVarGroupName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.Name).To(Equal(korifiv1alpha1.RunningEnvVarGroupName))
+			Expect(record.EnvironmentVariables).To(BeEmpty())
+			Expect(record.UpdatedAt).To(BeNil())
+		})
+
+		It("returns the variables of the group", func() {
+			Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFEnvVarGroup{
+				ObjectMeta: metav1.ObjectMeta{Name: korifiv1alpha1.StagingEnvVarGroupName, Namespace: rootNamespace},
+				Spec: korifiv1alpha1.CFEnvVarGroupSpec{
+					Env: map[string]string{"foo": "bar"},
+				},
+			})).To(Succeed())
+
+			record, err := repo.GetEnvVarGroup(ctx, authInfo, korifiv1alpha1.StagingEnvVarGroupName)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.EnvironmentVariables).To(Equal(map[string]string{"foo": "bar"}))
+			Expect(record.UpdatedAt).NotTo(BeNil())
+		})
+
+		It("returns a not found error for unknown groups", func() {
+			_, err := repo.GetEnvVarGroup(ctx, authInfo, "other")
+			Expect(err).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+	})
+
+	Describe("PatchEnvVarGroup", func() {
+		var (
+			message  PatchEnvVarGroupMessage
+			record   EnvVarGroupRecord
+			patchErr error
+		)
+
+		BeforeEach(func() {
+			message = PatchEnvVarGroupMessage{
+				Name: korifiv1alpha1.RunningEnvVarGroupName,
+				EnvironmentVariables: map[string]*string{
+					"foo": tools.PtrTo("bar"),
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, patchErr = repo.PatchEnvVarGroup(ctx, authInfo, message)
+		})
+
+		It("fails because the user is not a CF admin", func() {
+			Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates the group", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				Expect(record.EnvironmentVariables).To(Equal(map[string]string{"foo": "bar"}))
+
+				cfEnvVarGroup := new(korifiv1alpha1.CFEnvVarGroup)
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: korifiv1alpha1.RunningEnvVarGroupName}, cfEnvVarGroup)).To(Succeed())
+				Expect(cfEnvVarGroup.Spec.Env).To(Equal(map[string]string{"foo": "bar"}))
+			})
+
+			When("the group already exists", func() {
+				BeforeEach(func() {
+					Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFEnvVarGroup{
+						ObjectMeta: metav1.ObjectMeta{Name: korifiv1alpha1.RunningEnvVarGroupName, Namespace: rootNamespace},
+						Spec: korifiv1alpha1.CFEnvVarGroupSpec{
+							Env: map[string]string{"keep": "me", "remove": "me"},
+						},
+					})).To(Succeed())
+
+					message.EnvironmentVariables["remove"] = nil
+				})
+
+				It("merges the variables into the group", func() {
+					Expect(patchErr).NotTo(HaveOccurred())
+					Expect(record.EnvironmentVariables).To(Equal(map[string]string{"foo": "bar", "keep": "me"}))
+				})
+			})
+		})
+	})
+})
@@ -0,0 +1,252 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cffeatureflags,verbs=get
+
+const (
+	FeatureFlagResourceType = "Feature Flag"
+
+	FeatureFlagAppBitsUpload                        = "app_bits_upload"
+	FeatureFlagAppScaling                           = "app_scaling"
+	FeatureFlagDiegoDocker                          = "diego_docker"
+	FeatureFlagEnvVarVisibility                     = "env_var_visibility"
+	FeatureFlagResourceMatching                     = "resource_matching"
+	FeatureFlagRouteCreation                        = "route_creation"
+	FeatureFlagServiceInstanceCreation              = "service_instance_creation"
+	FeatureFlagServiceInstanceSharing               = "service_instance_sharing"
+	FeatureFlagSetRolesByUsername                   = "set_roles_by_username"
+	FeatureFlagSpaceDeveloperEnvVarVisibility       = "space_developer_env_var_visibility"
+	FeatureFlagTaskCreation                         = "task_creation"
+	FeatureFlagUserOrgCreation                      = "user_org_creation"
+	FeatureFlagDiegoCNB                             = "diego_cnb"
+	FeatureFlagAllowInsecureTLSForServiceBrokerURLs = "allow_insecure_tls_for_service_broker_urls"
+	// FeatureFlagAppSSHAccess is specific to Korifi and stands in for the
+	// `allow_app_ssh_access` setting of the Cloud Controller
+	FeatureFlagAppSSHAccess = "app_ssh_access"
+)
+
+type featureFlagDefault struct {
+	enabled bool
+	// adminOverride lets CF admins use the feature while the flag is disabled
+	adminOverride bool
+}
+
+// featureFlagDefaults are the feature flags known to the API, with the
+// defaults of the Cloud Controller
+var featureFlagDefaults = map[string]featureFlagDefault{
+	FeatureFlagAppBitsUpload:                        {enabled: true, adminOverride: true},
+	FeatureFlagAppScaling:                           {enabled: true, adminOverride: true},
+	FeatureFlagDiegoDocker:                          {enabled: false},
+	FeatureFlagEnvVarVisibility:                     {enabled: true},
+	FeatureFlagResourceMatching:                     {enabled: true},
+	FeatureFlagRouteCreation:                        {enabled: true, adminOverride: true},
+	FeatureFlagServiceInstanceCreation:              {enabled: true, adminOverride: true},
+	FeatureFlagServiceInstanceSharing:               {enabled: false},
+	FeatureFlagSetRolesByUsername:                   {enabled: true, adminOverride: true},
+	FeatureFlagSpaceDeveloperEnvVarVisibility:       {enabled: true, adminOverride: true},
+	FeatureFlagTaskCreation:                         {enabled: true},
+	FeatureFlagUserOrgCreation:                      {enabled: false, adminOverride: true},
+	FeatureFlagDiegoCNB:                             {enabled: false},
+	FeatureFlagAllowInsecureTLSForServiceBrokerURLs: {enabled: false},
+	FeatureFlagAppSSHAccess:                         {enabled: false},
+}
+
+type FeatureFlagRepo struct {
+	userClientFactory authorization.UserK8sClientFactory
+	privilegedClient  client.Client
+	rootNamespace     string
+}
+
+func NewFeatureFlagRepo(
+	userClientFactory authorization.UserK8sClientFactory,
+	privilegedClient client.Client,
+	rootNamespace string,
+) *FeatureFlagRepo {
+	return &FeatureFlagRepo{
+		userClientFactory: userClientFactory,
+		privilegedClient:  privilegedClient,
+		rootNamespace:     rootNamespace,
+	}
+}
+
+type FeatureFlagRecord struct {
+	Name               string
+	Enabled            bool
+	CustomErrorMessage string
+	UpdatedAt          *time.Time
+}
+
+type UpdateFeatureFlagMessage struct {
+	Name               string
+	Enabled            *bool
+	CustomErrorMessage *string
+}
+
+func (r *FeatureFlagRepo) GetFeatureFlag(ctx context.Context, authInfo authorization.Info, name string) (FeatureFlagRecord, error) {
+	if _, ok := featureFlagDefaults[name]; !ok {
+		return FeatureFlagRecord{}, apierrors.NewNotFoundError(fmt.Errorf("unknown feature flag %q", name), FeatureFlagResourceType)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return FeatureFlagRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfFeatureFlag, err := r.getCFFeatureFlag(ctx, userClient, name)
+	if err != nil {
+		return FeatureFlagRecord{}, err
+	}
+
+	return featureFlagToRecord(name, cfFeatureFlag), nil
+}
+
+func (r *FeatureFlagRepo) ListFeatureFlags(ctx context.Context, authInfo authorization.Info) ([]FeatureFlagRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []FeatureFlagRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	featureFlagList := new(korifiv1alpha1.CFFeatureFlagList)
+	err = userClient.List(ctx, featureFlagList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		return []FeatureFlagRecord{}, fmt.Errorf("failed to list feature flags in namespace %s: %w", r.rootNamespace, apierrors.FromK8sError(err, FeatureFlagResourceType))
+	}
+
+	cfFeatureFlags := map[string]*korifiv1alpha1.CFFeatureFlag{}
+	for i := range featureFlagList.Items {
+		cfFeatureFlags[featureFlagList.Items[i].Name] = &featureFlagList.Items[i]
+	}
+
+	records := make([]FeatureFlagRecord, 0, len(featureFlagDefaults))
+	for name := range featureFlagDefaults {
+		records = append(records, featureFlagToRecord(name, cfFeatureFlags[name]))
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Name < records[j].Name
+	})
+
+	return records, nil
+}
+
+// UpdateFeatureFlag creates the CFFeatureFlag overriding the default of the
+// flag on first update
+func (r *FeatureFlagRepo) UpdateFeatureFlag(ctx context.Context, authInfo authorization.Info, message UpdateFeatureFlagMessage) (FeatureFlagRecord, error) {
+	defaults, ok := featureFlagDefaults[message.Name]
+	if !ok {
+		return FeatureFlagRecord{}, apierrors.NewNotFoundError(fmt.Errorf("unknown feature flag %q", message.Name), FeatureFlagResourceType)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return FeatureFlagRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfFeatureFlag := &korifiv1alpha1.CFFeatureFlag{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      message.Name,
+			Namespace: r.rootNamespace,
+		},
+	}
+
+	_, err = controllerutil.CreateOrPatch(ctx, userClient, cfFeatureFlag, func() error {
+		if cfFeatureFlag.CreationTimestamp.IsZero() {
+			cfFeatureFlag.Spec.Enabled = defaults.enabled
+		}
+		if message.Enabled != nil {
+			cfFeatureFlag.Spec.Enabled = *message.Enabled
+		}
+		if message.CustomErrorMessage != nil {
+			cfFeatureFlag.Spec.CustomErrorMessage = *message.CustomErrorMessage
+		}
+		return nil
+	})
+	if err != nil {
+		return FeatureFlagRecord{}, fmt.Errorf("failed to update feature flag: %w", apierrors.FromK8sError(err, FeatureFlagResourceType))
+	}
+
+	return featureFlagToRecord(message.Name, cfFeatureFlag), nil
+}
+
+// CheckFeatureFlag returns a FeatureDisabledError when the flag is disabled,
+// unless the flag lets CF admins use the feature regardless and the user is
+// a CF admin. The flag is read with the privileged client as every user is
+// subject to it
+func (r *FeatureFlagRepo) CheckFeatureFlag(ctx context.Context, authInfo authorization.Info, name string) error {
+	defaults, ok := featureFlagDefaults[name]
+	if !ok {
+		return fmt.Errorf("unknown feature flag %q", name)
+	}
+
+	cfFeatureFlag, err := r.getCFFeatureFlag(ctx, r.privilegedClient, name)
+	if err != nil {
+		return err
+	}
+
+	record := featureFlagToRecord(name, cfFeatureFlag)
+	if record.Enabled {
+		return nil
+	}
+
+	if defaults.adminOverride {
+		userClient, err := r.userClientFactory.BuildClient(authInfo)
+		if err != nil {
+			return fmt.Errorf("failed to build user client: %w", err)
+		}
+
+		isAdmin, err := isAdminUser(ctx, userClient, r.rootNamespace)
+		if err != nil {
+			return err
+		}
+		if isAdmin {
+			return nil
+		}
+	}
+
+	return apierrors.NewFeatureDisabledError(fmt.Errorf("feature flag %q is disabled", name), name, record.CustomErrorMessage)
+}
+
+// getCFFeatureFlag returns nil when the flag has its default value
+func (r *FeatureFlagRepo) getCFFeatureFlag(ctx context.Context, k8sClient client.Client, name string) (*korifiv1alpha1.CFFeatureFlag, error) {
+	cfFeatureFlag := new(korifiv1alpha1.CFFeatureFlag)
+	err := k8sClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: name}, cfFeatureFlag)
+	if k8serrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feature flag %q: %w", name, apierrors.FromK8sError(err, FeatureFlagResourceType))
+	}
+
+	return cfFeatureFlag, nil
+}
+
+func featureFlagToRecord(name string, cfFeatureFlag *korifiv1alpha1.CFFeatureFlag) FeatureFlagRecord {
+	if cfFeatureFlag == nil {
+		return FeatureFlagRecord{
+			Name:    name,
+			Enabled: featureFlagDefaults[name].enabled,
+		}
+	}
+
+	return FeatureFlagRecord{
+		Name:               name,
+		Enabled:            cfFeatureFlag.Spec.Enabled,
+		CustomErrorMessage: cfFeatureFlag.Spec.CustomErrorMessage,
+		UpdatedAt:          getLastUpdatedTime(cfFeatureFlag),
+	}
+}
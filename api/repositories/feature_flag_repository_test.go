@@ -0,0 +1,152 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("FeatureFlagRepo", func() {
+	var repo *FeatureFlagRepo
+
+	BeforeEach(func() {
+		repo = NewFeatureFlagRepo(userClientFactory, k8sClient, rootNamespace)
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.DeleteAllOf(ctx, &korifiv1alpha1.CFFeatureFlag{}, client.InNamespace(rootNamespace))).To(Succeed())
+	})
+
+	disableFlag := func(name, customErrorMessage string) {
+		Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFFeatureFlag{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: rootNamespace},
+			Spec: korifiv1alpha1.CFFeatureFlagSpec{
+				Enabled:            false,
+				CustomErrorMessage: customErrorMessage,
+			},
+		})).To(Succeed())
+	}
+
+	Describe("GetFeatureFlag", func() {
+		It("returns the default of flags that have not been updated", func() {
+			record, err := repo.GetFeatureFlag(ctx, authInfo, FeatureFlagTaskCreation)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.Name).To(Equal(FeatureFlagTaskCreation))
+			Expect(record.Enabled).To(BeTrue())
+			Expect(record.UpdatedAt).To(BeNil())
+		})
+
+		It("returns the value of the CFFeatureFlag", func() {
+			disableFlag(FeatureFlagTaskCreation, "no tasks today")
+
+			record, err := repo.GetFeatureFlag(ctx, authInfo, FeatureFlagTaskCreation)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.Enabled).To(BeFalse())
+			Expect(record.CustomErrorMessage).To(Equal("no tasks today"))
+		})
+
+		It("returns a not found error for unknown flags", func() {
+			_, err := repo.GetFeatureFlag(ctx, authInfo, "not_a_flag")
+			Expect(err).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+	})
+
+	Describe("ListFeatureFlags", func() {
+		It("lists all known flags sorted by name", func() {
+			disableFlag(FeatureFlagTaskCreation, "")
+
+			records, err := repo.ListFeatureFlags(ctx, authInfo)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(ContainElements(
+				MatchFields(IgnoreExtras, Fields{"Name": Equal(FeatureFlagTaskCreation), "Enabled": BeFalse()}),
+				MatchFields(IgnoreExtras, Fields{"Name": Equal(FeatureFlagUserOrgCreation), "Enabled": BeFalse()}),
+				MatchFields(IgnoreExtras, Fields{"Name": Equal(FeatureFlagRouteCreation), "Enabled": BeTrue()}),
+			))
+			Expect(records[0].Name).To(Equal(FeatureFlagAllowInsecureTLSForServiceBrokerURLs))
+		})
+	})
+
+	Describe("UpdateFeatureFlag", func() {
+		var (
+			message   UpdateFeatureFlagMessage
+			record    FeatureFlagRecord
+			updateErr error
+		)
+
+		BeforeEach(func() {
+			message = UpdateFeatureFlagMessage{
+				Name:               FeatureFlagTaskCreation,
+				CustomErrorMessage: tools.PtrTo("no tasks today"),
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, updateErr = repo.UpdateFeatureFlag(ctx, authInfo, message)
+		})
+
+		It("fails because the user is not a CF admin", func() {
+			Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("keeps the default of the flag when only the message is set", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(record.Enabled).To(BeTrue())
+				Expect(record.CustomErrorMessage).To(Equal("no tasks today"))
+			})
+
+			When("the flag is disabled", func() {
+				BeforeEach(func() {
+					message.Enabled = tools.PtrTo(false)
+				})
+
+				It("stores the flag", func() {
+					Expect(updateErr).NotTo(HaveOccurred())
+
+					cfFeatureFlag := new(korifiv1alpha1.CFFeatureFlag)
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: FeatureFlagTaskCreation}, cfFeatureFlag)).To(Succeed())
+					Expect(cfFeatureFlag.Spec.Enabled).To(BeFalse())
+					Expect(cfFeatureFlag.Spec.CustomErrorMessage).To(Equal("no tasks today"))
+				})
+			})
+		})
+	})
+
+	Describe("CheckFeatureFlag", func() {
+		It("succeeds for enabled flags", func() {
+			Expect(repo.CheckFeatureFlag(ctx, authInfo, FeatureFlagTaskCreation)).To(Succeed())
+		})
+
+		It("returns a feature disabled error for disabled flags", func() {
+			disableFlag(FeatureFlagTaskCreation, "no tasks today")
+
+			err := repo.CheckFeatureFlag(ctx, authInfo, FeatureFlagTaskCreation)
+			Expect(err).To(BeAssignableToTypeOf(apierrors.FeatureDisabledError{}))
+			Expect(err.(apierrors.FeatureDisabledError).Detail()).To(Equal("Feature Disabled: no tasks today"))
+		})
+
+		When("the flag can be overridden by admins", func() {
+			It("rejects non admin users", func() {
+				err := repo.CheckFeatureFlag(ctx, authInfo, FeatureFlagUserOrgCreation)
+				Expect(err).To(BeAssignableToTypeOf(apierrors.FeatureDisabledError{}))
+			})
+
+			It("lets admins use the feature", func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				Expect(repo.CheckFeatureFlag(ctx, authInfo, FeatureFlagUserOrgCreation)).To(Succeed())
+			})
+		})
+	})
+})
@@ -20,16 +20,19 @@ import (
 
 const SourceImageResourceType = "SourceImage"
 
-//counterfeiter:generate -o fake -fake-name ImagePusher . ImagePusher
+//counterfeiter:generate -o fake -fake-name ImageClient . ImageClient
 
-type ImagePusher interface {
+type ImageClient interface {
 	Push(ctx context.Context, creds image.Creds, repoRef string, zipReader io.Reader, tags ...string) (string, error)
+	Copy(ctx context.Context, creds image.Creds, imageRef string, repoRef string, tags ...string) (string, error)
+	Download(ctx context.Context, creds image.Creds, imageRef string) (io.ReadCloser, error)
+	Exists(ctx context.Context, creds image.Creds, imageRef string) (bool, error)
 }
 
 type ImageRepository struct {
 	privilegedK8sClient k8sclient.Interface
 	userClientFactory   authorization.UserK8sClientFactory
-	pusher              ImagePusher
+	imageClient         ImageClient
 	pushSecretNames     []string
 	pushSecretNamespace string
 }
@@ -37,21 +40,21 @@ type ImageRepository struct {
 func NewImageRepository(
 	privilegedK8sClient k8sclient.Interface,
 	userClientFactory authorization.UserK8sClientFactory,
-	pusher ImagePusher,
+	imageClient ImageClient,
 	pushSecretNames []string,
 	pushSecretNamespace string,
 ) *ImageRepository {
 	return &ImageRepository{
 		privilegedK8sClient: privilegedK8sClient,
 		userClientFactory:   userClientFactory,
-		pusher:              pusher,
+		imageClient:         imageClient,
 		pushSecretNames:     pushSecretNames,
 		pushSecretNamespace: pushSecretNamespace,
 	}
 }
 
 func (r *ImageRepository) UploadSourceImage(ctx context.Context, authInfo authorization.Info, imageRef string, srcReader io.Reader, spaceGUID string, tags ...string) (string, error) {
-	authorized, err := r.canIPatchCFPackage(ctx, authInfo, spaceGUID)
+	authorized, err := r.canIPatch(ctx, authInfo, spaceGUID, "cfpackages", PackageResourceType)
 	if err != nil {
 		return "", fmt.Errorf("checking auth to upload source image for failed: %w", err)
 	}
@@ -60,15 +63,73 @@ func (r *ImageRepository) UploadSourceImage(ctx context.Context, authInfo author
 		return "", apierrors.NewForbiddenError(errors.New("not authorized to patch cfpackage"), PackageResourceType)
 	}
 
-	_, err = name.ParseReference(imageRef)
+	return r.push(ctx, imageRef, srcReader, tags...)
+}
+
+// UploadDropletImage pushes a droplet, i.e. a gzipped tarball of a staged
+// app, as an image
+func (r *ImageRepository) UploadDropletImage(ctx context.Context, authInfo authorization.Info, imageRef string, srcReader io.Reader, spaceGUID string, tags ...string) (string, error) {
+	authorized, err := r.canIPatch(ctx, authInfo, spaceGUID, "cfbuilds", DropletResourceType)
+	if err != nil {
+		return "", fmt.Errorf("checking auth to upload droplet image failed: %w", err)
+	}
+
+	if !authorized {
+		return "", apierrors.NewForbiddenError(errors.New("not authorized to patch cfbuild"), DropletResourceType)
+	}
+
+	return r.push(ctx, imageRef, srcReader, tags...)
+}
+
+// CopyDropletImage copies the image of a droplet into the repository of
+// another droplet
+func (r *ImageRepository) CopyDropletImage(ctx context.Context, authInfo authorization.Info, srcImageRef string, imageRef string, spaceGUID string, tags ...string) (string, error) {
+	authorized, err := r.canIPatch(ctx, authInfo, spaceGUID, "cfbuilds", DropletResourceType)
+	if err != nil {
+		return "", fmt.Errorf("checking auth to copy droplet image failed: %w", err)
+	}
+
+	if !authorized {
+		return "", apierrors.NewForbiddenError(errors.New("not authorized to patch cfbuild"), DropletResourceType)
+	}
+
+	return r.copy(ctx, srcImageRef, imageRef, tags...)
+}
+
+// CopyPackageImage copies the image of a package into the repository of
+// another package
+func (r *ImageRepository) CopyPackageImage(ctx context.Context, authInfo authorization.Info, srcImageRef string, imageRef string, spaceGUID string, tags ...string) (string, error) {
+	authorized, err := r.canIPatch(ctx, authInfo, spaceGUID, "cfpackages", PackageResourceType)
+	if err != nil {
+		return "", fmt.Errorf("checking auth to copy package image failed: %w", err)
+	}
+
+	if !authorized {
+		return "", apierrors.NewForbiddenError(errors.New("not authorized to patch cfpackage"), PackageResourceType)
+	}
+
+	return r.copy(ctx, srcImageRef, imageRef, tags...)
+}
+
+// DownloadImage returns the file system of the image as a tarball. Callers are
+// expected to have checked that the user can read the resource the image
+// belongs to.
+func (r *ImageRepository) DownloadImage(ctx context.Context, imageRef string) (io.ReadCloser, error) {
+	reader, err := r.imageClient.Download(ctx, r.creds(), imageRef)
+	if err != nil {
+		return nil, apierrors.NewBlobstoreUnavailableError(fmt.Errorf("downloading image ref '%s' failed: %w", imageRef, err))
+	}
+
+	return reader, nil
+}
+
+func (r *ImageRepository) push(ctx context.Context, imageRef string, srcReader io.Reader, tags ...string) (string, error) {
+	_, err := name.ParseReference(imageRef)
 	if err != nil {
 		return "", apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("invalid image ref: %q", imageRef))
 	}
 
-	pushedRef, err := r.pusher.Push(ctx, image.Creds{
-		Namespace:   r.pushSecretNamespace,
-		SecretNames: r.pushSecretNames,
-	}, imageRef, srcReader, tags...)
+	pushedRef, err := r.imageClient.Push(ctx, r.creds(), imageRef, srcReader, tags...)
 	if err != nil {
 		return "", apierrors.NewBlobstoreUnavailableError(fmt.Errorf("pushing image ref '%s' failed: %w", imageRef, err))
 	}
@@ -76,10 +137,31 @@ func (r *ImageRepository) UploadSourceImage(ctx context.Context, authInfo author
 	return pushedRef, nil
 }
 
-func (r *ImageRepository) canIPatchCFPackage(ctx context.Context, authInfo authorization.Info, spaceGUID string) (bool, error) {
+func (r *ImageRepository) copy(ctx context.Context, srcImageRef string, imageRef string, tags ...string) (string, error) {
+	_, err := name.ParseReference(imageRef)
+	if err != nil {
+		return "", apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("invalid image ref: %q", imageRef))
+	}
+
+	copiedRef, err := r.imageClient.Copy(ctx, r.creds(), srcImageRef, imageRef, tags...)
+	if err != nil {
+		return "", apierrors.NewBlobstoreUnavailableError(fmt.Errorf("copying image ref '%s' to '%s' failed: %w", srcImageRef, imageRef, err))
+	}
+
+	return copiedRef, nil
+}
+
+func (r *ImageRepository) creds() image.Creds {
+	return image.Creds{
+		Namespace:   r.pushSecretNamespace,
+		SecretNames: r.pushSecretNames,
+	}
+}
+
+func (r *ImageRepository) canIPatch(ctx context.Context, authInfo authorization.Info, spaceGUID string, resource string, resourceType string) (bool, error) {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
-		return false, fmt.Errorf("canIPatchCFPackage: failed to create user k8s client: %w", err)
+		return false, fmt.Errorf("canIPatch: failed to create user k8s client: %w", err)
 	}
 
 	review := authv1.SelfSubjectAccessReview{
@@ -88,12 +170,12 @@ func (r *ImageRepository) canIPatchCFPackage(ctx context.Context, authInfo autho
 				Namespace: spaceGUID,
 				Verb:      "patch",
 				Group:     "korifi.cloudfoundry.org",
-				Resource:  "cfpackages",
+				Resource:  resource,
 			},
 		},
 	}
 	if err := userClient.Create(ctx, &review); err != nil {
-		return false, fmt.Errorf("canIPatchCFPackage: failed to create self subject access review: %w", apierrors.FromK8sError(err, PackageResourceType))
+		return false, fmt.Errorf("canIPatch: failed to create self subject access review: %w", apierrors.FromK8sError(err, resourceType))
 	}
 
 	return review.Status.Allowed, nil
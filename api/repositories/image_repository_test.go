@@ -20,7 +20,7 @@ import (
 
 var _ = Describe("ImageRepository", func() {
 	var (
-		imagePusher *fake.ImagePusher
+		imageClient *fake.ImageClient
 		k8sClient   k8sclient.Interface
 		imageSource io.Reader
 		imageRepo   *repositories.ImageRepository
@@ -34,8 +34,8 @@ var _ = Describe("ImageRepository", func() {
 
 	BeforeEach(func() {
 		imageName = "my-image"
-		imagePusher = new(fake.ImagePusher)
-		imagePusher.PushReturns("my-pushed-image", nil)
+		imageClient = new(fake.ImageClient)
+		imageClient.PushReturns("my-pushed-image", nil)
 
 		imageSource = bytes.NewBufferString("")
 
@@ -51,62 +51,259 @@ var _ = Describe("ImageRepository", func() {
 		imageRepo = repositories.NewImageRepository(
 			k8sClient,
 			userClientFactory,
-			imagePusher,
+			imageClient,
 			[]string{"push-secret-name"},
 			rootNamespace,
 		)
 	})
 
-	JustBeforeEach(func() {
-		imageRef, uploadErr = imageRepo.UploadSourceImage(context.Background(), authInfo, imageName, imageSource, space.Name, tags...)
+	Describe("UploadSourceImage", func() {
+		JustBeforeEach(func() {
+			imageRef, uploadErr = imageRepo.UploadSourceImage(context.Background(), authInfo, imageName, imageSource, space.Name, tags...)
+		})
+
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("user has role SpaceDeveloper", func() {
+			BeforeEach(func() {
+				createRoleBinding(context.Background(), userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("succeeds", func() {
+				Expect(uploadErr).NotTo(HaveOccurred())
+				Expect(imageRef).To(Equal("my-pushed-image"))
+			})
+
+			It("uploads the image to the registry", func() {
+				Expect(imageClient.PushCallCount()).To(Equal(1))
+				_, creds, actualRef, zipReader, actualTags := imageClient.PushArgsForCall(0)
+				Expect(creds.Namespace).To(Equal(rootNamespace))
+				Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+				Expect(actualRef).To(Equal("my-image"))
+				Expect(zipReader).To(Equal(imageSource))
+				Expect(actualTags).To(Equal(tags))
+			})
+
+			When("the image name is invalid", func() {
+				BeforeEach(func() {
+					imageName = "invAlid-image"
+				})
+
+				It("fails with an easy to understand unprocessible entity error ", func() {
+					var apiError apierrors.UnprocessableEntityError
+					Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
+					Expect(apiError.Detail()).To(Equal(`invalid image ref: "invAlid-image"`))
+				})
+			})
+
+			When("pushing the image fails", func() {
+				BeforeEach(func() {
+					imageClient.PushReturns("", errors.New("push-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(uploadErr).To(MatchError(ContainSubstring("push-error")))
+					var apiError apierrors.BlobstoreUnavailableError
+					Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
+					Expect(apiError.Detail()).To(Equal("Error uploading source package to the container registry"))
+				})
+			})
+		})
 	})
 
-	It("fails with unauthorized error without a valid role in the space", func() {
-		Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+	Describe("UploadDropletImage", func() {
+		JustBeforeEach(func() {
+			imageRef, uploadErr = imageRepo.UploadDropletImage(context.Background(), authInfo, imageName, imageSource, space.Name, tags...)
+		})
+
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("user has role SpaceDeveloper", func() {
+			BeforeEach(func() {
+				createRoleBinding(context.Background(), userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("uploads the image to the registry", func() {
+				Expect(uploadErr).NotTo(HaveOccurred())
+				Expect(imageRef).To(Equal("my-pushed-image"))
+
+				Expect(imageClient.PushCallCount()).To(Equal(1))
+				_, creds, actualRef, reader, actualTags := imageClient.PushArgsForCall(0)
+				Expect(creds.Namespace).To(Equal(rootNamespace))
+				Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+				Expect(actualRef).To(Equal("my-image"))
+				Expect(reader).To(Equal(imageSource))
+				Expect(actualTags).To(Equal(tags))
+			})
+
+			When("pushing the image fails", func() {
+				BeforeEach(func() {
+					imageClient.PushReturns("", errors.New("push-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(uploadErr).To(MatchError(ContainSubstring("push-error")))
+					Expect(uploadErr).To(BeAssignableToTypeOf(apierrors.BlobstoreUnavailableError{}))
+				})
+			})
+		})
 	})
 
-	When("user has role SpaceDeveloper", func() {
+	Describe("CopyDropletImage", func() {
+		var (
+			copiedRef string
+			copyErr   error
+		)
+
 		BeforeEach(func() {
-			createRoleBinding(context.Background(), userName, spaceDeveloperRole.Name, space.Name)
+			imageClient.CopyReturns("my-copied-image", nil)
 		})
 
-		It("succeeds", func() {
-			Expect(uploadErr).NotTo(HaveOccurred())
-			Expect(imageRef).To(Equal("my-pushed-image"))
+		JustBeforeEach(func() {
+			copiedRef, copyErr = imageRepo.CopyDropletImage(context.Background(), authInfo, "source-image", imageName, space.Name, tags...)
 		})
 
-		It("uploads the image to the registry", func() {
-			Expect(imagePusher.PushCallCount()).To(Equal(1))
-			_, creds, actualRef, zipReader, actualTags := imagePusher.PushArgsForCall(0)
-			Expect(creds.Namespace).To(Equal(rootNamespace))
-			Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
-			Expect(actualRef).To(Equal("my-image"))
-			Expect(zipReader).To(Equal(imageSource))
-			Expect(actualTags).To(Equal(tags))
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(copyErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
 		})
 
-		When("the image name is invalid", func() {
+		When("user has role SpaceDeveloper", func() {
 			BeforeEach(func() {
-				imageName = "invAlid-image"
+				createRoleBinding(context.Background(), userName, spaceDeveloperRole.Name, space.Name)
 			})
 
-			It("fails with an easy to understand unprocessible entity error ", func() {
-				var apiError apierrors.UnprocessableEntityError
-				Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
-				Expect(apiError.Detail()).To(Equal(`invalid image ref: "invAlid-image"`))
+			It("copies the image", func() {
+				Expect(copyErr).NotTo(HaveOccurred())
+				Expect(copiedRef).To(Equal("my-copied-image"))
+
+				Expect(imageClient.CopyCallCount()).To(Equal(1))
+				_, creds, actualSrcRef, actualRef, actualTags := imageClient.CopyArgsForCall(0)
+				Expect(creds.Namespace).To(Equal(rootNamespace))
+				Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+				Expect(actualSrcRef).To(Equal("source-image"))
+				Expect(actualRef).To(Equal("my-image"))
+				Expect(actualTags).To(Equal(tags))
 			})
+
+			When("the image name is invalid", func() {
+				BeforeEach(func() {
+					imageName = "invAlid-image"
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(copyErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
+			When("copying the image fails", func() {
+				BeforeEach(func() {
+					imageClient.CopyReturns("", errors.New("copy-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(copyErr).To(MatchError(ContainSubstring("copy-error")))
+					Expect(copyErr).To(BeAssignableToTypeOf(apierrors.BlobstoreUnavailableError{}))
+				})
+			})
+		})
+	})
+
+	Describe("CopyPackageImage", func() {
+		var (
+			copiedRef string
+			copyErr   error
+		)
+
+		BeforeEach(func() {
+			imageClient.CopyReturns("my-copied-image", nil)
+		})
+
+		JustBeforeEach(func() {
+			copiedRef, copyErr = imageRepo.CopyPackageImage(context.Background(), authInfo, "source-image", imageName, space.Name, tags...)
+		})
+
+		It("fails with unauthorized error without a valid role in the space", func() {
+			Expect(copyErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("user has role SpaceDeveloper", func() {
+			BeforeEach(func() {
+				createRoleBinding(context.Background(), userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("copies the image", func() {
+				Expect(copyErr).NotTo(HaveOccurred())
+				Expect(copiedRef).To(Equal("my-copied-image"))
+
+				Expect(imageClient.CopyCallCount()).To(Equal(1))
+				_, creds, actualSrcRef, actualRef, actualTags := imageClient.CopyArgsForCall(0)
+				Expect(creds.Namespace).To(Equal(rootNamespace))
+				Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+				Expect(actualSrcRef).To(Equal("source-image"))
+				Expect(actualRef).To(Equal("my-image"))
+				Expect(actualTags).To(Equal(tags))
+			})
+
+			When("the image name is invalid", func() {
+				BeforeEach(func() {
+					imageName = "invAlid-image"
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(copyErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
+			When("copying the image fails", func() {
+				BeforeEach(func() {
+					imageClient.CopyReturns("", errors.New("copy-error"))
+				})
+
+				It("fails with a blobstore unavailable error", func() {
+					Expect(copyErr).To(MatchError(ContainSubstring("copy-error")))
+					Expect(copyErr).To(BeAssignableToTypeOf(apierrors.BlobstoreUnavailableError{}))
+				})
+			})
+		})
+	})
+
+	Describe("DownloadImage", func() {
+		var (
+			reader      io.ReadCloser
+			downloadErr error
+		)
+
+		BeforeEach(func() {
+			imageClient.DownloadReturns(io.NopCloser(bytes.NewBufferString("the-bits")), nil)
+		})
+
+		JustBeforeEach(func() {
+			reader, downloadErr = imageRepo.DownloadImage(context.Background(), "my-image")
+		})
+
+		It("downloads the image", func() {
+			Expect(downloadErr).NotTo(HaveOccurred())
+			Expect(io.ReadAll(reader)).To(Equal([]byte("the-bits")))
+
+			Expect(imageClient.DownloadCallCount()).To(Equal(1))
+			_, creds, actualRef := imageClient.DownloadArgsForCall(0)
+			Expect(creds.Namespace).To(Equal(rootNamespace))
+			Expect(creds.SecretNames).To(ConsistOf("push-secret-name"))
+			Expect(actualRef).To(Equal("my-image"))
 		})
 
-		When("pushing the image fails", func() {
+		When("downloading the image fails", func() {
 			BeforeEach(func() {
-				imagePusher.PushReturns("", errors.New("push-error"))
+				imageClient.DownloadReturns(nil, errors.New("download-error"))
 			})
 
 			It("fails with a blobstore unavailable error", func() {
-				Expect(uploadErr).To(MatchError(ContainSubstring("push-error")))
-				var apiError apierrors.BlobstoreUnavailableError
-				Expect(errors.As(uploadErr, &apiError)).To(BeTrue())
-				Expect(apiError.Detail()).To(Equal("Error uploading source package to the container registry"))
+				Expect(downloadErr).To(MatchError(ContainSubstring("download-error")))
+				Expect(downloadErr).To(BeAssignableToTypeOf(apierrors.BlobstoreUnavailableError{}))
 			})
 		})
 	})
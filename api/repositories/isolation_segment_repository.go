@@ -0,0 +1,544 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cforgs,verbs=get
+
+const (
+	IsolationSegmentResourceType = "Isolation Segment"
+)
+
+type IsolationSegmentRepo struct {
+	userClientFactory    authorization.UserK8sClientFactory
+	namespaceRetriever   NamespaceRetriever
+	namespacePermissions *authorization.NamespacePermissions
+	privilegedClient     client.Client
+	rootNamespace        string
+}
+
+func NewIsolationSegmentRepo(
+	userClientFactory authorization.UserK8sClientFactory,
+	namespaceRetriever NamespaceRetriever,
+	namespacePermissions *authorization.NamespacePermissions,
+	privilegedClient client.Client,
+	rootNamespace string,
+) *IsolationSegmentRepo {
+	return &IsolationSegmentRepo{
+		userClientFactory:    userClientFactory,
+		namespaceRetriever:   namespaceRetriever,
+		namespacePermissions: namespacePermissions,
+		privilegedClient:     privilegedClient,
+		rootNamespace:        rootNamespace,
+	}
+}
+
+type IsolationSegmentRecord struct {
+	GUID      string
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	DeletedAt *time.Time
+}
+
+type CreateIsolationSegmentMessage struct {
+	Name string
+}
+
+type UpdateIsolationSegmentMessage struct {
+	GUID string
+	Name *string
+}
+
+type ListIsolationSegmentsMessage struct {
+	GUIDs    []string
+	Names    []string
+	OrgGUIDs []string
+}
+
+type EntitleIsolationSegmentMessage struct {
+	GUID     string
+	OrgGUIDs []string
+}
+
+type RevokeIsolationSegmentMessage struct {
+	GUID    string
+	OrgGUID string
+}
+
+// AssignSpaceIsolationSegmentMessage assigns the space to the isolation
+// segment. An empty isolation segment GUID removes the assignment
+type AssignSpaceIsolationSegmentMessage struct {
+	SpaceGUID            string
+	IsolationSegmentGUID string
+}
+
+// CreateIsolationSegment creates an isolation segment whose workloads are
+// scheduled on the nodes labelled and tainted with its name. Operators can
+// change the node selector and tolerations of the CFIsolationSegment resource
+// to target existing node pools
+func (r *IsolationSegmentRepo) CreateIsolationSegment(ctx context.Context, authInfo authorization.Info, message CreateIsolationSegmentMessage) (IsolationSegmentRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return IsolationSegmentRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfIsolationSegment := &korifiv1alpha1.CFIsolationSegment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: r.rootNamespace,
+		},
+		Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+			DisplayName: message.Name,
+			NodeSelector: map[string]string{
+				korifiv1alpha1.IsolationSegmentLabelKey: message.Name,
+			},
+			Tolerations: []corev1.Toleration{{
+				Key:      korifiv1alpha1.IsolationSegmentLabelKey,
+				Operator: corev1.TolerationOpEqual,
+				Value:    message.Name,
+				Effect:   corev1.TaintEffectNoSchedule,
+			}},
+		},
+	}
+	if err = userClient.Create(ctx, cfIsolationSegment); err != nil {
+		return IsolationSegmentRecord{}, fmt.Errorf("failed to create isolation segment: %w", apierrors.FromK8sError(err, IsolationSegmentResourceType))
+	}
+
+	return cfIsolationSegmentToRecord(cfIsolationSegment), nil
+}
+
+func (r *IsolationSegmentRepo) GetIsolationSegment(ctx context.Context, authInfo authorization.Info, guid string) (IsolationSegmentRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return IsolationSegmentRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfIsolationSegment, err := r.getCFIsolationSegment(ctx, userClient, guid)
+	if err != nil {
+		return IsolationSegmentRecord{}, err
+	}
+
+	visibility, err := r.getVisibility(ctx, userClient, authInfo)
+	if err != nil {
+		return IsolationSegmentRecord{}, err
+	}
+
+	if !visibility.isVisible(guid) {
+		return IsolationSegmentRecord{}, apierrors.NewNotFoundError(fmt.Errorf("isolation segment %q is not visible", guid), IsolationSegmentResourceType)
+	}
+
+	return cfIsolationSegmentToRecord(cfIsolationSegment), nil
+}
+
+func (r *IsolationSegmentRepo) ListIsolationSegments(ctx context.Context, authInfo authorization.Info, message ListIsolationSegmentsMessage) ([]IsolationSegmentRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []IsolationSegmentRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	isolationSegmentList := new(korifiv1alpha1.CFIsolationSegmentList)
+	err = userClient.List(ctx, isolationSegmentList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		return []IsolationSegmentRecord{}, fmt.Errorf("failed to list isolation segments in namespace %s: %w", r.rootNamespace, apierrors.FromK8sError(err, IsolationSegmentResourceType))
+	}
+
+	visibility, err := r.getVisibility(ctx, userClient, authInfo)
+	if err != nil {
+		return []IsolationSegmentRecord{}, err
+	}
+
+	filtered := Filter(isolationSegmentList.Items,
+		func(s korifiv1alpha1.CFIsolationSegment) bool { return visibility.isVisible(s.Name) },
+		SetPredicate(message.GUIDs, func(s korifiv1alpha1.CFIsolationSegment) string { return s.Name }),
+		SetPredicate(message.Names, func(s korifiv1alpha1.CFIsolationSegment) string { return s.Spec.DisplayName }),
+		func(s korifiv1alpha1.CFIsolationSegment) bool {
+			return len(message.OrgGUIDs) == 0 || appliesToAnyOf(visibility.visibleOrgs(s.Name), message.OrgGUIDs)
+		},
+	)
+
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].CreationTimestamp.Before(&filtered[j].CreationTimestamp)
+	})
+
+	records := make([]IsolationSegmentRecord, 0, len(filtered))
+	for i := range filtered {
+		records = append(records, cfIsolationSegmentToRecord(&filtered[i]))
+	}
+
+	return records, nil
+}
+
+func (r *IsolationSegmentRepo) UpdateIsolationSegment(ctx context.Context, authInfo authorization.Info, message UpdateIsolationSegmentMessage) (IsolationSegmentRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return IsolationSegmentRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfIsolationSegment, err := r.getCFIsolationSegment(ctx, userClient, message.GUID)
+	if err != nil {
+		return IsolationSegmentRecord{}, err
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfIsolationSegment, func() {
+		if message.Name != nil {
+			cfIsolationSegment.Spec.DisplayName = *message.Name
+		}
+	})
+	if err != nil {
+		return IsolationSegmentRecord{}, fmt.Errorf("failed to patch isolation segment: %w", apierrors.FromK8sError(err, IsolationSegmentResourceType))
+	}
+
+	return cfIsolationSegmentToRecord(cfIsolationSegment), nil
+}
+
+// DeleteIsolationSegment deletes an isolation segment that no org is entitled to
+func (r *IsolationSegmentRepo) DeleteIsolationSegment(ctx context.Context, authInfo authorization.Info, guid string) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfIsolationSegment, err := r.getCFIsolationSegment(ctx, userClient, guid)
+	if err != nil {
+		return err
+	}
+
+	entitlements, err := r.listEntitlements(ctx)
+	if err != nil {
+		return err
+	}
+	if len(entitlements[guid]) > 0 {
+		return apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("isolation segment %q is entitled to orgs %v", guid, entitlements[guid]),
+			fmt.Sprintf("Cannot delete the %s Isolation Segment: Revoke all existing entitlements to the Isolation Segment.", cfIsolationSegment.Spec.DisplayName),
+		)
+	}
+
+	if err = userClient.Delete(ctx, cfIsolationSegment); err != nil {
+		return fmt.Errorf("failed to delete isolation segment: %w", apierrors.FromK8sError(err, IsolationSegmentResourceType))
+	}
+
+	return nil
+}
+
+// ListIsolationSegmentOrgs returns the visible orgs entitled to the isolation segment
+func (r *IsolationSegmentRepo) ListIsolationSegmentOrgs(ctx context.Context, authInfo authorization.Info, guid string) ([]string, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	visibility, err := r.getVisibility(ctx, userClient, authInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	return visibility.visibleOrgs(guid), nil
+}
+
+// ListIsolationSegmentSpaces returns the visible spaces assigned to the isolation segment
+func (r *IsolationSegmentRepo) ListIsolationSegmentSpaces(ctx context.Context, authInfo authorization.Info, guid string) ([]string, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	visibility, err := r.getVisibility(ctx, userClient, authInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	spaceList := new(korifiv1alpha1.CFSpaceList)
+	if err = r.privilegedClient.List(ctx, spaceList); err != nil {
+		return nil, fmt.Errorf("failed to list spaces: %w", apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	spaceGUIDs := []string{}
+	for _, space := range spaceList.Items {
+		if space.Spec.IsolationSegment == guid && visibility.isSpaceVisible(space.Name) {
+			spaceGUIDs = append(spaceGUIDs, space.Name)
+		}
+	}
+	sort.Strings(spaceGUIDs)
+
+	return spaceGUIDs, nil
+}
+
+// EntitleIsolationSegment entitles the orgs to the isolation segment and
+// returns all orgs entitled to it
+func (r *IsolationSegmentRepo) EntitleIsolationSegment(ctx context.Context, authInfo authorization.Info, message EntitleIsolationSegmentMessage) ([]string, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	if _, err = r.getCFIsolationSegment(ctx, userClient, message.GUID); err != nil {
+		return nil, err
+	}
+
+	cfOrgs := []*korifiv1alpha1.CFOrg{}
+	missingOrgGUIDs := []string{}
+	for _, orgGUID := range message.OrgGUIDs {
+		cfOrg := new(korifiv1alpha1.CFOrg)
+		err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: orgGUID}, cfOrg)
+		if k8serrors.IsNotFound(err) {
+			missingOrgGUIDs = append(missingOrgGUIDs, orgGUID)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get org %q: %w", orgGUID, apierrors.FromK8sError(err, OrgResourceType))
+		}
+		cfOrgs = append(cfOrgs, cfOrg)
+	}
+
+	if len(missingOrgGUIDs) > 0 {
+		return nil, apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("orgs %v do not exist", missingOrgGUIDs),
+			fmt.Sprintf("Organizations with guids [\"%s\"] do not exist, or you do not have access to them.", strings.Join(missingOrgGUIDs, "\", \"")),
+		)
+	}
+
+	for _, cfOrg := range cfOrgs {
+		err = k8s.PatchResource(ctx, userClient, cfOrg, func() {
+			cfOrg.Spec.IsolationSegments = withGUIDs(cfOrg.Spec.IsolationSegments, []string{message.GUID})
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to entitle org %q: %w", cfOrg.Name, apierrors.FromK8sError(err, OrgResourceType))
+		}
+	}
+
+	return r.ListIsolationSegmentOrgs(ctx, authInfo, message.GUID)
+}
+
+// RevokeIsolationSegment revokes the entitlement of the org to the isolation
+// segment, as long as none of the spaces of the org is assigned to it
+func (r *IsolationSegmentRepo) RevokeIsolationSegment(ctx context.Context, authInfo authorization.Info, message RevokeIsolationSegmentMessage) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfIsolationSegment, err := r.getCFIsolationSegment(ctx, userClient, message.GUID)
+	if err != nil {
+		return err
+	}
+
+	cfOrg := new(korifiv1alpha1.CFOrg)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: message.OrgGUID}, cfOrg)
+	if err != nil {
+		return fmt.Errorf("failed to get org: %w", apierrors.FromK8sError(err, OrgResourceType))
+	}
+
+	spaceList := new(korifiv1alpha1.CFSpaceList)
+	if err = r.privilegedClient.List(ctx, spaceList, client.InNamespace(message.OrgGUID)); err != nil {
+		return fmt.Errorf("failed to list spaces of org %q: %w", message.OrgGUID, apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	assignedSpaceNames := []string{}
+	for _, space := range spaceList.Items {
+		if space.Spec.IsolationSegment == message.GUID {
+			assignedSpaceNames = append(assignedSpaceNames, space.Spec.DisplayName)
+		}
+	}
+	if len(assignedSpaceNames) > 0 {
+		sort.Strings(assignedSpaceNames)
+		return apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("isolation segment %q is assigned to spaces %v of org %q", message.GUID, assignedSpaceNames, message.OrgGUID),
+			fmt.Sprintf("Cannot remove the entitlement of the %s Isolation Segment from the %s Organization: it is assigned to the spaces %s.",
+				cfIsolationSegment.Spec.DisplayName, cfOrg.Spec.DisplayName, strings.Join(assignedSpaceNames, ", ")),
+		)
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfOrg, func() {
+		cfOrg.Spec.IsolationSegments = withoutGUIDs(cfOrg.Spec.IsolationSegments, []string{message.GUID})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to revoke entitlement of org %q: %w", cfOrg.Name, apierrors.FromK8sError(err, OrgResourceType))
+	}
+
+	return nil
+}
+
+// GetSpaceIsolationSegment returns the GUID of the isolation segment the space
+// is assigned to, or an empty string
+func (r *IsolationSegmentRepo) GetSpaceIsolationSegment(ctx context.Context, authInfo authorization.Info, spaceGUID string) (string, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return "", fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpace, err := r.getCFSpace(ctx, userClient, spaceGUID)
+	if err != nil {
+		return "", err
+	}
+
+	return cfSpace.Spec.IsolationSegment, nil
+}
+
+// AssignSpaceIsolationSegment assigns the space to an isolation segment its
+// org is entitled to. The assignment only applies to apps started afterwards
+func (r *IsolationSegmentRepo) AssignSpaceIsolationSegment(ctx context.Context, authInfo authorization.Info, message AssignSpaceIsolationSegmentMessage) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpace, err := r.getCFSpace(ctx, userClient, message.SpaceGUID)
+	if err != nil {
+		return err
+	}
+
+	if message.IsolationSegmentGUID != "" {
+		cfOrg := new(korifiv1alpha1.CFOrg)
+		err = r.privilegedClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: cfSpace.Namespace}, cfOrg)
+		if err != nil {
+			return fmt.Errorf("failed to get org of space %q: %w", message.SpaceGUID, apierrors.FromK8sError(err, OrgResourceType))
+		}
+
+		if !NewSet(cfOrg.Spec.IsolationSegments...).Includes(message.IsolationSegmentGUID) {
+			return apierrors.NewUnprocessableEntityError(
+				fmt.Errorf("org %q is not entitled to isolation segment %q", cfOrg.Name, message.IsolationSegmentGUID),
+				fmt.Sprintf("Unable to assign isolation segment with guid '%s'. Ensure it has been entitled to the organization that this space belongs to.", message.IsolationSegmentGUID),
+			)
+		}
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfSpace, func() {
+		cfSpace.Spec.IsolationSegment = message.IsolationSegmentGUID
+	})
+	if err != nil {
+		return fmt.Errorf("failed to assign isolation segment to space %q: %w", message.SpaceGUID, apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	return nil
+}
+
+func (r *IsolationSegmentRepo) getCFSpace(ctx context.Context, userClient client.Client, spaceGUID string) (*korifiv1alpha1.CFSpace, error) {
+	orgGUID, err := r.namespaceRetriever.NamespaceFor(ctx, spaceGUID, SpaceResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	cfSpace := new(korifiv1alpha1.CFSpace)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: orgGUID, Name: spaceGUID}, cfSpace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get space: %w", apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	return cfSpace, nil
+}
+
+func (r *IsolationSegmentRepo) getCFIsolationSegment(ctx context.Context, userClient client.Client, guid string) (*korifiv1alpha1.CFIsolationSegment, error) {
+	cfIsolationSegment := new(korifiv1alpha1.CFIsolationSegment)
+	err := userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, cfIsolationSegment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get isolation segment: %w", apierrors.FromK8sError(err, IsolationSegmentResourceType))
+	}
+
+	return cfIsolationSegment, nil
+}
+
+// listEntitlements returns the GUIDs of the orgs entitled to each isolation segment
+func (r *IsolationSegmentRepo) listEntitlements(ctx context.Context) (map[string][]string, error) {
+	orgList := new(korifiv1alpha1.CFOrgList)
+	if err := r.privilegedClient.List(ctx, orgList, client.InNamespace(r.rootNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list orgs: %w", apierrors.FromK8sError(err, OrgResourceType))
+	}
+
+	entitlements := map[string][]string{}
+	for _, org := range orgList.Items {
+		for _, isolationSegmentGUID := range org.Spec.IsolationSegments {
+			entitlements[isolationSegmentGUID] = append(entitlements[isolationSegmentGUID], org.Name)
+		}
+	}
+
+	return entitlements, nil
+}
+
+func (r *IsolationSegmentRepo) getVisibility(ctx context.Context, userClient client.Client, authInfo authorization.Info) (isolationSegmentVisibility, error) {
+	entitlements, err := r.listEntitlements(ctx)
+	if err != nil {
+		return isolationSegmentVisibility{}, err
+	}
+
+	isAdmin, err := isAdminUser(ctx, userClient, r.rootNamespace)
+	if err != nil {
+		return isolationSegmentVisibility{}, err
+	}
+	if isAdmin {
+		return isolationSegmentVisibility{isAdmin: true, entitlements: entitlements}, nil
+	}
+
+	authorizedOrgs, err := r.namespacePermissions.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return isolationSegmentVisibility{}, fmt.Errorf("failed to list namespaces for orgs with user role bindings: %w", err)
+	}
+
+	authorizedSpaces, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return isolationSegmentVisibility{}, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+	}
+
+	return isolationSegmentVisibility{
+		entitlements:     entitlements,
+		authorizedOrgs:   authorizedOrgs,
+		authorizedSpaces: authorizedSpaces,
+	}, nil
+}
+
+// isolationSegmentVisibility decides which isolation segments a user is
+// allowed to see: admins see every isolation segment, everybody else only the
+// isolation segments one of their orgs is entitled to
+type isolationSegmentVisibility struct {
+	isAdmin          bool
+	entitlements     map[string][]string
+	authorizedOrgs   map[string]bool
+	authorizedSpaces map[string]bool
+}
+
+func (v isolationSegmentVisibility) isVisible(guid string) bool {
+	return v.isAdmin || len(v.visibleOrgs(guid)) > 0
+}
+
+func (v isolationSegmentVisibility) visibleOrgs(guid string) []string {
+	orgGUIDs := []string{}
+	for _, orgGUID := range v.entitlements[guid] {
+		if v.isAdmin || v.authorizedOrgs[orgGUID] {
+			orgGUIDs = append(orgGUIDs, orgGUID)
+		}
+	}
+	sort.Strings(orgGUIDs)
+
+	return orgGUIDs
+}
+
+func (v isolationSegmentVisibility) isSpaceVisible(spaceGUID string) bool {
+	return v.isAdmin || v.authorizedSpaces[spaceGUID]
+}
+
+func cfIsolationSegmentToRecord(cfIsolationSegment *korifiv1alpha1.CFIsolationSegment) IsolationSegmentRecord {
+	return IsolationSegmentRecord{
+		GUID:      cfIsolationSegment.Name,
+		Name:      cfIsolationSegment.Spec.DisplayName,
+		CreatedAt: cfIsolationSegment.CreationTimestamp.Time,
+		UpdatedAt: getLastUpdatedTime(cfIsolationSegment),
+		DeletedAt: golangTime(cfIsolationSegment.DeletionTimestamp),
+	}
+}
@@ -0,0 +1,326 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("IsolationSegmentRepo", func() {
+	var (
+		repo               *IsolationSegmentRepo
+		org                *korifiv1alpha1.CFOrg
+		space              *korifiv1alpha1.CFSpace
+		cfIsolationSegment *korifiv1alpha1.CFIsolationSegment
+	)
+
+	BeforeEach(func() {
+		repo = NewIsolationSegmentRepo(userClientFactory, namespaceRetriever, nsPerms, k8sClient, rootNamespace)
+
+		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+
+		cfIsolationSegment = &korifiv1alpha1.CFIsolationSegment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      generateGUID(),
+				Namespace: rootNamespace,
+			},
+			Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+				DisplayName: prefixedGUID("existing-segment"),
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfIsolationSegment)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, cfIsolationSegment))).To(Succeed())
+	})
+
+	entitleOrg := func() {
+		Expect(k8s.PatchResource(ctx, k8sClient, org, func() {
+			org.Spec.IsolationSegments = []string{cfIsolationSegment.Name}
+		})).To(Succeed())
+	}
+
+	assignSpace := func() {
+		Expect(k8s.PatchResource(ctx, k8sClient, space, func() {
+			space.Spec.IsolationSegment = cfIsolationSegment.Name
+		})).To(Succeed())
+	}
+
+	Describe("CreateIsolationSegment", func() {
+		var (
+			record    IsolationSegmentRecord
+			createErr error
+			name      string
+		)
+
+		BeforeEach(func() {
+			name = prefixedGUID("regulated")
+		})
+
+		JustBeforeEach(func() {
+			record, createErr = repo.CreateIsolationSegment(ctx, authInfo, CreateIsolationSegmentMessage{Name: name})
+		})
+
+		AfterEach(func() {
+			if record.GUID != "" {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &korifiv1alpha1.CFIsolationSegment{
+					ObjectMeta: metav1.ObjectMeta{Name: record.GUID, Namespace: rootNamespace},
+				}))).To(Succeed())
+			}
+		})
+
+		It("fails because the user is not a CF admin", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("creates an isolation segment targeting the nodes labelled with its name", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(record.Name).To(Equal(name))
+
+				created := new(korifiv1alpha1.CFIsolationSegment)
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: record.GUID}, created)).To(Succeed())
+				Expect(created.Spec.DisplayName).To(Equal(name))
+				Expect(created.Spec.NodeSelector).To(Equal(map[string]string{korifiv1alpha1.IsolationSegmentLabelKey: name}))
+				Expect(created.Spec.Tolerations).To(ConsistOf(corev1.Toleration{
+					Key:      korifiv1alpha1.IsolationSegmentLabelKey,
+					Operator: corev1.TolerationOpEqual,
+					Value:    name,
+					Effect:   corev1.TaintEffectNoSchedule,
+				}))
+			})
+		})
+	})
+
+	Describe("GetIsolationSegment", func() {
+		var (
+			record IsolationSegmentRecord
+			getErr error
+		)
+
+		JustBeforeEach(func() {
+			record, getErr = repo.GetIsolationSegment(ctx, authInfo, cfIsolationSegment.Name)
+		})
+
+		It("returns a not found error when no org of the user is entitled", func() {
+			Expect(getErr).To(HaveOccurred())
+		})
+
+		When("the user is a member of an entitled org", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, org.Name)
+				entitleOrg()
+			})
+
+			It("returns the isolation segment", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(Equal(cfIsolationSegment.Name))
+				Expect(record.Name).To(Equal(cfIsolationSegment.Spec.DisplayName))
+			})
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the isolation segment", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(Equal(cfIsolationSegment.Name))
+			})
+		})
+	})
+
+	Describe("ListIsolationSegments", func() {
+		var (
+			records []IsolationSegmentRecord
+			message ListIsolationSegmentsMessage
+			listErr error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			message = ListIsolationSegmentsMessage{}
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = repo.ListIsolationSegments(ctx, authInfo, message)
+		})
+
+		It("lists the isolation segments", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(records).To(ContainElement(MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfIsolationSegment.Name)})))
+		})
+
+		When("filtering by org", func() {
+			BeforeEach(func() {
+				message.OrgGUIDs = []string{org.Name}
+			})
+
+			It("only returns the isolation segments the org is entitled to", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("DeleteIsolationSegment", func() {
+		var deleteErr error
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = repo.DeleteIsolationSegment(ctx, authInfo, cfIsolationSegment.Name)
+		})
+
+		It("deletes the isolation segment", func() {
+			Expect(deleteErr).NotTo(HaveOccurred())
+		})
+
+		When("an org is entitled to the isolation segment", func() {
+			BeforeEach(func() {
+				entitleOrg()
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(deleteErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			})
+		})
+	})
+
+	Describe("EntitleIsolationSegment and RevokeIsolationSegment", func() {
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+		})
+
+		It("entitles and revokes the org", func() {
+			orgGUIDs, err := repo.EntitleIsolationSegment(ctx, authInfo, EntitleIsolationSegmentMessage{
+				GUID:     cfIsolationSegment.Name,
+				OrgGUIDs: []string{org.Name},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(orgGUIDs).To(ConsistOf(org.Name))
+
+			Expect(repo.RevokeIsolationSegment(ctx, authInfo, RevokeIsolationSegmentMessage{
+				GUID:    cfIsolationSegment.Name,
+				OrgGUID: org.Name,
+			})).To(Succeed())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(org), org)).To(Succeed())
+			Expect(org.Spec.IsolationSegments).To(BeEmpty())
+		})
+
+		It("fails to entitle orgs that do not exist", func() {
+			_, err := repo.EntitleIsolationSegment(ctx, authInfo, EntitleIsolationSegmentMessage{
+				GUID:     cfIsolationSegment.Name,
+				OrgGUIDs: []string{"not-an-org"},
+			})
+			Expect(err).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+		})
+
+		When("a space of the org is assigned to the isolation segment", func() {
+			BeforeEach(func() {
+				entitleOrg()
+				assignSpace()
+			})
+
+			It("refuses to revoke the entitlement", func() {
+				err := repo.RevokeIsolationSegment(ctx, authInfo, RevokeIsolationSegmentMessage{
+					GUID:    cfIsolationSegment.Name,
+					OrgGUID: org.Name,
+				})
+				Expect(err).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			})
+
+			It("lists the assigned space", func() {
+				spaceGUIDs, err := repo.ListIsolationSegmentSpaces(ctx, authInfo, cfIsolationSegment.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(spaceGUIDs).To(ConsistOf(space.Name))
+			})
+		})
+	})
+
+	Describe("AssignSpaceIsolationSegment", func() {
+		var (
+			message   AssignSpaceIsolationSegmentMessage
+			assignErr error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, orgManagerRole.Name, org.Name)
+			message = AssignSpaceIsolationSegmentMessage{
+				SpaceGUID:            space.Name,
+				IsolationSegmentGUID: cfIsolationSegment.Name,
+			}
+		})
+
+		JustBeforeEach(func() {
+			assignErr = repo.AssignSpaceIsolationSegment(ctx, authInfo, message)
+		})
+
+		It("fails because the org is not entitled", func() {
+			Expect(assignErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+		})
+
+		When("the org is entitled to the isolation segment", func() {
+			BeforeEach(func() {
+				entitleOrg()
+			})
+
+			It("assigns the space", func() {
+				Expect(assignErr).NotTo(HaveOccurred())
+
+				isolationSegmentGUID, err := repo.GetSpaceIsolationSegment(ctx, authInfo, space.Name)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(isolationSegmentGUID).To(Equal(cfIsolationSegment.Name))
+			})
+		})
+
+		When("the space is reset to the default placement", func() {
+			BeforeEach(func() {
+				assignSpace()
+				message.IsolationSegmentGUID = ""
+			})
+
+			It("removes the assignment", func() {
+				Expect(assignErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(space), space)).To(Succeed())
+				Expect(space.Spec.IsolationSegment).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("UpdateIsolationSegment", func() {
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+		})
+
+		It("renames the isolation segment", func() {
+			record, err := repo.UpdateIsolationSegment(ctx, authInfo, UpdateIsolationSegmentMessage{
+				GUID: cfIsolationSegment.Name,
+				Name: tools.PtrTo("renamed"),
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(record.Name).To(Equal("renamed"))
+		})
+	})
+})
@@ -11,9 +11,10 @@ import (
 	"k8s.io/client-go/dynamic"
 )
 
-//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps;cfbuilds;cfpackages;cfprocesses;cfspaces;cftasks,verbs=list
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps;cfbuilds;cfpackages;cfprocesses;cfrevisions;cfsidecars;cfspaces;cfspacequotas;cftasks,verbs=list
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfdomains;cfroutes,verbs=list
-//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebindings;cfserviceinstances,verbs=list
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebindings;cfserviceinstances;cfserviceroutebindings,verbs=list
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfauditevents,verbs=list
 
 var (
 	CFAppsGVR = schema.GroupVersionResource{
@@ -22,6 +23,12 @@ var (
 		Resource: "cfapps",
 	}
 
+	CFAuditEventsGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfauditevents",
+	}
+
 	CFBuildsGVR = schema.GroupVersionResource{
 		Group:    "korifi.cloudfoundry.org",
 		Version:  "v1alpha1",
@@ -52,6 +59,18 @@ var (
 		Resource: "cfprocesses",
 	}
 
+	CFRevisionsGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfrevisions",
+	}
+
+	CFSidecarsGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfsidecars",
+	}
+
 	CFRoutesGVR = schema.GroupVersionResource{
 		Group:    "korifi.cloudfoundry.org",
 		Version:  "v1alpha1",
@@ -70,12 +89,24 @@ var (
 		Resource: "cfserviceinstances",
 	}
 
+	CFServiceRouteBindingsGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfserviceroutebindings",
+	}
+
 	CFSpacesGVR = schema.GroupVersionResource{
 		Group:    "korifi.cloudfoundry.org",
 		Version:  "v1alpha1",
 		Resource: "cfspaces",
 	}
 
+	CFSpaceQuotasGVR = schema.GroupVersionResource{
+		Group:    "korifi.cloudfoundry.org",
+		Version:  "v1alpha1",
+		Resource: "cfspacequotas",
+	}
+
 	CFTasksGVR = schema.GroupVersionResource{
 		Group:    "korifi.cloudfoundry.org",
 		Version:  "v1alpha1",
@@ -83,17 +114,22 @@ var (
 	}
 
 	ResourceMap = map[string]schema.GroupVersionResource{
-		AppResourceType:             CFAppsGVR,
-		BuildResourceType:           CFBuildsGVR,
-		DropletResourceType:         CFDropletsGVR,
-		DomainResourceType:          CFDomainsGVR,
-		PackageResourceType:         CFPackagesGVR,
-		ProcessResourceType:         CFProcessesGVR,
-		RouteResourceType:           CFRoutesGVR,
-		ServiceBindingResourceType:  CFServiceBindingsGVR,
-		ServiceInstanceResourceType: CFServiceInstancesGVR,
-		SpaceResourceType:           CFSpacesGVR,
-		TaskResourceType:            CFTasksGVR,
+		AppResourceType:                 CFAppsGVR,
+		AuditEventResourceType:          CFAuditEventsGVR,
+		BuildResourceType:               CFBuildsGVR,
+		DropletResourceType:             CFDropletsGVR,
+		DomainResourceType:              CFDomainsGVR,
+		PackageResourceType:             CFPackagesGVR,
+		ProcessResourceType:             CFProcessesGVR,
+		RevisionResourceType:            CFRevisionsGVR,
+		SidecarResourceType:             CFSidecarsGVR,
+		RouteResourceType:               CFRoutesGVR,
+		ServiceBindingResourceType:      CFServiceBindingsGVR,
+		ServiceInstanceResourceType:     CFServiceInstancesGVR,
+		ServiceRouteBindingResourceType: CFServiceRouteBindingsGVR,
+		SpaceResourceType:               CFSpacesGVR,
+		SpaceQuotaResourceType:          CFSpaceQuotasGVR,
+		TaskResourceType:                CFTasksGVR,
 	}
 )
 
@@ -0,0 +1,535 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/quotas"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// the usage of orgs and spaces is calculated with the privileged client
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cforgs;cfspaces;cfapps;cfprocesses;cftasks;cfroutes;cfserviceinstances,verbs=list
+
+const (
+	OrgQuotaResourceType = "Organization Quota"
+)
+
+type OrgQuotaRepo struct {
+	userClientFactory    authorization.UserK8sClientFactory
+	namespacePermissions *authorization.NamespacePermissions
+	privilegedClient     client.Client
+	rootNamespace        string
+}
+
+func NewOrgQuotaRepo(
+	userClientFactory authorization.UserK8sClientFactory,
+	namespacePermissions *authorization.NamespacePermissions,
+	privilegedClient client.Client,
+	rootNamespace string,
+) *OrgQuotaRepo {
+	return &OrgQuotaRepo{
+		userClientFactory:    userClientFactory,
+		namespacePermissions: namespacePermissions,
+		privilegedClient:     privilegedClient,
+		rootNamespace:        rootNamespace,
+	}
+}
+
+type QuotaAppLimits struct {
+	TotalMemoryInMB      *int64
+	PerProcessMemoryInMB *int64
+	TotalInstances       *int64
+	PerAppTasks          *int64
+	TotalApps            *int64
+}
+
+type QuotaServiceLimits struct {
+	TotalServiceInstances *int64
+}
+
+type QuotaRouteLimits struct {
+	TotalRoutes *int64
+}
+
+type QuotaLimits struct {
+	Apps     QuotaAppLimits
+	Services QuotaServiceLimits
+	Routes   QuotaRouteLimits
+}
+
+// UpdateQuotaLimits replaces the limits of every group that is set
+type UpdateQuotaLimits struct {
+	Apps     *QuotaAppLimits
+	Services *QuotaServiceLimits
+	Routes   *QuotaRouteLimits
+}
+
+func (u UpdateQuotaLimits) apply(limits *korifiv1alpha1.QuotaLimits) {
+	current := toQuotaLimits(*limits)
+	if u.Apps != nil {
+		current.Apps = *u.Apps
+	}
+	if u.Services != nil {
+		current.Services = *u.Services
+	}
+	if u.Routes != nil {
+		current.Routes = *u.Routes
+	}
+	*limits = toCFQuotaLimits(current)
+}
+
+type UsageSummaryRecord struct {
+	StartedInstances int64
+	MemoryInMB       int64
+	Apps             int64
+	Routes           int64
+	ServiceInstances int64
+}
+
+type OrgQuotaRecord struct {
+	GUID      string
+	Name      string
+	Limits    QuotaLimits
+	OrgGUIDs  []string
+	CreatedAt time.Time
+	UpdatedAt *time.Time
+	DeletedAt *time.Time
+}
+
+type CreateOrgQuotaMessage struct {
+	Name     string
+	Limits   QuotaLimits
+	OrgGUIDs []string
+}
+
+type UpdateOrgQuotaMessage struct {
+	GUID   string
+	Name   *string
+	Limits UpdateQuotaLimits
+}
+
+type ListOrgQuotasMessage struct {
+	GUIDs    []string
+	Names    []string
+	OrgGUIDs []string
+}
+
+type ApplyOrgQuotaMessage struct {
+	GUID     string
+	OrgGUIDs []string
+}
+
+func (r *OrgQuotaRepo) CreateOrgQuota(ctx context.Context, authInfo authorization.Info, message CreateOrgQuotaMessage) (OrgQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	if err = r.checkOrgsExist(ctx, message.OrgGUIDs); err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	cfOrgQuota := &korifiv1alpha1.CFOrgQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: r.rootNamespace,
+		},
+		Spec: korifiv1alpha1.CFOrgQuotaSpec{
+			DisplayName: message.Name,
+			Limits:      toCFQuotaLimits(message.Limits),
+			Orgs:        withGUIDs(nil, message.OrgGUIDs),
+		},
+	}
+	if err = userClient.Create(ctx, cfOrgQuota); err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to create org quota: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	if err = r.removeFromOtherQuotas(ctx, userClient, cfOrgQuota.Name, message.OrgGUIDs); err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	return cfOrgQuotaToRecord(cfOrgQuota, quotaVisibility{isAdmin: true}), nil
+}
+
+func (r *OrgQuotaRepo) GetOrgQuota(ctx context.Context, authInfo authorization.Info, guid string) (OrgQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfOrgQuota, err := r.getCFOrgQuota(ctx, userClient, guid)
+	if err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	visibility, err := r.getVisibility(ctx, userClient, authInfo)
+	if err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	return cfOrgQuotaToRecord(cfOrgQuota, visibility), nil
+}
+
+func (r *OrgQuotaRepo) ListOrgQuotas(ctx context.Context, authInfo authorization.Info, message ListOrgQuotasMessage) ([]OrgQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []OrgQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	orgQuotaList := new(korifiv1alpha1.CFOrgQuotaList)
+	err = userClient.List(ctx, orgQuotaList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		return []OrgQuotaRecord{}, fmt.Errorf("failed to list org quotas in namespace %s: %w", r.rootNamespace, apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	visibility, err := r.getVisibility(ctx, userClient, authInfo)
+	if err != nil {
+		return []OrgQuotaRecord{}, err
+	}
+
+	filtered := Filter(orgQuotaList.Items,
+		SetPredicate(message.GUIDs, func(q korifiv1alpha1.CFOrgQuota) string { return q.Name }),
+		SetPredicate(message.Names, func(q korifiv1alpha1.CFOrgQuota) string { return q.Spec.DisplayName }),
+		func(q korifiv1alpha1.CFOrgQuota) bool {
+			return len(message.OrgGUIDs) == 0 || appliesToAnyOf(visibility.visibleOrgs(q.Spec.Orgs), message.OrgGUIDs)
+		},
+	)
+
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].CreationTimestamp.Before(&filtered[j].CreationTimestamp)
+	})
+
+	records := make([]OrgQuotaRecord, 0, len(filtered))
+	for i := range filtered {
+		records = append(records, cfOrgQuotaToRecord(&filtered[i], visibility))
+	}
+
+	return records, nil
+}
+
+func (r *OrgQuotaRepo) UpdateOrgQuota(ctx context.Context, authInfo authorization.Info, message UpdateOrgQuotaMessage) (OrgQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfOrgQuota, err := r.getCFOrgQuota(ctx, userClient, message.GUID)
+	if err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfOrgQuota, func() {
+		if message.Name != nil {
+			cfOrgQuota.Spec.DisplayName = *message.Name
+		}
+		message.Limits.apply(&cfOrgQuota.Spec.Limits)
+	})
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to patch org quota: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	return cfOrgQuotaToRecord(cfOrgQuota, quotaVisibility{isAdmin: true}), nil
+}
+
+func (r *OrgQuotaRepo) DeleteOrgQuota(ctx context.Context, authInfo authorization.Info, guid string) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfOrgQuota := &korifiv1alpha1.CFOrgQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      guid,
+			Namespace: r.rootNamespace,
+		},
+	}
+
+	if err = userClient.Delete(ctx, cfOrgQuota); err != nil {
+		return fmt.Errorf("failed to delete org quota: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	return nil
+}
+
+// ApplyOrgQuota applies the quota to the orgs, removing them from the quotas
+// that applied to them before, as an org has at most one quota
+func (r *OrgQuotaRepo) ApplyOrgQuota(ctx context.Context, authInfo authorization.Info, message ApplyOrgQuotaMessage) (OrgQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfOrgQuota, err := r.getCFOrgQuota(ctx, userClient, message.GUID)
+	if err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	if err = r.checkOrgsExist(ctx, message.OrgGUIDs); err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	if err = r.removeFromOtherQuotas(ctx, userClient, cfOrgQuota.Name, message.OrgGUIDs); err != nil {
+		return OrgQuotaRecord{}, err
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfOrgQuota, func() {
+		cfOrgQuota.Spec.Orgs = withGUIDs(cfOrgQuota.Spec.Orgs, message.OrgGUIDs)
+	})
+	if err != nil {
+		return OrgQuotaRecord{}, fmt.Errorf("failed to apply org quota: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	return cfOrgQuotaToRecord(cfOrgQuota, quotaVisibility{isAdmin: true}), nil
+}
+
+func (r *OrgQuotaRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error) {
+	orgQuota, err := r.GetOrgQuota(ctx, authInfo, guid)
+	return orgQuota.DeletedAt, err
+}
+
+// GetOrgUsageSummary returns the usage of all spaces of the org. The usage is
+// calculated with the privileged client as org users might not have access to
+// every space of the org
+func (r *OrgQuotaRepo) GetOrgUsageSummary(ctx context.Context, authInfo authorization.Info, orgGUID string) (UsageSummaryRecord, error) {
+	authorizedOrgs, err := r.namespacePermissions.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return UsageSummaryRecord{}, fmt.Errorf("failed to list namespaces for orgs with user role bindings: %w", err)
+	}
+	if !authorizedOrgs[orgGUID] {
+		return UsageSummaryRecord{}, apierrors.NewNotFoundError(fmt.Errorf("org %q is not authorized", orgGUID), OrgResourceType)
+	}
+
+	usage, err := quotas.NewCalculator(r.privilegedClient, r.rootNamespace).OrgUsage(ctx, orgGUID)
+	if err != nil {
+		return UsageSummaryRecord{}, fmt.Errorf("failed to calculate org usage: %w", err)
+	}
+
+	return toUsageSummaryRecord(usage), nil
+}
+
+// removeFromOtherQuotas ensures the orgs are not subject to any other quota
+// than the one with the given guid
+func (r *OrgQuotaRepo) removeFromOtherQuotas(ctx context.Context, userClient client.Client, guid string, orgGUIDs []string) error {
+	if len(orgGUIDs) == 0 {
+		return nil
+	}
+
+	orgQuotaList := new(korifiv1alpha1.CFOrgQuotaList)
+	err := userClient.List(ctx, orgQuotaList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		return fmt.Errorf("failed to list org quotas in namespace %s: %w", r.rootNamespace, apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	for i := range orgQuotaList.Items {
+		otherQuota := &orgQuotaList.Items[i]
+		if otherQuota.Name == guid || !appliesToAnyOf(otherQuota.Spec.Orgs, orgGUIDs) {
+			continue
+		}
+
+		err = k8s.PatchResource(ctx, userClient, otherQuota, func() {
+			otherQuota.Spec.Orgs = withoutGUIDs(otherQuota.Spec.Orgs, orgGUIDs)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to remove orgs from org quota %q: %w", otherQuota.Name, apierrors.FromK8sError(err, OrgQuotaResourceType))
+		}
+	}
+
+	return nil
+}
+
+func (r *OrgQuotaRepo) checkOrgsExist(ctx context.Context, orgGUIDs []string) error {
+	existingOrgs, err := r.existingOrgGUIDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	missingOrgGUIDs := []string{}
+	for _, orgGUID := range orgGUIDs {
+		if !existingOrgs[orgGUID] {
+			missingOrgGUIDs = append(missingOrgGUIDs, orgGUID)
+		}
+	}
+
+	if len(missingOrgGUIDs) > 0 {
+		return apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("orgs %v do not exist", missingOrgGUIDs),
+			fmt.Sprintf("Organizations with guids [\"%s\"] do not exist, or you do not have access to them.", strings.Join(missingOrgGUIDs, "\", \"")),
+		)
+	}
+
+	return nil
+}
+
+func (r *OrgQuotaRepo) existingOrgGUIDs(ctx context.Context) (map[string]bool, error) {
+	orgList := new(korifiv1alpha1.CFOrgList)
+	if err := r.privilegedClient.List(ctx, orgList, client.InNamespace(r.rootNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list orgs: %w", apierrors.FromK8sError(err, OrgResourceType))
+	}
+
+	orgGUIDs := map[string]bool{}
+	for _, org := range orgList.Items {
+		orgGUIDs[org.Name] = true
+	}
+
+	return orgGUIDs, nil
+}
+
+func (r *OrgQuotaRepo) getCFOrgQuota(ctx context.Context, userClient client.Client, guid string) (*korifiv1alpha1.CFOrgQuota, error) {
+	cfOrgQuota := new(korifiv1alpha1.CFOrgQuota)
+	err := userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, cfOrgQuota)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get org quota: %w", apierrors.FromK8sError(err, OrgQuotaResourceType))
+	}
+
+	return cfOrgQuota, nil
+}
+
+func (r *OrgQuotaRepo) getVisibility(ctx context.Context, userClient client.Client, authInfo authorization.Info) (quotaVisibility, error) {
+	isAdmin, err := isAdminUser(ctx, userClient, r.rootNamespace)
+	if err != nil {
+		return quotaVisibility{}, err
+	}
+	if isAdmin {
+		return quotaVisibility{isAdmin: true}, nil
+	}
+
+	authorizedOrgs, err := r.namespacePermissions.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return quotaVisibility{}, fmt.Errorf("failed to list namespaces for orgs with user role bindings: %w", err)
+	}
+
+	authorizedSpaces, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return quotaVisibility{}, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+	}
+
+	return quotaVisibility{authorizedOrgs: authorizedOrgs, authorizedSpaces: authorizedSpaces}, nil
+}
+
+// quotaVisibility decides which orgs and spaces are listed in the
+// relationships of a quota: admins see all of them, everybody else only the
+// orgs and spaces they have a role in
+type quotaVisibility struct {
+	isAdmin          bool
+	authorizedOrgs   map[string]bool
+	authorizedSpaces map[string]bool
+}
+
+func (v quotaVisibility) visibleOrgs(orgGUIDs []string) []string {
+	return v.visible(orgGUIDs, v.authorizedOrgs)
+}
+
+func (v quotaVisibility) visibleSpaces(spaceGUIDs []string) []string {
+	return v.visible(spaceGUIDs, v.authorizedSpaces)
+}
+
+func (v quotaVisibility) visible(guids []string, authorized map[string]bool) []string {
+	visibleGUIDs := []string{}
+	for _, guid := range guids {
+		if v.isAdmin || authorized[guid] {
+			visibleGUIDs = append(visibleGUIDs, guid)
+		}
+	}
+	sort.Strings(visibleGUIDs)
+
+	return visibleGUIDs
+}
+
+func appliesToAnyOf(appliedGUIDs []string, guids []string) bool {
+	applied := NewSet(appliedGUIDs...)
+	for _, guid := range guids {
+		if applied.Includes(guid) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func withGUIDs(guids []string, added []string) []string {
+	result := append([]string{}, guids...)
+	existing := NewSet(guids...)
+	for _, guid := range added {
+		if !existing.Includes(guid) {
+			result = append(result, guid)
+			existing[guid] = struct{}{}
+		}
+	}
+
+	return result
+}
+
+func withoutGUIDs(guids []string, removed []string) []string {
+	toRemove := NewSet(removed...)
+	result := []string{}
+	for _, guid := range guids {
+		if !toRemove.Includes(guid) {
+			result = append(result, guid)
+		}
+	}
+
+	return result
+}
+
+func toCFQuotaLimits(limits QuotaLimits) korifiv1alpha1.QuotaLimits {
+	return korifiv1alpha1.QuotaLimits{
+		TotalMemoryInMB:       limits.Apps.TotalMemoryInMB,
+		PerProcessMemoryInMB:  limits.Apps.PerProcessMemoryInMB,
+		TotalInstances:        limits.Apps.TotalInstances,
+		PerAppTasks:           limits.Apps.PerAppTasks,
+		TotalApps:             limits.Apps.TotalApps,
+		TotalServiceInstances: limits.Services.TotalServiceInstances,
+		TotalRoutes:           limits.Routes.TotalRoutes,
+	}
+}
+
+func toQuotaLimits(limits korifiv1alpha1.QuotaLimits) QuotaLimits {
+	return QuotaLimits{
+		Apps: QuotaAppLimits{
+			TotalMemoryInMB:      limits.TotalMemoryInMB,
+			PerProcessMemoryInMB: limits.PerProcessMemoryInMB,
+			TotalInstances:       limits.TotalInstances,
+			PerAppTasks:          limits.PerAppTasks,
+			TotalApps:            limits.TotalApps,
+		},
+		Services: QuotaServiceLimits{
+			TotalServiceInstances: limits.TotalServiceInstances,
+		},
+		Routes: QuotaRouteLimits{
+			TotalRoutes: limits.TotalRoutes,
+		},
+	}
+}
+
+func toUsageSummaryRecord(usage quotas.Usage) UsageSummaryRecord {
+	return UsageSummaryRecord{
+		StartedInstances: usage.Instances,
+		MemoryInMB:       usage.MemoryInMB,
+		Apps:             usage.Apps,
+		Routes:           usage.Routes,
+		ServiceInstances: usage.ServiceInstances,
+	}
+}
+
+func cfOrgQuotaToRecord(cfOrgQuota *korifiv1alpha1.CFOrgQuota, visibility quotaVisibility) OrgQuotaRecord {
+	return OrgQuotaRecord{
+		GUID:      cfOrgQuota.Name,
+		Name:      cfOrgQuota.Spec.DisplayName,
+		Limits:    toQuotaLimits(cfOrgQuota.Spec.Limits),
+		OrgGUIDs:  visibility.visibleOrgs(cfOrgQuota.Spec.Orgs),
+		CreatedAt: cfOrgQuota.CreationTimestamp.Time,
+		UpdatedAt: getLastUpdatedTime(cfOrgQuota),
+		DeletedAt: golangTime(cfOrgQuota.DeletionTimestamp),
+	}
+}
@@ -0,0 +1,391 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/onsi/gomega/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("OrgQuotaRepo", func() {
+	var (
+		repo       *OrgQuotaRepo
+		org        *korifiv1alpha1.CFOrg
+		otherOrg   *korifiv1alpha1.CFOrg
+		cfOrgQuota *korifiv1alpha1.CFOrgQuota
+	)
+
+	BeforeEach(func() {
+		repo = NewOrgQuotaRepo(userClientFactory, nsPerms, k8sClient, rootNamespace)
+
+		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		otherOrg = createOrgWithCleanup(ctx, prefixedGUID("other-org"))
+
+		cfOrgQuota = &korifiv1alpha1.CFOrgQuota{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      generateGUID(),
+				Namespace: rootNamespace,
+			},
+			Spec: korifiv1alpha1.CFOrgQuotaSpec{
+				DisplayName: prefixedGUID("existing-org-quota"),
+				Limits: korifiv1alpha1.QuotaLimits{
+					TotalMemoryInMB: tools.PtrTo[int64](2048),
+					TotalRoutes:     tools.PtrTo[int64](10),
+				},
+				Orgs: []string{org.Name, otherOrg.Name},
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfOrgQuota)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, cfOrgQuota))).To(Succeed())
+	})
+
+	withGUID := func(guid string) types.GomegaMatcher {
+		return MatchFields(IgnoreExtras, Fields{"GUID": Equal(guid)})
+	}
+
+	Describe("CreateOrgQuota", func() {
+		var (
+			message   CreateOrgQuotaMessage
+			record    OrgQuotaRecord
+			createErr error
+		)
+
+		BeforeEach(func() {
+			message = CreateOrgQuotaMessage{
+				Name: prefixedGUID("my-org-quota"),
+				Limits: QuotaLimits{
+					Apps:     QuotaAppLimits{TotalApps: tools.PtrTo[int64](5)},
+					Services: QuotaServiceLimits{TotalServiceInstances: tools.PtrTo[int64](0)},
+				},
+				OrgGUIDs: []string{org.Name},
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, createErr = repo.CreateOrgQuota(ctx, authInfo, message)
+		})
+
+		AfterEach(func() {
+			if record.GUID != "" {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &korifiv1alpha1.CFOrgQuota{
+					ObjectMeta: metav1.ObjectMeta{Name: record.GUID, Namespace: rootNamespace},
+				}))).To(Succeed())
+			}
+		})
+
+		It("fails because the user is not a CF admin", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns an org quota record", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(MatchRegexp("^[-0-9a-f]{36}$"), "record GUID was not a 36 character guid")
+				Expect(record.Name).To(Equal(message.Name))
+				Expect(record.Limits).To(Equal(message.Limits))
+				Expect(record.OrgGUIDs).To(ConsistOf(org.Name))
+			})
+
+			It("creates a CFOrgQuota", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				created := new(korifiv1alpha1.CFOrgQuota)
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: record.GUID}, created)).To(Succeed())
+				Expect(created.Spec.DisplayName).To(Equal(message.Name))
+				Expect(created.Spec.Limits).To(Equal(korifiv1alpha1.QuotaLimits{
+					TotalApps:             tools.PtrTo[int64](5),
+					TotalServiceInstances: tools.PtrTo[int64](0),
+				}))
+				Expect(created.Spec.Orgs).To(ConsistOf(org.Name))
+			})
+
+			It("removes the org from the quota that applied to it before", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfOrgQuota), cfOrgQuota)).To(Succeed())
+				Expect(cfOrgQuota.Spec.Orgs).To(ConsistOf(otherOrg.Name))
+			})
+
+			When("an org does not exist", func() {
+				BeforeEach(func() {
+					message.OrgGUIDs = []string{"does-not-exist"}
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+					Expect(createErr.(apierrors.UnprocessableEntityError).Detail()).To(ContainSubstring("does-not-exist"))
+				})
+			})
+		})
+	})
+
+	Describe("GetOrgQuota", func() {
+		var (
+			record OrgQuotaRecord
+			getErr error
+		)
+
+		JustBeforeEach(func() {
+			record, getErr = repo.GetOrgQuota(ctx, authInfo, cfOrgQuota.Name)
+		})
+
+		It("returns the org quota without the orgs the user has no role in", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(record.GUID).To(Equal(cfOrgQuota.Name))
+			Expect(record.Name).To(Equal(cfOrgQuota.Spec.DisplayName))
+			Expect(record.Limits).To(Equal(QuotaLimits{
+				Apps:   QuotaAppLimits{TotalMemoryInMB: tools.PtrTo[int64](2048)},
+				Routes: QuotaRouteLimits{TotalRoutes: tools.PtrTo[int64](10)},
+			}))
+			Expect(record.OrgGUIDs).To(BeEmpty())
+		})
+
+		When("the user has a role in one of the orgs", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, org.Name)
+			})
+
+			It("only lists that org", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.OrgGUIDs).To(ConsistOf(org.Name))
+			})
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("lists all orgs", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.OrgGUIDs).To(ConsistOf(org.Name, otherOrg.Name))
+			})
+		})
+
+		When("the org quota does not exist", func() {
+			JustBeforeEach(func() {
+				record, getErr = repo.GetOrgQuota(ctx, authInfo, "does-not-exist")
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("ListOrgQuotas", func() {
+		var (
+			message ListOrgQuotasMessage
+			records []OrgQuotaRecord
+			listErr error
+		)
+
+		BeforeEach(func() {
+			message = ListOrgQuotasMessage{}
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = repo.ListOrgQuotas(ctx, authInfo, message)
+		})
+
+		It("lists the org quotas", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(records).To(ContainElement(withGUID(cfOrgQuota.Name)))
+		})
+
+		When("filtering by name", func() {
+			BeforeEach(func() {
+				message.Names = []string{"some-other-name"}
+			})
+
+			It("filters out the org quota", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).NotTo(ContainElement(withGUID(cfOrgQuota.Name)))
+			})
+		})
+
+		When("filtering by organization guid", func() {
+			BeforeEach(func() {
+				message.OrgGUIDs = []string{otherOrg.Name}
+			})
+
+			It("returns the quota applying to the org", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ConsistOf(withGUID(cfOrgQuota.Name)))
+			})
+		})
+	})
+
+	Describe("UpdateOrgQuota", func() {
+		var (
+			message   UpdateOrgQuotaMessage
+			record    OrgQuotaRecord
+			updateErr error
+		)
+
+		BeforeEach(func() {
+			message = UpdateOrgQuotaMessage{
+				GUID: cfOrgQuota.Name,
+				Name: tools.PtrTo("new-name"),
+				Limits: UpdateQuotaLimits{
+					Apps: &QuotaAppLimits{TotalInstances: tools.PtrTo[int64](4)},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, updateErr = repo.UpdateOrgQuota(ctx, authInfo, message)
+		})
+
+		It("fails because the user is not a CF admin", func() {
+			Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("replaces the limits of the updated groups only", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(record.Name).To(Equal("new-name"))
+				Expect(record.Limits).To(Equal(QuotaLimits{
+					Apps:   QuotaAppLimits{TotalInstances: tools.PtrTo[int64](4)},
+					Routes: QuotaRouteLimits{TotalRoutes: tools.PtrTo[int64](10)},
+				}))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfOrgQuota), cfOrgQuota)).To(Succeed())
+				Expect(cfOrgQuota.Spec.DisplayName).To(Equal("new-name"))
+				Expect(cfOrgQuota.Spec.Limits.TotalMemoryInMB).To(BeNil())
+				Expect(cfOrgQuota.Spec.Limits.TotalInstances).To(PointTo(BeEquivalentTo(4)))
+			})
+		})
+	})
+
+	Describe("DeleteOrgQuota", func() {
+		var deleteErr error
+
+		JustBeforeEach(func() {
+			deleteErr = repo.DeleteOrgQuota(ctx, authInfo, cfOrgQuota.Name)
+		})
+
+		It("fails because the user is not a CF admin", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("deletes the CFOrgQuota", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(cfOrgQuota), &korifiv1alpha1.CFOrgQuota{})
+				Expect(err).To(MatchError(ContainSubstring("not found")))
+			})
+		})
+	})
+
+	Describe("ApplyOrgQuota", func() {
+		var (
+			newQuota *korifiv1alpha1.CFOrgQuota
+			record   OrgQuotaRecord
+			applyErr error
+		)
+
+		BeforeEach(func() {
+			newQuota = &korifiv1alpha1.CFOrgQuota{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      generateGUID(),
+					Namespace: rootNamespace,
+				},
+				Spec: korifiv1alpha1.CFOrgQuotaSpec{
+					DisplayName: prefixedGUID("new-org-quota"),
+				},
+			}
+			Expect(k8sClient.Create(ctx, newQuota)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, newQuota))).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			record, applyErr = repo.ApplyOrgQuota(ctx, authInfo, ApplyOrgQuotaMessage{
+				GUID:     newQuota.Name,
+				OrgGUIDs: []string{org.Name},
+			})
+		})
+
+		It("fails because the user is not a CF admin", func() {
+			Expect(applyErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("moves the org to the new quota", func() {
+				Expect(applyErr).NotTo(HaveOccurred())
+				Expect(record.OrgGUIDs).To(ConsistOf(org.Name))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfOrgQuota), cfOrgQuota)).To(Succeed())
+				Expect(cfOrgQuota.Spec.Orgs).To(ConsistOf(otherOrg.Name))
+			})
+		})
+	})
+
+	Describe("GetOrgUsageSummary", func() {
+		var (
+			usage    UsageSummaryRecord
+			usageErr error
+		)
+
+		BeforeEach(func() {
+			space := createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+			app := createAppCR(ctx, k8sClient, "app", generateGUID(), space.Name, string(korifiv1alpha1.StartedState))
+			createProcessCR(ctx, k8sClient, generateGUID(), space.Name, app.Name)
+			createAppCR(ctx, k8sClient, "stopped-app", generateGUID(), space.Name, string(korifiv1alpha1.StoppedState))
+		})
+
+		JustBeforeEach(func() {
+			usage, usageErr = repo.GetOrgUsageSummary(ctx, authInfo, org.Name)
+		})
+
+		It("returns a not found error as the user has no role in the org", func() {
+			Expect(usageErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the user is an org user", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, org.Name)
+			})
+
+			It("returns the usage of the spaces of the org", func() {
+				Expect(usageErr).NotTo(HaveOccurred())
+				Expect(usage).To(Equal(UsageSummaryRecord{
+					StartedInstances: 1,
+					MemoryInMB:       500,
+					Apps:             2,
+				}))
+			})
+		})
+	})
+})
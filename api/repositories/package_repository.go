@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
+	"github.com/google/go-containerregistry/pkg/name"
 	"github.com/google/uuid"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -70,6 +72,9 @@ type PackageRecord struct {
 	Labels      map[string]string
 	Annotations map[string]string
 	ImageRef    string
+	DockerImage string
+	// SourceImage is the image holding the bits of the package
+	SourceImage string
 }
 
 type ListPackagesMessage struct {
@@ -82,6 +87,13 @@ type CreatePackageMessage struct {
 	AppGUID   string
 	SpaceGUID string
 	Metadata  Metadata
+	Data      *PackageData
+}
+
+type PackageData struct {
+	Image    string
+	Username *string
+	Password *string
 }
 
 func (message CreatePackageMessage) toCFPackage() *korifiv1alpha1.CFPackage {
@@ -105,9 +117,27 @@ func (message CreatePackageMessage) toCFPackage() *korifiv1alpha1.CFPackage {
 		},
 	}
 
+	if message.Data != nil {
+		pkg.Spec.Source.Registry.Image = message.Data.Image
+		if message.Data.Username != nil {
+			pkg.Spec.Source.Registry.ImagePullSecrets = []corev1.LocalObjectReference{{Name: guid}}
+		}
+	}
+
 	return pkg
 }
 
+type CopyPackageMessage struct {
+	SourceGUID string
+	AppGUID    string
+	SpaceGUID  string
+}
+
+type DeletePackageMessage struct {
+	GUID      string
+	SpaceGUID string
+}
+
 type UpdatePackageMessage struct {
 	GUID          string
 	MetadataPatch MetadataPatch
@@ -132,9 +162,16 @@ func (r *PackageRepo) CreatePackage(ctx context.Context, authInfo authorization.
 		return PackageRecord{}, apierrors.FromK8sError(err, PackageResourceType)
 	}
 
-	err = r.repositoryCreator.CreateRepository(ctx, r.repositoryRef(message.AppGUID))
-	if err != nil {
-		return PackageRecord{}, fmt.Errorf("failed to create package repository: %w", err)
+	if cfPackage.Spec.Type == korifiv1alpha1.DockerPackage {
+		err = r.createImagePullSecret(ctx, userClient, cfPackage, message.Data)
+		if err != nil {
+			return PackageRecord{}, err
+		}
+	} else {
+		err = r.repositoryCreator.CreateRepository(ctx, r.repositoryRef(message.AppGUID))
+		if err != nil {
+			return PackageRecord{}, fmt.Errorf("failed to create package repository: %w", err)
+		}
 	}
 
 	cfPackage, err = r.awaiter.AwaitCondition(ctx, userClient, cfPackage, workloads.InitializedConditionType)
@@ -145,6 +182,194 @@ func (r *PackageRepo) CreatePackage(ctx context.Context, authInfo authorization.
 	return r.cfPackageToPackageRecord(cfPackage), nil
 }
 
+// createImagePullSecret stores the registry credentials of a docker package
+// in a secret owned by the app. The droplets staged from the package keep
+// pulling the image after the package has been cleaned up, so the secret is
+// only deleted along with the app
+func (r *PackageRepo) createImagePullSecret(ctx context.Context, userClient client.Client, cfPackage *korifiv1alpha1.CFPackage, data *PackageData) error {
+	if data == nil || data.Username == nil {
+		return nil
+	}
+
+	ownerReference, err := appOwnerReference(ctx, userClient, cfPackage)
+	if err != nil {
+		return err
+	}
+
+	ref, err := name.ParseReference(data.Image)
+	if err != nil {
+		return fmt.Errorf("failed to parse image reference %q: %w", data.Image, err)
+	}
+
+	dockerConfig, err := json.Marshal(map[string]any{
+		"auths": map[string]any{
+			ref.Context().RegistryStr(): map[string]string{
+				"username": *data.Username,
+				"password": *data.Password,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal docker config: %w", err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cfPackage.Name,
+			Namespace:       cfPackage.Namespace,
+			OwnerReferences: []metav1.OwnerReference{ownerReference},
+		},
+		Type: corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{
+			corev1.DockerConfigJsonKey: dockerConfig,
+		},
+	}
+
+	err = userClient.Create(ctx, secret)
+	if err != nil {
+		return fmt.Errorf("failed to create image pull secret: %w", apierrors.FromK8sError(err, PackageResourceType))
+	}
+
+	return nil
+}
+
+// CopyPackage creates a package for another app. Docker packages reference the
+// image of the source package, and image pull credentials stored for the source
+// package are copied along with it. Bits packages are created awaiting upload,
+// as their image has to be copied into the repository of the target app.
+func (r *PackageRepo) CopyPackage(ctx context.Context, authInfo authorization.Info, message CopyPackageMessage) (PackageRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, message.SourceGUID, PackageResourceType)
+	if err != nil {
+		return PackageRecord{}, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return PackageRecord{}, fmt.Errorf("failed to build user k8s client: %w", err)
+	}
+
+	sourcePackage := new(korifiv1alpha1.CFPackage)
+	if err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: message.SourceGUID}, sourcePackage); err != nil {
+		return PackageRecord{}, fmt.Errorf("failed to get package %q: %w", message.SourceGUID, apierrors.FromK8sError(err, PackageResourceType))
+	}
+
+	guid := uuid.NewString()
+	cfPackage := &korifiv1alpha1.CFPackage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      guid,
+			Namespace: message.SpaceGUID,
+		},
+		Spec: korifiv1alpha1.CFPackageSpec{
+			Type: sourcePackage.Spec.Type,
+			AppRef: corev1.LocalObjectReference{
+				Name: message.AppGUID,
+			},
+			Source: korifiv1alpha1.PackageSource{
+				Registry: korifiv1alpha1.Registry{
+					ImagePullSecrets: []corev1.LocalObjectReference{},
+				},
+			},
+		},
+	}
+
+	if cfPackage.Spec.Type != korifiv1alpha1.DockerPackage {
+		if err = userClient.Create(ctx, cfPackage); err != nil {
+			return PackageRecord{}, apierrors.FromK8sError(err, PackageResourceType)
+		}
+
+		return r.cfPackageToPackageRecord(cfPackage), nil
+	}
+
+	cfPackage.Spec.Source.Registry.Image = sourcePackage.Spec.Source.Registry.Image
+
+	var ownedSecret *corev1.LocalObjectReference
+	for _, secret := range sourcePackage.Spec.Source.Registry.ImagePullSecrets {
+		if secret.Name == sourcePackage.Name {
+			ownedSecret = &corev1.LocalObjectReference{Name: secret.Name}
+			secret.Name = guid
+		}
+		cfPackage.Spec.Source.Registry.ImagePullSecrets = append(cfPackage.Spec.Source.Registry.ImagePullSecrets, secret)
+	}
+
+	if err = userClient.Create(ctx, cfPackage); err != nil {
+		return PackageRecord{}, apierrors.FromK8sError(err, PackageResourceType)
+	}
+
+	if ownedSecret != nil {
+		if err = r.copyImagePullSecret(ctx, userClient, sourcePackage.Namespace, ownedSecret.Name, cfPackage); err != nil {
+			// the copy cannot pull its image without the secret
+			_ = userClient.Delete(ctx, cfPackage)
+			return PackageRecord{}, err
+		}
+	}
+
+	cfPackage, err = r.awaiter.AwaitCondition(ctx, userClient, cfPackage, shared.StatusConditionReady)
+	if err != nil {
+		return PackageRecord{}, fmt.Errorf("failed awaiting Ready status condition: %w", err)
+	}
+
+	return r.cfPackageToPackageRecord(cfPackage), nil
+}
+
+func (r *PackageRepo) DeletePackage(ctx context.Context, authInfo authorization.Info, message DeletePackageMessage) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	err = userClient.Delete(ctx, &korifiv1alpha1.CFPackage{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      message.GUID,
+			Namespace: message.SpaceGUID,
+		},
+	})
+
+	return apierrors.FromK8sError(err, PackageResourceType)
+}
+
+func (r *PackageRepo) copyImagePullSecret(ctx context.Context, userClient client.Client, sourceNamespace, sourceName string, cfPackage *korifiv1alpha1.CFPackage) error {
+	sourceSecret := new(corev1.Secret)
+	if err := userClient.Get(ctx, client.ObjectKey{Namespace: sourceNamespace, Name: sourceName}, sourceSecret); err != nil {
+		return fmt.Errorf("failed to get image pull secret: %w", apierrors.FromK8sError(err, PackageResourceType))
+	}
+
+	ownerReference, err := appOwnerReference(ctx, userClient, cfPackage)
+	if err != nil {
+		return err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            cfPackage.Name,
+			Namespace:       cfPackage.Namespace,
+			OwnerReferences: []metav1.OwnerReference{ownerReference},
+		},
+		Type: sourceSecret.Type,
+		Data: sourceSecret.Data,
+	}
+
+	if err := userClient.Create(ctx, secret); err != nil {
+		return fmt.Errorf("failed to create image pull secret: %w", apierrors.FromK8sError(err, PackageResourceType))
+	}
+
+	return nil
+}
+
+func appOwnerReference(ctx context.Context, userClient client.Client, cfPackage *korifiv1alpha1.CFPackage) (metav1.OwnerReference, error) {
+	cfApp := new(korifiv1alpha1.CFApp)
+	err := userClient.Get(ctx, client.ObjectKey{Namespace: cfPackage.Namespace, Name: cfPackage.Spec.AppRef.Name}, cfApp)
+	if err != nil {
+		return metav1.OwnerReference{}, fmt.Errorf("failed to get app: %w", apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	return metav1.OwnerReference{
+		APIVersion: APIVersion,
+		Kind:       Kind,
+		Name:       cfApp.Name,
+		UID:        cfApp.UID,
+	}, nil
+}
+
 func (r *PackageRepo) UpdatePackage(ctx context.Context, authInfo authorization.Info, updateMessage UpdatePackageMessage) (PackageRecord, error) {
 	ns, err := r.namespaceRetriever.NamespaceFor(ctx, updateMessage.GUID, PackageResourceType)
 	if err != nil {
@@ -276,9 +501,19 @@ func (r *PackageRepo) cfPackageToPackageRecord(cfPackage *korifiv1alpha1.CFPacka
 		Labels:      cfPackage.Labels,
 		Annotations: cfPackage.Annotations,
 		ImageRef:    r.repositoryRef(cfPackage.Spec.AppRef.Name),
+		DockerImage: dockerImage(cfPackage),
+		SourceImage: cfPackage.Spec.Source.Registry.Image,
 	}
 }
 
+func dockerImage(cfPackage *korifiv1alpha1.CFPackage) string {
+	if cfPackage.Spec.Type != korifiv1alpha1.DockerPackage {
+		return ""
+	}
+
+	return cfPackage.Spec.Source.Registry.Image
+}
+
 func (r *PackageRepo) convertToPackageRecords(packages []korifiv1alpha1.CFPackage) []PackageRecord {
 	packageRecords := make([]PackageRecord, 0, len(packages))
 
@@ -24,6 +24,7 @@ import (
 	. "github.com/onsi/gomega"
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -158,6 +159,225 @@ var _ = Describe("PackageRepository", func() {
 					Expect(createErr).To(MatchError(ContainSubstring("repo create error")))
 				})
 			})
+
+			When("the package type is docker", func() {
+				BeforeEach(func() {
+					packageCreate.Type = "docker"
+					packageCreate.Data = &repositories.PackageData{
+						Image:    "registry.example.org/my/image:latest",
+						Username: tools.PtrTo("user"),
+						Password: tools.PtrTo("pass"),
+					}
+				})
+
+				It("creates a package referencing the image", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(createdPackage.Type).To(Equal("docker"))
+					Expect(createdPackage.DockerImage).To(Equal("registry.example.org/my/image:latest"))
+
+					createdCFPackage := new(korifiv1alpha1.CFPackage)
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdPackage.GUID, Namespace: space.Name}, createdCFPackage)).To(Succeed())
+					Expect(createdCFPackage.Spec.Source.Registry.Image).To(Equal("registry.example.org/my/image:latest"))
+					Expect(createdCFPackage.Spec.Source.Registry.ImagePullSecrets).To(ConsistOf(corev1.LocalObjectReference{Name: createdPackage.GUID}))
+				})
+
+				It("stores the registry credentials in an image pull secret owned by the app", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					secret := new(corev1.Secret)
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdPackage.GUID, Namespace: space.Name}, secret)).To(Succeed())
+					Expect(secret.Type).To(Equal(corev1.SecretTypeDockerConfigJson))
+					Expect(secret.Data).To(HaveKeyWithValue(corev1.DockerConfigJsonKey,
+						MatchJSON(`{"auths":{"registry.example.org":{"username":"user","password":"pass"}}}`)))
+					Expect(secret.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"Kind": Equal("CFApp"),
+						"Name": Equal(app.Name),
+					})))
+				})
+
+				It("does not create a package repository", func() {
+					Expect(repoCreator.CreateRepositoryCallCount()).To(Equal(0))
+				})
+
+				When("no credentials are provided", func() {
+					BeforeEach(func() {
+						packageCreate.Data.Username = nil
+						packageCreate.Data.Password = nil
+					})
+
+					It("does not set image pull secrets", func() {
+						Expect(createErr).NotTo(HaveOccurred())
+
+						createdCFPackage := new(korifiv1alpha1.CFPackage)
+						Expect(k8sClient.Get(ctx, types.NamespacedName{Name: createdPackage.GUID, Namespace: space.Name}, createdCFPackage)).To(Succeed())
+						Expect(createdCFPackage.Spec.Source.Registry.ImagePullSecrets).To(BeEmpty())
+					})
+				})
+			})
+		})
+	})
+
+	Describe("CopyPackage", func() {
+		var (
+			sourceGUID    string
+			targetApp     *korifiv1alpha1.CFApp
+			copiedPackage repositories.PackageRecord
+			copyErr       error
+		)
+
+		BeforeEach(func() {
+			sourceGUID = generateGUID()
+			createPackageCR(ctx, k8sClient, sourceGUID, app.Name, space.Name, "some-org/some-repo@sha256:abc")
+			targetApp = createApp(space.Name)
+		})
+
+		JustBeforeEach(func() {
+			copiedPackage, copyErr = packageRepo.CopyPackage(ctx, authInfo, repositories.CopyPackageMessage{
+				SourceGUID: sourceGUID,
+				AppGUID:    targetApp.Name,
+				SpaceGUID:  space.Name,
+			})
+		})
+
+		It("fails because the user is not a space developer", func() {
+			Expect(copyErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a SpaceDeveloper", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("creates a package for the target app awaiting the copy of the image", func() {
+				Expect(copyErr).NotTo(HaveOccurred())
+				Expect(copiedPackage.GUID).NotTo(Equal(sourceGUID))
+				Expect(copiedPackage.Type).To(Equal("bits"))
+				Expect(copiedPackage.AppGUID).To(Equal(targetApp.Name))
+				Expect(copiedPackage.State).To(Equal("AWAITING_UPLOAD"))
+				Expect(copiedPackage.SourceImage).To(BeEmpty())
+				Expect(copiedPackage.ImageRef).To(Equal(fmt.Sprintf("container.registry/foo/my/prefix-%s-packages", targetApp.Name)))
+
+				copiedCFPackage := new(korifiv1alpha1.CFPackage)
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Name: copiedPackage.GUID, Namespace: space.Name}, copiedCFPackage)).To(Succeed())
+				Expect(copiedCFPackage.Spec.AppRef.Name).To(Equal(targetApp.Name))
+				Expect(copiedCFPackage.Spec.Source.Registry.Image).To(BeEmpty())
+			})
+
+			When("the source package is a docker package with credentials", func() {
+				BeforeEach(func() {
+					source, err := packageRepo.CreatePackage(ctx, authInfo, repositories.CreatePackageMessage{
+						Type:      "docker",
+						AppGUID:   app.Name,
+						SpaceGUID: space.Name,
+						Data: &repositories.PackageData{
+							Image:    "registry.example.org/my/image:latest",
+							Username: tools.PtrTo("user"),
+							Password: tools.PtrTo("pass"),
+						},
+					})
+					Expect(err).NotTo(HaveOccurred())
+					sourceGUID = source.GUID
+				})
+
+				It("copies the image pull secret for the new package", func() {
+					Expect(copyErr).NotTo(HaveOccurred())
+					Expect(copiedPackage.DockerImage).To(Equal("registry.example.org/my/image:latest"))
+
+					copiedCFPackage := new(korifiv1alpha1.CFPackage)
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: copiedPackage.GUID, Namespace: space.Name}, copiedCFPackage)).To(Succeed())
+					Expect(copiedCFPackage.Spec.Source.Registry.ImagePullSecrets).To(ConsistOf(corev1.LocalObjectReference{Name: copiedPackage.GUID}))
+
+					secret := new(corev1.Secret)
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Name: copiedPackage.GUID, Namespace: space.Name}, secret)).To(Succeed())
+					Expect(secret.Type).To(Equal(corev1.SecretTypeDockerConfigJson))
+					Expect(secret.Data).To(HaveKeyWithValue(corev1.DockerConfigJsonKey,
+						MatchJSON(`{"auths":{"registry.example.org":{"username":"user","password":"pass"}}}`)))
+					Expect(secret.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"Kind": Equal("CFApp"),
+						"Name": Equal(targetApp.Name),
+					})))
+				})
+
+				When("copying the image pull secret fails", func() {
+					BeforeEach(func() {
+						Expect(k8sClient.Delete(ctx, &corev1.Secret{
+							ObjectMeta: metav1.ObjectMeta{Name: sourceGUID, Namespace: space.Name},
+						})).To(Succeed())
+					})
+
+					It("deletes the package copy", func() {
+						Expect(copyErr).To(HaveOccurred())
+
+						Eventually(func(g Gomega) {
+							packages := new(korifiv1alpha1.CFPackageList)
+							g.Expect(k8sClient.List(ctx, packages, client.InNamespace(space.Name))).To(Succeed())
+							g.Expect(packages.Items).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{
+								"Spec": MatchFields(IgnoreExtras, Fields{
+									"AppRef": Equal(corev1.LocalObjectReference{Name: targetApp.Name}),
+								}),
+							})))
+						}).Should(Succeed())
+					})
+				})
+			})
+
+			When("the source package does not exist", func() {
+				BeforeEach(func() {
+					sourceGUID = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(copyErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+	})
+
+	Describe("DeletePackage", func() {
+		var (
+			packageGUID string
+			deleteErr   error
+		)
+
+		BeforeEach(func() {
+			packageGUID = generateGUID()
+			createPackageCR(ctx, k8sClient, packageGUID, app.Name, space.Name, "")
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = packageRepo.DeletePackage(ctx, authInfo, repositories.DeletePackageMessage{
+				GUID:      packageGUID,
+				SpaceGUID: space.Name,
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("deletes the package", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				Eventually(func(g Gomega) {
+					err := k8sClient.Get(ctx, types.NamespacedName{Name: packageGUID, Namespace: space.Name}, new(korifiv1alpha1.CFPackage))
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+
+			When("the package does not exist", func() {
+				BeforeEach(func() {
+					packageGUID = "i-do-not-exist"
+				})
+
+				It("returns a not found error", func() {
+					Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
 		})
 	})
 
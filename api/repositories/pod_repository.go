@@ -47,6 +47,13 @@ func (r *PodRepo) listPods(ctx context.Context, authInfo authorization.Info, lis
 	return podList.Items, nil
 }
 
+func (r *PodRepo) ListPods(ctx context.Context, authInfo authorization.Info, namespace string, podSelector client.MatchingLabels) ([]corev1.Pod, error) {
+	return r.listPods(ctx, authInfo, client.ListOptions{
+		Namespace:     namespace,
+		LabelSelector: labels.SelectorFromSet(labels.Set(podSelector)),
+	})
+}
+
 type RuntimeLogsMessage struct {
 	SpaceGUID   string
 	AppGUID     string
@@ -0,0 +1,339 @@
+package repositories
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"strings"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/tools/image"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	// ResourceCacheMinimumSize and ResourceCacheMaximumSize bound the size
+	// of the files that are cached, as the resource pool of the Cloud
+	// Controller does
+	ResourceCacheMinimumSize = 64 * 1024
+	ResourceCacheMaximumSize = 512 * 1024 * 1024
+
+	// ResourceCacheConcurrency bounds the number of registry requests made
+	// at once when looking up or caching the resources of a package
+	ResourceCacheConcurrency = 16
+
+	resourceCacheRepositoryName = "resource-cache"
+	resourceCacheFileName       = "resource"
+)
+
+type ResourceRecord struct {
+	SHA1 string
+	Size int64
+	Path string
+	Mode fs.FileMode
+}
+
+// ResourceCacheRepo stores the files of uploaded packages in the container
+// registry, so that later uploads can leave them out. Each file is stored as
+// a single layer image in the resource cache repository, tagged with the SHA1
+// of the file.
+type ResourceCacheRepo struct {
+	imageClient         ImageClient
+	repositoryCreator   RepositoryCreator
+	repositoryRef       string
+	pushSecretNames     []string
+	pushSecretNamespace string
+}
+
+func NewResourceCacheRepo(
+	imageClient ImageClient,
+	repositoryCreator RepositoryCreator,
+	repositoryPrefix string,
+	pushSecretNames []string,
+	pushSecretNamespace string,
+) *ResourceCacheRepo {
+	return &ResourceCacheRepo{
+		imageClient:         imageClient,
+		repositoryCreator:   repositoryCreator,
+		repositoryRef:       repositoryPrefix + resourceCacheRepositoryName,
+		pushSecretNames:     pushSecretNames,
+		pushSecretNamespace: pushSecretNamespace,
+	}
+}
+
+// MatchResources returns the resources that are in the cache
+func (r *ResourceCacheRepo) MatchResources(ctx context.Context, resources []ResourceRecord) ([]ResourceRecord, error) {
+	cached := make([]bool, len(resources))
+	err := forEachConcurrently(ctx, len(resources), func(ctx context.Context, i int) error {
+		if !isCacheable(resources[i].Size) {
+			return nil
+		}
+
+		var err error
+		cached[i], err = r.isCached(ctx, resources[i].SHA1)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	matches := []ResourceRecord{}
+	for i, resource := range resources {
+		if cached[i] {
+			matches = append(matches, resource)
+		}
+	}
+
+	return matches, nil
+}
+
+// CacheResources adds the files of a zip archive to the cache. Archives that
+// are not zip archives, such as gzipped tarballs, are ignored.
+func (r *ResourceCacheRepo) CacheResources(ctx context.Context, bits io.ReaderAt, size int64) error {
+	zipReader, err := zip.NewReader(bits, size)
+	if err != nil {
+		return nil
+	}
+
+	files := zipReader.File
+	shas := make([]string, len(files))
+	err = forEachConcurrently(ctx, len(files), func(ctx context.Context, i int) error {
+		file := files[i]
+		if !file.Mode().IsRegular() || !isCacheable(int64(file.UncompressedSize64)) {
+			return nil
+		}
+
+		sha, err := fileSHA1(file)
+		if err != nil {
+			return fmt.Errorf("failed to compute the checksum of %q: %w", file.Name, err)
+		}
+
+		cached, err := r.isCached(ctx, sha)
+		if err != nil {
+			return err
+		}
+		if !cached {
+			shas[i] = sha
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	var uncached []int
+	for i, sha := range shas {
+		if sha != "" {
+			uncached = append(uncached, i)
+		}
+	}
+	if len(uncached) == 0 {
+		return nil
+	}
+
+	if err = r.repositoryCreator.CreateRepository(ctx, r.repositoryRef); err != nil {
+		return fmt.Errorf("failed to create resource cache repository: %w", err)
+	}
+
+	return forEachConcurrently(ctx, len(uncached), func(ctx context.Context, i int) error {
+		return r.cacheFile(ctx, files[uncached[i]], shas[uncached[i]])
+	})
+}
+
+// AssemblePackage returns a zip archive with the files of the bits, if any,
+// and the cached resources. The archive is built while it is being read, so
+// it must be closed by the caller.
+func (r *ResourceCacheRepo) AssemblePackage(ctx context.Context, bits io.ReaderAt, size int64, resources []ResourceRecord) (io.ReadCloser, error) {
+	var bitsFiles []*zip.File
+	if bits != nil {
+		zipReader, err := zip.NewReader(bits, size)
+		if err != nil {
+			return nil, apierrors.NewUnprocessableEntityError(err, "Bits must be a zip archive when resources are given.")
+		}
+		bitsFiles = zipReader.File
+	}
+
+	err := forEachConcurrently(ctx, len(resources), func(ctx context.Context, i int) error {
+		cached, err := r.isCached(ctx, resources[i].SHA1)
+		if err != nil {
+			return err
+		}
+		if !cached {
+			return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Resource %q is not in the resource cache.", resources[i].Path))
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pipeReader, pipeWriter := io.Pipe()
+
+	go func() {
+		zipWriter := zip.NewWriter(pipeWriter)
+		err := r.writePackage(ctx, zipWriter, bitsFiles, resources)
+		if closeErr := zipWriter.Close(); err == nil {
+			err = closeErr
+		}
+		pipeWriter.CloseWithError(err)
+	}()
+
+	return pipeReader, nil
+}
+
+func (r *ResourceCacheRepo) writePackage(ctx context.Context, zipWriter *zip.Writer, bitsFiles []*zip.File, resources []ResourceRecord) error {
+	paths := map[string]bool{}
+	for _, file := range bitsFiles {
+		if err := zipWriter.Copy(file); err != nil {
+			return fmt.Errorf("failed to copy %q: %w", file.Name, err)
+		}
+		paths[file.Name] = true
+	}
+
+	for _, resource := range resources {
+		if paths[resource.Path] {
+			continue
+		}
+
+		header := &zip.FileHeader{
+			Name:   resource.Path,
+			Method: zip.Deflate,
+		}
+		header.SetMode(resource.Mode)
+
+		writer, err := zipWriter.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+
+		if err = r.readCachedFile(ctx, resource.SHA1, writer); err != nil {
+			return fmt.Errorf("failed to read resource %q from the cache: %w", resource.Path, err)
+		}
+		paths[resource.Path] = true
+	}
+
+	return nil
+}
+
+func (r *ResourceCacheRepo) isCached(ctx context.Context, sha string) (bool, error) {
+	cached, err := r.imageClient.Exists(ctx, r.creds(), r.resourceRef(sha))
+	if err != nil {
+		return false, apierrors.NewBlobstoreUnavailableError(fmt.Errorf("checking resource cache for %q failed: %w", sha, err))
+	}
+
+	return cached, nil
+}
+
+func (r *ResourceCacheRepo) cacheFile(ctx context.Context, file *zip.File, sha string) error {
+	pipeReader, pipeWriter := io.Pipe()
+	defer pipeReader.Close()
+
+	go func() {
+		zipWriter := zip.NewWriter(pipeWriter)
+		err := copyZipFile(zipWriter, file)
+		if closeErr := zipWriter.Close(); err == nil {
+			err = closeErr
+		}
+		pipeWriter.CloseWithError(err)
+	}()
+
+	if _, err := r.imageClient.Push(ctx, r.creds(), r.repositoryRef, pipeReader, sha); err != nil {
+		return apierrors.NewBlobstoreUnavailableError(fmt.Errorf("caching %q failed: %w", file.Name, err))
+	}
+
+	return nil
+}
+
+func (r *ResourceCacheRepo) readCachedFile(ctx context.Context, sha string, writer io.Writer) error {
+	reader, err := r.imageClient.Download(ctx, r.creds(), r.resourceRef(sha))
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return errors.New("resource not found in cached image")
+		}
+		if err != nil {
+			return err
+		}
+
+		if strings.TrimPrefix(header.Name, "/") == resourceCacheFileName {
+			_, err = io.Copy(writer, tarReader)
+			return err
+		}
+	}
+}
+
+func (r *ResourceCacheRepo) resourceRef(sha string) string {
+	return r.repositoryRef + ":" + sha
+}
+
+func (r *ResourceCacheRepo) creds() image.Creds {
+	return image.Creds{
+		Namespace:   r.pushSecretNamespace,
+		SecretNames: r.pushSecretNames,
+	}
+}
+
+// forEachConcurrently calls fn for the indexes up to count, with at most
+// ResourceCacheConcurrency calls running at once. It returns the first error,
+// cancelling the context of the calls still running.
+func forEachConcurrently(ctx context.Context, count int, fn func(ctx context.Context, i int) error) error {
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(ResourceCacheConcurrency)
+	for i := 0; i < count; i++ {
+		i := i
+		group.Go(func() error {
+			return fn(groupCtx, i)
+		})
+	}
+
+	return group.Wait()
+}
+
+func copyZipFile(zipWriter *zip.Writer, file *zip.File) error {
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	writer, err := zipWriter.Create(resourceCacheFileName)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(writer, reader)
+	return err
+}
+
+func fileSHA1(file *zip.File) (string, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	hash := sha1.New()
+	if _, err = io.Copy(hash, reader); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+func isCacheable(size int64) bool {
+	return size >= ResourceCacheMinimumSize && size <= ResourceCacheMaximumSize
+}
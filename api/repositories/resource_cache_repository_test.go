@@ -0,0 +1,356 @@
+package repositories_test
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/fake"
+	"code.cloudfoundry.org/korifi/tools/image"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResourceCacheRepo", func() {
+	var (
+		imageClient *fake.ImageClient
+		repoCreator *fake.RepositoryCreator
+		repo        *repositories.ResourceCacheRepo
+		cache       map[string][]byte
+		cacheMutex  *sync.Mutex
+		bigFile     []byte
+		bigFileSHA  string
+	)
+
+	BeforeEach(func() {
+		imageClient = new(fake.ImageClient)
+		repoCreator = new(fake.RepositoryCreator)
+		repo = repositories.NewResourceCacheRepo(imageClient, repoCreator, "registry.example.org/prefix-", []string{"push-secret"}, rootNamespace)
+
+		bigFile = bytes.Repeat([]byte("a"), repositories.ResourceCacheMinimumSize)
+		bigFileSHA = sha1Hex(bigFile)
+
+		cache = map[string][]byte{}
+		cacheMutex = new(sync.Mutex)
+		imageClient.ExistsStub = func(_ context.Context, _ image.Creds, imageRef string) (bool, error) {
+			cacheMutex.Lock()
+			defer cacheMutex.Unlock()
+			_, ok := cache[strings.TrimPrefix(imageRef, "registry.example.org/prefix-resource-cache:")]
+			return ok, nil
+		}
+		imageClient.PushStub = func(_ context.Context, _ image.Creds, _ string, zipReader io.Reader, tags ...string) (string, error) {
+			files := readZip(zipReader)
+			cacheMutex.Lock()
+			defer cacheMutex.Unlock()
+			cache[tags[0]] = files["resource"]
+			return "registry.example.org/prefix-resource-cache@sha256:abc", nil
+		}
+		imageClient.DownloadStub = func(_ context.Context, _ image.Creds, imageRef string) (io.ReadCloser, error) {
+			cacheMutex.Lock()
+			content := cache[strings.TrimPrefix(imageRef, "registry.example.org/prefix-resource-cache:")]
+			cacheMutex.Unlock()
+
+			buf := new(bytes.Buffer)
+			tarWriter := tar.NewWriter(buf)
+			Expect(tarWriter.WriteHeader(&tar.Header{Name: "/resource", Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(content))})).To(Succeed())
+			_, err := tarWriter.Write(content)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tarWriter.Close()).To(Succeed())
+
+			return io.NopCloser(buf), nil
+		}
+	})
+
+	Describe("MatchResources", func() {
+		var (
+			resources []repositories.ResourceRecord
+			matches   []repositories.ResourceRecord
+			matchErr  error
+		)
+
+		BeforeEach(func() {
+			cache[bigFileSHA] = bigFile
+			resources = []repositories.ResourceRecord{
+				{SHA1: bigFileSHA, Size: int64(len(bigFile)), Path: "cached"},
+				{SHA1: sha1Hex([]byte("unknown")), Size: repositories.ResourceCacheMinimumSize, Path: "not-cached"},
+				{SHA1: sha1Hex([]byte("small")), Size: 5, Path: "small"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			matches, matchErr = repo.MatchResources(ctx, resources)
+		})
+
+		It("returns the cached resources", func() {
+			Expect(matchErr).NotTo(HaveOccurred())
+			Expect(matches).To(Equal([]repositories.ResourceRecord{resources[0]}))
+		})
+
+		It("only looks up resources within the cacheable size", func() {
+			Expect(imageClient.ExistsCallCount()).To(Equal(2))
+			var actualRefs []string
+			for i := 0; i < imageClient.ExistsCallCount(); i++ {
+				_, creds, actualRef := imageClient.ExistsArgsForCall(i)
+				Expect(creds).To(Equal(image.Creds{Namespace: rootNamespace, SecretNames: []string{"push-secret"}}))
+				actualRefs = append(actualRefs, actualRef)
+			}
+			Expect(actualRefs).To(ConsistOf(
+				"registry.example.org/prefix-resource-cache:"+bigFileSHA,
+				"registry.example.org/prefix-resource-cache:"+sha1Hex([]byte("unknown")),
+			))
+		})
+
+		When("there are many resources", func() {
+			var maxInFlight int
+
+			BeforeEach(func() {
+				resources = nil
+				for i := 0; i < 3*repositories.ResourceCacheConcurrency; i++ {
+					resources = append(resources, repositories.ResourceRecord{
+						SHA1: sha1Hex([]byte{byte(i)}),
+						Size: repositories.ResourceCacheMinimumSize,
+					})
+				}
+
+				maxInFlight = 0
+				inFlight := 0
+				imageClient.ExistsStub = func(context.Context, image.Creds, string) (bool, error) {
+					cacheMutex.Lock()
+					inFlight++
+					if inFlight > maxInFlight {
+						maxInFlight = inFlight
+					}
+					cacheMutex.Unlock()
+
+					time.Sleep(10 * time.Millisecond)
+
+					cacheMutex.Lock()
+					inFlight--
+					cacheMutex.Unlock()
+					return false, nil
+				}
+			})
+
+			It("looks them up concurrently, with a bounded number of requests at once", func() {
+				Expect(matchErr).NotTo(HaveOccurred())
+				Expect(imageClient.ExistsCallCount()).To(Equal(len(resources)))
+				Expect(maxInFlight).To(BeNumerically(">", 1))
+				Expect(maxInFlight).To(BeNumerically("<=", repositories.ResourceCacheConcurrency))
+			})
+		})
+
+		When("looking up a resource fails", func() {
+			BeforeEach(func() {
+				imageClient.ExistsStub = nil
+				imageClient.ExistsReturns(false, errors.New("boom"))
+			})
+
+			It("returns a blobstore unavailable error", func() {
+				Expect(matchErr).To(BeAssignableToTypeOf(apierrors.BlobstoreUnavailableError{}))
+			})
+		})
+	})
+
+	Describe("CacheResources", func() {
+		var (
+			bits     []byte
+			cacheErr error
+		)
+
+		BeforeEach(func() {
+			bits = createZip(map[string][]byte{
+				"big":   bigFile,
+				"small": []byte("small"),
+			})
+		})
+
+		JustBeforeEach(func() {
+			cacheErr = repo.CacheResources(ctx, bytes.NewReader(bits), int64(len(bits)))
+		})
+
+		It("caches the files within the cacheable size", func() {
+			Expect(cacheErr).NotTo(HaveOccurred())
+			Expect(cache).To(HaveLen(1))
+			Expect(cache).To(HaveKeyWithValue(bigFileSHA, bigFile))
+
+			Expect(imageClient.PushCallCount()).To(Equal(1))
+			_, _, actualRepoRef, _, actualTags := imageClient.PushArgsForCall(0)
+			Expect(actualRepoRef).To(Equal("registry.example.org/prefix-resource-cache"))
+			Expect(actualTags).To(ConsistOf(bigFileSHA))
+		})
+
+		It("creates the resource cache repository", func() {
+			Expect(repoCreator.CreateRepositoryCallCount()).To(Equal(1))
+			_, actualRepoRef := repoCreator.CreateRepositoryArgsForCall(0)
+			Expect(actualRepoRef).To(Equal("registry.example.org/prefix-resource-cache"))
+		})
+
+		When("the files are already cached", func() {
+			BeforeEach(func() {
+				cache[bigFileSHA] = bigFile
+			})
+
+			It("does not push them again", func() {
+				Expect(cacheErr).NotTo(HaveOccurred())
+				Expect(imageClient.PushCallCount()).To(Equal(0))
+				Expect(repoCreator.CreateRepositoryCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the bits are not a zip archive", func() {
+			BeforeEach(func() {
+				bits = []byte("not-a-zip")
+			})
+
+			It("ignores them", func() {
+				Expect(cacheErr).NotTo(HaveOccurred())
+				Expect(imageClient.PushCallCount()).To(Equal(0))
+			})
+		})
+
+		When("pushing a file fails", func() {
+			BeforeEach(func() {
+				imageClient.PushStub = nil
+				imageClient.PushReturns("", errors.New("boom"))
+			})
+
+			It("returns a blobstore unavailable error", func() {
+				Expect(cacheErr).To(BeAssignableToTypeOf(apierrors.BlobstoreUnavailableError{}))
+			})
+		})
+	})
+
+	Describe("AssemblePackage", func() {
+		var (
+			bits        io.ReaderAt
+			bitsSize    int64
+			resources   []repositories.ResourceRecord
+			assembled   io.ReadCloser
+			assembleErr error
+		)
+
+		BeforeEach(func() {
+			cache[bigFileSHA] = bigFile
+
+			bitsZip := createZip(map[string][]byte{"small": []byte("small")})
+			bits = bytes.NewReader(bitsZip)
+			bitsSize = int64(len(bitsZip))
+
+			resources = []repositories.ResourceRecord{
+				{SHA1: bigFileSHA, Size: int64(len(bigFile)), Path: "path/to/big", Mode: 0o755},
+			}
+		})
+
+		JustBeforeEach(func() {
+			assembled, assembleErr = repo.AssemblePackage(ctx, bits, bitsSize, resources)
+		})
+
+		It("returns a zip archive with the bits and the cached resources", func() {
+			Expect(assembleErr).NotTo(HaveOccurred())
+			defer assembled.Close()
+
+			content, err := io.ReadAll(assembled)
+			Expect(err).NotTo(HaveOccurred())
+			zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(zipReader.File).To(HaveLen(2))
+			Expect(readZip(bytes.NewReader(content))).To(Equal(map[string][]byte{
+				"small":       []byte("small"),
+				"path/to/big": bigFile,
+			}))
+			Expect(zipReader.File[1].Mode().Perm()).To(BeEquivalentTo(0o755))
+		})
+
+		When("there are no bits", func() {
+			BeforeEach(func() {
+				bits = nil
+				bitsSize = 0
+			})
+
+			It("returns a zip archive with the cached resources", func() {
+				Expect(assembleErr).NotTo(HaveOccurred())
+				defer assembled.Close()
+
+				Expect(readZip(assembled)).To(Equal(map[string][]byte{
+					"path/to/big": bigFile,
+				}))
+			})
+		})
+
+		When("a resource is not cached", func() {
+			BeforeEach(func() {
+				resources = append(resources, repositories.ResourceRecord{SHA1: sha1Hex([]byte("unknown")), Path: "unknown"})
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(assembleErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				Expect(assembleErr.(apierrors.UnprocessableEntityError).Detail()).To(ContainSubstring(`"unknown"`))
+			})
+		})
+
+		When("the bits are not a zip archive", func() {
+			BeforeEach(func() {
+				bits = strings.NewReader("not-a-zip")
+				bitsSize = 9
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(assembleErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			})
+		})
+	})
+})
+
+func sha1Hex(content []byte) string {
+	hash := sha1.Sum(content)
+	return hex.EncodeToString(hash[:])
+}
+
+func createZip(files map[string][]byte) []byte {
+	GinkgoHelper()
+
+	buf := new(bytes.Buffer)
+	zipWriter := zip.NewWriter(buf)
+	for name, content := range files {
+		writer, err := zipWriter.Create(name)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = writer.Write(content)
+		Expect(err).NotTo(HaveOccurred())
+	}
+	Expect(zipWriter.Close()).To(Succeed())
+
+	return buf.Bytes()
+}
+
+func readZip(reader io.Reader) map[string][]byte {
+	GinkgoHelper()
+
+	content, err := io.ReadAll(reader)
+	Expect(err).NotTo(HaveOccurred())
+
+	zipReader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	Expect(err).NotTo(HaveOccurred())
+
+	files := map[string][]byte{}
+	for _, file := range zipReader.File {
+		fileReader, err := file.Open()
+		Expect(err).NotTo(HaveOccurred())
+		files[file.Name], err = io.ReadAll(fileReader)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(fileReader.Close()).To(Succeed())
+	}
+
+	return files
+}
@@ -0,0 +1,402 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	RevisionResourceType = "Revision"
+
+	RevisionDescriptionInitial    = "Initial revision."
+	RevisionDescriptionNewDroplet = "New droplet deployed."
+	RevisionDescriptionRollback   = "Rolled back to revision %d."
+)
+
+type RevisionRepo struct {
+	userClientFactory  authorization.UserK8sClientFactory
+	namespaceRetriever NamespaceRetriever
+}
+
+func NewRevisionRepo(
+	userClientFactory authorization.UserK8sClientFactory,
+	namespaceRetriever NamespaceRetriever,
+) *RevisionRepo {
+	return &RevisionRepo{
+		userClientFactory:  userClientFactory,
+		namespaceRetriever: namespaceRetriever,
+	}
+}
+
+type RevisionRecord struct {
+	GUID        string
+	AppGUID     string
+	SpaceGUID   string
+	Version     int
+	DropletGUID string
+	Processes   map[string]string
+	Description string
+	Deployable  bool
+	Labels      map[string]string
+	Annotations map[string]string
+	CreatedAt   time.Time
+	UpdatedAt   *time.Time
+}
+
+type RevisionEnvVarsRecord struct {
+	RevisionGUID         string
+	EnvironmentVariables map[string]string
+}
+
+type ListRevisionsMessage struct {
+	AppGUID  string
+	Versions []string
+	Deployed bool
+}
+
+func (r *RevisionRepo) GetRevision(ctx context.Context, authInfo authorization.Info, guid string) (RevisionRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return RevisionRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfRevision, err := r.getCFRevision(ctx, userClient, guid)
+	if err != nil {
+		return RevisionRecord{}, err
+	}
+
+	return cfRevisionToRecord(*cfRevision), nil
+}
+
+// ListRevisions lists the revisions of an app ordered by version. When
+// Deployed is set, only the revision currently running is returned
+func (r *RevisionRepo) ListRevisions(ctx context.Context, authInfo authorization.Info, message ListRevisionsMessage) ([]RevisionRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, message.AppGUID, AppResourceType)
+	if err != nil {
+		return []RevisionRecord{}, fmt.Errorf("failed to get namespace for app: %w", err)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []RevisionRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfRevisionList := new(korifiv1alpha1.CFRevisionList)
+	err = userClient.List(ctx, cfRevisionList, client.InNamespace(ns), client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: message.AppGUID})
+	if err != nil {
+		return []RevisionRecord{}, fmt.Errorf("failed to list revisions: %w", apierrors.FromK8sError(err, RevisionResourceType))
+	}
+
+	preds := []func(korifiv1alpha1.CFRevision) bool{
+		SetPredicate(message.Versions, func(rev korifiv1alpha1.CFRevision) string { return strconv.Itoa(rev.Spec.Version) }),
+	}
+
+	if message.Deployed {
+		cfApp := new(korifiv1alpha1.CFApp)
+		err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: message.AppGUID}, cfApp)
+		if err != nil {
+			return []RevisionRecord{}, fmt.Errorf("failed to get app: %w", apierrors.FromK8sError(err, AppResourceType))
+		}
+
+		preds = append(preds, func(rev korifiv1alpha1.CFRevision) bool {
+			return cfApp.Spec.DesiredState == korifiv1alpha1.StartedState &&
+				rev.Name == cfApp.Annotations[korifiv1alpha1.CFAppDeployedRevisionKey]
+		})
+	}
+
+	records := []RevisionRecord{}
+	for _, cfRevision := range Filter(cfRevisionList.Items, preds...) {
+		records = append(records, cfRevisionToRecord(cfRevision))
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].Version < records[j].Version
+	})
+
+	return records, nil
+}
+
+// GetRevisionEnvironmentVariables returns the environment variables of the
+// app when the revision was deployed
+func (r *RevisionRepo) GetRevisionEnvironmentVariables(ctx context.Context, authInfo authorization.Info, guid string) (RevisionEnvVarsRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return RevisionEnvVarsRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfRevision, err := r.getCFRevision(ctx, userClient, guid)
+	if err != nil {
+		return RevisionEnvVarsRecord{}, err
+	}
+
+	envVarsRecord := RevisionEnvVarsRecord{
+		RevisionGUID:         guid,
+		EnvironmentVariables: map[string]string{},
+	}
+	if cfRevision.Spec.EnvSecretName == "" {
+		return envVarsRecord, nil
+	}
+
+	envSecret := new(corev1.Secret)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: cfRevision.Namespace, Name: cfRevision.Spec.EnvSecretName}, envSecret)
+	if err != nil {
+		return RevisionEnvVarsRecord{}, fmt.Errorf("failed to get environment variables of revision %q: %w", guid, apierrors.FromK8sError(err, RevisionResourceType))
+	}
+	envVarsRecord.EnvironmentVariables = convertByteSliceValuesToStrings(envSecret.Data)
+
+	return envVarsRecord, nil
+}
+
+func (r *RevisionRepo) getCFRevision(ctx context.Context, userClient client.Client, guid string) (*korifiv1alpha1.CFRevision, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, guid, RevisionResourceType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespace for revision: %w", err)
+	}
+
+	cfRevision := new(korifiv1alpha1.CFRevision)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: guid}, cfRevision)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revision %q: %w", guid, apierrors.FromK8sError(err, RevisionResourceType))
+	}
+
+	return cfRevision, nil
+}
+
+// createRevision snapshots the droplet, process commands and environment
+// variables of the app as the revision with the given version
+func createRevision(ctx context.Context, userClient client.Client, cfApp *korifiv1alpha1.CFApp, version int, description string) (*korifiv1alpha1.CFRevision, error) {
+	cfProcessList := new(korifiv1alpha1.CFProcessList)
+	err := userClient.List(ctx, cfProcessList, client.InNamespace(cfApp.Namespace), client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list processes: %w", apierrors.FromK8sError(err, ProcessResourceType))
+	}
+
+	// the detected commands of the processes may still come from the previous
+	// droplet, so take them from the droplet being deployed instead
+	commands := map[string]korifiv1alpha1.CFRevisionProcess{}
+	if cfApp.Spec.CurrentDropletRef.Name != "" {
+		cfBuild := new(korifiv1alpha1.CFBuild)
+		err = userClient.Get(ctx, client.ObjectKey{Namespace: cfApp.Namespace, Name: cfApp.Spec.CurrentDropletRef.Name}, cfBuild)
+		if client.IgnoreNotFound(err) != nil {
+			return nil, fmt.Errorf("failed to get droplet: %w", apierrors.FromK8sError(err, DropletResourceType))
+		}
+
+		droplet := cfBuild.Status.Droplet
+		if droplet == nil {
+			droplet = cfBuild.Spec.Droplet
+		}
+		if droplet != nil {
+			for _, processType := range droplet.ProcessTypes {
+				commands[processType.Type] = korifiv1alpha1.CFRevisionProcess{Type: processType.Type, Command: processType.Command}
+			}
+		}
+	}
+
+	for _, cfProcess := range cfProcessList.Items {
+		if cfProcess.Spec.Command != "" {
+			commands[cfProcess.Spec.ProcessType] = korifiv1alpha1.CFRevisionProcess{
+				Type:         cfProcess.Spec.ProcessType,
+				Command:      cfProcess.Spec.Command,
+				UserProvided: true,
+			}
+		}
+	}
+
+	processes := []korifiv1alpha1.CFRevisionProcess{}
+	for _, process := range commands {
+		processes = append(processes, process)
+	}
+	sort.Slice(processes, func(i, j int) bool {
+		return processes[i].Type < processes[j].Type
+	})
+
+	cfRevision := &korifiv1alpha1.CFRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: cfApp.Namespace,
+			Labels: map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey:         cfApp.Name,
+				korifiv1alpha1.CFRevisionVersionLabelKey: strconv.Itoa(version),
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: APIVersion,
+				Kind:       Kind,
+				Name:       cfApp.Name,
+				UID:        cfApp.UID,
+			}},
+		},
+		Spec: korifiv1alpha1.CFRevisionSpec{
+			AppRef:      corev1.LocalObjectReference{Name: cfApp.Name},
+			Version:     version,
+			DropletRef:  cfApp.Spec.CurrentDropletRef,
+			Processes:   processes,
+			Description: description,
+		},
+	}
+
+	appEnvSecret := new(corev1.Secret)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: cfApp.Namespace, Name: cfApp.Spec.EnvSecretName}, appEnvSecret)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, fmt.Errorf("failed to get app env secret: %w", apierrors.FromK8sError(err, AppEnvResourceType))
+	}
+	if err == nil {
+		cfRevision.Spec.EnvSecretName = cfRevision.Name + "-env"
+	}
+
+	err = userClient.Create(ctx, cfRevision)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create revision: %w", apierrors.FromK8sError(err, RevisionResourceType))
+	}
+
+	if cfRevision.Spec.EnvSecretName == "" {
+		return cfRevision, nil
+	}
+
+	err = userClient.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfRevision.Spec.EnvSecretName,
+			Namespace: cfRevision.Namespace,
+			Labels:    map[string]string{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: APIVersion,
+				Kind:       "CFRevision",
+				Name:       cfRevision.Name,
+				UID:        cfRevision.UID,
+			}},
+		},
+		Data: appEnvSecret.Data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create revision env secret: %w", apierrors.FromK8sError(err, RevisionResourceType))
+	}
+
+	return cfRevision, nil
+}
+
+// restoreRevision sets the environment variables and process commands of the
+// app back to those of the revision. Commands the user had not set explicitly
+// are cleared so the processes fall back to the commands of the droplet
+func restoreRevision(ctx context.Context, userClient client.Client, cfApp *korifiv1alpha1.CFApp, cfRevision *korifiv1alpha1.CFRevision) error {
+	if cfRevision.Spec.EnvSecretName != "" {
+		revisionEnvSecret := new(corev1.Secret)
+		err := userClient.Get(ctx, client.ObjectKey{Namespace: cfRevision.Namespace, Name: cfRevision.Spec.EnvSecretName}, revisionEnvSecret)
+		if err != nil {
+			return fmt.Errorf("failed to get revision env secret: %w", apierrors.FromK8sError(err, RevisionResourceType))
+		}
+
+		appEnvSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: cfApp.Namespace, Name: cfApp.Spec.EnvSecretName},
+		}
+		err = userClient.Get(ctx, client.ObjectKeyFromObject(appEnvSecret), appEnvSecret)
+		if err != nil {
+			return fmt.Errorf("failed to get app env secret: %w", apierrors.FromK8sError(err, AppEnvResourceType))
+		}
+
+		err = k8s.PatchResource(ctx, userClient, appEnvSecret, func() {
+			appEnvSecret.Data = revisionEnvSecret.Data
+		})
+		if err != nil {
+			return fmt.Errorf("failed to restore app env secret: %w", apierrors.FromK8sError(err, AppEnvResourceType))
+		}
+	}
+
+	commands := map[string]string{}
+	for _, process := range cfRevision.Spec.Processes {
+		if process.UserProvided {
+			commands[process.Type] = process.Command
+		}
+	}
+
+	cfProcessList := new(korifiv1alpha1.CFProcessList)
+	err := userClient.List(ctx, cfProcessList, client.InNamespace(cfApp.Namespace), client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name})
+	if err != nil {
+		return fmt.Errorf("failed to list processes: %w", apierrors.FromK8sError(err, ProcessResourceType))
+	}
+
+	for i := range cfProcessList.Items {
+		cfProcess := &cfProcessList.Items[i]
+		command := commands[cfProcess.Spec.ProcessType]
+
+		err = k8s.PatchResource(ctx, userClient, cfProcess, func() {
+			cfProcess.Spec.Command = command
+		})
+		if err != nil {
+			return fmt.Errorf("failed to restore process command: %w", apierrors.FromK8sError(err, ProcessResourceType))
+		}
+	}
+
+	return nil
+}
+
+// getLatestRevision returns the revision of the app with the highest version,
+// or nil when the app has not been deployed yet
+func getLatestRevision(ctx context.Context, userClient client.Client, cfApp *korifiv1alpha1.CFApp) (*korifiv1alpha1.CFRevision, error) {
+	cfRevisionList := new(korifiv1alpha1.CFRevisionList)
+	err := userClient.List(ctx, cfRevisionList, client.InNamespace(cfApp.Namespace), client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list revisions: %w", apierrors.FromK8sError(err, RevisionResourceType))
+	}
+
+	var latest *korifiv1alpha1.CFRevision
+	for i := range cfRevisionList.Items {
+		if latest == nil || cfRevisionList.Items[i].Spec.Version > latest.Spec.Version {
+			latest = &cfRevisionList.Items[i]
+		}
+	}
+
+	return latest, nil
+}
+
+// getRollbackRevision returns the revision of the app to roll back to
+func getRollbackRevision(ctx context.Context, userClient client.Client, cfApp *korifiv1alpha1.CFApp, revisionGUID string) (*korifiv1alpha1.CFRevision, error) {
+	cfRevision := new(korifiv1alpha1.CFRevision)
+	err := userClient.Get(ctx, client.ObjectKey{Namespace: cfApp.Namespace, Name: revisionGUID}, cfRevision)
+	if k8serrors.IsNotFound(err) {
+		return nil, apierrors.NewUnprocessableEntityError(err, "The revision does not exist or belongs to another app.")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revision %q: %w", revisionGUID, apierrors.FromK8sError(err, RevisionResourceType))
+	}
+
+	if cfRevision.Spec.AppRef.Name != cfApp.Name {
+		return nil, apierrors.NewUnprocessableEntityError(nil, "The revision does not exist or belongs to another app.")
+	}
+
+	return cfRevision, nil
+}
+
+func cfRevisionToRecord(cfRevision korifiv1alpha1.CFRevision) RevisionRecord {
+	processes := map[string]string{}
+	for _, process := range cfRevision.Spec.Processes {
+		processes[process.Type] = process.Command
+	}
+
+	return RevisionRecord{
+		GUID:        cfRevision.Name,
+		AppGUID:     cfRevision.Spec.AppRef.Name,
+		SpaceGUID:   cfRevision.Namespace,
+		Version:     cfRevision.Spec.Version,
+		DropletGUID: cfRevision.Spec.DropletRef.Name,
+		Processes:   processes,
+		Description: cfRevision.Spec.Description,
+		Deployable:  cfRevision.Spec.DropletRef.Name != "",
+		Labels:      cfRevision.Labels,
+		Annotations: cfRevision.Annotations,
+		CreatedAt:   cfRevision.CreationTimestamp.Time,
+		UpdatedAt:   getLastUpdatedTime(&cfRevision),
+	}
+}
@@ -0,0 +1,241 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("RevisionRepo", func() {
+	var (
+		repo    *RevisionRepo
+		cfOrg   *korifiv1alpha1.CFOrg
+		cfSpace *korifiv1alpha1.CFSpace
+		cfApp   *korifiv1alpha1.CFApp
+	)
+
+	createRevision := func(version int, envSecretName string) *korifiv1alpha1.CFRevision {
+		cfRevision := &korifiv1alpha1.CFRevision{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      prefixedGUID("revision"),
+				Namespace: cfSpace.Name,
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+				},
+			},
+			Spec: korifiv1alpha1.CFRevisionSpec{
+				AppRef:        corev1.LocalObjectReference{Name: cfApp.Name},
+				Version:       version,
+				DropletRef:    corev1.LocalObjectReference{Name: "droplet-guid"},
+				Processes:     []korifiv1alpha1.CFRevisionProcess{{Type: "web", Command: "bundle exec rackup"}},
+				Description:   RevisionDescriptionInitial,
+				EnvSecretName: envSecretName,
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfRevision)).To(Succeed())
+		return cfRevision
+	}
+
+	BeforeEach(func() {
+		repo = NewRevisionRepo(userClientFactory, namespaceRetriever)
+
+		cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space"))
+		cfApp = createApp(cfSpace.Name)
+	})
+
+	Describe("GetRevision", func() {
+		var (
+			cfRevision *korifiv1alpha1.CFRevision
+			record     RevisionRecord
+			getErr     error
+		)
+
+		BeforeEach(func() {
+			cfRevision = createRevision(1, "")
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = repo.GetRevision(ctx, authInfo, cfRevision.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns the revision", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record).To(MatchFields(IgnoreExtras, Fields{
+					"GUID":        Equal(cfRevision.Name),
+					"AppGUID":     Equal(cfApp.Name),
+					"SpaceGUID":   Equal(cfSpace.Name),
+					"Version":     Equal(1),
+					"DropletGUID": Equal("droplet-guid"),
+					"Processes":   Equal(map[string]string{"web": "bundle exec rackup"}),
+					"Description": Equal(RevisionDescriptionInitial),
+					"Deployable":  BeTrue(),
+				}))
+			})
+		})
+
+		When("the revision does not exist", func() {
+			JustBeforeEach(func() {
+				_, getErr = repo.GetRevision(ctx, authInfo, "i-do-not-exist")
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("ListRevisions", func() {
+		var (
+			message ListRevisionsMessage
+			records []RevisionRecord
+			listErr error
+		)
+
+		var deployedRevision *korifiv1alpha1.CFRevision
+
+		BeforeEach(func() {
+			message = ListRevisionsMessage{AppGUID: cfApp.Name}
+			deployedRevision = createRevision(2, "")
+			createRevision(1, "")
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = repo.ListRevisions(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(listErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns the revisions ordered by version", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(HaveLen(2))
+				Expect(records[0].Version).To(Equal(1))
+				Expect(records[1].Version).To(Equal(2))
+			})
+
+			When("versions are specified", func() {
+				BeforeEach(func() {
+					message.Versions = []string{"2"}
+				})
+
+				It("returns the matching revisions", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(HaveLen(1))
+					Expect(records[0].Version).To(Equal(2))
+				})
+			})
+
+			When("only deployed revisions are requested", func() {
+				BeforeEach(func() {
+					message.Deployed = true
+					Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+						cfApp.Annotations[korifiv1alpha1.CFAppDeployedRevisionKey] = deployedRevision.Name
+						cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+					})).To(Succeed())
+				})
+
+				It("returns the revision the app is running", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(HaveLen(1))
+					Expect(records[0].GUID).To(Equal(deployedRevision.Name))
+				})
+
+				When("the app has been stopped and started again", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+							cfApp.Spec.DesiredState = korifiv1alpha1.StoppedState
+						})).To(Succeed())
+						// stopping the app bumps its app-rev
+						Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+							cfApp.Annotations[korifiv1alpha1.CFAppRevisionKey] = "7"
+							cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+						})).To(Succeed())
+					})
+
+					It("still returns the revision the app is running", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(records).To(HaveLen(1))
+						Expect(records[0].GUID).To(Equal(deployedRevision.Name))
+					})
+				})
+
+				When("the app is stopped", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(ctx, k8sClient, cfApp, func() {
+							cfApp.Spec.DesiredState = korifiv1alpha1.StoppedState
+						})).To(Succeed())
+					})
+
+					It("returns no revisions", func() {
+						Expect(listErr).NotTo(HaveOccurred())
+						Expect(records).To(BeEmpty())
+					})
+				})
+			})
+		})
+	})
+
+	Describe("GetRevisionEnvironmentVariables", func() {
+		var (
+			cfRevision *korifiv1alpha1.CFRevision
+			record     RevisionEnvVarsRecord
+			getErr     error
+		)
+
+		BeforeEach(func() {
+			envSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      prefixedGUID("revision-env"),
+					Namespace: cfSpace.Name,
+				},
+				StringData: map[string]string{"FOO": "bar"},
+			}
+			Expect(k8sClient.Create(ctx, envSecret)).To(Succeed())
+			cfRevision = createRevision(1, envSecret.Name)
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = repo.GetRevisionEnvironmentVariables(ctx, authInfo, cfRevision.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns the environment variables of the revision", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.RevisionGUID).To(Equal(cfRevision.Name))
+				Expect(record.EnvironmentVariables).To(Equal(map[string]string{"FOO": "bar"}))
+			})
+		})
+	})
+})
@@ -0,0 +1,514 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	SecurityGroupResourceType = "Security Group"
+
+	SecurityGroupRunningWorkload = "running"
+	SecurityGroupStagingWorkload = "staging"
+)
+
+type SecurityGroupRepo struct {
+	userClientFactory    authorization.UserK8sClientFactory
+	namespaceRetriever   NamespaceRetriever
+	namespacePermissions *authorization.NamespacePermissions
+	rootNamespace        string
+}
+
+func NewSecurityGroupRepo(
+	userClientFactory authorization.UserK8sClientFactory,
+	namespaceRetriever NamespaceRetriever,
+	namespacePermissions *authorization.NamespacePermissions,
+	rootNamespace string,
+) *SecurityGroupRepo {
+	return &SecurityGroupRepo{
+		userClientFactory:    userClientFactory,
+		namespaceRetriever:   namespaceRetriever,
+		namespacePermissions: namespacePermissions,
+		rootNamespace:        rootNamespace,
+	}
+}
+
+type SecurityGroupRule struct {
+	Protocol    string
+	Destination string
+	Ports       string
+	Type        *int32
+	Code        *int32
+	Description string
+	Log         bool
+}
+
+type SecurityGroupWorkloads struct {
+	Running bool
+	Staging bool
+}
+
+type SecurityGroupRecord struct {
+	GUID              string
+	Name              string
+	Rules             []SecurityGroupRule
+	GloballyEnabled   SecurityGroupWorkloads
+	RunningSpaceGUIDs []string
+	StagingSpaceGUIDs []string
+	CreatedAt         time.Time
+	UpdatedAt         *time.Time
+	DeletedAt         *time.Time
+}
+
+type CreateSecurityGroupMessage struct {
+	Name              string
+	Rules             []SecurityGroupRule
+	GloballyEnabled   SecurityGroupWorkloads
+	RunningSpaceGUIDs []string
+	StagingSpaceGUIDs []string
+}
+
+type UpdateSecurityGroupMessage struct {
+	GUID                   string
+	Name                   *string
+	Rules                  *[]SecurityGroupRule
+	GloballyEnabledRunning *bool
+	GloballyEnabledStaging *bool
+}
+
+func (m UpdateSecurityGroupMessage) Apply(cfSecurityGroup *korifiv1alpha1.CFSecurityGroup) {
+	if m.Name != nil {
+		cfSecurityGroup.Spec.DisplayName = *m.Name
+	}
+	if m.Rules != nil {
+		cfSecurityGroup.Spec.Rules = toCFSecurityGroupRules(*m.Rules)
+	}
+	if m.GloballyEnabledRunning != nil {
+		cfSecurityGroup.Spec.GloballyEnabled.Running = *m.GloballyEnabledRunning
+	}
+	if m.GloballyEnabledStaging != nil {
+		cfSecurityGroup.Spec.GloballyEnabled.Staging = *m.GloballyEnabledStaging
+	}
+}
+
+type ListSecurityGroupsMessage struct {
+	GUIDs                  []string
+	Names                  []string
+	GloballyEnabledRunning *bool
+	GloballyEnabledStaging *bool
+	RunningSpaceGUIDs      []string
+	StagingSpaceGUIDs      []string
+}
+
+type BindSecurityGroupMessage struct {
+	GUID       string
+	SpaceGUIDs []string
+	Workload   string
+}
+
+type UnbindSecurityGroupMessage struct {
+	GUID      string
+	SpaceGUID string
+	Workload  string
+}
+
+func (r *SecurityGroupRepo) CreateSecurityGroup(ctx context.Context, authInfo authorization.Info, message CreateSecurityGroupMessage) (SecurityGroupRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	spaces := map[string]korifiv1alpha1.SecurityGroupWorkloads{}
+	if err = r.bindSpaces(ctx, spaces, message.RunningSpaceGUIDs, SecurityGroupRunningWorkload); err != nil {
+		return SecurityGroupRecord{}, err
+	}
+	if err = r.bindSpaces(ctx, spaces, message.StagingSpaceGUIDs, SecurityGroupStagingWorkload); err != nil {
+		return SecurityGroupRecord{}, err
+	}
+
+	cfSecurityGroup := &korifiv1alpha1.CFSecurityGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: r.rootNamespace,
+		},
+		Spec: korifiv1alpha1.CFSecurityGroupSpec{
+			DisplayName: message.Name,
+			Rules:       toCFSecurityGroupRules(message.Rules),
+			GloballyEnabled: korifiv1alpha1.SecurityGroupWorkloads{
+				Running: message.GloballyEnabled.Running,
+				Staging: message.GloballyEnabled.Staging,
+			},
+			Spaces: spaces,
+		},
+	}
+	if err = userClient.Create(ctx, cfSecurityGroup); err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to create security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	return cfSecurityGroupToRecord(cfSecurityGroup, securityGroupVisibility{isAdmin: true}), nil
+}
+
+func (r *SecurityGroupRepo) GetSecurityGroup(ctx context.Context, authInfo authorization.Info, guid string) (SecurityGroupRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSecurityGroup, err := r.getCFSecurityGroup(ctx, userClient, guid)
+	if err != nil {
+		return SecurityGroupRecord{}, err
+	}
+
+	visibility, err := r.getVisibility(ctx, userClient, authInfo)
+	if err != nil {
+		return SecurityGroupRecord{}, err
+	}
+
+	if !visibility.isVisible(*cfSecurityGroup) {
+		return SecurityGroupRecord{}, apierrors.NewNotFoundError(fmt.Errorf("security group %q is not visible", guid), SecurityGroupResourceType)
+	}
+
+	return cfSecurityGroupToRecord(cfSecurityGroup, visibility), nil
+}
+
+func (r *SecurityGroupRepo) ListSecurityGroups(ctx context.Context, authInfo authorization.Info, message ListSecurityGroupsMessage) ([]SecurityGroupRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []SecurityGroupRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	securityGroupList := new(korifiv1alpha1.CFSecurityGroupList)
+	err = userClient.List(ctx, securityGroupList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		return []SecurityGroupRecord{}, fmt.Errorf("failed to list security groups in namespace %s: %w", r.rootNamespace, apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	visibility, err := r.getVisibility(ctx, userClient, authInfo)
+	if err != nil {
+		return []SecurityGroupRecord{}, err
+	}
+
+	filtered := Filter(securityGroupList.Items,
+		visibility.isVisible,
+		SetPredicate(message.GUIDs, func(g korifiv1alpha1.CFSecurityGroup) string { return g.Name }),
+		SetPredicate(message.Names, func(g korifiv1alpha1.CFSecurityGroup) string { return g.Spec.DisplayName }),
+		func(g korifiv1alpha1.CFSecurityGroup) bool {
+			return message.GloballyEnabledRunning == nil || g.Spec.GloballyEnabled.Running == *message.GloballyEnabledRunning
+		},
+		func(g korifiv1alpha1.CFSecurityGroup) bool {
+			return message.GloballyEnabledStaging == nil || g.Spec.GloballyEnabled.Staging == *message.GloballyEnabledStaging
+		},
+		boundToAnyOf(message.RunningSpaceGUIDs, SecurityGroupRunningWorkload),
+		boundToAnyOf(message.StagingSpaceGUIDs, SecurityGroupStagingWorkload),
+	)
+
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].CreationTimestamp.Before(&filtered[j].CreationTimestamp)
+	})
+
+	records := make([]SecurityGroupRecord, 0, len(filtered))
+	for i := range filtered {
+		records = append(records, cfSecurityGroupToRecord(&filtered[i], visibility))
+	}
+
+	return records, nil
+}
+
+func (r *SecurityGroupRepo) UpdateSecurityGroup(ctx context.Context, authInfo authorization.Info, message UpdateSecurityGroupMessage) (SecurityGroupRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSecurityGroup, err := r.getCFSecurityGroup(ctx, userClient, message.GUID)
+	if err != nil {
+		return SecurityGroupRecord{}, err
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfSecurityGroup, func() {
+		message.Apply(cfSecurityGroup)
+	})
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to patch security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	return cfSecurityGroupToRecord(cfSecurityGroup, securityGroupVisibility{isAdmin: true}), nil
+}
+
+func (r *SecurityGroupRepo) DeleteSecurityGroup(ctx context.Context, authInfo authorization.Info, guid string) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSecurityGroup := &korifiv1alpha1.CFSecurityGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      guid,
+			Namespace: r.rootNamespace,
+		},
+	}
+
+	if err = userClient.Delete(ctx, cfSecurityGroup); err != nil {
+		return fmt.Errorf("failed to delete security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	return nil
+}
+
+func (r *SecurityGroupRepo) BindSecurityGroup(ctx context.Context, authInfo authorization.Info, message BindSecurityGroupMessage) (SecurityGroupRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSecurityGroup, err := r.getCFSecurityGroup(ctx, userClient, message.GUID)
+	if err != nil {
+		return SecurityGroupRecord{}, err
+	}
+
+	spaces := map[string]korifiv1alpha1.SecurityGroupWorkloads{}
+	for spaceGUID, workloads := range cfSecurityGroup.Spec.Spaces {
+		spaces[spaceGUID] = workloads
+	}
+	if err = r.bindSpaces(ctx, spaces, message.SpaceGUIDs, message.Workload); err != nil {
+		return SecurityGroupRecord{}, err
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfSecurityGroup, func() {
+		cfSecurityGroup.Spec.Spaces = spaces
+	})
+	if err != nil {
+		return SecurityGroupRecord{}, fmt.Errorf("failed to bind security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	return cfSecurityGroupToRecord(cfSecurityGroup, securityGroupVisibility{isAdmin: true}), nil
+}
+
+func (r *SecurityGroupRepo) UnbindSecurityGroup(ctx context.Context, authInfo authorization.Info, message UnbindSecurityGroupMessage) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSecurityGroup, err := r.getCFSecurityGroup(ctx, userClient, message.GUID)
+	if err != nil {
+		return err
+	}
+
+	workloads, ok := cfSecurityGroup.Spec.Spaces[message.SpaceGUID]
+	if !ok || !isBoundFor(workloads, message.Workload) {
+		return apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("security group %q is not bound to space %q for %s", message.GUID, message.SpaceGUID, message.Workload),
+			fmt.Sprintf("Unable to unbind security group from space with guid '%s'. Ensure the space is bound to this security group.", message.SpaceGUID),
+		)
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfSecurityGroup, func() {
+		if message.Workload == SecurityGroupRunningWorkload {
+			workloads.Running = false
+		} else {
+			workloads.Staging = false
+		}
+
+		if workloads.Running || workloads.Staging {
+			cfSecurityGroup.Spec.Spaces[message.SpaceGUID] = workloads
+		} else {
+			delete(cfSecurityGroup.Spec.Spaces, message.SpaceGUID)
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unbind security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	return nil
+}
+
+func (r *SecurityGroupRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error) {
+	securityGroup, err := r.GetSecurityGroup(ctx, authInfo, guid)
+	return securityGroup.DeletedAt, err
+}
+
+// bindSpaces adds the workload to the spaces, which must exist
+func (r *SecurityGroupRepo) bindSpaces(ctx context.Context, spaces map[string]korifiv1alpha1.SecurityGroupWorkloads, spaceGUIDs []string, workload string) error {
+	missingSpaceGUIDs := []string{}
+	for _, spaceGUID := range spaceGUIDs {
+		_, err := r.namespaceRetriever.NamespaceFor(ctx, spaceGUID, SpaceResourceType)
+		if err != nil {
+			if errors.As(err, &apierrors.NotFoundError{}) {
+				missingSpaceGUIDs = append(missingSpaceGUIDs, spaceGUID)
+				continue
+			}
+			return err
+		}
+
+		workloads := spaces[spaceGUID]
+		if workload == SecurityGroupRunningWorkload {
+			workloads.Running = true
+		} else {
+			workloads.Staging = true
+		}
+		spaces[spaceGUID] = workloads
+	}
+
+	if len(missingSpaceGUIDs) > 0 {
+		return apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("spaces %v do not exist", missingSpaceGUIDs),
+			fmt.Sprintf("Space guids [\"%s\"] do not exist, or you do not have access to them.", strings.Join(missingSpaceGUIDs, "\", \"")),
+		)
+	}
+
+	return nil
+}
+
+func (r *SecurityGroupRepo) getCFSecurityGroup(ctx context.Context, userClient client.Client, guid string) (*korifiv1alpha1.CFSecurityGroup, error) {
+	cfSecurityGroup := new(korifiv1alpha1.CFSecurityGroup)
+	err := userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, cfSecurityGroup)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get security group: %w", apierrors.FromK8sError(err, SecurityGroupResourceType))
+	}
+
+	return cfSecurityGroup, nil
+}
+
+func (r *SecurityGroupRepo) getVisibility(ctx context.Context, userClient client.Client, authInfo authorization.Info) (securityGroupVisibility, error) {
+	isAdmin, err := isAdminUser(ctx, userClient, r.rootNamespace)
+	if err != nil {
+		return securityGroupVisibility{}, err
+	}
+	if isAdmin {
+		return securityGroupVisibility{isAdmin: true}, nil
+	}
+
+	authorizedSpaces, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return securityGroupVisibility{}, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+	}
+
+	return securityGroupVisibility{authorizedSpaces: authorizedSpaces}, nil
+}
+
+// securityGroupVisibility decides which security groups a user is allowed to
+// see: admins see every security group, everybody else only sees globally
+// enabled security groups and security groups bound to one of their spaces
+type securityGroupVisibility struct {
+	isAdmin          bool
+	authorizedSpaces map[string]bool
+}
+
+func (v securityGroupVisibility) isVisible(securityGroup korifiv1alpha1.CFSecurityGroup) bool {
+	if v.isAdmin || securityGroup.Spec.GloballyEnabled.Running || securityGroup.Spec.GloballyEnabled.Staging {
+		return true
+	}
+
+	for spaceGUID := range securityGroup.Spec.Spaces {
+		if v.authorizedSpaces[spaceGUID] {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (v securityGroupVisibility) isSpaceVisible(spaceGUID string) bool {
+	return v.isAdmin || v.authorizedSpaces[spaceGUID]
+}
+
+func boundToAnyOf(spaceGUIDs []string, workload string) func(korifiv1alpha1.CFSecurityGroup) bool {
+	return func(securityGroup korifiv1alpha1.CFSecurityGroup) bool {
+		if len(spaceGUIDs) == 0 {
+			return true
+		}
+
+		for _, spaceGUID := range spaceGUIDs {
+			workloads, ok := securityGroup.Spec.Spaces[spaceGUID]
+			if ok && isBoundFor(workloads, workload) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+func isBoundFor(workloads korifiv1alpha1.SecurityGroupWorkloads, workload string) bool {
+	if workload == SecurityGroupRunningWorkload {
+		return workloads.Running
+	}
+	return workloads.Staging
+}
+
+func toCFSecurityGroupRules(rules []SecurityGroupRule) []korifiv1alpha1.SecurityGroupRule {
+	cfRules := make([]korifiv1alpha1.SecurityGroupRule, 0, len(rules))
+	for _, rule := range rules {
+		cfRules = append(cfRules, korifiv1alpha1.SecurityGroupRule{
+			Protocol:    rule.Protocol,
+			Destination: rule.Destination,
+			Ports:       rule.Ports,
+			Type:        rule.Type,
+			Code:        rule.Code,
+			Description: rule.Description,
+			Log:         rule.Log,
+		})
+	}
+
+	return cfRules
+}
+
+func cfSecurityGroupToRecord(cfSecurityGroup *korifiv1alpha1.CFSecurityGroup, visibility securityGroupVisibility) SecurityGroupRecord {
+	rules := make([]SecurityGroupRule, 0, len(cfSecurityGroup.Spec.Rules))
+	for _, rule := range cfSecurityGroup.Spec.Rules {
+		rules = append(rules, SecurityGroupRule{
+			Protocol:    rule.Protocol,
+			Destination: rule.Destination,
+			Ports:       rule.Ports,
+			Type:        rule.Type,
+			Code:        rule.Code,
+			Description: rule.Description,
+			Log:         rule.Log,
+		})
+	}
+
+	runningSpaceGUIDs := []string{}
+	stagingSpaceGUIDs := []string{}
+	for spaceGUID, workloads := range cfSecurityGroup.Spec.Spaces {
+		if !visibility.isSpaceVisible(spaceGUID) {
+			continue
+		}
+		if workloads.Running {
+			runningSpaceGUIDs = append(runningSpaceGUIDs, spaceGUID)
+		}
+		if workloads.Staging {
+			stagingSpaceGUIDs = append(stagingSpaceGUIDs, spaceGUID)
+		}
+	}
+	sort.Strings(runningSpaceGUIDs)
+	sort.Strings(stagingSpaceGUIDs)
+
+	return SecurityGroupRecord{
+		GUID:  cfSecurityGroup.Name,
+		Name:  cfSecurityGroup.Spec.DisplayName,
+		Rules: rules,
+		GloballyEnabled: SecurityGroupWorkloads{
+			Running: cfSecurityGroup.Spec.GloballyEnabled.Running,
+			Staging: cfSecurityGroup.Spec.GloballyEnabled.Staging,
+		},
+		RunningSpaceGUIDs: runningSpaceGUIDs,
+		StagingSpaceGUIDs: stagingSpaceGUIDs,
+		CreatedAt:         cfSecurityGroup.CreationTimestamp.Time,
+		UpdatedAt:         getLastUpdatedTime(cfSecurityGroup),
+		DeletedAt:         golangTime(cfSecurityGroup.DeletionTimestamp),
+	}
+}
@@ -0,0 +1,476 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/onsi/gomega/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("SecurityGroupRepo", func() {
+	var (
+		repo            *SecurityGroupRepo
+		space           *korifiv1alpha1.CFSpace
+		otherSpace      *korifiv1alpha1.CFSpace
+		cfSecurityGroup *korifiv1alpha1.CFSecurityGroup
+	)
+
+	BeforeEach(func() {
+		repo = NewSecurityGroupRepo(userClientFactory, namespaceRetriever, nsPerms, rootNamespace)
+
+		org := createOrgWithCleanup(ctx, prefixedGUID("org"))
+		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+		otherSpace = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("other-space"))
+
+		cfSecurityGroup = &korifiv1alpha1.CFSecurityGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      generateGUID(),
+				Namespace: rootNamespace,
+			},
+			Spec: korifiv1alpha1.CFSecurityGroupSpec{
+				DisplayName: prefixedGUID("existing-security-group"),
+				Rules: []korifiv1alpha1.SecurityGroupRule{{
+					Protocol:    korifiv1alpha1.ProtocolTCP,
+					Destination: "10.0.0.1",
+					Ports:       "443",
+				}},
+				Spaces: map[string]korifiv1alpha1.SecurityGroupWorkloads{
+					space.Name:      {Running: true},
+					otherSpace.Name: {Staging: true},
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfSecurityGroup)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, cfSecurityGroup))).To(Succeed())
+	})
+
+	withGUID := func(guid string) types.GomegaMatcher {
+		return MatchFields(IgnoreExtras, Fields{"GUID": Equal(guid)})
+	}
+
+	Describe("CreateSecurityGroup", func() {
+		var (
+			message   CreateSecurityGroupMessage
+			record    SecurityGroupRecord
+			createErr error
+		)
+
+		BeforeEach(func() {
+			message = CreateSecurityGroupMessage{
+				Name: prefixedGUID("my-security-group"),
+				Rules: []SecurityGroupRule{{
+					Protocol:    korifiv1alpha1.ProtocolUDP,
+					Destination: "10.0.0.0/24",
+					Ports:       "53",
+				}},
+				GloballyEnabled:   SecurityGroupWorkloads{Staging: true},
+				RunningSpaceGUIDs: []string{space.Name},
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, createErr = repo.CreateSecurityGroup(ctx, authInfo, message)
+		})
+
+		It("fails because the user is not a CF admin", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns a security group record", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(MatchRegexp("^[-0-9a-f]{36}$"), "record GUID was not a 36 character guid")
+				Expect(record.Name).To(Equal(message.Name))
+				Expect(record.Rules).To(Equal(message.Rules))
+				Expect(record.GloballyEnabled).To(Equal(SecurityGroupWorkloads{Staging: true}))
+				Expect(record.RunningSpaceGUIDs).To(ConsistOf(space.Name))
+				Expect(record.StagingSpaceGUIDs).To(BeEmpty())
+			})
+
+			It("creates a CFSecurityGroup", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				created := new(korifiv1alpha1.CFSecurityGroup)
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: record.GUID}, created)).To(Succeed())
+				Expect(created.Spec.DisplayName).To(Equal(message.Name))
+				Expect(created.Spec.Rules).To(Equal([]korifiv1alpha1.SecurityGroupRule{{
+					Protocol:    korifiv1alpha1.ProtocolUDP,
+					Destination: "10.0.0.0/24",
+					Ports:       "53",
+				}}))
+				Expect(created.Spec.GloballyEnabled).To(Equal(korifiv1alpha1.SecurityGroupWorkloads{Staging: true}))
+				Expect(created.Spec.Spaces).To(Equal(map[string]korifiv1alpha1.SecurityGroupWorkloads{
+					space.Name: {Running: true},
+				}))
+			})
+
+			When("a space does not exist", func() {
+				BeforeEach(func() {
+					message.StagingSpaceGUIDs = []string{"does-not-exist"}
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("GetSecurityGroup", func() {
+		var (
+			record SecurityGroupRecord
+			getErr error
+		)
+
+		JustBeforeEach(func() {
+			record, getErr = repo.GetSecurityGroup(ctx, authInfo, cfSecurityGroup.Name)
+		})
+
+		It("returns a not found error as the security group is not bound to a space of the user", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the security group", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(Equal(cfSecurityGroup.Name))
+				Expect(record.Name).To(Equal(cfSecurityGroup.Spec.DisplayName))
+				Expect(record.Rules).To(Equal([]SecurityGroupRule{{
+					Protocol:    korifiv1alpha1.ProtocolTCP,
+					Destination: "10.0.0.1",
+					Ports:       "443",
+				}}))
+				Expect(record.RunningSpaceGUIDs).To(ConsistOf(space.Name))
+				Expect(record.StagingSpaceGUIDs).To(ConsistOf(otherSpace.Name))
+			})
+		})
+
+		When("the user has a role in a bound space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the security group with the spaces visible to the user only", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.RunningSpaceGUIDs).To(ConsistOf(space.Name))
+				Expect(record.StagingSpaceGUIDs).To(BeEmpty())
+			})
+		})
+
+		When("the security group is globally enabled", func() {
+			BeforeEach(func() {
+				Expect(k8sClient.Patch(ctx, cfSecurityGroup, client.RawPatch(
+					"application/merge-patch+json",
+					[]byte(`{"spec":{"globallyEnabled":{"running":true}}}`),
+				))).To(Succeed())
+			})
+
+			It("returns the security group", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.GloballyEnabled).To(Equal(SecurityGroupWorkloads{Running: true}))
+				Expect(record.RunningSpaceGUIDs).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("ListSecurityGroups", func() {
+		var (
+			message      ListSecurityGroupsMessage
+			records      []SecurityGroupRecord
+			listErr      error
+			globalGroup  *korifiv1alpha1.CFSecurityGroup
+			unboundGroup *korifiv1alpha1.CFSecurityGroup
+		)
+
+		BeforeEach(func() {
+			message = ListSecurityGroupsMessage{}
+
+			globalGroup = &korifiv1alpha1.CFSecurityGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      generateGUID(),
+					Namespace: rootNamespace,
+				},
+				Spec: korifiv1alpha1.CFSecurityGroupSpec{
+					DisplayName:     prefixedGUID("global"),
+					GloballyEnabled: korifiv1alpha1.SecurityGroupWorkloads{Running: true},
+				},
+			}
+			Expect(k8sClient.Create(ctx, globalGroup)).To(Succeed())
+
+			unboundGroup = &korifiv1alpha1.CFSecurityGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      generateGUID(),
+					Namespace: rootNamespace,
+				},
+				Spec: korifiv1alpha1.CFSecurityGroupSpec{
+					DisplayName: prefixedGUID("unbound"),
+				},
+			}
+			Expect(k8sClient.Create(ctx, unboundGroup)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, globalGroup))).To(Succeed())
+			Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, unboundGroup))).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = repo.ListSecurityGroups(ctx, authInfo, message)
+		})
+
+		It("returns the globally enabled security groups only", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(records).To(ContainElement(withGUID(globalGroup.Name)))
+			Expect(records).NotTo(ContainElement(withGUID(cfSecurityGroup.Name)))
+			Expect(records).NotTo(ContainElement(withGUID(unboundGroup.Name)))
+		})
+
+		When("the user has a role in a bound space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the security groups bound to the space too", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ContainElement(withGUID(cfSecurityGroup.Name)))
+				Expect(records).NotTo(ContainElement(withGUID(unboundGroup.Name)))
+			})
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns all security groups", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ContainElement(withGUID(globalGroup.Name)))
+				Expect(records).To(ContainElement(withGUID(cfSecurityGroup.Name)))
+				Expect(records).To(ContainElement(withGUID(unboundGroup.Name)))
+			})
+
+			When("filtering by running space", func() {
+				BeforeEach(func() {
+					message.RunningSpaceGUIDs = []string{space.Name}
+				})
+
+				It("returns the security groups bound to the space for running apps", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(ConsistOf(withGUID(cfSecurityGroup.Name)))
+				})
+			})
+
+			When("filtering by staging space", func() {
+				BeforeEach(func() {
+					message.StagingSpaceGUIDs = []string{space.Name}
+				})
+
+				It("returns no security groups", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(BeEmpty())
+				})
+			})
+
+			When("filtering by globally enabled running", func() {
+				BeforeEach(func() {
+					message.GloballyEnabledRunning = tools.PtrTo(true)
+					message.Names = []string{globalGroup.Spec.DisplayName, cfSecurityGroup.Spec.DisplayName}
+				})
+
+				It("returns the globally enabled security groups", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(ConsistOf(withGUID(globalGroup.Name)))
+				})
+			})
+		})
+	})
+
+	Describe("UpdateSecurityGroup", func() {
+		var (
+			message   UpdateSecurityGroupMessage
+			record    SecurityGroupRecord
+			updateErr error
+		)
+
+		BeforeEach(func() {
+			message = UpdateSecurityGroupMessage{
+				GUID:                   cfSecurityGroup.Name,
+				Name:                   tools.PtrTo("new-name"),
+				Rules:                  &[]SecurityGroupRule{{Protocol: korifiv1alpha1.ProtocolAll, Destination: "0.0.0.0/0"}},
+				GloballyEnabledStaging: tools.PtrTo(true),
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, updateErr = repo.UpdateSecurityGroup(ctx, authInfo, message)
+		})
+
+		It("fails because the user is not a CF admin", func() {
+			Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("updates the security group", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(record.Name).To(Equal("new-name"))
+				Expect(record.GloballyEnabled).To(Equal(SecurityGroupWorkloads{Staging: true}))
+
+				updated := new(korifiv1alpha1.CFSecurityGroup)
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), updated)).To(Succeed())
+				Expect(updated.Spec.DisplayName).To(Equal("new-name"))
+				Expect(updated.Spec.Rules).To(Equal([]korifiv1alpha1.SecurityGroupRule{{Protocol: korifiv1alpha1.ProtocolAll, Destination: "0.0.0.0/0"}}))
+				Expect(updated.Spec.GloballyEnabled).To(Equal(korifiv1alpha1.SecurityGroupWorkloads{Staging: true}))
+				Expect(updated.Spec.Spaces).To(HaveLen(2))
+			})
+		})
+	})
+
+	Describe("DeleteSecurityGroup", func() {
+		var deleteErr error
+
+		JustBeforeEach(func() {
+			deleteErr = repo.DeleteSecurityGroup(ctx, authInfo, cfSecurityGroup.Name)
+		})
+
+		It("fails because the user is not a CF admin", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("deletes the security group", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), new(korifiv1alpha1.CFSecurityGroup))
+				Expect(err).To(MatchError(ContainSubstring("not found")))
+			})
+		})
+	})
+
+	Describe("BindSecurityGroup", func() {
+		var (
+			message BindSecurityGroupMessage
+			record  SecurityGroupRecord
+			bindErr error
+		)
+
+		BeforeEach(func() {
+			message = BindSecurityGroupMessage{
+				GUID:       cfSecurityGroup.Name,
+				SpaceGUIDs: []string{space.Name},
+				Workload:   SecurityGroupStagingWorkload,
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, bindErr = repo.BindSecurityGroup(ctx, authInfo, message)
+		})
+
+		It("fails because the user is not a CF admin", func() {
+			Expect(bindErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("binds the space", func() {
+				Expect(bindErr).NotTo(HaveOccurred())
+				Expect(record.StagingSpaceGUIDs).To(ConsistOf(space.Name, otherSpace.Name))
+
+				updated := new(korifiv1alpha1.CFSecurityGroup)
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), updated)).To(Succeed())
+				Expect(updated.Spec.Spaces).To(Equal(map[string]korifiv1alpha1.SecurityGroupWorkloads{
+					space.Name:      {Running: true, Staging: true},
+					otherSpace.Name: {Staging: true},
+				}))
+			})
+
+			When("the space does not exist", func() {
+				BeforeEach(func() {
+					message.SpaceGUIDs = []string{"does-not-exist"}
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(bindErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("UnbindSecurityGroup", func() {
+		var (
+			message   UnbindSecurityGroupMessage
+			unbindErr error
+		)
+
+		BeforeEach(func() {
+			message = UnbindSecurityGroupMessage{
+				GUID:      cfSecurityGroup.Name,
+				SpaceGUID: space.Name,
+				Workload:  SecurityGroupRunningWorkload,
+			}
+		})
+
+		JustBeforeEach(func() {
+			unbindErr = repo.UnbindSecurityGroup(ctx, authInfo, message)
+		})
+
+		It("fails because the user is not a CF admin", func() {
+			Expect(unbindErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("unbinds the space", func() {
+				Expect(unbindErr).NotTo(HaveOccurred())
+
+				updated := new(korifiv1alpha1.CFSecurityGroup)
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), updated)).To(Succeed())
+				Expect(updated.Spec.Spaces).To(Equal(map[string]korifiv1alpha1.SecurityGroupWorkloads{
+					otherSpace.Name: {Staging: true},
+				}))
+			})
+
+			When("the space is not bound for the workload", func() {
+				BeforeEach(func() {
+					message.Workload = SecurityGroupStagingWorkload
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(unbindErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+})
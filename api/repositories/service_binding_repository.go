@@ -2,6 +2,7 @@ package repositories
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"time"
 
@@ -11,7 +12,6 @@ import (
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
-	"code.cloudfoundry.org/korifi/controllers/webhooks/services"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/google/uuid"
@@ -24,7 +24,8 @@ import (
 const (
 	LabelServiceBindingProvisionedService = "servicebinding.io/provisioned-service"
 	ServiceBindingResourceType            = "Service Binding"
-	ServiceBindingTypeApp                 = "app"
+	ServiceBindingTypeApp                 = korifiv1alpha1.AppBindingType
+	ServiceBindingTypeKey                 = korifiv1alpha1.KeyBindingType
 )
 
 type ServiceBindingRepo struct {
@@ -70,11 +71,19 @@ type ServiceBindingLastOperation struct {
 	UpdatedAt   *time.Time
 }
 
+type ServiceBindingDetailsRecord struct {
+	Credentials map[string]any
+}
+
 type CreateServiceBindingMessage struct {
+	Type                string
 	Name                *string
 	ServiceInstanceGUID string
 	AppGUID             string
 	SpaceGUID           string
+	// The space of the service instance, if it differs from the space of the
+	// binding because the instance is shared with it
+	ServiceInstanceSpaceGUID string
 }
 
 type DeleteServiceBindingMessage struct {
@@ -84,10 +93,23 @@ type DeleteServiceBindingMessage struct {
 type ListServiceBindingsMessage struct {
 	AppGUIDs             []string
 	ServiceInstanceGUIDs []string
+	Types                []string
+}
+
+func (m CreateServiceBindingMessage) bindingType() string {
+	if m.Type == "" {
+		return ServiceBindingTypeApp
+	}
+	return m.Type
 }
 
 func (m CreateServiceBindingMessage) toCFServiceBinding() *korifiv1alpha1.CFServiceBinding {
 	guid := uuid.NewString()
+	serviceNamespace := ""
+	if m.ServiceInstanceSpaceGUID != m.SpaceGUID {
+		serviceNamespace = m.ServiceInstanceSpaceGUID
+	}
+
 	return &korifiv1alpha1.CFServiceBinding{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      guid,
@@ -96,10 +118,12 @@ func (m CreateServiceBindingMessage) toCFServiceBinding() *korifiv1alpha1.CFServ
 		},
 		Spec: korifiv1alpha1.CFServiceBindingSpec{
 			DisplayName: m.Name,
+			Type:        korifiv1alpha1.BindingType(m.bindingType()),
 			Service: corev1.ObjectReference{
 				Kind:       "CFServiceInstance",
 				APIVersion: korifiv1alpha1.GroupVersion.Identifier(),
 				Name:       m.ServiceInstanceGUID,
+				Namespace:  serviceNamespace,
 			},
 			AppRef: corev1.LocalObjectReference{Name: m.AppGUID},
 		},
@@ -119,22 +143,27 @@ func (r *ServiceBindingRepo) CreateServiceBinding(ctx context.Context, authInfo
 
 	cfServiceBinding := message.toCFServiceBinding()
 
-	cfApp := new(korifiv1alpha1.CFApp)
-	err = userClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.AppRef.Name, Namespace: cfServiceBinding.Namespace}, cfApp)
-	if err != nil {
-		return ServiceBindingRecord{},
-			apierrors.AsUnprocessableEntity(
-				apierrors.FromK8sError(err, ServiceBindingResourceType),
-				"Unable to use app. Ensure that the app exists and you have access to it.",
-				apierrors.ForbiddenError{},
-				apierrors.NotFoundError{},
-			)
+	readyCondition := BindingSecretAvailableCondition
+	if !cfServiceBinding.IsKey() {
+		readyCondition = VCAPServicesSecretAvailableCondition
+
+		cfApp := new(korifiv1alpha1.CFApp)
+		err = userClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.AppRef.Name, Namespace: cfServiceBinding.Namespace}, cfApp)
+		if err != nil {
+			return ServiceBindingRecord{},
+				apierrors.AsUnprocessableEntity(
+					apierrors.FromK8sError(err, ServiceBindingResourceType),
+					"Unable to use app. Ensure that the app exists and you have access to it.",
+					apierrors.ForbiddenError{},
+					apierrors.NotFoundError{},
+				)
+		}
 	}
 
 	err = userClient.Create(ctx, cfServiceBinding)
 	if err != nil {
 		if validationError, ok := webhooks.WebhookErrorToValidationError(err); ok {
-			if validationError.Type == services.ServiceBindingErrorType {
+			if validationError.Type == webhooks.DuplicateNameErrorType {
 				return ServiceBindingRecord{}, apierrors.NewUniquenessError(err, validationError.GetMessage())
 			}
 		}
@@ -142,7 +171,7 @@ func (r *ServiceBindingRepo) CreateServiceBinding(ctx context.Context, authInfo
 		return ServiceBindingRecord{}, apierrors.FromK8sError(err, ServiceBindingResourceType)
 	}
 
-	cfServiceBinding, err = r.bindingConditionAwaiter.AwaitCondition(ctx, userClient, cfServiceBinding, VCAPServicesSecretAvailableCondition)
+	cfServiceBinding, err = r.bindingConditionAwaiter.AwaitCondition(ctx, userClient, cfServiceBinding, readyCondition)
 	if err != nil {
 		return ServiceBindingRecord{}, err
 	}
@@ -195,6 +224,57 @@ func (r *ServiceBindingRepo) GetServiceBinding(ctx context.Context, authInfo aut
 	return cfServiceBindingToRecord(serviceBinding), nil
 }
 
+func (r *ServiceBindingRepo) GetServiceBindingDetails(ctx context.Context, authInfo authorization.Info, guid string) (ServiceBindingDetailsRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, guid, ServiceBindingResourceType)
+	if err != nil {
+		return ServiceBindingDetailsRecord{}, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServiceBindingDetailsRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	serviceBinding := &korifiv1alpha1.CFServiceBinding{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: guid}, serviceBinding)
+	if err != nil {
+		return ServiceBindingDetailsRecord{}, apierrors.FromK8sError(err, ServiceBindingResourceType)
+	}
+
+	if serviceBinding.Status.Binding.Name == "" {
+		return ServiceBindingDetailsRecord{}, apierrors.NewNotFoundError(fmt.Errorf("service binding %s has no credentials yet", guid), ServiceBindingResourceType)
+	}
+
+	// Credentials returned by a broker are stored as JSON, so that they keep their types
+	if serviceBinding.Status.Credentials.Name != "" {
+		credentialsSecret := &corev1.Secret{}
+		err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: serviceBinding.Status.Credentials.Name}, credentialsSecret)
+		if err != nil {
+			return ServiceBindingDetailsRecord{}, fmt.Errorf("failed to get credentials secret: %w", apierrors.FromK8sError(err, ServiceBindingResourceType))
+		}
+
+		credentials := map[string]any{}
+		if err = json.Unmarshal(credentialsSecret.Data[korifiv1alpha1.CredentialsSecretKey], &credentials); err != nil {
+			return ServiceBindingDetailsRecord{}, fmt.Errorf("failed to unmarshal credentials: %w", err)
+		}
+
+		return ServiceBindingDetailsRecord{Credentials: credentials}, nil
+	}
+
+	credentialsSecret := &corev1.Secret{}
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: serviceBinding.Status.Binding.Name}, credentialsSecret)
+	if err != nil {
+		return ServiceBindingDetailsRecord{}, fmt.Errorf("failed to get credentials secret: %w", apierrors.FromK8sError(err, ServiceBindingResourceType))
+	}
+
+	credentials := map[string]any{}
+	for key, value := range credentialsSecret.Data {
+		credentials[key] = string(value)
+	}
+
+	return ServiceBindingDetailsRecord{Credentials: credentials}, nil
+}
+
 func (r *ServiceBindingRepo) UpdateServiceBinding(ctx context.Context, authInfo authorization.Info, updateMsg UpdateServiceBindingMessage) (ServiceBindingRecord, error) {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -231,7 +311,7 @@ func (r *ServiceBindingRepo) UpdateServiceBinding(ctx context.Context, authInfo
 func cfServiceBindingToRecord(binding *korifiv1alpha1.CFServiceBinding) ServiceBindingRecord {
 	return ServiceBindingRecord{
 		GUID:                binding.Name,
-		Type:                ServiceBindingTypeApp,
+		Type:                bindingType(binding),
 		Name:                binding.Spec.DisplayName,
 		AppGUID:             binding.Spec.AppRef.Name,
 		ServiceInstanceGUID: binding.Spec.Service.Name,
@@ -250,6 +330,13 @@ func cfServiceBindingToRecord(binding *korifiv1alpha1.CFServiceBinding) ServiceB
 	}
 }
 
+func bindingType(binding *korifiv1alpha1.CFServiceBinding) string {
+	if binding.IsKey() {
+		return ServiceBindingTypeKey
+	}
+	return ServiceBindingTypeApp
+}
+
 // nolint:dupl
 func (r *ServiceBindingRepo) ListServiceBindings(ctx context.Context, authInfo authorization.Info, message ListServiceBindingsMessage) ([]ServiceBindingRecord, error) {
 	nsList, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
@@ -265,6 +352,7 @@ func (r *ServiceBindingRepo) ListServiceBindings(ctx context.Context, authInfo a
 	preds := []func(korifiv1alpha1.CFServiceBinding) bool{
 		SetPredicate(message.ServiceInstanceGUIDs, func(s korifiv1alpha1.CFServiceBinding) string { return s.Spec.Service.Name }),
 		SetPredicate(message.AppGUIDs, func(s korifiv1alpha1.CFServiceBinding) string { return s.Spec.AppRef.Name }),
+		SetPredicate(message.Types, func(s korifiv1alpha1.CFServiceBinding) string { return bindingType(&s) }),
 	}
 
 	var filteredServiceBindings []korifiv1alpha1.CFServiceBinding
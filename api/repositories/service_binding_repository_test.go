@@ -15,6 +15,7 @@ import (
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -111,6 +112,12 @@ var _ = Describe("ServiceBindingRepo", func() {
 		originalServiceBinding := serviceBinding.DeepCopy()
 
 		serviceBinding.Status.Binding.Name = "service-secret-name"
+		meta.SetStatusCondition(&(serviceBinding.Status.Conditions), metav1.Condition{
+			Type:    repositories.BindingSecretAvailableCondition,
+			Status:  metav1.ConditionTrue,
+			Reason:  "blah",
+			Message: "blah",
+		})
 		meta.SetStatusCondition(&(serviceBinding.Status.Conditions), metav1.Condition{
 			Type:    repositories.VCAPServicesSecretAvailableCondition,
 			Status:  metav1.ConditionTrue,
@@ -138,19 +145,25 @@ var _ = Describe("ServiceBindingRepo", func() {
 
 	Describe("CreateServiceBinding", func() {
 		var (
-			record    repositories.ServiceBindingRecord
-			createErr error
+			record                   repositories.ServiceBindingRecord
+			bindingType              string
+			serviceInstanceSpaceGUID string
+			createErr                error
 		)
 		BeforeEach(func() {
 			bindingName = nil
+			bindingType = "app"
+			serviceInstanceSpaceGUID = space.Name
 		})
 
 		JustBeforeEach(func() {
 			record, createErr = repo.CreateServiceBinding(testCtx, authInfo, repositories.CreateServiceBindingMessage{
-				Name:                bindingName,
-				ServiceInstanceGUID: serviceInstanceGUID,
-				AppGUID:             appGUID,
-				SpaceGUID:           space.Name,
+				Type:                     bindingType,
+				Name:                     bindingName,
+				ServiceInstanceGUID:      serviceInstanceGUID,
+				AppGUID:                  appGUID,
+				SpaceGUID:                space.Name,
+				ServiceInstanceSpaceGUID: serviceInstanceSpaceGUID,
 			})
 		})
 
@@ -196,6 +209,7 @@ var _ = Describe("ServiceBindingRepo", func() {
 				Expect(serviceBinding.Spec).To(Equal(
 					korifiv1alpha1.CFServiceBindingSpec{
 						DisplayName: nil,
+						Type:        korifiv1alpha1.AppBindingType,
 						Service: corev1.ObjectReference{
 							Kind:       "CFServiceInstance",
 							APIVersion: korifiv1alpha1.GroupVersion.Identifier(),
@@ -208,6 +222,24 @@ var _ = Describe("ServiceBindingRepo", func() {
 				))
 			})
 
+			When("the service instance is shared from another space", func() {
+				BeforeEach(func() {
+					serviceInstanceSpaceGUID = "shared-from-space-guid"
+				})
+
+				It("references the service instance in its space", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+
+					serviceBinding := new(korifiv1alpha1.CFServiceBinding)
+					Expect(
+						k8sClient.Get(testCtx, types.NamespacedName{Name: record.GUID, Namespace: space.Name}, serviceBinding),
+					).To(Succeed())
+					Expect(serviceBinding.Spec.Service.Name).To(Equal(serviceInstanceGUID))
+					Expect(serviceBinding.Spec.Service.Namespace).To(Equal("shared-from-space-guid"))
+					Expect(record.SpaceGUID).To(Equal(space.Name))
+				})
+			})
+
 			When("the app does not exist", func() {
 				BeforeEach(func() {
 					doBindingControllerSimulation = false
@@ -239,6 +271,28 @@ var _ = Describe("ServiceBindingRepo", func() {
 					Expect(record.Name).To(Equal(bindingName))
 				})
 			})
+
+			When("the binding is a service key", func() {
+				BeforeEach(func() {
+					bindingType = "key"
+					bindingName = tools.PtrTo("my-key")
+					appGUID = ""
+				})
+
+				It("creates a service key without an app reference", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(record.Type).To(Equal("key"))
+					Expect(record.Name).To(PointTo(Equal("my-key")))
+					Expect(record.AppGUID).To(BeEmpty())
+
+					serviceBinding := new(korifiv1alpha1.CFServiceBinding)
+					Expect(
+						k8sClient.Get(testCtx, types.NamespacedName{Name: record.GUID, Namespace: space.Name}, serviceBinding),
+					).To(Succeed())
+					Expect(serviceBinding.Spec.Type).To(BeEquivalentTo(korifiv1alpha1.KeyBindingType))
+					Expect(serviceBinding.Spec.AppRef.Name).To(BeEmpty())
+				})
+			})
 		})
 	})
 
@@ -472,6 +526,43 @@ var _ = Describe("ServiceBindingRepo", func() {
 					))
 				})
 			})
+
+			When("filtered by type", func() {
+				var serviceKey *korifiv1alpha1.CFServiceBinding
+
+				BeforeEach(func() {
+					serviceKey = &korifiv1alpha1.CFServiceBinding{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      prefixedGUID("key"),
+							Namespace: space.Name,
+						},
+						Spec: korifiv1alpha1.CFServiceBindingSpec{
+							DisplayName: tools.PtrTo("my-key"),
+							Type:        korifiv1alpha1.KeyBindingType,
+							Service: corev1.ObjectReference{
+								Kind:       "CFServiceInstance",
+								APIVersion: korifiv1alpha1.GroupVersion.Identifier(),
+								Name:       serviceInstance1GUID,
+							},
+						},
+					}
+					Expect(k8sClient.Create(testCtx, serviceKey)).To(Succeed())
+
+					requestMessage = repositories.ListServiceBindingsMessage{
+						Types: []string{"key"},
+					}
+				})
+
+				It("returns only the ServiceBindings of that type", func() {
+					Expect(responseServiceBindings).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{
+							"GUID":    Equal(serviceKey.Name),
+							"Type":    Equal("key"),
+							"AppGUID": BeEmpty(),
+						}),
+					))
+				})
+			})
 		})
 
 		When("the user does not have access to any namespaces", func() {
@@ -556,6 +647,98 @@ var _ = Describe("ServiceBindingRepo", func() {
 		})
 	})
 
+	Describe("GetServiceBindingDetails", func() {
+		var (
+			serviceBindingGUID string
+			details            repositories.ServiceBindingDetailsRecord
+			getErr             error
+		)
+
+		BeforeEach(func() {
+			doBindingControllerSimulation = false
+			serviceBindingGUID = prefixedGUID("binding")
+
+			credentialsSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      prefixedGUID("credentials"),
+					Namespace: space.Name,
+				},
+				StringData: map[string]string{
+					"username": "admin",
+				},
+			}
+			Expect(k8sClient.Create(testCtx, credentialsSecret)).To(Succeed())
+
+			serviceBinding := &korifiv1alpha1.CFServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      serviceBindingGUID,
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceBindingSpec{
+					DisplayName: tools.PtrTo("my-key"),
+					Type:        korifiv1alpha1.KeyBindingType,
+					Service: corev1.ObjectReference{
+						Kind:       "CFServiceInstance",
+						APIVersion: korifiv1alpha1.GroupVersion.Identifier(),
+						Name:       serviceInstanceGUID,
+					},
+				},
+			}
+			Expect(k8sClient.Create(testCtx, serviceBinding)).To(Succeed())
+			Expect(k8s.Patch(testCtx, k8sClient, serviceBinding, func() {
+				serviceBinding.Status.Binding.Name = credentialsSecret.Name
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			details, getErr = repo.GetServiceBindingDetails(testCtx, authInfo, serviceBindingGUID)
+		})
+
+		It("returns a forbidden error as no user bindings are in place", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the credentials of the binding", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(details.Credentials).To(Equal(map[string]any{"username": "admin"}))
+			})
+
+			When("the binding stores the broker credentials as JSON", func() {
+				BeforeEach(func() {
+					brokerCredentialsSecret := &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      prefixedGUID("broker-credentials"),
+							Namespace: space.Name,
+						},
+						Data: map[string][]byte{
+							korifiv1alpha1.CredentialsSecretKey: []byte(`{"port":5432,"hosts":{"primary":"db-0"}}`),
+						},
+					}
+					Expect(k8sClient.Create(testCtx, brokerCredentialsSecret)).To(Succeed())
+
+					serviceBinding := &korifiv1alpha1.CFServiceBinding{}
+					Expect(k8sClient.Get(testCtx, client.ObjectKey{Namespace: space.Name, Name: serviceBindingGUID}, serviceBinding)).To(Succeed())
+					Expect(k8s.Patch(testCtx, k8sClient, serviceBinding, func() {
+						serviceBinding.Status.Credentials.Name = brokerCredentialsSecret.Name
+					})).To(Succeed())
+				})
+
+				It("keeps the types of the credentials", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(details.Credentials).To(Equal(map[string]any{
+						"port":  float64(5432),
+						"hosts": map[string]any{"primary": "db-0"},
+					}))
+				})
+			})
+		})
+	})
+
 	Describe("UpdateServiceBinding", func() {
 		var (
 			serviceBinding        *korifiv1alpha1.CFServiceBinding
@@ -0,0 +1,322 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ServiceBrokerResourceType = "Service Broker"
+)
+
+type ServiceBrokerRepo struct {
+	userClientFactory authorization.UserK8sClientFactory
+	rootNamespace     string
+}
+
+func NewServiceBrokerRepo(
+	userClientFactory authorization.UserK8sClientFactory,
+	rootNamespace string,
+) *ServiceBrokerRepo {
+	return &ServiceBrokerRepo{
+		userClientFactory: userClientFactory,
+		rootNamespace:     rootNamespace,
+	}
+}
+
+type BasicAuthentication struct {
+	Username string
+	Password string
+}
+
+type ServiceBrokerRecord struct {
+	Name        string
+	GUID        string
+	URL         string
+	Labels      map[string]string
+	Annotations map[string]string
+	CreatedAt   time.Time
+	UpdatedAt   *time.Time
+	DeletedAt   *time.Time
+}
+
+type CreateServiceBrokerMessage struct {
+	Name        string
+	URL         string
+	Credentials BasicAuthentication
+	Metadata    Metadata
+}
+
+type UpdateServiceBrokerMessage struct {
+	GUID          string
+	Name          *string
+	URL           *string
+	Credentials   *BasicAuthentication
+	MetadataPatch MetadataPatch
+}
+
+func (m UpdateServiceBrokerMessage) Apply(cfServiceBroker *korifiv1alpha1.CFServiceBroker) {
+	if m.Name != nil {
+		cfServiceBroker.Spec.Name = *m.Name
+	}
+	if m.URL != nil {
+		cfServiceBroker.Spec.URL = *m.URL
+	}
+	m.MetadataPatch.Apply(cfServiceBroker)
+}
+
+type ListServiceBrokersMessage struct {
+	Names []string
+}
+
+func (r *ServiceBrokerRepo) CreateServiceBroker(ctx context.Context, authInfo authorization.Info, message CreateServiceBrokerMessage) (ServiceBrokerRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServiceBrokerRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	guid := uuid.NewString()
+
+	// The credentials secret is created first, so that the broker controller
+	// never observes a broker without credentials
+	credentialsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      guid,
+			Namespace: r.rootNamespace,
+			Labels: map[string]string{
+				korifiv1alpha1.CFServiceBrokerGUIDLabelKey: guid,
+			},
+		},
+		StringData: credentialsSecretData(message.Credentials),
+	}
+	if err = userClient.Create(ctx, credentialsSecret); err != nil {
+		return ServiceBrokerRecord{}, fmt.Errorf("failed to create service broker credentials: %w", apierrors.FromK8sError(err, ServiceBrokerResourceType))
+	}
+
+	cfServiceBroker := &korifiv1alpha1.CFServiceBroker{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        guid,
+			Namespace:   r.rootNamespace,
+			Labels:      message.Metadata.Labels,
+			Annotations: message.Metadata.Annotations,
+		},
+		Spec: korifiv1alpha1.CFServiceBrokerSpec{
+			Name: message.Name,
+			URL:  message.URL,
+			Credentials: corev1.LocalObjectReference{
+				Name: credentialsSecret.Name,
+			},
+		},
+	}
+	if err = userClient.Create(ctx, cfServiceBroker); err != nil {
+		r.deleteCredentialsSecret(ctx, userClient, credentialsSecret)
+		return ServiceBrokerRecord{}, fmt.Errorf("failed to create service broker: %w", apierrors.FromK8sError(err, ServiceBrokerResourceType))
+	}
+
+	err = k8s.PatchResource(ctx, userClient, credentialsSecret, func() {
+		credentialsSecret.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: korifiv1alpha1.GroupVersion.String(),
+			Kind:       "CFServiceBroker",
+			Name:       cfServiceBroker.Name,
+			UID:        cfServiceBroker.UID,
+		}}
+	})
+	if err != nil {
+		r.deleteCredentialsSecret(ctx, userClient, credentialsSecret)
+		_ = userClient.Delete(ctx, cfServiceBroker)
+		return ServiceBrokerRecord{}, fmt.Errorf("failed to set service broker credentials owner: %w", apierrors.FromK8sError(err, ServiceBrokerResourceType))
+	}
+
+	return cfServiceBrokerToRecord(cfServiceBroker), nil
+}
+
+// deleteCredentialsSecret is a best effort cleanup of a credentials secret
+// that has not been given an owner yet and would otherwise be orphaned
+func (r *ServiceBrokerRepo) deleteCredentialsSecret(ctx context.Context, userClient client.WithWatch, secret *corev1.Secret) {
+	if err := userClient.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+		logr.FromContextOrDiscard(ctx).Error(err, "failed to delete orphaned service broker credentials", "secret", secret.Name)
+	}
+}
+
+func (r *ServiceBrokerRepo) GetServiceBroker(ctx context.Context, authInfo authorization.Info, guid string) (ServiceBrokerRecord, error) {
+	cfServiceBroker, err := r.getCFServiceBroker(ctx, authInfo, guid)
+	if err != nil {
+		return ServiceBrokerRecord{}, err
+	}
+
+	return cfServiceBrokerToRecord(cfServiceBroker), nil
+}
+
+func (r *ServiceBrokerRepo) ListServiceBrokers(ctx context.Context, authInfo authorization.Info, message ListServiceBrokersMessage) ([]ServiceBrokerRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []ServiceBrokerRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	brokerList := new(korifiv1alpha1.CFServiceBrokerList)
+	err = userClient.List(ctx, brokerList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		if k8serrors.IsForbidden(err) {
+			return []ServiceBrokerRecord{}, nil
+		}
+		return []ServiceBrokerRecord{}, fmt.Errorf("failed to list service brokers in namespace %s: %w", r.rootNamespace, apierrors.FromK8sError(err, ServiceBrokerResourceType))
+	}
+
+	filtered := Filter(brokerList.Items, SetPredicate(message.Names, func(b korifiv1alpha1.CFServiceBroker) string { return b.Spec.Name }))
+
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].CreationTimestamp.Before(&filtered[j].CreationTimestamp)
+	})
+
+	records := make([]ServiceBrokerRecord, 0, len(filtered))
+	for i := range filtered {
+		records = append(records, cfServiceBrokerToRecord(&filtered[i]))
+	}
+
+	return records, nil
+}
+
+func (r *ServiceBrokerRepo) UpdateServiceBroker(ctx context.Context, authInfo authorization.Info, message UpdateServiceBrokerMessage) (ServiceBrokerRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServiceBrokerRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfServiceBroker, err := r.getCFServiceBroker(ctx, authInfo, message.GUID)
+	if err != nil {
+		return ServiceBrokerRecord{}, err
+	}
+
+	// Secrets are not watched by the broker controller, so new credentials
+	// are stored in a new secret. Referencing it changes the broker spec,
+	// which makes the controller synchronise the catalog again.
+	oldCredentialsSecretName := cfServiceBroker.Spec.Credentials.Name
+	credentialsSecretName := oldCredentialsSecretName
+	if message.Credentials != nil {
+		credentialsSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: r.rootNamespace,
+				Labels: map[string]string{
+					korifiv1alpha1.CFServiceBrokerGUIDLabelKey: cfServiceBroker.Name,
+				},
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: korifiv1alpha1.GroupVersion.String(),
+					Kind:       "CFServiceBroker",
+					Name:       cfServiceBroker.Name,
+					UID:        cfServiceBroker.UID,
+				}},
+			},
+			StringData: credentialsSecretData(*message.Credentials),
+		}
+		if err = userClient.Create(ctx, credentialsSecret); err != nil {
+			return ServiceBrokerRecord{}, fmt.Errorf("failed to create service broker credentials: %w", apierrors.FromK8sError(err, ServiceBrokerResourceType))
+		}
+		credentialsSecretName = credentialsSecret.Name
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfServiceBroker, func() {
+		message.Apply(cfServiceBroker)
+		cfServiceBroker.Spec.Credentials.Name = credentialsSecretName
+	})
+	if err != nil {
+		return ServiceBrokerRecord{}, fmt.Errorf("failed to patch service broker: %w", apierrors.FromK8sError(err, ServiceBrokerResourceType))
+	}
+
+	if credentialsSecretName != oldCredentialsSecretName {
+		oldCredentialsSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      oldCredentialsSecretName,
+				Namespace: r.rootNamespace,
+			},
+		}
+		if err = userClient.Delete(ctx, oldCredentialsSecret); client.IgnoreNotFound(err) != nil {
+			return ServiceBrokerRecord{}, fmt.Errorf("failed to delete previous service broker credentials: %w", apierrors.FromK8sError(err, ServiceBrokerResourceType))
+		}
+	}
+
+	return cfServiceBrokerToRecord(cfServiceBroker), nil
+}
+
+func (r *ServiceBrokerRepo) DeleteServiceBroker(ctx context.Context, authInfo authorization.Info, guid string) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfServiceBroker := &korifiv1alpha1.CFServiceBroker{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      guid,
+			Namespace: r.rootNamespace,
+		},
+	}
+
+	if err = userClient.Delete(ctx, cfServiceBroker); err != nil {
+		return fmt.Errorf("failed to delete service broker: %w", apierrors.FromK8sError(err, ServiceBrokerResourceType))
+	}
+
+	return nil
+}
+
+func (r *ServiceBrokerRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error) {
+	serviceBroker, err := r.GetServiceBroker(ctx, authInfo, guid)
+	return serviceBroker.DeletedAt, err
+}
+
+func (r *ServiceBrokerRepo) GetState(ctx context.Context, authInfo authorization.Info, guid string) (ResourceState, error) {
+	cfServiceBroker, err := r.getCFServiceBroker(ctx, authInfo, guid)
+	if err != nil {
+		return ResourceState{}, err
+	}
+
+	return getResourceState(cfServiceBroker.Generation, cfServiceBroker.Status.Conditions), nil
+}
+
+func (r *ServiceBrokerRepo) getCFServiceBroker(ctx context.Context, authInfo authorization.Info, guid string) (*korifiv1alpha1.CFServiceBroker, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfServiceBroker := new(korifiv1alpha1.CFServiceBroker)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, cfServiceBroker)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service broker: %w", apierrors.FromK8sError(err, ServiceBrokerResourceType))
+	}
+
+	return cfServiceBroker, nil
+}
+
+func credentialsSecretData(credentials BasicAuthentication) map[string]string {
+	return map[string]string{
+		korifiv1alpha1.CFServiceBrokerUsernameKey: credentials.Username,
+		korifiv1alpha1.CFServiceBrokerPasswordKey: credentials.Password,
+	}
+}
+
+func cfServiceBrokerToRecord(cfServiceBroker *korifiv1alpha1.CFServiceBroker) ServiceBrokerRecord {
+	return ServiceBrokerRecord{
+		Name:        cfServiceBroker.Spec.Name,
+		GUID:        cfServiceBroker.Name,
+		URL:         cfServiceBroker.Spec.URL,
+		Labels:      cfServiceBroker.Labels,
+		Annotations: cfServiceBroker.Annotations,
+		CreatedAt:   cfServiceBroker.CreationTimestamp.Time,
+		UpdatedAt:   getLastUpdatedTime(cfServiceBroker),
+		DeletedAt:   golangTime(cfServiceBroker.DeletionTimestamp),
+	}
+}
@@ -0,0 +1,380 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("ServiceBrokerRepo", func() {
+	var (
+		repo            *ServiceBrokerRepo
+		cfServiceBroker *korifiv1alpha1.CFServiceBroker
+	)
+
+	BeforeEach(func() {
+		repo = NewServiceBrokerRepo(userClientFactory, rootNamespace)
+
+		cfServiceBroker = &korifiv1alpha1.CFServiceBroker{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      generateGUID(),
+				Namespace: rootNamespace,
+			},
+			Spec: korifiv1alpha1.CFServiceBrokerSpec{
+				Name: "existing-broker",
+				URL:  "https://existing.broker",
+				Credentials: corev1.LocalObjectReference{
+					Name: "existing-broker-credentials",
+				},
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfServiceBroker)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, cfServiceBroker))).To(Succeed())
+	})
+
+	Describe("CreateServiceBroker", func() {
+		var (
+			message   CreateServiceBrokerMessage
+			record    ServiceBrokerRecord
+			createErr error
+		)
+
+		BeforeEach(func() {
+			message = CreateServiceBrokerMessage{
+				Name: "my-broker",
+				URL:  "https://my.broker",
+				Credentials: BasicAuthentication{
+					Username: "user",
+					Password: "pass",
+				},
+				Metadata: Metadata{
+					Labels:      map[string]string{"foo": "bar"},
+					Annotations: map[string]string{"bar": "baz"},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, createErr = repo.CreateServiceBroker(ctx, authInfo, message)
+		})
+
+		It("fails because the user is not a CF admin", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns a service broker record", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(MatchRegexp("^[-0-9a-f]{36}$"), "record GUID was not a 36 character guid")
+				Expect(record.Name).To(Equal("my-broker"))
+				Expect(record.URL).To(Equal("https://my.broker"))
+				Expect(record.Labels).To(Equal(map[string]string{"foo": "bar"}))
+				Expect(record.Annotations).To(Equal(map[string]string{"bar": "baz"}))
+			})
+
+			It("creates a CFServiceBroker", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				created := new(korifiv1alpha1.CFServiceBroker)
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: record.GUID}, created)).To(Succeed())
+				Expect(created.Spec.Name).To(Equal("my-broker"))
+				Expect(created.Spec.URL).To(Equal("https://my.broker"))
+				Expect(created.Spec.Credentials.Name).To(Equal(record.GUID))
+			})
+
+			It("stores the credentials in a secret owned by the broker", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				secret := new(corev1.Secret)
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: record.GUID}, secret)).To(Succeed())
+				Expect(secret.Data).To(MatchAllKeys(Keys{
+					"username": BeEquivalentTo("user"),
+					"password": BeEquivalentTo("pass"),
+				}))
+				Expect(secret.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Kind": Equal("CFServiceBroker"),
+					"Name": Equal(record.GUID),
+				})))
+			})
+
+			When("creating the broker fails", func() {
+				BeforeEach(func() {
+					message.Metadata.Labels = map[string]string{"not a valid label key!": "foo"}
+				})
+
+				It("does not leave the credentials secret behind", func() {
+					Expect(createErr).To(HaveOccurred())
+
+					secrets := new(corev1.SecretList)
+					Expect(k8sClient.List(ctx, secrets, client.InNamespace(rootNamespace), client.HasLabels{korifiv1alpha1.CFServiceBrokerGUIDLabelKey})).To(Succeed())
+					Expect(secrets.Items).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	Describe("GetServiceBroker", func() {
+		var (
+			record ServiceBrokerRecord
+			getErr error
+		)
+
+		JustBeforeEach(func() {
+			record, getErr = repo.GetServiceBroker(ctx, authInfo, cfServiceBroker.Name)
+		})
+
+		It("returns a forbidden error as the user is not a CF admin", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the service broker", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(Equal(cfServiceBroker.Name))
+				Expect(record.Name).To(Equal("existing-broker"))
+				Expect(record.URL).To(Equal("https://existing.broker"))
+			})
+		})
+	})
+
+	Describe("ListServiceBrokers", func() {
+		var (
+			message ListServiceBrokersMessage
+			records []ServiceBrokerRecord
+			listErr error
+		)
+
+		BeforeEach(func() {
+			message = ListServiceBrokersMessage{}
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = repo.ListServiceBrokers(ctx, authInfo, message)
+		})
+
+		It("returns an empty list as the user is not a CF admin", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(records).To(BeEmpty())
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the service brokers", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"GUID": Equal(cfServiceBroker.Name),
+				})))
+			})
+
+			When("filtering by name", func() {
+				BeforeEach(func() {
+					message.Names = []string{"some-other-broker"}
+				})
+
+				It("returns the matching brokers only", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	Describe("UpdateServiceBroker", func() {
+		var (
+			message   UpdateServiceBrokerMessage
+			record    ServiceBrokerRecord
+			updateErr error
+		)
+
+		BeforeEach(func() {
+			message = UpdateServiceBrokerMessage{
+				GUID: cfServiceBroker.Name,
+				Name: tools.PtrTo("new-name"),
+				MetadataPatch: MetadataPatch{
+					Labels: map[string]*string{"foo": tools.PtrTo("bar")},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, updateErr = repo.UpdateServiceBroker(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error as the user is not a CF admin", func() {
+			Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("updates the broker", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(record.Name).To(Equal("new-name"))
+				Expect(record.Labels).To(HaveKeyWithValue("foo", "bar"))
+
+				updated := new(korifiv1alpha1.CFServiceBroker)
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceBroker), updated)).To(Succeed())
+				Expect(updated.Spec.Name).To(Equal("new-name"))
+				Expect(updated.Spec.URL).To(Equal("https://existing.broker"))
+			})
+
+			When("only the credentials change", func() {
+				var (
+					originalGeneration        int64
+					originalCredentialsSecret string
+				)
+
+				BeforeEach(func() {
+					originalGeneration = cfServiceBroker.Generation
+					originalCredentialsSecret = cfServiceBroker.Spec.Credentials.Name
+					Expect(k8sClient.Create(ctx, &corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{Name: originalCredentialsSecret, Namespace: rootNamespace},
+					})).To(Succeed())
+					message = UpdateServiceBrokerMessage{
+						GUID:        cfServiceBroker.Name,
+						Credentials: &BasicAuthentication{Username: "new-user", Password: "new-pass"},
+					}
+				})
+
+				It("references new credentials so that the broker is synchronised again", func() {
+					Expect(updateErr).NotTo(HaveOccurred())
+
+					updated := new(korifiv1alpha1.CFServiceBroker)
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceBroker), updated)).To(Succeed())
+					Expect(updated.Generation).To(BeNumerically(">", originalGeneration))
+					Expect(updated.Spec.Credentials.Name).NotTo(Equal(originalCredentialsSecret))
+
+					credentialsSecret := new(corev1.Secret)
+					Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: updated.Spec.Credentials.Name}, credentialsSecret)).To(Succeed())
+					Expect(credentialsSecret.Data).To(MatchAllKeys(Keys{
+						korifiv1alpha1.CFServiceBrokerUsernameKey: BeEquivalentTo("new-user"),
+						korifiv1alpha1.CFServiceBrokerPasswordKey: BeEquivalentTo("new-pass"),
+					}))
+					Expect(credentialsSecret.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+						"Name": Equal(cfServiceBroker.Name),
+					})))
+				})
+
+				It("deletes the previous credentials", func() {
+					Expect(updateErr).NotTo(HaveOccurred())
+					err := k8sClient.Get(ctx, client.ObjectKey{Namespace: rootNamespace, Name: originalCredentialsSecret}, new(corev1.Secret))
+					Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				})
+			})
+		})
+	})
+
+	Describe("GetState", func() {
+		var (
+			state  ResourceState
+			getErr error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+		})
+
+		JustBeforeEach(func() {
+			state, getErr = repo.GetState(ctx, authInfo, cfServiceBroker.Name)
+		})
+
+		It("returns processing while the broker has not been reconciled", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(state.Status).To(Equal(ResourceStatusProcessing))
+		})
+
+		When("the broker catalog has been synchronised", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, k8sClient, cfServiceBroker, func() {
+					meta.SetStatusCondition(&cfServiceBroker.Status.Conditions, metav1.Condition{
+						Type:               StatusConditionReady,
+						Status:             metav1.ConditionTrue,
+						Reason:             "CatalogSynced",
+						ObservedGeneration: cfServiceBroker.Generation,
+					})
+				})).To(Succeed())
+			})
+
+			It("returns ready", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(state.Status).To(Equal(ResourceStatusReady))
+			})
+		})
+
+		When("the broker catalog could not be fetched", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, k8sClient, cfServiceBroker, func() {
+					meta.SetStatusCondition(&cfServiceBroker.Status.Conditions, metav1.Condition{
+						Type:               StatusConditionReady,
+						Status:             metav1.ConditionFalse,
+						Reason:             "CatalogFetchFailed",
+						Message:            "broker responded with status 401",
+						ObservedGeneration: cfServiceBroker.Generation,
+					})
+				})).To(Succeed())
+			})
+
+			It("returns failed", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(state).To(Equal(ResourceState{
+					Status:  ResourceStatusFailed,
+					Details: "broker responded with status 401",
+				}))
+			})
+		})
+	})
+
+	Describe("DeleteServiceBroker", func() {
+		var deleteErr error
+
+		JustBeforeEach(func() {
+			deleteErr = repo.DeleteServiceBroker(ctx, authInfo, cfServiceBroker.Name)
+		})
+
+		It("returns a forbidden error as the user is not a CF admin", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("deletes the broker", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceBroker), &korifiv1alpha1.CFServiceBroker{})
+				Expect(client.IgnoreNotFound(err)).To(Succeed())
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+})
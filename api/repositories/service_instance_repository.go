@@ -2,18 +2,23 @@ package repositories
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"code.cloudfoundry.org/korifi/api/authorization"
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/google/uuid"
+	authv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
@@ -24,6 +29,8 @@ const (
 	serviceBindingSecretTypePrefix = "servicebinding.io/"
 )
 
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceinstances,verbs=get;list
+
 type NamespaceGetter interface {
 	GetNamespaceForServiceInstance(ctx context.Context, guid string) (string, error)
 }
@@ -32,52 +39,85 @@ type ServiceInstanceRepo struct {
 	namespaceRetriever   NamespaceRetriever
 	userClientFactory    authorization.UserK8sClientFactory
 	namespacePermissions *authorization.NamespacePermissions
+	privilegedClient     client.Client
+	rootNamespace        string
 }
 
 func NewServiceInstanceRepo(
 	namespaceRetriever NamespaceRetriever,
 	userClientFactory authorization.UserK8sClientFactory,
 	namespacePermissions *authorization.NamespacePermissions,
+	privilegedClient client.Client,
+	rootNamespace string,
 ) *ServiceInstanceRepo {
 	return &ServiceInstanceRepo{
 		namespaceRetriever:   namespaceRetriever,
 		userClientFactory:    userClientFactory,
 		namespacePermissions: namespacePermissions,
+		privilegedClient:     privilegedClient,
+		rootNamespace:        rootNamespace,
 	}
 }
 
 type CreateServiceInstanceMessage struct {
-	Name        string
-	SpaceGUID   string
-	Credentials map[string]string
-	Type        string
-	Tags        []string
-	Labels      map[string]string
-	Annotations map[string]string
+	Name            string
+	SpaceGUID       string
+	Credentials     map[string]string
+	SyslogDrainURL  *string
+	RouteServiceURL *string
+	Type            string
+	PlanGUID        string
+	Parameters      map[string]any
+	Tags            []string
+	Labels          map[string]string
+	Annotations     map[string]string
 }
 
 type PatchServiceInstanceMessage struct {
-	GUID        string
-	SpaceGUID   string
-	Name        *string
-	Credentials *map[string]string
-	Tags        *[]string
+	GUID            string
+	SpaceGUID       string
+	Name            *string
+	Credentials     *map[string]string
+	SyslogDrainURL  *string
+	RouteServiceURL *string
+	PlanGUID        *string
+	Parameters      *map[string]any
+	Tags            *[]string
 	MetadataPatch
 }
 
-func (p PatchServiceInstanceMessage) Apply(cfServiceInstance *korifiv1alpha1.CFServiceInstance) {
+func (p PatchServiceInstanceMessage) Apply(cfServiceInstance *korifiv1alpha1.CFServiceInstance) error {
 	if p.Name != nil {
 		cfServiceInstance.Spec.DisplayName = *p.Name
 	}
 	if p.Tags != nil {
 		cfServiceInstance.Spec.Tags = *p.Tags
 	}
+	if p.SyslogDrainURL != nil {
+		cfServiceInstance.Spec.SyslogDrainURL = nilIfEmpty(*p.SyslogDrainURL)
+	}
+	if p.RouteServiceURL != nil {
+		cfServiceInstance.Spec.RouteServiceURL = nilIfEmpty(*p.RouteServiceURL)
+	}
+	if p.PlanGUID != nil {
+		cfServiceInstance.Spec.PlanGUID = *p.PlanGUID
+	}
+	if p.Parameters != nil {
+		parameters, err := toRawExtension(*p.Parameters)
+		if err != nil {
+			return err
+		}
+		cfServiceInstance.Spec.Parameters = parameters
+	}
 	p.MetadataPatch.Apply(cfServiceInstance)
+
+	return nil
 }
 
 type ListServiceInstanceMessage struct {
 	Names      []string
 	SpaceGuids []string
+	PlanGUIDs  []string
 }
 
 type DeleteServiceInstanceMessage struct {
@@ -85,17 +125,40 @@ type DeleteServiceInstanceMessage struct {
 	SpaceGUID string
 }
 
+type ShareServiceInstanceMessage struct {
+	GUID             string
+	SpaceGUID        string
+	TargetSpaceGUIDs []string
+}
+
+type UnshareServiceInstanceMessage struct {
+	GUID            string
+	SpaceGUID       string
+	TargetSpaceGUID string
+}
+
 type ServiceInstanceRecord struct {
-	Name        string
-	GUID        string
-	SpaceGUID   string
-	SecretName  string
-	Tags        []string
+	Name             string
+	GUID             string
+	SpaceGUID        string
+	SecretName       string
+	Tags             []string
+	Type             string
+	PlanGUID         string
+	RouteServiceURL  *string
+	SyslogDrainURL   *string
+	SharedSpaceGUIDs []string
+	LastOperation    *ServiceInstanceLastOperation
+	Labels           map[string]string
+	Annotations      map[string]string
+	CreatedAt        time.Time
+	UpdatedAt        *time.Time
+}
+
+type ServiceInstanceLastOperation struct {
 	Type        string
-	Labels      map[string]string
-	Annotations map[string]string
-	CreatedAt   time.Time
-	UpdatedAt   *time.Time
+	State       string
+	Description string
 }
 
 func (r *ServiceInstanceRepo) CreateServiceInstance(ctx context.Context, authInfo authorization.Info, message CreateServiceInstanceMessage) (ServiceInstanceRecord, error) {
@@ -105,12 +168,26 @@ func (r *ServiceInstanceRepo) CreateServiceInstance(ctx context.Context, authInf
 		return ServiceInstanceRecord{}, fmt.Errorf("failed to build user client: %w", err)
 	}
 
-	cfServiceInstance := message.toCFServiceInstance()
+	if message.Type == korifiv1alpha1.ManagedType {
+		if _, err = r.getAvailablePlan(ctx, userClient, authInfo, message.PlanGUID, message.SpaceGUID); err != nil {
+			return ServiceInstanceRecord{}, err
+		}
+	}
+
+	cfServiceInstance, err := message.toCFServiceInstance()
+	if err != nil {
+		return ServiceInstanceRecord{}, err
+	}
+
 	err = userClient.Create(ctx, &cfServiceInstance)
 	if err != nil {
 		return ServiceInstanceRecord{}, apierrors.FromK8sError(err, ServiceInstanceResourceType)
 	}
 
+	if cfServiceInstance.Spec.Type == korifiv1alpha1.ManagedType {
+		return cfServiceInstanceToServiceInstanceRecord(cfServiceInstance), nil
+	}
+
 	secretObj := cfServiceInstanceToSecret(cfServiceInstance)
 	_, err = controllerutil.CreateOrPatch(ctx, userClient, &secretObj, func() error {
 		secretObj.StringData = message.Credentials
@@ -141,9 +218,19 @@ func (r *ServiceInstanceRepo) PatchServiceInstance(ctx context.Context, authInfo
 		return ServiceInstanceRecord{}, apierrors.FromK8sError(err, ServiceInstanceResourceType)
 	}
 
+	if message.PlanGUID != nil && *message.PlanGUID != cfServiceInstance.Spec.PlanGUID {
+		if err = r.validatePlanChange(ctx, userClient, authInfo, cfServiceInstance, *message.PlanGUID); err != nil {
+			return ServiceInstanceRecord{}, err
+		}
+	}
+
+	var applyErr error
 	err = k8s.PatchResource(ctx, userClient, &cfServiceInstance, func() {
-		message.Apply(&cfServiceInstance)
+		applyErr = message.Apply(&cfServiceInstance)
 	})
+	if applyErr != nil {
+		return ServiceInstanceRecord{}, applyErr
+	}
 	if err != nil {
 		return ServiceInstanceRecord{}, apierrors.FromK8sError(err, ServiceInstanceResourceType)
 	}
@@ -198,6 +285,7 @@ func (r *ServiceInstanceRepo) ListServiceInstances(ctx context.Context, authInfo
 
 	preds := []func(korifiv1alpha1.CFServiceInstance) bool{
 		SetPredicate(message.Names, func(s korifiv1alpha1.CFServiceInstance) string { return s.Spec.DisplayName }),
+		SetPredicate(message.PlanGUIDs, func(s korifiv1alpha1.CFServiceInstance) string { return s.Spec.PlanGUID }),
 	}
 
 	spaceGUIDSet := NewSet(message.SpaceGuids...)
@@ -221,9 +309,52 @@ func (r *ServiceInstanceRepo) ListServiceInstances(ctx context.Context, authInfo
 		filteredServiceInstances = append(filteredServiceInstances, Filter(serviceInstanceList.Items, preds...)...)
 	}
 
+	sharedServiceInstances, err := r.listSharedServiceInstances(ctx, nsList, spaceGUIDSet)
+	if err != nil {
+		return []ServiceInstanceRecord{}, err
+	}
+	filteredServiceInstances = append(filteredServiceInstances, Filter(sharedServiceInstances, preds...)...)
+
 	return returnServiceInstanceList(filteredServiceInstances), nil
 }
 
+// listSharedServiceInstances returns the service instances from other spaces
+// that are shared with any of the given spaces. Users have no permissions in
+// the spaces owning these instances, so they are listed with the privileged
+// client, by the shared-with label of each of the spaces
+func (r *ServiceInstanceRepo) listSharedServiceInstances(ctx context.Context, nsList map[string]bool, spaceGUIDSet Set[string]) ([]korifiv1alpha1.CFServiceInstance, error) {
+	sharedServiceInstances := map[client.ObjectKey]korifiv1alpha1.CFServiceInstance{}
+	for ns := range nsList {
+		if len(spaceGUIDSet) > 0 && !spaceGUIDSet.Includes(ns) {
+			continue
+		}
+
+		serviceInstanceList := new(korifiv1alpha1.CFServiceInstanceList)
+		err := r.privilegedClient.List(ctx, serviceInstanceList, client.HasLabels{sharedWithLabelKey(ns)})
+		if err != nil {
+			return nil, fmt.Errorf("failed to list service instances shared with namespace %s: %w", ns, apierrors.FromK8sError(err, ServiceInstanceResourceType))
+		}
+
+		for _, serviceInstance := range serviceInstanceList.Items {
+			if nsList[serviceInstance.Namespace] {
+				continue
+			}
+			sharedServiceInstances[client.ObjectKeyFromObject(&serviceInstance)] = serviceInstance
+		}
+	}
+
+	result := make([]korifiv1alpha1.CFServiceInstance, 0, len(sharedServiceInstances))
+	for _, serviceInstance := range sharedServiceInstances {
+		result = append(result, serviceInstance)
+	}
+
+	return result, nil
+}
+
+func sharedWithLabelKey(spaceGUID string) string {
+	return korifiv1alpha1.CFServiceInstanceSharedWithLabelPrefix + spaceGUID
+}
+
 func (r *ServiceInstanceRepo) GetServiceInstance(ctx context.Context, authInfo authorization.Info, guid string) (ServiceInstanceRecord, error) {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -236,13 +367,139 @@ func (r *ServiceInstanceRepo) GetServiceInstance(ctx context.Context, authInfo a
 	}
 
 	var serviceInstance korifiv1alpha1.CFServiceInstance
-	if err := userClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: guid}, &serviceInstance); err != nil {
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: guid}, &serviceInstance)
+	if k8serrors.IsForbidden(err) {
+		serviceInstance, err = r.getSharedServiceInstance(ctx, authInfo, namespace, guid, err)
+	}
+	if err != nil {
 		return ServiceInstanceRecord{}, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
 	}
 
 	return cfServiceInstanceToServiceInstanceRecord(serviceInstance), nil
 }
 
+// getSharedServiceInstance fetches a service instance the user is not allowed
+// to get in its own space. The instance is returned if it is shared with one
+// of the spaces of the user, otherwise the original forbidden error is returned
+func (r *ServiceInstanceRepo) getSharedServiceInstance(ctx context.Context, authInfo authorization.Info, namespace, guid string, forbiddenErr error) (korifiv1alpha1.CFServiceInstance, error) {
+	nsList, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return korifiv1alpha1.CFServiceInstance{}, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+	}
+
+	var serviceInstance korifiv1alpha1.CFServiceInstance
+	if err = r.privilegedClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: guid}, &serviceInstance); err != nil {
+		return korifiv1alpha1.CFServiceInstance{}, err
+	}
+
+	for _, sharedSpace := range serviceInstance.Spec.SharedSpaces {
+		if nsList[sharedSpace] {
+			return serviceInstance, nil
+		}
+	}
+
+	return korifiv1alpha1.CFServiceInstance{}, forbiddenErr
+}
+
+func (r *ServiceInstanceRepo) ShareServiceInstance(ctx context.Context, authInfo authorization.Info, message ShareServiceInstanceMessage) (ServiceInstanceRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServiceInstanceRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfServiceInstance := new(korifiv1alpha1.CFServiceInstance)
+	if err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.GUID}, cfServiceInstance); err != nil {
+		return ServiceInstanceRecord{}, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	for _, targetSpaceGUID := range message.TargetSpaceGUIDs {
+		var allowed bool
+		allowed, err = r.canIBindInSpace(ctx, userClient, targetSpaceGUID)
+		if err != nil {
+			return ServiceInstanceRecord{}, err
+		}
+
+		if !allowed {
+			return ServiceInstanceRecord{}, apierrors.NewUnprocessableEntityError(
+				fmt.Errorf("user cannot share service instance %q with space %q", message.GUID, targetSpaceGUID),
+				fmt.Sprintf("Unable to share service instance %s with spaces ['%s']. Ensure the spaces exist and that you have access to them.", cfServiceInstance.Spec.DisplayName, targetSpaceGUID),
+			)
+		}
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfServiceInstance, func() {
+		if cfServiceInstance.Labels == nil {
+			cfServiceInstance.Labels = map[string]string{}
+		}
+
+		sharedSpaces := NewSet(cfServiceInstance.Spec.SharedSpaces...)
+		for _, targetSpaceGUID := range message.TargetSpaceGUIDs {
+			cfServiceInstance.Labels[sharedWithLabelKey(targetSpaceGUID)] = "true"
+			if !sharedSpaces.Includes(targetSpaceGUID) {
+				cfServiceInstance.Spec.SharedSpaces = append(cfServiceInstance.Spec.SharedSpaces, targetSpaceGUID)
+				sharedSpaces[targetSpaceGUID] = struct{}{}
+			}
+		}
+	})
+	if err != nil {
+		return ServiceInstanceRecord{}, fmt.Errorf("failed to share service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	return cfServiceInstanceToServiceInstanceRecord(*cfServiceInstance), nil
+}
+
+func (r *ServiceInstanceRepo) UnshareServiceInstance(ctx context.Context, authInfo authorization.Info, message UnshareServiceInstanceMessage) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfServiceInstance := new(korifiv1alpha1.CFServiceInstance)
+	if err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.GUID}, cfServiceInstance); err != nil {
+		return fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfServiceInstance, func() {
+		delete(cfServiceInstance.Labels, sharedWithLabelKey(message.TargetSpaceGUID))
+		cfServiceInstance.Spec.SharedSpaces = Filter(cfServiceInstance.Spec.SharedSpaces, func(sharedSpace string) bool {
+			return sharedSpace != message.TargetSpaceGUID
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to unshare service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	return nil
+}
+
+// canIBindInSpace checks that the space exists and that the user is allowed to
+// bind service instances in it, which is required to share instances with it
+func (r *ServiceInstanceRepo) canIBindInSpace(ctx context.Context, userClient client.Client, spaceGUID string) (bool, error) {
+	_, err := r.namespaceRetriever.NamespaceFor(ctx, spaceGUID, SpaceResourceType)
+	if err != nil {
+		if errors.As(err, &apierrors.NotFoundError{}) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	review := authv1.SelfSubjectAccessReview{
+		Spec: authv1.SelfSubjectAccessReviewSpec{
+			ResourceAttributes: &authv1.ResourceAttributes{
+				Namespace: spaceGUID,
+				Verb:      "create",
+				Group:     "korifi.cloudfoundry.org",
+				Resource:  "cfservicebindings",
+			},
+		},
+	}
+	if err = userClient.Create(ctx, &review); err != nil {
+		return false, fmt.Errorf("failed to create self subject access review: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	return review.Status.Allowed, nil
+}
+
 func (r *ServiceInstanceRepo) DeleteServiceInstance(ctx context.Context, authInfo authorization.Info, message DeleteServiceInstanceMessage) error {
 	userClient, err := r.userClientFactory.BuildClient(authInfo)
 	if err != nil {
@@ -263,9 +520,191 @@ func (r *ServiceInstanceRepo) DeleteServiceInstance(ctx context.Context, authInf
 	return nil
 }
 
-func (m CreateServiceInstanceMessage) toCFServiceInstance() korifiv1alpha1.CFServiceInstance {
+// GetServiceInstanceCredentials returns the credentials of a user-provided
+// service instance. Managed service instances have no credentials of their own
+func (r *ServiceInstanceRepo) GetServiceInstanceCredentials(ctx context.Context, authInfo authorization.Info, guid string) (map[string]string, error) {
+	cfServiceInstance, err := r.getCFServiceInstance(ctx, authInfo, guid)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfServiceInstance.Spec.Type != korifiv1alpha1.UserProvidedType {
+		return nil, apierrors.NewNotFoundError(fmt.Errorf("service instance %s is not user-provided", guid), ServiceInstanceResourceType)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	credentialsSecret := new(corev1.Secret)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: cfServiceInstance.Namespace, Name: cfServiceInstance.Spec.SecretName}, credentialsSecret)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get credentials secret: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	credentials := map[string]string{}
+	for key, value := range credentialsSecret.Data {
+		credentials[key] = string(value)
+	}
+
+	return credentials, nil
+}
+
+// GetServiceInstanceParameters returns the parameters last sent to the broker
+// of a managed service instance
+func (r *ServiceInstanceRepo) GetServiceInstanceParameters(ctx context.Context, authInfo authorization.Info, guid string) (map[string]any, error) {
+	cfServiceInstance, err := r.getCFServiceInstance(ctx, authInfo, guid)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfServiceInstance.Spec.Type != korifiv1alpha1.ManagedType {
+		return nil, apierrors.NewNotFoundError(fmt.Errorf("service instance %s is not managed", guid), ServiceInstanceResourceType)
+	}
+
+	parameters := map[string]any{}
+	if cfServiceInstance.Spec.Parameters == nil || len(cfServiceInstance.Spec.Parameters.Raw) == 0 {
+		return parameters, nil
+	}
+
+	if err = json.Unmarshal(cfServiceInstance.Spec.Parameters.Raw, &parameters); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal parameters of service instance %s: %w", guid, err)
+	}
+
+	return parameters, nil
+}
+
+func (r *ServiceInstanceRepo) GetState(ctx context.Context, authInfo authorization.Info, guid string) (ResourceState, error) {
+	cfServiceInstance, err := r.getCFServiceInstance(ctx, authInfo, guid)
+	if err != nil {
+		return ResourceState{}, err
+	}
+
+	return getResourceState(cfServiceInstance.Generation, cfServiceInstance.Status.Conditions), nil
+}
+
+// GetDeletionState reports the progress of deleting the service instance from
+// its last operation. The deletion is complete once the instance is gone.
+func (r *ServiceInstanceRepo) GetDeletionState(ctx context.Context, authInfo authorization.Info, guid string) (ResourceState, error) {
+	cfServiceInstance, err := r.getCFServiceInstance(ctx, authInfo, guid)
+	if err != nil {
+		if errors.As(err, &apierrors.NotFoundError{}) {
+			return ResourceState{Status: ResourceStatusReady}, nil
+		}
+		return ResourceState{}, err
+	}
+
+	lastOperation := cfServiceInstance.Status.LastOperation
+	if lastOperation != nil &&
+		lastOperation.Type == korifiv1alpha1.DeleteLastOperationType &&
+		lastOperation.State == korifiv1alpha1.FailedLastOperationState {
+		return ResourceState{Status: ResourceStatusFailed, Details: lastOperation.Description}, nil
+	}
+
+	return ResourceState{Status: ResourceStatusProcessing}, nil
+}
+
+func (r *ServiceInstanceRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error) {
+	cfServiceInstance, err := r.getCFServiceInstance(ctx, authInfo, guid)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfServiceInstance.DeletionTimestamp != nil {
+		return tools.PtrTo(cfServiceInstance.DeletionTimestamp.Time), nil
+	}
+
+	return nil, nil
+}
+
+func (r *ServiceInstanceRepo) getCFServiceInstance(ctx context.Context, authInfo authorization.Info, guid string) (korifiv1alpha1.CFServiceInstance, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return korifiv1alpha1.CFServiceInstance{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	namespace, err := r.namespaceRetriever.NamespaceFor(ctx, guid, ServiceInstanceResourceType)
+	if err != nil {
+		return korifiv1alpha1.CFServiceInstance{}, err
+	}
+
+	var cfServiceInstance korifiv1alpha1.CFServiceInstance
+	if err := userClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: guid}, &cfServiceInstance); err != nil {
+		return korifiv1alpha1.CFServiceInstance{}, fmt.Errorf("failed to get service instance: %w", apierrors.FromK8sError(err, ServiceInstanceResourceType))
+	}
+
+	return cfServiceInstance, nil
+}
+
+// getAvailablePlan returns the plan with the given GUID if the user is allowed
+// to create instances of it in the given space
+func (r *ServiceInstanceRepo) getAvailablePlan(ctx context.Context, userClient client.Client, authInfo authorization.Info, planGUID, spaceGUID string) (*korifiv1alpha1.CFServicePlan, error) {
+	invalidPlanErr := apierrors.NewUnprocessableEntityError(
+		fmt.Errorf("service plan %q is not available in space %q", planGUID, spaceGUID),
+		"Invalid service plan. Ensure that the service plan exists, is available, and you have access to it.",
+	)
+
+	cfServicePlan := new(korifiv1alpha1.CFServicePlan)
+	if err := userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: planGUID}, cfServicePlan); err != nil {
+		if k8serrors.IsNotFound(err) || k8serrors.IsForbidden(err) {
+			return nil, invalidPlanErr
+		}
+		return nil, fmt.Errorf("failed to get service plan: %w", apierrors.FromK8sError(err, ServicePlanResourceType))
+	}
+
+	visibility, err := getPlanVisibility(ctx, userClient, r.namespacePermissions, authInfo, r.rootNamespace)
+	if err != nil {
+		return nil, err
+	}
+	if visibility.isAdmin {
+		return cfServicePlan, nil
+	}
+
+	availableInSpace, err := availableInOrgsPredicate(ctx, r.namespaceRetriever, nil, []string{spaceGUID})
+	if err != nil {
+		return nil, err
+	}
+	if !availableInSpace(*cfServicePlan) {
+		return nil, invalidPlanErr
+	}
+
+	return cfServicePlan, nil
+}
+
+// validatePlanChange checks that an instance can be moved to the given plan.
+// The new plan has to be available and belong to the same service offering
+func (r *ServiceInstanceRepo) validatePlanChange(ctx context.Context, userClient client.Client, authInfo authorization.Info, cfServiceInstance korifiv1alpha1.CFServiceInstance, planGUID string) error {
+	if cfServiceInstance.Spec.Type != korifiv1alpha1.ManagedType {
+		return apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("cannot set the plan of user-provided service instance %q", cfServiceInstance.Name),
+			"Service plans can only be set for managed service instances.",
+		)
+	}
+
+	newPlan, err := r.getAvailablePlan(ctx, userClient, authInfo, planGUID, cfServiceInstance.Namespace)
+	if err != nil {
+		return err
+	}
+
+	currentPlan := new(korifiv1alpha1.CFServicePlan)
+	if err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: cfServiceInstance.Spec.PlanGUID}, currentPlan); err != nil {
+		return fmt.Errorf("failed to get current service plan: %w", apierrors.FromK8sError(err, ServicePlanResourceType))
+	}
+
+	if currentPlan.Labels[korifiv1alpha1.CFServiceOfferingGUIDLabelKey] != newPlan.Labels[korifiv1alpha1.CFServiceOfferingGUIDLabelKey] {
+		return apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("service plan %q belongs to a different service offering", planGUID),
+			"Cannot update the plan of a service instance to a plan of a different service offering.",
+		)
+	}
+
+	return nil
+}
+
+func (m CreateServiceInstanceMessage) toCFServiceInstance() (korifiv1alpha1.CFServiceInstance, error) {
 	guid := uuid.NewString()
-	return korifiv1alpha1.CFServiceInstance{
+	cfServiceInstance := korifiv1alpha1.CFServiceInstance{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:        guid,
 			Namespace:   m.SpaceGUID,
@@ -274,26 +713,80 @@ func (m CreateServiceInstanceMessage) toCFServiceInstance() korifiv1alpha1.CFSer
 		},
 		Spec: korifiv1alpha1.CFServiceInstanceSpec{
 			DisplayName: m.Name,
-			SecretName:  guid,
 			Type:        korifiv1alpha1.InstanceType(m.Type),
 			Tags:        m.Tags,
 		},
 	}
+
+	if m.Type != korifiv1alpha1.ManagedType {
+		cfServiceInstance.Spec.SecretName = guid
+		if m.SyslogDrainURL != nil {
+			cfServiceInstance.Spec.SyslogDrainURL = nilIfEmpty(*m.SyslogDrainURL)
+		}
+		if m.RouteServiceURL != nil {
+			cfServiceInstance.Spec.RouteServiceURL = nilIfEmpty(*m.RouteServiceURL)
+		}
+		return cfServiceInstance, nil
+	}
+
+	parameters, err := toRawExtension(m.Parameters)
+	if err != nil {
+		return korifiv1alpha1.CFServiceInstance{}, err
+	}
+	cfServiceInstance.Spec.PlanGUID = m.PlanGUID
+	cfServiceInstance.Spec.Parameters = parameters
+
+	return cfServiceInstance, nil
 }
 
 func cfServiceInstanceToServiceInstanceRecord(cfServiceInstance korifiv1alpha1.CFServiceInstance) ServiceInstanceRecord {
+	var lastOperation *ServiceInstanceLastOperation
+	if cfServiceInstance.Status.LastOperation != nil {
+		lastOperation = &ServiceInstanceLastOperation{
+			Type:        cfServiceInstance.Status.LastOperation.Type,
+			State:       cfServiceInstance.Status.LastOperation.State,
+			Description: cfServiceInstance.Status.LastOperation.Description,
+		}
+	}
+
 	return ServiceInstanceRecord{
-		Name:        cfServiceInstance.Spec.DisplayName,
-		GUID:        cfServiceInstance.Name,
-		SpaceGUID:   cfServiceInstance.Namespace,
-		SecretName:  cfServiceInstance.Spec.SecretName,
-		Tags:        cfServiceInstance.Spec.Tags,
-		Type:        string(cfServiceInstance.Spec.Type),
-		Labels:      cfServiceInstance.Labels,
-		Annotations: cfServiceInstance.Annotations,
-		CreatedAt:   cfServiceInstance.CreationTimestamp.Time,
-		UpdatedAt:   getLastUpdatedTime(&cfServiceInstance),
+		Name:             cfServiceInstance.Spec.DisplayName,
+		GUID:             cfServiceInstance.Name,
+		SpaceGUID:        cfServiceInstance.Namespace,
+		SecretName:       cfServiceInstance.Spec.SecretName,
+		Tags:             cfServiceInstance.Spec.Tags,
+		Type:             string(cfServiceInstance.Spec.Type),
+		PlanGUID:         cfServiceInstance.Spec.PlanGUID,
+		RouteServiceURL:  cfServiceInstance.Spec.RouteServiceURL,
+		SyslogDrainURL:   cfServiceInstance.Spec.SyslogDrainURL,
+		SharedSpaceGUIDs: cfServiceInstance.Spec.SharedSpaces,
+		LastOperation:    lastOperation,
+		Labels:           cfServiceInstance.Labels,
+		Annotations:      cfServiceInstance.Annotations,
+		CreatedAt:        cfServiceInstance.CreationTimestamp.Time,
+		UpdatedAt:        getLastUpdatedTime(&cfServiceInstance),
+	}
+}
+
+func nilIfEmpty(value string) *string {
+	if value == "" {
+		return nil
+	}
+
+	return &value
+}
+
+func toRawExtension(value map[string]any) (*runtime.RawExtension, error) {
+	if value == nil {
+		return nil, nil
 	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %v: %w", value, err)
+	}
+
+	return &runtime.RawExtension{Raw: raw}, nil
 }
 
 func cfServiceInstanceToSecret(cfServiceInstance korifiv1alpha1.CFServiceInstance) corev1.Secret {
@@ -9,7 +9,9 @@ import (
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/repositories"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
 	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -17,7 +19,9 @@ import (
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 )
 
@@ -33,7 +37,7 @@ var _ = Describe("ServiceInstanceRepository", func() {
 
 	BeforeEach(func() {
 		testCtx = context.Background()
-		serviceInstanceRepo = repositories.NewServiceInstanceRepo(namespaceRetriever, userClientFactory, nsPerms)
+		serviceInstanceRepo = repositories.NewServiceInstanceRepo(namespaceRetriever, userClientFactory, nsPerms, k8sClient, rootNamespace)
 
 		org = createOrgWithCleanup(testCtx, prefixedGUID("org"))
 		space = createSpaceWithCleanup(testCtx, org.Name, prefixedGUID("space1"))
@@ -146,6 +150,23 @@ var _ = Describe("ServiceInstanceRepository", func() {
 					})
 				})
 			})
+
+			When("the syslog drain and route service URLs are provided", func() {
+				BeforeEach(func() {
+					serviceInstanceCreateMessage.SyslogDrainURL = tools.PtrTo("syslog://logs.example.com:514")
+					serviceInstanceCreateMessage.RouteServiceURL = tools.PtrTo("https://route-service.example.com")
+				})
+
+				It("stores them in the ServiceInstance CR", func() {
+					Expect(createdServiceInstanceRecord.SyslogDrainURL).To(PointTo(Equal("syslog://logs.example.com:514")))
+					Expect(createdServiceInstanceRecord.RouteServiceURL).To(PointTo(Equal("https://route-service.example.com")))
+
+					serviceInstance := new(korifiv1alpha1.CFServiceInstance)
+					Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: space.Name, Name: createdServiceInstanceRecord.GUID}, serviceInstance)).To(Succeed())
+					Expect(serviceInstance.Spec.SyslogDrainURL).To(PointTo(Equal("syslog://logs.example.com:514")))
+					Expect(serviceInstance.Spec.RouteServiceURL).To(PointTo(Equal("https://route-service.example.com")))
+				})
+			})
 		})
 
 		When("user does not have permissions to create ServiceInstances", func() {
@@ -153,6 +174,67 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				Expect(createErr).To(BeAssignableToTypeOf(apierrors.ForbiddenError{}))
 			})
 		})
+
+		When("creating a managed service instance", func() {
+			var cfServicePlan *korifiv1alpha1.CFServicePlan
+
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, orgUserRole.Name, org.Name)
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+
+				cfServicePlan = createServicePlan(createServiceOffering("my-offering"), "my-plan", korifiv1alpha1.ServicePlanVisibility{
+					Type:          korifiv1alpha1.OrganizationServicePlanVisibilityType,
+					Organizations: []string{org.Name},
+				})
+
+				serviceInstanceCreateMessage = repositories.CreateServiceInstanceMessage{
+					Name:       serviceInstanceName,
+					SpaceGUID:  space.Name,
+					Type:       korifiv1alpha1.ManagedType,
+					PlanGUID:   cfServicePlan.Name,
+					Parameters: map[string]any{"size": "xs"},
+					Tags:       serviceInstanceTags,
+				}
+			})
+
+			It("creates a managed ServiceInstance CR", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(createdServiceInstanceRecord.Type).To(Equal(korifiv1alpha1.ManagedType))
+				Expect(createdServiceInstanceRecord.PlanGUID).To(Equal(cfServicePlan.Name))
+				Expect(createdServiceInstanceRecord.SecretName).To(BeEmpty())
+
+				cfServiceInstance := new(korifiv1alpha1.CFServiceInstance)
+				Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: space.Name, Name: createdServiceInstanceRecord.GUID}, cfServiceInstance)).To(Succeed())
+				Expect(cfServiceInstance.Spec.PlanGUID).To(Equal(cfServicePlan.Name))
+				Expect(cfServiceInstance.Spec.Parameters.Raw).To(MatchJSON(`{"size":"xs"}`))
+			})
+
+			It("does not create a credentials secret", func() {
+				err := k8sClient.Get(ctx, types.NamespacedName{Namespace: space.Name, Name: createdServiceInstanceRecord.GUID}, new(corev1.Secret))
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+
+			When("the plan is not available in the org of the space", func() {
+				BeforeEach(func() {
+					cfServicePlan.Spec.Visibility.Organizations = []string{"another-org"}
+					Expect(k8sClient.Update(ctx, cfServicePlan)).To(Succeed())
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
+			When("the plan does not exist", func() {
+				BeforeEach(func() {
+					serviceInstanceCreateMessage.PlanGUID = "does-not-exist"
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
 	})
 
 	Describe("PatchServiceInstance", func() {
@@ -334,6 +416,34 @@ var _ = Describe("ServiceInstanceRepository", func() {
 				})
 			})
 
+			When("the syslog drain and route service URLs are set", func() {
+				BeforeEach(func() {
+					patchMessage.SyslogDrainURL = tools.PtrTo("syslog://logs.example.com:514")
+					patchMessage.RouteServiceURL = tools.PtrTo("https://route-service.example.com")
+				})
+
+				It("updates them", func() {
+					Expect(err).NotTo(HaveOccurred())
+					Expect(serviceInstanceRecord.SyslogDrainURL).To(PointTo(Equal("syslog://logs.example.com:514")))
+					Expect(serviceInstanceRecord.RouteServiceURL).To(PointTo(Equal("https://route-service.example.com")))
+				})
+
+				When("they are cleared afterwards", func() {
+					JustBeforeEach(func() {
+						Expect(err).NotTo(HaveOccurred())
+						patchMessage.SyslogDrainURL = tools.PtrTo("")
+						patchMessage.RouteServiceURL = tools.PtrTo("")
+						serviceInstanceRecord, err = serviceInstanceRepo.PatchServiceInstance(testCtx, authInfo, patchMessage)
+					})
+
+					It("removes them", func() {
+						Expect(err).NotTo(HaveOccurred())
+						Expect(serviceInstanceRecord.SyslogDrainURL).To(BeNil())
+						Expect(serviceInstanceRecord.RouteServiceURL).To(BeNil())
+					})
+				})
+			})
+
 			When("ServiceInstance credentials are cleared out", func() {
 				BeforeEach(func() {
 					patchMessage.Credentials = &map[string]string{}
@@ -352,6 +462,85 @@ var _ = Describe("ServiceInstanceRepository", func() {
 		})
 	})
 
+	Describe("PatchServiceInstance for managed service instances", func() {
+		var (
+			cfServiceOffering *korifiv1alpha1.CFServiceOffering
+			smallPlan         *korifiv1alpha1.CFServicePlan
+			largePlan         *korifiv1alpha1.CFServicePlan
+			cfServiceInstance *korifiv1alpha1.CFServiceInstance
+			patchMessage      repositories.PatchServiceInstanceMessage
+			patchErr          error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(testCtx, userName, orgUserRole.Name, org.Name)
+			createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+
+			publicVisibility := korifiv1alpha1.ServicePlanVisibility{Type: korifiv1alpha1.PublicServicePlanVisibilityType}
+			cfServiceOffering = createServiceOffering("my-offering")
+			smallPlan = createServicePlan(cfServiceOffering, "small", publicVisibility)
+			largePlan = createServicePlan(cfServiceOffering, "large", publicVisibility)
+
+			cfServiceInstance = &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      generateGUID(),
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					DisplayName: serviceInstanceName,
+					Type:        korifiv1alpha1.ManagedType,
+					PlanGUID:    smallPlan.Name,
+				},
+			}
+			Expect(k8sClient.Create(ctx, cfServiceInstance)).To(Succeed())
+
+			patchMessage = repositories.PatchServiceInstanceMessage{
+				GUID:       cfServiceInstance.Name,
+				SpaceGUID:  space.Name,
+				PlanGUID:   tools.PtrTo(largePlan.Name),
+				Parameters: &map[string]any{"size": "xl"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			_, patchErr = serviceInstanceRepo.PatchServiceInstance(testCtx, authInfo, patchMessage)
+		})
+
+		It("updates the plan and the parameters", func() {
+			Expect(patchErr).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), cfServiceInstance)).To(Succeed())
+			Expect(cfServiceInstance.Spec.PlanGUID).To(Equal(largePlan.Name))
+			Expect(cfServiceInstance.Spec.Parameters.Raw).To(MatchJSON(`{"size":"xl"}`))
+		})
+
+		When("the new plan belongs to a different offering", func() {
+			BeforeEach(func() {
+				otherPlan := createServicePlan(createServiceOffering("other-offering"), "other", korifiv1alpha1.ServicePlanVisibility{
+					Type: korifiv1alpha1.PublicServicePlanVisibilityType,
+				})
+				patchMessage.PlanGUID = tools.PtrTo(otherPlan.Name)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			})
+		})
+
+		When("the new plan is not available", func() {
+			BeforeEach(func() {
+				adminPlan := createServicePlan(cfServiceOffering, "admin", korifiv1alpha1.ServicePlanVisibility{
+					Type: korifiv1alpha1.AdminServicePlanVisibilityType,
+				})
+				patchMessage.PlanGUID = tools.PtrTo(adminPlan.Name)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			})
+		})
+	})
+
 	Describe("ListServiceInstances", func() {
 		var (
 			space2, space3                                             *korifiv1alpha1.CFSpace
@@ -404,6 +593,40 @@ var _ = Describe("ServiceInstanceRepository", func() {
 					MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfServiceInstance2.Name)}),
 				))
 			})
+
+			When("a service instance from another space is shared with a space of the user", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(testCtx, k8sClient, cfServiceInstance3, func() {
+						cfServiceInstance3.Labels = map[string]string{korifiv1alpha1.CFServiceInstanceSharedWithLabelPrefix + space2.Name: "true"}
+						cfServiceInstance3.Spec.SharedSpaces = []string{space2.Name}
+					})).To(Succeed())
+				})
+
+				It("includes the shared service instance", func() {
+					Expect(serviceInstanceList).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfServiceInstance1.Name)}),
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfServiceInstance2.Name)}),
+						MatchFields(IgnoreExtras, Fields{
+							"GUID":             Equal(cfServiceInstance3.Name),
+							"SpaceGUID":        Equal(space3.Name),
+							"SharedSpaceGUIDs": ConsistOf(space2.Name),
+						}),
+					))
+				})
+
+				When("the spaceGUID filter is set to the space the instance is shared with", func() {
+					BeforeEach(func() {
+						filters = repositories.ListServiceInstanceMessage{SpaceGuids: []string{space2.Name}}
+					})
+
+					It("returns the instances of the space and the instances shared with it", func() {
+						Expect(serviceInstanceList).To(ConsistOf(
+							MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfServiceInstance2.Name)}),
+							MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfServiceInstance3.Name)}),
+						))
+					})
+				})
+			})
 		})
 
 		When("user has permissions in all spaces", func() {
@@ -447,6 +670,24 @@ var _ = Describe("ServiceInstanceRepository", func() {
 					))
 				})
 			})
+
+			When("the plan guid filter is set", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(testCtx, k8sClient, cfServiceInstance2, func() {
+						cfServiceInstance2.Spec.PlanGUID = "plan-guid"
+					})).To(Succeed())
+
+					filters = repositories.ListServiceInstanceMessage{
+						PlanGUIDs: []string{"plan-guid"},
+					}
+				})
+
+				It("returns only records for the ServiceInstances provisioned from the matching plans", func() {
+					Expect(serviceInstanceList).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfServiceInstance2.Name)}),
+					))
+				})
+			})
 		})
 	})
 
@@ -497,6 +738,34 @@ var _ = Describe("ServiceInstanceRepository", func() {
 			})
 		})
 
+		When("the service instance is shared with a space of the user", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space2.Name)
+				Expect(k8s.PatchResource(testCtx, k8sClient, serviceInstance, func() {
+					serviceInstance.Spec.SharedSpaces = []string{space2.Name}
+				})).To(Succeed())
+			})
+
+			It("returns the service instance", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(Equal(serviceInstance.Name))
+				Expect(record.SpaceGUID).To(Equal(space.Name))
+				Expect(record.SharedSpaceGUIDs).To(ConsistOf(space2.Name))
+			})
+		})
+
+		When("the service instance is shared with a space the user has no role in", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(testCtx, k8sClient, serviceInstance, func() {
+					serviceInstance.Spec.SharedSpaces = []string{space2.Name}
+				})).To(Succeed())
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(errors.As(getErr, &apierrors.ForbiddenError{})).To(BeTrue())
+			})
+		})
+
 		When("the service instance does not exist", func() {
 			BeforeEach(func() {
 				getGUID = "does-not-exist"
@@ -533,6 +802,473 @@ var _ = Describe("ServiceInstanceRepository", func() {
 		})
 	})
 
+	Describe("GetServiceInstanceCredentials", func() {
+		var (
+			cfServiceInstance *korifiv1alpha1.CFServiceInstance
+			credentials       map[string]string
+			getErr            error
+		)
+
+		BeforeEach(func() {
+			serviceInstanceGUID := generateGUID()
+			cfServiceInstance = createServiceInstanceCR(testCtx, k8sClient, serviceInstanceGUID, space.Name, serviceInstanceName, serviceInstanceGUID)
+			Expect(k8sClient.Create(testCtx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      serviceInstanceGUID,
+					Namespace: space.Name,
+				},
+				StringData: map[string]string{
+					"username": "bob",
+					"type":     "user-provided",
+				},
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			credentials, getErr = serviceInstanceRepo.GetServiceInstanceCredentials(testCtx, authInfo, cfServiceInstance.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(errors.As(getErr, &apierrors.ForbiddenError{})).To(BeTrue())
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the credentials", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(credentials).To(Equal(map[string]string{
+					"username": "bob",
+					"type":     "user-provided",
+				}))
+			})
+
+			When("the service instance is managed", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(testCtx, k8sClient, cfServiceInstance, func() {
+						cfServiceInstance.Spec.Type = korifiv1alpha1.ManagedType
+					})).To(Succeed())
+				})
+
+				It("returns a not found error", func() {
+					Expect(errors.As(getErr, &apierrors.NotFoundError{})).To(BeTrue())
+				})
+			})
+		})
+
+		When("the user is a space auditor", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceAuditorRole.Name, space.Name)
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(errors.As(getErr, &apierrors.ForbiddenError{})).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("GetServiceInstanceParameters", func() {
+		var (
+			cfServiceInstance *korifiv1alpha1.CFServiceInstance
+			parameters        map[string]any
+			getErr            error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+
+			cfServiceInstance = &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      generateGUID(),
+					Namespace: space.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					DisplayName: serviceInstanceName,
+					Type:        korifiv1alpha1.ManagedType,
+					PlanGUID:    "plan-guid",
+					Parameters:  &runtime.RawExtension{Raw: []byte(`{"size":"xs"}`)},
+				},
+			}
+			Expect(k8sClient.Create(testCtx, cfServiceInstance)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			parameters, getErr = serviceInstanceRepo.GetServiceInstanceParameters(testCtx, authInfo, cfServiceInstance.Name)
+		})
+
+		It("returns the parameters", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(parameters).To(Equal(map[string]any{"size": "xs"}))
+		})
+
+		When("the service instance has no parameters", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(testCtx, k8sClient, cfServiceInstance, func() {
+					cfServiceInstance.Spec.Parameters = nil
+				})).To(Succeed())
+			})
+
+			It("returns empty parameters", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(parameters).To(BeEmpty())
+			})
+		})
+
+		When("the service instance is user-provided", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(testCtx, k8sClient, cfServiceInstance, func() {
+					cfServiceInstance.Spec.Type = korifiv1alpha1.UserProvidedType
+				})).To(Succeed())
+			})
+
+			It("returns a not found error", func() {
+				Expect(errors.As(getErr, &apierrors.NotFoundError{})).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("GetState", func() {
+		var (
+			cfServiceInstance *korifiv1alpha1.CFServiceInstance
+			state             repositories.ResourceState
+			stateErr          error
+		)
+
+		BeforeEach(func() {
+			cfServiceInstance = createServiceInstanceCR(testCtx, k8sClient, prefixedGUID("service-instance"), space.Name, "the-service-instance", prefixedGUID("secret"))
+		})
+
+		JustBeforeEach(func() {
+			state, stateErr = serviceInstanceRepo.GetState(testCtx, authInfo, cfServiceInstance.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(errors.As(stateErr, &apierrors.ForbiddenError{})).To(BeTrue())
+		})
+
+		When("the user is authorized", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns processing state", func() {
+				Expect(stateErr).NotTo(HaveOccurred())
+				Expect(state).To(Equal(repositories.ResourceState{Status: repositories.ResourceStatusProcessing}))
+			})
+
+			When("the instance has been provisioned", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfServiceInstance, func() {
+						meta.SetStatusCondition(&cfServiceInstance.Status.Conditions, metav1.Condition{
+							Type:               korifiv1alpha1.ReadyConditionType,
+							Status:             metav1.ConditionTrue,
+							Reason:             "ProvisioningSucceeded",
+							ObservedGeneration: cfServiceInstance.Generation,
+						})
+					})).To(Succeed())
+				})
+
+				It("returns ready state", func() {
+					Expect(stateErr).NotTo(HaveOccurred())
+					Expect(state).To(Equal(repositories.ResourceState{Status: repositories.ResourceStatusReady}))
+				})
+			})
+
+			When("provisioning has failed", func() {
+				BeforeEach(func() {
+					Expect(k8s.Patch(ctx, k8sClient, cfServiceInstance, func() {
+						meta.SetStatusCondition(&cfServiceInstance.Status.Conditions, metav1.Condition{
+							Type:               korifiv1alpha1.ReadyConditionType,
+							Status:             metav1.ConditionFalse,
+							Reason:             "ProvisioningFailed",
+							Message:            "out of capacity",
+							ObservedGeneration: cfServiceInstance.Generation,
+						})
+					})).To(Succeed())
+				})
+
+				It("returns failed state", func() {
+					Expect(stateErr).NotTo(HaveOccurred())
+					Expect(state).To(Equal(repositories.ResourceState{
+						Status:  repositories.ResourceStatusFailed,
+						Details: "out of capacity",
+					}))
+				})
+			})
+		})
+	})
+
+	Describe("GetDeletionState", func() {
+		var (
+			cfServiceInstance *korifiv1alpha1.CFServiceInstance
+			state             repositories.ResourceState
+			stateErr          error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			cfServiceInstance = createServiceInstanceCR(testCtx, k8sClient, prefixedGUID("service-instance"), space.Name, "the-service-instance", prefixedGUID("secret"))
+		})
+
+		JustBeforeEach(func() {
+			state, stateErr = serviceInstanceRepo.GetDeletionState(testCtx, authInfo, cfServiceInstance.Name)
+		})
+
+		It("returns processing state", func() {
+			Expect(stateErr).NotTo(HaveOccurred())
+			Expect(state).To(Equal(repositories.ResourceState{Status: repositories.ResourceStatusProcessing}))
+		})
+
+		When("the broker is still deprovisioning the instance", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, k8sClient, cfServiceInstance, func() {
+					cfServiceInstance.Status.LastOperation = &korifiv1alpha1.LastOperation{
+						Type:  korifiv1alpha1.DeleteLastOperationType,
+						State: korifiv1alpha1.InProgressLastOperationState,
+					}
+				})).To(Succeed())
+			})
+
+			It("returns processing state", func() {
+				Expect(stateErr).NotTo(HaveOccurred())
+				Expect(state).To(Equal(repositories.ResourceState{Status: repositories.ResourceStatusProcessing}))
+			})
+		})
+
+		When("the broker failed to deprovision the instance", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, k8sClient, cfServiceInstance, func() {
+					cfServiceInstance.Status.LastOperation = &korifiv1alpha1.LastOperation{
+						Type:        korifiv1alpha1.DeleteLastOperationType,
+						State:       korifiv1alpha1.FailedLastOperationState,
+						Description: "instance is still in use",
+					}
+				})).To(Succeed())
+			})
+
+			It("returns failed state with the broker description", func() {
+				Expect(stateErr).NotTo(HaveOccurred())
+				Expect(state).To(Equal(repositories.ResourceState{
+					Status:  repositories.ResourceStatusFailed,
+					Details: "instance is still in use",
+				}))
+			})
+		})
+
+		When("the last operation failed but was not a deletion", func() {
+			BeforeEach(func() {
+				Expect(k8s.Patch(ctx, k8sClient, cfServiceInstance, func() {
+					cfServiceInstance.Status.LastOperation = &korifiv1alpha1.LastOperation{
+						Type:  korifiv1alpha1.UpdateLastOperationType,
+						State: korifiv1alpha1.FailedLastOperationState,
+					}
+				})).To(Succeed())
+			})
+
+			It("returns processing state", func() {
+				Expect(stateErr).NotTo(HaveOccurred())
+				Expect(state).To(Equal(repositories.ResourceState{Status: repositories.ResourceStatusProcessing}))
+			})
+		})
+
+		When("the instance has been deleted", func() {
+			BeforeEach(func() {
+				Expect(k8sClient.Delete(ctx, cfServiceInstance)).To(Succeed())
+			})
+
+			It("returns ready state", func() {
+				Expect(stateErr).NotTo(HaveOccurred())
+				Expect(state).To(Equal(repositories.ResourceState{Status: repositories.ResourceStatusReady}))
+			})
+		})
+	})
+
+	Describe("GetDeletedAt", func() {
+		var (
+			cfServiceInstance *korifiv1alpha1.CFServiceInstance
+			deletedAt         *time.Time
+			getErr            error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			cfServiceInstance = createServiceInstanceCR(testCtx, k8sClient, prefixedGUID("service-instance"), space.Name, "the-service-instance", prefixedGUID("secret"))
+		})
+
+		JustBeforeEach(func() {
+			deletedAt, getErr = serviceInstanceRepo.GetDeletedAt(testCtx, authInfo, cfServiceInstance.Name)
+		})
+
+		It("returns nil", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(deletedAt).To(BeNil())
+		})
+
+		When("the instance is being deleted", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, k8sClient, cfServiceInstance, func() {
+					cfServiceInstance.Finalizers = append(cfServiceInstance.Finalizers, "kubernetes")
+				})).To(Succeed())
+
+				Expect(k8sClient.Delete(ctx, cfServiceInstance)).To(Succeed())
+			})
+
+			It("returns the deletion time", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(deletedAt).To(PointTo(BeTemporally("~", time.Now(), time.Minute)))
+			})
+		})
+
+		When("the instance does not exist", func() {
+			BeforeEach(func() {
+				Expect(k8sClient.Delete(ctx, cfServiceInstance)).To(Succeed())
+			})
+
+			It("returns a not found error", func() {
+				Expect(errors.As(getErr, &apierrors.NotFoundError{})).To(BeTrue())
+			})
+		})
+	})
+
+	Describe("ShareServiceInstance", func() {
+		var (
+			targetSpace     *korifiv1alpha1.CFSpace
+			serviceInstance *korifiv1alpha1.CFServiceInstance
+			shareMessage    repositories.ShareServiceInstanceMessage
+			record          repositories.ServiceInstanceRecord
+			shareErr        error
+		)
+
+		BeforeEach(func() {
+			targetSpace = createSpaceWithCleanup(testCtx, org.Name, prefixedGUID("target-space"))
+			serviceInstance = createServiceInstanceCR(testCtx, k8sClient, prefixedGUID("service-instance"), space.Name, "the-service-instance", prefixedGUID("secret"))
+
+			shareMessage = repositories.ShareServiceInstanceMessage{
+				GUID:             serviceInstance.Name,
+				SpaceGUID:        space.Name,
+				TargetSpaceGUIDs: []string{targetSpace.Name},
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, shareErr = serviceInstanceRepo.ShareServiceInstance(testCtx, authInfo, shareMessage)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(errors.As(shareErr, &apierrors.ForbiddenError{})).To(BeTrue())
+		})
+
+		When("the user is a space developer in the space of the instance", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(shareErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				Expect(shareErr.(apierrors.UnprocessableEntityError).Detail()).To(Equal(
+					fmt.Sprintf("Unable to share service instance the-service-instance with spaces ['%s']. Ensure the spaces exist and that you have access to them.", targetSpace.Name),
+				))
+			})
+
+			When("the user is a space developer in the target space", func() {
+				BeforeEach(func() {
+					createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, targetSpace.Name)
+				})
+
+				It("shares the service instance with the target space", func() {
+					Expect(shareErr).NotTo(HaveOccurred())
+					Expect(record.SharedSpaceGUIDs).To(ConsistOf(targetSpace.Name))
+
+					Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(serviceInstance), serviceInstance)).To(Succeed())
+					Expect(serviceInstance.Spec.SharedSpaces).To(ConsistOf(targetSpace.Name))
+					Expect(serviceInstance.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFServiceInstanceSharedWithLabelPrefix+targetSpace.Name, "true"))
+				})
+
+				When("the instance is already shared with the target space", func() {
+					BeforeEach(func() {
+						Expect(k8s.PatchResource(testCtx, k8sClient, serviceInstance, func() {
+							serviceInstance.Spec.SharedSpaces = []string{targetSpace.Name}
+						})).To(Succeed())
+					})
+
+					It("does not share it twice", func() {
+						Expect(shareErr).NotTo(HaveOccurred())
+						Expect(record.SharedSpaceGUIDs).To(ConsistOf(targetSpace.Name))
+					})
+				})
+			})
+
+			When("the user is a space manager in the target space", func() {
+				BeforeEach(func() {
+					createRoleBinding(testCtx, userName, spaceManagerRole.Name, targetSpace.Name)
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(shareErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+
+			When("the target space does not exist", func() {
+				BeforeEach(func() {
+					shareMessage.TargetSpaceGUIDs = []string{"does-not-exist"}
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(shareErr).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("UnshareServiceInstance", func() {
+		var (
+			targetSpace     *korifiv1alpha1.CFSpace
+			serviceInstance *korifiv1alpha1.CFServiceInstance
+			unshareErr      error
+		)
+
+		BeforeEach(func() {
+			targetSpace = createSpaceWithCleanup(testCtx, org.Name, prefixedGUID("target-space"))
+			serviceInstance = createServiceInstanceCR(testCtx, k8sClient, prefixedGUID("service-instance"), space.Name, "the-service-instance", prefixedGUID("secret"))
+			Expect(k8s.PatchResource(testCtx, k8sClient, serviceInstance, func() {
+				serviceInstance.Labels = map[string]string{
+					korifiv1alpha1.CFServiceInstanceSharedWithLabelPrefix + targetSpace.Name: "true",
+					korifiv1alpha1.CFServiceInstanceSharedWithLabelPrefix + "another-space":  "true",
+				}
+				serviceInstance.Spec.SharedSpaces = []string{targetSpace.Name, "another-space"}
+			})).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			unshareErr = serviceInstanceRepo.UnshareServiceInstance(testCtx, authInfo, repositories.UnshareServiceInstanceMessage{
+				GUID:            serviceInstance.Name,
+				SpaceGUID:       space.Name,
+				TargetSpaceGUID: targetSpace.Name,
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(errors.As(unshareErr, &apierrors.ForbiddenError{})).To(BeTrue())
+		})
+
+		When("the user is a space developer in the space of the instance", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("removes the target space from the shared spaces", func() {
+				Expect(unshareErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(serviceInstance), serviceInstance)).To(Succeed())
+				Expect(serviceInstance.Spec.SharedSpaces).To(ConsistOf("another-space"))
+				Expect(serviceInstance.Labels).NotTo(HaveKey(korifiv1alpha1.CFServiceInstanceSharedWithLabelPrefix + targetSpace.Name))
+				Expect(serviceInstance.Labels).To(HaveKey(korifiv1alpha1.CFServiceInstanceSharedWithLabelPrefix + "another-space"))
+			})
+		})
+	})
+
 	Describe("DeleteServiceInstance", func() {
 		var (
 			serviceInstance *korifiv1alpha1.CFServiceInstance
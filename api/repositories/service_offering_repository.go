@@ -0,0 +1,192 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ServiceOfferingResourceType = "Service Offering"
+)
+
+type ServiceOfferingRepo struct {
+	userClientFactory    authorization.UserK8sClientFactory
+	namespacePermissions *authorization.NamespacePermissions
+	namespaceRetriever   NamespaceRetriever
+	rootNamespace        string
+}
+
+func NewServiceOfferingRepo(
+	userClientFactory authorization.UserK8sClientFactory,
+	namespacePermissions *authorization.NamespacePermissions,
+	namespaceRetriever NamespaceRetriever,
+	rootNamespace string,
+) *ServiceOfferingRepo {
+	return &ServiceOfferingRepo{
+		userClientFactory:    userClientFactory,
+		namespacePermissions: namespacePermissions,
+		namespaceRetriever:   namespaceRetriever,
+		rootNamespace:        rootNamespace,
+	}
+}
+
+type ServiceOfferingRecord struct {
+	Name              string
+	GUID              string
+	Description       string
+	Tags              []string
+	Requires          []string
+	Shareable         bool
+	DocumentationURL  *string
+	ServiceBrokerGUID string
+	BrokerCatalog     ServiceOfferingBrokerCatalog
+	Labels            map[string]string
+	Annotations       map[string]string
+	CreatedAt         time.Time
+	UpdatedAt         *time.Time
+}
+
+type ServiceOfferingBrokerCatalog struct {
+	ID                   string
+	Metadata             map[string]any
+	PlanUpdateable       bool
+	Bindable             bool
+	InstancesRetrievable bool
+	BindingsRetrievable  bool
+	AllowContextUpdates  bool
+}
+
+type ListServiceOfferingMessage struct {
+	GUIDs              []string
+	Names              []string
+	ServiceBrokerGUIDs []string
+	OrganizationGUIDs  []string
+	SpaceGUIDs         []string
+}
+
+func (r *ServiceOfferingRepo) GetServiceOffering(ctx context.Context, authInfo authorization.Info, guid string) (ServiceOfferingRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServiceOfferingRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfServiceOffering := new(korifiv1alpha1.CFServiceOffering)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, cfServiceOffering)
+	if err != nil {
+		return ServiceOfferingRecord{}, fmt.Errorf("failed to get service offering: %w", apierrors.FromK8sError(err, ServiceOfferingResourceType))
+	}
+
+	plans, err := listVisibleServicePlans(ctx, userClient, r.namespacePermissions, authInfo, r.rootNamespace)
+	if err != nil {
+		return ServiceOfferingRecord{}, err
+	}
+
+	if !offeringsWithPlans(plans).Includes(guid) {
+		return ServiceOfferingRecord{}, apierrors.NewNotFoundError(fmt.Errorf("service offering %s has no visible plans", guid), ServiceOfferingResourceType)
+	}
+
+	return cfServiceOfferingToRecord(*cfServiceOffering)
+}
+
+func (r *ServiceOfferingRepo) ListServiceOfferings(ctx context.Context, authInfo authorization.Info, message ListServiceOfferingMessage) ([]ServiceOfferingRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []ServiceOfferingRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	availableInOrgs, err := availableInOrgsPredicate(ctx, r.namespaceRetriever, message.OrganizationGUIDs, message.SpaceGUIDs)
+	if err != nil {
+		return []ServiceOfferingRecord{}, err
+	}
+
+	plans, err := listVisibleServicePlans(ctx, userClient, r.namespacePermissions, authInfo, r.rootNamespace)
+	if err != nil {
+		return []ServiceOfferingRecord{}, err
+	}
+	visibleOfferings := offeringsWithPlans(Filter(plans, availableInOrgs))
+
+	offeringList := new(korifiv1alpha1.CFServiceOfferingList)
+	err = userClient.List(ctx, offeringList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		if k8serrors.IsForbidden(err) {
+			return []ServiceOfferingRecord{}, nil
+		}
+		return []ServiceOfferingRecord{}, fmt.Errorf("failed to list service offerings in namespace %s: %w", r.rootNamespace, apierrors.FromK8sError(err, ServiceOfferingResourceType))
+	}
+
+	filtered := Filter(offeringList.Items,
+		func(o korifiv1alpha1.CFServiceOffering) bool { return visibleOfferings.Includes(o.Name) },
+		SetPredicate(message.GUIDs, func(o korifiv1alpha1.CFServiceOffering) string { return o.Name }),
+		SetPredicate(message.Names, func(o korifiv1alpha1.CFServiceOffering) string { return o.Spec.Name }),
+		SetPredicate(message.ServiceBrokerGUIDs, func(o korifiv1alpha1.CFServiceOffering) string {
+			return o.Labels[korifiv1alpha1.CFServiceBrokerGUIDLabelKey]
+		}),
+	)
+
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].CreationTimestamp.Before(&filtered[j].CreationTimestamp)
+	})
+
+	records := make([]ServiceOfferingRecord, 0, len(filtered))
+	for _, offering := range filtered {
+		record, err := cfServiceOfferingToRecord(offering)
+		if err != nil {
+			return []ServiceOfferingRecord{}, err
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// offeringsWithPlans returns the GUIDs of the offerings the given plans belong to
+func offeringsWithPlans(plans []korifiv1alpha1.CFServicePlan) Set[string] {
+	offeringGUIDs := NewSet[string]()
+	for _, plan := range plans {
+		offeringGUIDs[plan.Labels[korifiv1alpha1.CFServiceOfferingGUIDLabelKey]] = struct{}{}
+	}
+
+	return offeringGUIDs
+}
+
+func cfServiceOfferingToRecord(cfServiceOffering korifiv1alpha1.CFServiceOffering) (ServiceOfferingRecord, error) {
+	metadata, err := fromRawExtension(cfServiceOffering.Spec.BrokerCatalog.Metadata)
+	if err != nil {
+		return ServiceOfferingRecord{}, err
+	}
+
+	shareable, _ := metadata["shareable"].(bool)
+
+	return ServiceOfferingRecord{
+		Name:              cfServiceOffering.Spec.Name,
+		GUID:              cfServiceOffering.Name,
+		Description:       cfServiceOffering.Spec.Description,
+		Tags:              cfServiceOffering.Spec.Tags,
+		Requires:          cfServiceOffering.Spec.Requires,
+		Shareable:         shareable,
+		DocumentationURL:  cfServiceOffering.Spec.DocumentationURL,
+		ServiceBrokerGUID: cfServiceOffering.Labels[korifiv1alpha1.CFServiceBrokerGUIDLabelKey],
+		BrokerCatalog: ServiceOfferingBrokerCatalog{
+			ID:                   cfServiceOffering.Spec.BrokerCatalog.ID,
+			Metadata:             metadata,
+			PlanUpdateable:       cfServiceOffering.Spec.PlanUpdateable,
+			Bindable:             cfServiceOffering.Spec.Bindable,
+			InstancesRetrievable: cfServiceOffering.Spec.InstancesRetrievable,
+			BindingsRetrievable:  cfServiceOffering.Spec.BindingsRetrievable,
+			AllowContextUpdates:  cfServiceOffering.Spec.AllowContextUpdates,
+		},
+		Labels:      cfServiceOffering.Labels,
+		Annotations: cfServiceOffering.Annotations,
+		CreatedAt:   cfServiceOffering.CreationTimestamp.Time,
+		UpdatedAt:   getLastUpdatedTime(&cfServiceOffering),
+	}, nil
+}
@@ -0,0 +1,175 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("ServiceOfferingRepo", func() {
+	var (
+		repo                 *ServiceOfferingRepo
+		cfOrg                *korifiv1alpha1.CFOrg
+		publicOffering       *korifiv1alpha1.CFServiceOffering
+		orgOffering          *korifiv1alpha1.CFServiceOffering
+		adminOffering        *korifiv1alpha1.CFServiceOffering
+		offeringWithoutPlans *korifiv1alpha1.CFServiceOffering
+	)
+
+	BeforeEach(func() {
+		repo = NewServiceOfferingRepo(userClientFactory, nsPerms, namespaceRetriever, rootNamespace)
+
+		cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+
+		publicOffering = createServiceOffering("public-offering")
+		createServicePlan(publicOffering, "public-plan", korifiv1alpha1.ServicePlanVisibility{
+			Type: korifiv1alpha1.PublicServicePlanVisibilityType,
+		})
+		createServicePlan(publicOffering, "admin-plan", korifiv1alpha1.ServicePlanVisibility{
+			Type: korifiv1alpha1.AdminServicePlanVisibilityType,
+		})
+
+		orgOffering = createServiceOffering("org-offering")
+		createServicePlan(orgOffering, "org-plan", korifiv1alpha1.ServicePlanVisibility{
+			Type:          korifiv1alpha1.OrganizationServicePlanVisibilityType,
+			Organizations: []string{cfOrg.Name},
+		})
+
+		adminOffering = createServiceOffering("admin-offering")
+		createServicePlan(adminOffering, "admin-plan", korifiv1alpha1.ServicePlanVisibility{
+			Type: korifiv1alpha1.AdminServicePlanVisibilityType,
+		})
+
+		offeringWithoutPlans = createServiceOffering("offering-without-plans")
+	})
+
+	Describe("GetServiceOffering", func() {
+		var (
+			offeringGUID string
+			record       ServiceOfferingRecord
+			getErr       error
+		)
+
+		BeforeEach(func() {
+			offeringGUID = publicOffering.Name
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = repo.GetServiceOffering(ctx, authInfo, offeringGUID)
+		})
+
+		It("returns the offering", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(record.GUID).To(Equal(publicOffering.Name))
+			Expect(record.Name).To(Equal("public-offering"))
+			Expect(record.Tags).To(ConsistOf("sql"))
+			Expect(record.Shareable).To(BeTrue())
+			Expect(record.DocumentationURL).To(PointTo(Equal("https://docs.my.broker")))
+			Expect(record.ServiceBrokerGUID).To(Equal("broker-guid"))
+			Expect(record.BrokerCatalog.ID).To(Equal("public-offering-id"))
+			Expect(record.BrokerCatalog.Bindable).To(BeTrue())
+		})
+
+		When("none of the offering plans is visible to the user", func() {
+			BeforeEach(func() {
+				offeringGUID = adminOffering.Name
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("ListServiceOfferings", func() {
+		var (
+			message ListServiceOfferingMessage
+			records []ServiceOfferingRecord
+			listErr error
+		)
+
+		BeforeEach(func() {
+			message = ListServiceOfferingMessage{}
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = repo.ListServiceOfferings(ctx, authInfo, message)
+		})
+
+		It("lists the offerings with public plans", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(records).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(publicOffering.Name)})))
+		})
+
+		When("the user is a member of an org a plan is visible in", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+			})
+
+			It("lists the offering of that plan too", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(publicOffering.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(orgOffering.Name)}),
+				))
+			})
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("lists all offerings that have plans", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(publicOffering.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(orgOffering.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(adminOffering.Name)}),
+				))
+				Expect(records).NotTo(ContainElement(MatchFields(IgnoreExtras, Fields{"GUID": Equal(offeringWithoutPlans.Name)})))
+			})
+
+			When("filtering by org", func() {
+				BeforeEach(func() {
+					message.OrganizationGUIDs = []string{cfOrg.Name}
+				})
+
+				It("only lists the offerings available in the org", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(publicOffering.Name)}),
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(orgOffering.Name)}),
+					))
+				})
+			})
+
+			When("filtering by name", func() {
+				BeforeEach(func() {
+					message.Names = []string{"admin-offering"}
+				})
+
+				It("only lists the matching offerings", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(adminOffering.Name)})))
+				})
+			})
+
+			When("filtering by broker", func() {
+				BeforeEach(func() {
+					message.ServiceBrokerGUIDs = []string{"another-broker"}
+				})
+
+				It("returns an empty list", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(BeEmpty())
+				})
+			})
+		})
+	})
+})
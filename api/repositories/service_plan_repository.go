@@ -0,0 +1,550 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ServicePlanResourceType           = "Service Plan"
+	ServicePlanVisibilityResourceType = "Service Plan Visibility"
+)
+
+type ServicePlanRepo struct {
+	userClientFactory    authorization.UserK8sClientFactory
+	namespacePermissions *authorization.NamespacePermissions
+	namespaceRetriever   NamespaceRetriever
+	rootNamespace        string
+}
+
+func NewServicePlanRepo(
+	userClientFactory authorization.UserK8sClientFactory,
+	namespacePermissions *authorization.NamespacePermissions,
+	namespaceRetriever NamespaceRetriever,
+	rootNamespace string,
+) *ServicePlanRepo {
+	return &ServicePlanRepo{
+		userClientFactory:    userClientFactory,
+		namespacePermissions: namespacePermissions,
+		namespaceRetriever:   namespaceRetriever,
+		rootNamespace:        rootNamespace,
+	}
+}
+
+type ServicePlanRecord struct {
+	Name                string
+	GUID                string
+	Description         string
+	Free                bool
+	VisibilityType      string
+	ServiceOfferingGUID string
+	ServiceBrokerGUID   string
+	BrokerCatalog       ServicePlanBrokerCatalog
+	Schemas             ServicePlanSchemas
+	Labels              map[string]string
+	Annotations         map[string]string
+	CreatedAt           time.Time
+	UpdatedAt           *time.Time
+}
+
+type ServicePlanBrokerCatalog struct {
+	ID             string
+	Metadata       map[string]any
+	Bindable       bool
+	PlanUpdateable bool
+}
+
+type ServicePlanSchemas struct {
+	ServiceInstanceCreate map[string]any
+	ServiceInstanceUpdate map[string]any
+	ServiceBindingCreate  map[string]any
+}
+
+type ServicePlanVisibilityRecord struct {
+	Type          string
+	Organizations []VisibilityOrganization
+}
+
+type VisibilityOrganization struct {
+	GUID string
+	Name string
+}
+
+type ListServicePlanMessage struct {
+	Names                []string
+	ServiceOfferingGUIDs []string
+	ServiceBrokerGUIDs   []string
+	OrganizationGUIDs    []string
+	SpaceGUIDs           []string
+}
+
+type UpdateServicePlanVisibilityMessage struct {
+	PlanGUID      string
+	Type          string
+	Organizations []string
+}
+
+type ApplyServicePlanVisibilityMessage struct {
+	PlanGUID      string
+	Type          string
+	Organizations []string
+}
+
+type DeleteServicePlanVisibilityMessage struct {
+	PlanGUID string
+	OrgGUID  string
+}
+
+func (r *ServicePlanRepo) GetServicePlan(ctx context.Context, authInfo authorization.Info, guid string) (ServicePlanRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServicePlanRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfServicePlan, err := r.getVisibleCFServicePlan(ctx, userClient, authInfo, guid)
+	if err != nil {
+		return ServicePlanRecord{}, err
+	}
+
+	cfServiceOffering := new(korifiv1alpha1.CFServiceOffering)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: cfServicePlan.Labels[korifiv1alpha1.CFServiceOfferingGUIDLabelKey]}, cfServiceOffering)
+	if err != nil {
+		return ServicePlanRecord{}, fmt.Errorf("failed to get service offering for plan %s: %w", guid, apierrors.FromK8sError(err, ServiceOfferingResourceType))
+	}
+
+	return cfServicePlanToRecord(*cfServicePlan, *cfServiceOffering)
+}
+
+func (r *ServicePlanRepo) ListServicePlans(ctx context.Context, authInfo authorization.Info, message ListServicePlanMessage) ([]ServicePlanRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []ServicePlanRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	availableInOrgs, err := availableInOrgsPredicate(ctx, r.namespaceRetriever, message.OrganizationGUIDs, message.SpaceGUIDs)
+	if err != nil {
+		return []ServicePlanRecord{}, err
+	}
+
+	plans, err := listVisibleServicePlans(ctx, userClient, r.namespacePermissions, authInfo, r.rootNamespace)
+	if err != nil {
+		return []ServicePlanRecord{}, err
+	}
+
+	offeringList := new(korifiv1alpha1.CFServiceOfferingList)
+	if err = userClient.List(ctx, offeringList, client.InNamespace(r.rootNamespace)); err != nil {
+		return []ServicePlanRecord{}, fmt.Errorf("failed to list service offerings in namespace %s: %w", r.rootNamespace, apierrors.FromK8sError(err, ServiceOfferingResourceType))
+	}
+	offerings := map[string]korifiv1alpha1.CFServiceOffering{}
+	for _, offering := range offeringList.Items {
+		offerings[offering.Name] = offering
+	}
+
+	filtered := Filter(plans,
+		availableInOrgs,
+		SetPredicate(message.Names, func(p korifiv1alpha1.CFServicePlan) string { return p.Spec.Name }),
+		SetPredicate(message.ServiceOfferingGUIDs, func(p korifiv1alpha1.CFServicePlan) string {
+			return p.Labels[korifiv1alpha1.CFServiceOfferingGUIDLabelKey]
+		}),
+		SetPredicate(message.ServiceBrokerGUIDs, func(p korifiv1alpha1.CFServicePlan) string {
+			return p.Labels[korifiv1alpha1.CFServiceBrokerGUIDLabelKey]
+		}),
+	)
+
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].CreationTimestamp.Before(&filtered[j].CreationTimestamp)
+	})
+
+	records := make([]ServicePlanRecord, 0, len(filtered))
+	for _, plan := range filtered {
+		offering, ok := offerings[plan.Labels[korifiv1alpha1.CFServiceOfferingGUIDLabelKey]]
+		if !ok {
+			// the offering is being deleted along with its plans
+			continue
+		}
+
+		record, err := cfServicePlanToRecord(plan, offering)
+		if err != nil {
+			return []ServicePlanRecord{}, err
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func (r *ServicePlanRepo) GetServicePlanVisibility(ctx context.Context, authInfo authorization.Info, planGUID string) (ServicePlanVisibilityRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServicePlanVisibilityRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfServicePlan, err := r.getVisibleCFServicePlan(ctx, userClient, authInfo, planGUID)
+	if err != nil {
+		return ServicePlanVisibilityRecord{}, err
+	}
+
+	return r.toVisibilityRecord(ctx, userClient, authInfo, cfServicePlan)
+}
+
+func (r *ServicePlanRepo) UpdateServicePlanVisibility(ctx context.Context, authInfo authorization.Info, message UpdateServicePlanVisibilityMessage) (ServicePlanVisibilityRecord, error) {
+	return r.patchVisibility(ctx, authInfo, message.PlanGUID, message.Organizations, func(visibility *korifiv1alpha1.ServicePlanVisibility) {
+		visibility.Type = message.Type
+		visibility.Organizations = nil
+		if message.Type == korifiv1alpha1.OrganizationServicePlanVisibilityType {
+			visibility.Organizations = dedup(message.Organizations)
+		}
+	})
+}
+
+func (r *ServicePlanRepo) ApplyServicePlanVisibility(ctx context.Context, authInfo authorization.Info, message ApplyServicePlanVisibilityMessage) (ServicePlanVisibilityRecord, error) {
+	return r.patchVisibility(ctx, authInfo, message.PlanGUID, message.Organizations, func(visibility *korifiv1alpha1.ServicePlanVisibility) {
+		if visibility.Type != korifiv1alpha1.OrganizationServicePlanVisibilityType {
+			visibility.Organizations = nil
+		}
+		visibility.Type = message.Type
+		visibility.Organizations = dedup(append(visibility.Organizations, message.Organizations...))
+	})
+}
+
+func (r *ServicePlanRepo) DeleteServicePlanVisibility(ctx context.Context, authInfo authorization.Info, message DeleteServicePlanVisibilityMessage) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfServicePlan, err := r.getVisibleCFServicePlan(ctx, userClient, authInfo, message.PlanGUID)
+	if err != nil {
+		return err
+	}
+
+	if cfServicePlan.Spec.Visibility.Type != korifiv1alpha1.OrganizationServicePlanVisibilityType ||
+		!NewSet(cfServicePlan.Spec.Visibility.Organizations...).Includes(message.OrgGUID) {
+		return apierrors.NewNotFoundError(fmt.Errorf("plan %s is not visible in org %s", message.PlanGUID, message.OrgGUID), ServicePlanVisibilityResourceType)
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfServicePlan, func() {
+		cfServicePlan.Spec.Visibility.Organizations = Filter(cfServicePlan.Spec.Visibility.Organizations, func(org string) bool {
+			return org != message.OrgGUID
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to patch service plan visibility: %w", apierrors.FromK8sError(err, ServicePlanVisibilityResourceType))
+	}
+
+	return nil
+}
+
+func (r *ServicePlanRepo) patchVisibility(
+	ctx context.Context,
+	authInfo authorization.Info,
+	planGUID string,
+	orgGUIDs []string,
+	patch func(*korifiv1alpha1.ServicePlanVisibility),
+) (ServicePlanVisibilityRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServicePlanVisibilityRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfServicePlan, err := r.getVisibleCFServicePlan(ctx, userClient, authInfo, planGUID)
+	if err != nil {
+		return ServicePlanVisibilityRecord{}, err
+	}
+
+	for _, orgGUID := range orgGUIDs {
+		err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: orgGUID}, new(korifiv1alpha1.CFOrg))
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				return ServicePlanVisibilityRecord{}, apierrors.NewUnprocessableEntityError(err, fmt.Sprintf("Could not find organization with guid %s", orgGUID))
+			}
+			return ServicePlanVisibilityRecord{}, fmt.Errorf("failed to get org %s: %w", orgGUID, apierrors.FromK8sError(err, OrgResourceType))
+		}
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfServicePlan, func() {
+		patch(&cfServicePlan.Spec.Visibility)
+	})
+	if err != nil {
+		return ServicePlanVisibilityRecord{}, fmt.Errorf("failed to patch service plan visibility: %w", apierrors.FromK8sError(err, ServicePlanVisibilityResourceType))
+	}
+
+	return r.toVisibilityRecord(ctx, userClient, authInfo, cfServicePlan)
+}
+
+func (r *ServicePlanRepo) getVisibleCFServicePlan(ctx context.Context, userClient client.Client, authInfo authorization.Info, guid string) (*korifiv1alpha1.CFServicePlan, error) {
+	cfServicePlan := new(korifiv1alpha1.CFServicePlan)
+	err := userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, cfServicePlan)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service plan: %w", apierrors.FromK8sError(err, ServicePlanResourceType))
+	}
+
+	visibility, err := getPlanVisibility(ctx, userClient, r.namespacePermissions, authInfo, r.rootNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	if !visibility.isVisible(*cfServicePlan) {
+		return nil, apierrors.NewNotFoundError(fmt.Errorf("service plan %s is not visible", guid), ServicePlanResourceType)
+	}
+
+	return cfServicePlan, nil
+}
+
+func (r *ServicePlanRepo) toVisibilityRecord(ctx context.Context, userClient client.Client, authInfo authorization.Info, cfServicePlan *korifiv1alpha1.CFServicePlan) (ServicePlanVisibilityRecord, error) {
+	record := ServicePlanVisibilityRecord{
+		Type:          cfServicePlan.Spec.Visibility.Type,
+		Organizations: []VisibilityOrganization{},
+	}
+
+	if record.Type != korifiv1alpha1.OrganizationServicePlanVisibilityType {
+		return record, nil
+	}
+
+	authorizedOrgs, err := r.namespacePermissions.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return ServicePlanVisibilityRecord{}, fmt.Errorf("failed to list namespaces for orgs with user role bindings: %w", err)
+	}
+
+	for _, orgGUID := range cfServicePlan.Spec.Visibility.Organizations {
+		if !authorizedOrgs[orgGUID] {
+			continue
+		}
+
+		cfOrg := new(korifiv1alpha1.CFOrg)
+		err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: orgGUID}, cfOrg)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				continue
+			}
+			return ServicePlanVisibilityRecord{}, fmt.Errorf("failed to get org %s: %w", orgGUID, apierrors.FromK8sError(err, OrgResourceType))
+		}
+
+		record.Organizations = append(record.Organizations, VisibilityOrganization{
+			GUID: cfOrg.Name,
+			Name: cfOrg.Spec.DisplayName,
+		})
+	}
+
+	return record, nil
+}
+
+// planVisibility decides which plans a user is allowed to see: admins see
+// every plan, everybody else only sees public plans and plans that have been
+// made available to one of their orgs
+type planVisibility struct {
+	isAdmin        bool
+	authorizedOrgs map[string]bool
+}
+
+func getPlanVisibility(
+	ctx context.Context,
+	userClient client.Client,
+	namespacePermissions *authorization.NamespacePermissions,
+	authInfo authorization.Info,
+	rootNamespace string,
+) (planVisibility, error) {
+	isAdmin, err := isAdminUser(ctx, userClient, rootNamespace)
+	if err != nil {
+		return planVisibility{}, err
+	}
+	if isAdmin {
+		return planVisibility{isAdmin: true}, nil
+	}
+
+	authorizedOrgs, err := namespacePermissions.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return planVisibility{}, fmt.Errorf("failed to list namespaces for orgs with user role bindings: %w", err)
+	}
+
+	return planVisibility{authorizedOrgs: authorizedOrgs}, nil
+}
+
+// isAdminUser tells whether the user is a CF admin. Managing service brokers
+// is reserved to admins
+func isAdminUser(ctx context.Context, userClient client.Client, rootNamespace string) (bool, error) {
+	err := userClient.List(ctx, new(korifiv1alpha1.CFServiceBrokerList), client.InNamespace(rootNamespace), client.Limit(1))
+	if err == nil {
+		return true, nil
+	}
+	if !k8serrors.IsForbidden(err) {
+		return false, fmt.Errorf("failed to list service brokers: %w", apierrors.FromK8sError(err, ServiceBrokerResourceType))
+	}
+
+	return false, nil
+}
+
+func (v planVisibility) isVisible(plan korifiv1alpha1.CFServicePlan) bool {
+	if v.isAdmin || plan.Spec.Visibility.Type == korifiv1alpha1.PublicServicePlanVisibilityType {
+		return true
+	}
+
+	if plan.Spec.Visibility.Type == korifiv1alpha1.OrganizationServicePlanVisibilityType {
+		for _, orgGUID := range plan.Spec.Visibility.Organizations {
+			if v.authorizedOrgs[orgGUID] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func listVisibleServicePlans(
+	ctx context.Context,
+	userClient client.Client,
+	namespacePermissions *authorization.NamespacePermissions,
+	authInfo authorization.Info,
+	rootNamespace string,
+) ([]korifiv1alpha1.CFServicePlan, error) {
+	planList := new(korifiv1alpha1.CFServicePlanList)
+	err := userClient.List(ctx, planList, client.InNamespace(rootNamespace))
+	if err != nil {
+		if k8serrors.IsForbidden(err) {
+			return []korifiv1alpha1.CFServicePlan{}, nil
+		}
+		return nil, fmt.Errorf("failed to list service plans in namespace %s: %w", rootNamespace, apierrors.FromK8sError(err, ServicePlanResourceType))
+	}
+
+	visibility, err := getPlanVisibility(ctx, userClient, namespacePermissions, authInfo, rootNamespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return Filter(planList.Items, visibility.isVisible), nil
+}
+
+// availableInOrgsPredicate matches the plans that can be used in any of the
+// given orgs, or in the orgs of the given spaces. Admin-only plans are never
+// available in an org
+func availableInOrgsPredicate(
+	ctx context.Context,
+	namespaceRetriever NamespaceRetriever,
+	orgGUIDs []string,
+	spaceGUIDs []string,
+) (func(korifiv1alpha1.CFServicePlan) bool, error) {
+	if len(orgGUIDs) == 0 && len(spaceGUIDs) == 0 {
+		return AlwaysTrue[korifiv1alpha1.CFServicePlan], nil
+	}
+
+	orgs := NewSet(orgGUIDs...)
+	for _, spaceGUID := range spaceGUIDs {
+		orgGUID, err := namespaceRetriever.NamespaceFor(ctx, spaceGUID, SpaceResourceType)
+		if err != nil {
+			var notFoundErr apierrors.NotFoundError
+			if errors.As(err, &notFoundErr) {
+				continue
+			}
+			return nil, err
+		}
+		orgs[orgGUID] = struct{}{}
+	}
+
+	return func(plan korifiv1alpha1.CFServicePlan) bool {
+		switch plan.Spec.Visibility.Type {
+		case korifiv1alpha1.PublicServicePlanVisibilityType:
+			return true
+		case korifiv1alpha1.OrganizationServicePlanVisibilityType:
+			for _, orgGUID := range plan.Spec.Visibility.Organizations {
+				if orgs.Includes(orgGUID) {
+					return true
+				}
+			}
+		}
+		return false
+	}, nil
+}
+
+func cfServicePlanToRecord(cfServicePlan korifiv1alpha1.CFServicePlan, cfServiceOffering korifiv1alpha1.CFServiceOffering) (ServicePlanRecord, error) {
+	metadata, err := fromRawExtension(cfServicePlan.Spec.BrokerCatalog.Metadata)
+	if err != nil {
+		return ServicePlanRecord{}, err
+	}
+	instanceCreateSchema, err := fromRawExtension(cfServicePlan.Spec.Schemas.ServiceInstance.Create.Parameters)
+	if err != nil {
+		return ServicePlanRecord{}, err
+	}
+	instanceUpdateSchema, err := fromRawExtension(cfServicePlan.Spec.Schemas.ServiceInstance.Update.Parameters)
+	if err != nil {
+		return ServicePlanRecord{}, err
+	}
+	bindingCreateSchema, err := fromRawExtension(cfServicePlan.Spec.Schemas.ServiceBinding.Create.Parameters)
+	if err != nil {
+		return ServicePlanRecord{}, err
+	}
+
+	// plan features default to the ones of the offering
+	bindable := cfServiceOffering.Spec.Bindable
+	if cfServicePlan.Spec.Bindable != nil {
+		bindable = *cfServicePlan.Spec.Bindable
+	}
+	planUpdateable := cfServiceOffering.Spec.PlanUpdateable
+	if cfServicePlan.Spec.PlanUpdateable != nil {
+		planUpdateable = *cfServicePlan.Spec.PlanUpdateable
+	}
+
+	return ServicePlanRecord{
+		Name:                cfServicePlan.Spec.Name,
+		GUID:                cfServicePlan.Name,
+		Description:         cfServicePlan.Spec.Description,
+		Free:                cfServicePlan.Spec.Free,
+		VisibilityType:      cfServicePlan.Spec.Visibility.Type,
+		ServiceOfferingGUID: cfServiceOffering.Name,
+		ServiceBrokerGUID:   cfServicePlan.Labels[korifiv1alpha1.CFServiceBrokerGUIDLabelKey],
+		BrokerCatalog: ServicePlanBrokerCatalog{
+			ID:             cfServicePlan.Spec.BrokerCatalog.ID,
+			Metadata:       metadata,
+			Bindable:       bindable,
+			PlanUpdateable: planUpdateable,
+		},
+		Schemas: ServicePlanSchemas{
+			ServiceInstanceCreate: instanceCreateSchema,
+			ServiceInstanceUpdate: instanceUpdateSchema,
+			ServiceBindingCreate:  bindingCreateSchema,
+		},
+		Labels:      cfServicePlan.Labels,
+		Annotations: cfServicePlan.Annotations,
+		CreatedAt:   cfServicePlan.CreationTimestamp.Time,
+		UpdatedAt:   getLastUpdatedTime(&cfServicePlan),
+	}, nil
+}
+
+func fromRawExtension(rawExtension *runtime.RawExtension) (map[string]any, error) {
+	if rawExtension == nil || len(rawExtension.Raw) == 0 {
+		return nil, nil
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(rawExtension.Raw, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal broker catalog data: %w", err)
+	}
+
+	return result, nil
+}
+
+func dedup(elements []string) []string {
+	seen := NewSet[string]()
+	result := []string{}
+	for _, e := range elements {
+		if seen.Includes(e) {
+			continue
+		}
+		seen[e] = struct{}{}
+		result = append(result, e)
+	}
+
+	return result
+}
@@ -0,0 +1,413 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("ServicePlanRepo", func() {
+	var (
+		repo              *ServicePlanRepo
+		cfOrg             *korifiv1alpha1.CFOrg
+		cfSpace           *korifiv1alpha1.CFSpace
+		cfServiceOffering *korifiv1alpha1.CFServiceOffering
+		publicPlan        *korifiv1alpha1.CFServicePlan
+		adminPlan         *korifiv1alpha1.CFServicePlan
+		orgPlan           *korifiv1alpha1.CFServicePlan
+	)
+
+	BeforeEach(func() {
+		repo = NewServicePlanRepo(userClientFactory, nsPerms, namespaceRetriever, rootNamespace)
+
+		cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space"))
+
+		cfServiceOffering = createServiceOffering("my-offering")
+		publicPlan = createServicePlan(cfServiceOffering, "public-plan", korifiv1alpha1.ServicePlanVisibility{
+			Type: korifiv1alpha1.PublicServicePlanVisibilityType,
+		})
+		adminPlan = createServicePlan(cfServiceOffering, "admin-plan", korifiv1alpha1.ServicePlanVisibility{
+			Type: korifiv1alpha1.AdminServicePlanVisibilityType,
+		})
+		orgPlan = createServicePlan(cfServiceOffering, "org-plan", korifiv1alpha1.ServicePlanVisibility{
+			Type:          korifiv1alpha1.OrganizationServicePlanVisibilityType,
+			Organizations: []string{cfOrg.Name},
+		})
+	})
+
+	Describe("GetServicePlan", func() {
+		var (
+			planGUID string
+			record   ServicePlanRecord
+			getErr   error
+		)
+
+		BeforeEach(func() {
+			planGUID = publicPlan.Name
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = repo.GetServicePlan(ctx, authInfo, planGUID)
+		})
+
+		It("returns the plan", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(record.GUID).To(Equal(publicPlan.Name))
+			Expect(record.Name).To(Equal("public-plan"))
+			Expect(record.VisibilityType).To(Equal(korifiv1alpha1.PublicServicePlanVisibilityType))
+			Expect(record.ServiceOfferingGUID).To(Equal(cfServiceOffering.Name))
+			Expect(record.ServiceBrokerGUID).To(Equal("broker-guid"))
+			Expect(record.BrokerCatalog.ID).To(Equal("public-plan-id"))
+			Expect(record.BrokerCatalog.Metadata).To(Equal(map[string]any{"foo": "bar"}))
+			Expect(record.BrokerCatalog.Bindable).To(BeTrue())
+			Expect(record.BrokerCatalog.PlanUpdateable).To(BeFalse())
+		})
+
+		When("the plan is restricted to admins", func() {
+			BeforeEach(func() {
+				planGUID = adminPlan.Name
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+
+			When("the user is a CF admin", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				})
+
+				It("returns the plan", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(record.GUID).To(Equal(adminPlan.Name))
+				})
+			})
+		})
+
+		When("the plan is visible in an org the user is not a member of", func() {
+			BeforeEach(func() {
+				planGUID = orgPlan.Name
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+
+			When("the user is a member of the org", func() {
+				BeforeEach(func() {
+					createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+				})
+
+				It("returns the plan", func() {
+					Expect(getErr).NotTo(HaveOccurred())
+					Expect(record.GUID).To(Equal(orgPlan.Name))
+				})
+			})
+		})
+	})
+
+	Describe("ListServicePlans", func() {
+		var (
+			message ListServicePlanMessage
+			records []ServicePlanRecord
+			listErr error
+		)
+
+		BeforeEach(func() {
+			message = ListServicePlanMessage{}
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = repo.ListServicePlans(ctx, authInfo, message)
+		})
+
+		It("only lists the public plans", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(records).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(publicPlan.Name)})))
+		})
+
+		When("the user is a member of an org the plan is visible in", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+			})
+
+			It("lists the org plans too", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(publicPlan.Name)}),
+					MatchFields(IgnoreExtras, Fields{"GUID": Equal(orgPlan.Name)}),
+				))
+			})
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("lists all plans", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(HaveLen(3))
+			})
+
+			When("filtering by space", func() {
+				BeforeEach(func() {
+					message.SpaceGUIDs = []string{cfSpace.Name}
+				})
+
+				It("only lists the plans available in the space's org", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(publicPlan.Name)}),
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(orgPlan.Name)}),
+					))
+				})
+			})
+
+			When("filtering by name", func() {
+				BeforeEach(func() {
+					message.Names = []string{"admin-plan"}
+				})
+
+				It("only lists the matching plans", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(adminPlan.Name)})))
+				})
+			})
+
+			When("filtering by offering", func() {
+				BeforeEach(func() {
+					message.ServiceOfferingGUIDs = []string{"some-other-offering"}
+				})
+
+				It("returns an empty list", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	Describe("GetServicePlanVisibility", func() {
+		var (
+			record ServicePlanVisibilityRecord
+			getErr error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, orgUserRole.Name, cfOrg.Name)
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = repo.GetServicePlanVisibility(ctx, authInfo, orgPlan.Name)
+		})
+
+		It("returns the orgs the plan is visible in", func() {
+			Expect(getErr).NotTo(HaveOccurred())
+			Expect(record).To(Equal(ServicePlanVisibilityRecord{
+				Type: korifiv1alpha1.OrganizationServicePlanVisibilityType,
+				Organizations: []VisibilityOrganization{{
+					GUID: cfOrg.Name,
+					Name: cfOrg.Spec.DisplayName,
+				}},
+			}))
+		})
+	})
+
+	Describe("UpdateServicePlanVisibility", func() {
+		var (
+			message   UpdateServicePlanVisibilityMessage
+			record    ServicePlanVisibilityRecord
+			updateErr error
+		)
+
+		BeforeEach(func() {
+			message = UpdateServicePlanVisibilityMessage{
+				PlanGUID: publicPlan.Name,
+				Type:     korifiv1alpha1.AdminServicePlanVisibilityType,
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, updateErr = repo.UpdateServicePlanVisibility(ctx, authInfo, message)
+		})
+
+		It("fails because the user is not a CF admin", func() {
+			Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+			})
+
+			It("updates the visibility", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(record.Type).To(Equal(korifiv1alpha1.AdminServicePlanVisibilityType))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(publicPlan), publicPlan)).To(Succeed())
+				Expect(publicPlan.Spec.Visibility).To(Equal(korifiv1alpha1.ServicePlanVisibility{
+					Type: korifiv1alpha1.AdminServicePlanVisibilityType,
+				}))
+			})
+
+			When("the plan is made available to orgs", func() {
+				BeforeEach(func() {
+					message.Type = korifiv1alpha1.OrganizationServicePlanVisibilityType
+					message.Organizations = []string{cfOrg.Name, cfOrg.Name}
+				})
+
+				It("replaces the orgs", func() {
+					Expect(updateErr).NotTo(HaveOccurred())
+					Expect(record.Organizations).To(ConsistOf(VisibilityOrganization{
+						GUID: cfOrg.Name,
+						Name: cfOrg.Spec.DisplayName,
+					}))
+
+					Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(publicPlan), publicPlan)).To(Succeed())
+					Expect(publicPlan.Spec.Visibility.Organizations).To(Equal([]string{cfOrg.Name}))
+				})
+			})
+
+			When("the org does not exist", func() {
+				BeforeEach(func() {
+					message.Type = korifiv1alpha1.OrganizationServicePlanVisibilityType
+					message.Organizations = []string{"not-an-org"}
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("ApplyServicePlanVisibility", func() {
+		var (
+			anotherOrg *korifiv1alpha1.CFOrg
+			record     ServicePlanVisibilityRecord
+			applyErr   error
+		)
+
+		BeforeEach(func() {
+			anotherOrg = createOrgWithCleanup(ctx, prefixedGUID("another-org"))
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+			createRoleBinding(ctx, userName, adminRole.Name, anotherOrg.Name)
+		})
+
+		JustBeforeEach(func() {
+			record, applyErr = repo.ApplyServicePlanVisibility(ctx, authInfo, ApplyServicePlanVisibilityMessage{
+				PlanGUID:      orgPlan.Name,
+				Type:          korifiv1alpha1.OrganizationServicePlanVisibilityType,
+				Organizations: []string{anotherOrg.Name},
+			})
+		})
+
+		It("appends the orgs", func() {
+			Expect(applyErr).NotTo(HaveOccurred())
+			Expect(record.Organizations).To(ConsistOf(
+				VisibilityOrganization{GUID: cfOrg.Name, Name: cfOrg.Spec.DisplayName},
+				VisibilityOrganization{GUID: anotherOrg.Name, Name: anotherOrg.Spec.DisplayName},
+			))
+		})
+	})
+
+	Describe("DeleteServicePlanVisibility", func() {
+		var (
+			orgGUID   string
+			deleteErr error
+		)
+
+		BeforeEach(func() {
+			orgGUID = cfOrg.Name
+			createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = repo.DeleteServicePlanVisibility(ctx, authInfo, DeleteServicePlanVisibilityMessage{
+				PlanGUID: orgPlan.Name,
+				OrgGUID:  orgGUID,
+			})
+		})
+
+		It("removes the org from the plan visibility", func() {
+			Expect(deleteErr).NotTo(HaveOccurred())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(orgPlan), orgPlan)).To(Succeed())
+			Expect(orgPlan.Spec.Visibility.Organizations).To(BeEmpty())
+		})
+
+		When("the plan is not visible in the org", func() {
+			BeforeEach(func() {
+				orgGUID = "another-org"
+			})
+
+			It("returns a not found error", func() {
+				Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+})
+
+func createServiceOffering(name string) *korifiv1alpha1.CFServiceOffering {
+	cfServiceOffering := &korifiv1alpha1.CFServiceOffering{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      generateGUID(),
+			Namespace: rootNamespace,
+			Labels: map[string]string{
+				korifiv1alpha1.CFServiceBrokerGUIDLabelKey: "broker-guid",
+			},
+		},
+		Spec: korifiv1alpha1.CFServiceOfferingSpec{
+			Name:             name,
+			Description:      "the " + name + " offering",
+			Tags:             []string{"sql"},
+			DocumentationURL: tools.PtrTo("https://docs.my.broker"),
+			Bindable:         true,
+			BrokerCatalog: korifiv1alpha1.ServiceBrokerCatalog{
+				ID:       name + "-id",
+				Metadata: &runtime.RawExtension{Raw: []byte(`{"shareable":true}`)},
+			},
+		},
+	}
+	Expect(k8sClient.Create(ctx, cfServiceOffering)).To(Succeed())
+
+	return cfServiceOffering
+}
+
+func createServicePlan(cfServiceOffering *korifiv1alpha1.CFServiceOffering, name string, visibility korifiv1alpha1.ServicePlanVisibility) *korifiv1alpha1.CFServicePlan {
+	cfServicePlan := &korifiv1alpha1.CFServicePlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      generateGUID(),
+			Namespace: rootNamespace,
+			Labels: map[string]string{
+				korifiv1alpha1.CFServiceBrokerGUIDLabelKey:   cfServiceOffering.Labels[korifiv1alpha1.CFServiceBrokerGUIDLabelKey],
+				korifiv1alpha1.CFServiceOfferingGUIDLabelKey: cfServiceOffering.Name,
+			},
+		},
+		Spec: korifiv1alpha1.CFServicePlanSpec{
+			Name:        name,
+			Description: "the " + name + " plan",
+			Free:        true,
+			BrokerCatalog: korifiv1alpha1.ServiceBrokerCatalog{
+				ID:       name + "-id",
+				Metadata: &runtime.RawExtension{Raw: []byte(`{"foo":"bar"}`)},
+			},
+			Visibility: visibility,
+		},
+	}
+	Expect(k8sClient.Create(ctx, cfServicePlan)).To(Succeed())
+
+	return cfServicePlan
+}
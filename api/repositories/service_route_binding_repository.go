@@ -0,0 +1,239 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	ServiceRouteBindingResourceType = "Service Route Binding"
+)
+
+type ServiceRouteBindingRepo struct {
+	userClientFactory       authorization.UserK8sClientFactory
+	namespacePermissions    *authorization.NamespacePermissions
+	namespaceRetriever      NamespaceRetriever
+	bindingConditionAwaiter ConditionAwaiter[*korifiv1alpha1.CFServiceRouteBinding]
+}
+
+func NewServiceRouteBindingRepo(
+	namespaceRetriever NamespaceRetriever,
+	userClientFactory authorization.UserK8sClientFactory,
+	namespacePermissions *authorization.NamespacePermissions,
+	bindingConditionAwaiter ConditionAwaiter[*korifiv1alpha1.CFServiceRouteBinding],
+) *ServiceRouteBindingRepo {
+	return &ServiceRouteBindingRepo{
+		userClientFactory:       userClientFactory,
+		namespacePermissions:    namespacePermissions,
+		namespaceRetriever:      namespaceRetriever,
+		bindingConditionAwaiter: bindingConditionAwaiter,
+	}
+}
+
+type ServiceRouteBindingRecord struct {
+	GUID                string
+	RouteServiceURL     string
+	ServiceInstanceGUID string
+	RouteGUID           string
+	SpaceGUID           string
+	Labels              map[string]string
+	Annotations         map[string]string
+	CreatedAt           time.Time
+	UpdatedAt           *time.Time
+}
+
+type CreateServiceRouteBindingMessage struct {
+	ServiceInstanceGUID string
+	RouteGUID           string
+	SpaceGUID           string
+	Labels              map[string]string
+	Annotations         map[string]string
+}
+
+type ListServiceRouteBindingsMessage struct {
+	RouteGUIDs           []string
+	ServiceInstanceGUIDs []string
+}
+
+type UpdateServiceRouteBindingMessage struct {
+	GUID          string
+	MetadataPatch MetadataPatch
+}
+
+func (m CreateServiceRouteBindingMessage) toCFServiceRouteBinding() *korifiv1alpha1.CFServiceRouteBinding {
+	return &korifiv1alpha1.CFServiceRouteBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        uuid.NewString(),
+			Namespace:   m.SpaceGUID,
+			Labels:      m.Labels,
+			Annotations: m.Annotations,
+		},
+		Spec: korifiv1alpha1.CFServiceRouteBindingSpec{
+			Service: corev1.ObjectReference{
+				Kind:       "CFServiceInstance",
+				APIVersion: korifiv1alpha1.GroupVersion.Identifier(),
+				Name:       m.ServiceInstanceGUID,
+			},
+			RouteRef: corev1.LocalObjectReference{Name: m.RouteGUID},
+		},
+	}
+}
+
+func (r *ServiceRouteBindingRepo) CreateServiceRouteBinding(ctx context.Context, authInfo authorization.Info, message CreateServiceRouteBindingMessage) (ServiceRouteBindingRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServiceRouteBindingRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfServiceRouteBinding := message.toCFServiceRouteBinding()
+	err = userClient.Create(ctx, cfServiceRouteBinding)
+	if err != nil {
+		return ServiceRouteBindingRecord{}, apierrors.FromK8sError(err, ServiceRouteBindingResourceType)
+	}
+
+	cfServiceRouteBinding, err = r.bindingConditionAwaiter.AwaitCondition(ctx, userClient, cfServiceRouteBinding, StatusConditionReady)
+	if err != nil {
+		return ServiceRouteBindingRecord{}, err
+	}
+
+	return cfServiceRouteBindingToRecord(cfServiceRouteBinding), nil
+}
+
+func (r *ServiceRouteBindingRepo) GetServiceRouteBinding(ctx context.Context, authInfo authorization.Info, guid string) (ServiceRouteBindingRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, guid, ServiceRouteBindingResourceType)
+	if err != nil {
+		return ServiceRouteBindingRecord{}, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServiceRouteBindingRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfServiceRouteBinding := new(korifiv1alpha1.CFServiceRouteBinding)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: guid}, cfServiceRouteBinding)
+	if err != nil {
+		return ServiceRouteBindingRecord{}, apierrors.FromK8sError(err, ServiceRouteBindingResourceType)
+	}
+
+	return cfServiceRouteBindingToRecord(cfServiceRouteBinding), nil
+}
+
+// nolint:dupl
+func (r *ServiceRouteBindingRepo) ListServiceRouteBindings(ctx context.Context, authInfo authorization.Info, message ListServiceRouteBindingsMessage) ([]ServiceRouteBindingRecord, error) {
+	nsList, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []ServiceRouteBindingRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	preds := []func(korifiv1alpha1.CFServiceRouteBinding) bool{
+		SetPredicate(message.RouteGUIDs, func(b korifiv1alpha1.CFServiceRouteBinding) string { return b.Spec.RouteRef.Name }),
+		SetPredicate(message.ServiceInstanceGUIDs, func(b korifiv1alpha1.CFServiceRouteBinding) string { return b.Spec.Service.Name }),
+	}
+
+	var filteredRouteBindings []korifiv1alpha1.CFServiceRouteBinding
+	for ns := range nsList {
+		routeBindingList := new(korifiv1alpha1.CFServiceRouteBindingList)
+		err = userClient.List(ctx, routeBindingList, client.InNamespace(ns))
+		if k8serrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return []ServiceRouteBindingRecord{}, fmt.Errorf("failed to list service route bindings in namespace %s: %w",
+				ns,
+				apierrors.FromK8sError(err, ServiceRouteBindingResourceType),
+			)
+		}
+		filteredRouteBindings = append(filteredRouteBindings, Filter(routeBindingList.Items, preds...)...)
+	}
+
+	records := make([]ServiceRouteBindingRecord, 0, len(filteredRouteBindings))
+	for i := range filteredRouteBindings {
+		records = append(records, cfServiceRouteBindingToRecord(&filteredRouteBindings[i]))
+	}
+
+	return records, nil
+}
+
+func (r *ServiceRouteBindingRepo) UpdateServiceRouteBinding(ctx context.Context, authInfo authorization.Info, message UpdateServiceRouteBindingMessage) (ServiceRouteBindingRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, message.GUID, ServiceRouteBindingResourceType)
+	if err != nil {
+		return ServiceRouteBindingRecord{}, err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return ServiceRouteBindingRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfServiceRouteBinding := new(korifiv1alpha1.CFServiceRouteBinding)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: message.GUID}, cfServiceRouteBinding)
+	if err != nil {
+		return ServiceRouteBindingRecord{}, fmt.Errorf("failed to get service route binding: %w", apierrors.FromK8sError(err, ServiceRouteBindingResourceType))
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfServiceRouteBinding, func() {
+		message.MetadataPatch.Apply(cfServiceRouteBinding)
+	})
+	if err != nil {
+		return ServiceRouteBindingRecord{}, fmt.Errorf("failed to patch service route binding metadata: %w", apierrors.FromK8sError(err, ServiceRouteBindingResourceType))
+	}
+
+	return cfServiceRouteBindingToRecord(cfServiceRouteBinding), nil
+}
+
+func (r *ServiceRouteBindingRepo) DeleteServiceRouteBinding(ctx context.Context, authInfo authorization.Info, guid string) error {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, guid, ServiceRouteBindingResourceType)
+	if err != nil {
+		return err
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfServiceRouteBinding := new(korifiv1alpha1.CFServiceRouteBinding)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: guid}, cfServiceRouteBinding)
+	if err != nil {
+		return apierrors.ForbiddenAsNotFound(apierrors.FromK8sError(err, ServiceRouteBindingResourceType))
+	}
+
+	err = userClient.Delete(ctx, cfServiceRouteBinding)
+	if err != nil {
+		return apierrors.FromK8sError(err, ServiceRouteBindingResourceType)
+	}
+
+	return nil
+}
+
+func cfServiceRouteBindingToRecord(binding *korifiv1alpha1.CFServiceRouteBinding) ServiceRouteBindingRecord {
+	return ServiceRouteBindingRecord{
+		GUID:                binding.Name,
+		RouteServiceURL:     binding.Status.RouteServiceURL,
+		ServiceInstanceGUID: binding.Spec.Service.Name,
+		RouteGUID:           binding.Spec.RouteRef.Name,
+		SpaceGUID:           binding.Namespace,
+		Labels:              binding.Labels,
+		Annotations:         binding.Annotations,
+		CreatedAt:           binding.CreationTimestamp.Time,
+		UpdatedAt:           getLastUpdatedTime(binding),
+	}
+}
@@ -0,0 +1,320 @@
+package repositories_test
+
+import (
+	"context"
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/conditions"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("ServiceRouteBindingRepo", func() {
+	var (
+		repo    *repositories.ServiceRouteBindingRepo
+		testCtx context.Context
+		org     *korifiv1alpha1.CFOrg
+		space   *korifiv1alpha1.CFSpace
+	)
+
+	BeforeEach(func() {
+		testCtx = context.Background()
+		bindingConditionAwaiter := conditions.NewConditionAwaiter[*korifiv1alpha1.CFServiceRouteBinding, korifiv1alpha1.CFServiceRouteBindingList](time.Second)
+		repo = repositories.NewServiceRouteBindingRepo(namespaceRetriever, userClientFactory, nsPerms, bindingConditionAwaiter)
+
+		org = createOrgWithCleanup(testCtx, prefixedGUID("org"))
+		space = createSpaceWithCleanup(testCtx, org.Name, prefixedGUID("space"))
+	})
+
+	createServiceRouteBindingCR := func(namespace, routeGUID, serviceInstanceGUID string) *korifiv1alpha1.CFServiceRouteBinding {
+		routeBinding := &korifiv1alpha1.CFServiceRouteBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      prefixedGUID("route-binding"),
+				Namespace: namespace,
+			},
+			Spec: korifiv1alpha1.CFServiceRouteBindingSpec{
+				Service: corev1.ObjectReference{
+					Kind:       "CFServiceInstance",
+					APIVersion: korifiv1alpha1.GroupVersion.Identifier(),
+					Name:       serviceInstanceGUID,
+				},
+				RouteRef: corev1.LocalObjectReference{Name: routeGUID},
+			},
+		}
+		Expect(k8sClient.Create(testCtx, routeBinding)).To(Succeed())
+
+		return routeBinding
+	}
+
+	Describe("CreateServiceRouteBinding", func() {
+		var (
+			record    repositories.ServiceRouteBindingRecord
+			createErr error
+		)
+
+		JustBeforeEach(func() {
+			record, createErr = repo.CreateServiceRouteBinding(testCtx, authInfo, repositories.CreateServiceRouteBindingMessage{
+				ServiceInstanceGUID: "service-instance-guid",
+				RouteGUID:           "route-guid",
+				SpaceGUID:           space.Name,
+				Labels:              map[string]string{"foo": "bar"},
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns an error as the binding does not become ready in time", func() {
+				Expect(createErr).To(MatchError(ContainSubstring("did not get the Ready condition")))
+			})
+
+			When("the binding becomes ready", func() {
+				BeforeEach(func() {
+					go func() {
+						defer GinkgoRecover()
+
+						Eventually(func(g Gomega) {
+							routeBindings := new(korifiv1alpha1.CFServiceRouteBindingList)
+							g.Expect(k8sClient.List(testCtx, routeBindings, client.InNamespace(space.Name))).To(Succeed())
+							g.Expect(routeBindings.Items).To(HaveLen(1))
+
+							routeBinding := &routeBindings.Items[0]
+							g.Expect(k8s.Patch(testCtx, k8sClient, routeBinding, func() {
+								routeBinding.Status.RouteServiceURL = "https://route-service.example.com"
+								meta.SetStatusCondition(&routeBinding.Status.Conditions, metav1.Condition{
+									Type:   "Ready",
+									Status: metav1.ConditionTrue,
+									Reason: "RouteServiceReady",
+								})
+							})).To(Succeed())
+						}).Should(Succeed())
+					}()
+				})
+
+				It("creates the binding and returns a record", func() {
+					Expect(createErr).NotTo(HaveOccurred())
+					Expect(record.GUID).NotTo(BeEmpty())
+					Expect(record.RouteGUID).To(Equal("route-guid"))
+					Expect(record.ServiceInstanceGUID).To(Equal("service-instance-guid"))
+					Expect(record.SpaceGUID).To(Equal(space.Name))
+					Expect(record.RouteServiceURL).To(Equal("https://route-service.example.com"))
+					Expect(record.Labels).To(HaveKeyWithValue("foo", "bar"))
+
+					routeBinding := new(korifiv1alpha1.CFServiceRouteBinding)
+					Expect(k8sClient.Get(testCtx, client.ObjectKey{Namespace: space.Name, Name: record.GUID}, routeBinding)).To(Succeed())
+					Expect(routeBinding.Spec.RouteRef.Name).To(Equal("route-guid"))
+					Expect(routeBinding.Spec.Service).To(MatchFields(IgnoreExtras, Fields{
+						"Kind": Equal("CFServiceInstance"),
+						"Name": Equal("service-instance-guid"),
+					}))
+				})
+			})
+		})
+	})
+
+	Describe("GetServiceRouteBinding", func() {
+		var (
+			routeBinding *korifiv1alpha1.CFServiceRouteBinding
+			record       repositories.ServiceRouteBindingRecord
+			getErr       error
+		)
+
+		BeforeEach(func() {
+			routeBinding = createServiceRouteBindingCR(space.Name, "route-guid", "service-instance-guid")
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = repo.GetServiceRouteBinding(testCtx, authInfo, routeBinding.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space manager", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceManagerRole.Name, space.Name)
+			})
+
+			It("returns the binding", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(Equal(routeBinding.Name))
+				Expect(record.RouteGUID).To(Equal("route-guid"))
+				Expect(record.ServiceInstanceGUID).To(Equal("service-instance-guid"))
+				Expect(record.SpaceGUID).To(Equal(space.Name))
+			})
+		})
+	})
+
+	Describe("ListServiceRouteBindings", func() {
+		var (
+			space2        *korifiv1alpha1.CFSpace
+			routeBinding1 *korifiv1alpha1.CFServiceRouteBinding
+			routeBinding2 *korifiv1alpha1.CFServiceRouteBinding
+			routeBinding3 *korifiv1alpha1.CFServiceRouteBinding
+			message       repositories.ListServiceRouteBindingsMessage
+			records       []repositories.ServiceRouteBindingRecord
+			listErr       error
+		)
+
+		BeforeEach(func() {
+			space2 = createSpaceWithCleanup(testCtx, org.Name, prefixedGUID("space2"))
+
+			routeBinding1 = createServiceRouteBindingCR(space.Name, "route-1", "instance-1")
+			routeBinding2 = createServiceRouteBindingCR(space.Name, "route-2", "instance-2")
+			routeBinding3 = createServiceRouteBindingCR(space2.Name, "route-3", "instance-1")
+
+			message = repositories.ListServiceRouteBindingsMessage{}
+			createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = repo.ListServiceRouteBindings(testCtx, authInfo, message)
+		})
+
+		It("returns the bindings in the spaces the user has access to", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(records).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(routeBinding1.Name)}),
+				MatchFields(IgnoreExtras, Fields{"GUID": Equal(routeBinding2.Name)}),
+			))
+		})
+
+		When("the user has access to both spaces", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space2.Name)
+			})
+
+			It("returns all bindings", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(HaveLen(3))
+			})
+
+			When("filtering by route guid", func() {
+				BeforeEach(func() {
+					message.RouteGUIDs = []string{"route-2"}
+				})
+
+				It("returns the bindings of the route", func() {
+					Expect(records).To(ConsistOf(MatchFields(IgnoreExtras, Fields{"GUID": Equal(routeBinding2.Name)})))
+				})
+			})
+
+			When("filtering by service instance guid", func() {
+				BeforeEach(func() {
+					message.ServiceInstanceGUIDs = []string{"instance-1"}
+				})
+
+				It("returns the bindings of the service instance", func() {
+					Expect(records).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(routeBinding1.Name)}),
+						MatchFields(IgnoreExtras, Fields{"GUID": Equal(routeBinding3.Name)}),
+					))
+				})
+			})
+		})
+	})
+
+	Describe("UpdateServiceRouteBinding", func() {
+		var (
+			routeBinding *korifiv1alpha1.CFServiceRouteBinding
+			record       repositories.ServiceRouteBindingRecord
+			updateErr    error
+		)
+
+		BeforeEach(func() {
+			routeBinding = createServiceRouteBindingCR(space.Name, "route-guid", "service-instance-guid")
+		})
+
+		JustBeforeEach(func() {
+			record, updateErr = repo.UpdateServiceRouteBinding(testCtx, authInfo, repositories.UpdateServiceRouteBindingMessage{
+				GUID: routeBinding.Name,
+				MetadataPatch: repositories.MetadataPatch{
+					Labels:      map[string]*string{"foo": tools.PtrTo("bar")},
+					Annotations: map[string]*string{"baz": tools.PtrTo("qux")},
+				},
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(updateErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("updates the binding metadata", func() {
+				Expect(updateErr).NotTo(HaveOccurred())
+				Expect(record.Labels).To(HaveKeyWithValue("foo", "bar"))
+				Expect(record.Annotations).To(HaveKeyWithValue("baz", "qux"))
+
+				Expect(k8sClient.Get(testCtx, client.ObjectKeyFromObject(routeBinding), routeBinding)).To(Succeed())
+				Expect(routeBinding.Labels).To(HaveKeyWithValue("foo", "bar"))
+				Expect(routeBinding.Annotations).To(HaveKeyWithValue("baz", "qux"))
+			})
+		})
+	})
+
+	Describe("DeleteServiceRouteBinding", func() {
+		var (
+			routeBinding *korifiv1alpha1.CFServiceRouteBinding
+			deleteErr    error
+		)
+
+		BeforeEach(func() {
+			routeBinding = createServiceRouteBindingCR(space.Name, "route-guid", "service-instance-guid")
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = repo.DeleteServiceRouteBinding(testCtx, authInfo, routeBinding.Name)
+		})
+
+		It("returns a not-found error for users with no role in the space", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the user is a space manager", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceManagerRole.Name, space.Name)
+			})
+
+			It("returns a forbidden error", func() {
+				Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(testCtx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("deletes the binding", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				err := k8sClient.Get(testCtx, client.ObjectKeyFromObject(routeBinding), routeBinding)
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+	})
+})
@@ -0,0 +1,234 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const ServiceUsageEventResourceType = "Service Usage Event"
+
+type ServiceUsageEventRepo struct {
+	usageEventRepo
+}
+
+func NewServiceUsageEventRepo(
+	userClientFactory authorization.UserK8sClientFactory,
+	namespacePermissions *authorization.NamespacePermissions,
+	rootNamespace string,
+) *ServiceUsageEventRepo {
+	return &ServiceUsageEventRepo{
+		usageEventRepo: usageEventRepo{
+			userClientFactory:    userClientFactory,
+			namespacePermissions: namespacePermissions,
+			rootNamespace:        rootNamespace,
+		},
+	}
+}
+
+type ServiceUsageEventRecord struct {
+	GUID                string
+	State               string
+	ServiceInstance     UsageEventResourceRecord
+	ServiceInstanceType string
+	ServicePlan         *UsageEventResourceRecord
+	ServiceOffering     *UsageEventResourceRecord
+	ServiceBroker       *UsageEventResourceRecord
+	Space               UsageEventResourceRecord
+	OrganizationGUID    string
+	CreatedAt           time.Time
+	UpdatedAt           *time.Time
+}
+
+type ListServiceUsageEventsMessage struct {
+	AfterGUID            string
+	GUIDs                []string
+	ServiceInstanceTypes []string
+	ServiceOfferingGUIDs []string
+}
+
+func (r *ServiceUsageEventRepo) GetServiceUsageEvent(ctx context.Context, authInfo authorization.Info, guid string) (ServiceUsageEventRecord, error) {
+	cfServiceUsageEvent := new(korifiv1alpha1.CFServiceUsageEvent)
+	if err := r.getUsageEvent(ctx, authInfo, guid, cfServiceUsageEvent, ServiceUsageEventResourceType); err != nil {
+		return ServiceUsageEventRecord{}, err
+	}
+
+	return cfServiceUsageEventToRecord(*cfServiceUsageEvent), nil
+}
+
+// ListServiceUsageEvents lists the events in the order in which they
+// occurred, starting after the AfterGUID event when set
+func (r *ServiceUsageEventRepo) ListServiceUsageEvents(ctx context.Context, authInfo authorization.Info, message ListServiceUsageEventsMessage) ([]ServiceUsageEventRecord, error) {
+	cfServiceUsageEventList := new(korifiv1alpha1.CFServiceUsageEventList)
+	if err := r.listUsageEvents(ctx, authInfo, cfServiceUsageEventList, ServiceUsageEventResourceType); err != nil {
+		return []ServiceUsageEventRecord{}, err
+	}
+
+	cfServiceUsageEvents, err := filterUsageEvents(
+		cfServiceUsageEventList.Items,
+		func(e korifiv1alpha1.CFServiceUsageEvent) string { return e.Name },
+		func(e korifiv1alpha1.CFServiceUsageEvent) metav1.MicroTime { return e.Spec.Timestamp },
+		message.AfterGUID,
+		message.GUIDs,
+		SetPredicate(message.ServiceInstanceTypes, func(e korifiv1alpha1.CFServiceUsageEvent) string { return e.Spec.ServiceInstanceType }),
+		SetPredicate(message.ServiceOfferingGUIDs, func(e korifiv1alpha1.CFServiceUsageEvent) string {
+			if e.Spec.ServiceOffering == nil {
+				return ""
+			}
+			return e.Spec.ServiceOffering.GUID
+		}),
+	)
+	if err != nil {
+		return []ServiceUsageEventRecord{}, err
+	}
+
+	records := []ServiceUsageEventRecord{}
+	for _, cfServiceUsageEvent := range cfServiceUsageEvents {
+		records = append(records, cfServiceUsageEventToRecord(cfServiceUsageEvent))
+	}
+
+	return records, nil
+}
+
+// PurgeAndReseedServiceUsageEvents deletes all service usage events and
+// records a CREATED event for each existing service instance
+func (r *ServiceUsageEventRepo) PurgeAndReseedServiceUsageEvents(ctx context.Context, authInfo authorization.Info) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	spaces, err := r.purgeUsageEvents(ctx, authInfo, userClient, new(korifiv1alpha1.CFServiceUsageEvent), ServiceUsageEventResourceType)
+	if err != nil {
+		return err
+	}
+
+	for _, space := range spaces {
+		cfServiceInstanceList := new(korifiv1alpha1.CFServiceInstanceList)
+		err = userClient.List(ctx, cfServiceInstanceList, client.InNamespace(space.Name))
+		if k8serrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to list service instances in namespace %s: %w", space.Name, apierrors.FromK8sError(err, ServiceInstanceResourceType))
+		}
+
+		for _, cfServiceInstance := range cfServiceInstanceList.Items {
+			if !cfServiceInstance.GetDeletionTimestamp().IsZero() {
+				continue
+			}
+
+			err = r.reseedServiceInstance(ctx, userClient, space, cfServiceInstance)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *ServiceUsageEventRepo) reseedServiceInstance(ctx context.Context, userClient client.Client, space korifiv1alpha1.CFSpace, cfServiceInstance korifiv1alpha1.CFServiceInstance) error {
+	cfServiceUsageEvent := &korifiv1alpha1.CFServiceUsageEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: r.rootNamespace,
+		},
+		Spec: korifiv1alpha1.CFServiceUsageEventSpec{
+			State: korifiv1alpha1.ServiceUsageEventStateCreated,
+			ServiceInstance: korifiv1alpha1.UsageEventResource{
+				GUID: cfServiceInstance.Name,
+				Name: cfServiceInstance.Spec.DisplayName,
+			},
+			ServiceInstanceType: korifiv1alpha1.UserProvidedServiceInstanceUsageType,
+			Space: korifiv1alpha1.UsageEventResource{
+				GUID: space.Name,
+				Name: space.Spec.DisplayName,
+			},
+			OrganizationGUID: space.Namespace,
+			Timestamp:        metav1.NowMicro(),
+		},
+	}
+
+	if cfServiceInstance.Spec.Type == korifiv1alpha1.ManagedType {
+		cfServiceUsageEvent.Spec.ServiceInstanceType = korifiv1alpha1.ManagedServiceInstanceUsageType
+		err := r.setCatalog(ctx, userClient, cfServiceUsageEvent, cfServiceInstance.Spec.PlanGUID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := userClient.Create(ctx, cfServiceUsageEvent)
+	if err != nil {
+		return fmt.Errorf("failed to create service usage event: %w", apierrors.FromK8sError(err, ServiceUsageEventResourceType))
+	}
+
+	return nil
+}
+
+// setCatalog sets the plan, offering and broker of the usage event. Catalog
+// resources that do not exist anymore are omitted from the event
+func (r *ServiceUsageEventRepo) setCatalog(ctx context.Context, userClient client.Client, cfServiceUsageEvent *korifiv1alpha1.CFServiceUsageEvent, planGUID string) error {
+	plan := new(korifiv1alpha1.CFServicePlan)
+	if err := userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: planGUID}, plan); err != nil {
+		return ignoreNotFound(err, ServicePlanResourceType)
+	}
+	cfServiceUsageEvent.Spec.ServicePlan = &korifiv1alpha1.UsageEventResource{GUID: plan.Name, Name: plan.Spec.Name}
+
+	offering := new(korifiv1alpha1.CFServiceOffering)
+	offeringGUID := plan.Labels[korifiv1alpha1.CFServiceOfferingGUIDLabelKey]
+	if err := userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: offeringGUID}, offering); err != nil {
+		return ignoreNotFound(err, ServiceOfferingResourceType)
+	}
+	cfServiceUsageEvent.Spec.ServiceOffering = &korifiv1alpha1.UsageEventResource{GUID: offering.Name, Name: offering.Spec.Name}
+
+	broker := new(korifiv1alpha1.CFServiceBroker)
+	brokerGUID := plan.Labels[korifiv1alpha1.CFServiceBrokerGUIDLabelKey]
+	if err := userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: brokerGUID}, broker); err != nil {
+		return ignoreNotFound(err, ServiceBrokerResourceType)
+	}
+	cfServiceUsageEvent.Spec.ServiceBroker = &korifiv1alpha1.UsageEventResource{GUID: broker.Name, Name: broker.Spec.Name}
+
+	return nil
+}
+
+func ignoreNotFound(err error, resourceType string) error {
+	if k8serrors.IsNotFound(err) {
+		return nil
+	}
+	return apierrors.FromK8sError(err, resourceType)
+}
+
+func cfServiceUsageEventToRecord(cfServiceUsageEvent korifiv1alpha1.CFServiceUsageEvent) ServiceUsageEventRecord {
+	return ServiceUsageEventRecord{
+		GUID:                cfServiceUsageEvent.Name,
+		State:               cfServiceUsageEvent.Spec.State,
+		ServiceInstance:     UsageEventResourceRecord(cfServiceUsageEvent.Spec.ServiceInstance),
+		ServiceInstanceType: cfServiceUsageEvent.Spec.ServiceInstanceType,
+		ServicePlan:         usageEventResourceToRecord(cfServiceUsageEvent.Spec.ServicePlan),
+		ServiceOffering:     usageEventResourceToRecord(cfServiceUsageEvent.Spec.ServiceOffering),
+		ServiceBroker:       usageEventResourceToRecord(cfServiceUsageEvent.Spec.ServiceBroker),
+		Space:               UsageEventResourceRecord(cfServiceUsageEvent.Spec.Space),
+		OrganizationGUID:    cfServiceUsageEvent.Spec.OrganizationGUID,
+		CreatedAt:           cfServiceUsageEvent.Spec.Timestamp.Time,
+		UpdatedAt:           getLastUpdatedTime(&cfServiceUsageEvent),
+	}
+}
+
+func usageEventResourceToRecord(resource *korifiv1alpha1.UsageEventResource) *UsageEventResourceRecord {
+	if resource == nil {
+		return nil
+	}
+
+	record := UsageEventResourceRecord(*resource)
+	return &record
+}
@@ -0,0 +1,224 @@
+package repositories_test
+
+import (
+	"time"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("ServiceUsageEventRepo", func() {
+	var (
+		repo      *ServiceUsageEventRepo
+		timestamp time.Time
+	)
+
+	createServiceUsageEvent := func(guid, instanceType string, offering *korifiv1alpha1.UsageEventResource, offset time.Duration) {
+		Expect(k8sClient.Create(ctx, &korifiv1alpha1.CFServiceUsageEvent{
+			ObjectMeta: metav1.ObjectMeta{Name: guid, Namespace: rootNamespace},
+			Spec: korifiv1alpha1.CFServiceUsageEventSpec{
+				State:               korifiv1alpha1.ServiceUsageEventStateCreated,
+				ServiceInstance:     korifiv1alpha1.UsageEventResource{GUID: "instance-guid", Name: "my-instance"},
+				ServiceInstanceType: instanceType,
+				ServiceOffering:     offering,
+				Space:               korifiv1alpha1.UsageEventResource{GUID: "space-guid", Name: "my-space"},
+				OrganizationGUID:    "org-guid",
+				Timestamp:           metav1.NewMicroTime(timestamp.Add(offset)),
+			},
+		})).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		repo = NewServiceUsageEventRepo(userClientFactory, nsPerms, rootNamespace)
+		timestamp = time.Now().Truncate(time.Microsecond)
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.DeleteAllOf(ctx, &korifiv1alpha1.CFServiceUsageEvent{}, client.InNamespace(rootNamespace))).To(Succeed())
+	})
+
+	Describe("GetServiceUsageEvent", func() {
+		var (
+			record ServiceUsageEventRecord
+			getErr error
+		)
+
+		BeforeEach(func() {
+			createServiceUsageEvent("event-guid", korifiv1alpha1.UserProvidedServiceInstanceUsageType, nil, 0)
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = repo.GetServiceUsageEvent(ctx, authInfo, "event-guid")
+		})
+
+		It("fails because the user is not a CF admin", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			It("returns the event", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record).To(MatchFields(IgnoreExtras, Fields{
+					"GUID":                Equal("event-guid"),
+					"State":               Equal(korifiv1alpha1.ServiceUsageEventStateCreated),
+					"ServiceInstance":     Equal(UsageEventResourceRecord{GUID: "instance-guid", Name: "my-instance"}),
+					"ServiceInstanceType": Equal(korifiv1alpha1.UserProvidedServiceInstanceUsageType),
+					"ServicePlan":         BeNil(),
+					"ServiceOffering":     BeNil(),
+					"ServiceBroker":       BeNil(),
+					"Space":               Equal(UsageEventResourceRecord{GUID: "space-guid", Name: "my-space"}),
+					"OrganizationGUID":    Equal("org-guid"),
+					"CreatedAt":           BeTemporally("==", timestamp),
+				}))
+			})
+		})
+	})
+
+	Describe("ListServiceUsageEvents", func() {
+		var (
+			message ListServiceUsageEventsMessage
+			records []ServiceUsageEventRecord
+			listErr error
+		)
+
+		BeforeEach(func() {
+			message = ListServiceUsageEventsMessage{}
+			createServiceUsageEvent("event-2", korifiv1alpha1.ManagedServiceInstanceUsageType, &korifiv1alpha1.UsageEventResource{GUID: "offering-guid"}, time.Second)
+			createServiceUsageEvent("event-1", korifiv1alpha1.UserProvidedServiceInstanceUsageType, nil, 0)
+			createServiceUsageEvent("event-3", korifiv1alpha1.ManagedServiceInstanceUsageType, &korifiv1alpha1.UsageEventResource{GUID: "other-offering-guid"}, 2*time.Second)
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = repo.ListServiceUsageEvents(ctx, authInfo, message)
+		})
+
+		It("fails because the user is not a CF admin", func() {
+			Expect(listErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+			})
+
+			When("service instance types are specified", func() {
+				BeforeEach(func() {
+					message.ServiceInstanceTypes = []string{korifiv1alpha1.ManagedServiceInstanceUsageType}
+				})
+
+				It("returns the matching events", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(HaveLen(2))
+					Expect(records[0].GUID).To(Equal("event-2"))
+					Expect(records[1].GUID).To(Equal("event-3"))
+				})
+			})
+
+			When("service offering guids are specified", func() {
+				BeforeEach(func() {
+					message.ServiceOfferingGUIDs = []string{"offering-guid"}
+				})
+
+				It("returns the matching events", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(HaveLen(1))
+					Expect(records[0].GUID).To(Equal("event-2"))
+				})
+			})
+		})
+	})
+
+	Describe("PurgeAndReseedServiceUsageEvents", func() {
+		var (
+			cfOrg             *korifiv1alpha1.CFOrg
+			cfSpace           *korifiv1alpha1.CFSpace
+			userProvided      *korifiv1alpha1.CFServiceInstance
+			managed           *korifiv1alpha1.CFServiceInstance
+			cfServiceOffering *korifiv1alpha1.CFServiceOffering
+			cfServicePlan     *korifiv1alpha1.CFServicePlan
+			purgeErr          error
+		)
+
+		BeforeEach(func() {
+			cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+			cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space"))
+
+			cfServiceOffering = createServiceOffering("my-offering")
+			cfServicePlan = createServicePlan(cfServiceOffering, "my-plan", korifiv1alpha1.ServicePlanVisibility{
+				Type: korifiv1alpha1.PublicServicePlanVisibilityType,
+			})
+
+			userProvided = createServiceInstanceCR(ctx, k8sClient, prefixedGUID("instance"), cfSpace.Name, "user-provided", "secret")
+			managed = &korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      prefixedGUID("instance"),
+					Namespace: cfSpace.Name,
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					DisplayName: "managed",
+					Type:        korifiv1alpha1.ManagedType,
+					PlanGUID:    cfServicePlan.Name,
+				},
+			}
+			Expect(k8sClient.Create(ctx, managed)).To(Succeed())
+
+			createServiceUsageEvent("old-event", korifiv1alpha1.UserProvidedServiceInstanceUsageType, nil, 0)
+		})
+
+		JustBeforeEach(func() {
+			purgeErr = repo.PurgeAndReseedServiceUsageEvents(ctx, authInfo)
+		})
+
+		It("fails because the user is not a CF admin", func() {
+			Expect(purgeErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a CF admin", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, rootNamespace)
+				createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+				createRoleBinding(ctx, userName, adminRole.Name, cfSpace.Name)
+			})
+
+			It("replaces the events with a CREATED event for each service instance", func() {
+				Expect(purgeErr).NotTo(HaveOccurred())
+
+				cfServiceUsageEvents := new(korifiv1alpha1.CFServiceUsageEventList)
+				Expect(k8sClient.List(ctx, cfServiceUsageEvents, client.InNamespace(rootNamespace))).To(Succeed())
+				Expect(cfServiceUsageEvents.Items).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"Spec": MatchFields(IgnoreExtras, Fields{
+							"State":               Equal(korifiv1alpha1.ServiceUsageEventStateCreated),
+							"ServiceInstance":     Equal(korifiv1alpha1.UsageEventResource{GUID: userProvided.Name, Name: "user-provided"}),
+							"ServiceInstanceType": Equal(korifiv1alpha1.UserProvidedServiceInstanceUsageType),
+							"Space":               Equal(korifiv1alpha1.UsageEventResource{GUID: cfSpace.Name, Name: cfSpace.Spec.DisplayName}),
+							"OrganizationGUID":    Equal(cfOrg.Name),
+						}),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"Spec": MatchFields(IgnoreExtras, Fields{
+							"State":               Equal(korifiv1alpha1.ServiceUsageEventStateCreated),
+							"ServiceInstance":     Equal(korifiv1alpha1.UsageEventResource{GUID: managed.Name, Name: "managed"}),
+							"ServiceInstanceType": Equal(korifiv1alpha1.ManagedServiceInstanceUsageType),
+							"ServicePlan":         PointTo(Equal(korifiv1alpha1.UsageEventResource{GUID: cfServicePlan.Name, Name: "my-plan"})),
+							"ServiceOffering":     PointTo(Equal(korifiv1alpha1.UsageEventResource{GUID: cfServiceOffering.Name, Name: "my-offering"})),
+							"ServiceBroker":       BeNil(),
+						}),
+					}),
+				))
+			})
+		})
+	})
+})
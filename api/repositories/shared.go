@@ -2,8 +2,11 @@ package repositories
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -13,6 +16,7 @@ import (
 const (
 	StatusConditionReady                 = "Ready"
 	VCAPServicesSecretAvailableCondition = "VCAPServicesSecretAvailable"
+	BindingSecretAvailableCondition      = "BindingSecretAvailable"
 )
 
 //go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 -generate
@@ -113,3 +117,51 @@ func SetPredicate[T comparable, S any](elements []T, mapFn func(S) T) func(S) bo
 }
 
 func AlwaysTrue[T any](_ T) bool { return true }
+
+type ResourceStatus int
+
+const (
+	ResourceStatusProcessing ResourceStatus = iota
+	ResourceStatusReady
+	ResourceStatusFailed
+)
+
+// ResourceState describes the progress of an asynchronous operation on a resource
+type ResourceState struct {
+	Status  ResourceStatus
+	Details string
+}
+
+// getResourceState maps the Ready condition of a resource onto a ResourceState.
+// The condition is only taken into account once it reflects the latest generation of the resource
+func getResourceState(generation int64, conditions []metav1.Condition) ResourceState {
+	readyCondition := meta.FindStatusCondition(conditions, StatusConditionReady)
+	if readyCondition == nil || readyCondition.ObservedGeneration != generation {
+		return ResourceState{Status: ResourceStatusProcessing}
+	}
+
+	switch readyCondition.Status {
+	case metav1.ConditionTrue:
+		return ResourceState{Status: ResourceStatusReady}
+	case metav1.ConditionFalse:
+		return ResourceState{Status: ResourceStatusFailed, Details: readyCondition.Message}
+	default:
+		return ResourceState{Status: ResourceStatusProcessing}
+	}
+}
+
+// After returns the elements following the element with the given GUID, or
+// all elements when the GUID is empty
+func After[T any](elements []T, guid string, guidFn func(T) string) ([]T, error) {
+	if guid == "" {
+		return elements, nil
+	}
+
+	for i, e := range elements {
+		if guidFn(e) == guid {
+			return elements[i+1:], nil
+		}
+	}
+
+	return nil, apierrors.NewUnprocessableEntityError(fmt.Errorf("element %q not found", guid), "After guid filter must be a valid event guid.")
+}
@@ -0,0 +1,290 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	SidecarResourceType = "Sidecar"
+	SidecarOriginUser   = "user"
+)
+
+type SidecarRepo struct {
+	userClientFactory  authorization.UserK8sClientFactory
+	namespaceRetriever NamespaceRetriever
+}
+
+func NewSidecarRepo(
+	userClientFactory authorization.UserK8sClientFactory,
+	namespaceRetriever NamespaceRetriever,
+) *SidecarRepo {
+	return &SidecarRepo{
+		userClientFactory:  userClientFactory,
+		namespaceRetriever: namespaceRetriever,
+	}
+}
+
+type SidecarRecord struct {
+	GUID         string
+	AppGUID      string
+	SpaceGUID    string
+	Name         string
+	Command      string
+	ProcessTypes []string
+	MemoryMB     *int64
+	Origin       string
+	CreatedAt    time.Time
+	UpdatedAt    *time.Time
+}
+
+type CreateSidecarMessage struct {
+	AppGUID      string
+	SpaceGUID    string
+	Name         string
+	Command      string
+	ProcessTypes []string
+	MemoryMB     *int64
+}
+
+type PatchSidecarMessage struct {
+	GUID         string
+	SpaceGUID    string
+	Name         *string
+	Command      *string
+	ProcessTypes []string
+	MemoryMB     *int64
+}
+
+type ListSidecarsMessage struct {
+	AppGUID     string
+	SpaceGUID   string
+	ProcessType string
+}
+
+type DeleteSidecarMessage struct {
+	GUID      string
+	SpaceGUID string
+}
+
+func (r *SidecarRepo) GetSidecar(ctx context.Context, authInfo authorization.Info, guid string) (SidecarRecord, error) {
+	ns, err := r.namespaceRetriever.NamespaceFor(ctx, guid, SidecarResourceType)
+	if err != nil {
+		return SidecarRecord{}, fmt.Errorf("failed to get namespace for sidecar: %w", err)
+	}
+
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SidecarRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSidecar := new(korifiv1alpha1.CFSidecar)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: ns, Name: guid}, cfSidecar)
+	if err != nil {
+		return SidecarRecord{}, fmt.Errorf("failed to get sidecar %q: %w", guid, apierrors.FromK8sError(err, SidecarResourceType))
+	}
+
+	return cfSidecarToRecord(*cfSidecar), nil
+}
+
+// ListSidecars lists the sidecars of an app ordered by name. When ProcessType
+// is set, only the sidecars running alongside that process type are returned
+func (r *SidecarRepo) ListSidecars(ctx context.Context, authInfo authorization.Info, message ListSidecarsMessage) ([]SidecarRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []SidecarRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSidecars, err := listAppSidecars(ctx, userClient, message.SpaceGUID, message.AppGUID)
+	if err != nil {
+		return []SidecarRecord{}, err
+	}
+
+	records := []SidecarRecord{}
+	for _, cfSidecar := range cfSidecars {
+		if message.ProcessType != "" && !contains(cfSidecar.Spec.ProcessTypes, message.ProcessType) {
+			continue
+		}
+		records = append(records, cfSidecarToRecord(cfSidecar))
+	}
+
+	return records, nil
+}
+
+func (r *SidecarRepo) CreateSidecar(ctx context.Context, authInfo authorization.Info, message CreateSidecarMessage) (SidecarRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SidecarRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfApp := new(korifiv1alpha1.CFApp)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.AppGUID}, cfApp)
+	if err != nil {
+		return SidecarRecord{}, fmt.Errorf("failed to get app %q: %w", message.AppGUID, apierrors.FromK8sError(err, AppResourceType))
+	}
+
+	if err = ensureUniqueSidecarName(ctx, userClient, cfApp.Namespace, cfApp.Name, "", message.Name); err != nil {
+		return SidecarRecord{}, err
+	}
+
+	cfSidecar := &korifiv1alpha1.CFSidecar{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: cfApp.Namespace,
+			Labels: map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+			},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: APIVersion,
+				Kind:       Kind,
+				Name:       cfApp.Name,
+				UID:        cfApp.UID,
+			}},
+		},
+		Spec: korifiv1alpha1.CFSidecarSpec{
+			AppRef:       corev1.LocalObjectReference{Name: cfApp.Name},
+			Name:         message.Name,
+			Command:      message.Command,
+			ProcessTypes: message.ProcessTypes,
+		},
+	}
+	if message.MemoryMB != nil {
+		cfSidecar.Spec.MemoryMB = *message.MemoryMB
+	}
+
+	err = userClient.Create(ctx, cfSidecar)
+	if err != nil {
+		return SidecarRecord{}, fmt.Errorf("failed to create sidecar: %w", apierrors.FromK8sError(err, SidecarResourceType))
+	}
+
+	return cfSidecarToRecord(*cfSidecar), nil
+}
+
+func (r *SidecarRepo) PatchSidecar(ctx context.Context, authInfo authorization.Info, message PatchSidecarMessage) (SidecarRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SidecarRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSidecar := new(korifiv1alpha1.CFSidecar)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.SpaceGUID, Name: message.GUID}, cfSidecar)
+	if err != nil {
+		return SidecarRecord{}, fmt.Errorf("failed to get sidecar %q: %w", message.GUID, apierrors.FromK8sError(err, SidecarResourceType))
+	}
+
+	if message.Name != nil {
+		err = ensureUniqueSidecarName(ctx, userClient, cfSidecar.Namespace, cfSidecar.Spec.AppRef.Name, cfSidecar.Name, *message.Name)
+		if err != nil {
+			return SidecarRecord{}, err
+		}
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfSidecar, func() {
+		if message.Name != nil {
+			cfSidecar.Spec.Name = *message.Name
+		}
+		if message.Command != nil {
+			cfSidecar.Spec.Command = *message.Command
+		}
+		if message.ProcessTypes != nil {
+			cfSidecar.Spec.ProcessTypes = message.ProcessTypes
+		}
+		if message.MemoryMB != nil {
+			cfSidecar.Spec.MemoryMB = *message.MemoryMB
+		}
+	})
+	if err != nil {
+		return SidecarRecord{}, fmt.Errorf("failed to patch sidecar %q: %w", message.GUID, apierrors.FromK8sError(err, SidecarResourceType))
+	}
+
+	return cfSidecarToRecord(*cfSidecar), nil
+}
+
+func (r *SidecarRepo) DeleteSidecar(ctx context.Context, authInfo authorization.Info, message DeleteSidecarMessage) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	err = userClient.Delete(ctx, &korifiv1alpha1.CFSidecar{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      message.GUID,
+			Namespace: message.SpaceGUID,
+		},
+	})
+
+	return apierrors.FromK8sError(err, SidecarResourceType)
+}
+
+func listAppSidecars(ctx context.Context, userClient client.Client, namespace, appGUID string) ([]korifiv1alpha1.CFSidecar, error) {
+	cfSidecarList := new(korifiv1alpha1.CFSidecarList)
+	err := userClient.List(ctx, cfSidecarList, client.InNamespace(namespace), client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: appGUID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list sidecars: %w", apierrors.FromK8sError(err, SidecarResourceType))
+	}
+
+	cfSidecars := cfSidecarList.Items
+	sort.Slice(cfSidecars, func(i, j int) bool {
+		return cfSidecars[i].Spec.Name < cfSidecars[j].Spec.Name
+	})
+
+	return cfSidecars, nil
+}
+
+// ensureUniqueSidecarName fails when another sidecar of the app, other than
+// the one with sidecarGUID, already has the name
+func ensureUniqueSidecarName(ctx context.Context, userClient client.Client, namespace, appGUID, sidecarGUID, name string) error {
+	cfSidecars, err := listAppSidecars(ctx, userClient, namespace, appGUID)
+	if err != nil {
+		return err
+	}
+
+	for _, cfSidecar := range cfSidecars {
+		if cfSidecar.Name != sidecarGUID && cfSidecar.Spec.Name == name {
+			return apierrors.NewUnprocessableEntityError(nil, fmt.Sprintf("Sidecar with name '%s' already exists for given app", name))
+		}
+	}
+
+	return nil
+}
+
+func contains(elements []string, element string) bool {
+	for _, e := range elements {
+		if e == element {
+			return true
+		}
+	}
+	return false
+}
+
+func cfSidecarToRecord(cfSidecar korifiv1alpha1.CFSidecar) SidecarRecord {
+	record := SidecarRecord{
+		GUID:         cfSidecar.Name,
+		AppGUID:      cfSidecar.Spec.AppRef.Name,
+		SpaceGUID:    cfSidecar.Namespace,
+		Name:         cfSidecar.Spec.Name,
+		Command:      cfSidecar.Spec.Command,
+		ProcessTypes: cfSidecar.Spec.ProcessTypes,
+		Origin:       SidecarOriginUser,
+		CreatedAt:    cfSidecar.CreationTimestamp.Time,
+		UpdatedAt:    getLastUpdatedTime(&cfSidecar),
+	}
+	if cfSidecar.Spec.MemoryMB > 0 {
+		memoryMB := cfSidecar.Spec.MemoryMB
+		record.MemoryMB = &memoryMB
+	}
+
+	return record
+}
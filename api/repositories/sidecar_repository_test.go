@@ -0,0 +1,306 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("SidecarRepo", func() {
+	var (
+		repo    *SidecarRepo
+		cfOrg   *korifiv1alpha1.CFOrg
+		cfSpace *korifiv1alpha1.CFSpace
+		cfApp   *korifiv1alpha1.CFApp
+	)
+
+	createSidecar := func(name string, processTypes ...string) *korifiv1alpha1.CFSidecar {
+		cfSidecar := &korifiv1alpha1.CFSidecar{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      prefixedGUID("sidecar"),
+				Namespace: cfSpace.Name,
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name,
+				},
+			},
+			Spec: korifiv1alpha1.CFSidecarSpec{
+				AppRef:       corev1.LocalObjectReference{Name: cfApp.Name},
+				Name:         name,
+				Command:      "bin/" + name,
+				ProcessTypes: processTypes,
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfSidecar)).To(Succeed())
+		return cfSidecar
+	}
+
+	BeforeEach(func() {
+		repo = NewSidecarRepo(userClientFactory, namespaceRetriever)
+
+		cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, prefixedGUID("space"))
+		cfApp = createApp(cfSpace.Name)
+	})
+
+	Describe("GetSidecar", func() {
+		var (
+			cfSidecar *korifiv1alpha1.CFSidecar
+			record    SidecarRecord
+			getErr    error
+		)
+
+		BeforeEach(func() {
+			cfSidecar = createSidecar("my-sidecar", "web")
+		})
+
+		JustBeforeEach(func() {
+			record, getErr = repo.GetSidecar(ctx, authInfo, cfSidecar.Name)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns the sidecar", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record).To(MatchFields(IgnoreExtras, Fields{
+					"GUID":         Equal(cfSidecar.Name),
+					"AppGUID":      Equal(cfApp.Name),
+					"SpaceGUID":    Equal(cfSpace.Name),
+					"Name":         Equal("my-sidecar"),
+					"Command":      Equal("bin/my-sidecar"),
+					"ProcessTypes": Equal([]string{"web"}),
+					"MemoryMB":     BeNil(),
+					"Origin":       Equal(SidecarOriginUser),
+				}))
+			})
+		})
+
+		When("the sidecar does not exist", func() {
+			JustBeforeEach(func() {
+				_, getErr = repo.GetSidecar(ctx, authInfo, "i-do-not-exist")
+			})
+
+			It("returns a not found error", func() {
+				Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+	})
+
+	Describe("ListSidecars", func() {
+		var (
+			message ListSidecarsMessage
+			records []SidecarRecord
+			listErr error
+		)
+
+		BeforeEach(func() {
+			message = ListSidecarsMessage{AppGUID: cfApp.Name, SpaceGUID: cfSpace.Name}
+			createSidecar("bob", "worker")
+			createSidecar("alice", "web", "worker")
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = repo.ListSidecars(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(listErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is authorized in the space", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("returns the app sidecars ordered by name", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(HaveLen(2))
+				Expect(records[0].Name).To(Equal("alice"))
+				Expect(records[1].Name).To(Equal("bob"))
+			})
+
+			When("filtering by process type", func() {
+				BeforeEach(func() {
+					message.ProcessType = "web"
+				})
+
+				It("returns only the sidecars running alongside that process type", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(HaveLen(1))
+					Expect(records[0].Name).To(Equal("alice"))
+				})
+			})
+		})
+	})
+
+	Describe("CreateSidecar", func() {
+		var (
+			message   CreateSidecarMessage
+			record    SidecarRecord
+			createErr error
+		)
+
+		BeforeEach(func() {
+			message = CreateSidecarMessage{
+				AppGUID:      cfApp.Name,
+				SpaceGUID:    cfSpace.Name,
+				Name:         "my-sidecar",
+				Command:      "bin/sidecar",
+				ProcessTypes: []string{"web"},
+				MemoryMB:     tools.PtrTo[int64](64),
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, createErr = repo.CreateSidecar(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("creates the sidecar", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(record.AppGUID).To(Equal(cfApp.Name))
+				Expect(record.Name).To(Equal("my-sidecar"))
+				Expect(record.MemoryMB).To(PointTo(BeEquivalentTo(64)))
+
+				cfSidecar := new(korifiv1alpha1.CFSidecar)
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: cfSpace.Name, Name: record.GUID}, cfSidecar)).To(Succeed())
+				Expect(cfSidecar.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFAppGUIDLabelKey, cfApp.Name))
+				Expect(cfSidecar.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+					"Kind": Equal("CFApp"),
+					"Name": Equal(cfApp.Name),
+				})))
+				Expect(cfSidecar.Spec).To(Equal(korifiv1alpha1.CFSidecarSpec{
+					AppRef:       corev1.LocalObjectReference{Name: cfApp.Name},
+					Name:         "my-sidecar",
+					Command:      "bin/sidecar",
+					ProcessTypes: []string{"web"},
+					MemoryMB:     64,
+				}))
+			})
+
+			When("the app already has a sidecar with the same name", func() {
+				BeforeEach(func() {
+					createSidecar("my-sidecar", "worker")
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("PatchSidecar", func() {
+		var (
+			cfSidecar *korifiv1alpha1.CFSidecar
+			message   PatchSidecarMessage
+			record    SidecarRecord
+			patchErr  error
+		)
+
+		BeforeEach(func() {
+			cfSidecar = createSidecar("my-sidecar", "web")
+			message = PatchSidecarMessage{
+				GUID:         cfSidecar.Name,
+				SpaceGUID:    cfSpace.Name,
+				Command:      tools.PtrTo("bin/other-sidecar"),
+				ProcessTypes: []string{"worker"},
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, patchErr = repo.PatchSidecar(ctx, authInfo, message)
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("patches the sidecar", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				Expect(record.Name).To(Equal("my-sidecar"))
+				Expect(record.Command).To(Equal("bin/other-sidecar"))
+				Expect(record.ProcessTypes).To(Equal([]string{"worker"}))
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSidecar), cfSidecar)).To(Succeed())
+				Expect(cfSidecar.Spec.Command).To(Equal("bin/other-sidecar"))
+				Expect(cfSidecar.Spec.ProcessTypes).To(Equal([]string{"worker"}))
+			})
+
+			When("renaming the sidecar to the name of another sidecar of the app", func() {
+				BeforeEach(func() {
+					createSidecar("other-sidecar", "web")
+					message.Name = tools.PtrTo("other-sidecar")
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("DeleteSidecar", func() {
+		var (
+			cfSidecar *korifiv1alpha1.CFSidecar
+			deleteErr error
+		)
+
+		BeforeEach(func() {
+			cfSidecar = createSidecar("my-sidecar", "web")
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = repo.DeleteSidecar(ctx, authInfo, DeleteSidecarMessage{
+				GUID:      cfSidecar.Name,
+				SpaceGUID: cfSpace.Name,
+			})
+		})
+
+		It("returns a forbidden error", func() {
+			Expect(deleteErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, cfSpace.Name)
+			})
+
+			It("deletes the sidecar", func() {
+				Expect(deleteErr).NotTo(HaveOccurred())
+
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSidecar), cfSidecar)
+				Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			})
+		})
+	})
+})
@@ -0,0 +1,421 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/quotas"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	SpaceQuotaResourceType = "Space Quota"
+)
+
+type SpaceQuotaRepo struct {
+	userClientFactory    authorization.UserK8sClientFactory
+	namespaceRetriever   NamespaceRetriever
+	namespacePermissions *authorization.NamespacePermissions
+	privilegedClient     client.Client
+	rootNamespace        string
+}
+
+func NewSpaceQuotaRepo(
+	userClientFactory authorization.UserK8sClientFactory,
+	namespaceRetriever NamespaceRetriever,
+	namespacePermissions *authorization.NamespacePermissions,
+	privilegedClient client.Client,
+	rootNamespace string,
+) *SpaceQuotaRepo {
+	return &SpaceQuotaRepo{
+		userClientFactory:    userClientFactory,
+		namespaceRetriever:   namespaceRetriever,
+		namespacePermissions: namespacePermissions,
+		privilegedClient:     privilegedClient,
+		rootNamespace:        rootNamespace,
+	}
+}
+
+type SpaceQuotaRecord struct {
+	GUID       string
+	Name       string
+	Limits     QuotaLimits
+	OrgGUID    string
+	SpaceGUIDs []string
+	CreatedAt  time.Time
+	UpdatedAt  *time.Time
+	DeletedAt  *time.Time
+}
+
+type CreateSpaceQuotaMessage struct {
+	Name       string
+	Limits     QuotaLimits
+	OrgGUID    string
+	SpaceGUIDs []string
+}
+
+type UpdateSpaceQuotaMessage struct {
+	GUID   string
+	Name   *string
+	Limits UpdateQuotaLimits
+}
+
+type ListSpaceQuotasMessage struct {
+	GUIDs      []string
+	Names      []string
+	OrgGUIDs   []string
+	SpaceGUIDs []string
+}
+
+type ApplySpaceQuotaMessage struct {
+	GUID       string
+	SpaceGUIDs []string
+}
+
+type RemoveSpaceQuotaMessage struct {
+	GUID      string
+	SpaceGUID string
+}
+
+func (r *SpaceQuotaRepo) CreateSpaceQuota(ctx context.Context, authInfo authorization.Info, message CreateSpaceQuotaMessage) (SpaceQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	if err = r.checkSpacesInOrg(ctx, message.OrgGUID, message.SpaceGUIDs); err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	cfSpaceQuota := &korifiv1alpha1.CFSpaceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: message.OrgGUID,
+		},
+		Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+			DisplayName: message.Name,
+			Limits:      toCFQuotaLimits(message.Limits),
+			Spaces:      withGUIDs(nil, message.SpaceGUIDs),
+		},
+	}
+	if err = userClient.Create(ctx, cfSpaceQuota); err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to create space quota: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	if err = r.removeFromOtherQuotas(ctx, userClient, cfSpaceQuota, message.SpaceGUIDs); err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	return cfSpaceQuotaToRecord(cfSpaceQuota, quotaVisibility{isAdmin: true}), nil
+}
+
+func (r *SpaceQuotaRepo) GetSpaceQuota(ctx context.Context, authInfo authorization.Info, guid string) (SpaceQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpaceQuota, err := r.getCFSpaceQuota(ctx, userClient, guid)
+	if err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	visibility, err := r.getVisibility(ctx, authInfo)
+	if err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	return cfSpaceQuotaToRecord(cfSpaceQuota, visibility), nil
+}
+
+func (r *SpaceQuotaRepo) ListSpaceQuotas(ctx context.Context, authInfo authorization.Info, message ListSpaceQuotasMessage) ([]SpaceQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return []SpaceQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	visibility, err := r.getVisibility(ctx, authInfo)
+	if err != nil {
+		return []SpaceQuotaRecord{}, err
+	}
+
+	orgGUIDs := NewSet(message.OrgGUIDs...)
+	cfSpaceQuotas := []korifiv1alpha1.CFSpaceQuota{}
+	for orgGUID := range visibility.authorizedOrgs {
+		if len(orgGUIDs) > 0 && !orgGUIDs.Includes(orgGUID) {
+			continue
+		}
+
+		spaceQuotaList := new(korifiv1alpha1.CFSpaceQuotaList)
+		err = userClient.List(ctx, spaceQuotaList, client.InNamespace(orgGUID))
+		if k8serrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return []SpaceQuotaRecord{}, fmt.Errorf("failed to list space quotas in namespace %s: %w", orgGUID, apierrors.FromK8sError(err, SpaceQuotaResourceType))
+		}
+
+		cfSpaceQuotas = append(cfSpaceQuotas, spaceQuotaList.Items...)
+	}
+
+	filtered := Filter(cfSpaceQuotas,
+		SetPredicate(message.GUIDs, func(q korifiv1alpha1.CFSpaceQuota) string { return q.Name }),
+		SetPredicate(message.Names, func(q korifiv1alpha1.CFSpaceQuota) string { return q.Spec.DisplayName }),
+		func(q korifiv1alpha1.CFSpaceQuota) bool {
+			return len(message.SpaceGUIDs) == 0 || appliesToAnyOf(visibility.visibleSpaces(q.Spec.Spaces), message.SpaceGUIDs)
+		},
+	)
+
+	sort.Slice(filtered, func(i, j int) bool {
+		return filtered[i].CreationTimestamp.Before(&filtered[j].CreationTimestamp)
+	})
+
+	records := make([]SpaceQuotaRecord, 0, len(filtered))
+	for i := range filtered {
+		records = append(records, cfSpaceQuotaToRecord(&filtered[i], visibility))
+	}
+
+	return records, nil
+}
+
+func (r *SpaceQuotaRepo) UpdateSpaceQuota(ctx context.Context, authInfo authorization.Info, message UpdateSpaceQuotaMessage) (SpaceQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpaceQuota, err := r.getCFSpaceQuota(ctx, userClient, message.GUID)
+	if err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfSpaceQuota, func() {
+		if message.Name != nil {
+			cfSpaceQuota.Spec.DisplayName = *message.Name
+		}
+		message.Limits.apply(&cfSpaceQuota.Spec.Limits)
+	})
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to patch space quota: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	return cfSpaceQuotaToRecord(cfSpaceQuota, quotaVisibility{isAdmin: true}), nil
+}
+
+func (r *SpaceQuotaRepo) DeleteSpaceQuota(ctx context.Context, authInfo authorization.Info, guid string) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	namespace, err := r.namespaceRetriever.NamespaceFor(ctx, guid, SpaceQuotaResourceType)
+	if err != nil {
+		return err
+	}
+
+	cfSpaceQuota := &korifiv1alpha1.CFSpaceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      guid,
+			Namespace: namespace,
+		},
+	}
+
+	if err = userClient.Delete(ctx, cfSpaceQuota); err != nil {
+		return fmt.Errorf("failed to delete space quota: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	return nil
+}
+
+// ApplySpaceQuota applies the quota to the spaces, removing them from the
+// quotas that applied to them before, as a space has at most one quota
+func (r *SpaceQuotaRepo) ApplySpaceQuota(ctx context.Context, authInfo authorization.Info, message ApplySpaceQuotaMessage) (SpaceQuotaRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpaceQuota, err := r.getCFSpaceQuota(ctx, userClient, message.GUID)
+	if err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	if err = r.checkSpacesInOrg(ctx, cfSpaceQuota.Namespace, message.SpaceGUIDs); err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	if err = r.removeFromOtherQuotas(ctx, userClient, cfSpaceQuota, message.SpaceGUIDs); err != nil {
+		return SpaceQuotaRecord{}, err
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfSpaceQuota, func() {
+		cfSpaceQuota.Spec.Spaces = withGUIDs(cfSpaceQuota.Spec.Spaces, message.SpaceGUIDs)
+	})
+	if err != nil {
+		return SpaceQuotaRecord{}, fmt.Errorf("failed to apply space quota: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	return cfSpaceQuotaToRecord(cfSpaceQuota, quotaVisibility{isAdmin: true}), nil
+}
+
+func (r *SpaceQuotaRepo) RemoveSpaceQuota(ctx context.Context, authInfo authorization.Info, message RemoveSpaceQuotaMessage) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpaceQuota, err := r.getCFSpaceQuota(ctx, userClient, message.GUID)
+	if err != nil {
+		return err
+	}
+
+	if !appliesToAnyOf(cfSpaceQuota.Spec.Spaces, []string{message.SpaceGUID}) {
+		return apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("space quota %q is not applied to space %q", message.GUID, message.SpaceGUID),
+			fmt.Sprintf("Unable to remove quota from space with guid '%s'. Ensure the space quota is applied to this space.", message.SpaceGUID),
+		)
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfSpaceQuota, func() {
+		cfSpaceQuota.Spec.Spaces = withoutGUIDs(cfSpaceQuota.Spec.Spaces, []string{message.SpaceGUID})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove space quota: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	return nil
+}
+
+func (r *SpaceQuotaRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, guid string) (*time.Time, error) {
+	spaceQuota, err := r.GetSpaceQuota(ctx, authInfo, guid)
+	return spaceQuota.DeletedAt, err
+}
+
+func (r *SpaceQuotaRepo) GetSpaceUsageSummary(ctx context.Context, authInfo authorization.Info, spaceGUID string) (UsageSummaryRecord, error) {
+	authorizedSpaces, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return UsageSummaryRecord{}, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+	}
+	if !authorizedSpaces[spaceGUID] {
+		return UsageSummaryRecord{}, apierrors.NewNotFoundError(fmt.Errorf("space %q is not authorized", spaceGUID), SpaceResourceType)
+	}
+
+	usage, err := quotas.NewCalculator(r.privilegedClient, r.rootNamespace).SpaceUsage(ctx, spaceGUID)
+	if err != nil {
+		return UsageSummaryRecord{}, fmt.Errorf("failed to calculate space usage: %w", err)
+	}
+
+	return toUsageSummaryRecord(usage), nil
+}
+
+// removeFromOtherQuotas ensures the spaces are not subject to any other quota
+// of the org than the given one
+func (r *SpaceQuotaRepo) removeFromOtherQuotas(ctx context.Context, userClient client.Client, cfSpaceQuota *korifiv1alpha1.CFSpaceQuota, spaceGUIDs []string) error {
+	if len(spaceGUIDs) == 0 {
+		return nil
+	}
+
+	spaceQuotaList := new(korifiv1alpha1.CFSpaceQuotaList)
+	err := userClient.List(ctx, spaceQuotaList, client.InNamespace(cfSpaceQuota.Namespace))
+	if err != nil {
+		return fmt.Errorf("failed to list space quotas in namespace %s: %w", cfSpaceQuota.Namespace, apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	for i := range spaceQuotaList.Items {
+		otherQuota := &spaceQuotaList.Items[i]
+		if otherQuota.Name == cfSpaceQuota.Name || !appliesToAnyOf(otherQuota.Spec.Spaces, spaceGUIDs) {
+			continue
+		}
+
+		err = k8s.PatchResource(ctx, userClient, otherQuota, func() {
+			otherQuota.Spec.Spaces = withoutGUIDs(otherQuota.Spec.Spaces, spaceGUIDs)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to remove spaces from space quota %q: %w", otherQuota.Name, apierrors.FromK8sError(err, SpaceQuotaResourceType))
+		}
+	}
+
+	return nil
+}
+
+// checkSpacesInOrg ensures the spaces exist and belong to the org of the quota
+func (r *SpaceQuotaRepo) checkSpacesInOrg(ctx context.Context, orgGUID string, spaceGUIDs []string) error {
+	invalidSpaceGUIDs := []string{}
+	for _, spaceGUID := range spaceGUIDs {
+		spaceOrgGUID, err := r.namespaceRetriever.NamespaceFor(ctx, spaceGUID, SpaceResourceType)
+		if err != nil {
+			if errors.As(err, &apierrors.NotFoundError{}) {
+				invalidSpaceGUIDs = append(invalidSpaceGUIDs, spaceGUID)
+				continue
+			}
+			return err
+		}
+
+		if spaceOrgGUID != orgGUID {
+			invalidSpaceGUIDs = append(invalidSpaceGUIDs, spaceGUID)
+		}
+	}
+
+	if len(invalidSpaceGUIDs) > 0 {
+		return apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("spaces %v do not exist in org %q", invalidSpaceGUIDs, orgGUID),
+			fmt.Sprintf("Spaces with guids [\"%s\"] do not exist within the organization specified, or you do not have access to them.", strings.Join(invalidSpaceGUIDs, "\", \"")),
+		)
+	}
+
+	return nil
+}
+
+func (r *SpaceQuotaRepo) getCFSpaceQuota(ctx context.Context, userClient client.Client, guid string) (*korifiv1alpha1.CFSpaceQuota, error) {
+	namespace, err := r.namespaceRetriever.NamespaceFor(ctx, guid, SpaceQuotaResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	cfSpaceQuota := new(korifiv1alpha1.CFSpaceQuota)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: namespace, Name: guid}, cfSpaceQuota)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get space quota: %w", apierrors.FromK8sError(err, SpaceQuotaResourceType))
+	}
+
+	return cfSpaceQuota, nil
+}
+
+func (r *SpaceQuotaRepo) getVisibility(ctx context.Context, authInfo authorization.Info) (quotaVisibility, error) {
+	authorizedOrgs, err := r.namespacePermissions.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return quotaVisibility{}, fmt.Errorf("failed to list namespaces for orgs with user role bindings: %w", err)
+	}
+
+	authorizedSpaces, err := r.namespacePermissions.GetAuthorizedSpaceNamespaces(ctx, authInfo)
+	if err != nil {
+		return quotaVisibility{}, fmt.Errorf("failed to list namespaces for spaces with user role bindings: %w", err)
+	}
+
+	return quotaVisibility{authorizedOrgs: authorizedOrgs, authorizedSpaces: authorizedSpaces}, nil
+}
+
+func cfSpaceQuotaToRecord(cfSpaceQuota *korifiv1alpha1.CFSpaceQuota, visibility quotaVisibility) SpaceQuotaRecord {
+	return SpaceQuotaRecord{
+		GUID:       cfSpaceQuota.Name,
+		Name:       cfSpaceQuota.Spec.DisplayName,
+		Limits:     toQuotaLimits(cfSpaceQuota.Spec.Limits),
+		OrgGUID:    cfSpaceQuota.Namespace,
+		SpaceGUIDs: visibility.visibleSpaces(cfSpaceQuota.Spec.Spaces),
+		CreatedAt:  cfSpaceQuota.CreationTimestamp.Time,
+		UpdatedAt:  getLastUpdatedTime(cfSpaceQuota),
+		DeletedAt:  golangTime(cfSpaceQuota.DeletionTimestamp),
+	}
+}
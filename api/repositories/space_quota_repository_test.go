@@ -0,0 +1,359 @@
+package repositories_test
+
+import (
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	"github.com/onsi/gomega/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("SpaceQuotaRepo", func() {
+	var (
+		repo         *SpaceQuotaRepo
+		org          *korifiv1alpha1.CFOrg
+		space        *korifiv1alpha1.CFSpace
+		otherSpace   *korifiv1alpha1.CFSpace
+		cfSpaceQuota *korifiv1alpha1.CFSpaceQuota
+	)
+
+	BeforeEach(func() {
+		repo = NewSpaceQuotaRepo(userClientFactory, namespaceRetriever, nsPerms, k8sClient, rootNamespace)
+
+		org = createOrgWithCleanup(ctx, prefixedGUID("org"))
+		space = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("space"))
+		otherSpace = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("other-space"))
+
+		cfSpaceQuota = &korifiv1alpha1.CFSpaceQuota{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      generateGUID(),
+				Namespace: org.Name,
+			},
+			Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+				DisplayName: prefixedGUID("existing-space-quota"),
+				Limits: korifiv1alpha1.QuotaLimits{
+					PerProcessMemoryInMB: tools.PtrTo[int64](512),
+				},
+				Spaces: []string{space.Name, otherSpace.Name},
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfSpaceQuota)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, cfSpaceQuota))).To(Succeed())
+	})
+
+	withGUID := func(guid string) types.GomegaMatcher {
+		return MatchFields(IgnoreExtras, Fields{"GUID": Equal(guid)})
+	}
+
+	Describe("CreateSpaceQuota", func() {
+		var (
+			message   CreateSpaceQuotaMessage
+			record    SpaceQuotaRecord
+			createErr error
+		)
+
+		BeforeEach(func() {
+			message = CreateSpaceQuotaMessage{
+				Name: prefixedGUID("my-space-quota"),
+				Limits: QuotaLimits{
+					Routes: QuotaRouteLimits{TotalRoutes: tools.PtrTo[int64](3)},
+				},
+				OrgGUID:    org.Name,
+				SpaceGUIDs: []string{space.Name},
+			}
+		})
+
+		JustBeforeEach(func() {
+			record, createErr = repo.CreateSpaceQuota(ctx, authInfo, message)
+		})
+
+		AfterEach(func() {
+			if record.GUID != "" {
+				Expect(client.IgnoreNotFound(k8sClient.Delete(ctx, &korifiv1alpha1.CFSpaceQuota{
+					ObjectMeta: metav1.ObjectMeta{Name: record.GUID, Namespace: org.Name},
+				}))).To(Succeed())
+			}
+		})
+
+		It("fails because the user has no role in the org", func() {
+			Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is an org manager", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgManagerRole.Name, org.Name)
+			})
+
+			It("creates a CFSpaceQuota in the org namespace", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(MatchRegexp("^[-0-9a-f]{36}$"), "record GUID was not a 36 character guid")
+				Expect(record.OrgGUID).To(Equal(org.Name))
+				Expect(record.SpaceGUIDs).To(ConsistOf(space.Name))
+
+				created := new(korifiv1alpha1.CFSpaceQuota)
+				Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: org.Name, Name: record.GUID}, created)).To(Succeed())
+				Expect(created.Spec.DisplayName).To(Equal(message.Name))
+				Expect(created.Spec.Limits).To(Equal(korifiv1alpha1.QuotaLimits{TotalRoutes: tools.PtrTo[int64](3)}))
+				Expect(created.Spec.Spaces).To(ConsistOf(space.Name))
+			})
+
+			It("removes the space from the quota that applied to it before", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpaceQuota), cfSpaceQuota)).To(Succeed())
+				Expect(cfSpaceQuota.Spec.Spaces).To(ConsistOf(otherSpace.Name))
+			})
+
+			When("a space is in another org", func() {
+				BeforeEach(func() {
+					otherOrg := createOrgWithCleanup(ctx, prefixedGUID("other-org"))
+					foreignSpace := createSpaceWithCleanup(ctx, otherOrg.Name, prefixedGUID("foreign-space"))
+					message.SpaceGUIDs = []string{foreignSpace.Name}
+				})
+
+				It("returns an unprocessable entity error", func() {
+					Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+				})
+			})
+		})
+	})
+
+	Describe("GetSpaceQuota", func() {
+		var (
+			record SpaceQuotaRecord
+			getErr error
+		)
+
+		JustBeforeEach(func() {
+			record, getErr = repo.GetSpaceQuota(ctx, authInfo, cfSpaceQuota.Name)
+		})
+
+		It("returns a forbidden error as the user has no role in the org", func() {
+			Expect(getErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+		})
+
+		When("the user is a space developer in one of the spaces", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, org.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the space quota with that space only", func() {
+				Expect(getErr).NotTo(HaveOccurred())
+				Expect(record.GUID).To(Equal(cfSpaceQuota.Name))
+				Expect(record.Name).To(Equal(cfSpaceQuota.Spec.DisplayName))
+				Expect(record.Limits.Apps.PerProcessMemoryInMB).To(PointTo(BeEquivalentTo(512)))
+				Expect(record.OrgGUID).To(Equal(org.Name))
+				Expect(record.SpaceGUIDs).To(ConsistOf(space.Name))
+			})
+		})
+	})
+
+	Describe("ListSpaceQuotas", func() {
+		var (
+			message ListSpaceQuotasMessage
+			records []SpaceQuotaRecord
+			listErr error
+		)
+
+		BeforeEach(func() {
+			message = ListSpaceQuotasMessage{}
+		})
+
+		JustBeforeEach(func() {
+			records, listErr = repo.ListSpaceQuotas(ctx, authInfo, message)
+		})
+
+		It("does not list space quotas of orgs the user has no role in", func() {
+			Expect(listErr).NotTo(HaveOccurred())
+			Expect(records).NotTo(ContainElement(withGUID(cfSpaceQuota.Name)))
+		})
+
+		When("the user is an org user", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, orgUserRole.Name, org.Name)
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, otherSpace.Name)
+			})
+
+			It("lists the space quotas of the org", func() {
+				Expect(listErr).NotTo(HaveOccurred())
+				Expect(records).To(ContainElement(withGUID(cfSpaceQuota.Name)))
+			})
+
+			When("filtering by a space the user has a role in", func() {
+				BeforeEach(func() {
+					message.SpaceGUIDs = []string{otherSpace.Name}
+				})
+
+				It("returns the quota applying to the space", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(ConsistOf(withGUID(cfSpaceQuota.Name)))
+				})
+			})
+
+			When("filtering by another org", func() {
+				BeforeEach(func() {
+					message.OrgGUIDs = []string{"some-other-org"}
+				})
+
+				It("filters out the space quota", func() {
+					Expect(listErr).NotTo(HaveOccurred())
+					Expect(records).To(BeEmpty())
+				})
+			})
+		})
+	})
+
+	Describe("UpdateSpaceQuota", func() {
+		var (
+			record    SpaceQuotaRecord
+			updateErr error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, orgManagerRole.Name, org.Name)
+		})
+
+		JustBeforeEach(func() {
+			record, updateErr = repo.UpdateSpaceQuota(ctx, authInfo, UpdateSpaceQuotaMessage{
+				GUID: cfSpaceQuota.Name,
+				Limits: UpdateQuotaLimits{
+					Services: &QuotaServiceLimits{TotalServiceInstances: tools.PtrTo[int64](1)},
+				},
+			})
+		})
+
+		It("updates the limits", func() {
+			Expect(updateErr).NotTo(HaveOccurred())
+			Expect(record.Name).To(Equal(cfSpaceQuota.Spec.DisplayName))
+			Expect(record.Limits).To(Equal(QuotaLimits{
+				Apps:     QuotaAppLimits{PerProcessMemoryInMB: tools.PtrTo[int64](512)},
+				Services: QuotaServiceLimits{TotalServiceInstances: tools.PtrTo[int64](1)},
+			}))
+		})
+	})
+
+	Describe("DeleteSpaceQuota", func() {
+		var deleteErr error
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, orgManagerRole.Name, org.Name)
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = repo.DeleteSpaceQuota(ctx, authInfo, cfSpaceQuota.Name)
+		})
+
+		It("deletes the CFSpaceQuota", func() {
+			Expect(deleteErr).NotTo(HaveOccurred())
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpaceQuota), &korifiv1alpha1.CFSpaceQuota{})
+			Expect(err).To(MatchError(ContainSubstring("not found")))
+		})
+	})
+
+	Describe("ApplySpaceQuota", func() {
+		var (
+			newSpace *korifiv1alpha1.CFSpace
+			record   SpaceQuotaRecord
+			applyErr error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, orgManagerRole.Name, org.Name)
+			newSpace = createSpaceWithCleanup(ctx, org.Name, prefixedGUID("new-space"))
+		})
+
+		JustBeforeEach(func() {
+			record, applyErr = repo.ApplySpaceQuota(ctx, authInfo, ApplySpaceQuotaMessage{
+				GUID:       cfSpaceQuota.Name,
+				SpaceGUIDs: []string{newSpace.Name},
+			})
+		})
+
+		It("applies the quota to the space", func() {
+			Expect(applyErr).NotTo(HaveOccurred())
+			Expect(record.SpaceGUIDs).To(ConsistOf(space.Name, otherSpace.Name, newSpace.Name))
+		})
+	})
+
+	Describe("RemoveSpaceQuota", func() {
+		var (
+			spaceGUID string
+			removeErr error
+		)
+
+		BeforeEach(func() {
+			createRoleBinding(ctx, userName, orgManagerRole.Name, org.Name)
+			spaceGUID = space.Name
+		})
+
+		JustBeforeEach(func() {
+			removeErr = repo.RemoveSpaceQuota(ctx, authInfo, RemoveSpaceQuotaMessage{
+				GUID:      cfSpaceQuota.Name,
+				SpaceGUID: spaceGUID,
+			})
+		})
+
+		It("removes the space from the quota", func() {
+			Expect(removeErr).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpaceQuota), cfSpaceQuota)).To(Succeed())
+			Expect(cfSpaceQuota.Spec.Spaces).To(ConsistOf(otherSpace.Name))
+		})
+
+		When("the quota is not applied to the space", func() {
+			BeforeEach(func() {
+				spaceGUID = "some-other-space"
+			})
+
+			It("returns an unprocessable entity error", func() {
+				Expect(removeErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			})
+		})
+	})
+
+	Describe("GetSpaceUsageSummary", func() {
+		var (
+			usage    UsageSummaryRecord
+			usageErr error
+		)
+
+		BeforeEach(func() {
+			app := createAppCR(ctx, k8sClient, "app", generateGUID(), space.Name, string(korifiv1alpha1.StartedState))
+			createProcessCR(ctx, k8sClient, generateGUID(), space.Name, app.Name)
+		})
+
+		JustBeforeEach(func() {
+			usage, usageErr = repo.GetSpaceUsageSummary(ctx, authInfo, space.Name)
+		})
+
+		It("returns a not found error as the user has no role in the space", func() {
+			Expect(usageErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+		})
+
+		When("the user is a space developer", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, spaceDeveloperRole.Name, space.Name)
+			})
+
+			It("returns the usage of the space", func() {
+				Expect(usageErr).NotTo(HaveOccurred())
+				Expect(usage).To(Equal(UsageSummaryRecord{
+					StartedInstances: 1,
+					MemoryInMB:       500,
+					Apps:             1,
+				}))
+			})
+		})
+	})
+})
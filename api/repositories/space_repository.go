@@ -21,6 +21,7 @@ import (
 const (
 	SpacePrefix       = "cf-space-"
 	SpaceResourceType = "Space"
+	SpaceFeatureSSH   = "ssh"
 )
 
 type CreateSpaceMessage struct {
@@ -45,10 +46,17 @@ type PatchSpaceMetadataMessage struct {
 	OrgGUID string
 }
 
+type PatchSpaceFeaturesMessage struct {
+	GUID       string
+	OrgGUID    string
+	SSHEnabled *bool
+}
+
 type SpaceRecord struct {
 	Name             string
 	GUID             string
 	OrganizationGUID string
+	SSHEnabled       bool
 	Labels           map[string]string
 	Annotations      map[string]string
 	CreatedAt        time.Time
@@ -240,6 +248,7 @@ func cfSpaceToSpaceRecord(cfSpace korifiv1alpha1.CFSpace) SpaceRecord {
 		Name:             cfSpace.Spec.DisplayName,
 		GUID:             cfSpace.Name,
 		OrganizationGUID: cfSpace.Namespace,
+		SSHEnabled:       cfSpace.SSHAllowed(),
 		Annotations:      cfSpace.Annotations,
 		Labels:           cfSpace.Labels,
 		CreatedAt:        cfSpace.CreationTimestamp.Time,
@@ -286,6 +295,30 @@ func (r *SpaceRepo) PatchSpaceMetadata(ctx context.Context, authInfo authorizati
 	return cfSpaceToSpaceRecord(*cfSpace), nil
 }
 
+func (r *SpaceRepo) PatchSpaceFeatures(ctx context.Context, authInfo authorization.Info, message PatchSpaceFeaturesMessage) (SpaceRecord, error) {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return SpaceRecord{}, fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	cfSpace := new(korifiv1alpha1.CFSpace)
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: message.OrgGUID, Name: message.GUID}, cfSpace)
+	if err != nil {
+		return SpaceRecord{}, fmt.Errorf("failed to get space: %w", apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	err = k8s.PatchResource(ctx, userClient, cfSpace, func() {
+		if message.SSHEnabled != nil {
+			cfSpace.Spec.AllowSSH = message.SSHEnabled
+		}
+	})
+	if err != nil {
+		return SpaceRecord{}, fmt.Errorf("failed to patch space features: %w", apierrors.FromK8sError(err, SpaceResourceType))
+	}
+
+	return cfSpaceToSpaceRecord(*cfSpace), nil
+}
+
 func (r *SpaceRepo) GetDeletedAt(ctx context.Context, authInfo authorization.Info, spaceGUID string) (*time.Time, error) {
 	space, err := r.GetSpace(ctx, authInfo, spaceGUID)
 	if err != nil {
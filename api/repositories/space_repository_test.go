@@ -10,6 +10,7 @@ import (
 	"code.cloudfoundry.org/korifi/api/repositories"
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -720,6 +721,70 @@ var _ = Describe("SpaceRepository", func() {
 		})
 	})
 
+	Describe("PatchSpaceFeatures", func() {
+		var (
+			cfOrg       *korifiv1alpha1.CFOrg
+			cfSpace     *korifiv1alpha1.CFSpace
+			message     repositories.PatchSpaceFeaturesMessage
+			spaceRecord repositories.SpaceRecord
+			patchErr    error
+		)
+
+		BeforeEach(func() {
+			cfOrg = createOrgWithCleanup(ctx, prefixedGUID("org"))
+			cfSpace = createSpaceWithCleanup(ctx, cfOrg.Name, "the-space")
+			message = repositories.PatchSpaceFeaturesMessage{
+				GUID:       cfSpace.Name,
+				OrgGUID:    cfOrg.Name,
+				SSHEnabled: tools.PtrTo(false),
+			}
+		})
+
+		JustBeforeEach(func() {
+			spaceRecord, patchErr = spaceRepo.PatchSpaceFeatures(ctx, authInfo, message)
+		})
+
+		It("defaults to ssh being allowed", func() {
+			Expect(cfSpace.Spec.AllowSSH).To(BeNil())
+			Expect(cfSpace.SSHAllowed()).To(BeTrue())
+		})
+
+		When("the user is authorized and the space exists", func() {
+			BeforeEach(func() {
+				createRoleBinding(ctx, userName, adminRole.Name, cfOrg.Name)
+			})
+
+			It("returns the updated space record", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				Expect(spaceRecord.GUID).To(Equal(cfSpace.Name))
+				Expect(spaceRecord.SSHEnabled).To(BeFalse())
+			})
+
+			It("updates the CFSpace", func() {
+				Expect(patchErr).NotTo(HaveOccurred())
+				updatedCFSpace := new(korifiv1alpha1.CFSpace)
+				Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(cfSpace), updatedCFSpace)).To(Succeed())
+				Expect(updatedCFSpace.Spec.AllowSSH).To(Equal(tools.PtrTo(false)))
+			})
+
+			When("the space does not exist", func() {
+				BeforeEach(func() {
+					message.GUID = "invalidSpaceGUID"
+				})
+
+				It("returns a not found error", func() {
+					Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotFoundError{}))
+				})
+			})
+		})
+
+		When("the user is not authorized", func() {
+			It("returns a forbidden error", func() {
+				Expect(patchErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.ForbiddenError{}))
+			})
+		})
+	})
+
 	Describe("GetDeletedAt", func() {
 		var (
 			cfSpace      *korifiv1alpha1.CFSpace
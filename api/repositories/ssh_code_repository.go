@@ -0,0 +1,150 @@
+package repositories
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=create,namespace=ROOT_NAMESPACE
+
+const (
+	SSHCodeLabelKey                = "korifi.cloudfoundry.org/ssh-code"
+	SSHCodeExpiresAtAnnotationKey  = "korifi.cloudfoundry.org/expires-at"
+	sshCodeSecretNamePrefix        = "ssh-code-"
+	sshCodeTokenKey                = "token"
+	sshCodeCertKey                 = "cert"
+	sshCodeTTL                     = 5 * time.Minute
+	sshCodeBytes                   = 32
+	invalidSSHCodeErrorDescription = "invalid or expired ssh code"
+)
+
+// SSHCodeRepo issues one-time codes that the SSH proxy exchanges for the
+// credentials of the user who requested them. Codes are stored as secrets in
+// the root namespace, named after the hash of the code.
+type SSHCodeRepo struct {
+	privilegedClient client.Client
+	rootNamespace    string
+}
+
+func NewSSHCodeRepo(privilegedClient client.Client, rootNamespace string) *SSHCodeRepo {
+	return &SSHCodeRepo{
+		privilegedClient: privilegedClient,
+		rootNamespace:    rootNamespace,
+	}
+}
+
+func (r *SSHCodeRepo) CreateSSHCode(ctx context.Context, authInfo authorization.Info) (string, error) {
+	data := map[string][]byte{}
+	switch authInfo.Scheme() {
+	case authorization.BearerScheme:
+		data[sshCodeTokenKey] = []byte(authInfo.Token)
+	case authorization.CertScheme:
+		data[sshCodeCertKey] = authInfo.CertData
+	default:
+		return "", apierrors.NewNotAuthenticatedError(errors.New("unsupported authorization scheme"))
+	}
+
+	codeBytes := make([]byte, sshCodeBytes)
+	if _, err := rand.Read(codeBytes); err != nil {
+		return "", fmt.Errorf("failed to generate ssh code: %w", err)
+	}
+	code := hex.EncodeToString(codeBytes)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      sshCodeSecretName(code),
+			Namespace: r.rootNamespace,
+			Labels: map[string]string{
+				SSHCodeLabelKey: "true",
+			},
+			Annotations: map[string]string{
+				SSHCodeExpiresAtAnnotationKey: time.Now().Add(sshCodeTTL).UTC().Format(time.RFC3339),
+			},
+		},
+		Data: data,
+	}
+
+	if err := r.privilegedClient.Create(ctx, secret); err != nil {
+		return "", fmt.Errorf("failed to create ssh code: %w", err)
+	}
+
+	return code, nil
+}
+
+// RedeemSSHCode returns the credentials stored for the code and deletes the
+// code, so that it cannot be used again
+func (r *SSHCodeRepo) RedeemSSHCode(ctx context.Context, code string) (authorization.Info, error) {
+	secret := new(corev1.Secret)
+	err := r.privilegedClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: sshCodeSecretName(code)}, secret)
+	if k8serrors.IsNotFound(err) {
+		return authorization.Info{}, apierrors.NewNotAuthenticatedError(errors.New(invalidSSHCodeErrorDescription))
+	}
+	if err != nil {
+		return authorization.Info{}, fmt.Errorf("failed to get ssh code: %w", err)
+	}
+
+	err = r.privilegedClient.Delete(ctx, secret, client.Preconditions{UID: &secret.UID})
+	if k8serrors.IsNotFound(err) || k8serrors.IsConflict(err) {
+		return authorization.Info{}, apierrors.NewNotAuthenticatedError(errors.New(invalidSSHCodeErrorDescription))
+	}
+	if err != nil {
+		return authorization.Info{}, fmt.Errorf("failed to delete ssh code: %w", err)
+	}
+
+	if isSSHCodeExpired(*secret) {
+		return authorization.Info{}, apierrors.NewNotAuthenticatedError(errors.New(invalidSSHCodeErrorDescription))
+	}
+
+	return authorization.Info{
+		Token:    string(secret.Data[sshCodeTokenKey]),
+		CertData: secret.Data[sshCodeCertKey],
+	}, nil
+}
+
+// DeleteExpiredSSHCodes deletes the codes that expired before being redeemed
+func (r *SSHCodeRepo) DeleteExpiredSSHCodes(ctx context.Context) error {
+	secrets := new(corev1.SecretList)
+	err := r.privilegedClient.List(ctx, secrets, client.InNamespace(r.rootNamespace), client.MatchingLabels{SSHCodeLabelKey: "true"})
+	if err != nil {
+		return fmt.Errorf("failed to list ssh codes: %w", err)
+	}
+
+	for i := range secrets.Items {
+		if !isSSHCodeExpired(secrets.Items[i]) {
+			continue
+		}
+
+		if err = r.privilegedClient.Delete(ctx, &secrets.Items[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete ssh code %q: %w", secrets.Items[i].Name, err)
+		}
+	}
+
+	return nil
+}
+
+func sshCodeSecretName(code string) string {
+	hash := sha256.Sum256([]byte(code))
+	return sshCodeSecretNamePrefix + hex.EncodeToString(hash[:])
+}
+
+func isSSHCodeExpired(secret corev1.Secret) bool {
+	expiresAt, err := time.Parse(time.RFC3339, secret.Annotations[SSHCodeExpiresAtAnnotationKey])
+	if err != nil {
+		return true
+	}
+
+	return time.Now().After(expiresAt)
+}
@@ -0,0 +1,173 @@
+package repositories_test
+
+import (
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("SSHCodeRepo", func() {
+	var (
+		repo         *SSHCodeRepo
+		codeAuthInfo authorization.Info
+	)
+
+	BeforeEach(func() {
+		repo = NewSSHCodeRepo(k8sClient, rootNamespace)
+		codeAuthInfo = authorization.Info{Token: "the-token"}
+	})
+
+	AfterEach(func() {
+		Expect(k8sClient.DeleteAllOf(ctx, &corev1.Secret{}, client.InNamespace(rootNamespace), client.MatchingLabels{SSHCodeLabelKey: "true"})).To(Succeed())
+	})
+
+	listCodeSecrets := func() []corev1.Secret {
+		secrets := new(corev1.SecretList)
+		Expect(k8sClient.List(ctx, secrets, client.InNamespace(rootNamespace), client.MatchingLabels{SSHCodeLabelKey: "true"})).To(Succeed())
+		return secrets.Items
+	}
+
+	expireCode := func(secret corev1.Secret) {
+		Expect(k8s.PatchResource(ctx, k8sClient, &secret, func() {
+			secret.Annotations[SSHCodeExpiresAtAnnotationKey] = time.Now().Add(-time.Minute).UTC().Format(time.RFC3339)
+		})).To(Succeed())
+	}
+
+	Describe("CreateSSHCode", func() {
+		var (
+			code      string
+			createErr error
+		)
+
+		JustBeforeEach(func() {
+			code, createErr = repo.CreateSSHCode(ctx, codeAuthInfo)
+		})
+
+		It("returns a code", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+			Expect(code).To(HaveLen(64))
+		})
+
+		It("stores the credentials without the code", func() {
+			Expect(createErr).NotTo(HaveOccurred())
+
+			secrets := listCodeSecrets()
+			Expect(secrets).To(HaveLen(1))
+			Expect(secrets[0].Name).NotTo(ContainSubstring(code))
+			Expect(secrets[0].Data).To(Equal(map[string][]byte{"token": []byte("the-token")}))
+			Expect(secrets[0].Annotations).To(HaveKey(SSHCodeExpiresAtAnnotationKey))
+		})
+
+		When("the user authenticates with a certificate", func() {
+			BeforeEach(func() {
+				codeAuthInfo = authorization.Info{CertData: []byte("the-cert")}
+			})
+
+			It("stores the certificate", func() {
+				Expect(createErr).NotTo(HaveOccurred())
+				Expect(listCodeSecrets()[0].Data).To(Equal(map[string][]byte{"cert": []byte("the-cert")}))
+			})
+		})
+
+		When("the auth info is empty", func() {
+			BeforeEach(func() {
+				codeAuthInfo = authorization.Info{}
+			})
+
+			It("returns a not authenticated error", func() {
+				Expect(createErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotAuthenticatedError{}))
+			})
+		})
+	})
+
+	Describe("RedeemSSHCode", func() {
+		var (
+			code             string
+			redeemedAuthInfo authorization.Info
+			redeemErr        error
+		)
+
+		BeforeEach(func() {
+			var err error
+			code, err = repo.CreateSSHCode(ctx, codeAuthInfo)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			redeemedAuthInfo, redeemErr = repo.RedeemSSHCode(ctx, code)
+		})
+
+		It("returns the credentials of the user", func() {
+			Expect(redeemErr).NotTo(HaveOccurred())
+			Expect(redeemedAuthInfo).To(Equal(authorization.Info{Token: "the-token"}))
+		})
+
+		It("deletes the code", func() {
+			Expect(redeemErr).NotTo(HaveOccurred())
+			Expect(listCodeSecrets()).To(BeEmpty())
+
+			_, err := repo.RedeemSSHCode(ctx, code)
+			Expect(err).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotAuthenticatedError{}))
+		})
+
+		When("the code does not exist", func() {
+			BeforeEach(func() {
+				code = "not-a-code"
+			})
+
+			It("returns a not authenticated error", func() {
+				Expect(redeemErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotAuthenticatedError{}))
+			})
+		})
+
+		When("the code has expired", func() {
+			BeforeEach(func() {
+				expireCode(listCodeSecrets()[0])
+			})
+
+			It("returns a not authenticated error", func() {
+				Expect(redeemErr).To(matchers.WrapErrorAssignableToTypeOf(apierrors.NotAuthenticatedError{}))
+			})
+
+			It("deletes the code", func() {
+				Expect(listCodeSecrets()).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("DeleteExpiredSSHCodes", func() {
+		var deleteErr error
+
+		BeforeEach(func() {
+			_, err := repo.CreateSSHCode(ctx, codeAuthInfo)
+			Expect(err).NotTo(HaveOccurred())
+			expireCode(listCodeSecrets()[0])
+
+			_, err = repo.CreateSSHCode(ctx, codeAuthInfo)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			deleteErr = repo.DeleteExpiredSSHCodes(ctx)
+		})
+
+		It("deletes only the expired codes", func() {
+			Expect(deleteErr).NotTo(HaveOccurred())
+
+			secrets := listCodeSecrets()
+			Expect(secrets).To(HaveLen(1))
+			expiresAt, err := time.Parse(time.RFC3339, secrets[0].Annotations[SSHCodeExpiresAtAnnotationKey])
+			Expect(err).NotTo(HaveOccurred())
+			Expect(expiresAt).To(BeTemporally(">", time.Now()))
+		})
+	})
+})
@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	"code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"github.com/google/uuid"
+)
+
+const (
+	StackResourceType = "Stack"
+)
+
+type StackRepository struct {
+	builderName       string
+	userClientFactory authorization.UserK8sClientFactory
+	rootNamespace     string
+	defaultStack      string
+}
+
+type StackRecord struct {
+	GUID             string
+	Name             string
+	Description      string
+	BuildRootFSImage string
+	RunRootFSImage   string
+	Default          bool
+	CreatedAt        time.Time
+	UpdatedAt        *time.Time
+}
+
+type ListStacksMessage struct {
+	Names []string
+}
+
+func NewStackRepository(builderName string, userClientFactory authorization.UserK8sClientFactory, rootNamespace string, defaultStack string) *StackRepository {
+	return &StackRepository{
+		builderName:       builderName,
+		userClientFactory: userClientFactory,
+		rootNamespace:     rootNamespace,
+		defaultStack:      defaultStack,
+	}
+}
+
+func (r *StackRepository) ListStacks(ctx context.Context, authInfo authorization.Info, message ListStacksMessage) ([]StackRecord, error) {
+	builderInfo, err := getReadyBuilderInfo(ctx, r.userClientFactory, authInfo, r.rootNamespace, r.builderName, StackResourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	return Filter(r.builderInfoToStackRecords(builderInfo),
+		SetPredicate(message.Names, func(s StackRecord) string { return s.Name }),
+	), nil
+}
+
+func (r *StackRepository) GetStack(ctx context.Context, authInfo authorization.Info, guid string) (StackRecord, error) {
+	stackRecords, err := r.ListStacks(ctx, authInfo, ListStacksMessage{})
+	if err != nil {
+		return StackRecord{}, err
+	}
+
+	for _, record := range stackRecords {
+		if record.GUID == guid {
+			return record, nil
+		}
+	}
+
+	return StackRecord{}, apierrors.NewNotFoundError(fmt.Errorf("stack %q not found", guid), StackResourceType)
+}
+
+// CheckStack succeeds for the stacks provided by the builder and for the
+// default stack apps are created with
+func (r *StackRepository) CheckStack(ctx context.Context, authInfo authorization.Info, name string) error {
+	if name == r.defaultStack {
+		return nil
+	}
+
+	stackRecords, err := r.ListStacks(ctx, authInfo, ListStacksMessage{Names: []string{name}})
+	if err != nil {
+		return err
+	}
+
+	if len(stackRecords) == 0 {
+		return apierrors.NewUnprocessableEntityError(
+			fmt.Errorf("stack %q not provided by the builder", name),
+			"Stack must be an existing stack",
+		)
+	}
+
+	return nil
+}
+
+func (r *StackRepository) builderInfoToStackRecords(info v1alpha1.BuilderInfo) []StackRecord {
+	hasDefaultStack := false
+	for _, s := range info.Status.Stacks {
+		if s.Name == r.defaultStack {
+			hasDefaultStack = true
+		}
+	}
+
+	stackRecords := make([]StackRecord, 0, len(info.Status.Stacks))
+	for i, s := range info.Status.Stacks {
+		stackRecords = append(stackRecords, StackRecord{
+			GUID:             uuid.NewSHA1(uuid.Nil, []byte(s.Name)).String(),
+			Name:             s.Name,
+			Description:      s.Description,
+			BuildRootFSImage: s.BuildImage,
+			RunRootFSImage:   s.RunImage,
+			// the first stack is the one of the builder default
+			Default:   s.Name == r.defaultStack || (!hasDefaultStack && i == 0),
+			CreatedAt: s.CreationTimestamp.Time,
+			UpdatedAt: &s.UpdatedTimestamp.Time,
+		})
+	}
+
+	return stackRecords
+}
@@ -0,0 +1,98 @@
+package repositories_test
+
+import (
+	"fmt"
+
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	. "code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("StackRepository", func() {
+	var stackRepo *StackRepository
+
+	BeforeEach(func() {
+		stackRepo = NewStackRepository(builderName, userClientFactory, rootNamespace, "cflinuxfs3")
+	})
+
+	When("the BuilderInfo reports stacks", func() {
+		BeforeEach(func() {
+			builderInfo := createBuilderInfoWithCleanup(ctx, builderName, "io.buildpacks.stacks.bionic", nil)
+			builderInfo.Status.Stacks[0].BuildImage = "bionic-build"
+			builderInfo.Status.Stacks[0].RunImage = "bionic-run"
+			builderInfo.Status.Stacks = append(builderInfo.Status.Stacks, v1alpha1.BuilderInfoStatusStack{
+				Name:              "io.buildpacks.stacks.jammy",
+				CreationTimestamp: builderInfo.Status.Stacks[0].CreationTimestamp,
+				UpdatedTimestamp:  builderInfo.Status.Stacks[0].UpdatedTimestamp,
+			})
+			Expect(k8sClient.Status().Update(ctx, builderInfo)).To(Succeed())
+		})
+
+		Describe("ListStacks", func() {
+			It("returns all stacks", func() {
+				stackRecords, err := stackRepo.ListStacks(ctx, authInfo, ListStacksMessage{})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stackRecords).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{
+						"Name":             Equal("io.buildpacks.stacks.bionic"),
+						"BuildRootFSImage": Equal("bionic-build"),
+						"RunRootFSImage":   Equal("bionic-run"),
+						"Default":          BeTrue(),
+					}),
+					MatchFields(IgnoreExtras, Fields{
+						"Name":    Equal("io.buildpacks.stacks.jammy"),
+						"Default": BeFalse(),
+					}),
+				))
+			})
+
+			It("filters the stacks by name", func() {
+				stackRecords, err := stackRepo.ListStacks(ctx, authInfo, ListStacksMessage{Names: []string{"io.buildpacks.stacks.jammy"}})
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stackRecords).To(ConsistOf(HaveField("Name", "io.buildpacks.stacks.jammy")))
+			})
+		})
+
+		Describe("GetStack", func() {
+			It("returns the stack with the guid", func() {
+				stackRecords, err := stackRepo.ListStacks(ctx, authInfo, ListStacksMessage{})
+				Expect(err).NotTo(HaveOccurred())
+
+				stackRecord, err := stackRepo.GetStack(ctx, authInfo, stackRecords[1].GUID)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(stackRecord).To(Equal(stackRecords[1]))
+			})
+
+			It("returns a not found error for unknown guids", func() {
+				_, err := stackRepo.GetStack(ctx, authInfo, "not-a-stack")
+				Expect(err).To(BeAssignableToTypeOf(apierrors.NotFoundError{}))
+			})
+		})
+
+		Describe("CheckStack", func() {
+			It("succeeds for stacks of the builder", func() {
+				Expect(stackRepo.CheckStack(ctx, authInfo, "io.buildpacks.stacks.jammy")).To(Succeed())
+			})
+
+			It("succeeds for the default stack", func() {
+				Expect(stackRepo.CheckStack(ctx, authInfo, "cflinuxfs3")).To(Succeed())
+			})
+
+			It("returns an unprocessable entity error for unknown stacks", func() {
+				err := stackRepo.CheckStack(ctx, authInfo, "not-a-stack")
+				Expect(err).To(BeAssignableToTypeOf(apierrors.UnprocessableEntityError{}))
+			})
+		})
+	})
+
+	When("the BuilderInfo does not exist", func() {
+		It("errors", func() {
+			_, err := stackRepo.ListStacks(ctx, authInfo, ListStacksMessage{})
+			Expect(err).To(MatchError(ContainSubstring(fmt.Sprintf("BuilderInfo %q not found in namespace %q", builderName, rootNamespace))))
+		})
+	})
+})
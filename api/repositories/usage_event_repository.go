@@ -0,0 +1,113 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+	apierrors "code.cloudfoundry.org/korifi/api/errors"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type UsageEventResourceRecord struct {
+	GUID string
+	Name string
+}
+
+// usageEventRepo holds what the app and the service usage event repositories
+// have in common. Usage events live in the root namespace.
+type usageEventRepo struct {
+	userClientFactory    authorization.UserK8sClientFactory
+	namespacePermissions *authorization.NamespacePermissions
+	rootNamespace        string
+}
+
+func (r *usageEventRepo) getUsageEvent(ctx context.Context, authInfo authorization.Info, guid string, usageEvent client.Object, resourceType string) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	err = userClient.Get(ctx, client.ObjectKey{Namespace: r.rootNamespace, Name: guid}, usageEvent)
+	if err != nil {
+		return fmt.Errorf("failed to get usage event %q: %w", guid, apierrors.FromK8sError(err, resourceType))
+	}
+
+	return nil
+}
+
+func (r *usageEventRepo) listUsageEvents(ctx context.Context, authInfo authorization.Info, usageEventList client.ObjectList, resourceType string) error {
+	userClient, err := r.userClientFactory.BuildClient(authInfo)
+	if err != nil {
+		return fmt.Errorf("failed to build user client: %w", err)
+	}
+
+	err = userClient.List(ctx, usageEventList, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		return fmt.Errorf("failed to list usage events: %w", apierrors.FromK8sError(err, resourceType))
+	}
+
+	return nil
+}
+
+// purgeUsageEvents deletes all the usage events of the given type and
+// returns the spaces the user is authorized to see, which carry the names
+// and organizations recorded in reseeded usage events
+func (r *usageEventRepo) purgeUsageEvents(ctx context.Context, authInfo authorization.Info, userClient client.Client, usageEvent client.Object, resourceType string) ([]korifiv1alpha1.CFSpace, error) {
+	err := userClient.DeleteAllOf(ctx, usageEvent, client.InNamespace(r.rootNamespace))
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge usage events: %w", apierrors.FromK8sError(err, resourceType))
+	}
+
+	orgNamespaces, err := r.namespacePermissions.GetAuthorizedOrgNamespaces(ctx, authInfo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces for orgs with user role bindings: %w", err)
+	}
+
+	cfSpaces := []korifiv1alpha1.CFSpace{}
+	for ns := range orgNamespaces {
+		cfSpaceList := new(korifiv1alpha1.CFSpaceList)
+		err = userClient.List(ctx, cfSpaceList, client.InNamespace(ns))
+		if k8serrors.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list spaces in namespace %s: %w", ns, apierrors.FromK8sError(err, SpaceResourceType))
+		}
+		cfSpaces = append(cfSpaces, cfSpaceList.Items...)
+	}
+
+	return cfSpaces, nil
+}
+
+// filterUsageEvents orders the usage events as they occurred, by name when
+// they occurred at the same time, and returns the ones following the
+// afterGUID event that match the guids and the predicates
+func filterUsageEvents[T any](
+	usageEvents []T,
+	getName func(T) string,
+	getTimestamp func(T) metav1.MicroTime,
+	afterGUID string,
+	guids []string,
+	predicates ...func(T) bool,
+) ([]T, error) {
+	sort.SliceStable(usageEvents, func(i, j int) bool {
+		timestampI, timestampJ := getTimestamp(usageEvents[i]), getTimestamp(usageEvents[j])
+		if !timestampI.Equal(&timestampJ) {
+			return timestampI.Before(&timestampJ)
+		}
+		return getName(usageEvents[i]) < getName(usageEvents[j])
+	})
+
+	usageEvents, err := After(usageEvents, afterGUID, getName)
+	if err != nil {
+		return nil, err
+	}
+
+	return Filter(usageEvents, append([]func(T) bool{SetPredicate(guids, getName)}, predicates...)...), nil
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
@@ -15,6 +16,7 @@ import (
 type Response struct {
 	httpStatus int
 	body       interface{}
+	stream     io.ReadCloser
 	headers    map[string][]string
 }
 
@@ -35,6 +37,13 @@ func (r *Response) WithBody(body interface{}) *Response {
 	return r
 }
 
+// WithStream sets a body that is copied as is into the response, such as a
+// file download. The stream is closed once written.
+func (r *Response) WithStream(stream io.ReadCloser) *Response {
+	r.stream = stream
+	return r
+}
+
 //counterfeiter:generate -o fake -fake-name Handler . Handler
 
 type Handler func(r *http.Request) (*Response, error)
@@ -86,6 +95,16 @@ func (response *Response) writeTo(w http.ResponseWriter) error {
 		}
 	}
 
+	if response.stream != nil {
+		defer response.stream.Close()
+
+		w.WriteHeader(response.httpStatus)
+		if _, err := io.Copy(w, response.stream); err != nil {
+			return fmt.Errorf("failed to write response stream: %w", err)
+		}
+		return nil
+	}
+
 	if response.body == nil {
 		w.WriteHeader(response.httpStatus)
 		return nil
@@ -2,7 +2,9 @@ package routing_test
 
 import (
 	"errors"
+	"io"
 	"net/http"
+	"strings"
 
 	apierrors "code.cloudfoundry.org/korifi/api/errors"
 	"code.cloudfoundry.org/korifi/api/routing"
@@ -59,6 +61,18 @@ var _ = Describe("Handler", func() {
 		})
 	})
 
+	When("the response has a stream", func() {
+		BeforeEach(func() {
+			response = response.WithHeader("Content-Type", "application/zip").WithStream(io.NopCloser(strings.NewReader("the-bits")))
+		})
+
+		It("copies the stream into the response", func() {
+			Expect(rr).To(HaveHTTPStatus(http.StatusTeapot))
+			Expect(rr).To(HaveHTTPHeaderWithValue("Content-Type", "application/zip"))
+			Expect(rr).To(HaveHTTPBody("the-bits"))
+		})
+	})
+
 	When("the response sets header values", func() {
 		BeforeEach(func() {
 			response = response.WithHeader("Location", "/home")
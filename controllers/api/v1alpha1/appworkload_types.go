@@ -52,6 +52,28 @@ type AppWorkloadSpec struct {
 
 	// +kubebuilder:validation:Optional
 	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// The node selector of the isolation segment the app instances are placed into
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// The tolerations of the isolation segment the app instances are placed into
+	// +kubebuilder:validation:Optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// The sidecars that run as additional containers next to each app instance
+	// +kubebuilder:validation:Optional
+	Sidecars []AppWorkloadSidecar `json:"sidecars,omitempty"`
+}
+
+// AppWorkloadSidecar defines a sidecar container of the app instances
+type AppWorkloadSidecar struct {
+	Name    string   `json:"name"`
+	Command []string `json:"command,omitempty"`
+
+	// The memory requested by the sidecar is taken out of the memory of the application container
+	// +kubebuilder:validation:Optional
+	Resources corev1.ResourceRequirements `json:"resources,omitempty"`
 }
 
 // AppWorkloadStatus defines the observed state of AppWorkload
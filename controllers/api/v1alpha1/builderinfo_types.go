@@ -35,8 +35,14 @@ type BuilderInfoStatus struct {
 }
 
 type BuilderInfoStatusStack struct {
-	Name              string      `json:"name"`
-	Description       string      `json:"description"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// The image the stack builds apps with
+	// +optional
+	BuildImage string `json:"buildImage,omitempty"`
+	// The image the stack runs apps with
+	// +optional
+	RunImage          string      `json:"runImage,omitempty"`
 	CreationTimestamp metav1.Time `json:"creationTimestamp"`
 	UpdatedTimestamp  metav1.Time `json:"updatedTimestamp"`
 }
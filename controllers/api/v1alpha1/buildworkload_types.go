@@ -37,6 +37,11 @@ type BuildWorkloadSpec struct {
 	// If no values are specified, then all available buildpacks will be used for auto-detection
 	Buildpacks []string `json:"buildpacks,omitempty"`
 
+	// The stack to build the app image on. Builders fall back to their
+	// default stack when the stack is not set or they do not provide it
+	// +optional
+	Stack string `json:"stack,omitempty"`
+
 	// The environment variables to set on the container that builds the image
 	Env []v1.EnvVar `json:"env,omitempty"`
 
@@ -45,6 +50,16 @@ type BuildWorkloadSpec struct {
 	// The name of the builder that should reconcile this BuildWorkload resource and execute the image building
 	// +kubebuilder:validation:Required
 	BuilderName string `json:"builderName"`
+
+	// The node selector of the isolation segment of the app space. Builders may
+	// choose to schedule their build pods with it
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// The tolerations of the isolation segment of the app space. Builders may
+	// choose to schedule their build pods with them
+	// +optional
+	Tolerations []v1.Toleration `json:"tolerations,omitempty"`
 }
 
 // BuildWorkloadStatus defines the observed state of BuildWorkload
@@ -44,6 +44,14 @@ type CFAppSpec struct {
 
 	// A reference to the CFBuild currently assigned to the app. The CFBuild must be in the same namespace.
 	CurrentDropletRef v1.LocalObjectReference `json:"currentDropletRef,omitempty"`
+
+	// Whether users can SSH into the instances of the app. SSH is enabled when unset
+	// +optional
+	EnableSSH *bool `json:"enableSSH,omitempty"`
+
+	// Whether a revision is recorded every time the app is deployed. Revisions are enabled when unset
+	// +optional
+	EnableRevisions *bool `json:"enableRevisions,omitempty"`
 }
 
 // DesiredState defines the desired state of CFApp.
@@ -58,6 +66,10 @@ type CFAppStatus struct {
 	//+kubebuilder:validation:Optional
 	ObservedDesiredState DesiredState `json:"observedDesiredState"`
 
+	// The state of the CFApp last recorded by the app usage events of its processes, i.e. `STARTED` or `STOPPED`
+	// +optional
+	UsageEventState string `json:"usageEventState,omitempty"`
+
 	// VCAPServicesSecretName contains the name of the CFApp's VCAP_SERVICES Secret, which should exist in the same namespace
 	//+kubebuilder:validation:Optional
 	VCAPServicesSecretName string `json:"vcapServicesSecretName"`
@@ -108,3 +120,11 @@ func (a CFApp) UniqueName() string {
 func (a CFApp) UniqueValidationErrorMessage() string {
 	return fmt.Sprintf("App with the name '%s' already exists.", a.Spec.DisplayName)
 }
+
+func (a CFApp) SSHEnabled() bool {
+	return a.Spec.EnableSSH == nil || *a.Spec.EnableSSH
+}
+
+func (a CFApp) RevisionsEnabled() bool {
+	return a.Spec.EnableRevisions == nil || *a.Spec.EnableRevisions
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	AppUsageEventStateStarted = "STARTED"
+	AppUsageEventStateStopped = "STOPPED"
+	AppUsageEventStateScaled  = "SCALED"
+)
+
+// UsageEventResource identifies a resource referenced by a usage event
+type UsageEventResource struct {
+	// The GUID of the resource
+	GUID string `json:"guid"`
+
+	// The name of the resource
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// CFAppUsageEventSpec defines the desired state of CFAppUsageEvent
+type CFAppUsageEventSpec struct {
+	// The state of the process after the event, i.e. `STARTED`, `STOPPED` or `SCALED`
+	State string `json:"state"`
+
+	// The state of the process before the event
+	// +optional
+	PreviousState string `json:"previousState,omitempty"`
+
+	// The app of the process
+	App UsageEventResource `json:"app"`
+
+	// The process, named after its type
+	Process UsageEventResource `json:"process"`
+
+	// The space of the app
+	Space UsageEventResource `json:"space"`
+
+	// The GUID of the organization of the app
+	// +optional
+	OrganizationGUID string `json:"organizationGUID,omitempty"`
+
+	// The number of instances of the process after the event
+	InstanceCount int32 `json:"instanceCount"`
+
+	// The number of instances of the process before the event
+	// +optional
+	PreviousInstanceCount int32 `json:"previousInstanceCount,omitempty"`
+
+	// The memory limit of each instance of the process after the event
+	MemoryInMBPerInstance int64 `json:"memoryInMBPerInstance"`
+
+	// The memory limit of each instance of the process before the event
+	// +optional
+	PreviousMemoryInMBPerInstance int64 `json:"previousMemoryInMBPerInstance,omitempty"`
+
+	// When the event occurred. Events are ordered by this timestamp, which is
+	// more precise than the creation timestamp
+	Timestamp metav1.MicroTime `json:"timestamp"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.spec.state`
+//+kubebuilder:printcolumn:name="App",type=string,JSONPath=`.spec.app.name`
+//+kubebuilder:printcolumn:name="Process",type=string,JSONPath=`.spec.process.name`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFAppUsageEvent is the Schema for the cfappusageevents API.
+// App usage events live in the root namespace, so that they outlive the
+// apps and spaces they refer to
+type CFAppUsageEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFAppUsageEventSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFAppUsageEventList contains a list of CFAppUsageEvent
+type CFAppUsageEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFAppUsageEvent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFAppUsageEvent{}, &CFAppUsageEventList{})
+}
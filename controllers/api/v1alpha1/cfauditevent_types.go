@@ -0,0 +1,102 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	CFAuditEventTypeLabelKey       = "korifi.cloudfoundry.org/audit-event-type"
+	CFAuditEventTargetGUIDLabelKey = "korifi.cloudfoundry.org/audit-event-target-guid"
+
+	AuditEventTypeAppCreate            = "audit.app.create"
+	AuditEventTypeAppStart             = "audit.app.start"
+	AuditEventTypeAppProcessCrash      = "audit.app.process.crash"
+	AuditEventTypeRouteMap             = "audit.route.map"
+	AuditEventTypeServiceBindingCreate = "audit.service_binding.create"
+	AuditEventActorTypeUser            = "user"
+	AuditEventActorTypeProcess         = "process"
+	AuditEventTargetTypeApp            = "app"
+	AuditEventTargetTypeRoute          = "route"
+	AuditEventTargetTypeServiceBinding = "service_binding"
+)
+
+// CFAuditEventParticipant identifies the actor or the target of an audit event
+type CFAuditEventParticipant struct {
+	// The GUID of the actor or target
+	GUID string `json:"guid"`
+
+	// The type of the actor or target, e.g. `user` or `app`
+	Type string `json:"type"`
+
+	// The name of the actor or target
+	// +optional
+	Name string `json:"name,omitempty"`
+}
+
+// CFAuditEventSpec defines the desired state of CFAuditEvent
+type CFAuditEventSpec struct {
+	// The type of the event, e.g. `audit.app.create`
+	Type string `json:"type"`
+
+	// The user or the process that caused the event
+	Actor CFAuditEventParticipant `json:"actor"`
+
+	// The resource the event is about
+	Target CFAuditEventParticipant `json:"target"`
+
+	// The GUID of the space of the target
+	// +optional
+	SpaceGUID string `json:"spaceGUID,omitempty"`
+
+	// The GUID of the organization of the target
+	// +optional
+	OrganizationGUID string `json:"organizationGUID,omitempty"`
+
+	// Additional information about the event
+	// +optional
+	Data map[string]string `json:"data,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
+//+kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.spec.target.guid`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFAuditEvent is the Schema for the cfauditevents API.
+// Audit events live in the namespace of the space of their target and are
+// deleted once they are older than the configured audit event TTL
+type CFAuditEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFAuditEventSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFAuditEventList contains a list of CFAuditEvent
+type CFAuditEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFAuditEvent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFAuditEvent{}, &CFAuditEventList{})
+}
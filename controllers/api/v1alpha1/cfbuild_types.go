@@ -23,7 +23,7 @@ import (
 
 // CFBuildSpec defines the desired state of CFBuild
 type CFBuildSpec struct {
-	// The CFPackage associated with this build. Must be in the same namespace
+	// The CFPackage associated with this build. Must be in the same namespace. Empty for builds with a droplet
 	PackageRef v1.LocalObjectReference `json:"packageRef"`
 	// The CFApp associated with this build. Must be in the same namespace
 	AppRef v1.LocalObjectReference `json:"appRef"`
@@ -35,6 +35,11 @@ type CFBuildSpec struct {
 
 	// Specifies the buildpacks and stack for the build
 	Lifecycle Lifecycle `json:"lifecycle"`
+
+	// A droplet that was not staged by Korifi, such as an uploaded or a copied droplet.
+	// When set, the package is not staged and the droplet is used as soon as its image is set
+	// +optional
+	Droplet *BuildDropletStatus `json:"droplet,omitempty"`
 }
 
 // CFBuildStatus defines the observed state of CFBuild
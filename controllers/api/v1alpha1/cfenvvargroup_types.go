@@ -0,0 +1,60 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	RunningEnvVarGroupName = "running"
+	StagingEnvVarGroupName = "staging"
+)
+
+// CFEnvVarGroupSpec defines the desired state of CFEnvVarGroup
+type CFEnvVarGroupSpec struct {
+	// The environment variables of the group
+	// +optional
+	Env map[string]string `json:"env,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFEnvVarGroup is the Schema for the cfenvvargroups API.
+// Environment variable groups live in the root namespace and are named either
+// `running` or `staging`. The running group is set on all app and task
+// workloads, the staging group on all build workloads
+type CFEnvVarGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFEnvVarGroupSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFEnvVarGroupList contains a list of CFEnvVarGroup
+type CFEnvVarGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFEnvVarGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFEnvVarGroup{}, &CFEnvVarGroupList{})
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CFFeatureFlagSpec defines the desired state of CFFeatureFlag
+type CFFeatureFlagSpec struct {
+	// Whether the feature is enabled
+	Enabled bool `json:"enabled"`
+
+	// The message returned to users when they try to use the disabled feature
+	// +optional
+	CustomErrorMessage string `json:"customErrorMessage,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Enabled",type=boolean,JSONPath=`.spec.enabled`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFFeatureFlag is the Schema for the cffeatureflags API.
+// Feature flags live in the root namespace and are named after the flag they
+// override. Flags without a CFFeatureFlag keep their default value
+type CFFeatureFlag struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFFeatureFlagSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFFeatureFlagList contains a list of CFFeatureFlag
+type CFFeatureFlagList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFFeatureFlag `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFFeatureFlag{}, &CFFeatureFlagList{})
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// IsolationSegmentLabelKey is the node label and taint key used by the
+	// default placement of isolation segments created via the API
+	IsolationSegmentLabelKey = "korifi.cloudfoundry.org/isolation-segment"
+)
+
+// CFIsolationSegmentSpec defines the desired state of CFIsolationSegment
+type CFIsolationSegmentSpec struct {
+	// The mutable, user-friendly name of the isolation segment. Unlike metadata.name, the user can change this field
+	DisplayName string `json:"displayName"`
+
+	// The node selector of the app, task and staging pods placed into the isolation segment
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// The tolerations of the app, task and staging pods placed into the isolation segment
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Display Name",type=string,JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFIsolationSegment is the Schema for the cfisolationsegments API.
+// Orgs are entitled to isolation segments via their spec, and the workloads of
+// the spaces assigned to an isolation segment are scheduled with its node
+// selector and tolerations
+type CFIsolationSegment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFIsolationSegmentSpec `json:"spec,omitempty"`
+}
+
+func (s CFIsolationSegment) UniqueName() string {
+	return strings.ToLower(s.Spec.DisplayName)
+}
+
+func (s CFIsolationSegment) UniqueValidationErrorMessage() string {
+	return fmt.Sprintf("Isolation Segment names are case insensitive and must be unique: '%s'", s.Spec.DisplayName)
+}
+
+//+kubebuilder:object:root=true
+
+// CFIsolationSegmentList contains a list of CFIsolationSegment
+type CFIsolationSegmentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFIsolationSegment `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFIsolationSegment{}, &CFIsolationSegmentList{})
+}
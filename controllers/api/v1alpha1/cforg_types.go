@@ -36,6 +36,10 @@ type CFOrgSpec struct {
 	// The mutable, user-friendly name of the CFOrg. Unlike metadata.name, the user can change this field.
 	// +kubebuilder:validation:Pattern="^[[:alnum:][:punct:][:print:]]+$"
 	DisplayName string `json:"displayName"`
+
+	// The GUIDs of the isolation segments the spaces of the org can be assigned to
+	// +optional
+	IsolationSegments []string `json:"isolationSegments,omitempty"`
 }
 
 // CFOrgStatus defines the observed state of CFOrg
@@ -0,0 +1,102 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// QuotaLimits are the limits enforced by a quota. A nil limit means unlimited
+type QuotaLimits struct {
+	// The maximum memory of all started processes and running tasks
+	// +optional
+	TotalMemoryInMB *int64 `json:"totalMemoryInMB,omitempty"`
+
+	// The maximum memory of a single process instance or task
+	// +optional
+	PerProcessMemoryInMB *int64 `json:"perProcessMemoryInMB,omitempty"`
+
+	// The maximum number of instances of all started processes
+	// +optional
+	TotalInstances *int64 `json:"totalInstances,omitempty"`
+
+	// The maximum number of tasks running concurrently for a single app
+	// +optional
+	PerAppTasks *int64 `json:"perAppTasks,omitempty"`
+
+	// The maximum number of apps
+	// +optional
+	TotalApps *int64 `json:"totalApps,omitempty"`
+
+	// The maximum number of managed service instances
+	// +optional
+	TotalServiceInstances *int64 `json:"totalServiceInstances,omitempty"`
+
+	// The maximum number of routes
+	// +optional
+	TotalRoutes *int64 `json:"totalRoutes,omitempty"`
+}
+
+// CFOrgQuotaSpec defines the desired state of CFOrgQuota
+type CFOrgQuotaSpec struct {
+	// The mutable, user-friendly name of the quota. Unlike metadata.name, the user can change this field
+	DisplayName string `json:"displayName"`
+
+	// +optional
+	Limits QuotaLimits `json:"limits,omitempty"`
+
+	// The GUIDs of the orgs the quota applies to
+	// +optional
+	Orgs []string `json:"orgs,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Display Name",type=string,JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFOrgQuota is the Schema for the cforgquotas API.
+// Its limits are enforced by the validating webhooks of the resources
+// consuming them, across all spaces of the orgs the quota applies to
+type CFOrgQuota struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFOrgQuotaSpec `json:"spec,omitempty"`
+}
+
+func (q CFOrgQuota) UniqueName() string {
+	return q.Spec.DisplayName
+}
+
+func (q CFOrgQuota) UniqueValidationErrorMessage() string {
+	return fmt.Sprintf("Organization Quota '%s' already exists.", q.Spec.DisplayName)
+}
+
+//+kubebuilder:object:root=true
+
+// CFOrgQuotaList contains a list of CFOrgQuota
+type CFOrgQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFOrgQuota `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFOrgQuota{}, &CFOrgQuotaList{})
+}
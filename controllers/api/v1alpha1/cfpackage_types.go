@@ -27,7 +27,8 @@ const (
 
 // CFPackageSpec defines the desired state of CFPackage
 type CFPackageSpec struct {
-	// The package type. Only "bits" is currently allowed.
+	// The package type. Only "bits" and "docker" are currently allowed.
+	// The source image of a docker package is the image the app runs.
 	Type PackageType `json:"type"`
 
 	// Reference the CFApp that owns this package. The CFApp must be in the same namespace.
@@ -38,7 +39,7 @@ type CFPackageSpec struct {
 }
 
 // PackageType used to enum the inputs to package.type
-// +kubebuilder:validation:Enum=bits
+// +kubebuilder:validation:Enum=bits;docker
 type PackageType string
 
 type PackageSource struct {
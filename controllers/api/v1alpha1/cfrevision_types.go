@@ -0,0 +1,91 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	CFRevisionVersionLabelKey = "korifi.cloudfoundry.org/revision-version"
+)
+
+// CFRevisionProcess records the command of a process type of the app
+type CFRevisionProcess struct {
+	// The process type, e.g. `web`
+	Type string `json:"type"`
+	// The command the process was run with
+	// +optional
+	Command string `json:"command,omitempty"`
+	// Whether the command was set by the user rather than detected from the droplet
+	// +optional
+	UserProvided bool `json:"userProvided,omitempty"`
+}
+
+// CFRevisionSpec defines the desired state of CFRevision
+type CFRevisionSpec struct {
+	// A reference to the CFApp the revision belongs to
+	AppRef corev1.LocalObjectReference `json:"appRef"`
+
+	// The version of the revision, matching the revision annotation of the CFApp when it was deployed
+	Version int `json:"version"`
+
+	// A reference to the droplet that was deployed
+	DropletRef corev1.LocalObjectReference `json:"dropletRef"`
+
+	// The commands of the processes of the app when it was deployed
+	// +optional
+	Processes []CFRevisionProcess `json:"processes,omitempty"`
+
+	// The name of the Secret holding a snapshot of the environment variables of the app when it was deployed
+	// +optional
+	EnvSecretName string `json:"envSecretName,omitempty"`
+
+	// A short description of what changed in the revision
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="App",type=string,JSONPath=`.spec.appRef.name`
+//+kubebuilder:printcolumn:name="Version",type=integer,JSONPath=`.spec.version`
+//+kubebuilder:printcolumn:name="Droplet",type=string,JSONPath=`.spec.dropletRef.name`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFRevision is the Schema for the cfrevisions API.
+// A revision is a snapshot of the droplet, process commands and environment
+// variables of an app, recorded every time the app is deployed
+type CFRevision struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFRevisionSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFRevisionList contains a list of CFRevision
+type CFRevisionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFRevision `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFRevision{}, &CFRevisionList{})
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	CFSecurityGroupFinalizerName = "cfSecurityGroup.korifi.cloudfoundry.org"
+
+	ProtocolTCP  = "tcp"
+	ProtocolUDP  = "udp"
+	ProtocolICMP = "icmp"
+	ProtocolAll  = "all"
+)
+
+// SecurityGroupRule describes the egress traffic allowed by a CFSecurityGroup
+type SecurityGroupRule struct {
+	// The protocol of the allowed traffic
+	// +kubebuilder:validation:Enum=tcp;udp;icmp;all
+	Protocol string `json:"protocol"`
+
+	// The destinations of the allowed traffic. A single IP address, a CIDR, an IP address range
+	// (e.g. `10.0.0.1-10.0.0.5`) or a comma separated list of those
+	Destination string `json:"destination"`
+
+	// The destination ports of the allowed traffic. A single port, a port range (e.g. `8000-9000`)
+	// or a comma separated list of those. Only used by `tcp` and `udp` rules
+	// +optional
+	Ports string `json:"ports,omitempty"`
+
+	// The ICMP type. Only used by `icmp` rules
+	// +optional
+	Type *int32 `json:"type,omitempty"`
+
+	// The ICMP code. Only used by `icmp` rules
+	// +optional
+	Code *int32 `json:"code,omitempty"`
+
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// +optional
+	Log bool `json:"log,omitempty"`
+}
+
+// SecurityGroupWorkloads selects the workloads a CFSecurityGroup applies to
+type SecurityGroupWorkloads struct {
+	// Apply the security group to running app instances
+	// +optional
+	Running bool `json:"running,omitempty"`
+
+	// Apply the security group to app staging
+	// +optional
+	Staging bool `json:"staging,omitempty"`
+}
+
+// CFSecurityGroupSpec defines the desired state of CFSecurityGroup
+type CFSecurityGroupSpec struct {
+	// The mutable, user-friendly name of the security group. Unlike metadata.name, the user can change this field
+	DisplayName string `json:"displayName"`
+
+	// The egress rules of the security group
+	// +optional
+	Rules []SecurityGroupRule `json:"rules,omitempty"`
+
+	// The workloads the security group applies to in every space
+	// +optional
+	GloballyEnabled SecurityGroupWorkloads `json:"globallyEnabled,omitempty"`
+
+	// The workloads the security group applies to in individual spaces, keyed by space GUID
+	// +optional
+	Spaces map[string]SecurityGroupWorkloads `json:"spaces,omitempty"`
+}
+
+// CFSecurityGroupStatus defines the observed state of CFSecurityGroup
+type CFSecurityGroupStatus struct {
+	//+kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration captures the latest generation of the CFSecurityGroup that has been reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Display Name",type=string,JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFSecurityGroup is the Schema for the cfsecuritygroups API.
+// Its rules are rendered into NetworkPolicies allowing egress traffic from the
+// app and staging pods in the spaces the security group is bound to
+type CFSecurityGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CFSecurityGroupSpec   `json:"spec,omitempty"`
+	Status CFSecurityGroupStatus `json:"status,omitempty"`
+}
+
+func (g CFSecurityGroup) UniqueName() string {
+	return g.Spec.DisplayName
+}
+
+func (g CFSecurityGroup) UniqueValidationErrorMessage() string {
+	return fmt.Sprintf("Security group with name '%s' already exists.", g.Spec.DisplayName)
+}
+
+//+kubebuilder:object:root=true
+
+// CFSecurityGroupList contains a list of CFSecurityGroup
+type CFSecurityGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFSecurityGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFSecurityGroup{}, &CFSecurityGroupList{})
+}
@@ -23,6 +23,17 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+const (
+	CFServiceBindingFinalizerName = "cfServiceBinding.korifi.cloudfoundry.org"
+
+	AppBindingType = "app"
+	KeyBindingType = "key"
+
+	// CredentialsSecretKey is the key of the credentials secret entry holding
+	// the credentials returned by the broker as JSON
+	CredentialsSecretKey = "credentials"
+)
+
 // CFServiceBindingSpec defines the desired state of CFServiceBinding
 type CFServiceBindingSpec struct {
 	// The mutable, user-friendly name of the service binding. Unlike metadata.name, the user can change this field
@@ -31,17 +42,35 @@ type CFServiceBindingSpec struct {
 	// The Service this binding uses. When created by the korifi API, this will refer to a CFServiceInstance
 	Service v1.ObjectReference `json:"service"`
 
-	// A reference to the CFApp that owns this service binding. The CFApp must be in the same namespace
-	AppRef v1.LocalObjectReference `json:"appRef"`
+	// Type of the binding. Must be `app` or `key`. Defaults to `app`
+	// +kubebuilder:default=app
+	// +optional
+	Type BindingType `json:"type,omitempty"`
+
+	// A reference to the CFApp that owns this service binding. The CFApp must be in the same namespace.
+	// Only set for bindings of type `app`
+	// +optional
+	AppRef v1.LocalObjectReference `json:"appRef,omitempty"`
 }
 
+// BindingType defines the type of the Service Binding
+// +kubebuilder:validation:Enum=app;key
+type BindingType string
+
 // CFServiceBindingStatus defines the observed state of CFServiceBinding
 type CFServiceBindingStatus struct {
 	// A reference to the Secret containing the credentials.
-	// This is required to conform to the Kubernetes Service Bindings spec
+	// This is required to conform to the Kubernetes Service Bindings spec.
+	// For bindings to user-provided service instances this is the secret of the instance,
+	// for bindings to managed service instances it holds the credentials returned by the broker
 	// +optional
 	Binding v1.LocalObjectReference `json:"binding"`
 
+	// A reference to the Secret holding the credentials returned by the broker as JSON, under the `credentials` key.
+	// Only set for bindings to managed service instances
+	// +optional
+	Credentials v1.LocalObjectReference `json:"credentials"`
+
 	//+kubebuilder:validation:Optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
@@ -77,14 +106,41 @@ func (b CFServiceBinding) StatusConditions() []metav1.Condition {
 	return b.Status.Conditions
 }
 
+// IsKey returns true for service keys, i.e. bindings that are not bound to an app
+func (b CFServiceBinding) IsKey() bool {
+	return b.Spec.Type == KeyBindingType
+}
+
+// ServiceNamespace returns the namespace of the bound service instance. Bindings to
+// service instances shared from other spaces reference them by namespace
+func (b CFServiceBinding) ServiceNamespace() string {
+	if b.Spec.Service.Namespace != "" {
+		return b.Spec.Service.Namespace
+	}
+	return b.Namespace
+}
+
 func (b CFServiceBinding) UniqueName() string {
+	if b.IsKey() {
+		return fmt.Sprintf("sk::%s::%s::%s", b.Spec.Service.Namespace, b.Spec.Service.Name, displayNameOrEmpty(b.Spec.DisplayName))
+	}
 	return fmt.Sprintf("sb::%s::%s::%s", b.Spec.AppRef.Name, b.Spec.Service.Namespace, b.Spec.Service.Name)
 }
 
 func (b CFServiceBinding) UniqueValidationErrorMessage() string {
+	if b.IsKey() {
+		return fmt.Sprintf("Service key already exists: Name: %s Service Instance: %s", displayNameOrEmpty(b.Spec.DisplayName), b.Spec.Service.Name)
+	}
 	return fmt.Sprintf("Service binding already exists: App: %s Service Instance: %s", b.Spec.AppRef.Name, b.Spec.Service.Name)
 }
 
+func displayNameOrEmpty(name *string) string {
+	if name == nil {
+		return ""
+	}
+	return *name
+}
+
 func init() {
 	SchemeBuilder.Register(&CFServiceBinding{}, &CFServiceBindingList{})
 }
@@ -0,0 +1,88 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	CFServiceBrokerUsernameKey = "username"
+	CFServiceBrokerPasswordKey = "password"
+)
+
+// CFServiceBrokerSpec defines the desired state of CFServiceBroker
+type CFServiceBrokerSpec struct {
+	// The mutable, user-friendly name of the service broker. Unlike metadata.name, the user can change this field
+	Name string `json:"name"`
+
+	// The URL of the service broker. It must expose the Open Service Broker API
+	URL string `json:"url"`
+
+	// A reference to a Secret in the same namespace holding the basic auth credentials of the broker.
+	// The Secret must contain the `username` and `password` keys
+	Credentials corev1.LocalObjectReference `json:"credentials"`
+}
+
+// CFServiceBrokerStatus defines the observed state of CFServiceBroker
+type CFServiceBrokerStatus struct {
+	//+kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration captures the latest generation of the CFServiceBroker that has been reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Name",type=string,JSONPath=`.spec.name`
+//+kubebuilder:printcolumn:name="URL",type=string,JSONPath=`.spec.url`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFServiceBroker is the Schema for the cfservicebrokers API
+type CFServiceBroker struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CFServiceBrokerSpec   `json:"spec,omitempty"`
+	Status CFServiceBrokerStatus `json:"status,omitempty"`
+}
+
+func (b CFServiceBroker) UniqueName() string {
+	return b.Spec.Name
+}
+
+func (b CFServiceBroker) UniqueValidationErrorMessage() string {
+	// Note: the cf cli expects the specific text 'Name must be unique'
+	return fmt.Sprintf("Name must be unique: %s", b.Spec.Name)
+}
+
+//+kubebuilder:object:root=true
+
+// CFServiceBrokerList contains a list of CFServiceBroker
+type CFServiceBrokerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFServiceBroker `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFServiceBroker{}, &CFServiceBrokerList{})
+}
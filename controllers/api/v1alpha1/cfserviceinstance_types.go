@@ -21,10 +21,22 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 const (
 	UserProvidedType = "user-provided"
+	ManagedType      = "managed"
+
+	CFServiceInstanceFinalizerName = "cfServiceInstance.korifi.cloudfoundry.org"
+
+	CreateLastOperationType = "create"
+	UpdateLastOperationType = "update"
+	DeleteLastOperationType = "delete"
+
+	InProgressLastOperationState = "in progress"
+	SucceededLastOperationState  = "succeeded"
+	FailedLastOperationState     = "failed"
 )
 
 // CFServiceInstanceSpec defines the desired state of CFServiceInstance
@@ -32,12 +44,34 @@ type CFServiceInstanceSpec struct {
 	// The mutable, user-friendly name of the service instance. Unlike metadata.name, the user can change this field
 	DisplayName string `json:"displayName"`
 
-	// Name of a secret containing the service credentials. The Secret must be in the same namespace
-	SecretName string `json:"secretName"`
+	// Name of a secret containing the service credentials. The Secret must be in the same namespace.
+	// Only used by `user-provided` service instances
+	// +optional
+	SecretName string `json:"secretName,omitempty"`
 
-	// Type of the Service Instance. Must be `user-provided`
+	// Type of the Service Instance. Must be `user-provided` or `managed`
 	Type InstanceType `json:"type"`
 
+	// The GUID of the CFServicePlan a `managed` service instance is provisioned from.
+	// The plan must be in the root namespace
+	// +optional
+	PlanGUID string `json:"planGuid,omitempty"`
+
+	// Arbitrary parameters passed to the broker when provisioning or updating a `managed` service instance
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Parameters *runtime.RawExtension `json:"parameters,omitempty"`
+
+	// The URL of a route service traffic to bound routes is forwarded to.
+	// Only used by `user-provided` service instances
+	// +optional
+	RouteServiceURL *string `json:"routeServiceURL,omitempty"`
+
+	// The URL of a syslog drain the logs of bound apps are streamed to. It is exposed to bound apps in VCAP_SERVICES.
+	// Only used by `user-provided` service instances
+	// +optional
+	SyslogDrainURL *string `json:"syslogDrainURL,omitempty"`
+
 	// Service label to use when adding this instance to VCAP_Services
 	// Defaults to `user-provided` when this field is not set
 	// +optional
@@ -45,12 +79,33 @@ type CFServiceInstanceSpec struct {
 
 	// Tags are used by apps to identify service instances
 	Tags []string `json:"tags,omitempty"`
+
+	// GUIDs of the spaces the service instance is shared with. Apps in these spaces can bind to the instance.
+	// Only used by `managed` service instances
+	// +optional
+	SharedSpaces []string `json:"sharedSpaces,omitempty"`
 }
 
 // InstanceType defines the type of the Service Instance
-// +kubebuilder:validation:Enum=user-provided
+// +kubebuilder:validation:Enum=user-provided;managed
 type InstanceType string
 
+// LastOperation describes the last broker operation performed on a `managed` service instance
+type LastOperation struct {
+	// +kubebuilder:validation:Enum=create;update;delete
+	Type string `json:"type"`
+
+	// +kubebuilder:validation:Enum="in progress";succeeded;failed
+	State string `json:"state"`
+
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// The operation token returned by the broker for asynchronous operations
+	// +optional
+	Operation string `json:"operation,omitempty"`
+}
+
 // CFServiceInstanceStatus defines the observed state of CFServiceInstance
 type CFServiceInstanceStatus struct {
 	// A reference to the Secret containing the credentials (same as spec.secretName).
@@ -61,6 +116,28 @@ type CFServiceInstanceStatus struct {
 	//+kubebuilder:validation:Optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
+	// The last broker operation performed on a `managed` service instance
+	// +optional
+	LastOperation *LastOperation `json:"lastOperation,omitempty"`
+
+	// The generation of the CFServiceInstance the last broker operation was started for. Changes made while
+	// the operation is in progress are sent to the broker once it completes
+	// +optional
+	OperationGeneration int64 `json:"operationGeneration,omitempty"`
+
+	// The GUID of the plan used by the last broker operation performed on a `managed` service instance
+	// +optional
+	PlanGUID string `json:"planGuid,omitempty"`
+
+	// A hash of the spec fields sent to the broker by the last operation performed on a `managed` service instance.
+	// Changes to other fields, such as the shared spaces, do not result in broker requests
+	// +optional
+	BrokerSpecHash string `json:"brokerSpecHash,omitempty"`
+
+	// The state of the service instance last recorded by a service usage event, i.e. `CREATED` or `DELETED`
+	// +optional
+	UsageEventState string `json:"usageEventState,omitempty"`
+
 	// ObservedGeneration captures the latest generation of the CFServiceInstance that has been reconciled
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
@@ -68,6 +145,7 @@ type CFServiceInstanceStatus struct {
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="Display Name",type=string,JSONPath=`.spec.displayName`
+//+kubebuilder:printcolumn:name="Type",type=string,JSONPath=`.spec.type`
 //+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
 
 // CFServiceInstance is the Schema for the cfserviceinstances API
@@ -0,0 +1,96 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// CFServiceOfferingSpec defines the desired state of CFServiceOffering.
+// It mirrors a service entry of the broker catalog and is maintained by the CFServiceBroker controller
+type CFServiceOfferingSpec struct {
+	// The name of the offering as advertised by the broker catalog
+	Name string `json:"name"`
+
+	// A short description of the offering
+	Description string `json:"description"`
+
+	// Tags are used by apps to identify service instances of this offering
+	Tags []string `json:"tags,omitempty"`
+
+	// Permissions the user has to grant to the platform, e.g. `route_forwarding` or `syslog_drain`
+	Requires []string `json:"requires,omitempty"`
+
+	// A URL pointing to the documentation of the offering
+	// +optional
+	DocumentationURL *string `json:"documentationURL,omitempty"`
+
+	// Whether service instances of this offering can be bound to apps
+	Bindable bool `json:"bindable"`
+
+	// Whether the offering supports switching plans of existing service instances
+	PlanUpdateable bool `json:"planUpdateable"`
+
+	// Whether the broker supports fetching service instances
+	InstancesRetrievable bool `json:"instancesRetrievable"`
+
+	// Whether the broker supports fetching service bindings
+	BindingsRetrievable bool `json:"bindingsRetrievable"`
+
+	// Whether the broker supports updating the context of service instances
+	AllowContextUpdates bool `json:"allowContextUpdates"`
+
+	BrokerCatalog ServiceBrokerCatalog `json:"brokerCatalog"`
+}
+
+// ServiceBrokerCatalog holds the catalog specific details of an offering or a plan
+type ServiceBrokerCatalog struct {
+	// The identifier of the offering or plan in the broker catalog
+	ID string `json:"id"`
+
+	// Opaque metadata as returned by the broker
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Metadata *runtime.RawExtension `json:"metadata,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Name",type=string,JSONPath=`.spec.name`
+//+kubebuilder:printcolumn:name="Broker",type=string,JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/service-broker-guid`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFServiceOffering is the Schema for the cfserviceofferings API
+type CFServiceOffering struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFServiceOfferingSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFServiceOfferingList contains a list of CFServiceOffering
+type CFServiceOfferingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFServiceOffering `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFServiceOffering{}, &CFServiceOfferingList{})
+}
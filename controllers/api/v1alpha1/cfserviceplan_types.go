@@ -0,0 +1,116 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+const (
+	AdminServicePlanVisibilityType        = "admin"
+	PublicServicePlanVisibilityType       = "public"
+	OrganizationServicePlanVisibilityType = "organization"
+)
+
+// CFServicePlanSpec defines the desired state of CFServicePlan.
+// It mirrors a plan entry of the broker catalog and is maintained by the CFServiceBroker controller
+type CFServicePlanSpec struct {
+	// The name of the plan as advertised by the broker catalog
+	Name string `json:"name"`
+
+	// A short description of the plan
+	Description string `json:"description"`
+
+	// Whether the plan is free of charge
+	Free bool `json:"free"`
+
+	// Whether service instances of this plan can be bound to apps.
+	// When not set, the value of the offering is used
+	// +optional
+	Bindable *bool `json:"bindable,omitempty"`
+
+	// Whether service instances can be updated to and from this plan.
+	// When not set, the value of the offering is used
+	// +optional
+	PlanUpdateable *bool `json:"planUpdateable,omitempty"`
+
+	BrokerCatalog ServiceBrokerCatalog `json:"brokerCatalog"`
+
+	Schemas ServicePlanSchemas `json:"schemas"`
+
+	// Who is allowed to see and use the plan. Plans are only visible to
+	// admins until they are made available via the visibility endpoints
+	Visibility ServicePlanVisibility `json:"visibility"`
+}
+
+type ServicePlanVisibility struct {
+	// +kubebuilder:validation:Enum=admin;public;organization
+	Type string `json:"type"`
+
+	// The GUIDs of the orgs the plan is available in. Only relevant for the `organization` visibility type
+	// +optional
+	Organizations []string `json:"organizations,omitempty"`
+}
+
+// ServicePlanSchemas holds the JSON schemas of the configuration parameters accepted by the broker
+type ServicePlanSchemas struct {
+	ServiceInstance ServiceInstanceSchema `json:"serviceInstance"`
+	ServiceBinding  ServiceBindingSchema  `json:"serviceBinding"`
+}
+
+type ServiceInstanceSchema struct {
+	Create InputParametersSchema `json:"create"`
+	Update InputParametersSchema `json:"update"`
+}
+
+type ServiceBindingSchema struct {
+	Create InputParametersSchema `json:"create"`
+}
+
+type InputParametersSchema struct {
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +optional
+	Parameters *runtime.RawExtension `json:"parameters,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="Name",type=string,JSONPath=`.spec.name`
+//+kubebuilder:printcolumn:name="Offering",type=string,JSONPath=`.metadata.labels.korifi\.cloudfoundry\.org/service-offering-guid`
+//+kubebuilder:printcolumn:name="Visibility",type=string,JSONPath=`.spec.visibility.type`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFServicePlan is the Schema for the cfserviceplans API
+type CFServicePlan struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFServicePlanSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFServicePlanList contains a list of CFServicePlan
+type CFServicePlanList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFServicePlan `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFServicePlan{}, &CFServicePlanList{})
+}
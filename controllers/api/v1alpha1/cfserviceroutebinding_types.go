@@ -0,0 +1,89 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	CFServiceRouteBindingRouteServiceSignatureKey     = "signature"
+	CFServiceRouteBindingSignatureRotatedAtAnnotation = "korifi.cloudfoundry.org/route-service-signature-rotated-at"
+)
+
+// CFServiceRouteBindingSpec defines the desired state of CFServiceRouteBinding
+type CFServiceRouteBindingSpec struct {
+	// The route service this binding uses. When created by the korifi API, this will refer to a CFServiceInstance
+	// with a route service URL. The CFServiceInstance must be in the same namespace
+	Service v1.ObjectReference `json:"service"`
+
+	// A reference to the CFRoute whose traffic is forwarded through the route service. The CFRoute must be in the same namespace
+	RouteRef v1.LocalObjectReference `json:"routeRef"`
+}
+
+// CFServiceRouteBindingStatus defines the observed state of CFServiceRouteBinding
+type CFServiceRouteBindingStatus struct {
+	// The URL of the route service the traffic of the route is forwarded to
+	// +optional
+	RouteServiceURL string `json:"routeServiceURL,omitempty"`
+
+	//+kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration captures the latest generation of the CFServiceRouteBinding that has been reconciled
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Route",type=string,JSONPath=`.spec.routeRef.name`
+//+kubebuilder:printcolumn:name="Route Service URL",type=string,JSONPath=`.status.routeServiceURL`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFServiceRouteBinding is the Schema for the cfserviceroutebindings API
+type CFServiceRouteBinding struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CFServiceRouteBindingSpec   `json:"spec,omitempty"`
+	Status CFServiceRouteBindingStatus `json:"status,omitempty"`
+}
+
+// RouteServiceName is the name of the Service and Endpoints pointing at the
+// addresses of the route service and of the Secret holding the signature the
+// route service has to send back with the forwarded requests
+func (b CFServiceRouteBinding) RouteServiceName() string {
+	return "rs-" + b.Name
+}
+
+func (b CFServiceRouteBinding) StatusConditions() []metav1.Condition {
+	return b.Status.Conditions
+}
+
+//+kubebuilder:object:root=true
+
+// CFServiceRouteBindingList contains a list of CFServiceRouteBinding
+type CFServiceRouteBindingList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFServiceRouteBinding `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFServiceRouteBinding{}, &CFServiceRouteBindingList{})
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ServiceUsageEventStateCreated = "CREATED"
+	ServiceUsageEventStateDeleted = "DELETED"
+
+	ManagedServiceInstanceUsageType      = "managed_service_instance"
+	UserProvidedServiceInstanceUsageType = "user_provided_service_instance"
+)
+
+// CFServiceUsageEventSpec defines the desired state of CFServiceUsageEvent
+type CFServiceUsageEventSpec struct {
+	// The state of the service instance after the event, i.e. `CREATED` or `DELETED`
+	State string `json:"state"`
+
+	// The service instance
+	ServiceInstance UsageEventResource `json:"serviceInstance"`
+
+	// The type of the service instance, i.e. `managed_service_instance` or `user_provided_service_instance`
+	ServiceInstanceType string `json:"serviceInstanceType"`
+
+	// The plan of a managed service instance
+	// +optional
+	ServicePlan *UsageEventResource `json:"servicePlan,omitempty"`
+
+	// The offering of a managed service instance
+	// +optional
+	ServiceOffering *UsageEventResource `json:"serviceOffering,omitempty"`
+
+	// The broker of a managed service instance
+	// +optional
+	ServiceBroker *UsageEventResource `json:"serviceBroker,omitempty"`
+
+	// The space of the service instance
+	Space UsageEventResource `json:"space"`
+
+	// The GUID of the organization of the service instance
+	// +optional
+	OrganizationGUID string `json:"organizationGUID,omitempty"`
+
+	// When the event occurred. Events are ordered by this timestamp, which is
+	// more precise than the creation timestamp
+	Timestamp metav1.MicroTime `json:"timestamp"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="State",type=string,JSONPath=`.spec.state`
+//+kubebuilder:printcolumn:name="Service Instance",type=string,JSONPath=`.spec.serviceInstance.name`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFServiceUsageEvent is the Schema for the cfserviceusageevents API.
+// Service usage events live in the root namespace, so that they outlive the
+// service instances and spaces they refer to
+type CFServiceUsageEvent struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFServiceUsageEventSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFServiceUsageEventList contains a list of CFServiceUsageEvent
+type CFServiceUsageEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFServiceUsageEvent `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&CFServiceUsageEvent{}, &CFServiceUsageEventList{})
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CFSidecarSpec defines the desired state of CFSidecar
+type CFSidecarSpec struct {
+	// A reference to the CFApp the sidecar belongs to
+	AppRef corev1.LocalObjectReference `json:"appRef"`
+
+	// The name of the sidecar, unique within the app
+	Name string `json:"name"`
+
+	// The command used to start the sidecar
+	Command string `json:"command"`
+
+	// The process types the sidecar runs alongside
+	// +kubebuilder:validation:MinItems=1
+	ProcessTypes []string `json:"processTypes"`
+
+	// The memory of the sidecar in MB, taken out of the memory of the process it runs alongside. When not set, the sidecar
+	// shares the memory of the process without reserving any
+	// +optional
+	MemoryMB int64 `json:"memoryMB,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:printcolumn:name="App",type=string,JSONPath=`.spec.appRef.name`
+//+kubebuilder:printcolumn:name="Name",type=string,JSONPath=`.spec.name`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=`.metadata.creationTimestamp`
+
+// CFSidecar is the Schema for the cfsidecars API.
+// Sidecars run as additional containers next to the instances of the
+// processes of their app
+type CFSidecar struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec CFSidecarSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// CFSidecarList contains a list of CFSidecar
+type CFSidecarList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []CFSidecar `json:"items"`
+}
+
+// RunsAlongside tells whether the sidecar runs next to the instances of the process type
+func (s CFSidecar) RunsAlongside(processType string) bool {
+	for _, sidecarProcessType := range s.Spec.ProcessTypes {
+		if sidecarProcessType == processType {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	SchemeBuilder.Register(&CFSidecar{}, &CFSidecarList{})
+}
@@ -35,6 +35,15 @@ type CFSpaceSpec struct {
 	// The mutable, user-friendly name of the space. Unlike metadata.name, the user can change this field
 	// +kubebuilder:validation:Pattern="^[[:alnum:][:punct:][:print:]]+$"
 	DisplayName string `json:"displayName"`
+
+	// The GUID of the isolation segment the workloads of the space are placed into.
+	// The org of the space must be entitled to the isolation segment
+	// +optional
+	IsolationSegment string `json:"isolationSegment,omitempty"`
+
+	// Whether users can SSH into the instances of the apps in the space. SSH is allowed when unset
+	// +optional
+	AllowSSH *bool `json:"allowSSH,omitempty"`
 }
 
 // CFSpaceStatus defines the observed state of CFSpace
@@ -71,6 +80,10 @@ func (s CFSpace) UniqueName() string {
 	return strings.ToLower(s.Spec.DisplayName)
 }
 
+func (s CFSpace) SSHAllowed() bool {
+	return s.Spec.AllowSSH == nil || *s.Spec.AllowSSH
+}
+
 //+kubebuilder:object:root=true
 
 // CFSpaceList contains a list of CFSpace
@@ -2,6 +2,8 @@ package v1alpha1
 
 const (
 	BuildpackLifecycle LifecycleType = "buildpack"
+	DockerLifecycle    LifecycleType = "docker"
+	BitsPackage        PackageType   = "bits"
 	DockerPackage      PackageType   = "docker"
 
 	StartedState DesiredState = "STARTED"
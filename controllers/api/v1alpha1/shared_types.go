@@ -9,6 +9,10 @@ const (
 	CFAppRevisionKey         = "korifi.cloudfoundry.org/app-rev"
 	CFAppLastStopRevisionKey = "korifi.cloudfoundry.org/last-stop-app-rev"
 	CFAppRevisionKeyDefault  = "0"
+	// CFAppDeployedRevisionKey holds the guid of the CFRevision the app was
+	// last deployed with. Unlike app-rev, it is not bumped when the app is
+	// stopped
+	CFAppDeployedRevisionKey = "korifi.cloudfoundry.org/deployed-revision"
 	CFPackageGUIDLabelKey    = "korifi.cloudfoundry.org/package-guid"
 	CFBuildGUIDLabelKey      = "korifi.cloudfoundry.org/build-guid"
 	CFProcessGUIDLabelKey    = "korifi.cloudfoundry.org/process-guid"
@@ -17,6 +21,14 @@ const (
 	CFRouteGUIDLabelKey      = "korifi.cloudfoundry.org/route-guid"
 	CFTaskGUIDLabelKey       = "korifi.cloudfoundry.org/task-guid"
 
+	CFServiceBrokerGUIDLabelKey   = "korifi.cloudfoundry.org/service-broker-guid"
+	CFServiceOfferingGUIDLabelKey = "korifi.cloudfoundry.org/service-offering-guid"
+	CFSecurityGroupGUIDLabelKey   = "korifi.cloudfoundry.org/security-group-guid"
+
+	// CFServiceInstanceSharedWithLabelPrefix is followed by the guid of a space
+	// a service instance is shared with
+	CFServiceInstanceSharedWithLabelPrefix = "shared-with.korifi.cloudfoundry.org/"
+
 	StagingConditionType   = "Staging"
 	ReadyConditionType     = "Ready"
 	SucceededConditionType = "Succeeded"
@@ -29,15 +41,15 @@ const (
 
 type Lifecycle struct {
 	// The CF Lifecycle type.
-	// Only "buildpack" is currently allowed
+	// Only "buildpack" and "docker" are currently allowed
 	Type LifecycleType `json:"type"`
 	// Data used to specify details for the Lifecycle
 	Data LifecycleData `json:"data"`
 }
 
 // LifecycleType inform the platform of how to build droplets and run apps
-// allow only values "buildpack"
-// +kubebuilder:validation:Enum=buildpack
+// allow only values "buildpack" and "docker"
+// +kubebuilder:validation:Enum=buildpack;docker
 type LifecycleType string
 
 // LifecycleData is shared by CFApp and CFBuild
@@ -46,7 +58,7 @@ type LifecycleData struct {
 	// If no values are specified, then all available buildpacks will be used for auto-detection
 	Buildpacks []string `json:"buildpacks,omitempty"`
 
-	// Stack to use when building the app image
+	// Stack to use when building the app image. Empty for the docker lifecycle
 	Stack string `json:"stack"`
 }
 
@@ -37,6 +37,14 @@ type TaskWorkloadSpec struct {
 
 	// +kubebuilder:validation:Optional
 	Env []corev1.EnvVar `json:"env"`
+
+	// The node selector of the isolation segment the task is placed into
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// The tolerations of the isolation segment the task is placed into
+	// +kubebuilder:validation:Optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
 }
 
 // TaskWorkloadStatus defines the observed state of TaskWorkload
@@ -86,6 +86,27 @@ func (in *AppWorkloadList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppWorkloadSidecar) DeepCopyInto(out *AppWorkloadSidecar) {
+	*out = *in
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppWorkloadSidecar.
+func (in *AppWorkloadSidecar) DeepCopy() *AppWorkloadSidecar {
+	if in == nil {
+		return nil
+	}
+	out := new(AppWorkloadSidecar)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppWorkloadSpec) DeepCopyInto(out *AppWorkloadSpec) {
 	*out = *in
@@ -127,6 +148,27 @@ func (in *AppWorkloadSpec) DeepCopyInto(out *AppWorkloadSpec) {
 		copy(*out, *in)
 	}
 	in.Resources.DeepCopyInto(&out.Resources)
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Sidecars != nil {
+		in, out := &in.Sidecars, &out.Sidecars
+		*out = make([]AppWorkloadSidecar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppWorkloadSpec.
@@ -268,6 +310,20 @@ func (in *BuildWorkloadSpec) DeepCopyInto(out *BuildWorkloadSpec) {
 		*out = make([]v1.ObjectReference, len(*in))
 		copy(*out, *in)
 	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuildWorkloadSpec.
@@ -515,6 +571,16 @@ func (in *CFAppSpec) DeepCopyInto(out *CFAppSpec) {
 	*out = *in
 	in.Lifecycle.DeepCopyInto(&out.Lifecycle)
 	out.CurrentDropletRef = in.CurrentDropletRef
+	if in.EnableSSH != nil {
+		in, out := &in.EnableSSH, &out.EnableSSH
+		*out = new(bool)
+		**out = **in
+	}
+	if in.EnableRevisions != nil {
+		in, out := &in.EnableRevisions, &out.EnableRevisions
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppSpec.
@@ -550,26 +616,25 @@ func (in *CFAppStatus) DeepCopy() *CFAppStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFBuild) DeepCopyInto(out *CFBuild) {
+func (in *CFAppUsageEvent) DeepCopyInto(out *CFAppUsageEvent) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuild.
-func (in *CFBuild) DeepCopy() *CFBuild {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppUsageEvent.
+func (in *CFAppUsageEvent) DeepCopy() *CFAppUsageEvent {
 	if in == nil {
 		return nil
 	}
-	out := new(CFBuild)
+	out := new(CFAppUsageEvent)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFBuild) DeepCopyObject() runtime.Object {
+func (in *CFAppUsageEvent) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -577,31 +642,31 @@ func (in *CFBuild) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFBuildList) DeepCopyInto(out *CFBuildList) {
+func (in *CFAppUsageEventList) DeepCopyInto(out *CFAppUsageEventList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFBuild, len(*in))
+		*out = make([]CFAppUsageEvent, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildList.
-func (in *CFBuildList) DeepCopy() *CFBuildList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppUsageEventList.
+func (in *CFAppUsageEventList) DeepCopy() *CFAppUsageEventList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFBuildList)
+	out := new(CFAppUsageEventList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFBuildList) DeepCopyObject() runtime.Object {
+func (in *CFAppUsageEventList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -609,71 +674,44 @@ func (in *CFBuildList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFBuildSpec) DeepCopyInto(out *CFBuildSpec) {
-	*out = *in
-	out.PackageRef = in.PackageRef
-	out.AppRef = in.AppRef
-	in.Lifecycle.DeepCopyInto(&out.Lifecycle)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildSpec.
-func (in *CFBuildSpec) DeepCopy() *CFBuildSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(CFBuildSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFBuildStatus) DeepCopyInto(out *CFBuildStatus) {
+func (in *CFAppUsageEventSpec) DeepCopyInto(out *CFAppUsageEventSpec) {
 	*out = *in
-	if in.Droplet != nil {
-		in, out := &in.Droplet, &out.Droplet
-		*out = new(BuildDropletStatus)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
+	out.App = in.App
+	out.Process = in.Process
+	out.Space = in.Space
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildStatus.
-func (in *CFBuildStatus) DeepCopy() *CFBuildStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAppUsageEventSpec.
+func (in *CFAppUsageEventSpec) DeepCopy() *CFAppUsageEventSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFBuildStatus)
+	out := new(CFAppUsageEventSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFDomain) DeepCopyInto(out *CFDomain) {
+func (in *CFAuditEvent) DeepCopyInto(out *CFAuditEvent) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomain.
-func (in *CFDomain) DeepCopy() *CFDomain {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAuditEvent.
+func (in *CFAuditEvent) DeepCopy() *CFAuditEvent {
 	if in == nil {
 		return nil
 	}
-	out := new(CFDomain)
+	out := new(CFAuditEvent)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFDomain) DeepCopyObject() runtime.Object {
+func (in *CFAuditEvent) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -681,31 +719,31 @@ func (in *CFDomain) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFDomainList) DeepCopyInto(out *CFDomainList) {
+func (in *CFAuditEventList) DeepCopyInto(out *CFAuditEventList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFDomain, len(*in))
+		*out = make([]CFAuditEvent, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainList.
-func (in *CFDomainList) DeepCopy() *CFDomainList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAuditEventList.
+func (in *CFAuditEventList) DeepCopy() *CFAuditEventList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFDomainList)
+	out := new(CFAuditEventList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFDomainList) DeepCopyObject() runtime.Object {
+func (in *CFAuditEventList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -713,63 +751,65 @@ func (in *CFDomainList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFDomainSpec) DeepCopyInto(out *CFDomainSpec) {
+func (in *CFAuditEventParticipant) DeepCopyInto(out *CFAuditEventParticipant) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainSpec.
-func (in *CFDomainSpec) DeepCopy() *CFDomainSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAuditEventParticipant.
+func (in *CFAuditEventParticipant) DeepCopy() *CFAuditEventParticipant {
 	if in == nil {
 		return nil
 	}
-	out := new(CFDomainSpec)
+	out := new(CFAuditEventParticipant)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFDomainStatus) DeepCopyInto(out *CFDomainStatus) {
+func (in *CFAuditEventSpec) DeepCopyInto(out *CFAuditEventSpec) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	out.Actor = in.Actor
+	out.Target = in.Target
+	if in.Data != nil {
+		in, out := &in.Data, &out.Data
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainStatus.
-func (in *CFDomainStatus) DeepCopy() *CFDomainStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFAuditEventSpec.
+func (in *CFAuditEventSpec) DeepCopy() *CFAuditEventSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFDomainStatus)
+	out := new(CFAuditEventSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFOrg) DeepCopyInto(out *CFOrg) {
+func (in *CFBuild) DeepCopyInto(out *CFBuild) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrg.
-func (in *CFOrg) DeepCopy() *CFOrg {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuild.
+func (in *CFBuild) DeepCopy() *CFBuild {
 	if in == nil {
 		return nil
 	}
-	out := new(CFOrg)
+	out := new(CFBuild)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFOrg) DeepCopyObject() runtime.Object {
+func (in *CFBuild) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -777,31 +817,31 @@ func (in *CFOrg) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFOrgList) DeepCopyInto(out *CFOrgList) {
+func (in *CFBuildList) DeepCopyInto(out *CFBuildList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFOrg, len(*in))
+		*out = make([]CFBuild, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgList.
-func (in *CFOrgList) DeepCopy() *CFOrgList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildList.
+func (in *CFBuildList) DeepCopy() *CFBuildList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFOrgList)
+	out := new(CFBuildList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFOrgList) DeepCopyObject() runtime.Object {
+func (in *CFBuildList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -809,23 +849,36 @@ func (in *CFOrgList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFOrgSpec) DeepCopyInto(out *CFOrgSpec) {
+func (in *CFBuildSpec) DeepCopyInto(out *CFBuildSpec) {
 	*out = *in
+	out.PackageRef = in.PackageRef
+	out.AppRef = in.AppRef
+	in.Lifecycle.DeepCopyInto(&out.Lifecycle)
+	if in.Droplet != nil {
+		in, out := &in.Droplet, &out.Droplet
+		*out = new(BuildDropletStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgSpec.
-func (in *CFOrgSpec) DeepCopy() *CFOrgSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildSpec.
+func (in *CFBuildSpec) DeepCopy() *CFBuildSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFOrgSpec)
+	out := new(CFBuildSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFOrgStatus) DeepCopyInto(out *CFOrgStatus) {
+func (in *CFBuildStatus) DeepCopyInto(out *CFBuildStatus) {
 	*out = *in
+	if in.Droplet != nil {
+		in, out := &in.Droplet, &out.Droplet
+		*out = new(BuildDropletStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -835,37 +888,37 @@ func (in *CFOrgStatus) DeepCopyInto(out *CFOrgStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgStatus.
-func (in *CFOrgStatus) DeepCopy() *CFOrgStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFBuildStatus.
+func (in *CFBuildStatus) DeepCopy() *CFBuildStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CFOrgStatus)
+	out := new(CFBuildStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFPackage) DeepCopyInto(out *CFPackage) {
+func (in *CFDomain) DeepCopyInto(out *CFDomain) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	out.Spec = in.Spec
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackage.
-func (in *CFPackage) DeepCopy() *CFPackage {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomain.
+func (in *CFDomain) DeepCopy() *CFDomain {
 	if in == nil {
 		return nil
 	}
-	out := new(CFPackage)
+	out := new(CFDomain)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFPackage) DeepCopyObject() runtime.Object {
+func (in *CFDomain) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -873,31 +926,31 @@ func (in *CFPackage) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFPackageList) DeepCopyInto(out *CFPackageList) {
+func (in *CFDomainList) DeepCopyInto(out *CFDomainList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFPackage, len(*in))
+		*out = make([]CFDomain, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageList.
-func (in *CFPackageList) DeepCopy() *CFPackageList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainList.
+func (in *CFDomainList) DeepCopy() *CFDomainList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFPackageList)
+	out := new(CFDomainList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFPackageList) DeepCopyObject() runtime.Object {
+func (in *CFDomainList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -905,24 +958,22 @@ func (in *CFPackageList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFPackageSpec) DeepCopyInto(out *CFPackageSpec) {
+func (in *CFDomainSpec) DeepCopyInto(out *CFDomainSpec) {
 	*out = *in
-	out.AppRef = in.AppRef
-	in.Source.DeepCopyInto(&out.Source)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageSpec.
-func (in *CFPackageSpec) DeepCopy() *CFPackageSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainSpec.
+func (in *CFDomainSpec) DeepCopy() *CFDomainSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFPackageSpec)
+	out := new(CFDomainSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFPackageStatus) DeepCopyInto(out *CFPackageStatus) {
+func (in *CFDomainStatus) DeepCopyInto(out *CFDomainStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
@@ -933,37 +984,36 @@ func (in *CFPackageStatus) DeepCopyInto(out *CFPackageStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageStatus.
-func (in *CFPackageStatus) DeepCopy() *CFPackageStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFDomainStatus.
+func (in *CFDomainStatus) DeepCopy() *CFDomainStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CFPackageStatus)
+	out := new(CFDomainStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFProcess) DeepCopyInto(out *CFProcess) {
+func (in *CFEnvVarGroup) DeepCopyInto(out *CFEnvVarGroup) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcess.
-func (in *CFProcess) DeepCopy() *CFProcess {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFEnvVarGroup.
+func (in *CFEnvVarGroup) DeepCopy() *CFEnvVarGroup {
 	if in == nil {
 		return nil
 	}
-	out := new(CFProcess)
+	out := new(CFEnvVarGroup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFProcess) DeepCopyObject() runtime.Object {
+func (in *CFEnvVarGroup) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -971,46 +1021,31 @@ func (in *CFProcess) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFProcessDefaulter) DeepCopyInto(out *CFProcessDefaulter) {
-	*out = *in
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessDefaulter.
-func (in *CFProcessDefaulter) DeepCopy() *CFProcessDefaulter {
-	if in == nil {
-		return nil
-	}
-	out := new(CFProcessDefaulter)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFProcessList) DeepCopyInto(out *CFProcessList) {
+func (in *CFEnvVarGroupList) DeepCopyInto(out *CFEnvVarGroupList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFProcess, len(*in))
+		*out = make([]CFEnvVarGroup, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessList.
-func (in *CFProcessList) DeepCopy() *CFProcessList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFEnvVarGroupList.
+func (in *CFEnvVarGroupList) DeepCopy() *CFEnvVarGroupList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFProcessList)
+	out := new(CFEnvVarGroupList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFProcessList) DeepCopyObject() runtime.Object {
+func (in *CFEnvVarGroupList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1018,75 +1053,1255 @@ func (in *CFProcessList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFProcessSpec) DeepCopyInto(out *CFProcessSpec) {
+func (in *CFEnvVarGroupSpec) DeepCopyInto(out *CFEnvVarGroupSpec) {
 	*out = *in
-	out.AppRef = in.AppRef
-	out.HealthCheck = in.HealthCheck
-	if in.DesiredInstances != nil {
-		in, out := &in.DesiredInstances, &out.DesiredInstances
-		*out = new(int)
-		**out = **in
-	}
-	if in.Ports != nil {
-		in, out := &in.Ports, &out.Ports
-		*out = make([]int32, len(*in))
-		copy(*out, *in)
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessSpec.
-func (in *CFProcessSpec) DeepCopy() *CFProcessSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFEnvVarGroupSpec.
+func (in *CFEnvVarGroupSpec) DeepCopy() *CFEnvVarGroupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFProcessSpec)
+	out := new(CFEnvVarGroupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFProcessStatus) DeepCopyInto(out *CFProcessStatus) {
+func (in *CFFeatureFlag) DeepCopyInto(out *CFFeatureFlag) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFFeatureFlag.
+func (in *CFFeatureFlag) DeepCopy() *CFFeatureFlag {
+	if in == nil {
+		return nil
+	}
+	out := new(CFFeatureFlag)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFFeatureFlag) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFFeatureFlagList) DeepCopyInto(out *CFFeatureFlagList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFFeatureFlag, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFFeatureFlagList.
+func (in *CFFeatureFlagList) DeepCopy() *CFFeatureFlagList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFFeatureFlagList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFFeatureFlagList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFFeatureFlagSpec) DeepCopyInto(out *CFFeatureFlagSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFFeatureFlagSpec.
+func (in *CFFeatureFlagSpec) DeepCopy() *CFFeatureFlagSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFFeatureFlagSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFIsolationSegment) DeepCopyInto(out *CFIsolationSegment) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFIsolationSegment.
+func (in *CFIsolationSegment) DeepCopy() *CFIsolationSegment {
+	if in == nil {
+		return nil
+	}
+	out := new(CFIsolationSegment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFIsolationSegment) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFIsolationSegmentList) DeepCopyInto(out *CFIsolationSegmentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFIsolationSegment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFIsolationSegmentList.
+func (in *CFIsolationSegmentList) DeepCopy() *CFIsolationSegmentList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFIsolationSegmentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFIsolationSegmentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFIsolationSegmentSpec) DeepCopyInto(out *CFIsolationSegmentSpec) {
+	*out = *in
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFIsolationSegmentSpec.
+func (in *CFIsolationSegmentSpec) DeepCopy() *CFIsolationSegmentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFIsolationSegmentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrg) DeepCopyInto(out *CFOrg) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrg.
+func (in *CFOrg) DeepCopy() *CFOrg {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrg)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFOrg) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrgList) DeepCopyInto(out *CFOrgList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFOrg, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgList.
+func (in *CFOrgList) DeepCopy() *CFOrgList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrgList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFOrgList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrgQuota) DeepCopyInto(out *CFOrgQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgQuota.
+func (in *CFOrgQuota) DeepCopy() *CFOrgQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrgQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFOrgQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrgQuotaList) DeepCopyInto(out *CFOrgQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFOrgQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgQuotaList.
+func (in *CFOrgQuotaList) DeepCopy() *CFOrgQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrgQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFOrgQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrgQuotaSpec) DeepCopyInto(out *CFOrgQuotaSpec) {
+	*out = *in
+	in.Limits.DeepCopyInto(&out.Limits)
+	if in.Orgs != nil {
+		in, out := &in.Orgs, &out.Orgs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgQuotaSpec.
+func (in *CFOrgQuotaSpec) DeepCopy() *CFOrgQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrgQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrgSpec) DeepCopyInto(out *CFOrgSpec) {
+	*out = *in
+	if in.IsolationSegments != nil {
+		in, out := &in.IsolationSegments, &out.IsolationSegments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgSpec.
+func (in *CFOrgSpec) DeepCopy() *CFOrgSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrgSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFOrgStatus) DeepCopyInto(out *CFOrgStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFOrgStatus.
+func (in *CFOrgStatus) DeepCopy() *CFOrgStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFOrgStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFPackage) DeepCopyInto(out *CFPackage) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackage.
+func (in *CFPackage) DeepCopy() *CFPackage {
+	if in == nil {
+		return nil
+	}
+	out := new(CFPackage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFPackage) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFPackageList) DeepCopyInto(out *CFPackageList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFPackage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageList.
+func (in *CFPackageList) DeepCopy() *CFPackageList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFPackageList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFPackageList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFPackageSpec) DeepCopyInto(out *CFPackageSpec) {
+	*out = *in
+	out.AppRef = in.AppRef
+	in.Source.DeepCopyInto(&out.Source)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageSpec.
+func (in *CFPackageSpec) DeepCopy() *CFPackageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFPackageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFPackageStatus) DeepCopyInto(out *CFPackageStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFPackageStatus.
+func (in *CFPackageStatus) DeepCopy() *CFPackageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFPackageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFProcess) DeepCopyInto(out *CFProcess) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcess.
+func (in *CFProcess) DeepCopy() *CFProcess {
+	if in == nil {
+		return nil
+	}
+	out := new(CFProcess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFProcess) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFProcessDefaulter) DeepCopyInto(out *CFProcessDefaulter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessDefaulter.
+func (in *CFProcessDefaulter) DeepCopy() *CFProcessDefaulter {
+	if in == nil {
+		return nil
+	}
+	out := new(CFProcessDefaulter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFProcessList) DeepCopyInto(out *CFProcessList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFProcess, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessList.
+func (in *CFProcessList) DeepCopy() *CFProcessList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFProcessList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFProcessList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFProcessSpec) DeepCopyInto(out *CFProcessSpec) {
+	*out = *in
+	out.AppRef = in.AppRef
+	out.HealthCheck = in.HealthCheck
+	if in.DesiredInstances != nil {
+		in, out := &in.DesiredInstances, &out.DesiredInstances
+		*out = new(int)
+		**out = **in
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessSpec.
+func (in *CFProcessSpec) DeepCopy() *CFProcessSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFProcessSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFProcessStatus) DeepCopyInto(out *CFProcessStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessStatus.
+func (in *CFProcessStatus) DeepCopy() *CFProcessStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFProcessStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFRevision) DeepCopyInto(out *CFRevision) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRevision.
+func (in *CFRevision) DeepCopy() *CFRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(CFRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFRevision) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFRevisionList) DeepCopyInto(out *CFRevisionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRevisionList.
+func (in *CFRevisionList) DeepCopy() *CFRevisionList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFRevisionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFRevisionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFRevisionProcess) DeepCopyInto(out *CFRevisionProcess) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRevisionProcess.
+func (in *CFRevisionProcess) DeepCopy() *CFRevisionProcess {
+	if in == nil {
+		return nil
+	}
+	out := new(CFRevisionProcess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFRevisionSpec) DeepCopyInto(out *CFRevisionSpec) {
+	*out = *in
+	out.AppRef = in.AppRef
+	out.DropletRef = in.DropletRef
+	if in.Processes != nil {
+		in, out := &in.Processes, &out.Processes
+		*out = make([]CFRevisionProcess, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRevisionSpec.
+func (in *CFRevisionSpec) DeepCopy() *CFRevisionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFRevisionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFRoute) DeepCopyInto(out *CFRoute) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRoute.
+func (in *CFRoute) DeepCopy() *CFRoute {
+	if in == nil {
+		return nil
+	}
+	out := new(CFRoute)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFRoute) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFRouteList) DeepCopyInto(out *CFRouteList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFRoute, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRouteList.
+func (in *CFRouteList) DeepCopy() *CFRouteList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFRouteList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFRouteList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFRouteSpec) DeepCopyInto(out *CFRouteSpec) {
+	*out = *in
+	out.DomainRef = in.DomainRef
+	if in.Destinations != nil {
+		in, out := &in.Destinations, &out.Destinations
+		*out = make([]Destination, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRouteSpec.
+func (in *CFRouteSpec) DeepCopy() *CFRouteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFRouteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFRouteStatus) DeepCopyInto(out *CFRouteStatus) {
+	*out = *in
+	if in.Destinations != nil {
+		in, out := &in.Destinations, &out.Destinations
+		*out = make([]Destination, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRouteStatus.
+func (in *CFRouteStatus) DeepCopy() *CFRouteStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFRouteStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSecurityGroup) DeepCopyInto(out *CFSecurityGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSecurityGroup.
+func (in *CFSecurityGroup) DeepCopy() *CFSecurityGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSecurityGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFSecurityGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSecurityGroupList) DeepCopyInto(out *CFSecurityGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFSecurityGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSecurityGroupList.
+func (in *CFSecurityGroupList) DeepCopy() *CFSecurityGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSecurityGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFSecurityGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSecurityGroupSpec) DeepCopyInto(out *CFSecurityGroupSpec) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]SecurityGroupRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.GloballyEnabled = in.GloballyEnabled
+	if in.Spaces != nil {
+		in, out := &in.Spaces, &out.Spaces
+		*out = make(map[string]SecurityGroupWorkloads, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSecurityGroupSpec.
+func (in *CFSecurityGroupSpec) DeepCopy() *CFSecurityGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSecurityGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSecurityGroupStatus) DeepCopyInto(out *CFSecurityGroupStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSecurityGroupStatus.
+func (in *CFSecurityGroupStatus) DeepCopy() *CFSecurityGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSecurityGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceBinding) DeepCopyInto(out *CFServiceBinding) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBinding.
+func (in *CFServiceBinding) DeepCopy() *CFServiceBinding {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceBinding)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceBinding) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceBindingList) DeepCopyInto(out *CFServiceBindingList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFServiceBinding, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBindingList.
+func (in *CFServiceBindingList) DeepCopy() *CFServiceBindingList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceBindingList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceBindingList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceBindingSpec) DeepCopyInto(out *CFServiceBindingSpec) {
+	*out = *in
+	if in.DisplayName != nil {
+		in, out := &in.DisplayName, &out.DisplayName
+		*out = new(string)
+		**out = **in
+	}
+	out.Service = in.Service
+	out.AppRef = in.AppRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBindingSpec.
+func (in *CFServiceBindingSpec) DeepCopy() *CFServiceBindingSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceBindingSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceBindingStatus) DeepCopyInto(out *CFServiceBindingStatus) {
+	*out = *in
+	out.Binding = in.Binding
+	out.Credentials = in.Credentials
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBindingStatus.
+func (in *CFServiceBindingStatus) DeepCopy() *CFServiceBindingStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceBindingStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceBroker) DeepCopyInto(out *CFServiceBroker) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBroker.
+func (in *CFServiceBroker) DeepCopy() *CFServiceBroker {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceBroker)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceBroker) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceBrokerList) DeepCopyInto(out *CFServiceBrokerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFServiceBroker, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBrokerList.
+func (in *CFServiceBrokerList) DeepCopy() *CFServiceBrokerList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceBrokerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceBrokerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceBrokerSpec) DeepCopyInto(out *CFServiceBrokerSpec) {
+	*out = *in
+	out.Credentials = in.Credentials
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBrokerSpec.
+func (in *CFServiceBrokerSpec) DeepCopy() *CFServiceBrokerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceBrokerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceBrokerStatus) DeepCopyInto(out *CFServiceBrokerStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBrokerStatus.
+func (in *CFServiceBrokerStatus) DeepCopy() *CFServiceBrokerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceBrokerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceInstance) DeepCopyInto(out *CFServiceInstance) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstance.
+func (in *CFServiceInstance) DeepCopy() *CFServiceInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceInstance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceInstanceList) DeepCopyInto(out *CFServiceInstanceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFServiceInstance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstanceList.
+func (in *CFServiceInstanceList) DeepCopy() *CFServiceInstanceList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceInstanceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceInstanceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceInstanceSpec) DeepCopyInto(out *CFServiceInstanceSpec) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RouteServiceURL != nil {
+		in, out := &in.RouteServiceURL, &out.RouteServiceURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.SyslogDrainURL != nil {
+		in, out := &in.SyslogDrainURL, &out.SyslogDrainURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.ServiceLabel != nil {
+		in, out := &in.ServiceLabel, &out.ServiceLabel
+		*out = new(string)
+		**out = **in
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SharedSpaces != nil {
+		in, out := &in.SharedSpaces, &out.SharedSpaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstanceSpec.
+func (in *CFServiceInstanceSpec) DeepCopy() *CFServiceInstanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceInstanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceInstanceStatus) DeepCopyInto(out *CFServiceInstanceStatus) {
+	*out = *in
+	out.Binding = in.Binding
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastOperation != nil {
+		in, out := &in.LastOperation, &out.LastOperation
+		*out = new(LastOperation)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFProcessStatus.
-func (in *CFProcessStatus) DeepCopy() *CFProcessStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstanceStatus.
+func (in *CFServiceInstanceStatus) DeepCopy() *CFServiceInstanceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CFProcessStatus)
+	out := new(CFServiceInstanceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFRoute) DeepCopyInto(out *CFRoute) {
+func (in *CFServiceOffering) DeepCopyInto(out *CFServiceOffering) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRoute.
-func (in *CFRoute) DeepCopy() *CFRoute {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceOffering.
+func (in *CFServiceOffering) DeepCopy() *CFServiceOffering {
 	if in == nil {
 		return nil
 	}
-	out := new(CFRoute)
+	out := new(CFServiceOffering)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFRoute) DeepCopyObject() runtime.Object {
+func (in *CFServiceOffering) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1094,31 +2309,31 @@ func (in *CFRoute) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFRouteList) DeepCopyInto(out *CFRouteList) {
+func (in *CFServiceOfferingList) DeepCopyInto(out *CFServiceOfferingList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFRoute, len(*in))
+		*out = make([]CFServiceOffering, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRouteList.
-func (in *CFRouteList) DeepCopy() *CFRouteList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceOfferingList.
+func (in *CFServiceOfferingList) DeepCopy() *CFServiceOfferingList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFRouteList)
+	out := new(CFServiceOfferingList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFRouteList) DeepCopyObject() runtime.Object {
+func (in *CFServiceOfferingList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1126,74 +2341,143 @@ func (in *CFRouteList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFRouteSpec) DeepCopyInto(out *CFRouteSpec) {
+func (in *CFServiceOfferingSpec) DeepCopyInto(out *CFServiceOfferingSpec) {
 	*out = *in
-	out.DomainRef = in.DomainRef
-	if in.Destinations != nil {
-		in, out := &in.Destinations, &out.Destinations
-		*out = make([]Destination, len(*in))
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Requires != nil {
+		in, out := &in.Requires, &out.Requires
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.DocumentationURL != nil {
+		in, out := &in.DocumentationURL, &out.DocumentationURL
+		*out = new(string)
+		**out = **in
+	}
+	in.BrokerCatalog.DeepCopyInto(&out.BrokerCatalog)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRouteSpec.
-func (in *CFRouteSpec) DeepCopy() *CFRouteSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceOfferingSpec.
+func (in *CFServiceOfferingSpec) DeepCopy() *CFServiceOfferingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFRouteSpec)
+	out := new(CFServiceOfferingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFRouteStatus) DeepCopyInto(out *CFRouteStatus) {
+func (in *CFServicePlan) DeepCopyInto(out *CFServicePlan) {
 	*out = *in
-	if in.Destinations != nil {
-		in, out := &in.Destinations, &out.Destinations
-		*out = make([]Destination, len(*in))
-		copy(*out, *in)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServicePlan.
+func (in *CFServicePlan) DeepCopy() *CFServicePlan {
+	if in == nil {
+		return nil
 	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+	out := new(CFServicePlan)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServicePlan) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServicePlanList) DeepCopyInto(out *CFServicePlanList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFServicePlan, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFRouteStatus.
-func (in *CFRouteStatus) DeepCopy() *CFRouteStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServicePlanList.
+func (in *CFServicePlanList) DeepCopy() *CFServicePlanList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFRouteStatus)
+	out := new(CFServicePlanList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServicePlanList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceBinding) DeepCopyInto(out *CFServiceBinding) {
+func (in *CFServicePlanSpec) DeepCopyInto(out *CFServicePlanSpec) {
+	*out = *in
+	if in.Bindable != nil {
+		in, out := &in.Bindable, &out.Bindable
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PlanUpdateable != nil {
+		in, out := &in.PlanUpdateable, &out.PlanUpdateable
+		*out = new(bool)
+		**out = **in
+	}
+	in.BrokerCatalog.DeepCopyInto(&out.BrokerCatalog)
+	in.Schemas.DeepCopyInto(&out.Schemas)
+	in.Visibility.DeepCopyInto(&out.Visibility)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServicePlanSpec.
+func (in *CFServicePlanSpec) DeepCopy() *CFServicePlanSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServicePlanSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceRouteBinding) DeepCopyInto(out *CFServiceRouteBinding) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	out.Spec = in.Spec
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBinding.
-func (in *CFServiceBinding) DeepCopy() *CFServiceBinding {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceRouteBinding.
+func (in *CFServiceRouteBinding) DeepCopy() *CFServiceRouteBinding {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceBinding)
+	out := new(CFServiceRouteBinding)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFServiceBinding) DeepCopyObject() runtime.Object {
+func (in *CFServiceRouteBinding) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1201,31 +2485,31 @@ func (in *CFServiceBinding) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceBindingList) DeepCopyInto(out *CFServiceBindingList) {
+func (in *CFServiceRouteBindingList) DeepCopyInto(out *CFServiceRouteBindingList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFServiceBinding, len(*in))
+		*out = make([]CFServiceRouteBinding, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBindingList.
-func (in *CFServiceBindingList) DeepCopy() *CFServiceBindingList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceRouteBindingList.
+func (in *CFServiceRouteBindingList) DeepCopy() *CFServiceRouteBindingList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceBindingList)
+	out := new(CFServiceRouteBindingList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFServiceBindingList) DeepCopyObject() runtime.Object {
+func (in *CFServiceRouteBindingList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1233,31 +2517,25 @@ func (in *CFServiceBindingList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceBindingSpec) DeepCopyInto(out *CFServiceBindingSpec) {
+func (in *CFServiceRouteBindingSpec) DeepCopyInto(out *CFServiceRouteBindingSpec) {
 	*out = *in
-	if in.DisplayName != nil {
-		in, out := &in.DisplayName, &out.DisplayName
-		*out = new(string)
-		**out = **in
-	}
 	out.Service = in.Service
-	out.AppRef = in.AppRef
+	out.RouteRef = in.RouteRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBindingSpec.
-func (in *CFServiceBindingSpec) DeepCopy() *CFServiceBindingSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceRouteBindingSpec.
+func (in *CFServiceRouteBindingSpec) DeepCopy() *CFServiceRouteBindingSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceBindingSpec)
+	out := new(CFServiceRouteBindingSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceBindingStatus) DeepCopyInto(out *CFServiceBindingStatus) {
+func (in *CFServiceRouteBindingStatus) DeepCopyInto(out *CFServiceRouteBindingStatus) {
 	*out = *in
-	out.Binding = in.Binding
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]metav1.Condition, len(*in))
@@ -1267,37 +2545,127 @@ func (in *CFServiceBindingStatus) DeepCopyInto(out *CFServiceBindingStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceBindingStatus.
-func (in *CFServiceBindingStatus) DeepCopy() *CFServiceBindingStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceRouteBindingStatus.
+func (in *CFServiceRouteBindingStatus) DeepCopy() *CFServiceRouteBindingStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceBindingStatus)
+	out := new(CFServiceRouteBindingStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceInstance) DeepCopyInto(out *CFServiceInstance) {
+func (in *CFServiceUsageEvent) DeepCopyInto(out *CFServiceUsageEvent) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstance.
-func (in *CFServiceInstance) DeepCopy() *CFServiceInstance {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceUsageEvent.
+func (in *CFServiceUsageEvent) DeepCopy() *CFServiceUsageEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceUsageEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceUsageEvent) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceUsageEventList) DeepCopyInto(out *CFServiceUsageEventList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFServiceUsageEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceUsageEventList.
+func (in *CFServiceUsageEventList) DeepCopy() *CFServiceUsageEventList {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceUsageEventList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFServiceUsageEventList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFServiceUsageEventSpec) DeepCopyInto(out *CFServiceUsageEventSpec) {
+	*out = *in
+	out.ServiceInstance = in.ServiceInstance
+	if in.ServicePlan != nil {
+		in, out := &in.ServicePlan, &out.ServicePlan
+		*out = new(UsageEventResource)
+		**out = **in
+	}
+	if in.ServiceOffering != nil {
+		in, out := &in.ServiceOffering, &out.ServiceOffering
+		*out = new(UsageEventResource)
+		**out = **in
+	}
+	if in.ServiceBroker != nil {
+		in, out := &in.ServiceBroker, &out.ServiceBroker
+		*out = new(UsageEventResource)
+		**out = **in
+	}
+	out.Space = in.Space
+	in.Timestamp.DeepCopyInto(&out.Timestamp)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceUsageEventSpec.
+func (in *CFServiceUsageEventSpec) DeepCopy() *CFServiceUsageEventSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFServiceUsageEventSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSidecar) DeepCopyInto(out *CFSidecar) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSidecar.
+func (in *CFSidecar) DeepCopy() *CFSidecar {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceInstance)
+	out := new(CFSidecar)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFServiceInstance) DeepCopyObject() runtime.Object {
+func (in *CFSidecar) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1305,31 +2673,31 @@ func (in *CFServiceInstance) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceInstanceList) DeepCopyInto(out *CFServiceInstanceList) {
+func (in *CFSidecarList) DeepCopyInto(out *CFSidecarList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFServiceInstance, len(*in))
+		*out = make([]CFSidecar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstanceList.
-func (in *CFServiceInstanceList) DeepCopy() *CFServiceInstanceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSidecarList.
+func (in *CFSidecarList) DeepCopy() *CFSidecarList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceInstanceList)
+	out := new(CFSidecarList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFServiceInstanceList) DeepCopyObject() runtime.Object {
+func (in *CFSidecarList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1337,74 +2705,105 @@ func (in *CFServiceInstanceList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceInstanceSpec) DeepCopyInto(out *CFServiceInstanceSpec) {
+func (in *CFSidecarSpec) DeepCopyInto(out *CFSidecarSpec) {
 	*out = *in
-	if in.ServiceLabel != nil {
-		in, out := &in.ServiceLabel, &out.ServiceLabel
-		*out = new(string)
-		**out = **in
-	}
-	if in.Tags != nil {
-		in, out := &in.Tags, &out.Tags
+	out.AppRef = in.AppRef
+	if in.ProcessTypes != nil {
+		in, out := &in.ProcessTypes, &out.ProcessTypes
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstanceSpec.
-func (in *CFServiceInstanceSpec) DeepCopy() *CFServiceInstanceSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSidecarSpec.
+func (in *CFSidecarSpec) DeepCopy() *CFSidecarSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceInstanceSpec)
+	out := new(CFSidecarSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFServiceInstanceStatus) DeepCopyInto(out *CFServiceInstanceStatus) {
+func (in *CFSpace) DeepCopyInto(out *CFSpace) {
 	*out = *in
-	out.Binding = in.Binding
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpace.
+func (in *CFSpace) DeepCopy() *CFSpace {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSpace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFSpace) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSpaceList) DeepCopyInto(out *CFSpaceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]CFSpace, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFServiceInstanceStatus.
-func (in *CFServiceInstanceStatus) DeepCopy() *CFServiceInstanceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceList.
+func (in *CFSpaceList) DeepCopy() *CFSpaceList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFServiceInstanceStatus)
+	out := new(CFSpaceList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CFSpaceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFSpace) DeepCopyInto(out *CFSpace) {
+func (in *CFSpaceQuota) DeepCopyInto(out *CFSpaceQuota) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpace.
-func (in *CFSpace) DeepCopy() *CFSpace {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceQuota.
+func (in *CFSpaceQuota) DeepCopy() *CFSpaceQuota {
 	if in == nil {
 		return nil
 	}
-	out := new(CFSpace)
+	out := new(CFSpaceQuota)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFSpace) DeepCopyObject() runtime.Object {
+func (in *CFSpaceQuota) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1412,40 +2811,66 @@ func (in *CFSpace) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *CFSpaceList) DeepCopyInto(out *CFSpaceList) {
+func (in *CFSpaceQuotaList) DeepCopyInto(out *CFSpaceQuotaList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]CFSpace, len(*in))
+		*out = make([]CFSpaceQuota, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceList.
-func (in *CFSpaceList) DeepCopy() *CFSpaceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceQuotaList.
+func (in *CFSpaceQuotaList) DeepCopy() *CFSpaceQuotaList {
 	if in == nil {
 		return nil
 	}
-	out := new(CFSpaceList)
+	out := new(CFSpaceQuotaList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *CFSpaceList) DeepCopyObject() runtime.Object {
+func (in *CFSpaceQuotaList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CFSpaceQuotaSpec) DeepCopyInto(out *CFSpaceQuotaSpec) {
+	*out = *in
+	in.Limits.DeepCopyInto(&out.Limits)
+	if in.Spaces != nil {
+		in, out := &in.Spaces, &out.Spaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceQuotaSpec.
+func (in *CFSpaceQuotaSpec) DeepCopy() *CFSpaceQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CFSpaceQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CFSpaceSpec) DeepCopyInto(out *CFSpaceSpec) {
 	*out = *in
+	if in.AllowSSH != nil {
+		in, out := &in.AllowSSH, &out.AllowSSH
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CFSpaceSpec.
@@ -1625,6 +3050,41 @@ func (in *HealthCheckData) DeepCopy() *HealthCheckData {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InputParametersSchema) DeepCopyInto(out *InputParametersSchema) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InputParametersSchema.
+func (in *InputParametersSchema) DeepCopy() *InputParametersSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(InputParametersSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LastOperation) DeepCopyInto(out *LastOperation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LastOperation.
+func (in *LastOperation) DeepCopy() *LastOperation {
+	if in == nil {
+		return nil
+	}
+	out := new(LastOperation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Lifecycle) DeepCopyInto(out *Lifecycle) {
 	*out = *in
@@ -1692,6 +3152,56 @@ func (in *ProcessType) DeepCopy() *ProcessType {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QuotaLimits) DeepCopyInto(out *QuotaLimits) {
+	*out = *in
+	if in.TotalMemoryInMB != nil {
+		in, out := &in.TotalMemoryInMB, &out.TotalMemoryInMB
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PerProcessMemoryInMB != nil {
+		in, out := &in.PerProcessMemoryInMB, &out.PerProcessMemoryInMB
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TotalInstances != nil {
+		in, out := &in.TotalInstances, &out.TotalInstances
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PerAppTasks != nil {
+		in, out := &in.PerAppTasks, &out.PerAppTasks
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TotalApps != nil {
+		in, out := &in.TotalApps, &out.TotalApps
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TotalServiceInstances != nil {
+		in, out := &in.TotalServiceInstances, &out.TotalServiceInstances
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TotalRoutes != nil {
+		in, out := &in.TotalRoutes, &out.TotalRoutes
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QuotaLimits.
+func (in *QuotaLimits) DeepCopy() *QuotaLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(QuotaLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Registry) DeepCopyInto(out *Registry) {
 	*out = *in
@@ -1839,6 +3349,136 @@ func (in *RunnerInfoStatus) DeepCopy() *RunnerInfoStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupRule) DeepCopyInto(out *SecurityGroupRule) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Code != nil {
+		in, out := &in.Code, &out.Code
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupRule.
+func (in *SecurityGroupRule) DeepCopy() *SecurityGroupRule {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecurityGroupWorkloads) DeepCopyInto(out *SecurityGroupWorkloads) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecurityGroupWorkloads.
+func (in *SecurityGroupWorkloads) DeepCopy() *SecurityGroupWorkloads {
+	if in == nil {
+		return nil
+	}
+	out := new(SecurityGroupWorkloads)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceBindingSchema) DeepCopyInto(out *ServiceBindingSchema) {
+	*out = *in
+	in.Create.DeepCopyInto(&out.Create)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBindingSchema.
+func (in *ServiceBindingSchema) DeepCopy() *ServiceBindingSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceBindingSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceBrokerCatalog) DeepCopyInto(out *ServiceBrokerCatalog) {
+	*out = *in
+	if in.Metadata != nil {
+		in, out := &in.Metadata, &out.Metadata
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBrokerCatalog.
+func (in *ServiceBrokerCatalog) DeepCopy() *ServiceBrokerCatalog {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceBrokerCatalog)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceInstanceSchema) DeepCopyInto(out *ServiceInstanceSchema) {
+	*out = *in
+	in.Create.DeepCopyInto(&out.Create)
+	in.Update.DeepCopyInto(&out.Update)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceSchema.
+func (in *ServiceInstanceSchema) DeepCopy() *ServiceInstanceSchema {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceInstanceSchema)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServicePlanSchemas) DeepCopyInto(out *ServicePlanSchemas) {
+	*out = *in
+	in.ServiceInstance.DeepCopyInto(&out.ServiceInstance)
+	in.ServiceBinding.DeepCopyInto(&out.ServiceBinding)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicePlanSchemas.
+func (in *ServicePlanSchemas) DeepCopy() *ServicePlanSchemas {
+	if in == nil {
+		return nil
+	}
+	out := new(ServicePlanSchemas)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServicePlanVisibility) DeepCopyInto(out *ServicePlanVisibility) {
+	*out = *in
+	if in.Organizations != nil {
+		in, out := &in.Organizations, &out.Organizations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServicePlanVisibility.
+func (in *ServicePlanVisibility) DeepCopy() *ServicePlanVisibility {
+	if in == nil {
+		return nil
+	}
+	out := new(ServicePlanVisibility)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskWorkload) DeepCopyInto(out *TaskWorkload) {
 	*out = *in
@@ -1919,6 +3559,20 @@ func (in *TaskWorkloadSpec) DeepCopyInto(out *TaskWorkloadSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskWorkloadSpec.
@@ -1952,3 +3606,18 @@ func (in *TaskWorkloadStatus) DeepCopy() *TaskWorkloadStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsageEventResource) DeepCopyInto(out *UsageEventResource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsageEventResource.
+func (in *UsageEventResource) DeepCopy() *UsageEventResource {
+	if in == nil {
+		return nil
+	}
+	out := new(UsageEventResource)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,59 @@
+package cleanup
+
+import (
+	"context"
+	"sort"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type RevisionCleaner struct {
+	k8sClient         client.Client
+	retainedRevisions int
+}
+
+func NewRevisionCleaner(k8sClient client.Client, retainedRevisions int) RevisionCleaner {
+	return RevisionCleaner{k8sClient: k8sClient, retainedRevisions: retainedRevisions}
+}
+
+func (c RevisionCleaner) Clean(ctx context.Context, app types.NamespacedName) error {
+	var cfApp korifiv1alpha1.CFApp
+	err := c.k8sClient.Get(ctx, app, &cfApp)
+	if err != nil {
+		return err
+	}
+
+	var cfRevisions korifiv1alpha1.CFRevisionList
+	err = c.k8sClient.List(ctx, &cfRevisions,
+		client.InNamespace(app.Namespace),
+		client.MatchingLabels{
+			korifiv1alpha1.CFAppGUIDLabelKey: app.Name,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	var deletableRevisions []korifiv1alpha1.CFRevision
+	for _, cfRevision := range cfRevisions.Items {
+		if cfRevision.Name == cfApp.Annotations[korifiv1alpha1.CFAppDeployedRevisionKey] {
+			continue
+		}
+		deletableRevisions = append(deletableRevisions, cfRevision)
+	}
+
+	sort.Slice(deletableRevisions, func(i, j int) bool {
+		return deletableRevisions[j].Spec.Version < deletableRevisions[i].Spec.Version
+	})
+
+	for i := c.retainedRevisions; i < len(deletableRevisions); i++ {
+		err = c.k8sClient.Delete(ctx, &deletableRevisions[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package cleanup_test
+
+import (
+	"strconv"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/cleanup"
+	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+var _ = Describe("RevisionCleaner", func() {
+	var (
+		cleaner                                                                   cleanup.RevisionCleaner
+		appGUID                                                                   string
+		cfApp                                                                     *korifiv1alpha1.CFApp
+		namespace                                                                 string
+		revOldest, revDeployed, revDeletable, revRetained, revLatest, revOtherApp *korifiv1alpha1.CFRevision
+		cleanErr                                                                  error
+	)
+
+	BeforeEach(func() {
+		cleaner = cleanup.NewRevisionCleaner(controllersClient, 2)
+
+		namespace = GenerateGUID()
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: namespace,
+			},
+		})).To(Succeed())
+
+		appGUID = GenerateGUID()
+
+		revOldest = createRevision(namespace, appGUID, 1)
+		revDeployed = createRevision(namespace, appGUID, 2)
+		revDeletable = createRevision(namespace, appGUID, 3)
+		revRetained = createRevision(namespace, appGUID, 4)
+		revLatest = createRevision(namespace, appGUID, 5)
+		revOtherApp = createRevision(namespace, "other-app-guid", 1)
+
+		cfApp = &korifiv1alpha1.CFApp{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      appGUID,
+				Namespace: namespace,
+				Annotations: map[string]string{
+					korifiv1alpha1.CFAppRevisionKey:         "7",
+					korifiv1alpha1.CFAppDeployedRevisionKey: revDeployed.Name,
+				},
+			},
+			Spec: korifiv1alpha1.CFAppSpec{
+				DisplayName: "an-app",
+				Lifecycle: korifiv1alpha1.Lifecycle{
+					Type: "buildpack",
+				},
+				DesiredState: "STARTED",
+			},
+		}
+		Expect(k8sClient.Create(ctx, cfApp)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		cleanErr = cleaner.Clean(ctx, types.NamespacedName{Name: appGUID, Namespace: namespace})
+	})
+
+	It("keeps the deployed revision and the most recent ones", func() {
+		Expect(cleanErr).NotTo(HaveOccurred())
+
+		Expect(revDeployed).To(BeFound())
+		Expect(revRetained).To(BeFound())
+		Expect(revLatest).To(BeFound())
+		Expect(revOtherApp).To(BeFound())
+
+		Expect(revOldest).To(BeNotFound())
+		Expect(revDeletable).To(BeNotFound())
+	})
+})
+
+func createRevision(namespace, appGUID string, version int) *korifiv1alpha1.CFRevision {
+	rev := &korifiv1alpha1.CFRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GenerateGUID(),
+			Namespace: namespace,
+			Labels: map[string]string{
+				korifiv1alpha1.CFAppGUIDLabelKey:         appGUID,
+				korifiv1alpha1.CFRevisionVersionLabelKey: strconv.Itoa(version),
+			},
+		},
+		Spec: korifiv1alpha1.CFRevisionSpec{
+			AppRef:  corev1.LocalObjectReference{Name: appGUID},
+			Version: version,
+		},
+	}
+	Expect(k8sClient.Create(ctx, rev)).To(Succeed())
+	return rev
+}
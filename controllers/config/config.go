@@ -22,6 +22,9 @@ type ControllerConfig struct {
 	CFRootNamespace                  string             `yaml:"cfRootNamespace"`
 	ContainerRegistrySecretNames     []string           `yaml:"containerRegistrySecretNames"`
 	TaskTTL                          string             `yaml:"taskTTL"`
+	AuditEventTTL                    string             `yaml:"auditEventTTL"`
+	UsageEventTTL                    string             `yaml:"usageEventTTL"`
+	RouteServiceSignatureRotation    string             `yaml:"routeServiceSignatureRotation"`
 	WorkloadsTLSSecretName           string             `yaml:"workloads_tls_secret_name"`
 	WorkloadsTLSSecretNamespace      string             `yaml:"workloads_tls_secret_namespace"`
 	BuilderName                      string             `yaml:"builderName"`
@@ -30,6 +33,7 @@ type ControllerConfig struct {
 	ExtraVCAPApplicationValues       map[string]any     `yaml:"extraVCAPApplicationValues"`
 	MaxRetainedPackagesPerApp        int                `yaml:"maxRetainedPackagesPerApp"`
 	MaxRetainedBuildsPerApp          int                `yaml:"maxRetainedBuildsPerApp"`
+	MaxRetainedRevisionsPerApp       int                `yaml:"maxRetainedRevisionsPerApp"`
 	LogLevel                         zapcore.Level      `yaml:"logLevel"`
 	SpaceFinalizerAppDeletionTimeout *int64             `yaml:"spaceFinalizerAppDeletionTimeout"`
 
@@ -37,11 +41,14 @@ type ControllerConfig struct {
 	JobTTL string `yaml:"jobTTL"`
 
 	// kpack-image-builder
-	ClusterBuilderName        string `yaml:"clusterBuilderName"`
-	BuilderServiceAccount     string `yaml:"builderServiceAccount"`
-	BuilderReadinessTimeout   string `yaml:"builderReadinessTimeout"`
-	ContainerRepositoryPrefix string `yaml:"containerRepositoryPrefix"`
-	ContainerRegistryType     string `yaml:"containerRegistryType"`
+	ClusterBuilderName        string   `yaml:"clusterBuilderName"`
+	StackClusterBuilderNames  []string `yaml:"stackClusterBuilderNames"`
+	DefaultStack              string   `yaml:"defaultStack"`
+	BuilderServiceAccount     string   `yaml:"builderServiceAccount"`
+	BuilderReadinessTimeout   string   `yaml:"builderReadinessTimeout"`
+	ContainerRepositoryPrefix string   `yaml:"containerRepositoryPrefix"`
+	ContainerRegistryType     string   `yaml:"containerRegistryType"`
+	StageInIsolationSegments  bool     `yaml:"stageInIsolationSegments"`
 }
 
 type CFProcessDefaults struct {
@@ -57,10 +64,13 @@ type CFStagingResources struct {
 }
 
 const (
-	defaultTaskTTL            = 30 * 24 * time.Hour
-	defaultTimeout      int64 = 60
-	defaultJobTTL             = 24 * time.Hour
-	defaultBuildCacheMB       = 2048
+	defaultTaskTTL                 = 30 * 24 * time.Hour
+	defaultAuditEventTTL           = 31 * 24 * time.Hour
+	defaultUsageEventTTL           = 31 * 24 * time.Hour
+	defaultSignatureRotation       = 24 * time.Hour
+	defaultTimeout           int64 = 60
+	defaultJobTTL                  = 24 * time.Hour
+	defaultBuildCacheMB            = 2048
 )
 
 func LoadFromPath(path string) (*ControllerConfig, error) {
@@ -109,6 +119,30 @@ func (c ControllerConfig) ParseTaskTTL() (time.Duration, error) {
 	return tools.ParseDuration(c.TaskTTL)
 }
 
+func (c ControllerConfig) ParseAuditEventTTL() (time.Duration, error) {
+	if c.AuditEventTTL == "" {
+		return defaultAuditEventTTL, nil
+	}
+
+	return tools.ParseDuration(c.AuditEventTTL)
+}
+
+func (c ControllerConfig) ParseUsageEventTTL() (time.Duration, error) {
+	if c.UsageEventTTL == "" {
+		return defaultUsageEventTTL, nil
+	}
+
+	return tools.ParseDuration(c.UsageEventTTL)
+}
+
+func (c ControllerConfig) ParseRouteServiceSignatureRotation() (time.Duration, error) {
+	if c.RouteServiceSignatureRotation == "" {
+		return defaultSignatureRotation, nil
+	}
+
+	return tools.ParseDuration(c.RouteServiceSignatureRotation)
+}
+
 func (c ControllerConfig) ParseBuilderReadinessTimeout() (time.Duration, error) {
 	return tools.ParseDuration(c.BuilderReadinessTimeout)
 }
@@ -47,6 +47,8 @@ var _ = Describe("LoadFromPath", func() {
 			WorkloadsTLSSecretNamespace:      "workloadsTLSSecretNamespace",
 			BuilderName:                      "buildReconciler",
 			RunnerName:                       "statefulset-runner",
+			StackClusterBuilderNames:         []string{"stackClusterBuilderName"},
+			DefaultStack:                     "defaultStack",
 			JobTTL:                           "jobTTL",
 			LogLevel:                         zapcore.DebugLevel,
 			SpaceFinalizerAppDeletionTimeout: tools.PtrTo(int64(42)),
@@ -87,6 +89,8 @@ var _ = Describe("LoadFromPath", func() {
 			RunnerName:                       "statefulset-runner",
 			NamespaceLabels:                  map[string]string{},
 			ExtraVCAPApplicationValues:       map[string]any{},
+			StackClusterBuilderNames:         []string{"stackClusterBuilderName"},
+			DefaultStack:                     "defaultStack",
 			JobTTL:                           "jobTTL",
 			LogLevel:                         zapcore.DebugLevel,
 			SpaceFinalizerAppDeletionTimeout: tools.PtrTo(int64(42)),
@@ -180,6 +184,144 @@ var _ = Describe("ParseTaskTTL", func() {
 	})
 })
 
+var _ = Describe("ParseAuditEventTTL", func() {
+	var (
+		auditEventTTLString string
+		auditEventTTL       time.Duration
+		parseErr            error
+	)
+
+	BeforeEach(func() {
+		auditEventTTLString = ""
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			AuditEventTTL: auditEventTTLString,
+		}
+
+		auditEventTTL, parseErr = cfg.ParseAuditEventTTL()
+	})
+
+	It("return 31 days by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(auditEventTTL).To(Equal(31 * 24 * time.Hour))
+	})
+
+	When("entering something parseable by tools.ParseDuration", func() {
+		BeforeEach(func() {
+			auditEventTTLString = "7d"
+		})
+
+		It("parses ok", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(auditEventTTL).To(Equal(7 * 24 * time.Hour))
+		})
+	})
+
+	When("entering something that cannot be parsed", func() {
+		BeforeEach(func() {
+			auditEventTTLString = "foreva"
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("ParseUsageEventTTL", func() {
+	var (
+		usageEventTTLString string
+		usageEventTTL       time.Duration
+		parseErr            error
+	)
+
+	BeforeEach(func() {
+		usageEventTTLString = ""
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			UsageEventTTL: usageEventTTLString,
+		}
+
+		usageEventTTL, parseErr = cfg.ParseUsageEventTTL()
+	})
+
+	It("return 31 days by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(usageEventTTL).To(Equal(31 * 24 * time.Hour))
+	})
+
+	When("entering something parseable by tools.ParseDuration", func() {
+		BeforeEach(func() {
+			usageEventTTLString = "90d"
+		})
+
+		It("parses ok", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(usageEventTTL).To(Equal(90 * 24 * time.Hour))
+		})
+	})
+
+	When("entering something that cannot be parsed", func() {
+		BeforeEach(func() {
+			usageEventTTLString = "foreva"
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
+
+var _ = Describe("ParseRouteServiceSignatureRotation", func() {
+	var (
+		rotationString string
+		rotation       time.Duration
+		parseErr       error
+	)
+
+	BeforeEach(func() {
+		rotationString = ""
+	})
+
+	JustBeforeEach(func() {
+		cfg := config.ControllerConfig{
+			RouteServiceSignatureRotation: rotationString,
+		}
+
+		rotation, parseErr = cfg.ParseRouteServiceSignatureRotation()
+	})
+
+	It("return 1 day by default", func() {
+		Expect(parseErr).NotTo(HaveOccurred())
+		Expect(rotation).To(Equal(24 * time.Hour))
+	})
+
+	When("entering something parseable by tools.ParseDuration", func() {
+		BeforeEach(func() {
+			rotationString = "6h"
+		})
+
+		It("parses ok", func() {
+			Expect(parseErr).NotTo(HaveOccurred())
+			Expect(rotation).To(Equal(6 * time.Hour))
+		})
+	})
+
+	When("entering something that cannot be parsed", func() {
+		BeforeEach(func() {
+			rotationString = "sometimes"
+		})
+
+		It("returns an error", func() {
+			Expect(parseErr).To(HaveOccurred())
+		})
+	})
+})
+
 var _ = Describe("ParseJobTTL", func() {
 	var (
 		jobTTL    time.Duration
@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/url"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
@@ -39,6 +40,14 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	RouteServiceForwardedURLHeader = "X-CF-Forwarded-Url"
+	RouteServiceSignatureHeader    = "X-CF-Proxy-Signature"
+	RouteServiceMetadataHeader     = "X-CF-Proxy-Metadata"
 )
 
 // CFRouteReconciler reconciles a CFRoute object to create Contour resources
@@ -61,7 +70,19 @@ func NewCFRouteReconciler(
 
 func (r *CFRouteReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&korifiv1alpha1.CFRoute{})
+		For(&korifiv1alpha1.CFRoute{}).
+		Watches(
+			&korifiv1alpha1.CFServiceRouteBinding{},
+			handler.EnqueueRequestsFromMapFunc(serviceRouteBindingToRoute),
+		)
+}
+
+func serviceRouteBindingToRoute(ctx context.Context, o client.Object) []reconcile.Request {
+	routeBinding := o.(*korifiv1alpha1.CFServiceRouteBinding)
+
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{Name: routeBinding.Spec.RouteRef.Name, Namespace: routeBinding.Namespace},
+	}}
 }
 
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfroutes,verbs=get;list;watch;create;update;patch;delete
@@ -74,6 +95,9 @@ func (r *CFRouteReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder
 
 //+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceroutebindings,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get
+
 func (r *CFRouteReconciler) ReconcileResource(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) (ctrl.Result, error) {
 	log := shared.ObjectLogger(r.log, cfRoute)
 	ctx = logr.NewContext(ctx, log)
@@ -105,7 +129,7 @@ func (r *CFRouteReconciler) ReconcileResource(ctx context.Context, cfRoute *kori
 		return ctrl.Result{}, err
 	}
 
-	err = r.createOrPatchRouteProxy(ctx, cfRoute)
+	err = r.createOrPatchRouteProxy(ctx, cfRoute, cfDomain)
 	if err != nil {
 		cfRoute.Status = createInvalidRouteStatus(log, cfRoute, "Error creating/patching Route Proxy", "CreatePatchRouteProxy", err.Error())
 		return ctrl.Result{}, err
@@ -264,9 +288,15 @@ func (r *CFRouteReconciler) createOrPatchServices(ctx context.Context, cfRoute *
 	return nil
 }
 
-func (r *CFRouteReconciler) createOrPatchRouteProxy(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) error {
+func (r *CFRouteReconciler) createOrPatchRouteProxy(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchRouteProxy").WithValues("httpProxyNamespace", cfRoute.Namespace, "httpProxyName", cfRoute.Name)
 
+	routeService, err := r.getRouteService(ctx, cfRoute)
+	if err != nil {
+		log.Info("failed to get route service", "reason", err)
+		return err
+	}
+
 	services := make([]contourv1.Service, 0, len(cfRoute.Spec.Destinations))
 
 	for i, destination := range cfRoute.Spec.Destinations {
@@ -296,6 +326,10 @@ func (r *CFRouteReconciler) createOrPatchRouteProxy(ctx context.Context, cfRoute
 					EnableWebsockets: true,
 				},
 			}
+
+			if routeService != nil {
+				routeHTTPProxy.Spec.Routes = r.routeServiceRoutes(cfRoute, cfDomain, routeService, routeHTTPProxy.Spec.Routes[0])
+			}
 		}
 
 		err := controllerutil.SetControllerReference(cfRoute, routeHTTPProxy, r.scheme)
@@ -315,6 +349,111 @@ func (r *CFRouteReconciler) createOrPatchRouteProxy(ctx context.Context, cfRoute
 	return nil
 }
 
+type routeService struct {
+	serviceName string
+	port        int
+	url         *url.URL
+	signature   string
+}
+
+// getRouteService returns the route service the route is bound to, or nil if
+// the route has no ready service route binding
+func (r *CFRouteReconciler) getRouteService(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute) (*routeService, error) {
+	routeBindings := new(korifiv1alpha1.CFServiceRouteBindingList)
+	err := r.client.List(ctx, routeBindings,
+		client.InNamespace(cfRoute.Namespace),
+		client.MatchingFields{shared.IndexServiceRouteBindingRouteGUID: cfRoute.Name},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service route bindings: %w", err)
+	}
+
+	for _, routeBinding := range routeBindings.Items {
+		if !routeBinding.GetDeletionTimestamp().IsZero() || !meta.IsStatusConditionTrue(routeBinding.Status.Conditions, shared.StatusConditionReady) {
+			continue
+		}
+
+		routeServiceURL, err := url.Parse(routeBinding.Status.RouteServiceURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid route service URL %q: %w", routeBinding.Status.RouteServiceURL, err)
+		}
+
+		externalService := new(corev1.Service)
+		err = r.client.Get(ctx, types.NamespacedName{Name: routeBinding.RouteServiceName(), Namespace: routeBinding.Namespace}, externalService)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get route service: %w", err)
+		}
+		if len(externalService.Spec.Ports) == 0 {
+			return nil, fmt.Errorf("route service %q has no ports", externalService.Name)
+		}
+
+		signatureSecret := new(corev1.Secret)
+		err = r.client.Get(ctx, types.NamespacedName{Name: routeBinding.RouteServiceName(), Namespace: routeBinding.Namespace}, signatureSecret)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get route service signature: %w", err)
+		}
+
+		return &routeService{
+			serviceName: routeBinding.RouteServiceName(),
+			port:        int(externalService.Spec.Ports[0].Port),
+			url:         routeServiceURL,
+			signature:   string(signatureSecret.Data[korifiv1alpha1.CFServiceRouteBindingRouteServiceSignatureKey]),
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// routeServiceRoutes forwards requests to the route service, unless they carry
+// the route service signature, i.e. they have been sent back by the route
+// service, in which case they are forwarded to the app
+func (r *CFRouteReconciler) routeServiceRoutes(cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain, rs *routeService, appRoute contourv1.Route) []contourv1.Route {
+	appRoute.Conditions = append(appRoute.Conditions, contourv1.MatchCondition{
+		Header: &contourv1.HeaderMatchCondition{
+			Name:  RouteServiceSignatureHeader,
+			Exact: rs.signature,
+		},
+	})
+
+	forwardedURLScheme := "http"
+	if r.controllerConfig.WorkloadsTLSSecretNameWithNamespace() != "" {
+		forwardedURLScheme = "https"
+	}
+
+	routeServiceBackend := contourv1.Service{
+		Name: rs.serviceName,
+		Port: rs.port,
+	}
+	if rs.url.Scheme == "https" {
+		protocol := "tls"
+		routeServiceBackend.Protocol = &protocol
+	}
+
+	routeServiceRoute := contourv1.Route{
+		Conditions: []contourv1.MatchCondition{
+			{Prefix: cfRoute.Spec.Path},
+		},
+		Services: []contourv1.Service{routeServiceBackend},
+		RequestHeadersPolicy: &contourv1.HeadersPolicy{
+			Set: []contourv1.HeaderValue{
+				{Name: "Host", Value: rs.url.Host},
+				{Name: RouteServiceForwardedURLHeader, Value: fmt.Sprintf("%s://%s%%REQ(:path)%%", forwardedURLScheme, buildFQDN(cfRoute, cfDomain))},
+				{Name: RouteServiceSignatureHeader, Value: rs.signature},
+				{Name: RouteServiceMetadataHeader, Value: "%REQ(X-Request-Id)%"},
+			},
+		},
+		EnableWebsockets: true,
+	}
+
+	if routeServicePath := rs.url.EscapedPath(); routeServicePath != "" && routeServicePath != "/" {
+		routeServiceRoute.PathRewritePolicy = &contourv1.PathRewritePolicy{
+			ReplacePrefix: []contourv1.ReplacePrefix{{Replacement: routeServicePath}},
+		}
+	}
+
+	return []contourv1.Route{appRoute, routeServiceRoute}
+}
+
 func (r *CFRouteReconciler) createOrPatchFQDNProxy(ctx context.Context, cfRoute *korifiv1alpha1.CFRoute, cfDomain *korifiv1alpha1.CFDomain) error {
 	fqdn := buildFQDN(cfRoute, cfDomain)
 
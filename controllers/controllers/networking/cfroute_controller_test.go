@@ -16,6 +16,7 @@ import (
 	contourv1 "github.com/projectcontour/contour/apis/projectcontour/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -239,6 +240,121 @@ var _ = Describe("CFRouteReconciler Integration Tests", func() {
 				g.Expect(cfRoute.Status.Destinations).To(Equal(cfRoute.Spec.Destinations))
 			}).Should(Succeed())
 		})
+
+		When("the CFRoute is bound to a route service", func() {
+			var routeBinding *korifiv1alpha1.CFServiceRouteBinding
+
+			JustBeforeEach(func() {
+				routeBinding = &korifiv1alpha1.CFServiceRouteBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      GenerateGUID(),
+						Namespace: testNamespace,
+					},
+					Spec: korifiv1alpha1.CFServiceRouteBindingSpec{
+						Service: corev1.ObjectReference{
+							Kind:       "CFServiceInstance",
+							APIVersion: korifiv1alpha1.GroupVersion.Identifier(),
+							Name:       GenerateGUID(),
+						},
+						RouteRef: corev1.LocalObjectReference{Name: testRouteGUID},
+					},
+				}
+
+				Expect(adminClient.Create(ctx, &corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      routeBinding.RouteServiceName(),
+						Namespace: testNamespace,
+					},
+					Spec: corev1.ServiceSpec{
+						Type:  corev1.ServiceTypeClusterIP,
+						Ports: []corev1.ServicePort{{Port: 443}},
+					},
+				})).To(Succeed())
+				Expect(adminClient.Create(ctx, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      routeBinding.RouteServiceName(),
+						Namespace: testNamespace,
+					},
+					StringData: map[string]string{
+						korifiv1alpha1.CFServiceRouteBindingRouteServiceSignatureKey: "the-signature",
+					},
+				})).To(Succeed())
+
+				Expect(adminClient.Create(ctx, routeBinding)).To(Succeed())
+				Expect(k8s.Patch(ctx, adminClient, routeBinding, func() {
+					routeBinding.Status.RouteServiceURL = "https://route-service.example.com"
+					meta.SetStatusCondition(&routeBinding.Status.Conditions, metav1.Condition{
+						Type:   "Ready",
+						Status: metav1.ConditionTrue,
+						Reason: "RouteServiceReady",
+					})
+				})).To(Succeed())
+			})
+
+			It("forwards requests without the route service signature to the route service", func() {
+				Eventually(func(g Gomega) {
+					var proxy contourv1.HTTPProxy
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, &proxy)).To(Succeed())
+					g.Expect(proxy.Spec.Routes).To(ConsistOf(
+						contourv1.Route{
+							Conditions: []contourv1.MatchCondition{
+								{Prefix: "/test/path"},
+								{Header: &contourv1.HeaderMatchCondition{Name: "X-CF-Proxy-Signature", Exact: "the-signature"}},
+							},
+							Services: []contourv1.Service{
+								{
+									Name: fmt.Sprintf("s-%s", cfRoute.Spec.Destinations[0].GUID),
+									Port: cfRoute.Spec.Destinations[0].Port,
+								},
+							},
+							EnableWebsockets: true,
+						},
+						contourv1.Route{
+							Conditions: []contourv1.MatchCondition{
+								{Prefix: "/test/path"},
+							},
+							Services: []contourv1.Service{
+								{
+									Name:     routeBinding.RouteServiceName(),
+									Port:     443,
+									Protocol: tools.PtrTo("tls"),
+								},
+							},
+							RequestHeadersPolicy: &contourv1.HeadersPolicy{
+								Set: []contourv1.HeaderValue{
+									{Name: "Host", Value: "route-service.example.com"},
+									{Name: "X-CF-Forwarded-Url", Value: "https://" + fqdnProxyName() + "%REQ(:path)%"},
+									{Name: "X-CF-Proxy-Signature", Value: "the-signature"},
+									{Name: "X-CF-Proxy-Metadata", Value: "%REQ(X-Request-Id)%"},
+								},
+							},
+							EnableWebsockets: true,
+						},
+					))
+				}).Should(Succeed())
+			})
+
+			When("the route binding is deleted", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						var proxy contourv1.HTTPProxy
+						g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, &proxy)).To(Succeed())
+						g.Expect(proxy.Spec.Routes).To(HaveLen(2))
+					}).Should(Succeed())
+
+					Expect(adminClient.Delete(ctx, routeBinding)).To(Succeed())
+				})
+
+				It("forwards all requests to the app again", func() {
+					Eventually(func(g Gomega) {
+						var proxy contourv1.HTTPProxy
+						g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: testRouteGUID, Namespace: testNamespace}, &proxy)).To(Succeed())
+						g.Expect(proxy.Spec.Routes).To(HaveLen(1))
+						g.Expect(proxy.Spec.Routes[0].Conditions).To(ConsistOf(contourv1.MatchCondition{Prefix: "/test/path"}))
+					}).Should(Succeed())
+				})
+			})
+		})
 	})
 
 	When("there are multiple routes in the space", func() {
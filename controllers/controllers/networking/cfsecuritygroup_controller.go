@@ -0,0 +1,296 @@
+package networking
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// KpackBuildPodLabelKey is set by kpack on the pods building app images
+	KpackBuildPodLabelKey = "kpack.io/build"
+
+	RunningNetworkPolicySuffix = "running"
+	StagingNetworkPolicySuffix = "staging"
+)
+
+type CFSecurityGroupReconciler struct {
+	client        client.Client
+	scheme        *runtime.Scheme
+	rootNamespace string
+	log           logr.Logger
+}
+
+func NewCFSecurityGroupReconciler(
+	client client.Client,
+	scheme *runtime.Scheme,
+	rootNamespace string,
+	log logr.Logger,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFSecurityGroup, *korifiv1alpha1.CFSecurityGroup] {
+	securityGroupReconciler := CFSecurityGroupReconciler{client: client, scheme: scheme, rootNamespace: rootNamespace, log: log}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFSecurityGroup, *korifiv1alpha1.CFSecurityGroup](log, client, &securityGroupReconciler)
+}
+
+func (r *CFSecurityGroupReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFSecurityGroup{}).
+		Watches(
+			&korifiv1alpha1.CFSpace{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueSecurityGroupRequests),
+		)
+}
+
+func (r *CFSecurityGroupReconciler) enqueueSecurityGroupRequests(ctx context.Context, o client.Object) []reconcile.Request {
+	securityGroups := new(korifiv1alpha1.CFSecurityGroupList)
+	if err := r.client.List(ctx, securityGroups, client.InNamespace(r.rootNamespace)); err != nil {
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, securityGroup := range securityGroups.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: securityGroup.Name, Namespace: securityGroup.Namespace},
+		})
+	}
+
+	return requests
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfsecuritygroups,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfsecuritygroups/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfsecuritygroups/finalizers,verbs=update
+//+kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+
+func (r *CFSecurityGroupReconciler) ReconcileResource(ctx context.Context, cfSecurityGroup *korifiv1alpha1.CFSecurityGroup) (ctrl.Result, error) {
+	log := shared.ObjectLogger(r.log, cfSecurityGroup)
+	ctx = logr.NewContext(ctx, log)
+
+	if !cfSecurityGroup.GetDeletionTimestamp().IsZero() {
+		return r.finalizeCFSecurityGroup(ctx, cfSecurityGroup)
+	}
+
+	cfSecurityGroup.Status.ObservedGeneration = cfSecurityGroup.Generation
+	log.V(1).Info("set observed generation", "generation", cfSecurityGroup.Status.ObservedGeneration)
+
+	egressRules, err := toEgressRules(cfSecurityGroup.Spec.Rules)
+	if err != nil {
+		log.Info("invalid security group rules", "reason", err)
+		setSecurityGroupNotReady(cfSecurityGroup, "InvalidRules", err.Error())
+		return ctrl.Result{}, nil
+	}
+
+	// A policy without egress rules would deny all egress of the pods it
+	// selects, so groups without rules that can be rendered, e.g. made of icmp
+	// rules only, get no policies at all
+	desiredPolicies := []networkPolicyTarget{}
+	if len(egressRules) > 0 {
+		desiredPolicies, err = r.desiredNetworkPolicies(ctx, cfSecurityGroup)
+		if err != nil {
+			log.Info("failed to list spaces", "reason", err)
+			setSecurityGroupNotReady(cfSecurityGroup, "ListSpacesFailed", err.Error())
+			return ctrl.Result{}, err
+		}
+	}
+
+	for _, desiredPolicy := range desiredPolicies {
+		if err = r.createOrPatchNetworkPolicy(ctx, cfSecurityGroup, desiredPolicy, egressRules); err != nil {
+			log.Info("failed to create or patch network policy", "reason", err)
+			setSecurityGroupNotReady(cfSecurityGroup, "NetworkPolicyFailed", err.Error())
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err = r.deleteStaleNetworkPolicies(ctx, cfSecurityGroup, desiredPolicies); err != nil {
+		log.Info("failed to delete stale network policies", "reason", err)
+		setSecurityGroupNotReady(cfSecurityGroup, "NetworkPolicyFailed", err.Error())
+		return ctrl.Result{}, err
+	}
+
+	meta.SetStatusCondition(&cfSecurityGroup.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.ReadyConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             "NetworkPoliciesApplied",
+		ObservedGeneration: cfSecurityGroup.Generation,
+	})
+
+	return ctrl.Result{}, nil
+}
+
+type networkPolicyTarget struct {
+	namespace string
+	suffix    string
+}
+
+func (r *CFSecurityGroupReconciler) desiredNetworkPolicies(ctx context.Context, cfSecurityGroup *korifiv1alpha1.CFSecurityGroup) ([]networkPolicyTarget, error) {
+	spaces := new(korifiv1alpha1.CFSpaceList)
+	if err := r.client.List(ctx, spaces); err != nil {
+		return nil, err
+	}
+
+	targets := []networkPolicyTarget{}
+	for _, space := range spaces.Items {
+		if !space.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+
+		workloads := cfSecurityGroup.Spec.GloballyEnabled
+		if spaceWorkloads, ok := cfSecurityGroup.Spec.Spaces[space.Name]; ok {
+			workloads.Running = workloads.Running || spaceWorkloads.Running
+			workloads.Staging = workloads.Staging || spaceWorkloads.Staging
+		}
+
+		// The namespace of a space is named after the space GUID
+		if workloads.Running {
+			targets = append(targets, networkPolicyTarget{namespace: space.Name, suffix: RunningNetworkPolicySuffix})
+		}
+		if workloads.Staging {
+			targets = append(targets, networkPolicyTarget{namespace: space.Name, suffix: StagingNetworkPolicySuffix})
+		}
+	}
+
+	return targets, nil
+}
+
+func (r *CFSecurityGroupReconciler) createOrPatchNetworkPolicy(
+	ctx context.Context,
+	cfSecurityGroup *korifiv1alpha1.CFSecurityGroup,
+	target networkPolicyTarget,
+	egressRules []networkingv1.NetworkPolicyEgressRule,
+) error {
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      NetworkPolicyName(cfSecurityGroup.Name, target.suffix),
+			Namespace: target.namespace,
+		},
+	}
+
+	podSelectorKey := korifiv1alpha1.CFAppGUIDLabelKey
+	if target.suffix == StagingNetworkPolicySuffix {
+		podSelectorKey = KpackBuildPodLabelKey
+	}
+
+	_, err := controllerutil.CreateOrPatch(ctx, r.client, networkPolicy, func() error {
+		if networkPolicy.Labels == nil {
+			networkPolicy.Labels = map[string]string{}
+		}
+		networkPolicy.Labels[korifiv1alpha1.CFSecurityGroupGUIDLabelKey] = cfSecurityGroup.Name
+
+		networkPolicy.Spec = networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{
+					Key:      podSelectorKey,
+					Operator: metav1.LabelSelectorOpExists,
+				}},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+			Egress:      egressRules,
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create or patch network policy %s/%s: %w", networkPolicy.Namespace, networkPolicy.Name, err)
+	}
+
+	return nil
+}
+
+func (r *CFSecurityGroupReconciler) deleteStaleNetworkPolicies(ctx context.Context, cfSecurityGroup *korifiv1alpha1.CFSecurityGroup, desiredPolicies []networkPolicyTarget) error {
+	desired := map[string]bool{}
+	for _, target := range desiredPolicies {
+		desired[target.namespace+"/"+NetworkPolicyName(cfSecurityGroup.Name, target.suffix)] = true
+	}
+
+	networkPolicies, err := r.listNetworkPolicies(ctx, cfSecurityGroup)
+	if err != nil {
+		return err
+	}
+
+	for i := range networkPolicies {
+		if desired[networkPolicies[i].Namespace+"/"+networkPolicies[i].Name] {
+			continue
+		}
+
+		if err = r.client.Delete(ctx, &networkPolicies[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete network policy %s/%s: %w", networkPolicies[i].Namespace, networkPolicies[i].Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *CFSecurityGroupReconciler) listNetworkPolicies(ctx context.Context, cfSecurityGroup *korifiv1alpha1.CFSecurityGroup) ([]networkingv1.NetworkPolicy, error) {
+	networkPolicies := new(networkingv1.NetworkPolicyList)
+	err := r.client.List(ctx, networkPolicies, client.MatchingLabels{korifiv1alpha1.CFSecurityGroupGUIDLabelKey: cfSecurityGroup.Name})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network policies: %w", err)
+	}
+
+	return networkPolicies.Items, nil
+}
+
+func (r *CFSecurityGroupReconciler) finalizeCFSecurityGroup(ctx context.Context, cfSecurityGroup *korifiv1alpha1.CFSecurityGroup) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("finalizeCFSecurityGroup")
+
+	if !controllerutil.ContainsFinalizer(cfSecurityGroup, korifiv1alpha1.CFSecurityGroupFinalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	// Network policies live in the space namespaces, so they cannot be garbage collected via owner references
+	networkPolicies, err := r.listNetworkPolicies(ctx, cfSecurityGroup)
+	if err != nil {
+		log.Info("failed to list network policies", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	if len(networkPolicies) == 0 {
+		if controllerutil.RemoveFinalizer(cfSecurityGroup, korifiv1alpha1.CFSecurityGroupFinalizerName) {
+			log.V(1).Info("finalizer removed")
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	for i := range networkPolicies {
+		err = r.client.Delete(ctx, &networkPolicies[i])
+		if client.IgnoreNotFound(err) != nil {
+			log.Info("failed to delete network policy", "reason", err)
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{RequeueAfter: time.Second}, nil
+}
+
+func setSecurityGroupNotReady(cfSecurityGroup *korifiv1alpha1.CFSecurityGroup, reason, message string) {
+	meta.SetStatusCondition(&cfSecurityGroup.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.ReadyConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: cfSecurityGroup.Generation,
+	})
+}
+
+// NetworkPolicyName returns the name of the network policy rendered for the
+// running or staging workloads of a security group
+func NetworkPolicyName(securityGroupGUID, suffix string) string {
+	return securityGroupGUID + "-" + suffix
+}
@@ -0,0 +1,297 @@
+package networking_test
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	. "code.cloudfoundry.org/korifi/controllers/controllers/networking"
+	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFSecurityGroupReconciler Integration Tests", func() {
+	var (
+		ctx             context.Context
+		spaceGUID       string
+		otherSpaceGUID  string
+		cfSecurityGroup *korifiv1alpha1.CFSecurityGroup
+	)
+
+	createSpace := func(orgGUID string) string {
+		spaceGUID := GenerateGUID()
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: spaceGUID},
+		})).To(Succeed())
+		Expect(adminClient.Create(ctx, &korifiv1alpha1.CFSpace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      spaceGUID,
+				Namespace: orgGUID,
+			},
+			Spec: korifiv1alpha1.CFSpaceSpec{
+				DisplayName: GenerateGUID(),
+			},
+		})).To(Succeed())
+
+		return spaceGUID
+	}
+
+	getNetworkPolicy := func(g Gomega, namespace, suffix string) *networkingv1.NetworkPolicy {
+		networkPolicy := &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      NetworkPolicyName(cfSecurityGroup.Name, suffix),
+				Namespace: namespace,
+			},
+		}
+		g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(networkPolicy), networkPolicy)).To(Succeed())
+
+		return networkPolicy
+	}
+
+	networkPolicyExists := func(namespace, suffix string) func() bool {
+		return func() bool {
+			err := adminClient.Get(ctx, client.ObjectKey{Name: NetworkPolicyName(cfSecurityGroup.Name, suffix), Namespace: namespace}, &networkingv1.NetworkPolicy{})
+			return err == nil
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		orgGUID := GenerateGUID()
+		Expect(adminClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: orgGUID},
+		})).To(Succeed())
+		Expect(adminClient.Create(ctx, &korifiv1alpha1.CFOrg{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      orgGUID,
+				Namespace: rootNamespace,
+			},
+			Spec: korifiv1alpha1.CFOrgSpec{
+				DisplayName: GenerateGUID(),
+			},
+		})).To(Succeed())
+
+		spaceGUID = createSpace(orgGUID)
+		otherSpaceGUID = createSpace(orgGUID)
+
+		cfSecurityGroup = &korifiv1alpha1.CFSecurityGroup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      GenerateGUID(),
+				Namespace: rootNamespace,
+			},
+			Spec: korifiv1alpha1.CFSecurityGroupSpec{
+				DisplayName: GenerateGUID(),
+				Rules: []korifiv1alpha1.SecurityGroupRule{
+					{Protocol: korifiv1alpha1.ProtocolTCP, Destination: "10.0.0.1", Ports: "443,8000-9000"},
+					{Protocol: korifiv1alpha1.ProtocolUDP, Destination: "10.0.1.0/24"},
+					{Protocol: korifiv1alpha1.ProtocolAll, Destination: "192.168.0.1-192.168.0.5"},
+					{Protocol: korifiv1alpha1.ProtocolICMP, Destination: "0.0.0.0/0", Type: tools.PtrTo[int32](0), Code: tools.PtrTo[int32](0)},
+				},
+				Spaces: map[string]korifiv1alpha1.SecurityGroupWorkloads{},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		Expect(adminClient.Create(ctx, cfSecurityGroup)).To(Succeed())
+	})
+
+	It("sets the Ready condition", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+			g.Expect(cfSecurityGroup.Status.ObservedGeneration).To(Equal(cfSecurityGroup.Generation))
+			g.Expect(meta.IsStatusConditionTrue(cfSecurityGroup.Status.Conditions, korifiv1alpha1.ReadyConditionType)).To(BeTrue())
+		}).Should(Succeed())
+	})
+
+	It("does not create network policies", func() {
+		Consistently(networkPolicyExists(spaceGUID, RunningNetworkPolicySuffix)).Should(BeFalse())
+		Consistently(networkPolicyExists(spaceGUID, StagingNetworkPolicySuffix)).Should(BeFalse())
+	})
+
+	When("the security group is bound to a space for running apps", func() {
+		BeforeEach(func() {
+			cfSecurityGroup.Spec.Spaces[spaceGUID] = korifiv1alpha1.SecurityGroupWorkloads{Running: true}
+		})
+
+		It("creates a network policy selecting the app pods in the space", func() {
+			Eventually(func(g Gomega) {
+				networkPolicy := getNetworkPolicy(g, spaceGUID, RunningNetworkPolicySuffix)
+				g.Expect(networkPolicy.Labels).To(HaveKeyWithValue(korifiv1alpha1.CFSecurityGroupGUIDLabelKey, cfSecurityGroup.Name))
+				g.Expect(networkPolicy.Spec.PodSelector.MatchExpressions).To(ConsistOf(metav1.LabelSelectorRequirement{
+					Key:      korifiv1alpha1.CFAppGUIDLabelKey,
+					Operator: metav1.LabelSelectorOpExists,
+				}))
+				g.Expect(networkPolicy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeEgress))
+			}).Should(Succeed())
+		})
+
+		It("renders the rules into egress rules", func() {
+			tcp := corev1.ProtocolTCP
+			udp := corev1.ProtocolUDP
+			port443 := intstr.FromInt(443)
+			port8000 := intstr.FromInt(8000)
+
+			Eventually(func(g Gomega) {
+				networkPolicy := getNetworkPolicy(g, spaceGUID, RunningNetworkPolicySuffix)
+				g.Expect(networkPolicy.Spec.Egress).To(Equal([]networkingv1.NetworkPolicyEgressRule{
+					{
+						To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.1/32"}}},
+						Ports: []networkingv1.NetworkPolicyPort{
+							{Protocol: &tcp, Port: &port443},
+							{Protocol: &tcp, Port: &port8000, EndPort: tools.PtrTo[int32](9000)},
+						},
+					},
+					{
+						To:    []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.1.0/24"}}},
+						Ports: []networkingv1.NetworkPolicyPort{{Protocol: &udp}},
+					},
+					{
+						To: []networkingv1.NetworkPolicyPeer{
+							{IPBlock: &networkingv1.IPBlock{CIDR: "192.168.0.1/32"}},
+							{IPBlock: &networkingv1.IPBlock{CIDR: "192.168.0.2/31"}},
+							{IPBlock: &networkingv1.IPBlock{CIDR: "192.168.0.4/31"}},
+						},
+					},
+				}))
+			}).Should(Succeed())
+		})
+
+		It("does not create network policies for staging or for other spaces", func() {
+			Consistently(networkPolicyExists(spaceGUID, StagingNetworkPolicySuffix)).Should(BeFalse())
+			Consistently(networkPolicyExists(otherSpaceGUID, RunningNetworkPolicySuffix)).Should(BeFalse())
+		})
+
+		When("the space is unbound", func() {
+			JustBeforeEach(func() {
+				Eventually(networkPolicyExists(spaceGUID, RunningNetworkPolicySuffix)).Should(BeTrue())
+
+				Expect(k8s.PatchResource(ctx, adminClient, cfSecurityGroup, func() {
+					delete(cfSecurityGroup.Spec.Spaces, spaceGUID)
+				})).To(Succeed())
+			})
+
+			It("deletes the network policy", func() {
+				Eventually(networkPolicyExists(spaceGUID, RunningNetworkPolicySuffix)).Should(BeFalse())
+			})
+		})
+
+		When("the security group is deleted", func() {
+			JustBeforeEach(func() {
+				Eventually(networkPolicyExists(spaceGUID, RunningNetworkPolicySuffix)).Should(BeTrue())
+
+				Expect(adminClient.Delete(ctx, cfSecurityGroup)).To(Succeed())
+			})
+
+			It("deletes the network policy and the security group", func() {
+				Eventually(networkPolicyExists(spaceGUID, RunningNetworkPolicySuffix)).Should(BeFalse())
+				Eventually(func(g Gomega) {
+					err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)
+					g.Expect(err).To(MatchError(ContainSubstring("not found")))
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the security group is bound to a space for staging", func() {
+		BeforeEach(func() {
+			cfSecurityGroup.Spec.Spaces[spaceGUID] = korifiv1alpha1.SecurityGroupWorkloads{Staging: true}
+		})
+
+		It("creates a network policy selecting the kpack build pods in the space", func() {
+			Eventually(func(g Gomega) {
+				networkPolicy := getNetworkPolicy(g, spaceGUID, StagingNetworkPolicySuffix)
+				g.Expect(networkPolicy.Spec.PodSelector.MatchExpressions).To(ConsistOf(metav1.LabelSelectorRequirement{
+					Key:      KpackBuildPodLabelKey,
+					Operator: metav1.LabelSelectorOpExists,
+				}))
+			}).Should(Succeed())
+			Consistently(networkPolicyExists(spaceGUID, RunningNetworkPolicySuffix)).Should(BeFalse())
+		})
+	})
+
+	When("the security group is globally enabled", func() {
+		BeforeEach(func() {
+			cfSecurityGroup.Spec.GloballyEnabled = korifiv1alpha1.SecurityGroupWorkloads{Running: true, Staging: true}
+		})
+
+		It("creates network policies in every space", func() {
+			for _, guid := range []string{spaceGUID, otherSpaceGUID} {
+				Eventually(networkPolicyExists(guid, RunningNetworkPolicySuffix)).Should(BeTrue())
+				Eventually(networkPolicyExists(guid, StagingNetworkPolicySuffix)).Should(BeTrue())
+			}
+		})
+	})
+
+	When("the security group only has icmp rules", func() {
+		BeforeEach(func() {
+			cfSecurityGroup.Spec.Rules = []korifiv1alpha1.SecurityGroupRule{
+				{Protocol: korifiv1alpha1.ProtocolICMP, Destination: "0.0.0.0/0", Type: tools.PtrTo[int32](0), Code: tools.PtrTo[int32](0)},
+			}
+			cfSecurityGroup.Spec.GloballyEnabled = korifiv1alpha1.SecurityGroupWorkloads{Running: true, Staging: true}
+		})
+
+		It("sets the Ready condition", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+				g.Expect(cfSecurityGroup.Status.ObservedGeneration).To(Equal(cfSecurityGroup.Generation))
+				g.Expect(meta.IsStatusConditionTrue(cfSecurityGroup.Status.Conditions, korifiv1alpha1.ReadyConditionType)).To(BeTrue())
+			}).Should(Succeed())
+		})
+
+		It("does not create network policies denying all egress", func() {
+			for _, guid := range []string{spaceGUID, otherSpaceGUID} {
+				Consistently(networkPolicyExists(guid, RunningNetworkPolicySuffix)).Should(BeFalse())
+				Consistently(networkPolicyExists(guid, StagingNetworkPolicySuffix)).Should(BeFalse())
+			}
+		})
+	})
+
+	When("the rules of a bound security group are changed to icmp rules only", func() {
+		BeforeEach(func() {
+			cfSecurityGroup.Spec.Spaces[spaceGUID] = korifiv1alpha1.SecurityGroupWorkloads{Running: true}
+		})
+
+		JustBeforeEach(func() {
+			Eventually(networkPolicyExists(spaceGUID, RunningNetworkPolicySuffix)).Should(BeTrue())
+
+			Expect(k8s.PatchResource(ctx, adminClient, cfSecurityGroup, func() {
+				cfSecurityGroup.Spec.Rules = []korifiv1alpha1.SecurityGroupRule{
+					{Protocol: korifiv1alpha1.ProtocolICMP, Destination: "0.0.0.0/0", Type: tools.PtrTo[int32](0), Code: tools.PtrTo[int32](0)},
+				}
+			})).To(Succeed())
+		})
+
+		It("deletes the network policy", func() {
+			Eventually(networkPolicyExists(spaceGUID, RunningNetworkPolicySuffix)).Should(BeFalse())
+		})
+	})
+
+	When("the rules cannot be rendered", func() {
+		BeforeEach(func() {
+			cfSecurityGroup.Spec.Rules = []korifiv1alpha1.SecurityGroupRule{
+				{Protocol: korifiv1alpha1.ProtocolTCP, Destination: "not-an-ip"},
+			}
+		})
+
+		It("sets the Ready condition to false", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfSecurityGroup), cfSecurityGroup)).To(Succeed())
+				readyCondition := meta.FindStatusCondition(cfSecurityGroup.Status.Conditions, korifiv1alpha1.ReadyConditionType)
+				g.Expect(readyCondition).NotTo(BeNil())
+				g.Expect(readyCondition.Status).To(Equal(metav1.ConditionFalse))
+				g.Expect(readyCondition.Reason).To(Equal("InvalidRules"))
+			}).Should(Succeed())
+		})
+	})
+})
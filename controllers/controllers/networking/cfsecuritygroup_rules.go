@@ -0,0 +1,185 @@
+package networking
+
+import (
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// toEgressRules converts security group rules into network policy egress
+// rules. NetworkPolicies cannot express ICMP traffic, therefore `icmp` rules
+// are skipped
+func toEgressRules(rules []korifiv1alpha1.SecurityGroupRule) ([]networkingv1.NetworkPolicyEgressRule, error) {
+	egressRules := []networkingv1.NetworkPolicyEgressRule{}
+
+	for i, rule := range rules {
+		if rule.Protocol == korifiv1alpha1.ProtocolICMP {
+			continue
+		}
+
+		peers, err := toNetworkPolicyPeers(rule.Destination)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+
+		ports, err := toNetworkPolicyPorts(rule.Protocol, rule.Ports)
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i, err)
+		}
+
+		egressRules = append(egressRules, networkingv1.NetworkPolicyEgressRule{
+			To:    peers,
+			Ports: ports,
+		})
+	}
+
+	return egressRules, nil
+}
+
+func toNetworkPolicyPeers(destination string) ([]networkingv1.NetworkPolicyPeer, error) {
+	peers := []networkingv1.NetworkPolicyPeer{}
+
+	for _, dest := range strings.Split(destination, ",") {
+		cidrs, err := destinationToCIDRs(strings.TrimSpace(dest))
+		if err != nil {
+			return nil, err
+		}
+
+		for _, cidr := range cidrs {
+			peers = append(peers, networkingv1.NetworkPolicyPeer{
+				IPBlock: &networkingv1.IPBlock{CIDR: cidr},
+			})
+		}
+	}
+
+	return peers, nil
+}
+
+func destinationToCIDRs(destination string) ([]string, error) {
+	if _, ipNet, err := net.ParseCIDR(destination); err == nil {
+		return []string{ipNet.String()}, nil
+	}
+
+	if ip := net.ParseIP(destination); ip != nil {
+		if ip.To4() != nil {
+			return []string{ip.String() + "/32"}, nil
+		}
+		return []string{ip.String() + "/128"}, nil
+	}
+
+	start, end, found := strings.Cut(destination, "-")
+	if !found {
+		return nil, fmt.Errorf("invalid destination %q", destination)
+	}
+
+	startIP := net.ParseIP(strings.TrimSpace(start)).To4()
+	endIP := net.ParseIP(strings.TrimSpace(end)).To4()
+	if startIP == nil || endIP == nil {
+		return nil, fmt.Errorf("invalid destination %q: only IPv4 ranges are supported", destination)
+	}
+
+	return ipv4RangeToCIDRs(binary.BigEndian.Uint32(startIP), binary.BigEndian.Uint32(endIP))
+}
+
+// ipv4RangeToCIDRs returns the smallest list of CIDRs exactly covering the
+// inclusive range between start and end
+func ipv4RangeToCIDRs(start, end uint32) ([]string, error) {
+	if start > end {
+		return nil, fmt.Errorf("invalid IP range: %s is greater than %s", uint32ToIP(start), uint32ToIP(end))
+	}
+
+	cidrs := []string{}
+	for {
+		prefixLen := 32
+		for prefixLen > 0 {
+			blockSize := uint64(1) << (32 - (prefixLen - 1))
+			if uint64(start)%blockSize != 0 || uint64(start)+blockSize-1 > uint64(end) {
+				break
+			}
+			prefixLen--
+		}
+
+		cidrs = append(cidrs, fmt.Sprintf("%s/%d", uint32ToIP(start), prefixLen))
+
+		last := uint64(start) + (uint64(1) << (32 - prefixLen)) - 1
+		if last >= uint64(end) {
+			return cidrs, nil
+		}
+		start = uint32(last + 1)
+	}
+}
+
+func uint32ToIP(value uint32) net.IP {
+	ip := make(net.IP, net.IPv4len)
+	binary.BigEndian.PutUint32(ip, value)
+	return ip
+}
+
+func toNetworkPolicyPorts(protocol, ports string) ([]networkingv1.NetworkPolicyPort, error) {
+	if protocol == korifiv1alpha1.ProtocolAll {
+		return nil, nil
+	}
+
+	k8sProtocol := corev1.ProtocolTCP
+	if protocol == korifiv1alpha1.ProtocolUDP {
+		k8sProtocol = corev1.ProtocolUDP
+	}
+
+	if strings.TrimSpace(ports) == "" {
+		return []networkingv1.NetworkPolicyPort{{Protocol: &k8sProtocol}}, nil
+	}
+
+	policyPorts := []networkingv1.NetworkPolicyPort{}
+	for _, portSpec := range strings.Split(ports, ",") {
+		policyPort, err := toNetworkPolicyPort(k8sProtocol, strings.TrimSpace(portSpec))
+		if err != nil {
+			return nil, err
+		}
+		policyPorts = append(policyPorts, policyPort)
+	}
+
+	return policyPorts, nil
+}
+
+func toNetworkPolicyPort(protocol corev1.Protocol, portSpec string) (networkingv1.NetworkPolicyPort, error) {
+	startSpec, endSpec, isRange := strings.Cut(portSpec, "-")
+
+	start, err := parsePort(startSpec)
+	if err != nil {
+		return networkingv1.NetworkPolicyPort{}, err
+	}
+
+	port := intstr.FromInt(int(start))
+	policyPort := networkingv1.NetworkPolicyPort{Protocol: &protocol, Port: &port}
+	if !isRange {
+		return policyPort, nil
+	}
+
+	end, err := parsePort(endSpec)
+	if err != nil {
+		return networkingv1.NetworkPolicyPort{}, err
+	}
+	if end < start {
+		return networkingv1.NetworkPolicyPort{}, fmt.Errorf("invalid port range %q", portSpec)
+	}
+	policyPort.EndPort = &end
+
+	return policyPort, nil
+}
+
+func parsePort(portSpec string) (int32, error) {
+	port, err := strconv.ParseInt(strings.TrimSpace(portSpec), 10, 32)
+	if err != nil || port < 1 || port > 65535 {
+		return 0, fmt.Errorf("invalid port %q", portSpec)
+	}
+
+	return int32(port), nil
+}
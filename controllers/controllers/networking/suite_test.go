@@ -100,19 +100,40 @@ var _ = BeforeSuite(func() {
 	)).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	err = (NewCFSecurityGroupReconciler(
+		k8sManager.GetClient(),
+		k8sManager.GetScheme(),
+		rootNamespace,
+		ctrl.Log.WithName("controllers").WithName("CFSecurityGroup"),
+	)).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	finalizer.NewControllersFinalizerWebhook().SetupWebhookWithManager(k8sManager)
 	version.NewVersionWebhook("some-version").SetupWebhookWithManager(k8sManager)
 	Expect((&korifiv1alpha1.CFApp{}).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect(workloads.NewCFAppValidator(
 		webhooks.NewDuplicateValidator(coordination.NewNameRegistry(k8sManager.GetClient(), workloads.AppEntityType)),
+		webhooks.NewQuotaLimitValidator(k8sManager.GetClient(), rootNamespace),
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect(networking.NewCFDomainValidator(k8sManager.GetClient()).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect((&korifiv1alpha1.CFRoute{}).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect(networking.NewCFRouteValidator(
 		webhooks.NewDuplicateValidator(coordination.NewNameRegistry(k8sManager.GetClient(), networking.RouteEntityType)),
+		webhooks.NewQuotaLimitValidator(k8sManager.GetClient(), rootNamespace),
 		rootNamespace,
 		k8sManager.GetClient(),
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
+	Expect(networking.NewCFSecurityGroupValidator(
+		webhooks.NewDuplicateValidator(coordination.NewNameRegistry(k8sManager.GetClient(), networking.SecurityGroupEntityType)),
+	).SetupWebhookWithManager(k8sManager)).To(Succeed())
+	Expect(workloads.NewCFOrgValidator(
+		webhooks.NewDuplicateValidator(coordination.NewNameRegistry(k8sManager.GetClient(), workloads.CFOrgEntityType)),
+		webhooks.NewPlacementValidator(k8sManager.GetClient(), rootNamespace),
+	).SetupWebhookWithManager(k8sManager)).To(Succeed())
+	Expect(workloads.NewCFSpaceValidator(
+		webhooks.NewDuplicateValidator(coordination.NewNameRegistry(k8sManager.GetClient(), workloads.CFSpaceEntityType)),
+		webhooks.NewPlacementValidator(k8sManager.GetClient(), rootNamespace),
+	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	Expect(adminClient.Create(context.Background(), &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
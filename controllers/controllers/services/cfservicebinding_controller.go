@@ -18,11 +18,13 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
@@ -47,19 +49,34 @@ const (
 	ServiceCredentialBindingTypeLabel    = "korifi.cloudfoundry.org/service-credential-binding-type"
 )
 
+type ServiceBindingBrokerClient interface {
+	Bind(context.Context, osbapi.Broker, string, string, osbapi.BindRequest) (osbapi.BindResponse, error)
+	Unbind(context.Context, osbapi.Broker, string, string, osbapi.UnbindRequest) error
+}
+
 // CFServiceBindingReconciler reconciles a CFServiceBinding object
 type CFServiceBindingReconciler struct {
-	k8sClient client.Client
-	scheme    *runtime.Scheme
-	log       logr.Logger
+	k8sClient     client.Client
+	scheme        *runtime.Scheme
+	brokerClient  ServiceBindingBrokerClient
+	rootNamespace string
+	log           logr.Logger
 }
 
 func NewCFServiceBindingReconciler(
 	k8sClient client.Client,
 	scheme *runtime.Scheme,
+	brokerClient ServiceBindingBrokerClient,
+	rootNamespace string,
 	log logr.Logger,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceBinding, *korifiv1alpha1.CFServiceBinding] {
-	cfBindingReconciler := &CFServiceBindingReconciler{k8sClient: k8sClient, scheme: scheme, log: log}
+	cfBindingReconciler := &CFServiceBindingReconciler{
+		k8sClient:     k8sClient,
+		scheme:        scheme,
+		brokerClient:  brokerClient,
+		rootNamespace: rootNamespace,
+		log:           log,
+	}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFServiceBinding, *korifiv1alpha1.CFServiceBinding](log, k8sClient, cfBindingReconciler)
 }
 
@@ -70,6 +87,8 @@ func (r *CFServiceBindingReconciler) SetupWithManager(mgr ctrl.Manager) *builder
 
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebindings,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebindings/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebindings/finalizers,verbs=update
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create
 //+kubebuilder:rbac:groups=servicebinding.io,resources=servicebindings,verbs=get;list;create;update;patch;watch
 
 func (r *CFServiceBindingReconciler) ReconcileResource(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (ctrl.Result, error) {
@@ -79,28 +98,72 @@ func (r *CFServiceBindingReconciler) ReconcileResource(ctx context.Context, cfSe
 	cfServiceBinding.Status.ObservedGeneration = cfServiceBinding.Generation
 	log.V(1).Info("set observed generation", "generation", cfServiceBinding.Status.ObservedGeneration)
 
+	if !cfServiceBinding.GetDeletionTimestamp().IsZero() {
+		return r.finalizeCFServiceBinding(ctx, cfServiceBinding)
+	}
+
 	instance := new(korifiv1alpha1.CFServiceInstance)
-	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.Service.Name, Namespace: cfServiceBinding.Namespace}, instance)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.Service.Name, Namespace: cfServiceBinding.ServiceNamespace()}, instance)
 	if err != nil {
 		// Unlike with CFApp cascading delete, CFServiceInstance delete cleans up CFServiceBindings itself as part of finalizing,
 		// so we do not check for deletion timestamp before returning here.
 		return r.handleGetError(ctx, err, cfServiceBinding, BindingSecretAvailableCondition, "ServiceInstanceNotFound", "Service instance")
 	}
 
-	err = controllerutil.SetControllerReference(instance, cfServiceBinding, r.scheme)
-	if err != nil {
-		log.Info("error when making the service instance owner of the service binding", "reason", err)
-		return ctrl.Result{}, err
+	// Owner references cannot cross namespaces. Bindings to shared service instances
+	// are deleted by the service instance controller instead
+	if instance.Namespace == cfServiceBinding.Namespace {
+		err = controllerutil.SetControllerReference(instance, cfServiceBinding, r.scheme)
+		if err != nil {
+			log.Info("error when making the service instance owner of the service binding", "reason", err)
+			return ctrl.Result{}, err
+		}
 	}
 
-	secret := new(corev1.Secret)
-	// Note: is there a reason to fetch the secret name from the service instance spec?
-	err = r.k8sClient.Get(ctx, types.NamespacedName{Name: instance.Spec.SecretName, Namespace: cfServiceBinding.Namespace}, secret)
-	if err != nil {
-		return r.handleGetError(ctx, err, cfServiceBinding, BindingSecretAvailableCondition, "SecretNotFound", "Binding secret")
+	var secret *corev1.Secret
+	credentials := corev1.LocalObjectReference{}
+	if instance.Spec.Type == korifiv1alpha1.ManagedType {
+		if !isProvisioned(instance) || operationInProgress(instance) {
+			log.V(1).Info("service instance is not ready for binding", "instance", instance.Name)
+			cfServiceBinding.Status.Binding = corev1.LocalObjectReference{}
+			cfServiceBinding.Status.Credentials = corev1.LocalObjectReference{}
+			meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+				Type:               BindingSecretAvailableCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             "ServiceInstanceNotReady",
+				Message:            "Service instance has not been provisioned yet",
+				ObservedGeneration: cfServiceBinding.Generation,
+			})
+			return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+		}
+
+		var credentialsSecret *corev1.Secret
+		secret, credentialsSecret, err = r.bind(ctx, cfServiceBinding, instance)
+		if err != nil {
+			log.Info("failed to bind service instance", "reason", err)
+			cfServiceBinding.Status.Binding = corev1.LocalObjectReference{}
+			cfServiceBinding.Status.Credentials = corev1.LocalObjectReference{}
+			meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
+				Type:               BindingSecretAvailableCondition,
+				Status:             metav1.ConditionFalse,
+				Reason:             "BindingFailed",
+				Message:            err.Error(),
+				ObservedGeneration: cfServiceBinding.Generation,
+			})
+			return ctrl.Result{}, err
+		}
+		credentials.Name = credentialsSecret.Name
+	} else {
+		secret = new(corev1.Secret)
+		// Note: is there a reason to fetch the secret name from the service instance spec?
+		err = r.k8sClient.Get(ctx, types.NamespacedName{Name: instance.Spec.SecretName, Namespace: cfServiceBinding.Namespace}, secret)
+		if err != nil {
+			return r.handleGetError(ctx, err, cfServiceBinding, BindingSecretAvailableCondition, "SecretNotFound", "Binding secret")
+		}
 	}
 
-	cfServiceBinding.Status.Binding.Name = instance.Spec.SecretName
+	cfServiceBinding.Status.Binding.Name = secret.Name
+	cfServiceBinding.Status.Credentials = credentials
 	meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
 		Type:               BindingSecretAvailableCondition,
 		Status:             metav1.ConditionTrue,
@@ -109,6 +172,10 @@ func (r *CFServiceBindingReconciler) ReconcileResource(ctx context.Context, cfSe
 		ObservedGeneration: cfServiceBinding.Generation,
 	})
 
+	if cfServiceBinding.IsKey() {
+		return ctrl.Result{}, nil
+	}
+
 	cfApp := new(korifiv1alpha1.CFApp)
 	err = r.k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.AppRef.Name, Namespace: cfServiceBinding.Namespace}, cfApp)
 	if err != nil {
@@ -155,8 +222,200 @@ func (r *CFServiceBindingReconciler) ReconcileResource(ctx context.Context, cfSe
 	return ctrl.Result{}, nil
 }
 
+// bind asks the broker of a managed service instance for binding credentials.
+// The credentials are stored as JSON in a credentials secret and, flattened for
+// the servicebinding.io projection, in a binding secret named after the binding.
+// Bindings are created synchronously; the broker is only called when the
+// credentials secret does not exist yet
+func (r *CFServiceBindingReconciler) bind(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding, instance *korifiv1alpha1.CFServiceInstance) (*corev1.Secret, *corev1.Secret, error) {
+	credentialsSecret, err := r.getOrCreateCredentialsSecret(ctx, cfServiceBinding, instance)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bindingSecret, err := r.getOrCreateBindingSecret(ctx, cfServiceBinding, credentialsSecret)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return bindingSecret, credentialsSecret, nil
+}
+
+func (r *CFServiceBindingReconciler) getOrCreateCredentialsSecret(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding, instance *korifiv1alpha1.CFServiceInstance) (*corev1.Secret, error) {
+	credentialsSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfServiceBinding.Name + "-credentials",
+			Namespace: cfServiceBinding.Namespace,
+		},
+	}
+
+	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(credentialsSecret), credentialsSecret)
+	if err == nil {
+		return credentialsSecret, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get credentials secret: %w", err)
+	}
+
+	catalogPlan, err := getCatalogPlan(ctx, r.k8sClient, r.rootNamespace, instance.Status.PlanGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	orgGUID, err := getOrgGUID(ctx, r.k8sClient, cfServiceBinding.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	bindRequest := osbapi.BindRequest{
+		ServiceID: catalogPlan.offering.Spec.BrokerCatalog.ID,
+		PlanID:    catalogPlan.plan.Spec.BrokerCatalog.ID,
+		Context: osbapi.Context{
+			Platform:         "cloudfoundry",
+			OrganizationGUID: orgGUID,
+			SpaceGUID:        cfServiceBinding.Namespace,
+		},
+	}
+	if !cfServiceBinding.IsKey() {
+		bindRequest.AppGUID = cfServiceBinding.Spec.AppRef.Name
+		bindRequest.BindResource = &osbapi.BindResource{
+			AppGUID: cfServiceBinding.Spec.AppRef.Name,
+		}
+	}
+
+	response, err := r.brokerClient.Bind(ctx, catalogPlan.broker, instance.Name, cfServiceBinding.Name, bindRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	credentials := response.Credentials
+	if credentials == nil {
+		credentials = map[string]any{}
+	}
+
+	credentialsJSON, err := json.Marshal(credentials)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal credentials: %w", err)
+	}
+	credentialsSecret.Data = map[string][]byte{
+		korifiv1alpha1.CredentialsSecretKey: credentialsJSON,
+	}
+
+	if err = controllerutil.SetControllerReference(cfServiceBinding, credentialsSecret, r.scheme); err != nil {
+		return nil, err
+	}
+
+	if err = r.k8sClient.Create(ctx, credentialsSecret); err != nil {
+		return nil, fmt.Errorf("failed to create credentials secret: %w", err)
+	}
+
+	return credentialsSecret, nil
+}
+
+func (r *CFServiceBindingReconciler) getOrCreateBindingSecret(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding, credentialsSecret *corev1.Secret) (*corev1.Secret, error) {
+	bindingSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      cfServiceBinding.Name,
+			Namespace: cfServiceBinding.Namespace,
+		},
+	}
+
+	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(bindingSecret), bindingSecret)
+	if err == nil {
+		return bindingSecret, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get binding secret: %w", err)
+	}
+
+	credentials := map[string]any{}
+	if err = json.Unmarshal(credentialsSecret.Data[korifiv1alpha1.CredentialsSecretKey], &credentials); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal credentials: %w", err)
+	}
+
+	bindingSecret.Data, err = toSecretData(credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = controllerutil.SetControllerReference(cfServiceBinding, bindingSecret, r.scheme); err != nil {
+		return nil, err
+	}
+
+	if err = r.k8sClient.Create(ctx, bindingSecret); err != nil {
+		return nil, fmt.Errorf("failed to create binding secret: %w", err)
+	}
+
+	return bindingSecret, nil
+}
+
+func (r *CFServiceBindingReconciler) finalizeCFServiceBinding(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if !controllerutil.ContainsFinalizer(cfServiceBinding, korifiv1alpha1.CFServiceBindingFinalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	instance := new(korifiv1alpha1.CFServiceInstance)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceBinding.Spec.Service.Name, Namespace: cfServiceBinding.ServiceNamespace()}, instance)
+	if client.IgnoreNotFound(err) != nil {
+		log.Info("failed to get service instance", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	if err == nil && instance.Spec.Type == korifiv1alpha1.ManagedType {
+		if err = r.unbind(ctx, cfServiceBinding, instance); err != nil {
+			log.Info("failed to unbind service instance", "reason", err)
+			return ctrl.Result{}, err
+		}
+	}
+
+	if controllerutil.RemoveFinalizer(cfServiceBinding, korifiv1alpha1.CFServiceBindingFinalizerName) {
+		log.V(1).Info("finalizer removed")
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *CFServiceBindingReconciler) unbind(ctx context.Context, cfServiceBinding *korifiv1alpha1.CFServiceBinding, instance *korifiv1alpha1.CFServiceInstance) error {
+	catalogPlan, err := getCatalogPlan(ctx, r.k8sClient, r.rootNamespace, instance.Status.PlanGUID)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			logr.FromContextOrDiscard(ctx).Info("service plan does not exist anymore, skipping unbind", "plan", instance.Status.PlanGUID)
+			return nil
+		}
+		return err
+	}
+
+	return r.brokerClient.Unbind(ctx, catalogPlan.broker, instance.Name, cfServiceBinding.Name, osbapi.UnbindRequest{
+		ServiceID: catalogPlan.offering.Spec.BrokerCatalog.ID,
+		PlanID:    catalogPlan.plan.Spec.BrokerCatalog.ID,
+	})
+}
+
+// toSecretData flattens the credentials for the servicebinding.io projection,
+// storing string credentials as they are and all other values as JSON
+func toSecretData(credentials map[string]any) (map[string][]byte, error) {
+	data := map[string][]byte{}
+	for key, value := range credentials {
+		if stringValue, ok := value.(string); ok {
+			data[key] = []byte(stringValue)
+			continue
+		}
+
+		jsonValue, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal credential %q: %w", key, err)
+		}
+		data[key] = jsonValue
+	}
+
+	return data, nil
+}
+
 func (r *CFServiceBindingReconciler) handleGetError(ctx context.Context, err error, cfServiceBinding *korifiv1alpha1.CFServiceBinding, conditionType, notFoundReason, objectType string) (ctrl.Result, error) {
 	cfServiceBinding.Status.Binding = corev1.LocalObjectReference{}
+	cfServiceBinding.Status.Credentials = corev1.LocalObjectReference{}
 	if apierrors.IsNotFound(err) {
 		meta.SetStatusCondition(&cfServiceBinding.Status.Conditions, metav1.Condition{
 			Type:               conditionType,
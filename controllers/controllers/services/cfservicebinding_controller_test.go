@@ -3,10 +3,14 @@ package services_test
 import (
 	"context"
 	"fmt"
+	"net/http"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/controllers/services"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
+	"code.cloudfoundry.org/korifi/tests/helpers"
+	"code.cloudfoundry.org/korifi/tools"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -15,6 +19,7 @@ import (
 	. "github.com/onsi/gomega/gstruct"
 	servicebindingv1beta1 "github.com/servicebinding/runtime/apis/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -234,6 +239,29 @@ var _ = Describe("CFServiceBinding", func() {
 		})
 	})
 
+	When("the CFServiceBinding is a service key", func() {
+		BeforeEach(func() {
+			cfServiceBinding.Spec.Type = korifiv1alpha1.KeyBindingType
+			cfServiceBinding.Spec.DisplayName = tools.PtrTo("my-key")
+			cfServiceBinding.Spec.AppRef = corev1.LocalObjectReference{}
+		})
+
+		It("resolves the secretName without creating a servicebinding.io ServiceBinding", func() {
+			Eventually(func(g Gomega) {
+				updatedCFServiceBinding := new(korifiv1alpha1.CFServiceBinding)
+				g.Expect(adminClient.Get(context.Background(), client.ObjectKeyFromObject(cfServiceBinding), updatedCFServiceBinding)).To(Succeed())
+				g.Expect(updatedCFServiceBinding.Status.Binding.Name).To(Equal(secret.Name))
+				g.Expect(meta.IsStatusConditionTrue(updatedCFServiceBinding.Status.Conditions, services.BindingSecretAvailableCondition)).To(BeTrue())
+			}).Should(Succeed())
+
+			Consistently(func(g Gomega) {
+				sbServiceBinding := servicebindingv1beta1.ServiceBinding{}
+				err := adminClient.Get(context.Background(), types.NamespacedName{Name: fmt.Sprintf("cf-binding-%s", cfServiceBindingGUID), Namespace: namespace.Name}, &sbServiceBinding)
+				g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			}).Should(Succeed())
+		})
+	})
+
 	When("the referenced secret does not exist", func() {
 		var otherSecret *corev1.Secret
 
@@ -314,3 +342,222 @@ var _ = Describe("CFServiceBinding", func() {
 		})
 	})
 })
+
+var _ = Describe("Managed CFServiceBinding", func() {
+	var (
+		orgNamespace      string
+		spaceNamespace    string
+		broker            *helpers.FakeBroker
+		cfServiceBroker   *korifiv1alpha1.CFServiceBroker
+		cfApp             *korifiv1alpha1.CFApp
+		cfServiceInstance *korifiv1alpha1.CFServiceInstance
+		cfServiceBinding  *korifiv1alpha1.CFServiceBinding
+	)
+
+	BeforeEach(func() {
+		orgNamespace, spaceNamespace = createSpaceNamespaces()
+
+		broker = helpers.NewFakeBroker(osbapi.Catalog{
+			Services: []osbapi.Service{{
+				ID:       "service-id",
+				Name:     "my-service",
+				Bindable: true,
+				Plans:    []osbapi.Plan{{ID: "plan-id", Name: "my-plan"}},
+			}},
+		})
+		broker.SetCredentials(map[string]any{
+			"username": "alice",
+			"port":     5432,
+			"hosts":    map[string]any{"primary": "db-0"},
+		})
+
+		var plans map[string]korifiv1alpha1.CFServicePlan
+		cfServiceBroker, plans = registerFakeBroker(broker, 1)
+
+		cfApp = BuildCFAppCRObject(GenerateGUID(), spaceNamespace)
+		Expect(adminClient.Create(ctx, cfApp)).To(Succeed())
+		Expect(k8s.Patch(ctx, adminClient, cfApp, func() {
+			cfApp.Status.VCAPServicesSecretName = "vcap-services"
+			cfApp.Status.VCAPApplicationSecretName = "vcap-application"
+		})).To(Succeed())
+
+		cfServiceInstance = &korifiv1alpha1.CFServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       GenerateGUID(),
+				Namespace:  spaceNamespace,
+				Finalizers: []string{korifiv1alpha1.CFServiceInstanceFinalizerName},
+			},
+			Spec: korifiv1alpha1.CFServiceInstanceSpec{
+				DisplayName: "my-managed-instance",
+				Type:        korifiv1alpha1.ManagedType,
+				PlanGUID:    plans["my-plan"].Name,
+			},
+		}
+		Expect(adminClient.Create(ctx, cfServiceInstance)).To(Succeed())
+
+		cfServiceBinding = &korifiv1alpha1.CFServiceBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       GenerateGUID(),
+				Namespace:  spaceNamespace,
+				Finalizers: []string{korifiv1alpha1.CFServiceBindingFinalizerName},
+			},
+			Spec: korifiv1alpha1.CFServiceBindingSpec{
+				Service: corev1.ObjectReference{
+					Kind:       "CFServiceInstance",
+					Name:       cfServiceInstance.Name,
+					APIVersion: "korifi.cloudfoundry.org/v1alpha1",
+				},
+				AppRef: corev1.LocalObjectReference{
+					Name: cfApp.Name,
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		broker.Close()
+		Expect(adminClient.Delete(ctx, cfServiceBroker)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		Expect(adminClient.Create(ctx, cfServiceBinding)).To(Succeed())
+	})
+
+	It("binds the instance via the broker", func() {
+		Eventually(func(g Gomega) {
+			binding, ok := broker.GetBinding(cfServiceBinding.Name)
+			g.Expect(ok).To(BeTrue())
+			g.Expect(binding).To(Equal(helpers.FakeServiceBinding{
+				InstanceID: cfServiceInstance.Name,
+				ServiceID:  "service-id",
+				PlanID:     "plan-id",
+				AppGUID:    cfApp.Name,
+				Context: osbapi.Context{
+					Platform:         "cloudfoundry",
+					OrganizationGUID: orgNamespace,
+					SpaceGUID:        spaceNamespace,
+				},
+			}))
+		}).Should(Succeed())
+	})
+
+	It("stores the flattened credentials in the binding secret", func() {
+		Eventually(func(g Gomega) {
+			updatedCFServiceBinding := new(korifiv1alpha1.CFServiceBinding)
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfServiceBinding), updatedCFServiceBinding)).To(Succeed())
+			g.Expect(updatedCFServiceBinding.Status.Binding.Name).To(Equal(cfServiceBinding.Name))
+			g.Expect(meta.IsStatusConditionTrue(updatedCFServiceBinding.Status.Conditions, services.BindingSecretAvailableCondition)).To(BeTrue())
+
+			bindingSecret := new(corev1.Secret)
+			g.Expect(adminClient.Get(ctx, types.NamespacedName{Namespace: spaceNamespace, Name: updatedCFServiceBinding.Status.Binding.Name}, bindingSecret)).To(Succeed())
+			g.Expect(bindingSecret.Data).To(Equal(map[string][]byte{
+				"username": []byte("alice"),
+				"port":     []byte("5432"),
+				"hosts":    []byte(`{"primary":"db-0"}`),
+			}))
+			g.Expect(bindingSecret.OwnerReferences).To(ConsistOf(HaveField("Name", cfServiceBinding.Name)))
+		}).Should(Succeed())
+	})
+
+	It("stores the raw credentials JSON in a secret referenced by the binding status", func() {
+		Eventually(func(g Gomega) {
+			updatedCFServiceBinding := new(korifiv1alpha1.CFServiceBinding)
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfServiceBinding), updatedCFServiceBinding)).To(Succeed())
+			g.Expect(updatedCFServiceBinding.Status.Credentials.Name).To(Equal(cfServiceBinding.Name + "-credentials"))
+
+			credentialsSecret := new(corev1.Secret)
+			g.Expect(adminClient.Get(ctx, types.NamespacedName{Namespace: spaceNamespace, Name: updatedCFServiceBinding.Status.Credentials.Name}, credentialsSecret)).To(Succeed())
+			g.Expect(credentialsSecret.Data).To(HaveKeyWithValue(korifiv1alpha1.CredentialsSecretKey, MatchJSON(`{
+				"username": "alice",
+				"port": 5432,
+				"hosts": {"primary": "db-0"}
+			}`)))
+			g.Expect(credentialsSecret.OwnerReferences).To(ConsistOf(HaveField("Name", cfServiceBinding.Name)))
+		}).Should(Succeed())
+	})
+
+	When("the binding is a service key", func() {
+		BeforeEach(func() {
+			cfServiceBinding.Spec.Type = korifiv1alpha1.KeyBindingType
+			cfServiceBinding.Spec.DisplayName = tools.PtrTo("my-key")
+			cfServiceBinding.Spec.AppRef = corev1.LocalObjectReference{}
+		})
+
+		It("binds the instance without an app", func() {
+			Eventually(func(g Gomega) {
+				binding, ok := broker.GetBinding(cfServiceBinding.Name)
+				g.Expect(ok).To(BeTrue())
+				g.Expect(binding.AppGUID).To(BeEmpty())
+
+				updatedCFServiceBinding := new(korifiv1alpha1.CFServiceBinding)
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfServiceBinding), updatedCFServiceBinding)).To(Succeed())
+				g.Expect(updatedCFServiceBinding.Status.Binding.Name).To(Equal(cfServiceBinding.Name))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the broker fails to bind", func() {
+		BeforeEach(func() {
+			Eventually(func(g Gomega) {
+				_, ok := broker.GetInstance(cfServiceInstance.Name)
+				g.Expect(ok).To(BeTrue())
+			}).Should(Succeed())
+			broker.SetOperationError(&osbapi.BrokerError{StatusCode: http.StatusUnprocessableEntity, Description: "binding not allowed"})
+		})
+
+		It("sets the BindingSecretAvailable condition to false", func() {
+			Eventually(func(g Gomega) {
+				updatedCFServiceBinding := new(korifiv1alpha1.CFServiceBinding)
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfServiceBinding), updatedCFServiceBinding)).To(Succeed())
+				g.Expect(updatedCFServiceBinding.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Type":    Equal(services.BindingSecretAvailableCondition),
+					"Status":  Equal(metav1.ConditionFalse),
+					"Reason":  Equal("BindingFailed"),
+					"Message": ContainSubstring("binding not allowed"),
+				})))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the binding is deleted", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				_, ok := broker.GetBinding(cfServiceBinding.Name)
+				g.Expect(ok).To(BeTrue())
+			}).Should(Succeed())
+
+			Expect(adminClient.Delete(ctx, cfServiceBinding)).To(Succeed())
+		})
+
+		It("unbinds via the broker and removes the binding", func() {
+			Eventually(func(g Gomega) {
+				_, ok := broker.GetBinding(cfServiceBinding.Name)
+				g.Expect(ok).To(BeFalse())
+
+				err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfServiceBinding), new(korifiv1alpha1.CFServiceBinding))
+				g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			}).Should(Succeed())
+		})
+	})
+
+	When("the service instance is deleted", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				_, ok := broker.GetBinding(cfServiceBinding.Name)
+				g.Expect(ok).To(BeTrue())
+			}).Should(Succeed())
+
+			Expect(adminClient.Delete(ctx, cfServiceInstance)).To(Succeed())
+		})
+
+		It("unbinds before deprovisioning", func() {
+			Eventually(func(g Gomega) {
+				_, ok := broker.GetBinding(cfServiceBinding.Name)
+				g.Expect(ok).To(BeFalse())
+
+				_, ok = broker.GetInstance(cfServiceInstance.Name)
+				g.Expect(ok).To(BeFalse())
+			}).Should(Succeed())
+		})
+	})
+})
@@ -0,0 +1,308 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+type BrokerClient interface {
+	GetCatalog(context.Context, osbapi.Broker) (osbapi.Catalog, error)
+}
+
+// CFServiceBrokerReconciler reconciles a CFServiceBroker object
+type CFServiceBrokerReconciler struct {
+	k8sClient    client.Client
+	scheme       *runtime.Scheme
+	brokerClient BrokerClient
+	log          logr.Logger
+}
+
+func NewCFServiceBrokerReconciler(
+	client client.Client,
+	scheme *runtime.Scheme,
+	brokerClient BrokerClient,
+	log logr.Logger,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceBroker, *korifiv1alpha1.CFServiceBroker] {
+	serviceBrokerReconciler := CFServiceBrokerReconciler{k8sClient: client, scheme: scheme, brokerClient: brokerClient, log: log}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFServiceBroker, *korifiv1alpha1.CFServiceBroker](log, client, &serviceBrokerReconciler)
+}
+
+func (r *CFServiceBrokerReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFServiceBroker{})
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebrokers,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebrokers/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebrokers/finalizers,verbs=update
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceofferings,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceplans,verbs=get;list;watch;create;update;patch;delete
+
+func (r *CFServiceBrokerReconciler) ReconcileResource(ctx context.Context, cfServiceBroker *korifiv1alpha1.CFServiceBroker) (ctrl.Result, error) {
+	log := shared.ObjectLogger(r.log, cfServiceBroker)
+	ctx = logr.NewContext(ctx, log)
+
+	cfServiceBroker.Status.ObservedGeneration = cfServiceBroker.Generation
+	log.V(1).Info("set observed generation", "generation", cfServiceBroker.Status.ObservedGeneration)
+
+	credentialsSecret := new(corev1.Secret)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceBroker.Spec.Credentials.Name, Namespace: cfServiceBroker.Namespace}, credentialsSecret)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			setBrokerNotReady(cfServiceBroker, "CredentialsSecretNotFound", "Credentials secret does not exist")
+			return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+		}
+
+		log.Info("failed to get credentials secret", "reason", err)
+		setBrokerNotReady(cfServiceBroker, "UnknownError", "Error occurred while fetching credentials secret: "+err.Error())
+		return ctrl.Result{}, err
+	}
+
+	catalog, err := r.brokerClient.GetCatalog(ctx, osbapi.Broker{
+		URL:      cfServiceBroker.Spec.URL,
+		Username: string(credentialsSecret.Data[korifiv1alpha1.CFServiceBrokerUsernameKey]),
+		Password: string(credentialsSecret.Data[korifiv1alpha1.CFServiceBrokerPasswordKey]),
+	})
+	if err != nil {
+		log.Info("failed to fetch catalog", "reason", err)
+		setBrokerNotReady(cfServiceBroker, "CatalogFetchFailed", err.Error())
+		return ctrl.Result{}, err
+	}
+
+	if err = r.syncCatalog(ctx, cfServiceBroker, catalog); err != nil {
+		log.Info("failed to sync catalog", "reason", err)
+		setBrokerNotReady(cfServiceBroker, "CatalogSyncFailed", err.Error())
+		return ctrl.Result{}, err
+	}
+
+	meta.SetStatusCondition(&cfServiceBroker.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.ReadyConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             "CatalogSynced",
+		ObservedGeneration: cfServiceBroker.Generation,
+	})
+
+	return ctrl.Result{}, nil
+}
+
+func (r *CFServiceBrokerReconciler) syncCatalog(ctx context.Context, cfServiceBroker *korifiv1alpha1.CFServiceBroker, catalog osbapi.Catalog) error {
+	offeringGUIDs := map[string]bool{}
+	planGUIDs := map[string]bool{}
+
+	for _, service := range catalog.Services {
+		offering, err := r.syncOffering(ctx, cfServiceBroker, service)
+		if err != nil {
+			return err
+		}
+		offeringGUIDs[offering.Name] = true
+
+		for _, plan := range service.Plans {
+			cfServicePlan, err := r.syncPlan(ctx, cfServiceBroker, offering, plan)
+			if err != nil {
+				return err
+			}
+			planGUIDs[cfServicePlan.Name] = true
+		}
+	}
+
+	brokerSelector := client.MatchingLabels{korifiv1alpha1.CFServiceBrokerGUIDLabelKey: cfServiceBroker.Name}
+
+	plans := new(korifiv1alpha1.CFServicePlanList)
+	if err := r.k8sClient.List(ctx, plans, client.InNamespace(cfServiceBroker.Namespace), brokerSelector); err != nil {
+		return fmt.Errorf("failed to list service plans: %w", err)
+	}
+	for i := range plans.Items {
+		if planGUIDs[plans.Items[i].Name] {
+			continue
+		}
+		if err := r.k8sClient.Delete(ctx, &plans.Items[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete service plan %q: %w", plans.Items[i].Name, err)
+		}
+	}
+
+	offerings := new(korifiv1alpha1.CFServiceOfferingList)
+	if err := r.k8sClient.List(ctx, offerings, client.InNamespace(cfServiceBroker.Namespace), brokerSelector); err != nil {
+		return fmt.Errorf("failed to list service offerings: %w", err)
+	}
+	for i := range offerings.Items {
+		if offeringGUIDs[offerings.Items[i].Name] {
+			continue
+		}
+		if err := r.k8sClient.Delete(ctx, &offerings.Items[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete service offering %q: %w", offerings.Items[i].Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *CFServiceBrokerReconciler) syncOffering(ctx context.Context, cfServiceBroker *korifiv1alpha1.CFServiceBroker, service osbapi.Service) (*korifiv1alpha1.CFServiceOffering, error) {
+	offering := &korifiv1alpha1.CFServiceOffering{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      catalogEntryGUID(cfServiceBroker.Name, service.ID),
+			Namespace: cfServiceBroker.Namespace,
+		},
+	}
+
+	metadata, err := toRawExtension(service.Metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = controllerutil.CreateOrPatch(ctx, r.k8sClient, offering, func() error {
+		if offering.Labels == nil {
+			offering.Labels = map[string]string{}
+		}
+		offering.Labels[korifiv1alpha1.CFServiceBrokerGUIDLabelKey] = cfServiceBroker.Name
+
+		offering.Spec.Name = service.Name
+		offering.Spec.Description = service.Description
+		offering.Spec.Tags = service.Tags
+		offering.Spec.Requires = service.Requires
+		offering.Spec.DocumentationURL = documentationURL(service.Metadata)
+		offering.Spec.Bindable = service.Bindable
+		offering.Spec.PlanUpdateable = service.PlanUpdateable
+		offering.Spec.InstancesRetrievable = service.InstancesRetrievable
+		offering.Spec.BindingsRetrievable = service.BindingsRetrievable
+		offering.Spec.AllowContextUpdates = service.AllowContextUpdates
+		offering.Spec.BrokerCatalog = korifiv1alpha1.ServiceBrokerCatalog{
+			ID:       service.ID,
+			Metadata: metadata,
+		}
+
+		return controllerutil.SetControllerReference(cfServiceBroker, offering, r.scheme)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create or patch service offering %q: %w", service.Name, err)
+	}
+
+	return offering, nil
+}
+
+func (r *CFServiceBrokerReconciler) syncPlan(ctx context.Context, cfServiceBroker *korifiv1alpha1.CFServiceBroker, offering *korifiv1alpha1.CFServiceOffering, plan osbapi.Plan) (*korifiv1alpha1.CFServicePlan, error) {
+	cfServicePlan := &korifiv1alpha1.CFServicePlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      catalogEntryGUID(cfServiceBroker.Name, offering.Spec.BrokerCatalog.ID, plan.ID),
+			Namespace: cfServiceBroker.Namespace,
+		},
+	}
+
+	metadata, err := toRawExtension(plan.Metadata)
+	if err != nil {
+		return nil, err
+	}
+	instanceCreateParameters, err := toRawExtension(plan.Schemas.ServiceInstance.Create.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	instanceUpdateParameters, err := toRawExtension(plan.Schemas.ServiceInstance.Update.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	bindingCreateParameters, err := toRawExtension(plan.Schemas.ServiceBinding.Create.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = controllerutil.CreateOrPatch(ctx, r.k8sClient, cfServicePlan, func() error {
+		if cfServicePlan.Labels == nil {
+			cfServicePlan.Labels = map[string]string{}
+		}
+		cfServicePlan.Labels[korifiv1alpha1.CFServiceBrokerGUIDLabelKey] = cfServiceBroker.Name
+		cfServicePlan.Labels[korifiv1alpha1.CFServiceOfferingGUIDLabelKey] = offering.Name
+
+		cfServicePlan.Spec.Name = plan.Name
+		cfServicePlan.Spec.Description = plan.Description
+		// OSBAPI: plans are free unless stated otherwise
+		cfServicePlan.Spec.Free = plan.Free == nil || *plan.Free
+		cfServicePlan.Spec.Bindable = plan.Bindable
+		cfServicePlan.Spec.PlanUpdateable = plan.PlanUpdateable
+		cfServicePlan.Spec.BrokerCatalog = korifiv1alpha1.ServiceBrokerCatalog{
+			ID:       plan.ID,
+			Metadata: metadata,
+		}
+		// visibility is managed via the API, new plans are restricted to admins
+		if cfServicePlan.Spec.Visibility.Type == "" {
+			cfServicePlan.Spec.Visibility.Type = korifiv1alpha1.AdminServicePlanVisibilityType
+		}
+		cfServicePlan.Spec.Schemas = korifiv1alpha1.ServicePlanSchemas{
+			ServiceInstance: korifiv1alpha1.ServiceInstanceSchema{
+				Create: korifiv1alpha1.InputParametersSchema{Parameters: instanceCreateParameters},
+				Update: korifiv1alpha1.InputParametersSchema{Parameters: instanceUpdateParameters},
+			},
+			ServiceBinding: korifiv1alpha1.ServiceBindingSchema{
+				Create: korifiv1alpha1.InputParametersSchema{Parameters: bindingCreateParameters},
+			},
+		}
+
+		return controllerutil.SetControllerReference(cfServiceBroker, cfServicePlan, r.scheme)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create or patch service plan %q: %w", plan.Name, err)
+	}
+
+	return cfServicePlan, nil
+}
+
+func setBrokerNotReady(cfServiceBroker *korifiv1alpha1.CFServiceBroker, reason, message string) {
+	meta.SetStatusCondition(&cfServiceBroker.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.ReadyConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: cfServiceBroker.Generation,
+	})
+}
+
+// catalogEntryGUID generates a stable GUID for a catalog entry, so that
+// offerings and plans keep their GUIDs across catalog synchronisations
+func catalogEntryGUID(brokerGUID string, catalogIDs ...string) string {
+	name := brokerGUID
+	for _, id := range catalogIDs {
+		name += "::" + id
+	}
+
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(name)).String()
+}
+
+func documentationURL(metadata map[string]any) *string {
+	url, ok := metadata["documentationUrl"].(string)
+	if !ok {
+		return nil
+	}
+
+	return &url
+}
+
+func toRawExtension(value map[string]any) (*runtime.RawExtension, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %v: %w", value, err)
+	}
+
+	return &runtime.RawExtension{Raw: raw}, nil
+}
@@ -0,0 +1,289 @@
+package services_test
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
+	"code.cloudfoundry.org/korifi/tests/helpers"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFServiceBroker", func() {
+	var (
+		namespace       *corev1.Namespace
+		broker          *helpers.FakeBroker
+		secret          *corev1.Secret
+		cfServiceBroker *korifiv1alpha1.CFServiceBroker
+	)
+
+	BeforeEach(func() {
+		namespace = BuildNamespaceObject(GenerateGUID())
+		Expect(adminClient.Create(context.Background(), namespace)).To(Succeed())
+
+		broker = helpers.NewFakeBroker(osbapi.Catalog{
+			Services: []osbapi.Service{{
+				ID:          "service-id",
+				Name:        "my-service",
+				Description: "my service description",
+				Bindable:    true,
+				Tags:        []string{"db"},
+				Metadata: map[string]any{
+					"documentationUrl": "https://my.service/docs",
+				},
+				Plans: []osbapi.Plan{
+					{
+						ID:          "small-plan-id",
+						Name:        "small",
+						Description: "a small plan",
+						Schemas: osbapi.Schemas{
+							ServiceInstance: osbapi.ServiceInstanceSchemas{
+								Create: osbapi.InputParameters{
+									Parameters: map[string]any{"type": "object"},
+								},
+							},
+						},
+					},
+					{
+						ID:          "large-plan-id",
+						Name:        "large",
+						Description: "a large plan",
+						Free:        tools.PtrTo(false),
+					},
+				},
+			}},
+		})
+
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "broker-credentials",
+				Namespace: namespace.Name,
+			},
+			StringData: map[string]string{
+				korifiv1alpha1.CFServiceBrokerUsernameKey: broker.Username,
+				korifiv1alpha1.CFServiceBrokerPasswordKey: broker.Password,
+			},
+		}
+		Expect(adminClient.Create(ctx, secret)).To(Succeed())
+
+		cfServiceBroker = &korifiv1alpha1.CFServiceBroker{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      GenerateGUID(),
+				Namespace: namespace.Name,
+			},
+			Spec: korifiv1alpha1.CFServiceBrokerSpec{
+				Name: "my-broker",
+				URL:  broker.URL(),
+				Credentials: corev1.LocalObjectReference{
+					Name: secret.Name,
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		broker.Close()
+		Expect(adminClient.Delete(context.Background(), namespace)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		Expect(adminClient.Create(context.Background(), cfServiceBroker)).To(Succeed())
+	})
+
+	listOfferings := func(g Gomega) []korifiv1alpha1.CFServiceOffering {
+		offerings := new(korifiv1alpha1.CFServiceOfferingList)
+		g.Expect(adminClient.List(ctx, offerings,
+			client.InNamespace(namespace.Name),
+			client.MatchingLabels{korifiv1alpha1.CFServiceBrokerGUIDLabelKey: cfServiceBroker.Name},
+		)).To(Succeed())
+		return offerings.Items
+	}
+
+	listPlans := func(g Gomega) []korifiv1alpha1.CFServicePlan {
+		plans := new(korifiv1alpha1.CFServicePlanList)
+		g.Expect(adminClient.List(ctx, plans,
+			client.InNamespace(namespace.Name),
+			client.MatchingLabels{korifiv1alpha1.CFServiceBrokerGUIDLabelKey: cfServiceBroker.Name},
+		)).To(Succeed())
+		return plans.Items
+	}
+
+	It("sets the Ready condition to true", func() {
+		Eventually(func(g Gomega) {
+			updatedBroker := new(korifiv1alpha1.CFServiceBroker)
+			g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfServiceBroker), updatedBroker)).To(Succeed())
+			g.Expect(updatedBroker.Status.ObservedGeneration).To(Equal(updatedBroker.Generation))
+			g.Expect(updatedBroker.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Type":   Equal(korifiv1alpha1.ReadyConditionType),
+				"Status": Equal(metav1.ConditionTrue),
+				"Reason": Equal("CatalogSynced"),
+			})))
+		}).Should(Succeed())
+	})
+
+	It("creates a service offering for each catalog service", func() {
+		Eventually(func(g Gomega) {
+			offerings := listOfferings(g)
+			g.Expect(offerings).To(HaveLen(1))
+
+			offering := offerings[0]
+			g.Expect(offering.Spec.Name).To(Equal("my-service"))
+			g.Expect(offering.Spec.Description).To(Equal("my service description"))
+			g.Expect(offering.Spec.Bindable).To(BeTrue())
+			g.Expect(offering.Spec.Tags).To(ConsistOf("db"))
+			g.Expect(offering.Spec.DocumentationURL).To(PointTo(Equal("https://my.service/docs")))
+			g.Expect(offering.Spec.BrokerCatalog.ID).To(Equal("service-id"))
+			g.Expect(offering.Spec.BrokerCatalog.Metadata.Raw).To(MatchJSON(`{"documentationUrl": "https://my.service/docs"}`))
+			g.Expect(offering.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Kind": Equal("CFServiceBroker"),
+				"Name": Equal(cfServiceBroker.Name),
+			})))
+		}).Should(Succeed())
+	})
+
+	It("creates a service plan for each catalog plan", func() {
+		Eventually(func(g Gomega) {
+			offerings := listOfferings(g)
+			g.Expect(offerings).To(HaveLen(1))
+
+			plans := listPlans(g)
+			g.Expect(plans).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{
+					"ObjectMeta": MatchFields(IgnoreExtras, Fields{
+						"Labels": HaveKeyWithValue(korifiv1alpha1.CFServiceOfferingGUIDLabelKey, offerings[0].Name),
+					}),
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"Name":        Equal("small"),
+						"Description": Equal("a small plan"),
+						"Free":        BeTrue(),
+						"BrokerCatalog": MatchFields(IgnoreExtras, Fields{
+							"ID": Equal("small-plan-id"),
+						}),
+						"Visibility": Equal(korifiv1alpha1.ServicePlanVisibility{
+							Type: korifiv1alpha1.AdminServicePlanVisibilityType,
+						}),
+					}),
+				}),
+				MatchFields(IgnoreExtras, Fields{
+					"ObjectMeta": MatchFields(IgnoreExtras, Fields{
+						"Labels": HaveKeyWithValue(korifiv1alpha1.CFServiceOfferingGUIDLabelKey, offerings[0].Name),
+					}),
+					"Spec": MatchFields(IgnoreExtras, Fields{
+						"Name": Equal("large"),
+						"Free": BeFalse(),
+					}),
+				}),
+			))
+		}).Should(Succeed())
+	})
+
+	When("the catalog changes", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(listPlans(g)).To(HaveLen(2))
+			}).Should(Succeed())
+
+			broker.SetCatalog(osbapi.Catalog{
+				Services: []osbapi.Service{{
+					ID:          "service-id",
+					Name:        "my-renamed-service",
+					Description: "my service description",
+					Plans: []osbapi.Plan{{
+						ID:   "small-plan-id",
+						Name: "small",
+					}},
+				}},
+			})
+
+			Expect(k8s.PatchResource(ctx, adminClient, cfServiceBroker, func() {
+				cfServiceBroker.Spec.Name = "my-updated-broker"
+			})).To(Succeed())
+		})
+
+		It("updates the offerings and removes plans that are no longer in the catalog", func() {
+			Eventually(func(g Gomega) {
+				offerings := listOfferings(g)
+				g.Expect(offerings).To(HaveLen(1))
+				g.Expect(offerings[0].Spec.Name).To(Equal("my-renamed-service"))
+
+				plans := listPlans(g)
+				g.Expect(plans).To(HaveLen(1))
+				g.Expect(plans[0].Spec.Name).To(Equal("small"))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the plan visibility has been changed", func() {
+		JustBeforeEach(func() {
+			var plans []korifiv1alpha1.CFServicePlan
+			Eventually(func(g Gomega) {
+				plans = listPlans(g)
+				g.Expect(plans).To(HaveLen(2))
+			}).Should(Succeed())
+
+			for i := range plans {
+				Expect(k8s.PatchResource(ctx, adminClient, &plans[i], func() {
+					plans[i].Spec.Visibility.Type = korifiv1alpha1.PublicServicePlanVisibilityType
+				})).To(Succeed())
+			}
+
+			Expect(k8s.PatchResource(ctx, adminClient, cfServiceBroker, func() {
+				cfServiceBroker.Spec.Name = "my-updated-broker"
+			})).To(Succeed())
+		})
+
+		It("keeps the plan visibility when syncing the catalog", func() {
+			Consistently(func(g Gomega) {
+				for _, plan := range listPlans(g) {
+					g.Expect(plan.Spec.Visibility.Type).To(Equal(korifiv1alpha1.PublicServicePlanVisibilityType))
+				}
+			}).Should(Succeed())
+		})
+	})
+
+	When("the credentials secret does not exist", func() {
+		BeforeEach(func() {
+			cfServiceBroker.Spec.Credentials.Name = "not-there"
+		})
+
+		It("sets the Ready condition to false", func() {
+			Eventually(func(g Gomega) {
+				updatedBroker := new(korifiv1alpha1.CFServiceBroker)
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfServiceBroker), updatedBroker)).To(Succeed())
+				g.Expect(updatedBroker.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(korifiv1alpha1.ReadyConditionType),
+					"Status": Equal(metav1.ConditionFalse),
+					"Reason": Equal("CredentialsSecretNotFound"),
+				})))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the broker credentials are wrong", func() {
+		BeforeEach(func() {
+			broker.Password = "something-else"
+		})
+
+		It("sets the Ready condition to false", func() {
+			Eventually(func(g Gomega) {
+				updatedBroker := new(korifiv1alpha1.CFServiceBroker)
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfServiceBroker), updatedBroker)).To(Succeed())
+				g.Expect(updatedBroker.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Type":    Equal(korifiv1alpha1.ReadyConditionType),
+					"Status":  Equal(metav1.ConditionFalse),
+					"Reason":  Equal("CatalogFetchFailed"),
+					"Message": ContainSubstring("401"),
+				})))
+			}).Should(Succeed())
+		})
+	})
+})
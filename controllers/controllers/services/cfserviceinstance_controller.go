@@ -18,9 +18,15 @@ package services
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
@@ -34,21 +40,41 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
+const lastOperationPollingInterval = 5 * time.Second
+
+type ServiceInstanceBrokerClient interface {
+	Provision(context.Context, osbapi.Broker, string, osbapi.ProvisionRequest) (osbapi.OperationResponse, error)
+	Update(context.Context, osbapi.Broker, string, osbapi.UpdateRequest) (osbapi.OperationResponse, error)
+	Deprovision(context.Context, osbapi.Broker, string, osbapi.DeprovisionRequest) (osbapi.OperationResponse, error)
+	GetLastOperation(context.Context, osbapi.Broker, string, osbapi.LastOperationRequest) (osbapi.LastOperation, error)
+}
+
 // CFServiceInstanceReconciler reconciles a CFServiceInstance object
 type CFServiceInstanceReconciler struct {
-	k8sClient client.Client
-	scheme    *runtime.Scheme
-	log       logr.Logger
+	k8sClient     client.Client
+	scheme        *runtime.Scheme
+	brokerClient  ServiceInstanceBrokerClient
+	rootNamespace string
+	log           logr.Logger
 }
 
 func NewCFServiceInstanceReconciler(
 	client client.Client,
 	scheme *runtime.Scheme,
+	brokerClient ServiceInstanceBrokerClient,
+	rootNamespace string,
 	log logr.Logger,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceInstance, *korifiv1alpha1.CFServiceInstance] {
-	serviceInstanceReconciler := CFServiceInstanceReconciler{k8sClient: client, scheme: scheme, log: log}
+	serviceInstanceReconciler := CFServiceInstanceReconciler{
+		k8sClient:     client,
+		scheme:        scheme,
+		brokerClient:  brokerClient,
+		rootNamespace: rootNamespace,
+		log:           log,
+	}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFServiceInstance, *korifiv1alpha1.CFServiceInstance](log, client, &serviceInstanceReconciler)
 }
 
@@ -60,6 +86,11 @@ func (r *CFServiceInstanceReconciler) SetupWithManager(mgr ctrl.Manager) *builde
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceinstances,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceinstances/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceinstances/finalizers,verbs=update
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfservicebrokers,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceofferings,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceplans,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfspaces,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceusageevents,verbs=create
 
 func (r *CFServiceInstanceReconciler) ReconcileResource(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance) (ctrl.Result, error) {
 	log := shared.ObjectLogger(r.log, cfServiceInstance)
@@ -68,6 +99,27 @@ func (r *CFServiceInstanceReconciler) ReconcileResource(ctx context.Context, cfS
 	cfServiceInstance.Status.ObservedGeneration = cfServiceInstance.Generation
 	log.V(1).Info("set observed generation", "generation", cfServiceInstance.Status.ObservedGeneration)
 
+	if err := r.recordUsageEvent(ctx, cfServiceInstance); err != nil {
+		log.Info("failed to record service usage event", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	if cfServiceInstance.GetDeletionTimestamp().IsZero() {
+		if err := r.deleteUnsharedServiceBindings(ctx, cfServiceInstance); err != nil {
+			log.Info("failed to delete service bindings in unshared spaces", "reason", err)
+			return ctrl.Result{}, err
+		}
+	}
+
+	if cfServiceInstance.Spec.Type == korifiv1alpha1.ManagedType {
+		return r.reconcileManagedInstance(ctx, cfServiceInstance)
+	}
+
+	if !cfServiceInstance.GetDeletionTimestamp().IsZero() {
+		removeInstanceFinalizer(ctx, cfServiceInstance)
+		return ctrl.Result{}, nil
+	}
+
 	secret := new(corev1.Secret)
 	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: cfServiceInstance.Spec.SecretName, Namespace: cfServiceInstance.Namespace}, secret)
 	if err != nil {
@@ -85,12 +137,592 @@ func (r *CFServiceInstanceReconciler) ReconcileResource(ctx context.Context, cfS
 	return ctrl.Result{}, nil
 }
 
+func (r *CFServiceInstanceReconciler) reconcileManagedInstance(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if !cfServiceInstance.GetDeletionTimestamp().IsZero() {
+		return r.finalizeManagedInstance(ctx, cfServiceInstance)
+	}
+
+	if operationInProgress(cfServiceInstance) {
+		return r.pollLastOperation(ctx, cfServiceInstance)
+	}
+
+	readyCondition := meta.FindStatusCondition(cfServiceInstance.Status.Conditions, korifiv1alpha1.ReadyConditionType)
+	if readyCondition != nil && readyCondition.ObservedGeneration == cfServiceInstance.Generation {
+		return ctrl.Result{}, nil
+	}
+
+	specHash, err := brokerSpecHash(cfServiceInstance.Spec)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if readyCondition != nil && readyCondition.Status == metav1.ConditionTrue && cfServiceInstance.Status.BrokerSpecHash == specHash {
+		log.V(1).Info("no changes relevant to the broker")
+		readyCondition.ObservedGeneration = cfServiceInstance.Generation
+		return ctrl.Result{}, nil
+	}
+
+	catalogPlan, err := getCatalogPlan(ctx, r.k8sClient, r.rootNamespace, cfServiceInstance.Spec.PlanGUID)
+	if err != nil {
+		log.Info("failed to get service plan", "reason", err)
+		setInstanceNotReady(cfServiceInstance, "PlanNotFound", err.Error())
+		return ctrl.Result{}, err
+	}
+
+	orgGUID, err := getOrgGUID(ctx, r.k8sClient, cfServiceInstance.Namespace)
+	if err != nil {
+		log.Info("failed to get org of service instance", "reason", err)
+		setInstanceNotReady(cfServiceInstance, "SpaceNotFound", err.Error())
+		return ctrl.Result{}, err
+	}
+
+	parameters, err := fromRawExtension(cfServiceInstance.Spec.Parameters)
+	if err != nil {
+		setInstanceNotReady(cfServiceInstance, "InvalidParameters", err.Error())
+		return ctrl.Result{}, nil
+	}
+
+	brokerContext := osbapi.Context{
+		Platform:         "cloudfoundry",
+		OrganizationGUID: orgGUID,
+		SpaceGUID:        cfServiceInstance.Namespace,
+		InstanceName:     cfServiceInstance.Spec.DisplayName,
+	}
+
+	cfServiceInstance.Status.BrokerSpecHash = specHash
+	if !isProvisioned(cfServiceInstance) {
+		return r.provision(ctx, cfServiceInstance, catalogPlan, orgGUID, brokerContext, parameters)
+	}
+
+	return r.update(ctx, cfServiceInstance, catalogPlan, brokerContext, parameters)
+}
+
+func (r *CFServiceInstanceReconciler) provision(
+	ctx context.Context,
+	cfServiceInstance *korifiv1alpha1.CFServiceInstance,
+	catalogPlan catalogPlan,
+	orgGUID string,
+	brokerContext osbapi.Context,
+	parameters map[string]any,
+) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	cfServiceInstance.Status.PlanGUID = catalogPlan.plan.Name
+	response, err := r.brokerClient.Provision(ctx, catalogPlan.broker, cfServiceInstance.Name, osbapi.ProvisionRequest{
+		ServiceID:        catalogPlan.offering.Spec.BrokerCatalog.ID,
+		PlanID:           catalogPlan.plan.Spec.BrokerCatalog.ID,
+		OrganizationGUID: orgGUID,
+		SpaceGUID:        cfServiceInstance.Namespace,
+		Parameters:       parameters,
+		Context:          brokerContext,
+	})
+	if err != nil {
+		log.Info("failed to provision service instance", "reason", err)
+		setLastOperation(cfServiceInstance, korifiv1alpha1.CreateLastOperationType, korifiv1alpha1.FailedLastOperationState, err.Error(), "")
+		setInstanceNotReady(cfServiceInstance, "ProvisioningFailed", err.Error())
+		return ctrl.Result{}, nil
+	}
+
+	return r.operationStarted(cfServiceInstance, korifiv1alpha1.CreateLastOperationType, response)
+}
+
+func (r *CFServiceInstanceReconciler) update(
+	ctx context.Context,
+	cfServiceInstance *korifiv1alpha1.CFServiceInstance,
+	catalogPlan catalogPlan,
+	brokerContext osbapi.Context,
+	parameters map[string]any,
+) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	previousPlanGUID := cfServiceInstance.Status.PlanGUID
+	request := osbapi.UpdateRequest{
+		ServiceID:  catalogPlan.offering.Spec.BrokerCatalog.ID,
+		Parameters: parameters,
+		Context:    brokerContext,
+		PreviousValues: osbapi.PreviousValues{
+			ServiceID: catalogPlan.offering.Spec.BrokerCatalog.ID,
+		},
+	}
+	if previousPlanGUID != catalogPlan.plan.Name {
+		// OSBAPI: the plan id is only sent when the plan is being changed
+		request.PlanID = catalogPlan.plan.Spec.BrokerCatalog.ID
+		if previousPlan, err := getCatalogPlan(ctx, r.k8sClient, r.rootNamespace, previousPlanGUID); err == nil {
+			request.PreviousValues.PlanID = previousPlan.plan.Spec.BrokerCatalog.ID
+		}
+	} else {
+		request.PreviousValues.PlanID = catalogPlan.plan.Spec.BrokerCatalog.ID
+	}
+
+	cfServiceInstance.Status.PlanGUID = catalogPlan.plan.Name
+	response, err := r.brokerClient.Update(ctx, catalogPlan.broker, cfServiceInstance.Name, request)
+	if err != nil {
+		log.Info("failed to update service instance", "reason", err)
+		cfServiceInstance.Status.PlanGUID = previousPlanGUID
+		setLastOperation(cfServiceInstance, korifiv1alpha1.UpdateLastOperationType, korifiv1alpha1.FailedLastOperationState, err.Error(), "")
+		setInstanceNotReady(cfServiceInstance, "UpdateFailed", err.Error())
+		return ctrl.Result{}, nil
+	}
+
+	return r.operationStarted(cfServiceInstance, korifiv1alpha1.UpdateLastOperationType, response)
+}
+
+func (r *CFServiceInstanceReconciler) operationStarted(cfServiceInstance *korifiv1alpha1.CFServiceInstance, operationType string, response osbapi.OperationResponse) (ctrl.Result, error) {
+	cfServiceInstance.Status.OperationGeneration = cfServiceInstance.Generation
+	if response.Async {
+		setLastOperation(cfServiceInstance, operationType, korifiv1alpha1.InProgressLastOperationState, "", response.Operation)
+		setInstanceOperationInProgress(cfServiceInstance, operationType)
+		return ctrl.Result{RequeueAfter: lastOperationPollingInterval}, nil
+	}
+
+	setLastOperation(cfServiceInstance, operationType, korifiv1alpha1.SucceededLastOperationState, "", "")
+	setInstanceReady(cfServiceInstance, operationType)
+	return ctrl.Result{}, nil
+}
+
+func (r *CFServiceInstanceReconciler) pollLastOperation(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+	operation := cfServiceInstance.Status.LastOperation
+
+	catalogPlan, err := getCatalogPlan(ctx, r.k8sClient, r.rootNamespace, cfServiceInstance.Status.PlanGUID)
+	if err != nil {
+		log.Info("failed to get service plan", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	lastOperation, err := r.brokerClient.GetLastOperation(ctx, catalogPlan.broker, cfServiceInstance.Name, osbapi.LastOperationRequest{
+		ServiceID: catalogPlan.offering.Spec.BrokerCatalog.ID,
+		PlanID:    catalogPlan.plan.Spec.BrokerCatalog.ID,
+		Operation: operation.Operation,
+	})
+	if err != nil {
+		// OSBAPI: 410 Gone while polling a deprovision means that the instance has been deleted
+		if operation.Type == korifiv1alpha1.DeleteLastOperationType && isGone(err) {
+			lastOperation = osbapi.LastOperation{State: osbapi.SucceededState}
+		} else {
+			log.Info("failed to get last operation", "reason", err)
+			return ctrl.Result{}, err
+		}
+	}
+
+	switch lastOperation.State {
+	case osbapi.SucceededState:
+		setLastOperation(cfServiceInstance, operation.Type, korifiv1alpha1.SucceededLastOperationState, lastOperation.Description, "")
+		if operation.Type == korifiv1alpha1.DeleteLastOperationType {
+			removeInstanceFinalizer(ctx, cfServiceInstance)
+			return ctrl.Result{}, nil
+		}
+		setInstanceReady(cfServiceInstance, operation.Type)
+		return requeueIfChangedDuringOperation(cfServiceInstance), nil
+	case osbapi.FailedState:
+		setLastOperation(cfServiceInstance, operation.Type, korifiv1alpha1.FailedLastOperationState, lastOperation.Description, "")
+		setInstanceOperationFailed(cfServiceInstance, operation.Type, lastOperation.Description)
+		if operation.Type == korifiv1alpha1.DeleteLastOperationType {
+			// keep retrying until the broker manages to deprovision the instance
+			return ctrl.Result{}, fmt.Errorf("deprovisioning failed: %s", lastOperation.Description)
+		}
+		return requeueIfChangedDuringOperation(cfServiceInstance), nil
+	default:
+		setLastOperation(cfServiceInstance, operation.Type, korifiv1alpha1.InProgressLastOperationState, lastOperation.Description, operation.Operation)
+		setInstanceOperationInProgress(cfServiceInstance, operation.Type)
+		return ctrl.Result{RequeueAfter: lastOperationPollingInterval}, nil
+	}
+}
+
+func (r *CFServiceInstanceReconciler) finalizeManagedInstance(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx)
+
+	if !controllerutil.ContainsFinalizer(cfServiceInstance, korifiv1alpha1.CFServiceInstanceFinalizerName) {
+		return ctrl.Result{}, nil
+	}
+
+	if operationInProgress(cfServiceInstance) {
+		return r.pollLastOperation(ctx, cfServiceInstance)
+	}
+
+	// OSBAPI: bindings have to be deleted by the broker before the instance can be deprovisioned
+	bindingsDeleted, err := r.deleteServiceBindings(ctx, cfServiceInstance)
+	if err != nil {
+		log.Info("failed to delete service bindings", "reason", err)
+		return ctrl.Result{}, err
+	}
+	if !bindingsDeleted {
+		log.V(1).Info("waiting for service bindings to be deleted")
+		return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+	}
+
+	// nothing has ever been requested from the broker
+	if cfServiceInstance.Status.LastOperation == nil {
+		removeInstanceFinalizer(ctx, cfServiceInstance)
+		return ctrl.Result{}, nil
+	}
+
+	catalogPlan, err := getCatalogPlan(ctx, r.k8sClient, r.rootNamespace, cfServiceInstance.Status.PlanGUID)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			log.Info("service plan does not exist anymore, skipping deprovision", "plan", cfServiceInstance.Status.PlanGUID)
+			removeInstanceFinalizer(ctx, cfServiceInstance)
+			return ctrl.Result{}, nil
+		}
+
+		log.Info("failed to get service plan", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	response, err := r.brokerClient.Deprovision(ctx, catalogPlan.broker, cfServiceInstance.Name, osbapi.DeprovisionRequest{
+		ServiceID: catalogPlan.offering.Spec.BrokerCatalog.ID,
+		PlanID:    catalogPlan.plan.Spec.BrokerCatalog.ID,
+	})
+	if err != nil {
+		log.Info("failed to deprovision service instance", "reason", err)
+		setLastOperation(cfServiceInstance, korifiv1alpha1.DeleteLastOperationType, korifiv1alpha1.FailedLastOperationState, err.Error(), "")
+		setInstanceNotReady(cfServiceInstance, "DeprovisioningFailed", err.Error())
+		return ctrl.Result{}, err
+	}
+
+	if response.Async {
+		cfServiceInstance.Status.OperationGeneration = cfServiceInstance.Generation
+		setLastOperation(cfServiceInstance, korifiv1alpha1.DeleteLastOperationType, korifiv1alpha1.InProgressLastOperationState, "", response.Operation)
+		setInstanceOperationInProgress(cfServiceInstance, korifiv1alpha1.DeleteLastOperationType)
+		return ctrl.Result{RequeueAfter: lastOperationPollingInterval}, nil
+	}
+
+	removeInstanceFinalizer(ctx, cfServiceInstance)
+	return ctrl.Result{}, nil
+}
+
+// recordUsageEvent records the creation of user-provided instances and of
+// successfully provisioned managed instances, as well as the deletion of
+// instances whose creation has been recorded
+func (r *CFServiceInstanceReconciler) recordUsageEvent(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance) error {
+	deleting := !cfServiceInstance.GetDeletionTimestamp().IsZero()
+	managed := cfServiceInstance.Spec.Type == korifiv1alpha1.ManagedType
+
+	var state string
+	switch {
+	case deleting && cfServiceInstance.Status.UsageEventState == korifiv1alpha1.ServiceUsageEventStateCreated:
+		state = korifiv1alpha1.ServiceUsageEventStateDeleted
+	case !deleting && cfServiceInstance.Status.UsageEventState == "" && (!managed || isProvisioned(cfServiceInstance)):
+		state = korifiv1alpha1.ServiceUsageEventStateCreated
+	default:
+		return nil
+	}
+
+	space, orgGUID, err := shared.GetUsageEventSpace(ctx, r.k8sClient, cfServiceInstance.Namespace)
+	if err != nil {
+		return err
+	}
+
+	usageEvent := &korifiv1alpha1.CFServiceUsageEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: r.rootNamespace,
+			Name:      shared.UsageEventName(string(cfServiceInstance.UID), state),
+		},
+		Spec: korifiv1alpha1.CFServiceUsageEventSpec{
+			State: state,
+			ServiceInstance: korifiv1alpha1.UsageEventResource{
+				GUID: cfServiceInstance.Name,
+				Name: cfServiceInstance.Spec.DisplayName,
+			},
+			ServiceInstanceType: korifiv1alpha1.UserProvidedServiceInstanceUsageType,
+			Space:               space,
+			OrganizationGUID:    orgGUID,
+			Timestamp:           metav1.NowMicro(),
+		},
+	}
+
+	if managed {
+		usageEvent.Spec.ServiceInstanceType = korifiv1alpha1.ManagedServiceInstanceUsageType
+		err = r.setUsageEventCatalog(ctx, usageEvent, cfServiceInstance.Spec.PlanGUID)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = shared.CreateUsageEvent(ctx, r.k8sClient, usageEvent)
+	if err != nil {
+		return err
+	}
+
+	cfServiceInstance.Status.UsageEventState = state
+	return nil
+}
+
+// setUsageEventCatalog sets the plan, offering and broker of the usage event.
+// Catalog resources that do not exist anymore are omitted from the event
+func (r *CFServiceInstanceReconciler) setUsageEventCatalog(ctx context.Context, usageEvent *korifiv1alpha1.CFServiceUsageEvent, planGUID string) error {
+	plan := new(korifiv1alpha1.CFServicePlan)
+	if err := r.k8sClient.Get(ctx, types.NamespacedName{Namespace: r.rootNamespace, Name: planGUID}, plan); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	usageEvent.Spec.ServicePlan = &korifiv1alpha1.UsageEventResource{GUID: plan.Name, Name: plan.Spec.Name}
+
+	offering := new(korifiv1alpha1.CFServiceOffering)
+	offeringGUID := plan.Labels[korifiv1alpha1.CFServiceOfferingGUIDLabelKey]
+	if err := r.k8sClient.Get(ctx, types.NamespacedName{Namespace: r.rootNamespace, Name: offeringGUID}, offering); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	usageEvent.Spec.ServiceOffering = &korifiv1alpha1.UsageEventResource{GUID: offering.Name, Name: offering.Spec.Name}
+
+	broker := new(korifiv1alpha1.CFServiceBroker)
+	brokerGUID := plan.Labels[korifiv1alpha1.CFServiceBrokerGUIDLabelKey]
+	if err := r.k8sClient.Get(ctx, types.NamespacedName{Namespace: r.rootNamespace, Name: brokerGUID}, broker); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	usageEvent.Spec.ServiceBroker = &korifiv1alpha1.UsageEventResource{GUID: broker.Name, Name: broker.Spec.Name}
+
+	return nil
+}
+
+// deleteServiceBindings deletes the bindings of the instance, including the
+// ones in the spaces it is shared with, and reports whether they are all gone
+func (r *CFServiceInstanceReconciler) deleteServiceBindings(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance) (bool, error) {
+	bindings, err := r.listServiceBindings(ctx, cfServiceInstance)
+	if err != nil {
+		return false, err
+	}
+
+	for i := range bindings {
+		if err = r.k8sClient.Delete(ctx, &bindings[i]); client.IgnoreNotFound(err) != nil {
+			return false, fmt.Errorf("failed to delete service binding %q: %w", bindings[i].Name, err)
+		}
+	}
+
+	return len(bindings) == 0, nil
+}
+
+// deleteUnsharedServiceBindings deletes the bindings of the instance in
+// namespaces it is not shared with (anymore)
+func (r *CFServiceInstanceReconciler) deleteUnsharedServiceBindings(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance) error {
+	bindings, err := r.listServiceBindings(ctx, cfServiceInstance)
+	if err != nil {
+		return err
+	}
+
+	sharedSpaces := map[string]bool{cfServiceInstance.Namespace: true}
+	for _, sharedSpace := range cfServiceInstance.Spec.SharedSpaces {
+		sharedSpaces[sharedSpace] = true
+	}
+
+	for i := range bindings {
+		if sharedSpaces[bindings[i].Namespace] {
+			continue
+		}
+
+		if err = r.k8sClient.Delete(ctx, &bindings[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete service binding %q: %w", bindings[i].Name, err)
+		}
+	}
+
+	return nil
+}
+
+// listServiceBindings lists the bindings of the instance in all namespaces
+func (r *CFServiceInstanceReconciler) listServiceBindings(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance) ([]korifiv1alpha1.CFServiceBinding, error) {
+	bindings := new(korifiv1alpha1.CFServiceBindingList)
+	err := r.k8sClient.List(ctx, bindings,
+		client.MatchingFields{shared.IndexServiceBindingServiceInstanceGUID: cfServiceInstance.Name},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service bindings: %w", err)
+	}
+
+	instanceBindings := []korifiv1alpha1.CFServiceBinding{}
+	for _, binding := range bindings.Items {
+		if binding.ServiceNamespace() == cfServiceInstance.Namespace {
+			instanceBindings = append(instanceBindings, binding)
+		}
+	}
+
+	return instanceBindings, nil
+}
+
+// brokerSpecHash hashes the spec fields of a managed service instance that
+// are sent to the broker, i.e. all fields but the shared spaces
+func brokerSpecHash(spec korifiv1alpha1.CFServiceInstanceSpec) (string, error) {
+	spec.SharedSpaces = nil
+	specBytes, err := json.Marshal(spec)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal service instance spec: %w", err)
+	}
+
+	return fmt.Sprintf("%x", sha256.Sum256(specBytes)), nil
+}
+
+type catalogPlan struct {
+	broker   osbapi.Broker
+	offering *korifiv1alpha1.CFServiceOffering
+	plan     *korifiv1alpha1.CFServicePlan
+}
+
+// getCatalogPlan fetches the plan with the given GUID together with its
+// offering and the credentials of the broker serving it
+func getCatalogPlan(ctx context.Context, k8sClient client.Client, rootNamespace, planGUID string) (catalogPlan, error) {
+	plan := new(korifiv1alpha1.CFServicePlan)
+	if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: rootNamespace, Name: planGUID}, plan); err != nil {
+		return catalogPlan{}, fmt.Errorf("failed to get service plan %q: %w", planGUID, err)
+	}
+
+	offering := new(korifiv1alpha1.CFServiceOffering)
+	offeringGUID := plan.Labels[korifiv1alpha1.CFServiceOfferingGUIDLabelKey]
+	if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: rootNamespace, Name: offeringGUID}, offering); err != nil {
+		return catalogPlan{}, fmt.Errorf("failed to get service offering %q: %w", offeringGUID, err)
+	}
+
+	cfServiceBroker := new(korifiv1alpha1.CFServiceBroker)
+	brokerGUID := plan.Labels[korifiv1alpha1.CFServiceBrokerGUIDLabelKey]
+	if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: rootNamespace, Name: brokerGUID}, cfServiceBroker); err != nil {
+		return catalogPlan{}, fmt.Errorf("failed to get service broker %q: %w", brokerGUID, err)
+	}
+
+	credentialsSecret := new(corev1.Secret)
+	if err := k8sClient.Get(ctx, types.NamespacedName{Namespace: rootNamespace, Name: cfServiceBroker.Spec.Credentials.Name}, credentialsSecret); err != nil {
+		return catalogPlan{}, fmt.Errorf("failed to get credentials of service broker %q: %w", brokerGUID, err)
+	}
+
+	return catalogPlan{
+		broker: osbapi.Broker{
+			URL:      cfServiceBroker.Spec.URL,
+			Username: string(credentialsSecret.Data[korifiv1alpha1.CFServiceBrokerUsernameKey]),
+			Password: string(credentialsSecret.Data[korifiv1alpha1.CFServiceBrokerPasswordKey]),
+		},
+		offering: offering,
+		plan:     plan,
+	}, nil
+}
+
+func getOrgGUID(ctx context.Context, k8sClient client.Client, spaceGUID string) (string, error) {
+	spaces := new(korifiv1alpha1.CFSpaceList)
+	if err := k8sClient.List(ctx, spaces, client.MatchingFields{shared.IndexSpaceNamespaceName: spaceGUID}); err != nil {
+		return "", fmt.Errorf("error listing cfSpaces: %w", err)
+	}
+
+	if len(spaces.Items) != 1 {
+		return "", fmt.Errorf("expected a unique CFSpace for namespace %q, got %d", spaceGUID, len(spaces.Items))
+	}
+
+	return spaces.Items[0].Namespace, nil
+}
+
+func operationInProgress(cfServiceInstance *korifiv1alpha1.CFServiceInstance) bool {
+	return cfServiceInstance.Status.LastOperation != nil &&
+		cfServiceInstance.Status.LastOperation.State == korifiv1alpha1.InProgressLastOperationState
+}
+
+// isProvisioned returns true once the broker has successfully provisioned the instance
+func isProvisioned(cfServiceInstance *korifiv1alpha1.CFServiceInstance) bool {
+	lastOperation := cfServiceInstance.Status.LastOperation
+	if lastOperation == nil {
+		return false
+	}
+
+	return lastOperation.Type != korifiv1alpha1.CreateLastOperationType || lastOperation.State == korifiv1alpha1.SucceededLastOperationState
+}
+
+func isGone(err error) bool {
+	var brokerErr osbapi.BrokerError
+	return errors.As(err, &brokerErr) && brokerErr.StatusCode == http.StatusGone
+}
+
+func removeInstanceFinalizer(ctx context.Context, cfServiceInstance *korifiv1alpha1.CFServiceInstance) {
+	if controllerutil.RemoveFinalizer(cfServiceInstance, korifiv1alpha1.CFServiceInstanceFinalizerName) {
+		logr.FromContextOrDiscard(ctx).V(1).Info("finalizer removed")
+	}
+}
+
+func setLastOperation(cfServiceInstance *korifiv1alpha1.CFServiceInstance, operationType, state, description, operation string) {
+	cfServiceInstance.Status.LastOperation = &korifiv1alpha1.LastOperation{
+		Type:        operationType,
+		State:       state,
+		Description: description,
+		Operation:   operation,
+	}
+}
+
+// requeueIfChangedDuringOperation makes sure that spec changes made while a
+// broker operation was in progress are sent to the broker
+func requeueIfChangedDuringOperation(cfServiceInstance *korifiv1alpha1.CFServiceInstance) ctrl.Result {
+	return ctrl.Result{Requeue: cfServiceInstance.Status.OperationGeneration != cfServiceInstance.Generation}
+}
+
+// The conditions reporting the outcome of broker operations observe the
+// generation the operation was started for, rather than the current one
+
+func setInstanceOperationInProgress(cfServiceInstance *korifiv1alpha1.CFServiceInstance, operationType string) {
+	meta.SetStatusCondition(&cfServiceInstance.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.ReadyConditionType,
+		Status:             metav1.ConditionUnknown,
+		Reason:             operationReasonPrefix(operationType) + "InProgress",
+		ObservedGeneration: cfServiceInstance.Status.OperationGeneration,
+	})
+}
+
+func setInstanceReady(cfServiceInstance *korifiv1alpha1.CFServiceInstance, operationType string) {
+	meta.SetStatusCondition(&cfServiceInstance.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.ReadyConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             operationReasonPrefix(operationType) + "Succeeded",
+		ObservedGeneration: cfServiceInstance.Status.OperationGeneration,
+	})
+}
+
+func setInstanceOperationFailed(cfServiceInstance *korifiv1alpha1.CFServiceInstance, operationType, message string) {
+	meta.SetStatusCondition(&cfServiceInstance.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.ReadyConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             operationFailedReason(operationType),
+		Message:            message,
+		ObservedGeneration: cfServiceInstance.Status.OperationGeneration,
+	})
+}
+
+func setInstanceNotReady(cfServiceInstance *korifiv1alpha1.CFServiceInstance, reason, message string) {
+	meta.SetStatusCondition(&cfServiceInstance.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.ReadyConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: cfServiceInstance.Generation,
+	})
+}
+
+func operationFailedReason(operationType string) string {
+	return operationReasonPrefix(operationType) + "Failed"
+}
+
+func operationReasonPrefix(operationType string) string {
+	switch operationType {
+	case korifiv1alpha1.CreateLastOperationType:
+		return "Provisioning"
+	case korifiv1alpha1.DeleteLastOperationType:
+		return "Deprovisioning"
+	default:
+		return "Update"
+	}
+}
+
+func fromRawExtension(value *runtime.RawExtension) (map[string]any, error) {
+	if value == nil || len(value.Raw) == 0 {
+		return nil, nil
+	}
+
+	result := map[string]any{}
+	if err := json.Unmarshal(value.Raw, &result); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal parameters: %w", err)
+	}
+
+	return result, nil
+}
+
 func bindSecretAvailableStatus(cfServiceInstance *korifiv1alpha1.CFServiceInstance) korifiv1alpha1.CFServiceInstanceStatus {
 	status := korifiv1alpha1.CFServiceInstanceStatus{
 		Binding: corev1.LocalObjectReference{
 			Name: cfServiceInstance.Spec.SecretName,
 		},
 		Conditions:         cfServiceInstance.Status.Conditions,
+		UsageEventState:    cfServiceInstance.Status.UsageEventState,
 		ObservedGeneration: cfServiceInstance.Status.ObservedGeneration,
 	}
 
@@ -108,6 +740,7 @@ func bindSecretUnavailableStatus(cfServiceInstance *korifiv1alpha1.CFServiceInst
 	status := korifiv1alpha1.CFServiceInstanceStatus{
 		Binding:            corev1.LocalObjectReference{},
 		Conditions:         cfServiceInstance.Status.Conditions,
+		UsageEventState:    cfServiceInstance.Status.UsageEventState,
 		ObservedGeneration: cfServiceInstance.Status.ObservedGeneration,
 	}
 
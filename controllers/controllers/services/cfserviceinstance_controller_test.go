@@ -2,17 +2,24 @@ package services_test
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/onsi/gomega/gbytes"
 	. "github.com/onsi/gomega/gstruct"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
+	"code.cloudfoundry.org/korifi/tests/helpers"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 var _ = Describe("CFServiceInstance", func() {
@@ -89,6 +96,52 @@ var _ = Describe("CFServiceInstance", func() {
 		Eventually(logOutput).Should(gbytes.Say("set observed generation"))
 	})
 
+	When("the instance is created and deleted", func() {
+		BeforeEach(func() {
+			cfServiceInstance.Name = GenerateGUID()
+			cfServiceInstance.Finalizers = []string{korifiv1alpha1.CFServiceInstanceFinalizerName}
+		})
+
+		It("records a CREATED service usage event", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(listServiceUsageEvents(g, cfServiceInstance.Name)).To(ConsistOf(
+					MatchFields(IgnoreExtras, Fields{"Spec": MatchFields(IgnoreExtras, Fields{
+						"State":               Equal(korifiv1alpha1.ServiceUsageEventStateCreated),
+						"ServiceInstance":     Equal(korifiv1alpha1.UsageEventResource{GUID: cfServiceInstance.Name, Name: "service-instance-name"}),
+						"ServiceInstanceType": Equal(korifiv1alpha1.UserProvidedServiceInstanceUsageType),
+						"ServicePlan":         BeNil(),
+						"Space":               Equal(korifiv1alpha1.UsageEventResource{GUID: namespace.Name}),
+					})}),
+				))
+			}).Should(Succeed())
+		})
+
+		When("the instance is deleted", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					updatedCFServiceInstance := new(korifiv1alpha1.CFServiceInstance)
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), updatedCFServiceInstance)).To(Succeed())
+					g.Expect(updatedCFServiceInstance.Status.UsageEventState).To(Equal(korifiv1alpha1.ServiceUsageEventStateCreated))
+				}).Should(Succeed())
+
+				Expect(adminClient.Delete(ctx, cfServiceInstance)).To(Succeed())
+			})
+
+			It("records a DELETED service usage event and removes the finalizer", func() {
+				Eventually(func(g Gomega) {
+					err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), new(korifiv1alpha1.CFServiceInstance))
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+
+					g.Expect(listServiceUsageEvents(g, cfServiceInstance.Name)).To(ContainElement(
+						MatchFields(IgnoreExtras, Fields{"Spec": MatchFields(IgnoreExtras, Fields{
+							"State": Equal(korifiv1alpha1.ServiceUsageEventStateDeleted),
+						})}),
+					))
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	When("the referenced secret does not exist", func() {
 		BeforeEach(func() {
 			cfServiceInstance.Spec.SecretName = "other-secret-name"
@@ -138,3 +191,429 @@ var _ = Describe("CFServiceInstance", func() {
 		})
 	})
 })
+
+var _ = Describe("Managed CFServiceInstance", func() {
+	var (
+		orgNamespace      string
+		spaceNamespace    string
+		broker            *helpers.FakeBroker
+		cfServiceBroker   *korifiv1alpha1.CFServiceBroker
+		plans             map[string]korifiv1alpha1.CFServicePlan
+		cfServiceInstance *korifiv1alpha1.CFServiceInstance
+	)
+
+	getInstance := func(g Gomega) *korifiv1alpha1.CFServiceInstance {
+		updatedCFServiceInstance := new(korifiv1alpha1.CFServiceInstance)
+		g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), updatedCFServiceInstance)).To(Succeed())
+		return updatedCFServiceInstance
+	}
+
+	BeforeEach(func() {
+		orgNamespace, spaceNamespace = createSpaceNamespaces()
+
+		broker = helpers.NewFakeBroker(osbapi.Catalog{
+			Services: []osbapi.Service{{
+				ID:             "service-id",
+				Name:           "my-service",
+				Bindable:       true,
+				PlanUpdateable: true,
+				Plans: []osbapi.Plan{
+					{ID: "small-plan-id", Name: "small"},
+					{ID: "large-plan-id", Name: "large"},
+				},
+			}},
+		})
+		cfServiceBroker, plans = registerFakeBroker(broker, 2)
+
+		cfServiceInstance = &korifiv1alpha1.CFServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       GenerateGUID(),
+				Namespace:  spaceNamespace,
+				Finalizers: []string{korifiv1alpha1.CFServiceInstanceFinalizerName},
+			},
+			Spec: korifiv1alpha1.CFServiceInstanceSpec{
+				DisplayName: "my-managed-instance",
+				Type:        korifiv1alpha1.ManagedType,
+				PlanGUID:    plans["small"].Name,
+				Parameters:  &runtime.RawExtension{Raw: []byte(`{"size":"xs"}`)},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		broker.Close()
+		Expect(adminClient.Delete(ctx, cfServiceBroker)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		Expect(adminClient.Create(ctx, cfServiceInstance)).To(Succeed())
+	})
+
+	It("provisions the instance via the broker", func() {
+		Eventually(func(g Gomega) {
+			instance, ok := broker.GetInstance(cfServiceInstance.Name)
+			g.Expect(ok).To(BeTrue())
+			g.Expect(instance).To(Equal(helpers.FakeServiceInstance{
+				ServiceID:  "service-id",
+				PlanID:     "small-plan-id",
+				Parameters: map[string]any{"size": "xs"},
+				Context: osbapi.Context{
+					Platform:         "cloudfoundry",
+					OrganizationGUID: orgNamespace,
+					SpaceGUID:        spaceNamespace,
+					InstanceName:     "my-managed-instance",
+				},
+			}))
+		}).Should(Succeed())
+	})
+
+	It("sets the Ready condition and the last operation", func() {
+		Eventually(func(g Gomega) {
+			updatedCFServiceInstance := getInstance(g)
+			g.Expect(updatedCFServiceInstance.Status.PlanGUID).To(Equal(plans["small"].Name))
+			g.Expect(updatedCFServiceInstance.Status.LastOperation).To(PointTo(MatchFields(IgnoreExtras, Fields{
+				"Type":  Equal(korifiv1alpha1.CreateLastOperationType),
+				"State": Equal(korifiv1alpha1.SucceededLastOperationState),
+			})))
+			g.Expect(updatedCFServiceInstance.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Type":               Equal(korifiv1alpha1.ReadyConditionType),
+				"Status":             Equal(metav1.ConditionTrue),
+				"Reason":             Equal("ProvisioningSucceeded"),
+				"ObservedGeneration": Equal(updatedCFServiceInstance.Generation),
+			})))
+		}).Should(Succeed())
+	})
+
+	It("records a CREATED service usage event with the catalog of the instance", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(listServiceUsageEvents(g, cfServiceInstance.Name)).To(ConsistOf(
+				MatchFields(IgnoreExtras, Fields{"Spec": MatchFields(IgnoreExtras, Fields{
+					"State":               Equal(korifiv1alpha1.ServiceUsageEventStateCreated),
+					"ServiceInstanceType": Equal(korifiv1alpha1.ManagedServiceInstanceUsageType),
+					"ServicePlan":         PointTo(Equal(korifiv1alpha1.UsageEventResource{GUID: plans["small"].Name, Name: "small"})),
+					"ServiceOffering":     PointTo(MatchFields(IgnoreExtras, Fields{"Name": Equal("my-service")})),
+					"ServiceBroker":       PointTo(MatchFields(IgnoreExtras, Fields{"GUID": Equal(cfServiceBroker.Name)})),
+					"Space":               Equal(korifiv1alpha1.UsageEventResource{GUID: spaceNamespace, Name: "my-space"}),
+					"OrganizationGUID":    Equal(orgNamespace),
+				})}),
+			))
+		}).Should(Succeed())
+	})
+
+	When("the broker fails to provision the instance", func() {
+		BeforeEach(func() {
+			broker.SetOperationError(&osbapi.BrokerError{StatusCode: http.StatusBadRequest, Description: "invalid size"})
+		})
+
+		It("does not record a service usage event", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(listServiceUsageEvents(g, cfServiceInstance.Name)).To(BeEmpty())
+			}).Should(Succeed())
+		})
+
+		It("sets the Ready condition to false", func() {
+			Eventually(func(g Gomega) {
+				updatedCFServiceInstance := getInstance(g)
+				g.Expect(updatedCFServiceInstance.Status.LastOperation).To(PointTo(MatchFields(IgnoreExtras, Fields{
+					"Type":  Equal(korifiv1alpha1.CreateLastOperationType),
+					"State": Equal(korifiv1alpha1.FailedLastOperationState),
+				})))
+				g.Expect(updatedCFServiceInstance.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Type":    Equal(korifiv1alpha1.ReadyConditionType),
+					"Status":  Equal(metav1.ConditionFalse),
+					"Reason":  Equal("ProvisioningFailed"),
+					"Message": ContainSubstring("invalid size"),
+				})))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the plan does not exist", func() {
+		BeforeEach(func() {
+			cfServiceInstance.Spec.PlanGUID = "i-do-not-exist"
+		})
+
+		It("sets the Ready condition to false", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(getInstance(g).Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(korifiv1alpha1.ReadyConditionType),
+					"Status": Equal(metav1.ConditionFalse),
+					"Reason": Equal("PlanNotFound"),
+				})))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the broker provisions asynchronously", func() {
+		BeforeEach(func() {
+			broker.SetAsync(true)
+			broker.SetLastOperation(osbapi.LastOperation{State: osbapi.InProgressState, Description: "creating"})
+		})
+
+		It("reports the operation as in progress", func() {
+			Eventually(func(g Gomega) {
+				updatedCFServiceInstance := getInstance(g)
+				g.Expect(updatedCFServiceInstance.Status.LastOperation).To(PointTo(Equal(korifiv1alpha1.LastOperation{
+					Type:        korifiv1alpha1.CreateLastOperationType,
+					State:       korifiv1alpha1.InProgressLastOperationState,
+					Description: "creating",
+					Operation:   "provision-" + cfServiceInstance.Name,
+				})))
+				g.Expect(updatedCFServiceInstance.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal(korifiv1alpha1.ReadyConditionType),
+					"Status": Equal(metav1.ConditionUnknown),
+					"Reason": Equal("ProvisioningInProgress"),
+				})))
+			}).Should(Succeed())
+		})
+
+		When("the operation succeeds", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(getInstance(g).Status.LastOperation).NotTo(BeNil())
+				}).Should(Succeed())
+				broker.SetLastOperation(osbapi.LastOperation{State: osbapi.SucceededState})
+			})
+
+			It("sets the Ready condition to true", func() {
+				Eventually(func(g Gomega) {
+					updatedCFServiceInstance := getInstance(g)
+					g.Expect(updatedCFServiceInstance.Status.LastOperation.State).To(Equal(korifiv1alpha1.SucceededLastOperationState))
+					g.Expect(meta.IsStatusConditionTrue(updatedCFServiceInstance.Status.Conditions, korifiv1alpha1.ReadyConditionType)).To(BeTrue())
+				}).Should(Succeed())
+			})
+		})
+
+		When("the instance is changed while the operation is in progress", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(getInstance(g).Status.LastOperation).NotTo(BeNil())
+				}).Should(Succeed())
+				Expect(k8s.PatchResource(ctx, adminClient, cfServiceInstance, func() {
+					cfServiceInstance.Spec.PlanGUID = plans["large"].Name
+				})).To(Succeed())
+				broker.SetLastOperation(osbapi.LastOperation{State: osbapi.SucceededState})
+			})
+
+			It("sends the change to the broker once the operation completes", func() {
+				Eventually(func(g Gomega) {
+					instance, ok := broker.GetInstance(cfServiceInstance.Name)
+					g.Expect(ok).To(BeTrue())
+					g.Expect(instance.PlanID).To(Equal("large-plan-id"))
+
+					updatedCFServiceInstance := getInstance(g)
+					g.Expect(updatedCFServiceInstance.Status.LastOperation).To(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(korifiv1alpha1.UpdateLastOperationType),
+						"State": Equal(korifiv1alpha1.SucceededLastOperationState),
+					})))
+					g.Expect(updatedCFServiceInstance.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Type":               Equal(korifiv1alpha1.ReadyConditionType),
+						"Status":             Equal(metav1.ConditionTrue),
+						"ObservedGeneration": Equal(updatedCFServiceInstance.Generation),
+					})))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the operation fails", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					g.Expect(getInstance(g).Status.LastOperation).NotTo(BeNil())
+				}).Should(Succeed())
+				broker.SetLastOperation(osbapi.LastOperation{State: osbapi.FailedState, Description: "out of capacity"})
+			})
+
+			It("sets the Ready condition to false", func() {
+				Eventually(func(g Gomega) {
+					updatedCFServiceInstance := getInstance(g)
+					g.Expect(updatedCFServiceInstance.Status.LastOperation.State).To(Equal(korifiv1alpha1.FailedLastOperationState))
+					g.Expect(updatedCFServiceInstance.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Type":    Equal(korifiv1alpha1.ReadyConditionType),
+						"Status":  Equal(metav1.ConditionFalse),
+						"Reason":  Equal("ProvisioningFailed"),
+						"Message": Equal("out of capacity"),
+					})))
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	When("the instance has been provisioned", func() {
+		JustBeforeEach(func() {
+			Eventually(func(g Gomega) {
+				g.Expect(meta.IsStatusConditionTrue(getInstance(g).Status.Conditions, korifiv1alpha1.ReadyConditionType)).To(BeTrue())
+			}).Should(Succeed())
+		})
+
+		When("the plan is changed", func() {
+			JustBeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfServiceInstance, func() {
+					cfServiceInstance.Spec.PlanGUID = plans["large"].Name
+				})).To(Succeed())
+			})
+
+			It("updates the instance via the broker", func() {
+				Eventually(func(g Gomega) {
+					instance, ok := broker.GetInstance(cfServiceInstance.Name)
+					g.Expect(ok).To(BeTrue())
+					g.Expect(instance.PlanID).To(Equal("large-plan-id"))
+
+					updatedCFServiceInstance := getInstance(g)
+					g.Expect(updatedCFServiceInstance.Status.PlanGUID).To(Equal(plans["large"].Name))
+					g.Expect(updatedCFServiceInstance.Status.LastOperation).To(PointTo(MatchFields(IgnoreExtras, Fields{
+						"Type":  Equal(korifiv1alpha1.UpdateLastOperationType),
+						"State": Equal(korifiv1alpha1.SucceededLastOperationState),
+					})))
+					g.Expect(updatedCFServiceInstance.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Type":               Equal(korifiv1alpha1.ReadyConditionType),
+						"Status":             Equal(metav1.ConditionTrue),
+						"ObservedGeneration": Equal(updatedCFServiceInstance.Generation),
+					})))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the instance is shared with another space", func() {
+			var (
+				otherSpaceNamespace string
+				sharedBinding       *korifiv1alpha1.CFServiceBinding
+			)
+
+			BeforeEach(func() {
+				_, otherSpaceNamespace = createSpaceNamespaces()
+			})
+
+			JustBeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfServiceInstance, func() {
+					cfServiceInstance.Spec.SharedSpaces = []string{otherSpaceNamespace}
+				})).To(Succeed())
+
+				sharedBinding = &korifiv1alpha1.CFServiceBinding{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      GenerateGUID(),
+						Namespace: otherSpaceNamespace,
+					},
+					Spec: korifiv1alpha1.CFServiceBindingSpec{
+						Service: corev1.ObjectReference{
+							Kind:       "CFServiceInstance",
+							Name:       cfServiceInstance.Name,
+							Namespace:  spaceNamespace,
+							APIVersion: "korifi.cloudfoundry.org/v1alpha1",
+						},
+						AppRef: corev1.LocalObjectReference{
+							Name: GenerateGUID(),
+						},
+					},
+				}
+				Expect(adminClient.Create(ctx, sharedBinding)).To(Succeed())
+			})
+
+			It("does not update the instance via the broker", func() {
+				Eventually(func(g Gomega) {
+					updatedCFServiceInstance := getInstance(g)
+					g.Expect(updatedCFServiceInstance.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Type":               Equal(korifiv1alpha1.ReadyConditionType),
+						"Status":             Equal(metav1.ConditionTrue),
+						"ObservedGeneration": Equal(updatedCFServiceInstance.Generation),
+					})))
+					g.Expect(updatedCFServiceInstance.Status.LastOperation.Type).To(Equal(korifiv1alpha1.CreateLastOperationType))
+				}).Should(Succeed())
+			})
+
+			It("keeps the bindings in the shared space", func() {
+				Consistently(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(sharedBinding), new(korifiv1alpha1.CFServiceBinding))).To(Succeed())
+				}).Should(Succeed())
+			})
+
+			When("the instance is unshared", func() {
+				JustBeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, adminClient, cfServiceInstance, func() {
+						cfServiceInstance.Spec.SharedSpaces = nil
+					})).To(Succeed())
+				})
+
+				It("deletes the bindings in the space", func() {
+					Eventually(func(g Gomega) {
+						err := adminClient.Get(ctx, client.ObjectKeyFromObject(sharedBinding), new(korifiv1alpha1.CFServiceBinding))
+						g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+					}).Should(Succeed())
+				})
+			})
+
+			When("the instance is deleted", func() {
+				JustBeforeEach(func() {
+					Expect(adminClient.Delete(ctx, cfServiceInstance)).To(Succeed())
+				})
+
+				It("deletes the bindings in the shared space", func() {
+					Eventually(func(g Gomega) {
+						err := adminClient.Get(ctx, client.ObjectKeyFromObject(sharedBinding), new(korifiv1alpha1.CFServiceBinding))
+						g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+					}).Should(Succeed())
+				})
+			})
+		})
+
+		When("the instance is deleted", func() {
+			JustBeforeEach(func() {
+				Expect(adminClient.Delete(ctx, cfServiceInstance)).To(Succeed())
+			})
+
+			It("deprovisions the instance and removes the finalizer", func() {
+				Eventually(func(g Gomega) {
+					_, ok := broker.GetInstance(cfServiceInstance.Name)
+					g.Expect(ok).To(BeFalse())
+
+					err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfServiceInstance), new(korifiv1alpha1.CFServiceInstance))
+					g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+				}).Should(Succeed())
+			})
+
+			It("records a DELETED service usage event", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listServiceUsageEvents(g, cfServiceInstance.Name)).To(ContainElement(
+						MatchFields(IgnoreExtras, Fields{"Spec": MatchFields(IgnoreExtras, Fields{
+							"State":           Equal(korifiv1alpha1.ServiceUsageEventStateDeleted),
+							"ServiceInstance": Equal(korifiv1alpha1.UsageEventResource{GUID: cfServiceInstance.Name, Name: "my-managed-instance"}),
+						})}),
+					))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the broker fails to deprovision the instance", func() {
+			JustBeforeEach(func() {
+				broker.SetOperationError(&osbapi.BrokerError{StatusCode: http.StatusInternalServerError, Description: "oops"})
+				Expect(adminClient.Delete(ctx, cfServiceInstance)).To(Succeed())
+			})
+
+			It("keeps the instance and reports the failure", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(getInstance(g).Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+						"Type":   Equal(korifiv1alpha1.ReadyConditionType),
+						"Status": Equal(metav1.ConditionFalse),
+						"Reason": Equal("DeprovisioningFailed"),
+					})))
+				}).Should(Succeed())
+
+				Consistently(func(g Gomega) {
+					g.Expect(getInstance(g).Finalizers).To(ContainElement(korifiv1alpha1.CFServiceInstanceFinalizerName))
+				}).Should(Succeed())
+			})
+		})
+	})
+})
+
+func listServiceUsageEvents(g Gomega, serviceInstanceGUID string) []korifiv1alpha1.CFServiceUsageEvent {
+	usageEvents := korifiv1alpha1.CFServiceUsageEventList{}
+	g.Expect(adminClient.List(ctx, &usageEvents, client.InNamespace(rootNamespace))).To(Succeed())
+
+	instanceUsageEvents := []korifiv1alpha1.CFServiceUsageEvent{}
+	for _, usageEvent := range usageEvents.Items {
+		if usageEvent.Spec.ServiceInstance.GUID == serviceInstanceGUID {
+			instanceUsageEvents = append(instanceUsageEvents, usageEvent)
+		}
+	}
+	return instanceUsageEvents
+}
@@ -0,0 +1,326 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// routeServiceResolvePeriod is how often the addresses of the route services
+// are resolved again
+const routeServiceResolvePeriod = 5 * time.Minute
+
+// CFServiceRouteBindingReconciler reconciles a CFServiceRouteBinding object
+type CFServiceRouteBindingReconciler struct {
+	k8sClient         client.Client
+	scheme            *runtime.Scheme
+	signatureRotation time.Duration
+	log               logr.Logger
+}
+
+func NewCFServiceRouteBindingReconciler(
+	k8sClient client.Client,
+	scheme *runtime.Scheme,
+	signatureRotation time.Duration,
+	log logr.Logger,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceRouteBinding, *korifiv1alpha1.CFServiceRouteBinding] {
+	routeBindingReconciler := &CFServiceRouteBindingReconciler{
+		k8sClient:         k8sClient,
+		scheme:            scheme,
+		signatureRotation: signatureRotation,
+		log:               log,
+	}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFServiceRouteBinding, *korifiv1alpha1.CFServiceRouteBinding](log, k8sClient, routeBindingReconciler)
+}
+
+func (r *CFServiceRouteBindingReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFServiceRouteBinding{}).
+		Owns(&corev1.Service{}).
+		Owns(&corev1.Endpoints{}).
+		Watches(
+			&korifiv1alpha1.CFServiceInstance{},
+			handler.EnqueueRequestsFromMapFunc(r.serviceInstanceToRouteBindings),
+		)
+}
+
+func (r *CFServiceRouteBindingReconciler) serviceInstanceToRouteBindings(ctx context.Context, o client.Object) []reconcile.Request {
+	routeBindings := new(korifiv1alpha1.CFServiceRouteBindingList)
+	err := r.k8sClient.List(ctx, routeBindings,
+		client.InNamespace(o.GetNamespace()),
+		client.MatchingFields{shared.IndexServiceRouteBindingInstanceGUID: o.GetName()},
+	)
+	if err != nil {
+		r.log.Info("failed to list service route bindings", "serviceInstance", o.GetName(), "reason", err)
+		return nil
+	}
+
+	requests := []reconcile.Request{}
+	for _, routeBinding := range routeBindings.Items {
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&routeBinding)})
+	}
+
+	return requests
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceroutebindings,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfserviceroutebindings/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=endpoints,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;patch
+
+func (r *CFServiceRouteBindingReconciler) ReconcileResource(ctx context.Context, routeBinding *korifiv1alpha1.CFServiceRouteBinding) (ctrl.Result, error) {
+	log := shared.ObjectLogger(r.log, routeBinding)
+	ctx = logr.NewContext(ctx, log)
+
+	routeBinding.Status.ObservedGeneration = routeBinding.Generation
+	log.V(1).Info("set observed generation", "generation", routeBinding.Status.ObservedGeneration)
+
+	if !routeBinding.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	instance := new(korifiv1alpha1.CFServiceInstance)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: routeBinding.Spec.Service.Name, Namespace: routeBinding.Namespace}, instance)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			setRouteBindingNotReady(routeBinding, "ServiceInstanceNotFound", "Service instance does not exist")
+			return ctrl.Result{RequeueAfter: 2 * time.Second}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	err = controllerutil.SetControllerReference(instance, routeBinding, r.scheme)
+	if err != nil {
+		log.Info("error when making the service instance owner of the service route binding", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	if instance.Spec.RouteServiceURL == nil || *instance.Spec.RouteServiceURL == "" {
+		routeBinding.Status.RouteServiceURL = ""
+		setRouteBindingNotReady(routeBinding, "RouteServiceURLMissing", "Service instance has no route service URL")
+		return ctrl.Result{}, nil
+	}
+
+	routeServiceURL, err := url.Parse(*instance.Spec.RouteServiceURL)
+	if err != nil {
+		routeBinding.Status.RouteServiceURL = ""
+		setRouteBindingNotReady(routeBinding, "InvalidRouteServiceURL", err.Error())
+		return ctrl.Result{}, nil
+	}
+
+	// the route service is reached through the addresses resolved here rather
+	// than through its host name, so that it cannot be pointed at the cluster
+	// network after it has been checked
+	routeServiceIPs, err := tools.ResolvePublicHost(ctx, routeServiceURL.Hostname())
+	if err != nil {
+		routeBinding.Status.RouteServiceURL = ""
+		setRouteBindingNotReady(routeBinding, "InvalidRouteServiceHost", err.Error())
+		return ctrl.Result{RequeueAfter: routeServiceResolvePeriod}, nil
+	}
+
+	if err = r.createOrPatchRouteService(ctx, routeBinding, routeServiceURL, routeServiceIPs); err != nil {
+		log.Info("failed to reconcile route service", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	rotateSignatureAfter, err := r.ensureSignatureSecret(ctx, routeBinding)
+	if err != nil {
+		log.Info("failed to reconcile route service signature", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	routeBinding.Status.RouteServiceURL = routeServiceURL.String()
+	meta.SetStatusCondition(&routeBinding.Status.Conditions, metav1.Condition{
+		Type:               shared.StatusConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             "RouteServiceReady",
+		ObservedGeneration: routeBinding.Generation,
+	})
+
+	requeueAfter := routeServiceResolvePeriod
+	if rotateSignatureAfter < requeueAfter {
+		requeueAfter = rotateSignatureAfter
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// createOrPatchRouteService maintains a Service without selector and its
+// Endpoints, holding the addresses of the route service, so that the route
+// proxy can forward traffic to it
+func (r *CFServiceRouteBindingReconciler) createOrPatchRouteService(ctx context.Context, routeBinding *korifiv1alpha1.CFServiceRouteBinding, routeServiceURL *url.URL, routeServiceIPs []net.IP) error {
+	port, err := routeServicePort(routeServiceURL)
+	if err != nil {
+		return err
+	}
+
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      routeBinding.RouteServiceName(),
+			Namespace: routeBinding.Namespace,
+		},
+	}
+
+	_, err = controllerutil.CreateOrPatch(ctx, r.k8sClient, service, func() error {
+		service.Spec.Type = corev1.ServiceTypeClusterIP
+		service.Spec.ExternalName = ""
+		service.Spec.Selector = nil
+		service.Spec.Ports = []corev1.ServicePort{{Port: port, TargetPort: intstr.FromInt(int(port))}}
+
+		return controllerutil.SetControllerReference(routeBinding, service, r.scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reconcile route service: %w", err)
+	}
+
+	endpoints := &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      routeBinding.RouteServiceName(),
+			Namespace: routeBinding.Namespace,
+		},
+	}
+
+	_, err = controllerutil.CreateOrPatch(ctx, r.k8sClient, endpoints, func() error {
+		addresses := []corev1.EndpointAddress{}
+		for _, ip := range routeServiceIPs {
+			addresses = append(addresses, corev1.EndpointAddress{IP: ip.String()})
+		}
+		endpoints.Subsets = []corev1.EndpointSubset{{
+			Addresses: addresses,
+			Ports:     []corev1.EndpointPort{{Port: port}},
+		}}
+
+		return controllerutil.SetControllerReference(routeBinding, endpoints, r.scheme)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reconcile route service endpoints: %w", err)
+	}
+
+	return nil
+}
+
+// ensureSignatureSecret maintains the secret holding the value of the
+// X-CF-Proxy-Signature header. The route service has to send the header back
+// with every request it forwards to the route, which is how the route proxy
+// tells those requests apart from the ones it has to forward to the route
+// service.
+//
+// Contour cannot sign each request with the forwarded URL and a timestamp the
+// way the CF router does, so the value is a static bearer secret. To limit how
+// long a leaked value can be used to bypass the route service, it is replaced
+// every signatureRotation (and every route binding gets its own). The rotation
+// time is recorded on the route binding, so that the route controller picks up
+// the new value. It returns how long until the next rotation is due.
+func (r *CFServiceRouteBindingReconciler) ensureSignatureSecret(ctx context.Context, routeBinding *korifiv1alpha1.CFServiceRouteBinding) (time.Duration, error) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      routeBinding.RouteServiceName(),
+			Namespace: routeBinding.Namespace,
+		},
+	}
+
+	err := r.k8sClient.Get(ctx, client.ObjectKeyFromObject(secret), secret)
+	if client.IgnoreNotFound(err) != nil {
+		return 0, fmt.Errorf("failed to get signature secret: %w", err)
+	}
+
+	if err == nil {
+		rotatedAt, parseErr := time.Parse(time.RFC3339, routeBinding.Annotations[korifiv1alpha1.CFServiceRouteBindingSignatureRotatedAtAnnotation])
+		if parseErr == nil {
+			if rotateAfter := time.Until(rotatedAt.Add(r.signatureRotation)); rotateAfter > 0 {
+				return rotateAfter, nil
+			}
+		}
+	}
+
+	signature := make([]byte, 32)
+	if _, err = rand.Read(signature); err != nil {
+		return 0, fmt.Errorf("failed to generate signature: %w", err)
+	}
+
+	_, err = controllerutil.CreateOrPatch(ctx, r.k8sClient, secret, func() error {
+		secret.Data = map[string][]byte{
+			korifiv1alpha1.CFServiceRouteBindingRouteServiceSignatureKey: []byte(hex.EncodeToString(signature)),
+		}
+
+		return controllerutil.SetControllerReference(routeBinding, secret, r.scheme)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to rotate signature: %w", err)
+	}
+
+	if routeBinding.Annotations == nil {
+		routeBinding.Annotations = map[string]string{}
+	}
+	routeBinding.Annotations[korifiv1alpha1.CFServiceRouteBindingSignatureRotatedAtAnnotation] = time.Now().UTC().Format(time.RFC3339)
+
+	return r.signatureRotation, nil
+}
+
+func routeServicePort(routeServiceURL *url.URL) (int32, error) {
+	if routeServiceURL.Port() == "" {
+		if routeServiceURL.Scheme == "http" {
+			return 80, nil
+		}
+		return 443, nil
+	}
+
+	port, err := strconv.ParseInt(routeServiceURL.Port(), 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid route service port %q: %w", routeServiceURL.Port(), err)
+	}
+
+	return int32(port), nil
+}
+
+func setRouteBindingNotReady(routeBinding *korifiv1alpha1.CFServiceRouteBinding, reason, message string) {
+	meta.SetStatusCondition(&routeBinding.Status.Conditions, metav1.Condition{
+		Type:               shared.StatusConditionReady,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: routeBinding.Generation,
+	})
+}
@@ -0,0 +1,222 @@
+package services_test
+
+import (
+	"context"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFServiceRouteBinding", func() {
+	var (
+		namespace         *corev1.Namespace
+		cfServiceInstance *korifiv1alpha1.CFServiceInstance
+		routeBinding      *korifiv1alpha1.CFServiceRouteBinding
+	)
+
+	BeforeEach(func() {
+		namespace = BuildNamespaceObject(GenerateGUID())
+		Expect(adminClient.Create(context.Background(), namespace)).To(Succeed())
+
+		cfServiceInstance = &korifiv1alpha1.CFServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      GenerateGUID(),
+				Namespace: namespace.Name,
+			},
+			Spec: korifiv1alpha1.CFServiceInstanceSpec{
+				DisplayName:     "route-service",
+				Type:            "user-provided",
+				RouteServiceURL: tools.PtrTo("https://203.0.113.10:8443/some/path"),
+			},
+		}
+		Expect(adminClient.Create(context.Background(), cfServiceInstance)).To(Succeed())
+
+		routeBinding = &korifiv1alpha1.CFServiceRouteBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      GenerateGUID(),
+				Namespace: namespace.Name,
+			},
+			Spec: korifiv1alpha1.CFServiceRouteBindingSpec{
+				Service: corev1.ObjectReference{
+					Kind:       "CFServiceInstance",
+					APIVersion: korifiv1alpha1.GroupVersion.Identifier(),
+					Name:       cfServiceInstance.Name,
+				},
+				RouteRef: corev1.LocalObjectReference{Name: GenerateGUID()},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(adminClient.Delete(context.Background(), namespace)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		Expect(adminClient.Create(context.Background(), routeBinding)).To(Succeed())
+	})
+
+	It("sets the route service URL and the Ready condition in the status", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(context.Background(), client.ObjectKeyFromObject(routeBinding), routeBinding)).To(Succeed())
+			g.Expect(routeBinding.Status.RouteServiceURL).To(Equal("https://203.0.113.10:8443/some/path"))
+			g.Expect(routeBinding.Status.ObservedGeneration).To(Equal(routeBinding.Generation))
+			g.Expect(routeBinding.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+				"Type":   Equal("Ready"),
+				"Status": Equal(metav1.ConditionTrue),
+			})))
+		}).Should(Succeed())
+	})
+
+	It("makes the service instance the owner of the route binding", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(context.Background(), client.ObjectKeyFromObject(routeBinding), routeBinding)).To(Succeed())
+			g.Expect(routeBinding.OwnerReferences).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Kind": Equal("CFServiceInstance"),
+				"Name": Equal(cfServiceInstance.Name),
+			})))
+		}).Should(Succeed())
+	})
+
+	It("creates a service pointing at the addresses of the route service", func() {
+		Eventually(func(g Gomega) {
+			service := new(corev1.Service)
+			g.Expect(adminClient.Get(context.Background(), client.ObjectKey{Namespace: namespace.Name, Name: "rs-" + routeBinding.Name}, service)).To(Succeed())
+			g.Expect(service.Spec.Type).To(Equal(corev1.ServiceTypeClusterIP))
+			g.Expect(service.Spec.Selector).To(BeEmpty())
+			g.Expect(service.Spec.Ports).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Port": BeEquivalentTo(8443),
+			})))
+
+			endpoints := new(corev1.Endpoints)
+			g.Expect(adminClient.Get(context.Background(), client.ObjectKey{Namespace: namespace.Name, Name: "rs-" + routeBinding.Name}, endpoints)).To(Succeed())
+			g.Expect(endpoints.Subsets).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+				"Addresses": ConsistOf(MatchFields(IgnoreExtras, Fields{"IP": Equal("203.0.113.10")})),
+				"Ports":     ConsistOf(MatchFields(IgnoreExtras, Fields{"Port": BeEquivalentTo(8443)})),
+			})))
+		}).Should(Succeed())
+	})
+
+	It("creates a secret with the route service signature", func() {
+		Eventually(func(g Gomega) {
+			secret := new(corev1.Secret)
+			g.Expect(adminClient.Get(context.Background(), client.ObjectKey{Namespace: namespace.Name, Name: "rs-" + routeBinding.Name}, secret)).To(Succeed())
+			g.Expect(secret.Data).To(HaveKeyWithValue("signature", Not(BeEmpty())))
+		}).Should(Succeed())
+	})
+
+	It("records when the signature was rotated on the route binding", func() {
+		Eventually(func(g Gomega) {
+			g.Expect(adminClient.Get(context.Background(), client.ObjectKeyFromObject(routeBinding), routeBinding)).To(Succeed())
+			g.Expect(routeBinding.Annotations).To(HaveKey(korifiv1alpha1.CFServiceRouteBindingSignatureRotatedAtAnnotation))
+		}).Should(Succeed())
+	})
+
+	It("rotates the signature periodically", func() {
+		var signature []byte
+		Eventually(func(g Gomega) {
+			secret := new(corev1.Secret)
+			g.Expect(adminClient.Get(context.Background(), client.ObjectKey{Namespace: namespace.Name, Name: "rs-" + routeBinding.Name}, secret)).To(Succeed())
+			signature = secret.Data["signature"]
+			g.Expect(signature).NotTo(BeEmpty())
+		}).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			secret := new(corev1.Secret)
+			g.Expect(adminClient.Get(context.Background(), client.ObjectKey{Namespace: namespace.Name, Name: "rs-" + routeBinding.Name}, secret)).To(Succeed())
+			g.Expect(secret.Data["signature"]).NotTo(Equal(signature))
+		}).WithTimeout(10 * time.Second).Should(Succeed())
+	})
+
+	When("the service instance has no route service URL", func() {
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(context.Background(), adminClient, cfServiceInstance, func() {
+				cfServiceInstance.Spec.RouteServiceURL = nil
+			})).To(Succeed())
+		})
+
+		It("sets the Ready condition to false", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(context.Background(), client.ObjectKeyFromObject(routeBinding), routeBinding)).To(Succeed())
+				g.Expect(routeBinding.Status.RouteServiceURL).To(BeEmpty())
+				g.Expect(routeBinding.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal("Ready"),
+					"Status": Equal(metav1.ConditionFalse),
+					"Reason": Equal("RouteServiceURLMissing"),
+				})))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the route service URL points at a non-public host", func() {
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(context.Background(), adminClient, cfServiceInstance, func() {
+				cfServiceInstance.Spec.RouteServiceURL = tools.PtrTo("https://kubernetes.default.svc.cluster.local")
+			})).To(Succeed())
+		})
+
+		It("sets the Ready condition to false", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(context.Background(), client.ObjectKeyFromObject(routeBinding), routeBinding)).To(Succeed())
+				g.Expect(routeBinding.Status.RouteServiceURL).To(BeEmpty())
+				g.Expect(routeBinding.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal("Ready"),
+					"Status": Equal(metav1.ConditionFalse),
+					"Reason": Equal("InvalidRouteServiceHost"),
+				})))
+			}).Should(Succeed())
+		})
+
+		It("does not create a service pointing at it", func() {
+			Consistently(func(g Gomega) {
+				service := new(corev1.Service)
+				g.Expect(adminClient.Get(context.Background(), client.ObjectKey{Namespace: namespace.Name, Name: "rs-" + routeBinding.Name}, service)).NotTo(Succeed())
+			}).Should(Succeed())
+		})
+	})
+
+	When("the route service URL resolves to a loopback address", func() {
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(context.Background(), adminClient, cfServiceInstance, func() {
+				cfServiceInstance.Spec.RouteServiceURL = tools.PtrTo("https://127.0.0.1:8443")
+			})).To(Succeed())
+		})
+
+		It("sets the Ready condition to false", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(context.Background(), client.ObjectKeyFromObject(routeBinding), routeBinding)).To(Succeed())
+				g.Expect(routeBinding.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal("Ready"),
+					"Status": Equal(metav1.ConditionFalse),
+					"Reason": Equal("InvalidRouteServiceHost"),
+				})))
+			}).Should(Succeed())
+		})
+	})
+
+	When("the service instance does not exist", func() {
+		BeforeEach(func() {
+			routeBinding.Spec.Service.Name = "does-not-exist"
+		})
+
+		It("sets the Ready condition to false", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(context.Background(), client.ObjectKeyFromObject(routeBinding), routeBinding)).To(Succeed())
+				g.Expect(routeBinding.Status.Conditions).To(ContainElement(MatchFields(IgnoreExtras, Fields{
+					"Type":   Equal("Ready"),
+					"Status": Equal(metav1.ConditionFalse),
+					"Reason": Equal("ServiceInstanceNotFound"),
+				})))
+			}).Should(Succeed())
+		})
+	})
+})
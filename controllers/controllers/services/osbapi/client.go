@@ -0,0 +1,227 @@
+package osbapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+const (
+	APIVersionHeader = "X-Broker-API-Version"
+	APIVersion       = "2.17"
+)
+
+// Client talks to service brokers implementing the Open Service Broker API
+// (https://github.com/openservicebrokerapi/servicebroker/blob/v2.17/spec.md)
+type Client struct {
+	httpClient *http.Client
+}
+
+func NewClient(httpClient *http.Client) *Client {
+	return &Client{
+		httpClient: httpClient,
+	}
+}
+
+// BrokerError is returned whenever the broker responds with an unexpected status code
+type BrokerError struct {
+	StatusCode  int
+	ErrorCode   string `json:"error"`
+	Description string `json:"description"`
+}
+
+func (e BrokerError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("broker responded with status %d: %s", e.StatusCode, e.Description)
+	}
+
+	return fmt.Sprintf("broker responded with status %d", e.StatusCode)
+}
+
+func (c *Client) GetCatalog(ctx context.Context, broker Broker) (Catalog, error) {
+	var catalog Catalog
+	if _, err := c.do(ctx, broker, http.MethodGet, "/v2/catalog", nil, nil, &catalog, http.StatusOK); err != nil {
+		return Catalog{}, fmt.Errorf("failed to get catalog: %w", err)
+	}
+
+	return catalog, nil
+}
+
+func (c *Client) Provision(ctx context.Context, broker Broker, instanceID string, request ProvisionRequest) (OperationResponse, error) {
+	var response OperationResponse
+	statusCode, err := c.do(ctx, broker, http.MethodPut, instancePath(instanceID), acceptsIncomplete(), request, &response,
+		http.StatusOK, http.StatusCreated, http.StatusAccepted,
+	)
+	if err != nil {
+		return OperationResponse{}, fmt.Errorf("failed to provision service instance %q: %w", instanceID, err)
+	}
+
+	response.Async = statusCode == http.StatusAccepted
+	return response, nil
+}
+
+func (c *Client) Update(ctx context.Context, broker Broker, instanceID string, request UpdateRequest) (OperationResponse, error) {
+	var response OperationResponse
+	statusCode, err := c.do(ctx, broker, http.MethodPatch, instancePath(instanceID), acceptsIncomplete(), request, &response,
+		http.StatusOK, http.StatusAccepted,
+	)
+	if err != nil {
+		return OperationResponse{}, fmt.Errorf("failed to update service instance %q: %w", instanceID, err)
+	}
+
+	response.Async = statusCode == http.StatusAccepted
+	return response, nil
+}
+
+// Deprovision asks the broker to delete the service instance. A broker
+// responding with 410 Gone is treated as a successful deprovision
+func (c *Client) Deprovision(ctx context.Context, broker Broker, instanceID string, request DeprovisionRequest) (OperationResponse, error) {
+	query := acceptsIncomplete()
+	query.Set("service_id", request.ServiceID)
+	query.Set("plan_id", request.PlanID)
+
+	var response OperationResponse
+	statusCode, err := c.do(ctx, broker, http.MethodDelete, instancePath(instanceID), query, nil, &response,
+		http.StatusOK, http.StatusAccepted, http.StatusGone,
+	)
+	if err != nil {
+		return OperationResponse{}, fmt.Errorf("failed to deprovision service instance %q: %w", instanceID, err)
+	}
+
+	response.Async = statusCode == http.StatusAccepted
+	return response, nil
+}
+
+func (c *Client) GetLastOperation(ctx context.Context, broker Broker, instanceID string, request LastOperationRequest) (LastOperation, error) {
+	query := url.Values{}
+	query.Set("service_id", request.ServiceID)
+	query.Set("plan_id", request.PlanID)
+	if request.Operation != "" {
+		query.Set("operation", request.Operation)
+	}
+
+	var lastOperation LastOperation
+	if _, err := c.do(ctx, broker, http.MethodGet, instancePath(instanceID)+"/last_operation", query, nil, &lastOperation, http.StatusOK); err != nil {
+		return LastOperation{}, fmt.Errorf("failed to get last operation of service instance %q: %w", instanceID, err)
+	}
+
+	return lastOperation, nil
+}
+
+// Bind creates a binding synchronously and returns the credentials generated
+// by the broker
+func (c *Client) Bind(ctx context.Context, broker Broker, instanceID, bindingID string, request BindRequest) (BindResponse, error) {
+	var response BindResponse
+	if _, err := c.do(ctx, broker, http.MethodPut, bindingPath(instanceID, bindingID), nil, request, &response,
+		http.StatusOK, http.StatusCreated,
+	); err != nil {
+		return BindResponse{}, fmt.Errorf("failed to bind service instance %q: %w", instanceID, err)
+	}
+
+	return response, nil
+}
+
+// Unbind deletes a binding synchronously. A broker responding with 410 Gone
+// is treated as a successful unbind
+func (c *Client) Unbind(ctx context.Context, broker Broker, instanceID, bindingID string, request UnbindRequest) error {
+	query := url.Values{}
+	query.Set("service_id", request.ServiceID)
+	query.Set("plan_id", request.PlanID)
+
+	if _, err := c.do(ctx, broker, http.MethodDelete, bindingPath(instanceID, bindingID), query, nil, nil,
+		http.StatusOK, http.StatusGone,
+	); err != nil {
+		return fmt.Errorf("failed to unbind service binding %q: %w", bindingID, err)
+	}
+
+	return nil
+}
+
+func (c *Client) do(
+	ctx context.Context,
+	broker Broker,
+	method string,
+	path string,
+	query url.Values,
+	requestBody any,
+	responseBody any,
+	expectedStatusCodes ...int,
+) (int, error) {
+	requestURL, err := url.JoinPath(broker.URL, path)
+	if err != nil {
+		return 0, fmt.Errorf("invalid broker url %q: %w", broker.URL, err)
+	}
+	if len(query) > 0 {
+		requestURL = requestURL + "?" + query.Encode()
+	}
+
+	var body io.Reader
+	if requestBody != nil {
+		bodyBytes, marshalErr := json.Marshal(requestBody)
+		if marshalErr != nil {
+			return 0, fmt.Errorf("failed to marshal request body: %w", marshalErr)
+		}
+		body = bytes.NewReader(bodyBytes)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(broker.Username, broker.Password)
+	req.Header.Set(APIVersionHeader, APIVersion)
+	if requestBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request to broker failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if !contains(expectedStatusCodes, resp.StatusCode) {
+		brokerErr := BrokerError{}
+		_ = json.Unmarshal(respBytes, &brokerErr)
+		brokerErr.StatusCode = resp.StatusCode
+		return resp.StatusCode, brokerErr
+	}
+
+	if responseBody != nil && len(respBytes) > 0 {
+		if err = json.Unmarshal(respBytes, responseBody); err != nil {
+			return resp.StatusCode, fmt.Errorf("failed to unmarshal response body: %w", err)
+		}
+	}
+
+	return resp.StatusCode, nil
+}
+
+func instancePath(instanceID string) string {
+	return "/v2/service_instances/" + url.PathEscape(instanceID)
+}
+
+func bindingPath(instanceID, bindingID string) string {
+	return instancePath(instanceID) + "/service_bindings/" + url.PathEscape(bindingID)
+}
+
+func acceptsIncomplete() url.Values {
+	return url.Values{"accepts_incomplete": []string{"true"}}
+}
+
+func contains(statusCodes []int, statusCode int) bool {
+	for _, c := range statusCodes {
+		if c == statusCode {
+			return true
+		}
+	}
+	return false
+}
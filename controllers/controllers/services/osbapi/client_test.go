@@ -0,0 +1,455 @@
+package osbapi_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+	. "github.com/onsi/gomega/gstruct"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		server *ghttp.Server
+		broker osbapi.Broker
+		client *osbapi.Client
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+		broker = osbapi.Broker{
+			URL:      server.URL(),
+			Username: "broker-user",
+			Password: "broker-password",
+		}
+		client = osbapi.NewClient(http.DefaultClient)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Describe("GetCatalog", func() {
+		var (
+			catalog osbapi.Catalog
+			err     error
+		)
+
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/v2/catalog"),
+				ghttp.VerifyBasicAuth("broker-user", "broker-password"),
+				ghttp.VerifyHeaderKV(osbapi.APIVersionHeader, osbapi.APIVersion),
+				ghttp.RespondWith(http.StatusOK, `{
+					"services": [{
+						"id": "service-id",
+						"name": "my-service",
+						"description": "my service",
+						"bindable": true,
+						"tags": ["db"],
+						"metadata": {"displayName": "My Service"},
+						"plans": [{
+							"id": "plan-id",
+							"name": "small",
+							"description": "a small plan",
+							"free": false,
+							"schemas": {
+								"service_instance": {
+									"create": {"parameters": {"type": "object"}}
+								}
+							}
+						}]
+					}]
+				}`),
+			))
+		})
+
+		JustBeforeEach(func() {
+			catalog, err = client.GetCatalog(context.Background(), broker)
+		})
+
+		It("returns the broker catalog", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(catalog.Services).To(HaveLen(1))
+
+			service := catalog.Services[0]
+			Expect(service.ID).To(Equal("service-id"))
+			Expect(service.Name).To(Equal("my-service"))
+			Expect(service.Bindable).To(BeTrue())
+			Expect(service.Tags).To(ConsistOf("db"))
+			Expect(service.Metadata).To(HaveKeyWithValue("displayName", "My Service"))
+			Expect(service.Plans).To(HaveLen(1))
+
+			plan := service.Plans[0]
+			Expect(plan.ID).To(Equal("plan-id"))
+			Expect(plan.Name).To(Equal("small"))
+			Expect(plan.Free).To(PointTo(BeFalse()))
+			Expect(plan.Schemas.ServiceInstance.Create.Parameters).To(HaveKeyWithValue("type", "object"))
+		})
+
+		When("the broker responds with an error", func() {
+			BeforeEach(func() {
+				server.SetHandler(0, ghttp.RespondWith(http.StatusUnauthorized, `{"description": "bad credentials"}`))
+			})
+
+			It("returns a broker error", func() {
+				Expect(err).To(MatchError(ContainSubstring("bad credentials")))
+
+				var brokerErr osbapi.BrokerError
+				Expect(errors.As(err, &brokerErr)).To(BeTrue())
+				Expect(brokerErr.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		When("the broker is unreachable", func() {
+			BeforeEach(func() {
+				broker.URL = "http://127.0.0.1:1"
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("request to broker failed")))
+			})
+		})
+	})
+
+	Describe("Provision", func() {
+		var (
+			response osbapi.OperationResponse
+			err      error
+		)
+
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodPut, "/v2/service_instances/instance-guid", "accepts_incomplete=true"),
+				ghttp.VerifyBasicAuth("broker-user", "broker-password"),
+				ghttp.VerifyHeaderKV(osbapi.APIVersionHeader, osbapi.APIVersion),
+				ghttp.VerifyJSON(`{
+					"service_id": "service-id",
+					"plan_id": "plan-id",
+					"organization_guid": "org-guid",
+					"space_guid": "space-guid",
+					"parameters": {"foo": "bar"},
+					"context": {
+						"platform": "cloudfoundry",
+						"organization_guid": "org-guid",
+						"space_guid": "space-guid",
+						"instance_name": "my-instance"
+					}
+				}`),
+				ghttp.RespondWith(http.StatusCreated, `{"dashboard_url": "https://dashboard"}`),
+			))
+		})
+
+		JustBeforeEach(func() {
+			response, err = client.Provision(context.Background(), broker, "instance-guid", osbapi.ProvisionRequest{
+				ServiceID:        "service-id",
+				PlanID:           "plan-id",
+				OrganizationGUID: "org-guid",
+				SpaceGUID:        "space-guid",
+				Parameters:       map[string]any{"foo": "bar"},
+				Context: osbapi.Context{
+					Platform:         "cloudfoundry",
+					OrganizationGUID: "org-guid",
+					SpaceGUID:        "space-guid",
+					InstanceName:     "my-instance",
+				},
+			})
+		})
+
+		It("provisions the instance synchronously", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Async).To(BeFalse())
+			Expect(response.DashboardURL).To(Equal("https://dashboard"))
+		})
+
+		When("the broker provisions asynchronously", func() {
+			BeforeEach(func() {
+				server.SetHandler(0, ghttp.RespondWith(http.StatusAccepted, `{"operation": "op-1"}`))
+			})
+
+			It("returns the operation", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Async).To(BeTrue())
+				Expect(response.Operation).To(Equal("op-1"))
+			})
+		})
+
+		When("the broker responds with an error", func() {
+			BeforeEach(func() {
+				server.SetHandler(0, ghttp.RespondWith(http.StatusBadRequest, `{"description": "invalid parameters"}`))
+			})
+
+			It("returns a broker error", func() {
+				Expect(err).To(MatchError(ContainSubstring("invalid parameters")))
+
+				var brokerErr osbapi.BrokerError
+				Expect(errors.As(err, &brokerErr)).To(BeTrue())
+				Expect(brokerErr.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+	})
+
+	Describe("Update", func() {
+		var (
+			response osbapi.OperationResponse
+			err      error
+		)
+
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodPatch, "/v2/service_instances/instance-guid", "accepts_incomplete=true"),
+				ghttp.VerifyBasicAuth("broker-user", "broker-password"),
+				ghttp.VerifyJSON(`{
+					"service_id": "service-id",
+					"plan_id": "new-plan-id",
+					"context": {
+						"platform": "cloudfoundry",
+						"organization_guid": "org-guid",
+						"space_guid": "space-guid",
+						"instance_name": "my-instance"
+					},
+					"previous_values": {
+						"service_id": "service-id",
+						"plan_id": "plan-id"
+					}
+				}`),
+				ghttp.RespondWith(http.StatusAccepted, `{"operation": "op-2"}`),
+			))
+		})
+
+		JustBeforeEach(func() {
+			response, err = client.Update(context.Background(), broker, "instance-guid", osbapi.UpdateRequest{
+				ServiceID: "service-id",
+				PlanID:    "new-plan-id",
+				Context: osbapi.Context{
+					Platform:         "cloudfoundry",
+					OrganizationGUID: "org-guid",
+					SpaceGUID:        "space-guid",
+					InstanceName:     "my-instance",
+				},
+				PreviousValues: osbapi.PreviousValues{
+					ServiceID: "service-id",
+					PlanID:    "plan-id",
+				},
+			})
+		})
+
+		It("updates the instance", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Async).To(BeTrue())
+			Expect(response.Operation).To(Equal("op-2"))
+		})
+
+		When("the broker responds with an error", func() {
+			BeforeEach(func() {
+				server.SetHandler(0, ghttp.RespondWith(http.StatusUnprocessableEntity, `{"error": "ConcurrencyError"}`))
+			})
+
+			It("returns a broker error", func() {
+				var brokerErr osbapi.BrokerError
+				Expect(errors.As(err, &brokerErr)).To(BeTrue())
+				Expect(brokerErr.StatusCode).To(Equal(http.StatusUnprocessableEntity))
+				Expect(brokerErr.ErrorCode).To(Equal("ConcurrencyError"))
+			})
+		})
+	})
+
+	Describe("Deprovision", func() {
+		var (
+			response osbapi.OperationResponse
+			err      error
+		)
+
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodDelete, "/v2/service_instances/instance-guid", "accepts_incomplete=true&plan_id=plan-id&service_id=service-id"),
+				ghttp.VerifyBasicAuth("broker-user", "broker-password"),
+				ghttp.RespondWith(http.StatusOK, `{}`),
+			))
+		})
+
+		JustBeforeEach(func() {
+			response, err = client.Deprovision(context.Background(), broker, "instance-guid", osbapi.DeprovisionRequest{
+				ServiceID: "service-id",
+				PlanID:    "plan-id",
+			})
+		})
+
+		It("deprovisions the instance synchronously", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Async).To(BeFalse())
+		})
+
+		When("the instance does not exist anymore", func() {
+			BeforeEach(func() {
+				server.SetHandler(0, ghttp.RespondWith(http.StatusGone, `{}`))
+			})
+
+			It("succeeds", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Async).To(BeFalse())
+			})
+		})
+
+		When("the broker deprovisions asynchronously", func() {
+			BeforeEach(func() {
+				server.SetHandler(0, ghttp.RespondWith(http.StatusAccepted, `{"operation": "op-3"}`))
+			})
+
+			It("returns the operation", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(response.Async).To(BeTrue())
+				Expect(response.Operation).To(Equal("op-3"))
+			})
+		})
+	})
+
+	Describe("GetLastOperation", func() {
+		var (
+			lastOperation osbapi.LastOperation
+			err           error
+		)
+
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodGet, "/v2/service_instances/instance-guid/last_operation", "operation=op-1&plan_id=plan-id&service_id=service-id"),
+				ghttp.VerifyBasicAuth("broker-user", "broker-password"),
+				ghttp.RespondWith(http.StatusOK, `{"state": "in progress", "description": "creating"}`),
+			))
+		})
+
+		JustBeforeEach(func() {
+			lastOperation, err = client.GetLastOperation(context.Background(), broker, "instance-guid", osbapi.LastOperationRequest{
+				ServiceID: "service-id",
+				PlanID:    "plan-id",
+				Operation: "op-1",
+			})
+		})
+
+		It("returns the last operation", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(lastOperation).To(Equal(osbapi.LastOperation{
+				State:       osbapi.InProgressState,
+				Description: "creating",
+			}))
+		})
+
+		When("the instance is gone", func() {
+			BeforeEach(func() {
+				server.SetHandler(0, ghttp.RespondWith(http.StatusGone, `{}`))
+			})
+
+			It("returns a broker error with the gone status code", func() {
+				var brokerErr osbapi.BrokerError
+				Expect(errors.As(err, &brokerErr)).To(BeTrue())
+				Expect(brokerErr.StatusCode).To(Equal(http.StatusGone))
+			})
+		})
+	})
+
+	Describe("Bind", func() {
+		var (
+			response osbapi.BindResponse
+			err      error
+		)
+
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodPut, "/v2/service_instances/instance-guid/service_bindings/binding-guid"),
+				ghttp.VerifyBasicAuth("broker-user", "broker-password"),
+				ghttp.VerifyJSON(`{
+					"service_id": "service-id",
+					"plan_id": "plan-id",
+					"app_guid": "app-guid",
+					"bind_resource": {"app_guid": "app-guid"},
+					"context": {
+						"platform": "cloudfoundry",
+						"organization_guid": "org-guid",
+						"space_guid": "space-guid"
+					}
+				}`),
+				ghttp.RespondWith(http.StatusCreated, `{"credentials": {"user": "alice", "port": 1234}}`),
+			))
+		})
+
+		JustBeforeEach(func() {
+			response, err = client.Bind(context.Background(), broker, "instance-guid", "binding-guid", osbapi.BindRequest{
+				ServiceID:    "service-id",
+				PlanID:       "plan-id",
+				AppGUID:      "app-guid",
+				BindResource: &osbapi.BindResource{AppGUID: "app-guid"},
+				Context: osbapi.Context{
+					Platform:         "cloudfoundry",
+					OrganizationGUID: "org-guid",
+					SpaceGUID:        "space-guid",
+				},
+			})
+		})
+
+		It("returns the binding credentials", func() {
+			Expect(err).NotTo(HaveOccurred())
+			Expect(response.Credentials).To(Equal(map[string]any{
+				"user": "alice",
+				"port": float64(1234),
+			}))
+		})
+
+		When("the broker fails", func() {
+			BeforeEach(func() {
+				server.SetHandler(0, ghttp.RespondWith(http.StatusUnprocessableEntity, `{"error": "RequiresApp", "description": "app required"}`))
+			})
+
+			It("returns a broker error", func() {
+				Expect(err).To(MatchError(ContainSubstring("app required")))
+			})
+		})
+	})
+
+	Describe("Unbind", func() {
+		var err error
+
+		BeforeEach(func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest(http.MethodDelete, "/v2/service_instances/instance-guid/service_bindings/binding-guid", "plan_id=plan-id&service_id=service-id"),
+				ghttp.VerifyBasicAuth("broker-user", "broker-password"),
+				ghttp.RespondWith(http.StatusOK, `{}`),
+			))
+		})
+
+		JustBeforeEach(func() {
+			err = client.Unbind(context.Background(), broker, "instance-guid", "binding-guid", osbapi.UnbindRequest{
+				ServiceID: "service-id",
+				PlanID:    "plan-id",
+			})
+		})
+
+		It("succeeds", func() {
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("the binding does not exist anymore", func() {
+			BeforeEach(func() {
+				server.SetHandler(0, ghttp.RespondWith(http.StatusGone, `{}`))
+			})
+
+			It("succeeds", func() {
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the broker fails", func() {
+			BeforeEach(func() {
+				server.SetHandler(0, ghttp.RespondWith(http.StatusInternalServerError, `{}`))
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(MatchError(ContainSubstring("500")))
+			})
+		})
+	})
+})
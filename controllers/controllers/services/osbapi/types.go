@@ -0,0 +1,137 @@
+package osbapi
+
+type Broker struct {
+	URL      string
+	Username string
+	Password string
+}
+
+type Catalog struct {
+	Services []Service `json:"services"`
+}
+
+type Service struct {
+	ID                   string         `json:"id"`
+	Name                 string         `json:"name"`
+	Description          string         `json:"description"`
+	Tags                 []string       `json:"tags,omitempty"`
+	Requires             []string       `json:"requires,omitempty"`
+	Bindable             bool           `json:"bindable"`
+	InstancesRetrievable bool           `json:"instances_retrievable,omitempty"`
+	BindingsRetrievable  bool           `json:"bindings_retrievable,omitempty"`
+	AllowContextUpdates  bool           `json:"allow_context_updates,omitempty"`
+	PlanUpdateable       bool           `json:"plan_updateable,omitempty"`
+	Metadata             map[string]any `json:"metadata,omitempty"`
+	Plans                []Plan         `json:"plans"`
+}
+
+type Plan struct {
+	ID             string         `json:"id"`
+	Name           string         `json:"name"`
+	Description    string         `json:"description"`
+	Free           *bool          `json:"free,omitempty"`
+	Bindable       *bool          `json:"bindable,omitempty"`
+	PlanUpdateable *bool          `json:"plan_updateable,omitempty"`
+	Metadata       map[string]any `json:"metadata,omitempty"`
+	Schemas        Schemas        `json:"schemas,omitempty"`
+}
+
+type Schemas struct {
+	ServiceInstance ServiceInstanceSchemas `json:"service_instance,omitempty"`
+	ServiceBinding  ServiceBindingSchemas  `json:"service_binding,omitempty"`
+}
+
+type ServiceInstanceSchemas struct {
+	Create InputParameters `json:"create,omitempty"`
+	Update InputParameters `json:"update,omitempty"`
+}
+
+type ServiceBindingSchemas struct {
+	Create InputParameters `json:"create,omitempty"`
+}
+
+type InputParameters struct {
+	Parameters map[string]any `json:"parameters,omitempty"`
+}
+
+const (
+	InProgressState = "in progress"
+	SucceededState  = "succeeded"
+	FailedState     = "failed"
+)
+
+// Context is the platform specific contextual information sent to brokers
+type Context struct {
+	Platform         string `json:"platform"`
+	OrganizationGUID string `json:"organization_guid"`
+	SpaceGUID        string `json:"space_guid"`
+	InstanceName     string `json:"instance_name,omitempty"`
+}
+
+type ProvisionRequest struct {
+	ServiceID        string         `json:"service_id"`
+	PlanID           string         `json:"plan_id"`
+	OrganizationGUID string         `json:"organization_guid"`
+	SpaceGUID        string         `json:"space_guid"`
+	Parameters       map[string]any `json:"parameters,omitempty"`
+	Context          Context        `json:"context"`
+}
+
+type UpdateRequest struct {
+	ServiceID      string         `json:"service_id"`
+	PlanID         string         `json:"plan_id,omitempty"`
+	Parameters     map[string]any `json:"parameters,omitempty"`
+	Context        Context        `json:"context"`
+	PreviousValues PreviousValues `json:"previous_values"`
+}
+
+type PreviousValues struct {
+	ServiceID string `json:"service_id,omitempty"`
+	PlanID    string `json:"plan_id,omitempty"`
+}
+
+type DeprovisionRequest struct {
+	ServiceID string
+	PlanID    string
+}
+
+type LastOperationRequest struct {
+	ServiceID string
+	PlanID    string
+	Operation string
+}
+
+// OperationResponse is the broker response to provision, update and deprovision requests.
+// Async is set when the broker accepted the request and has to be polled for its last operation
+type OperationResponse struct {
+	Async        bool   `json:"-"`
+	DashboardURL string `json:"dashboard_url,omitempty"`
+	Operation    string `json:"operation,omitempty"`
+}
+
+type LastOperation struct {
+	State       string `json:"state"`
+	Description string `json:"description,omitempty"`
+}
+
+type BindRequest struct {
+	ServiceID    string         `json:"service_id"`
+	PlanID       string         `json:"plan_id"`
+	AppGUID      string         `json:"app_guid,omitempty"`
+	BindResource *BindResource  `json:"bind_resource,omitempty"`
+	Parameters   map[string]any `json:"parameters,omitempty"`
+	Context      Context        `json:"context"`
+}
+
+type BindResource struct {
+	AppGUID string `json:"app_guid,omitempty"`
+}
+
+type BindResponse struct {
+	Credentials map[string]any `json:"credentials"`
+}
+
+type UnbindRequest struct {
+	ServiceID string
+	PlanID    string
+}
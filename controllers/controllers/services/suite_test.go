@@ -18,19 +18,25 @@ package services_test
 
 import (
 	"context"
+	"net/http"
 	"path/filepath"
 	"testing"
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	. "code.cloudfoundry.org/korifi/controllers/controllers/services"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
 	"code.cloudfoundry.org/korifi/tests/helpers"
+	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
 	servicebindingv1beta1 "github.com/servicebinding/runtime/apis/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -46,6 +52,7 @@ var (
 	testEnv         *envtest.Environment
 	adminClient     client.Client
 	logOutput       *gbytes.Buffer
+	rootNamespace   string
 )
 
 func TestAPIs(t *testing.T) {
@@ -82,9 +89,14 @@ var _ = BeforeSuite(func() {
 
 	adminClient, stopClientCache = helpers.NewCachedClient(testEnv.Config)
 
+	rootNamespace = GenerateGUID()
+	Expect(adminClient.Create(ctx, BuildNamespaceObject(rootNamespace))).To(Succeed())
+
 	err = (NewCFServiceBindingReconciler(
 		k8sManager.GetClient(),
 		k8sManager.GetScheme(),
+		osbapi.NewClient(http.DefaultClient),
+		rootNamespace,
 		ctrl.Log.WithName("controllers").WithName("CFServiceBinding"),
 	)).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
@@ -92,10 +104,28 @@ var _ = BeforeSuite(func() {
 	err = (NewCFServiceInstanceReconciler(
 		k8sManager.GetClient(),
 		k8sManager.GetScheme(),
+		osbapi.NewClient(http.DefaultClient),
+		rootNamespace,
 		ctrl.Log.WithName("controllers").WithName("CFServiceInstance"),
 	)).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	err = (NewCFServiceRouteBindingReconciler(
+		k8sManager.GetClient(),
+		k8sManager.GetScheme(),
+		2*time.Second,
+		ctrl.Log.WithName("controllers").WithName("CFServiceRouteBinding"),
+	)).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (NewCFServiceBrokerReconciler(
+		k8sManager.GetClient(),
+		k8sManager.GetScheme(),
+		osbapi.NewClient(http.DefaultClient),
+		ctrl.Log.WithName("controllers").WithName("CFServiceBroker"),
+	)).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	stopManager = helpers.StartK8sManager(k8sManager)
 })
 
@@ -104,3 +134,74 @@ var _ = AfterSuite(func() {
 	stopManager()
 	Expect(testEnv.Stop()).To(Succeed())
 })
+
+// createSpaceNamespaces creates an org and a space namespace together with the
+// CFSpace describing the space namespace
+func createSpaceNamespaces() (string, string) {
+	orgNamespace := BuildNamespaceObject(GenerateGUID())
+	Expect(adminClient.Create(ctx, orgNamespace)).To(Succeed())
+	spaceNamespace := BuildNamespaceObject(GenerateGUID())
+	Expect(adminClient.Create(ctx, spaceNamespace)).To(Succeed())
+
+	cfSpace := &korifiv1alpha1.CFSpace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      spaceNamespace.Name,
+			Namespace: orgNamespace.Name,
+		},
+		Spec: korifiv1alpha1.CFSpaceSpec{
+			DisplayName: "my-space",
+		},
+	}
+	Expect(adminClient.Create(ctx, cfSpace)).To(Succeed())
+	Expect(k8s.Patch(ctx, adminClient, cfSpace, func() {
+		cfSpace.Status.GUID = spaceNamespace.Name
+	})).To(Succeed())
+
+	return orgNamespace.Name, spaceNamespace.Name
+}
+
+// registerFakeBroker creates a CFServiceBroker for the fake broker and waits
+// for the expected number of plans to be synced from its catalog. The plans
+// are returned by name
+func registerFakeBroker(broker *helpers.FakeBroker, expectedPlans int) (*korifiv1alpha1.CFServiceBroker, map[string]korifiv1alpha1.CFServicePlan) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GenerateGUID(),
+			Namespace: rootNamespace,
+		},
+		StringData: map[string]string{
+			korifiv1alpha1.CFServiceBrokerUsernameKey: broker.Username,
+			korifiv1alpha1.CFServiceBrokerPasswordKey: broker.Password,
+		},
+	}
+	Expect(adminClient.Create(ctx, secret)).To(Succeed())
+
+	cfServiceBroker := &korifiv1alpha1.CFServiceBroker{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GenerateGUID(),
+			Namespace: rootNamespace,
+		},
+		Spec: korifiv1alpha1.CFServiceBrokerSpec{
+			Name:        GenerateGUID(),
+			URL:         broker.URL(),
+			Credentials: corev1.LocalObjectReference{Name: secret.Name},
+		},
+	}
+	Expect(adminClient.Create(ctx, cfServiceBroker)).To(Succeed())
+
+	plans := map[string]korifiv1alpha1.CFServicePlan{}
+	Eventually(func(g Gomega) {
+		planList := new(korifiv1alpha1.CFServicePlanList)
+		g.Expect(adminClient.List(ctx, planList,
+			client.InNamespace(rootNamespace),
+			client.MatchingLabels{korifiv1alpha1.CFServiceBrokerGUIDLabelKey: cfServiceBroker.Name},
+		)).To(Succeed())
+		g.Expect(planList.Items).To(HaveLen(expectedPlans))
+
+		for _, plan := range planList.Items {
+			plans[plan.Spec.Name] = plan
+		}
+	}).Should(Succeed())
+
+	return cfServiceBroker, plans
+}
@@ -19,6 +19,8 @@ const (
 	IndexRouteDomainQualifiedName          = "domainQualifiedName"
 	IndexServiceBindingAppGUID             = "serviceBindingAppGUID"
 	IndexServiceBindingServiceInstanceGUID = "serviceBindingServiceInstanceGUID"
+	IndexServiceRouteBindingRouteGUID      = "serviceRouteBindingRouteGUID"
+	IndexServiceRouteBindingInstanceGUID   = "serviceRouteBindingServiceInstanceGUID"
 	IndexAppTasks                          = "appTasks"
 	IndexSpaceNamespaceName                = "spaceNamespace"
 	IndexOrgNamespaceName                  = "orgNamespace"
@@ -47,6 +49,16 @@ func SetupIndexWithManager(mgr manager.Manager) error {
 		return err
 	}
 
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), new(korifiv1alpha1.CFServiceRouteBinding), IndexServiceRouteBindingRouteGUID, serviceRouteBindingRouteGUIDIndexFn)
+	if err != nil {
+		return err
+	}
+
+	err = mgr.GetFieldIndexer().IndexField(context.Background(), new(korifiv1alpha1.CFServiceRouteBinding), IndexServiceRouteBindingInstanceGUID, serviceRouteBindingServiceInstanceGUIDIndexFn)
+	if err != nil {
+		return err
+	}
+
 	err = mgr.GetFieldIndexer().IndexField(context.Background(), &korifiv1alpha1.CFTask{}, IndexAppTasks, func(object client.Object) []string {
 		task := object.(*korifiv1alpha1.CFTask)
 		return []string{task.Spec.AppRef.Name}
@@ -98,6 +110,16 @@ func serviceBindingServiceInstanceGUIDIndexFn(rawObj client.Object) []string {
 	return []string{serviceBinding.Spec.Service.Name}
 }
 
+func serviceRouteBindingRouteGUIDIndexFn(rawObj client.Object) []string {
+	serviceRouteBinding := rawObj.(*korifiv1alpha1.CFServiceRouteBinding)
+	return []string{serviceRouteBinding.Spec.RouteRef.Name}
+}
+
+func serviceRouteBindingServiceInstanceGUIDIndexFn(rawObj client.Object) []string {
+	serviceRouteBinding := rawObj.(*korifiv1alpha1.CFServiceRouteBinding)
+	return []string{serviceRouteBinding.Spec.Service.Name}
+}
+
 // GetConditionOrSetAsUnknown is a helper function that retrieves the value of the provided conditionType, like
 // "Succeeded" and returns the value: "True", "False", or "Unknown". If the value is not present, the pointer to the
 // list of conditions provided to the function is used to add an entry to the list of Conditions with a value of
@@ -0,0 +1,52 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/google/uuid"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// UsageEventName derives the name of a usage event from the transition it
+// records, so that a transition that is reconciled more than once results in a
+// single event
+func UsageEventName(transition ...string) string {
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(strings.Join(transition, "/"))).String()
+}
+
+// CreateUsageEvent creates the usage event unless it has already been recorded
+func CreateUsageEvent(ctx context.Context, k8sClient client.Client, usageEvent client.Object) error {
+	err := k8sClient.Create(ctx, usageEvent)
+	if k8serrors.IsAlreadyExists(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create usage event: %w", err)
+	}
+
+	return nil
+}
+
+// GetUsageEventSpace returns the space and organization of the space namespace
+// for usage events. Only the space GUID is returned when the CFSpace cannot be
+// found, as usage must be recorded regardless
+func GetUsageEventSpace(ctx context.Context, k8sClient client.Client, spaceGUID string) (korifiv1alpha1.UsageEventResource, string, error) {
+	spaces := new(korifiv1alpha1.CFSpaceList)
+	if err := k8sClient.List(ctx, spaces, client.MatchingFields{IndexSpaceNamespaceName: spaceGUID}); err != nil {
+		return korifiv1alpha1.UsageEventResource{}, "", fmt.Errorf("error listing cfSpaces: %w", err)
+	}
+
+	if len(spaces.Items) != 1 {
+		return korifiv1alpha1.UsageEventResource{GUID: spaceGUID}, "", nil
+	}
+
+	return korifiv1alpha1.UsageEventResource{
+		GUID: spaceGUID,
+		Name: spaces.Items[0].Spec.DisplayName,
+	}, spaces.Items[0].Namespace, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
@@ -25,6 +26,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
+//counterfeiter:generate -o fake -fake-name RevisionCleaner . RevisionCleaner
+
+type RevisionCleaner interface {
+	Clean(ctx context.Context, app types.NamespacedName) error
+}
+
 type EnvValueBuilder interface {
 	BuildEnvValue(context.Context, *korifiv1alpha1.CFApp) (map[string]string, error)
 }
@@ -36,15 +43,19 @@ type CFAppReconciler struct {
 	scheme                    *runtime.Scheme
 	vcapServicesEnvBuilder    EnvValueBuilder
 	vcapApplicationEnvBuilder EnvValueBuilder
+	rootNamespace             string
+	revisionCleaner           RevisionCleaner
 }
 
-func NewCFAppReconciler(k8sClient client.Client, scheme *runtime.Scheme, log logr.Logger, vcapServicesBuilder, vcapApplicationBuilder EnvValueBuilder) *k8s.PatchingReconciler[korifiv1alpha1.CFApp, *korifiv1alpha1.CFApp] {
+func NewCFAppReconciler(k8sClient client.Client, scheme *runtime.Scheme, log logr.Logger, vcapServicesBuilder, vcapApplicationBuilder EnvValueBuilder, rootNamespace string, revisionCleaner RevisionCleaner) *k8s.PatchingReconciler[korifiv1alpha1.CFApp, *korifiv1alpha1.CFApp] {
 	appReconciler := CFAppReconciler{
 		log:                       log,
 		k8sClient:                 k8sClient,
 		scheme:                    scheme,
 		vcapServicesEnvBuilder:    vcapServicesBuilder,
 		vcapApplicationEnvBuilder: vcapApplicationBuilder,
+		rootNamespace:             rootNamespace,
+		revisionCleaner:           revisionCleaner,
 	}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFApp, *korifiv1alpha1.CFApp](log, k8sClient, &appReconciler)
 }
@@ -80,7 +91,7 @@ func buildToApp(ctx context.Context, o client.Object) []reconcile.Request {
 
 func serviceBindingToApp(ctx context.Context, o client.Object) []reconcile.Request {
 	serviceBinding, ok := o.(*korifiv1alpha1.CFServiceBinding)
-	if !ok {
+	if !ok || serviceBinding.IsKey() {
 		return nil
 	}
 
@@ -99,6 +110,8 @@ func serviceBindingToApp(ctx context.Context, o client.Object) []reconcile.Reque
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps/finalizers,verbs=update
 
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfappusageevents,verbs=create
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfrevisions,verbs=get;list;watch;delete
 
 func (r *CFAppReconciler) ReconcileResource(ctx context.Context, cfApp *korifiv1alpha1.CFApp) (ctrl.Result, error) {
 	log := shared.ObjectLogger(r.log, cfApp)
@@ -163,14 +176,65 @@ func (r *CFAppReconciler) ReconcileResource(ctx context.Context, cfApp *korifiv1
 		ObservedGeneration: cfApp.Generation,
 	})
 
-	err = r.startApp(ctx, cfApp, droplet)
+	cfProcesses, err := r.startApp(ctx, cfApp, droplet)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
+	if state, previousState, ok := appUsageTransition(cfApp); ok {
+		err = r.recordUsageEvents(ctx, cfApp, cfProcesses, state, previousState)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		cfApp.Status.UsageEventState = state
+	}
+
+	err = r.revisionCleaner.Clean(ctx, types.NamespacedName{Name: cfApp.Name, Namespace: cfApp.Namespace})
+	if err != nil {
+		log.Info("unable to clean up old revisions", "reason", err)
+	}
+
 	return ctrl.Result{}, nil
 }
 
+// appUsageTransition returns the app usage event state to record for the
+// processes of the app, if its desired state has changed since the last event
+func appUsageTransition(cfApp *korifiv1alpha1.CFApp) (string, string, bool) {
+	switch {
+	case cfApp.Spec.DesiredState == korifiv1alpha1.StartedState && cfApp.Status.UsageEventState != korifiv1alpha1.AppUsageEventStateStarted:
+		return korifiv1alpha1.AppUsageEventStateStarted, korifiv1alpha1.AppUsageEventStateStopped, true
+	case cfApp.Spec.DesiredState == korifiv1alpha1.StoppedState && cfApp.Status.UsageEventState == korifiv1alpha1.AppUsageEventStateStarted:
+		return korifiv1alpha1.AppUsageEventStateStopped, korifiv1alpha1.AppUsageEventStateStarted, true
+	default:
+		return "", "", false
+	}
+}
+
+func (r *CFAppReconciler) recordUsageEvents(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcesses []korifiv1alpha1.CFProcess, state, previousState string) error {
+	log := logr.FromContextOrDiscard(ctx).WithName("recordUsageEvents").WithValues("state", state)
+
+	space, orgGUID, err := shared.GetUsageEventSpace(ctx, r.k8sClient, cfApp.Namespace)
+	if err != nil {
+		log.Info("error when fetching the space of the app", "reason", err)
+		return err
+	}
+
+	for i := range cfProcesses {
+		usageEvent := newAppUsageEvent(r.rootNamespace, cfApp, &cfProcesses[i], space, orgGUID)
+		usageEvent.Name = shared.UsageEventName(string(cfProcesses[i].UID), strconv.FormatInt(cfApp.Generation, 10), state)
+		usageEvent.Spec.State = state
+		usageEvent.Spec.PreviousState = previousState
+
+		err = shared.CreateUsageEvent(ctx, r.k8sClient, usageEvent)
+		if err != nil {
+			log.Info("error when recording app usage event", "processType", cfProcesses[i].Spec.ProcessType, "reason", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (r *CFAppReconciler) getDroplet(ctx context.Context, cfApp *korifiv1alpha1.CFApp) (*korifiv1alpha1.BuildDropletStatus, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("getDroplet").WithValues("dropletName", cfApp.Spec.CurrentDropletRef.Name)
 
@@ -190,9 +254,10 @@ func (r *CFAppReconciler) getDroplet(ctx context.Context, cfApp *korifiv1alpha1.
 	return cfBuild.Status.Droplet, nil
 }
 
-func (r *CFAppReconciler) startApp(ctx context.Context, cfApp *korifiv1alpha1.CFApp, droplet *korifiv1alpha1.BuildDropletStatus) error {
+func (r *CFAppReconciler) startApp(ctx context.Context, cfApp *korifiv1alpha1.CFApp, droplet *korifiv1alpha1.BuildDropletStatus) ([]korifiv1alpha1.CFProcess, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("startApp")
 
+	cfProcesses := []korifiv1alpha1.CFProcess{}
 	for _, dropletProcess := range addWebIfMissing(droplet.ProcessTypes) {
 		loopLog := log.WithValues("processType", dropletProcess.Type)
 		ctx = logr.NewContext(ctx, loopLog)
@@ -200,25 +265,27 @@ func (r *CFAppReconciler) startApp(ctx context.Context, cfApp *korifiv1alpha1.CF
 		existingProcess, err := r.fetchProcessByType(ctx, cfApp.Name, cfApp.Namespace, dropletProcess.Type)
 		if err != nil {
 			loopLog.Info("error when fetching CFProcess by type", "reason", err)
-			return err
+			return nil, err
 		}
 
 		if existingProcess != nil {
 			err = r.updateCFProcessCommand(ctx, existingProcess, dropletProcess.Command)
 			if err != nil {
 				loopLog.Info("error updating CFProcess", "reason", err)
-				return err
+				return nil, err
 			}
+			cfProcesses = append(cfProcesses, *existingProcess)
 		} else {
-			err = r.createCFProcess(ctx, dropletProcess, droplet.Ports, cfApp)
+			createdProcess, err := r.createCFProcess(ctx, dropletProcess, droplet.Ports, cfApp)
 			if err != nil {
 				loopLog.Info("error creating CFProcess", "reason", err)
-				return err
+				return nil, err
 			}
+			cfProcesses = append(cfProcesses, *createdProcess)
 		}
 	}
 
-	return nil
+	return cfProcesses, nil
 }
 
 func addWebIfMissing(processTypes []korifiv1alpha1.ProcessType) []korifiv1alpha1.ProcessType {
@@ -237,7 +304,7 @@ func (r *CFAppReconciler) updateCFProcessCommand(ctx context.Context, process *k
 	})
 }
 
-func (r *CFAppReconciler) createCFProcess(ctx context.Context, process korifiv1alpha1.ProcessType, ports []int32, cfApp *korifiv1alpha1.CFApp) error {
+func (r *CFAppReconciler) createCFProcess(ctx context.Context, process korifiv1alpha1.ProcessType, ports []int32, cfApp *korifiv1alpha1.CFApp) (*korifiv1alpha1.CFProcess, error) {
 	desiredCFProcess := &korifiv1alpha1.CFProcess{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: cfApp.Namespace,
@@ -257,10 +324,14 @@ func (r *CFAppReconciler) createCFProcess(ctx context.Context, process korifiv1a
 
 	if err := controllerutil.SetControllerReference(cfApp, desiredCFProcess, r.scheme); err != nil {
 		err = fmt.Errorf("failed to set OwnerRef on CFProcess: %w", err)
-		return err
+		return nil, err
+	}
+
+	if err := r.k8sClient.Create(ctx, desiredCFProcess); err != nil {
+		return nil, err
 	}
 
-	return r.k8sClient.Create(ctx, desiredCFProcess)
+	return desiredCFProcess, nil
 }
 
 func (r *CFAppReconciler) fetchProcessByType(ctx context.Context, appGUID, appNamespace, processType string) (*korifiv1alpha1.CFProcess, error) {
@@ -294,6 +365,21 @@ func (r *CFAppReconciler) finalizeCFApp(ctx context.Context, cfApp *korifiv1alph
 		return ctrl.Result{}, nil
 	}
 
+	if cfApp.Status.UsageEventState == korifiv1alpha1.AppUsageEventStateStarted {
+		cfProcesses := korifiv1alpha1.CFProcessList{}
+		err := r.k8sClient.List(ctx, &cfProcesses, client.InNamespace(cfApp.Namespace), client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name})
+		if err != nil {
+			log.Info("failed to list app processes", "reason", err)
+			return ctrl.Result{}, err
+		}
+
+		err = r.recordUsageEvents(ctx, cfApp, cfProcesses.Items, korifiv1alpha1.AppUsageEventStateStopped, korifiv1alpha1.AppUsageEventStateStarted)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		cfApp.Status.UsageEventState = korifiv1alpha1.AppUsageEventStateStopped
+	}
+
 	err := r.finalizeCFAppRoutes(ctx, cfApp)
 	if err != nil {
 		return ctrl.Result{}, err
@@ -14,6 +14,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
+	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -489,6 +490,83 @@ var _ = Describe("CFAppReconciler Integration Tests", func() {
 			}).Should(Succeed())
 		})
 
+		It("cleans up old revisions of the app", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(revisionCleaner.CleanCallCount()).To(BeNumerically(">", 0))
+				var cleanedApps []types.NamespacedName
+				for i := 0; i < revisionCleaner.CleanCallCount(); i++ {
+					_, app := revisionCleaner.CleanArgsForCall(i)
+					cleanedApps = append(cleanedApps, app)
+				}
+				g.Expect(cleanedApps).To(ContainElement(types.NamespacedName{Name: cfAppGUID, Namespace: cfSpace.Status.GUID}))
+			}).Should(Succeed())
+		})
+
+		When("the app is started", func() {
+			JustBeforeEach(func() {
+				Eventually(func(g Gomega) {
+					processes := korifiv1alpha1.CFProcessList{}
+					g.Expect(adminClient.List(ctx, &processes, client.InNamespace(cfSpace.Status.GUID), client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfAppGUID})).To(Succeed())
+					g.Expect(processes.Items).To(HaveLen(2))
+				}).Should(Succeed())
+
+				Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+					cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+				})).To(Succeed())
+			})
+
+			It("records a STARTED app usage event for each process", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listAppUsageEvents(g, cfAppGUID)).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"Spec": MatchFields(IgnoreExtras, Fields{
+							"State":         Equal(korifiv1alpha1.AppUsageEventStateStarted),
+							"PreviousState": Equal(korifiv1alpha1.AppUsageEventStateStopped),
+							"App":           Equal(korifiv1alpha1.UsageEventResource{GUID: cfAppGUID, Name: cfApp.Spec.DisplayName}),
+							"Process":       MatchFields(IgnoreExtras, Fields{"Name": Equal(processTypeWeb)}),
+							"Space":         Equal(korifiv1alpha1.UsageEventResource{GUID: cfSpace.Status.GUID, Name: cfSpace.Spec.DisplayName}),
+						})}),
+						MatchFields(IgnoreExtras, Fields{"Spec": MatchFields(IgnoreExtras, Fields{
+							"State":   Equal(korifiv1alpha1.AppUsageEventStateStarted),
+							"Process": MatchFields(IgnoreExtras, Fields{"Name": Equal(processTypeWorker)}),
+						})}),
+					))
+				}).Should(Succeed())
+			})
+
+			It("sets the usage event state", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+					g.Expect(cfApp.Status.UsageEventState).To(Equal(korifiv1alpha1.AppUsageEventStateStarted))
+				}).Should(Succeed())
+			})
+
+			When("the app is stopped", func() {
+				JustBeforeEach(func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfApp), cfApp)).To(Succeed())
+						g.Expect(cfApp.Status.UsageEventState).To(Equal(korifiv1alpha1.AppUsageEventStateStarted))
+					}).Should(Succeed())
+
+					Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+						cfApp.Spec.DesiredState = korifiv1alpha1.StoppedState
+					})).To(Succeed())
+				})
+
+				It("records a STOPPED app usage event for each process", func() {
+					Eventually(func(g Gomega) {
+						stoppedEvents := 0
+						for _, event := range listAppUsageEvents(g, cfAppGUID) {
+							if event.Spec.State == korifiv1alpha1.AppUsageEventStateStopped {
+								g.Expect(event.Spec.PreviousState).To(Equal(korifiv1alpha1.AppUsageEventStateStarted))
+								stoppedEvents++
+							}
+						}
+						g.Expect(stoppedEvents).To(Equal(2))
+					}).Should(Succeed())
+				})
+			})
+		})
+
 		When("the droplet disappears", func() {
 			JustBeforeEach(func() {
 				Eventually(func(g Gomega) {
@@ -641,3 +719,16 @@ func labelSelectorForAppAndProcess(appGUID, processType string) labels.Selector
 	Expect(selectorValidationErr).NotTo(HaveOccurred())
 	return selector
 }
+
+func listAppUsageEvents(g Gomega, appGUID string) []korifiv1alpha1.CFAppUsageEvent {
+	usageEvents := korifiv1alpha1.CFAppUsageEventList{}
+	g.Expect(adminClient.List(ctx, &usageEvents, client.InNamespace(cfRootNamespace))).To(Succeed())
+
+	appUsageEvents := []korifiv1alpha1.CFAppUsageEvent{}
+	for _, usageEvent := range usageEvents.Items {
+		if usageEvent.Spec.App.GUID == appGUID {
+			appUsageEvents = append(appUsageEvents, usageEvent)
+		}
+	}
+	return appUsageEvents
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"context"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CFAuditEventReconciler prunes CFAuditEvents once they outlive the audit event TTL
+type CFAuditEventReconciler struct {
+	k8sClient             client.Client
+	log                   logr.Logger
+	auditEventTTLDuration time.Duration
+}
+
+func NewCFAuditEventReconciler(
+	client client.Client,
+	log logr.Logger,
+	auditEventTTLDuration time.Duration,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFAuditEvent, *korifiv1alpha1.CFAuditEvent] {
+	auditEventReconciler := CFAuditEventReconciler{
+		k8sClient:             client,
+		log:                   log,
+		auditEventTTLDuration: auditEventTTLDuration,
+	}
+	return k8s.NewPatchingReconciler[korifiv1alpha1.CFAuditEvent, *korifiv1alpha1.CFAuditEvent](log, client, &auditEventReconciler)
+}
+
+func (r *CFAuditEventReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&korifiv1alpha1.CFAuditEvent{})
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfauditevents,verbs=get;list;watch;create;patch;delete
+
+func (r *CFAuditEventReconciler) ReconcileResource(ctx context.Context, cfAuditEvent *korifiv1alpha1.CFAuditEvent) (ctrl.Result, error) {
+	log := shared.ObjectLogger(r.log, cfAuditEvent)
+
+	expiresAt := cfAuditEvent.CreationTimestamp.Add(r.auditEventTTLDuration)
+	if time.Now().Before(expiresAt) {
+		return ctrl.Result{RequeueAfter: time.Until(expiresAt)}, nil
+	}
+
+	log.V(1).Info("deleting-expired-audit-event")
+	err := r.k8sClient.Delete(ctx, cfAuditEvent)
+	if err != nil {
+		log.Info("error-deleting-audit-event", "reason", err)
+	}
+	return ctrl.Result{}, client.IgnoreNotFound(err)
+}
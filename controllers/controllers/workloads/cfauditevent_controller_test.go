@@ -0,0 +1,52 @@
+package workloads_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFAuditEventReconciler Integration Tests", func() {
+	var cfAuditEvent *korifiv1alpha1.CFAuditEvent
+
+	BeforeEach(func() {
+		cfSpace := createSpace(cfOrg)
+
+		cfAuditEvent = &korifiv1alpha1.CFAuditEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cfSpace.Status.GUID,
+				Name:      testutils.PrefixedGUID("audit-event"),
+			},
+			Spec: korifiv1alpha1.CFAuditEventSpec{
+				Type: korifiv1alpha1.AuditEventTypeAppCreate,
+				Actor: korifiv1alpha1.CFAuditEventParticipant{
+					GUID: "some-user",
+					Type: korifiv1alpha1.AuditEventActorTypeUser,
+				},
+				Target: korifiv1alpha1.CFAuditEventParticipant{
+					GUID: "some-app-guid",
+					Type: korifiv1alpha1.AuditEventTargetTypeApp,
+				},
+			},
+		}
+		Expect(adminClient.Create(ctx, cfAuditEvent)).To(Succeed())
+	})
+
+	It("it can get the audit event shortly after it has been recorded", func() {
+		Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfAuditEvent), cfAuditEvent)).To(Succeed())
+	})
+
+	It("deletes the audit event after it expires", func() {
+		auditEvent := new(korifiv1alpha1.CFAuditEvent)
+
+		Eventually(func(g Gomega) {
+			err := adminClient.Get(ctx, client.ObjectKeyFromObject(cfAuditEvent), auditEvent)
+			g.Expect(err).To(HaveOccurred())
+			g.Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+		}).Should(Succeed())
+	})
+})
@@ -19,10 +19,12 @@ package workloads
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/tools/image"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
 	"github.com/go-logr/logr"
@@ -46,14 +48,25 @@ type BuildCleaner interface {
 	Clean(ctx context.Context, app types.NamespacedName) error
 }
 
+type StagingEnvBuilder interface {
+	BuildStagingEnv(ctx context.Context, cfApp *korifiv1alpha1.CFApp) ([]corev1.EnvVar, error)
+}
+
+//counterfeiter:generate -o fake -fake-name ImageConfigGetter . ImageConfigGetter
+
+type ImageConfigGetter interface {
+	Config(ctx context.Context, creds image.Creds, imageRef string) (image.Config, error)
+}
+
 // CFBuildReconciler reconciles a CFBuild object
 type CFBuildReconciler struct {
-	k8sClient        client.Client
-	buildCleaner     BuildCleaner
-	scheme           *runtime.Scheme
-	log              logr.Logger
-	controllerConfig *config.ControllerConfig
-	envBuilder       EnvBuilder
+	k8sClient         client.Client
+	buildCleaner      BuildCleaner
+	scheme            *runtime.Scheme
+	log               logr.Logger
+	controllerConfig  *config.ControllerConfig
+	envBuilder        StagingEnvBuilder
+	imageConfigGetter ImageConfigGetter
 }
 
 func NewCFBuildReconciler(
@@ -62,15 +75,17 @@ func NewCFBuildReconciler(
 	scheme *runtime.Scheme,
 	log logr.Logger,
 	controllerConfig *config.ControllerConfig,
-	envBuilder EnvBuilder,
+	envBuilder StagingEnvBuilder,
+	imageConfigGetter ImageConfigGetter,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFBuild, *korifiv1alpha1.CFBuild] {
 	buildReconciler := CFBuildReconciler{
-		k8sClient:        k8sClient,
-		buildCleaner:     buildCleaner,
-		scheme:           scheme,
-		log:              log,
-		controllerConfig: controllerConfig,
-		envBuilder:       envBuilder,
+		k8sClient:         k8sClient,
+		buildCleaner:      buildCleaner,
+		scheme:            scheme,
+		log:               log,
+		controllerConfig:  controllerConfig,
+		envBuilder:        envBuilder,
+		imageConfigGetter: imageConfigGetter,
 	}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFBuild, *korifiv1alpha1.CFBuild](log, k8sClient, &buildReconciler)
 }
@@ -119,13 +134,6 @@ func (r *CFBuildReconciler) ReconcileResource(ctx context.Context, cfBuild *kori
 		return ctrl.Result{}, err
 	}
 
-	cfPackage := new(korifiv1alpha1.CFPackage)
-	err = r.k8sClient.Get(ctx, types.NamespacedName{Name: cfBuild.Spec.PackageRef.Name, Namespace: cfBuild.Namespace}, cfPackage)
-	if err != nil {
-		log.Info("error when fetching CFPackage", "reason", err)
-		return ctrl.Result{}, err
-	}
-
 	err = controllerutil.SetControllerReference(cfApp, cfBuild, r.scheme)
 	if err != nil {
 		log.Info("unable to set owner reference on CFBuild", "reason", err)
@@ -145,6 +153,21 @@ func (r *CFBuildReconciler) ReconcileResource(ctx context.Context, cfBuild *kori
 		return ctrl.Result{}, nil
 	}
 
+	if cfBuild.Spec.Droplet != nil {
+		return r.useDroplet(ctx, cfBuild)
+	}
+
+	cfPackage := new(korifiv1alpha1.CFPackage)
+	err = r.k8sClient.Get(ctx, types.NamespacedName{Name: cfBuild.Spec.PackageRef.Name, Namespace: cfBuild.Namespace}, cfPackage)
+	if err != nil {
+		log.Info("error when fetching CFPackage", "reason", err)
+		return ctrl.Result{}, err
+	}
+
+	if cfBuild.Spec.Lifecycle.Type == korifiv1alpha1.DockerLifecycle {
+		return r.stageDockerImage(ctx, cfBuild, cfPackage)
+	}
+
 	if stagingStatus == metav1.ConditionUnknown {
 		err = r.createBuildWorkload(ctx, cfBuild, cfApp, cfPackage)
 		if err != nil {
@@ -217,6 +240,92 @@ func (r *CFBuildReconciler) ReconcileResource(ctx context.Context, cfBuild *kori
 	return ctrl.Result{}, nil
 }
 
+// stageDockerImage produces the droplet of a docker build straight from the
+// config of the package image, without running a BuildWorkload. The build only
+// fails when the registry refuses the image for good, it is retried otherwise.
+func (r *CFBuildReconciler) stageDockerImage(ctx context.Context, cfBuild *korifiv1alpha1.CFBuild, cfPackage *korifiv1alpha1.CFPackage) (ctrl.Result, error) {
+	log := logr.FromContextOrDiscard(ctx).WithName("stageDockerImage")
+
+	if cfPackage.Spec.Type != korifiv1alpha1.DockerPackage {
+		setDockerBuildFailed(cfBuild, "InvalidPackage", fmt.Sprintf("package %q is not a docker package", cfPackage.Name))
+		return ctrl.Result{}, nil
+	}
+
+	registry := cfPackage.Spec.Source.Registry
+	creds := image.Creds{Namespace: cfBuild.Namespace}
+	for _, secret := range registry.ImagePullSecrets {
+		creds.SecretNames = append(creds.SecretNames, secret.Name)
+	}
+
+	imageConfig, err := r.imageConfigGetter.Config(ctx, creds, registry.Image)
+	if err != nil {
+		if !image.IsPermanentError(err) {
+			return ctrl.Result{}, fmt.Errorf("failed to get image config: %w", err)
+		}
+
+		log.Info("failed to get image config", "reason", err)
+		setDockerBuildFailed(cfBuild, "ImageConfigError", err.Error())
+		return ctrl.Result{}, nil
+	}
+
+	setBuildSucceeded(cfBuild)
+	cfBuild.Status.Droplet = &korifiv1alpha1.BuildDropletStatus{
+		Registry: registry,
+		ProcessTypes: []korifiv1alpha1.ProcessType{{
+			Type:    korifiv1alpha1.ProcessTypeWeb,
+			Command: strings.Join(append(imageConfig.Entrypoint, imageConfig.Cmd...), " "),
+		}},
+		Ports: imageConfig.ExposedPorts,
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// useDroplet makes the droplet set on the build its staged droplet, once the
+// droplet image has been uploaded
+func (r *CFBuildReconciler) useDroplet(ctx context.Context, cfBuild *korifiv1alpha1.CFBuild) (ctrl.Result, error) {
+	if cfBuild.Spec.Droplet.Registry.Image == "" {
+		logr.FromContextOrDiscard(ctx).V(1).Info("waiting for the droplet image to be uploaded")
+		return ctrl.Result{}, nil
+	}
+
+	setBuildSucceeded(cfBuild)
+	cfBuild.Status.Droplet = cfBuild.Spec.Droplet.DeepCopy()
+
+	return ctrl.Result{}, nil
+}
+
+func setBuildSucceeded(cfBuild *korifiv1alpha1.CFBuild) {
+	meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.StagingConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             "BuildNotRunning",
+		ObservedGeneration: cfBuild.Generation,
+	})
+	meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.SucceededConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             "BuildSucceeded",
+		ObservedGeneration: cfBuild.Generation,
+	})
+}
+
+func setDockerBuildFailed(cfBuild *korifiv1alpha1.CFBuild, reason, message string) {
+	meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.StagingConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             "BuildNotRunning",
+		ObservedGeneration: cfBuild.Generation,
+	})
+	meta.SetStatusCondition(&cfBuild.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.SucceededConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             "BuildFailed",
+		Message:            fmt.Sprintf("%s: %s", reason, message),
+		ObservedGeneration: cfBuild.Generation,
+	})
+}
+
 func (r *CFBuildReconciler) createBuildWorkload(ctx context.Context, cfBuild *korifiv1alpha1.CFBuild, cfApp *korifiv1alpha1.CFApp, cfPackage *korifiv1alpha1.CFPackage) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("createBuildWorkload")
 
@@ -242,6 +351,7 @@ func (r *CFBuildReconciler) createBuildWorkload(ctx context.Context, cfBuild *ko
 			},
 			BuilderName: r.controllerConfig.BuilderName,
 			Buildpacks:  cfBuild.Spec.Lifecycle.Data.Buildpacks,
+			Stack:       cfBuild.Spec.Lifecycle.Data.Stack,
 		},
 	}
 
@@ -251,13 +361,21 @@ func (r *CFBuildReconciler) createBuildWorkload(ctx context.Context, cfBuild *ko
 	}
 	desiredWorkload.Spec.Services = buildServices
 
-	imageEnvironment, err := r.envBuilder.BuildEnv(ctx, cfApp)
+	imageEnvironment, err := r.envBuilder.BuildStagingEnv(ctx, cfApp)
 	if err != nil {
 		log.Info("failed to build environment", "reason", err)
 		return err
 	}
 	desiredWorkload.Spec.Env = imageEnvironment
 
+	nodeSelector, tolerations, err := getIsolationSegmentPlacement(ctx, r.k8sClient, r.controllerConfig.CFRootNamespace, namespace)
+	if err != nil {
+		log.Info("failed to get the isolation segment of the space", "reason", err)
+		return err
+	}
+	desiredWorkload.Spec.NodeSelector = nodeSelector
+	desiredWorkload.Spec.Tolerations = tolerations
+
 	err = controllerutil.SetControllerReference(cfBuild, &desiredWorkload, r.scheme)
 	if err != nil {
 		log.Info("failed to set OwnerRef on BuildWorkload", "reason", err)
@@ -3,17 +3,22 @@ package workloads_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	. "code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
 	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/image"
 	"code.cloudfoundry.org/korifi/tools/k8s"
 
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
 	. "github.com/onsi/gomega/gstruct"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -196,6 +201,21 @@ var _ = Describe("CFBuildReconciler Integration Tests", func() {
 			})
 		})
 
+		When("the space is assigned to an isolation segment", func() {
+			var isolationSegment *korifiv1alpha1.CFIsolationSegment
+
+			BeforeEach(func() {
+				isolationSegment = assignIsolationSegment(cfSpace)
+			})
+
+			It("creates a BuildWorkload with the node selector and tolerations of the isolation segment", func() {
+				eventuallyBuildWorkloadShould(func(workload *korifiv1alpha1.BuildWorkload, g Gomega) {
+					g.Expect(workload.Spec.NodeSelector).To(Equal(isolationSegment.Spec.NodeSelector))
+					g.Expect(workload.Spec.Tolerations).To(Equal(isolationSegment.Spec.Tolerations))
+				})
+			})
+		})
+
 		When("BuildWorkload with CFBuild GUID doesn't exist", func() {
 			It("creates a BuildWorkload owned by the CFBuild", func() {
 				lookupKey := types.NamespacedName{Name: cfBuildGUID, Namespace: cfSpace.Status.GUID}
@@ -472,6 +492,181 @@ var _ = Describe("CFBuildReconciler Integration Tests", func() {
 		})
 	})
 
+	When("the build uses the docker lifecycle", func() {
+		BeforeEach(func() {
+			imageConfigGetter.ConfigReturns(image.Config{
+				ExposedPorts: []int32{8888},
+				Entrypoint:   []string{"/bin/app"},
+				Cmd:          []string{"--serve"},
+			}, nil)
+
+			desiredCFPackage = BuildCFPackageCRObject(cfPackageGUID, cfSpace.Status.GUID, cfAppGUID, "docker.io/some/image")
+			desiredCFPackage.Spec.Type = korifiv1alpha1.DockerPackage
+			desiredCFPackage.Spec.Source.Registry.ImagePullSecrets = []corev1.LocalObjectReference{{Name: "image-pull-secret"}}
+			Expect(adminClient.Create(ctx, desiredCFPackage)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			cfBuildGUID = PrefixedGUID("cf-build")
+			desiredCFBuild = BuildCFBuildObject(cfBuildGUID, cfSpace.Status.GUID, cfPackageGUID, cfAppGUID)
+			desiredCFBuild.Spec.Lifecycle = korifiv1alpha1.Lifecycle{Type: korifiv1alpha1.DockerLifecycle}
+			Expect(adminClient.Create(ctx, desiredCFBuild)).To(Succeed())
+		})
+
+		It("sets the droplet from the image config without a BuildWorkload", func() {
+			lookupKey := types.NamespacedName{Name: cfBuildGUID, Namespace: cfSpace.Status.GUID}
+			Eventually(func(g Gomega) {
+				createdCFBuild := new(korifiv1alpha1.CFBuild)
+				g.Expect(adminClient.Get(ctx, lookupKey, createdCFBuild)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(createdCFBuild.Status.Conditions, succeededConditionType)).To(BeTrue())
+				g.Expect(createdCFBuild.Status.Droplet).To(Equal(&korifiv1alpha1.BuildDropletStatus{
+					Registry: korifiv1alpha1.Registry{
+						Image:            "docker.io/some/image",
+						ImagePullSecrets: []corev1.LocalObjectReference{{Name: "image-pull-secret"}},
+					},
+					ProcessTypes: []korifiv1alpha1.ProcessType{{Type: "web", Command: "/bin/app --serve"}},
+					Ports:        []int32{8888},
+				}))
+			}).Should(Succeed())
+
+			Consistently(func(g Gomega) {
+				err := adminClient.Get(ctx, lookupKey, new(korifiv1alpha1.BuildWorkload))
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}, "1s").Should(Succeed())
+		})
+
+		It("reads the image config with the package image pull secrets", func() {
+			Eventually(func(g Gomega) {
+				found := false
+				for i := 0; i < imageConfigGetter.ConfigCallCount(); i++ {
+					_, creds, imageRef := imageConfigGetter.ConfigArgsForCall(i)
+					if creds.Namespace == cfSpace.Status.GUID {
+						found = true
+						g.Expect(creds.SecretNames).To(ConsistOf("image-pull-secret"))
+						g.Expect(imageRef).To(Equal("docker.io/some/image"))
+					}
+				}
+				g.Expect(found).To(BeTrue())
+			}).Should(Succeed())
+		})
+
+		When("the registry does not know the image", func() {
+			BeforeEach(func() {
+				imageConfigGetter.ConfigReturns(image.Config{}, fmt.Errorf("failed to get image: %w", &transport.Error{
+					StatusCode: http.StatusNotFound,
+					Errors:     []transport.Diagnostic{{Code: transport.ManifestUnknownErrorCode, Message: "no-such-image"}},
+				}))
+			})
+
+			It("fails the build", func() {
+				lookupKey := types.NamespacedName{Name: cfBuildGUID, Namespace: cfSpace.Status.GUID}
+				Eventually(func(g Gomega) {
+					createdCFBuild := new(korifiv1alpha1.CFBuild)
+					g.Expect(adminClient.Get(ctx, lookupKey, createdCFBuild)).To(Succeed())
+					succeededStatusCondition := meta.FindStatusCondition(createdCFBuild.Status.Conditions, succeededConditionType)
+					g.Expect(succeededStatusCondition).NotTo(BeNil())
+					g.Expect(succeededStatusCondition.Status).To(Equal(metav1.ConditionFalse))
+					g.Expect(succeededStatusCondition.Message).To(ContainSubstring("no-such-image"))
+				}).Should(Succeed())
+			})
+		})
+
+		When("getting the image config fails temporarily", func() {
+			// the image config getter is shared with other tests, so only
+			// count the calls for the space of this test
+			configCallsInSpace := func() int {
+				calls := 0
+				for i := 0; i < imageConfigGetter.ConfigCallCount(); i++ {
+					if _, creds, _ := imageConfigGetter.ConfigArgsForCall(i); creds.Namespace == cfSpace.Status.GUID {
+						calls++
+					}
+				}
+				return calls
+			}
+
+			BeforeEach(func() {
+				imageConfigGetter.ConfigReturns(image.Config{}, errors.New("connection refused"))
+			})
+
+			It("retries without failing the build", func() {
+				Eventually(configCallsInSpace).Should(BeNumerically(">", 1))
+
+				lookupKey := types.NamespacedName{Name: cfBuildGUID, Namespace: cfSpace.Status.GUID}
+				Consistently(func(g Gomega) {
+					createdCFBuild := new(korifiv1alpha1.CFBuild)
+					g.Expect(adminClient.Get(ctx, lookupKey, createdCFBuild)).To(Succeed())
+					g.Expect(meta.IsStatusConditionFalse(createdCFBuild.Status.Conditions, succeededConditionType)).To(BeFalse())
+				}, "1s").Should(Succeed())
+			})
+
+			When("the registry recovers", func() {
+				JustBeforeEach(func() {
+					Eventually(configCallsInSpace).Should(BeNumerically(">", 1))
+					imageConfigGetter.ConfigReturns(image.Config{Entrypoint: []string{"/bin/app"}}, nil)
+				})
+
+				It("stages the build", func() {
+					lookupKey := types.NamespacedName{Name: cfBuildGUID, Namespace: cfSpace.Status.GUID}
+					Eventually(func(g Gomega) {
+						createdCFBuild := new(korifiv1alpha1.CFBuild)
+						g.Expect(adminClient.Get(ctx, lookupKey, createdCFBuild)).To(Succeed())
+						g.Expect(meta.IsStatusConditionTrue(createdCFBuild.Status.Conditions, succeededConditionType)).To(BeTrue())
+					}).Should(Succeed())
+				})
+			})
+		})
+	})
+
+	When("the build has a droplet", func() {
+		var lookupKey types.NamespacedName
+
+		BeforeEach(func() {
+			cfBuildGUID = PrefixedGUID("cf-build")
+			lookupKey = types.NamespacedName{Name: cfBuildGUID, Namespace: cfSpace.Status.GUID}
+
+			desiredCFBuild = BuildCFBuildObject(cfBuildGUID, cfSpace.Status.GUID, "", cfAppGUID)
+			desiredCFBuild.Spec.Droplet = &korifiv1alpha1.BuildDropletStatus{
+				ProcessTypes: []korifiv1alpha1.ProcessType{{Type: "web", Command: "run-me"}},
+				Ports:        []int32{},
+			}
+			Expect(adminClient.Create(ctx, desiredCFBuild)).To(Succeed())
+		})
+
+		It("waits for the droplet image without staging", func() {
+			Consistently(func(g Gomega) {
+				createdCFBuild := new(korifiv1alpha1.CFBuild)
+				g.Expect(adminClient.Get(ctx, lookupKey, createdCFBuild)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(createdCFBuild.Status.Conditions, succeededConditionType)).To(BeFalse())
+				g.Expect(createdCFBuild.Status.Droplet).To(BeNil())
+
+				err := adminClient.Get(ctx, lookupKey, new(korifiv1alpha1.BuildWorkload))
+				g.Expect(k8serrors.IsNotFound(err)).To(BeTrue())
+			}, "1s").Should(Succeed())
+		})
+
+		When("the droplet image is set", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, desiredCFBuild, func() {
+					desiredCFBuild.Spec.Droplet.Registry.Image = "droplet-image"
+				})).To(Succeed())
+			})
+
+			It("uses the droplet", func() {
+				Eventually(func(g Gomega) {
+					createdCFBuild := new(korifiv1alpha1.CFBuild)
+					g.Expect(adminClient.Get(ctx, lookupKey, createdCFBuild)).To(Succeed())
+					g.Expect(meta.IsStatusConditionTrue(createdCFBuild.Status.Conditions, succeededConditionType)).To(BeTrue())
+					g.Expect(meta.IsStatusConditionTrue(createdCFBuild.Status.Conditions, stagingConditionType)).To(BeFalse())
+					g.Expect(createdCFBuild.Status.Droplet).To(Equal(&korifiv1alpha1.BuildDropletStatus{
+						Registry:     korifiv1alpha1.Registry{Image: "droplet-image"},
+						ProcessTypes: []korifiv1alpha1.ProcessType{{Type: "web", Command: "run-me"}},
+						Ports:        []int32{},
+					}))
+				}).Should(Succeed())
+			})
+		})
+	})
+
 	When("CFBuild status conditions Staging=True and others are unknown", func() {
 		BeforeEach(func() {
 			desiredCFPackage = BuildCFPackageCRObject(cfPackageGUID, cfSpace.Status.GUID, cfAppGUID, "ref")
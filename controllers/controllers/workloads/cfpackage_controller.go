@@ -149,7 +149,8 @@ func (r *CFPackageReconciler) finalize(ctx context.Context, cfPackage *korifiv1a
 		return ctrl.Result{}, nil
 	}
 
-	if cfPackage.Spec.Source.Registry.Image != "" {
+	// the image of a docker package is not owned by korifi
+	if cfPackage.Spec.Type != korifiv1alpha1.DockerPackage && cfPackage.Spec.Source.Registry.Image != "" {
 		if err := r.imageDeleter.Delete(ctx, image.Creds{
 			Namespace:   cfPackage.Namespace,
 			SecretNames: r.packageRepoSecretNames,
@@ -75,6 +75,10 @@ func (r *CFProcessReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builde
 		Watches(
 			&korifiv1alpha1.CFApp{},
 			handler.EnqueueRequestsFromMapFunc(r.enqueueCFProcessRequests),
+		).
+		Watches(
+			&korifiv1alpha1.CFSidecar{},
+			handler.EnqueueRequestsFromMapFunc(r.enqueueCFProcessRequestsForSidecar),
 		)
 }
 
@@ -94,6 +98,22 @@ func (r *CFProcessReconciler) enqueueCFProcessRequests(ctx context.Context, o cl
 	return requests
 }
 
+func (r *CFProcessReconciler) enqueueCFProcessRequestsForSidecar(ctx context.Context, o client.Object) []reconcile.Request {
+	cfSidecar, ok := o.(*korifiv1alpha1.CFSidecar)
+	if !ok {
+		return []reconcile.Request{}
+	}
+
+	cfApp := &korifiv1alpha1.CFApp{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: cfSidecar.Namespace,
+			Name:      cfSidecar.Spec.AppRef.Name,
+		},
+	}
+
+	return r.enqueueCFProcessRequests(ctx, cfApp)
+}
+
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfprocesses,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfprocesses/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfprocesses/finalizers,verbs=update
@@ -101,6 +121,8 @@ func (r *CFProcessReconciler) enqueueCFProcessRequests(ctx context.Context, o cl
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=appworkloads,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=appworkloads/finalizers,verbs=update
 //+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;patch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfappusageevents,verbs=create
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfsidecars,verbs=get;list;watch
 
 func (r *CFProcessReconciler) ReconcileResource(ctx context.Context, cfProcess *korifiv1alpha1.CFProcess) (ctrl.Result, error) {
 	log := shared.ObjectLogger(r.log, cfProcess)
@@ -180,7 +202,7 @@ func (r *CFProcessReconciler) createOrPatchAppWorkload(ctx context.Context, cfAp
 	}
 
 	var appPort int
-	appPort, err = r.getPort(ctx, cfProcess, cfApp)
+	appPort, err = r.getPort(ctx, cfProcess, cfApp, cfBuild.Status.Droplet)
 	if err != nil {
 		log.Info("error when trying to fetch routes for CFApp", "namespace", cfProcess.Namespace, "name", cfApp.Spec.DisplayName, "reason", err)
 		return err
@@ -192,6 +214,18 @@ func (r *CFProcessReconciler) createOrPatchAppWorkload(ctx context.Context, cfAp
 		return err
 	}
 
+	nodeSelector, tolerations, err := getIsolationSegmentPlacement(ctx, r.k8sClient, r.controllerConfig.CFRootNamespace, cfProcess.Namespace)
+	if err != nil {
+		log.Info("error when trying to fetch the isolation segment of the space", "namespace", cfProcess.Namespace, "reason", err)
+		return err
+	}
+
+	sidecars, err := r.getSidecars(ctx, cfApp, cfProcess)
+	if err != nil {
+		log.Info("error when trying to fetch the sidecars of the app", "namespace", cfProcess.Namespace, "name", cfApp.Spec.DisplayName, "reason", err)
+		return err
+	}
+
 	actualAppWorkload := &korifiv1alpha1.AppWorkload{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: cfProcess.Namespace,
@@ -205,15 +239,57 @@ func (r *CFProcessReconciler) createOrPatchAppWorkload(ctx context.Context, cfAp
 		log.Info("error when initializing AppWorkload", "reason", err)
 		return err
 	}
+	desiredAppWorkload.Spec.NodeSelector = nodeSelector
+	desiredAppWorkload.Spec.Tolerations = tolerations
+	desiredAppWorkload.Spec.Sidecars = sidecars
 
-	_, err = controllerutil.CreateOrPatch(ctx, r.k8sClient, actualAppWorkload, appWorkloadMutateFunction(actualAppWorkload, desiredAppWorkload))
+	var previousSpec *korifiv1alpha1.AppWorkloadSpec
+	mutate := appWorkloadMutateFunction(actualAppWorkload, desiredAppWorkload)
+	_, err = controllerutil.CreateOrPatch(ctx, r.k8sClient, actualAppWorkload, func() error {
+		if !actualAppWorkload.CreationTimestamp.IsZero() {
+			previousSpec = actualAppWorkload.Spec.DeepCopy()
+		}
+		return mutate()
+	})
 	if err != nil {
 		log.Info("error calling CreateOrPatch on AppWorkload", "reason", err)
 		return err
 	}
+
+	// starting the app is recorded by the app reconciler, the process only
+	// records changes to the instances and memory of a running workload
+	if previousSpec != nil && isScaled(*previousSpec, desiredAppWorkload.Spec) {
+		err = r.recordScaledUsageEvent(ctx, cfApp, cfProcess, *previousSpec)
+		if err != nil {
+			log.Info("error when recording app usage event", "reason", err)
+			return err
+		}
+	}
+
 	return nil
 }
 
+func isScaled(previousSpec, spec korifiv1alpha1.AppWorkloadSpec) bool {
+	return previousSpec.Instances != spec.Instances ||
+		!previousSpec.Resources.Limits.Memory().Equal(*spec.Resources.Limits.Memory())
+}
+
+func (r *CFProcessReconciler) recordScaledUsageEvent(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess, previousSpec korifiv1alpha1.AppWorkloadSpec) error {
+	space, orgGUID, err := shared.GetUsageEventSpace(ctx, r.k8sClient, cfProcess.Namespace)
+	if err != nil {
+		return err
+	}
+
+	usageEvent := newAppUsageEvent(r.controllerConfig.CFRootNamespace, cfApp, cfProcess, space, orgGUID)
+	usageEvent.Name = shared.UsageEventName(string(cfProcess.UID), strconv.FormatInt(cfProcess.Generation, 10), korifiv1alpha1.AppUsageEventStateScaled)
+	usageEvent.Spec.State = korifiv1alpha1.AppUsageEventStateScaled
+	usageEvent.Spec.PreviousState = korifiv1alpha1.AppUsageEventStateStarted
+	usageEvent.Spec.PreviousInstanceCount = previousSpec.Instances
+	usageEvent.Spec.PreviousMemoryInMBPerInstance = previousSpec.Resources.Limits.Memory().Value() / (1024 * 1024)
+
+	return shared.CreateUsageEvent(ctx, r.k8sClient, usageEvent)
+}
+
 func (r *CFProcessReconciler) cleanUpAppWorkloads(ctx context.Context, cfProcess *korifiv1alpha1.CFProcess, desiredState korifiv1alpha1.DesiredState, cfLastStopAppRev string) error {
 	log := logr.FromContextOrDiscard(ctx).WithName("cleanUpAppWorkloads")
 
@@ -340,7 +416,7 @@ func (r *CFProcessReconciler) fetchAppWorkloadsForProcess(ctx context.Context, c
 	return appWorkloadsForProcess, err
 }
 
-func (r *CFProcessReconciler) getPort(ctx context.Context, cfProcess *korifiv1alpha1.CFProcess, cfApp *korifiv1alpha1.CFApp) (int, error) {
+func (r *CFProcessReconciler) getPort(ctx context.Context, cfProcess *korifiv1alpha1.CFProcess, cfApp *korifiv1alpha1.CFApp, droplet *korifiv1alpha1.BuildDropletStatus) (int, error) {
 	// Get Routes for the process
 	var cfRoutesForProcess korifiv1alpha1.CFRouteList
 	err := r.k8sClient.List(ctx, &cfRoutesForProcess, client.InNamespace(cfApp.GetNamespace()), client.MatchingFields{shared.IndexRouteDestinationAppName: cfApp.Name})
@@ -363,9 +439,53 @@ func (r *CFProcessReconciler) getPort(ctx context.Context, cfProcess *korifiv1al
 		}
 	}
 
+	// Docker images listen on the port they expose
+	if cfApp.Spec.Lifecycle.Type == korifiv1alpha1.DockerLifecycle && len(droplet.Ports) > 0 {
+		return int(droplet.Ports[0]), nil
+	}
+
 	return 8080, nil
 }
 
+// getSidecars returns the sidecars of the app that run alongside the process
+// type, ordered by name so that the workload spec is stable
+func (r *CFProcessReconciler) getSidecars(ctx context.Context, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess) ([]korifiv1alpha1.AppWorkloadSidecar, error) {
+	var cfSidecarList korifiv1alpha1.CFSidecarList
+	err := r.k8sClient.List(ctx, &cfSidecarList, client.InNamespace(cfApp.Namespace), client.MatchingLabels{korifiv1alpha1.CFAppGUIDLabelKey: cfApp.Name})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(cfSidecarList.Items, func(i, j int) bool {
+		return cfSidecarList.Items[i].Spec.Name < cfSidecarList.Items[j].Spec.Name
+	})
+
+	var sidecars []korifiv1alpha1.AppWorkloadSidecar
+	for _, cfSidecar := range cfSidecarList.Items {
+		if !cfSidecar.RunsAlongside(cfProcess.Spec.ProcessType) {
+			continue
+		}
+
+		// sidecars without memory of their own share the memory of the
+		// process, they reserve nothing but cannot use more than the process
+		limit := cfSidecar.Spec.MemoryMB
+		if limit == 0 {
+			limit = cfProcess.Spec.MemoryMB
+		}
+
+		sidecars = append(sidecars, korifiv1alpha1.AppWorkloadSidecar{
+			Name:    cfSidecar.Spec.Name,
+			Command: commandForApp(cfSidecar.Spec.Command, cfApp),
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: mebibyteQuantity(cfSidecar.Spec.MemoryMB)},
+				Limits:   corev1.ResourceList{corev1.ResourceMemory: mebibyteQuantity(limit)},
+			},
+		})
+	}
+
+	return sidecars, nil
+}
+
 func generateEnvVars(port int, commonEnv []corev1.EnvVar) []corev1.EnvVar {
 	var result []corev1.EnvVar
 	result = append(result, commonEnv...)
@@ -388,9 +508,18 @@ func generateEnvVars(port int, commonEnv []corev1.EnvVar) []corev1.EnvVar {
 func commandForProcess(process *korifiv1alpha1.CFProcess, app *korifiv1alpha1.CFApp) []string {
 	cmd := process.Spec.Command
 	if cmd == "" {
+		// An empty command runs the entrypoint and command of the docker
+		// image as they are, the detected command is only informational
+		if app.Spec.Lifecycle.Type == korifiv1alpha1.DockerLifecycle {
+			return []string{}
+		}
 		cmd = process.Spec.DetectedCommand
 	}
 
+	return commandForApp(cmd, app)
+}
+
+func commandForApp(cmd string, app *korifiv1alpha1.CFApp) []string {
 	if cmd == "" {
 		return []string{}
 	}
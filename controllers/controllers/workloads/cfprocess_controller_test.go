@@ -235,6 +235,34 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})
 		})
 
+		When("the app uses the docker lifecycle", func() {
+			BeforeEach(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+					cfApp.Spec.Lifecycle = korifiv1alpha1.Lifecycle{Type: korifiv1alpha1.DockerLifecycle}
+				})).To(Succeed())
+			})
+
+			It("runs the command in a shell", func() {
+				eventuallyCreatedAppWorkloadShould(testProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+					g.Expect(appWorkload.Spec.Command).To(Equal([]string{"/bin/sh", "-c", processTypeWebCommand}))
+				})
+			})
+
+			When("the process command field isn't set", func() {
+				BeforeEach(func() {
+					Expect(k8s.PatchResource(ctx, adminClient, cfProcess, func() {
+						cfProcess.Spec.Command = ""
+					})).To(Succeed())
+				})
+
+				It("runs the command of the image", func() {
+					eventuallyCreatedAppWorkloadShould(testProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+						g.Expect(appWorkload.Spec.Command).To(BeEmpty())
+					})
+				})
+			})
+		})
+
 		When("a CFApp desired state is updated to STOPPED", func() {
 			JustBeforeEach(func() {
 				eventuallyCreatedAppWorkloadShould(testProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {})
@@ -254,6 +282,29 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 			})
 		})
 
+		When("the app process is scaled", func() {
+			JustBeforeEach(func() {
+				eventuallyCreatedAppWorkloadShould(testProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {})
+				Expect(k8s.PatchResource(ctx, adminClient, cfProcess, func() {
+					cfProcess.Spec.DesiredInstances = tools.PtrTo(3)
+				})).To(Succeed())
+			})
+
+			It("records a SCALED app usage event", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(listAppUsageEvents(g, testAppGUID)).To(ConsistOf(
+						MatchFields(IgnoreExtras, Fields{"Spec": MatchFields(IgnoreExtras, Fields{
+							"State":                 Equal(korifiv1alpha1.AppUsageEventStateScaled),
+							"PreviousState":         Equal(korifiv1alpha1.AppUsageEventStateStarted),
+							"Process":               Equal(korifiv1alpha1.UsageEventResource{GUID: testProcessGUID, Name: processTypeWeb}),
+							"InstanceCount":         BeEquivalentTo(3),
+							"PreviousInstanceCount": BeEquivalentTo(1),
+						})}),
+					))
+				}).Should(Succeed())
+			})
+		})
+
 		When("the app process instances are scaled down to 0", func() {
 			JustBeforeEach(func() {
 				eventuallyCreatedAppWorkloadShould(testProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {})
@@ -551,6 +602,74 @@ var _ = Describe("CFProcessReconciler Integration Tests", func() {
 		})
 	})
 
+	When("the space is assigned to an isolation segment", func() {
+		var isolationSegment *korifiv1alpha1.CFIsolationSegment
+
+		BeforeEach(func() {
+			isolationSegment = assignIsolationSegment(cfSpace)
+			Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+				cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+			})).To(Succeed())
+		})
+
+		It("places the AppWorkload into the isolation segment", func() {
+			eventuallyCreatedAppWorkloadShould(testProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+				g.Expect(appWorkload.Spec.NodeSelector).To(Equal(isolationSegment.Spec.NodeSelector))
+				g.Expect(appWorkload.Spec.Tolerations).To(Equal(isolationSegment.Spec.Tolerations))
+			})
+		})
+	})
+
+	When("the app has sidecars", func() {
+		BeforeEach(func() {
+			for _, sidecar := range []korifiv1alpha1.CFSidecarSpec{
+				{Name: "worker-sidecar", Command: "./worker-sidecar", ProcessTypes: []string{processTypeWorker}},
+				{Name: "web-sidecar", Command: "./web-sidecar", ProcessTypes: []string{processTypeWeb}, MemoryMB: 64},
+				{Name: "default-memory-sidecar", Command: "./default-memory-sidecar", ProcessTypes: []string{processTypeWeb}},
+			} {
+				sidecar.AppRef = corev1.LocalObjectReference{Name: testAppGUID}
+				Expect(adminClient.Create(ctx, &korifiv1alpha1.CFSidecar{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      GenerateGUID(),
+						Namespace: cfSpace.Status.GUID,
+						Labels:    map[string]string{CFAppGUIDLabelKey: testAppGUID},
+					},
+					Spec: sidecar,
+				})).To(Succeed())
+			}
+
+			Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
+				cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+			})).To(Succeed())
+		})
+
+		It("adds the sidecars of the process type to the AppWorkload", func() {
+			eventuallyCreatedAppWorkloadShould(testProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+				g.Expect(appWorkload.Spec.Sidecars).To(HaveLen(2))
+				g.Expect(appWorkload.Spec.Sidecars[1].Name).To(Equal("web-sidecar"))
+				g.Expect(appWorkload.Spec.Sidecars[1].Command).To(Equal([]string{"/cnb/lifecycle/launcher", "./web-sidecar"}))
+				g.Expect(appWorkload.Spec.Sidecars[1].Resources.Limits.Memory().String()).To(Equal("64Mi"))
+			})
+		})
+
+		It("reserves the memory of the sidecars that have memory", func() {
+			eventuallyCreatedAppWorkloadShould(testProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+				g.Expect(appWorkload.Spec.Sidecars).To(HaveLen(2))
+				g.Expect(appWorkload.Spec.Sidecars[1].Resources.Requests.Memory().String()).To(Equal("64Mi"))
+				g.Expect(appWorkload.Spec.Resources.Limits.Memory().String()).To(Equal("1Gi"))
+			})
+		})
+
+		It("lets the sidecars without memory share the memory of the process", func() {
+			eventuallyCreatedAppWorkloadShould(testProcessGUID, cfSpace.Status.GUID, func(g Gomega, appWorkload korifiv1alpha1.AppWorkload) {
+				g.Expect(appWorkload.Spec.Sidecars).To(HaveLen(2))
+				g.Expect(appWorkload.Spec.Sidecars[0].Name).To(Equal("default-memory-sidecar"))
+				g.Expect(appWorkload.Spec.Sidecars[0].Resources.Requests.Memory().IsZero()).To(BeTrue())
+				g.Expect(appWorkload.Spec.Sidecars[0].Resources.Limits.Memory().String()).To(Equal("1Gi"))
+			})
+		})
+	})
+
 	When("the CFProcess has a process health check", func() {
 		BeforeEach(func() {
 			Expect(k8s.PatchResource(ctx, adminClient, cfApp, func() {
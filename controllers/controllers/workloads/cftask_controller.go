@@ -53,6 +53,7 @@ type CFTaskReconciler struct {
 	recorder        record.EventRecorder
 	log             logr.Logger
 	envBuilder      EnvBuilder
+	rootNamespace   string
 	taskTTLDuration time.Duration
 }
 
@@ -62,6 +63,7 @@ func NewCFTaskReconciler(
 	recorder record.EventRecorder,
 	log logr.Logger,
 	envBuilder EnvBuilder,
+	rootNamespace string,
 	taskTTLDuration time.Duration,
 ) *k8s.PatchingReconciler[korifiv1alpha1.CFTask, *korifiv1alpha1.CFTask] {
 	taskReconciler := CFTaskReconciler{
@@ -70,6 +72,7 @@ func NewCFTaskReconciler(
 		recorder:        recorder,
 		log:             log,
 		envBuilder:      envBuilder,
+		rootNamespace:   rootNamespace,
 		taskTTLDuration: taskTTLDuration,
 	}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.CFTask, *korifiv1alpha1.CFTask](log, client, &taskReconciler)
@@ -243,6 +246,12 @@ func (r *CFTaskReconciler) getWebProcess(ctx context.Context, cfApp *korifiv1alp
 func (r *CFTaskReconciler) createOrPatchTaskWorkload(ctx context.Context, cfTask *korifiv1alpha1.CFTask, cfDroplet *korifiv1alpha1.CFBuild, webProcess korifiv1alpha1.CFProcess, env []corev1.EnvVar) (*korifiv1alpha1.TaskWorkload, error) {
 	log := logr.FromContextOrDiscard(ctx).WithName("createOrPatchTaskWorkload")
 
+	nodeSelector, tolerations, err := getIsolationSegmentPlacement(ctx, r.k8sClient, r.rootNamespace, cfTask.Namespace)
+	if err != nil {
+		log.Info("failed to get the isolation segment of the space", "reason", err)
+		return nil, err
+	}
+
 	taskWorkload := &korifiv1alpha1.TaskWorkload{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      cfTask.Name,
@@ -275,6 +284,8 @@ func (r *CFTaskReconciler) createOrPatchTaskWorkload(ctx context.Context, cfTask
 		taskWorkload.Spec.Resources.Limits[corev1.ResourceEphemeralStorage] = *resource.NewScaledQuantity(cfTask.Status.DiskQuotaMB, resource.Mega)
 		taskWorkload.Spec.Resources.Requests[corev1.ResourceCPU] = *resource.NewScaledQuantity(calculateDefaultCPURequestMillicores(webProcess.Spec.MemoryMB), resource.Milli)
 		taskWorkload.Spec.Env = env
+		taskWorkload.Spec.NodeSelector = nodeSelector
+		taskWorkload.Spec.Tolerations = tolerations
 
 		if err := ctrl.SetControllerReference(cfTask, taskWorkload, r.scheme); err != nil {
 			log.Info("failed to set owner ref", "reason", err)
@@ -291,6 +291,28 @@ var _ = Describe("CFTaskReconciler Integration Tests", func() {
 			Expect(eventMessageArgs).To(Equal([]interface{}{task.Name}), "Unexpected event message args in event record")
 		})
 
+		When("the space is assigned to an isolation segment", func() {
+			var isolationSegment *korifiv1alpha1.CFIsolationSegment
+
+			BeforeEach(func() {
+				isolationSegment = assignIsolationSegment(cfSpace)
+			})
+
+			It("places the TaskWorkload into the isolation segment", func() {
+				Eventually(func(g Gomega) {
+					var taskWorkloads korifiv1alpha1.TaskWorkloadList
+
+					g.Expect(adminClient.List(ctx, &taskWorkloads,
+						client.InNamespace(cfSpace.Status.GUID),
+						client.MatchingLabels{korifiv1alpha1.CFTaskGUIDLabelKey: cfTask.Name},
+					)).To(Succeed())
+					g.Expect(taskWorkloads.Items).To(HaveLen(1))
+					g.Expect(taskWorkloads.Items[0].Spec.NodeSelector).To(Equal(isolationSegment.Spec.NodeSelector))
+					g.Expect(taskWorkloads.Items[0].Spec.Tolerations).To(Equal(isolationSegment.Spec.Tolerations))
+				}).Should(Succeed())
+			})
+		})
+
 		When("the task workload status condition changes", func() {
 			JustBeforeEach(func() {
 				Eventually(func(g Gomega) {
@@ -3,6 +3,7 @@ package env
 import (
 	"context"
 	"fmt"
+	"sort"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 
@@ -14,27 +15,53 @@ import (
 type VCAPServices map[string][]ServiceDetails
 
 type ServiceDetails struct {
-	Label          string            `json:"label"`
-	Name           string            `json:"name"`
-	Tags           []string          `json:"tags"`
-	InstanceGUID   string            `json:"instance_guid"`
-	InstanceName   string            `json:"instance_name"`
-	BindingGUID    string            `json:"binding_guid"`
-	BindingName    *string           `json:"binding_name"`
-	Credentials    map[string]string `json:"credentials"`
-	SyslogDrainURL *string           `json:"syslog_drain_url"`
-	VolumeMounts   []string          `json:"volume_mounts"`
+	Label string `json:"label"`
+	*ManagedServiceDetails
+	Name           string         `json:"name"`
+	Tags           []string       `json:"tags"`
+	InstanceGUID   string         `json:"instance_guid"`
+	InstanceName   string         `json:"instance_name"`
+	BindingGUID    string         `json:"binding_guid"`
+	BindingName    *string        `json:"binding_name"`
+	Credentials    map[string]any `json:"credentials"`
+	SyslogDrainURL *string        `json:"syslog_drain_url"`
+	VolumeMounts   []string       `json:"volume_mounts"`
 }
 
+// ManagedServiceDetails holds the fields that are only rendered for bindings
+// to managed service instances
+type ManagedServiceDetails struct {
+	Provider *string `json:"provider"`
+	Plan     string  `json:"plan"`
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfenvvargroups,verbs=get;list;watch
+
 type WorkloadEnvBuilder struct {
-	k8sClient client.Client
+	k8sClient     client.Client
+	rootNamespace string
 }
 
-func NewWorkloadEnvBuilder(k8sClient client.Client) *WorkloadEnvBuilder {
-	return &WorkloadEnvBuilder{k8sClient: k8sClient}
+func NewWorkloadEnvBuilder(k8sClient client.Client, rootNamespace string) *WorkloadEnvBuilder {
+	return &WorkloadEnvBuilder{
+		k8sClient:     k8sClient,
+		rootNamespace: rootNamespace,
+	}
 }
 
+// BuildEnv returns the env of the app workloads, made of the running
+// environment variable group and the app env
 func (b *WorkloadEnvBuilder) BuildEnv(ctx context.Context, cfApp *korifiv1alpha1.CFApp) ([]corev1.EnvVar, error) {
+	return b.buildEnv(ctx, cfApp, korifiv1alpha1.RunningEnvVarGroupName)
+}
+
+// BuildStagingEnv returns the env of the build workloads, made of the staging
+// environment variable group and the app env
+func (b *WorkloadEnvBuilder) BuildStagingEnv(ctx context.Context, cfApp *korifiv1alpha1.CFApp) ([]corev1.EnvVar, error) {
+	return b.buildEnv(ctx, cfApp, korifiv1alpha1.StagingEnvVarGroupName)
+}
+
+func (b *WorkloadEnvBuilder) buildEnv(ctx context.Context, cfApp *korifiv1alpha1.CFApp, envVarGroupName string) ([]corev1.EnvVar, error) {
 	var appEnvSecret, vcapServicesSecret, vcapApplicationSecret corev1.Secret
 
 	if cfApp.Spec.EnvSecretName != "" {
@@ -58,8 +85,38 @@ func (b *WorkloadEnvBuilder) BuildEnv(ctx context.Context, cfApp *korifiv1alpha1
 		}
 	}
 
+	var envVarGroup korifiv1alpha1.CFEnvVarGroup
+	err := b.k8sClient.Get(ctx, types.NamespacedName{Namespace: b.rootNamespace, Name: envVarGroupName}, &envVarGroup)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, fmt.Errorf("error when trying to fetch %s environment variable group %s/%s: %w", envVarGroupName, b.rootNamespace, envVarGroupName, err)
+	}
+
 	// We explicitly order the vcapServicesSecret last so that its "VCAP_*" contents win
-	return envVarsFromSecrets(appEnvSecret, vcapServicesSecret, vcapApplicationSecret), nil
+	secretEnvVars := envVarsFromSecrets(appEnvSecret, vcapServicesSecret, vcapApplicationSecret)
+
+	return append(envVarsFromGroup(envVarGroup, secretEnvVars), secretEnvVars...), nil
+}
+
+// envVarsFromGroup skips the group variables that are overridden by the app
+func envVarsFromGroup(envVarGroup korifiv1alpha1.CFEnvVarGroup, overrides []corev1.EnvVar) []corev1.EnvVar {
+	overridden := map[string]bool{}
+	for _, envVar := range overrides {
+		overridden[envVar.Name] = true
+	}
+
+	var envVars []corev1.EnvVar
+	for k, v := range envVarGroup.Spec.Env {
+		if overridden[k] {
+			continue
+		}
+		envVars = append(envVars, corev1.EnvVar{Name: k, Value: v})
+	}
+
+	sort.Slice(envVars, func(i, j int) bool {
+		return envVars[i].Name < envVars[j].Name
+	})
+
+	return envVars
 }
 
 func envVarsFromSecrets(secrets ...corev1.Secret) []corev1.EnvVar {
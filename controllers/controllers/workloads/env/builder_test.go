@@ -26,7 +26,7 @@ var _ = Describe("Builder", func() {
 	)
 
 	BeforeEach(func() {
-		builder = env.NewWorkloadEnvBuilder(controllersClient)
+		builder = env.NewWorkloadEnvBuilder(controllersClient, rootNamespace)
 
 		appSecret = &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
@@ -205,6 +205,44 @@ var _ = Describe("Builder", func() {
 			})
 		})
 
+		When("the running environment variable group is set", func() {
+			BeforeEach(func() {
+				ensureCreate(&korifiv1alpha1.CFEnvVarGroup{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      korifiv1alpha1.RunningEnvVarGroupName,
+					},
+					Spec: korifiv1alpha1.CFEnvVarGroupSpec{
+						Env: map[string]string{
+							"running-var": "running-value",
+							"app-secret":  "overridden",
+						},
+					},
+				})
+				ensureCreate(&korifiv1alpha1.CFEnvVarGroup{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      korifiv1alpha1.StagingEnvVarGroupName,
+					},
+					Spec: korifiv1alpha1.CFEnvVarGroupSpec{
+						Env: map[string]string{
+							"staging-var": "staging-value",
+						},
+					},
+				})
+			})
+
+			It("adds the running group variables that are not overridden by the app", func() {
+				Expect(buildEnvErr).NotTo(HaveOccurred())
+				Expect(envVars).To(ConsistOf(
+					Equal(corev1.EnvVar{Name: "running-var", Value: "running-value"}),
+					appSecretEnv,
+					vcapServicesEnv,
+					vcapApplicationEnv,
+				))
+			})
+		})
+
 		When("the app vcap application secret does not exist", func() {
 			BeforeEach(func() {
 				ensureDelete(vcapApplicationSecret)
@@ -246,4 +284,42 @@ var _ = Describe("Builder", func() {
 			})
 		})
 	})
+
+	Describe("BuildStagingEnv", func() {
+		BeforeEach(func() {
+			ensureCreate(&korifiv1alpha1.CFEnvVarGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: rootNamespace,
+					Name:      korifiv1alpha1.RunningEnvVarGroupName,
+				},
+				Spec: korifiv1alpha1.CFEnvVarGroupSpec{
+					Env: map[string]string{
+						"running-var": "running-value",
+					},
+				},
+			})
+			ensureCreate(&korifiv1alpha1.CFEnvVarGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: rootNamespace,
+					Name:      korifiv1alpha1.StagingEnvVarGroupName,
+				},
+				Spec: korifiv1alpha1.CFEnvVarGroupSpec{
+					Env: map[string]string{
+						"staging-var": "staging-value",
+					},
+				},
+			})
+		})
+
+		JustBeforeEach(func() {
+			envVars, buildEnvErr = builder.BuildStagingEnv(context.Background(), cfApp)
+		})
+
+		It("returns the staging group variables along with the app env", func() {
+			Expect(buildEnvErr).NotTo(HaveOccurred())
+			Expect(envVars).To(ContainElement(Equal(corev1.EnvVar{Name: "staging-var", Value: "staging-value"})))
+			Expect(envVars).To(ContainElement(HaveField("Name", "app-secret")))
+			Expect(envVars).NotTo(ContainElement(HaveField("Name", "running-var")))
+		})
+	})
 })
@@ -16,11 +16,12 @@ import (
 const UserProvided = "user-provided"
 
 type VCAPServicesEnvValueBuilder struct {
-	k8sClient client.Client
+	k8sClient     client.Client
+	rootNamespace string
 }
 
-func NewVCAPServicesEnvValueBuilder(k8sClient client.Client) *VCAPServicesEnvValueBuilder {
-	return &VCAPServicesEnvValueBuilder{k8sClient: k8sClient}
+func NewVCAPServicesEnvValueBuilder(k8sClient client.Client, rootNamespace string) *VCAPServicesEnvValueBuilder {
+	return &VCAPServicesEnvValueBuilder{k8sClient: k8sClient, rootNamespace: rootNamespace}
 }
 
 func (b *VCAPServicesEnvValueBuilder) BuildEnvValue(ctx context.Context, cfApp *korifiv1alpha1.CFApp) (map[string]string, error) {
@@ -46,7 +47,7 @@ func (b *VCAPServicesEnvValueBuilder) BuildEnvValue(ctx context.Context, cfApp *
 
 		var serviceEnv ServiceDetails
 		var serviceLabel string
-		serviceEnv, serviceLabel, err = buildSingleServiceEnv(ctx, b.k8sClient, currentServiceBinding)
+		serviceEnv, serviceLabel, err = b.buildSingleServiceEnv(ctx, currentServiceBinding)
 		if err != nil {
 			return nil, err
 		}
@@ -64,7 +65,7 @@ func (b *VCAPServicesEnvValueBuilder) BuildEnvValue(ctx context.Context, cfApp *
 	}, nil
 }
 
-func buildSingleServiceEnv(ctx context.Context, k8sClient client.Client, serviceBinding korifiv1alpha1.CFServiceBinding) (ServiceDetails, string, error) {
+func (b *VCAPServicesEnvValueBuilder) buildSingleServiceEnv(ctx context.Context, serviceBinding korifiv1alpha1.CFServiceBinding) (ServiceDetails, string, error) {
 	if serviceBinding.Status.Binding.Name == "" {
 		return ServiceDetails{}, "", fmt.Errorf("service binding secret name is empty")
 	}
@@ -72,28 +73,60 @@ func buildSingleServiceEnv(ctx context.Context, k8sClient client.Client, service
 	serviceLabel := UserProvided
 
 	serviceInstance := korifiv1alpha1.CFServiceInstance{}
-	err := k8sClient.Get(ctx, types.NamespacedName{Namespace: serviceBinding.Namespace, Name: serviceBinding.Spec.Service.Name}, &serviceInstance)
+	err := b.k8sClient.Get(ctx, types.NamespacedName{Namespace: serviceBinding.ServiceNamespace(), Name: serviceBinding.Spec.Service.Name}, &serviceInstance)
 	if err != nil {
 		return ServiceDetails{}, "", fmt.Errorf("error fetching CFServiceInstance: %w", err)
 	}
 
-	secret := corev1.Secret{}
-	err = k8sClient.Get(ctx, types.NamespacedName{Namespace: serviceBinding.Namespace, Name: serviceBinding.Status.Binding.Name}, &secret)
+	credentials, err := b.getCredentials(ctx, serviceBinding)
 	if err != nil {
-		return ServiceDetails{}, "", fmt.Errorf("error fetching CFServiceBinding Secret: %w", err)
+		return ServiceDetails{}, "", err
+	}
+
+	if serviceInstance.Spec.Type == korifiv1alpha1.ManagedType {
+		var managedDetails *ManagedServiceDetails
+		managedDetails, serviceLabel, err = b.buildManagedServiceDetails(ctx, serviceInstance)
+		if err != nil {
+			return ServiceDetails{}, "", err
+		}
+
+		serviceDetails := fromServiceBinding(serviceBinding, serviceInstance, credentials, serviceLabel)
+		serviceDetails.ManagedServiceDetails = managedDetails
+		return serviceDetails, serviceLabel, nil
 	}
 
 	if serviceInstance.Spec.ServiceLabel != nil && *serviceInstance.Spec.ServiceLabel != "" {
 		serviceLabel = *serviceInstance.Spec.ServiceLabel
 	}
 
-	return fromServiceBinding(serviceBinding, serviceInstance, secret, serviceLabel), serviceLabel, nil
+	return fromServiceBinding(serviceBinding, serviceInstance, credentials, serviceLabel), serviceLabel, nil
+}
+
+// buildManagedServiceDetails returns the plan details of a managed service
+// instance, together with its label, which is the name of the service offering
+func (b *VCAPServicesEnvValueBuilder) buildManagedServiceDetails(ctx context.Context, serviceInstance korifiv1alpha1.CFServiceInstance) (*ManagedServiceDetails, string, error) {
+	servicePlan := korifiv1alpha1.CFServicePlan{}
+	err := b.k8sClient.Get(ctx, types.NamespacedName{Namespace: b.rootNamespace, Name: serviceInstance.Spec.PlanGUID}, &servicePlan)
+	if err != nil {
+		return nil, "", fmt.Errorf("error fetching CFServicePlan: %w", err)
+	}
+
+	serviceOffering := korifiv1alpha1.CFServiceOffering{}
+	err = b.k8sClient.Get(ctx, types.NamespacedName{Namespace: b.rootNamespace, Name: servicePlan.Labels[korifiv1alpha1.CFServiceOfferingGUIDLabelKey]}, &serviceOffering)
+	if err != nil {
+		return nil, "", fmt.Errorf("error fetching CFServiceOffering: %w", err)
+	}
+
+	return &ManagedServiceDetails{
+		Provider: nil,
+		Plan:     servicePlan.Spec.Name,
+	}, serviceOffering.Spec.Name, nil
 }
 
 func fromServiceBinding(
 	serviceBinding korifiv1alpha1.CFServiceBinding,
 	serviceInstance korifiv1alpha1.CFServiceInstance,
-	serviceBindingSecret corev1.Secret,
+	credentials map[string]any,
 	serviceLabel string,
 ) ServiceDetails {
 	var serviceName string
@@ -120,14 +153,43 @@ func fromServiceBinding(
 		InstanceName:   serviceInstance.Spec.DisplayName,
 		BindingGUID:    serviceBinding.Name,
 		BindingName:    bindingName,
-		Credentials:    mapFromSecret(serviceBindingSecret),
-		SyslogDrainURL: nil,
+		Credentials:    credentials,
+		SyslogDrainURL: serviceInstance.Spec.SyslogDrainURL,
 		VolumeMounts:   []string{},
 	}
 }
 
-func mapFromSecret(secret corev1.Secret) map[string]string {
-	convertedMap := make(map[string]string)
+// getCredentials returns the credentials of the binding. Credentials returned
+// by a broker are read from the JSON stored in the credentials secret, so that
+// they keep their types; all other credentials are plain strings
+func (b *VCAPServicesEnvValueBuilder) getCredentials(ctx context.Context, serviceBinding korifiv1alpha1.CFServiceBinding) (map[string]any, error) {
+	if serviceBinding.Status.Credentials.Name != "" {
+		secret := corev1.Secret{}
+		err := b.k8sClient.Get(ctx, types.NamespacedName{Namespace: serviceBinding.Namespace, Name: serviceBinding.Status.Credentials.Name}, &secret)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching CFServiceBinding credentials Secret: %w", err)
+		}
+
+		credentials := map[string]any{}
+		err = json.Unmarshal(secret.Data[korifiv1alpha1.CredentialsSecretKey], &credentials)
+		if err != nil {
+			return nil, fmt.Errorf("error unmarshalling CFServiceBinding credentials: %w", err)
+		}
+
+		return credentials, nil
+	}
+
+	secret := corev1.Secret{}
+	err := b.k8sClient.Get(ctx, types.NamespacedName{Namespace: serviceBinding.Namespace, Name: serviceBinding.Status.Binding.Name}, &secret)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching CFServiceBinding Secret: %w", err)
+	}
+
+	return mapFromSecret(secret), nil
+}
+
+func mapFromSecret(secret corev1.Secret) map[string]any {
+	convertedMap := make(map[string]any)
 	for k, v := range secret.Data {
 		convertedMap[k] = string(v)
 	}
@@ -24,7 +24,7 @@ var _ = Describe("Builder", func() {
 	)
 
 	BeforeEach(func() {
-		builder = env.NewVCAPServicesEnvValueBuilder(controllersClient)
+		builder = env.NewVCAPServicesEnvValueBuilder(controllersClient, rootNamespace)
 
 		serviceInstance = &korifiv1alpha1.CFServiceInstance{
 			ObjectMeta: metav1.ObjectMeta{
@@ -206,6 +206,18 @@ var _ = Describe("Builder", func() {
 			})
 		})
 
+		When("the service instance has a syslog drain URL", func() {
+			BeforeEach(func() {
+				ensurePatch(serviceInstance, func(s *korifiv1alpha1.CFServiceInstance) {
+					s.Spec.SyslogDrainURL = tools.PtrTo("syslog://logs.example.com:514")
+				})
+			})
+
+			It("sets the syslog drain URL", func() {
+				Expect(extractServiceInfo(vcapServices, "user-provided", 1)).To(ContainElement(HaveKeyWithValue("syslog_drain_url", "syslog://logs.example.com:514")))
+			})
+		})
+
 		When("serviceLabel is set but blank", func() {
 			BeforeEach(func() {
 				ensurePatch(serviceInstance, func(s *korifiv1alpha1.CFServiceInstance) {
@@ -230,6 +242,100 @@ var _ = Describe("Builder", func() {
 			})
 		})
 
+		When("the service instance is managed", func() {
+			BeforeEach(func() {
+				serviceOffering := &korifiv1alpha1.CFServiceOffering{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      "offering-guid",
+					},
+					Spec: korifiv1alpha1.CFServiceOfferingSpec{
+						Name:        "elephantsql",
+						Description: "postgres as a service",
+						BrokerCatalog: korifiv1alpha1.ServiceBrokerCatalog{
+							ID: "offering-id",
+						},
+					},
+				}
+				ensureCreate(serviceOffering)
+
+				servicePlan := &korifiv1alpha1.CFServicePlan{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: rootNamespace,
+						Name:      "plan-guid",
+						Labels: map[string]string{
+							korifiv1alpha1.CFServiceOfferingGUIDLabelKey: serviceOffering.Name,
+						},
+					},
+					Spec: korifiv1alpha1.CFServicePlanSpec{
+						Name:        "turtle",
+						Description: "the turtle plan",
+						BrokerCatalog: korifiv1alpha1.ServiceBrokerCatalog{
+							ID: "plan-id",
+						},
+						Visibility: korifiv1alpha1.ServicePlanVisibility{
+							Type: korifiv1alpha1.PublicServicePlanVisibilityType,
+						},
+					},
+				}
+				ensureCreate(servicePlan)
+
+				ensurePatch(serviceInstance, func(s *korifiv1alpha1.CFServiceInstance) {
+					s.Spec.Type = korifiv1alpha1.ManagedType
+					s.Spec.PlanGUID = servicePlan.Name
+				})
+			})
+
+			It("uses the offering name as label and includes the plan and provider", func() {
+				Expect(buildVCAPServicesEnvValueErr).NotTo(HaveOccurred())
+
+				Expect(extractServiceInfo(vcapServices, "elephantsql", 1)).To(ContainElements(
+					SatisfyAll(
+						HaveLen(12),
+						HaveKeyWithValue("label", "elephantsql"),
+						HaveKeyWithValue("plan", "turtle"),
+						HaveKeyWithValue("provider", BeNil()),
+						HaveKeyWithValue("name", "my-service-binding"),
+						HaveKeyWithValue("instance_guid", "my-service-instance-guid"),
+						HaveKeyWithValue("binding_guid", "my-service-binding-guid"),
+						HaveKeyWithValue("credentials", SatisfyAll(HaveKeyWithValue("foo", "bar"), HaveLen(1))),
+					),
+				))
+			})
+
+			When("the binding stores the broker credentials as JSON", func() {
+				BeforeEach(func() {
+					ensureCreate(&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: cfSpace.Status.GUID,
+							Name:      "service-binding-credentials",
+						},
+						Data: map[string][]byte{
+							korifiv1alpha1.CredentialsSecretKey: []byte(`{"port":5432,"hosts":{"primary":"db-0","replicas":["db-1"]}}`),
+						},
+					})
+					ensurePatch(serviceBinding, func(sb *korifiv1alpha1.CFServiceBinding) {
+						sb.Status.Credentials = corev1.LocalObjectReference{Name: "service-binding-credentials"}
+					})
+				})
+
+				It("keeps the types of the credentials", func() {
+					Expect(buildVCAPServicesEnvValueErr).NotTo(HaveOccurred())
+
+					Expect(extractServiceInfo(vcapServices, "elephantsql", 1)).To(ContainElements(
+						HaveKeyWithValue("credentials", SatisfyAll(
+							HaveKeyWithValue("port", BeNumerically("==", 5432)),
+							HaveKeyWithValue("hosts", SatisfyAll(
+								HaveKeyWithValue("primary", "db-0"),
+								HaveKeyWithValue("replicas", ConsistOf("db-1")),
+							)),
+							HaveLen(2),
+						)),
+					))
+				})
+			})
+		})
+
 		When("there are no service bindings for the app", func() {
 			BeforeEach(func() {
 				Expect(adminClient.DeleteAllOf(ctx, &korifiv1alpha1.CFServiceBinding{}, client.InNamespace(cfSpace.Status.GUID))).To(Succeed())
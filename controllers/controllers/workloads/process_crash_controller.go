@@ -0,0 +1,203 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+
+	"github.com/go-logr/logr"
+	"github.com/google/uuid"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+const processCrashReason = "CRASHED"
+
+// ProcessCrashReconciler records an audit.app.process.crash CFAuditEvent for
+// every container of an app instance pod that terminates unsuccessfully. App
+// instance pods are identified by their app GUID and process type labels, so
+// that crashes are recorded regardless of the runner that created the pods.
+// Pods that are being deleted are ignored, as their containers are expected to
+// exit on SIGTERM when the app is stopped, scaled down or rolled out.
+// The reconciler only reads pods, therefore it does not use the patching reconciler
+type ProcessCrashReconciler struct {
+	k8sClient client.Client
+	log       logr.Logger
+}
+
+func NewProcessCrashReconciler(client client.Client, log logr.Logger) *ProcessCrashReconciler {
+	return &ProcessCrashReconciler{
+		k8sClient: client,
+		log:       log,
+	}
+}
+
+func (r *ProcessCrashReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("processcrash").
+		For(&corev1.Pod{}, builder.WithPredicates(predicate.NewPredicateFuncs(isAppInstancePod))).
+		Complete(r)
+}
+
+// AppInstancePodsSelector selects the pods the reconciler is interested in. It
+// is meant to restrict the manager's pod cache, see isAppInstancePod
+func AppInstancePodsSelector() labels.Selector {
+	requirement, err := labels.NewRequirement(korifiv1alpha1.CFAppGUIDLabelKey, selection.Exists, nil)
+	if err != nil {
+		panic(err)
+	}
+	return labels.NewSelector().Add(*requirement)
+}
+
+func isAppInstancePod(object client.Object) bool {
+	labels := object.GetLabels()
+	return labels[korifiv1alpha1.CFAppGUIDLabelKey] != "" && labels[korifiv1alpha1.CFProcessTypeLabelKey] != ""
+}
+
+//+kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps,verbs=get
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfprocesses,verbs=list
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfauditevents,verbs=create
+
+func (r *ProcessCrashReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	pod := new(corev1.Pod)
+	err := r.k8sClient.Get(ctx, req.NamespacedName, pod)
+	if err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	log := shared.ObjectLogger(r.log, pod)
+
+	if pod.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	crashes := crashedContainers(pod)
+	if len(crashes) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	appGUID := pod.Labels[korifiv1alpha1.CFAppGUIDLabelKey]
+	processType := pod.Labels[korifiv1alpha1.CFProcessTypeLabelKey]
+
+	cfApp := new(korifiv1alpha1.CFApp)
+	err = r.k8sClient.Get(ctx, types.NamespacedName{Namespace: pod.Namespace, Name: appGUID}, cfApp)
+	if err != nil {
+		log.Info("error when fetching CFApp", "reason", err)
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	cfProcessList := new(korifiv1alpha1.CFProcessList)
+	err = r.k8sClient.List(ctx, cfProcessList, client.InNamespace(pod.Namespace), client.MatchingLabels{
+		korifiv1alpha1.CFAppGUIDLabelKey:     appGUID,
+		korifiv1alpha1.CFProcessTypeLabelKey: processType,
+	})
+	if err != nil {
+		log.Info("error when listing CFProcesses", "reason", err)
+		return ctrl.Result{}, err
+	}
+	if len(cfProcessList.Items) == 0 {
+		log.Info("no CFProcess found for pod", "appGUID", appGUID, "processType", processType)
+		return ctrl.Result{}, nil
+	}
+
+	for _, crash := range crashes {
+		err = r.recordCrash(ctx, pod, cfApp, cfProcessList.Items[0], crash)
+		if err != nil {
+			log.Info("error when recording process crash", "container", crash.containerName, "reason", err)
+			return ctrl.Result{}, err
+		}
+	}
+
+	return ctrl.Result{}, nil
+}
+
+type containerCrash struct {
+	containerName string
+	terminated    *corev1.ContainerStateTerminated
+}
+
+func crashedContainers(pod *corev1.Pod) []containerCrash {
+	crashes := []containerCrash{}
+	for _, status := range pod.Status.ContainerStatuses {
+		for _, terminated := range []*corev1.ContainerStateTerminated{status.LastTerminationState.Terminated, status.State.Terminated} {
+			if terminated != nil && terminated.ExitCode != 0 {
+				crashes = append(crashes, containerCrash{containerName: status.Name, terminated: terminated})
+			}
+		}
+	}
+	return crashes
+}
+
+func (r *ProcessCrashReconciler) recordCrash(ctx context.Context, pod *corev1.Pod, cfApp *korifiv1alpha1.CFApp, cfProcess korifiv1alpha1.CFProcess, crash containerCrash) error {
+	exitDescription := crash.terminated.Message
+	if exitDescription == "" {
+		exitDescription = crash.terminated.Reason
+	}
+
+	// the event name is derived from the terminated container so that every
+	// crash is recorded exactly once, however often the pod is reconciled
+	crashID := fmt.Sprintf("%s/%s/%s/%s", pod.UID, crash.containerName, crash.terminated.ContainerID, crash.terminated.FinishedAt.UTC())
+	cfAuditEvent := &korifiv1alpha1.CFAuditEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: pod.Namespace,
+			Name:      uuid.NewSHA1(uuid.NameSpaceOID, []byte(crashID)).String(),
+			Labels: map[string]string{
+				korifiv1alpha1.CFAuditEventTypeLabelKey:       korifiv1alpha1.AuditEventTypeAppProcessCrash,
+				korifiv1alpha1.CFAuditEventTargetGUIDLabelKey: cfApp.Name,
+			},
+		},
+		Spec: korifiv1alpha1.CFAuditEventSpec{
+			Type: korifiv1alpha1.AuditEventTypeAppProcessCrash,
+			Actor: korifiv1alpha1.CFAuditEventParticipant{
+				GUID: cfProcess.Name,
+				Type: korifiv1alpha1.AuditEventActorTypeProcess,
+				Name: cfProcess.Spec.ProcessType,
+			},
+			Target: korifiv1alpha1.CFAuditEventParticipant{
+				GUID: cfApp.Name,
+				Type: korifiv1alpha1.AuditEventTargetTypeApp,
+				Name: cfApp.Spec.DisplayName,
+			},
+			SpaceGUID: pod.Namespace,
+			Data: map[string]string{
+				"instance":         pod.Name,
+				"exit_status":      strconv.Itoa(int(crash.terminated.ExitCode)),
+				"exit_description": exitDescription,
+				"reason":           processCrashReason,
+			},
+		},
+	}
+
+	err := r.k8sClient.Create(ctx, cfAuditEvent)
+	if k8serrors.IsAlreadyExists(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,178 @@
+package workloads_test
+
+import (
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("ProcessCrashReconciler Integration Tests", func() {
+	var (
+		cfSpace   *korifiv1alpha1.CFSpace
+		cfApp     *korifiv1alpha1.CFApp
+		cfProcess *korifiv1alpha1.CFProcess
+		pod       *corev1.Pod
+	)
+
+	BeforeEach(func() {
+		cfSpace = createSpace(cfOrg)
+
+		cfApp = &korifiv1alpha1.CFApp{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cfSpace.Status.GUID,
+				Name:      testutils.PrefixedGUID("app"),
+			},
+			Spec: korifiv1alpha1.CFAppSpec{
+				Lifecycle:    korifiv1alpha1.Lifecycle{Type: "buildpack"},
+				DesiredState: "STOPPED",
+				DisplayName:  "my-app",
+			},
+		}
+		Expect(adminClient.Create(ctx, cfApp)).To(Succeed())
+
+		cfProcess = &korifiv1alpha1.CFProcess{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cfSpace.Status.GUID,
+				Name:      testutils.PrefixedGUID("web-process"),
+				Labels: map[string]string{
+					korifiv1alpha1.CFProcessTypeLabelKey: "web",
+					korifiv1alpha1.CFAppGUIDLabelKey:     cfApp.Name,
+				},
+			},
+			Spec: korifiv1alpha1.CFProcessSpec{
+				AppRef:      corev1.LocalObjectReference{Name: cfApp.Name},
+				ProcessType: "web",
+				HealthCheck: korifiv1alpha1.HealthCheck{Type: "process"},
+			},
+		}
+		Expect(adminClient.Create(ctx, cfProcess)).To(Succeed())
+
+		pod = &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: cfSpace.Status.GUID,
+				Name:      testutils.PrefixedGUID("pod"),
+				Labels: map[string]string{
+					korifiv1alpha1.CFAppGUIDLabelKey:     cfApp.Name,
+					korifiv1alpha1.CFProcessTypeLabelKey: "web",
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{
+					Name:  "application",
+					Image: "some-image",
+				}},
+			},
+		}
+		Expect(adminClient.Create(ctx, pod)).To(Succeed())
+	})
+
+	listCrashEvents := func(g Gomega) []korifiv1alpha1.CFAuditEvent {
+		auditEvents := new(korifiv1alpha1.CFAuditEventList)
+		g.Expect(adminClient.List(ctx, auditEvents, client.InNamespace(cfSpace.Status.GUID), client.MatchingLabels{
+			korifiv1alpha1.CFAuditEventTypeLabelKey: korifiv1alpha1.AuditEventTypeAppProcessCrash,
+		})).To(Succeed())
+		return auditEvents.Items
+	}
+
+	It("does not record crashes for running pods", func() {
+		Consistently(func(g Gomega) {
+			g.Expect(listCrashEvents(g)).To(BeEmpty())
+		}, "1s").Should(Succeed())
+	})
+
+	When("a container of the pod crashes", func() {
+		BeforeEach(func() {
+			Expect(k8s.Patch(ctx, adminClient, pod, func() {
+				pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+					Name:         "application",
+					Image:        "some-image",
+					ImageID:      "some-image-id",
+					RestartCount: 1,
+					LastTerminationState: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode:    137,
+							Reason:      "OOMKilled",
+							ContainerID: "containerd://some-container",
+						},
+					},
+				}}
+			})).To(Succeed())
+		})
+
+		It("records a process crash audit event for the app", func() {
+			Eventually(func(g Gomega) {
+				auditEvents := listCrashEvents(g)
+				g.Expect(auditEvents).To(HaveLen(1))
+				g.Expect(auditEvents[0].Labels).To(HaveKeyWithValue(korifiv1alpha1.CFAuditEventTargetGUIDLabelKey, cfApp.Name))
+				g.Expect(auditEvents[0].Spec).To(MatchFields(IgnoreExtras, Fields{
+					"Type":      Equal(korifiv1alpha1.AuditEventTypeAppProcessCrash),
+					"Actor":     Equal(korifiv1alpha1.CFAuditEventParticipant{GUID: cfProcess.Name, Type: "process", Name: "web"}),
+					"Target":    Equal(korifiv1alpha1.CFAuditEventParticipant{GUID: cfApp.Name, Type: "app", Name: "my-app"}),
+					"SpaceGUID": Equal(cfSpace.Status.GUID),
+					"Data": SatisfyAll(
+						HaveKeyWithValue("exit_status", "137"),
+						HaveKeyWithValue("exit_description", "OOMKilled"),
+						HaveKeyWithValue("instance", pod.Name),
+						HaveKeyWithValue("reason", "CRASHED"),
+					),
+				}))
+			}).Should(Succeed())
+		})
+
+		It("records the crash only once", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(listCrashEvents(g)).To(HaveLen(1))
+			}).Should(Succeed())
+
+			Expect(k8s.PatchResource(ctx, adminClient, pod, func() {
+				pod.Annotations = map[string]string{"trigger-the": "reconciler"}
+			})).To(Succeed())
+
+			Consistently(func(g Gomega) {
+				g.Expect(listCrashEvents(g)).To(HaveLen(1))
+			}, "1s").Should(Succeed())
+		})
+	})
+
+	When("a container of a pod that is being deleted terminates", func() {
+		BeforeEach(func() {
+			Expect(k8s.PatchResource(ctx, adminClient, pod, func() {
+				pod.Finalizers = []string{"korifi.cloudfoundry.org/test"}
+			})).To(Succeed())
+			DeferCleanup(func() {
+				Expect(k8s.PatchResource(ctx, adminClient, pod, func() {
+					pod.Finalizers = nil
+				})).To(Succeed())
+			})
+			Expect(adminClient.Delete(ctx, pod)).To(Succeed())
+
+			Expect(k8s.Patch(ctx, adminClient, pod, func() {
+				pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+					Name:    "application",
+					Image:   "some-image",
+					ImageID: "some-image-id",
+					State: corev1.ContainerState{
+						Terminated: &corev1.ContainerStateTerminated{
+							ExitCode:    143,
+							Reason:      "Error",
+							ContainerID: "containerd://some-container",
+						},
+					},
+				}}
+			})).To(Succeed())
+		})
+
+		It("does not record a process crash", func() {
+			Consistently(func(g Gomega) {
+				g.Expect(listCrashEvents(g)).To(BeEmpty())
+			}, "1s").Should(Succeed())
+		})
+	})
+})
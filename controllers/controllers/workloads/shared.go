@@ -206,3 +206,60 @@ func logAndSetReadyStatus(err error, log logr.Logger, conditions *[]metav1.Condi
 
 	return ctrl.Result{}, err
 }
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfisolationsegments,verbs=get;list;watch
+
+// getIsolationSegmentPlacement returns the node selector and tolerations of
+// the isolation segment the space is assigned to. Both are nil when the space
+// is not assigned to any isolation segment
+func getIsolationSegmentPlacement(ctx context.Context, k8sClient client.Client, rootNamespace, spaceGUID string) (map[string]string, []corev1.Toleration, error) {
+	spaces := new(korifiv1alpha1.CFSpaceList)
+	if err := k8sClient.List(ctx, spaces, client.MatchingFields{shared.IndexSpaceNamespaceName: spaceGUID}); err != nil {
+		return nil, nil, fmt.Errorf("failed to list spaces: %w", err)
+	}
+
+	if len(spaces.Items) != 1 || spaces.Items[0].Spec.IsolationSegment == "" {
+		return nil, nil, nil
+	}
+	isolationSegmentGUID := spaces.Items[0].Spec.IsolationSegment
+
+	isolationSegment := new(korifiv1alpha1.CFIsolationSegment)
+	err := k8sClient.Get(ctx, types.NamespacedName{Namespace: rootNamespace, Name: isolationSegmentGUID}, isolationSegment)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get isolation segment %q of space %q: %w", isolationSegmentGUID, spaceGUID, err)
+	}
+
+	return isolationSegment.Spec.NodeSelector, isolationSegment.Spec.Tolerations, nil
+}
+
+// newAppUsageEvent returns an app usage event for the current instances and
+// memory of the process. Callers set the name and the states of the event
+func newAppUsageEvent(rootNamespace string, cfApp *korifiv1alpha1.CFApp, cfProcess *korifiv1alpha1.CFProcess, space korifiv1alpha1.UsageEventResource, orgGUID string) *korifiv1alpha1.CFAppUsageEvent {
+	var instances int32
+	if cfProcess.Spec.DesiredInstances != nil {
+		instances = int32(*cfProcess.Spec.DesiredInstances)
+	}
+
+	return &korifiv1alpha1.CFAppUsageEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: rootNamespace,
+		},
+		Spec: korifiv1alpha1.CFAppUsageEventSpec{
+			App: korifiv1alpha1.UsageEventResource{
+				GUID: cfApp.Name,
+				Name: cfApp.Spec.DisplayName,
+			},
+			Process: korifiv1alpha1.UsageEventResource{
+				GUID: cfProcess.Name,
+				Name: cfProcess.Spec.ProcessType,
+			},
+			Space:                         space,
+			OrganizationGUID:              orgGUID,
+			InstanceCount:                 instances,
+			PreviousInstanceCount:         instances,
+			MemoryInMBPerInstance:         cfProcess.Spec.MemoryMB,
+			PreviousMemoryInMBPerInstance: cfProcess.Spec.MemoryMB,
+			Timestamp:                     metav1.NowMicro(),
+		},
+	}
+}
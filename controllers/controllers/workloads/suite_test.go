@@ -59,6 +59,8 @@ var (
 	packageCleaner       *fake.PackageCleaner
 	eventRecorder        *controllerfake.EventRecorder
 	buildCleaner         *fake.BuildCleaner
+	revisionCleaner      *fake.RevisionCleaner
+	imageConfigGetter    *fake.ImageConfigGetter
 	logOutput            *gbytes.Buffer
 )
 
@@ -124,24 +126,29 @@ var _ = BeforeSuite(func() {
 	}
 
 	eventRecorder = new(controllerfake.EventRecorder)
+	revisionCleaner = new(fake.RevisionCleaner)
 
 	err = (NewCFAppReconciler(
 		k8sManager.GetClient(),
 		k8sManager.GetScheme(),
 		ctrl.Log.WithName("controllers").WithName("CFApp"),
-		env.NewVCAPServicesEnvValueBuilder(k8sManager.GetClient()),
+		env.NewVCAPServicesEnvValueBuilder(k8sManager.GetClient(), cfRootNamespace),
 		env.NewVCAPApplicationEnvValueBuilder(k8sManager.GetClient(), nil),
+		cfRootNamespace,
+		revisionCleaner,
 	)).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
 	buildCleaner = new(fake.BuildCleaner)
+	imageConfigGetter = new(fake.ImageConfigGetter)
 	cfBuildReconciler := NewCFBuildReconciler(
 		k8sManager.GetClient(),
 		buildCleaner,
 		k8sManager.GetScheme(),
 		ctrl.Log.WithName("controllers").WithName("CFBuild"),
 		controllerConfig,
-		env.NewWorkloadEnvBuilder(k8sManager.GetClient()),
+		env.NewWorkloadEnvBuilder(k8sManager.GetClient(), cfRootNamespace),
+		imageConfigGetter,
 	)
 	err = (cfBuildReconciler).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
@@ -151,7 +158,7 @@ var _ = BeforeSuite(func() {
 		k8sManager.GetScheme(),
 		ctrl.Log.WithName("controllers").WithName("CFProcess"),
 		controllerConfig,
-		env.NewWorkloadEnvBuilder(k8sManager.GetClient()),
+		env.NewWorkloadEnvBuilder(k8sManager.GetClient(), cfRootNamespace),
 	)).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
@@ -197,16 +204,45 @@ var _ = BeforeSuite(func() {
 		k8sManager.GetScheme(),
 		eventRecorder,
 		ctrl.Log.WithName("controllers").WithName("CFTask"),
-		env.NewWorkloadEnvBuilder(k8sManager.GetClient()),
+		env.NewWorkloadEnvBuilder(k8sManager.GetClient(), cfRootNamespace),
+		cfRootNamespace,
 		2*time.Second,
 	).SetupWithManager(k8sManager)
 	Expect(err).NotTo(HaveOccurred())
 
+	err = NewCFAuditEventReconciler(
+		k8sManager.GetClient(),
+		ctrl.Log.WithName("controllers").WithName("CFAuditEvent"),
+		5*time.Second,
+	).SetupWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = NewCFAppUsageEventReconciler(
+		k8sManager.GetClient(),
+		ctrl.Log.WithName("controllers").WithName("CFAppUsageEvent"),
+		20*time.Second,
+	).SetupWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = NewCFServiceUsageEventReconciler(
+		k8sManager.GetClient(),
+		ctrl.Log.WithName("controllers").WithName("CFServiceUsageEvent"),
+		20*time.Second,
+	).SetupWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+
+	err = NewProcessCrashReconciler(
+		k8sManager.GetClient(),
+		ctrl.Log.WithName("controllers").WithName("ProcessCrash"),
+	).SetupWithManager(k8sManager)
+	Expect(err).NotTo(HaveOccurred())
+
 	finalizer.NewControllersFinalizerWebhook().SetupWebhookWithManager(k8sManager)
 	version.NewVersionWebhook("some-version").SetupWebhookWithManager(k8sManager)
 	Expect((&korifiv1alpha1.CFApp{}).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect(workloads.NewCFAppValidator(
 		webhooks.NewDuplicateValidator(coordination.NewNameRegistry(k8sManager.GetClient(), workloads.AppEntityType)),
+		webhooks.NewQuotaLimitValidator(k8sManager.GetClient(), cfRootNamespace),
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	(&workloads.AppRevWebhook{}).SetupWebhookWithManager(k8sManager)
 
@@ -221,11 +257,19 @@ var _ = BeforeSuite(func() {
 	Expect(networking.NewCFDomainValidator(k8sManager.GetClient()).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect(services.NewCFServiceInstanceValidator(
 		webhooks.NewDuplicateValidator(coordination.NewNameRegistry(k8sManager.GetClient(), services.ServiceInstanceEntityType)),
+		webhooks.NewQuotaLimitValidator(k8sManager.GetClient(), cfRootNamespace),
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	Expect((&korifiv1alpha1.CFPackage{}).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
-	Expect(workloads.NewCFTaskValidator().SetupWebhookWithManager(k8sManager)).To(Succeed())
+	Expect(workloads.NewCFTaskValidator(
+		webhooks.NewQuotaLimitValidator(k8sManager.GetClient(), cfRootNamespace),
+		config.CFProcessDefaults{MemoryMB: defaultMemoryMB, DiskQuotaMB: defaultDiskQuotaMB},
+	).SetupWebhookWithManager(k8sManager)).To(Succeed())
+	Expect(workloads.NewCFProcessValidator(
+		webhooks.NewQuotaLimitValidator(k8sManager.GetClient(), cfRootNamespace),
+		k8sManager.GetClient(),
+	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect(workloads.NewCFTaskDefaulter(config.CFProcessDefaults{
 		MemoryMB:    128,
 		DiskQuotaMB: 256,
@@ -237,11 +281,13 @@ var _ = BeforeSuite(func() {
 	Expect((&korifiv1alpha1.CFRoute{}).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect(networking.NewCFRouteValidator(
 		webhooks.NewDuplicateValidator(coordination.NewNameRegistry(k8sManager.GetClient(), networking.RouteEntityType)),
+		webhooks.NewQuotaLimitValidator(k8sManager.GetClient(), cfRootNamespace),
 		cfRootNamespace,
 		k8sManager.GetClient(),
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect(services.NewCFServiceBindingValidator(
 		webhooks.NewDuplicateValidator(coordination.NewNameRegistry(k8sManager.GetClient(), services.ServiceBindingEntityType)),
+		k8sManager.GetClient(),
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	stopManager = helpers.StartK8sManager(k8sManager)
@@ -404,3 +450,28 @@ func createSpace(org *korifiv1alpha1.CFOrg) *korifiv1alpha1.CFSpace {
 	}).Should(Succeed())
 	return cfSpace
 }
+
+func assignIsolationSegment(cfSpace *korifiv1alpha1.CFSpace) *korifiv1alpha1.CFIsolationSegment {
+	isolationSegment := &korifiv1alpha1.CFIsolationSegment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      testutils.PrefixedGUID("isolation-segment"),
+			Namespace: cfRootNamespace,
+		},
+		Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+			DisplayName:  testutils.PrefixedGUID("isolation-segment"),
+			NodeSelector: map[string]string{"pool": "regulated"},
+			Tolerations: []corev1.Toleration{{
+				Key:      "pool",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "regulated",
+				Effect:   corev1.TaintEffectNoSchedule,
+			}},
+		},
+	}
+	Expect(adminClient.Create(ctx, isolationSegment)).To(Succeed())
+	Expect(k8s.PatchResource(ctx, adminClient, cfSpace, func() {
+		cfSpace.Spec.IsolationSegment = isolationSegment.Name
+	})).To(Succeed())
+
+	return isolationSegment
+}
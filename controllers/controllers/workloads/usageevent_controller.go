@@ -0,0 +1,91 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package workloads
+
+import (
+	"context"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// UsageEventReconciler prunes CFAppUsageEvents and CFServiceUsageEvents once
+// they outlive the usage event TTL
+type UsageEventReconciler[T any, PT k8s.ObjectWithDeepCopy[T]] struct {
+	k8sClient             client.Client
+	log                   logr.Logger
+	usageEventTTLDuration time.Duration
+}
+
+func NewCFAppUsageEventReconciler(
+	client client.Client,
+	log logr.Logger,
+	usageEventTTLDuration time.Duration,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFAppUsageEvent, *korifiv1alpha1.CFAppUsageEvent] {
+	return newUsageEventReconciler[korifiv1alpha1.CFAppUsageEvent](client, log, usageEventTTLDuration)
+}
+
+func NewCFServiceUsageEventReconciler(
+	client client.Client,
+	log logr.Logger,
+	usageEventTTLDuration time.Duration,
+) *k8s.PatchingReconciler[korifiv1alpha1.CFServiceUsageEvent, *korifiv1alpha1.CFServiceUsageEvent] {
+	return newUsageEventReconciler[korifiv1alpha1.CFServiceUsageEvent](client, log, usageEventTTLDuration)
+}
+
+func newUsageEventReconciler[T any, PT k8s.ObjectWithDeepCopy[T]](
+	client client.Client,
+	log logr.Logger,
+	usageEventTTLDuration time.Duration,
+) *k8s.PatchingReconciler[T, PT] {
+	usageEventReconciler := UsageEventReconciler[T, PT]{
+		k8sClient:             client,
+		log:                   log,
+		usageEventTTLDuration: usageEventTTLDuration,
+	}
+	return k8s.NewPatchingReconciler[T, PT](log, client, &usageEventReconciler)
+}
+
+func (r *UsageEventReconciler[T, PT]) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(PT(new(T)))
+}
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfappusageevents;cfserviceusageevents,verbs=get;list;watch;create;patch;delete
+
+func (r *UsageEventReconciler[T, PT]) ReconcileResource(ctx context.Context, usageEvent PT) (ctrl.Result, error) {
+	log := shared.ObjectLogger(r.log, usageEvent)
+
+	expiresAt := usageEvent.GetCreationTimestamp().Add(r.usageEventTTLDuration)
+	if time.Now().Before(expiresAt) {
+		return ctrl.Result{RequeueAfter: time.Until(expiresAt)}, nil
+	}
+
+	log.V(1).Info("deleting-expired-usage-event")
+	err := r.k8sClient.Delete(ctx, usageEvent)
+	if err != nil {
+		log.Info("error-deleting-usage-event", "reason", err)
+	}
+	return ctrl.Result{}, client.IgnoreNotFound(err)
+}
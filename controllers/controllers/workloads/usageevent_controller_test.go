@@ -0,0 +1,75 @@
+package workloads_test
+
+import (
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/testutils"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("UsageEventReconciler Integration Tests", func() {
+	var usageEvent client.Object
+
+	JustBeforeEach(func() {
+		Expect(adminClient.Create(ctx, usageEvent)).To(Succeed())
+	})
+
+	expectUsageEventToExpire := func() {
+		It("it can get the usage event shortly after it has been recorded", func() {
+			Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(usageEvent), usageEvent)).To(Succeed())
+		})
+
+		It("deletes the usage event after it expires", func() {
+			Eventually(func(g Gomega) {
+				err := adminClient.Get(ctx, client.ObjectKeyFromObject(usageEvent), usageEvent)
+				g.Expect(err).To(HaveOccurred())
+				g.Expect(client.IgnoreNotFound(err)).NotTo(HaveOccurred())
+			}).WithTimeout(30 * time.Second).Should(Succeed())
+		})
+	}
+
+	Describe("app usage events", func() {
+		BeforeEach(func() {
+			usageEvent = &korifiv1alpha1.CFAppUsageEvent{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: cfRootNamespace,
+					Name:      testutils.PrefixedGUID("app-usage-event"),
+				},
+				Spec: korifiv1alpha1.CFAppUsageEventSpec{
+					State:     korifiv1alpha1.AppUsageEventStateStarted,
+					App:       korifiv1alpha1.UsageEventResource{GUID: "app-guid", Name: "my-app"},
+					Process:   korifiv1alpha1.UsageEventResource{GUID: "process-guid", Name: "web"},
+					Space:     korifiv1alpha1.UsageEventResource{GUID: "space-guid", Name: "my-space"},
+					Timestamp: metav1.NowMicro(),
+				},
+			}
+		})
+
+		expectUsageEventToExpire()
+	})
+
+	Describe("service usage events", func() {
+		BeforeEach(func() {
+			usageEvent = &korifiv1alpha1.CFServiceUsageEvent{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: cfRootNamespace,
+					Name:      testutils.PrefixedGUID("service-usage-event"),
+				},
+				Spec: korifiv1alpha1.CFServiceUsageEventSpec{
+					State:               korifiv1alpha1.ServiceUsageEventStateCreated,
+					ServiceInstance:     korifiv1alpha1.UsageEventResource{GUID: "instance-guid", Name: "my-instance"},
+					ServiceInstanceType: korifiv1alpha1.UserProvidedServiceInstanceUsageType,
+					Space:               korifiv1alpha1.UsageEventResource{GUID: "space-guid", Name: "my-space"},
+					Timestamp:           metav1.NowMicro(),
+				},
+			}
+		})
+
+		expectUsageEventToExpire()
+	})
+})
@@ -21,6 +21,7 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"time"
 
@@ -29,6 +30,7 @@ import (
 	"code.cloudfoundry.org/korifi/controllers/config"
 	networkingcontrollers "code.cloudfoundry.org/korifi/controllers/controllers/networking"
 	servicescontrollers "code.cloudfoundry.org/korifi/controllers/controllers/services"
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	workloadscontrollers "code.cloudfoundry.org/korifi/controllers/controllers/workloads"
 	"code.cloudfoundry.org/korifi/controllers/controllers/workloads/env"
@@ -53,6 +55,7 @@ import (
 	buildv1alpha2 "github.com/pivotal/kpack/pkg/apis/build/v1alpha2"
 	contourv1 "github.com/projectcontour/contour/apis/projectcontour/v1"
 	servicebindingv1beta1 "github.com/servicebinding/runtime/apis/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	k8sclient "k8s.io/client-go/kubernetes"
@@ -60,6 +63,8 @@ import (
 	"k8s.io/klog/v2"
 	admission "k8s.io/pod-security-admission/api"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
@@ -68,6 +73,9 @@ import (
 	//+kubebuilder:scaffold:imports
 )
 
+// osbapiRequestTimeout is the minimum request timeout platforms should use according to the OSBAPI spec
+const osbapiRequestTimeout = 60 * time.Second
+
 var (
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
@@ -135,19 +143,35 @@ func main() {
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "13c200ec.cloudfoundry.org",
+		Cache: cache.Options{
+			ByObject: map[client.Object]cache.ByObject{
+				// only app instance pods are watched (by the process crash
+				// controller), there is no need to cache every pod in the cluster
+				&corev1.Pod{}: {Label: workloadscontrollers.AppInstancePodsSelector()},
+			},
+		},
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to initialize manager")
 		os.Exit(1)
 	}
 
+	// Setup Index with Manager, both controllers and webhooks list by index
+	err = shared.SetupIndexWithManager(mgr)
+	if err != nil {
+		setupLog.Error(err, "unable to setup index on manager")
+		os.Exit(1)
+	}
+
 	if os.Getenv("ENABLE_CONTROLLERS") != "false" {
 		if err = (workloadscontrollers.NewCFAppReconciler(
 			mgr.GetClient(),
 			mgr.GetScheme(),
 			ctrl.Log.WithName("controllers").WithName("CFApp"),
-			env.NewVCAPServicesEnvValueBuilder(mgr.GetClient()),
+			env.NewVCAPServicesEnvValueBuilder(mgr.GetClient(), controllerConfig.CFRootNamespace),
 			env.NewVCAPApplicationEnvValueBuilder(mgr.GetClient(), controllerConfig.ExtraVCAPApplicationValues),
+			controllerConfig.CFRootNamespace,
+			cleanup.NewRevisionCleaner(mgr.GetClient(), controllerConfig.MaxRetainedRevisionsPerApp),
 		)).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFApp")
 			os.Exit(1)
@@ -159,7 +183,8 @@ func main() {
 			mgr.GetScheme(),
 			ctrl.Log.WithName("controllers").WithName("CFBuild"),
 			controllerConfig,
-			env.NewWorkloadEnvBuilder(mgr.GetClient()),
+			env.NewWorkloadEnvBuilder(mgr.GetClient(), controllerConfig.CFRootNamespace),
+			image.NewClient(k8sClient),
 		)).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFBuild")
 			os.Exit(1)
@@ -182,7 +207,7 @@ func main() {
 			mgr.GetScheme(),
 			ctrl.Log.WithName("controllers").WithName("CFProcess"),
 			controllerConfig,
-			env.NewWorkloadEnvBuilder(mgr.GetClient()),
+			env.NewWorkloadEnvBuilder(mgr.GetClient(), controllerConfig.CFRootNamespace),
 		)).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFProcess")
 			os.Exit(1)
@@ -191,21 +216,52 @@ func main() {
 		if err = (servicescontrollers.NewCFServiceInstanceReconciler(
 			mgr.GetClient(),
 			mgr.GetScheme(),
+			osbapi.NewClient(&http.Client{Timeout: osbapiRequestTimeout}),
+			controllerConfig.CFRootNamespace,
 			ctrl.Log.WithName("controllers").WithName("CFServiceInstance"),
 		)).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFServiceInstance")
 			os.Exit(1)
 		}
 
+		if err = (servicescontrollers.NewCFServiceBrokerReconciler(
+			mgr.GetClient(),
+			mgr.GetScheme(),
+			osbapi.NewClient(&http.Client{Timeout: osbapiRequestTimeout}),
+			ctrl.Log.WithName("controllers").WithName("CFServiceBroker"),
+		)).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFServiceBroker")
+			os.Exit(1)
+		}
+
 		if err = (servicescontrollers.NewCFServiceBindingReconciler(
 			mgr.GetClient(),
 			mgr.GetScheme(),
+			osbapi.NewClient(&http.Client{Timeout: osbapiRequestTimeout}),
+			controllerConfig.CFRootNamespace,
 			ctrl.Log.WithName("controllers").WithName("CFServiceBinding"),
 		)).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFServiceBinding")
 			os.Exit(1)
 		}
 
+		var signatureRotation time.Duration
+		signatureRotation, err = controllerConfig.ParseRouteServiceSignatureRotation()
+		if err != nil {
+			setupLog.Error(err, "failed to parse route service signature rotation", "routeServiceSignatureRotation", controllerConfig.RouteServiceSignatureRotation)
+			os.Exit(1)
+		}
+
+		if err = (servicescontrollers.NewCFServiceRouteBindingReconciler(
+			mgr.GetClient(),
+			mgr.GetScheme(),
+			signatureRotation,
+			ctrl.Log.WithName("controllers").WithName("CFServiceRouteBinding"),
+		)).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFServiceRouteBinding")
+			os.Exit(1)
+		}
+
 		labelCompiler := labels.NewCompiler().
 			Defaults(map[string]string{
 				admission.EnforceLevelLabel: string(admission.LevelRestricted),
@@ -249,13 +305,60 @@ func main() {
 			mgr.GetScheme(),
 			mgr.GetEventRecorderFor("cftask-controller"),
 			ctrl.Log.WithName("controllers").WithName("CFTask"),
-			env.NewWorkloadEnvBuilder(mgr.GetClient()),
+			env.NewWorkloadEnvBuilder(mgr.GetClient(), controllerConfig.CFRootNamespace),
+			controllerConfig.CFRootNamespace,
 			taskTTL,
 		).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "CFTask")
 			os.Exit(1)
 		}
 
+		var auditEventTTL time.Duration
+		auditEventTTL, err = controllerConfig.ParseAuditEventTTL()
+		if err != nil {
+			setupLog.Error(err, "failed to parse audit event TTL", "controller", "CFAuditEvent", "auditEventTTL", controllerConfig.AuditEventTTL)
+			os.Exit(1)
+		}
+		if err = workloadscontrollers.NewCFAuditEventReconciler(
+			mgr.GetClient(),
+			ctrl.Log.WithName("controllers").WithName("CFAuditEvent"),
+			auditEventTTL,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFAuditEvent")
+			os.Exit(1)
+		}
+
+		var usageEventTTL time.Duration
+		usageEventTTL, err = controllerConfig.ParseUsageEventTTL()
+		if err != nil {
+			setupLog.Error(err, "failed to parse usage event TTL", "usageEventTTL", controllerConfig.UsageEventTTL)
+			os.Exit(1)
+		}
+		if err = workloadscontrollers.NewCFAppUsageEventReconciler(
+			mgr.GetClient(),
+			ctrl.Log.WithName("controllers").WithName("CFAppUsageEvent"),
+			usageEventTTL,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFAppUsageEvent")
+			os.Exit(1)
+		}
+		if err = workloadscontrollers.NewCFServiceUsageEventReconciler(
+			mgr.GetClient(),
+			ctrl.Log.WithName("controllers").WithName("CFServiceUsageEvent"),
+			usageEventTTL,
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFServiceUsageEvent")
+			os.Exit(1)
+		}
+
+		if err = workloadscontrollers.NewProcessCrashReconciler(
+			mgr.GetClient(),
+			ctrl.Log.WithName("controllers").WithName("ProcessCrash"),
+		).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ProcessCrash")
+			os.Exit(1)
+		}
+
 		if err = (networkingcontrollers.NewCFDomainReconciler(
 			mgr.GetClient(),
 			mgr.GetScheme(),
@@ -264,14 +367,17 @@ func main() {
 			setupLog.Error(err, "unable to create controller", "controller", "CFDomain")
 			os.Exit(1)
 		}
-		//+kubebuilder:scaffold:builder
 
-		// Setup Index with Manager
-		err = shared.SetupIndexWithManager(mgr)
-		if err != nil {
-			setupLog.Error(err, "unable to setup index on manager")
+		if err = (networkingcontrollers.NewCFSecurityGroupReconciler(
+			mgr.GetClient(),
+			mgr.GetScheme(),
+			controllerConfig.CFRootNamespace,
+			ctrl.Log.WithName("controllers").WithName("CFSecurityGroup"),
+		)).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "CFSecurityGroup")
 			os.Exit(1)
 		}
+		//+kubebuilder:scaffold:builder
 
 		if controllerConfig.IncludeKpackImageBuilder {
 			var builderReadinessTimeout time.Duration
@@ -299,6 +405,7 @@ func main() {
 				mgr.GetScheme(),
 				ctrl.Log.WithName("controllers").WithName("BuilderInfoReconciler"),
 				controllerConfig.ClusterBuilderName,
+				controllerConfig.StackClusterBuilderNames,
 				controllerConfig.CFRootNamespace,
 			).SetupWithManager(mgr); err != nil {
 				setupLog.Error(err, "unable to create controller", "controller", "BuilderInfo")
@@ -328,7 +435,8 @@ func main() {
 				logger,
 				mgr.GetClient(),
 				mgr.GetScheme(),
-				jobtaskrunnercontrollers.NewStatusGetter(logger, mgr.GetClient()),
+				// task pods are not in the manager cache, see the pod cache options above
+				jobtaskrunnercontrollers.NewStatusGetter(logger, mgr.GetAPIReader()),
 				jobTTL,
 			)
 			if err = taskWorkloadReconciler.SetupWithManager(mgr); err != nil {
@@ -403,8 +511,11 @@ func main() {
 			os.Exit(1)
 		}
 
+		quotaValidator := webhooks.NewQuotaLimitValidator(mgr.GetClient(), controllerConfig.CFRootNamespace)
+
 		if err = workloads.NewCFAppValidator(
 			webhooks.NewDuplicateValidator(coordination.NewNameRegistry(mgr.GetClient(), workloads.AppEntityType)),
+			quotaValidator,
 		).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFApp")
 			os.Exit(1)
@@ -412,6 +523,7 @@ func main() {
 
 		if err = networking.NewCFRouteValidator(
 			webhooks.NewDuplicateValidator(coordination.NewNameRegistry(mgr.GetClient(), networking.RouteEntityType)),
+			quotaValidator,
 			controllerConfig.CFRootNamespace,
 			mgr.GetClient(),
 		).SetupWebhookWithManager(mgr); err != nil {
@@ -421,6 +533,7 @@ func main() {
 
 		if err = services.NewCFServiceInstanceValidator(
 			webhooks.NewDuplicateValidator(coordination.NewNameRegistry(mgr.GetClient(), services.ServiceInstanceEntityType)),
+			quotaValidator,
 		).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFServiceInstance")
 			os.Exit(1)
@@ -428,11 +541,26 @@ func main() {
 
 		if err = services.NewCFServiceBindingValidator(
 			webhooks.NewDuplicateValidator(coordination.NewNameRegistry(mgr.GetClient(), services.ServiceBindingEntityType)),
+			mgr.GetClient(),
 		).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFServiceBinding")
 			os.Exit(1)
 		}
 
+		if err = services.NewCFServiceBrokerValidator(
+			webhooks.NewDuplicateValidator(coordination.NewNameRegistry(mgr.GetClient(), services.ServiceBrokerEntityType)),
+		).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CFServiceBroker")
+			os.Exit(1)
+		}
+
+		if err = networking.NewCFSecurityGroupValidator(
+			webhooks.NewDuplicateValidator(coordination.NewNameRegistry(mgr.GetClient(), networking.SecurityGroupEntityType)),
+		).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CFSecurityGroup")
+			os.Exit(1)
+		}
+
 		if err = networking.NewCFDomainValidator(
 			mgr.GetClient(),
 		).SetupWebhookWithManager(mgr); err != nil {
@@ -466,11 +594,42 @@ func main() {
 			os.Exit(1)
 		}
 
-		if err = workloads.NewCFTaskValidator().SetupWebhookWithManager(mgr); err != nil {
+		if err = workloads.NewCFTaskValidator(quotaValidator, controllerConfig.CFProcessDefaults).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "CFTask")
 			os.Exit(1)
 		}
 
+		if err = workloads.NewCFProcessValidator(quotaValidator, mgr.GetClient()).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CFProcess")
+			os.Exit(1)
+		}
+
+		if err = workloads.NewCFSidecarValidator(mgr.GetClient()).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CFSidecar")
+			os.Exit(1)
+		}
+
+		if err = workloads.NewCFOrgQuotaValidator(
+			webhooks.NewDuplicateValidator(coordination.NewNameRegistry(mgr.GetClient(), workloads.OrgQuotaEntityType)),
+		).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CFOrgQuota")
+			os.Exit(1)
+		}
+
+		if err = workloads.NewCFSpaceQuotaValidator(
+			webhooks.NewDuplicateValidator(coordination.NewNameRegistry(mgr.GetClient(), workloads.SpaceQuotaEntityType)),
+		).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CFSpaceQuota")
+			os.Exit(1)
+		}
+
+		if err = workloads.NewCFIsolationSegmentValidator(
+			webhooks.NewDuplicateValidator(coordination.NewNameRegistry(mgr.GetClient(), workloads.IsolationSegmentEntityType)),
+		).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "CFIsolationSegment")
+			os.Exit(1)
+		}
+
 		versionwebhook.NewVersionWebhook(version.Version).SetupWebhookWithManager(mgr)
 		controllersfinalizer.NewControllersFinalizerWebhook().SetupWebhookWithManager(mgr)
 
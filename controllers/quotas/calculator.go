@@ -0,0 +1,344 @@
+package quotas
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	OrgScope   = "organization"
+	SpaceScope = "space"
+)
+
+// Usage is the amount of quota limited resources consumed in a space or an org
+type Usage struct {
+	MemoryInMB       int64
+	Instances        int64
+	Apps             int64
+	Routes           int64
+	ServiceInstances int64
+}
+
+func (u Usage) Add(other Usage) Usage {
+	return Usage{
+		MemoryInMB:       u.MemoryInMB + other.MemoryInMB,
+		Instances:        u.Instances + other.Instances,
+		Apps:             u.Apps + other.Apps,
+		Routes:           u.Routes + other.Routes,
+		ServiceInstances: u.ServiceInstances + other.ServiceInstances,
+	}
+}
+
+// Request describes the resources a workload is about to consume
+type Request struct {
+	// The usage the request adds to the space
+	Increase Usage
+	// The memory of a single process instance or task, checked against the per process limit when set
+	ProcessMemoryInMB int64
+	// The app a new task is requested for, checked against the per app tasks limit when set
+	TaskAppGUID string
+	// The app about to be started, the usage of its processes is added to the increase when set
+	StartedAppGUID string
+}
+
+type ExceededError struct {
+	Scope string
+	Limit string
+}
+
+func (e ExceededError) Error() string {
+	return fmt.Sprintf("You have exceeded the %s limit for your %s's quota.", e.Limit, e.Scope)
+}
+
+type Calculator struct {
+	k8sClient     client.Client
+	rootNamespace string
+}
+
+func NewCalculator(k8sClient client.Client, rootNamespace string) Calculator {
+	return Calculator{k8sClient: k8sClient, rootNamespace: rootNamespace}
+}
+
+// Check returns an ExceededError when granting the request would exceed the
+// space quota or the org quota applying to the space
+func (c Calculator) Check(ctx context.Context, spaceGUID string, request Request) error {
+	cfSpace, err := c.findSpace(ctx, spaceGUID)
+	if err != nil {
+		return err
+	}
+	if cfSpace == nil {
+		return nil
+	}
+	orgGUID := cfSpace.Namespace
+
+	spaceQuota, err := c.FindSpaceQuota(ctx, orgGUID, spaceGUID)
+	if err != nil {
+		return err
+	}
+	orgQuota, err := c.FindOrgQuota(ctx, orgGUID)
+	if err != nil {
+		return err
+	}
+	if spaceQuota == nil && orgQuota == nil {
+		return nil
+	}
+
+	if request.StartedAppGUID != "" {
+		request, err = c.addAppProcesses(ctx, spaceGUID, request)
+		if err != nil {
+			return err
+		}
+	}
+
+	if spaceQuota != nil {
+		err = c.checkLimits(ctx, SpaceScope, spaceQuota.Spec.Limits, spaceGUID, request, func() (Usage, error) {
+			return c.SpaceUsage(ctx, spaceGUID)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if orgQuota != nil {
+		err = c.checkLimits(ctx, OrgScope, orgQuota.Spec.Limits, spaceGUID, request, func() (Usage, error) {
+			return c.OrgUsage(ctx, orgGUID)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c Calculator) addAppProcesses(ctx context.Context, spaceGUID string, request Request) (Request, error) {
+	var processes korifiv1alpha1.CFProcessList
+	if err := c.k8sClient.List(ctx, &processes, client.InNamespace(spaceGUID)); err != nil {
+		return Request{}, fmt.Errorf("failed to list processes: %w", err)
+	}
+
+	for _, process := range processes.Items {
+		if process.Spec.AppRef.Name != request.StartedAppGUID {
+			continue
+		}
+
+		request.Increase = request.Increase.Add(ProcessUsage(process))
+		if process.Spec.MemoryMB > request.ProcessMemoryInMB {
+			request.ProcessMemoryInMB = process.Spec.MemoryMB
+		}
+	}
+
+	return request, nil
+}
+
+func (c Calculator) checkLimits(ctx context.Context, scope string, limits korifiv1alpha1.QuotaLimits, spaceGUID string, request Request, getUsage func() (Usage, error)) error {
+	if exceeds(request.ProcessMemoryInMB, limits.PerProcessMemoryInMB) {
+		return ExceededError{Scope: scope, Limit: "instance memory"}
+	}
+
+	if request.TaskAppGUID != "" && limits.PerAppTasks != nil {
+		runningTasks, err := c.countRunningTasks(ctx, spaceGUID, request.TaskAppGUID)
+		if err != nil {
+			return err
+		}
+		if exceeds(runningTasks+1, limits.PerAppTasks) {
+			return ExceededError{Scope: scope, Limit: "app task"}
+		}
+	}
+
+	usage, err := getUsage()
+	if err != nil {
+		return err
+	}
+
+	increase := request.Increase
+	switch {
+	case increase.MemoryInMB > 0 && exceeds(usage.MemoryInMB+increase.MemoryInMB, limits.TotalMemoryInMB):
+		return ExceededError{Scope: scope, Limit: "memory"}
+	case increase.Instances > 0 && exceeds(usage.Instances+increase.Instances, limits.TotalInstances):
+		return ExceededError{Scope: scope, Limit: "app instance"}
+	case increase.Apps > 0 && exceeds(usage.Apps+increase.Apps, limits.TotalApps):
+		return ExceededError{Scope: scope, Limit: "app"}
+	case increase.Routes > 0 && exceeds(usage.Routes+increase.Routes, limits.TotalRoutes):
+		return ExceededError{Scope: scope, Limit: "route"}
+	case increase.ServiceInstances > 0 && exceeds(usage.ServiceInstances+increase.ServiceInstances, limits.TotalServiceInstances):
+		return ExceededError{Scope: scope, Limit: "service instance"}
+	}
+
+	return nil
+}
+
+func exceeds(value int64, limit *int64) bool {
+	return limit != nil && value > *limit
+}
+
+// SpaceUsage sums up the memory and instances of the started processes and
+// running tasks, and counts the apps, routes and managed service instances
+// of a space
+func (c Calculator) SpaceUsage(ctx context.Context, spaceGUID string) (Usage, error) {
+	usage := Usage{}
+
+	var apps korifiv1alpha1.CFAppList
+	if err := c.k8sClient.List(ctx, &apps, client.InNamespace(spaceGUID)); err != nil {
+		return Usage{}, fmt.Errorf("failed to list apps: %w", err)
+	}
+	startedApps := map[string]bool{}
+	for _, app := range apps.Items {
+		startedApps[app.Name] = app.Spec.DesiredState == korifiv1alpha1.StartedState
+	}
+	usage.Apps = int64(len(apps.Items))
+
+	var processes korifiv1alpha1.CFProcessList
+	if err := c.k8sClient.List(ctx, &processes, client.InNamespace(spaceGUID)); err != nil {
+		return Usage{}, fmt.Errorf("failed to list processes: %w", err)
+	}
+	for _, process := range processes.Items {
+		if startedApps[process.Spec.AppRef.Name] {
+			usage = usage.Add(ProcessUsage(process))
+		}
+	}
+
+	var tasks korifiv1alpha1.CFTaskList
+	if err := c.k8sClient.List(ctx, &tasks, client.InNamespace(spaceGUID)); err != nil {
+		return Usage{}, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	for _, task := range tasks.Items {
+		if isTaskRunning(task) {
+			usage.MemoryInMB += task.Status.MemoryMB
+		}
+	}
+
+	var routes korifiv1alpha1.CFRouteList
+	if err := c.k8sClient.List(ctx, &routes, client.InNamespace(spaceGUID)); err != nil {
+		return Usage{}, fmt.Errorf("failed to list routes: %w", err)
+	}
+	usage.Routes = int64(len(routes.Items))
+
+	var serviceInstances korifiv1alpha1.CFServiceInstanceList
+	if err := c.k8sClient.List(ctx, &serviceInstances, client.InNamespace(spaceGUID)); err != nil {
+		return Usage{}, fmt.Errorf("failed to list service instances: %w", err)
+	}
+	for _, serviceInstance := range serviceInstances.Items {
+		if serviceInstance.Spec.Type == korifiv1alpha1.ManagedType {
+			usage.ServiceInstances++
+		}
+	}
+
+	return usage, nil
+}
+
+// OrgUsage sums up the usage of all spaces of an org
+func (c Calculator) OrgUsage(ctx context.Context, orgGUID string) (Usage, error) {
+	var spaces korifiv1alpha1.CFSpaceList
+	if err := c.k8sClient.List(ctx, &spaces, client.InNamespace(orgGUID)); err != nil {
+		return Usage{}, fmt.Errorf("failed to list spaces: %w", err)
+	}
+
+	usage := Usage{}
+	for _, space := range spaces.Items {
+		spaceUsage, err := c.SpaceUsage(ctx, space.Name)
+		if err != nil {
+			return Usage{}, err
+		}
+		usage = usage.Add(spaceUsage)
+	}
+
+	return usage, nil
+}
+
+// FindOrgQuota returns the quota applying to an org, or nil if there is none
+func (c Calculator) FindOrgQuota(ctx context.Context, orgGUID string) (*korifiv1alpha1.CFOrgQuota, error) {
+	var orgQuotas korifiv1alpha1.CFOrgQuotaList
+	if err := c.k8sClient.List(ctx, &orgQuotas, client.InNamespace(c.rootNamespace)); err != nil {
+		return nil, fmt.Errorf("failed to list org quotas: %w", err)
+	}
+
+	for i := range orgQuotas.Items {
+		if contains(orgQuotas.Items[i].Spec.Orgs, orgGUID) {
+			return &orgQuotas.Items[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// FindSpaceQuota returns the quota applying to a space, or nil if there is none
+func (c Calculator) FindSpaceQuota(ctx context.Context, orgGUID, spaceGUID string) (*korifiv1alpha1.CFSpaceQuota, error) {
+	var spaceQuotas korifiv1alpha1.CFSpaceQuotaList
+	if err := c.k8sClient.List(ctx, &spaceQuotas, client.InNamespace(orgGUID)); err != nil {
+		return nil, fmt.Errorf("failed to list space quotas: %w", err)
+	}
+
+	for i := range spaceQuotas.Items {
+		if contains(spaceQuotas.Items[i].Spec.Spaces, spaceGUID) {
+			return &spaceQuotas.Items[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (c Calculator) findSpace(ctx context.Context, spaceGUID string) (*korifiv1alpha1.CFSpace, error) {
+	var spaces korifiv1alpha1.CFSpaceList
+	if err := c.k8sClient.List(ctx, &spaces, client.MatchingFields{shared.IndexSpaceNamespaceName: spaceGUID}); err != nil {
+		return nil, fmt.Errorf("failed to list spaces: %w", err)
+	}
+
+	if len(spaces.Items) != 1 {
+		return nil, nil
+	}
+
+	return &spaces.Items[0], nil
+}
+
+func (c Calculator) countRunningTasks(ctx context.Context, spaceGUID, appGUID string) (int64, error) {
+	var tasks korifiv1alpha1.CFTaskList
+	if err := c.k8sClient.List(ctx, &tasks, client.InNamespace(spaceGUID)); err != nil {
+		return 0, fmt.Errorf("failed to list tasks: %w", err)
+	}
+
+	var count int64
+	for _, task := range tasks.Items {
+		if task.Spec.AppRef.Name == appGUID && isTaskRunning(task) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// ProcessUsage returns the memory and instances of a process when its app is
+// started. The memory of the sidecars is part of the memory of the process
+func ProcessUsage(process korifiv1alpha1.CFProcess) Usage {
+	if process.Spec.DesiredInstances == nil {
+		return Usage{}
+	}
+
+	instances := int64(*process.Spec.DesiredInstances)
+
+	return Usage{
+		MemoryInMB: instances * process.Spec.MemoryMB,
+		Instances:  instances,
+	}
+}
+
+func isTaskRunning(task korifiv1alpha1.CFTask) bool {
+	return !meta.IsStatusConditionTrue(task.Status.Conditions, korifiv1alpha1.TaskSucceededConditionType) &&
+		!meta.IsStatusConditionTrue(task.Status.Conditions, korifiv1alpha1.TaskFailedConditionType)
+}
+
+func contains(guids []string, guid string) bool {
+	for _, g := range guids {
+		if g == guid {
+			return true
+		}
+	}
+
+	return false
+}
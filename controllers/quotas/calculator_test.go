@@ -0,0 +1,359 @@
+package quotas_test
+
+import (
+	"context"
+	"errors"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
+	"code.cloudfoundry.org/korifi/controllers/fake"
+	"code.cloudfoundry.org/korifi/controllers/quotas"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Calculator", func() {
+	const (
+		rootNamespace = "cf"
+		orgGUID       = "org-guid"
+		spaceGUID     = "space-guid"
+		otherSpace    = "other-space-guid"
+	)
+
+	var (
+		ctx        context.Context
+		fakeClient *fake.Client
+		calculator quotas.Calculator
+
+		spaces           []korifiv1alpha1.CFSpace
+		orgQuotas        []korifiv1alpha1.CFOrgQuota
+		spaceQuotas      []korifiv1alpha1.CFSpaceQuota
+		apps             []korifiv1alpha1.CFApp
+		processes        []korifiv1alpha1.CFProcess
+		tasks            []korifiv1alpha1.CFTask
+		routes           []korifiv1alpha1.CFRoute
+		serviceInstances []korifiv1alpha1.CFServiceInstance
+		listErr          error
+	)
+
+	meta := func(namespace, name string) metav1.ObjectMeta {
+		return metav1.ObjectMeta{Namespace: namespace, Name: name}
+	}
+
+	inNamespace := func(namespace string, objNamespace string) bool {
+		return namespace == "" || namespace == objNamespace
+	}
+
+	matchesFields := func(listOpts *client.ListOptions, objFields fields.Set) bool {
+		return listOpts.FieldSelector == nil || listOpts.FieldSelector.Matches(objFields)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		listErr = nil
+
+		spaces = []korifiv1alpha1.CFSpace{
+			{ObjectMeta: meta(orgGUID, spaceGUID), Status: korifiv1alpha1.CFSpaceStatus{GUID: spaceGUID}},
+			{ObjectMeta: meta(orgGUID, otherSpace), Status: korifiv1alpha1.CFSpaceStatus{GUID: otherSpace}},
+		}
+		orgQuotas = nil
+		spaceQuotas = nil
+		apps = []korifiv1alpha1.CFApp{
+			{ObjectMeta: meta(spaceGUID, "started-app"), Spec: korifiv1alpha1.CFAppSpec{DesiredState: korifiv1alpha1.StartedState}},
+			{ObjectMeta: meta(spaceGUID, "stopped-app"), Spec: korifiv1alpha1.CFAppSpec{DesiredState: korifiv1alpha1.StoppedState}},
+			{ObjectMeta: meta(otherSpace, "other-app"), Spec: korifiv1alpha1.CFAppSpec{DesiredState: korifiv1alpha1.StartedState}},
+		}
+		processes = []korifiv1alpha1.CFProcess{
+			{ObjectMeta: meta(spaceGUID, "started-web"), Spec: korifiv1alpha1.CFProcessSpec{
+				AppRef: corev1.LocalObjectReference{Name: "started-app"}, ProcessType: "web", DesiredInstances: tools.PtrTo(2), MemoryMB: 256,
+			}},
+			{ObjectMeta: meta(spaceGUID, "stopped-web"), Spec: korifiv1alpha1.CFProcessSpec{
+				AppRef: corev1.LocalObjectReference{Name: "stopped-app"}, DesiredInstances: tools.PtrTo(3), MemoryMB: 512,
+			}},
+			{ObjectMeta: meta(otherSpace, "other-web"), Spec: korifiv1alpha1.CFProcessSpec{
+				AppRef: corev1.LocalObjectReference{Name: "other-app"}, DesiredInstances: tools.PtrTo(1), MemoryMB: 128,
+			}},
+		}
+		tasks = []korifiv1alpha1.CFTask{
+			{ObjectMeta: meta(spaceGUID, "running-task"), Spec: korifiv1alpha1.CFTaskSpec{AppRef: corev1.LocalObjectReference{Name: "started-app"}}, Status: korifiv1alpha1.CFTaskStatus{MemoryMB: 100}},
+			{ObjectMeta: meta(spaceGUID, "succeeded-task"), Spec: korifiv1alpha1.CFTaskSpec{AppRef: corev1.LocalObjectReference{Name: "started-app"}}, Status: korifiv1alpha1.CFTaskStatus{
+				MemoryMB:   100,
+				Conditions: []metav1.Condition{{Type: korifiv1alpha1.TaskSucceededConditionType, Status: metav1.ConditionTrue}},
+			}},
+		}
+		routes = []korifiv1alpha1.CFRoute{
+			{ObjectMeta: meta(spaceGUID, "route-1")},
+			{ObjectMeta: meta(otherSpace, "route-2")},
+		}
+		serviceInstances = []korifiv1alpha1.CFServiceInstance{
+			{ObjectMeta: meta(spaceGUID, "managed"), Spec: korifiv1alpha1.CFServiceInstanceSpec{Type: korifiv1alpha1.ManagedType}},
+			{ObjectMeta: meta(spaceGUID, "upsi"), Spec: korifiv1alpha1.CFServiceInstanceSpec{Type: korifiv1alpha1.UserProvidedType}},
+		}
+
+		fakeClient = new(fake.Client)
+		fakeClient.ListStub = func(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+			if listErr != nil {
+				return listErr
+			}
+
+			listOpts := &client.ListOptions{}
+			listOpts.ApplyOptions(opts)
+			ns := listOpts.Namespace
+
+			switch list := list.(type) {
+			case *korifiv1alpha1.CFSpaceList:
+				for _, o := range spaces {
+					if inNamespace(ns, o.Namespace) && matchesFields(listOpts, fields.Set{shared.IndexSpaceNamespaceName: o.Status.GUID}) {
+						list.Items = append(list.Items, o)
+					}
+				}
+			case *korifiv1alpha1.CFOrgQuotaList:
+				for _, o := range orgQuotas {
+					if inNamespace(ns, o.Namespace) {
+						list.Items = append(list.Items, o)
+					}
+				}
+			case *korifiv1alpha1.CFSpaceQuotaList:
+				for _, o := range spaceQuotas {
+					if inNamespace(ns, o.Namespace) {
+						list.Items = append(list.Items, o)
+					}
+				}
+			case *korifiv1alpha1.CFAppList:
+				for _, o := range apps {
+					if inNamespace(ns, o.Namespace) {
+						list.Items = append(list.Items, o)
+					}
+				}
+			case *korifiv1alpha1.CFProcessList:
+				for _, o := range processes {
+					if inNamespace(ns, o.Namespace) {
+						list.Items = append(list.Items, o)
+					}
+				}
+			case *korifiv1alpha1.CFTaskList:
+				for _, o := range tasks {
+					if inNamespace(ns, o.Namespace) {
+						list.Items = append(list.Items, o)
+					}
+				}
+			case *korifiv1alpha1.CFRouteList:
+				for _, o := range routes {
+					if inNamespace(ns, o.Namespace) {
+						list.Items = append(list.Items, o)
+					}
+				}
+			case *korifiv1alpha1.CFServiceInstanceList:
+				for _, o := range serviceInstances {
+					if inNamespace(ns, o.Namespace) {
+						list.Items = append(list.Items, o)
+					}
+				}
+			default:
+				panic("unexpected list type")
+			}
+
+			return nil
+		}
+
+		calculator = quotas.NewCalculator(fakeClient, rootNamespace)
+	})
+
+	Describe("SpaceUsage", func() {
+		It("sums up the usage of the space", func() {
+			usage, err := calculator.SpaceUsage(ctx, spaceGUID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usage).To(Equal(quotas.Usage{
+				MemoryInMB:       2*256 + 100,
+				Instances:        2,
+				Apps:             2,
+				Routes:           1,
+				ServiceInstances: 1,
+			}))
+		})
+
+		When("listing fails", func() {
+			BeforeEach(func() {
+				listErr = errors.New("list-err")
+			})
+
+			It("returns the error", func() {
+				_, err := calculator.SpaceUsage(ctx, spaceGUID)
+				Expect(err).To(MatchError(ContainSubstring("list-err")))
+			})
+		})
+	})
+
+	Describe("ProcessUsage", func() {
+		It("multiplies the memory of the process by its instances", func() {
+			Expect(quotas.ProcessUsage(processes[0])).To(Equal(quotas.Usage{MemoryInMB: 2 * 256, Instances: 2}))
+		})
+
+		When("the process has no desired instances", func() {
+			BeforeEach(func() {
+				processes[0].Spec.DesiredInstances = nil
+			})
+
+			It("returns no usage", func() {
+				Expect(quotas.ProcessUsage(processes[0])).To(BeZero())
+			})
+		})
+	})
+
+	Describe("OrgUsage", func() {
+		It("sums up the usage of all spaces in the org", func() {
+			usage, err := calculator.OrgUsage(ctx, orgGUID)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(usage).To(Equal(quotas.Usage{
+				MemoryInMB:       2*256 + 100 + 128,
+				Instances:        3,
+				Apps:             3,
+				Routes:           2,
+				ServiceInstances: 1,
+			}))
+		})
+	})
+
+	Describe("Check", func() {
+		var (
+			request  quotas.Request
+			checkErr error
+		)
+
+		BeforeEach(func() {
+			request = quotas.Request{Increase: quotas.Usage{Apps: 1}}
+		})
+
+		JustBeforeEach(func() {
+			checkErr = calculator.Check(ctx, spaceGUID, request)
+		})
+
+		It("succeeds when no quota applies", func() {
+			Expect(checkErr).NotTo(HaveOccurred())
+		})
+
+		When("the space does not belong to an org", func() {
+			JustBeforeEach(func() {
+				checkErr = calculator.Check(ctx, "not-a-space", request)
+			})
+
+			It("succeeds", func() {
+				Expect(checkErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("a space quota applies", func() {
+			BeforeEach(func() {
+				spaceQuotas = []korifiv1alpha1.CFSpaceQuota{{
+					ObjectMeta: meta(orgGUID, "space-quota"),
+					Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+						Limits: korifiv1alpha1.QuotaLimits{TotalApps: tools.PtrTo[int64](3)},
+						Spaces: []string{spaceGUID},
+					},
+				}}
+			})
+
+			It("succeeds while the limit is not exceeded", func() {
+				Expect(checkErr).NotTo(HaveOccurred())
+			})
+
+			When("the request exceeds the limit", func() {
+				BeforeEach(func() {
+					request.Increase.Apps = 2
+				})
+
+				It("returns an exceeded error", func() {
+					Expect(checkErr).To(Equal(quotas.ExceededError{Scope: quotas.SpaceScope, Limit: "app"}))
+					Expect(checkErr).To(MatchError("You have exceeded the app limit for your space's quota."))
+				})
+			})
+		})
+
+		When("an org quota applies", func() {
+			BeforeEach(func() {
+				orgQuotas = []korifiv1alpha1.CFOrgQuota{{
+					ObjectMeta: meta(rootNamespace, "org-quota"),
+					Spec: korifiv1alpha1.CFOrgQuotaSpec{
+						Limits: korifiv1alpha1.QuotaLimits{
+							TotalMemoryInMB:      tools.PtrTo[int64](1024),
+							PerProcessMemoryInMB: tools.PtrTo[int64](512),
+							PerAppTasks:          tools.PtrTo[int64](1),
+						},
+						Orgs: []string{orgGUID},
+					},
+				}}
+			})
+
+			It("succeeds while the limits are not exceeded", func() {
+				Expect(checkErr).NotTo(HaveOccurred())
+			})
+
+			When("the process memory exceeds the per process limit", func() {
+				BeforeEach(func() {
+					request = quotas.Request{ProcessMemoryInMB: 1024}
+				})
+
+				It("returns an exceeded error", func() {
+					Expect(checkErr).To(Equal(quotas.ExceededError{Scope: quotas.OrgScope, Limit: "instance memory"}))
+				})
+			})
+
+			When("the app already runs as many tasks as allowed", func() {
+				BeforeEach(func() {
+					request = quotas.Request{TaskAppGUID: "started-app"}
+				})
+
+				It("returns an exceeded error", func() {
+					Expect(checkErr).To(Equal(quotas.ExceededError{Scope: quotas.OrgScope, Limit: "app task"}))
+				})
+			})
+
+			When("the memory increase exceeds the total memory of the org", func() {
+				BeforeEach(func() {
+					request = quotas.Request{Increase: quotas.Usage{MemoryInMB: 1024 - (2*256 + 100 + 128) + 1}}
+				})
+
+				It("returns an exceeded error", func() {
+					Expect(checkErr).To(Equal(quotas.ExceededError{Scope: quotas.OrgScope, Limit: "memory"}))
+				})
+			})
+
+			When("starting an app would exceed the total memory of the org", func() {
+				BeforeEach(func() {
+					request = quotas.Request{StartedAppGUID: "stopped-app"}
+				})
+
+				It("returns an exceeded error", func() {
+					Expect(checkErr).To(Equal(quotas.ExceededError{Scope: quotas.OrgScope, Limit: "memory"}))
+				})
+			})
+
+			When("the request only decreases usage", func() {
+				BeforeEach(func() {
+					orgQuotas[0].Spec.Limits.TotalMemoryInMB = tools.PtrTo[int64](0)
+					request = quotas.Request{Increase: quotas.Usage{MemoryInMB: -256}}
+				})
+
+				It("succeeds", func() {
+					Expect(checkErr).NotTo(HaveOccurred())
+				})
+			})
+		})
+
+		When("listing fails", func() {
+			BeforeEach(func() {
+				listErr = errors.New("list-err")
+			})
+
+			It("returns the error", func() {
+				Expect(checkErr).To(MatchError(ContainSubstring("list-err")))
+			})
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package quotas_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestQuotas(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Quotas Suite")
+}
@@ -0,0 +1,120 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/controllers/quotas"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	"github.com/go-logr/logr"
+)
+
+type QuotaValidator struct {
+	ValidateRequestStub        func(context.Context, logr.Logger, string, quotas.Request) error
+	validateRequestMutex       sync.RWMutex
+	validateRequestArgsForCall []struct {
+		arg1 context.Context
+		arg2 logr.Logger
+		arg3 string
+		arg4 quotas.Request
+	}
+	validateRequestReturns struct {
+		result1 error
+	}
+	validateRequestReturnsOnCall map[int]struct {
+		result1 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *QuotaValidator) ValidateRequest(arg1 context.Context, arg2 logr.Logger, arg3 string, arg4 quotas.Request) error {
+	fake.validateRequestMutex.Lock()
+	ret, specificReturn := fake.validateRequestReturnsOnCall[len(fake.validateRequestArgsForCall)]
+	fake.validateRequestArgsForCall = append(fake.validateRequestArgsForCall, struct {
+		arg1 context.Context
+		arg2 logr.Logger
+		arg3 string
+		arg4 quotas.Request
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ValidateRequestStub
+	fakeReturns := fake.validateRequestReturns
+	fake.recordInvocation("ValidateRequest", []interface{}{arg1, arg2, arg3, arg4})
+	fake.validateRequestMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	return fakeReturns.result1
+}
+
+func (fake *QuotaValidator) ValidateRequestCallCount() int {
+	fake.validateRequestMutex.RLock()
+	defer fake.validateRequestMutex.RUnlock()
+	return len(fake.validateRequestArgsForCall)
+}
+
+func (fake *QuotaValidator) ValidateRequestCalls(stub func(context.Context, logr.Logger, string, quotas.Request) error) {
+	fake.validateRequestMutex.Lock()
+	defer fake.validateRequestMutex.Unlock()
+	fake.ValidateRequestStub = stub
+}
+
+func (fake *QuotaValidator) ValidateRequestArgsForCall(i int) (context.Context, logr.Logger, string, quotas.Request) {
+	fake.validateRequestMutex.RLock()
+	defer fake.validateRequestMutex.RUnlock()
+	argsForCall := fake.validateRequestArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *QuotaValidator) ValidateRequestReturns(result1 error) {
+	fake.validateRequestMutex.Lock()
+	defer fake.validateRequestMutex.Unlock()
+	fake.ValidateRequestStub = nil
+	fake.validateRequestReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *QuotaValidator) ValidateRequestReturnsOnCall(i int, result1 error) {
+	fake.validateRequestMutex.Lock()
+	defer fake.validateRequestMutex.Unlock()
+	fake.ValidateRequestStub = nil
+	if fake.validateRequestReturnsOnCall == nil {
+		fake.validateRequestReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.validateRequestReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *QuotaValidator) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.validateRequestMutex.RLock()
+	defer fake.validateRequestMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *QuotaValidator) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ webhooks.QuotaValidator = new(QuotaValidator)
@@ -117,6 +117,65 @@ var _ = Describe("Controllers Finalizers Webhook", func() {
 			},
 			korifiv1alpha1.CFDomainFinalizerName,
 		),
+		Entry("managed cfserviceinstance",
+			&korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test-org-" + uuid.NewString(),
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					DisplayName: "managed-instance",
+					Type:        korifiv1alpha1.ManagedType,
+					PlanGUID:    "plan-guid",
+				},
+			},
+			korifiv1alpha1.CFServiceInstanceFinalizerName,
+		),
+		Entry("user-provided cfserviceinstance",
+			&korifiv1alpha1.CFServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test-org-" + uuid.NewString(),
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFServiceInstanceSpec{
+					DisplayName: "user-provided-instance",
+					Type:        korifiv1alpha1.UserProvidedType,
+					SecretName:  "secret-name",
+				},
+			},
+			korifiv1alpha1.CFServiceInstanceFinalizerName,
+		),
+		Entry("cfservicebinding",
+			&korifiv1alpha1.CFServiceBinding{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "test-org-" + uuid.NewString(),
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFServiceBindingSpec{
+					Service: corev1.ObjectReference{
+						Kind:       "CFServiceInstance",
+						APIVersion: korifiv1alpha1.GroupVersion.Identifier(),
+						Name:       "service-instance-guid",
+					},
+					AppRef: corev1.LocalObjectReference{
+						Name: "app-guid",
+					},
+				},
+			},
+			korifiv1alpha1.CFServiceBindingFinalizerName,
+		),
+		Entry("cfsecuritygroup",
+			&korifiv1alpha1.CFSecurityGroup{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: rootNamespace,
+					Name:      uuid.NewString(),
+				},
+				Spec: korifiv1alpha1.CFSecurityGroupSpec{
+					DisplayName: "my-security-group",
+				},
+			},
+			korifiv1alpha1.CFSecurityGroupFinalizerName,
+		),
 		Entry("builderinfo (no finalizer is added)",
 			&korifiv1alpha1.BuilderInfo{
 				ObjectMeta: metav1.ObjectMeta{
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/quotas"
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
 	"github.com/hashicorp/go-multierror"
 
@@ -48,6 +49,7 @@ var logger = logf.Log.WithName("route-validation")
 
 type CFRouteValidator struct {
 	duplicateValidator webhooks.NameValidator
+	quotaValidator     webhooks.QuotaValidator
 	rootNamespace      string
 	client             client.Client
 }
@@ -56,11 +58,13 @@ var _ webhook.CustomValidator = &CFRouteValidator{}
 
 func NewCFRouteValidator(
 	nameValidator webhooks.NameValidator,
+	quotaValidator webhooks.QuotaValidator,
 	rootNamespace string,
 	client client.Client,
 ) *CFRouteValidator {
 	return &CFRouteValidator{
 		duplicateValidator: nameValidator,
+		quotaValidator:     quotaValidator,
 		rootNamespace:      rootNamespace,
 		client:             client,
 	}
@@ -86,6 +90,10 @@ func (v *CFRouteValidator) ValidateCreate(ctx context.Context, obj runtime.Objec
 
 	route.Status.FQDN = cfDomain.Spec.Name
 
+	if err = v.quotaValidator.ValidateRequest(ctx, logger, route.Namespace, quotas.Request{Increase: quotas.Usage{Routes: 1}}); err != nil {
+		return nil, err
+	}
+
 	return nil, v.duplicateValidator.ValidateCreate(ctx, logger, v.rootNamespace, route)
 }
 
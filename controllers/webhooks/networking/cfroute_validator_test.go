@@ -9,6 +9,7 @@ import (
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
 	controllerfake "code.cloudfoundry.org/korifi/controllers/fake"
+	"code.cloudfoundry.org/korifi/controllers/quotas"
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/networking"
@@ -29,6 +30,7 @@ var _ = Describe("CFRouteValidator", func() {
 	var (
 		ctx                context.Context
 		duplicateValidator *fake.NameValidator
+		quotaValidator     *fake.QuotaValidator
 		fakeClient         *controllerfake.Client
 		cfRoute            *korifiv1alpha1.CFRoute
 		cfDomain           *korifiv1alpha1.CFDomain
@@ -83,6 +85,7 @@ var _ = Describe("CFRouteValidator", func() {
 		cfApp = &korifiv1alpha1.CFApp{}
 
 		duplicateValidator = new(fake.NameValidator)
+		quotaValidator = new(fake.QuotaValidator)
 		fakeClient = new(controllerfake.Client)
 
 		fakeClient.GetStub = func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
@@ -98,7 +101,7 @@ var _ = Describe("CFRouteValidator", func() {
 			}
 		}
 
-		validatingWebhook = networking.NewCFRouteValidator(duplicateValidator, rootNamespace, fakeClient)
+		validatingWebhook = networking.NewCFRouteValidator(duplicateValidator, quotaValidator, rootNamespace, fakeClient)
 	})
 
 	Describe("ValidateCreate", func() {
@@ -152,6 +155,24 @@ var _ = Describe("CFRouteValidator", func() {
 			})
 		})
 
+		It("checks the route quota of the route's space", func() {
+			Expect(quotaValidator.ValidateRequestCallCount()).To(Equal(1))
+			_, _, actualSpaceGUID, actualRequest := quotaValidator.ValidateRequestArgsForCall(0)
+			Expect(actualSpaceGUID).To(Equal(testRouteNamespace))
+			Expect(actualRequest).To(Equal(quotas.Request{Increase: quotas.Usage{Routes: 1}}))
+		})
+
+		When("the route quota is exceeded", func() {
+			BeforeEach(func() {
+				quotaValidator.ValidateRequestReturns(errors.New("quota-exceeded"))
+			})
+
+			It("denies the request without registering the name", func() {
+				Expect(retErr).To(MatchError("quota-exceeded"))
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(BeZero())
+			})
+		})
+
 		When("the FQDN is too long", func() {
 			BeforeEach(func() {
 				cfRoute.Spec.Host = "a-very-looooooooooooong-invalid-host-name-that-should-fail-validation"
@@ -0,0 +1,79 @@
+package networking
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	SecurityGroupEntityType = "securitygroup"
+)
+
+var cfsecuritygrouplog = logf.Log.WithName("cfsecuritygroup-validate")
+
+//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfsecuritygroup,mutating=false,failurePolicy=fail,sideEffects=NoneOnDryRun,groups=korifi.cloudfoundry.org,resources=cfsecuritygroups,verbs=create;update;delete,versions=v1alpha1,name=vcfsecuritygroup.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+
+type CFSecurityGroupValidator struct {
+	duplicateValidator webhooks.NameValidator
+}
+
+var _ webhook.CustomValidator = &CFSecurityGroupValidator{}
+
+func NewCFSecurityGroupValidator(duplicateValidator webhooks.NameValidator) *CFSecurityGroupValidator {
+	return &CFSecurityGroupValidator{
+		duplicateValidator: duplicateValidator,
+	}
+}
+
+func (v *CFSecurityGroupValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&korifiv1alpha1.CFSecurityGroup{}).
+		WithValidator(v).
+		Complete()
+}
+
+func (v *CFSecurityGroupValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	securityGroup, ok := obj.(*korifiv1alpha1.CFSecurityGroup)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFSecurityGroup but got a %T", obj))
+	}
+
+	return nil, v.duplicateValidator.ValidateCreate(ctx, cfsecuritygrouplog, securityGroup.Namespace, securityGroup)
+}
+
+func (v *CFSecurityGroupValidator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Object) (admission.Warnings, error) {
+	securityGroup, ok := obj.(*korifiv1alpha1.CFSecurityGroup)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFSecurityGroup but got a %T", obj))
+	}
+
+	if !securityGroup.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	oldSecurityGroup, ok := oldObj.(*korifiv1alpha1.CFSecurityGroup)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFSecurityGroup but got a %T", oldObj))
+	}
+
+	return nil, v.duplicateValidator.ValidateUpdate(ctx, cfsecuritygrouplog, securityGroup.Namespace, oldSecurityGroup, securityGroup)
+}
+
+func (v *CFSecurityGroupValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	securityGroup, ok := obj.(*korifiv1alpha1.CFSecurityGroup)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFSecurityGroup but got a %T", obj))
+	}
+
+	return nil, v.duplicateValidator.ValidateDelete(ctx, cfsecuritygrouplog, securityGroup.Namespace, securityGroup)
+}
@@ -0,0 +1,44 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/korifi/controllers/quotas"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const QuotaExceededErrorType = "QuotaExceededError"
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cforgquotas;cfspacequotas,verbs=get;list;watch
+
+type QuotaLimitValidator struct {
+	calculator quotas.Calculator
+}
+
+func NewQuotaLimitValidator(client client.Client, rootNamespace string) *QuotaLimitValidator {
+	return &QuotaLimitValidator{calculator: quotas.NewCalculator(client, rootNamespace)}
+}
+
+func (v QuotaLimitValidator) ValidateRequest(ctx context.Context, logger logr.Logger, spaceGUID string, request quotas.Request) error {
+	err := v.calculator.Check(ctx, spaceGUID, request)
+	if err == nil {
+		return nil
+	}
+
+	var exceededErr quotas.ExceededError
+	if errors.As(err, &exceededErr) {
+		return ValidationError{
+			Type:    QuotaExceededErrorType,
+			Message: exceededErr.Error(),
+		}.ExportJSONError()
+	}
+
+	logger.WithName("quotaLimitValidator.ValidateRequest").Info("failed to check quotas", "space", spaceGUID, "reason", err)
+	return unknownError()
+}
+
+// check interface is implemented correctly
+var _ QuotaValidator = QuotaLimitValidator{}
@@ -0,0 +1,94 @@
+package webhooks_test
+
+import (
+	"context"
+	"errors"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/fake"
+	"code.cloudfoundry.org/korifi/controllers/quotas"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var _ = Describe("QuotaLimitValidator", func() {
+	var (
+		fakeClient     *fake.Client
+		quotaValidator *webhooks.QuotaLimitValidator
+		request        quotas.Request
+		listErr        error
+		validationErr  error
+	)
+
+	BeforeEach(func() {
+		listErr = nil
+		request = quotas.Request{Increase: quotas.Usage{Routes: 1}}
+
+		fakeClient = new(fake.Client)
+		fakeClient.ListStub = func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			if listErr != nil {
+				return listErr
+			}
+
+			switch list := list.(type) {
+			case *korifiv1alpha1.CFSpaceList:
+				list.Items = []korifiv1alpha1.CFSpace{{ObjectMeta: metav1.ObjectMeta{Namespace: "org-guid", Name: "space-guid"}}}
+			case *korifiv1alpha1.CFSpaceQuotaList:
+				list.Items = []korifiv1alpha1.CFSpaceQuota{{
+					ObjectMeta: metav1.ObjectMeta{Namespace: "org-guid", Name: "space-quota"},
+					Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+						Limits: korifiv1alpha1.QuotaLimits{TotalRoutes: tools.PtrTo[int64](1)},
+						Spaces: []string{"space-guid"},
+					},
+				}}
+			case *korifiv1alpha1.CFRouteList:
+				list.Items = []korifiv1alpha1.CFRoute{{ObjectMeta: metav1.ObjectMeta{Namespace: "space-guid", Name: "route"}}}
+			}
+
+			return nil
+		}
+
+		quotaValidator = webhooks.NewQuotaLimitValidator(fakeClient, "cf")
+	})
+
+	JustBeforeEach(func() {
+		validationErr = quotaValidator.ValidateRequest(context.Background(), logf.Log, "space-guid", request)
+	})
+
+	It("returns a quota exceeded validation error when the quota is exceeded", func() {
+		Expect(validationErr).To(matchers.BeValidationError(
+			webhooks.QuotaExceededErrorType,
+			Equal("You have exceeded the route limit for your space's quota."),
+		))
+	})
+
+	When("the quota is not exceeded", func() {
+		BeforeEach(func() {
+			request = quotas.Request{Increase: quotas.Usage{Apps: 1}}
+		})
+
+		It("succeeds", func() {
+			Expect(validationErr).NotTo(HaveOccurred())
+		})
+	})
+
+	When("checking the quotas fails", func() {
+		BeforeEach(func() {
+			listErr = errors.New("list-err")
+		})
+
+		It("returns an unknown error", func() {
+			Expect(validationErr).To(matchers.BeValidationError(
+				webhooks.UnknownErrorType,
+				Equal(webhooks.UnknownErrorMessage),
+			))
+		})
+	})
+})
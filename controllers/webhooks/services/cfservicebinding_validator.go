@@ -9,7 +9,9 @@ import (
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -34,13 +36,15 @@ func (v *CFServiceBindingValidator) SetupWebhookWithManager(mgr ctrl.Manager) er
 
 type CFServiceBindingValidator struct {
 	duplicateValidator webhooks.NameValidator
+	client             client.Client
 }
 
 var _ webhook.CustomValidator = &CFServiceBindingValidator{}
 
-func NewCFServiceBindingValidator(duplicateValidator webhooks.NameValidator) *CFServiceBindingValidator {
+func NewCFServiceBindingValidator(duplicateValidator webhooks.NameValidator, client client.Client) *CFServiceBindingValidator {
 	return &CFServiceBindingValidator{
 		duplicateValidator: duplicateValidator,
+		client:             client,
 	}
 }
 
@@ -50,6 +54,14 @@ func (v *CFServiceBindingValidator) ValidateCreate(ctx context.Context, obj runt
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFServiceBinding but got a %T", obj))
 	}
 
+	if err := validateBindingType(serviceBinding); err != nil {
+		return nil, err
+	}
+
+	if err := v.validateServiceInstanceShare(ctx, serviceBinding); err != nil {
+		return nil, err
+	}
+
 	return nil, v.duplicateValidator.ValidateCreate(ctx, cfservicebindinglog, serviceBinding.Namespace, serviceBinding)
 }
 
@@ -68,6 +80,10 @@ func (v *CFServiceBindingValidator) ValidateUpdate(ctx context.Context, oldObj,
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFServiceBinding but got a %T", oldObj))
 	}
 
+	if oldServiceBinding.Spec.Type != serviceBinding.Spec.Type {
+		return nil, webhooks.ValidationError{Type: ServiceBindingErrorType, Message: "Type is immutable"}
+	}
+
 	if oldServiceBinding.Spec.AppRef.Name != serviceBinding.Spec.AppRef.Name {
 		return nil, webhooks.ValidationError{Type: ServiceBindingErrorType, Message: "AppRef.Name is immutable"}
 	}
@@ -80,7 +96,11 @@ func (v *CFServiceBindingValidator) ValidateUpdate(ctx context.Context, oldObj,
 		return nil, webhooks.ValidationError{Type: ServiceBindingErrorType, Message: "Service.Namespace is immutable"}
 	}
 
-	return nil, nil
+	if err := validateBindingType(serviceBinding); err != nil {
+		return nil, err
+	}
+
+	return nil, v.duplicateValidator.ValidateUpdate(ctx, cfservicebindinglog, serviceBinding.Namespace, oldServiceBinding, serviceBinding)
 }
 
 func (v *CFServiceBindingValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
@@ -91,3 +111,57 @@ func (v *CFServiceBindingValidator) ValidateDelete(ctx context.Context, obj runt
 
 	return nil, v.duplicateValidator.ValidateDelete(ctx, cfservicebindinglog, serviceBinding.Namespace, serviceBinding)
 }
+
+// validateBindingType checks that app bindings reference an app and that
+// service keys have a name and do not reference an app
+func validateBindingType(serviceBinding *korifiv1alpha1.CFServiceBinding) error {
+	if !serviceBinding.IsKey() {
+		if serviceBinding.Spec.AppRef.Name == "" {
+			return webhooks.ValidationError{Type: ServiceBindingErrorType, Message: "AppRef.Name is required for app bindings"}.ExportJSONError()
+		}
+		return nil
+	}
+
+	if serviceBinding.Spec.AppRef.Name != "" {
+		return webhooks.ValidationError{Type: ServiceBindingErrorType, Message: "AppRef must not be set for service keys"}.ExportJSONError()
+	}
+
+	if serviceBinding.Spec.DisplayName == nil || *serviceBinding.Spec.DisplayName == "" {
+		return webhooks.ValidationError{Type: ServiceBindingErrorType, Message: "DisplayName is required for service keys"}.ExportJSONError()
+	}
+
+	return nil
+}
+
+// validateServiceInstanceShare checks that bindings to service instances in
+// other namespaces only reference instances shared with the binding namespace
+func (v *CFServiceBindingValidator) validateServiceInstanceShare(ctx context.Context, serviceBinding *korifiv1alpha1.CFServiceBinding) error {
+	if serviceBinding.ServiceNamespace() == serviceBinding.Namespace {
+		return nil
+	}
+
+	serviceInstance := new(korifiv1alpha1.CFServiceInstance)
+	err := v.client.Get(ctx, types.NamespacedName{Namespace: serviceBinding.ServiceNamespace(), Name: serviceBinding.Spec.Service.Name}, serviceInstance)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return webhooks.ValidationError{
+				Type:    ServiceBindingErrorType,
+				Message: fmt.Sprintf("Service instance %s/%s does not exist", serviceBinding.ServiceNamespace(), serviceBinding.Spec.Service.Name),
+			}.ExportJSONError()
+		}
+
+		cfservicebindinglog.Info("error while retrieving CFServiceInstance", "reason", err)
+		return webhooks.ValidationError{Type: webhooks.UnknownErrorType, Message: webhooks.UnknownErrorMessage}.ExportJSONError()
+	}
+
+	for _, sharedSpace := range serviceInstance.Spec.SharedSpaces {
+		if sharedSpace == serviceBinding.Namespace {
+			return nil
+		}
+	}
+
+	return webhooks.ValidationError{
+		Type:    ServiceBindingErrorType,
+		Message: fmt.Sprintf("Service instance %s is not shared with namespace %s", serviceBinding.Spec.Service.Name, serviceBinding.Namespace),
+	}.ExportJSONError()
+}
@@ -6,14 +6,22 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	controllerfake "code.cloudfoundry.org/korifi/controllers/fake"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/services"
+	"code.cloudfoundry.org/korifi/tests/matchers"
+	"code.cloudfoundry.org/korifi/tools"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	v1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 var _ = Describe("CFServiceBindingValidatingWebhook", func() {
@@ -27,6 +35,9 @@ var _ = Describe("CFServiceBindingValidatingWebhook", func() {
 		serviceBindingGUID  string
 		ctx                 context.Context
 		duplicateValidator  *fake.NameValidator
+		fakeClient          *controllerfake.Client
+		serviceInstance     *korifiv1alpha1.CFServiceInstance
+		getInstanceErr      error
 		serviceBinding      *korifiv1alpha1.CFServiceBinding
 		validatingWebhook   *services.CFServiceBindingValidator
 		retErr              error
@@ -58,8 +69,21 @@ var _ = Describe("CFServiceBindingValidatingWebhook", func() {
 			},
 		}
 
+		serviceInstance = &korifiv1alpha1.CFServiceInstance{}
+		getInstanceErr = nil
+		fakeClient = new(controllerfake.Client)
+		fakeClient.GetStub = func(_ context.Context, _ types.NamespacedName, obj client.Object, _ ...client.GetOption) error {
+			switch obj := obj.(type) {
+			case *korifiv1alpha1.CFServiceInstance:
+				serviceInstance.DeepCopyInto(obj)
+				return getInstanceErr
+			default:
+				panic("TestClient Get provided an unexpected object type")
+			}
+		}
+
 		duplicateValidator = new(fake.NameValidator)
-		validatingWebhook = services.NewCFServiceBindingValidator(duplicateValidator)
+		validatingWebhook = services.NewCFServiceBindingValidator(duplicateValidator, fakeClient)
 	})
 
 	Describe("ValidateCreate", func() {
@@ -88,6 +112,103 @@ var _ = Describe("CFServiceBindingValidatingWebhook", func() {
 				Expect(retErr).To(MatchError("foo"))
 			})
 		})
+
+		It("does not look up the service instance", func() {
+			Expect(fakeClient.GetCallCount()).To(BeZero())
+		})
+
+		When("the service instance is in another namespace", func() {
+			BeforeEach(func() {
+				serviceBinding.Spec.Service.Namespace = "instance-namespace"
+				serviceInstance.Spec.SharedSpaces = []string{"some-namespace", defaultNamespace}
+			})
+
+			It("allows the binding of service instances shared with the binding namespace", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+
+				Expect(fakeClient.GetCallCount()).To(Equal(1))
+				_, actualName, _, _ := fakeClient.GetArgsForCall(0)
+				Expect(actualName).To(Equal(types.NamespacedName{Namespace: "instance-namespace", Name: serviceInstanceGUID}))
+			})
+
+			When("the service instance is not shared with the binding namespace", func() {
+				BeforeEach(func() {
+					serviceInstance.Spec.SharedSpaces = []string{"some-namespace"}
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(services.ServiceBindingErrorType, ContainSubstring("is not shared with namespace "+defaultNamespace)))
+					Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(0))
+				})
+			})
+
+			When("the service instance does not exist", func() {
+				BeforeEach(func() {
+					getInstanceErr = k8serrors.NewNotFound(schema.GroupResource{}, serviceInstanceGUID)
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(services.ServiceBindingErrorType, ContainSubstring("does not exist")))
+				})
+			})
+
+			When("getting the service instance fails", func() {
+				BeforeEach(func() {
+					getInstanceErr = errors.New("boom")
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(webhooks.UnknownErrorType, Equal(webhooks.UnknownErrorMessage)))
+				})
+			})
+		})
+
+		When("the app reference is missing", func() {
+			BeforeEach(func() {
+				serviceBinding.Spec.AppRef = v1.LocalObjectReference{}
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(matchers.BeValidationError(services.ServiceBindingErrorType, ContainSubstring("AppRef.Name is required for app bindings")))
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(0))
+			})
+		})
+
+		When("the service binding is a service key", func() {
+			BeforeEach(func() {
+				serviceBinding.Spec.Type = korifiv1alpha1.KeyBindingType
+				serviceBinding.Spec.DisplayName = tools.PtrTo("my-key")
+				serviceBinding.Spec.AppRef = v1.LocalObjectReference{}
+			})
+
+			It("locks the name of the key within the service instance", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+				Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(1))
+				_, _, _, actualResource := duplicateValidator.ValidateCreateArgsForCall(0)
+				Expect(actualResource.UniqueName()).To(Equal("sk::" + defaultNamespace + "::" + serviceInstanceGUID + "::my-key"))
+				Expect(actualResource.UniqueValidationErrorMessage()).To(Equal("Service key already exists: Name: my-key Service Instance: " + serviceInstanceGUID))
+			})
+
+			When("it references an app", func() {
+				BeforeEach(func() {
+					serviceBinding.Spec.AppRef = v1.LocalObjectReference{Name: appGUID}
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(services.ServiceBindingErrorType, ContainSubstring("AppRef must not be set for service keys")))
+				})
+			})
+
+			When("it has no name", func() {
+				BeforeEach(func() {
+					serviceBinding.Spec.DisplayName = nil
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(matchers.BeValidationError(services.ServiceBindingErrorType, ContainSubstring("DisplayName is required for service keys")))
+				})
+			})
+		})
 	})
 
 	Describe("ValidateUpdate", func() {
@@ -127,6 +248,35 @@ var _ = Describe("CFServiceBindingValidatingWebhook", func() {
 			})
 		})
 
+		When("the type changes", func() {
+			BeforeEach(func() {
+				updatedServiceBinding.Spec.Type = korifiv1alpha1.KeyBindingType
+			})
+
+			It("does not allow the change", func() {
+				Expect(retErr).To(MatchError(ContainSubstring("Type is immutable")))
+			})
+		})
+
+		When("a service key is renamed", func() {
+			BeforeEach(func() {
+				serviceBinding.Spec.Type = korifiv1alpha1.KeyBindingType
+				serviceBinding.Spec.DisplayName = tools.PtrTo("my-key")
+				serviceBinding.Spec.AppRef = v1.LocalObjectReference{}
+				updatedServiceBinding = serviceBinding.DeepCopy()
+				updatedServiceBinding.Spec.DisplayName = tools.PtrTo("my-other-key")
+			})
+
+			It("updates the lock for the key name", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+				Expect(duplicateValidator.ValidateUpdateCallCount()).To(Equal(1))
+				_, _, actualNamespace, actualOldResource, actualResource := duplicateValidator.ValidateUpdateArgsForCall(0)
+				Expect(actualNamespace).To(Equal(defaultNamespace))
+				Expect(actualOldResource).To(Equal(serviceBinding))
+				Expect(actualResource).To(Equal(updatedServiceBinding))
+			})
+		})
+
 		When("the Service Instance name changes", func() {
 			BeforeEach(func() {
 				updatedServiceBinding.Spec.Service.Name = "updated-service-instance"
@@ -0,0 +1,159 @@
+package services_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
+	"code.cloudfoundry.org/korifi/controllers/webhooks/services"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var _ = Describe("CFServiceBrokerValidatingWebhook", func() {
+	const (
+		rootNamespace = "cf"
+	)
+
+	var (
+		serviceBrokerGUID  string
+		serviceBrokerName  string
+		ctx                context.Context
+		duplicateValidator *fake.NameValidator
+		serviceBroker      *korifiv1alpha1.CFServiceBroker
+		validatingWebhook  *services.CFServiceBrokerValidator
+		retErr             error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		scheme := runtime.NewScheme()
+		err := korifiv1alpha1.AddToScheme(scheme)
+		Expect(err).NotTo(HaveOccurred())
+
+		serviceBrokerName = generateGUID("service-broker")
+		serviceBrokerGUID = generateGUID("service-broker")
+		serviceBroker = &korifiv1alpha1.CFServiceBroker{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      serviceBrokerGUID,
+				Namespace: rootNamespace,
+			},
+			Spec: korifiv1alpha1.CFServiceBrokerSpec{
+				Name: serviceBrokerName,
+				URL:  "https://my.broker",
+			},
+		}
+
+		duplicateValidator = new(fake.NameValidator)
+		validatingWebhook = services.NewCFServiceBrokerValidator(duplicateValidator)
+	})
+
+	Describe("ValidateCreate", func() {
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateCreate(ctx, serviceBroker)
+		})
+
+		It("allows the request", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		It("invokes the validator correctly", func() {
+			Expect(duplicateValidator.ValidateCreateCallCount()).To(Equal(1))
+			actualContext, _, actualNamespace, actualResource := duplicateValidator.ValidateCreateArgsForCall(0)
+			Expect(actualContext).To(Equal(ctx))
+			Expect(actualNamespace).To(Equal(serviceBroker.Namespace))
+			Expect(actualResource).To(Equal(serviceBroker))
+			Expect(actualResource.UniqueValidationErrorMessage()).To(Equal("Name must be unique: " + serviceBroker.Spec.Name))
+		})
+
+		When("the serviceBroker name is a duplicate", func() {
+			BeforeEach(func() {
+				duplicateValidator.ValidateCreateReturns(errors.New("foo"))
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(MatchError("foo"))
+			})
+		})
+	})
+
+	Describe("ValidateUpdate", func() {
+		var updatedServiceBroker *korifiv1alpha1.CFServiceBroker
+
+		BeforeEach(func() {
+			updatedServiceBroker = serviceBroker.DeepCopy()
+			updatedServiceBroker.Spec.Name = "the-new-name"
+		})
+
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateUpdate(ctx, serviceBroker, updatedServiceBroker)
+		})
+
+		It("allows the request", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		It("invokes the validator correctly", func() {
+			Expect(duplicateValidator.ValidateUpdateCallCount()).To(Equal(1))
+			actualContext, _, actualNamespace, oldResource, newResource := duplicateValidator.ValidateUpdateArgsForCall(0)
+			Expect(actualContext).To(Equal(ctx))
+			Expect(actualNamespace).To(Equal(serviceBroker.Namespace))
+			Expect(oldResource).To(Equal(serviceBroker))
+			Expect(newResource).To(Equal(updatedServiceBroker))
+		})
+
+		When("the service broker is being deleted", func() {
+			BeforeEach(func() {
+				updatedServiceBroker.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+			})
+
+			It("does not return an error", func() {
+				Expect(retErr).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the new serviceBroker name is a duplicate", func() {
+			BeforeEach(func() {
+				duplicateValidator.ValidateUpdateReturns(errors.New("foo"))
+			})
+
+			It("denies the request", func() {
+				Expect(retErr).To(MatchError("foo"))
+			})
+		})
+	})
+
+	Describe("ValidateDelete", func() {
+		JustBeforeEach(func() {
+			_, retErr = validatingWebhook.ValidateDelete(ctx, serviceBroker)
+		})
+
+		It("allows the request", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+		})
+
+		It("invokes the validator correctly", func() {
+			Expect(duplicateValidator.ValidateDeleteCallCount()).To(Equal(1))
+			actualContext, _, actualNamespace, actualResource := duplicateValidator.ValidateDeleteArgsForCall(0)
+			Expect(actualContext).To(Equal(ctx))
+			Expect(actualNamespace).To(Equal(serviceBroker.Namespace))
+			Expect(actualResource).To(Equal(serviceBroker))
+		})
+
+		When("delete validation fails", func() {
+			BeforeEach(func() {
+				duplicateValidator.ValidateDeleteReturns(errors.New("foo"))
+			})
+
+			It("disallows the request", func() {
+				Expect(retErr).To(MatchError("foo"))
+			})
+		})
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/quotas"
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -25,13 +26,15 @@ var cfserviceinstancelog = logf.Log.WithName("cfserviceinstance-validate")
 
 type CFServiceInstanceValidator struct {
 	duplicateValidator webhooks.NameValidator
+	quotaValidator     webhooks.QuotaValidator
 }
 
 var _ webhook.CustomValidator = &CFServiceInstanceValidator{}
 
-func NewCFServiceInstanceValidator(duplicateValidator webhooks.NameValidator) *CFServiceInstanceValidator {
+func NewCFServiceInstanceValidator(duplicateValidator webhooks.NameValidator, quotaValidator webhooks.QuotaValidator) *CFServiceInstanceValidator {
 	return &CFServiceInstanceValidator{
 		duplicateValidator: duplicateValidator,
+		quotaValidator:     quotaValidator,
 	}
 }
 
@@ -48,6 +51,16 @@ func (v *CFServiceInstanceValidator) ValidateCreate(ctx context.Context, obj run
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFServiceInstance but got a %T", obj))
 	}
 
+	// user-provided service instances do not count towards the quota
+	if serviceInstance.Spec.Type == korifiv1alpha1.ManagedType {
+		err := v.quotaValidator.ValidateRequest(ctx, cfserviceinstancelog, serviceInstance.Namespace, quotas.Request{
+			Increase: quotas.Usage{ServiceInstances: 1},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return nil, v.duplicateValidator.ValidateCreate(ctx, cfserviceinstancelog, serviceInstance.Namespace, serviceInstance)
 }
 
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/quotas"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/fake"
 	"code.cloudfoundry.org/korifi/controllers/webhooks/services"
 
@@ -25,6 +26,7 @@ var _ = Describe("CFServiceInstanceValidatingWebhook", func() {
 		serviceInstanceName string
 		ctx                 context.Context
 		duplicateValidator  *fake.NameValidator
+		quotaValidator      *fake.QuotaValidator
 		serviceInstance     *korifiv1alpha1.CFServiceInstance
 		validatingWebhook   *services.CFServiceInstanceValidator
 		retErr              error
@@ -51,7 +53,8 @@ var _ = Describe("CFServiceInstanceValidatingWebhook", func() {
 		}
 
 		duplicateValidator = new(fake.NameValidator)
-		validatingWebhook = services.NewCFServiceInstanceValidator(duplicateValidator)
+		quotaValidator = new(fake.QuotaValidator)
+		validatingWebhook = services.NewCFServiceInstanceValidator(duplicateValidator, quotaValidator)
 	})
 
 	Describe("ValidateCreate", func() {
@@ -81,6 +84,34 @@ var _ = Describe("CFServiceInstanceValidatingWebhook", func() {
 				Expect(retErr).To(MatchError("foo"))
 			})
 		})
+
+		It("does not check the quota of user-provided service instances", func() {
+			Expect(quotaValidator.ValidateRequestCallCount()).To(BeZero())
+		})
+
+		When("the service instance is managed", func() {
+			BeforeEach(func() {
+				serviceInstance.Spec.Type = korifiv1alpha1.ManagedType
+			})
+
+			It("checks the service instance quota of the space", func() {
+				Expect(quotaValidator.ValidateRequestCallCount()).To(Equal(1))
+				_, _, actualSpaceGUID, actualRequest := quotaValidator.ValidateRequestArgsForCall(0)
+				Expect(actualSpaceGUID).To(Equal(defaultNamespace))
+				Expect(actualRequest).To(Equal(quotas.Request{Increase: quotas.Usage{ServiceInstances: 1}}))
+			})
+
+			When("the service instance quota is exceeded", func() {
+				BeforeEach(func() {
+					quotaValidator.ValidateRequestReturns(errors.New("quota-exceeded"))
+				})
+
+				It("denies the request", func() {
+					Expect(retErr).To(MatchError("quota-exceeded"))
+					Expect(duplicateValidator.ValidateCreateCallCount()).To(BeZero())
+				})
+			})
+		})
 	})
 
 	Describe("ValidateUpdate", func() {
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/quotas"
 	"github.com/go-logr/logr"
 )
 
@@ -22,3 +23,9 @@ type NamespaceValidator interface {
 	ValidateOrgCreate(org korifiv1alpha1.CFOrg) error
 	ValidateSpaceCreate(space korifiv1alpha1.CFSpace) error
 }
+
+//counterfeiter:generate -o fake -fake-name QuotaValidator . QuotaValidator
+
+type QuotaValidator interface {
+	ValidateRequest(ctx context.Context, logger logr.Logger, spaceGUID string, request quotas.Request) error
+}
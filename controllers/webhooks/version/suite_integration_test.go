@@ -7,6 +7,7 @@ import (
 	"time"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
 	"code.cloudfoundry.org/korifi/controllers/controllers/shared"
 	"code.cloudfoundry.org/korifi/controllers/coordination"
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
@@ -89,14 +90,23 @@ var _ = BeforeSuite(func() {
 	Expect(networking.NewCFDomainValidator(k8sManager.GetClient()).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect(services.NewCFServiceInstanceValidator(
 		webhooks.NewDuplicateValidator(coordination.NewNameRegistry(k8sManager.GetClient(), services.ServiceInstanceEntityType)),
+		webhooks.NewQuotaLimitValidator(k8sManager.GetClient(), rootNamespace),
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect(workloads.NewCFAppValidator(
 		webhooks.NewDuplicateValidator(coordination.NewNameRegistry(k8sManager.GetClient(), workloads.AppEntityType)),
+		webhooks.NewQuotaLimitValidator(k8sManager.GetClient(), rootNamespace),
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	Expect((&korifiv1alpha1.CFPackage{}).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
-	Expect(workloads.NewCFTaskValidator().SetupWebhookWithManager(k8sManager)).To(Succeed())
+	Expect(workloads.NewCFTaskValidator(
+		webhooks.NewQuotaLimitValidator(k8sManager.GetClient(), rootNamespace),
+		config.CFProcessDefaults{MemoryMB: defaultMemoryMB, DiskQuotaMB: defaultDiskQuotaMB},
+	).SetupWebhookWithManager(k8sManager)).To(Succeed())
+	Expect(workloads.NewCFProcessValidator(
+		webhooks.NewQuotaLimitValidator(k8sManager.GetClient(), rootNamespace),
+		k8sManager.GetClient(),
+	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	Expect(korifiv1alpha1.NewCFProcessDefaulter(defaultMemoryMB, defaultDiskQuotaMB, defaultTimeout).
 		SetupWebhookWithManager(k8sManager)).To(Succeed())
@@ -104,11 +114,13 @@ var _ = BeforeSuite(func() {
 	Expect((&korifiv1alpha1.CFRoute{}).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect(networking.NewCFRouteValidator(
 		webhooks.NewDuplicateValidator(coordination.NewNameRegistry(k8sManager.GetClient(), networking.RouteEntityType)),
+		webhooks.NewQuotaLimitValidator(k8sManager.GetClient(), rootNamespace),
 		rootNamespace,
 		k8sManager.GetClient(),
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	Expect(services.NewCFServiceBindingValidator(
 		webhooks.NewDuplicateValidator(coordination.NewNameRegistry(k8sManager.GetClient(), services.ServiceBindingEntityType)),
+		k8sManager.GetClient(),
 	).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	finalizer.NewControllersFinalizerWebhook().SetupWebhookWithManager(k8sManager)
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/quotas"
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -26,13 +27,15 @@ var cfapplog = logf.Log.WithName("cfapp-validate")
 
 type CFAppValidator struct {
 	duplicateValidator webhooks.NameValidator
+	quotaValidator     webhooks.QuotaValidator
 }
 
 var _ webhook.CustomValidator = &CFAppValidator{}
 
-func NewCFAppValidator(duplicateValidator webhooks.NameValidator) *CFAppValidator {
+func NewCFAppValidator(duplicateValidator webhooks.NameValidator, quotaValidator webhooks.QuotaValidator) *CFAppValidator {
 	return &CFAppValidator{
 		duplicateValidator: duplicateValidator,
+		quotaValidator:     quotaValidator,
 	}
 }
 
@@ -49,6 +52,14 @@ func (v *CFAppValidator) ValidateCreate(ctx context.Context, obj runtime.Object)
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFApp but got a %T", obj))
 	}
 
+	request := quotas.Request{Increase: quotas.Usage{Apps: 1}}
+	if app.Spec.DesiredState == korifiv1alpha1.StartedState {
+		request.StartedAppGUID = app.Name
+	}
+	if err := v.quotaValidator.ValidateRequest(ctx, cfapplog, app.Namespace, request); err != nil {
+		return nil, err
+	}
+
 	return nil, v.duplicateValidator.ValidateCreate(ctx, cfapplog, app.Namespace, app)
 }
 
@@ -67,6 +78,13 @@ func (v *CFAppValidator) ValidateUpdate(ctx context.Context, oldObj, obj runtime
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFApp but got a %T", oldObj))
 	}
 
+	if oldApp.Spec.DesiredState != korifiv1alpha1.StartedState && app.Spec.DesiredState == korifiv1alpha1.StartedState {
+		err := v.quotaValidator.ValidateRequest(ctx, cfapplog, app.Namespace, quotas.Request{StartedAppGUID: app.Name})
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return nil, v.duplicateValidator.ValidateUpdate(ctx, cfapplog, app.Namespace, oldApp, app)
 }
 
@@ -0,0 +1,55 @@
+package workloads_test
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("CFIsolationSegmentValidatingWebhook", func() {
+	var (
+		ctx         context.Context
+		segmentName string
+		createErr   error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		segmentName = "segment-" + uuid.NewString()
+	})
+
+	JustBeforeEach(func() {
+		createErr = adminClient.Create(ctx, makeCFIsolationSegment(segmentName))
+	})
+
+	It("succeeds", func() {
+		Expect(createErr).NotTo(HaveOccurred())
+	})
+
+	When("another isolation segment with the same name exists", func() {
+		BeforeEach(func() {
+			Expect(adminClient.Create(ctx, makeCFIsolationSegment(segmentName))).To(Succeed())
+		})
+
+		It("fails", func() {
+			Expect(createErr).To(MatchError(ContainSubstring("Isolation Segment names are case insensitive and must be unique: '%s'", segmentName)))
+		})
+	})
+})
+
+func makeCFIsolationSegment(name string) *korifiv1alpha1.CFIsolationSegment {
+	return &korifiv1alpha1.CFIsolationSegment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: rootNamespace,
+		},
+		Spec: korifiv1alpha1.CFIsolationSegmentSpec{
+			DisplayName: name,
+		},
+	}
+}
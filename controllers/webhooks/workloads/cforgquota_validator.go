@@ -0,0 +1,79 @@
+package workloads
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	OrgQuotaEntityType = "orgquota"
+)
+
+var cforgquotalog = logf.Log.WithName("cforgquota-validate")
+
+//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cforgquota,mutating=false,failurePolicy=fail,sideEffects=NoneOnDryRun,groups=korifi.cloudfoundry.org,resources=cforgquotas,verbs=create;update;delete,versions=v1alpha1,name=vcforgquota.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+
+type CFOrgQuotaValidator struct {
+	duplicateValidator webhooks.NameValidator
+}
+
+var _ webhook.CustomValidator = &CFOrgQuotaValidator{}
+
+func NewCFOrgQuotaValidator(duplicateValidator webhooks.NameValidator) *CFOrgQuotaValidator {
+	return &CFOrgQuotaValidator{
+		duplicateValidator: duplicateValidator,
+	}
+}
+
+func (v *CFOrgQuotaValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&korifiv1alpha1.CFOrgQuota{}).
+		WithValidator(v).
+		Complete()
+}
+
+func (v *CFOrgQuotaValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	orgQuota, ok := obj.(*korifiv1alpha1.CFOrgQuota)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFOrgQuota but got a %T", obj))
+	}
+
+	return nil, v.duplicateValidator.ValidateCreate(ctx, cforgquotalog, orgQuota.Namespace, orgQuota)
+}
+
+func (v *CFOrgQuotaValidator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Object) (admission.Warnings, error) {
+	orgQuota, ok := obj.(*korifiv1alpha1.CFOrgQuota)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFOrgQuota but got a %T", obj))
+	}
+
+	if !orgQuota.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	oldOrgQuota, ok := oldObj.(*korifiv1alpha1.CFOrgQuota)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFOrgQuota but got a %T", oldObj))
+	}
+
+	return nil, v.duplicateValidator.ValidateUpdate(ctx, cforgquotalog, orgQuota.Namespace, oldOrgQuota, orgQuota)
+}
+
+func (v *CFOrgQuotaValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	orgQuota, ok := obj.(*korifiv1alpha1.CFOrgQuota)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFOrgQuota but got a %T", obj))
+	}
+
+	return nil, v.duplicateValidator.ValidateDelete(ctx, cforgquotalog, orgQuota.Namespace, orgQuota)
+}
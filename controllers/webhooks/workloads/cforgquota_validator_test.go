@@ -0,0 +1,55 @@
+package workloads_test
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("CFOrgQuotaValidatingWebhook", func() {
+	var (
+		ctx       context.Context
+		quotaName string
+		createErr error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		quotaName = "quota-" + uuid.NewString()
+	})
+
+	JustBeforeEach(func() {
+		createErr = adminClient.Create(ctx, makeCFOrgQuota(quotaName))
+	})
+
+	It("succeeds", func() {
+		Expect(createErr).NotTo(HaveOccurred())
+	})
+
+	When("another org quota with the same name exists", func() {
+		BeforeEach(func() {
+			Expect(adminClient.Create(ctx, makeCFOrgQuota(quotaName))).To(Succeed())
+		})
+
+		It("fails", func() {
+			Expect(createErr).To(MatchError(ContainSubstring("Organization Quota '%s' already exists.", quotaName)))
+		})
+	})
+})
+
+func makeCFOrgQuota(name string) *korifiv1alpha1.CFOrgQuota {
+	return &korifiv1alpha1.CFOrgQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      uuid.NewString(),
+			Namespace: rootNamespace,
+		},
+		Spec: korifiv1alpha1.CFOrgQuotaSpec{
+			DisplayName: name,
+		},
+	}
+}
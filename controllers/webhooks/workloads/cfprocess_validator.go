@@ -0,0 +1,150 @@
+package workloads
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/quotas"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+var cfprocesslog = logf.Log.WithName("cfprocess-validate")
+
+//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfprocess,mutating=false,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cfprocesses,verbs=create;update,versions=v1alpha1,name=vcfprocess.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+
+type CFProcessValidator struct {
+	quotaValidator webhooks.QuotaValidator
+	client         client.Client
+}
+
+var _ webhook.CustomValidator = &CFProcessValidator{}
+
+func NewCFProcessValidator(quotaValidator webhooks.QuotaValidator, client client.Client) *CFProcessValidator {
+	return &CFProcessValidator{
+		quotaValidator: quotaValidator,
+		client:         client,
+	}
+}
+
+func (v *CFProcessValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&korifiv1alpha1.CFProcess{}).
+		WithValidator(v).
+		Complete()
+}
+
+func (v *CFProcessValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	process, ok := obj.(*korifiv1alpha1.CFProcess)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFProcess but got a %T", obj))
+	}
+
+	if err := v.validateSidecarsMemory(ctx, process); err != nil {
+		return nil, err
+	}
+
+	request := quotas.Request{ProcessMemoryInMB: process.Spec.MemoryMB}
+
+	appStarted, err := v.isAppStarted(ctx, process)
+	if err != nil {
+		return nil, err
+	}
+	if appStarted {
+		request.Increase = quotas.ProcessUsage(*process)
+	}
+
+	return nil, v.quotaValidator.ValidateRequest(ctx, cfprocesslog, process.Namespace, request)
+}
+
+func (v *CFProcessValidator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Object) (admission.Warnings, error) {
+	process, ok := obj.(*korifiv1alpha1.CFProcess)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFProcess but got a %T", obj))
+	}
+
+	if !process.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	oldProcess, ok := oldObj.(*korifiv1alpha1.CFProcess)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFProcess but got a %T", oldObj))
+	}
+
+	if process.Spec.MemoryMB < oldProcess.Spec.MemoryMB {
+		if err := v.validateSidecarsMemory(ctx, process); err != nil {
+			return nil, err
+		}
+	}
+
+	newUsage := quotas.ProcessUsage(*process)
+	oldUsage := quotas.ProcessUsage(*oldProcess)
+	if newUsage.MemoryInMB <= oldUsage.MemoryInMB && newUsage.Instances <= oldUsage.Instances && process.Spec.MemoryMB <= oldProcess.Spec.MemoryMB {
+		return nil, nil
+	}
+
+	request := quotas.Request{}
+	if process.Spec.MemoryMB > oldProcess.Spec.MemoryMB {
+		request.ProcessMemoryInMB = process.Spec.MemoryMB
+	}
+
+	appStarted, err := v.isAppStarted(ctx, process)
+	if err != nil {
+		return nil, err
+	}
+	if appStarted {
+		request.Increase = quotas.Usage{
+			MemoryInMB: newUsage.MemoryInMB - oldUsage.MemoryInMB,
+			Instances:  newUsage.Instances - oldUsage.Instances,
+		}
+	}
+
+	return nil, v.quotaValidator.ValidateRequest(ctx, cfprocesslog, process.Namespace, request)
+}
+
+func (v *CFProcessValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *CFProcessValidator) isAppStarted(ctx context.Context, process *korifiv1alpha1.CFProcess) (bool, error) {
+	app := &korifiv1alpha1.CFApp{}
+	err := v.client.Get(ctx, client.ObjectKey{Namespace: process.Namespace, Name: process.Spec.AppRef.Name}, app)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		cfprocesslog.Info("failed to get the app of the process", "namespace", process.Namespace, "name", process.Name, "reason", err)
+		return false, unknownError()
+	}
+
+	return app.Spec.DesiredState == korifiv1alpha1.StartedState, nil
+}
+
+// validateSidecarsMemory rejects processes that would leave no memory to
+// their app container once the sidecars of the app got theirs
+func (v *CFProcessValidator) validateSidecarsMemory(ctx context.Context, process *korifiv1alpha1.CFProcess) error {
+	sidecars, err := listAppSidecars(ctx, v.client, process.Namespace, process.Spec.AppRef.Name)
+	if err != nil {
+		cfprocesslog.Info("failed to list the sidecars of the app", "namespace", process.Namespace, "name", process.Name, "reason", err)
+		return unknownError()
+	}
+
+	memory := sidecarsMemoryMB(*process, sidecars)
+	if memory > 0 && memory >= process.Spec.MemoryMB {
+		return webhooks.ValidationError{
+			Type:    SidecarMemoryErrorType,
+			Message: "The requested memory allocation is not large enough to run all of your sidecar processes",
+		}.ExportJSONError()
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package workloads_test
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("CFProcessValidatingWebhook", func() {
+	var (
+		ctx          context.Context
+		orgNamespace string
+		cfSpace      *korifiv1alpha1.CFSpace
+		cfApp        *korifiv1alpha1.CFApp
+		cfProcess    *korifiv1alpha1.CFProcess
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		orgNamespace = "test-org-" + uuid.NewString()
+		Expect(adminClient.Create(ctx, &korifiv1alpha1.CFOrg{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      orgNamespace,
+				Namespace: rootNamespace,
+			},
+			Spec: korifiv1alpha1.CFOrgSpec{
+				DisplayName: orgNamespace,
+			},
+		})).To(Succeed())
+		Expect(adminClient.Create(ctx, &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   orgNamespace,
+				Labels: map[string]string{korifiv1alpha1.OrgNameKey: orgNamespace},
+			},
+		})).To(Succeed())
+
+		cfSpace = makeCFSpace(orgNamespace, "space-"+uuid.NewString())
+		Expect(adminClient.Create(ctx, cfSpace)).To(Succeed())
+		Expect(adminClient.Create(ctx, &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: cfSpace.Name,
+			},
+		})).To(Succeed())
+
+		Expect(adminClient.Create(ctx, &korifiv1alpha1.CFSpaceQuota{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: orgNamespace,
+			},
+			Spec: korifiv1alpha1.CFSpaceQuotaSpec{
+				DisplayName: "quota-" + uuid.NewString(),
+				Limits: korifiv1alpha1.QuotaLimits{
+					TotalInstances:       tools.PtrTo[int64](2),
+					PerProcessMemoryInMB: tools.PtrTo[int64](1024),
+				},
+				Spaces: []string{cfSpace.Name},
+			},
+		})).To(Succeed())
+
+		cfApp = makeCFApp(uuid.NewString(), cfSpace.Name, uuid.NewString())
+		cfApp.Spec.DesiredState = korifiv1alpha1.StartedState
+		Expect(adminClient.Create(ctx, cfApp)).To(Succeed())
+
+		cfProcess = &korifiv1alpha1.CFProcess{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: cfSpace.Name,
+			},
+			Spec: korifiv1alpha1.CFProcessSpec{
+				AppRef:           v1.LocalObjectReference{Name: cfApp.Name},
+				ProcessType:      "web",
+				DesiredInstances: tools.PtrTo(1),
+				MemoryMB:         512,
+			},
+		}
+	})
+
+	Describe("Create", func() {
+		It("allows processes within the quota", func() {
+			Expect(adminClient.Create(ctx, cfProcess)).To(Succeed())
+		})
+
+		When("the sidecars of the app take all the memory of the process", func() {
+			BeforeEach(func() {
+				Expect(adminClient.Create(ctx, &korifiv1alpha1.CFSidecar{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: cfSpace.Name,
+					},
+					Spec: korifiv1alpha1.CFSidecarSpec{
+						AppRef:       v1.LocalObjectReference{Name: cfApp.Name},
+						Name:         "my-sidecar",
+						Command:      "./my-sidecar",
+						ProcessTypes: []string{"web"},
+						MemoryMB:     512,
+					},
+				})).To(Succeed())
+			})
+
+			It("denies the request", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Create(ctx, cfProcess)).To(MatchError(ContainSubstring("The requested memory allocation is not large enough to run all of your sidecar processes")))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the process memory exceeds the per process limit", func() {
+			BeforeEach(func() {
+				cfProcess.Spec.MemoryMB = 2048
+			})
+
+			It("denies the request", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Create(ctx, cfProcess)).To(MatchError(ContainSubstring("You have exceeded the instance memory limit for your space's quota.")))
+				}).Should(Succeed())
+			})
+		})
+	})
+
+	Describe("Update", func() {
+		BeforeEach(func() {
+			Expect(adminClient.Create(ctx, cfProcess)).To(Succeed())
+		})
+
+		It("allows scaling within the quota", func() {
+			Expect(k8sPatch(ctx, cfProcess, func() {
+				cfProcess.Spec.DesiredInstances = tools.PtrTo(2)
+			})).To(Succeed())
+		})
+
+		When("the app has sidecars", func() {
+			BeforeEach(func() {
+				Expect(adminClient.Create(ctx, &korifiv1alpha1.CFSidecar{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      uuid.NewString(),
+						Namespace: cfSpace.Name,
+					},
+					Spec: korifiv1alpha1.CFSidecarSpec{
+						AppRef:       v1.LocalObjectReference{Name: cfApp.Name},
+						Name:         "my-sidecar",
+						Command:      "./my-sidecar",
+						ProcessTypes: []string{"web"},
+						MemoryMB:     256,
+					},
+				})).To(Succeed())
+			})
+
+			It("denies scaling the memory down to the memory of the sidecars", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+					g.Expect(k8sPatch(ctx, cfProcess, func() {
+						cfProcess.Spec.MemoryMB = 256
+					})).To(MatchError(ContainSubstring("The requested memory allocation is not large enough to run all of your sidecar processes")))
+				}).Should(Succeed())
+			})
+		})
+
+		It("denies scaling beyond the instance limit", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(cfProcess), cfProcess)).To(Succeed())
+				g.Expect(k8sPatch(ctx, cfProcess, func() {
+					cfProcess.Spec.DesiredInstances = tools.PtrTo(3)
+				})).To(MatchError(ContainSubstring("You have exceeded the app instance limit for your space's quota.")))
+			}).Should(Succeed())
+		})
+	})
+})
+
+func k8sPatch(ctx context.Context, obj client.Object, modify func()) error {
+	original := obj.DeepCopyObject().(client.Object)
+	modify()
+
+	return adminClient.Patch(ctx, obj, client.MergeFrom(original))
+}
@@ -0,0 +1,146 @@
+package workloads
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const SidecarMemoryErrorType = "SidecarMemoryError"
+
+var cfsidecarlog = logf.Log.WithName("cfsidecar-validate")
+
+//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfsidecar,mutating=false,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cfsidecars,verbs=create;update,versions=v1alpha1,name=vcfsidecar.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+
+type CFSidecarValidator struct {
+	client client.Client
+}
+
+var _ webhook.CustomValidator = &CFSidecarValidator{}
+
+func NewCFSidecarValidator(client client.Client) *CFSidecarValidator {
+	return &CFSidecarValidator{
+		client: client,
+	}
+}
+
+func (v *CFSidecarValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&korifiv1alpha1.CFSidecar{}).
+		WithValidator(v).
+		Complete()
+}
+
+func (v *CFSidecarValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	sidecar, ok := obj.(*korifiv1alpha1.CFSidecar)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFSidecar but got a %T", obj))
+	}
+
+	return nil, v.validateMemory(ctx, sidecar)
+}
+
+func (v *CFSidecarValidator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Object) (admission.Warnings, error) {
+	sidecar, ok := obj.(*korifiv1alpha1.CFSidecar)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFSidecar but got a %T", obj))
+	}
+
+	if !sidecar.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	return nil, v.validateMemory(ctx, sidecar)
+}
+
+func (v *CFSidecarValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// validateMemory rejects sidecars that would leave no memory to the
+// processes they run alongside, together with the other sidecars of the app
+func (v *CFSidecarValidator) validateMemory(ctx context.Context, sidecar *korifiv1alpha1.CFSidecar) error {
+	if sidecar.Spec.MemoryMB == 0 {
+		return nil
+	}
+
+	processes := &korifiv1alpha1.CFProcessList{}
+	if err := v.client.List(ctx, processes, client.InNamespace(sidecar.Namespace)); err != nil {
+		cfsidecarlog.Info("failed to list processes", "namespace", sidecar.Namespace, "reason", err)
+		return unknownError()
+	}
+
+	sidecars, err := listAppSidecars(ctx, v.client, sidecar.Namespace, sidecar.Spec.AppRef.Name)
+	if err != nil {
+		cfsidecarlog.Info("failed to list sidecars", "namespace", sidecar.Namespace, "reason", err)
+		return unknownError()
+	}
+
+	appSidecars := []korifiv1alpha1.CFSidecar{*sidecar}
+	for _, s := range sidecars {
+		if s.Name != sidecar.Name {
+			appSidecars = append(appSidecars, s)
+		}
+	}
+
+	for _, process := range processes.Items {
+		if process.Spec.AppRef.Name != sidecar.Spec.AppRef.Name || !sidecar.RunsAlongside(process.Spec.ProcessType) {
+			continue
+		}
+
+		if sidecarsMemoryMB(process, appSidecars) >= process.Spec.MemoryMB {
+			return webhooks.ValidationError{
+				Type:    SidecarMemoryErrorType,
+				Message: fmt.Sprintf("The memory allocation defined is too large to run with the dependent %q process", process.Spec.ProcessType),
+			}.ExportJSONError()
+		}
+	}
+
+	return nil
+}
+
+func listAppSidecars(ctx context.Context, k8sClient client.Client, namespace, appGUID string) ([]korifiv1alpha1.CFSidecar, error) {
+	sidecars := &korifiv1alpha1.CFSidecarList{}
+	if err := k8sClient.List(ctx, sidecars, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+
+	var appSidecars []korifiv1alpha1.CFSidecar
+	for _, sidecar := range sidecars.Items {
+		if sidecar.Spec.AppRef.Name == appGUID {
+			appSidecars = append(appSidecars, sidecar)
+		}
+	}
+
+	return appSidecars, nil
+}
+
+// sidecarsMemoryMB sums up the memory the sidecars take out of the memory of
+// each instance of the process
+func sidecarsMemoryMB(process korifiv1alpha1.CFProcess, sidecars []korifiv1alpha1.CFSidecar) int64 {
+	var memory int64
+	for _, sidecar := range sidecars {
+		if sidecar.RunsAlongside(process.Spec.ProcessType) {
+			memory += sidecar.Spec.MemoryMB
+		}
+	}
+
+	return memory
+}
+
+func unknownError() error {
+	return webhooks.ValidationError{
+		Type:    webhooks.UnknownErrorType,
+		Message: webhooks.UnknownErrorMessage,
+	}.ExportJSONError()
+}
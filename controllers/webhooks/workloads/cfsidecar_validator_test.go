@@ -0,0 +1,137 @@
+package workloads_test
+
+import (
+	"context"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/tools"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("CFSidecarValidatingWebhook", func() {
+	var (
+		ctx       context.Context
+		namespace string
+		cfApp     *korifiv1alpha1.CFApp
+		cfSidecar *korifiv1alpha1.CFSidecar
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		namespace = uuid.NewString()
+		Expect(adminClient.Create(ctx, &v1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: namespace,
+			},
+		})).To(Succeed())
+
+		cfApp = makeCFApp(uuid.NewString(), namespace, uuid.NewString())
+		Expect(adminClient.Create(ctx, cfApp)).To(Succeed())
+
+		Expect(adminClient.Create(ctx, &korifiv1alpha1.CFProcess{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: namespace,
+			},
+			Spec: korifiv1alpha1.CFProcessSpec{
+				AppRef:           v1.LocalObjectReference{Name: cfApp.Name},
+				ProcessType:      "web",
+				DesiredInstances: tools.PtrTo(1),
+				MemoryMB:         256,
+			},
+		})).To(Succeed())
+
+		Expect(adminClient.Create(ctx, &korifiv1alpha1.CFSidecar{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: namespace,
+			},
+			Spec: korifiv1alpha1.CFSidecarSpec{
+				AppRef:       v1.LocalObjectReference{Name: cfApp.Name},
+				Name:         "other-sidecar",
+				Command:      "./other-sidecar",
+				ProcessTypes: []string{"web"},
+				MemoryMB:     64,
+			},
+		})).To(Succeed())
+
+		cfSidecar = &korifiv1alpha1.CFSidecar{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      uuid.NewString(),
+				Namespace: namespace,
+			},
+			Spec: korifiv1alpha1.CFSidecarSpec{
+				AppRef:       v1.LocalObjectReference{Name: cfApp.Name},
+				Name:         "my-sidecar",
+				Command:      "./my-sidecar",
+				ProcessTypes: []string{"web"},
+				MemoryMB:     128,
+			},
+		}
+	})
+
+	Describe("Create", func() {
+		It("allows sidecars leaving memory to the process", func() {
+			Expect(adminClient.Create(ctx, cfSidecar)).To(Succeed())
+		})
+
+		When("the sidecar has no memory", func() {
+			BeforeEach(func() {
+				cfSidecar.Spec.MemoryMB = 0
+			})
+
+			It("allows the sidecar", func() {
+				Expect(adminClient.Create(ctx, cfSidecar)).To(Succeed())
+			})
+		})
+
+		When("the sidecars take all the memory of the process", func() {
+			BeforeEach(func() {
+				cfSidecar.Spec.MemoryMB = 192
+			})
+
+			It("denies the request", func() {
+				Eventually(func(g Gomega) {
+					g.Expect(adminClient.Create(ctx, cfSidecar)).To(MatchError(ContainSubstring(`The memory allocation defined is too large to run with the dependent "web" process`)))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the sidecar does not run alongside the process", func() {
+			BeforeEach(func() {
+				cfSidecar.Spec.ProcessTypes = []string{"worker"}
+				cfSidecar.Spec.MemoryMB = 1024
+			})
+
+			It("allows the sidecar", func() {
+				Expect(adminClient.Create(ctx, cfSidecar)).To(Succeed())
+			})
+		})
+	})
+
+	Describe("Update", func() {
+		BeforeEach(func() {
+			Expect(adminClient.Create(ctx, cfSidecar)).To(Succeed())
+		})
+
+		It("allows shrinking the memory of the sidecar", func() {
+			Expect(k8sPatch(ctx, cfSidecar, func() {
+				cfSidecar.Spec.MemoryMB = 32
+			})).To(Succeed())
+		})
+
+		It("denies growing the memory of the sidecar to the memory of the process", func() {
+			Eventually(func(g Gomega) {
+				g.Expect(k8sPatch(ctx, cfSidecar, func() {
+					cfSidecar.Spec.MemoryMB = 256
+				})).To(MatchError(ContainSubstring(`The memory allocation defined is too large to run with the dependent "web" process`)))
+			}).Should(Succeed())
+		})
+	})
+})
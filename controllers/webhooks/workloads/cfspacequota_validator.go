@@ -0,0 +1,79 @@
+package workloads
+
+import (
+	"context"
+	"fmt"
+
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/webhooks"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	SpaceQuotaEntityType = "spacequota"
+)
+
+var cfspacequotalog = logf.Log.WithName("cfspacequota-validate")
+
+//+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cfspacequota,mutating=false,failurePolicy=fail,sideEffects=NoneOnDryRun,groups=korifi.cloudfoundry.org,resources=cfspacequotas,verbs=create;update;delete,versions=v1alpha1,name=vcfspacequota.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
+
+type CFSpaceQuotaValidator struct {
+	duplicateValidator webhooks.NameValidator
+}
+
+var _ webhook.CustomValidator = &CFSpaceQuotaValidator{}
+
+func NewCFSpaceQuotaValidator(duplicateValidator webhooks.NameValidator) *CFSpaceQuotaValidator {
+	return &CFSpaceQuotaValidator{
+		duplicateValidator: duplicateValidator,
+	}
+}
+
+func (v *CFSpaceQuotaValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&korifiv1alpha1.CFSpaceQuota{}).
+		WithValidator(v).
+		Complete()
+}
+
+func (v *CFSpaceQuotaValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	spaceQuota, ok := obj.(*korifiv1alpha1.CFSpaceQuota)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFSpaceQuota but got a %T", obj))
+	}
+
+	return nil, v.duplicateValidator.ValidateCreate(ctx, cfspacequotalog, spaceQuota.Namespace, spaceQuota)
+}
+
+func (v *CFSpaceQuotaValidator) ValidateUpdate(ctx context.Context, oldObj, obj runtime.Object) (admission.Warnings, error) {
+	spaceQuota, ok := obj.(*korifiv1alpha1.CFSpaceQuota)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFSpaceQuota but got a %T", obj))
+	}
+
+	if !spaceQuota.GetDeletionTimestamp().IsZero() {
+		return nil, nil
+	}
+
+	oldSpaceQuota, ok := oldObj.(*korifiv1alpha1.CFSpaceQuota)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFSpaceQuota but got a %T", oldObj))
+	}
+
+	return nil, v.duplicateValidator.ValidateUpdate(ctx, cfspacequotalog, spaceQuota.Namespace, oldSpaceQuota, spaceQuota)
+}
+
+func (v *CFSpaceQuotaValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	spaceQuota, ok := obj.(*korifiv1alpha1.CFSpaceQuota)
+	if !ok {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a CFSpaceQuota but got a %T", obj))
+	}
+
+	return nil, v.duplicateValidator.ValidateDelete(ctx, cfspacequotalog, spaceQuota.Namespace, spaceQuota)
+}
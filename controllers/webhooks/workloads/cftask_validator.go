@@ -21,6 +21,8 @@ import (
 	"fmt"
 
 	"code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/controllers/config"
+	"code.cloudfoundry.org/korifi/controllers/quotas"
 	"code.cloudfoundry.org/korifi/controllers/webhooks"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
@@ -43,12 +45,18 @@ var cftasklog = logf.Log.WithName("cftask-resource")
 
 //+kubebuilder:webhook:path=/validate-korifi-cloudfoundry-org-v1alpha1-cftask,mutating=false,failurePolicy=fail,sideEffects=None,groups=korifi.cloudfoundry.org,resources=cftasks;cftasks/status,verbs=create;update,versions=v1alpha1,name=vcftask.korifi.cloudfoundry.org,admissionReviewVersions={v1,v1beta1}
 
-type CFTaskValidator struct{}
+type CFTaskValidator struct {
+	quotaValidator    webhooks.QuotaValidator
+	cfProcessDefaults config.CFProcessDefaults
+}
 
 var _ webhook.CustomValidator = &CFTaskValidator{}
 
-func NewCFTaskValidator() *CFTaskValidator {
-	return &CFTaskValidator{}
+func NewCFTaskValidator(quotaValidator webhooks.QuotaValidator, cfProcessDefaults config.CFProcessDefaults) *CFTaskValidator {
+	return &CFTaskValidator{
+		quotaValidator:    quotaValidator,
+		cfProcessDefaults: cfProcessDefaults,
+	}
 }
 
 func (v *CFTaskValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
@@ -82,7 +90,12 @@ func (v *CFTaskValidator) ValidateCreate(ctx context.Context, obj runtime.Object
 		}.ExportJSONError()
 	}
 
-	return nil, nil
+	// tasks always run with the default process memory, see the CFTask defaulter
+	return nil, v.quotaValidator.ValidateRequest(ctx, cftasklog, task.Namespace, quotas.Request{
+		Increase:          quotas.Usage{MemoryInMB: v.cfProcessDefaults.MemoryMB},
+		ProcessMemoryInMB: v.cfProcessDefaults.MemoryMB,
+		TaskAppGUID:       task.Spec.AppRef.Name,
+	})
 }
 
 func (v *CFTaskValidator) ValidateUpdate(ctx context.Context, oldObj runtime.Object, obj runtime.Object) (admission.Warnings, error) {
@@ -80,8 +80,10 @@ var _ = BeforeSuite(func() {
 
 	(&workloads.AppRevWebhook{}).SetupWebhookWithManager(k8sManager)
 
+	quotaValidator := webhooks.NewQuotaLimitValidator(k8sManager.GetClient(), rootNamespace)
+
 	appNameDuplicateValidator := webhooks.NewDuplicateValidator(coordination.NewNameRegistry(k8sManager.GetClient(), workloads.AppEntityType))
-	Expect(workloads.NewCFAppValidator(appNameDuplicateValidator).SetupWebhookWithManager(k8sManager)).To(Succeed())
+	Expect(workloads.NewCFAppValidator(appNameDuplicateValidator, quotaValidator).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
 	orgNameDuplicateValidator := webhooks.NewDuplicateValidator(coordination.NewNameRegistry(k8sManager.GetClient(), workloads.CFOrgEntityType))
 	orgPlacementValidator := webhooks.NewPlacementValidator(k8sManager.GetClient(), rootNamespace)
@@ -91,11 +93,23 @@ var _ = BeforeSuite(func() {
 	spacePlacementValidator := webhooks.NewPlacementValidator(k8sManager.GetClient(), rootNamespace)
 	Expect(workloads.NewCFSpaceValidator(spaceNameDuplicateValidator, spacePlacementValidator).SetupWebhookWithManager(k8sManager)).To(Succeed())
 
-	Expect(workloads.NewCFTaskDefaulter(config.CFProcessDefaults{
+	cfProcessDefaults := config.CFProcessDefaults{
 		MemoryMB:    500,
 		DiskQuotaMB: 512,
-	}).SetupWebhookWithManager(k8sManager)).To(Succeed())
-	Expect(workloads.NewCFTaskValidator().SetupWebhookWithManager(k8sManager)).To(Succeed())
+	}
+	Expect(workloads.NewCFTaskDefaulter(cfProcessDefaults).SetupWebhookWithManager(k8sManager)).To(Succeed())
+	Expect(workloads.NewCFTaskValidator(quotaValidator, cfProcessDefaults).SetupWebhookWithManager(k8sManager)).To(Succeed())
+	Expect(workloads.NewCFProcessValidator(quotaValidator, k8sManager.GetClient()).SetupWebhookWithManager(k8sManager)).To(Succeed())
+	Expect(workloads.NewCFSidecarValidator(k8sManager.GetClient()).SetupWebhookWithManager(k8sManager)).To(Succeed())
+
+	orgQuotaNameDuplicateValidator := webhooks.NewDuplicateValidator(coordination.NewNameRegistry(k8sManager.GetClient(), workloads.OrgQuotaEntityType))
+	Expect(workloads.NewCFOrgQuotaValidator(orgQuotaNameDuplicateValidator).SetupWebhookWithManager(k8sManager)).To(Succeed())
+
+	isolationSegmentNameDuplicateValidator := webhooks.NewDuplicateValidator(coordination.NewNameRegistry(k8sManager.GetClient(), workloads.IsolationSegmentEntityType))
+	Expect(workloads.NewCFIsolationSegmentValidator(isolationSegmentNameDuplicateValidator).SetupWebhookWithManager(k8sManager)).To(Succeed())
+
+	spaceQuotaNameDuplicateValidator := webhooks.NewDuplicateValidator(coordination.NewNameRegistry(k8sManager.GetClient(), workloads.SpaceQuotaEntityType))
+	Expect(workloads.NewCFSpaceQuotaValidator(spaceQuotaNameDuplicateValidator).SetupWebhookWithManager(k8sManager)).To(Succeed())
 	version.NewVersionWebhook("some-version").SetupWebhookWithManager(k8sManager)
 	finalizer.NewControllersFinalizerWebhook().SetupWebhookWithManager(k8sManager)
 
@@ -14,10 +14,10 @@ import (
 
 type StatusGetter struct {
 	logger    logr.Logger
-	k8sClient client.Client
+	k8sClient client.Reader
 }
 
-func NewStatusGetter(logger logr.Logger, k8sClient client.Client) *StatusGetter {
+func NewStatusGetter(logger logr.Logger, k8sClient client.Reader) *StatusGetter {
 	return &StatusGetter{
 		logger:    logger,
 		k8sClient: k8sClient,
@@ -12,6 +12,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -156,6 +157,34 @@ var _ = Describe("TaskworkloadController", func() {
 			Expect(job.Name).To(Equal(taskWorkload.Name))
 		})
 
+		When("the task is placed into an isolation segment", func() {
+			var desiredJob *batchv1.Job
+
+			BeforeEach(func() {
+				fakeClient.CreateStub = func(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+					desiredJob = obj.(*batchv1.Job).DeepCopy()
+					return nil
+				}
+
+				taskWorkload.Spec.NodeSelector = map[string]string{"pool": "regulated"}
+				taskWorkload.Spec.Tolerations = []corev1.Toleration{{
+					Key:      "pool",
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoSchedule,
+				}}
+			})
+
+			It("sets the node selector and tolerations of the job pod", func() {
+				Expect(reconcileErr).NotTo(HaveOccurred())
+				Expect(desiredJob.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{"pool": "regulated"}))
+				Expect(desiredJob.Spec.Template.Spec.Tolerations).To(ConsistOf(corev1.Toleration{
+					Key:      "pool",
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoSchedule,
+				}))
+			})
+		})
+
 		When("the taskworkload has the initialized true condition", func() {
 			BeforeEach(func() {
 				meta.SetStatusCondition(&taskWorkload.Status.Conditions, metav1.Condition{
@@ -48,14 +48,16 @@ func NewBuilderInfoReconciler(
 	scheme *runtime.Scheme,
 	log logr.Logger,
 	clusterBuilderName string,
+	stackClusterBuilderNames []string,
 	rootNamespaceName string,
 ) *k8s.PatchingReconciler[korifiv1alpha1.BuilderInfo, *korifiv1alpha1.BuilderInfo] {
 	builderInfoReconciler := BuilderInfoReconciler{
-		k8sClient:          c,
-		scheme:             scheme,
-		log:                log,
-		clusterBuilderName: clusterBuilderName,
-		rootNamespaceName:  rootNamespaceName,
+		k8sClient:                c,
+		scheme:                   scheme,
+		log:                      log,
+		clusterBuilderName:       clusterBuilderName,
+		stackClusterBuilderNames: stackClusterBuilderNames,
+		rootNamespaceName:        rootNamespaceName,
 	}
 	return k8s.NewPatchingReconciler[korifiv1alpha1.BuilderInfo, *korifiv1alpha1.BuilderInfo](log, c, &builderInfoReconciler)
 }
@@ -65,7 +67,9 @@ type BuilderInfoReconciler struct {
 	scheme             *runtime.Scheme
 	log                logr.Logger
 	clusterBuilderName string
-	rootNamespaceName  string
+	// the ClusterBuilders providing stacks other than the one of the default ClusterBuilder
+	stackClusterBuilderNames []string
+	rootNamespaceName        string
 }
 
 func (r *BuilderInfoReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Builder {
@@ -75,20 +79,33 @@ func (r *BuilderInfoReconciler) SetupWithManager(mgr ctrl.Manager) *builder.Buil
 			new(buildv1alpha2.ClusterBuilder),
 			handler.EnqueueRequestsFromMapFunc(r.enqueueBuilderInfoRequests),
 		).
+		Watches(
+			new(buildv1alpha2.ClusterStack),
+			handler.EnqueueRequestsFromMapFunc(r.enqueueAllBuilderInfoRequests),
+		).
 		WithEventFilter(predicate.NewPredicateFuncs(r.filterBuilderInfos))
 }
 
 func (r *BuilderInfoReconciler) enqueueBuilderInfoRequests(ctx context.Context, o client.Object) []reconcile.Request {
-	var requests []reconcile.Request
-	if o.GetName() == r.clusterBuilderName {
-		requests = append(requests, reconcile.Request{
-			NamespacedName: types.NamespacedName{
-				Name:      BuilderInfoName,
-				Namespace: r.rootNamespaceName,
-			},
-		})
+	for _, name := range r.clusterBuilderNames() {
+		if o.GetName() == name {
+			return r.enqueueAllBuilderInfoRequests(ctx, o)
+		}
 	}
-	return requests
+	return nil
+}
+
+func (r *BuilderInfoReconciler) enqueueAllBuilderInfoRequests(ctx context.Context, o client.Object) []reconcile.Request {
+	return []reconcile.Request{{
+		NamespacedName: types.NamespacedName{
+			Name:      BuilderInfoName,
+			Namespace: r.rootNamespaceName,
+		},
+	}}
+}
+
+func (r *BuilderInfoReconciler) clusterBuilderNames() []string {
+	return append([]string{r.clusterBuilderName}, r.stackClusterBuilderNames...)
 }
 
 func (r *BuilderInfoReconciler) filterBuilderInfos(object client.Object) bool {
@@ -105,6 +122,7 @@ func (r *BuilderInfoReconciler) filterBuilderInfos(object client.Object) bool {
 
 //+kubebuilder:rbac:groups=kpack.io,resources=clusterbuilders,verbs=get;list;watch
 //+kubebuilder:rbac:groups=kpack.io,resources=clusterbuilders/status,verbs=get
+//+kubebuilder:rbac:groups=kpack.io,resources=clusterstacks,verbs=get;list;watch
 
 func (r *BuilderInfoReconciler) ReconcileResource(ctx context.Context, info *korifiv1alpha1.BuilderInfo) (ctrl.Result, error) {
 	log := r.log.WithValues("namespace", info.Namespace, "name", info.Name)
@@ -129,9 +147,35 @@ func (r *BuilderInfoReconciler) ReconcileResource(ctx context.Context, info *kor
 		return ctrl.Result{}, err
 	}
 
-	updatedTimestamp := lastUpdatedTime(clusterBuilder.ObjectMeta)
-	info.Status.Stacks = clusterBuilderToStacks(clusterBuilder, updatedTimestamp)
-	info.Status.Buildpacks = clusterBuilderToBuildpacks(clusterBuilder, updatedTimestamp)
+	clusterBuilders := []*buildv1alpha2.ClusterBuilder{clusterBuilder}
+	for _, name := range r.stackClusterBuilderNames {
+		stackClusterBuilder := new(buildv1alpha2.ClusterBuilder)
+		err = r.k8sClient.Get(ctx, types.NamespacedName{Name: name}, stackClusterBuilder)
+		if err != nil {
+			log.Info("skipping stack ClusterBuilder", "clusterBuilder", name, "reason", err)
+			continue
+		}
+		clusterBuilders = append(clusterBuilders, stackClusterBuilder)
+	}
+
+	info.Status.Stacks = []korifiv1alpha1.BuilderInfoStatusStack{}
+	info.Status.Buildpacks = []korifiv1alpha1.BuilderInfoStatusBuildpack{}
+	reportedStacks := map[string]bool{}
+	for i, builder := range clusterBuilders {
+		// the default ClusterBuilder wins when several ClusterBuilders provide the same stack
+		if i > 0 && (builder.Status.Stack.ID == "" || reportedStacks[builder.Status.Stack.ID]) {
+			continue
+		}
+		reportedStacks[builder.Status.Stack.ID] = true
+
+		updatedTimestamp := lastUpdatedTime(builder.ObjectMeta)
+		stacks, err := r.clusterBuilderToStacks(ctx, builder, updatedTimestamp)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		info.Status.Stacks = append(info.Status.Stacks, stacks...)
+		info.Status.Buildpacks = append(info.Status.Buildpacks, clusterBuilderToBuildpacks(builder, updatedTimestamp)...)
+	}
 
 	clusterBuilderReadyCondition := clusterBuilder.Status.GetCondition(corev1alpha1.ConditionReady)
 	if clusterBuilderReadyCondition != nil && clusterBuilderReadyCondition.Status == corev1.ConditionTrue {
@@ -164,19 +208,31 @@ func (r *BuilderInfoReconciler) ReconcileResource(ctx context.Context, info *kor
 	return ctrl.Result{}, nil
 }
 
-func clusterBuilderToStacks(clusterBuilder *buildv1alpha2.ClusterBuilder, updatedTimestamp metav1.Time) []korifiv1alpha1.BuilderInfoStatusStack {
+func (r *BuilderInfoReconciler) clusterBuilderToStacks(ctx context.Context, clusterBuilder *buildv1alpha2.ClusterBuilder, updatedTimestamp metav1.Time) ([]korifiv1alpha1.BuilderInfoStatusStack, error) {
 	if clusterBuilder.Status.Stack.ID == "" {
-		return []korifiv1alpha1.BuilderInfoStatusStack{}
+		return []korifiv1alpha1.BuilderInfoStatusStack{}, nil
 	}
 
-	return []korifiv1alpha1.BuilderInfoStatusStack{
-		{
-			Name:              clusterBuilder.Status.Stack.ID,
-			Description:       "",
-			CreationTimestamp: clusterBuilder.CreationTimestamp,
-			UpdatedTimestamp:  updatedTimestamp,
-		},
+	stack := korifiv1alpha1.BuilderInfoStatusStack{
+		Name:              clusterBuilder.Status.Stack.ID,
+		Description:       "",
+		CreationTimestamp: clusterBuilder.CreationTimestamp,
+		UpdatedTimestamp:  updatedTimestamp,
+	}
+
+	clusterStack := new(buildv1alpha2.ClusterStack)
+	err := r.k8sClient.Get(ctx, types.NamespacedName{Name: clusterBuilder.Spec.Stack.Name}, clusterStack)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, fmt.Errorf("failed to get ClusterStack %q: %w", clusterBuilder.Spec.Stack.Name, err)
 	}
+	if err == nil {
+		stack.BuildImage = clusterStack.Spec.BuildImage.Image
+		stack.RunImage = clusterStack.Spec.RunImage.Image
+		stack.CreationTimestamp = clusterStack.CreationTimestamp
+		stack.UpdatedTimestamp = lastUpdatedTime(clusterStack.ObjectMeta)
+	}
+
+	return []korifiv1alpha1.BuilderInfoStatusStack{stack}, nil
 }
 
 func clusterBuilderToBuildpacks(builder *buildv1alpha2.ClusterBuilder, updatedTimestamp metav1.Time) []korifiv1alpha1.BuilderInfoStatusBuildpack {
@@ -27,6 +27,7 @@ var _ = Describe("BuilderInfoReconciler", Serial, func() {
 		pythonBuildpackVersion = "2.3.4"
 		javaBuildpackName      = "java"
 		javaBuildpackVersion   = "3.4"
+		clusterStackName       = "my-cluster-stack"
 	)
 
 	var (
@@ -54,6 +55,14 @@ var _ = Describe("BuilderInfoReconciler", Serial, func() {
 				ObjectMeta: metav1.ObjectMeta{
 					Name: clusterBuilderName,
 				},
+				Spec: buildv1alpha2.ClusterBuilderSpec{
+					BuilderSpec: buildv1alpha2.BuilderSpec{
+						Stack: v1.ObjectReference{
+							Kind: "ClusterStack",
+							Name: clusterStackName,
+						},
+					},
+				},
 			}
 
 			Expect(adminClient.Create(context.Background(), clusterBuilder)).To(Succeed())
@@ -124,6 +133,85 @@ var _ = Describe("BuilderInfoReconciler", Serial, func() {
 				Expect(info.Status.Stacks[0]).To(HaveField("Name", Equal(stack)))
 			})
 
+			When("the ClusterStack of the ClusterBuilder exists", func() {
+				var clusterStack *buildv1alpha2.ClusterStack
+
+				BeforeEach(func() {
+					clusterStack = &buildv1alpha2.ClusterStack{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: clusterStackName,
+						},
+						Spec: buildv1alpha2.ClusterStackSpec{
+							Id:         stack,
+							BuildImage: buildv1alpha2.ClusterStackSpecImage{Image: "my-build-image"},
+							RunImage:   buildv1alpha2.ClusterStackSpecImage{Image: "my-run-image"},
+						},
+					}
+					Expect(adminClient.Create(context.Background(), clusterStack)).To(Succeed())
+				})
+
+				AfterEach(func() {
+					Expect(adminClient.Delete(context.Background(), clusterStack)).To(Succeed())
+				})
+
+				It("sets the stack images on the BuilderInfo", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(context.Background(), client.ObjectKeyFromObject(info), info)).To(Succeed())
+						g.Expect(info.Status.Stacks).To(ConsistOf(MatchFields(IgnoreExtras, Fields{
+							"Name":       Equal(stack),
+							"BuildImage": Equal("my-build-image"),
+							"RunImage":   Equal("my-run-image"),
+						})))
+					}).Should(Succeed())
+				})
+			})
+
+			When("a stack ClusterBuilder exists", func() {
+				const otherStack = "ubuntu-jammy-jellyfish"
+
+				var stackClusterBuilder *buildv1alpha2.ClusterBuilder
+
+				BeforeEach(func() {
+					stackClusterBuilder = &buildv1alpha2.ClusterBuilder{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: stackClusterBuilderName,
+						},
+					}
+					Expect(adminClient.Create(context.Background(), stackClusterBuilder)).To(Succeed())
+
+					stackClusterBuilder.Status = buildv1alpha2.BuilderStatus{
+						Order: []corev1alpha1.OrderEntry{
+							{Group: []corev1alpha1.BuildpackRef{
+								{BuildpackInfo: corev1alpha1.BuildpackInfo{Id: golangBuildpackName, Version: golangBuildpackVersion}},
+							}},
+						},
+						Stack: corev1alpha1.BuildStack{
+							ID: otherStack,
+						},
+					}
+					Expect(adminClient.Status().Update(context.Background(), stackClusterBuilder)).To(Succeed())
+				})
+
+				AfterEach(func() {
+					Expect(adminClient.Delete(context.Background(), stackClusterBuilder)).To(Succeed())
+				})
+
+				It("sets the stacks of both ClusterBuilders on the BuilderInfo", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(adminClient.Get(context.Background(), client.ObjectKeyFromObject(info), info)).To(Succeed())
+						g.Expect(info.Status.Stacks).To(ConsistOf(
+							HaveField("Name", Equal(stack)),
+							HaveField("Name", Equal(otherStack)),
+						))
+						g.Expect(info.Status.Buildpacks).To(HaveLen(4))
+						g.Expect(info.Status.Buildpacks[3]).To(MatchFields(IgnoreExtras, Fields{
+							"Name":  Equal(golangBuildpackName),
+							"Stack": Equal(otherStack),
+						}))
+					}).Should(Succeed())
+				})
+			})
+
 			It("marks the BuilderInfo as ready", func() {
 				Eventually(func(g Gomega) []v1alpha1.BuilderInfoStatusBuildpack {
 					g.Expect(adminClient.Get(context.Background(), client.ObjectKeyFromObject(info), info)).To(Succeed())
@@ -182,9 +182,16 @@ func (r *BuildWorkloadReconciler) ReconcileResource(ctx context.Context, buildWo
 
 	var err error
 	if !hasKpackImage(buildWorkload) {
+		var clusterBuilderName string
+		clusterBuilderName, err = r.clusterBuilderNameForStack(ctx, log, buildWorkload)
+		if err != nil {
+			log.Info("failed selecting the ClusterBuilder of the stack", "stack", buildWorkload.Spec.Stack, "reason", err)
+			return ctrl.Result{}, ignoreDoNotRetryError(err)
+		}
+
 		var builderName string
 		if len(buildWorkload.Spec.Buildpacks) > 0 {
-			builderName, err = r.ensureKpackBuilder(ctx, log, buildWorkload)
+			builderName, err = r.ensureKpackBuilder(ctx, log, buildWorkload, clusterBuilderName)
 			if err != nil {
 				log.Info("failed ensuring custom builder", "reason", err)
 				return ctrl.Result{}, ignoreDoNotRetryError(fmt.Errorf("failed ensuring custom builder: %w", err))
@@ -197,7 +204,7 @@ func (r *BuildWorkloadReconciler) ReconcileResource(ctx context.Context, buildWo
 			return ctrl.Result{}, err
 		}
 
-		return ctrl.Result{}, r.reconcileKpackImage(ctx, log, buildWorkload, builderName)
+		return ctrl.Result{}, r.reconcileKpackImage(ctx, log, buildWorkload, clusterBuilderName, builderName)
 	}
 
 	if hasCompleted(buildWorkload) {
@@ -389,10 +396,45 @@ func (r *BuildWorkloadReconciler) getBuilderReadyCondition(ctx context.Context,
 	return condition, nil
 }
 
-func (r *BuildWorkloadReconciler) getDefaultClusterBuilder(ctx context.Context) (*buildv1alpha2.ClusterBuilder, error) {
-	var defaultBuilder buildv1alpha2.ClusterBuilder
-	err := r.k8sClient.Get(ctx, client.ObjectKey{Name: r.controllerConfig.ClusterBuilderName}, &defaultBuilder)
-	return &defaultBuilder, err
+func (r *BuildWorkloadReconciler) getClusterBuilder(ctx context.Context, name string) (*buildv1alpha2.ClusterBuilder, error) {
+	var clusterBuilder buildv1alpha2.ClusterBuilder
+	err := r.k8sClient.Get(ctx, client.ObjectKey{Name: name}, &clusterBuilder)
+	return &clusterBuilder, err
+}
+
+// clusterBuilderNameForStack returns the ClusterBuilder providing the stack
+// of the build workload. The default ClusterBuilder builds workloads without
+// a stack, with the configured default stack, or with its own stack.
+func (r *BuildWorkloadReconciler) clusterBuilderNameForStack(ctx context.Context, log logr.Logger, buildWorkload *korifiv1alpha1.BuildWorkload) (string, error) {
+	stack := buildWorkload.Spec.Stack
+	if stack == "" || stack == r.controllerConfig.DefaultStack {
+		return r.controllerConfig.ClusterBuilderName, nil
+	}
+
+	for _, name := range append([]string{r.controllerConfig.ClusterBuilderName}, r.controllerConfig.StackClusterBuilderNames...) {
+		clusterBuilder, err := r.getClusterBuilder(ctx, name)
+		if err != nil {
+			if k8serrors.IsNotFound(err) {
+				log.Info("skipping missing ClusterBuilder", "clusterBuilder", name)
+				continue
+			}
+			return "", err
+		}
+
+		if clusterBuilder.Status.Stack.ID == stack {
+			return name, nil
+		}
+	}
+
+	meta.SetStatusCondition(&buildWorkload.Status.Conditions, metav1.Condition{
+		Type:               korifiv1alpha1.SucceededConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             "UnknownStack",
+		Message:            fmt.Sprintf("No ClusterBuilder provides stack %q", stack),
+		ObservedGeneration: buildWorkload.Generation,
+	})
+
+	return "", newDoNotRetryError(fmt.Errorf("no ClusterBuilder provides stack %q", stack))
 }
 
 type doNotRetryError struct {
@@ -420,29 +462,34 @@ func ignoreDoNotRetryError(err error) error {
 	return err
 }
 
-func (r *BuildWorkloadReconciler) ensureKpackBuilder(ctx context.Context, log logr.Logger, buildWorkload *korifiv1alpha1.BuildWorkload) (string, error) {
+func (r *BuildWorkloadReconciler) ensureKpackBuilder(ctx context.Context, log logr.Logger, buildWorkload *korifiv1alpha1.BuildWorkload, clusterBuilderName string) (string, error) {
 	var (
-		defaultBuilder *buildv1alpha2.ClusterBuilder
+		clusterBuilder *buildv1alpha2.ClusterBuilder
 		err            error
 	)
 
-	if defaultBuilder, err = r.getDefaultClusterBuilder(ctx); err != nil {
+	if clusterBuilder, err = r.getClusterBuilder(ctx, clusterBuilderName); err != nil {
 		if k8serrors.IsNotFound(err) {
+			message := fmt.Sprintf("ClusterBuilder %q not found", clusterBuilderName)
+			if clusterBuilderName == r.controllerConfig.ClusterBuilderName {
+				message = "Default ClusterBuilder not found"
+			}
+
 			meta.SetStatusCondition(&buildWorkload.Status.Conditions, metav1.Condition{
 				Type:               korifiv1alpha1.SucceededConditionType,
 				Status:             metav1.ConditionFalse,
 				Reason:             "BuilderNotReady",
-				Message:            "Default ClusterBuilder not found",
+				Message:            message,
 				ObservedGeneration: buildWorkload.Generation,
 			})
-			return "", newDoNotRetryError(fmt.Errorf("default ClusterBuilder not found: %w", err))
+			return "", newDoNotRetryError(fmt.Errorf("ClusterBuilder %q not found: %w", clusterBuilderName, err))
 		}
 
-		log.Info("error when fetching default ClusterBuilder", "reason", err)
+		log.Info("error when fetching ClusterBuilder", "clusterBuilder", clusterBuilderName, "reason", err)
 		return "", err
 	}
 
-	if err = r.checkBuildpacks(ctx, buildWorkload, defaultBuilder); err != nil {
+	if err = r.checkBuildpacks(ctx, buildWorkload, clusterBuilder); err != nil {
 		meta.SetStatusCondition(&buildWorkload.Status.Conditions, metav1.Condition{
 			Type:               korifiv1alpha1.SucceededConditionType,
 			Status:             metav1.ConditionFalse,
@@ -455,6 +502,9 @@ func (r *BuildWorkloadReconciler) ensureKpackBuilder(ctx context.Context, log lo
 	}
 
 	builderName := ComputeBuilderName(buildWorkload.Spec.Buildpacks)
+	if clusterBuilderName != r.controllerConfig.ClusterBuilderName {
+		builderName = ComputeStackBuilderName(clusterBuilderName, buildWorkload.Spec.Buildpacks)
+	}
 	builderRepo := fmt.Sprintf("%sbuilders-%s", r.imageRepoPrefix, builderName)
 	err = r.imageRepoCreator.CreateRepository(ctx, builderRepo)
 	if err != nil {
@@ -475,8 +525,8 @@ func (r *BuildWorkloadReconciler) ensureKpackBuilder(ctx context.Context, log lo
 		}
 
 		builder.Spec.Tag = builderRepo
-		builder.Spec.Stack = defaultBuilder.Spec.Stack
-		builder.Spec.Store = defaultBuilder.Spec.Store
+		builder.Spec.Stack = clusterBuilder.Spec.Stack
+		builder.Spec.Store = clusterBuilder.Spec.Store
 		builder.Spec.ServiceAccountName = r.controllerConfig.BuilderServiceAccount
 		builder.Spec.Order = nil
 		for _, bp := range buildWorkload.Spec.Buildpacks {
@@ -505,9 +555,16 @@ func ComputeBuilderName(bps []string) string {
 	return uuid.NewSHA1(uuid.Nil, []byte(strings.Join(bps, "\x00"))).String()
 }
 
-func (r *BuildWorkloadReconciler) checkBuildpacks(ctx context.Context, buildWorkload *korifiv1alpha1.BuildWorkload, defaultBuilder *buildv1alpha2.ClusterBuilder) error {
+// ComputeStackBuilderName is used for builders based on a stack
+// ClusterBuilder, so that the names of the builders based on the default
+// ClusterBuilder stay unchanged
+func ComputeStackBuilderName(clusterBuilderName string, bps []string) string {
+	return uuid.NewSHA1(uuid.Nil, []byte(strings.Join(append([]string{clusterBuilderName}, bps...), "\x00"))).String()
+}
+
+func (r *BuildWorkloadReconciler) checkBuildpacks(ctx context.Context, buildWorkload *korifiv1alpha1.BuildWorkload, clusterBuilder *buildv1alpha2.ClusterBuilder) error {
 	validIDs := map[string]bool{}
-	for _, bp := range clusterBuilderToBuildpacks(defaultBuilder, metav1.Now()) {
+	for _, bp := range clusterBuilderToBuildpacks(clusterBuilder, metav1.Now()) {
 		validIDs[bp.Name] = true
 	}
 
@@ -635,6 +692,7 @@ func (r *BuildWorkloadReconciler) reconcileKpackImage(
 	ctx context.Context,
 	log logr.Logger,
 	buildWorkload *korifiv1alpha1.BuildWorkload,
+	clusterBuilderName string,
 	customBuilderName string,
 ) error {
 	appGUID := buildWorkload.Labels[korifiv1alpha1.CFAppGUIDLabelKey]
@@ -689,7 +747,7 @@ func (r *BuildWorkloadReconciler) reconcileKpackImage(
 			Tag: kpackImageTag,
 			Builder: corev1.ObjectReference{
 				Kind:       clusterBuilderKind,
-				Name:       r.controllerConfig.ClusterBuilderName,
+				Name:       clusterBuilderName,
 				APIVersion: clusterBuilderAPIVersion,
 			},
 			ServiceAccountName: r.controllerConfig.BuilderServiceAccount,
@@ -710,6 +768,10 @@ func (r *BuildWorkloadReconciler) reconcileKpackImage(
 				},
 			},
 		}
+		if r.controllerConfig.StageInIsolationSegments {
+			desiredKpackImage.Spec.Build.NodeSelector = buildWorkload.Spec.NodeSelector
+			desiredKpackImage.Spec.Build.Tolerations = buildWorkload.Spec.Tolerations
+		}
 		if customBuilderName != "" {
 			desiredKpackImage.Spec.Builder.Kind = "Builder"
 			desiredKpackImage.Spec.Builder.Name = customBuilderName
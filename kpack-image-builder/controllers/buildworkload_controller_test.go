@@ -44,10 +44,16 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 		buildpacks                []string
 		imageRepoCreatorCallCount int
 		expectedCacheVolumeSize   string
+		nodeSelector              map[string]string
+		tolerations               []corev1.Toleration
+		stack                     string
 	)
 
 	BeforeEach(func() {
 		expectedCacheVolumeSize = "1024Mi"
+		nodeSelector = nil
+		tolerations = nil
+		stack = ""
 		reconcilerName = "kpack-image-builder"
 		namespaceGUID = PrefixedGUID("namespace")
 		Expect(adminClient.Create(ctx, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: namespaceGUID}})).To(Succeed())
@@ -190,6 +196,9 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 	Describe("BuildWorkload initialization phase", func() {
 		JustBeforeEach(func() {
 			buildWorkload = buildWorkloadObject(buildWorkloadGUID, namespaceGUID, source, env, services, reconcilerName, buildpacks)
+			buildWorkload.Spec.NodeSelector = nodeSelector
+			buildWorkload.Spec.Tolerations = tolerations
+			buildWorkload.Spec.Stack = stack
 			Expect(adminClient.Create(ctx, buildWorkload)).To(Succeed())
 		})
 
@@ -235,6 +244,92 @@ var _ = Describe("BuildWorkloadReconciler", func() {
 
 		CheckInitialization()
 
+		When("the build workload is placed into an isolation segment", func() {
+			BeforeEach(func() {
+				nodeSelector = map[string]string{"pool": "regulated"}
+				tolerations = []corev1.Toleration{{
+					Key:      "pool",
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoSchedule,
+				}}
+			})
+
+			It("sets the node selector and tolerations of the kpack image build", func() {
+				Eventually(func(g Gomega) {
+					kpackImage := new(buildv1alpha2.Image)
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: "app-guid", Namespace: namespaceGUID}, kpackImage)).To(Succeed())
+					g.Expect(kpackImage.Spec.Build).ToNot(BeNil())
+					g.Expect(kpackImage.Spec.Build.NodeSelector).To(Equal(nodeSelector))
+					g.Expect(kpackImage.Spec.Build.Tolerations).To(Equal(tolerations))
+				}).Should(Succeed())
+			})
+		})
+
+		When("the build workload requests a stack", func() {
+			var stackClusterBuilder *buildv1alpha2.ClusterBuilder
+
+			BeforeEach(func() {
+				stack = "other-stack"
+
+				stackClusterBuilder = &buildv1alpha2.ClusterBuilder{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "cf-kpack-other-stack-builder",
+					},
+				}
+				Expect(adminClient.Create(ctx, stackClusterBuilder)).To(Succeed())
+				Expect(k8s.Patch(ctx, adminClient, stackClusterBuilder, func() {
+					stackClusterBuilder.Status.Stack.ID = "other-stack"
+				})).To(Succeed())
+			})
+
+			AfterEach(func() {
+				Expect(adminClient.Delete(ctx, stackClusterBuilder)).To(Succeed())
+			})
+
+			It("builds the kpack image with the stack ClusterBuilder", func() {
+				Eventually(func(g Gomega) {
+					kpackImage := new(buildv1alpha2.Image)
+					g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: "app-guid", Namespace: namespaceGUID}, kpackImage)).To(Succeed())
+					g.Expect(kpackImage.Spec.Builder.Kind).To(Equal("ClusterBuilder"))
+					g.Expect(kpackImage.Spec.Builder.Name).To(Equal("cf-kpack-other-stack-builder"))
+				}).Should(Succeed())
+			})
+
+			When("no stack ClusterBuilder provides the stack", func() {
+				BeforeEach(func() {
+					stack = "unknown-stack"
+				})
+
+				It("fails the build workload", func() {
+					Eventually(func(g Gomega) {
+						updatedBuildWorkload := new(korifiv1alpha1.BuildWorkload)
+						g.Expect(adminClient.Get(ctx, client.ObjectKeyFromObject(buildWorkload), updatedBuildWorkload)).To(Succeed())
+						succeededCondition := mustHaveCondition(g, updatedBuildWorkload.Status.Conditions, "Succeeded")
+						g.Expect(succeededCondition.Status).To(Equal(metav1.ConditionFalse))
+						g.Expect(succeededCondition.Reason).To(Equal("UnknownStack"))
+					}).Should(Succeed())
+
+					Consistently(func(g Gomega) {
+						g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: "app-guid", Namespace: namespaceGUID}, new(buildv1alpha2.Image))).To(MatchError(ContainSubstring("not found")))
+					}).Should(Succeed())
+				})
+			})
+
+			When("the build workload requests the default stack", func() {
+				BeforeEach(func() {
+					stack = "cflinuxfs3"
+				})
+
+				It("builds the kpack image with the default ClusterBuilder", func() {
+					Eventually(func(g Gomega) {
+						kpackImage := new(buildv1alpha2.Image)
+						g.Expect(adminClient.Get(ctx, types.NamespacedName{Name: "app-guid", Namespace: namespaceGUID}, kpackImage)).To(Succeed())
+						g.Expect(kpackImage.Spec.Builder.Name).To(Equal("cf-kpack-builder"))
+					}).Should(Succeed())
+				})
+			})
+		})
+
 		When("kpack image already exists", func() {
 			BeforeEach(func() {
 				Expect(adminClient.Create(ctx, &buildv1alpha2.Image{
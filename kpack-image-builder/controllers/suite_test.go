@@ -50,7 +50,8 @@ import (
 // http://onsi.github.io/ginkgo/ to learn more about Ginkgo.
 
 const (
-	clusterBuilderName = "my-amazing-cluster-builder"
+	clusterBuilderName      = "my-amazing-cluster-builder"
+	stackClusterBuilderName = "my-other-stack-cluster-builder"
 )
 
 var (
@@ -108,8 +109,11 @@ var _ = BeforeSuite(func() {
 	controllerConfig := &config.ControllerConfig{
 		CFRootNamespace:           PrefixedGUID("cf"),
 		ClusterBuilderName:        "cf-kpack-builder",
+		StackClusterBuilderNames:  []string{"cf-kpack-other-stack-builder"},
+		DefaultStack:              "cflinuxfs3",
 		ContainerRepositoryPrefix: "image/registry/tag",
 		BuilderServiceAccount:     "builder-service-account",
+		StageInIsolationSegments:  true,
 		CFStagingResources: config.CFStagingResources{
 			BuildCacheMB: 1024,
 			DiskMB:       2048,
@@ -138,6 +142,7 @@ var _ = BeforeSuite(func() {
 			k8sManager.GetScheme(),
 			ctrl.Log.WithName("kpack-image-builder").WithName("BuilderInfo"),
 			clusterBuilderName,
+			[]string{stackClusterBuilderName},
 			controllerConfig.CFRootNamespace,
 		).SetupWithManager(k8sManager),
 	).To(Succeed())
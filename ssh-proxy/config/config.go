@@ -0,0 +1,97 @@
+package config
+
+import (
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/korifi/tools"
+
+	"go.uber.org/zap/zapcore"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	defaultListenPort          = 2222
+	defaultCodeCleanupInterval = 5 * time.Minute
+)
+
+type SSHProxyConfig struct {
+	ListenPort          int           `yaml:"listenPort"`
+	HostKeyPath         string        `yaml:"hostKeyPath"`
+	RootNamespace       string        `yaml:"rootNamespace"`
+	CodeCleanupInterval string        `yaml:"codeCleanupInterval"`
+	AuthProxyHost       string        `yaml:"authProxyHost"`
+	AuthProxyCACert     string        `yaml:"authProxyCACert"`
+	LogLevel            zapcore.Level `yaml:"logLevel"`
+}
+
+func LoadFromPath(path string) (*SSHProxyConfig, error) {
+	var config SSHProxyConfig
+	err := tools.LoadConfigInto(&config, path)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = config.validate(); err != nil {
+		return nil, err
+	}
+
+	if config.ListenPort == 0 {
+		config.ListenPort = defaultListenPort
+	}
+
+	return &config, nil
+}
+
+func GetLogLevelFromPath(path string) (zapcore.Level, error) {
+	cfg, err := LoadFromPath(path)
+	if err != nil {
+		return zapcore.InfoLevel, err
+	}
+
+	return cfg.LogLevel, nil
+}
+
+func (c *SSHProxyConfig) validate() error {
+	if c.HostKeyPath == "" {
+		return errors.New("HostKeyPath must have a value")
+	}
+
+	if c.RootNamespace == "" {
+		return errors.New("RootNamespace must have a value")
+	}
+
+	if c.AuthProxyHost != "" && c.AuthProxyCACert == "" {
+		return errors.New("AuthProxyHost requires a value for AuthProxyCACert")
+	}
+
+	if c.AuthProxyCACert != "" && c.AuthProxyHost == "" {
+		return errors.New("AuthProxyCACert requires a value for AuthProxyHost")
+	}
+
+	if c.CodeCleanupInterval != "" {
+		if _, err := tools.ParseDuration(c.CodeCleanupInterval); err != nil {
+			return errors.New(`invalid duration format for codeCleanupInterval. Use a format like "5m"`)
+		}
+	}
+
+	return nil
+}
+
+func (c *SSHProxyConfig) ParseCodeCleanupInterval() time.Duration {
+	if c.CodeCleanupInterval == "" {
+		return defaultCodeCleanupInterval
+	}
+
+	d, _ := tools.ParseDuration(c.CodeCleanupInterval)
+	return d
+}
+
+func (c *SSHProxyConfig) GenerateK8sClientConfig(k8sClientConfig *rest.Config) *rest.Config {
+	if c.AuthProxyHost != "" && c.AuthProxyCACert != "" {
+		k8sClientConfig.Host = c.AuthProxyHost
+		k8sClientConfig.CAData = []byte(c.AuthProxyCACert)
+	}
+
+	return k8sClientConfig
+}
@@ -0,0 +1,130 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"code.cloudfoundry.org/korifi/ssh-proxy/config"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/yaml.v3"
+	"k8s.io/client-go/rest"
+)
+
+var _ = Describe("Config", func() {
+	var (
+		configPath string
+		cfg        map[string]any
+		retConfig  *config.SSHProxyConfig
+		retErr     error
+	)
+
+	BeforeEach(func() {
+		var err error
+		configPath, err = os.MkdirTemp("", "config")
+		Expect(err).NotTo(HaveOccurred())
+
+		cfg = map[string]any{
+			"listenPort":          2022,
+			"hostKeyPath":         "/etc/ssh-proxy/host-key",
+			"rootNamespace":       "cf",
+			"codeCleanupInterval": "10m",
+			"logLevel":            "debug",
+		}
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(configPath)).To(Succeed())
+	})
+
+	JustBeforeEach(func() {
+		configYAML, err := yaml.Marshal(cfg)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(os.WriteFile(filepath.Join(configPath, "file1"), configYAML, 0o644)).To(Succeed())
+		retConfig, retErr = config.LoadFromPath(configPath)
+	})
+
+	It("loads the configuration", func() {
+		Expect(retErr).NotTo(HaveOccurred())
+		Expect(*retConfig).To(Equal(config.SSHProxyConfig{
+			ListenPort:          2022,
+			HostKeyPath:         "/etc/ssh-proxy/host-key",
+			RootNamespace:       "cf",
+			CodeCleanupInterval: "10m",
+			LogLevel:            zapcore.DebugLevel,
+		}))
+		Expect(retConfig.ParseCodeCleanupInterval()).To(Equal(10 * time.Minute))
+	})
+
+	When("optional values are not set", func() {
+		BeforeEach(func() {
+			delete(cfg, "listenPort")
+			delete(cfg, "codeCleanupInterval")
+		})
+
+		It("uses the defaults", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+			Expect(retConfig.ListenPort).To(Equal(2222))
+			Expect(retConfig.ParseCodeCleanupInterval()).To(Equal(5 * time.Minute))
+		})
+	})
+
+	When("the host key path is not set", func() {
+		BeforeEach(func() {
+			delete(cfg, "hostKeyPath")
+		})
+
+		It("returns an error", func() {
+			Expect(retErr).To(MatchError("HostKeyPath must have a value"))
+		})
+	})
+
+	When("the root namespace is not set", func() {
+		BeforeEach(func() {
+			delete(cfg, "rootNamespace")
+		})
+
+		It("returns an error", func() {
+			Expect(retErr).To(MatchError("RootNamespace must have a value"))
+		})
+	})
+
+	When("the code cleanup interval is invalid", func() {
+		BeforeEach(func() {
+			cfg["codeCleanupInterval"] = "often"
+		})
+
+		It("returns an error", func() {
+			Expect(retErr).To(MatchError(ContainSubstring("invalid duration format for codeCleanupInterval")))
+		})
+	})
+
+	When("only the auth proxy host is set", func() {
+		BeforeEach(func() {
+			cfg["authProxyHost"] = "auth-proxy.example.org"
+		})
+
+		It("returns an error", func() {
+			Expect(retErr).To(MatchError("AuthProxyHost requires a value for AuthProxyCACert"))
+		})
+	})
+
+	When("the auth proxy is configured", func() {
+		BeforeEach(func() {
+			cfg["authProxyHost"] = "auth-proxy.example.org"
+			cfg["authProxyCACert"] = "the-ca-cert"
+		})
+
+		It("points the k8s client config at the auth proxy", func() {
+			Expect(retErr).NotTo(HaveOccurred())
+
+			k8sConfig := retConfig.GenerateK8sClientConfig(&rest.Config{Host: "kubernetes.default"})
+			Expect(k8sConfig.Host).To(Equal("auth-proxy.example.org"))
+			Expect(k8sConfig.CAData).To(Equal([]byte("the-ca-cert")))
+		})
+	})
+})
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/actions"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/api/repositories"
+	"code.cloudfoundry.org/korifi/api/repositories/conditions"
+	korifiv1alpha1 "code.cloudfoundry.org/korifi/controllers/api/v1alpha1"
+	"code.cloudfoundry.org/korifi/ssh-proxy/config"
+	"code.cloudfoundry.org/korifi/ssh-proxy/pods"
+	"code.cloudfoundry.org/korifi/ssh-proxy/proxy"
+	"code.cloudfoundry.org/korifi/tools"
+	"code.cloudfoundry.org/korifi/tools/k8s"
+	"code.cloudfoundry.org/korifi/version"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/ssh"
+	"k8s.io/apimachinery/pkg/util/cache"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes/scheme"
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cfapps;cfprocesses;cfspaces,verbs=list
+//+kubebuilder:rbac:groups=korifi.cloudfoundry.org,resources=cffeatureflags,verbs=get
+//+kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+//+kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=list
+//+kubebuilder:rbac:groups="",resources=namespaces,verbs=list
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;delete,namespace=ROOT_NAMESPACE
+
+var createTimeout = time.Second * 120
+
+func init() {
+	utilruntime.Must(korifiv1alpha1.AddToScheme(scheme.Scheme))
+}
+
+func main() {
+	configPath, found := os.LookupEnv("SSHPROXYCONFIG")
+	if !found {
+		panic("SSHPROXYCONFIG must be set")
+	}
+	cfg, err := config.LoadFromPath(configPath)
+	if err != nil {
+		errorMessage := fmt.Sprintf("Config could not be read: %v", err)
+		panic(errorMessage)
+	}
+	k8sClientConfig := cfg.GenerateK8sClientConfig(ctrl.GetConfigOrDie())
+
+	logger, atomicLevel, err := tools.NewZapLogger(cfg.LogLevel)
+	if err != nil {
+		panic(fmt.Sprintf("error creating new zap logger: %v", err))
+	}
+	ctrl.SetLogger(logger)
+	klog.SetLogger(ctrl.Log)
+
+	eventChan := make(chan string)
+	go func() {
+		ctrl.Log.Info("starting to watch config file at "+configPath+" for logger level changes", "currentLevel", atomicLevel.Level())
+		if err2 := tools.WatchForConfigChangeEvents(context.Background(), configPath, ctrl.Log, eventChan); err2 != nil {
+			ctrl.Log.Error(err2, "error watching logging config")
+			os.Exit(1)
+		}
+	}()
+
+	go tools.SyncLogLevel(context.Background(), ctrl.Log, eventChan, atomicLevel, config.GetLogLevelFromPath)
+
+	ctrl.Log.Info("starting Korifi SSH proxy", "version", version.Version)
+
+	privilegedCRClient, err := client.NewWithWatch(k8sClientConfig, client.Options{})
+	if err != nil {
+		panic(fmt.Sprintf("could not create privileged k8s client: %v", err))
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(k8sClientConfig)
+	if err != nil {
+		panic(fmt.Sprintf("could not create dynamic k8s client: %v", err))
+	}
+	namespaceRetriever := repositories.NewNamespaceRetriever(dynamicClient)
+
+	httpClient, err := rest.HTTPClientFor(k8sClientConfig)
+	if err != nil {
+		panic(fmt.Sprintf("could not create http client from k8s rest config: %v", err))
+	}
+	mapper, err := apiutil.NewDynamicRESTMapper(k8sClientConfig, httpClient)
+	if err != nil {
+		panic(fmt.Sprintf("could not create kubernetes REST mapper: %v", err))
+	}
+
+	userClientFactory := authorization.NewUnprivilegedClientFactory(k8sClientConfig, mapper, k8s.NewDefaultBackoff())
+
+	identityProvider := authorization.NewCertTokenIdentityProvider(
+		authorization.NewTokenReviewer(privilegedCRClient),
+		authorization.NewCertInspector(k8sClientConfig),
+	)
+	cachingIdentityProvider := authorization.NewCachingIdentityProvider(identityProvider, cache.NewExpiring())
+	nsPermissions := authorization.NewNamespacePermissions(privilegedCRClient, cachingIdentityProvider)
+
+	orgRepo := repositories.NewOrgRepo(
+		cfg.RootNamespace,
+		privilegedCRClient,
+		userClientFactory,
+		nsPermissions,
+		createTimeout,
+	)
+	spaceRepo := repositories.NewSpaceRepo(
+		namespaceRetriever,
+		orgRepo,
+		userClientFactory,
+		nsPermissions,
+		createTimeout,
+	)
+	processRepo := repositories.NewProcessRepo(
+		namespaceRetriever,
+		userClientFactory,
+		nsPermissions,
+	)
+	appRepo := repositories.NewAppRepo(
+		namespaceRetriever,
+		userClientFactory,
+		nsPermissions,
+		conditions.NewConditionAwaiter[*korifiv1alpha1.CFApp, korifiv1alpha1.CFAppList](createTimeout),
+	)
+	podRepo := repositories.NewPodRepo(
+		userClientFactory,
+	)
+	featureFlagRepo := repositories.NewFeatureFlagRepo(
+		userClientFactory,
+		privilegedCRClient,
+		cfg.RootNamespace,
+	)
+	sshCodeRepo := repositories.NewSSHCodeRepo(
+		privilegedCRClient,
+		cfg.RootNamespace,
+	)
+	sshAccess := actions.NewSSHAccess(processRepo, appRepo, spaceRepo, podRepo, featureFlagRepo)
+
+	hostKeyBytes, err := os.ReadFile(cfg.HostKeyPath)
+	if err != nil {
+		panic(fmt.Sprintf("could not read host key: %v", err))
+	}
+	hostKey, err := ssh.ParsePrivateKey(hostKeyBytes)
+	if err != nil {
+		panic(fmt.Sprintf("could not parse host key: %v", err))
+	}
+
+	ctx := logr.NewContext(ctrl.SetupSignalHandler(), ctrl.Log)
+
+	go wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := sshCodeRepo.DeleteExpiredSSHCodes(ctx); err != nil {
+			ctrl.Log.Error(err, "error deleting expired ssh codes")
+		}
+	}, cfg.ParseCodeCleanupInterval())
+
+	portString := fmt.Sprintf(":%v", cfg.ListenPort)
+	listener, err := net.Listen("tcp", portString)
+	if err != nil {
+		ctrl.Log.Error(err, "error listening on "+portString)
+		os.Exit(1)
+	}
+
+	ctrl.Log.Info("listening on "+portString, "hostKeyFingerprint", strings.TrimPrefix(ssh.FingerprintSHA256(hostKey.PublicKey()), "SHA256:"))
+	server := proxy.NewServer(hostKey, sshCodeRepo, sshAccess, pods.NewConnector(userClientFactory))
+	if err = server.Serve(ctx, listener); err != nil {
+		ctrl.Log.Error(err, "error serving SSH")
+		os.Exit(1)
+	}
+}
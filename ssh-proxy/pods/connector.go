@@ -0,0 +1,56 @@
+package pods
+
+import (
+	"fmt"
+	"net/url"
+
+	"code.cloudfoundry.org/korifi/api/authorization"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+)
+
+//counterfeiter:generate -o fake -fake-name ConfigBuilder . ConfigBuilder
+
+type ConfigBuilder interface {
+	BuildConfig(authInfo authorization.Info) (*rest.Config, error)
+}
+
+// Connector opens exec and port forward streams to pods on behalf of a user,
+// so that the kubernetes RBAC of the user applies
+type Connector struct {
+	configBuilder ConfigBuilder
+}
+
+func NewConnector(configBuilder ConfigBuilder) *Connector {
+	return &Connector{
+		configBuilder: configBuilder,
+	}
+}
+
+// podSubresource returns the rest config of the user together with the URL of
+// the subresource of the pod
+func (c *Connector) podSubresource(authInfo authorization.Info, namespace, podName, subresource string, params runtime.Object) (*rest.Config, *url.URL, error) {
+	config, err := c.configBuilder.BuildConfig(authInfo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to build rest config: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create clientset: %w", err)
+	}
+
+	request := clientset.CoreV1().RESTClient().Post().
+		Namespace(namespace).
+		Resource("pods").
+		Name(podName).
+		SubResource(subresource)
+	if params != nil {
+		request = request.VersionedParams(params, scheme.ParameterCodec)
+	}
+
+	return config, request.URL(), nil
+}
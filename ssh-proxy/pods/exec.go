@@ -0,0 +1,99 @@
+package pods
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"code.cloudfoundry.org/korifi/api/actions"
+	"code.cloudfoundry.org/korifi/api/authorization"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/tools/remotecommand"
+	"k8s.io/client-go/util/exec"
+)
+
+type TerminalSize struct {
+	Width  uint16
+	Height uint16
+}
+
+type ExecOptions struct {
+	Command []string
+	TTY     bool
+	Stdin   io.Reader
+	Stdout  io.Writer
+	Stderr  io.Writer
+	Resize  <-chan TerminalSize
+}
+
+// Exec runs the command in the target container and returns its exit code.
+// The end of stdin is passed on to the container.
+func (c *Connector) Exec(ctx context.Context, authInfo authorization.Info, target actions.SSHTarget, opts ExecOptions) (int, error) {
+	execOptions := &corev1.PodExecOptions{
+		Container: target.Container,
+		Command:   opts.Command,
+		Stdin:     opts.Stdin != nil,
+		Stdout:    true,
+		Stderr:    !opts.TTY,
+		TTY:       opts.TTY,
+	}
+
+	config, execURL, err := c.podSubresource(authInfo, target.Namespace, target.PodName, "exec", execOptions)
+	if err != nil {
+		return 0, err
+	}
+
+	executor, err := remotecommand.NewSPDYExecutor(config, http.MethodPost, execURL)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create executor: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	streamOptions := remotecommand.StreamOptions{
+		Stdin:  opts.Stdin,
+		Stdout: opts.Stdout,
+		Tty:    opts.TTY,
+	}
+	if !opts.TTY {
+		streamOptions.Stderr = opts.Stderr
+	}
+	if opts.Resize != nil {
+		streamOptions.TerminalSizeQueue = terminalSizeQueue{sizes: opts.Resize, done: ctx.Done()}
+	}
+
+	err = executor.StreamWithContext(ctx, streamOptions)
+	if err == nil {
+		return 0, nil
+	}
+
+	var exitErr exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.Exited() {
+		return exitErr.ExitStatus(), nil
+	}
+
+	return 0, err
+}
+
+// terminalSizeQueue hands the terminal sizes to the executor until the command
+// has completed
+type terminalSizeQueue struct {
+	sizes <-chan TerminalSize
+	done  <-chan struct{}
+}
+
+func (q terminalSizeQueue) Next() *remotecommand.TerminalSize {
+	select {
+	case size, ok := <-q.sizes:
+		if !ok {
+			return nil
+		}
+		return &remotecommand.TerminalSize{Width: size.Width, Height: size.Height}
+	case <-q.done:
+		return nil
+	}
+}
@@ -0,0 +1,207 @@
+package pods_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+
+	"code.cloudfoundry.org/korifi/api/actions"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/ssh-proxy/pods"
+	"code.cloudfoundry.org/korifi/ssh-proxy/pods/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/rest"
+)
+
+var _ = Describe("Exec", func() {
+	const execProtocol = "v4.channel.k8s.io"
+
+	var (
+		handler       func(<-chan httpstream.Stream)
+		requests      <-chan receivedRequest
+		configBuilder *fake.ConfigBuilder
+		connector     *pods.Connector
+		opts          pods.ExecOptions
+		stdout        *bytes.Buffer
+		stderr        *bytes.Buffer
+		buildErr      error
+		exitCode      int
+		execErr       error
+	)
+
+	BeforeEach(func() {
+		handler = func(streamCh <-chan httpstream.Stream) {
+			defer GinkgoRecover()
+
+			streams := receiveStreams(streamCh, 4)
+			defer streams[corev1.StreamTypeError].Close()
+			defer streams[corev1.StreamTypeStdout].Close()
+			defer streams[corev1.StreamTypeStderr].Close()
+
+			write(streams[corev1.StreamTypeStdout], "hello")
+			write(streams[corev1.StreamTypeStderr], "oops")
+
+			// reading stdin to its end only completes when the end of stdin
+			// reaches the container
+			stdin, err := io.ReadAll(streams[corev1.StreamTypeStdin])
+			Expect(err).NotTo(HaveOccurred())
+			write(streams[corev1.StreamTypeStdout], strings.ToUpper(string(stdin)))
+
+			write(streams[corev1.StreamTypeError], `{"status":"Success"}`)
+		}
+
+		stdout = new(bytes.Buffer)
+		stderr = new(bytes.Buffer)
+		opts = pods.ExecOptions{
+			Command: []string{"/bin/sh", "-c", "cat"},
+			Stdin:   strings.NewReader("world"),
+			Stdout:  stdout,
+			Stderr:  stderr,
+		}
+
+		buildErr = nil
+		configBuilder = new(fake.ConfigBuilder)
+		connector = pods.NewConnector(configBuilder)
+	})
+
+	JustBeforeEach(func() {
+		var server *httptest.Server
+		server, requests = newStreamServer(execProtocol, handler)
+		configBuilder.BuildConfigReturns(&rest.Config{Host: server.URL, BearerToken: "the-token"}, buildErr)
+
+		exitCode, execErr = connector.Exec(ctx, authorization.Info{Token: "the-token"}, actions.SSHTarget{
+			Namespace: "the-namespace",
+			PodName:   "the-pod",
+			Container: "application",
+		}, opts)
+	})
+
+	It("runs the command in the container", func() {
+		Expect(execErr).NotTo(HaveOccurred())
+		Expect(exitCode).To(Equal(0))
+
+		Expect(configBuilder.BuildConfigCallCount()).To(Equal(1))
+		Expect(configBuilder.BuildConfigArgsForCall(0)).To(Equal(authorization.Info{Token: "the-token"}))
+
+		var request receivedRequest
+		Eventually(requests).Should(Receive(&request))
+		Expect(request.path).To(Equal("/api/v1/namespaces/the-namespace/pods/the-pod/exec"))
+		Expect(request.authorization).To(Equal("Bearer the-token"))
+		Expect(request.query).To(Equal(map[string][]string{
+			"container": {"application"},
+			"command":   {"/bin/sh", "-c", "cat"},
+			"stdin":     {"true"},
+			"stdout":    {"true"},
+			"stderr":    {"true"},
+		}))
+	})
+
+	It("streams stdin up to its end, stdout and stderr", func() {
+		Expect(execErr).NotTo(HaveOccurred())
+		Expect(stdout.String()).To(Equal("helloWORLD"))
+		Expect(stderr.String()).To(Equal("oops"))
+	})
+
+	When("a terminal is requested", func() {
+		var resizes chan pods.TerminalSize
+
+		BeforeEach(func() {
+			resizes = make(chan pods.TerminalSize, 1)
+			resizes <- pods.TerminalSize{Width: 80, Height: 24}
+
+			opts.TTY = true
+			opts.Stdin = nil
+			opts.Resize = resizes
+
+			handler = func(streamCh <-chan httpstream.Stream) {
+				defer GinkgoRecover()
+
+				streams := receiveStreams(streamCh, 3)
+				defer streams[corev1.StreamTypeError].Close()
+				defer streams[corev1.StreamTypeStdout].Close()
+
+				size := make([]byte, 64)
+				n, err := streams[corev1.StreamTypeResize].Read(size)
+				Expect(err).NotTo(HaveOccurred())
+				write(streams[corev1.StreamTypeStdout], string(size[:n]))
+
+				write(streams[corev1.StreamTypeError], `{"status":"Success"}`)
+			}
+		})
+
+		It("requests a tty and forwards the terminal size", func() {
+			Expect(execErr).NotTo(HaveOccurred())
+			Expect(stdout.String()).To(MatchJSON(`{"Width":80,"Height":24}`))
+
+			var request receivedRequest
+			Eventually(requests).Should(Receive(&request))
+			Expect(request.query).To(HaveKeyWithValue("tty", []string{"true"}))
+			Expect(request.query).NotTo(HaveKey("stderr"))
+			Expect(request.query).NotTo(HaveKey("stdin"))
+		})
+	})
+
+	When("the command exits with a non-zero code", func() {
+		BeforeEach(func() {
+			opts.Stdin = nil
+
+			handler = func(streamCh <-chan httpstream.Stream) {
+				defer GinkgoRecover()
+
+				streams := receiveStreams(streamCh, 3)
+				streams[corev1.StreamTypeStdout].Close()
+				streams[corev1.StreamTypeStderr].Close()
+
+				write(streams[corev1.StreamTypeError], `{
+					"status": "Failure",
+					"reason": "NonZeroExitCode",
+					"message": "command terminated with non-zero exit code",
+					"details": {"causes": [{"reason": "ExitCode", "message": "42"}]}
+				}`)
+				streams[corev1.StreamTypeError].Close()
+			}
+		})
+
+		It("returns the exit code", func() {
+			Expect(execErr).NotTo(HaveOccurred())
+			Expect(exitCode).To(Equal(42))
+		})
+	})
+
+	When("the command cannot be run", func() {
+		BeforeEach(func() {
+			opts.Stdin = nil
+
+			handler = func(streamCh <-chan httpstream.Stream) {
+				defer GinkgoRecover()
+
+				streams := receiveStreams(streamCh, 3)
+				streams[corev1.StreamTypeStdout].Close()
+				streams[corev1.StreamTypeStderr].Close()
+
+				write(streams[corev1.StreamTypeError], `{"status": "Failure", "message": "container not found"}`)
+				streams[corev1.StreamTypeError].Close()
+			}
+		})
+
+		It("returns an error", func() {
+			Expect(execErr).To(MatchError(ContainSubstring("container not found")))
+		})
+	})
+
+	When("building the config fails", func() {
+		BeforeEach(func() {
+			buildErr = errors.New("build-config")
+		})
+
+		It("returns an error", func() {
+			Expect(execErr).To(MatchError(ContainSubstring("failed to build rest config")))
+		})
+	})
+})
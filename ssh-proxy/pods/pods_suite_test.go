@@ -0,0 +1,82 @@
+package pods_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/httpstream/spdy"
+)
+
+var ctx context.Context
+
+func TestPods(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Pods Suite")
+}
+
+var _ = BeforeEach(func() {
+	ctx = context.Background()
+})
+
+type receivedRequest struct {
+	path          string
+	query         map[string][]string
+	authorization string
+}
+
+// newStreamServer starts a server that upgrades connections to SPDY with the
+// given kubernetes streaming protocol and hands the streams opened by the
+// client to the handler
+func newStreamServer(protocol string, handler func(<-chan httpstream.Stream)) (*httptest.Server, <-chan receivedRequest) {
+	requests := make(chan receivedRequest, 10)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		requests <- receivedRequest{
+			path:          req.URL.Path,
+			query:         req.URL.Query(),
+			authorization: req.Header.Get("Authorization"),
+		}
+
+		if _, err := httpstream.Handshake(req, w, []string{protocol}); err != nil {
+			return
+		}
+
+		streams := make(chan httpstream.Stream, 10)
+		conn := spdy.NewResponseUpgrader().UpgradeResponse(w, req, func(stream httpstream.Stream, _ <-chan struct{}) error {
+			streams <- stream
+			return nil
+		})
+		if conn == nil {
+			return
+		}
+		defer conn.Close()
+
+		handler(streams)
+	}))
+	DeferCleanup(server.Close)
+
+	return server, requests
+}
+
+// receiveStreams waits for the given number of streams and returns them by
+// their stream type
+func receiveStreams(streams <-chan httpstream.Stream, count int) map[string]httpstream.Stream {
+	received := map[string]httpstream.Stream{}
+	for i := 0; i < count; i++ {
+		var stream httpstream.Stream
+		Eventually(streams).Should(Receive(&stream))
+		received[stream.Headers().Get(corev1.StreamType)] = stream
+	}
+	return received
+}
+
+func write(stream httpstream.Stream, data string) {
+	_, err := stream.Write([]byte(data))
+	Expect(err).NotTo(HaveOccurred())
+}
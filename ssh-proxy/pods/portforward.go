@@ -0,0 +1,77 @@
+package pods
+
+import (
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/actions"
+	"code.cloudfoundry.org/korifi/api/authorization"
+
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+)
+
+// PortForward opens a connection to the port of the target pod. Every
+// connection uses its own port forwarder, listening on a random loopback port
+// for that connection only.
+func (c *Connector) PortForward(authInfo authorization.Info, target actions.SSHTarget, port uint16) (io.ReadWriteCloser, error) {
+	config, portForwardURL, err := c.podSubresource(authInfo, target.Namespace, target.PodName, "portforward", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	transport, upgrader, err := spdy.RoundTripperFor(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create round tripper: %w", err)
+	}
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, http.MethodPost, portForwardURL)
+
+	stop := make(chan struct{})
+	ready := make(chan struct{})
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{"127.0.0.1"}, []string{fmt.Sprintf(":%d", port)}, stop, ready, io.Discard, io.Discard)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create port forwarder: %w", err)
+	}
+
+	forwardErr := make(chan error, 1)
+	go func() {
+		forwardErr <- forwarder.ForwardPorts()
+	}()
+
+	select {
+	case <-ready:
+	case err = <-forwardErr:
+		return nil, fmt.Errorf("failed to forward port %d: %w", port, err)
+	}
+
+	forwardedPorts, err := forwarder.GetPorts()
+	if err != nil {
+		close(stop)
+		return nil, fmt.Errorf("failed to get forwarded port: %w", err)
+	}
+
+	conn, err := net.Dial("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(int(forwardedPorts[0].Local))))
+	if err != nil {
+		close(stop)
+		return nil, fmt.Errorf("failed to connect to forwarded port: %w", err)
+	}
+
+	return &portForwardConn{Conn: conn, stop: stop}, nil
+}
+
+// portForwardConn stops the port forwarder when the connection is closed
+type portForwardConn struct {
+	net.Conn
+	stop     chan struct{}
+	stopOnce sync.Once
+}
+
+func (c *portForwardConn) Close() error {
+	err := c.Conn.Close()
+	c.stopOnce.Do(func() { close(c.stop) })
+	return err
+}
@@ -0,0 +1,128 @@
+package pods_test
+
+import (
+	"errors"
+	"io"
+	"net/http/httptest"
+
+	"code.cloudfoundry.org/korifi/api/actions"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/ssh-proxy/pods"
+	"code.cloudfoundry.org/korifi/ssh-proxy/pods/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/rest"
+)
+
+var _ = Describe("PortForward", func() {
+	const portForwardProtocol = "portforward.k8s.io"
+
+	var (
+		handler       func(<-chan httpstream.Stream)
+		requests      <-chan receivedRequest
+		ports         chan string
+		configBuilder *fake.ConfigBuilder
+		buildErr      error
+		stream        io.ReadWriteCloser
+		forwardErr    error
+	)
+
+	BeforeEach(func() {
+		ports = make(chan string, 1)
+		handler = func(streamCh <-chan httpstream.Stream) {
+			defer GinkgoRecover()
+
+			streams := receiveStreams(streamCh, 2)
+			defer streams[corev1.StreamTypeError].Close()
+			defer streams[corev1.StreamTypeData].Close()
+			ports <- streams[corev1.StreamTypeData].Headers().Get(corev1.PortHeader)
+
+			ping := make([]byte, 4)
+			_, err := io.ReadFull(streams[corev1.StreamTypeData], ping)
+			Expect(err).NotTo(HaveOccurred())
+			write(streams[corev1.StreamTypeData], "pong:"+string(ping))
+
+			// keep the connection open until the client is done
+			_, _ = io.Copy(io.Discard, streams[corev1.StreamTypeData])
+		}
+
+		buildErr = nil
+		configBuilder = new(fake.ConfigBuilder)
+	})
+
+	JustBeforeEach(func() {
+		var server *httptest.Server
+		server, requests = newStreamServer(portForwardProtocol, handler)
+		configBuilder.BuildConfigReturns(&rest.Config{Host: server.URL, BearerToken: "the-token"}, buildErr)
+
+		stream, forwardErr = pods.NewConnector(configBuilder).PortForward(authorization.Info{Token: "the-token"}, actions.SSHTarget{
+			Namespace: "the-namespace",
+			PodName:   "the-pod",
+			Container: "application",
+		}, 8080)
+	})
+
+	AfterEach(func() {
+		if stream != nil {
+			stream.Close()
+		}
+	})
+
+	It("forwards the port of the pod", func() {
+		Expect(forwardErr).NotTo(HaveOccurred())
+
+		var request receivedRequest
+		Eventually(requests).Should(Receive(&request))
+		Expect(request.path).To(Equal("/api/v1/namespaces/the-namespace/pods/the-pod/portforward"))
+		Expect(request.authorization).To(Equal("Bearer the-token"))
+
+		_, err := stream.Write([]byte("ping"))
+		Expect(err).NotTo(HaveOccurred())
+		Eventually(ports).Should(Receive(Equal("8080")))
+	})
+
+	It("streams the data of the forwarded port", func() {
+		Expect(forwardErr).NotTo(HaveOccurred())
+
+		_, err := stream.Write([]byte("ping"))
+		Expect(err).NotTo(HaveOccurred())
+
+		buf := make([]byte, 9)
+		_, err = io.ReadFull(stream, buf)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(buf)).To(Equal("pong:ping"))
+	})
+
+	When("forwarding fails", func() {
+		BeforeEach(func() {
+			handler = func(streamCh <-chan httpstream.Stream) {
+				defer GinkgoRecover()
+
+				streams := receiveStreams(streamCh, 2)
+				write(streams[corev1.StreamTypeError], "connection refused")
+				streams[corev1.StreamTypeError].Close()
+				streams[corev1.StreamTypeData].Close()
+			}
+		})
+
+		It("closes the connection", func() {
+			Expect(forwardErr).NotTo(HaveOccurred())
+
+			_, err := io.ReadAll(stream)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	When("building the config fails", func() {
+		BeforeEach(func() {
+			buildErr = errors.New("build-config")
+		})
+
+		It("returns an error", func() {
+			Expect(forwardErr).To(MatchError(ContainSubstring("failed to build rest config")))
+		})
+	})
+})
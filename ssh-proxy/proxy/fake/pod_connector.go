@@ -0,0 +1,210 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package fake
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/api/actions"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/ssh-proxy/pods"
+	"code.cloudfoundry.org/korifi/ssh-proxy/proxy"
+)
+
+type PodConnector struct {
+	ExecStub        func(context.Context, authorization.Info, actions.SSHTarget, pods.ExecOptions) (int, error)
+	execMutex       sync.RWMutex
+	execArgsForCall []struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 actions.SSHTarget
+		arg4 pods.ExecOptions
+	}
+	execReturns struct {
+		result1 int
+		result2 error
+	}
+	execReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	PortForwardStub        func(authorization.Info, actions.SSHTarget, uint16) (io.ReadWriteCloser, error)
+	portForwardMutex       sync.RWMutex
+	portForwardArgsForCall []struct {
+		arg1 authorization.Info
+		arg2 actions.SSHTarget
+		arg3 uint16
+	}
+	portForwardReturns struct {
+		result1 io.ReadWriteCloser
+		result2 error
+	}
+	portForwardReturnsOnCall map[int]struct {
+		result1 io.ReadWriteCloser
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *PodConnector) Exec(arg1 context.Context, arg2 authorization.Info, arg3 actions.SSHTarget, arg4 pods.ExecOptions) (int, error) {
+	fake.execMutex.Lock()
+	ret, specificReturn := fake.execReturnsOnCall[len(fake.execArgsForCall)]
+	fake.execArgsForCall = append(fake.execArgsForCall, struct {
+		arg1 context.Context
+		arg2 authorization.Info
+		arg3 actions.SSHTarget
+		arg4 pods.ExecOptions
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.ExecStub
+	fakeReturns := fake.execReturns
+	fake.recordInvocation("Exec", []interface{}{arg1, arg2, arg3, arg4})
+	fake.execMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PodConnector) ExecCallCount() int {
+	fake.execMutex.RLock()
+	defer fake.execMutex.RUnlock()
+	return len(fake.execArgsForCall)
+}
+
+func (fake *PodConnector) ExecCalls(stub func(context.Context, authorization.Info, actions.SSHTarget, pods.ExecOptions) (int, error)) {
+	fake.execMutex.Lock()
+	defer fake.execMutex.Unlock()
+	fake.ExecStub = stub
+}
+
+func (fake *PodConnector) ExecArgsForCall(i int) (context.Context, authorization.Info, actions.SSHTarget, pods.ExecOptions) {
+	fake.execMutex.RLock()
+	defer fake.execMutex.RUnlock()
+	argsForCall := fake.execArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *PodConnector) ExecReturns(result1 int, result2 error) {
+	fake.execMutex.Lock()
+	defer fake.execMutex.Unlock()
+	fake.ExecStub = nil
+	fake.execReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PodConnector) ExecReturnsOnCall(i int, result1 int, result2 error) {
+	fake.execMutex.Lock()
+	defer fake.execMutex.Unlock()
+	fake.ExecStub = nil
+	if fake.execReturnsOnCall == nil {
+		fake.execReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.execReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PodConnector) PortForward(arg1 authorization.Info, arg2 actions.SSHTarget, arg3 uint16) (io.ReadWriteCloser, error) {
+	fake.portForwardMutex.Lock()
+	ret, specificReturn := fake.portForwardReturnsOnCall[len(fake.portForwardArgsForCall)]
+	fake.portForwardArgsForCall = append(fake.portForwardArgsForCall, struct {
+		arg1 authorization.Info
+		arg2 actions.SSHTarget
+		arg3 uint16
+	}{arg1, arg2, arg3})
+	stub := fake.PortForwardStub
+	fakeReturns := fake.portForwardReturns
+	fake.recordInvocation("PortForward", []interface{}{arg1, arg2, arg3})
+	fake.portForwardMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *PodConnector) PortForwardCallCount() int {
+	fake.portForwardMutex.RLock()
+	defer fake.portForwardMutex.RUnlock()
+	return len(fake.portForwardArgsForCall)
+}
+
+func (fake *PodConnector) PortForwardCalls(stub func(authorization.Info, actions.SSHTarget, uint16) (io.ReadWriteCloser, error)) {
+	fake.portForwardMutex.Lock()
+	defer fake.portForwardMutex.Unlock()
+	fake.PortForwardStub = stub
+}
+
+func (fake *PodConnector) PortForwardArgsForCall(i int) (authorization.Info, actions.SSHTarget, uint16) {
+	fake.portForwardMutex.RLock()
+	defer fake.portForwardMutex.RUnlock()
+	argsForCall := fake.portForwardArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *PodConnector) PortForwardReturns(result1 io.ReadWriteCloser, result2 error) {
+	fake.portForwardMutex.Lock()
+	defer fake.portForwardMutex.Unlock()
+	fake.PortForwardStub = nil
+	fake.portForwardReturns = struct {
+		result1 io.ReadWriteCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PodConnector) PortForwardReturnsOnCall(i int, result1 io.ReadWriteCloser, result2 error) {
+	fake.portForwardMutex.Lock()
+	defer fake.portForwardMutex.Unlock()
+	fake.PortForwardStub = nil
+	if fake.portForwardReturnsOnCall == nil {
+		fake.portForwardReturnsOnCall = make(map[int]struct {
+			result1 io.ReadWriteCloser
+			result2 error
+		})
+	}
+	fake.portForwardReturnsOnCall[i] = struct {
+		result1 io.ReadWriteCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *PodConnector) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.execMutex.RLock()
+	defer fake.execMutex.RUnlock()
+	fake.portForwardMutex.RLock()
+	defer fake.portForwardMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *PodConnector) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ proxy.PodConnector = new(PodConnector)
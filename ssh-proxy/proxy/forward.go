@@ -0,0 +1,81 @@
+package proxy
+
+import (
+	"context"
+	"io"
+	"math"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/ssh"
+)
+
+type directTCPIPRequest struct {
+	HostToConnect  string
+	PortToConnect  uint32
+	OriginatorIP   string
+	OriginatorPort uint32
+}
+
+// handleDirectTCPIP forwards a local port of the client to a port of the app
+// instance. Only the ports the instance listens on can be reached, so other
+// hosts cannot be connected to through the instance.
+func (s *Server) handleDirectTCPIP(ctx context.Context, session sshSession, newChannel ssh.NewChannel) {
+	logger := logr.FromContextOrDiscard(ctx).WithName("direct-tcpip")
+
+	var payload directTCPIPRequest
+	if err := ssh.Unmarshal(newChannel.ExtraData(), &payload); err != nil {
+		_ = newChannel.Reject(ssh.ConnectionFailed, "invalid forwarding request")
+		return
+	}
+
+	if !isLocalhost(payload.HostToConnect) {
+		_ = newChannel.Reject(ssh.Prohibited, "only ports of the app instance on localhost can be forwarded")
+		return
+	}
+
+	if payload.PortToConnect == 0 || payload.PortToConnect > math.MaxUint16 {
+		_ = newChannel.Reject(ssh.ConnectionFailed, "invalid port")
+		return
+	}
+
+	stream, err := s.podConnector.PortForward(session.authInfo, session.target, uint16(payload.PortToConnect))
+	if err != nil {
+		logger.Info("port forward failed", "reason", err, "port", payload.PortToConnect)
+		_ = newChannel.Reject(ssh.ConnectionFailed, "failed to connect to the app instance")
+		return
+	}
+	defer stream.Close()
+
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		logger.Info("failed to accept direct-tcpip channel", "reason", err)
+		return
+	}
+	defer channel.Close()
+
+	go ssh.DiscardRequests(requests)
+
+	copied := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(stream, channel)
+		copied <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(channel, stream)
+		copied <- struct{}{}
+	}()
+
+	select {
+	case <-copied:
+	case <-ctx.Done():
+	}
+}
+
+func isLocalhost(host string) bool {
+	switch host {
+	case "localhost", "127.0.0.1", "::1":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,192 @@
+package proxy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/korifi/api/actions"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/ssh-proxy/pods"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	handshakeTimeout = 30 * time.Second
+
+	tokenExtension     = "korifi-token"
+	certExtension      = "korifi-cert"
+	namespaceExtension = "korifi-namespace"
+	podExtension       = "korifi-pod"
+	containerExtension = "korifi-container"
+)
+
+// usernameRegexp matches the `cf:<process-guid>/<index>` usernames that the
+// CLI uses to select the app instance
+var usernameRegexp = regexp.MustCompile(`^cf:([^/]+)/(\d+)$`)
+
+//counterfeiter:generate -o fake -fake-name SSHCodeRedeemer . SSHCodeRedeemer
+//counterfeiter:generate -o fake -fake-name TargetFinder . TargetFinder
+//counterfeiter:generate -o fake -fake-name PodConnector . PodConnector
+
+type SSHCodeRedeemer interface {
+	RedeemSSHCode(context.Context, string) (authorization.Info, error)
+}
+
+type TargetFinder interface {
+	Target(context.Context, authorization.Info, string, int) (actions.SSHTarget, error)
+}
+
+type PodConnector interface {
+	Exec(context.Context, authorization.Info, actions.SSHTarget, pods.ExecOptions) (int, error)
+	PortForward(authorization.Info, actions.SSHTarget, uint16) (io.ReadWriteCloser, error)
+}
+
+// Server is an SSH server that authenticates users with the one-time codes
+// issued by the API and bridges their sessions to the app instance they
+// selected
+type Server struct {
+	hostKey      ssh.Signer
+	codeRedeemer SSHCodeRedeemer
+	targetFinder TargetFinder
+	podConnector PodConnector
+}
+
+func NewServer(
+	hostKey ssh.Signer,
+	codeRedeemer SSHCodeRedeemer,
+	targetFinder TargetFinder,
+	podConnector PodConnector,
+) *Server {
+	return &Server{
+		hostKey:      hostKey,
+		codeRedeemer: codeRedeemer,
+		targetFinder: targetFinder,
+		podConnector: podConnector,
+	}
+}
+
+// Serve handles the connections accepted by the listener until the context
+// is done
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+
+		go s.handleConn(ctx, conn)
+	}
+}
+
+type sshSession struct {
+	authInfo authorization.Info
+	target   actions.SSHTarget
+}
+
+func (s *Server) handleConn(ctx context.Context, conn net.Conn) {
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	logger := logr.FromContextOrDiscard(ctx).WithName("ssh-proxy").WithValues("remoteAddr", conn.RemoteAddr().String())
+	ctx = logr.NewContext(ctx, logger)
+
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(meta ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			return s.authenticate(ctx, meta.User(), string(password))
+		},
+	}
+	config.AddHostKey(s.hostKey)
+
+	_ = conn.SetDeadline(time.Now().Add(handshakeTimeout))
+	sshConn, channels, requests, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		logger.Info("ssh handshake failed", "reason", err)
+		return
+	}
+	defer sshConn.Close()
+	_ = conn.SetDeadline(time.Time{})
+
+	go ssh.DiscardRequests(requests)
+
+	session := sshSession{
+		authInfo: authorization.Info{
+			Token:    sshConn.Permissions.Extensions[tokenExtension],
+			CertData: []byte(sshConn.Permissions.Extensions[certExtension]),
+		},
+		target: actions.SSHTarget{
+			Namespace: sshConn.Permissions.Extensions[namespaceExtension],
+			PodName:   sshConn.Permissions.Extensions[podExtension],
+			Container: sshConn.Permissions.Extensions[containerExtension],
+		},
+	}
+	if len(session.authInfo.CertData) == 0 {
+		session.authInfo.CertData = nil
+	}
+
+	for newChannel := range channels {
+		switch newChannel.ChannelType() {
+		case "session":
+			go s.handleSession(ctx, session, newChannel)
+		case "direct-tcpip":
+			go s.handleDirectTCPIP(ctx, session, newChannel)
+		default:
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unsupported channel type")
+		}
+	}
+}
+
+func (s *Server) authenticate(ctx context.Context, username, code string) (*ssh.Permissions, error) {
+	logger := logr.FromContextOrDiscard(ctx).WithName("authenticate").WithValues("username", username)
+
+	matches := usernameRegexp.FindStringSubmatch(username)
+	if matches == nil {
+		logger.Info("invalid username")
+		return nil, errors.New("invalid username")
+	}
+	processGUID := matches[1]
+	index, err := strconv.Atoi(matches[2])
+	if err != nil {
+		logger.Info("invalid instance index", "reason", err)
+		return nil, errors.New("invalid username")
+	}
+
+	authInfo, err := s.codeRedeemer.RedeemSSHCode(ctx, code)
+	if err != nil {
+		logger.Info("failed to redeem ssh code", "reason", err)
+		return nil, errors.New("invalid credentials")
+	}
+
+	target, err := s.targetFinder.Target(ctx, authInfo, processGUID, index)
+	if err != nil {
+		logger.Info("failed to find the app instance", "reason", err)
+		return nil, errors.New("app instance not accessible")
+	}
+
+	return &ssh.Permissions{
+		Extensions: map[string]string{
+			tokenExtension:     authInfo.Token,
+			certExtension:      string(authInfo.CertData),
+			namespaceExtension: target.Namespace,
+			podExtension:       target.PodName,
+			containerExtension: target.Container,
+		},
+	}, nil
+}
@@ -0,0 +1,272 @@
+package proxy_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+
+	"code.cloudfoundry.org/korifi/api/actions"
+	"code.cloudfoundry.org/korifi/api/authorization"
+	"code.cloudfoundry.org/korifi/ssh-proxy/pods"
+	"code.cloudfoundry.org/korifi/ssh-proxy/proxy"
+	"code.cloudfoundry.org/korifi/ssh-proxy/proxy/fake"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"golang.org/x/crypto/ssh"
+)
+
+var _ = Describe("Server", func() {
+	var (
+		hostKey      ssh.Signer
+		codeRedeemer *fake.SSHCodeRedeemer
+		targetFinder *fake.TargetFinder
+		podConnector *fake.PodConnector
+		listener     net.Listener
+		username     string
+		client       *ssh.Client
+		dialErr      error
+		target       actions.SSHTarget
+	)
+
+	BeforeEach(func() {
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		Expect(err).NotTo(HaveOccurred())
+		hostKey, err = ssh.NewSignerFromKey(privateKey)
+		Expect(err).NotTo(HaveOccurred())
+
+		target = actions.SSHTarget{
+			Namespace: "the-namespace",
+			PodName:   "the-pod",
+			Container: "application",
+		}
+
+		codeRedeemer = new(fake.SSHCodeRedeemer)
+		codeRedeemer.RedeemSSHCodeReturns(authorization.Info{Token: "the-token"}, nil)
+		targetFinder = new(fake.TargetFinder)
+		targetFinder.TargetReturns(target, nil)
+		podConnector = new(fake.PodConnector)
+
+		username = "cf:the-process-guid/1"
+
+		listener, err = net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+
+		serveCtx, cancel := context.WithCancel(ctx)
+		DeferCleanup(cancel)
+
+		server := proxy.NewServer(hostKey, codeRedeemer, targetFinder, podConnector)
+		go func() {
+			defer GinkgoRecover()
+			Expect(server.Serve(serveCtx, listener)).To(Succeed())
+		}()
+	})
+
+	JustBeforeEach(func() {
+		client, dialErr = ssh.Dial("tcp", listener.Addr().String(), &ssh.ClientConfig{
+			User:            username,
+			Auth:            []ssh.AuthMethod{ssh.Password("the-code")},
+			HostKeyCallback: ssh.FixedHostKey(hostKey.PublicKey()),
+		})
+	})
+
+	AfterEach(func() {
+		if client != nil {
+			client.Close()
+		}
+	})
+
+	It("authenticates with the ssh code", func() {
+		Expect(dialErr).NotTo(HaveOccurred())
+
+		Expect(codeRedeemer.RedeemSSHCodeCallCount()).To(Equal(1))
+		_, actualCode := codeRedeemer.RedeemSSHCodeArgsForCall(0)
+		Expect(actualCode).To(Equal("the-code"))
+
+		Expect(targetFinder.TargetCallCount()).To(Equal(1))
+		_, actualAuthInfo, actualProcessGUID, actualIndex := targetFinder.TargetArgsForCall(0)
+		Expect(actualAuthInfo).To(Equal(authorization.Info{Token: "the-token"}))
+		Expect(actualProcessGUID).To(Equal("the-process-guid"))
+		Expect(actualIndex).To(Equal(1))
+	})
+
+	When("the username is invalid", func() {
+		BeforeEach(func() {
+			username = "the-process-guid"
+		})
+
+		It("fails to authenticate", func() {
+			Expect(dialErr).To(MatchError(ContainSubstring("unable to authenticate")))
+			Expect(codeRedeemer.RedeemSSHCodeCallCount()).To(Equal(0))
+		})
+	})
+
+	When("the code cannot be redeemed", func() {
+		BeforeEach(func() {
+			codeRedeemer.RedeemSSHCodeReturns(authorization.Info{}, errors.New("redeem"))
+		})
+
+		It("fails to authenticate", func() {
+			Expect(dialErr).To(MatchError(ContainSubstring("unable to authenticate")))
+			Expect(targetFinder.TargetCallCount()).To(Equal(0))
+		})
+	})
+
+	When("the app instance cannot be accessed", func() {
+		BeforeEach(func() {
+			targetFinder.TargetReturns(actions.SSHTarget{}, errors.New("target"))
+		})
+
+		It("fails to authenticate", func() {
+			Expect(dialErr).To(MatchError(ContainSubstring("unable to authenticate")))
+		})
+	})
+
+	Describe("sessions", func() {
+		var session *ssh.Session
+
+		BeforeEach(func() {
+			podConnector.ExecStub = func(_ context.Context, _ authorization.Info, _ actions.SSHTarget, opts pods.ExecOptions) (int, error) {
+				fmt.Fprint(opts.Stdout, "hello")
+				fmt.Fprint(opts.Stderr, "oops")
+				return 3, nil
+			}
+		})
+
+		JustBeforeEach(func() {
+			Expect(dialErr).NotTo(HaveOccurred())
+
+			var err error
+			session, err = client.NewSession()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("runs commands in the app instance", func() {
+			stdout, err := session.StdoutPipe()
+			Expect(err).NotTo(HaveOccurred())
+			stderr, err := session.StderrPipe()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(session.Start("echo hello")).To(Succeed())
+			Expect(io.ReadAll(stdout)).To(Equal([]byte("hello")))
+			Expect(io.ReadAll(stderr)).To(Equal([]byte("oops")))
+
+			err = session.Wait()
+			var exitErr *ssh.ExitError
+			Expect(errors.As(err, &exitErr)).To(BeTrue())
+			Expect(exitErr.ExitStatus()).To(Equal(3))
+
+			Expect(podConnector.ExecCallCount()).To(Equal(1))
+			_, actualAuthInfo, actualTarget, actualOpts := podConnector.ExecArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authorization.Info{Token: "the-token"}))
+			Expect(actualTarget).To(Equal(target))
+			Expect(actualOpts.Command).To(Equal([]string{"/bin/sh", "-c", "echo hello"}))
+			Expect(actualOpts.TTY).To(BeFalse())
+		})
+
+		It("runs the shell of the container", func() {
+			podConnector.ExecStub = func(_ context.Context, _ authorization.Info, _ actions.SSHTarget, opts pods.ExecOptions) (int, error) {
+				size := <-opts.Resize
+				fmt.Fprintf(opts.Stdout, "%dx%d", size.Width, size.Height)
+				return 0, nil
+			}
+			stdout, err := session.StdoutPipe()
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(session.RequestPty("xterm", 24, 80, ssh.TerminalModes{})).To(Succeed())
+			Expect(session.Shell()).To(Succeed())
+			Expect(io.ReadAll(stdout)).To(Equal([]byte("80x24")))
+			Expect(session.Wait()).To(Succeed())
+
+			_, _, _, actualOpts := podConnector.ExecArgsForCall(0)
+			Expect(actualOpts.Command).To(Equal([]string{"env", "TERM=xterm", "/bin/sh"}))
+			Expect(actualOpts.TTY).To(BeTrue())
+		})
+
+		When("the command cannot be run", func() {
+			BeforeEach(func() {
+				podConnector.ExecReturns(0, errors.New("exec-failed"))
+			})
+
+			It("reports the error", func() {
+				output, err := session.CombinedOutput("true")
+				Expect(string(output)).To(ContainSubstring("exec-failed"))
+
+				var exitErr *ssh.ExitError
+				Expect(errors.As(err, &exitErr)).To(BeTrue())
+				Expect(exitErr.ExitStatus()).To(Equal(255))
+			})
+		})
+	})
+
+	Describe("port forwarding", func() {
+		var appConn net.Conn
+
+		BeforeEach(func() {
+			var proxyConn net.Conn
+			proxyConn, appConn = net.Pipe()
+			podConnector.PortForwardReturns(proxyConn, nil)
+
+			go func(conn net.Conn) {
+				defer GinkgoRecover()
+
+				buf := make([]byte, 4)
+				if _, err := io.ReadFull(conn, buf); err != nil {
+					return
+				}
+				_, _ = conn.Write(append([]byte("pong:"), buf...))
+			}(appConn)
+		})
+
+		AfterEach(func() {
+			appConn.Close()
+		})
+
+		It("forwards connections to the app instance", func() {
+			Expect(dialErr).NotTo(HaveOccurred())
+
+			conn, err := client.Dial("tcp", "localhost:8080")
+			Expect(err).NotTo(HaveOccurred())
+			defer conn.Close()
+
+			_, err = conn.Write([]byte("ping"))
+			Expect(err).NotTo(HaveOccurred())
+
+			buf := make([]byte, 9)
+			_, err = io.ReadFull(conn, buf)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(buf)).To(Equal("pong:ping"))
+
+			Expect(podConnector.PortForwardCallCount()).To(Equal(1))
+			actualAuthInfo, actualTarget, actualPort := podConnector.PortForwardArgsForCall(0)
+			Expect(actualAuthInfo).To(Equal(authorization.Info{Token: "the-token"}))
+			Expect(actualTarget).To(Equal(target))
+			Expect(actualPort).To(BeEquivalentTo(8080))
+		})
+
+		It("does not forward connections to other hosts", func() {
+			Expect(dialErr).NotTo(HaveOccurred())
+
+			_, err := client.Dial("tcp", "example.org:80")
+			Expect(err).To(MatchError(ContainSubstring("only ports of the app instance on localhost can be forwarded")))
+			Expect(podConnector.PortForwardCallCount()).To(Equal(0))
+		})
+
+		When("port forwarding fails", func() {
+			BeforeEach(func() {
+				podConnector.PortForwardReturns(nil, errors.New("port-forward"))
+			})
+
+			It("rejects the connection", func() {
+				Expect(dialErr).NotTo(HaveOccurred())
+
+				_, err := client.Dial("tcp", "localhost:8080")
+				Expect(err).To(MatchError(ContainSubstring("failed to connect to the app instance")))
+			})
+		})
+	})
+})
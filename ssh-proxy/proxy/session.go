@@ -0,0 +1,177 @@
+package proxy
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/korifi/ssh-proxy/pods"
+
+	"github.com/go-logr/logr"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	shell = "/bin/sh"
+
+	// execFailedExitStatus is reported when the command could not be run,
+	// like ssh does when it fails to connect
+	execFailedExitStatus = 255
+
+	resizeBufferSize = 16
+)
+
+type (
+	envRequest struct {
+		Name  string
+		Value string
+	}
+
+	ptyRequest struct {
+		Term     string
+		Columns  uint32
+		Rows     uint32
+		Width    uint32
+		Height   uint32
+		Modelist string
+	}
+
+	windowChangeRequest struct {
+		Columns uint32
+		Rows    uint32
+		Width   uint32
+		Height  uint32
+	}
+
+	execRequest struct {
+		Command string
+	}
+
+	exitStatus struct {
+		Status uint32
+	}
+)
+
+func (s *Server) handleSession(ctx context.Context, session sshSession, newChannel ssh.NewChannel) {
+	logger := logr.FromContextOrDiscard(ctx).WithName("session")
+
+	channel, requests, err := newChannel.Accept()
+	if err != nil {
+		logger.Info("failed to accept session channel", "reason", err)
+		return
+	}
+	defer channel.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		env     []string
+		tty     bool
+		started bool
+		resizes = make(chan pods.TerminalSize, resizeBufferSize)
+	)
+
+	for req := range requests {
+		switch req.Type {
+		case "env":
+			var payload envRequest
+			if err = ssh.Unmarshal(req.Payload, &payload); err != nil {
+				_ = req.Reply(false, nil)
+				continue
+			}
+			env = append(env, payload.Name+"="+payload.Value)
+			_ = req.Reply(true, nil)
+
+		case "pty-req":
+			var payload ptyRequest
+			if err = ssh.Unmarshal(req.Payload, &payload); err != nil {
+				_ = req.Reply(false, nil)
+				continue
+			}
+			tty = true
+			env = append(env, "TERM="+payload.Term)
+			queueResize(resizes, payload.Columns, payload.Rows)
+			_ = req.Reply(true, nil)
+
+		case "window-change":
+			var payload windowChangeRequest
+			if err = ssh.Unmarshal(req.Payload, &payload); err != nil {
+				_ = req.Reply(false, nil)
+				continue
+			}
+			queueResize(resizes, payload.Columns, payload.Rows)
+			_ = req.Reply(true, nil)
+
+		case "shell", "exec":
+			if started {
+				_ = req.Reply(false, nil)
+				continue
+			}
+
+			var payload execRequest
+			if req.Type == "exec" {
+				if err = ssh.Unmarshal(req.Payload, &payload); err != nil {
+					_ = req.Reply(false, nil)
+					continue
+				}
+			}
+
+			started = true
+			_ = req.Reply(true, nil)
+
+			go s.exec(ctx, session, channel, pods.ExecOptions{
+				Command: containerCommand(env, payload.Command),
+				TTY:     tty,
+				Stdin:   channel,
+				Stdout:  channel,
+				Stderr:  channel.Stderr(),
+				Resize:  resizes,
+			})
+
+		default:
+			_ = req.Reply(false, nil)
+		}
+	}
+}
+
+func (s *Server) exec(ctx context.Context, session sshSession, channel ssh.Channel, opts pods.ExecOptions) {
+	logger := logr.FromContextOrDiscard(ctx).WithName("exec")
+	defer channel.Close()
+
+	status, err := s.podConnector.Exec(ctx, session.authInfo, session.target, opts)
+	if ctx.Err() != nil {
+		return
+	}
+	if err != nil {
+		logger.Info("exec failed", "reason", err)
+		fmt.Fprintf(channel.Stderr(), "Failed to run command: %v\r\n", err)
+		status = execFailedExitStatus
+	}
+
+	_, err = channel.SendRequest("exit-status", false, ssh.Marshal(exitStatus{Status: uint32(status)}))
+	if err != nil {
+		logger.Info("failed to send exit status", "reason", err)
+	}
+}
+
+// containerCommand runs the command with the shell of the container, or runs
+// an interactive shell when the command is empty
+func containerCommand(env []string, command string) []string {
+	cmd := []string{shell}
+	if command != "" {
+		cmd = append(cmd, "-c", command)
+	}
+
+	if len(env) == 0 {
+		return cmd
+	}
+
+	return append(append([]string{"env"}, env...), cmd...)
+}
+
+func queueResize(resizes chan pods.TerminalSize, columns, rows uint32) {
+	select {
+	case resizes <- pods.TerminalSize{Width: uint16(columns), Height: uint16(rows)}:
+	default:
+	}
+}
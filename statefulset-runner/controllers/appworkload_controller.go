@@ -60,6 +60,7 @@ const (
 	LabelStatefulSetRunnerIndex = "korifi.cloudfoundry.org/add-stsr-index"
 
 	ApplicationContainerName  = "application"
+	SidecarContainerPrefix    = "sidecar-"
 	AppWorkloadReconcilerName = "statefulset-runner"
 	ServiceAccountName        = "korifi-app"
 
@@ -102,21 +102,25 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 			Command:         appWorkload.Spec.Command,
 			Env:             envs,
 			Ports:           ports,
-			SecurityContext: &corev1.SecurityContext{
-				AllowPrivilegeEscalation: tools.PtrTo(false),
-				Capabilities: &corev1.Capabilities{
-					Drop: []corev1.Capability{"ALL"},
-				},
-				SeccompProfile: &corev1.SeccompProfile{
-					Type: corev1.SeccompProfileTypeRuntimeDefault,
-				},
-			},
-			Resources:     appWorkload.Spec.Resources,
-			StartupProbe:  appWorkload.Spec.StartupProbe,
-			LivenessProbe: appWorkload.Spec.LivenessProbe,
+			SecurityContext: containerSecurityContext(),
+			Resources:       applicationResources(appWorkload.Spec),
+			StartupProbe:    appWorkload.Spec.StartupProbe,
+			LivenessProbe:   appWorkload.Spec.LivenessProbe,
 		},
 	}
 
+	for i, sidecar := range appWorkload.Spec.Sidecars {
+		containers = append(containers, corev1.Container{
+			Name:            sidecarContainerName(sidecar.Name, i),
+			Image:           appWorkload.Spec.Image,
+			ImagePullPolicy: corev1.PullAlways,
+			Command:         sidecar.Command,
+			Env:             envs,
+			SecurityContext: containerSecurityContext(),
+			Resources:       sidecar.Resources,
+		})
+	}
+
 	statefulsetName, err := getStatefulSetName(appWorkload)
 	if err != nil {
 		return nil, err
@@ -134,6 +138,8 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 				Spec: corev1.PodSpec{
 					Containers:       containers,
 					ImagePullSecrets: appWorkload.Spec.ImagePullSecrets,
+					NodeSelector:     appWorkload.Spec.NodeSelector,
+					Tolerations:      appWorkload.Spec.Tolerations,
 					SecurityContext: &corev1.PodSecurityContext{
 						RunAsNonRoot: tools.PtrTo(true),
 					},
@@ -191,6 +197,50 @@ func (r *AppWorkloadToStatefulsetConverter) Convert(appWorkload *korifiv1alpha1.
 	return statefulSet, nil
 }
 
+func containerSecurityContext() *corev1.SecurityContext {
+	return &corev1.SecurityContext{
+		AllowPrivilegeEscalation: tools.PtrTo(false),
+		Capabilities: &corev1.Capabilities{
+			Drop: []corev1.Capability{"ALL"},
+		},
+		SeccompProfile: &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileTypeRuntimeDefault,
+		},
+	}
+}
+
+// applicationResources takes the memory requested by the sidecars out of the
+// memory of the instance, so that the containers of an instance together get
+// the memory of the process
+func applicationResources(spec korifiv1alpha1.AppWorkloadSpec) corev1.ResourceRequirements {
+	resources := *spec.Resources.DeepCopy()
+	for _, sidecar := range spec.Sidecars {
+		sidecarMemory := sidecar.Resources.Requests.Memory()
+		for _, list := range []corev1.ResourceList{resources.Requests, resources.Limits} {
+			if memory, ok := list[corev1.ResourceMemory]; ok {
+				memory.Sub(*sidecarMemory)
+				list[corev1.ResourceMemory] = memory
+			}
+		}
+	}
+
+	return resources
+}
+
+// sidecarContainerName derives the container name from the sidecar name,
+// falling back to the index of the sidecar when the name is not a valid
+// container name
+func sidecarContainerName(name string, index int) string {
+	const containerNameMaxLen = 63
+	fallback := fmt.Sprintf("%s%d", SidecarContainerPrefix, index)
+	containerName := sanitizeNameWithMaxStringLen(SidecarContainerPrefix+name, fallback, containerNameMaxLen)
+	if strings.ContainsAny(containerName, ".") || strings.HasSuffix(containerName, "-") {
+		return fallback
+	}
+
+	return containerName
+}
+
 func sanitizeName(name, fallback string) string {
 	const sanitizedNameMaxLen = 40
 	return sanitizeNameWithMaxStringLen(name, fallback, sanitizedNameMaxLen)
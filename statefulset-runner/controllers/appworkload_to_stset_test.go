@@ -286,6 +286,89 @@ var _ = Describe("AppWorkload to StatefulSet Converter", func() {
 		})
 	})
 
+	It("should not set a node selector or tolerations", func() {
+		Expect(statefulSet.Spec.Template.Spec.NodeSelector).To(BeEmpty())
+		Expect(statefulSet.Spec.Template.Spec.Tolerations).To(BeEmpty())
+	})
+
+	When("the app is placed into an isolation segment", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.NodeSelector = map[string]string{"pool": "regulated"}
+			appWorkload.Spec.Tolerations = []corev1.Toleration{{
+				Key:      "pool",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "regulated",
+				Effect:   corev1.TaintEffectNoSchedule,
+			}}
+		})
+
+		It("sets the node selector and tolerations of the pods", func() {
+			Expect(statefulSet.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{"pool": "regulated"}))
+			Expect(statefulSet.Spec.Template.Spec.Tolerations).To(ConsistOf(corev1.Toleration{
+				Key:      "pool",
+				Operator: corev1.TolerationOpEqual,
+				Value:    "regulated",
+				Effect:   corev1.TaintEffectNoSchedule,
+			}))
+		})
+	})
+
+	It("should only run the application container", func() {
+		Expect(statefulSet.Spec.Template.Spec.Containers).To(HaveLen(1))
+	})
+
+	When("the app has sidecars", func() {
+		BeforeEach(func() {
+			appWorkload.Spec.Sidecars = []korifiv1alpha1.AppWorkloadSidecar{
+				{
+					Name:    "my_sidecar",
+					Command: []string{"/cnb/lifecycle/launcher", "./sidecar"},
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+						Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+					},
+				},
+				{
+					Name:    "not.a.container.name",
+					Command: []string{"./other-sidecar"},
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("0")},
+						Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+					},
+				},
+			}
+		})
+
+		It("runs each sidecar as a container next to the application", func() {
+			containers := statefulSet.Spec.Template.Spec.Containers
+			Expect(containers).To(HaveLen(3))
+			Expect(containers[0].Name).To(Equal(controllers.ApplicationContainerName))
+
+			Expect(containers[1].Name).To(Equal("sidecar-my-sidecar"))
+			Expect(containers[1].Image).To(Equal(appWorkload.Spec.Image))
+			Expect(containers[1].Command).To(Equal([]string{"/cnb/lifecycle/launcher", "./sidecar"}))
+			Expect(containers[1].Env).To(Equal(containers[0].Env))
+			Expect(containers[1].Resources.Limits.Memory().String()).To(Equal("64Mi"))
+			Expect(containers[1].Ports).To(BeEmpty())
+			Expect(containers[1].LivenessProbe).To(BeNil())
+			Expect(*containers[1].SecurityContext.AllowPrivilegeEscalation).To(BeFalse())
+		})
+
+		It("takes the memory requested by the sidecars out of the memory of the application", func() {
+			containers := statefulSet.Spec.Template.Spec.Containers
+			Expect(containers[0].Resources.Requests.Memory().String()).To(Equal("960Mi"))
+			Expect(containers[0].Resources.Limits.Memory().String()).To(Equal("960Mi"))
+			Expect(containers[2].Resources.Limits.Memory().String()).To(Equal("1Gi"))
+			Expect(appWorkload.Spec.Resources.Limits.Memory().String()).To(Equal("1Gi"))
+		})
+
+		It("falls back to the sidecar index when the name is not a valid container name", func() {
+			containers := statefulSet.Spec.Template.Spec.Containers
+			Expect(containers[2].Name).To(Equal("sidecar-1"))
+			Expect(containers[2].Command).To(Equal([]string{"./other-sidecar"}))
+		})
+	})
+
 	When("env vars are unsorted", func() {
 		BeforeEach(func() {
 			appWorkload.Spec.Env = []corev1.EnvVar{
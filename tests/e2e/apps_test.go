@@ -957,7 +957,14 @@ var _ = Describe("Apps", func() {
 	})
 
 	Describe("query SSH enabled", func() {
-		It("always returns false", func() {
+		var appGUID string
+
+		BeforeEach(func() {
+			createSpaceRole("space_developer", certUserName, space1GUID)
+			appGUID = createApp(space1GUID, generateGUID("app"))
+		})
+
+		It("returns false when the app_ssh_access feature flag is disabled", func() {
 			var respObj struct {
 				Enabled bool   `json:"enabled"`
 				Reason  string `json:"reason"`
@@ -965,7 +972,7 @@ var _ = Describe("Apps", func() {
 
 			resp, err := certClient.R().
 				SetResult(&respObj).
-				Get("/v3/apps/any-guid/ssh_enabled")
+				Get("/v3/apps/" + appGUID + "/ssh_enabled")
 			Expect(err).NotTo(HaveOccurred())
 
 			Expect(resp).To(HaveRestyStatusCode(http.StatusOK))
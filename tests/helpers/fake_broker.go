@@ -0,0 +1,323 @@
+package helpers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"code.cloudfoundry.org/korifi/controllers/controllers/services/osbapi"
+)
+
+// FakeBroker is an in-process service broker implementing the subset of the
+// Open Service Broker API used by korifi
+type FakeBroker struct {
+	server   *httptest.Server
+	Username string
+	Password string
+
+	mu             sync.Mutex
+	catalog        osbapi.Catalog
+	instances      map[string]FakeServiceInstance
+	bindings       map[string]FakeServiceBinding
+	credentials    map[string]any
+	async          bool
+	lastOperation  osbapi.LastOperation
+	operationError *osbapi.BrokerError
+}
+
+// FakeServiceInstance is the state of a service instance provisioned by the FakeBroker
+type FakeServiceInstance struct {
+	ServiceID  string
+	PlanID     string
+	Parameters map[string]any
+	Context    osbapi.Context
+}
+
+// FakeServiceBinding is the state of a service binding created by the FakeBroker
+type FakeServiceBinding struct {
+	InstanceID string
+	ServiceID  string
+	PlanID     string
+	AppGUID    string
+	Context    osbapi.Context
+}
+
+func NewFakeBroker(catalog osbapi.Catalog) *FakeBroker {
+	broker := &FakeBroker{
+		Username:  "broker-user",
+		Password:  "broker-password",
+		catalog:   catalog,
+		instances: map[string]FakeServiceInstance{},
+		bindings:  map[string]FakeServiceBinding{},
+		credentials: map[string]any{
+			"username": "broker-generated-user",
+			"password": "broker-generated-password",
+		},
+		lastOperation: osbapi.LastOperation{
+			State: osbapi.SucceededState,
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/catalog", broker.authenticated(broker.getCatalog))
+	mux.HandleFunc("/v2/service_instances/", broker.authenticated(broker.serviceInstances))
+	broker.server = httptest.NewServer(mux)
+
+	return broker
+}
+
+func (b *FakeBroker) URL() string {
+	return b.server.URL
+}
+
+func (b *FakeBroker) Close() {
+	b.server.Close()
+}
+
+func (b *FakeBroker) SetCatalog(catalog osbapi.Catalog) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.catalog = catalog
+}
+
+// SetAsync makes the broker respond to instance operations with 202 Accepted.
+// Operations then have to be polled via last_operation
+func (b *FakeBroker) SetAsync(async bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.async = async
+}
+
+// SetLastOperation sets the state reported by the last_operation endpoint
+func (b *FakeBroker) SetLastOperation(lastOperation osbapi.LastOperation) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastOperation = lastOperation
+}
+
+// SetCredentials sets the credentials returned for new bindings
+func (b *FakeBroker) SetCredentials(credentials map[string]any) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.credentials = credentials
+}
+
+// SetOperationError makes all instance and binding requests except for
+// last_operation polling fail with the given error. Passing nil restores the
+// default behaviour
+func (b *FakeBroker) SetOperationError(brokerErr *osbapi.BrokerError) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.operationError = brokerErr
+}
+
+func (b *FakeBroker) GetInstance(instanceID string) (FakeServiceInstance, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	instance, ok := b.instances[instanceID]
+	return instance, ok
+}
+
+func (b *FakeBroker) GetBinding(bindingID string) (FakeServiceBinding, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	binding, ok := b.bindings[bindingID]
+	return binding, ok
+}
+
+func (b *FakeBroker) authenticated(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		username, password, ok := r.BasicAuth()
+		if !ok || username != b.Username || password != b.Password {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		if r.Header.Get(osbapi.APIVersionHeader) == "" {
+			writeJSON(w, http.StatusPreconditionFailed, map[string]string{
+				"error":       "PreconditionFailed",
+				"description": "missing " + osbapi.APIVersionHeader + " header",
+			})
+			return
+		}
+
+		handler(w, r)
+	}
+}
+
+func (b *FakeBroker) getCatalog(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, b.catalog)
+}
+
+func (b *FakeBroker) serviceInstances(w http.ResponseWriter, r *http.Request) {
+	instanceID := strings.TrimPrefix(r.URL.Path, "/v2/service_instances/")
+	isLastOperation := strings.HasSuffix(instanceID, "/last_operation")
+	instanceID = strings.TrimSuffix(instanceID, "/last_operation")
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if isLastOperation {
+		b.getLastOperation(w, r)
+		return
+	}
+
+	if b.operationError != nil && r.Method != http.MethodGet {
+		writeJSON(w, b.operationError.StatusCode, b.operationError)
+		return
+	}
+
+	if instanceID, bindingID, isBinding := strings.Cut(instanceID, "/service_bindings/"); isBinding {
+		b.serviceBinding(w, r, instanceID, bindingID)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		b.provision(w, r, instanceID)
+	case http.MethodPatch:
+		b.update(w, r, instanceID)
+	case http.MethodDelete:
+		b.deprovision(w, instanceID)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (b *FakeBroker) provision(w http.ResponseWriter, r *http.Request, instanceID string) {
+	var request osbapi.ProvisionRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"description": err.Error()})
+		return
+	}
+
+	b.instances[instanceID] = FakeServiceInstance{
+		ServiceID:  request.ServiceID,
+		PlanID:     request.PlanID,
+		Parameters: request.Parameters,
+		Context:    request.Context,
+	}
+
+	b.respondToOperation(w, http.StatusCreated, "provision-"+instanceID)
+}
+
+func (b *FakeBroker) update(w http.ResponseWriter, r *http.Request, instanceID string) {
+	instance, ok := b.instances[instanceID]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"description": "instance not found"})
+		return
+	}
+
+	var request osbapi.UpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"description": err.Error()})
+		return
+	}
+
+	if request.PlanID != "" {
+		instance.PlanID = request.PlanID
+	}
+	if request.Parameters != nil {
+		instance.Parameters = request.Parameters
+	}
+	instance.Context = request.Context
+	b.instances[instanceID] = instance
+
+	b.respondToOperation(w, http.StatusOK, "update-"+instanceID)
+}
+
+func (b *FakeBroker) deprovision(w http.ResponseWriter, instanceID string) {
+	if _, ok := b.instances[instanceID]; !ok {
+		writeJSON(w, http.StatusGone, map[string]string{})
+		return
+	}
+
+	delete(b.instances, instanceID)
+
+	b.respondToOperation(w, http.StatusOK, "deprovision-"+instanceID)
+}
+
+func (b *FakeBroker) serviceBinding(w http.ResponseWriter, r *http.Request, instanceID, bindingID string) {
+	switch r.Method {
+	case http.MethodPut:
+		b.bind(w, r, instanceID, bindingID)
+	case http.MethodDelete:
+		b.unbind(w, bindingID)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (b *FakeBroker) bind(w http.ResponseWriter, r *http.Request, instanceID, bindingID string) {
+	if _, ok := b.instances[instanceID]; !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"description": "instance not found"})
+		return
+	}
+
+	var request osbapi.BindRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"description": err.Error()})
+		return
+	}
+
+	b.bindings[bindingID] = FakeServiceBinding{
+		InstanceID: instanceID,
+		ServiceID:  request.ServiceID,
+		PlanID:     request.PlanID,
+		AppGUID:    request.AppGUID,
+		Context:    request.Context,
+	}
+
+	writeJSON(w, http.StatusCreated, osbapi.BindResponse{Credentials: b.credentials})
+}
+
+func (b *FakeBroker) unbind(w http.ResponseWriter, bindingID string) {
+	if _, ok := b.bindings[bindingID]; !ok {
+		writeJSON(w, http.StatusGone, map[string]string{})
+		return
+	}
+
+	delete(b.bindings, bindingID)
+	writeJSON(w, http.StatusOK, map[string]string{})
+}
+
+func (b *FakeBroker) getLastOperation(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, b.lastOperation)
+}
+
+func (b *FakeBroker) respondToOperation(w http.ResponseWriter, syncStatusCode int, operation string) {
+	if b.async {
+		writeJSON(w, http.StatusAccepted, osbapi.OperationResponse{Operation: operation})
+		return
+	}
+
+	writeJSON(w, syncStatusCode, map[string]string{})
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -2,6 +2,7 @@ package image
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 	"github.com/google/go-containerregistry/pkg/authn"
 	"github.com/google/go-containerregistry/pkg/authn/k8schain"
 	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/empty"
 	"github.com/google/go-containerregistry/pkg/v1/mutate"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
@@ -38,6 +40,8 @@ type Creds struct {
 type Config struct {
 	Labels       map[string]string
 	ExposedPorts []int32
+	Entrypoint   []string
+	Cmd          []string
 }
 
 func NewClient(k8sClient kubernetes.Interface) Client {
@@ -47,6 +51,8 @@ func NewClient(k8sClient kubernetes.Interface) Client {
 	}
 }
 
+// Push pushes the content of a zip archive or of a gzipped tarball as a
+// single layer image
 func (c Client) Push(ctx context.Context, creds Creds, repoRef string, zipReader io.Reader, tags ...string) (string, error) {
 	tmpFile, err := os.CreateTemp(os.TempDir(), "sourceimg-%s")
 	if err != nil {
@@ -58,9 +64,14 @@ func (c Client) Push(ctx context.Context, creds Creds, repoRef string, zipReader
 		return "", fmt.Errorf("failed to copy image source into temp file '%s' %w", tmpFile.Name(), err)
 	}
 
-	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
-		return archive.ReadZipAsTar(tmpFile.Name(), "/", 0, 0, -1, true, nil), nil
-	})
+	var layer v1.Layer
+	if isGzip(tmpFile) {
+		layer, err = tarball.LayerFromFile(tmpFile.Name())
+	} else {
+		layer, err = tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+			return archive.ReadZipAsTar(tmpFile.Name(), "/", 0, 0, -1, true, nil), nil
+		})
+	}
 	if err != nil {
 		return "", fmt.Errorf("failed to create a layer out of '%s': %w", tmpFile.Name(), err)
 	}
@@ -80,12 +91,16 @@ func (c Client) Push(ctx context.Context, creds Creds, repoRef string, zipReader
 		return "", fmt.Errorf("error creating keychain: %w", err)
 	}
 
-	if err = remote.Write(ref, image, authOpt); err != nil {
+	return c.write(ref, image, authOpt, tags)
+}
+
+func (c Client) write(ref name.Reference, image v1.Image, authOpt remote.Option, tags []string) (string, error) {
+	if err := remote.Write(ref, image, authOpt); err != nil {
 		return "", fmt.Errorf("failed to upload image: %w", err)
 	}
 
 	for _, tag := range tags {
-		err = remote.Tag(ref.Context().Tag(tag), image, authOpt)
+		err := remote.Tag(ref.Context().Tag(tag), image, authOpt)
 		if err != nil {
 			return "", fmt.Errorf("failed to tag image: %w", err)
 		}
@@ -137,9 +152,114 @@ func (c Client) Config(ctx context.Context, creds Creds, imageRef string) (Confi
 	return Config{
 		Labels:       cfgFile.Config.Labels,
 		ExposedPorts: ports,
+		Entrypoint:   cfgFile.Config.Entrypoint,
+		Cmd:          cfgFile.Config.Cmd,
 	}, nil
 }
 
+// IsPermanentError reports whether the error is the registry refusing the image
+// for good, i.e. an invalid reference, missing permissions or an unknown image.
+// Other errors, such as network failures or image pull secrets that cannot be
+// read yet, may go away when retrying.
+func IsPermanentError(err error) bool {
+	if name.IsErrBadName(err) {
+		return true
+	}
+
+	var transportErr *transport.Error
+	if !errors.As(err, &transportErr) {
+		return false
+	}
+
+	switch transportErr.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		return true
+	}
+
+	for _, diagnostic := range transportErr.Errors {
+		switch diagnostic.Code {
+		case transport.UnauthorizedErrorCode,
+			transport.DeniedErrorCode,
+			transport.ManifestUnknownErrorCode,
+			transport.NameUnknownErrorCode,
+			transport.NameInvalidErrorCode,
+			transport.TagInvalidErrorCode:
+			return true
+		}
+	}
+
+	return false
+}
+
+// Copy copies the image to the given repository and returns the reference of
+// the copy
+func (c Client) Copy(ctx context.Context, creds Creds, imageRef string, repoRef string, tags ...string) (string, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return "", fmt.Errorf("error parsing repository reference %s: %w", imageRef, err)
+	}
+
+	dstRef, err := name.ParseReference(repoRef)
+	if err != nil {
+		return "", fmt.Errorf("error parsing repository reference %s: %w", repoRef, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return "", fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	img, err := remote.Image(ref, authOpt)
+	if err != nil {
+		return "", fmt.Errorf("failed to get image: %w", err)
+	}
+
+	return c.write(dstRef, img, authOpt, tags)
+}
+
+// Download returns the file system of the image as an uncompressed tarball
+func (c Client) Download(ctx context.Context, creds Creds, imageRef string) (io.ReadCloser, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing repository reference %s: %w", imageRef, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return nil, fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	img, err := remote.Image(ref, authOpt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get image: %w", err)
+	}
+
+	return mutate.Extract(img), nil
+}
+
+// Exists reports whether the image exists in the registry
+func (c Client) Exists(ctx context.Context, creds Creds, imageRef string) (bool, error) {
+	ref, err := name.ParseReference(imageRef)
+	if err != nil {
+		return false, fmt.Errorf("error parsing repository reference %s: %w", imageRef, err)
+	}
+
+	authOpt, err := c.authOpt(ctx, creds)
+	if err != nil {
+		return false, fmt.Errorf("error creating keychain: %w", err)
+	}
+
+	_, err = remote.Head(ref, authOpt)
+	if err != nil {
+		if structuredErr, ok := err.(*transport.Error); ok && structuredErr.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get image: %w", err)
+	}
+
+	return true, nil
+}
+
 func (c Client) Delete(ctx context.Context, creds Creds, imageRef string, tagsToDelete ...string) error {
 	c.logger.V(1).Info("deleting", "ref", imageRef)
 	ref, err := name.ParseReference(imageRef)
@@ -269,3 +389,10 @@ func (c Client) authOpt(ctx context.Context, creds Creds) (remote.Option, error)
 
 	return remote.WithAuthFromKeychain(keychain), nil
 }
+
+func isGzip(file *os.File) bool {
+	header := make([]byte, 2)
+	_, err := file.ReadAt(header, 0)
+
+	return err == nil && header[0] == 0x1f && header[1] == 0x8b
+}
@@ -1,6 +1,11 @@
 package image_test
 
 import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 
@@ -70,6 +75,28 @@ var _ = Describe("Client", func() {
 			})
 		})
 
+		When("the input is a gzipped tarball", func() {
+			BeforeEach(func() {
+				var err error
+				zipFile, err = os.CreateTemp("", "layer-*.tgz")
+				Expect(err).NotTo(HaveOccurred())
+				DeferCleanup(os.Remove, zipFile.Name())
+
+				writeTgz(zipFile, "bar", "tarball-content")
+				_, err = zipFile.Seek(0, io.SeekStart)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("pushes the tarball as an image to the registry", func() {
+				Expect(testErr).NotTo(HaveOccurred())
+
+				reader, err := imgClient.Download(ctx, creds, imgRef)
+				Expect(err).NotTo(HaveOccurred())
+				defer reader.Close()
+				Expect(readTarFile(reader, "bar")).To(Equal("tarball-content"))
+			})
+		})
+
 		When("zip input is not valid", func() {
 			BeforeEach(func() {
 				var err error
@@ -131,7 +158,12 @@ var _ = Describe("Client", func() {
 
 		BeforeEach(func() {
 			pushRef += "/with/labels"
-			pushImgWithLabelsAndPorts(pushRef, map[string]string{"foo": "bar"}, []string{"123", "456"})
+			pushImgWithConfig(pushRef, v1.Config{
+				Labels:       map[string]string{"foo": "bar"},
+				ExposedPorts: map[string]struct{}{"123": {}, "456": {}},
+				Entrypoint:   []string{"/bin/app"},
+				Cmd:          []string{"--serve"},
+			})
 		})
 
 		JustBeforeEach(func() {
@@ -141,6 +173,8 @@ var _ = Describe("Client", func() {
 		It("fetches the image config", func() {
 			Expect(config.Labels).To(Equal(map[string]string{"foo": "bar"}))
 			Expect(config.ExposedPorts).To(ConsistOf(int32(123), int32(456)))
+			Expect(config.Entrypoint).To(Equal([]string{"/bin/app"}))
+			Expect(config.Cmd).To(Equal([]string{"--serve"}))
 		})
 
 		When("the ref is invalid", func() {
@@ -148,8 +182,9 @@ var _ = Describe("Client", func() {
 				pushRef += "::ads"
 			})
 
-			It("fails", func() {
+			It("fails permanently", func() {
 				Expect(testErr).To(MatchError(ContainSubstring("error parsing repository reference")))
+				Expect(image.IsPermanentError(testErr)).To(BeTrue())
 			})
 		})
 
@@ -158,8 +193,33 @@ var _ = Describe("Client", func() {
 				creds.SecretNames = []string{"not-a-secret"}
 			})
 
-			It("fails to authenticate", func() {
+			It("fails to authenticate permanently", func() {
 				Expect(testErr).To(MatchError(ContainSubstring("UNAUTHORIZED")))
+				Expect(image.IsPermanentError(testErr)).To(BeTrue())
+			})
+		})
+
+		When("the tag does not exist", func() {
+			BeforeEach(func() {
+				pushRef += ":not-a-tag"
+			})
+
+			It("fails permanently", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("MANIFEST_UNKNOWN")))
+				Expect(image.IsPermanentError(testErr)).To(BeTrue())
+			})
+		})
+
+		When("the registry cannot be reached", func() {
+			BeforeEach(func() {
+				unreachableServer := httptest.NewServer(http.NotFoundHandler())
+				unreachableServer.Close()
+				pushRef = strings.Replace(unreachableServer.URL+"/foo/bar", "http://", "", 1)
+			})
+
+			It("fails temporarily", func() {
+				Expect(testErr).To(HaveOccurred())
+				Expect(image.IsPermanentError(testErr)).To(BeFalse())
 			})
 		})
 
@@ -195,6 +255,166 @@ var _ = Describe("Client", func() {
 		})
 	})
 
+	Describe("Copy", func() {
+		var (
+			srcRef  string
+			copyRef string
+		)
+
+		BeforeEach(func() {
+			var err error
+			srcRef, err = imgClient.Push(ctx, creds, pushRef, zipFile)
+			Expect(err).NotTo(HaveOccurred())
+
+			copyRef = strings.Replace(authRegistryServer.URL+"/foo/copy", "http://", "", 1)
+		})
+
+		JustBeforeEach(func() {
+			imgRef, testErr = imgClient.Copy(ctx, creds, srcRef, copyRef, "jim")
+		})
+
+		It("copies the image to the repository", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+			Expect(imgRef).To(HavePrefix(copyRef + "@"))
+			Expect(strings.Split(imgRef, "@")[1]).To(Equal(strings.Split(srcRef, "@")[1]))
+
+			_, err := imgClient.Config(ctx, creds, copyRef+":jim")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		When("the source image is deleted", func() {
+			BeforeEach(func() {
+				var err error
+				srcRef, err = imgClient.Push(ctx, creds, pushRef, otherZipFile, "source-package-guid")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			JustBeforeEach(func() {
+				Expect(testErr).NotTo(HaveOccurred())
+				Expect(imgClient.Delete(ctx, creds, srcRef, "source-package-guid")).To(Succeed())
+			})
+
+			It("still resolves the copy", func() {
+				_, err := imgClient.Config(ctx, creds, srcRef)
+				Expect(err).To(MatchError(ContainSubstring("MANIFEST_UNKNOWN")))
+
+				_, err = imgClient.Config(ctx, creds, imgRef)
+				Expect(err).NotTo(HaveOccurred())
+			})
+		})
+
+		When("the source image does not exist", func() {
+			BeforeEach(func() {
+				srcRef = pushRef + ":not-a-tag"
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("failed to get image")))
+			})
+		})
+
+		When("the repository is invalid", func() {
+			BeforeEach(func() {
+				copyRef += ":bar:baz"
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("error parsing repository reference")))
+			})
+		})
+	})
+
+	Describe("Exists", func() {
+		var exists bool
+
+		BeforeEach(func() {
+			var err error
+			imgRef, err = imgClient.Push(ctx, creds, pushRef, zipFile, "jim")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			exists, testErr = imgClient.Exists(ctx, creds, imgRef)
+		})
+
+		It("finds the image", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+			Expect(exists).To(BeTrue())
+		})
+
+		When("the tag does not exist", func() {
+			BeforeEach(func() {
+				imgRef = pushRef + ":not-a-tag"
+			})
+
+			It("does not find the image", func() {
+				Expect(testErr).NotTo(HaveOccurred())
+				Expect(exists).To(BeFalse())
+			})
+		})
+
+		When("the ref is invalid", func() {
+			BeforeEach(func() {
+				imgRef += "::ads"
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("error parsing repository reference")))
+			})
+		})
+
+		When("the secret doesn't exist", func() {
+			BeforeEach(func() {
+				creds.SecretNames = []string{"not-a-secret"}
+			})
+
+			It("fails to authenticate", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("UNAUTHORIZED")))
+			})
+		})
+	})
+
+	Describe("Download", func() {
+		var reader io.ReadCloser
+
+		BeforeEach(func() {
+			var err error
+			imgRef, err = imgClient.Push(ctx, creds, pushRef, zipFile)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		JustBeforeEach(func() {
+			reader, testErr = imgClient.Download(ctx, creds, imgRef)
+		})
+
+		It("returns the file system of the image", func() {
+			Expect(testErr).NotTo(HaveOccurred())
+			defer reader.Close()
+
+			Expect(readTarFile(reader, "foo")).To(Equal("hello\n"))
+		})
+
+		When("the ref is invalid", func() {
+			BeforeEach(func() {
+				imgRef += "::ads"
+			})
+
+			It("fails", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("error parsing repository reference")))
+			})
+		})
+
+		When("the secret doesn't exist", func() {
+			BeforeEach(func() {
+				creds.SecretNames = []string{"not-a-secret"}
+			})
+
+			It("fails to authenticate", func() {
+				Expect(testErr).To(MatchError(ContainSubstring("UNAUTHORIZED")))
+			})
+		})
+	})
+
 	Describe("Delete", func() {
 		var tagsToDelete []string
 
@@ -373,11 +593,15 @@ func pushImgWithLabelsAndPorts(repoRef string, labels map[string]string, ports [
 		portsMap[port] = struct{}{}
 	}
 
+	pushImgWithConfig(repoRef, v1.Config{
+		Labels:       labels,
+		ExposedPorts: portsMap,
+	})
+}
+
+func pushImgWithConfig(repoRef string, config v1.Config) {
 	image, err := mutate.ConfigFile(empty.Image, &v1.ConfigFile{
-		Config: v1.Config{
-			Labels:       labels,
-			ExposedPorts: portsMap,
-		},
+		Config: config,
 	})
 	Expect(err).NotTo(HaveOccurred())
 
@@ -389,3 +613,26 @@ func pushImgWithLabelsAndPorts(repoRef string, labels map[string]string, ports [
 		Password: "password",
 	}))).To(Succeed())
 }
+
+func writeTgz(w io.Writer, fileName, content string) {
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+	Expect(tarWriter.WriteHeader(&tar.Header{Name: fileName, Mode: 0o644, Size: int64(len(content))})).To(Succeed())
+	_, err := tarWriter.Write([]byte(content))
+	Expect(err).NotTo(HaveOccurred())
+	Expect(tarWriter.Close()).To(Succeed())
+	Expect(gzipWriter.Close()).To(Succeed())
+}
+
+func readTarFile(reader io.Reader, fileName string) string {
+	tarReader := tar.NewReader(reader)
+	for {
+		header, err := tarReader.Next()
+		Expect(err).NotTo(HaveOccurred())
+		if strings.TrimPrefix(header.Name, "/") == fileName {
+			content, err := io.ReadAll(tarReader)
+			Expect(err).NotTo(HaveOccurred())
+			return string(content)
+		}
+	}
+}
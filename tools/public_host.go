@@ -0,0 +1,90 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+var nonPublicNetworks = []*net.IPNet{
+	mustParseCIDR("0.0.0.0/8"),
+	mustParseCIDR("100.64.0.0/10"),
+	mustParseCIDR("192.0.0.0/24"),
+	mustParseCIDR("198.18.0.0/15"),
+	mustParseCIDR("240.0.0.0/4"),
+}
+
+var clusterDomainSuffixes = []string{".localhost", ".local", ".internal", ".svc"}
+
+var ErrHostNotPublic = errors.New("host is not public")
+
+// ResolvePublicHost resolves the host to its addresses. It fails with
+// ErrHostNotPublic when the host is in the cluster domain or any of its
+// addresses is loopback, link-local, private or otherwise not public, as
+// tenants must not make korifi send traffic into the cluster network
+func ResolvePublicHost(ctx context.Context, host string) ([]net.IP, error) {
+	if IsClusterHost(host) {
+		return nil, fmt.Errorf("%w: %q is in the cluster domain", ErrHostNotPublic, host)
+	}
+
+	ips, err := net.DefaultResolver.LookupIP(ctx, "ip", host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve host %q: %w", host, err)
+	}
+
+	for _, ip := range ips {
+		if !IsPublicIP(ip) {
+			return nil, fmt.Errorf("%w: %q resolves to %s", ErrHostNotPublic, host, ip)
+		}
+	}
+
+	return ips, nil
+}
+
+// IsClusterHost tells whether the host name can only be resolved within the
+// cluster, i.e. it is a single label or in a local or cluster domain
+func IsClusterHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if net.ParseIP(host) != nil {
+		return false
+	}
+
+	if host == "localhost" || !strings.Contains(host, ".") {
+		return true
+	}
+
+	for _, suffix := range clusterDomainSuffixes {
+		if strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsPublicIP tells whether the address is a global unicast address outside of
+// the private, shared and reserved networks
+func IsPublicIP(ip net.IP) bool {
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() {
+		return false
+	}
+
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, network, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+
+	return network
+}
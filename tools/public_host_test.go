@@ -0,0 +1,52 @@
+package tools_test
+
+import (
+	"context"
+	"net"
+
+	"code.cloudfoundry.org/korifi/tools"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ResolvePublicHost", func() {
+	It("resolves public addresses to themselves", func() {
+		ips, err := tools.ResolvePublicHost(context.Background(), "1.2.3.4")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ips).To(HaveLen(1))
+		Expect(ips[0].String()).To(Equal("1.2.3.4"))
+	})
+
+	DescribeTable("rejects non-public hosts",
+		func(host string) {
+			_, err := tools.ResolvePublicHost(context.Background(), host)
+			Expect(err).To(MatchError(tools.ErrHostNotPublic))
+		},
+		Entry("localhost", "localhost"),
+		Entry("a loopback address", "127.0.0.1"),
+		Entry("an ipv6 loopback address", "::1"),
+		Entry("a link-local address", "169.254.169.254"),
+		Entry("a private address", "10.96.0.1"),
+		Entry("a private ipv6 address", "fd00::1"),
+		Entry("a shared address", "100.64.0.1"),
+		Entry("an unspecified address", "0.0.0.0"),
+		Entry("a single label host", "kubernetes"),
+		Entry("a service host", "kubernetes.default.svc"),
+		Entry("a cluster domain host", "kubernetes.default.svc.cluster.local."),
+	)
+})
+
+var _ = Describe("IsPublicIP", func() {
+	It("accepts global unicast addresses", func() {
+		Expect(tools.IsPublicIP(net.ParseIP("8.8.8.8"))).To(BeTrue())
+		Expect(tools.IsPublicIP(net.ParseIP("2001:4860:4860::8888"))).To(BeTrue())
+	})
+
+	It("rejects multicast addresses", func() {
+		Expect(tools.IsPublicIP(net.ParseIP("224.0.0.1"))).To(BeFalse())
+	})
+
+	It("rejects ipv4-mapped private addresses", func() {
+		Expect(tools.IsPublicIP(net.ParseIP("::ffff:192.168.0.1"))).To(BeFalse())
+	})
+})